@@ -0,0 +1,236 @@
+package main
+
+// Handlers for managing API tokens and for the token authenticated API used
+// by amcli and other scripts.
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go.skia.org/infra/am/go/apitoken"
+	"go.skia.org/infra/am/go/audit"
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/note"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/am/go/types"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/human"
+	"go.skia.org/infra/go/sklog"
+)
+
+const (
+	// apiPrefix is the path prefix of all the token authenticated endpoints.
+	// Requests to these endpoints bypass the login middleware.
+	apiPrefix = "/api/"
+
+	bearerPrefix = "Bearer "
+)
+
+// tokenHandlerFunc is an http.HandlerFunc which is also passed the
+// validated API token of the request.
+type tokenHandlerFunc func(w http.ResponseWriter, r *http.Request, token *apitoken.Token)
+
+// tokenAuth returns an http.HandlerFunc which only calls h if the request
+// carries a valid API token which grants the given scope.
+func (srv *server) tokenAuth(scope apitoken.Scope, h tokenHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authHeader, bearerPrefix) {
+			http.Error(w, "An API token is required.", http.StatusUnauthorized)
+			return
+		}
+		token, err := srv.tokenStore.Validate(r.Context(), strings.TrimPrefix(authHeader, bearerPrefix))
+		if err == apitoken.ErrInvalidToken {
+			http.Error(w, "Invalid or expired API token.", http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			httputils.ReportError(w, err, "Failed to validate API token.", http.StatusInternalServerError)
+			return
+		}
+		if !token.HasScope(scope) {
+			http.Error(w, "The API token does not grant the scope "+string(scope)+".", http.StatusForbidden)
+			return
+		}
+		h(w, r, token)
+	}
+}
+
+// skipForAPI returns a middleware which applies m to all requests except
+// those to the token authenticated API.
+func skipForAPI(m func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		wrapped := m(h)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, apiPrefix) {
+				h.ServeHTTP(w, r)
+				return
+			}
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (srv *server) apiTokensHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	tokens, err := srv.tokenStore.List(r.Context(), srv.user(r))
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load API tokens.", http.StatusInternalServerError)
+		return
+	}
+	if tokens == nil {
+		tokens = []*apitoken.Token{}
+	}
+	if err := json.NewEncoder(w).Encode(tokens); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+func (srv *server) createAPITokenHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var req types.CreateAPITokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode API token creation request.", http.StatusBadRequest)
+		return
+	}
+	duration, err := human.ParseDuration(req.Duration)
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid duration.", http.StatusBadRequest)
+		return
+	}
+	audit.Log(r, "create-api-token", req, srv.alogin)
+	token, tokenString, err := srv.tokenStore.Create(r.Context(), srv.user(r), req.Description, req.Scopes, duration)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to create API token.", http.StatusBadRequest)
+		return
+	}
+	resp := types.CreateAPITokenResponse{
+		Token:       token,
+		TokenString: tokenString,
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+func (srv *server) revokeAPITokenHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var req types.RevokeAPITokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode API token revocation request.", http.StatusBadRequest)
+		return
+	}
+	audit.Log(r, "revoke-api-token", req, srv.alogin)
+	if err := srv.tokenStore.Revoke(r.Context(), srv.user(r), req.Key); err != nil {
+		httputils.ReportError(w, err, "Failed to revoke API token.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(req); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+func (srv *server) apiIncidentsHandler(w http.ResponseWriter, r *http.Request, _ *apitoken.Token) {
+	w.Header().Set("Content-Type", "application/json")
+	ins, err := srv.incidentStore.GetAll()
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load incidents.", http.StatusInternalServerError)
+		return
+	}
+	if ins == nil {
+		ins = []incident.Incident{}
+	}
+	if err := json.NewEncoder(w).Encode(ins); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+func (srv *server) apiAckIncidentHandler(w http.ResponseWriter, r *http.Request, token *apitoken.Token) {
+	w.Header().Set("Content-Type", "application/json")
+	var req types.AckIncidentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode ack request.", http.StatusBadRequest)
+		return
+	}
+	audit.LogWithUser(r, token.User, "take", req)
+	in, err := srv.incidentStore.Assign(req.Key, token.User)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to assign.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(in); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+func (srv *server) apiSilencesHandler(w http.ResponseWriter, r *http.Request, _ *apitoken.Token) {
+	w.Header().Set("Content-Type", "application/json")
+	silences, err := srv.silenceStore.GetAll()
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load silences.", http.StatusInternalServerError)
+		return
+	}
+	if silences == nil {
+		silences = []silence.Silence{}
+	}
+	if err := json.NewEncoder(w).Encode(silences); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+func (srv *server) apiCreateSilenceHandler(w http.ResponseWriter, r *http.Request, token *apitoken.Token) {
+	w.Header().Set("Content-Type", "application/json")
+	var req types.CreateSilenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode silence creation request.", http.StatusBadRequest)
+		return
+	}
+	if len(req.ParamSet) == 0 {
+		http.Error(w, "A silence must match at least one param.", http.StatusBadRequest)
+		return
+	}
+	s := silence.New(token.User)
+	s.ParamSet = req.ParamSet
+	if req.Duration != "" {
+		s.Duration = req.Duration
+	}
+	if req.Note != "" {
+		s.Notes = append(s.Notes, note.Note{
+			Text:   req.Note,
+			Author: token.User,
+			TS:     s.Created,
+		})
+	}
+	if err := s.ValidateRegexes(); err != nil {
+		httputils.ReportError(w, err, "Silence has invalid regex.", http.StatusBadRequest)
+		return
+	}
+
+	audit.LogWithUser(r, token.User, "create-silence", s)
+	created, err := srv.silenceStore.Put(s)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to create silence.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+func (srv *server) apiArchiveSilenceHandler(w http.ResponseWriter, r *http.Request, token *apitoken.Token) {
+	w.Header().Set("Content-Type", "application/json")
+	var req types.ArchiveSilenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode silence archive request.", http.StatusBadRequest)
+		return
+	}
+	audit.LogWithUser(r, token.User, "archive-silence", req)
+	s, err := srv.silenceStore.Archive(req.Key)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to archive silence.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+// Handlers for uploading, downloading and deleting attachments of incidents
+// and silences.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"cloud.google.com/go/datastore"
+
+	"go.skia.org/infra/am/go/attachment"
+	"go.skia.org/infra/am/go/audit"
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+const (
+	// attachmentFormField is the multipart form field which holds the file
+	// being uploaded.
+	attachmentFormField = "file"
+
+	// maxAttachmentRequestSize leaves some room for the other form fields on
+	// top of the maximum size of the attachment itself.
+	maxAttachmentRequestSize = attachment.MaxSize + 64*1024
+
+	// purgePeriod is how often old incidents are purged.
+	purgePeriod = 24 * time.Hour
+)
+
+type delAttachmentRequest struct {
+	Key string `json:"key"`
+	ID  string `json:"id"`
+}
+
+// uploadAttachment stores the file in the multipart form of the request as an
+// attachment of the incident or silence given by the "key" form field, and
+// then calls add to record it. The uploaded file is removed again if add
+// fails.
+func (srv *server) uploadAttachment(w http.ResponseWriter, r *http.Request, action string, add func(key string, a attachment.Attachment) (interface{}, error)) {
+	w.Header().Set("Content-Type", "application/json")
+	if srv.attachmentStore == nil {
+		httputils.ReportError(w, nil, "Attachments are not enabled.", http.StatusNotFound)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentRequestSize)
+	if err := r.ParseMultipartForm(maxAttachmentRequestSize); err != nil {
+		httputils.ReportError(w, err, "Failed to parse attachment upload. Attachments must not be larger than 10MB.", http.StatusBadRequest)
+		return
+	}
+	key := r.FormValue("key")
+	f, header, err := r.FormFile(attachmentFormField)
+	if err != nil {
+		httputils.ReportError(w, err, "Missing attachment file.", http.StatusBadRequest)
+		return
+	}
+	defer f.Close()
+
+	ctx := r.Context()
+	a, err := srv.attachmentStore.Upload(ctx, key, header.Filename, header.Header.Get("Content-Type"), srv.user(r), f)
+	if errors.Is(err, attachment.ErrTooLarge) {
+		httputils.ReportError(w, err, "Attachments must not be larger than 10MB.", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		httputils.ReportError(w, err, "Failed to store attachment.", http.StatusInternalServerError)
+		return
+	}
+	audit.Log(r, action, map[string]interface{}{"key": key, "attachment": a}, srv.alogin)
+
+	ret, err := add(key, *a)
+	if err != nil {
+		if err := srv.attachmentStore.Delete(ctx, key, a.ID); err != nil {
+			sklog.Errorf("Failed to remove orphaned attachment: %s", err)
+		}
+		httputils.ReportError(w, err, "Failed to add attachment.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(ret); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+// deleteAttachment removes the attachment given in the request by calling
+// del, and then removes its contents.
+func (srv *server) deleteAttachment(w http.ResponseWriter, r *http.Request, action string, del func(key, id string) (interface{}, error)) {
+	w.Header().Set("Content-Type", "application/json")
+	if srv.attachmentStore == nil {
+		httputils.ReportError(w, nil, "Attachments are not enabled.", http.StatusNotFound)
+		return
+	}
+	var req delAttachmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode delete attachment request.", http.StatusBadRequest)
+		return
+	}
+	audit.Log(r, action, req, srv.alogin)
+	ret, err := del(req.Key, req.ID)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to delete attachment.", http.StatusInternalServerError)
+		return
+	}
+	if err := srv.attachmentStore.Delete(r.Context(), req.Key, req.ID); err != nil {
+		sklog.Errorf("Failed to delete attachment contents: %s", err)
+	}
+	if err := json.NewEncoder(w).Encode(ret); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+func (srv *server) addAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	srv.uploadAttachment(w, r, "add-attachment", func(key string, a attachment.Attachment) (interface{}, error) {
+		return srv.incidentStore.AddAttachment(key, a)
+	})
+}
+
+func (srv *server) addSilenceAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	srv.uploadAttachment(w, r, "add-silence-attachment", func(key string, a attachment.Attachment) (interface{}, error) {
+		return srv.silenceStore.AddAttachment(key, a)
+	})
+}
+
+func (srv *server) delAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	srv.deleteAttachment(w, r, "del-attachment", func(key, id string) (interface{}, error) {
+		return srv.incidentStore.DeleteAttachment(key, id)
+	})
+}
+
+func (srv *server) delSilenceAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	srv.deleteAttachment(w, r, "del-silence-attachment", func(key, id string) (interface{}, error) {
+		return srv.silenceStore.DeleteAttachment(key, id)
+	})
+}
+
+// attachmentsOf returns the attachments of the incident or silence with the
+// given encoded key.
+func (srv *server) attachmentsOf(key string) ([]attachment.Attachment, error) {
+	k, err := datastore.DecodeKey(key)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	switch ds.Kind(k.Kind) {
+	case ds.INCIDENT_AM:
+		in, err := srv.incidentStore.Get(key)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		return in.Attachments, nil
+	case ds.SILENCE_AM:
+		sil, err := srv.silenceStore.Get(key)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		return sil.Attachments, nil
+	default:
+		return nil, skerr.Fmt("key %q is not an incident or silence", key)
+	}
+}
+
+// attachmentHandler redirects to a signed URL for the attachment given by the
+// "key" and "id" query parameters. The attachment must be listed in the
+// metadata of the incident or silence, so that only attachments which are
+// still attached can be downloaded.
+func (srv *server) attachmentHandler(w http.ResponseWriter, r *http.Request) {
+	if srv.attachmentStore == nil {
+		httputils.ReportError(w, nil, "Attachments are not enabled.", http.StatusNotFound)
+		return
+	}
+	key := r.FormValue("key")
+	id := r.FormValue("id")
+	if key == "" || id == "" {
+		httputils.ReportError(w, nil, "The key and id parameters are required.", http.StatusBadRequest)
+		return
+	}
+	attachments, err := srv.attachmentsOf(key)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to find attachment.", http.StatusNotFound)
+		return
+	}
+	if attachment.Find(attachments, id) == nil {
+		httputils.ReportError(w, nil, "Failed to find attachment.", http.StatusNotFound)
+		return
+	}
+	u, err := srv.attachmentStore.SignedURL(r.Context(), key, id)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to find attachment.", http.StatusNotFound)
+		return
+	}
+	http.Redirect(w, r, u, http.StatusFound)
+}
+
+// startPurgingIncidents periodically deletes archived incidents which were
+// last seen longer ago than retention, along with their attachments.
+func (srv *server) startPurgingIncidents(ctx context.Context, retention time.Duration) {
+	go func() {
+		for range time.Tick(purgePeriod) {
+			purged, err := srv.incidentStore.Purge(retention)
+			if err != nil {
+				sklog.Errorf("Failed to purge incidents: %s", err)
+				continue
+			}
+			sklog.Infof("Purged %d incidents.", len(purged))
+			if srv.attachmentStore == nil {
+				continue
+			}
+			for _, in := range purged {
+				if len(in.Attachments) == 0 {
+					continue
+				}
+				if err := srv.attachmentStore.DeleteAll(ctx, in.Key); err != nil {
+					sklog.Errorf("Failed to delete attachments of purged incident %s: %s", in.Key, err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,100 @@
+package main
+
+// Ingestion of deployment events, which archive the incidents that are
+// resolved by the deployment according to the deployment rules.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+
+	"go.skia.org/infra/am/go/deployment"
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/note"
+	"go.skia.org/infra/am/go/types"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/pubsub/sub"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// handleDeployment archives the incidents resolved by the given deployment and
+// returns them.
+func (srv *server) handleDeployment(ev types.DeploymentEvent) ([]incident.Incident, error) {
+	if ev.Source == "" || ev.App == "" {
+		return nil, skerr.Fmt("A deployment event must have a source and an app.")
+	}
+	if ev.Timestamp == 0 {
+		ev.Timestamp = time.Now().Unix()
+	}
+	ins, err := srv.incidentStore.GetAll()
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to load incidents.")
+	}
+	archived := []incident.Incident{}
+	for _, in := range deployment.Resolved(srv.deploymentRules, ev, ins) {
+		updated, err := srv.incidentStore.ArchiveWithNote(in.Key, note.Note{
+			Text:   deployment.Note(ev),
+			Author: ev.Source,
+			TS:     time.Now().Unix(),
+		})
+		if err != nil {
+			return archived, skerr.Wrapf(err, "Failed to archive incident %q.", in.ID)
+		}
+		archived = append(archived, *updated)
+	}
+	sklog.Infof("Deployment of %s by %s archived %d incidents.", ev.App, ev.Source, len(archived))
+	return archived, nil
+}
+
+func (srv *server) deploymentHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var ev types.DeploymentEvent
+	if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+		httputils.ReportError(w, err, "Failed to decode deployment event.", http.StatusBadRequest)
+		return
+	}
+	if ev.Source == "" || ev.App == "" {
+		http.Error(w, "A deployment event must have a source and an app.", http.StatusBadRequest)
+		return
+	}
+	archived, err := srv.handleDeployment(ev)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to process deployment event.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(types.DeploymentResponse{Archived: archived}); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+// startDeploymentSubscription processes the deployment events published to
+// the given PubSub topic.
+func (srv *server) startDeploymentSubscription(ctx context.Context, local bool, project, topic string) error {
+	s, err := sub.New(ctx, local, project, topic, 1)
+	if err != nil {
+		return skerr.Wrapf(err, "Failed to create deployment subscription.")
+	}
+	go func() {
+		for {
+			err := s.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+				msg.Ack()
+				var ev types.DeploymentEvent
+				if err := json.Unmarshal(msg.Data, &ev); err != nil {
+					sklog.Errorf("Failed to decode deployment event: %s", err)
+					return
+				}
+				if _, err := srv.handleDeployment(ev); err != nil {
+					sklog.Errorf("Failed to process deployment event: %s", err)
+				}
+			})
+			if err != nil {
+				sklog.Errorf("Failed receiving deployment pubsub message: %s", err)
+			}
+		}
+	}()
+	return nil
+}
@@ -12,12 +12,16 @@ import (
 	"time"
 
 	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/storage"
 	"github.com/go-chi/chi/v5"
 	"github.com/unrolled/secure"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 
+	"go.skia.org/infra/am/go/apitoken"
+	"go.skia.org/infra/am/go/attachment"
 	"go.skia.org/infra/am/go/audit"
+	"go.skia.org/infra/am/go/deployment"
 	"go.skia.org/infra/am/go/incident"
 	"go.skia.org/infra/am/go/note"
 	"go.skia.org/infra/am/go/reminder"
@@ -31,6 +35,8 @@ import (
 	"go.skia.org/infra/go/auth"
 	"go.skia.org/infra/go/baseapp"
 	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/ds/backup"
+	"go.skia.org/infra/go/gcs/gcsclient"
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/pubsub/sub"
@@ -49,6 +55,16 @@ var (
 	project      = flag.String("project", "skia-public", "The Google Cloud project name.")
 
 	silenceRecentlyExpiredDuration = flag.Duration("recently_expired_duration", 2*time.Hour, "Incidents with silences that recently expired within this duration are shown with an icon.")
+
+	backupBucket    = flag.String("backup_bucket", "", "The GCS bucket to back up the Cloud Datastore namespace to. Backups are disabled if empty.")
+	backupPeriod    = flag.Duration("backup_period", 24*time.Hour, "How often to back up the Cloud Datastore namespace.")
+	backupRetention = flag.Duration("backup_retention", 30*24*time.Hour, "How long to keep backups of the Cloud Datastore namespace.")
+
+	attachmentBucket  = flag.String("attachment_bucket", "", "The GCS bucket to store attachments of incidents and silences in. Attachments are disabled if empty.")
+	incidentRetention = flag.Duration("incident_retention", 0, "How long to keep archived incidents, and their attachments, after they were last seen. Incidents are kept forever if 0.")
+
+	deploymentTopic = flag.String("deployment_topic", "", "The PubSub topic to receive deployment events from, in addition to the internal /_/deployment endpoint. Disabled if empty.")
+	deploymentRules = flag.String("deployment_rules", "", "A JSON file with the rules which decide which incidents are archived by a deployment. If empty, incidents whose app param is the deployed app are archived.")
 )
 
 const (
@@ -65,11 +81,14 @@ const (
 
 // server is the state of the server.
 type server struct {
-	incidentStore *incident.Store
-	silenceStore  *silence.Store
-	templates     *template.Template
-	assign        allowed.Allow // A list of people that incidents can be assigned to.
-	alogin        *proxylogin.ProxyLogin
+	incidentStore   *incident.Store
+	silenceStore    *silence.Store
+	tokenStore      *apitoken.Store
+	attachmentStore *attachment.Store // Nil if attachments are disabled.
+	deploymentRules []deployment.Rule
+	templates       *template.Template
+	assign          allowed.Allow // A list of people that incidents can be assigned to.
+	alogin          *proxylogin.ProxyLogin
 }
 
 // See baseapp.Constructor.
@@ -77,7 +96,7 @@ func New() (baseapp.App, error) {
 	var assign allowed.Allow
 	ctx := context.Background()
 
-	ts, err := google.DefaultTokenSource(ctx, pubsub.ScopePubSub, auth.ScopeUserinfoEmail, "https://www.googleapis.com/auth/datastore")
+	ts, err := google.DefaultTokenSource(ctx, pubsub.ScopePubSub, auth.ScopeUserinfoEmail, "https://www.googleapis.com/auth/datastore", storage.ScopeReadWrite)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +120,11 @@ func New() (baseapp.App, error) {
 	if err := ds.InitWithOpt(*project, *namespace, option.WithTokenSource(ts)); err != nil {
 		return nil, fmt.Errorf("Failed to init Cloud Datastore: %s", err)
 	}
+	if *backupBucket != "" {
+		if err := backup.StartForNamespace(ctx, *project, *namespace, *backupBucket, *backupPeriod, *backupRetention, option.WithTokenSource(ts)); err != nil {
+			return nil, skerr.Wrapf(err, "Failed to start Cloud Datastore backups.")
+		}
+	}
 
 	sub, err := sub.New(ctx, *baseapp.Local, *project, alerts.TOPIC, numPubSubReceiverGoRoutines)
 	if err != nil {
@@ -110,9 +134,30 @@ func New() (baseapp.App, error) {
 	srv := &server{
 		incidentStore: incident.NewStore(ds.DS, []string{"kubernetes_pod_name", "instance", "pod_template_hash", "pod", "exported_pod", "uid"}),
 		silenceStore:  silence.NewStore(ds.DS),
+		tokenStore:    apitoken.NewStore(ds.DS),
 		assign:        assign,
 		alogin:        proxylogin.NewWithDefaults(),
 	}
+	if *attachmentBucket != "" {
+		storageClient, err := storage.NewClient(ctx, option.WithTokenSource(ts))
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Failed to create GCS client.")
+		}
+		srv.attachmentStore = attachment.NewStore(gcsclient.New(storageClient, *attachmentBucket), attachment.NewURLSigner(storageClient.Bucket(*attachmentBucket)))
+	}
+	if *deploymentRules != "" {
+		srv.deploymentRules, err = deployment.ReadRules(*deploymentRules)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+	} else {
+		srv.deploymentRules = deployment.DefaultRules()
+	}
+	if *deploymentTopic != "" {
+		if err := srv.startDeploymentSubscription(ctx, *baseapp.Local, *project, *deploymentTopic); err != nil {
+			return nil, err
+		}
+	}
 	srv.loadTemplates()
 
 	// Start goroutine to send reminders to active alert owners.
@@ -126,8 +171,8 @@ func New() (baseapp.App, error) {
 		for {
 			err := sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
 				msg.Ack()
-				var m map[string]string
-				if err := json.Unmarshal(msg.Data, &m); err != nil {
+				m, err := alerts.ParseMessage(msg.Data)
+				if err != nil {
 					sklog.Error(err)
 					return
 				}
@@ -171,6 +216,10 @@ func New() (baseapp.App, error) {
 		}
 	}()
 
+	if *incidentRetention > 0 {
+		srv.startPurgingIncidents(ctx, *incidentRetention)
+	}
+
 	srv.startInternalServer()
 
 	return srv, nil
@@ -624,6 +673,11 @@ func (srv *server) deleteSilenceHandler(w http.ResponseWriter, r *http.Request)
 		httputils.ReportError(w, err, "Failed to delete silence.", http.StatusInternalServerError)
 		return
 	}
+	if srv.attachmentStore != nil {
+		if err := srv.attachmentStore.DeleteAll(r.Context(), sil.Key); err != nil {
+			sklog.Errorf("Failed to delete attachments of silence %s: %s", sil.Key, err)
+		}
+	}
 	if err := json.NewEncoder(w).Encode(sil); err != nil {
 		sklog.Errorf("Failed to send response: %s", err)
 	}
@@ -649,15 +703,21 @@ func (srv *server) AddHandlers(r chi.Router) {
 	r.Get("/_/new_silence", srv.newSilenceHandler)
 	r.Get("/_/recent_incidents", srv.recentIncidentsHandler)
 	r.Get("/_/silences", srv.silencesHandler)
+	r.Get("/_/api_tokens", srv.apiTokensHandler)
+	r.Get("/_/attachment", srv.attachmentHandler)
 
 	// POSTs
+	r.Post("/_/add_attachment", srv.addAttachmentHandler)
 	r.Post("/_/add_note", srv.addNoteHandler)
+	r.Post("/_/add_silence_attachment", srv.addSilenceAttachmentHandler)
 	r.Post("/_/add_silence_note", srv.addSilenceNoteHandler)
 	r.Post("/_/archive_silence", srv.archiveSilenceHandler)
 	r.Post("/_/assign", srv.assignHandler)
 	r.Post("/_/assign_multiple", srv.assignMultipleHandler)
 	r.Post("/_/audit_logs", srv.auditLogsHandler)
+	r.Post("/_/del_attachment", srv.delAttachmentHandler)
 	r.Post("/_/del_note", srv.delNoteHandler)
+	r.Post("/_/del_silence_attachment", srv.delSilenceAttachmentHandler)
 	r.Post("/_/del_silence_note", srv.delSilenceNoteHandler)
 	r.Post("/_/del_silence", srv.deleteSilenceHandler)
 	r.Post("/_/reactivate_silence", srv.reactivateSilenceHandler)
@@ -665,13 +725,23 @@ func (srv *server) AddHandlers(r chi.Router) {
 	r.Post("/_/take", srv.takeHandler)
 	r.Post("/_/stats", srv.statsHandler)
 	r.Post("/_/incidents_in_range", srv.incidentsInRangeHandler)
+	r.Post("/_/api_tokens/create", srv.createAPITokenHandler)
+	r.Post("/_/api_tokens/revoke", srv.revokeAPITokenHandler)
+
+	// API token authenticated endpoints, e.g. for amcli.
+	r.Get("/api/v1/incidents", srv.tokenAuth(apitoken.IncidentsRead, srv.apiIncidentsHandler))
+	r.Post("/api/v1/incidents/ack", srv.tokenAuth(apitoken.IncidentsWrite, srv.apiAckIncidentHandler))
+	r.Get("/api/v1/silences", srv.tokenAuth(apitoken.SilencesRead, srv.apiSilencesHandler))
+	r.Post("/api/v1/silences/create", srv.tokenAuth(apitoken.SilencesWrite, srv.apiCreateSilenceHandler))
+	r.Post("/api/v1/silences/archive", srv.tokenAuth(apitoken.SilencesWrite, srv.apiArchiveSilenceHandler))
 }
 
 // See baseapp.App.
 func (srv *server) AddMiddleware() []func(http.Handler) http.Handler {
 	ret := []func(http.Handler) http.Handler{}
 	if !*baseapp.Local {
-		ret = append(ret, alogin.ForceRoleMiddleware(srv.alogin, roles.Viewer))
+		// The API endpoints are authenticated with API tokens instead.
+		ret = append(ret, skipForAPI(alogin.ForceRoleMiddleware(srv.alogin, roles.Viewer)))
 	}
 	return ret
 }
@@ -681,6 +751,9 @@ func (srv *server) startInternalServer() {
 	unprotected := chi.NewRouter()
 	unprotected.Get("/_/incidents", srv.incidentHandler)
 	unprotected.Get("/_/silences", srv.silencesHandler)
+	unprotected.Get("/_/rollers/{roller}/pause", srv.rollerPauseHandler)
+	unprotected.Post("/_/maintenance_silence", srv.maintenanceSilenceHandler)
+	unprotected.Post("/_/deployment", srv.deploymentHandler)
 	go func() {
 		sklog.Fatal(http.ListenAndServe(*internalPort, unprotected))
 	}()
@@ -0,0 +1,125 @@
+package main
+
+// Handlers for the internal maintenance API, which lets other services in the
+// cluster find out whether they have been paused by a silence, and create
+// silences for planned maintenance.
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"go.skia.org/infra/am/go/audit"
+	"go.skia.org/infra/am/go/note"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/am/go/types"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/human"
+	"go.skia.org/infra/go/sklog"
+)
+
+// defaultMaintenanceDuration is the duration of maintenance silences if the
+// request does not specify one.
+const defaultMaintenanceDuration = "1h"
+
+func (srv *server) rollerPauseHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	roller := chi.URLParam(r, "roller")
+	silences, err := srv.silenceStore.GetAll()
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load silences.", http.StatusInternalServerError)
+		return
+	}
+	resp := types.RollerPauseResponse{}
+	for i := range silences {
+		if silences[i].PausesRoller(roller) {
+			resp.Paused = true
+			resp.Silence = &silences[i]
+			break
+		}
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+func (srv *server) maintenanceSilenceHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var req types.MaintenanceSilenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode maintenance silence request.", http.StatusBadRequest)
+		return
+	}
+	if req.Source == "" {
+		http.Error(w, "A maintenance silence must have a source.", http.StatusBadRequest)
+		return
+	}
+	if len(req.ParamSet) == 0 {
+		http.Error(w, "A silence must match at least one param.", http.StatusBadRequest)
+		return
+	}
+	if req.Duration == "" {
+		req.Duration = defaultMaintenanceDuration
+	}
+	if _, err := human.ParseDuration(req.Duration); err != nil {
+		httputils.ReportError(w, err, "Invalid duration.", http.StatusBadRequest)
+		return
+	}
+	req.ParamSet.Normalize()
+
+	silences, err := srv.silenceStore.GetAll()
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load silences.", http.StatusInternalServerError)
+		return
+	}
+	// Services are expected to repeat the request for as long as the
+	// maintenance lasts, so extend an existing silence instead of creating a
+	// new one each time.
+	s := findMaintenanceSilence(silences, req)
+	if s == nil {
+		s = silence.New(req.Source)
+		s.ParamSet = req.ParamSet
+		if req.Reason != "" {
+			s.Notes = append(s.Notes, note.Note{
+				Text:   req.Reason,
+				Author: req.Source,
+				TS:     s.Created,
+			})
+		}
+		if err := s.ValidateRegexes(); err != nil {
+			httputils.ReportError(w, err, "Silence has invalid regex.", http.StatusBadRequest)
+			return
+		}
+		audit.LogWithUser(r, req.Source, "create-maintenance-silence", s)
+	} else {
+		s.Created = time.Now().Unix()
+		s.Updated = s.Created
+	}
+	s.Duration = req.Duration
+	saved, err := srv.silenceStore.Put(s)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to save silence.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(saved); err != nil {
+		sklog.Errorf("Failed to send response: %s", err)
+	}
+}
+
+// findMaintenanceSilence returns the active silence previously created for
+// the same source and params as the given request, or nil if there is none.
+func findMaintenanceSilence(silences []silence.Silence, req types.MaintenanceSilenceRequest) *silence.Silence {
+	for i := range silences {
+		s := &silences[i]
+		if !s.Active || s.User != req.Source {
+			continue
+		}
+		s.ParamSet.Normalize()
+		if s.ParamSet.Equal(req.ParamSet) {
+			return s
+		}
+	}
+	return nil
+}
@@ -1,12 +1,16 @@
 package alertclient
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 
 	"go.skia.org/infra/am/go/incident"
 	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/am/go/types"
 	"go.skia.org/infra/go/util"
 )
 
@@ -15,6 +19,10 @@ const (
 	// so https is not required.
 	API_INCIDENTS_PATTERN = "http://%s/_/incidents"
 	API_SILENCES_PATTERN  = "http://%s/_/silences"
+
+	API_ROLLER_PAUSE_PATTERN        = "http://%s/_/rollers/%s/pause"
+	API_MAINTENANCE_SILENCE_PATTERN = "http://%s/_/maintenance_silence"
+	API_DEPLOYMENT_PATTERN          = "http://%s/_/deployment"
 )
 
 type APIClient interface {
@@ -22,6 +30,15 @@ type APIClient interface {
 	GetAlerts() ([]incident.Incident, error)
 	// GetSilences fetches all silences from the server and returns them in a slice.
 	GetSilences() ([]silence.Silence, error)
+	// GetRollerPause returns whether an active silence asks the given
+	// autoroller to pause.
+	GetRollerPause(roller string) (*types.RollerPauseResponse, error)
+	// CreateMaintenanceSilence creates a silence for planned maintenance, or
+	// extends the one previously created for the same source and params.
+	CreateMaintenanceSilence(req types.MaintenanceSilenceRequest) (*silence.Silence, error)
+	// ReportDeployment reports that an app was deployed, which archives the
+	// incidents resolved by the deployment.
+	ReportDeployment(ev types.DeploymentEvent) (*types.DeploymentResponse, error)
 }
 
 // apiclient fulfills the APIClient interface
@@ -34,6 +51,7 @@ type apiclient struct {
 // is a subset of http.Client and makes for easier mocking.
 type HTTPClient interface {
 	Get(url string) (*http.Response, error)
+	Post(url, contentType string, body io.Reader) (*http.Response, error)
 }
 
 // New creates a new APIClient with the given parameters.
@@ -77,3 +95,62 @@ func (a *apiclient) GetSilences() ([]silence.Silence, error) {
 	}
 	return silences, nil
 }
+
+// See the APIClient interface for a description of GetRollerPause
+func (a *apiclient) GetRollerPause(roller string) (*types.RollerPauseResponse, error) {
+	r, err := a.hc.Get(fmt.Sprintf(API_ROLLER_PAUSE_PATTERN, a.server, url.PathEscape(roller)))
+	if err != nil {
+		return nil, err
+	}
+	defer util.Close(r.Body)
+	if r.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP error %s", r.Status)
+	}
+	var resp types.RollerPauseResponse
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("Could not parse JSON: %s", err)
+	}
+	return &resp, nil
+}
+
+// See the APIClient interface for a description of CreateMaintenanceSilence
+func (a *apiclient) CreateMaintenanceSilence(req types.MaintenanceSilenceRequest) (*silence.Silence, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode request: %s", err)
+	}
+	r, err := a.hc.Post(fmt.Sprintf(API_MAINTENANCE_SILENCE_PATTERN, a.server), "application/json", bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer util.Close(r.Body)
+	if r.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP error %s", r.Status)
+	}
+	var s silence.Silence
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		return nil, fmt.Errorf("Could not parse JSON: %s", err)
+	}
+	return &s, nil
+}
+
+// See the APIClient interface for a description of ReportDeployment
+func (a *apiclient) ReportDeployment(ev types.DeploymentEvent) (*types.DeploymentResponse, error) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode request: %s", err)
+	}
+	r, err := a.hc.Post(fmt.Sprintf(API_DEPLOYMENT_PATTERN, a.server), "application/json", bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer util.Close(r.Body)
+	if r.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP error %s", r.Status)
+	}
+	var resp types.DeploymentResponse
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("Could not parse JSON: %s", err)
+	}
+	return &resp, nil
+}
@@ -9,6 +9,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/am/go/types"
+	"go.skia.org/infra/go/paramtools"
 )
 
 func TestSunnyDayGetAlerts(t *testing.T) {
@@ -62,6 +65,89 @@ func TestSunnyDayGetSileces(t *testing.T) {
 	assert.Equal(t, []string{"BotUnemployed"}, silences[2].ParamSet["alertname"])
 }
 
+func TestGetRollerPause_Paused(t *testing.T) {
+	mc := &mockhttpclient{}
+	defer mc.AssertExpectations(t)
+	client := New(mc, "alert-manager:9000")
+
+	mockResponse := http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"paused": true, "silence": {"key": "abc", "active": true, "pause_roller": true, "param_set": {"roller": ["skia-autoroll"]}}}`)),
+	}
+	mc.On("Get", "http://alert-manager:9000/_/rollers/skia-autoroll/pause").Return(&mockResponse, nil)
+
+	resp, err := client.GetRollerPause("skia-autoroll")
+	require.NoError(t, err)
+	assert.True(t, resp.Paused)
+	assert.Equal(t, "abc", resp.Silence.Key)
+	assert.True(t, resp.Silence.PauseRoller)
+}
+
+func TestGetRollerPause_HTTPError_ReturnsError(t *testing.T) {
+	mc := &mockhttpclient{}
+	defer mc.AssertExpectations(t)
+	client := New(mc, "alert-manager:9000")
+
+	mockResponse := http.Response{
+		Status:     "500 Internal Server Error",
+		StatusCode: http.StatusInternalServerError,
+		Body:       io.NopCloser(bytes.NewBufferString("")),
+	}
+	mc.On("Get", "http://alert-manager:9000/_/rollers/skia-autoroll/pause").Return(&mockResponse, nil)
+
+	_, err := client.GetRollerPause("skia-autoroll")
+	require.Error(t, err)
+}
+
+func TestCreateMaintenanceSilence_PostsRequest(t *testing.T) {
+	mc := &mockhttpclient{}
+	defer mc.AssertExpectations(t)
+	client := New(mc, "alert-manager:9000")
+
+	mockResponse := http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"key": "abc", "active": true, "user": "k8s-checker", "param_set": {"node": ["node-1"]}, "duration": "1h"}`)),
+	}
+	mc.On("Post", "http://alert-manager:9000/_/maintenance_silence", "application/json",
+		`{"source":"k8s-checker","param_set":{"node":["node-1"]},"duration":"1h","reason":"Node maintenance."}`).Return(&mockResponse, nil)
+
+	s, err := client.CreateMaintenanceSilence(types.MaintenanceSilenceRequest{
+		Source:   "k8s-checker",
+		ParamSet: paramtools.ParamSet{"node": []string{"node-1"}},
+		Duration: "1h",
+		Reason:   "Node maintenance.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "abc", s.Key)
+	assert.Equal(t, "k8s-checker", s.User)
+}
+
+func TestReportDeployment_PostsEvent(t *testing.T) {
+	mc := &mockhttpclient{}
+	defer mc.AssertExpectations(t)
+	client := New(mc, "alert-manager:9000")
+
+	mockResponse := http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"archived": [{"key": "abc", "id": "123", "active": false}]}`)),
+	}
+	mc.On("Post", "http://alert-manager:9000/_/deployment", "application/json",
+		`{"source":"k8s-deployer","app":"perf","version":"abc123","url":"","timestamp":1000}`).Return(&mockResponse, nil)
+
+	resp, err := client.ReportDeployment(types.DeploymentEvent{
+		Source:    "k8s-deployer",
+		App:       "perf",
+		Version:   "abc123",
+		Timestamp: 1000,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Archived, 1)
+	assert.Equal(t, "abc", resp.Archived[0].Key)
+}
+
 type mockhttpclient struct {
 	mock.Mock
 }
@@ -71,6 +157,15 @@ func (m *mockhttpclient) Get(url string) (*http.Response, error) {
 	return args.Get(0).(*http.Response), args.Error(1)
 }
 
+func (m *mockhttpclient) Post(url, contentType string, body io.Reader) (*http.Response, error) {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(url, contentType, string(b))
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
 var INCIDENTS_RESPONSE = `[
   {
     "key": "hh",
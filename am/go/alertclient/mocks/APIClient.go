@@ -7,6 +7,8 @@ import (
 	incident "go.skia.org/infra/am/go/incident"
 
 	silence "go.skia.org/infra/am/go/silence"
+
+	types "go.skia.org/infra/am/go/types"
 )
 
 // APIClient is an autogenerated mock type for the APIClient type
@@ -14,6 +16,36 @@ type APIClient struct {
 	mock.Mock
 }
 
+// CreateMaintenanceSilence provides a mock function with given fields: req
+func (_m *APIClient) CreateMaintenanceSilence(req types.MaintenanceSilenceRequest) (*silence.Silence, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMaintenanceSilence")
+	}
+
+	var r0 *silence.Silence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(types.MaintenanceSilenceRequest) (*silence.Silence, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(types.MaintenanceSilenceRequest) *silence.Silence); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*silence.Silence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(types.MaintenanceSilenceRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAlerts provides a mock function with given fields:
 func (_m *APIClient) GetAlerts() ([]incident.Incident, error) {
 	ret := _m.Called()
@@ -44,6 +76,36 @@ func (_m *APIClient) GetAlerts() ([]incident.Incident, error) {
 	return r0, r1
 }
 
+// GetRollerPause provides a mock function with given fields: roller
+func (_m *APIClient) GetRollerPause(roller string) (*types.RollerPauseResponse, error) {
+	ret := _m.Called(roller)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRollerPause")
+	}
+
+	var r0 *types.RollerPauseResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*types.RollerPauseResponse, error)); ok {
+		return rf(roller)
+	}
+	if rf, ok := ret.Get(0).(func(string) *types.RollerPauseResponse); ok {
+		r0 = rf(roller)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RollerPauseResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(roller)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSilences provides a mock function with given fields:
 func (_m *APIClient) GetSilences() ([]silence.Silence, error) {
 	ret := _m.Called()
@@ -74,6 +136,36 @@ func (_m *APIClient) GetSilences() ([]silence.Silence, error) {
 	return r0, r1
 }
 
+// ReportDeployment provides a mock function with given fields: ev
+func (_m *APIClient) ReportDeployment(ev types.DeploymentEvent) (*types.DeploymentResponse, error) {
+	ret := _m.Called(ev)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportDeployment")
+	}
+
+	var r0 *types.DeploymentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(types.DeploymentEvent) (*types.DeploymentResponse, error)); ok {
+		return rf(ev)
+	}
+	if rf, ok := ret.Get(0).(func(types.DeploymentEvent) *types.DeploymentResponse); ok {
+		r0 = rf(ev)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DeploymentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(types.DeploymentEvent) error); ok {
+		r1 = rf(ev)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAPIClient creates a new instance of APIClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAPIClient(t interface {
@@ -0,0 +1,199 @@
+// amcli is a command line tool for listing and acknowledging alert-manager
+// incidents, and for creating and archiving silences, e.g. from maintenance
+// scripts.
+//
+// It authenticates with an API token, which can be created in the
+// alert-manager UI and is passed via --token or the AM_API_TOKEN environment
+// variable.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"go.skia.org/infra/am/go/types"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	flagHost     = "host"
+	flagToken    = "token"
+	flagDuration = "duration"
+	flagNote     = "note"
+
+	tokenEnvVar = "AM_API_TOKEN"
+)
+
+func main() {
+	app := &cli.App{
+		Name:        "amcli",
+		Description: `amcli lists and acknowledges alert-manager incidents and manages silences.`,
+		Usage:       "amcli <subcommand>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  flagHost,
+				Usage: "The alert-manager instance to talk to.",
+				Value: "https://am.skia.org",
+			},
+			&cli.StringFlag{
+				Name:    flagToken,
+				Usage:   "The API token to authenticate with.",
+				EnvVars: []string{tokenEnvVar},
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:        "incidents",
+				Description: "List the active incidents.",
+				Usage:       "incidents",
+				Action: func(ctx *cli.Context) error {
+					return newClient(ctx).do(ctx.Context, http.MethodGet, "/api/v1/incidents", nil, os.Stdout)
+				},
+			},
+			{
+				Name:        "ack",
+				Description: "Assign an incident to the owner of the API token.",
+				Usage:       "ack <incident key>",
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 1 {
+						return skerr.Fmt("exactly one incident key is required")
+					}
+					req := types.AckIncidentRequest{Key: ctx.Args().First()}
+					return newClient(ctx).do(ctx.Context, http.MethodPost, "/api/v1/incidents/ack", req, os.Stdout)
+				},
+			},
+			{
+				Name:        "silences",
+				Description: "List the active silences.",
+				Usage:       "silences",
+				Action: func(ctx *cli.Context) error {
+					return newClient(ctx).do(ctx.Context, http.MethodGet, "/api/v1/silences", nil, os.Stdout)
+				},
+			},
+			{
+				Name:        "silence",
+				Description: "Create a silence matching the given params, e.g. amcli silence --duration=1h alertname=BotMissing bot=skia-rpi-001",
+				Usage:       "silence <options> key=value [key=value...]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  flagDuration,
+						Usage: "How long the silence lasts, e.g. 2h or 1d.",
+						Value: "2h",
+					},
+					&cli.StringFlag{
+						Name:  flagNote,
+						Usage: "An optional note to attach to the silence.",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					ps, err := parseParamSet(ctx.Args().Slice())
+					if err != nil {
+						return err
+					}
+					req := types.CreateSilenceRequest{
+						ParamSet: ps,
+						Duration: ctx.String(flagDuration),
+						Note:     ctx.String(flagNote),
+					}
+					return newClient(ctx).do(ctx.Context, http.MethodPost, "/api/v1/silences/create", req, os.Stdout)
+				},
+			},
+			{
+				Name:        "unsilence",
+				Description: "Archive a silence.",
+				Usage:       "unsilence <silence key>",
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 1 {
+						return skerr.Fmt("exactly one silence key is required")
+					}
+					req := types.ArchiveSilenceRequest{Key: ctx.Args().First()}
+					return newClient(ctx).do(ctx.Context, http.MethodPost, "/api/v1/silences/archive", req, os.Stdout)
+				},
+			},
+		},
+	}
+	if err := app.RunContext(context.Background(), os.Args); err != nil {
+		sklog.Fatal(err)
+	}
+}
+
+// parseParamSet converts arguments of the form key=value into a ParamSet.
+// Repeating a key adds another value for it.
+func parseParamSet(args []string) (paramtools.ParamSet, error) {
+	if len(args) == 0 {
+		return nil, skerr.Fmt("at least one key=value param is required")
+	}
+	ps := paramtools.ParamSet{}
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" || value == "" {
+			return nil, skerr.Fmt("invalid param %q, expected key=value", arg)
+		}
+		ps.AddParams(paramtools.Params{key: value})
+	}
+	ps.Normalize()
+	return ps, nil
+}
+
+// client makes authenticated requests to the alert-manager API.
+type client struct {
+	hc    *http.Client
+	host  string
+	token string
+}
+
+func newClient(ctx *cli.Context) *client {
+	return &client{
+		hc:    httputils.DefaultClientConfig().WithoutRetries().WithDialTimeout(time.Minute).Client(),
+		host:  strings.TrimSuffix(ctx.String(flagHost), "/"),
+		token: ctx.String(flagToken),
+	}
+}
+
+// do sends body, if not nil, as JSON to the given path and copies the JSON
+// response to w.
+func (c *client) do(ctx context.Context, method, path string, body interface{}, w io.Writer) error {
+	if c.token == "" {
+		return skerr.Fmt("an API token is required, pass --%s or set %s", flagToken, tokenEnvVar)
+	}
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.host+path, reqBody)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return skerr.Wrapf(err, "requesting %s", path)
+	}
+	defer util.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return skerr.Fmt("%s failed with %s: %s", path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return skerr.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/am/go/types"
+	"go.skia.org/infra/go/paramtools"
+)
+
+func TestParseParamSet_ValidArgs_ReturnsParamSet(t *testing.T) {
+	ps, err := parseParamSet([]string{"alertname=BotMissing", "bot=skia-rpi-002", "bot=skia-rpi-001"})
+	require.NoError(t, err)
+	assert.Equal(t, paramtools.ParamSet{
+		"alertname": {"BotMissing"},
+		"bot":       {"skia-rpi-001", "skia-rpi-002"},
+	}, ps)
+}
+
+func TestParseParamSet_InvalidArgs_ReturnsError(t *testing.T) {
+	for _, args := range [][]string{nil, {"alertname"}, {"=BotMissing"}, {"alertname="}} {
+		_, err := parseParamSet(args)
+		assert.Error(t, err, "%v", args)
+	}
+}
+
+func TestClientDo_SendsTokenAndBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/silences/archive", r.URL.Path)
+		assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"key":"abc"}`, string(b))
+		_, _ = w.Write([]byte(`{"key":"abc","active":false}`))
+	}))
+	defer ts.Close()
+
+	c := &client{hc: ts.Client(), host: ts.URL, token: "my-token"}
+	var out bytes.Buffer
+	require.NoError(t, c.do(context.Background(), http.MethodPost, "/api/v1/silences/archive", types.ArchiveSilenceRequest{Key: "abc"}, &out))
+	assert.Equal(t, `{"key":"abc","active":false}`, out.String())
+}
+
+func TestClientDo_ErrorStatus_ReturnsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Invalid or expired API token.", http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	c := &client{hc: ts.Client(), host: ts.URL, token: "bad-token"}
+	err := c.do(context.Background(), http.MethodGet, "/api/v1/incidents", nil, io.Discard)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid or expired API token.")
+}
+
+func TestClientDo_NoToken_ReturnsError(t *testing.T) {
+	c := &client{hc: http.DefaultClient, host: "http://localhost"}
+	assert.Error(t, c.do(context.Background(), http.MethodGet, "/api/v1/incidents", nil, io.Discard))
+}
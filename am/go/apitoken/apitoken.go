@@ -0,0 +1,240 @@
+// Package apitoken issues and validates the API tokens which allow scripts,
+// such as amcli, to call the alert-manager API on behalf of a user.
+//
+// Each token is limited to a set of scopes and expires after a fixed
+// duration. Only a hash of the token's secret is stored, so the full token is
+// only available to the user when it is created.
+package apitoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/skerr"
+)
+
+// Scope limits what a token may be used for.
+type Scope string
+
+const (
+	// IncidentsRead allows listing incidents.
+	IncidentsRead Scope = "incidents:read"
+
+	// IncidentsWrite allows acknowledging incidents.
+	IncidentsWrite Scope = "incidents:write"
+
+	// SilencesRead allows listing silences.
+	SilencesRead Scope = "silences:read"
+
+	// SilencesWrite allows creating and archiving silences.
+	SilencesWrite Scope = "silences:write"
+)
+
+// AllScopes is the list of all valid scopes.
+var AllScopes = []Scope{IncidentsRead, IncidentsWrite, SilencesRead, SilencesWrite}
+
+const (
+	// MaxDuration is the longest time a token may be valid for.
+	MaxDuration = 30 * 24 * time.Hour
+
+	// secretNumBytes is the number of random bytes in a token secret.
+	secretNumBytes = 32
+
+	// separator separates the key from the secret in a token string. Encoded
+	// Datastore keys are URL safe base64, so never contain it.
+	separator = "."
+)
+
+var (
+	// ErrInvalidToken is returned by Store.Validate if the token is
+	// malformed, unknown, expired, or does not match the stored hash.
+	ErrInvalidToken = skerr.Fmt("invalid or expired API token")
+)
+
+// Token is an API token as stored in the Datastore.
+type Token struct {
+	Key          string   `json:"key" datastore:"-"`
+	User         string   `json:"user" datastore:"user"`
+	Description  string   `json:"description" datastore:"description,noindex"`
+	Scopes       []string `json:"scopes" datastore:"scopes,noindex"`
+	Created      int64    `json:"created" datastore:"created,noindex"`
+	Expires      int64    `json:"expires" datastore:"expires,noindex"`
+	HashedSecret string   `json:"-" datastore:"hashed_secret,noindex"`
+}
+
+// HasScope returns true if the token grants the given scope.
+func (t *Token) HasScope(scope Scope) bool {
+	for _, s := range t.Scopes {
+		if s == string(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired returns true if the token is no longer valid at the given time.
+func (t *Token) Expired(now time.Time) bool {
+	return !now.Before(time.Unix(t.Expires, 0))
+}
+
+// ValidateScopes returns an error if scopes is empty or contains an unknown
+// scope.
+func ValidateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return skerr.Fmt("at least one scope is required")
+	}
+	for _, s := range scopes {
+		valid := false
+		for _, known := range AllScopes {
+			if s == string(known) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return skerr.Fmt("unknown scope %q", s)
+		}
+	}
+	return nil
+}
+
+// hashSecret returns the hex encoded SHA-256 hash of the secret.
+func hashSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// splitToken splits a token string into the encoded Datastore key and the
+// secret.
+func splitToken(token string) (string, string, error) {
+	key, secret, ok := strings.Cut(token, separator)
+	if !ok || key == "" || secret == "" {
+		return "", "", ErrInvalidToken
+	}
+	return key, secret, nil
+}
+
+// Store saves and validates API tokens in Cloud Datastore.
+type Store struct {
+	ds *datastore.Client
+}
+
+// NewStore creates a new Store from the given Datastore client.
+func NewStore(ds *datastore.Client) *Store {
+	return &Store{
+		ds: ds,
+	}
+}
+
+// Create issues a new token for the given user which is valid for the given
+// duration. It returns the stored Token and the token string to be given to
+// the user, which is the only time the token string is available.
+func (s *Store) Create(ctx context.Context, user, description string, scopes []string, duration time.Duration) (*Token, string, error) {
+	if user == "" {
+		return nil, "", skerr.Fmt("a user is required")
+	}
+	if err := ValidateScopes(scopes); err != nil {
+		return nil, "", err
+	}
+	if duration <= 0 || duration > MaxDuration {
+		return nil, "", skerr.Fmt("duration must be positive and at most %s", MaxDuration)
+	}
+	b := make([]byte, secretNumBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", skerr.Wrapf(err, "generating secret")
+	}
+	secret := hex.EncodeToString(b)
+	now := time.Now()
+	sortedScopes := append([]string{}, scopes...)
+	sort.Strings(sortedScopes)
+	t := &Token{
+		User:         user,
+		Description:  description,
+		Scopes:       sortedScopes,
+		Created:      now.Unix(),
+		Expires:      now.Add(duration).Unix(),
+		HashedSecret: hashSecret(secret),
+	}
+	key, err := s.ds.Put(ctx, ds.NewKey(ds.APITOKEN_AM), t)
+	if err != nil {
+		return nil, "", skerr.Wrapf(err, "writing token")
+	}
+	t.Key = key.Encode()
+	return t, t.Key + separator + secret, nil
+}
+
+// Validate returns the Token for the given token string, or ErrInvalidToken
+// if the token is not valid.
+func (s *Store) Validate(ctx context.Context, token string) (*Token, error) {
+	encodedKey, secret, err := splitToken(token)
+	if err != nil {
+		return nil, err
+	}
+	key, err := datastore.DecodeKey(encodedKey)
+	if err != nil || key.Kind != string(ds.APITOKEN_AM) {
+		return nil, ErrInvalidToken
+	}
+	var t Token
+	if err := s.ds.Get(ctx, key, &t); err == datastore.ErrNoSuchEntity {
+		return nil, ErrInvalidToken
+	} else if err != nil {
+		return nil, skerr.Wrapf(err, "reading token")
+	}
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(t.HashedSecret)) != 1 {
+		return nil, ErrInvalidToken
+	}
+	if t.Expired(time.Now()) {
+		return nil, ErrInvalidToken
+	}
+	t.Key = encodedKey
+	return &t, nil
+}
+
+// List returns all the tokens issued to the given user, including expired
+// ones, most recently created first.
+func (s *Store) List(ctx context.Context, user string) ([]*Token, error) {
+	var tokens []*Token
+	q := ds.NewQuery(ds.APITOKEN_AM).Filter("user=", user)
+	keys, err := s.ds.GetAll(ctx, q, &tokens)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "listing tokens")
+	}
+	for i, key := range keys {
+		tokens[i].Key = key.Encode()
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Created > tokens[j].Created
+	})
+	return tokens, nil
+}
+
+// Revoke deletes the token with the given key. Users may only revoke their own
+// tokens.
+func (s *Store) Revoke(ctx context.Context, user, encodedKey string) error {
+	key, err := datastore.DecodeKey(encodedKey)
+	if err != nil || key.Kind != string(ds.APITOKEN_AM) {
+		return skerr.Fmt("invalid token key %q", encodedKey)
+	}
+	_, err = s.ds.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		var t Token
+		if err := tx.Get(key, &t); err != nil {
+			return err
+		}
+		if t.User != user {
+			return skerr.Fmt("token does not belong to %s", user)
+		}
+		return tx.Delete(key)
+	})
+	if err != nil {
+		return skerr.Wrapf(err, "revoking token")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package apitoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/ds/testutil"
+)
+
+func TestToken_HasScope(t *testing.T) {
+	tok := &Token{Scopes: []string{string(SilencesRead), string(SilencesWrite)}}
+	assert.True(t, tok.HasScope(SilencesWrite))
+	assert.False(t, tok.HasScope(IncidentsWrite))
+}
+
+func TestToken_Expired(t *testing.T) {
+	ts := time.Unix(1000, 0)
+	tok := &Token{Expires: ts.Unix()}
+	assert.False(t, tok.Expired(ts.Add(-time.Second)))
+	assert.True(t, tok.Expired(ts))
+}
+
+func TestValidateScopes(t *testing.T) {
+	assert.NoError(t, ValidateScopes([]string{"incidents:read", "silences:write"}))
+	assert.Error(t, ValidateScopes(nil))
+	assert.Error(t, ValidateScopes([]string{"incidents:read", "admin"}))
+}
+
+func TestSplitToken(t *testing.T) {
+	key, secret, err := splitToken("abc.def")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", key)
+	assert.Equal(t, "def", secret)
+
+	for _, bad := range []string{"", "abc", ".def", "abc."} {
+		_, _, err := splitToken(bad)
+		assert.Equal(t, ErrInvalidToken, err, bad)
+	}
+}
+
+func TestStore(t *testing.T) {
+	cleanup := testutil.InitDatastore(t, ds.APITOKEN_AM)
+	defer cleanup()
+
+	ctx := context.Background()
+	st := NewStore(ds.DS)
+
+	// Invalid requests.
+	_, _, err := st.Create(ctx, "fred@example.org", "", nil, time.Hour)
+	assert.Error(t, err)
+	_, _, err = st.Create(ctx, "fred@example.org", "", []string{string(SilencesRead)}, MaxDuration+time.Hour)
+	assert.Error(t, err)
+
+	tok, tokenString, err := st.Create(ctx, "fred@example.org", "maintenance script", []string{string(SilencesWrite), string(SilencesRead)}, time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, "", tok.Key)
+	assert.Equal(t, []string{string(SilencesRead), string(SilencesWrite)}, tok.Scopes)
+
+	// Valid token.
+	got, err := st.Validate(ctx, tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, "fred@example.org", got.User)
+	assert.Equal(t, tok.Key, got.Key)
+	assert.True(t, got.HasScope(SilencesWrite))
+
+	// Wrong secret.
+	_, err = st.Validate(ctx, tok.Key+".0000")
+	assert.Equal(t, ErrInvalidToken, err)
+
+	// Listing.
+	tokens, err := st.List(ctx, "fred@example.org")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "maintenance script", tokens[0].Description)
+	tokens, err = st.List(ctx, "barney@example.org")
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+
+	// Only the owner may revoke.
+	assert.Error(t, st.Revoke(ctx, "barney@example.org", tok.Key))
+	require.NoError(t, st.Revoke(ctx, "fred@example.org", tok.Key))
+	_, err = st.Validate(ctx, tokenString)
+	assert.Equal(t, ErrInvalidToken, err)
+}
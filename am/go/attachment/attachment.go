@@ -0,0 +1,216 @@
+// Package attachment stores files, such as screenshots and log snippets,
+// which are attached to Incidents and Silences.
+//
+// The contents of attachments are stored in GCS, while the metadata is stored
+// along with the Incident or Silence they are attached to. Attachments are
+// only ever served via short lived signed URLs.
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
+
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/skerr"
+)
+
+const (
+	// MaxSize is the maximum size of a single attachment in bytes.
+	MaxSize = 10 * 1024 * 1024
+
+	// SignedURLDuration is how long signed URLs for attachments are valid.
+	SignedURLDuration = 15 * time.Minute
+
+	// gcsPrefix is the directory in the bucket under which all attachments are
+	// stored.
+	gcsPrefix = "attachments"
+)
+
+// ErrTooLarge is returned when an attachment is larger than MaxSize.
+var ErrTooLarge = fmt.Errorf("attachments must not be larger than %d bytes", MaxSize)
+
+// validKey matches encoded datastore keys, which are URL-safe base64 without
+// padding. In particular it never matches anything containing a "/" or "..".
+var validKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Attachment is one file attached to an Incident or Silence.
+type Attachment struct {
+	ID          string `json:"id" datastore:"id"`
+	Name        string `json:"name" datastore:"name"`
+	ContentType string `json:"content_type" datastore:"content_type"`
+	Size        int64  `json:"size" datastore:"size"`
+	Author      string `json:"author" datastore:"author"`
+	TS          int64  `json:"ts" datastore:"ts"` // Time in seconds since the epoch.
+}
+
+// URLSigner returns a signed URL which grants read access to the given GCS
+// object until the given time.
+type URLSigner func(object string, expires time.Time) (string, error)
+
+// NewURLSigner returns a URLSigner for objects in the given bucket.
+func NewURLSigner(bucket *storage.BucketHandle) URLSigner {
+	return func(object string, expires time.Time) (string, error) {
+		return bucket.SignedURL(object, &storage.SignedURLOptions{
+			Method:  "GET",
+			Expires: expires,
+			Scheme:  storage.SigningSchemeV4,
+		})
+	}
+}
+
+// Store the contents of attachments in GCS.
+type Store struct {
+	gcs    gcs.GCSClient
+	signer URLSigner
+}
+
+// NewStore creates a new Store.
+//
+// gcsClient - Client for the bucket the attachments are stored in.
+// signer - Used to create signed URLs for objects in that bucket.
+func NewStore(gcsClient gcs.GCSClient, signer URLSigner) *Store {
+	return &Store{
+		gcs:    gcsClient,
+		signer: signer,
+	}
+}
+
+// validateKey returns an error if parentKey isn't an encoded datastore key.
+func validateKey(parentKey string) error {
+	if !validKey.MatchString(parentKey) {
+		return skerr.Fmt("invalid attachment key %q", parentKey)
+	}
+	return nil
+}
+
+// objectPath returns the path of the given attachment of the Incident or
+// Silence with the given encoded key. Returns an error if either the key or
+// the ID is malformed, so that the path can never escape the directory of the
+// Incident or Silence.
+func objectPath(parentKey, id string) (string, error) {
+	if err := validateKey(parentKey); err != nil {
+		return "", err
+	}
+	// IDs are always created by uuid.New().String(), so anything else is
+	// rejected, including the other formats uuid.Parse accepts.
+	if u, err := uuid.Parse(id); err != nil || u.String() != id {
+		return "", skerr.Fmt("invalid attachment id %q", id)
+	}
+	return path.Join(gcsPrefix, parentKey, id), nil
+}
+
+// Find returns the attachment with the given ID, or nil if there is none.
+func Find(attachments []Attachment, id string) *Attachment {
+	for i := range attachments {
+		if attachments[i].ID == id {
+			return &attachments[i]
+		}
+	}
+	return nil
+}
+
+// Upload stores the contents of r as a new attachment of the Incident or
+// Silence with the given encoded key. Returns ErrTooLarge if there are more
+// than MaxSize bytes in r. The caller is responsible for adding the returned
+// Attachment to the Incident or Silence.
+func (s *Store) Upload(ctx context.Context, parentKey, name, contentType, author string, r io.Reader) (*Attachment, error) {
+	if err := validateKey(parentKey); err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(io.LimitReader(r, MaxSize+1))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading attachment %q", name)
+	}
+	if len(b) > MaxSize {
+		return nil, ErrTooLarge
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	a := &Attachment{
+		ID:          uuid.New().String(),
+		Name:        path.Base(name),
+		ContentType: contentType,
+		Size:        int64(len(b)),
+		Author:      author,
+		TS:          time.Now().Unix(),
+	}
+	opts := gcs.FileWriteOptions{
+		ContentType:        contentType,
+		ContentDisposition: fmt.Sprintf("attachment; filename=%q", a.Name),
+	}
+	p, err := objectPath(parentKey, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.gcs.SetFileContents(ctx, p, opts, b); err != nil {
+		return nil, skerr.Wrapf(err, "writing attachment %q", name)
+	}
+	return a, nil
+}
+
+// SignedURL returns a URL which can be used to download the given attachment
+// of the Incident or Silence with the given encoded key for
+// SignedURLDuration. The caller is responsible for checking that the
+// attachment belongs to the Incident or Silence.
+func (s *Store) SignedURL(ctx context.Context, parentKey, id string) (string, error) {
+	p, err := objectPath(parentKey, id)
+	if err != nil {
+		return "", err
+	}
+	exists, err := s.gcs.DoesFileExist(ctx, p)
+	if err != nil {
+		return "", skerr.Wrapf(err, "looking up attachment %s", p)
+	}
+	if !exists {
+		return "", skerr.Fmt("attachment %s does not exist", p)
+	}
+	u, err := s.signer(p, time.Now().Add(SignedURLDuration))
+	if err != nil {
+		return "", skerr.Wrapf(err, "signing URL for attachment %s", p)
+	}
+	return u, nil
+}
+
+// Delete removes the given attachment of the Incident or Silence with the
+// given encoded key. It is not an error if the attachment does not exist.
+func (s *Store) Delete(ctx context.Context, parentKey, id string) error {
+	p, err := objectPath(parentKey, id)
+	if err != nil {
+		return err
+	}
+	if err := s.gcs.DeleteFile(ctx, p); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return skerr.Wrapf(err, "deleting attachment %s", p)
+	}
+	return nil
+}
+
+// DeleteAll removes all attachments of the Incident or Silence with the given
+// encoded key.
+func (s *Store) DeleteAll(ctx context.Context, parentKey string) error {
+	if err := validateKey(parentKey); err != nil {
+		return err
+	}
+	var paths []string
+	err := s.gcs.AllFilesInDirectory(ctx, path.Join(gcsPrefix, parentKey)+"/", func(item *storage.ObjectAttrs) error {
+		paths = append(paths, item.Name)
+		return nil
+	})
+	if err != nil {
+		return skerr.Wrapf(err, "listing attachments of %s", parentKey)
+	}
+	for _, p := range paths {
+		if err := s.gcs.DeleteFile(ctx, p); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return skerr.Wrapf(err, "deleting attachment %s", p)
+		}
+	}
+	return nil
+}
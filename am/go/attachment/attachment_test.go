@@ -0,0 +1,120 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/gcs/mem_gcsclient"
+)
+
+const parentKey = "incident-key"
+
+func setupStore(t *testing.T) (*Store, *mem_gcsclient.MemoryGCSClient) {
+	gcsClient := mem_gcsclient.New("my-bucket")
+	signer := func(object string, expires time.Time) (string, error) {
+		return "https://signed.example.com/" + object, nil
+	}
+	return NewStore(gcsClient, signer), gcsClient
+}
+
+func TestUpload_StoresContentsAndReturnsMetadata(t *testing.T) {
+	s, gcsClient := setupStore(t)
+	ctx := context.Background()
+
+	a, err := s.Upload(ctx, parentKey, "dir/screenshot.png", "image/png", "fred@example.org", strings.NewReader("not really a png"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, a.ID)
+	assert.Equal(t, "screenshot.png", a.Name)
+	assert.Equal(t, "image/png", a.ContentType)
+	assert.Equal(t, int64(16), a.Size)
+	assert.Equal(t, "fred@example.org", a.Author)
+
+	contents, err := gcsClient.GetFileContents(ctx, "attachments/incident-key/"+a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "not really a png", string(contents))
+
+	u, err := s.SignedURL(ctx, parentKey, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://signed.example.com/attachments/incident-key/"+a.ID, u)
+}
+
+func TestUpload_TooLarge_ReturnsErrTooLarge(t *testing.T) {
+	s, _ := setupStore(t)
+
+	_, err := s.Upload(context.Background(), parentKey, "log.txt", "text/plain", "fred@example.org", strings.NewReader(strings.Repeat("a", MaxSize+1)))
+	assert.True(t, errors.Is(err, ErrTooLarge))
+}
+
+func TestSignedURL_AttachmentDoesNotExist_ReturnsError(t *testing.T) {
+	s, _ := setupStore(t)
+
+	_, err := s.SignedURL(context.Background(), parentKey, "6f2d4bb0-6a4e-4c3c-9b0e-7d8f7e3d1a2b")
+	assert.ErrorContains(t, err, "does not exist")
+}
+
+func TestSignedURL_IDIsNotAUUID_ReturnsError(t *testing.T) {
+	s, _ := setupStore(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"missing", "../other-key/6f2d4bb0-6a4e-4c3c-9b0e-7d8f7e3d1a2b", "{6f2d4bb0-6a4e-4c3c-9b0e-7d8f7e3d1a2b}", ""} {
+		_, err := s.SignedURL(ctx, parentKey, id)
+		assert.ErrorContains(t, err, "invalid attachment id", id)
+	}
+}
+
+func TestSignedURL_KeyEscapesAttachmentsDirectory_ReturnsError(t *testing.T) {
+	s, gcsClient := setupStore(t)
+	ctx := context.Background()
+	id := "6f2d4bb0-6a4e-4c3c-9b0e-7d8f7e3d1a2b"
+	require.NoError(t, gcsClient.SetFileContents(ctx, "private/"+id, gcs.FileWriteOptions{}, []byte("secret")))
+
+	for _, key := range []string{"../private", "..", "a/b", ""} {
+		_, err := s.SignedURL(ctx, key, id)
+		assert.ErrorContains(t, err, "invalid attachment key", key)
+	}
+}
+
+func TestUpload_InvalidKey_ReturnsError(t *testing.T) {
+	s, _ := setupStore(t)
+
+	_, err := s.Upload(context.Background(), "../private", "log.txt", "text/plain", "fred@example.org", strings.NewReader("log"))
+	assert.ErrorContains(t, err, "invalid attachment key")
+}
+
+func TestFind(t *testing.T) {
+	attachments := []Attachment{{ID: "a"}, {ID: "b"}}
+	assert.Equal(t, &attachments[1], Find(attachments, "b"))
+	assert.Nil(t, Find(attachments, "c"))
+	assert.Nil(t, Find(nil, "a"))
+}
+
+func TestDeleteAll_OnlyRemovesAttachmentsOfTheGivenKey(t *testing.T) {
+	s, gcsClient := setupStore(t)
+	ctx := context.Background()
+
+	a1, err := s.Upload(ctx, parentKey, "one.txt", "text/plain", "fred@example.org", strings.NewReader("one"))
+	require.NoError(t, err)
+	a2, err := s.Upload(ctx, parentKey, "two.txt", "text/plain", "fred@example.org", strings.NewReader("two"))
+	require.NoError(t, err)
+	other, err := s.Upload(ctx, parentKey+"-other", "three.txt", "text/plain", "fred@example.org", strings.NewReader("three"))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Delete(ctx, parentKey, a1.ID))
+	require.NoError(t, s.DeleteAll(ctx, parentKey))
+
+	for _, p := range []string{"attachments/incident-key/" + a1.ID, "attachments/incident-key/" + a2.ID} {
+		exists, err := gcsClient.DoesFileExist(ctx, p)
+		require.NoError(t, err)
+		assert.False(t, exists, p)
+	}
+	exists, err := gcsClient.DoesFileExist(ctx, "attachments/incident-key-other/"+other.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
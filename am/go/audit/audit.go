@@ -18,16 +18,21 @@ const getLogsLimit = 200
 
 // Log outputs the action/user/body to stdout and persists it in datastore.
 func Log(r *http.Request, action string, body interface{}, alogin *proxylogin.ProxyLogin) {
+	LogWithUser(r, string(alogin.LoggedInAs(r)), action, body)
+}
+
+// LogWithUser is like Log but for requests where the user is not known to
+// alogin, e.g. requests authenticated with an API token.
+func LogWithUser(r *http.Request, user string, action string, body interface{}) {
 	// Log to stdout.
-	user := alogin.LoggedInAs(r)
-	auditlog.LogWithUser(r, string(user), action, body)
+	auditlog.LogWithUser(r, user, action, body)
 
 	// Add the log to datastore to display in UI. Doing this in a Go routine
 	// to avoid introducing latency in the UI.
 	go func() {
 		a := types.AuditLog{
 			Action:    action,
-			User:      string(user),
+			User:      user,
 			Body:      fmt.Sprintf("%+v", body),
 			Timestamp: time.Now().Unix(),
 		}
@@ -0,0 +1,137 @@
+// Package deployment decides which incidents are resolved by a deployment, so
+// that incidents which go away once a fix is deployed are archived right away
+// instead of staying open until they expire.
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/am/go/types"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/skerr"
+)
+
+// AppPlaceholder may be used in the values of Rule.ParamSet and is replaced by
+// the name of the deployed app.
+const AppPlaceholder = "$app"
+
+// DefaultRules returns the rules used if no rules are configured. They resolve
+// the incidents whose "app" param is the deployed app.
+func DefaultRules() []Rule {
+	rules := []Rule{
+		{
+			App: ".*",
+			ParamSet: paramtools.ParamSet{
+				"app": {AppPlaceholder},
+			},
+		},
+	}
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			// The default rules are known to be valid.
+			panic(err)
+		}
+	}
+	return rules
+}
+
+// Rule describes which incidents are resolved by deployments of an app.
+type Rule struct {
+	// App is a regex which must match the whole name of the deployed app.
+	App string `json:"app"`
+
+	// ParamSet selects the incidents which are resolved, with the same
+	// semantics as silence.Silence.ParamSet. AppPlaceholder in the values is
+	// replaced by the name of the deployed app.
+	ParamSet paramtools.ParamSet `json:"param_set"`
+
+	app *regexp.Regexp
+}
+
+// Validate compiles the rule's regexes and returns an error if any of them is
+// invalid.
+func (r *Rule) Validate() error {
+	if len(r.ParamSet) == 0 {
+		return skerr.Fmt("rule for app %q must match at least one param", r.App)
+	}
+	app, err := regexp.Compile(fmt.Sprintf(`^%s$`, r.App))
+	if err != nil {
+		return skerr.Wrapf(err, "invalid app regex %q", r.App)
+	}
+	r.app = app
+	s := silence.Silence{ParamSet: r.ParamSet}
+	if err := s.ValidateRegexes(); err != nil {
+		return skerr.Wrapf(err, "invalid param_set for app %q", r.App)
+	}
+	return nil
+}
+
+// Matches returns true if the given deployment resolves the given incident.
+// Incidents which started after the deployment are never resolved by it.
+func (r *Rule) Matches(ev types.DeploymentEvent, in incident.Incident) bool {
+	if !in.Active || in.Start > ev.Timestamp || r.app == nil || !r.app.MatchString(ev.App) {
+		return false
+	}
+	ps := make(paramtools.ParamSet, len(r.ParamSet))
+	for key, values := range r.ParamSet {
+		expanded := make([]string, 0, len(values))
+		for _, v := range values {
+			expanded = append(expanded, strings.ReplaceAll(v, AppPlaceholder, regexp.QuoteMeta(ev.App)))
+		}
+		ps[key] = expanded
+	}
+	s := silence.Silence{ParamSet: ps}
+	return s.Matches(in.Params)
+}
+
+// ReadRules reads a JSON list of Rules from the given file and validates them.
+func ReadRules(path string) ([]Rule, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading deployment rules")
+	}
+	var rules []Rule
+	if err := json.Unmarshal(b, &rules); err != nil {
+		return nil, skerr.Wrapf(err, "parsing deployment rules")
+	}
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+	}
+	return rules, nil
+}
+
+// Resolved returns the incidents which are resolved by the given deployment
+// according to any of the rules.
+func Resolved(rules []Rule, ev types.DeploymentEvent, incidents []incident.Incident) []incident.Incident {
+	var rv []incident.Incident
+	for _, in := range incidents {
+		for i := range rules {
+			if rules[i].Matches(ev, in) {
+				rv = append(rv, in)
+				break
+			}
+		}
+	}
+	return rv
+}
+
+// Note returns the text of the note added to incidents resolved by the given
+// deployment.
+func Note(ev types.DeploymentEvent) string {
+	text := fmt.Sprintf("Automatically archived after %s deployed %s", ev.Source, ev.App)
+	if ev.Version != "" {
+		text += " at " + ev.Version
+	}
+	if ev.URL != "" {
+		text += ": " + ev.URL
+	}
+	return text + ". A new incident is created if the alert fires again."
+}
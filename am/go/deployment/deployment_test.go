@@ -0,0 +1,79 @@
+package deployment
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/types"
+	"go.skia.org/infra/go/paramtools"
+)
+
+var ev = types.DeploymentEvent{
+	Source:    "k8s-deployer",
+	App:       "perf.skia",
+	Version:   "abc123",
+	URL:       "https://skia.googlesource.com/k8s-config/+/abc123",
+	Timestamp: 2000,
+}
+
+func TestResolved_DefaultRules_MatchesIncidentsOfDeployedApp(t *testing.T) {
+	ins := []incident.Incident{
+		{Key: "match", Active: true, Start: 1000, Params: paramtools.Params{"app": "perf.skia", "alertname": "HighErrorRate"}},
+		// The "." in the app name must not match any character.
+		{Key: "other-app", Active: true, Start: 1000, Params: paramtools.Params{"app": "perfxskia"}},
+		{Key: "no-app", Active: true, Start: 1000, Params: paramtools.Params{"alertname": "HighErrorRate"}},
+		// Started after the deployment.
+		{Key: "too-new", Active: true, Start: 3000, Params: paramtools.Params{"app": "perf.skia"}},
+		{Key: "archived", Active: false, Start: 1000, Params: paramtools.Params{"app": "perf.skia"}},
+	}
+	resolved := Resolved(DefaultRules(), ev, ins)
+	require.Len(t, resolved, 1)
+	assert.Equal(t, "match", resolved[0].Key)
+}
+
+func TestResolved_CustomRules(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"app": "perf.*", "param_set": {"alertname": ["PerfIngestion.*"], "instance": ["$app-.*"]}},
+		{"app": "datahopper", "param_set": {"app": ["datahopper"]}}
+	]`), 0644))
+	rules, err := ReadRules(path)
+	require.NoError(t, err)
+
+	ins := []incident.Incident{
+		{Key: "match", Active: true, Start: 1000, Params: paramtools.Params{"alertname": "PerfIngestionFailed", "instance": "perf.skia-0"}},
+		{Key: "wrong-alert", Active: true, Start: 1000, Params: paramtools.Params{"alertname": "HighErrorRate", "instance": "perf.skia-0"}},
+		{Key: "wrong-instance", Active: true, Start: 1000, Params: paramtools.Params{"alertname": "PerfIngestionFailed", "instance": "perf.chrome-0"}},
+		{Key: "other-app", Active: true, Start: 1000, Params: paramtools.Params{"app": "datahopper"}},
+	}
+	resolved := Resolved(rules, ev, ins)
+	require.Len(t, resolved, 1)
+	assert.Equal(t, "match", resolved[0].Key)
+}
+
+func TestReadRules_InvalidRules_ReturnsError(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"invalid json":      `{`,
+		"no params":         `[{"app": "perf"}]`,
+		"invalid app regex": `[{"app": "(", "param_set": {"app": ["perf"]}}]`,
+		"invalid param":     `[{"app": "perf", "param_set": {"app": ["("]}}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, "rules.json")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			_, err := ReadRules(path)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNote(t *testing.T) {
+	assert.Equal(t, "Automatically archived after k8s-deployer deployed perf.skia at abc123: https://skia.googlesource.com/k8s-config/+/abc123. A new incident is created if the alert fires again.", Note(ev))
+	assert.Equal(t, "Automatically archived after k8s-deployer deployed perf.skia. A new incident is created if the alert fires again.", Note(types.DeploymentEvent{Source: "k8s-deployer", App: "perf.skia"}))
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/datastore"
+	"go.skia.org/infra/am/go/attachment"
 	"go.skia.org/infra/am/go/note"
 	"go.skia.org/infra/am/go/silence"
 	"go.skia.org/infra/go/alerts"
@@ -71,6 +72,13 @@ type Incident struct {
 	Params       paramtools.Params `json:"params" datastore:"-"`                // Params
 	ParamsSerial string            `json:"-" datastore:"params_serial,noindex"` // Params serialized as JSON for easy storing in the datastore.
 	Notes        []note.Note       `json:"notes" datastore:"notes,flatten"`
+
+	// Routing information from the most recent alert.
+	Severity   string `json:"severity" datastore:"severity"`
+	RunbookURL string `json:"runbook_url" datastore:"runbook_url,noindex"`
+	Team       string `json:"team" datastore:"team"`
+
+	Attachments []attachment.Attachment `json:"attachments" datastore:"attachments,flatten"`
 }
 
 // Load converts the JSON params back into a map[string]string.
@@ -102,28 +110,7 @@ func (in *Incident) IsSilenced(silences []silence.Silence, matchOnlyActiveSilenc
 		if !s.Active && matchOnlyActiveSilences {
 			continue
 		}
-		if s.ParamSet.Matches(ps) {
-			return true
-		}
-
-		allSilenceKeysMatched := true
-		for sKey, sValues := range s.ParamSet {
-			valueMatchedForKey := false
-			if iVal, ok := in.Params[sKey]; ok {
-				for _, sVal := range sValues {
-					sValRegex := regexp.MustCompile(fmt.Sprintf("^%s$", sVal))
-					if sValRegex.Match([]byte(iVal)) {
-						valueMatchedForKey = true
-						break
-					}
-				}
-			}
-			if !valueMatchedForKey {
-				allSilenceKeysMatched = false
-				break
-			}
-		}
-		if allSilenceKeysMatched {
+		if s.ParamSet.Matches(ps) || s.Matches(in.Params) {
 			return true
 		}
 	}
@@ -268,14 +255,25 @@ func (s *Store) inFromAlert(m map[string]string, id string) *Incident {
 	}
 
 	now := time.Now().Unix()
-	return &Incident{
-		Active:   true,
-		ID:       id,
-		Start:    now,
-		LastSeen: now,
-		Params:   m,
-		Notes:    []note.Note{},
+	in := &Incident{
+		Active:      true,
+		ID:          id,
+		Start:       now,
+		LastSeen:    now,
+		Params:      m,
+		Notes:       []note.Note{},
+		Attachments: []attachment.Attachment{},
 	}
+	in.setRouting(m)
+	return in
+}
+
+// setRouting sets the routing information of the Incident from the given
+// alert.
+func (in *Incident) setRouting(m map[string]string) {
+	in.Severity = m[alerts.SEVERITY]
+	in.RunbookURL = m[alerts.RUNBOOK_URL]
+	in.Team = m[alerts.TEAM]
 }
 
 // AlertArrival turns alerts into Incidents, or archives Incidents if
@@ -334,6 +332,7 @@ func (s *Store) AlertArrival(m map[string]string) (*Incident, error) {
 			key = keys[0]
 			active[0].LastSeen = time.Now().Unix()
 			active[0].Key = key.Encode()
+			active[0].setRouting(m)
 
 			if existingAlertPodName, ok := active[0].Params[K8S_POD_NAME]; ok {
 				if newAlertPodName, ok := m[K8S_POD_NAME]; ok {
@@ -393,6 +392,20 @@ func (s *Store) _mutateIncident(encodedKey string, mutator func(in *Incident) er
 	return &in, err
 }
 
+// Get returns the Incident with the given key.
+func (s *Store) Get(encodedKey string) (*Incident, error) {
+	key, err := datastore.DecodeKey(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	var in Incident
+	if err := s.ds.Get(context.Background(), key, &in); err != nil {
+		return nil, err
+	}
+	in.Key = encodedKey
+	return &in, nil
+}
+
 func (s *Store) AddNote(encodedKey string, note note.Note) (*Incident, error) {
 	return s._mutateIncident(encodedKey, func(in *Incident) error {
 		in.Notes = append(in.Notes, note)
@@ -410,6 +423,28 @@ func (s *Store) DeleteNote(encodedKey string, index int) (*Incident, error) {
 	})
 }
 
+// AddAttachment adds the given attachment to the Incident with the given key.
+func (s *Store) AddAttachment(encodedKey string, a attachment.Attachment) (*Incident, error) {
+	return s._mutateIncident(encodedKey, func(in *Incident) error {
+		in.Attachments = append(in.Attachments, a)
+		return nil
+	})
+}
+
+// DeleteAttachment removes the attachment with the given ID from the Incident
+// with the given key.
+func (s *Store) DeleteAttachment(encodedKey string, id string) (*Incident, error) {
+	return s._mutateIncident(encodedKey, func(in *Incident) error {
+		for i, a := range in.Attachments {
+			if a.ID == id {
+				in.Attachments = append(in.Attachments[:i], in.Attachments[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("No attachment with ID %q.", id)
+	})
+}
+
 func (s *Store) Assign(encodedKey string, user string) (*Incident, error) {
 	return s._mutateIncident(encodedKey, func(in *Incident) error {
 		in.Params[ASSIGNED_TO] = user
@@ -424,6 +459,19 @@ func (s *Store) Archive(encodedKey string) (*Incident, error) {
 	})
 }
 
+// ArchiveWithNote archives the Incident with the given key and adds the given
+// note explaining why. Incidents which are already archived are left alone.
+func (s *Store) ArchiveWithNote(encodedKey string, note note.Note) (*Incident, error) {
+	return s._mutateIncident(encodedKey, func(in *Incident) error {
+		if !in.Active {
+			return nil
+		}
+		in.Active = false
+		in.Notes = append(in.Notes, note)
+		return nil
+	})
+}
+
 // GetAll returns a list of all active Incidents.
 func (s *Store) GetAll() ([]Incident, error) {
 	var active []Incident
@@ -517,6 +565,40 @@ func (s *Store) GetRecentlyResolvedInRangeWithID(d, id string) ([]Incident, erro
 	return resolved, err
 }
 
+// Purge deletes all archived Incidents which were last seen longer ago than
+// the given duration, and returns them so that the caller can clean up
+// anything associated with them, such as attachments.
+func (s *Store) Purge(olderThan time.Duration) ([]Incident, error) {
+	ts := time.Now().Add(-1 * olderThan).Unix()
+	var old []Incident
+	q := ds.NewQuery(ds.INCIDENT_AM).Filter("last_seen<", ts)
+	keys, err := s.ds.GetAll(context.Background(), q, &old)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find old incidents: %s", err)
+	}
+	purged := []Incident{}
+	toDelete := []*datastore.Key{}
+	for i, key := range keys {
+		// Never purge an incident that is still active, no matter how long
+		// ago it was last seen.
+		if old[i].Active {
+			continue
+		}
+		old[i].Key = key.Encode()
+		purged = append(purged, old[i])
+		toDelete = append(toDelete, key)
+	}
+	// Datastore limits the number of keys in a single call.
+	for len(toDelete) > 0 {
+		n := util.MinInt(len(toDelete), ds.MAX_MODIFICATIONS)
+		if err := s.ds.DeleteMulti(context.Background(), toDelete[:n]); err != nil {
+			return nil, fmt.Errorf("Failed to delete old incidents: %s", err)
+		}
+		toDelete = toDelete[n:]
+	}
+	return purged, nil
+}
+
 // AreIncidentsFlaky is a utility function to help determine whether a slice
 // of incidents are flaky. Flaky here is defined as alerts which occasionally
 // show up and go away on their own with no actions taken to resolve them.
@@ -190,6 +190,27 @@ func TestIdForAlert(t *testing.T) {
 	assert.Equal(t, id1, id2)
 }
 
+func TestInFromAlert_SetsRouting(t *testing.T) {
+	m := map[string]string{
+		"__name__":         "ALERTS",
+		"alertname":        "BotMissing",
+		alerts.SEVERITY:    "critical",
+		alerts.RUNBOOK_URL: "https://example.com/runbook",
+		alerts.TEAM:        "infra",
+	}
+	st := NewStore(nil, []string{})
+	in := st.inFromAlert(m, "some-id")
+	assert.Equal(t, "critical", in.Severity)
+	assert.Equal(t, "https://example.com/runbook", in.RunbookURL)
+	assert.Equal(t, "infra", in.Team)
+
+	// Alerts without routing information leave the fields empty.
+	in = st.inFromAlert(map[string]string{"alertname": "BotMissing"}, "some-id")
+	assert.Equal(t, "", in.Severity)
+	assert.Equal(t, "", in.RunbookURL)
+	assert.Equal(t, "", in.Team)
+}
+
 func TestGetRegexesToOwners(t *testing.T) {
 
 	ownersRegexesStr := "owner1:abbr_regex1,abbr_regex2;owner2:abbr_regex3"
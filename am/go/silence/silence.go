@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/datastore"
+	"go.skia.org/infra/am/go/attachment"
 	"go.skia.org/infra/am/go/note"
 	"go.skia.org/infra/go/ds"
 	"go.skia.org/infra/go/human"
@@ -19,6 +20,10 @@ const (
 	SILENCE_PARENT_KEY = "-silence-"
 
 	NUM_RECENTLY_ARCHIVED = 500
+
+	// RollerParam is the param which identifies the autoroller an alert is
+	// about.
+	RollerParam = "roller"
 )
 
 // Silence is a filter that matches Incidents and is used to silence them.
@@ -32,6 +37,12 @@ type Silence struct {
 	Updated        int64               `json:"updated" datastore:"updated"`
 	Duration       string              `json:"duration" datastore:"duration"`
 	Notes          []note.Note         `json:"notes" datastore:"notes,flatten"`
+
+	Attachments []attachment.Attachment `json:"attachments" datastore:"attachments,flatten"`
+
+	// PauseRoller, if true, asks the autorollers matched by the "roller"
+	// param of this silence to pause themselves while the silence is active.
+	PauseRoller bool `json:"pause_roller" datastore:"pause_roller,noindex"`
 }
 
 // New creates a new Silence.
@@ -40,13 +51,14 @@ type Silence struct {
 func New(user string) *Silence {
 	now := time.Now().Unix()
 	return &Silence{
-		Active:   true,
-		User:     user,
-		ParamSet: paramtools.ParamSet{},
-		Created:  now,
-		Updated:  now,
-		Duration: "2h",
-		Notes:    []note.Note{},
+		Active:      true,
+		User:        user,
+		ParamSet:    paramtools.ParamSet{},
+		Created:     now,
+		Updated:     now,
+		Duration:    "2h",
+		Notes:       []note.Note{},
+		Attachments: []attachment.Attachment{},
 	}
 }
 
@@ -83,6 +95,45 @@ func (silence *Silence) ValidateRegexes() error {
 	return nil
 }
 
+// Matches returns true if the silence applies to an incident with the given
+// params. Every key of the silence must be present in params, with a value
+// which matches one of the silence's values. Values are regexes, see
+// skbug.com/9587.
+func (silence *Silence) Matches(params map[string]string) bool {
+	for key, values := range silence.ParamSet {
+		value, ok := params[key]
+		if !ok || !matchesAny(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// PausesRoller returns true if the silence is active and asks the given
+// autoroller to pause.
+func (silence *Silence) PausesRoller(roller string) bool {
+	if !silence.Active || !silence.PauseRoller {
+		return false
+	}
+	values, ok := silence.ParamSet[RollerParam]
+	return ok && matchesAny(values, roller)
+}
+
+// matchesAny returns true if value matches any of the given regexes. Invalid
+// regexes never match.
+func matchesAny(regexes []string, value string) bool {
+	for _, r := range regexes {
+		re, err := regexp.Compile(fmt.Sprintf(`^%s$`, r))
+		if err != nil {
+			continue
+		}
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
 // Store saves and updates silences in Cloud Datastore.
 type Store struct {
 	ds *datastore.Client
@@ -181,6 +232,20 @@ func (s *Store) _mutate(encodedKey string, mutator func(silence *Silence) error)
 	return &silence, err
 }
 
+// Get returns the Silence with the given key.
+func (s *Store) Get(encodedKey string) (*Silence, error) {
+	key, err := datastore.DecodeKey(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	var silence Silence
+	if err := s.ds.Get(context.Background(), key, &silence); err != nil {
+		return nil, err
+	}
+	silence.Key = encodedKey
+	return &silence, nil
+}
+
 func (s *Store) Archive(encodedKey string) (*Silence, error) {
 	return s._mutate(encodedKey, func(silence *Silence) error {
 		silence.Active = false
@@ -242,6 +307,30 @@ func (s *Store) DeleteNote(encodedKey string, index int) (*Silence, error) {
 	})
 }
 
+// AddAttachment adds the given attachment to the Silence with the given key.
+func (s *Store) AddAttachment(encodedKey string, a attachment.Attachment) (*Silence, error) {
+	return s._mutate(encodedKey, func(silence *Silence) error {
+		silence.Updated = time.Now().Unix()
+		silence.Attachments = append(silence.Attachments, a)
+		return nil
+	})
+}
+
+// DeleteAttachment removes the attachment with the given ID from the Silence
+// with the given key.
+func (s *Store) DeleteAttachment(encodedKey string, id string) (*Silence, error) {
+	return s._mutate(encodedKey, func(silence *Silence) error {
+		for i, a := range silence.Attachments {
+			if a.ID == id {
+				silence.Updated = time.Now().Unix()
+				silence.Attachments = append(silence.Attachments[:i], silence.Attachments[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("No attachment with ID %q.", id)
+	})
+}
+
 // GetAll returns a list of all active Silences.
 func (s *Store) GetAll() ([]Silence, error) {
 	var active []Silence
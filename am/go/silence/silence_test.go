@@ -29,6 +29,43 @@ func TestValidateRegexes(t *testing.T) {
 	assert.Error(t, s.ValidateRegexes())
 }
 
+func TestMatches(t *testing.T) {
+	s := &Silence{
+		ParamSet: paramtools.ParamSet{
+			"alertname": []string{"BotQuarantined"},
+			"bot":       []string{"skia-rpi-1.*", "skia-rpi-200"},
+		},
+	}
+	assert.True(t, s.Matches(map[string]string{"alertname": "BotQuarantined", "bot": "skia-rpi-104", "extra": "value"}))
+	assert.True(t, s.Matches(map[string]string{"alertname": "BotQuarantined", "bot": "skia-rpi-200"}))
+	assert.False(t, s.Matches(map[string]string{"alertname": "BotQuarantined", "bot": "skia-rpi-204"}))
+	assert.False(t, s.Matches(map[string]string{"alertname": "BotQuarantined"}))
+}
+
+func TestPausesRoller(t *testing.T) {
+	s := &Silence{
+		Active:      true,
+		PauseRoller: true,
+		ParamSet: paramtools.ParamSet{
+			"alertname": []string{"AutoRollLastTransition"},
+			RollerParam: []string{"skia-.*-autoroll"},
+		},
+	}
+	assert.True(t, s.PausesRoller("skia-flutter-autoroll"))
+	assert.False(t, s.PausesRoller("angle-skia-autoroll"))
+
+	s.PauseRoller = false
+	assert.False(t, s.PausesRoller("skia-flutter-autoroll"))
+
+	s.PauseRoller = true
+	s.Active = false
+	assert.False(t, s.PausesRoller("skia-flutter-autoroll"))
+
+	s.Active = true
+	delete(s.ParamSet, RollerParam)
+	assert.False(t, s.PausesRoller("skia-flutter-autoroll"))
+}
+
 func TestStore(t *testing.T) {
 
 	cleanup := testutil.InitDatastore(t, ds.SILENCE_AM)
@@ -57,6 +94,11 @@ func TestStore(t *testing.T) {
 	assert.Len(t, all, 1)
 	assert.Equal(t, "fred@example.org", all[0].User)
 
+	got, err := st.Get(s.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, s.Key, got.Key)
+	assert.Equal(t, "fred@example.org", got.User)
+
 	// Add a note.
 	s, err = st.AddNote(s.Key, note.Note{
 		Text:   "Stuff happened.",
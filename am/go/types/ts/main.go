@@ -8,6 +8,8 @@ import (
 	"flag"
 	"io"
 
+	"go.skia.org/infra/am/go/apitoken"
+	"go.skia.org/infra/am/go/attachment"
 	"go.skia.org/infra/am/go/incident"
 	"go.skia.org/infra/am/go/note"
 	"go.skia.org/infra/am/go/silence"
@@ -29,12 +31,17 @@ func main() {
 		incident.Incident{},
 		silence.Silence{},
 		note.Note{},
+		attachment.Attachment{},
 		types.RecentIncidentsResponse{},
 		types.StatsRequest{},
 		types.StatsResponse{},
 		types.IncidentsResponse{},
 		types.IncidentsInRangeRequest{},
 		types.AuditLog{},
+		apitoken.Token{},
+		types.CreateAPITokenRequest{},
+		types.CreateAPITokenResponse{},
+		types.RevokeAPITokenRequest{},
 	)
 
 	err := util.WithWriteFile(*outputPath, func(w io.Writer) error {
@@ -1,6 +1,11 @@
 package types
 
-import "go.skia.org/infra/am/go/incident"
+import (
+	"go.skia.org/infra/am/go/apitoken"
+	"go.skia.org/infra/am/go/incident"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/go/paramtools"
+)
 
 // RecentIncidentsResponse - response of the "recent_incidents" endpoint.
 type RecentIncidentsResponse struct {
@@ -43,3 +48,83 @@ type AuditLog struct {
 	Body      string `json:"body" datastore:"body,noindex"`
 	Timestamp int64  `json:"timestamp" datastore:"timestamp"`
 }
+
+// CreateAPITokenRequest - request of the "api_tokens/create" endpoint.
+type CreateAPITokenRequest struct {
+	Description string   `json:"description"`
+	Scopes      []string `json:"scopes"`
+	Duration    string   `json:"duration"`
+}
+
+// CreateAPITokenResponse - response of the "api_tokens/create" endpoint. The
+// TokenString is only ever returned here.
+type CreateAPITokenResponse struct {
+	Token       *apitoken.Token `json:"token"`
+	TokenString string          `json:"token_string"`
+}
+
+// RevokeAPITokenRequest - request of the "api_tokens/revoke" endpoint.
+type RevokeAPITokenRequest struct {
+	Key string `json:"key"`
+}
+
+// AckIncidentRequest - request of the "/api/v1/incidents/ack" endpoint.
+type AckIncidentRequest struct {
+	Key string `json:"key"`
+}
+
+// CreateSilenceRequest - request of the "/api/v1/silences/create" endpoint.
+type CreateSilenceRequest struct {
+	ParamSet paramtools.ParamSet `json:"param_set"`
+	Duration string              `json:"duration"`
+	Note     string              `json:"note"`
+}
+
+// ArchiveSilenceRequest - request of the "/api/v1/silences/archive" endpoint.
+type ArchiveSilenceRequest struct {
+	Key string `json:"key"`
+}
+
+// RollerPauseResponse - response of the internal "/_/rollers/{roller}/pause"
+// endpoint.
+type RollerPauseResponse struct {
+	// Paused is true if an active silence asks the roller to pause.
+	Paused bool `json:"paused"`
+	// Silence is the silence which pauses the roller, if any.
+	Silence *silence.Silence `json:"silence"`
+}
+
+// MaintenanceSilenceRequest - request of the internal "/_/maintenance_silence"
+// endpoint. It is used by other services to silence the alerts which are
+// expected during planned maintenance.
+type MaintenanceSilenceRequest struct {
+	// Source identifies the service making the request, e.g. "k8s-checker".
+	// It is used as the user of the silence.
+	Source   string              `json:"source"`
+	ParamSet paramtools.ParamSet `json:"param_set"`
+	Duration string              `json:"duration"`
+	Reason   string              `json:"reason"`
+}
+
+// DeploymentEvent - request of the internal "/_/deployment" endpoint, also
+// accepted as a message on the --deployment_topic PubSub topic. It reports
+// that a new version of an app was deployed, which may resolve incidents.
+type DeploymentEvent struct {
+	// Source identifies the service which deployed the app, e.g.
+	// "k8s-deployer".
+	Source string `json:"source"`
+	// App is the name of the deployed app.
+	App string `json:"app"`
+	// Version is the deployed version, e.g. a commit hash or image tag.
+	Version string `json:"version"`
+	// URL links to the deployment, e.g. the commit which was applied.
+	URL string `json:"url"`
+	// Timestamp is the time of the deployment in seconds since the epoch.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// DeploymentResponse - response of the internal "/_/deployment" endpoint.
+type DeploymentResponse struct {
+	// Archived are the incidents which were archived due to the deployment.
+	Archived []incident.Incident `json:"archived"`
+}
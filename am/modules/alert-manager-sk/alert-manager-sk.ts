@@ -323,6 +323,12 @@ export class AlertManagerSk extends HTMLElement {
     );
     this.addEventListener('add-silence-note', (e) => this.addSilenceNote(e as CustomEvent));
     this.addEventListener('del-silence-note', (e) => this.delSilenceNote(e as CustomEvent));
+    this.addEventListener('add-silence-attachment', (e) =>
+      this.addSilenceAttachment(e as CustomEvent)
+    );
+    this.addEventListener('del-silence-attachment', (e) =>
+      this.delSilenceAttachment(e as CustomEvent)
+    );
     this.addEventListener('add-silence-param', (e) =>
       this.addSilenceParam((e as CustomEvent).detail.silence)
     );
@@ -334,6 +340,8 @@ export class AlertManagerSk extends HTMLElement {
     );
     this.addEventListener('add-note', (e) => this.addNote(e as CustomEvent));
     this.addEventListener('del-note', (e) => this.delNote(e as CustomEvent));
+    this.addEventListener('add-attachment', (e) => this.addAttachment(e as CustomEvent));
+    this.addEventListener('del-attachment', (e) => this.delAttachment(e as CustomEvent));
     this.addEventListener('take', (e) => this.take(e as CustomEvent));
     this.addEventListener('bot-chooser', () => this.botChooser());
     this.addEventListener('assign', (e) => this.assign(e as CustomEvent));
@@ -1168,6 +1176,14 @@ export class AlertManagerSk extends HTMLElement {
     this.doImpl('/_/del_note', e.detail);
   }
 
+  private addAttachment(e: CustomEvent): void {
+    this.uploadImpl('/_/add_attachment', e.detail.key, e.detail.file);
+  }
+
+  private delAttachment(e: CustomEvent): void {
+    this.doImpl('/_/del_attachment', e.detail);
+  }
+
   private addSilenceParam(silence: Silence): void {
     // Don't save silences that are just being created when you add a param.
     if (!silence.key) {
@@ -1240,6 +1256,18 @@ export class AlertManagerSk extends HTMLElement {
     );
   }
 
+  private addSilenceAttachment(e: CustomEvent): void {
+    this.uploadImpl('/_/add_silence_attachment', e.detail.key, e.detail.file, (json: Silence) =>
+      this.silenceAction(json, false)
+    );
+  }
+
+  private delSilenceAttachment(e: CustomEvent): void {
+    this.doImpl('/_/del_silence_attachment', e.detail, (json: Silence) =>
+      this.silenceAction(json, false)
+    );
+  }
+
   private botChooser(): void {
     this.populateBotsToIncidents(this.incidents);
     ($$('#bot-chooser', this) as BotChooserSk)
@@ -1423,6 +1451,35 @@ export class AlertManagerSk extends HTMLElement {
       });
   }
 
+  // Uploads the file as an attachment of the incident or silence with the
+  // given key. Works like doImpl, but sends a multipart form.
+  private uploadImpl(
+    url: string,
+    key: string,
+    file: File,
+    action = (json: any) => this.incidentAction(json)
+  ): void {
+    const body = new FormData();
+    body.append('key', key);
+    body.append('file', file);
+    this.spinner!.active = true;
+    fetch(url, {
+      body: body,
+      credentials: 'include',
+      method: 'POST',
+    })
+      .then(jsonOrThrow)
+      .then((json) => {
+        action(json);
+        this._render();
+        this.spinner!.active = false;
+      })
+      .catch((msg) => {
+        this.spinner!.active = false;
+        msg.resp.text().then(errorMessage);
+      });
+  }
+
   // Fix-up all the incidents and silences, including re-sorting them.
   private rationalize(): void {
     this.incidents.forEach((incident) => {
@@ -3,7 +3,7 @@ import { unsafeHTML, UnsafeHTMLDirective } from 'lit/directives/unsafe-html.js';
 import { DirectiveResult } from 'lit/directive.js';
 import { TemplateResult, html } from 'lit/html.js';
 import { diffDate } from '../../infra-sk/modules/human';
-import { Note, ParamSet } from './json';
+import { Attachment, Note, ParamSet } from './json';
 
 const linkRe = /(http[s]?:\/\/[^\s]*)/gm;
 
@@ -76,6 +76,51 @@ export function displayNotes(notes: Note[], stateKey: string, eventName: string)
   );
 }
 
+/**
+ * Templates attachments to be displayed. Attachments are downloaded via the
+ * /_/attachment endpoint, which redirects to a signed URL.
+ */
+export function displayAttachments(
+  attachments: Attachment[] | null,
+  stateKey: string,
+  eventName: string
+): TemplateResult[] {
+  if (!attachments) {
+    return [];
+  }
+  return attachments.map(
+    (attachment: Attachment) =>
+      html`<section class="attachment">
+        <a
+          href="/_/attachment?key=${encodeURIComponent(stateKey)}&id=${encodeURIComponent(
+            attachment.id
+          )}"
+          rel="noopener"
+          target="_blank"
+          >${attachment.name}</a
+        >
+        <div class="meta">
+          <span class="author">${attachment.author}</span>
+          <span class="date">${diffDate(attachment.ts * 1000)}</span>
+          <delete-icon-sk
+            title="Delete attachment."
+            @click=${(e: Event) => deleteAttachment(attachment.id, stateKey, eventName, e)}></delete-icon-sk>
+        </div>
+      </section>`
+  );
+}
+
+/**
+ * Deletes attachments in the specified event's target.
+ */
+function deleteAttachment(id: string, key: string, eventName: string, e: Event): void {
+  const detail = {
+    key: key,
+    id: id,
+  };
+  e.target!.dispatchEvent(new CustomEvent(eventName, { detail: detail, bubbles: true }));
+}
+
 /**
  * Deletes notes in the specified event's target.
  */
@@ -32,6 +32,26 @@
  *     }
  *   </pre>
  *
+ * @evt add-attachment Sent when the user attaches a file to an incident.
+ *    The detail includes the file and the key of the incident.
+ *
+ *   <pre>
+ *     detail {
+ *       key: "12312123123",
+ *       file: File,
+ *     }
+ *   </pre>
+ *
+ * @evt del-attachment Sent when the user deletes an attachment of an incident.
+ *    The detail includes the id of the attachment and the key of the incident.
+ *
+ *   <pre>
+ *     detail {
+ *       key: "12312123123",
+ *       id: "0c1c5e1e-...",
+ *     }
+ *   </pre>
+ *
  * @evt take Sent when the user wants the incident assigned to themselves.
  *    The detail includes the key of the incident.
  *
@@ -64,9 +84,9 @@ import { $$ } from '../../../infra-sk/modules/dom';
 import { diffDate, strDuration } from '../../../infra-sk/modules/human';
 import { errorMessage } from '../../../elements-sk/modules/errorMessage';
 import { jsonOrThrow } from '../../../infra-sk/modules/jsonOrThrow';
-import { abbr, linkify, displayNotes } from '../am';
+import { abbr, linkify, displayNotes, displayAttachments } from '../am';
 import * as paramset from '../paramset';
-import { Silence, Incident, Params, RecentIncidentsResponse, Note } from '../json';
+import { Silence, Incident, Params, RecentIncidentsResponse, Note, Attachment } from '../json';
 
 const MAX_MATCHING_SILENCES_TO_DISPLAY = 50;
 
@@ -86,6 +106,8 @@ class State {
   active: boolean = false;
 
   notes: Note[] = [];
+
+  attachments: Attachment[] = [];
 }
 
 export class IncidentSk extends HTMLElement {
@@ -105,6 +127,7 @@ export class IncidentSk extends HTMLElement {
     last_seen: 0,
     active: false,
     notes: [],
+    attachments: [],
   };
 
   private static template = (ele: IncidentSk) => html`
@@ -131,6 +154,11 @@ export class IncidentSk extends HTMLElement {
         <textarea rows="2" cols="80"></textarea>
         <button @click=${ele.addNote}>Submit</button>
       </section>
+      ${displayAttachments(ele.state.attachments, ele.state.key, 'del-attachment')}
+      <section class="addAttachment">
+        <input type="file" />
+        <button @click=${ele.addAttachment}>Attach</button>
+      </section>
       <section class="matchingSilences">
         <span class="matchingSilencesHeaders">
           <h3>Matching Silences</h3>
@@ -372,6 +400,19 @@ export class IncidentSk extends HTMLElement {
     textarea.value = '';
   }
 
+  private addAttachment(): void {
+    const input = $$('.addAttachment input', this) as HTMLInputElement;
+    if (!input.files || input.files.length === 0) {
+      return;
+    }
+    const detail = {
+      key: this.state.key,
+      file: input.files[0],
+    };
+    this.dispatchEvent(new CustomEvent('add-attachment', { detail: detail, bubbles: true }));
+    input.value = '';
+  }
+
   private _render(): void {
     if (!this.state) {
       return;
@@ -6,6 +6,15 @@ export interface Note {
 	ts: number;
 }
 
+export interface Attachment {
+	id: string;
+	name: string;
+	content_type: string;
+	size: number;
+	author: string;
+	ts: number;
+}
+
 export interface Incident {
 	key: string;
 	id: string;
@@ -14,6 +23,10 @@ export interface Incident {
 	last_seen: number;
 	params: Params;
 	notes: Note[] | null;
+	severity: string;
+	runbook_url: string;
+	team: string;
+	attachments: Attachment[] | null;
 }
 
 export interface Silence {
@@ -25,6 +38,11 @@ export interface Silence {
 	updated: number;
 	duration: string;
 	notes: Note[] | null;
+	severity: string;
+	runbook_url: string;
+	team: string;
+	attachments: Attachment[] | null;
+	pause_roller: boolean;
 }
 
 export interface RecentIncidentsResponse {
@@ -60,6 +78,30 @@ export interface AuditLog {
 	timestamp: number;
 }
 
+export interface Token {
+	key: string;
+	user: string;
+	description: string;
+	scopes: string[] | null;
+	created: number;
+	expires: number;
+}
+
+export interface CreateAPITokenRequest {
+	description: string;
+	scopes: string[] | null;
+	duration: string;
+}
+
+export interface CreateAPITokenResponse {
+	token: Token | null;
+	token_string: string;
+}
+
+export interface RevokeAPITokenRequest {
+	key: string;
+}
+
 export type Params = { [key: string]: string };
 
 export type ParamSet = { [key: string]: string[] };
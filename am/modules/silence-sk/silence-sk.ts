@@ -22,6 +22,26 @@
  *     }
  *   </pre>
  *
+ * @evt add-silence-attachment Sent when the user attaches a file to a silence.
+ *    The detail includes the file and the key of the silence.
+ *
+ *   <pre>
+ *     detail {
+ *       key: "12312123123",
+ *       file: File,
+ *     }
+ *   </pre>
+ *
+ * @evt del-silence-attachment Sent when the user deletes an attachment of a silence.
+ *    The detail includes the id of the attachment and the key of the silence.
+ *
+ *   <pre>
+ *     detail {
+ *       key: "12312123123",
+ *       id: "0c1c5e1e-...",
+ *     }
+ *   </pre>
+ *
  * @evt save-silence Sent when the user saves a silence.
  *    The detail is the silence.
  *
@@ -88,15 +108,23 @@
  */
 import { html, render, TemplateResult } from 'lit/html.js';
 import { define } from '../../../elements-sk/modules/define';
+import '../../../elements-sk/modules/checkbox-sk';
 import '../../../elements-sk/modules/icons/add-box-icon-sk';
 import '../../../elements-sk/modules/icons/delete-icon-sk';
 
 import { $$ } from '../../../infra-sk/modules/dom';
 import { diffDate } from '../../../infra-sk/modules/human';
 import { errorMessage } from '../../../elements-sk/modules/errorMessage';
-import { abbr, displaySilence, expiresIn, getDurationTillNextDay, displayNotes } from '../am';
+import {
+  abbr,
+  displaySilence,
+  expiresIn,
+  getDurationTillNextDay,
+  displayNotes,
+  displayAttachments,
+} from '../am';
 import * as paramset from '../paramset';
-import { Incident, ParamSet, Note, Silence } from '../json';
+import { Incident, ParamSet, Note, Silence, Attachment } from '../json';
 
 const BOT_CENTRIC_PARAMS = ['alertname', 'bot'];
 
@@ -113,7 +141,11 @@ export class State {
 
   notes: Note[] = [];
 
+  attachments: Attachment[] = [];
+
   active: boolean = false;
+
+  pause_roller: boolean = false;
 }
 
 export class SilenceSk extends HTMLElement {
@@ -126,7 +158,9 @@ export class SilenceSk extends HTMLElement {
     created: 0,
     user: '',
     notes: [],
+    attachments: [],
     active: false,
+    pause_roller: false,
   };
 
   private incidents: Incident[] = [];
@@ -144,6 +178,7 @@ export class SilenceSk extends HTMLElement {
       }></input><button class="param-btns" @click=${
         ele.tillNextShift
       }>Till next shift</button></td></th>
+      ${ele.pauseRollerRow()}
       <tr><th>Created</th><td title=${new Date(
         ele.state.created * 1000
       ).toLocaleString()}>${diffDate(ele.state.created * 1000)}</td></tr>
@@ -162,6 +197,10 @@ export class SilenceSk extends HTMLElement {
     <section class=addNote>
       ${ele.displayAddNote()}
     </section>
+    <section class=attachments>
+      ${displayAttachments(ele.state.attachments, ele.state.key, 'del-silence-attachment')}
+      ${ele.displayAddAttachment()}
+    </section>
     <section class=matches>
       <h1>Matches</h1>
       ${ele.displayMatches()}
@@ -258,6 +297,17 @@ export class SilenceSk extends HTMLElement {
       placeholder="Add description for the silence"></textarea>`;
   }
 
+  private displayAddAttachment(): TemplateResult {
+    // Only saved silences can have attachments.
+    if (!this.state.key) {
+      return html``;
+    }
+    return html`
+      <input type="file" />
+      <button @click=${this.addAttachment}>Attach</button>
+    `;
+  }
+
   private gotoIncident(incident: Incident): void {
     window.location.href = `/?alert_id=${incident.id}&tab=1`;
   }
@@ -302,6 +352,21 @@ export class SilenceSk extends HTMLElement {
     }
   }
 
+  // Only silences of roller alerts may pause the roller.
+  private pauseRollerRow(): TemplateResult | string {
+    if (!this.state.param_set.roller) {
+      return '';
+    }
+    return html`<tr><th>Roller:</th><td><checkbox-sk class="pause-roller"
+      ?checked=${this.state.pause_roller}
+      @change=${this.pauseRollerChange}
+      label="Pause the roller while this silence is active"></checkbox-sk></td></tr>`;
+  }
+
+  private pauseRollerChange(e: Event): void {
+    this.state.pause_roller = (e.target as HTMLInputElement).checked;
+  }
+
   private durationChange(e: Event): void {
     this.state.duration = (e.target as HTMLInputElement).value;
   }
@@ -424,6 +489,21 @@ export class SilenceSk extends HTMLElement {
     textarea.value = '';
   }
 
+  private addAttachment(): void {
+    const input = $$('.attachments input', this) as HTMLInputElement;
+    if (!input.files || input.files.length === 0) {
+      return;
+    }
+    const detail = {
+      key: this.state.key,
+      file: input.files[0],
+    };
+    this.dispatchEvent(
+      new CustomEvent('add-silence-attachment', { detail: detail, bubbles: true })
+    );
+    input.value = '';
+  }
+
   private _render(): void {
     render(SilenceSk.template(this), this, { host: this });
   }
@@ -6,6 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 	"cloud.google.com/go/datastore"
 	"cloud.google.com/go/storage"
 	"github.com/go-chi/chi/v5"
+	"go.skia.org/infra/am/go/alertclient"
 	"go.skia.org/infra/autoroll/go/codereview"
 	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/autoroll/go/config/conversion"
@@ -71,6 +73,7 @@ var hangOptions = []HangOption{hangNone, hangImmediately, hangBeforeRollerCreati
 
 // flags
 var (
+	alertManagerServer     = flag.String("alert_manager_server", "", "If set, the address of the alert-manager internal server, eg. \"alert-manager:9000\". The roller stops itself while a silence there asks it to pause.")
 	configContents         = flag.String("config", "", "Base 64 encoded configuration in JSON format, mutually exclusive with --config_file.")
 	configFile             = flag.String("config_file", "", "Configuration file to use, mutually exclusive with --config.")
 	firestoreInstance      = flag.String("firestore_instance", "", "Firestore instance to use, eg. \"production\"")
@@ -102,6 +105,14 @@ func clientConfig(ts oauth2.TokenSource) httputils.ClientConfig {
 	return c
 }
 
+// rollerServerURL returns the URL of the status page for the given roller.
+func rollerServerURL(cfg *config.Config) string {
+	if cfg.IsInternal {
+		return roller.AutorollURLPrivate + "/r/" + cfg.RollerName
+	}
+	return roller.AutorollURLPublic + "/r/" + cfg.RollerName
+}
+
 func main() {
 	common.InitWithMust(
 		"autoroll-be",
@@ -196,6 +207,7 @@ func main() {
 	var emailer emailclient.Client
 	var chatBotConfigReader chatbot.ConfigReader
 	var gcsClient gcs.GCSClient
+	var configDB db.DB
 	rollerName := cfg.RollerName
 	if *local {
 		hostname, err := os.Hostname()
@@ -229,7 +241,7 @@ func main() {
 		}
 
 		// Update the roller config in the DB.
-		configDB, err := db.NewDBWithParams(ctx, firestore.FIRESTORE_PROJECT, namespace, *firestoreInstance, ts)
+		configDB, err = db.NewDBWithParams(ctx, firestore.FIRESTORE_PROJECT, namespace, *firestoreInstance, ts)
 		if err != nil {
 			sklog.Fatal(err)
 		}
@@ -238,10 +250,7 @@ func main() {
 		}
 	}
 
-	serverURL := roller.AutorollURLPublic + "/r/" + cfg.RollerName
-	if cfg.IsInternal {
-		serverURL = roller.AutorollURLPrivate + "/r/" + cfg.RollerName
-	}
+	serverURL := rollerServerURL(&cfg)
 
 	// TODO(borenet/rmistry): Create a code review sub-config as described in
 	// https://skia-review.googlesource.com/c/buildbot/+/116980/6/autoroll/go/autoroll/main.go#261
@@ -366,13 +375,57 @@ func main() {
 		sklog.Fatal(err)
 	}
 
+	// Create a roller for each additional parent branch. These share the Child
+	// with the main roller but upload their own roll CLs and track their own
+	// status.
+	branchRollers := map[string]*roller.AutoRoller{}
+	if len(cfg.ParentBranches) > 0 {
+		sharedChild, err := arb.ShareChild()
+		if err != nil {
+			sklog.Fatal(err)
+		}
+		for _, branch := range cfg.ParentBranches {
+			branchCfg, err := cfg.ForParentBranch(branch)
+			if err != nil {
+				sklog.Fatal(err)
+			}
+			if configDB != nil {
+				if err := configDB.Put(ctx, branchCfg.RollerName, branchCfg); err != nil {
+					sklog.Fatal(err)
+				}
+			}
+			branchRollerName := config.BranchRollerName(rollerName, branch)
+			branchWorkdir := filepath.Join(*workdir, "branches", branchRollerName)
+			if _, err := fileutil.EnsureDirExists(branchWorkdir); err != nil {
+				sklog.Fatal(err)
+			}
+			sklog.Infof("Creating roller %s for parent branch %s", branchRollerName, branch)
+			branchArb, err := roller.NewAutoRollerWithSharedChild(ctx, branchCfg, emailer, chatBotConfigReader, g, githubClient, branchWorkdir, rollerServerURL(branchCfg), gcsClient, client, branchRollerName, *local, statusDB, manualRolls, rollerCleanup, sharedChild)
+			if err != nil {
+				sklog.Fatalf("Failed to create roller for parent branch %s: %s", branch, err)
+			}
+			branchRollers[branchRollerName] = branchArb
+		}
+	}
+
+	if *alertManagerServer != "" {
+		alertClient := alertclient.New(httputils.DefaultClientConfig().Client(), *alertManagerServer)
+		arb.SetAlertClient(alertClient)
+		for _, branchArb := range branchRollers {
+			branchArb.SetAlertClient(alertClient)
+		}
+	}
+
 	if HangOption(*hang) == hangBeforeRunning {
 		sklog.Infof("--hang provided; doing nothing.")
 		httputils.RunHealthCheckServer(*port)
 	}
 
-	// Start the roller.
+	// Start the roller(s).
 	arb.Start(ctx, time.Minute /* tickFrequency */)
+	for _, branchArb := range branchRollers {
+		branchArb.Start(ctx, time.Minute /* tickFrequency */)
+	}
 
 	if g != nil {
 		// Periodically delete old roll CLs.
@@ -468,5 +521,17 @@ func main() {
 			}()
 		}
 	}
-	httputils.RunHealthCheckServer(*port)
+
+	// Serve the roller's HTTP handlers, eg. /json/health.
+	r := chi.NewRouter()
+	arb.AddHandlers(r)
+	for branchRollerName, branchArb := range branchRollers {
+		r.Route("/branch/"+branchRollerName, branchArb.AddHandlers)
+	}
+	h := httputils.LoggingGzipRequestResponse(r)
+	if !*local {
+		h = httputils.HealthzAndHTTPS(h)
+	}
+	http.Handle("/", h)
+	sklog.Fatal(http.ListenAndServe(*port, nil))
 }
@@ -354,6 +354,12 @@ func submitConfigUpdate(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, redirectURL, http.StatusFound)
 }
 
+// rollersJSONV1Handler serves the versioned JSON API used by external
+// dashboards.
+func rollersJSONV1Handler(w http.ResponseWriter, r *http.Request) {
+	srv.RollersJSONV1Handler(w, r)
+}
+
 // addCorsMiddleware wraps the specified HTTP handler with a handler that applies the
 // CORS specification on the request, and adds relevant CORS headers as necessary.
 // This is needed for some handlers that do not have this middleware. Eg: the twirp
@@ -381,6 +387,7 @@ func runServer(ctx context.Context, serverURL string, srv http.Handler, plogin a
 		r.HandleFunc("/strategy-history", strategyHistoryHandler)
 	})
 	r.Handle(rpc.AutoRollServicePathPrefix+"*", addCorsMiddleware(srv))
+	r.Handle(rpc.RollersJSONV1Path, addCorsMiddleware(http.HandlerFunc(rollersJSONV1Handler)))
 	h := httputils.LoggingRequestResponse(r)
 	h = httputils.XFrameOptionsDeny(h)
 	if !*local {
@@ -15,6 +15,7 @@ import (
 	"go.skia.org/infra/autoroll/go/recent_rolls"
 	"go.skia.org/infra/autoroll/go/revision"
 	"go.skia.org/infra/autoroll/go/state_machine"
+	"go.skia.org/infra/autoroll/go/telemetry"
 	"go.skia.org/infra/go/autoroll"
 	"go.skia.org/infra/go/gerrit"
 	"go.skia.org/infra/go/github"
@@ -365,15 +366,21 @@ func (r *gerritRoll) RetryDryRun(ctx context.Context) error {
 // See documentation for state_machine.RollCLImpl interface.
 func (r *gerritRoll) Update(ctx context.Context) error {
 	alreadyClosed := r.IsClosed()
-	ci, err := r.retrieveRoll(ctx)
-	if err != nil {
+	var ci *gerrit.ChangeInfo
+	if err := telemetry.Track(ctx, telemetry.DependencyCodeReview, func(ctx context.Context) error {
+		var err error
+		ci, err = r.retrieveRoll(ctx)
+		return err
+	}); err != nil {
 		return err
 	}
 	r.ci = ci
 	if r.result != "" {
 		r.issue.Result = r.result
 	}
-	if err := r.recent.Update(ctx, r.issue); err != nil {
+	if err := telemetry.Track(ctx, telemetry.DependencyDB, func(ctx context.Context) error {
+		return r.recent.Update(ctx, r.issue)
+	}); err != nil {
 		return err
 	}
 	if r.IsClosed() && !alreadyClosed && r.finishedCallback != nil {
@@ -608,15 +615,21 @@ func (r *githubRoll) withModify(ctx context.Context, action string, fn func() er
 // See documentation for state_machine.RollCLImpl interface.
 func (r *githubRoll) Update(ctx context.Context) error {
 	alreadyClosed := r.IsClosed()
-	pullRequest, err := r.retrieveRoll(ctx)
-	if err != nil {
+	var pullRequest *github_api.PullRequest
+	if err := telemetry.Track(ctx, telemetry.DependencyCodeReview, func(ctx context.Context) error {
+		var err error
+		pullRequest, err = r.retrieveRoll(ctx)
+		return err
+	}); err != nil {
 		return err
 	}
 	r.pullRequest = pullRequest
 	if r.result != "" {
 		r.issue.Result = r.result
 	}
-	if err := r.recent.Update(ctx, r.issue); err != nil {
+	if err := telemetry.Track(ctx, telemetry.DependencyDB, func(ctx context.Context) error {
+		return r.recent.Update(ctx, r.issue)
+	}); err != nil {
 		return err
 	}
 	if r.IsClosed() && !alreadyClosed && r.finishedCallback != nil {
@@ -7,6 +7,7 @@ package config
 //go:generate bazelisk run --config=mayberemote //:protoc -- --twirp_typescript_out=../../modules/config ./config.proto
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -14,8 +15,10 @@ import (
 	"go.skia.org/infra/autoroll/go/time_window"
 	"go.skia.org/infra/go/deepequal"
 	"go.skia.org/infra/go/deepequal/assertdeep"
+	"go.skia.org/infra/go/human"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/util"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -50,6 +53,10 @@ var (
 		"https://chromium-review.googlesource.com",
 		"https://chrome-internal-review.googlesource.com",
 	}
+	// reInvalidRollerNameChars matches characters which are not valid in roller
+	// names.
+	reInvalidRollerNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
 	// Valid Gerrit configs for Chromium projects.
 	validChromiumGerritConfigs = []GerritConfig_Config{
 		GerritConfig_CHROMIUM_BOT_COMMIT,
@@ -111,6 +118,14 @@ func (c NotifierConfig_MsgType) Validate() error {
 	return nil
 }
 
+// Validate implements util.Validator.
+func (c NotifierConfig_FailureClass) Validate() error {
+	if _, ok := NotifierConfig_FailureClass_name[int32(c)]; !ok {
+		return skerr.Fmt("Unknown NotifierConfig_FailureClass: %v", c)
+	}
+	return nil
+}
+
 // Validate implements util.Validator.
 func (c *Config) Validate() error {
 	if c.RollerName == "" {
@@ -262,6 +277,41 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for _, p := range c.Prerequisites {
+		if err := p.Validate(); err != nil {
+			return skerr.Wrapf(err, "prerequisite config failed validation")
+		}
+		if p.RollerName == c.RollerName {
+			return skerr.Fmt("roller %q cannot be a prerequisite of itself", c.RollerName)
+		}
+	}
+
+	if c.NoRollMarker != nil {
+		if err := c.NoRollMarker.Validate(); err != nil {
+			return skerr.Wrapf(err, "no-roll marker config failed validation")
+		}
+	}
+
+	if len(c.ParentBranches) > 0 {
+		if c.GetParentChildRepoManager() == nil {
+			return skerr.Fmt("ParentBranches is only supported for ParentChildRepoManager.")
+		}
+		branchRollerNames := make(map[string]bool, len(c.ParentBranches))
+		for _, branch := range c.ParentBranches {
+			if branch == "" {
+				return skerr.Fmt("ParentBranches may not contain empty branch names.")
+			}
+			name := BranchRollerName(c.RollerName, branch)
+			if len(name) > MaxRollerNameLength || !ValidK8sLabel.MatchString(name) {
+				return skerr.Fmt("roller name %q for parent branch %q is invalid; it may be at most %d characters", name, branch, MaxRollerNameLength)
+			}
+			if branchRollerNames[name] {
+				return skerr.Fmt("parent branch %q results in duplicate roller name %q", branch, name)
+			}
+			branchRollerNames[name] = true
+		}
+	}
+
 	if len(c.TransitiveDeps) != len(parentTransitiveDeps) {
 		return skerr.Fmt("top level transitive dependency count %d does not match transitive dependency count %d set on parent", len(c.TransitiveDeps), len(parentTransitiveDeps))
 	}
@@ -306,6 +356,32 @@ func (c *Config) GetRepoManagerConfig() RepoManagerConfig {
 	return nil
 }
 
+// BranchRollerName returns the name of the roller which rolls into the given
+// branch of the parent repo on behalf of the roller with the given name.
+func BranchRollerName(rollerName, branch string) string {
+	branch = strings.TrimPrefix(branch, "refs/heads/")
+	branch = reInvalidRollerNameChars.ReplaceAllString(strings.ToLower(branch), "-")
+	return rollerName + "-" + strings.Trim(branch, "-")
+}
+
+// ForParentBranch returns a copy of the Config which rolls into the given
+// branch of the parent repo, for use by the roller for that branch. See
+// ParentBranches.
+func (c *Config) ForParentBranch(branch string) (*Config, error) {
+	rv := proto.Clone(c).(*Config)
+	pc := rv.GetParentChildRepoManager()
+	if pc == nil {
+		return nil, skerr.Fmt("ParentBranches is only supported for ParentChildRepoManager.")
+	}
+	if err := pc.setParentBranch(branch); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	rv.RollerName = BranchRollerName(c.RollerName, branch)
+	rv.ParentDisplayName = fmt.Sprintf("%s (%s)", c.ParentDisplayName, branch)
+	rv.ParentBranches = nil
+	return rv, nil
+}
+
 // ValidStrategies returns the valid strategies for this roller.
 func (c *Config) ValidStrategies() []string {
 	return c.GetRepoManagerConfig().ValidStrategies()
@@ -392,6 +468,11 @@ func (c *NotifierConfig) Validate() error {
 	if len(c.MsgType) != 0 && c.LogLevel != 0 {
 		return skerr.Fmt("LogLevel and MsgType are mutually exclusive")
 	}
+	for _, failureClass := range c.FailureClass {
+		if err := failureClass.Validate(); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
 	cfg := []util.Validator{}
 	if c.GetChat() != nil {
 		cfg = append(cfg, c.GetChat())
@@ -457,6 +538,35 @@ func (c *ThrottleConfig) Validate() error {
 	return nil
 }
 
+// Validate implements util.Validator.
+func (c *RollerPrerequisiteConfig) Validate() error {
+	if c.RollerName == "" {
+		return skerr.Fmt("RollerName is required.")
+	}
+	if c.MaxDelay != "" {
+		if _, err := human.ParseDuration(c.MaxDelay); err != nil {
+			return skerr.Wrapf(err, "MaxDelay is invalid")
+		}
+	}
+	return nil
+}
+
+// Validate implements util.Validator.
+func (c *NoRollMarkerConfig) Validate() error {
+	if c.GitilesRepo == "" {
+		return skerr.Fmt("GitilesRepo is required.")
+	}
+	if c.Path == "" {
+		return skerr.Fmt("Path is required.")
+	}
+	if c.ContentRegex != "" {
+		if _, err := regexp.Compile(c.ContentRegex); err != nil {
+			return skerr.Wrapf(err, "ContentRegex is invalid")
+		}
+	}
+	return nil
+}
+
 // Validate implements util.Validator.
 func (c *TransitiveDepConfig) Validate() error {
 	if c.Child == nil {
@@ -568,6 +678,7 @@ func (c *AndroidRepoManagerConfig) ValidStrategies() []string {
 	return []string{
 		strategy.ROLL_STRATEGY_BATCH,
 		strategy.ROLL_STRATEGY_N_BATCH,
+		strategy.ROLL_STRATEGY_BISECT,
 	}
 }
 
@@ -705,6 +816,9 @@ func (c *ParentChildRepoManagerConfig) Validate() error {
 	if c.GetDockerChild() != nil {
 		children = append(children, c.GetDockerChild())
 	}
+	if c.GetCipdGroupChild() != nil {
+		children = append(children, c.GetCipdGroupChild())
+	}
 	if len(children) != 1 {
 		return skerr.Fmt("Exactly one Child is required, config has %d.", len(children))
 	}
@@ -782,11 +896,36 @@ func (c *ParentChildRepoManagerConfig) NoCheckout() bool {
 	return true
 }
 
+// setParentBranch changes the branch of the parent repo to roll into.
+func (c *ParentChildRepoManagerConfig) setParentBranch(branch string) error {
+	if p := c.GetCopyParent(); p != nil {
+		p.Gitiles.Gitiles.Branch = branch
+	} else if p := c.GetDepsLocalGithubParent(); p != nil {
+		p.DepsLocal.GitCheckout.GitCheckout.Branch = branch
+	} else if p := c.GetDepsLocalGerritParent(); p != nil {
+		p.DepsLocal.GitCheckout.GitCheckout.Branch = branch
+	} else if p := c.GetGitCheckoutGithubFileParent(); p != nil {
+		p.GitCheckout.GitCheckout.GitCheckout.Branch = branch
+	} else if p := c.GetGitilesParent(); p != nil {
+		p.Gitiles.Branch = branch
+	} else if p := c.GetGoModGerritParent(); p != nil {
+		p.GoMod.GitCheckout.Branch = branch
+	} else if p := c.GetGitCheckoutGerritParent(); p != nil {
+		p.GitCheckout.GitCheckout.Branch = branch
+	} else {
+		return skerr.Fmt("unknown parent type")
+	}
+	return nil
+}
+
 // ValidStrategies implements RepoManagerConfig.
 func (c *ParentChildRepoManagerConfig) ValidStrategies() []string {
 	if c.GetCipdChild() != nil {
 		return []string{strategy.ROLL_STRATEGY_BATCH}
 	}
+	if c.GetCipdGroupChild() != nil {
+		return []string{strategy.ROLL_STRATEGY_BATCH}
+	}
 	if c.GetFuchsiaSdkChild() != nil {
 		return []string{strategy.ROLL_STRATEGY_BATCH}
 	}
@@ -794,6 +933,7 @@ func (c *ParentChildRepoManagerConfig) ValidStrategies() []string {
 		strategy.ROLL_STRATEGY_BATCH,
 		strategy.ROLL_STRATEGY_N_BATCH,
 		strategy.ROLL_STRATEGY_SINGLE,
+		strategy.ROLL_STRATEGY_BISECT,
 	}
 }
 
@@ -976,6 +1116,28 @@ func (c *CIPDChildConfig) Validate() error {
 	return nil
 }
 
+// Validate implements util.Validator.
+func (c *CIPDGroupChildConfig) Validate() error {
+	if len(c.Names) < 2 {
+		return skerr.Fmt("At least two Names are required.")
+	}
+	for idx, name := range c.Names {
+		if name == "" {
+			return skerr.Fmt("Names must not be empty.")
+		}
+		if util.Index(name, c.Names) != idx {
+			return skerr.Fmt("Names must not contain duplicates; found %q more than once.", name)
+		}
+	}
+	if c.Tag == "" {
+		return skerr.Fmt("Tag is required.")
+	}
+	if c.RevisionIdTag == "" {
+		return skerr.Fmt("RevisionIdTag is required.")
+	}
+	return nil
+}
+
 // Validate implements util.Validator.
 func (c *GitCheckoutChildConfig) Validate() error {
 	if c.GitCheckout == nil {
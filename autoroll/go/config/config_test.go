@@ -163,3 +163,144 @@ func TestValidation_RollerName(t *testing.T) {
 	})
 
 }
+
+func TestValidation_Prerequisites(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.Prerequisites = []*RollerPrerequisiteConfig{
+			{RollerName: "a-into-b"},
+			{RollerName: "c-into-b", MaxDelay: "6h"},
+		}
+		require.NoError(t, cfg.Validate())
+	})
+	t.Run("missing roller name", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.Prerequisites = []*RollerPrerequisiteConfig{{MaxDelay: "6h"}}
+		require.ErrorContains(t, cfg.Validate(), "RollerName is required")
+	})
+	t.Run("invalid max delay", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.Prerequisites = []*RollerPrerequisiteConfig{{RollerName: "a-into-b", MaxDelay: "soon"}}
+		require.ErrorContains(t, cfg.Validate(), "MaxDelay is invalid")
+	})
+	t.Run("prerequisite of itself", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.Prerequisites = []*RollerPrerequisiteConfig{{RollerName: cfg.RollerName}}
+		require.ErrorContains(t, cfg.Validate(), "cannot be a prerequisite of itself")
+	})
+}
+
+func TestValidation_ParentBranches(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.ParentBranches = []string{"refs/heads/release/m120", "chromium/6099"}
+		require.NoError(t, cfg.Validate())
+	})
+	t.Run("empty branch", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.ParentBranches = []string{""}
+		require.ErrorContains(t, cfg.Validate(), "empty branch names")
+	})
+	t.Run("duplicate roller name", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.ParentBranches = []string{"release/m120", "release-m120"}
+		require.ErrorContains(t, cfg.Validate(), "duplicate roller name")
+	})
+	t.Run("roller name too long", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.ParentBranches = []string{"a-very-long-release-branch-name-for-testing"}
+		require.ErrorContains(t, cfg.Validate(), "may be at most")
+	})
+	t.Run("unsupported repo manager", func(t *testing.T) {
+		cfg := makeConfig()
+		cmd := &CommandRepoManagerConfig_CommandConfig{Command: []string{"true"}}
+		cfg.RepoManager = &Config_CommandRepoManager{
+			CommandRepoManager: &CommandRepoManagerConfig{
+				GitCheckout: &GitCheckoutConfig{
+					Branch:  "main",
+					RepoUrl: "https://parent.repo.git",
+				},
+				GetTipRev:    cmd,
+				GetPinnedRev: cmd,
+				SetPinnedRev: cmd,
+			},
+		}
+		cfg.ParentBranches = []string{"release"}
+		require.ErrorContains(t, cfg.Validate(), "only supported for ParentChildRepoManager")
+	})
+}
+
+func TestForParentBranch(t *testing.T) {
+	cfg := makeConfig()
+	cfg.ParentBranches = []string{"refs/heads/release/M120"}
+	branchCfg, err := cfg.ForParentBranch(cfg.ParentBranches[0])
+	require.NoError(t, err)
+	require.Equal(t, "test-release-m120", branchCfg.RollerName)
+	require.Equal(t, "test (refs/heads/release/M120)", branchCfg.ParentDisplayName)
+	require.Empty(t, branchCfg.ParentBranches)
+	require.Equal(t, "refs/heads/release/M120", branchCfg.GetParentChildRepoManager().GetDepsLocalGerritParent().DepsLocal.GitCheckout.GitCheckout.Branch)
+	require.NoError(t, branchCfg.Validate())
+
+	// The original config is unchanged.
+	require.Equal(t, "test", cfg.RollerName)
+	require.Equal(t, "main", cfg.GetParentChildRepoManager().GetDepsLocalGerritParent().DepsLocal.GitCheckout.GitCheckout.Branch)
+}
+
+func TestValidation_NoRollMarker(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.NoRollMarker = &NoRollMarkerConfig{
+			GitilesRepo:  "https://skia.googlesource.com/skia.git",
+			Path:         "infra/config/roll.json",
+			ContentRegex: `"paused":\s*true`,
+		}
+		require.NoError(t, cfg.Validate())
+	})
+	t.Run("missing repo", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.NoRollMarker = &NoRollMarkerConfig{Path: "NOROLL"}
+		require.ErrorContains(t, cfg.Validate(), "GitilesRepo is required")
+	})
+	t.Run("missing path", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.NoRollMarker = &NoRollMarkerConfig{GitilesRepo: "https://skia.googlesource.com/skia.git"}
+		require.ErrorContains(t, cfg.Validate(), "Path is required")
+	})
+	t.Run("invalid content regex", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.NoRollMarker = &NoRollMarkerConfig{
+			GitilesRepo:  "https://skia.googlesource.com/skia.git",
+			Path:         "NOROLL",
+			ContentRegex: "(",
+		}
+		require.ErrorContains(t, cfg.Validate(), "ContentRegex is invalid")
+	})
+}
+
+func TestValidation_CIPDGroupChild(t *testing.T) {
+	makeChild := func() *CIPDGroupChildConfig {
+		return &CIPDGroupChildConfig{
+			Names:         []string{"some/package/linux-amd64", "some/package/mac-amd64"},
+			Tag:           "latest",
+			RevisionIdTag: "version",
+		}
+	}
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, makeChild().Validate())
+	})
+	t.Run("single package", func(t *testing.T) {
+		c := makeChild()
+		c.Names = c.Names[:1]
+		require.ErrorContains(t, c.Validate(), "At least two Names are required")
+	})
+	t.Run("duplicate package", func(t *testing.T) {
+		c := makeChild()
+		c.Names = append(c.Names, c.Names[0])
+		require.ErrorContains(t, c.Validate(), "must not contain duplicates")
+	})
+	t.Run("missing revision ID tag", func(t *testing.T) {
+		c := makeChild()
+		c.RevisionIdTag = ""
+		require.ErrorContains(t, c.Validate(), "RevisionIdTag is required")
+	})
+}
@@ -0,0 +1,107 @@
+// Package noroll allows the owners of a repo, typically the parent repo, to
+// pause a roller by landing a marker file (eg. NOROLL) in their repo, without
+// needing access to the roller itself.
+package noroll
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/go/git"
+	"go.skia.org/infra/go/gitiles"
+	"go.skia.org/infra/go/skerr"
+)
+
+// maxReasonLength is the maximum number of characters of the marker file which
+// are included in the reason for pausing the roller.
+const maxReasonLength = 200
+
+// Checker determines whether the no-roll marker is present.
+type Checker struct {
+	repo         gitiles.GitilesRepo
+	branch       string
+	path         string
+	contentRegex *regexp.Regexp
+}
+
+// New returns a Checker for the given marker config. It returns nil if cfg is
+// nil; a nil Checker never reports the marker as present.
+func New(cfg *config.NoRollMarkerConfig, client *http.Client) (*Checker, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	return NewWithRepo(cfg, gitiles.NewRepo(cfg.GitilesRepo, client))
+}
+
+// NewWithRepo returns a Checker which uses the given GitilesRepo to look for
+// the marker described by cfg.
+func NewWithRepo(cfg *config.NoRollMarkerConfig, repo gitiles.GitilesRepo) (*Checker, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	c := &Checker{
+		repo:   repo,
+		branch: cfg.Branch,
+		path:   strings.Trim(cfg.Path, "/"),
+	}
+	if c.branch == "" {
+		c.branch = git.MainBranch
+	}
+	if cfg.ContentRegex != "" {
+		re, err := regexp.Compile(cfg.ContentRegex)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "invalid content_regex")
+		}
+		c.contentRegex = re
+	}
+	return c, nil
+}
+
+// Check returns a human-readable reason why the roller is paused if the marker
+// is present, or the empty string if it is not.
+func (c *Checker) Check(ctx context.Context) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	// Gitiles doesn't let us distinguish a missing file from other errors, so
+	// look for the file in its directory first.
+	dir, name := path.Split(c.path)
+	entries, err := c.repo.ListDirAtRef(ctx, dir, c.branch)
+	if err != nil {
+		return "", skerr.Wrapf(err, "listing %q at %s", dir, c.branch)
+	}
+	found := false
+	for _, fi := range entries {
+		if fi.Name() == name && !fi.IsDir() {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", nil
+	}
+	contents, err := c.repo.ReadFileAtRef(ctx, c.path, c.branch)
+	if err != nil {
+		return "", skerr.Wrapf(err, "reading %q at %s", c.path, c.branch)
+	}
+	if c.contentRegex != nil {
+		match := c.contentRegex.Find(contents)
+		if match == nil {
+			return "", nil
+		}
+		return fmt.Sprintf("%s on %s contains %q", c.path, c.branch, string(match)), nil
+	}
+	reason := fmt.Sprintf("%s exists on %s", c.path, c.branch)
+	if msg := strings.TrimSpace(string(contents)); msg != "" {
+		if len(msg) > maxReasonLength {
+			msg = msg[:maxReasonLength] + "..."
+		}
+		reason += ": " + msg
+	}
+	return reason, nil
+}
@@ -0,0 +1,96 @@
+package noroll
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/go/git"
+	"go.skia.org/infra/go/gitiles/mocks"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/go/vfs"
+)
+
+const repoURL = "https://fake.googlesource.com/parent.git"
+
+func file(name string) fs.FileInfo {
+	return vfs.FileInfo{Name: name}.Get()
+}
+
+func setup(t *testing.T, cfg *config.NoRollMarkerConfig) (*Checker, *mocks.GitilesRepo) {
+	repo := &mocks.GitilesRepo{}
+	t.Cleanup(func() {
+		repo.AssertExpectations(t)
+	})
+	c, err := NewWithRepo(cfg, repo)
+	require.NoError(t, err)
+	return c, repo
+}
+
+func TestCheck_NoMarker_NotPaused(t *testing.T) {
+	c, repo := setup(t, &config.NoRollMarkerConfig{
+		GitilesRepo: repoURL,
+		Path:        "NOROLL",
+	})
+	repo.On("ListDirAtRef", testutils.AnyContext, "", git.MainBranch).Return([]fs.FileInfo{file("DEPS"), file("README.md")}, nil)
+
+	reason, err := c.Check(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, reason)
+}
+
+func TestCheck_MarkerExists_PausedWithContents(t *testing.T) {
+	c, repo := setup(t, &config.NoRollMarkerConfig{
+		GitilesRepo: repoURL,
+		Branch:      "release",
+		Path:        "infra/NOROLL",
+	})
+	repo.On("ListDirAtRef", testutils.AnyContext, "infra/", "release").Return([]fs.FileInfo{file("NOROLL")}, nil)
+	repo.On("ReadFileAtRef", testutils.AnyContext, "infra/NOROLL", "release").Return([]byte("Release freeze until Monday.\n"), nil)
+
+	reason, err := c.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "infra/NOROLL exists on release: Release freeze until Monday.", reason)
+}
+
+func TestCheck_ContentRegex_PausedOnlyIfMatched(t *testing.T) {
+	c, repo := setup(t, &config.NoRollMarkerConfig{
+		GitilesRepo:  repoURL,
+		Path:         "roll.json",
+		ContentRegex: `"paused":\s*true`,
+	})
+	repo.On("ListDirAtRef", testutils.AnyContext, "", git.MainBranch).Return([]fs.FileInfo{file("roll.json")}, nil)
+	read := repo.On("ReadFileAtRef", testutils.AnyContext, "roll.json", git.MainBranch).Return([]byte(`{"paused": false}`), nil)
+
+	reason, err := c.Check(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, reason)
+
+	read.Return([]byte(`{"paused": true}`), nil)
+	reason, err = c.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `roll.json on main contains "\"paused\": true"`, reason)
+}
+
+func TestCheck_ListFails_ReturnsError(t *testing.T) {
+	c, repo := setup(t, &config.NoRollMarkerConfig{
+		GitilesRepo: repoURL,
+		Path:        "NOROLL",
+	})
+	repo.On("ListDirAtRef", testutils.AnyContext, "", git.MainBranch).Return(nil, errors.New("gitiles is down"))
+
+	_, err := c.Check(context.Background())
+	require.ErrorContains(t, err, "gitiles is down")
+}
+
+func TestCheck_NilChecker_NotPaused(t *testing.T) {
+	c, err := New(nil, nil)
+	require.NoError(t, err)
+	reason, err := c.Check(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, reason)
+}
@@ -9,6 +9,7 @@ import (
 
 	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/email/go/emailclient"
+	"go.skia.org/infra/go/autoroll"
 	"go.skia.org/infra/go/chatbot"
 	"go.skia.org/infra/go/notifier"
 	"go.skia.org/infra/go/skerr"
@@ -122,6 +123,15 @@ var (
 		MSG_TYPE_SUCCESS_THROTTLE:            config.NotifierConfig_SUCCESS_THROTTLE,
 	}
 
+	protoToFailureClass = map[config.NotifierConfig_FailureClass]string{
+		config.NotifierConfig_INFRA: autoroll.FAILURE_CLASS_INFRA,
+		config.NotifierConfig_TEST:  autoroll.FAILURE_CLASS_TEST,
+	}
+	failureClassToProto = map[string]config.NotifierConfig_FailureClass{
+		autoroll.FAILURE_CLASS_INFRA: config.NotifierConfig_INFRA,
+		autoroll.FAILURE_CLASS_TEST:  config.NotifierConfig_TEST,
+	}
+
 	// Note that these really belong in the go/notifier package, but it doesn't
 	// really make sense for that package to import the AutoRoller's config
 	// package.  These values must be kept in sync with those from go/notifier.
@@ -145,6 +155,7 @@ var (
 // text templates in the Subject and Body fields of messages.
 type tmplVars struct {
 	ChildName      string
+	FailureClass   string
 	IssueID        string
 	IssueURL       string
 	Mode           string
@@ -226,6 +237,7 @@ func (a *AutoRollNotifier) send(ctx context.Context, vars *tmplVars, subjectTmpl
 		Severity:        severity,
 		Type:            msgType,
 		ExtraRecipients: extraRecipients,
+		FailureClass:    vars.FailureClass,
 	}); err != nil {
 		// We don't want to block the roller on failure to send
 		// notifications. Log the error and move on.
@@ -249,9 +261,10 @@ func (a *AutoRollNotifier) SendIssueUpdate(ctx context.Context, id, url, msg str
 // Send a notification that creation of a manual roll failed.
 func (a *AutoRollNotifier) SendManualRollCreationFailed(ctx context.Context, requester, revision string, err error) {
 	a.send(ctx, &tmplVars{
-		Message:  err.Error(),
-		Revision: revision,
-		User:     requester,
+		FailureClass: autoroll.FAILURE_CLASS_INFRA,
+		Message:      err.Error(),
+		Revision:     revision,
+		User:         requester,
 	}, subjectTmplManualRollCreationFailed, bodyTmplManualRollCreationFailed, notifier.SEVERITY_ERROR, MSG_TYPE_MANUAL_ROLL_CREATION_FAILED, []string{requester})
 }
 
@@ -267,7 +280,8 @@ func (a *AutoRollNotifier) SendModeChange(ctx context.Context, user, mode, messa
 // Send a notification that creation of a roll failed.
 func (a *AutoRollNotifier) SendRollCreationFailed(ctx context.Context, err error) {
 	a.send(ctx, &tmplVars{
-		Message: err.Error(),
+		FailureClass: autoroll.FAILURE_CLASS_INFRA,
+		Message:      err.Error(),
 	}, subjectTmplRollCreationFailed, bodyTmplRollCreationFailed, notifier.SEVERITY_ERROR, MSG_TYPE_ROLL_CREATION_FAILED, nil)
 }
 
@@ -304,19 +318,23 @@ func (a *AutoRollNotifier) SendNewSuccess(ctx context.Context, id, url string) {
 }
 
 // Send a notification that the most recent roll failed when the roll before
-// it succeeded.
-func (a *AutoRollNotifier) SendNewFailure(ctx context.Context, id, url string) {
+// it succeeded. The failureClass is one of the autoroll.FAILURE_CLASS_*
+// constants.
+func (a *AutoRollNotifier) SendNewFailure(ctx context.Context, id, url, failureClass string) {
 	a.send(ctx, &tmplVars{
-		IssueID:  id,
-		IssueURL: url,
+		FailureClass: failureClass,
+		IssueID:      id,
+		IssueURL:     url,
 	}, subjectTmplNewFailure, bodyTmplNewFailure, notifier.SEVERITY_WARNING, MSG_TYPE_NEW_FAILURE, nil)
 }
 
-// Send a notification that the last N roll attempts have failed.
-func (a *AutoRollNotifier) SendLastNFailed(ctx context.Context, n int, url string) {
+// Send a notification that the last N roll attempts have failed. The
+// failureClass is that of the most recent failure.
+func (a *AutoRollNotifier) SendLastNFailed(ctx context.Context, n int, url, failureClass string) {
 	a.send(ctx, &tmplVars{
-		IssueURL: url,
-		N:        n,
+		FailureClass: failureClass,
+		IssueURL:     url,
+		N:            n,
 	}, subjectTmplLastNFailed, bodyTmplLastNFailed, notifier.SEVERITY_ERROR, MSG_TYPE_LAST_N_FAILED, nil)
 }
 
@@ -334,6 +352,13 @@ func ConfigToProto(cfg *notifier.Config) (*config.NotifierConfig, error) {
 	rv := &config.NotifierConfig{
 		Subject: cfg.Subject,
 	}
+	for _, failureClass := range cfg.IncludeFailureClasses {
+		fc, ok := failureClassToProto[failureClass]
+		if !ok {
+			return nil, skerr.Fmt("unknown failure class %q", failureClass)
+		}
+		rv.FailureClass = append(rv.FailureClass, fc)
+	}
 
 	if cfg.Filter != "" {
 		filter, err := notifier.ParseFilter(cfg.Filter)
@@ -385,6 +410,9 @@ func ProtoToConfig(cfg *config.NotifierConfig) *notifier.Config {
 	rv := &notifier.Config{
 		Subject: cfg.Subject,
 	}
+	for _, failureClass := range cfg.FailureClass {
+		rv.IncludeFailureClasses = append(rv.IncludeFailureClasses, protoToFailureClass[failureClass])
+	}
 
 	if len(cfg.MsgType) > 0 {
 		for _, msgType := range cfg.MsgType {
@@ -2,12 +2,15 @@ package notifier
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/email/go/emailclient"
+	"go.skia.org/infra/go/autoroll"
 	"go.skia.org/infra/go/notifier"
 )
 
@@ -64,3 +67,39 @@ func TestNotifier(t *testing.T) {
 	require.Equal(t, notifier.SEVERITY_ERROR, t1.msgs[2].m.Severity)
 	require.Equal(t, 1, len(t2.msgs))
 }
+
+func TestNotifier_FailureClass(t *testing.T) {
+	ctx := context.Background()
+	n, err := New(ctx, "childRepo", "parentRepo", "https://autoroll.skia.org/r/test-roller", nil, emailclient.New(), nil, nil)
+	require.NoError(t, err)
+	t1 := &testNotifier{}
+	n.Router().Add(t1, notifier.FILTER_DEBUG, nil, "")
+
+	n.SendNewFailure(ctx, "123", "https://codereview/123", autoroll.FAILURE_CLASS_TEST)
+	n.SendLastNFailed(ctx, 3, "https://codereview/123", autoroll.FAILURE_CLASS_INFRA)
+	n.SendRollCreationFailed(ctx, errors.New("failed"))
+	n.SendModeChange(ctx, "test@skia.org", "STOPPED", "Stop")
+	require.Len(t, t1.msgs, 4)
+	require.Equal(t, autoroll.FAILURE_CLASS_TEST, t1.msgs[0].m.FailureClass)
+	require.Equal(t, autoroll.FAILURE_CLASS_INFRA, t1.msgs[1].m.FailureClass)
+	require.Equal(t, autoroll.FAILURE_CLASS_INFRA, t1.msgs[2].m.FailureClass)
+	require.Equal(t, "", t1.msgs[3].m.FailureClass)
+}
+
+func TestConfigToProto_FailureClass(t *testing.T) {
+	cfg := &notifier.Config{
+		Filter: "warning",
+		Chat: &notifier.ChatNotifierConfig{
+			RoomID: "infra-room",
+		},
+		IncludeFailureClasses: []string{autoroll.FAILURE_CLASS_INFRA},
+	}
+	proto, err := ConfigToProto(cfg)
+	require.NoError(t, err)
+	require.Equal(t, []config.NotifierConfig_FailureClass{config.NotifierConfig_INFRA}, proto.FailureClass)
+	require.Equal(t, cfg, ProtoToConfig(proto))
+
+	cfg.IncludeFailureClasses = []string{"bogus"}
+	_, err = ConfigToProto(cfg)
+	require.ErrorContains(t, err, `unknown failure class "bogus"`)
+}
@@ -0,0 +1,125 @@
+// Package prerequisites allows a roller to wait for the in-flight rolls of
+// other rollers to land before it uploads a roll of its own. For example, if A
+// rolls into B and B rolls into C, the B-into-C roller can declare the
+// A-into-B roller as a prerequisite so that C doesn't receive a version of B
+// which is missing a pending update to A. If the prerequisite names the
+// dependency which it rolls, the roller also waits until the revision it would
+// roll pins the prerequisite roller's last rolled revision.
+package prerequisites
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/autoroll/go/status"
+	"go.skia.org/infra/go/human"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+// prerequisite is a parsed config.RollerPrerequisiteConfig.
+type prerequisite struct {
+	rollerName string
+	// maxDelay is how long to wait for an in-flight roll of the prerequisite
+	// roller. Zero means wait indefinitely.
+	maxDelay time.Duration
+	// dependencyID is the ID of the dependency rolled by the prerequisite
+	// roller, as found in revision.Revision.Dependencies. Optional.
+	dependencyID string
+}
+
+// pendingRoll is a revision of a prerequisite roller which we're waiting for,
+// either because it's being rolled or because the revision we'd roll doesn't
+// include it yet.
+type pendingRoll struct {
+	rev   string
+	since time.Time
+}
+
+// Checker determines whether any prerequisite rollers have in-flight rolls,
+// using the status each roller writes to the status.DB.
+type Checker struct {
+	db      status.DB
+	prereqs []prerequisite
+
+	mtx sync.Mutex
+	// pending tracks when we started waiting for each prerequisite roller,
+	// keyed by roller name, so that we can enforce maxDelay.
+	pending map[string]pendingRoll
+}
+
+// New returns a Checker for the given prerequisites.
+func New(db status.DB, cfgs []*config.RollerPrerequisiteConfig) (*Checker, error) {
+	prereqs := make([]prerequisite, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		p := prerequisite{
+			rollerName:   cfg.RollerName,
+			dependencyID: cfg.DependencyId,
+		}
+		if cfg.MaxDelay != "" {
+			d, err := human.ParseDuration(cfg.MaxDelay)
+			if err != nil {
+				return nil, skerr.Wrapf(err, "invalid max_delay for prerequisite %q", cfg.RollerName)
+			}
+			p.maxDelay = d
+		}
+		prereqs = append(prereqs, p)
+	}
+	return &Checker{
+		db:      db,
+		prereqs: prereqs,
+		pending: map[string]pendingRoll{},
+	}, nil
+}
+
+// Blocking returns the names of the prerequisite rollers which we should wait
+// for before rolling to the given revision: those which have an in-flight
+// roll, and those whose last rolled revision isn't pinned by the given
+// revision. The latter is only checked for prerequisites with a dependency ID,
+// and only if the revision pins that dependency. Prerequisite rollers whose
+// status cannot be read are not considered to be blocking, so that a broken or
+// deleted roller doesn't stop this one indefinitely.
+func (c *Checker) Blocking(ctx context.Context, rollingTo *revision.Revision) []string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	var blocking []string
+	for _, p := range c.prereqs {
+		st, err := c.db.Get(ctx, p.rollerName)
+		if err != nil {
+			sklog.Errorf("Failed to read status of prerequisite roller %q; not waiting for it: %s", p.rollerName, err)
+			delete(c.pending, p.rollerName)
+			continue
+		}
+		waitingFor := ""
+		if st.CurrentRollRev != "" && st.CurrentRollRev != st.LastRollRev {
+			waitingFor = st.CurrentRollRev
+		} else if p.dependencyID != "" && rollingTo != nil && st.LastRollRev != "" {
+			if pinned, ok := rollingTo.Dependencies[p.dependencyID]; ok && pinned != st.LastRollRev {
+				sklog.Infof("%s pins %s at %s but prerequisite roller %q last rolled %s.", rollingTo.Id, p.dependencyID, pinned, p.rollerName, st.LastRollRev)
+				waitingFor = st.LastRollRev
+			}
+		}
+		if waitingFor == "" {
+			delete(c.pending, p.rollerName)
+			continue
+		}
+		pending, ok := c.pending[p.rollerName]
+		if !ok || pending.rev != waitingFor {
+			pending = pendingRoll{
+				rev:   waitingFor,
+				since: now.Now(ctx),
+			}
+			c.pending[p.rollerName] = pending
+		}
+		if p.maxDelay != 0 && now.Now(ctx).Sub(pending.since) >= p.maxDelay {
+			sklog.Warningf("Have been waiting for %s from prerequisite roller %q for longer than %s; no longer waiting for it.", pending.rev, p.rollerName, p.maxDelay)
+			continue
+		}
+		blocking = append(blocking, p.rollerName)
+	}
+	return blocking
+}
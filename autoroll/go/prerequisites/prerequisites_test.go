@@ -0,0 +1,146 @@
+package prerequisites
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/autoroll/go/status"
+	"go.skia.org/infra/autoroll/go/status/mocks"
+	"go.skia.org/infra/go/now"
+)
+
+const prereqRoller = "a-into-b"
+
+var startTime = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func statusForTest(currentRollRev, lastRollRev string) *status.AutoRollStatus {
+	return &status.AutoRollStatus{
+		AutoRollMiniStatus: status.AutoRollMiniStatus{
+			CurrentRollRev: currentRollRev,
+			LastRollRev:    lastRollRev,
+		},
+	}
+}
+
+func TestBlocking_NoPrerequisites_ReturnsEmpty(t *testing.T) {
+	c, err := New(mocks.NewDB(t), nil)
+	require.NoError(t, err)
+	require.Empty(t, c.Blocking(now.TimeTravelingContext(startTime), nil))
+}
+
+func TestBlocking_PrerequisiteIdle_ReturnsEmpty(t *testing.T) {
+	db := mocks.NewDB(t)
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("", "abc"), nil)
+	c, err := New(db, []*config.RollerPrerequisiteConfig{{RollerName: prereqRoller}})
+	require.NoError(t, err)
+	require.Empty(t, c.Blocking(now.TimeTravelingContext(startTime), nil))
+}
+
+func TestBlocking_PrerequisiteRollInFlight_ReturnsRollerUntilLanded(t *testing.T) {
+	db := mocks.NewDB(t)
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("def", "abc"), nil).Once()
+	c, err := New(db, []*config.RollerPrerequisiteConfig{{RollerName: prereqRoller}})
+	require.NoError(t, err)
+	ctx := now.TimeTravelingContext(startTime)
+	require.Equal(t, []string{prereqRoller}, c.Blocking(ctx, nil))
+
+	// Without a max delay, we wait indefinitely.
+	ctx.SetTime(startTime.Add(24 * time.Hour))
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("def", "abc"), nil).Once()
+	require.Equal(t, []string{prereqRoller}, c.Blocking(ctx, nil))
+
+	// The roll lands.
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("def", "def"), nil).Once()
+	require.Empty(t, c.Blocking(ctx, nil))
+}
+
+func TestBlocking_MaxDelayExceeded_NoLongerBlocks(t *testing.T) {
+	db := mocks.NewDB(t)
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("def", "abc"), nil).Twice()
+	c, err := New(db, []*config.RollerPrerequisiteConfig{{RollerName: prereqRoller, MaxDelay: "1h"}})
+	require.NoError(t, err)
+	ctx := now.TimeTravelingContext(startTime)
+	require.Equal(t, []string{prereqRoller}, c.Blocking(ctx, nil))
+	ctx.SetTime(startTime.Add(time.Hour))
+	require.Empty(t, c.Blocking(ctx, nil))
+
+	// A new roll of the prerequisite resets the delay.
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("ghi", "abc"), nil).Once()
+	require.Equal(t, []string{prereqRoller}, c.Blocking(ctx, nil))
+}
+
+func TestBlocking_StatusReadFails_DoesNotBlock(t *testing.T) {
+	db := mocks.NewDB(t)
+	db.On("Get", mock.Anything, prereqRoller).Return(nil, errors.New("no such roller"))
+	c, err := New(db, []*config.RollerPrerequisiteConfig{{RollerName: prereqRoller}})
+	require.NoError(t, err)
+	require.Empty(t, c.Blocking(now.TimeTravelingContext(startTime), nil))
+}
+
+func TestNew_InvalidMaxDelay_ReturnsError(t *testing.T) {
+	_, err := New(mocks.NewDB(t), []*config.RollerPrerequisiteConfig{{RollerName: prereqRoller, MaxDelay: "soon"}})
+	require.Error(t, err)
+}
+
+func revisionPinning(dependencyRev string) *revision.Revision {
+	return &revision.Revision{
+		Id: "b-rev",
+		Dependencies: map[string]string{
+			"a": dependencyRev,
+		},
+	}
+}
+
+func TestBlocking_RevisionPinsLastRollRev_ReturnsEmpty(t *testing.T) {
+	db := mocks.NewDB(t)
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("abc", "abc"), nil)
+	c, err := New(db, []*config.RollerPrerequisiteConfig{{RollerName: prereqRoller, DependencyId: "a"}})
+	require.NoError(t, err)
+	require.Empty(t, c.Blocking(now.TimeTravelingContext(startTime), revisionPinning("abc")))
+}
+
+func TestBlocking_RevisionDoesNotPinLastRollRev_ReturnsRollerUntilPinned(t *testing.T) {
+	db := mocks.NewDB(t)
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("def", "def"), nil)
+	c, err := New(db, []*config.RollerPrerequisiteConfig{{RollerName: prereqRoller, DependencyId: "a"}})
+	require.NoError(t, err)
+	ctx := now.TimeTravelingContext(startTime)
+	require.Equal(t, []string{prereqRoller}, c.Blocking(ctx, revisionPinning("abc")))
+
+	// Once a revision which includes the landed roll is available, we stop
+	// waiting.
+	require.Empty(t, c.Blocking(ctx, revisionPinning("def")))
+}
+
+func TestBlocking_RevisionDoesNotPinDependency_ReturnsEmpty(t *testing.T) {
+	db := mocks.NewDB(t)
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("def", "def"), nil)
+	c, err := New(db, []*config.RollerPrerequisiteConfig{{RollerName: prereqRoller, DependencyId: "a"}})
+	require.NoError(t, err)
+	require.Empty(t, c.Blocking(now.TimeTravelingContext(startTime), &revision.Revision{Id: "b-rev"}))
+}
+
+func TestBlocking_NoDependencyID_DoesNotCheckRevision(t *testing.T) {
+	db := mocks.NewDB(t)
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("def", "def"), nil)
+	c, err := New(db, []*config.RollerPrerequisiteConfig{{RollerName: prereqRoller}})
+	require.NoError(t, err)
+	require.Empty(t, c.Blocking(now.TimeTravelingContext(startTime), revisionPinning("abc")))
+}
+
+func TestBlocking_RevisionDoesNotPinLastRollRev_StopsWaitingAfterMaxDelay(t *testing.T) {
+	db := mocks.NewDB(t)
+	db.On("Get", mock.Anything, prereqRoller).Return(statusForTest("def", "def"), nil)
+	c, err := New(db, []*config.RollerPrerequisiteConfig{{RollerName: prereqRoller, DependencyId: "a", MaxDelay: "1h"}})
+	require.NoError(t, err)
+	ctx := now.TimeTravelingContext(startTime)
+	require.Equal(t, []string{prereqRoller}, c.Blocking(ctx, revisionPinning("abc")))
+
+	ctx.SetTime(startTime.Add(time.Hour))
+	require.Empty(t, c.Blocking(ctx, revisionPinning("abc")))
+}
@@ -30,3 +30,11 @@ type Child interface {
 	// Revision.
 	VFS(context.Context, *revision.Revision) (vfs.FS, error)
 }
+
+// RollValidator may be implemented by a Child which needs to verify that a
+// roll to the given Revision is still valid immediately before it is uploaded.
+type RollValidator interface {
+	// ValidateRoll returns an error if a roll to the given Revision should
+	// not be uploaded.
+	ValidateRoll(context.Context, *revision.Revision) error
+}
@@ -0,0 +1,215 @@
+package child
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	cipd_api "go.chromium.org/luci/cipd/client/cipd"
+	"go.chromium.org/luci/cipd/client/cipd/pkg"
+	"go.chromium.org/luci/cipd/common"
+
+	"go.skia.org/infra/autoroll/go/config"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/cipd"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/vfs"
+)
+
+// NewCIPDGroup returns an implementation of Child which deals with a group of
+// CIPD packages which are rolled together. If the caller calls
+// CIPDGroupChild.VFS, each package is installed into a subdirectory named
+// after the package.
+func NewCIPDGroup(ctx context.Context, c *config.CIPDGroupChildConfig, client *http.Client, workdir string) (*CIPDGroupChild, error) {
+	if err := c.Validate(); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	cipdClient, err := cipd.NewClient(client, workdir, cipd.DefaultServiceURL)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return &CIPDGroupChild{
+		client:                cipdClient,
+		names:                 c.Names,
+		root:                  workdir,
+		tag:                   c.Tag,
+		revisionIdTag:         c.RevisionIdTag,
+		revisionIdTagStripKey: c.RevisionIdTagStripKey,
+	}, nil
+}
+
+// CIPDGroupChild is an implementation of Child which deals with a group of CIPD
+// packages. Revisions are identified by a tag which must be present on exactly
+// one instance of each package; the instance IDs of the packages are stored in
+// the Dependencies of the Revision, keyed by package name.
+type CIPDGroupChild struct {
+	client                cipd.CIPDClient
+	names                 []string
+	root                  string
+	tag                   string
+	revisionIdTag         string
+	revisionIdTagStripKey bool
+}
+
+// revisionTag returns the full CIPD tag for the given revision ID.
+func (c *CIPDGroupChild) revisionTag(id string) string {
+	if c.revisionIdTagStripKey {
+		return joinCIPDTag(c.revisionIdTag, id)
+	}
+	return id
+}
+
+// findInstance returns the ID of the single instance of the given package
+// which has the given tag. It returns an empty string and the reason if there
+// isn't exactly one such instance.
+func (c *CIPDGroupChild) findInstance(ctx context.Context, name, tag string) (string, string, error) {
+	pins, err := c.client.SearchInstances(ctx, name, []string{tag})
+	if err != nil {
+		return "", "", skerr.Wrapf(err, "failed to search for instances of %q with tag %q", name, tag)
+	}
+	if len(pins) != 1 {
+		return "", fmt.Sprintf("Package %s has %d instances with tag %q; expected exactly one.", name, len(pins), tag), nil
+	}
+	return pins[0].InstanceID, "", nil
+}
+
+// groupRevision creates a revision.Revision from the given instance of the
+// primary package, finding the matching instances of the other packages. The
+// revision is invalid if they aren't consistent.
+func (c *CIPDGroupChild) groupRevision(ctx context.Context, instance *cipd_api.InstanceDescription) (*revision.Revision, error) {
+	rev, err := CIPDInstanceToRevision(c.names[0], instance, c.revisionIdTag, c.revisionIdTagStripKey)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	rev.Dependencies = map[string]string{
+		c.names[0]: instance.Pin.InstanceID,
+	}
+	if rev.InvalidReason != "" {
+		return rev, nil
+	}
+	tag := c.revisionTag(rev.Id)
+	for _, name := range c.names[1:] {
+		instanceID, invalidReason, err := c.findInstance(ctx, name, tag)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		if invalidReason != "" {
+			rev.InvalidReason = invalidReason
+			return rev, nil
+		}
+		rev.Dependencies[name] = instanceID
+	}
+	return rev, nil
+}
+
+// GetRevision implements Child.
+func (c *CIPDGroupChild) GetRevision(ctx context.Context, id string) (*revision.Revision, error) {
+	instanceID, invalidReason, err := c.findInstance(ctx, c.names[0], c.revisionTag(id))
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if invalidReason != "" {
+		return nil, skerr.Fmt("failed to retrieve revision ID %q: %s", id, invalidReason)
+	}
+	instance, err := c.client.Describe(ctx, c.names[0], instanceID, false)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return c.groupRevision(ctx, instance)
+}
+
+// LogRevisions implements Child.
+func (c *CIPDGroupChild) LogRevisions(ctx context.Context, from, to *revision.Revision) ([]*revision.Revision, error) {
+	revs := []*revision.Revision{}
+	if from.Id != to.Id {
+		revs = append(revs, to)
+	}
+	return revs, nil
+}
+
+// Update implements Child.
+// Note: that this just finds the newest version of the primary package.
+func (c *CIPDGroupChild) Update(ctx context.Context, lastRollRev *revision.Revision) (*revision.Revision, []*revision.Revision, error) {
+	head, err := c.client.ResolveVersion(ctx, c.names[0], c.tag)
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	instance, err := c.client.Describe(ctx, c.names[0], head.InstanceID, false)
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	tipRev, err := c.groupRevision(ctx, instance)
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	notRolledRevs, err := c.LogRevisions(ctx, lastRollRev, tipRev)
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	return tipRev, notRolledRevs, nil
+}
+
+// VFS implements the Child interface.
+func (c *CIPDGroupChild) VFS(ctx context.Context, rev *revision.Revision) (vfs.FS, error) {
+	fs, err := vfs.TempDir(ctx, c.root, "tmp")
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	dest, err := filepath.Rel(c.root, fs.Dir())
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	pins := common.PinSliceBySubdir{}
+	for _, name := range c.names {
+		instanceID, ok := rev.Dependencies[name]
+		if !ok {
+			return nil, skerr.Fmt("revision %q has no instance of package %q", rev.Id, name)
+		}
+		pins[filepath.Join(dest, name)] = []common.Pin{
+			{
+				PackageName: name,
+				InstanceID:  instanceID,
+			},
+		}
+	}
+	if _, err := c.client.EnsurePackages(ctx, pins, &cipd_api.EnsureOptions{
+		Paranoia:            cipd_api.CheckPresence,
+		DryRun:              false,
+		OverrideInstallMode: pkg.InstallModeCopy,
+	}); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return fs, nil
+}
+
+// ValidateRoll implements RollValidator. It verifies that the tag of the given
+// revision still identifies the same instance of every package in the group.
+func (c *CIPDGroupChild) ValidateRoll(ctx context.Context, to *revision.Revision) error {
+	if to.InvalidReason != "" {
+		return skerr.Fmt("revision %q is invalid: %s", to.Id, to.InvalidReason)
+	}
+	tag := c.revisionTag(to.Id)
+	for _, name := range c.names {
+		instanceID, invalidReason, err := c.findInstance(ctx, name, tag)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		if invalidReason != "" {
+			return skerr.Fmt("%s", invalidReason)
+		}
+		if expect := to.Dependencies[name]; instanceID != expect {
+			return skerr.Fmt("tag %q of package %s now refers to instance %s instead of %s", tag, name, instanceID, expect)
+		}
+	}
+	return nil
+}
+
+// SetClientForTesting sets the CIPDClient used by the CIPDGroupChild so that it
+// can be overridden for testing.
+func (c *CIPDGroupChild) SetClientForTesting(client cipd.CIPDClient) {
+	c.client = client
+}
+
+var _ Child = &CIPDGroupChild{}
+var _ RollValidator = &CIPDGroupChild{}
@@ -0,0 +1,153 @@
+package child
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.chromium.org/luci/cipd/client/cipd"
+	"go.chromium.org/luci/cipd/common"
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/cipd/mocks"
+	"go.skia.org/infra/go/testutils"
+)
+
+const (
+	cipdGroupPrimaryInstanceID = "8ECbL8K2HVu1GGLRMtnzdXr5IG-ky0QnA-gU44BViPYC"
+	cipdGroupOtherInstanceID   = "other-instance-id"
+)
+
+func setupCIPDGroupChild(t *testing.T) (context.Context, *CIPDGroupChild, *mocks.CIPDClient) {
+	mockCipdClient := &mocks.CIPDClient{}
+	t.Cleanup(func() {
+		mockCipdClient.AssertExpectations(t)
+	})
+	c := &CIPDGroupChild{
+		client:        mockCipdClient,
+		names:         []string{"some/package/linux-amd64", "some/package/mac-amd64"},
+		tag:           "latest",
+		revisionIdTag: "version",
+	}
+	ts := time.Unix(1615384545, 0)
+	mockCipdClient.On("Describe", testutils.AnyContext, c.names[0], cipdGroupPrimaryInstanceID, false).Return(&cipd.InstanceDescription{
+		InstanceInfo: cipd.InstanceInfo{
+			Pin: common.Pin{
+				PackageName: c.names[0],
+				InstanceID:  cipdGroupPrimaryInstanceID,
+			},
+			RegisteredBy: "me@google.com",
+			RegisteredTs: cipd.UnixTime(ts),
+		},
+		Tags: []cipd.TagInfo{
+			{
+				Tag: "version:5",
+			},
+		},
+	}, nil)
+	return context.Background(), c, mockCipdClient
+}
+
+func cipdGroupExpectedRevision() *revision.Revision {
+	return &revision.Revision{
+		Id:       "version:5",
+		Checksum: "f0409b2fc2b61d5bb51862d132d9f3757af9206fa4cb442703e814e3805588f6",
+		Author:   "me@google.com",
+		Dependencies: map[string]string{
+			"some/package/linux-amd64": cipdGroupPrimaryInstanceID,
+			"some/package/mac-amd64":   cipdGroupOtherInstanceID,
+		},
+		Description: "some/package/linux-amd64:8ECbL8K2HVu1GGLRMtnzdXr5IG-ky0QnA-gU44BViPYC",
+		Display:     "5",
+		Timestamp:   time.Unix(1615384545, 0),
+		URL:         "https://chrome-infra-packages.appspot.com/p/some/package/linux-amd64/+/8ECbL8K2HVu1GGLRMtnzdXr5IG-ky0QnA-gU44BViPYC",
+	}
+}
+
+func TestCIPDGroupChild_Update(t *testing.T) {
+	ctx, c, mockCipdClient := setupCIPDGroupChild(t)
+	mockCipdClient.On("ResolveVersion", testutils.AnyContext, c.names[0], c.tag).Return(common.Pin{
+		PackageName: c.names[0],
+		InstanceID:  cipdGroupPrimaryInstanceID,
+	}, nil)
+	mockCipdClient.On("SearchInstances", testutils.AnyContext, c.names[1], []string{"version:5"}).Return(common.PinSlice{
+		{
+			PackageName: c.names[1],
+			InstanceID:  cipdGroupOtherInstanceID,
+		},
+	}, nil)
+
+	lastRollRev := &revision.Revision{Id: "version:4"}
+	tipRev, notRolledRevs, err := c.Update(ctx, lastRollRev)
+	require.NoError(t, err)
+	require.Equal(t, cipdGroupExpectedRevision(), tipRev)
+	require.Equal(t, []*revision.Revision{tipRev}, notRolledRevs)
+}
+
+func TestCIPDGroupChild_Update_MissingPackageVersion_RevisionIsInvalid(t *testing.T) {
+	ctx, c, mockCipdClient := setupCIPDGroupChild(t)
+	mockCipdClient.On("ResolveVersion", testutils.AnyContext, c.names[0], c.tag).Return(common.Pin{
+		PackageName: c.names[0],
+		InstanceID:  cipdGroupPrimaryInstanceID,
+	}, nil)
+	mockCipdClient.On("SearchInstances", testutils.AnyContext, c.names[1], []string{"version:5"}).Return(common.PinSlice{}, nil)
+
+	tipRev, _, err := c.Update(ctx, &revision.Revision{Id: "version:4"})
+	require.NoError(t, err)
+	require.Equal(t, "version:5", tipRev.Id)
+	require.Equal(t, "Package some/package/mac-amd64 has 0 instances with tag \"version:5\"; expected exactly one.", tipRev.InvalidReason)
+}
+
+func TestCIPDGroupChild_GetRevision_StripKey(t *testing.T) {
+	ctx, c, mockCipdClient := setupCIPDGroupChild(t)
+	c.revisionIdTagStripKey = true
+	for name, instanceID := range map[string]string{
+		c.names[0]: cipdGroupPrimaryInstanceID,
+		c.names[1]: cipdGroupOtherInstanceID,
+	} {
+		mockCipdClient.On("SearchInstances", testutils.AnyContext, name, []string{"version:5"}).Return(common.PinSlice{
+			{
+				PackageName: name,
+				InstanceID:  instanceID,
+			},
+		}, nil)
+	}
+
+	rev, err := c.GetRevision(ctx, "5")
+	require.NoError(t, err)
+	expect := cipdGroupExpectedRevision()
+	expect.Id = "5"
+	require.Equal(t, expect, rev)
+}
+
+func TestCIPDGroupChild_ValidateRoll_TagMoved_ReturnsError(t *testing.T) {
+	mockCipdClient := &mocks.CIPDClient{}
+	c := &CIPDGroupChild{
+		client:        mockCipdClient,
+		names:         []string{"some/package/linux-amd64", "some/package/mac-amd64"},
+		tag:           "latest",
+		revisionIdTag: "version",
+	}
+	ctx := context.Background()
+	mockCipdClient.On("SearchInstances", testutils.AnyContext, c.names[0], []string{"version:5"}).Return(common.PinSlice{
+		{
+			PackageName: c.names[0],
+			InstanceID:  cipdGroupPrimaryInstanceID,
+		},
+	}, nil)
+	mockCipdClient.On("SearchInstances", testutils.AnyContext, c.names[1], []string{"version:5"}).Return(common.PinSlice{
+		{
+			PackageName: c.names[1],
+			InstanceID:  "some-newer-instance-id",
+		},
+	}, nil)
+
+	rev := cipdGroupExpectedRevision()
+	require.ErrorContains(t, c.ValidateRoll(ctx, rev), "now refers to instance some-newer-instance-id")
+
+	rev.Dependencies[c.names[1]] = "some-newer-instance-id"
+	require.NoError(t, c.ValidateRoll(ctx, rev))
+
+	rev.InvalidReason = "bad"
+	require.ErrorContains(t, c.ValidateRoll(ctx, rev), "is invalid")
+}
@@ -0,0 +1,70 @@
+package child
+
+import (
+	"context"
+	"sync"
+
+	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/vfs"
+)
+
+// SharedChild wraps a Child so that it can be used by multiple rollers at once,
+// eg. rollers of the same Child into different branches of a Parent. Calls to
+// the wrapped Child are serialized, so that a single checkout may be reused.
+type SharedChild struct {
+	Child
+	mtx sync.Mutex
+}
+
+// NewShared returns a SharedChild which wraps the given Child. If the Child is
+// already a SharedChild, it is returned as-is.
+func NewShared(c Child) *SharedChild {
+	if shared, ok := c.(*SharedChild); ok {
+		return shared
+	}
+	return &SharedChild{Child: c}
+}
+
+// Update implements Child.
+func (c *SharedChild) Update(ctx context.Context, lastRollRev *revision.Revision) (*revision.Revision, []*revision.Revision, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Child.Update(ctx, lastRollRev)
+}
+
+// GetRevision implements Child.
+func (c *SharedChild) GetRevision(ctx context.Context, id string) (*revision.Revision, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Child.GetRevision(ctx, id)
+}
+
+// LogRevisions implements Child.
+func (c *SharedChild) LogRevisions(ctx context.Context, from, to *revision.Revision) ([]*revision.Revision, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Child.LogRevisions(ctx, from, to)
+}
+
+// VFS implements Child.
+func (c *SharedChild) VFS(ctx context.Context, rev *revision.Revision) (vfs.FS, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.Child.VFS(ctx, rev)
+}
+
+// ValidateRoll implements RollValidator. It is a no-op if the wrapped Child
+// doesn't implement RollValidator.
+func (c *SharedChild) ValidateRoll(ctx context.Context, to *revision.Revision) error {
+	validator, ok := c.Child.(RollValidator)
+	if !ok {
+		return nil
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return validator.ValidateRoll(ctx, to)
+}
+
+// SharedChild implements Child.
+var _ Child = &SharedChild{}
+var _ RollValidator = &SharedChild{}
@@ -125,7 +125,7 @@ func setupCopy(t *testing.T) (context.Context, *config.ParentChildRepoManagerCon
 	mockParent.MockReadFile(ctx, cfg.GetCopyParent().Gitiles.Dep.Primary.File[0].Path, parentHead)
 
 	// Create the RepoManager.
-	rm, err := newParentChildRepoManager(ctx, cfg, setupRegistry(t), wd, "fake-roller", "fake.server.com", urlmock.Client(), gerritCR(t, g, urlmock.Client()))
+	rm, err := newParentChildRepoManager(ctx, cfg, setupRegistry(t), wd, "fake-roller", "fake.server.com", urlmock.Client(), gerritCR(t, g, urlmock.Client()), nil)
 	require.NoError(t, err)
 
 	// Update.
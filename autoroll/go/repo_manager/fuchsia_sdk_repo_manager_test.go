@@ -131,7 +131,7 @@ func setupFuchsiaSDK(t *testing.T) (context.Context, *parentChildRepoManager, *m
 	mockParent.MockReadFile(ctx, fuchsiaSDKVersionFilePathMac, parentHead)
 	mockGetLatestSDK(urlmock, fuchsiaSDKRevBase, "mac-base")
 
-	rm, err := newParentChildRepoManager(ctx, cfg, setupRegistry(t), wd, "fake-roller", "fake.server.com", urlmock.Client(), gerritCR(t, g, urlmock.Client()))
+	rm, err := newParentChildRepoManager(ctx, cfg, setupRegistry(t), wd, "fake-roller", "fake.server.com", urlmock.Client(), gerritCR(t, g, urlmock.Client()), nil)
 	require.NoError(t, err)
 
 	cleanup := func() {
@@ -12,6 +12,7 @@ import (
 	"go.skia.org/infra/autoroll/go/repo_manager/child/revision_filter"
 	"go.skia.org/infra/autoroll/go/repo_manager/parent"
 	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/autoroll/go/telemetry"
 	"go.skia.org/infra/go/git"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
@@ -26,8 +27,9 @@ type parentChildRepoManager struct {
 }
 
 // newParentChildRepoManager returns a RepoManager which pairs a Parent with a
-// Child.
-func newParentChildRepoManager(ctx context.Context, c *config.ParentChildRepoManagerConfig, reg *config_vars.Registry, workdir, rollerName, serverURL string, client *http.Client, cr codereview.CodeReview) (*parentChildRepoManager, error) {
+// Child. If sharedChild is provided, it is used instead of creating a new
+// Child, unless the Child must be created by the Parent.
+func newParentChildRepoManager(ctx context.Context, c *config.ParentChildRepoManagerConfig, reg *config_vars.Registry, workdir, rollerName, serverURL string, client *http.Client, cr codereview.CodeReview, sharedChild *child.SharedChild) (*parentChildRepoManager, error) {
 	var childRM child.Child
 	var parentRM parent.Parent
 	var err error
@@ -66,8 +68,11 @@ func newParentChildRepoManager(ctx context.Context, c *config.ParentChildRepoMan
 		return nil, skerr.Wrap(err)
 	}
 
-	// Create the Child.
-	if c.GetCipdChild() != nil {
+	// Create the Child. A Child which lives inside of the Parent's checkout
+	// can't be shared, since each Parent has its own checkout.
+	if sharedChild != nil && childCheckout == nil {
+		childRM = sharedChild
+	} else if c.GetCipdChild() != nil {
 		childRM, err = child.NewCIPD(ctx, c.GetCipdChild(), reg, client, workdir)
 	} else if c.GetFuchsiaSdkChild() != nil {
 		childRM, err = child.NewFuchsiaSDK(ctx, c.GetFuchsiaSdkChild(), client)
@@ -81,6 +86,8 @@ func newParentChildRepoManager(ctx context.Context, c *config.ParentChildRepoMan
 		childRM, err = child.NewSemVerGCS(ctx, c.GetSemverGcsChild(), reg, client)
 	} else if c.GetDockerChild() != nil {
 		childRM, err = child.NewDocker(ctx, c.GetDockerChild())
+	} else if c.GetCipdGroupChild() != nil {
+		childRM, err = child.NewCIPDGroup(ctx, c.GetCipdGroupChild(), client, workdir)
 	}
 	if err != nil {
 		return nil, skerr.Wrap(err)
@@ -131,36 +138,62 @@ func newParentChildRepoManager(ctx context.Context, c *config.ParentChildRepoMan
 	}, nil
 }
 
+// shareChild wraps the Child in a SharedChild, so that it can be used by other
+// RepoManagers, and returns it.
+func (rm *parentChildRepoManager) shareChild() *child.SharedChild {
+	shared := child.NewShared(rm.Child)
+	rm.Child = shared
+	return shared
+}
+
 // See documentation for RepoManager interface.
 func (rm *parentChildRepoManager) Update(ctx context.Context) (*revision.Revision, *revision.Revision, []*revision.Revision, error) {
-	lastRollRevId, err := rm.Parent.Update(ctx)
-	if err != nil {
+	var lastRollRevId string
+	if err := telemetry.Track(ctx, telemetry.DependencyParentRepo, func(ctx context.Context) error {
+		var err error
+		lastRollRevId, err = rm.Parent.Update(ctx)
+		return err
+	}); err != nil {
 		return nil, nil, nil, skerr.Wrapf(err, "failed to update Parent")
 	}
-	lastRollRev, err := rm.Child.GetRevision(ctx, lastRollRevId)
-	if err != nil {
+	var lastRollRev *revision.Revision
+	if err := telemetry.Track(ctx, telemetry.DependencyChildRepo, func(ctx context.Context) error {
+		var err error
+		lastRollRev, err = rm.Child.GetRevision(ctx, lastRollRevId)
+		return err
+	}); err != nil {
 		sklog.Errorf("Last roll rev %q not found. This is acceptable for some rollers which allow outside versions to be rolled manually (eg. AFDO roller). A human should verify that this is indeed caused by a manual roll. Attempting to continue with no last-rolled revision. The revisions listed in the commit message will be incorrect!  Error: %s", lastRollRevId, err)
 		lastRollRev = &revision.Revision{
 			Id:            lastRollRevId,
 			InvalidReason: "Failed to retrieve revision.",
 		}
 	}
-	tipRev, notRolledRevs, err := rm.Child.Update(ctx, lastRollRev)
-	if err != nil {
+	var tipRev *revision.Revision
+	var notRolledRevs []*revision.Revision
+	if err := telemetry.Track(ctx, telemetry.DependencyChildRepo, func(ctx context.Context) error {
+		var err error
+		tipRev, notRolledRevs, err = rm.Child.Update(ctx, lastRollRev)
+		return err
+	}); err != nil {
 		return nil, nil, nil, skerr.Wrapf(err, "failed to get next revision to roll from Child")
 	}
 
 	// Optionally filter not-rolled revisions.
-	if err := rm.revFilters.Update(ctx); err != nil {
-		return nil, nil, nil, skerr.Wrap(err)
-	}
-	if err := rm.revFilters.MaybeSetInvalid(ctx, tipRev); err != nil {
-		return nil, nil, nil, skerr.Wrap(err)
-	}
-	for _, notRolledRev := range notRolledRevs {
-		if err := rm.revFilters.MaybeSetInvalid(ctx, notRolledRev); err != nil {
-			return nil, nil, nil, skerr.Wrap(err)
+	if err := telemetry.Track(ctx, telemetry.DependencyRevisionFilter, func(ctx context.Context) error {
+		if err := rm.revFilters.Update(ctx); err != nil {
+			return skerr.Wrap(err)
+		}
+		if err := rm.revFilters.MaybeSetInvalid(ctx, tipRev); err != nil {
+			return skerr.Wrap(err)
 		}
+		for _, notRolledRev := range notRolledRevs {
+			if err := rm.revFilters.MaybeSetInvalid(ctx, notRolledRev); err != nil {
+				return skerr.Wrap(err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, nil, skerr.Wrap(err)
 	}
 	return lastRollRev, tipRev, notRolledRevs, nil
 }
@@ -170,5 +203,15 @@ func (rm *parentChildRepoManager) LogRevisions(ctx context.Context, from, to *re
 	return rm.Child.LogRevisions(ctx, from, to)
 }
 
+// CreateNewRoll implements RepoManager.
+func (rm *parentChildRepoManager) CreateNewRoll(ctx context.Context, from, to *revision.Revision, rolling []*revision.Revision, emails []string, dryRun bool, commitMsg string) (int64, error) {
+	if validator, ok := rm.Child.(child.RollValidator); ok {
+		if err := validator.ValidateRoll(ctx, to); err != nil {
+			return 0, skerr.Wrapf(err, "refusing to roll to %s", to.Id)
+		}
+	}
+	return rm.Parent.CreateNewRoll(ctx, from, to, rolling, emails, dryRun, commitMsg)
+}
+
 // parentChildRepoManager implements RepoManager.
 var _ RepoManager = &parentChildRepoManager{}
@@ -7,6 +7,7 @@ import (
 	"go.skia.org/infra/autoroll/go/codereview"
 	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/autoroll/go/config_vars"
+	"go.skia.org/infra/autoroll/go/repo_manager/child"
 	"go.skia.org/infra/autoroll/go/revision"
 	"go.skia.org/infra/go/skerr"
 )
@@ -48,7 +49,30 @@ func New(ctx context.Context, c config.RepoManagerConfig, reg *config_vars.Regis
 	} else if rmc, ok := c.(*config.FreeTypeRepoManagerConfig); ok {
 		return NewFreeTypeRepoManager(ctx, rmc, reg, workdir, serverURL, client, cr, local)
 	} else if rmc, ok := c.(*config.ParentChildRepoManagerConfig); ok {
-		return newParentChildRepoManager(ctx, rmc, reg, workdir, rollerName, serverURL, client, cr)
+		return newParentChildRepoManager(ctx, rmc, reg, workdir, rollerName, serverURL, client, cr, nil)
 	}
 	return nil, skerr.Fmt("Unknown RepoManager type.")
 }
+
+// NewWithSharedChild returns a RepoManager for the given
+// ParentChildRepoManagerConfig which uses the given Child, as returned by
+// ShareChild, rather than creating its own. This allows a Child to be rolled
+// into multiple Parents, eg. different branches of the same repo, without
+// syncing it multiple times.
+func NewWithSharedChild(ctx context.Context, c *config.ParentChildRepoManagerConfig, reg *config_vars.Registry, workdir, rollerName, serverURL string, client *http.Client, cr codereview.CodeReview, sharedChild *child.SharedChild) (RepoManager, error) {
+	if err := c.Validate(); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return newParentChildRepoManager(ctx, c, reg, workdir, rollerName, serverURL, client, cr, sharedChild)
+}
+
+// ShareChild returns the Child of the given RepoManager, wrapped so that it may
+// be passed to NewWithSharedChild. Returns an error if the RepoManager does not
+// have a separate Child.
+func ShareChild(rm RepoManager) (*child.SharedChild, error) {
+	pcrm, ok := rm.(*parentChildRepoManager)
+	if !ok {
+		return nil, skerr.Fmt("only parent/child RepoManagers have a Child which can be shared")
+	}
+	return pcrm.shareChild(), nil
+}
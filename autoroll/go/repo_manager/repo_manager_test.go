@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.skia.org/infra/autoroll/go/config_vars"
+	"go.skia.org/infra/autoroll/go/repo_manager/child"
 	"go.skia.org/infra/go/chrome_branch/mocks"
 )
 
@@ -17,3 +18,21 @@ func setupRegistry(t *testing.T) *config_vars.Registry {
 	require.NoError(t, err)
 	return reg
 }
+
+func TestShareChild_ParentChildRepoManager_WrapsChildOnce(t *testing.T) {
+	rm := &parentChildRepoManager{Child: &child.GitCheckoutChild{}}
+	shared, err := ShareChild(rm)
+	require.NoError(t, err)
+	require.Same(t, shared, rm.Child)
+
+	// Sharing again returns the same SharedChild rather than wrapping it
+	// twice.
+	sharedAgain, err := ShareChild(rm)
+	require.NoError(t, err)
+	require.Same(t, shared, sharedAgain)
+}
+
+func TestShareChild_NoSeparateChild_ReturnsError(t *testing.T) {
+	_, err := ShareChild(&androidRepoManager{})
+	require.Error(t, err)
+}
@@ -12,20 +12,25 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"go.skia.org/infra/am/go/alertclient"
 	"go.skia.org/infra/autoroll/go/codereview"
 	"go.skia.org/infra/autoroll/go/commit_msg"
 	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/autoroll/go/config_vars"
 	"go.skia.org/infra/autoroll/go/manual"
 	"go.skia.org/infra/autoroll/go/modes"
+	"go.skia.org/infra/autoroll/go/noroll"
 	arb_notifier "go.skia.org/infra/autoroll/go/notifier"
+	"go.skia.org/infra/autoroll/go/prerequisites"
 	"go.skia.org/infra/autoroll/go/recent_rolls"
 	"go.skia.org/infra/autoroll/go/repo_manager"
+	"go.skia.org/infra/autoroll/go/repo_manager/child"
 	"go.skia.org/infra/autoroll/go/revision"
 	"go.skia.org/infra/autoroll/go/roller_cleanup"
 	"go.skia.org/infra/autoroll/go/state_machine"
 	"go.skia.org/infra/autoroll/go/status"
 	"go.skia.org/infra/autoroll/go/strategy"
+	"go.skia.org/infra/autoroll/go/telemetry"
 	"go.skia.org/infra/autoroll/go/time_window"
 	"go.skia.org/infra/autoroll/go/unthrottle"
 	"go.skia.org/infra/email/go/emailclient"
@@ -63,11 +68,16 @@ const (
 	// roll before uploading a new roll. This prevents safety-throttling for
 	// rollers which have a very fast commit queue.
 	defaultRollCooldown = 10 * time.Minute
+
+	// silenceModeUser is the user recorded for mode changes which are made
+	// because of alert-manager silences.
+	silenceModeUser = "alert-manager"
 )
 
 // AutoRoller is a struct which automates the merging new revisions of one
 // project into another.
 type AutoRoller struct {
+	alertClient           alertclient.APIClient
 	cfg                   *config.Config
 	cleanup               roller_cleanup.DB
 	client                *http.Client
@@ -83,8 +93,11 @@ type AutoRoller struct {
 	manualRollDB          manual.DB
 	modeHistory           modes.ModeHistory
 	nextRollRev           *revision.Revision
+	noRoll                *noroll.Checker
+	noRollReason          string
 	notifier              *arb_notifier.AutoRollNotifier
 	notRolledRevs         []*revision.Revision
+	prerequisites         *prerequisites.Checker
 	recent                *recent_rolls.RecentRolls
 	reg                   *config_vars.Registry
 	rm                    repo_manager.RepoManager
@@ -104,6 +117,7 @@ type AutoRoller struct {
 	strategyHistory       *strategy.DatastoreStrategyHistory
 	strategyMtx           sync.RWMutex // Protects strategy
 	successThrottle       *state_machine.Throttler
+	telemetry             *telemetry.Tracker
 	throttle              unthrottle.Throttle
 	timeWindow            *time_window.TimeWindow
 	tipRev                *revision.Revision
@@ -112,6 +126,21 @@ type AutoRoller struct {
 
 // NewAutoRoller returns an AutoRoller instance.
 func NewAutoRoller(ctx context.Context, c *config.Config, emailer emailclient.Client, chatBotConfigReader chatbot.ConfigReader, g gerrit.GerritInterface, githubClient *github.GitHub, workdir, serverURL string, gcsClient gcs.GCSClient, client *http.Client, rollerName string, local bool, statusDB status.DB, manualRollDB manual.DB, cleanupDB roller_cleanup.DB) (*AutoRoller, error) {
+	return newAutoRoller(ctx, c, emailer, chatBotConfigReader, g, githubClient, workdir, serverURL, gcsClient, client, rollerName, local, statusDB, manualRollDB, cleanupDB, nil)
+}
+
+// NewAutoRollerWithSharedChild returns an AutoRoller instance which uses the
+// given Child, as returned by ShareChild on another AutoRoller, rather than
+// creating its own. This is used to roll the same Child into multiple branches
+// of the Parent; see config.Config.ParentBranches.
+func NewAutoRollerWithSharedChild(ctx context.Context, c *config.Config, emailer emailclient.Client, chatBotConfigReader chatbot.ConfigReader, g gerrit.GerritInterface, githubClient *github.GitHub, workdir, serverURL string, gcsClient gcs.GCSClient, client *http.Client, rollerName string, local bool, statusDB status.DB, manualRollDB manual.DB, cleanupDB roller_cleanup.DB, sharedChild *child.SharedChild) (*AutoRoller, error) {
+	if c.GetParentChildRepoManager() == nil {
+		return nil, skerr.Fmt("a shared Child can only be used with ParentChildRepoManager")
+	}
+	return newAutoRoller(ctx, c, emailer, chatBotConfigReader, g, githubClient, workdir, serverURL, gcsClient, client, rollerName, local, statusDB, manualRollDB, cleanupDB, sharedChild)
+}
+
+func newAutoRoller(ctx context.Context, c *config.Config, emailer emailclient.Client, chatBotConfigReader chatbot.ConfigReader, g gerrit.GerritInterface, githubClient *github.GitHub, workdir, serverURL string, gcsClient gcs.GCSClient, client *http.Client, rollerName string, local bool, statusDB status.DB, manualRollDB manual.DB, cleanupDB roller_cleanup.DB, sharedChild *child.SharedChild) (*AutoRoller, error) {
 	// Validation and setup.
 	if err := c.Validate(); err != nil {
 		return nil, skerr.Wrapf(err, "Failed to validate config")
@@ -148,12 +177,18 @@ func NewAutoRoller(ctx context.Context, c *config.Config, emailer emailclient.Cl
 		serverURL:          serverURL,
 		reviewers:          c.Reviewer,
 		reviewersBackup:    c.ReviewerBackup,
+		telemetry:          telemetry.NewTracker(c.RollerName),
 		throttle:           unthrottle.NewDatastore(ctx),
 		workdir:            workdir,
 	}
 
 	// Create the RepoManager.
-	rm, err := repo_manager.New(ctx, c.GetRepoManagerConfig(), reg, workdir, rollerName, serverURL, c.ServiceAccount, client, cr, c.IsInternal, local)
+	var rm repo_manager.RepoManager
+	if sharedChild != nil {
+		rm, err = repo_manager.NewWithSharedChild(ctx, c.GetParentChildRepoManager(), reg, workdir, rollerName, serverURL, client, cr, sharedChild)
+	} else {
+		rm, err = repo_manager.New(ctx, c.GetRepoManagerConfig(), reg, workdir, rollerName, serverURL, c.ServiceAccount, client, cr, c.IsInternal, local)
+	}
 	if err != nil {
 		// If this failed, it's possible that we're in a broken state. Let's try
 		// deleting the local data before we fail out.
@@ -165,6 +200,13 @@ func NewAutoRoller(ctx context.Context, c *config.Config, emailer emailclient.Cl
 	}
 	arb.rm = rm
 
+	sklog.Info("Creating roll history")
+	recent, err := recent_rolls.NewRecentRolls(ctx, recent_rolls.NewDatastoreRollsDB(ctx), rollerName)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to create recent rolls DB")
+	}
+	arb.recent = recent
+
 	sklog.Info("Creating strategy history.")
 	sh, err := strategy.NewDatastoreStrategyHistory(ctx, rollerName, c.ValidStrategies())
 	if err != nil {
@@ -183,7 +225,7 @@ func NewAutoRoller(ctx context.Context, c *config.Config, emailer emailclient.Cl
 	}
 
 	sklog.Info("Setting strategy.")
-	strat, err := strategy.GetNextRollStrategy(currentStrategy.Strategy)
+	strat, err := strategy.GetNextRollStrategy(currentStrategy.Strategy, recent)
 	if err != nil {
 		return nil, skerr.Wrapf(err, "Failed to get next roll strategy")
 	}
@@ -214,13 +256,6 @@ func NewAutoRoller(ctx context.Context, c *config.Config, emailer emailclient.Cl
 	}
 	arb.reportedRevs = reportedRevs
 
-	sklog.Info("Creating roll history")
-	recent, err := recent_rolls.NewRecentRolls(ctx, recent_rolls.NewDatastoreRollsDB(ctx), rollerName)
-	if err != nil {
-		return nil, skerr.Wrapf(err, "Failed to create recent rolls DB")
-	}
-	arb.recent = recent
-
 	sklog.Info("Creating mode history")
 	mh, err := modes.NewDatastoreModeHistory(ctx, rollerName)
 	if err != nil {
@@ -300,6 +335,20 @@ func NewAutoRoller(ctx context.Context, c *config.Config, emailer emailclient.Cl
 	}
 	arb.status = statusCache
 
+	sklog.Info("Creating prerequisites checker.")
+	prereqs, err := prerequisites.New(statusDB, c.Prerequisites)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to create prerequisites checker")
+	}
+	arb.prerequisites = prereqs
+
+	sklog.Info("Creating no-roll marker checker.")
+	noRoll, err := noroll.New(c.NoRollMarker, client)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to create no-roll marker checker")
+	}
+	arb.noRoll = noRoll
+
 	sklog.Info("Creating TimeWindow.")
 	var tw *time_window.TimeWindow
 	if c.TimeWindow != "" {
@@ -581,8 +630,12 @@ func (r *AutoRoller) createNewRoll(ctx context.Context, from, to *revision.Revis
 		return nil, skerr.Wrap(err)
 	}
 	sklog.Infof("Creating new roll with commit message: \n%s", commitMsg)
-	issueNum, err := r.rm.CreateNewRoll(ctx, from, to, revs, emails, dryRun, commitMsg)
-	if err != nil {
+	var issueNum int64
+	if err := telemetry.Track(ctx, telemetry.DependencyParentRepo, func(ctx context.Context) error {
+		var err error
+		issueNum, err = r.rm.CreateNewRoll(ctx, from, to, revs, emails, dryRun, commitMsg)
+		return err
+	}); err != nil {
 		return nil, skerr.Wrap(err)
 	}
 	issue := &autoroll.AutoRollIssue{
@@ -640,6 +693,34 @@ func (r *AutoRoller) InRollWindow(t time.Time) bool {
 	return r.timeWindow.Test(t)
 }
 
+// PrerequisitesSatisfied implements state_machine.AutoRollerImpl.
+func (r *AutoRoller) PrerequisitesSatisfied(ctx context.Context) bool {
+	blocking := r.prerequisites.Blocking(ctx, r.GetNextRollRev())
+	if len(blocking) > 0 {
+		sklog.Infof("Waiting for prerequisite rollers: %v", blocking)
+		return false
+	}
+	return true
+}
+
+// NoRollMarkerPresent implements state_machine.AutoRollerImpl.
+func (r *AutoRoller) NoRollMarkerPresent(ctx context.Context) bool {
+	reason, err := r.noRoll.Check(ctx)
+	if err != nil {
+		// Don't let a flaky Gitiles block the roller indefinitely.
+		sklog.Errorf("Failed to check for no-roll marker: %s", err)
+		reason = ""
+	}
+	r.statusMtx.Lock()
+	r.noRollReason = reason
+	r.statusMtx.Unlock()
+	if reason != "" {
+		sklog.Infof("Not rolling: %s", reason)
+		return true
+	}
+	return false
+}
+
 // RolledPast implements state_machine.AutoRollerImpl.
 func (r *AutoRoller) RolledPast(ctx context.Context, rev *revision.Revision) (bool, error) {
 	r.statusMtx.RLock()
@@ -820,6 +901,15 @@ func (r *AutoRoller) updateStatus(ctx context.Context, replaceLastError bool, la
 	if currentRoll != nil {
 		currentRollRev = currentRoll.RollingTo
 	}
+	currentState := string(r.sm.Current())
+	if currentState == state_machine.S_NORMAL_WAIT_FOR_NO_ROLL && r.noRollReason != "" {
+		currentState = fmt.Sprintf("%s: %s", currentState, r.noRollReason)
+	}
+	ts := time.Now().UTC()
+	statusChangedAt := ts
+	if prev := r.status.Get(); prev != nil && prev.Status == currentState && !prev.StatusChangedAt.IsZero() {
+		statusChangedAt = prev.StatusChangedAt
+	}
 	if err := r.status.Set(ctx, r.roller, &status.AutoRollStatus{
 		AutoRollMiniStatus: status.AutoRollMiniStatus{
 			CurrentRollRev:              currentRollRev,
@@ -827,7 +917,7 @@ func (r *AutoRoller) updateStatus(ctx context.Context, replaceLastError bool, la
 			Mode:                        r.GetMode(),
 			NumFailedRolls:              r.recent.NumFailedRolls(),
 			NumNotRolledCommits:         numNotRolled,
-			Timestamp:                   time.Now().UTC(),
+			Timestamp:                   ts,
 			LastSuccessfulRollTimestamp: r.recent.LastSuccessfulRollTime(),
 		},
 		ChildName:          r.cfg.ChildDisplayName,
@@ -838,7 +928,8 @@ func (r *AutoRoller) updateStatus(ctx context.Context, replaceLastError bool, la
 		LastRoll:           r.recent.LastRoll(),
 		NotRolledRevisions: notRolledRevs,
 		Recent:             recent,
-		Status:             string(r.sm.Current()),
+		Status:             currentState,
+		StatusChangedAt:    statusChangedAt,
 		ThrottledUntil:     throttledUntil,
 		ValidModes:         modes.ValidModes,
 		ValidStrategies:    r.cfg.ValidStrategies(),
@@ -854,6 +945,7 @@ func (r *AutoRoller) updateStatus(ctx context.Context, replaceLastError bool, la
 func (r *AutoRoller) Tick(ctx context.Context) error {
 	r.runningMtx.Lock()
 	defer r.runningMtx.Unlock()
+	ctx = telemetry.WithTracker(ctx, r.telemetry)
 
 	sklog.Infof("Running autoroller.")
 
@@ -895,13 +987,18 @@ func (r *AutoRoller) Tick(ctx context.Context) error {
 	if err := r.modeHistory.Update(ctx); err != nil {
 		return skerr.Wrapf(err, "Failed to update mode history")
 	}
+	if r.alertClient != nil {
+		if err := r.syncModeWithSilences(ctx); err != nil {
+			sklog.Errorf("Failed to sync mode with alert-manager silences; continuing: %s", err)
+		}
+	}
 	oldStrategy := r.strategyHistory.CurrentStrategy().Strategy
 	if err := r.strategyHistory.Update(ctx); err != nil {
 		return skerr.Wrapf(err, "Failed to update strategy history")
 	}
 	newStrategy := r.strategyHistory.CurrentStrategy().Strategy
 	if oldStrategy != newStrategy {
-		strat, err := strategy.GetNextRollStrategy(newStrategy)
+		strat, err := strategy.GetNextRollStrategy(newStrategy, r.recent)
 		if err != nil {
 			return skerr.Wrapf(err, "Failed to get next roll strategy")
 		}
@@ -918,7 +1015,9 @@ func (r *AutoRoller) Tick(ctx context.Context) error {
 	}
 
 	// Update the status information.
-	if err := r.updateStatus(ctx, true, lastErrStr); err != nil {
+	if err := telemetry.Track(ctx, telemetry.DependencyDB, func(ctx context.Context) error {
+		return r.updateStatus(ctx, true, lastErrStr)
+	}); err != nil {
 		return skerr.Wrapf(err, "Failed to update status")
 	}
 	sklog.Infof("Autoroller state %s", r.sm.Current())
@@ -939,8 +1038,48 @@ func (r *AutoRoller) AddComment(ctx context.Context, issueNum int64, message, us
 	return r.recent.Update(ctx, roll)
 }
 
+// SetAlertClient makes the roller stop itself while an alert-manager silence
+// asks it to pause, and resume once the silence has ended. See
+// silence.Silence.PauseRoller.
+func (r *AutoRoller) SetAlertClient(c alertclient.APIClient) {
+	r.alertClient = c
+}
+
+// syncModeWithSilences stops the roller if it is running and an alert-manager
+// silence asks it to pause. It restarts the roller once no silence asks it to
+// pause, but only if it was stopped because of a silence in the first place.
+func (r *AutoRoller) syncModeWithSilences(ctx context.Context) error {
+	pause, err := r.alertClient.GetRollerPause(r.cfg.RollerName)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	current := r.modeHistory.CurrentMode()
+	if current == nil {
+		return nil
+	}
+	if pause.Paused && current.Mode == modes.ModeRunning {
+		msg := "Stopped while an alert-manager silence is active."
+		if pause.Silence != nil {
+			msg = fmt.Sprintf("Stopped while the alert-manager silence created by %s is active.", pause.Silence.User)
+		}
+		return skerr.Wrap(r.modeHistory.Add(ctx, modes.ModeStopped, silenceModeUser, msg))
+	}
+	if !pause.Paused && current.Mode == modes.ModeStopped && current.User == silenceModeUser {
+		return skerr.Wrap(r.modeHistory.Add(ctx, modes.ModeRunning, silenceModeUser, "The alert-manager silence which stopped the roller has ended."))
+	}
+	return nil
+}
+
+// ShareChild returns the Child of this roller, wrapped so that it may also be
+// used by other rollers via NewAutoRollerWithSharedChild.
+func (r *AutoRoller) ShareChild() (*child.SharedChild, error) {
+	return repo_manager.ShareChild(r.rm)
+}
+
 // AddHandlers implements main.AutoRollerI.
-func (r *AutoRoller) AddHandlers(chi.Router) {}
+func (r *AutoRoller) AddHandlers(router chi.Router) {
+	router.Get("/json/health", r.telemetry.HealthHandler)
+}
 
 // Callback function which runs when roll CLs are closed.
 func (r *AutoRoller) rollFinished(ctx context.Context, justFinished codereview.RollImpl) error {
@@ -987,7 +1126,7 @@ func (r *AutoRoller) rollFinished(ctx context.Context, justFinished codereview.R
 		if currentSuccess && !lastSuccess {
 			r.notifier.SendNewSuccess(ctx, fmt.Sprintf("%d", currentRoll.Issue), issueURL)
 		} else if !currentSuccess && lastSuccess {
-			r.notifier.SendNewFailure(ctx, fmt.Sprintf("%d", currentRoll.Issue), issueURL)
+			r.notifier.SendNewFailure(ctx, fmt.Sprintf("%d", currentRoll.Issue), issueURL, currentRoll.FailureClass())
 		}
 	}
 
@@ -1002,7 +1141,7 @@ func (r *AutoRoller) rollFinished(ctx context.Context, justFinished codereview.R
 		}
 	}
 	if nFailed == notifyIfLastNFailed {
-		r.notifier.SendLastNFailed(ctx, notifyIfLastNFailed, issueURL)
+		r.notifier.SendLastNFailed(ctx, notifyIfLastNFailed, issueURL, currentRoll.FailureClass())
 	}
 
 	return nil
@@ -1012,6 +1151,7 @@ func (r *AutoRoller) rollFinished(ctx context.Context, justFinished codereview.R
 func (r *AutoRoller) handleManualRolls(ctx context.Context) error {
 	r.runningMtx.Lock()
 	defer r.runningMtx.Unlock()
+	ctx = telemetry.WithTracker(ctx, r.telemetry)
 
 	if r.GetMode() == modes.ModeOffline {
 		return nil
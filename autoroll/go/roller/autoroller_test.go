@@ -9,9 +9,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	alertclient_mocks "go.skia.org/infra/am/go/alertclient/mocks"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/am/go/types"
 	"go.skia.org/infra/autoroll/go/config"
 	"go.skia.org/infra/autoroll/go/manual"
+	"go.skia.org/infra/autoroll/go/modes"
+	modes_mocks "go.skia.org/infra/autoroll/go/modes/mocks"
 	"go.skia.org/infra/autoroll/go/revision"
 	"go.skia.org/infra/autoroll/go/roller_cleanup"
 	roller_cleanup_mocks "go.skia.org/infra/autoroll/go/roller_cleanup/mocks"
@@ -270,3 +276,46 @@ func TestRepoManagerInitFailed(t *testing.T) {
 	require.True(t, urlmock.Empty())
 	cleanupDB.AssertExpectations(t)
 }
+
+func setupSyncModeWithSilences(t *testing.T, paused bool, current *modes.ModeChange) (*AutoRoller, *modes_mocks.ModeHistory) {
+	alertClient := &alertclient_mocks.APIClient{}
+	resp := &types.RollerPauseResponse{Paused: paused}
+	if paused {
+		resp.Silence = &silence.Silence{User: "someone@google.com"}
+	}
+	alertClient.On("GetRollerPause", "my-roller").Return(resp, nil)
+	modeHistory := &modes_mocks.ModeHistory{}
+	modeHistory.On("CurrentMode").Return(current)
+	t.Cleanup(func() {
+		alertClient.AssertExpectations(t)
+		modeHistory.AssertExpectations(t)
+	})
+	r := &AutoRoller{
+		cfg:         &config.Config{RollerName: "my-roller"},
+		modeHistory: modeHistory,
+	}
+	r.SetAlertClient(alertClient)
+	return r, modeHistory
+}
+
+func TestSyncModeWithSilences_PausedWhileRunning_Stops(t *testing.T) {
+	r, modeHistory := setupSyncModeWithSilences(t, true, &modes.ModeChange{Mode: modes.ModeRunning, User: "someone-else@google.com"})
+	modeHistory.On("Add", mock.Anything, modes.ModeStopped, silenceModeUser, mock.Anything).Return(nil)
+	require.NoError(t, r.syncModeWithSilences(context.Background()))
+}
+
+func TestSyncModeWithSilences_NotPausedAfterSilenceStop_Resumes(t *testing.T) {
+	r, modeHistory := setupSyncModeWithSilences(t, false, &modes.ModeChange{Mode: modes.ModeStopped, User: silenceModeUser})
+	modeHistory.On("Add", mock.Anything, modes.ModeRunning, silenceModeUser, mock.Anything).Return(nil)
+	require.NoError(t, r.syncModeWithSilences(context.Background()))
+}
+
+func TestSyncModeWithSilences_NotPausedAfterManualStop_StaysStopped(t *testing.T) {
+	r, _ := setupSyncModeWithSilences(t, false, &modes.ModeChange{Mode: modes.ModeStopped, User: "someone@google.com"})
+	require.NoError(t, r.syncModeWithSilences(context.Background()))
+}
+
+func TestSyncModeWithSilences_PausedInDryRun_NoChange(t *testing.T) {
+	r, _ := setupSyncModeWithSilences(t, true, &modes.ModeChange{Mode: modes.ModeDryRun, User: "someone@google.com"})
+	require.NoError(t, r.syncModeWithSilences(context.Background()))
+}
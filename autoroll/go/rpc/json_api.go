@@ -0,0 +1,184 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"go.skia.org/infra/autoroll/go/modes"
+	"go.skia.org/infra/autoroll/go/status"
+	"go.skia.org/infra/go/autoroll"
+	"go.skia.org/infra/go/httputils"
+)
+
+// RollersJSONV1Path is the path at which the rollers are served by
+// RollersJSONV1Handler.
+const RollersJSONV1Path = "/json/v1/rollers"
+
+// Health values reported by the v1 JSON API.
+const (
+	// HealthOK indicates that the roller is reporting in and rolling
+	// normally.
+	HealthOK = "ok"
+	// HealthFailing indicates that the roller has at least
+	// failingRollsThreshold consecutive failed rolls.
+	HealthFailing = "failing"
+	// HealthStale indicates that the roller has not reported its status
+	// within staleStatusThreshold, eg. because it is crashing.
+	HealthStale = "stale"
+)
+
+const (
+	// failingRollsThreshold is the number of consecutive failed rolls after
+	// which a roller is considered to be failing.
+	failingRollsThreshold = 3
+
+	// staleStatusThreshold is the age of a roller's status after which the
+	// roller is considered to be stale.
+	staleStatusThreshold = 30 * time.Minute
+)
+
+// RollerJSONV1 is the representation of a roller in the v1 JSON API. Fields may
+// be added to this struct, but existing fields must not be removed, renamed,
+// or have their meaning changed; create a new version of the API instead.
+type RollerJSONV1 struct {
+	RollerID   string `json:"rollerId"`
+	ChildName  string `json:"childName"`
+	ParentName string `json:"parentName"`
+
+	Mode          string    `json:"mode"`
+	ModeChangedAt time.Time `json:"modeChangedAt"`
+
+	// State is the state of the roller's state machine.
+	State            string    `json:"state"`
+	StateChangedAt   time.Time `json:"stateChangedAt"`
+	TimeInStateSecs  int64     `json:"timeInStateSecs"`
+	StatusReportedAt time.Time `json:"statusReportedAt"`
+
+	CurrentRoll *RollJSONV1 `json:"currentRoll"`
+	LastRoll    *RollJSONV1 `json:"lastRoll"`
+
+	LastSuccessfulRollAt time.Time `json:"lastSuccessfulRollAt"`
+	NumFailedRolls       int       `json:"numFailedRolls"`
+	NumBehind            int       `json:"numBehind"`
+
+	Throttled      bool      `json:"throttled"`
+	ThrottledUntil time.Time `json:"throttledUntil"`
+
+	Health string `json:"health"`
+	Error  string `json:"error"`
+}
+
+// RollJSONV1 is the representation of a roll CL in the v1 JSON API.
+type RollJSONV1 struct {
+	Issue       int64     `json:"issue"`
+	URL         string    `json:"url"`
+	Subject     string    `json:"subject"`
+	RollingFrom string    `json:"rollingFrom"`
+	RollingTo   string    `json:"rollingTo"`
+	Result      string    `json:"result"`
+	IsDryRun    bool      `json:"isDryRun"`
+	Created     time.Time `json:"created"`
+	Modified    time.Time `json:"modified"`
+}
+
+// RollersJSONV1Response is the response body of the v1 JSON API.
+type RollersJSONV1Response struct {
+	Version int             `json:"version"`
+	Rollers []*RollerJSONV1 `json:"rollers"`
+}
+
+// RollersJSONV1Handler serves the status of all rollers as JSON. Unlike the
+// RPCs, whose messages are tailored to the UI, the response format is stable
+// and intended for use by external dashboards.
+func (s *AutoRollServer) RollersJSONV1Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.getRollersJSONV1()); err != nil {
+		httputils.ReportError(w, err, "Failed to encode response.", http.StatusInternalServerError)
+		return
+	}
+}
+
+// getRollersJSONV1 returns the RollersJSONV1Response for all rollers, sorted
+// by ID.
+func (s *AutoRollServer) getRollersJSONV1() *RollersJSONV1Response {
+	s.rollersMtx.RLock()
+	defer s.rollersMtx.RUnlock()
+	currentTime := timeNowFunc()
+	rollers := make([]*RollerJSONV1, 0, len(s.rollers))
+	for name, roller := range s.rollers {
+		mode := modes.ModeRunning
+		var modeChangedAt time.Time
+		if mc := roller.Mode.CurrentMode(); mc != nil {
+			mode = mc.Mode
+			modeChangedAt = mc.Time
+		}
+		rollers = append(rollers, makeRollerJSONV1(name, roller.Status.Get(), mode, modeChangedAt, roller.Cfg.ChildDisplayName, roller.Cfg.ParentDisplayName, currentTime))
+	}
+	sort.Slice(rollers, func(i, j int) bool {
+		return rollers[i].RollerID < rollers[j].RollerID
+	})
+	return &RollersJSONV1Response{
+		Version: 1,
+		Rollers: rollers,
+	}
+}
+
+// makeRollerJSONV1 converts the given status into a RollerJSONV1.
+func makeRollerJSONV1(rollerID string, st *status.AutoRollStatus, mode string, modeChangedAt time.Time, childName, parentName string, currentTime time.Time) *RollerJSONV1 {
+	rv := &RollerJSONV1{
+		RollerID:             rollerID,
+		ChildName:            childName,
+		ParentName:           parentName,
+		Mode:                 mode,
+		ModeChangedAt:        modeChangedAt,
+		State:                st.Status,
+		StateChangedAt:       st.StatusChangedAt,
+		StatusReportedAt:     st.Timestamp,
+		CurrentRoll:          makeRollJSONV1(st.CurrentRoll, st.IssueUrlBase),
+		LastRoll:             makeRollJSONV1(st.LastRoll, st.IssueUrlBase),
+		LastSuccessfulRollAt: st.LastSuccessfulRollTimestamp,
+		NumFailedRolls:       st.NumFailedRolls,
+		NumBehind:            st.NumNotRolledCommits,
+		Error:                st.Error,
+	}
+	if !st.StatusChangedAt.IsZero() {
+		rv.TimeInStateSecs = int64(currentTime.Sub(st.StatusChangedAt).Seconds())
+	}
+	if st.ThrottledUntil > 0 {
+		rv.ThrottledUntil = time.Unix(st.ThrottledUntil, 0).UTC()
+		rv.Throttled = rv.ThrottledUntil.After(currentTime)
+	}
+	if st.Timestamp.IsZero() || currentTime.Sub(st.Timestamp) > staleStatusThreshold {
+		rv.Health = HealthStale
+	} else if st.NumFailedRolls >= failingRollsThreshold {
+		rv.Health = HealthFailing
+	} else {
+		rv.Health = HealthOK
+	}
+	return rv
+}
+
+// makeRollJSONV1 converts the given AutoRollIssue into a RollJSONV1.
+func makeRollJSONV1(issue *autoroll.AutoRollIssue, issueURLBase string) *RollJSONV1 {
+	if issue == nil {
+		return nil
+	}
+	var url string
+	if issueURLBase != "" {
+		url = fmt.Sprintf("%s%d", issueURLBase, issue.Issue)
+	}
+	return &RollJSONV1{
+		Issue:       issue.Issue,
+		URL:         url,
+		Subject:     issue.Subject,
+		RollingFrom: issue.RollingFrom,
+		RollingTo:   issue.RollingTo,
+		Result:      issue.Result,
+		IsDryRun:    issue.IsDryRun,
+		Created:     issue.Created,
+		Modified:    issue.Modified,
+	}
+}
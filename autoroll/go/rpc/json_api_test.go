@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/autoroll/go/modes"
+	"go.skia.org/infra/autoroll/go/status"
+	"go.skia.org/infra/go/autoroll"
+)
+
+func TestRollersJSONV1Handler(t *testing.T) {
+	_, rollers, srv := setup(t)
+
+	w := httptest.NewRecorder()
+	srv.RollersJSONV1Handler(w, httptest.NewRequest(http.MethodGet, RollersJSONV1Path, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var resp RollersJSONV1Response
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 1, resp.Version)
+	require.Len(t, resp.Rollers, len(rollers))
+	require.Equal(t, "roller1", resp.Rollers[0].RollerID)
+	require.Equal(t, "roller2", resp.Rollers[1].RollerID)
+
+	r := resp.Rollers[0]
+	assert.Equal(t, "roller1_child", r.ChildName)
+	assert.Equal(t, "roller1_parent", r.ParentName)
+	assert.Equal(t, modes.ModeDryRun, r.Mode)
+	assert.Equal(t, "rolling", r.State)
+	assert.Equal(t, 1, r.NumFailedRolls)
+	assert.Equal(t, 2, r.NumBehind)
+	assert.Equal(t, HealthOK, r.Health)
+	require.NotNil(t, r.CurrentRoll)
+	assert.Equal(t, "http://fake212345", r.CurrentRoll.URL)
+	assert.Equal(t, autoroll.ROLL_RESULT_DRY_RUN_IN_PROGRESS, r.CurrentRoll.Result)
+}
+
+func TestMakeRollerJSONV1(t *testing.T) {
+	ts := time.Unix(1598467386, 0).UTC()
+	st := &status.AutoRollStatus{
+		AutoRollMiniStatus: status.AutoRollMiniStatus{
+			NumFailedRolls:      0,
+			NumNotRolledCommits: 5,
+			Timestamp:           ts.Add(-time.Minute),
+		},
+		Status:          "idle",
+		StatusChangedAt: ts.Add(-time.Hour),
+		ThrottledUntil:  ts.Add(time.Hour).Unix(),
+	}
+
+	test := func(name string, fn func(*status.AutoRollStatus), check func(*testing.T, *RollerJSONV1)) {
+		t.Run(name, func(t *testing.T) {
+			cp := st.Copy()
+			fn(cp)
+			check(t, makeRollerJSONV1("my-roller", cp, modes.ModeRunning, ts, "child", "parent", ts))
+		})
+	}
+
+	test("healthy", func(*status.AutoRollStatus) {}, func(t *testing.T, r *RollerJSONV1) {
+		assert.Equal(t, HealthOK, r.Health)
+		assert.Equal(t, int64(3600), r.TimeInStateSecs)
+		assert.True(t, r.Throttled)
+		assert.Equal(t, ts.Add(time.Hour), r.ThrottledUntil)
+		assert.Nil(t, r.CurrentRoll)
+		assert.Nil(t, r.LastRoll)
+	})
+	test("failing", func(st *status.AutoRollStatus) {
+		st.NumFailedRolls = failingRollsThreshold
+	}, func(t *testing.T, r *RollerJSONV1) {
+		assert.Equal(t, HealthFailing, r.Health)
+	})
+	test("stale", func(st *status.AutoRollStatus) {
+		st.NumFailedRolls = failingRollsThreshold
+		st.Timestamp = ts.Add(-2 * staleStatusThreshold)
+	}, func(t *testing.T, r *RollerJSONV1) {
+		assert.Equal(t, HealthStale, r.Health)
+	})
+	test("throttle expired", func(st *status.AutoRollStatus) {
+		st.ThrottledUntil = ts.Add(-time.Hour).Unix()
+	}, func(t *testing.T, r *RollerJSONV1) {
+		assert.False(t, r.Throttled)
+	})
+	test("no state change time", func(st *status.AutoRollStatus) {
+		st.StatusChangedAt = time.Time{}
+	}, func(t *testing.T, r *RollerJSONV1) {
+		assert.Equal(t, int64(0), r.TimeInStateSecs)
+	})
+}
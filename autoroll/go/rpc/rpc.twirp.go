@@ -91,6 +91,10 @@ type AutoRollService interface {
 
 	// Unthrottle clears any throttling of the roller, allowing it to roll again.
 	Unthrottle(context.Context, *UnthrottleRequest) (*UnthrottleResponse, error)
+
+	// GetNextRollWindow retrieves the next time at which a roller is allowed to
+	// upload rolls, according to its configured time window.
+	GetNextRollWindow(context.Context, *GetNextRollWindowRequest) (*GetNextRollWindowResponse, error)
 }
 
 // ===============================
@@ -99,7 +103,7 @@ type AutoRollService interface {
 
 type autoRollServiceProtobufClient struct {
 	client      HTTPClient
-	urls        [12]string
+	urls        [13]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -119,7 +123,7 @@ func NewAutoRollServiceProtobufClient(baseURL string, client HTTPClient, opts ..
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(clientOpts.PathPrefix(), "autoroll.rpc", "AutoRollService")
-	urls := [12]string{
+	urls := [13]string{
 		serviceURL + "AddCleanupRequest",
 		serviceURL + "GetCleanupHistory",
 		serviceURL + "GetRollers",
@@ -132,6 +136,7 @@ func NewAutoRollServiceProtobufClient(baseURL string, client HTTPClient, opts ..
 		serviceURL + "GetStrategyHistory",
 		serviceURL + "CreateManualRoll",
 		serviceURL + "Unthrottle",
+		serviceURL + "GetNextRollWindow",
 	}
 
 	return &autoRollServiceProtobufClient{
@@ -694,13 +699,59 @@ func (c *autoRollServiceProtobufClient) callUnthrottle(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *autoRollServiceProtobufClient) GetNextRollWindow(ctx context.Context, in *GetNextRollWindowRequest) (*GetNextRollWindowResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "autoroll.rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "AutoRollService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetNextRollWindow")
+	caller := c.callGetNextRollWindow
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetNextRollWindowRequest) (*GetNextRollWindowResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetNextRollWindowRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetNextRollWindowRequest) when calling interceptor")
+					}
+					return c.callGetNextRollWindow(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetNextRollWindowResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetNextRollWindowResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *autoRollServiceProtobufClient) callGetNextRollWindow(ctx context.Context, in *GetNextRollWindowRequest) (*GetNextRollWindowResponse, error) {
+	out := new(GetNextRollWindowResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[12], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ===========================
 // AutoRollService JSON Client
 // ===========================
 
 type autoRollServiceJSONClient struct {
 	client      HTTPClient
-	urls        [12]string
+	urls        [13]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -720,7 +771,7 @@ func NewAutoRollServiceJSONClient(baseURL string, client HTTPClient, opts ...twi
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(clientOpts.PathPrefix(), "autoroll.rpc", "AutoRollService")
-	urls := [12]string{
+	urls := [13]string{
 		serviceURL + "AddCleanupRequest",
 		serviceURL + "GetCleanupHistory",
 		serviceURL + "GetRollers",
@@ -733,6 +784,7 @@ func NewAutoRollServiceJSONClient(baseURL string, client HTTPClient, opts ...twi
 		serviceURL + "GetStrategyHistory",
 		serviceURL + "CreateManualRoll",
 		serviceURL + "Unthrottle",
+		serviceURL + "GetNextRollWindow",
 	}
 
 	return &autoRollServiceJSONClient{
@@ -1295,6 +1347,52 @@ func (c *autoRollServiceJSONClient) callUnthrottle(ctx context.Context, in *Unth
 	return out, nil
 }
 
+func (c *autoRollServiceJSONClient) GetNextRollWindow(ctx context.Context, in *GetNextRollWindowRequest) (*GetNextRollWindowResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "autoroll.rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "AutoRollService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetNextRollWindow")
+	caller := c.callGetNextRollWindow
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetNextRollWindowRequest) (*GetNextRollWindowResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetNextRollWindowRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetNextRollWindowRequest) when calling interceptor")
+					}
+					return c.callGetNextRollWindow(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetNextRollWindowResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetNextRollWindowResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *autoRollServiceJSONClient) callGetNextRollWindow(ctx context.Context, in *GetNextRollWindowRequest) (*GetNextRollWindowResponse, error) {
+	out := new(GetNextRollWindowResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[12], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ==============================
 // AutoRollService Server Handler
 // ==============================
@@ -1415,6 +1513,9 @@ func (s *autoRollServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Re
 	case "Unthrottle":
 		s.serveUnthrottle(ctx, resp, req)
 		return
+	case "GetNextRollWindow":
+		s.serveGetNextRollWindow(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
@@ -3522,6 +3623,181 @@ func (s *autoRollServiceServer) serveUnthrottleProtobuf(ctx context.Context, res
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *autoRollServiceServer) serveGetNextRollWindow(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetNextRollWindowJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetNextRollWindowProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *autoRollServiceServer) serveGetNextRollWindowJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetNextRollWindow")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GetNextRollWindowRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	handler := s.AutoRollService.GetNextRollWindow
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetNextRollWindowRequest) (*GetNextRollWindowResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetNextRollWindowRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetNextRollWindowRequest) when calling interceptor")
+					}
+					return s.AutoRollService.GetNextRollWindow(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetNextRollWindowResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetNextRollWindowResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetNextRollWindowResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetNextRollWindowResponse and nil error while calling GetNextRollWindow. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true, EmitDefaults: !s.jsonSkipDefaults}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *autoRollServiceServer) serveGetNextRollWindowProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetNextRollWindow")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(GetNextRollWindowRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AutoRollService.GetNextRollWindow
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetNextRollWindowRequest) (*GetNextRollWindowResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetNextRollWindowRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetNextRollWindowRequest) when calling interceptor")
+					}
+					return s.AutoRollService.GetNextRollWindow(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetNextRollWindowResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetNextRollWindowResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetNextRollWindowResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetNextRollWindowResponse and nil error while calling GetNextRollWindow. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *autoRollServiceServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -4087,139 +4363,144 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 2129 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x76, 0xdb, 0xc6,
-	0x11, 0x2e, 0x7f, 0x44, 0x82, 0x43, 0x89, 0xa2, 0x57, 0x8a, 0x03, 0xd3, 0x71, 0x4c, 0xc3, 0x8e,
-	0xa3, 0xe6, 0xe4, 0x50, 0xad, 0xec, 0x26, 0x3e, 0xc9, 0xe9, 0x85, 0x4c, 0x51, 0x3f, 0x8d, 0x44,
-	0x29, 0xa0, 0x58, 0xb7, 0xe9, 0xe9, 0xc1, 0x81, 0x88, 0x25, 0x0d, 0x1b, 0xc4, 0xb2, 0xbb, 0x80,
-	0x5c, 0x5d, 0xf5, 0x05, 0x7a, 0xd3, 0xb7, 0x68, 0xaf, 0xda, 0xbb, 0x5c, 0xb6, 0x6f, 0xd2, 0x57,
-	0xe9, 0xd9, 0x1f, 0x80, 0x00, 0xf8, 0x23, 0xba, 0x6e, 0xaf, 0xc4, 0x9d, 0xf9, 0x66, 0x76, 0x76,
-	0x67, 0x76, 0xbe, 0x81, 0xa0, 0x42, 0x27, 0x83, 0xd6, 0x84, 0x92, 0x80, 0xa0, 0x75, 0x3b, 0x0c,
-	0x08, 0x25, 0x9e, 0xd7, 0xa2, 0x93, 0x41, 0xe3, 0xe1, 0x88, 0x90, 0x91, 0x87, 0x77, 0x85, 0xee,
-	0x2a, 0x1c, 0xee, 0x06, 0xee, 0x18, 0xb3, 0xc0, 0x1e, 0x4f, 0x24, 0xdc, 0xf8, 0x57, 0x01, 0xd0,
-	0x7e, 0x18, 0x10, 0x93, 0x78, 0xde, 0x99, 0xeb, 0xbb, 0xbd, 0xc0, 0x0e, 0x42, 0x86, 0xee, 0x43,
-	0x85, 0xfb, 0xc0, 0xd4, 0x72, 0x1d, 0x3d, 0xd7, 0xcc, 0xed, 0x54, 0x4c, 0x4d, 0x0a, 0x4e, 0x1c,
-	0xf4, 0x00, 0x60, 0xf0, 0xda, 0xf5, 0x1c, 0xcb, 0xb7, 0xc7, 0x58, 0xcf, 0x0b, 0x6d, 0x45, 0x48,
-	0xba, 0xf6, 0x18, 0xa3, 0x87, 0x50, 0x9d, 0xd8, 0x14, 0xfb, 0x81, 0xd4, 0x17, 0x84, 0x1e, 0xa4,
-	0x48, 0x00, 0x9e, 0x42, 0x71, 0x4c, 0x1c, 0xac, 0x17, 0x9b, 0xb9, 0x9d, 0xda, 0x1e, 0x6a, 0x25,
-	0x23, 0x6e, 0x9d, 0x11, 0x07, 0x9b, 0x42, 0x8f, 0x76, 0xa0, 0x3e, 0x08, 0xa9, 0xf0, 0xc4, 0xd5,
-	0x16, 0xc5, 0xd7, 0xfa, 0x9a, 0xf0, 0x56, 0x53, 0x72, 0x1e, 0xb5, 0x89, 0xaf, 0x91, 0x01, 0x1b,
-	0x9e, 0xcd, 0x12, 0xb0, 0x92, 0x80, 0x55, 0xb9, 0x30, 0xc2, 0x3c, 0x00, 0xf0, 0xc3, 0xb1, 0x35,
-	0xb4, 0x5d, 0x0f, 0x3b, 0x7a, 0xb9, 0x99, 0xdb, 0x59, 0x33, 0x2b, 0x7e, 0x38, 0x3e, 0x14, 0x82,
-	0x48, 0x7d, 0x85, 0x5f, 0xbb, 0xbe, 0xa3, 0x6b, 0xb1, 0xfa, 0xa5, 0x10, 0xa0, 0x17, 0x50, 0x89,
-	0xaf, 0x4e, 0xaf, 0x34, 0x73, 0x3b, 0xd5, 0xbd, 0x46, 0x4b, 0x5e, 0x6e, 0x2b, 0xba, 0xdc, 0xd6,
-	0x65, 0x84, 0x30, 0xa7, 0x60, 0x64, 0xc1, 0xa7, 0x22, 0x36, 0x16, 0x0e, 0x06, 0x98, 0xb1, 0x61,
-	0xe8, 0xc9, 0x30, 0xa7, 0xee, 0xe0, 0x56, 0x77, 0xf7, 0xb9, 0x87, 0x5e, 0xec, 0x80, 0x1f, 0x29,
-	0x56, 0x1a, 0x7f, 0xcd, 0x43, 0xe9, 0x92, 0xde, 0xfc, 0x8a, 0x5c, 0x21, 0x04, 0x45, 0x71, 0xe7,
-	0x32, 0x63, 0xe2, 0x37, 0x7a, 0x06, 0x25, 0x26, 0x92, 0x2a, 0x32, 0x55, 0xdb, 0xbb, 0x9f, 0xbe,
-	0x6f, 0x69, 0xd9, 0x92, 0x79, 0x37, 0x15, 0x94, 0x1b, 0x51, 0xcc, 0x42, 0x2f, 0x10, 0xe9, 0x5b,
-	0x64, 0x64, 0x0a, 0x88, 0xa9, 0xa0, 0xa8, 0x0e, 0x85, 0x90, 0x7a, 0x22, 0xad, 0x15, 0x93, 0xff,
-	0x44, 0x0d, 0xd0, 0x06, 0x76, 0x80, 0x47, 0x84, 0xde, 0xa8, 0xcc, 0xc5, 0x6b, 0xe3, 0x97, 0x50,
-	0x92, 0xf6, 0xa8, 0x0a, 0xe5, 0x7e, 0xf7, 0xbb, 0xee, 0xf9, 0xab, 0x6e, 0xfd, 0x27, 0x7c, 0xd1,
-	0xeb, 0xb7, 0xdb, 0x9d, 0x5e, 0xaf, 0x9e, 0xe3, 0x8b, 0xc3, 0xfd, 0x93, 0xd3, 0xbe, 0xd9, 0xa9,
-	0xe7, 0xd1, 0x3a, 0x68, 0xed, 0xfd, 0x6e, 0xbb, 0x73, 0xda, 0x39, 0xa8, 0x17, 0x8c, 0x67, 0x50,
-	0x52, 0xb5, 0xba, 0x01, 0x95, 0x5e, 0xfb, 0xb8, 0x73, 0xd0, 0xe7, 0x0a, 0xe9, 0xe0, 0x72, 0xdf,
-	0xbc, 0xec, 0x1c, 0xd4, 0x73, 0x5c, 0xd7, 0x3e, 0x3f, 0xbb, 0x38, 0xed, 0xf0, 0x65, 0xde, 0xf8,
-	0x77, 0x01, 0x20, 0xaa, 0xf6, 0xf6, 0x29, 0xaa, 0x41, 0x3e, 0x2e, 0xef, 0xbc, 0xeb, 0xa0, 0xaf,
-	0xe3, 0x53, 0xcb, 0xab, 0x7a, 0x98, 0x3e, 0xf5, 0xd4, 0x32, 0x7b, 0x72, 0x1d, 0xca, 0x2c, 0xbc,
-	0x7a, 0x83, 0x07, 0x81, 0x2a, 0xf7, 0x68, 0xc9, 0xcb, 0x8a, 0xdb, 0xbb, 0xfe, 0xc8, 0x0a, 0x88,
-	0xba, 0x9a, 0x8a, 0x92, 0x5c, 0x12, 0xf4, 0x08, 0xd6, 0x23, 0xf5, 0x90, 0x92, 0xb1, 0xba, 0xa4,
-	0xaa, 0x92, 0x1d, 0x52, 0x32, 0x46, 0xcf, 0xa1, 0x3c, 0xa0, 0xd8, 0x0e, 0xb0, 0x23, 0xaa, 0x7a,
-	0x79, 0xa1, 0x44, 0x50, 0xf4, 0x15, 0x68, 0x63, 0xe2, 0xb8, 0x43, 0x57, 0xd5, 0xfa, 0x72, 0xb3,
-	0x18, 0x8b, 0x76, 0x41, 0x0b, 0xe8, 0x8d, 0xf5, 0x86, 0x5c, 0x31, 0x5d, 0x6b, 0x16, 0x76, 0xaa,
-	0x7b, 0xdb, 0xf3, 0x52, 0x6f, 0x96, 0x03, 0xf1, 0x97, 0x19, 0x7f, 0xce, 0xc5, 0x79, 0xdc, 0x84,
-	0xea, 0x49, 0xd7, 0xba, 0x30, 0xcf, 0x8f, 0x4c, 0x9e, 0xbe, 0x65, 0xb9, 0xfc, 0x18, 0xb6, 0x0e,
-	0xcc, 0xdf, 0x5a, 0x66, 0xbf, 0x6b, 0x25, 0x4d, 0x0a, 0x68, 0x0b, 0x36, 0x23, 0x45, 0x64, 0x5a,
-	0x4c, 0x0a, 0x23, 0x17, 0x6b, 0x68, 0x1b, 0xea, 0xc7, 0xfd, 0xb3, 0x7d, 0xee, 0xe0, 0xb2, 0x63,
-	0xfe, 0xba, 0xd3, 0xed, 0x1c, 0xd4, 0x4b, 0xc6, 0x3f, 0x73, 0xa0, 0x99, 0xf8, 0xda, 0x65, 0x2e,
-	0xf1, 0x67, 0xf2, 0xab, 0x43, 0xd9, 0x71, 0xd9, 0xc4, 0xb3, 0x6f, 0x54, 0xd7, 0x8a, 0x96, 0xa8,
-	0x09, 0x55, 0x07, 0xb3, 0x01, 0x75, 0x27, 0x81, 0x4b, 0x7c, 0x95, 0xc4, 0xa4, 0x08, 0xb5, 0xa0,
-	0xc8, 0x5f, 0xac, 0x48, 0xe1, 0xf2, 0xcb, 0x14, 0xb8, 0xe8, 0x31, 0xac, 0x4d, 0x1f, 0xc3, 0x67,
-	0x50, 0x73, 0xfd, 0x6b, 0xdb, 0x73, 0x1d, 0x8b, 0x62, 0x9b, 0x11, 0x5f, 0x75, 0xa9, 0x0d, 0x25,
-	0x35, 0x85, 0xd0, 0xf8, 0x47, 0x1e, 0x6a, 0x71, 0xa5, 0x11, 0x7f, 0xe8, 0x8e, 0xd0, 0x13, 0xa8,
-	0xc9, 0x86, 0x7b, 0x15, 0x8e, 0x2c, 0xcf, 0xf5, 0xdf, 0x2a, 0xb7, 0xeb, 0x42, 0xfa, 0x32, 0x1c,
-	0x9d, 0xba, 0xfe, 0x5b, 0xf4, 0x14, 0x36, 0x55, 0xdf, 0x8d, 0x61, 0x6a, 0x03, 0x29, 0x8e, 0x70,
-	0x3f, 0x85, 0xba, 0xc2, 0xbd, 0xb3, 0x03, 0x4c, 0x87, 0xb6, 0xe7, 0xa9, 0x3b, 0x52, 0xf6, 0xaf,
-	0x22, 0x71, 0x9a, 0x06, 0xf2, 0x19, 0x1a, 0xd8, 0x83, 0x8f, 0x58, 0x38, 0x99, 0x10, 0x1a, 0x30,
-	0x6b, 0x6c, 0xfb, 0xa1, 0x2d, 0x1b, 0x1b, 0x13, 0xb7, 0xa7, 0x99, 0x5b, 0x91, 0xf2, 0x4c, 0xe8,
-	0xf8, 0x71, 0x18, 0xe7, 0x06, 0x7e, 0x3b, 0xd6, 0x3b, 0xd7, 0x77, 0xc8, 0x3b, 0xf5, 0x1e, 0x80,
-	0x8b, 0x5e, 0x09, 0x09, 0x7a, 0x06, 0x55, 0x79, 0x45, 0x9c, 0x01, 0x98, 0x5e, 0x6e, 0x16, 0x16,
-	0x50, 0x04, 0x08, 0x18, 0xff, 0xc9, 0x8c, 0xbf, 0xe7, 0x00, 0xf8, 0xaf, 0xf6, 0x6b, 0xdb, 0x1f,
-	0xe1, 0xe5, 0xe4, 0x15, 0x91, 0x4f, 0xfe, 0x16, 0xf2, 0x41, 0x50, 0x0c, 0x19, 0xa6, 0xaa, 0x14,
-	0xc4, 0xef, 0xf7, 0xae, 0x01, 0x1d, 0xca, 0x63, 0xcc, 0x98, 0x3d, 0xc2, 0x2a, 0x61, 0xd1, 0x92,
-	0x97, 0x69, 0xad, 0x17, 0x50, 0xde, 0x0b, 0x6f, 0x56, 0x89, 0x7a, 0x0f, 0x34, 0xa6, 0xe0, 0x2a,
-	0xf2, 0xbb, 0xe9, 0xc8, 0x23, 0x67, 0x66, 0x8c, 0xfb, 0x3f, 0x9f, 0xe0, 0x2f, 0x45, 0x80, 0x69,
-	0x66, 0x67, 0x9e, 0xda, 0xd2, 0xca, 0x69, 0x80, 0x46, 0xd5, 0x1b, 0x55, 0xd1, 0xc5, 0x6b, 0xf4,
-	0x09, 0x54, 0x28, 0xfe, 0x43, 0x88, 0x59, 0x80, 0x69, 0xdc, 0x2f, 0x23, 0x41, 0xa2, 0x43, 0xaf,
-	0xcd, 0xeb, 0xd0, 0xd3, 0x80, 0xb2, 0x1d, 0xfa, 0xeb, 0x98, 0x05, 0x4b, 0xb7, 0x18, 0x66, 0x98,
-	0x30, 0x45, 0xfc, 0xe5, 0xf7, 0x21, 0x7e, 0xd5, 0x01, 0xb4, 0x69, 0x07, 0xf8, 0x18, 0xca, 0x0e,
-	0xbd, 0xb1, 0x68, 0xe8, 0x8b, 0x11, 0x42, 0x33, 0x4b, 0x0e, 0xbd, 0x31, 0x43, 0x1f, 0xdd, 0x03,
-	0xcd, 0x27, 0x16, 0x1e, 0xdb, 0xae, 0x27, 0xa6, 0x01, 0xcd, 0x2c, 0xfb, 0xa4, 0xc3, 0x97, 0xa8,
-	0x05, 0x5b, 0x3e, 0xb1, 0x28, 0x66, 0xc4, 0xbb, 0xc6, 0x56, 0x7c, 0x6d, 0x55, 0x81, 0xba, 0xe3,
-	0x13, 0x53, 0x6a, 0xe2, 0x9e, 0x77, 0x17, 0x4a, 0x03, 0xdb, 0xb7, 0xe9, 0x8d, 0xbe, 0x2e, 0xb7,
-	0x90, 0x2b, 0x63, 0x77, 0x21, 0xdd, 0x46, 0x2d, 0x35, 0x97, 0xec, 0xd7, 0x79, 0xe3, 0xe7, 0x31,
-	0xc1, 0x56, 0xa1, 0x7c, 0xd1, 0xe9, 0x1e, 0x9c, 0x74, 0x8f, 0x6e, 0xa1, 0xd7, 0x1f, 0x4b, 0xd3,
-	0xd6, 0xa5, 0x6c, 0xf7, 0xa1, 0x3a, 0x76, 0x7d, 0xd7, 0x52, 0x97, 0x9f, 0x13, 0x17, 0xd8, 0x9c,
-	0xcf, 0xab, 0xd3, 0xf9, 0xd3, 0x84, 0xf1, 0x74, 0x16, 0xbd, 0x9b, 0x1a, 0x60, 0x2a, 0x71, 0x66,
-	0x9e, 0x43, 0x69, 0x20, 0xfa, 0xa3, 0xa8, 0xa1, 0xea, 0xde, 0x27, 0x0b, 0xd8, 0x5a, 0x60, 0x4c,
-	0x85, 0xe5, 0x43, 0xe5, 0x30, 0xf4, 0x3c, 0xeb, 0xb5, 0xcb, 0x02, 0x42, 0x6f, 0xac, 0xe9, 0xc4,
-	0x52, 0xe3, 0xf2, 0x63, 0x29, 0xee, 0x53, 0x8f, 0x77, 0x5d, 0x97, 0xb1, 0x10, 0x73, 0x88, 0x75,
-	0x65, 0xb3, 0xe8, 0x09, 0xac, 0x0b, 0x69, 0x9f, 0x7a, 0x2f, 0x6d, 0x86, 0xd1, 0x97, 0xaa, 0x9f,
-	0x48, 0x6e, 0xd6, 0x67, 0xfb, 0x89, 0x7c, 0xde, 0xaa, 0xab, 0xbc, 0x48, 0xbc, 0xe3, 0xf2, 0xbc,
-	0xa8, 0xd3, 0x4d, 0x21, 0xf1, 0x9a, 0x8f, 0x61, 0xdb, 0x27, 0x72, 0xc2, 0xc5, 0x4e, 0x5c, 0x07,
-	0x11, 0x49, 0x67, 0xba, 0x41, 0x54, 0x0d, 0x26, 0xf2, 0x89, 0x18, 0x80, 0xb1, 0x13, 0x89, 0x18,
-	0xfa, 0x16, 0xd6, 0x93, 0x63, 0xb5, 0x9a, 0x66, 0xf5, 0x45, 0xb3, 0x8e, 0x59, 0x4d, 0x0c, 0xdb,
-	0xe8, 0x17, 0x50, 0x89, 0x27, 0x6d, 0x35, 0xb8, 0x2e, 0xb6, 0xd4, 0xa2, 0xf9, 0x9b, 0xef, 0x49,
-	0xf1, 0x20, 0xda, 0x92, 0xe9, 0x55, 0x11, 0xf5, 0x92, 0x3d, 0x25, 0x5a, 0x92, 0xc6, 0xb7, 0xb0,
-	0x9e, 0xe2, 0x97, 0xf5, 0x79, 0xc6, 0xd3, 0x17, 0x6c, 0x56, 0xc7, 0x09, 0xc6, 0xd9, 0x86, 0x35,
-	0x4c, 0x29, 0xa1, 0xfa, 0x86, 0x48, 0x9e, 0x5c, 0xa0, 0x36, 0x6c, 0x06, 0xaf, 0x29, 0x09, 0x02,
-	0x7e, 0x99, 0xa1, 0x1f, 0xb8, 0x9e, 0x5e, 0xbb, 0xf5, 0x6d, 0xd7, 0x62, 0x93, 0x3e, 0xb7, 0x40,
-	0x27, 0x70, 0x67, 0xe0, 0x61, 0xdb, 0x0f, 0x27, 0x56, 0xd4, 0xa1, 0x1c, 0x7d, 0x73, 0x5e, 0x56,
-	0xdb, 0x12, 0x66, 0x4a, 0x94, 0x59, 0x1f, 0xa4, 0xd6, 0xd8, 0x31, 0xb6, 0xe0, 0xce, 0x11, 0x96,
-	0x99, 0xa2, 0x4c, 0x89, 0x8d, 0x0b, 0x40, 0x49, 0x21, 0x9b, 0x10, 0x9f, 0x61, 0xf4, 0x0d, 0x94,
-	0x65, 0x23, 0xe5, 0xaf, 0xa9, 0xb0, 0xd2, 0x6b, 0x8a, 0x0c, 0x8c, 0x43, 0xd8, 0x54, 0x1e, 0xa3,
-	0x4d, 0x96, 0xd3, 0x0e, 0x6f, 0x26, 0x21, 0x65, 0x84, 0x46, 0x4f, 0x4f, 0xae, 0x8c, 0x1f, 0xa0,
-	0x3e, 0xf5, 0xa3, 0xe2, 0x6a, 0xc1, 0x9a, 0x4c, 0x4f, 0xee, 0x96, 0xdc, 0x4a, 0xd8, 0x42, 0xdf,
-	0xcf, 0x60, 0xfb, 0x08, 0x07, 0x89, 0xe8, 0x57, 0x08, 0xd4, 0xf8, 0x1e, 0x3e, 0xca, 0x18, 0xa9,
-	0xa8, 0x5e, 0xc4, 0xcd, 0x63, 0xd5, 0xd6, 0xa3, 0xf0, 0xc6, 0xae, 0x38, 0xe3, 0x7b, 0xc4, 0x70,
-	0x22, 0x72, 0x98, 0xd9, 0xff, 0x79, 0x66, 0xff, 0x05, 0x4d, 0x2a, 0xb3, 0x37, 0x81, 0x5a, 0x0f,
-	0x07, 0x62, 0x1a, 0x59, 0x25, 0x4d, 0xab, 0xce, 0x34, 0x09, 0x36, 0x2f, 0xa6, 0xd9, 0xfc, 0x08,
-	0x36, 0xe3, 0x0d, 0x3f, 0x28, 0xf2, 0x53, 0x99, 0x08, 0xe2, 0x60, 0xd5, 0x49, 0x57, 0xad, 0x33,
-	0x32, 0x1c, 0x32, 0x2c, 0x3f, 0xbc, 0xd6, 0x4c, 0xb5, 0x32, 0xc6, 0x70, 0x37, 0xeb, 0x4d, 0x45,
-	0xb7, 0x07, 0x65, 0xd5, 0xc1, 0xe7, 0xd7, 0x5b, 0xa2, 0xf3, 0x46, 0x40, 0x3e, 0x7c, 0xfa, 0xf8,
-	0x8f, 0x81, 0x95, 0xda, 0x0a, 0xb8, 0xe8, 0x5c, 0x6e, 0xf7, 0x27, 0x40, 0x3d, 0x9e, 0x41, 0x35,
-	0x4a, 0xad, 0x12, 0xf9, 0x7f, 0x33, 0x98, 0x2d, 0x4e, 0xc3, 0x77, 0xb0, 0x95, 0x0a, 0xe0, 0x83,
-	0x52, 0x71, 0x01, 0xf7, 0x8e, 0xa6, 0xce, 0xfe, 0x17, 0xe9, 0x08, 0xa1, 0x31, 0xcf, 0xa3, 0x8a,
-	0xf2, 0xab, 0x6c, 0x4a, 0x96, 0x53, 0xdb, 0xea, 0x69, 0x79, 0x0b, 0x1f, 0xb7, 0xc5, 0x67, 0x6d,
-	0xa2, 0xc7, 0xaf, 0x72, 0x8c, 0xe4, 0x98, 0x99, 0xcf, 0x8c, 0x99, 0x89, 0x51, 0xac, 0x90, 0x1c,
-	0xc5, 0x8c, 0x63, 0xd0, 0x67, 0x37, 0x53, 0x27, 0xfc, 0x12, 0x8a, 0x82, 0xf7, 0x72, 0x73, 0xb9,
-	0x7e, 0x8a, 0x17, 0x28, 0xe3, 0x67, 0x70, 0xa7, 0xef, 0x47, 0x94, 0xb1, 0x52, 0x07, 0xd9, 0x06,
-	0x94, 0xb4, 0x90, 0xbb, 0x1a, 0xbf, 0x07, 0x7d, 0xdf, 0x71, 0x32, 0x14, 0xb2, 0xca, 0xf9, 0x9f,
-	0xc0, 0xc6, 0x9b, 0x90, 0x05, 0xee, 0xd0, 0x1d, 0xd8, 0xc1, 0xf4, 0x12, 0xd2, 0x42, 0xe3, 0x7b,
-	0xb8, 0x37, 0xc7, 0xfd, 0x07, 0x55, 0xde, 0x19, 0xe8, 0x47, 0x38, 0x50, 0x2e, 0xdf, 0xa7, 0xf0,
-	0xb6, 0x61, 0xcd, 0x73, 0xc7, 0xae, 0x2c, 0x82, 0x0d, 0x53, 0x2e, 0x8c, 0x9e, 0x28, 0xe4, 0xac,
-	0xbb, 0x15, 0xab, 0x2e, 0x73, 0xb0, 0x08, 0x6c, 0xfc, 0x2d, 0x07, 0xb5, 0xb4, 0x0e, 0x3d, 0x86,
-	0x0d, 0x1f, 0x63, 0x87, 0x59, 0x8a, 0x9e, 0x45, 0x78, 0x9a, 0xb9, 0x2e, 0x84, 0x0a, 0x1b, 0x7f,
-	0x55, 0xe5, 0x13, 0x5f, 0x55, 0xa9, 0x6f, 0x84, 0xc2, 0xfb, 0x7c, 0x23, 0xcc, 0xa4, 0xa8, 0x38,
-	0x27, 0x45, 0x5f, 0x7c, 0x03, 0x45, 0xde, 0xcf, 0xf8, 0xec, 0x6d, 0xf6, 0xbb, 0xdd, 0xc4, 0x20,
-	0x7e, 0x7e, 0x71, 0x21, 0x06, 0xf1, 0x2a, 0x94, 0xd5, 0x7f, 0x48, 0xea, 0x79, 0xbe, 0x38, 0x3f,
-	0x3c, 0x3c, 0x3d, 0xe9, 0x76, 0xea, 0x85, 0x2f, 0x5a, 0xa0, 0x45, 0x0f, 0x0f, 0x55, 0x60, 0xed,
-	0xe5, 0xfe, 0x65, 0xfb, 0x58, 0x5a, 0x77, 0x2d, 0xb9, 0xc8, 0x21, 0x80, 0x52, 0xef, 0xa4, 0x7b,
-	0x74, 0xda, 0xa9, 0xe7, 0xf7, 0x7e, 0xd4, 0x60, 0x33, 0x4e, 0x2b, 0xa6, 0xd7, 0xee, 0x00, 0x23,
-	0x07, 0xee, 0xcc, 0x94, 0x08, 0x7a, 0x9a, 0x29, 0x85, 0x05, 0x25, 0xda, 0xf8, 0xfc, 0x56, 0x9c,
-	0xca, 0xa4, 0x23, 0xf8, 0x33, 0x9d, 0xe6, 0xec, 0x2e, 0x8b, 0xca, 0x2a, 0xbb, 0xcb, 0xe2, 0x7a,
-	0x39, 0x07, 0x98, 0x0e, 0x55, 0xe8, 0xe1, 0x8c, 0x59, 0x7a, 0x06, 0x6b, 0x34, 0x17, 0x03, 0x94,
-	0xc3, 0x13, 0xd0, 0xa2, 0x59, 0x08, 0x3d, 0x98, 0x8b, 0x8e, 0x9d, 0x7d, 0xba, 0x48, 0xad, 0x5c,
-	0xfd, 0x06, 0x36, 0x52, 0x53, 0x0c, 0x32, 0x66, 0x0c, 0x66, 0xe6, 0xa2, 0xc6, 0xe3, 0xa5, 0x18,
-	0xe5, 0xf9, 0x14, 0x2a, 0xf1, 0x6c, 0x82, 0x66, 0xc3, 0x48, 0x7b, 0x7c, 0xb8, 0x50, 0xaf, 0xbc,
-	0x1d, 0x42, 0x59, 0x4d, 0x0b, 0x28, 0xdb, 0xe3, 0x53, 0x53, 0x4b, 0xe3, 0xc1, 0x02, 0xad, 0xf2,
-	0xf3, 0x3b, 0xa8, 0xa5, 0xe9, 0x1d, 0xcd, 0x39, 0xcc, 0xcc, 0x28, 0xd1, 0x78, 0xb2, 0x1c, 0xa4,
-	0x9c, 0x9b, 0x50, 0x4d, 0x70, 0x29, 0x6a, 0xce, 0x84, 0x92, 0xe1, 0xf9, 0xc6, 0xa3, 0x25, 0x08,
-	0xe5, 0x73, 0x24, 0x26, 0xf2, 0x0c, 0x01, 0xa2, 0xcf, 0xe7, 0xdc, 0xd7, 0x3c, 0xd2, 0x6d, 0xec,
-	0xdc, 0x0e, 0x54, 0x1b, 0xd9, 0x50, 0xcf, 0xb2, 0x10, 0xfa, 0x2c, 0xd3, 0xd8, 0xe6, 0x53, 0x62,
-	0xe3, 0xe9, 0x6d, 0xb0, 0xe9, 0x43, 0x98, 0x92, 0x4d, 0xf6, 0x21, 0xcc, 0x10, 0x57, 0xf6, 0x21,
-	0xcc, 0xf2, 0xd4, 0xcb, 0xc7, 0x3f, 0x3c, 0x1a, 0x91, 0x16, 0x7b, 0xeb, 0xda, 0x2d, 0x42, 0x47,
-	0xbb, 0xae, 0x3f, 0xa4, 0xf6, 0x6e, 0x64, 0xb4, 0x3b, 0x22, 0xbb, 0x74, 0x32, 0xb8, 0x2a, 0x89,
-	0x7e, 0xf8, 0xec, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x76, 0x9c, 0x0a, 0x3c, 0xb2, 0x1a, 0x00,
-	0x00,
+	// 2213 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4b, 0x73, 0xdb, 0xc8,
+	0x11, 0x0e, 0x1f, 0xe2, 0xa3, 0x29, 0x51, 0xd4, 0x48, 0xbb, 0x86, 0xe9, 0xf5, 0x9a, 0x86, 0xbd,
+	0xb6, 0xb2, 0xb5, 0x45, 0x25, 0xb2, 0xb3, 0x76, 0x79, 0x2b, 0x07, 0x89, 0xa2, 0x1e, 0x59, 0x89,
+	0xd2, 0x82, 0x52, 0x9c, 0x6c, 0x2a, 0x85, 0x82, 0x88, 0x21, 0x0d, 0x1b, 0xc4, 0x30, 0x33, 0x80,
+	0xbc, 0x3a, 0xe5, 0x0f, 0xe4, 0x92, 0x1f, 0x90, 0x43, 0x6e, 0xc9, 0x29, 0xb9, 0xe5, 0x98, 0xfc,
+	0x93, 0xfc, 0x95, 0xd4, 0x3c, 0x00, 0x02, 0xe0, 0xd3, 0x71, 0x72, 0x12, 0xa7, 0xfb, 0xeb, 0x9e,
+	0x9e, 0x7e, 0x43, 0x50, 0xa6, 0xa3, 0x5e, 0x73, 0x44, 0x89, 0x4f, 0xd0, 0xaa, 0x15, 0xf8, 0x84,
+	0x12, 0xd7, 0x6d, 0xd2, 0x51, 0xaf, 0xfe, 0x60, 0x40, 0xc8, 0xc0, 0xc5, 0x3b, 0x82, 0x77, 0x1d,
+	0xf4, 0x77, 0x7c, 0x67, 0x88, 0x99, 0x6f, 0x0d, 0x47, 0x12, 0xae, 0xff, 0x2b, 0x07, 0x68, 0x2f,
+	0xf0, 0x89, 0x41, 0x5c, 0xf7, 0xcc, 0xf1, 0x9c, 0xae, 0x6f, 0xf9, 0x01, 0x43, 0xf7, 0xa0, 0xcc,
+	0x75, 0x60, 0x6a, 0x3a, 0xb6, 0x96, 0x69, 0x64, 0xb6, 0xcb, 0x46, 0x49, 0x12, 0x4e, 0x6c, 0x74,
+	0x1f, 0xa0, 0xf7, 0xc6, 0x71, 0x6d, 0xd3, 0xb3, 0x86, 0x58, 0xcb, 0x0a, 0x6e, 0x59, 0x50, 0x3a,
+	0xd6, 0x10, 0xa3, 0x07, 0x50, 0x19, 0x59, 0x14, 0x7b, 0xbe, 0xe4, 0xe7, 0x04, 0x1f, 0x24, 0x49,
+	0x00, 0x9e, 0x40, 0x7e, 0x48, 0x6c, 0xac, 0xe5, 0x1b, 0x99, 0xed, 0xea, 0x2e, 0x6a, 0xc6, 0x2d,
+	0x6e, 0x9e, 0x11, 0x1b, 0x1b, 0x82, 0x8f, 0xb6, 0xa1, 0xd6, 0x0b, 0xa8, 0xd0, 0xc4, 0xd9, 0x26,
+	0xc5, 0x37, 0xda, 0x8a, 0xd0, 0x56, 0x55, 0x74, 0x6e, 0xb5, 0x81, 0x6f, 0x90, 0x0e, 0x6b, 0xae,
+	0xc5, 0x62, 0xb0, 0x82, 0x80, 0x55, 0x38, 0x31, 0xc4, 0xdc, 0x07, 0xf0, 0x82, 0xa1, 0xd9, 0xb7,
+	0x1c, 0x17, 0xdb, 0x5a, 0xb1, 0x91, 0xd9, 0x5e, 0x31, 0xca, 0x5e, 0x30, 0x3c, 0x14, 0x84, 0x90,
+	0x7d, 0x8d, 0xdf, 0x38, 0x9e, 0xad, 0x95, 0x22, 0xf6, 0xbe, 0x20, 0xa0, 0x97, 0x50, 0x8e, 0x5c,
+	0xa7, 0x95, 0x1b, 0x99, 0xed, 0xca, 0x6e, 0xbd, 0x29, 0x9d, 0xdb, 0x0c, 0x9d, 0xdb, 0xbc, 0x0c,
+	0x11, 0xc6, 0x18, 0x8c, 0x4c, 0xf8, 0x5c, 0xd8, 0xc6, 0x82, 0x5e, 0x0f, 0x33, 0xd6, 0x0f, 0x5c,
+	0x69, 0xe6, 0x58, 0x1d, 0x2c, 0x54, 0x77, 0x8f, 0x6b, 0xe8, 0x46, 0x0a, 0xf8, 0x93, 0x22, 0xa6,
+	0xfe, 0x97, 0x2c, 0x14, 0x2e, 0xe9, 0xed, 0x2f, 0xc8, 0x35, 0x42, 0x90, 0x17, 0x3e, 0x97, 0x11,
+	0x13, 0xbf, 0xd1, 0x33, 0x28, 0x30, 0x11, 0x54, 0x11, 0xa9, 0xea, 0xee, 0xbd, 0xa4, 0xbf, 0xa5,
+	0x64, 0x53, 0xc6, 0xdd, 0x50, 0x50, 0x2e, 0x44, 0x31, 0x0b, 0x5c, 0x5f, 0xcb, 0xcd, 0x11, 0x32,
+	0x04, 0xc4, 0x50, 0x50, 0x54, 0x83, 0x5c, 0x40, 0x5d, 0x11, 0xd6, 0xb2, 0xc1, 0x7f, 0xa2, 0x3a,
+	0x94, 0x7a, 0x96, 0x8f, 0x07, 0x84, 0xde, 0xaa, 0xc8, 0x45, 0x67, 0xfd, 0xe7, 0x50, 0x90, 0xf2,
+	0xa8, 0x02, 0xc5, 0xab, 0xce, 0xb7, 0x9d, 0xf3, 0xd7, 0x9d, 0xda, 0x8f, 0xf8, 0xa1, 0x7b, 0xd5,
+	0x6a, 0xb5, 0xbb, 0xdd, 0x5a, 0x86, 0x1f, 0x0e, 0xf7, 0x4e, 0x4e, 0xaf, 0x8c, 0x76, 0x2d, 0x8b,
+	0x56, 0xa1, 0xd4, 0xda, 0xeb, 0xb4, 0xda, 0xa7, 0xed, 0x83, 0x5a, 0x4e, 0x7f, 0x06, 0x05, 0x95,
+	0xab, 0x6b, 0x50, 0xee, 0xb6, 0x8e, 0xdb, 0x07, 0x57, 0x9c, 0x21, 0x15, 0x5c, 0xee, 0x19, 0x97,
+	0xed, 0x83, 0x5a, 0x86, 0xf3, 0x5a, 0xe7, 0x67, 0x17, 0xa7, 0x6d, 0x7e, 0xcc, 0xea, 0xff, 0xce,
+	0x01, 0x84, 0xd9, 0xde, 0x3a, 0x45, 0x55, 0xc8, 0x46, 0xe9, 0x9d, 0x75, 0x6c, 0xf4, 0x22, 0x7a,
+	0xb5, 0x74, 0xd5, 0x83, 0xe4, 0xab, 0xc7, 0x92, 0xe9, 0x97, 0x6b, 0x50, 0x64, 0xc1, 0xf5, 0x5b,
+	0xdc, 0xf3, 0x55, 0xba, 0x87, 0x47, 0x9e, 0x56, 0x5c, 0xde, 0xf1, 0x06, 0xa6, 0x4f, 0x94, 0x6b,
+	0xca, 0x8a, 0x72, 0x49, 0xd0, 0x43, 0x58, 0x0d, 0xd9, 0x7d, 0x4a, 0x86, 0xca, 0x49, 0x15, 0x45,
+	0x3b, 0xa4, 0x64, 0x88, 0x9e, 0x43, 0xb1, 0x47, 0xb1, 0xe5, 0x63, 0x5b, 0x2b, 0x2c, 0x4c, 0x94,
+	0x10, 0x8a, 0xbe, 0x86, 0xd2, 0x90, 0xd8, 0x4e, 0xdf, 0x51, 0xb9, 0x3e, 0x5f, 0x2c, 0xc2, 0xa2,
+	0x1d, 0x28, 0xf9, 0xf4, 0xd6, 0x7c, 0x4b, 0xae, 0x99, 0x56, 0x6a, 0xe4, 0xb6, 0x2b, 0xbb, 0x5b,
+	0xd3, 0x42, 0x6f, 0x14, 0x7d, 0xf1, 0x97, 0xe9, 0x7f, 0xc8, 0x44, 0x71, 0x5c, 0x87, 0xca, 0x49,
+	0xc7, 0xbc, 0x30, 0xce, 0x8f, 0x0c, 0x1e, 0xbe, 0x79, 0xb1, 0xbc, 0x03, 0x9b, 0x07, 0xc6, 0xaf,
+	0x4d, 0xe3, 0xaa, 0x63, 0xc6, 0x45, 0x72, 0x68, 0x13, 0xd6, 0x43, 0x46, 0x28, 0x9a, 0x8f, 0x13,
+	0x43, 0x15, 0x2b, 0x68, 0x0b, 0x6a, 0xc7, 0x57, 0x67, 0x7b, 0x5c, 0xc1, 0x65, 0xdb, 0xf8, 0x65,
+	0xbb, 0xd3, 0x3e, 0xa8, 0x15, 0xf4, 0x7f, 0x66, 0xa0, 0x64, 0xe0, 0x1b, 0x87, 0x39, 0xc4, 0x9b,
+	0x88, 0xaf, 0x06, 0x45, 0xdb, 0x61, 0x23, 0xd7, 0xba, 0x55, 0x5d, 0x2b, 0x3c, 0xa2, 0x06, 0x54,
+	0x6c, 0xcc, 0x7a, 0xd4, 0x19, 0xf9, 0x0e, 0xf1, 0x54, 0x10, 0xe3, 0x24, 0xd4, 0x84, 0x3c, 0xaf,
+	0x58, 0x2d, 0xbf, 0xd0, 0x99, 0x02, 0x17, 0x16, 0xc3, 0xca, 0xb8, 0x18, 0xbe, 0x80, 0xaa, 0xe3,
+	0xdd, 0x58, 0xae, 0x63, 0x9b, 0x14, 0x5b, 0x8c, 0x78, 0xaa, 0x4b, 0xad, 0x29, 0xaa, 0x21, 0x88,
+	0xfa, 0xdf, 0xb3, 0x50, 0x8d, 0x32, 0x8d, 0x78, 0x7d, 0x67, 0x80, 0x1e, 0x43, 0x55, 0x36, 0xdc,
+	0xeb, 0x60, 0x60, 0xba, 0x8e, 0xf7, 0x4e, 0xa9, 0x5d, 0x15, 0xd4, 0xfd, 0x60, 0x70, 0xea, 0x78,
+	0xef, 0xd0, 0x13, 0x58, 0x57, 0x7d, 0x37, 0x82, 0xa9, 0x0b, 0x24, 0x39, 0xc4, 0xfd, 0x18, 0x6a,
+	0x0a, 0xf7, 0xde, 0xf2, 0x31, 0xed, 0x5b, 0xae, 0xab, 0x7c, 0xa4, 0xe4, 0x5f, 0x87, 0xe4, 0xe4,
+	0x18, 0xc8, 0xa6, 0xc6, 0xc0, 0x2e, 0x7c, 0xc2, 0x82, 0xd1, 0x88, 0x50, 0x9f, 0x99, 0x43, 0xcb,
+	0x0b, 0x2c, 0xd9, 0xd8, 0x98, 0xf0, 0x5e, 0xc9, 0xd8, 0x0c, 0x99, 0x67, 0x82, 0xc7, 0x9f, 0xc3,
+	0xf8, 0x6c, 0xe0, 0xde, 0x31, 0xdf, 0x3b, 0x9e, 0x4d, 0xde, 0xab, 0x7a, 0x00, 0x4e, 0x7a, 0x2d,
+	0x28, 0xe8, 0x19, 0x54, 0xa4, 0x8b, 0xf8, 0x04, 0x60, 0x5a, 0xb1, 0x91, 0x9b, 0x31, 0x22, 0x40,
+	0xc0, 0xf8, 0x4f, 0xa6, 0xff, 0x2d, 0x03, 0xc0, 0x7f, 0xb5, 0xde, 0x58, 0xde, 0x00, 0xcf, 0x1f,
+	0x5e, 0xe1, 0xf0, 0xc9, 0x2e, 0x18, 0x3e, 0x08, 0xf2, 0x01, 0xc3, 0x54, 0xa5, 0x82, 0xf8, 0xfd,
+	0xc1, 0x39, 0xa0, 0x41, 0x71, 0x88, 0x19, 0xb3, 0x06, 0x58, 0x05, 0x2c, 0x3c, 0xf2, 0x34, 0xad,
+	0x76, 0x7d, 0xca, 0x7b, 0xe1, 0xed, 0x32, 0x56, 0xef, 0x42, 0x89, 0x29, 0xb8, 0xb2, 0xfc, 0xd3,
+	0xa4, 0xe5, 0xa1, 0x32, 0x23, 0xc2, 0xfd, 0x9f, 0x5f, 0xf0, 0xc7, 0x3c, 0xc0, 0x38, 0xb2, 0x13,
+	0xa5, 0x36, 0x37, 0x73, 0xea, 0x50, 0xa2, 0xaa, 0x46, 0x95, 0x75, 0xd1, 0x19, 0x7d, 0x06, 0x65,
+	0x8a, 0x7f, 0x17, 0x60, 0xe6, 0x63, 0x1a, 0xf5, 0xcb, 0x90, 0x10, 0xeb, 0xd0, 0x2b, 0xd3, 0x3a,
+	0xf4, 0xd8, 0xa0, 0x74, 0x87, 0x7e, 0x11, 0x4d, 0xc1, 0xc2, 0x02, 0xc1, 0xd4, 0x24, 0x4c, 0x0c,
+	0xfe, 0xe2, 0x87, 0x0c, 0x7e, 0xd5, 0x01, 0x4a, 0xe3, 0x0e, 0x70, 0x07, 0x8a, 0x36, 0xbd, 0x35,
+	0x69, 0xe0, 0x89, 0x15, 0xa2, 0x64, 0x14, 0x6c, 0x7a, 0x6b, 0x04, 0x1e, 0xba, 0x0b, 0x25, 0x8f,
+	0x98, 0x78, 0x68, 0x39, 0xae, 0xd8, 0x06, 0x4a, 0x46, 0xd1, 0x23, 0x6d, 0x7e, 0x44, 0x4d, 0xd8,
+	0xf4, 0x88, 0x49, 0x31, 0x23, 0xee, 0x0d, 0x36, 0x23, 0xb7, 0x55, 0x04, 0x6a, 0xc3, 0x23, 0x86,
+	0xe4, 0x44, 0x3d, 0xef, 0x53, 0x28, 0xf4, 0x2c, 0xcf, 0xa2, 0xb7, 0xda, 0xaa, 0xbc, 0x42, 0x9e,
+	0xf4, 0x9d, 0x99, 0xe3, 0x36, 0x6c, 0xa9, 0x99, 0x78, 0xbf, 0xce, 0xea, 0x3f, 0x8d, 0x06, 0x6c,
+	0x05, 0x8a, 0x17, 0xed, 0xce, 0xc1, 0x49, 0xe7, 0x68, 0xc1, 0x78, 0xfd, 0x47, 0x61, 0xdc, 0xba,
+	0x94, 0xec, 0x1e, 0x54, 0x86, 0x8e, 0xe7, 0x98, 0xca, 0xf9, 0x19, 0xe1, 0xc0, 0xc6, 0xf4, 0xb9,
+	0x3a, 0xde, 0x3f, 0x0d, 0x18, 0x46, 0xbf, 0xf9, 0x8b, 0x62, 0x0b, 0x4c, 0x39, 0x8a, 0xcc, 0x73,
+	0x28, 0xf4, 0x44, 0x7f, 0x14, 0x39, 0x54, 0xd9, 0xfd, 0x6c, 0xc6, 0xb4, 0x16, 0x18, 0x43, 0x61,
+	0xf9, 0x52, 0xd9, 0x0f, 0x5c, 0xd7, 0x7c, 0xe3, 0x30, 0x9f, 0xd0, 0x5b, 0x73, 0xbc, 0xb1, 0x54,
+	0x39, 0xfd, 0x58, 0x92, 0xaf, 0xa8, 0xcb, 0xbb, 0xae, 0xc3, 0x58, 0x80, 0x39, 0xc4, 0xbc, 0xb6,
+	0x58, 0x58, 0x02, 0xab, 0x82, 0x7a, 0x45, 0xdd, 0x7d, 0x8b, 0x61, 0xf4, 0x95, 0xea, 0x27, 0x72,
+	0x36, 0x6b, 0x93, 0xfd, 0x44, 0x96, 0xb7, 0xea, 0x2a, 0x2f, 0x63, 0x75, 0x5c, 0x9c, 0x66, 0x75,
+	0xb2, 0x29, 0xc4, 0xaa, 0xf9, 0x18, 0xb6, 0x3c, 0x22, 0x37, 0x5c, 0x6c, 0x47, 0x79, 0x10, 0x0e,
+	0xe9, 0x54, 0x37, 0x08, 0xb3, 0xc1, 0x40, 0x1e, 0x11, 0x0b, 0x30, 0xb6, 0x43, 0x12, 0x43, 0xdf,
+	0xc0, 0x6a, 0x7c, 0xad, 0xd6, 0xca, 0xd3, 0x2c, 0x1f, 0xef, 0x3a, 0x46, 0x25, 0xb6, 0x6c, 0xa3,
+	0x9f, 0x41, 0x39, 0xda, 0xb4, 0x35, 0x58, 0x20, 0x59, 0x0a, 0xf7, 0x6f, 0x7e, 0x27, 0xc5, 0xbd,
+	0xf0, 0x4a, 0xa6, 0x55, 0x1a, 0xb9, 0xb9, 0x92, 0x15, 0x89, 0x96, 0x43, 0xe3, 0x1b, 0x58, 0x4d,
+	0xcc, 0x97, 0xd5, 0x69, 0xc2, 0xe3, 0x0a, 0x36, 0x2a, 0xc3, 0xd8, 0xc4, 0xd9, 0x82, 0x15, 0x4c,
+	0x29, 0xa1, 0xda, 0x9a, 0x08, 0x9e, 0x3c, 0xa0, 0x16, 0xac, 0xfb, 0x6f, 0x28, 0xf1, 0x7d, 0xee,
+	0xcc, 0xc0, 0xf3, 0x1d, 0x57, 0xab, 0x2e, 0xac, 0xed, 0x6a, 0x24, 0x72, 0xc5, 0x25, 0xd0, 0x09,
+	0x6c, 0xf4, 0x5c, 0x6c, 0x79, 0xc1, 0xc8, 0x0c, 0x3b, 0x94, 0xad, 0xad, 0x4f, 0x8b, 0x6a, 0x4b,
+	0xc2, 0x0c, 0x89, 0x32, 0x6a, 0xbd, 0xc4, 0x19, 0xdb, 0xfa, 0x26, 0x6c, 0x1c, 0x61, 0x19, 0x29,
+	0xca, 0x14, 0x59, 0xbf, 0x00, 0x14, 0x27, 0xb2, 0x11, 0xf1, 0x18, 0x46, 0xaf, 0xa0, 0x28, 0x1b,
+	0x29, 0xaf, 0xa6, 0xdc, 0x52, 0xd5, 0x14, 0x0a, 0xe8, 0x87, 0xb0, 0xae, 0x34, 0x86, 0x97, 0xcc,
+	0x1f, 0x3b, 0xbc, 0x99, 0x04, 0x94, 0x11, 0x1a, 0x96, 0x9e, 0x3c, 0xe9, 0xdf, 0x43, 0x6d, 0xac,
+	0x47, 0xd9, 0xd5, 0x84, 0x15, 0x19, 0x9e, 0xcc, 0x82, 0xd8, 0x4a, 0xd8, 0x4c, 0xdd, 0xcf, 0x60,
+	0xeb, 0x08, 0xfb, 0x31, 0xeb, 0x97, 0x30, 0x54, 0xff, 0x0e, 0x3e, 0x49, 0x09, 0x29, 0xab, 0x5e,
+	0x46, 0xcd, 0x63, 0xd9, 0xd6, 0xa3, 0xf0, 0xfa, 0x8e, 0x78, 0xe3, 0x07, 0xd8, 0x70, 0x02, 0x1b,
+	0x31, 0x01, 0x75, 0xff, 0xf3, 0xd4, 0xfd, 0x33, 0x9a, 0x54, 0xea, 0x6e, 0x02, 0xd5, 0x2e, 0xf6,
+	0xc5, 0x36, 0xb2, 0x4c, 0x98, 0x96, 0xdd, 0x69, 0x62, 0xd3, 0x3c, 0x9f, 0x9c, 0xe6, 0x47, 0xb0,
+	0x1e, 0x5d, 0xf8, 0x51, 0x96, 0x9f, 0xca, 0x40, 0x10, 0x1b, 0xab, 0x4e, 0xba, 0x6c, 0x9e, 0x91,
+	0x7e, 0x9f, 0x61, 0xf9, 0xe1, 0xb5, 0x62, 0xa8, 0x93, 0x3e, 0x84, 0x4f, 0xd3, 0xda, 0x94, 0x75,
+	0xbb, 0x50, 0x54, 0x1d, 0x7c, 0x7a, 0xbe, 0xc5, 0x3a, 0x6f, 0x08, 0xe4, 0xcb, 0xa7, 0x87, 0x7f,
+	0xf0, 0xcd, 0xc4, 0x55, 0xc0, 0x49, 0xe7, 0xf2, 0xba, 0xdf, 0x03, 0xea, 0xf2, 0x08, 0xaa, 0x55,
+	0x6a, 0x19, 0xcb, 0xff, 0x9b, 0xc5, 0x6c, 0x76, 0x18, 0xbe, 0x85, 0xcd, 0x84, 0x01, 0x1f, 0x15,
+	0x8a, 0x0b, 0xb8, 0x7b, 0x34, 0x56, 0xf6, 0xbf, 0x08, 0x47, 0x00, 0xf5, 0x69, 0x1a, 0x95, 0x95,
+	0x5f, 0xa7, 0x43, 0x32, 0x7f, 0xb4, 0x2d, 0x1f, 0x96, 0x77, 0x70, 0xa7, 0x25, 0x3e, 0x6b, 0x63,
+	0x3d, 0x7e, 0x99, 0x67, 0xc4, 0xd7, 0xcc, 0x6c, 0x6a, 0xcd, 0x8c, 0xad, 0x62, 0xb9, 0xf8, 0x2a,
+	0xa6, 0x1f, 0x83, 0x36, 0x79, 0x99, 0x7a, 0xe1, 0x57, 0x90, 0x17, 0x73, 0x2f, 0x33, 0x75, 0xd6,
+	0x8f, 0xf1, 0x02, 0xa5, 0xff, 0x04, 0x36, 0xae, 0xbc, 0x70, 0x64, 0x2c, 0xd5, 0x41, 0xb6, 0x00,
+	0xc5, 0x25, 0xe4, 0xad, 0xfa, 0x6f, 0x41, 0xdb, 0xb3, 0xed, 0xd4, 0x08, 0x59, 0xe6, 0xfd, 0x8f,
+	0x61, 0xed, 0x6d, 0xc0, 0x7c, 0xa7, 0xef, 0xf4, 0x2c, 0x7f, 0xec, 0x84, 0x24, 0x51, 0xff, 0x0e,
+	0xee, 0x4e, 0x51, 0xff, 0x51, 0x99, 0x77, 0x06, 0xda, 0x11, 0xf6, 0x95, 0xca, 0x0f, 0x49, 0xbc,
+	0x2d, 0x58, 0x71, 0x9d, 0xa1, 0x23, 0x93, 0x60, 0xcd, 0x90, 0x07, 0xbd, 0x0b, 0x77, 0xa7, 0xa8,
+	0x5b, 0x32, 0xeb, 0x52, 0x0f, 0x0b, 0xc1, 0xfa, 0x5f, 0x33, 0x50, 0x4d, 0xf2, 0xd0, 0x23, 0x58,
+	0xf3, 0x30, 0xb6, 0x99, 0xa9, 0xc6, 0xb3, 0x30, 0xaf, 0x64, 0xac, 0x0a, 0xa2, 0xc2, 0x46, 0x5f,
+	0x55, 0xd9, 0xd8, 0x57, 0x55, 0xe2, 0x1b, 0x21, 0xf7, 0x21, 0xdf, 0x08, 0x13, 0x21, 0xca, 0x4f,
+	0x0b, 0xd1, 0x0b, 0xe1, 0xcf, 0x0e, 0xfe, 0x41, 0x8c, 0x5c, 0xf9, 0xa5, 0xbc, 0x54, 0x42, 0xfd,
+	0x39, 0x03, 0x77, 0xa7, 0x48, 0x2a, 0xd7, 0xa5, 0x3e, 0xc6, 0x33, 0x13, 0x1f, 0xe3, 0xf7, 0xa0,
+	0xec, 0x78, 0x21, 0x3b, 0x2b, 0x9c, 0x51, 0x72, 0x3c, 0xc5, 0x3c, 0x84, 0x0d, 0x51, 0xb6, 0x92,
+	0xcd, 0x17, 0x7c, 0xea, 0x2f, 0xf1, 0xf8, 0x75, 0x2e, 0x24, 0x55, 0x74, 0xb9, 0xc8, 0x97, 0xaf,
+	0x20, 0xcf, 0x9b, 0x35, 0xff, 0xb0, 0x30, 0xae, 0x3a, 0x9d, 0xd8, 0x57, 0xc6, 0xf9, 0xc5, 0x85,
+	0xf8, 0xca, 0xa8, 0x40, 0x51, 0xfd, 0xfb, 0xa7, 0x96, 0xe5, 0x87, 0xf3, 0xc3, 0xc3, 0xd3, 0x93,
+	0x4e, 0xbb, 0x96, 0xfb, 0xf2, 0x15, 0x94, 0xc2, 0xae, 0x82, 0xca, 0xb0, 0xb2, 0xbf, 0x77, 0xd9,
+	0x3a, 0x96, 0xd2, 0x1d, 0x53, 0x1e, 0x32, 0x08, 0xa0, 0xd0, 0x3d, 0xe9, 0x1c, 0x9d, 0xf2, 0x7f,
+	0x3b, 0x01, 0x14, 0xf6, 0x4f, 0xba, 0xed, 0xd6, 0x65, 0x2d, 0xb7, 0xfb, 0xa7, 0x32, 0xac, 0x47,
+	0xf9, 0x8b, 0xe9, 0x8d, 0xd3, 0xc3, 0xc8, 0x86, 0x8d, 0x89, 0x5a, 0x40, 0x4f, 0x52, 0x39, 0x3f,
+	0xa3, 0x16, 0xeb, 0x4f, 0x17, 0xe2, 0x94, 0xdf, 0x6d, 0xb1, 0x28, 0x24, 0xf3, 0x39, 0x7d, 0xcb,
+	0xac, 0xfa, 0xa9, 0x3f, 0x5d, 0x88, 0x53, 0xb7, 0x9c, 0x03, 0x8c, 0xb7, 0x47, 0xf4, 0x60, 0x42,
+	0x2c, 0xb9, 0x6c, 0xd6, 0x1b, 0xb3, 0x01, 0x4a, 0xe1, 0x09, 0x94, 0x14, 0x95, 0xa1, 0xfb, 0x53,
+	0xd1, 0x91, 0xb2, 0xcf, 0x67, 0xb1, 0x95, 0xaa, 0x5f, 0xc1, 0x5a, 0x62, 0x5d, 0x43, 0xfa, 0x84,
+	0xc0, 0xc4, 0x02, 0x58, 0x7f, 0x34, 0x17, 0xa3, 0x34, 0x9f, 0x42, 0x39, 0x5a, 0xc2, 0xd0, 0xa4,
+	0x19, 0x49, 0x8d, 0x0f, 0x66, 0xf2, 0x95, 0xb6, 0x43, 0x28, 0xaa, 0xb5, 0x08, 0xa5, 0x87, 0x59,
+	0x62, 0x3d, 0xab, 0xdf, 0x9f, 0xc1, 0x55, 0x7a, 0x7e, 0x03, 0xd5, 0xe4, 0x1e, 0x83, 0xa6, 0x3c,
+	0x66, 0x62, 0x67, 0xaa, 0x3f, 0x9e, 0x0f, 0x52, 0xca, 0x0d, 0xa8, 0xc4, 0x96, 0x06, 0xd4, 0x98,
+	0x30, 0x25, 0xb5, 0xd0, 0xd4, 0x1f, 0xce, 0x41, 0x28, 0x9d, 0x03, 0xf1, 0xe9, 0x91, 0x9a, 0xf4,
+	0xe8, 0xe9, 0x14, 0x7f, 0x4d, 0xdb, 0x2e, 0xea, 0xdb, 0x8b, 0x81, 0xea, 0x22, 0x0b, 0x6a, 0xe9,
+	0x71, 0x8b, 0xbe, 0x48, 0x75, 0xf0, 0xe9, 0xb3, 0xbf, 0xfe, 0x64, 0x11, 0x6c, 0x5c, 0x08, 0xe3,
+	0xa9, 0x9a, 0x2e, 0x84, 0x89, 0x09, 0x5d, 0x6f, 0xcc, 0x06, 0x24, 0xea, 0x37, 0xd9, 0x54, 0xa7,
+	0xd4, 0xef, 0xd4, 0x7e, 0x5d, 0x7f, 0xba, 0x10, 0x27, 0x6f, 0xd9, 0x7f, 0xf4, 0xfd, 0xc3, 0x01,
+	0x69, 0xb2, 0x77, 0x8e, 0xd5, 0x24, 0x74, 0xb0, 0xe3, 0x78, 0x7d, 0x6a, 0xed, 0x84, 0xb2, 0x3b,
+	0x03, 0xb2, 0x43, 0x47, 0xbd, 0xeb, 0x82, 0xe8, 0xb0, 0xcf, 0xfe, 0x33, 0x00, 0x34, 0x5b, 0xf3,
+	0x3c, 0x01, 0x1c, 0x00, 0x00,
 }
@@ -18,6 +18,7 @@ import (
 	"go.skia.org/infra/autoroll/go/roller_cleanup"
 	"go.skia.org/infra/autoroll/go/status"
 	"go.skia.org/infra/autoroll/go/strategy"
+	"go.skia.org/infra/autoroll/go/time_window"
 	"go.skia.org/infra/autoroll/go/unthrottle"
 	"go.skia.org/infra/go/alogin"
 	"go.skia.org/infra/go/autoroll"
@@ -421,6 +422,8 @@ func (s *AutoRollServer) SetStrategy(ctx context.Context, req *SetStrategyReques
 		strat = strategy.ROLL_STRATEGY_N_BATCH
 	case Strategy_SINGLE:
 		strat = strategy.ROLL_STRATEGY_SINGLE
+	case Strategy_BISECT:
+		strat = strategy.ROLL_STRATEGY_BISECT
 	default:
 		return nil, twirp.InvalidArgumentError("strategy", "invalid strategy")
 	}
@@ -507,6 +510,25 @@ func (s *AutoRollServer) Unthrottle(ctx context.Context, req *UnthrottleRequest)
 	return &UnthrottleResponse{}, nil
 }
 
+// GetNextRollWindow implements AutoRollRPCs.
+func (s *AutoRollServer) GetNextRollWindow(ctx context.Context, req *GetNextRollWindowRequest) (*GetNextRollWindowResponse, error) {
+	roller, err := s.GetRoller(req.RollerId)
+	if err != nil {
+		return nil, err
+	}
+	tw, err := time_window.Parse(roller.Cfg.TimeWindow)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	now := timeNowFunc()
+	next := tw.Next(now)
+	return &GetNextRollWindowResponse{
+		TimeWindow:      roller.Cfg.TimeWindow,
+		InWindow:        !next.After(now),
+		NextWindowStart: timestamppb.New(next),
+	}, nil
+}
+
 // AddCleanupRequest implements AutoRollRPCs.
 func (s *AutoRollServer) AddCleanupRequest(ctx context.Context, req *AddCleanupRequestRequest) (*AddCleanupRequestResponse, error) {
 	// Verify that the user has edit access.
@@ -731,6 +753,8 @@ func convertStrategy(s string) (Strategy, error) {
 		return Strategy_N_BATCH, nil
 	case strategy.ROLL_STRATEGY_SINGLE:
 		return Strategy_SINGLE, nil
+	case strategy.ROLL_STRATEGY_BISECT:
+		return Strategy_BISECT, nil
 	default:
 		return -1, twirp.InternalError(fmt.Sprintf("invalid strategy %q", s))
 	}
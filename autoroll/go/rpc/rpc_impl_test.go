@@ -569,6 +569,52 @@ func TestUnthrottle(t *testing.T) {
 	assertdeep.Equal(t, &UnthrottleResponse{}, res)
 }
 
+func TestGetNextRollWindow(t *testing.T) {
+
+	// Setup, mocks.
+	ctx, rollers, srv := setup(t)
+	roller := rollers["roller1"]
+	req := &GetNextRollWindowRequest{
+		RollerId: "this roller doesn't exist",
+	}
+
+	// Check error for unknown roller.
+	ctx = alogin.FakeStatus(ctx, &notLoggedInStatus)
+	res, err := srv.GetNextRollWindow(ctx, req)
+	require.Nil(t, res)
+	require.EqualError(t, err, "twirp error not_found: Unknown roller")
+
+	// No time window; the roller may always upload rolls.
+	req.RollerId = roller.Cfg.RollerName
+	roller.Cfg.TimeWindow = ""
+	res, err = srv.GetNextRollWindow(ctx, req)
+	require.NoError(t, err)
+	assertdeep.Equal(t, &GetNextRollWindowResponse{
+		InWindow:        true,
+		NextWindowStart: timestamppb.New(currentTime),
+	}, res)
+
+	// currentTime is on a Wednesday afternoon in New York.
+	roller.Cfg.TimeWindow = "M-F 06:00-18:00 America/New_York"
+	res, err = srv.GetNextRollWindow(ctx, req)
+	require.NoError(t, err)
+	assertdeep.Equal(t, &GetNextRollWindowResponse{
+		TimeWindow:      roller.Cfg.TimeWindow,
+		InWindow:        true,
+		NextWindowStart: timestamppb.New(currentTime),
+	}, res)
+
+	// Outside of the window.
+	roller.Cfg.TimeWindow = "Sa 08:00-09:00"
+	res, err = srv.GetNextRollWindow(ctx, req)
+	require.NoError(t, err)
+	assertdeep.Equal(t, &GetNextRollWindowResponse{
+		TimeWindow:      roller.Cfg.TimeWindow,
+		InWindow:        false,
+		NextWindowStart: timestamppb.New(time.Date(2020, 8, 29, 8, 0, 0, 0, time.UTC)),
+	}, res)
+}
+
 func TestAddCleanupRequest(t *testing.T) {
 	// Setup, mocks.
 	ctx, rollers, srv := setup(t)
@@ -35,6 +35,8 @@ const (
 	S_NORMAL_FAILURE_THROTTLED     = "failure throttled"
 	S_NORMAL_SAFETY_THROTTLED      = "safety throttled"
 	S_NORMAL_WAIT_FOR_WINDOW       = "waiting for roll window"
+	S_NORMAL_WAIT_FOR_PREREQS      = "waiting for prerequisite rollers"
+	S_NORMAL_WAIT_FOR_NO_ROLL      = "paused by no-roll marker"
 	S_TOO_MANY_CLS                 = "too many CLs to same revision"
 	S_DRY_RUN_IDLE                 = "dry run idle"
 	S_DRY_RUN_ACTIVE               = "dry run active"
@@ -191,6 +193,16 @@ type AutoRollerImpl interface {
 	// time window in which it is allowed to roll.
 	InRollWindow(time.Time) bool
 
+	// PrerequisitesSatisfied returns true iff none of the rollers which this
+	// roller depends on have an in-flight roll which should land before we
+	// upload a roll of our own, and the next roll revision includes their
+	// last rolled revisions.
+	PrerequisitesSatisfied(context.Context) bool
+
+	// NoRollMarkerPresent returns true iff the parent repo contains a marker
+	// file indicating that rolls should not be uploaded.
+	NoRollMarkerPresent(context.Context) bool
+
 	// Return true if we have already rolled past the given revision.
 	RolledPast(context.Context, *revision.Revision) (bool, error)
 
@@ -519,6 +531,8 @@ func New(ctx context.Context, impl AutoRollerImpl, n *notifier.AutoRollNotifier,
 	b.T(S_NORMAL_IDLE, S_NORMAL_SUCCESS_THROTTLED, F_NOOP)
 	b.T(S_NORMAL_IDLE, S_NORMAL_ACTIVE, F_UPLOAD_ROLL)
 	b.T(S_NORMAL_IDLE, S_NORMAL_WAIT_FOR_WINDOW, F_NOOP)
+	b.T(S_NORMAL_IDLE, S_NORMAL_WAIT_FOR_PREREQS, F_NOOP)
+	b.T(S_NORMAL_IDLE, S_NORMAL_WAIT_FOR_NO_ROLL, F_NOOP)
 	b.T(S_NORMAL_IDLE, S_TOO_MANY_CLS, F_NOTIFY_TOO_MANY_CLS)
 	b.T(S_NORMAL_ACTIVE, S_NORMAL_ACTIVE, F_UPDATE_ROLL)
 	b.T(S_NORMAL_ACTIVE, S_DRY_RUN_ACTIVE, F_SWITCH_TO_DRY_RUN)
@@ -552,6 +566,10 @@ func New(ctx context.Context, impl AutoRollerImpl, n *notifier.AutoRollNotifier,
 	b.T(S_NORMAL_SAFETY_THROTTLED, S_NORMAL_SAFETY_THROTTLED, F_UPDATE_REPOS)
 	b.T(S_NORMAL_WAIT_FOR_WINDOW, S_NORMAL_WAIT_FOR_WINDOW, F_UPDATE_REPOS)
 	b.T(S_NORMAL_WAIT_FOR_WINDOW, S_NORMAL_IDLE, F_NOOP)
+	b.T(S_NORMAL_WAIT_FOR_PREREQS, S_NORMAL_WAIT_FOR_PREREQS, F_UPDATE_REPOS)
+	b.T(S_NORMAL_WAIT_FOR_PREREQS, S_NORMAL_IDLE, F_NOOP)
+	b.T(S_NORMAL_WAIT_FOR_NO_ROLL, S_NORMAL_WAIT_FOR_NO_ROLL, F_UPDATE_REPOS)
+	b.T(S_NORMAL_WAIT_FOR_NO_ROLL, S_NORMAL_IDLE, F_NOOP)
 
 	// Dry run states.
 	b.T(S_DRY_RUN_IDLE, S_STOPPED, F_NOOP)
@@ -679,6 +697,10 @@ func (s *AutoRollStateMachine) GetNext(ctx context.Context) (string, error) {
 			return S_NORMAL_SAFETY_THROTTLED, nil
 		} else if s.a.SuccessThrottle().IsThrottled() {
 			return S_NORMAL_SUCCESS_THROTTLED, nil
+		} else if !s.a.PrerequisitesSatisfied(ctx) {
+			return S_NORMAL_WAIT_FOR_PREREQS, nil
+		} else if s.a.NoRollMarkerPresent(ctx) {
+			return S_NORMAL_WAIT_FOR_NO_ROLL, nil
 		}
 		lastNRevs := s.a.GetLastNRollRevs(maxRollCLsToSameRevision)
 		if len(lastNRevs) == maxRollCLsToSameRevision {
@@ -800,6 +822,16 @@ func (s *AutoRollStateMachine) GetNext(ctx context.Context) (string, error) {
 			return S_NORMAL_IDLE, nil
 		}
 		return S_NORMAL_WAIT_FOR_WINDOW, nil
+	case S_NORMAL_WAIT_FOR_PREREQS:
+		if s.a.PrerequisitesSatisfied(ctx) {
+			return S_NORMAL_IDLE, nil
+		}
+		return S_NORMAL_WAIT_FOR_PREREQS, nil
+	case S_NORMAL_WAIT_FOR_NO_ROLL:
+		if !s.a.NoRollMarkerPresent(ctx) {
+			return S_NORMAL_IDLE, nil
+		}
+		return S_NORMAL_WAIT_FOR_NO_ROLL, nil
 	case S_DRY_RUN_IDLE:
 		if desiredMode == modes.ModeRunning {
 			if s.a.SuccessThrottle().IsThrottled() {
@@ -241,7 +241,9 @@ type TestAutoRollerImpl struct {
 	dryRunSuccessThrottle *Throttler
 	updateError           error
 
-	rollWindowOpen bool
+	rollWindowOpen         bool
+	prerequisitesSatisfied bool
+	noRollMarkerPresent    bool
 
 	// This is in chronological order and needs to be reversed before returning
 	// it from GetLastNRollRevs.
@@ -265,14 +267,15 @@ func NewTestAutoRollerImpl(t *testing.T, ctx context.Context, gcsClient gcs.GCSC
 	dryRunSuccessThrottle, err := NewThrottler(ctx, gcsClient, "", time.Duration(0), 0)
 	require.NoError(t, err)
 	return &TestAutoRollerImpl{
-		t:                     t,
-		failureThrottle:       failureThrottle,
-		getModeResult:         modes.ModeRunning,
-		rolledPast:            map[string]bool{},
-		safetyThrottle:        safetyThrottle,
-		successThrottle:       successThrottle,
-		dryRunSuccessThrottle: dryRunSuccessThrottle,
-		rollWindowOpen:        true,
+		t:                      t,
+		failureThrottle:        failureThrottle,
+		getModeResult:          modes.ModeRunning,
+		rolledPast:             map[string]bool{},
+		safetyThrottle:         safetyThrottle,
+		successThrottle:        successThrottle,
+		dryRunSuccessThrottle:  dryRunSuccessThrottle,
+		rollWindowOpen:         true,
+		prerequisitesSatisfied: true,
 	}
 }
 
@@ -418,6 +421,16 @@ func (r *TestAutoRollerImpl) InRollWindow(time.Time) bool {
 	return r.rollWindowOpen
 }
 
+// Return true if none of the prerequisite rollers have pending rolls.
+func (r *TestAutoRollerImpl) PrerequisitesSatisfied(context.Context) bool {
+	return r.prerequisitesSatisfied
+}
+
+// Return true if the parent repo contains a no-roll marker.
+func (r *TestAutoRollerImpl) NoRollMarkerPresent(context.Context) bool {
+	return r.noRollMarkerPresent
+}
+
 func (r *TestAutoRollerImpl) GetConfig() *config.Config {
 	return &config.Config{
 		MaxRollCqAttempts:        int32(r.maxRollCQAttempts),
@@ -594,6 +607,42 @@ func TestNormal(t *testing.T) {
 	checkNextState(t, sm, S_NORMAL_IDLE)
 }
 
+func TestNormal_PrerequisiteRollPending_WaitsUntilLanded(t *testing.T) {
+	_, sm, r, _, cleanup := setup(t)
+	defer cleanup()
+
+	// We don't wait for prerequisites when there's nothing to roll.
+	r.prerequisitesSatisfied = false
+	checkNextState(t, sm, S_NORMAL_IDLE)
+
+	r.SetNextRollRev("HEAD+1")
+	checkNextState(t, sm, S_NORMAL_WAIT_FOR_PREREQS)
+	checkNextState(t, sm, S_NORMAL_WAIT_FOR_PREREQS)
+	require.Nil(t, r.GetActiveRoll())
+
+	r.prerequisitesSatisfied = true
+	checkNextState(t, sm, S_NORMAL_IDLE)
+	checkNextState(t, sm, S_NORMAL_ACTIVE)
+}
+
+func TestNormal_NoRollMarkerPresent_WaitsUntilRemoved(t *testing.T) {
+	_, sm, r, _, cleanup := setup(t)
+	defer cleanup()
+
+	// The marker doesn't matter when there's nothing to roll.
+	r.noRollMarkerPresent = true
+	checkNextState(t, sm, S_NORMAL_IDLE)
+
+	r.SetNextRollRev("HEAD+1")
+	checkNextState(t, sm, S_NORMAL_WAIT_FOR_NO_ROLL)
+	checkNextState(t, sm, S_NORMAL_WAIT_FOR_NO_ROLL)
+	require.Nil(t, r.GetActiveRoll())
+
+	r.noRollMarkerPresent = false
+	checkNextState(t, sm, S_NORMAL_IDLE)
+	checkNextState(t, sm, S_NORMAL_ACTIVE)
+}
+
 func TestDryRun(t *testing.T) {
 	ctx, sm, r, gcsClient, cleanup := setup(t)
 	defer cleanup()
@@ -951,7 +1000,7 @@ func TestNilCurrentRoll(t *testing.T) {
 	// Verify that every state in the state machine handles a nil current
 	// roll without crashing.
 	states := sm.s.ListStates()
-	require.Equal(t, 20, len(states))
+	require.Equal(t, 22, len(states))
 	stateFile := "test-roller/state_machine"
 	n, err := notifier.New(ctx, "fake", "fake", "fake", nil, emailclient.New(), nil, nil)
 	require.NoError(t, err)
@@ -44,6 +44,7 @@ type AutoRollStatus struct {
 	ParentName         string                    `json:"parentName"`
 	Recent             []*autoroll.AutoRollIssue `json:"recent"`
 	Status             string                    `json:"status"`
+	StatusChangedAt    time.Time                 `json:"statusChangedAt"`
 	ThrottledUntil     int64                     `json:"throttledUntil"`
 	ValidModes         []string                  `json:"validModes"`
 	ValidStrategies    []string                  `json:"validStrategies"`
@@ -86,6 +87,7 @@ func (s *AutoRollStatus) Copy() *AutoRollStatus {
 		ParentName:         s.ParentName,
 		Recent:             recent,
 		Status:             s.Status,
+		StatusChangedAt:    s.StatusChangedAt,
 		ThrottledUntil:     s.ThrottledUntil,
 		ValidModes:         util.CopyStringSlice(s.ValidModes),
 		ValidStrategies:    util.CopyStringSlice(s.ValidStrategies),
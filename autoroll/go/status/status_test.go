@@ -49,6 +49,7 @@ func TestCopyStatus(t *testing.T) {
 		ParentName:      "parent-repo",
 		Recent:          recent,
 		Status:          "some-status",
+		StatusChangedAt: time.Now(),
 		ThrottledUntil:  time.Now().Unix(),
 		ValidModes:      modes.ValidModes,
 		ValidStrategies: []string{strategy.ROLL_STRATEGY_SINGLE, strategy.ROLL_STRATEGY_BATCH},
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/autoroll"
 )
 
 const (
@@ -11,11 +12,20 @@ const (
 	// TODO(rmistry): Rename to "batch of " + N_REVISIONS ?
 	ROLL_STRATEGY_N_BATCH = "n_batch"
 	ROLL_STRATEGY_SINGLE  = "single"
+	ROLL_STRATEGY_BISECT  = "bisect"
 
-	// The number of Revisions to use in ROLL_STRATEGY_N_BATCH.
+	// The number of Revisions to use in ROLL_STRATEGY_N_BATCH and
+	// ROLL_STRATEGY_BISECT.
 	N_REVISIONS = 20
 )
 
+// RecentRolls provides the roll history used by NextRollStrategies which take
+// the results of previous rolls into account.
+type RecentRolls interface {
+	// GetRecentRolls returns the most recent rolls, most recent first.
+	GetRecentRolls() []*autoroll.AutoRollIssue
+}
+
 // NextRollStrategy is an interface for modules which determine what the next
 // roll Revision should be.
 type NextRollStrategy interface {
@@ -26,8 +36,9 @@ type NextRollStrategy interface {
 	GetNextRollRev([]*revision.Revision) *revision.Revision
 }
 
-// Return the NextRollStrategy indicated by the given string.
-func GetNextRollStrategy(strategy string) (NextRollStrategy, error) {
+// Return the NextRollStrategy indicated by the given string. The RecentRolls
+// are only used by ROLL_STRATEGY_BISECT.
+func GetNextRollStrategy(strategy string, recent RecentRolls) (NextRollStrategy, error) {
 	switch strategy {
 	case ROLL_STRATEGY_BATCH:
 		return StrategyBatch(), nil
@@ -35,6 +46,8 @@ func GetNextRollStrategy(strategy string) (NextRollStrategy, error) {
 		return StrategyNBatch(), nil
 	case ROLL_STRATEGY_SINGLE:
 		return StrategySingle(), nil
+	case ROLL_STRATEGY_BISECT:
+		return StrategyBisect(recent), nil
 	default:
 		return nil, fmt.Errorf("Unknown roll strategy %q", strategy)
 	}
@@ -103,3 +116,77 @@ func (s *singleStrategy) GetNextRollRev(notRolled []*revision.Revision) *revisio
 func StrategySingle() NextRollStrategy {
 	return &singleStrategy{}
 }
+
+// bisectStrategy is a NextRollStrategy which rolls up to N Revisions at a time,
+// like nBatchStrategy, but when a roll fails it splits the failed batch in half
+// and rolls the older half, then the newer half, and so on until the culprit is
+// rolled by itself, after which it goes back to rolling N Revisions at a time.
+// This reduces the number of rolls through the CQ for child
+// repos which change frequently while still pinpointing the Revision which
+// broke the roll.
+type bisectStrategy struct {
+	recent RecentRolls
+}
+
+// See documentation for NextRollStrategy interface.
+func (s *bisectStrategy) GetNextRollRev(notRolled []*revision.Revision) *revision.Revision {
+	n := N_REVISIONS
+	if s.recent != nil {
+		// Find the most recent finished roll which failed and whose
+		// Revisions have not all been rolled since. The culprit is one
+		// of its Revisions which are still in notRolled.
+		first := true
+		for _, roll := range s.recent.GetRecentRolls() {
+			if !roll.Closed || roll.IsDryRun {
+				continue
+			}
+			if !roll.Failed() {
+				first = false
+				continue
+			}
+			idx := -1
+			for i, rev := range notRolled {
+				if rev.Id == roll.RollingTo {
+					idx = i
+					break
+				}
+			}
+			if idx < 0 {
+				// The failed Revisions have all been rolled.
+				break
+			}
+			remaining := len(notRolled) - idx
+			if first {
+				if remaining == 1 {
+					// The culprit failed on its own. Roll
+					// a full batch again in case a fix or
+					// revert has landed in the meantime.
+					break
+				}
+				// The previous roll failed; roll the older half
+				// of its Revisions.
+				remaining = (remaining + 1) / 2
+			}
+			// Otherwise, the older half of the failed Revisions
+			// landed in the meantime; roll the rest.
+			if remaining < n {
+				n = remaining
+			}
+			break
+		}
+	}
+	idx := 0
+	if len(notRolled) > n {
+		idx = len(notRolled) - n
+	}
+	return StrategyBatch().GetNextRollRev(notRolled[idx:])
+}
+
+// StrategyBisect returns a NextRollStrategy which rolls up to N Revisions at a
+// time and, when a roll fails, bisects the failed Revisions to find the
+// culprit.
+func StrategyBisect(recent RecentRolls) NextRollStrategy {
+	return &bisectStrategy{
+		recent: recent,
+	}
+}
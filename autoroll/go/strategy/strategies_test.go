@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.skia.org/infra/autoroll/go/revision"
+	"go.skia.org/infra/go/autoroll"
 )
 
 func TestStrategyBatch(t *testing.T) {
@@ -115,3 +116,82 @@ func TestStrategySingle(t *testing.T) {
 	}
 	require.Nil(t, s.GetNextRollRev(testRevs))
 }
+
+type fakeRecentRolls []*autoroll.AutoRollIssue
+
+func (r *fakeRecentRolls) GetRecentRolls() []*autoroll.AutoRollIssue {
+	return *r
+}
+
+// add inserts a finished roll to the given Revision as the most recent roll.
+func (r *fakeRecentRolls) add(rollingTo, result string) {
+	*r = append([]*autoroll.AutoRollIssue{{
+		Closed:    true,
+		Result:    result,
+		RollingTo: rollingTo,
+	}}, *r...)
+}
+
+func TestStrategyBisect(t *testing.T) {
+
+	recent := &fakeRecentRolls{}
+	s := StrategyBisect(recent)
+
+	// No revisions to roll.
+	require.Nil(t, s.GetNextRollRev(nil))
+	require.Nil(t, s.GetNextRollRev([]*revision.Revision{}))
+
+	// Revisions are passed in reverse chronological order; testRevs[i] has
+	// ID i+1, counting from the oldest.
+	testRevs := make([]*revision.Revision, 0, N_REVISIONS+2)
+	for i := 0; i < N_REVISIONS+2; i++ {
+		testRevs = append(testRevs, &revision.Revision{
+			Id: fmt.Sprintf("%d", N_REVISIONS+2-i),
+		})
+	}
+	// notRolledAfter returns the Revisions newer than the given one.
+	notRolledAfter := func(id int) []*revision.Revision {
+		return testRevs[:len(testRevs)-id]
+	}
+
+	// With no history, we roll N Revisions, like N_BATCH.
+	require.Equal(t, "20", s.GetNextRollRev(testRevs).Id)
+
+	// The roll failed; roll the older half.
+	recent.add("20", autoroll.ROLL_RESULT_FAILURE)
+	require.Equal(t, "10", s.GetNextRollRev(testRevs).Id)
+
+	// In-progress rolls and dry runs are ignored.
+	*recent = append([]*autoroll.AutoRollIssue{{RollingTo: "10"}, {Closed: true, IsDryRun: true, Result: autoroll.ROLL_RESULT_DRY_RUN_SUCCESS, RollingTo: "10"}}, *recent...)
+	require.Equal(t, "10", s.GetNextRollRev(testRevs).Id)
+
+	// The older half landed; roll the rest of the failed Revisions.
+	recent.add("10", autoroll.ROLL_RESULT_SUCCESS)
+	require.Equal(t, "20", s.GetNextRollRev(notRolledAfter(10)).Id)
+
+	// That failed too; keep bisecting.
+	recent.add("20", autoroll.ROLL_RESULT_FAILURE)
+	require.Equal(t, "15", s.GetNextRollRev(notRolledAfter(10)).Id)
+	recent.add("15", autoroll.ROLL_RESULT_FAILURE)
+	require.Equal(t, "13", s.GetNextRollRev(notRolledAfter(10)).Id)
+	recent.add("13", autoroll.ROLL_RESULT_SUCCESS)
+	require.Equal(t, "15", s.GetNextRollRev(notRolledAfter(13)).Id)
+	recent.add("15", autoroll.ROLL_RESULT_FAILURE)
+	require.Equal(t, "14", s.GetNextRollRev(notRolledAfter(13)).Id)
+
+	// Revision 14 is the culprit. Once it fails on its own, go back to
+	// rolling N Revisions in case a fix has landed.
+	recent.add("14", autoroll.ROLL_RESULT_FAILURE)
+	require.Equal(t, "22", s.GetNextRollRev(notRolledAfter(13)).Id)
+
+	// Once the failed Revisions have been rolled, they no longer matter.
+	*recent = fakeRecentRolls{}
+	recent.add("5", autoroll.ROLL_RESULT_FAILURE)
+	recent.add("10", autoroll.ROLL_RESULT_SUCCESS)
+	require.Equal(t, "22", s.GetNextRollRev(notRolledAfter(10)).Id)
+
+	// Invalid Revisions are skipped, as with N_BATCH.
+	recent.add("20", autoroll.ROLL_RESULT_FAILURE)
+	testRevs[len(testRevs)-10].InvalidReason = "flu"
+	require.Equal(t, "9", s.GetNextRollRev(testRevs).Id)
+}
@@ -0,0 +1,161 @@
+// Package telemetry tracks the latency of calls made by an AutoRoller to the
+// external services it depends on, so that slow or failing rolls can be
+// attributed to a particular dependency, eg. Gerrit, the child repo, etc.
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+)
+
+// Dependency identifies an external service used by the roller.
+type Dependency string
+
+const (
+	// DependencyChildRepo covers syncing and querying the child repo.
+	DependencyChildRepo Dependency = "child_repo"
+	// DependencyParentRepo covers syncing the parent repo and uploading
+	// rolls to it.
+	DependencyParentRepo Dependency = "parent_repo"
+	// DependencyRevisionFilter covers external services used to filter
+	// revisions, eg. Buildbucket.
+	DependencyRevisionFilter Dependency = "revision_filter"
+	// DependencyCodeReview covers retrieving the state of roll CLs, including
+	// their CQ status, from Gerrit or GitHub.
+	DependencyCodeReview Dependency = "code_review"
+	// DependencyDB covers reads and writes to the roller's databases.
+	DependencyDB Dependency = "db"
+
+	// Metric names.
+	metricLatency = "autoroll_dependency_latency_ms"
+	metricCalls   = "autoroll_dependency_calls"
+)
+
+type contextKeyType string
+
+// contextKey is used to store a Tracker in a context.Context.
+const contextKey contextKeyType = "autorollTelemetryTracker"
+
+// DependencyStats summarizes the calls made to a single Dependency.
+type DependencyStats struct {
+	Calls         int64     `json:"calls"`
+	Errors        int64     `json:"errors"`
+	LastCall      time.Time `json:"lastCall"`
+	LastError     string    `json:"lastError,omitempty"`
+	LastLatencyMs int64     `json:"lastLatencyMs"`
+	MaxLatencyMs  int64     `json:"maxLatencyMs"`
+	MeanLatencyMs int64     `json:"meanLatencyMs"`
+
+	totalLatency time.Duration
+}
+
+// Health is the JSON-encodable summary of all the Dependencies used by a
+// roller.
+type Health struct {
+	Roller       string                         `json:"roller"`
+	Dependencies map[Dependency]DependencyStats `json:"dependencies"`
+}
+
+// Tracker records the latency of calls to Dependencies for a single roller.
+type Tracker struct {
+	roller string
+	mtx    sync.Mutex
+	stats  map[Dependency]*DependencyStats
+}
+
+// NewTracker returns a Tracker instance for the given roller.
+func NewTracker(roller string) *Tracker {
+	return &Tracker{
+		roller: roller,
+		stats:  map[Dependency]*DependencyStats{},
+	}
+}
+
+// Track runs the given function, attributing the time it takes and whether it
+// fails to the given Dependency. Returns the error returned by the function.
+func (t *Tracker) Track(ctx context.Context, dep Dependency, fn func(context.Context) error) error {
+	start := now.Now(ctx)
+	err := fn(ctx)
+	latency := now.Now(ctx).Sub(start)
+
+	success := "true"
+	if err != nil {
+		success = "false"
+	}
+	tags := map[string]string{
+		"roller":     t.roller,
+		"dependency": string(dep),
+		"success":    success,
+	}
+	metrics2.GetFloat64SummaryMetric(metricLatency, tags).Observe(float64(latency.Milliseconds()))
+	metrics2.GetCounter(metricCalls, tags).Inc(1)
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	stats, ok := t.stats[dep]
+	if !ok {
+		stats = &DependencyStats{}
+		t.stats[dep] = stats
+	}
+	stats.Calls++
+	stats.LastCall = start
+	stats.LastLatencyMs = latency.Milliseconds()
+	if stats.LastLatencyMs > stats.MaxLatencyMs {
+		stats.MaxLatencyMs = stats.LastLatencyMs
+	}
+	stats.totalLatency += latency
+	stats.MeanLatencyMs = (stats.totalLatency / time.Duration(stats.Calls)).Milliseconds()
+	if err != nil {
+		stats.Errors++
+		stats.LastError = err.Error()
+	}
+	return err
+}
+
+// Health returns a snapshot of the stats for all Dependencies which have been
+// used so far.
+func (t *Tracker) Health() *Health {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	rv := &Health{
+		Roller:       t.roller,
+		Dependencies: make(map[Dependency]DependencyStats, len(t.stats)),
+	}
+	for dep, stats := range t.stats {
+		rv.Dependencies[dep] = *stats
+	}
+	return rv
+}
+
+// HealthHandler writes the JSON-encoded Health of the roller.
+func (t *Tracker) HealthHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(t.Health()); err != nil {
+		httputils.ReportError(w, err, "Failed to encode health.", http.StatusInternalServerError)
+		return
+	}
+}
+
+// WithTracker returns a context.Context which carries the given Tracker, to be
+// retrieved by Track.
+func WithTracker(ctx context.Context, t *Tracker) context.Context {
+	return context.WithValue(ctx, contextKey, t)
+}
+
+// Track runs the given function using the Tracker stored in the context via
+// WithTracker. If there is no Tracker, the function is simply run. This allows
+// code shared between rollers, eg. RepoManagers, to report telemetry without
+// needing a Tracker passed to it explicitly.
+func Track(ctx context.Context, dep Dependency, fn func(context.Context) error) error {
+	if t, ok := ctx.Value(contextKey).(*Tracker); ok && t != nil {
+		return t.Track(ctx, dep, fn)
+	}
+	return fn(ctx)
+}
@@ -0,0 +1,93 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/now"
+)
+
+var ts = time.Unix(1700000000, 0).UTC()
+
+func TestTracker_Track_RecordsLatencyAndErrors(t *testing.T) {
+	ctx := now.TimeTravelingContext(ts)
+	tr := NewTracker("my-roller")
+
+	require.NoError(t, tr.Track(ctx, DependencyCodeReview, func(context.Context) error {
+		ctx.SetTime(ts.Add(3 * time.Second))
+		return nil
+	}))
+	err := tr.Track(ctx, DependencyCodeReview, func(context.Context) error {
+		ctx.SetTime(ts.Add(4 * time.Second))
+		return errors.New("gerrit is down")
+	})
+	require.EqualError(t, err, "gerrit is down")
+
+	require.Equal(t, &Health{
+		Roller: "my-roller",
+		Dependencies: map[Dependency]DependencyStats{
+			DependencyCodeReview: {
+				Calls:         2,
+				Errors:        1,
+				LastCall:      ts.Add(3 * time.Second),
+				LastError:     "gerrit is down",
+				LastLatencyMs: 1000,
+				MaxLatencyMs:  3000,
+				MeanLatencyMs: 2000,
+				totalLatency:  4 * time.Second,
+			},
+		},
+	}, tr.Health())
+}
+
+func TestTrack_NoTrackerInContext_RunsFunc(t *testing.T) {
+	called := false
+	require.NoError(t, Track(context.Background(), DependencyChildRepo, func(context.Context) error {
+		called = true
+		return nil
+	}))
+	require.True(t, called)
+}
+
+func TestTrack_TrackerInContext_UsesTracker(t *testing.T) {
+	tr := NewTracker("my-roller")
+	ctx := WithTracker(now.TimeTravelingContext(ts), tr)
+	require.NoError(t, Track(ctx, DependencyChildRepo, func(context.Context) error {
+		return nil
+	}))
+	require.Equal(t, int64(1), tr.Health().Dependencies[DependencyChildRepo].Calls)
+}
+
+func TestTracker_HealthHandler_ReturnsJSON(t *testing.T) {
+	ctx := now.TimeTravelingContext(ts)
+	tr := NewTracker("my-roller")
+	require.NoError(t, tr.Track(ctx, DependencyParentRepo, func(context.Context) error {
+		ctx.SetTime(ts.Add(2 * time.Second))
+		return nil
+	}))
+
+	w := httptest.NewRecorder()
+	tr.HealthHandler(w, httptest.NewRequest(http.MethodGet, "/json/health", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var actual Health
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&actual))
+	require.Equal(t, Health{
+		Roller: "my-roller",
+		Dependencies: map[Dependency]DependencyStats{
+			DependencyParentRepo: {
+				Calls:         1,
+				LastCall:      ts,
+				LastLatencyMs: 2000,
+				MaxLatencyMs:  2000,
+				MeanLatencyMs: 2000,
+			},
+		},
+	}, actual)
+}
@@ -22,14 +22,16 @@ var (
 )
 
 // Parse returns a TimeWindow instance based on the given string. Times are
-// interpreted as GMT. The accepted format is as follows:
+// interpreted as GMT unless a time zone is given. The accepted format is as
+// follows:
 //
 //	FullWindowExpr      = SingleDayWindowExpr(;SingleDayWindowExpr)*
-//	SingleDayWindowExpr = DayRangesExpr TimeExpr-TimeExpr
+//	SingleDayWindowExpr = DayRangesExpr TimeExpr-TimeExpr( LocationExpr)?
 //	DayRangesExpr       = (*|DayRangeExpr(,DayRangeExpr)*)
 //	DayRangeExpr        = DayExpr(-DayExpr)?
 //	DayExpr             = (Su|M|Tu|W|Th|F|Sa)
 //	TimeExpr            = \d\d:\d\d
+//	LocationExpr        = IANA time zone name, eg. America/New_York
 //
 // Examples:
 //
@@ -38,6 +40,7 @@ var (
 //	Multiple days, same time:          Sa,M-W 08:00-09:00
 //	Multiple days, different times:    Sa 08:00-09:00; M-W 12:00-03:00
 //	Wrap around to next day:           M-F 22:00-02:00
+//	Local time:                        M-F 06:00-18:00 America/New_York
 func Parse(s string) (*TimeWindow, error) {
 	if s == "" {
 		// A nil TimeWindow always returns true from Test().
@@ -99,24 +102,46 @@ type dayWindow struct {
 	day   time.Weekday
 	start dayTime
 	end   dayTime
+	loc   *time.Location
+}
+
+// at returns the given dayTime on the given day, offset by the given number of
+// days. Days are added to the date rather than as multiples of 24 hours, so
+// that the result is correct across daylight saving time changes.
+func (w dayWindow) at(day time.Time, offset int, dt dayTime) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+offset, dt.hours, dt.minutes, 0, 0, w.loc)
 }
 
 // test returns true iff the given time.Time occurs within the dayWindow.
 func (w dayWindow) test(t time.Time) bool {
 	// Find the nearest start and end to this t.
-	start := time.Date(t.Year(), t.Month(), t.Day(), w.start.hours, w.start.minutes, 0, 0, time.UTC)
+	t = t.In(w.loc)
+	start := w.at(t, 0, w.start)
 	for start.Weekday() != w.day {
-		start = start.Add(-24 * time.Hour)
+		start = w.at(start, -1, w.start)
 	}
-	end := time.Date(start.Year(), start.Month(), start.Day(), w.end.hours, w.end.minutes, 0, 0, time.UTC)
+	end := w.at(start, 0, w.end)
 	if !start.After(t) && end.After(t) {
 		return true
 	}
-	start = start.Add(7 * 24 * time.Hour)
-	end = end.Add(7 * 24 * time.Hour)
+	start = w.at(start, 7, w.start)
+	end = w.at(start, 0, w.end)
 	return !start.After(t) && end.After(t)
 }
 
+// next returns the earliest time.Time which is not before the given time.Time
+// and occurs within the dayWindow.
+func (w dayWindow) next(t time.Time) time.Time {
+	if w.test(t) {
+		return t
+	}
+	start := w.at(t.In(w.loc), 0, w.start)
+	for start.Weekday() != w.day || start.Before(t) {
+		start = w.at(start, 1, w.start)
+	}
+	return start
+}
+
 // parse days and dayWindows from a string formatted like: "M-W,Th,Sa 02:34-03:45"
 func parseDayWindows(s string) ([]*dayWindow, error) {
 	split := strings.SplitN(strings.TrimSpace(s), " ", 2)
@@ -126,10 +151,23 @@ func parseDayWindows(s string) ([]*dayWindow, error) {
 	dayExpr := strings.TrimSpace(split[0])
 	timeExpr := strings.TrimSpace(split[1])
 
+	// The time expression may be followed by a time zone, which is the only
+	// part of the expression without a colon.
+	loc := time.UTC
+	if fields := strings.Fields(timeExpr); len(fields) > 1 && !strings.Contains(fields[len(fields)-1], ":") {
+		locName := fields[len(fields)-1]
+		var err error
+		loc, err = time.LoadLocation(locName)
+		if err != nil {
+			return nil, fmt.Errorf("Unknown time zone %q: %s", locName, err)
+		}
+		timeExpr = strings.TrimSpace(strings.TrimSuffix(timeExpr, locName))
+	}
+
 	// Parse the starting and ending times.
 	timeSplit := strings.Split(timeExpr, "-")
 	if len(timeSplit) != 2 {
-		return nil, fmt.Errorf("Expected window format \"hh:mm-hh:mm\", not %q", split[1])
+		return nil, fmt.Errorf("Expected window format \"hh:mm-hh:mm\", not %q", timeExpr)
 	}
 	start, err := parseDayTime(timeSplit[0])
 	if err != nil {
@@ -158,6 +196,7 @@ func parseDayWindows(s string) ([]*dayWindow, error) {
 				day:   d,
 				start: start,
 				end:   end,
+				loc:   loc,
 			})
 		}
 		return rv, nil
@@ -176,6 +215,7 @@ func parseDayWindows(s string) ([]*dayWindow, error) {
 				day:   day,
 				start: start,
 				end:   end,
+				loc:   loc,
 			})
 		} else if len(rangeSplit) == 2 {
 			startDay, ok := dayMap[rangeSplit[0]]
@@ -195,6 +235,7 @@ func parseDayWindows(s string) ([]*dayWindow, error) {
 					day:   day,
 					start: start,
 					end:   end,
+					loc:   loc,
 				})
 			}
 		} else {
@@ -215,7 +256,6 @@ func (w *TimeWindow) Test(t time.Time) bool {
 	if w == nil {
 		return true
 	}
-	t = t.UTC()
 	for _, dw := range w.dayWindows {
 		if dw.test(t) {
 			return true
@@ -223,3 +263,20 @@ func (w *TimeWindow) Test(t time.Time) bool {
 	}
 	return false
 }
+
+// Next returns the earliest time.Time which is not before the given time.Time
+// and occurs within the TimeWindow, ie. the given time.Time itself if it is
+// within the TimeWindow and the start of the next window otherwise.
+func (w *TimeWindow) Next(t time.Time) time.Time {
+	if w == nil || len(w.dayWindows) == 0 {
+		return t
+	}
+	var rv time.Time
+	for idx, dw := range w.dayWindows {
+		next := dw.next(t)
+		if idx == 0 || next.Before(rv) {
+			rv = next
+		}
+	}
+	return rv.In(t.Location())
+}
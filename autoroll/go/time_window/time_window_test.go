@@ -82,3 +82,50 @@ func TestTimeWindow(t *testing.T) {
 	// A nil TimeWindow always returns true from Test.
 	require.Equal(t, true, (*TimeWindow)(nil).Test(time.Now()))
 }
+
+func TestTimeWindow_TimeZone(t *testing.T) {
+	w, err := Parse("M-F 06:00-18:00 America/New_York")
+	require.NoError(t, err)
+	// Monday, 2019-03-25, during daylight saving time, ie. UTC-4.
+	require.False(t, w.Test(time.Date(2019, 3, 25, 9, 59, 0, 0, time.UTC)))
+	require.True(t, w.Test(time.Date(2019, 3, 25, 10, 0, 0, 0, time.UTC)))
+	require.True(t, w.Test(time.Date(2019, 3, 25, 21, 59, 0, 0, time.UTC)))
+	require.False(t, w.Test(time.Date(2019, 3, 25, 22, 0, 0, 0, time.UTC)))
+
+	// Windows may use different time zones.
+	w, err = Parse("Sa 08:00-09:00 Europe/Berlin; Su 08:00-09:00")
+	require.NoError(t, err)
+	require.True(t, w.Test(time.Date(2019, 3, 23, 7, 30, 0, 0, time.UTC)))
+	require.False(t, w.Test(time.Date(2019, 3, 23, 8, 30, 0, 0, time.UTC)))
+	require.True(t, w.Test(time.Date(2019, 3, 24, 8, 30, 0, 0, time.UTC)))
+
+	_, err = Parse("M 00:00-01:00 Mars/Olympus_Mons")
+	require.ErrorContains(t, err, "Unknown time zone \"Mars/Olympus_Mons\"")
+}
+
+func TestTimeWindow_Next(t *testing.T) {
+	w, err := Parse("M-F 06:00-18:00 America/New_York")
+	require.NoError(t, err)
+
+	// Inside the window.
+	ts := time.Date(2019, 3, 25, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, ts, w.Next(ts))
+
+	// Before the window on the same day.
+	require.Equal(t, time.Date(2019, 3, 25, 10, 0, 0, 0, time.UTC), w.Next(time.Date(2019, 3, 25, 3, 0, 0, 0, time.UTC)))
+
+	// Friday evening and the weekend skip to Monday morning.
+	expect := time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC)
+	require.Equal(t, expect, w.Next(time.Date(2019, 3, 29, 23, 0, 0, 0, time.UTC)))
+	require.Equal(t, expect, w.Next(time.Date(2019, 3, 30, 12, 0, 0, 0, time.UTC)))
+
+	// The end of daylight saving time on 2019-11-03 moves the window by an
+	// hour in UTC.
+	w, err = Parse("* 06:00-07:00 America/New_York")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 11, 3, 11, 0, 0, 0, time.UTC), w.Next(time.Date(2019, 11, 2, 12, 0, 0, 0, time.UTC)))
+
+	// A nil TimeWindow is always open.
+	ts = time.Now()
+	require.Equal(t, ts, (*TimeWindow)(nil).Next(ts))
+}
@@ -31,6 +31,7 @@ import {
   AutoRollStatus,
   CreateManualRollResponse,
   GetAutoRollService,
+  GetNextRollWindowResponse,
   GetStatusResponse,
   ManualRoll,
   ManualRoll_Result,
@@ -781,139 +782,23 @@ export class ARBStatusSk extends ElementSk {
       );
   }
 
-  // computeRollWindowStart returns a string indicating when the configured
-  // roll window will start. If errors are encountered, in particular those
-  // relating to parsing the roll window, the returned string will contain
-  // the error.
-  private computeRollWindowStart(config: AutoRollConfig): Date {
+  // loadRollWindowStart retrieves the time at which the configured roll window
+  // will next start.
+  private loadRollWindowStart(config: AutoRollConfig) {
     if (!config || !config.timeWindow) {
-      return new Date();
+      this.rollWindowStart = new Date();
+      return;
     }
-    // TODO(borenet): This duplicates code in the go/time_window package.
-
-    // parseDayTime returns a 2-element array containing the hour and
-    // minutes as ints. Throws an error (string) if the given string cannot
-    // be parsed as hours and minutes.
-    const parseDayTime = function (s: string) {
-      const timeSplit = s.split(':');
-      if (timeSplit.length !== 2) {
-        throw `Expected time format "hh:mm", not ${s}`;
-      }
-      const hours = parseInt(timeSplit[0]);
-      if (hours < 0 || hours >= 24) {
-        throw `Hours must be between 0-23, not ${timeSplit[0]}`;
-      }
-      const minutes = parseInt(timeSplit[1]);
-      if (minutes < 0 || minutes >= 60) {
-        throw `Minutes must be between 0-59, not ${timeSplit[1]}`;
-      }
-      return [hours, minutes];
-    };
-
-    // Parse multiple day/time windows, eg. M-W 00:00-04:00; Th-F 00:00-02:00
-    const windows = [];
-    const split = config.timeWindow.split(';');
-    for (let i = 0; i < split.length; i++) {
-      const dayTimeWindow = split[i].trim();
-      // Parse individual day/time window, eg. M-W 00:00-04:00
-      const windowSplit = dayTimeWindow.split(' ');
-      if (windowSplit.length !== 2) {
-        console.error(`expected format "D hh:mm", not ${dayTimeWindow}`);
-        return new Date();
-      }
-      const dayExpr = windowSplit[0].trim();
-      const timeExpr = windowSplit[1].trim();
-
-      // Parse the starting and ending times.
-      const timeExprSplit = timeExpr.split('-');
-      if (timeExprSplit.length !== 2) {
-        console.error(`expected format "hh:mm-hh:mm", not ${timeExpr}`);
-        return new Date();
-      }
-      let startTime;
-      try {
-        startTime = parseDayTime(timeExprSplit[0]);
-      } catch (e) {
-        console.error(e);
-        return new Date();
-      }
-      let endTime;
-      try {
-        endTime = parseDayTime(timeExprSplit[1]);
-      } catch (e) {
-        console.error(e);
-        return new Date();
-      }
-
-      // Parse the day(s).
-      const allDays = ['Su', 'M', 'Tu', 'W', 'Th', 'F', 'Sa'];
-      const days = [];
-
-      // "*" means every day.
-      if (dayExpr === '*') {
-        days.push(...allDays.map((_, i) => i));
-      } else {
-        const rangesSplit = dayExpr.split(',');
-        for (let i = 0; i < rangesSplit.length; i++) {
-          const rangeSplit = rangesSplit[i].split('-');
-          if (rangeSplit.length === 1) {
-            const day = allDays.indexOf(rangeSplit[0]);
-            if (day === -1) {
-              console.error(`Unknown day ${rangeSplit[0]}`);
-              return new Date();
-            }
-            days.push(day);
-          } else if (rangeSplit.length === 2) {
-            const startDay = allDays.indexOf(rangeSplit[0]);
-            if (startDay === -1) {
-              console.error(`Unknown day ${rangeSplit[0]}`);
-              return new Date();
-            }
-            let endDay = allDays.indexOf(rangeSplit[1]);
-            if (endDay === -1) {
-              console.error(`Unknown day ${rangeSplit[1]}`);
-              return new Date();
-            }
-            if (endDay < startDay) {
-              endDay += 7;
-            }
-            for (let day = startDay; day <= endDay; day++) {
-              days.push(day % 7);
-            }
-          } else {
-            console.error(`Invalid day expression ${rangesSplit[i]}`);
-            return new Date();
-          }
+    this.rpc
+      .getNextRollWindow({
+        rollerId: this.roller,
+      })
+      .then((resp: GetNextRollWindowResponse) => {
+        if (resp.nextWindowStart) {
+          this.rollWindowStart = new Date(resp.nextWindowStart);
+          this._render();
         }
-      }
-
-      // Add the windows to the list.
-      for (let i = 0; i < days.length; i++) {
-        windows.push({
-          day: days[i],
-          start: startTime,
-          end: endTime,
-        });
-      }
-    }
-
-    // For each window, find the timestamp at which it opens next.
-    const now = new Date().getTime();
-    const openTimes = windows.map((w) => {
-      let next = new Date(now);
-      next.setUTCHours(w.start[0], w.start[1], 0, 0);
-      const dayOffsetMs = (w.day - next.getUTCDay()) * 24 * 60 * 60 * 1000;
-      next = new Date(next.getTime() + dayOffsetMs);
-      if (next.getTime() < now) {
-        // If we've missed this week's window, bump forward a week.
-        next = new Date(next.getTime() + 7 * 24 * 60 * 60 * 1000);
-      }
-      return next;
-    });
-
-    // Pick the next window.
-    openTimes.sort((a, b) => a.getTime() - b.getTime());
-    return openTimes[0];
+      });
   }
 
   private issueURL(status: AutoRollStatus, roll: AutoRollCL): string {
@@ -946,6 +831,8 @@ export class ARBStatusSk extends ElementSk {
         return 'N_BATCH rolls multiple new revisions in a single CL with a limit on the number of revisions';
       case Strategy.SINGLE:
         return 'SINGLE rolls one revision per CL';
+      case Strategy.BISECT:
+        return 'BISECT rolls multiple new revisions in a single CL with a limit on the number of revisions, and splits the revisions of a failed roll in half until the culprit is found';
       default:
         return '';
     }
@@ -1229,7 +1116,7 @@ export class ARBStatusSk extends ElementSk {
     this.lastLoaded = new Date();
     this.validModes = Object.keys(Mode).map((key) => Mode[key as keyof typeof Mode]);
     if (status.config) {
-      this.rollWindowStart = this.computeRollWindowStart(status.config);
+      this.loadRollWindowStart(status.config);
       if ((status.config.validModes || []).length > 0) {
         this.validModes = status.config.validModes!;
       }
@@ -64,6 +64,11 @@ export enum NotifierConfig_MsgType {
   MANUAL_ROLL_CREATION_FAILED = "MANUAL_ROLL_CREATION_FAILED",
 }
 
+export enum NotifierConfig_FailureClass {
+  INFRA = "INFRA",
+  TEST = "TEST",
+}
+
 export interface Config {
   rollerName: string;
   childBugLink: string;
@@ -99,6 +104,9 @@ export interface Config {
   validModes?: Mode[];
   maxRollCqAttempts: number;
   maxRollClsToSameRevision: number;
+  prerequisites?: RollerPrerequisiteConfig[];
+  parentBranches?: string[];
+  noRollMarker?: NoRollMarkerConfig;
 }
 
 interface ConfigJSON {
@@ -136,6 +144,9 @@ interface ConfigJSON {
   valid_modes?: string[];
   max_roll_cq_attempts?: number;
   max_roll_cls_to_same_revision?: number;
+  prerequisites?: RollerPrerequisiteConfigJSON[];
+  parent_branches?: string[];
+  no_roll_marker?: NoRollMarkerConfigJSON;
 }
 
 export interface CommitMsgConfig {
@@ -337,6 +348,7 @@ export interface ParentChildRepoManagerConfig {
   gitilesChild?: GitilesChildConfig;
   semverGcsChild?: SemVerGCSChildConfig;
   dockerChild?: DockerChildConfig;
+  cipdGroupChild?: CIPDGroupChildConfig;
   buildbucketRevisionFilter?: BuildbucketRevisionFilterConfig[];
   cipdRevisionFilter?: CIPDRevisionFilterConfig[];
   validHttpRevisionFilter?: ValidHttpRevisionFilterConfig[];
@@ -357,6 +369,7 @@ interface ParentChildRepoManagerConfigJSON {
   gitiles_child?: GitilesChildConfigJSON;
   semver_gcs_child?: SemVerGCSChildConfigJSON;
   docker_child?: DockerChildConfigJSON;
+  cipd_group_child?: CIPDGroupChildConfigJSON;
   buildbucket_revision_filter?: BuildbucketRevisionFilterConfigJSON[];
   cipd_revision_filter?: CIPDRevisionFilterConfigJSON[];
   valid_http_revision_filter?: ValidHttpRevisionFilterConfigJSON[];
@@ -548,6 +561,20 @@ interface CIPDChildConfigJSON {
   source_repo?: GitilesConfigJSON;
 }
 
+export interface CIPDGroupChildConfig {
+  names?: string[];
+  tag: string;
+  revisionIdTag: string;
+  revisionIdTagStripKey: boolean;
+}
+
+interface CIPDGroupChildConfigJSON {
+  names?: string[];
+  tag?: string;
+  revision_id_tag?: string;
+  revision_id_tag_strip_key?: boolean;
+}
+
 export interface FuchsiaSDKChildConfig {
   includeMacSdk: boolean;
   gcsBucket: string;
@@ -638,6 +665,7 @@ export interface NotifierConfig {
   monorail?: MonorailNotifierConfig;
   pubsub?: PubSubNotifierConfig;
   subject: string;
+  failureClass?: NotifierConfig_FailureClass[];
 }
 
 interface NotifierConfigJSON {
@@ -648,6 +676,7 @@ interface NotifierConfigJSON {
   monorail?: MonorailNotifierConfigJSON;
   pubsub?: PubSubNotifierConfigJSON;
   subject?: string;
+  failure_class?: string[];
 }
 
 export interface EmailNotifierConfig {
@@ -700,6 +729,32 @@ interface ThrottleConfigJSON {
   time_window?: string;
 }
 
+export interface RollerPrerequisiteConfig {
+  rollerName: string;
+  maxDelay: string;
+  dependencyId: string;
+}
+
+interface RollerPrerequisiteConfigJSON {
+  roller_name?: string;
+  max_delay?: string;
+  dependency_id?: string;
+}
+
+export interface NoRollMarkerConfig {
+  gitilesRepo: string;
+  branch: string;
+  path: string;
+  contentRegex: string;
+}
+
+interface NoRollMarkerConfigJSON {
+  gitiles_repo?: string;
+  branch?: string;
+  path?: string;
+  content_regex?: string;
+}
+
 export interface TransitiveDepConfig {
   child?: VersionFileConfig;
   parent?: VersionFileConfig;
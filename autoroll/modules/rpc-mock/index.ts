@@ -8,6 +8,8 @@ import {
   GetMiniStatusResponse,
   GetModeHistoryRequest,
   GetModeHistoryResponse,
+  GetNextRollWindowRequest,
+  GetNextRollWindowResponse,
   GetRollersRequest,
   GetRollersResponse,
   GetStatusRequest,
@@ -205,6 +207,14 @@ class FakeAutoRollService implements AutoRollService {
     return Promise.resolve({});
   }
 
+  getNextRollWindow(_: GetNextRollWindowRequest): Promise<GetNextRollWindowResponse> {
+    return Promise.resolve({
+      timeWindow: this.status.config?.timeWindow || '',
+      inWindow: true,
+      nextWindowStart: new Date().toISOString(),
+    });
+  }
+
   addCleanupRequest(req: AddCleanupRequestRequest): Promise<AddCleanupRequestResponse> {
     const cleanupRequest: CleanupRequest = {
       needsCleanup: true,
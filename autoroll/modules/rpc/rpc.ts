@@ -11,6 +11,7 @@ export enum Strategy {
   BATCH = "BATCH",
   N_BATCH = "N_BATCH",
   SINGLE = "SINGLE",
+  BISECT = "BISECT",
 }
 
 export enum TryJob_Result {
@@ -761,6 +762,40 @@ const JSONToCleanupRequest = (m: CleanupRequestJSON): CleanupRequest => {
   };
 };
 
+export interface GetNextRollWindowRequest {
+  rollerId: string;
+}
+
+interface GetNextRollWindowRequestJSON {
+  roller_id?: string;
+}
+
+const GetNextRollWindowRequestToJSON = (m: GetNextRollWindowRequest): GetNextRollWindowRequestJSON => {
+  return {
+    roller_id: m.rollerId,
+  };
+};
+
+export interface GetNextRollWindowResponse {
+  timeWindow: string;
+  inWindow: boolean;
+  nextWindowStart?: string;
+}
+
+interface GetNextRollWindowResponseJSON {
+  time_window?: string;
+  in_window?: boolean;
+  next_window_start?: string;
+}
+
+const JSONToGetNextRollWindowResponse = (m: GetNextRollWindowResponseJSON): GetNextRollWindowResponse => {
+  return {
+    timeWindow: m.time_window || "",
+    inWindow: m.in_window || false,
+    nextWindowStart: m.next_window_start,
+  };
+};
+
 export interface AutoRollService {
   addCleanupRequest: (addCleanupRequestRequest: AddCleanupRequestRequest) => Promise<AddCleanupRequestResponse>;
   getCleanupHistory: (getCleanupHistoryRequest: GetCleanupHistoryRequest) => Promise<GetCleanupHistoryResponse>;
@@ -774,6 +809,7 @@ export interface AutoRollService {
   getStrategyHistory: (getStrategyHistoryRequest: GetStrategyHistoryRequest) => Promise<GetStrategyHistoryResponse>;
   createManualRoll: (createManualRollRequest: CreateManualRollRequest) => Promise<CreateManualRollResponse>;
   unthrottle: (unthrottleRequest: UnthrottleRequest) => Promise<UnthrottleResponse>;
+  getNextRollWindow: (getNextRollWindowRequest: GetNextRollWindowRequest) => Promise<GetNextRollWindowResponse>;
 }
 
 export class AutoRollServiceClient implements AutoRollService {
@@ -969,4 +1005,19 @@ export class AutoRollServiceClient implements AutoRollService {
       return resp.json().then(JSONToUnthrottleResponse);
     });
   }
+
+  getNextRollWindow(getNextRollWindowRequest: GetNextRollWindowRequest): Promise<GetNextRollWindowResponse> {
+    const url = this.hostname + this.pathPrefix + "GetNextRollWindow";
+    let body: GetNextRollWindowRequest | GetNextRollWindowRequestJSON = getNextRollWindowRequest;
+    if (!this.writeCamelCase) {
+      body = GetNextRollWindowRequestToJSON(getNextRollWindowRequest);
+    }
+    return this.fetch(createTwirpRequest(url, body, this.optionsOverride)).then((resp) => {
+      if (!resp.ok) {
+        return throwTwirpError(resp);
+      }
+
+      return resp.json().then(JSONToGetNextRollWindowResponse);
+    });
+  }
 }
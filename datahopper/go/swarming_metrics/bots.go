@@ -23,6 +23,15 @@ const (
 	measurementSwarmingBotsLastTask    = "swarming_bots_last_task"
 	measurementSwarmingBotsDeviceTemp  = "swarming_bots_device_temp"
 	measurementSwarmingBotsUptime      = "swarming_bots_uptime_s"
+	measurementSwarmingPoolUtilization = "swarming_pool_utilization_s"
+
+	// poolUtilizationWindow is the window of time over which pool utilization
+	// is reported.
+	poolUtilizationWindow = 24 * time.Hour
+
+	// poolUtilizationPeriod is how often pool utilization is reported, and how
+	// long it is cached for.
+	poolUtilizationPeriod = 30 * time.Minute
 )
 
 var ignoreBatteries = []*regexp.Regexp{
@@ -246,9 +255,31 @@ type androidDevice struct {
 	State             string                 `json:"state"`
 }
 
+// reportPoolUtilization reports how many seconds the bots in the given pool
+// spent busy, idle and dead over the last poolUtilizationWindow.
+func reportPoolUtilization(ctx context.Context, cache *swarmingv2.UtilizationCache, metricsClient metrics2.Client, pool, server string) error {
+	summary, err := cache.Get(ctx, pool, poolUtilizationWindow)
+	if err != nil {
+		return skerr.Wrapf(err, "could not get utilization for pool %s", pool)
+	}
+	for state, d := range map[string]time.Duration{
+		"busy": summary.Busy,
+		"idle": summary.Idle,
+		"dead": summary.Dead,
+	} {
+		metricsClient.GetInt64Metric(measurementSwarmingPoolUtilization, map[string]string{
+			"pool":     pool,
+			"swarming": server,
+			"state":    state,
+		}).Update(int64(d.Seconds()))
+	}
+	return nil
+}
+
 // StartSwarmingBotMetrics spins up several go routines to begin reporting
 // metrics every 2 minutes.
 func StartSwarmingBotMetrics(ctx context.Context, swarmingServer string, swarmingPools []string, client swarmingv2.SwarmingV2Client, metricsClient metrics2.Client) {
+	utilizationCache := swarmingv2.NewUtilizationCache(client, poolUtilizationPeriod)
 	for _, pool := range swarmingPools {
 		pool := pool
 		lvReportBotMetrics := metrics2.NewLiveness("last_successful_report_bot_metrics", map[string]string{
@@ -281,5 +312,10 @@ func StartSwarmingBotMetrics(ctx context.Context, swarmingServer string, swarmin
 			oldMetrics = newMetricsMap
 			lvReportBotMetrics.Reset()
 		})
+		go util.RepeatCtx(ctx, poolUtilizationPeriod, func(ctx context.Context) {
+			if err := reportPoolUtilization(ctx, utilizationCache, metricsClient, pool, swarmingServer); err != nil {
+				sklog.Error(err)
+			}
+		})
 	}
 }
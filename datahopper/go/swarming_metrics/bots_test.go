@@ -7,11 +7,14 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	apipb "go.chromium.org/luci/swarming/proto/api_v2"
 	"go.skia.org/infra/go/metrics2"
 	metrics_util "go.skia.org/infra/go/metrics2/testutils"
+	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/swarming"
+	swarmingv2 "go.skia.org/infra/go/swarming/v2"
 	"go.skia.org/infra/go/swarming/v2/mocks"
 	"go.skia.org/infra/go/testutils"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -411,3 +414,48 @@ func TestBotUptimeMetrics(t *testing.T) {
 	actual := metrics_util.GetRecordedMetric(t, measurementSwarmingBotsUptime, tags)
 	require.Equal(t, "153", actual)
 }
+
+func TestReportPoolUtilization(t *testing.T) {
+	ms := &mocks.SwarmingV2Client{}
+	defer ms.AssertExpectations(t)
+
+	end := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	ctx := now.TimeTravelingContext(end)
+
+	ms.On("ListBots", testutils.AnyContext, mock.Anything).Return(&apipb.BotInfoListResponse{
+		Items: []*apipb.BotInfo{{BotId: "bot-a"}},
+	}, nil)
+	ms.On("ListBotTasks", testutils.AnyContext, mock.Anything).Return(&apipb.TaskListResponse{
+		Items: []*apipb.TaskResultResponse{
+			{
+				StartedTs:   timestamppb.New(end.Add(-3 * time.Hour)),
+				CompletedTs: timestamppb.New(end.Add(-time.Hour)),
+				State:       apipb.TaskState_COMPLETED,
+			},
+		},
+	}, nil)
+	ms.On("ListBotEvents", testutils.AnyContext, mock.Anything).Return(&apipb.BotEventsResponse{
+		Items: []*apipb.BotEventResponse{
+			{Ts: timestamppb.New(end.Add(-30 * time.Minute)), EventType: "bot_missing"},
+		},
+	}, nil)
+
+	pc := getPromClient()
+	cache := swarmingv2.NewUtilizationCache(ms, poolUtilizationPeriod)
+	require.NoError(t, reportPoolUtilization(ctx, cache, pc, MOCK_POOL, MOCK_SERVER))
+
+	for state, expected := range map[string]time.Duration{
+		"busy": 2 * time.Hour,
+		"dead": 30 * time.Minute,
+		"idle": poolUtilizationWindow - 150*time.Minute,
+	} {
+		tags := map[string]string{
+			"pool":     MOCK_POOL,
+			"swarming": MOCK_SERVER,
+			"state":    state,
+		}
+		actual, err := strconv.ParseFloat(metrics_util.GetRecordedMetric(t, measurementSwarmingPoolUtilization, tags), 64)
+		require.NoError(t, err)
+		require.Equalf(t, int64(expected.Seconds()), int64(actual), "Wrong utilization for state %s", state)
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"html"
 	"html/template"
 	ttemplate "html/template"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -47,6 +49,18 @@ var (
 	scrapExchange    = flag.String("scrapexchange", "http://scrapexchange:9000", "Scrap exchange service HTTP address.")
 	sourceImageDir   = flag.String("source_image_dir", "./source", "The directory to load the source images from.")
 	fiddlerNamespace = flag.String("fiddler_namespace", "default", "The k8s namespace that the fiddlers live in. ")
+	origin           = flag.String("origin", "https://fiddle.skia.org", "The URL that fiddle is served from, used in the links returned by the embedding API.")
+)
+
+const (
+	// embedCodeWidth and embedChromeHeight approximate the room taken up by the
+	// code editor and controls of an embedded fiddle, beyond its output.
+	embedCodeWidth    = 550
+	embedChromeHeight = 120
+
+	// embedMinHeight is the smallest height of the output area of an embedded
+	// fiddle, which leaves room for a few lines of code.
+	embedMinHeight = 200
 )
 
 var (
@@ -128,6 +142,7 @@ func loadTemplates() {
 	templates = template.Must(template.New("").Delims("{%", "%}").Funcs(funcMap).ParseFiles(
 		filepath.Join(*distDir, "newindex.html"),
 		filepath.Join(*distDir, "named.html"),
+		filepath.Join(*distDir, "iframe.html"),
 	))
 }
 
@@ -254,6 +269,159 @@ func embedHandle(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// iframeHTML returns the markup for an iframe of the given size which shows the
+// given page.
+func iframeHTML(src, id string, width, height int) string {
+	return fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" title="Skia Fiddle %s" style="border: none;" loading="lazy"></iframe>`,
+		html.EscapeString(src), width, height, html.EscapeString(id))
+}
+
+// makeEmbed returns a description of how to embed the fiddle with the given id
+// and options.
+func makeEmbed(id string, options *types.Options) *types.Embed {
+	iframeURL := fmt.Sprintf("%s/iframe/%s", *origin, id)
+	width := embedCodeWidth + options.Width
+	height := util.MaxInt(embedMinHeight, options.Height) + embedChromeHeight
+	rv := &types.Embed{
+		FiddleHash: id,
+		URL:        fmt.Sprintf("%s/c/%s", *origin, id),
+		IFrameURL:  iframeURL,
+		HTML:       iframeHTML(iframeURL, id, width, height),
+		Width:      width,
+		Height:     height,
+	}
+	if !options.TextOnly && !options.Animated {
+		rv.Images = types.EmbedImages{
+			CPU: fmt.Sprintf("%s/i/%s_raster.png", *origin, id),
+			GPU: fmt.Sprintf("%s/i/%s_gpu.png", *origin, id),
+			PDF: fmt.Sprintf("%s/i/%s.pdf", *origin, id),
+			SKP: fmt.Sprintf("%s/i/%s.skp", *origin, id),
+		}
+	}
+	return rv
+}
+
+// loadEmbed returns a description of how to embed the fiddle with the given
+// id, which can be a fiddle hash or name, along with the fiddle's options.
+func loadEmbed(id string) (*types.Embed, *types.Options, error) {
+	fiddleHash, err := names.DereferenceID(id)
+	if err != nil {
+		return nil, nil, skerr.Wrapf(err, "Invalid id")
+	}
+	_, options, err := fiddleStore.GetCode(fiddleHash)
+	if err != nil {
+		return nil, nil, skerr.Fmt("Fiddle %s not found.", fiddleHash)
+	}
+	return makeEmbed(id, options), options, nil
+}
+
+// jsonEmbedHandler returns the iframe markup, dimensions, and image fallbacks
+// for embedding a fiddle in another page.
+func jsonEmbedHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	embed, _, err := loadEmbed(chi.URLParam(r, "id"))
+	if err != nil {
+		http.NotFound(w, r)
+		sklog.Errorf("Failed to load embed: %s", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(embed); err != nil {
+		httputils.ReportError(w, err, "Failed to JSON Encode response.", http.StatusInternalServerError)
+	}
+}
+
+var fiddleURLPathRe = regexp.MustCompile("^/(?:c|iframe)/([@0-9a-zA-Z_]+)$")
+
+// idFromFiddleURL returns the fiddle hash or name from a permalink or iframe
+// URL of a fiddle, e.g. https://fiddle.skia.org/c/@shapes.
+func idFromFiddleURL(fiddleURL string) (string, error) {
+	u, err := url.Parse(fiddleURL)
+	if err != nil {
+		return "", skerr.Wrapf(err, "Invalid URL")
+	}
+	o, err := url.Parse(*origin)
+	if err != nil {
+		return "", skerr.Wrapf(err, "Invalid origin")
+	}
+	if u.Host != o.Host {
+		return "", skerr.Fmt("URL %q is not hosted at %q", fiddleURL, *origin)
+	}
+	match := fiddleURLPathRe.FindStringSubmatch(u.Path)
+	if match == nil {
+		return "", skerr.Fmt("URL %q is not a fiddle", fiddleURL)
+	}
+	return match[1], nil
+}
+
+// limitDimension returns the smaller of the given dimension and the given
+// oEmbed maxwidth or maxheight parameter, which may be empty.
+func limitDimension(dim int, max string) (int, error) {
+	if max == "" {
+		return dim, nil
+	}
+	i, err := strconv.Atoi(max)
+	if err != nil {
+		return 0, skerr.Wrap(err)
+	}
+	if i <= 0 {
+		return 0, skerr.Fmt("Must be positive: %d", i)
+	}
+	return util.MinInt(dim, i), nil
+}
+
+// oembedHandler implements an oEmbed endpoint, see https://oembed.com/, for
+// fiddle permalinks. Only the JSON format is supported.
+func oembedHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	query := r.URL.Query()
+	if format := query.Get("format"); format != "" && format != "json" {
+		http.Error(w, "Only the json format is supported.", http.StatusNotImplemented)
+		return
+	}
+	id, err := idFromFiddleURL(query.Get("url"))
+	if err != nil {
+		httputils.ReportError(w, err, "Not a fiddle URL.", http.StatusNotFound)
+		return
+	}
+	embed, options, err := loadEmbed(id)
+	if err != nil {
+		http.NotFound(w, r)
+		sklog.Errorf("Failed to load embed: %s", err)
+		return
+	}
+	maxWidth, err := limitDimension(embed.Width, query.Get("maxwidth"))
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid maxwidth.", http.StatusBadRequest)
+		return
+	}
+	maxHeight, err := limitDimension(embed.Height, query.Get("maxheight"))
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid maxheight.", http.StatusBadRequest)
+		return
+	}
+	resp := types.OEmbed{
+		Version:      "1.0",
+		Type:         "rich",
+		Title:        fmt.Sprintf("Skia Fiddle %s", id),
+		ProviderName: "Skia Fiddle",
+		ProviderURL:  *origin,
+		HTML:         iframeHTML(embed.IFrameURL, id, maxWidth, maxHeight),
+		Width:        maxWidth,
+		Height:       maxHeight,
+	}
+	// The thumbnail may not exceed the requested maximum size.
+	if embed.Images.CPU != "" && options.Width <= maxWidth && options.Height <= maxHeight {
+		resp.ThumbnailURL = embed.Images.CPU
+		resp.ThumbnailWidth = options.Width
+		resp.ThumbnailHeight = options.Height
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		httputils.ReportError(w, err, "Failed to JSON Encode response.", http.StatusInternalServerError)
+	}
+}
+
 // individualHandle handles permalinks to individual fiddles.
 func individualHandle(w http.ResponseWriter, r *http.Request) {
 	context, err := loadContext(w, r)
@@ -559,6 +727,9 @@ func addHandlers(r chi.Router) {
 	r.Get("/i/{id:[@0-9a-zA-Z._]+}", imageHandler)
 	r.Get("/c/{id:[@0-9a-zA-Z_]+}", individualHandle)
 	r.Get("/e/{id:[@0-9a-zA-Z_]+}", embedHandle)
+	r.Get("/iframe/{id:[@0-9a-zA-Z_]+}", iframeHandle)
+	r.Get("/json/embed/{id:[@0-9a-zA-Z_]+}", jsonEmbedHandler)
+	r.Get("/oembed", oembedHandler)
 	r.Get("/s/{id:[0-9]+}", sourceHandler)
 	r.Get("/scrap/{type:[a-z]+}/{hashOrName:[@0-9a-zA-Z-_]+}", scrapHandler)
 	r.Get("/f/", failedHandler)
@@ -2,14 +2,18 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/fiddlek/go/named"
 	"go.skia.org/infra/fiddlek/go/store/mocks"
 	"go.skia.org/infra/fiddlek/go/types"
 	"go.skia.org/infra/go/testutils"
@@ -133,3 +137,116 @@ func TestScrapHandler_WithInvalidType_ReturnsBadRequest(t *testing.T) {
 
 	require.Equal(t, 404, w.Code)
 }
+
+const embedFiddleHash = "cbb8dee39e9f1576cd97c2d504db8eee"
+
+// setupEmbedStore installs a mock fiddle store which contains a single 256x128
+// fiddle with the hash embedFiddleHash.
+func setupEmbedStore() {
+	store := &mocks.Store{}
+	store.On("GetCode", embedFiddleHash).Return(code, &types.Options{Width: 256, Height: 128}, nil).Maybe()
+	store.On("GetCode", mock.Anything).Return("", nil, errMyMockError).Maybe()
+	fiddleStore = store
+	names = named.New(store)
+}
+
+func serveEmbedRequest(url string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("GET", url, nil)
+	w := httptest.NewRecorder()
+	router := chi.NewRouter()
+	addHandlers(router)
+	router.ServeHTTP(w, r)
+	return w
+}
+
+func TestJSONEmbedHandler_HappyPath(t *testing.T) {
+	setupEmbedStore()
+
+	w := serveEmbedRequest("/json/embed/" + embedFiddleHash)
+
+	require.Equal(t, 200, w.Code)
+	require.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	var embed types.Embed
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &embed))
+	assert.Equal(t, types.Embed{
+		FiddleHash: embedFiddleHash,
+		URL:        "https://fiddle.skia.org/c/" + embedFiddleHash,
+		IFrameURL:  "https://fiddle.skia.org/iframe/" + embedFiddleHash,
+		HTML:       `<iframe src="https://fiddle.skia.org/iframe/cbb8dee39e9f1576cd97c2d504db8eee" width="806" height="320" title="Skia Fiddle cbb8dee39e9f1576cd97c2d504db8eee" style="border: none;" loading="lazy"></iframe>`,
+		Width:      806,
+		Height:     320,
+		Images: types.EmbedImages{
+			CPU: "https://fiddle.skia.org/i/" + embedFiddleHash + "_raster.png",
+			GPU: "https://fiddle.skia.org/i/" + embedFiddleHash + "_gpu.png",
+			PDF: "https://fiddle.skia.org/i/" + embedFiddleHash + ".pdf",
+			SKP: "https://fiddle.skia.org/i/" + embedFiddleHash + ".skp",
+		},
+	}, embed)
+}
+
+func TestJSONEmbedHandler_UnknownFiddle_ReturnsNotFound(t *testing.T) {
+	setupEmbedStore()
+
+	w := serveEmbedRequest("/json/embed/00000000000000000000000000000000")
+
+	require.Equal(t, 404, w.Code)
+}
+
+func TestOEmbedHandler_HappyPath(t *testing.T) {
+	setupEmbedStore()
+
+	w := serveEmbedRequest("/oembed?maxwidth=600&url=" + url.QueryEscape("https://fiddle.skia.org/c/"+embedFiddleHash))
+
+	require.Equal(t, 200, w.Code)
+	var resp types.OEmbed
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, types.OEmbed{
+		Version:         "1.0",
+		Type:            "rich",
+		Title:           "Skia Fiddle " + embedFiddleHash,
+		ProviderName:    "Skia Fiddle",
+		ProviderURL:     "https://fiddle.skia.org",
+		HTML:            `<iframe src="https://fiddle.skia.org/iframe/cbb8dee39e9f1576cd97c2d504db8eee" width="600" height="320" title="Skia Fiddle cbb8dee39e9f1576cd97c2d504db8eee" style="border: none;" loading="lazy"></iframe>`,
+		Width:           600,
+		Height:          320,
+		ThumbnailURL:    "https://fiddle.skia.org/i/" + embedFiddleHash + "_raster.png",
+		ThumbnailWidth:  256,
+		ThumbnailHeight: 128,
+	}, resp)
+}
+
+func TestOEmbedHandler_MaxHeightSmallerThanThumbnail_NoThumbnail(t *testing.T) {
+	setupEmbedStore()
+
+	w := serveEmbedRequest("/oembed?maxheight=100&url=" + url.QueryEscape("https://fiddle.skia.org/iframe/"+embedFiddleHash))
+
+	require.Equal(t, 200, w.Code)
+	var resp types.OEmbed
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 100, resp.Height)
+	assert.Empty(t, resp.ThumbnailURL)
+}
+
+func TestOEmbedHandler_NotAFiddleURL_ReturnsNotFound(t *testing.T) {
+	setupEmbedStore()
+
+	w := serveEmbedRequest("/oembed?url=" + url.QueryEscape("https://example.com/c/"+embedFiddleHash))
+
+	require.Equal(t, 404, w.Code)
+}
+
+func TestOEmbedHandler_XMLFormat_ReturnsNotImplemented(t *testing.T) {
+	setupEmbedStore()
+
+	w := serveEmbedRequest("/oembed?format=xml&url=" + url.QueryEscape("https://fiddle.skia.org/c/"+embedFiddleHash))
+
+	require.Equal(t, 501, w.Code)
+}
+
+func TestOEmbedHandler_InvalidMaxWidth_ReturnsBadRequest(t *testing.T) {
+	setupEmbedStore()
+
+	w := serveEmbedRequest("/oembed?maxwidth=-1&url=" + url.QueryEscape("https://fiddle.skia.org/c/"+embedFiddleHash))
+
+	require.Equal(t, 400, w.Code)
+}
@@ -152,6 +152,43 @@ type RunResults struct {
 	Text          string         `json:"text"`
 }
 
+// EmbedImages are the URLs of the images a fiddle produced, for use as a
+// fallback where an iframe can't be used. Empty for text only and animated
+// fiddles.
+type EmbedImages struct {
+	CPU string `json:"cpu,omitempty"`
+	GPU string `json:"gpu,omitempty"`
+	PDF string `json:"pdf,omitempty"`
+	SKP string `json:"skp,omitempty"`
+}
+
+// Embed is the JSON returned from /json/embed/{id}, it describes how to embed
+// a fiddle in another page.
+type Embed struct {
+	FiddleHash string      `json:"fiddlehash"` // The fiddle hash or the fiddle name, as requested.
+	URL        string      `json:"url"`        // The permalink to the fiddle.
+	IFrameURL  string      `json:"iframe_url"` // The page to load in an iframe.
+	HTML       string      `json:"html"`       // The iframe markup.
+	Width      int         `json:"width"`      // The width of the iframe.
+	Height     int         `json:"height"`     // The height of the iframe.
+	Images     EmbedImages `json:"images"`
+}
+
+// OEmbed is the JSON returned from /oembed, see https://oembed.com/.
+type OEmbed struct {
+	Version         string `json:"version"`
+	Type            string `json:"type"`
+	Title           string `json:"title"`
+	ProviderName    string `json:"provider_name"`
+	ProviderURL     string `json:"provider_url"`
+	HTML            string `json:"html"`
+	Width           int    `json:"width"`
+	Height          int    `json:"height"`
+	ThumbnailURL    string `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth  int    `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight int    `json:"thumbnail_height,omitempty"`
+}
+
 type BulkRequest map[string]*FiddleContext
 type BulkResponse map[string]*RunResults
 
@@ -20,6 +20,7 @@ require (
 	github.com/Masterminds/sprig v2.22.0+incompatible
 	github.com/a8m/envsubst v1.2.0
 	github.com/aclements/go-moremath v0.0.0-20190830160640-d16893ddf098
+	github.com/apache/arrow/go/v12 v12.0.0
 	github.com/bazelbuild/bazel-gazelle v0.33.0
 	github.com/bazelbuild/buildtools v0.0.0-20231017121127-23aa65d4e117
 	github.com/bazelbuild/remote-apis v0.0.0-20230822133051-6c32c3b917cc
@@ -33,6 +34,7 @@ require (
 	github.com/dustin/go-humanize v1.0.1
 	github.com/fiorix/go-web v1.0.1-0.20150221144011-5b593f1e8966
 	github.com/flynn/json5 v0.0.0-20160717195620-7620272ed633
+	github.com/gen2brain/avif v0.1.0
 	github.com/go-chi/chi/v5 v5.0.8
 	github.com/go-python/gpython v0.0.3
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da
@@ -66,6 +68,7 @@ require (
 	github.com/protocolbuffers/txtpbfmt v0.0.0-20230730201308-0c31dbd32b9f
 	github.com/r3labs/sse/v2 v2.8.1
 	github.com/redis/go-redis/v9 v9.5.3
+	github.com/robfig/cron v1.2.0
 	github.com/rs/cors v1.6.0
 	github.com/sendgrid/sendgrid-go v3.11.1+incompatible
 	github.com/shirou/gopsutil v3.21.11+incompatible
@@ -90,6 +93,7 @@ require (
 	go.temporal.io/api v1.26.2
 	go.temporal.io/sdk v1.25.2-0.20240108215803-6244097c5aca
 	golang.org/x/exp v0.0.0-20231127185646-65229373498e
+	golang.org/x/image v0.15.0
 	golang.org/x/net v0.21.0
 	golang.org/x/oauth2 v0.16.0
 	golang.org/x/sync v0.6.0
@@ -120,9 +124,9 @@ require (
 	cloud.google.com/go/container v1.29.0 // indirect
 	cloud.google.com/go/longrunning v0.5.4 // indirect
 	cloud.google.com/go/trace v1.10.4 // indirect
+	github.com/JohnCGriffin/overflow v0.0.0-20211019200055-46fa312c352c // indirect
 	github.com/Masterminds/goutils v1.1.1 // indirect
 	github.com/andybalholm/brotli v1.0.4 // indirect
-	github.com/apache/arrow/go/v12 v12.0.0 // indirect
 	github.com/apache/thrift v0.16.0 // indirect
 	github.com/aws/aws-sdk-go v1.35.18 // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
@@ -207,7 +211,6 @@ require (
 	github.com/prometheus/common v0.26.0 // indirect
 	github.com/prometheus/procfs v0.6.0 // indirect
 	github.com/robertkrimen/otto v0.0.0-20200922221731-ef014fd054ac // indirect
-	github.com/robfig/cron v1.2.0 // indirect
 	github.com/rs/zerolog v1.29.0 // indirect
 	github.com/russross/blackfriday/v2 v2.1.0 // indirect
 	github.com/santhosh-tekuri/jsonschema/v5 v5.1.1 // indirect
@@ -221,6 +224,7 @@ require (
 	github.com/src-d/gcfg v1.4.0 // indirect
 	github.com/stretchr/objx v0.5.0 // indirect
 	github.com/subosito/gotenv v1.4.2 // indirect
+	github.com/tetratelabs/wazero v1.6.0 // indirect
 	github.com/tklauser/go-sysconf v0.3.10 // indirect
 	github.com/tklauser/numcpus v0.4.0 // indirect
 	github.com/xanzy/ssh-agent v0.2.1 // indirect
@@ -1,5 +1,11 @@
 package alerts
 
+import (
+	"encoding/json"
+
+	"go.skia.org/infra/go/skerr"
+)
+
 const (
 	// TOPIC is the PubSub topic for alert messages.
 	TOPIC = "promtheus-alerts"
@@ -16,3 +22,80 @@ const (
 	// Where the alert came from, e.g. 'skia-public' or 'skolo'.
 	LOCATION = "skia_location"
 )
+
+// Well known keys for the routing information of an alert. These are promoted
+// to the top level of a v2 Message and flattened back into the map returned by
+// ParseMessage.
+const (
+	SEVERITY    = "severity"
+	RUNBOOK_URL = "runbook_url"
+	TEAM        = "team"
+)
+
+// SCHEMA_VERSION_2 is the schema version of Message. Version 1 messages have
+// no schema version and are a flat JSON map[string]string.
+const SCHEMA_VERSION_2 = 2
+
+// Message is the v2 payload of a PubSub alert message.
+type Message struct {
+	// SchemaVersion is always SCHEMA_VERSION_2.
+	SchemaVersion int `json:"schema_version"`
+
+	// Severity of the alert, eg. "critical" or "warning".
+	Severity string `json:"severity,omitempty"`
+
+	// RunbookURL links to instructions for handling the alert.
+	RunbookURL string `json:"runbook_url,omitempty"`
+
+	// Team is the team the alert should be routed to.
+	Team string `json:"team,omitempty"`
+
+	// Params are all the labels and annotations of the alert, including the
+	// well known keys above.
+	Params map[string]string `json:"params"`
+}
+
+// NewMessage returns a v2 Message for the given labels and annotations of an
+// alert, taking the routing information from the well known keys.
+func NewMessage(m map[string]string) *Message {
+	return &Message{
+		SchemaVersion: SCHEMA_VERSION_2,
+		Severity:      m[SEVERITY],
+		RunbookURL:    m[RUNBOOK_URL],
+		Team:          m[TEAM],
+		Params:        m,
+	}
+}
+
+// ParseMessage parses the data of a PubSub alert message in either the v1 or
+// v2 format and returns the flattened labels and annotations of the alert. The
+// routing information of a v2 Message is stored under the well known keys.
+func ParseMessage(b []byte) (map[string]string, error) {
+	// A v1 message which happens to have a "schema_version" label will fail
+	// to decode here, since its value is a string, so fall through to v1.
+	var msg Message
+	if err := json.Unmarshal(b, &msg); err == nil && msg.SchemaVersion != 0 {
+		if msg.SchemaVersion != SCHEMA_VERSION_2 {
+			return nil, skerr.Fmt("unsupported alert schema version %d", msg.SchemaVersion)
+		}
+		m := make(map[string]string, len(msg.Params)+3)
+		for k, v := range msg.Params {
+			m[k] = v
+		}
+		for k, v := range map[string]string{
+			SEVERITY:    msg.Severity,
+			RUNBOOK_URL: msg.RunbookURL,
+			TEAM:        msg.Team,
+		} {
+			if v != "" {
+				m[k] = v
+			}
+		}
+		return m, nil
+	}
+	var m map[string]string
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, skerr.Wrapf(err, "failed to decode alert message")
+	}
+	return m, nil
+}
@@ -0,0 +1,68 @@
+package alerts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessage_V1_ReturnsFlatMap(t *testing.T) {
+	m, err := ParseMessage([]byte(`{"__name__": "ALERTS", "alertname": "BotMissing", "severity": "critical", "schema_version": "3"}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		TYPE:             TYPE_ALERTS,
+		"alertname":      "BotMissing",
+		SEVERITY:         "critical",
+		"schema_version": "3",
+	}, m)
+}
+
+func TestParseMessage_V2_RoutingFieldsAreFlattened(t *testing.T) {
+	b, err := json.Marshal(&Message{
+		SchemaVersion: SCHEMA_VERSION_2,
+		Severity:      "warning",
+		RunbookURL:    "https://example.com/runbook",
+		Team:          "infra",
+		Params: map[string]string{
+			TYPE:        TYPE_ALERTS,
+			"alertname": "BotMissing",
+			SEVERITY:    "critical",
+		},
+	})
+	require.NoError(t, err)
+	m, err := ParseMessage(b)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		TYPE:        TYPE_ALERTS,
+		"alertname": "BotMissing",
+		SEVERITY:    "warning",
+		RUNBOOK_URL: "https://example.com/runbook",
+		TEAM:        "infra",
+	}, m)
+}
+
+func TestParseMessage_NewMessage_RoundTrips(t *testing.T) {
+	params := map[string]string{
+		TYPE:        TYPE_ALERTS,
+		"alertname": "BotMissing",
+		SEVERITY:    "critical",
+		RUNBOOK_URL: "https://example.com/runbook",
+	}
+	b, err := json.Marshal(NewMessage(params))
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"schema_version":2`)
+	m, err := ParseMessage(b)
+	require.NoError(t, err)
+	require.Equal(t, params, m)
+}
+
+func TestParseMessage_UnknownVersion_ReturnsError(t *testing.T) {
+	_, err := ParseMessage([]byte(`{"schema_version": 3, "params": {}}`))
+	require.Error(t, err)
+}
+
+func TestParseMessage_InvalidJSON_ReturnsError(t *testing.T) {
+	_, err := ParseMessage([]byte(`not json`))
+	require.Error(t, err)
+}
@@ -6,8 +6,11 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/pubsub"
@@ -166,14 +169,20 @@ func (s *skoloTokenSource) Token() (*oauth2.Token, error) {
 type cachingTokenSource struct {
 	cacheFilePath string
 	tokenSource   oauth2.TokenSource
-	lastToken     *oauth2.Token
+
+	mutex     sync.Mutex
+	lastToken *oauth2.Token
 }
 
+// tokenFlow obtains a new token from the user, e.g. by having them authorize
+// the app in their browser.
+type tokenFlow func(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error)
+
 // newCachingTokenSource creates a new instance of CachingTokenSource that
 // caches the token in cacheFilePath. ctx and config are used to create and
-// retrieve the token in the first place.  If no token is available it will run
-// though the oauth flow for an installed app.
-func newCachingTokenSource(cacheFilePath string, ctx context.Context, config *oauth2.Config) (oauth2.TokenSource, error) {
+// retrieve the token in the first place. If no usable token is cached it will
+// run though the given flow.
+func newCachingTokenSource(cacheFilePath string, ctx context.Context, config *oauth2.Config, flow tokenFlow) (oauth2.TokenSource, error) {
 	var tok *oauth2.Token = nil
 	var err error
 
@@ -185,25 +194,22 @@ func newCachingTokenSource(cacheFilePath string, ctx context.Context, config *oa
 		if err != nil {
 			return nil, err
 		}
+		defer util.Close(f)
 		tok = &oauth2.Token{}
 		if err = json.NewDecoder(f).Decode(tok); err != nil {
 			return nil, err
 		}
+		// An expired token can't be used without a refresh token.
+		if !tok.Valid() && tok.RefreshToken == "" {
+			tok = nil
+		}
 	} else if !os.IsNotExist(err) {
 		return nil, err
 	}
 
 	// If there was no token, we run through the flow.
 	if tok == nil {
-		// Run through the flow.
-		url := config.AuthCodeURL("state", oauth2.AccessTypeOffline)
-		fmt.Printf("Your browser has been opened to visit:\n\n%s\n\nEnter the verification code:", url)
-
-		var code string
-		if _, err = fmt.Scan(&code); err != nil {
-			return nil, err
-		}
-		tok, err = config.Exchange(ctx, code)
+		tok, err = flow(ctx, config)
 		if err != nil {
 			return nil, err
 		}
@@ -223,6 +229,58 @@ func newCachingTokenSource(cacheFilePath string, ctx context.Context, config *oa
 	}, nil
 }
 
+// installedAppFlow implements tokenFlow by having the user visit a URL in
+// their browser and paste the resulting verification code.
+func installedAppFlow(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+	url := config.AuthCodeURL("state", oauth2.AccessTypeOffline)
+	fmt.Printf("Your browser has been opened to visit:\n\n%s\n\nEnter the verification code:", url)
+
+	var code string
+	if _, err := fmt.Scan(&code); err != nil {
+		return nil, err
+	}
+	return config.Exchange(ctx, code)
+}
+
+// deviceCodeFlow returns a tokenFlow which uses the OAuth 2.0 device
+// authorization grant, see https://www.rfc-editor.org/rfc/rfc8628. The user is
+// asked, via out, to enter a code at a URL on any device with a browser, while
+// we poll for the resulting token.
+func deviceCodeFlow(out io.Writer) tokenFlow {
+	return func(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+		resp, err := config.DeviceAuth(ctx)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "starting device authorization")
+		}
+		if _, err := fmt.Fprintf(out, "To authorize, visit:\n\n%s\n\non any device and enter the code:\n\n%s\n\n", resp.VerificationURI, resp.UserCode); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		tok, err := config.DeviceAccessToken(ctx, resp)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "waiting for device authorization")
+		}
+		return tok, nil
+	}
+}
+
+// NewDeviceCodeTokenSource creates an oauth2.TokenSource for command line
+// tools, which works on headless machines. If no usable token is cached in
+// cacheFilePath, the user is asked via out to visit a URL on any device with a
+// browser and enter a code there, i.e. the OAuth 2.0 device authorization flow
+// is used. The token, which includes a refresh token, is then cached in
+// cacheFilePath so that the user only needs to authorize the tool once. If
+// cacheFilePath is empty the token isn't cached.
+//
+// The config must be for an OAuth client of the "TVs and Limited Input devices"
+// type, and its Endpoint must have a DeviceAuthURL, e.g. google.Endpoint.
+func NewDeviceCodeTokenSource(ctx context.Context, config *oauth2.Config, cacheFilePath string, out io.Writer) (oauth2.TokenSource, error) {
+	ts, err := newCachingTokenSource(cacheFilePath, ctx, config, deviceCodeFlow(out))
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return ts, nil
+}
+
 // Token is part of implementing the oauth2.TokenSource interface.
 func (c *cachingTokenSource) Token() (*oauth2.Token, error) {
 	newToken, err := c.tokenSource.Token()
@@ -230,6 +288,8 @@ func (c *cachingTokenSource) Token() (*oauth2.Token, error) {
 		return nil, err
 	}
 
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	if newToken.AccessToken != c.lastToken.AccessToken {
 		// Write the token to file.
 		if err := saveToken(c.cacheFilePath, newToken); err != nil {
@@ -241,13 +301,18 @@ func (c *cachingTokenSource) Token() (*oauth2.Token, error) {
 	return newToken, nil
 }
 
+// saveToken writes the token to cacheFilePath, which is only readable by the
+// current user since the token includes the refresh token.
 func saveToken(cacheFilePath string, tok *oauth2.Token) error {
 	if cacheFilePath == "" {
 		return nil
 	}
 
 	if tok != nil {
-		f, err := os.Create(cacheFilePath)
+		if err := os.MkdirAll(filepath.Dir(cacheFilePath), 0700); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(cacheFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 		if err != nil {
 			return err
 		}
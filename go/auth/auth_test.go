@@ -1,10 +1,19 @@
 package auth
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
 
 	"github.com/stretchr/testify/assert"
 	"go.skia.org/infra/go/mockhttpclient"
@@ -48,3 +57,107 @@ func TestSkoloToken_InvalidToken_ReturnsError(t *testing.T) {
 	test("Missing expires_in", `{"access_token":"ya29.c.El...zwJOP","token_type":"Bearer"}`)
 	test("Missing access_token", `{"expires_in":900,"token_type":"Bearer"}`)
 }
+
+// newFakeDeviceAuthServer returns a server which implements the device
+// authorization and token endpoints of an OAuth 2.0 provider, and which
+// immediately grants a token for the device code. The returned counter tracks
+// the number of device authorization requests.
+func newFakeDeviceAuthServer(t *testing.T) (*httptest.Server, *int) {
+	numDeviceAuthRequests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device/code", func(w http.ResponseWriter, r *http.Request) {
+		numDeviceAuthRequests++
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "my-client-id", r.Form.Get("client_id"))
+		w.Header().Set("Content-Type", "application/json")
+		_, err := fmt.Fprint(w, `{"device_code":"my-device-code","user_code":"ABCD-EFGH","verification_url":"https://www.google.com/device","expires_in":60,"interval":1}`)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "my-device-code", r.Form.Get("device_code"))
+		w.Header().Set("Content-Type", "application/json")
+		_, err := fmt.Fprint(w, `{"access_token":"my-access-token","refresh_token":"my-refresh-token","token_type":"Bearer","expires_in":3600}`)
+		require.NoError(t, err)
+	})
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s, &numDeviceAuthRequests
+}
+
+func deviceAuthConfig(s *httptest.Server) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     "my-client-id",
+		ClientSecret: "my-client-secret",
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: s.URL + "/device/code",
+			TokenURL:      s.URL + "/token",
+			AuthStyle:     oauth2.AuthStyleInParams,
+		},
+		Scopes: []string{ScopeUserinfoEmail},
+	}
+}
+
+func TestNewDeviceCodeTokenSource_NoCachedToken_PromptsUserAndCachesToken(t *testing.T) {
+	s, numDeviceAuthRequests := newFakeDeviceAuthServer(t)
+	cacheFilePath := filepath.Join(t.TempDir(), "mytool", "token.json")
+	var out bytes.Buffer
+
+	ts, err := NewDeviceCodeTokenSource(context.Background(), deviceAuthConfig(s), cacheFilePath, &out)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *numDeviceAuthRequests)
+	assert.Contains(t, out.String(), "https://www.google.com/device")
+	assert.Contains(t, out.String(), "ABCD-EFGH")
+
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "my-access-token", tok.AccessToken)
+
+	fi, err := os.Stat(cacheFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	b, err := os.ReadFile(cacheFilePath)
+	require.NoError(t, err)
+	var cached oauth2.Token
+	require.NoError(t, json.Unmarshal(b, &cached))
+	assert.Equal(t, "my-access-token", cached.AccessToken)
+	assert.Equal(t, "my-refresh-token", cached.RefreshToken)
+}
+
+func TestNewDeviceCodeTokenSource_ValidCachedToken_DoesNotPromptUser(t *testing.T) {
+	s, numDeviceAuthRequests := newFakeDeviceAuthServer(t)
+	cacheFilePath := filepath.Join(t.TempDir(), "token.json")
+	require.NoError(t, saveToken(cacheFilePath, &oauth2.Token{
+		AccessToken:  "my-cached-access-token",
+		RefreshToken: "my-refresh-token",
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(time.Hour),
+	}))
+	var out bytes.Buffer
+
+	ts, err := NewDeviceCodeTokenSource(context.Background(), deviceAuthConfig(s), cacheFilePath, &out)
+	require.NoError(t, err)
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "my-cached-access-token", tok.AccessToken)
+	assert.Equal(t, 0, *numDeviceAuthRequests)
+	assert.Empty(t, out.String())
+}
+
+func TestNewDeviceCodeTokenSource_ExpiredCachedTokenWithoutRefreshToken_PromptsUser(t *testing.T) {
+	s, numDeviceAuthRequests := newFakeDeviceAuthServer(t)
+	cacheFilePath := filepath.Join(t.TempDir(), "token.json")
+	require.NoError(t, saveToken(cacheFilePath, &oauth2.Token{
+		AccessToken: "my-expired-access-token",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(-time.Hour),
+	}))
+	var out bytes.Buffer
+
+	ts, err := NewDeviceCodeTokenSource(context.Background(), deviceAuthConfig(s), cacheFilePath, &out)
+	require.NoError(t, err)
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "my-access-token", tok.AccessToken)
+	assert.Equal(t, 1, *numDeviceAuthRequests)
+}
@@ -43,6 +43,11 @@ const (
 	TRYBOT_RESULT_CANCELED = "CANCELED"
 	TRYBOT_RESULT_SUCCESS  = "SUCCESS"
 	TRYBOT_RESULT_FAILURE  = "FAILURE"
+
+	// Classes of roll failure, used to route notifications to the people
+	// who can fix them.
+	FAILURE_CLASS_INFRA = "infra"
+	FAILURE_CLASS_TEST  = "test"
 )
 
 var (
@@ -269,6 +274,30 @@ func (a *AutoRollIssue) Succeeded() bool {
 	return util.In(a.Result, SUCCESS_RESULTS)
 }
 
+// FailureClass returns FAILURE_CLASS_TEST if the roll failed and the most
+// recent result of at least one CQ trybot is a failure which was not an
+// infrastructure failure, FAILURE_CLASS_INFRA if the roll failed for any other
+// reason, eg. infrastructure failures of trybots or failure to land the CL,
+// and the empty string if the roll did not fail.
+func (a *AutoRollIssue) FailureClass() string {
+	if !a.Failed() {
+		return ""
+	}
+	// For each trybot, find the most recent result.
+	bots := map[string]*TryResult{}
+	for _, t := range a.TryResults {
+		if prev, ok := bots[t.Builder]; !ok || prev.Created.Before(t.Created) {
+			bots[t.Builder] = t
+		}
+	}
+	for _, t := range bots {
+		if t.Category == TRYBOT_CATEGORY_CQ && t.Failed() && !t.InfraFailure {
+			return FAILURE_CLASS_TEST
+		}
+	}
+	return FAILURE_CLASS_INFRA
+}
+
 // TryResult is a struct which contains trybot result details.
 type TryResult struct {
 	Builder  string    `json:"builder"`
@@ -277,6 +306,9 @@ type TryResult struct {
 	Result   string    `json:"result"`
 	Status   string    `json:"status"`
 	Url      string    `json:"url"`
+	// InfraFailure is true if the trybot failed because of an infrastructure
+	// problem rather than the change under test.
+	InfraFailure bool `json:"infra_failure,omitempty"`
 }
 
 // TryResultFromBuildbucket returns a new TryResult based on a buildbucketpb.Build.
@@ -304,6 +336,7 @@ func TryResultFromBuildbucket(b *buildbucketpb.Build) (*TryResult, error) {
 
 	status := TRYBOT_STATUS_SCHEDULED
 	result := ""
+	infraFailure := false
 	switch b.Status {
 	case buildbucketpb.Status_STARTED:
 		status = TRYBOT_STATUS_STARTED
@@ -316,6 +349,7 @@ func TryResultFromBuildbucket(b *buildbucketpb.Build) (*TryResult, error) {
 	case buildbucketpb.Status_INFRA_FAILURE:
 		status = TRYBOT_STATUS_COMPLETED
 		result = TRYBOT_RESULT_FAILURE
+		infraFailure = true
 	case buildbucketpb.Status_CANCELED:
 		status = TRYBOT_STATUS_COMPLETED
 		result = TRYBOT_RESULT_CANCELED
@@ -326,12 +360,13 @@ func TryResultFromBuildbucket(b *buildbucketpb.Build) (*TryResult, error) {
 	}
 	createTime = createTime.UTC()
 	return &TryResult{
-		Builder:  b.Builder.Builder,
-		Category: category,
-		Created:  createTime,
-		Result:   result,
-		Status:   status,
-		Url:      fmt.Sprintf(buildbucket.BUILD_URL_TMPL, buildbucket.DEFAULT_HOST, b.Id),
+		Builder:      b.Builder.Builder,
+		Category:     category,
+		Created:      createTime,
+		Result:       result,
+		Status:       status,
+		Url:          fmt.Sprintf(buildbucket.BUILD_URL_TMPL, buildbucket.DEFAULT_HOST, b.Id),
+		InfraFailure: infraFailure,
 	}, nil
 }
 
@@ -421,12 +456,13 @@ func (t TryResult) Succeeded() bool {
 // Copy returns a copy of the TryResult.
 func (t *TryResult) Copy() *TryResult {
 	return &TryResult{
-		Builder:  t.Builder,
-		Category: t.Category,
-		Created:  t.Created,
-		Result:   t.Result,
-		Status:   t.Status,
-		Url:      t.Url,
+		Builder:      t.Builder,
+		Category:     t.Category,
+		Created:      t.Created,
+		Result:       t.Result,
+		Status:       t.Status,
+		Url:          t.Url,
+		InfraFailure: t.InfraFailure,
 	}
 }
 
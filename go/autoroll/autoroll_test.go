@@ -44,12 +44,13 @@ func TestAutoRollIssueCopy(t *testing.T) {
 		Subject:         "Roll src/third_party/skia abc123..def456 (3 commits).",
 		TryResults: []*TryResult{
 			{
-				Builder:  "build",
-				Category: "cats",
-				Created:  time.Now(),
-				Result:   TRYBOT_RESULT_SUCCESS,
-				Status:   TRYBOT_STATUS_COMPLETED,
-				Url:      "http://build/cats",
+				Builder:      "build",
+				Category:     "cats",
+				Created:      time.Now(),
+				Result:       TRYBOT_RESULT_SUCCESS,
+				Status:       TRYBOT_STATUS_COMPLETED,
+				Url:          "http://build/cats",
+				InfraFailure: true,
 			},
 		},
 	}
@@ -207,6 +208,69 @@ func TestTrybotResults_SkCQ(t *testing.T) {
 	require.Equal(t, tryResult.Category, TRYBOT_CATEGORY_CQ)
 }
 
+func TestFailureClass(t *testing.T) {
+	now := time.Now()
+	roll := &AutoRollIssue{
+		Result: ROLL_RESULT_SUCCESS,
+		TryResults: []*TryResult{
+			{
+				Builder:      "infra-bot",
+				Category:     TRYBOT_CATEGORY_CQ,
+				Created:      now,
+				Result:       TRYBOT_RESULT_FAILURE,
+				Status:       TRYBOT_STATUS_COMPLETED,
+				InfraFailure: true,
+			},
+		},
+	}
+	require.Equal(t, "", roll.FailureClass())
+
+	// Only infra failures.
+	roll.Result = ROLL_RESULT_FAILURE
+	require.Equal(t, FAILURE_CLASS_INFRA, roll.FailureClass())
+
+	// No trybot failures, eg. the CL failed to land.
+	roll.TryResults = nil
+	require.Equal(t, FAILURE_CLASS_INFRA, roll.FailureClass())
+
+	// A test failure.
+	testFailure := &TryResult{
+		Builder:  "test-bot",
+		Category: TRYBOT_CATEGORY_CQ,
+		Created:  now,
+		Result:   TRYBOT_RESULT_FAILURE,
+		Status:   TRYBOT_STATUS_COMPLETED,
+	}
+	roll.TryResults = []*TryResult{testFailure}
+	require.Equal(t, FAILURE_CLASS_TEST, roll.FailureClass())
+
+	// Failures of non-CQ trybots are ignored.
+	testFailure.Category = ""
+	require.Equal(t, FAILURE_CLASS_INFRA, roll.FailureClass())
+	testFailure.Category = TRYBOT_CATEGORY_CQ
+
+	// A successful retry supersedes the failure.
+	roll.TryResults = append(roll.TryResults, &TryResult{
+		Builder:  "test-bot",
+		Category: TRYBOT_CATEGORY_CQ,
+		Created:  now.Add(time.Minute),
+		Result:   TRYBOT_RESULT_SUCCESS,
+		Status:   TRYBOT_STATUS_COMPLETED,
+	})
+	require.Equal(t, FAILURE_CLASS_INFRA, roll.FailureClass())
+}
+
+func TestTryResultFromBuildbucket_InfraFailure(t *testing.T) {
+	tryResult, err := TryResultFromBuildbucket(&buildbucketpb.Build{
+		Builder:    &buildbucketpb.BuilderID{Builder: "fake-builder"},
+		CreateTime: ts(time.Now().UTC()),
+		Status:     buildbucketpb.Status_INFRA_FAILURE,
+	})
+	require.NoError(t, err)
+	require.True(t, tryResult.Failed())
+	require.True(t, tryResult.InfraFailure)
+}
+
 func TestTryResultsFromGithubChecks(t *testing.T) {
 
 	// Create local vars since you cannot take address of a const.
@@ -6,6 +6,10 @@
 // do binary operators. We can do those via functions if needed, ala
 // add(x, y), sub(x, y), etc.
 //
+// Functions beyond the built-in ones can be added at runtime via
+// RegisterFunc, which also validates the arity and argument types of every
+// call to them before a formula is evaluated.
+//
 // Caveats:
 // * Only handles ASCII.
 //
@@ -218,12 +218,23 @@ func (AveFunc) Describe() string {
 
 var aveFunc = AveFunc{}
 
+// RatioFunc implements Func and divides traces point by point.
+//
+// If the first argument is a string then it is a query, every trace that
+// matches it is divided by the reference trace given by the second argument,
+// which may be either a query or a function. If the reference matches more
+// than one trace then they are averaged into a single reference trace.
+//
+// Otherwise both arguments are functions and the first row of each is used.
 type RatioFunc struct{}
 
 func (RatioFunc) Eval(ctx *Context, node *Node) (types.TraceSet, error) {
 	if len(node.Args) != 2 {
 		return nil, fmt.Errorf("ratio() takes two arguments")
 	}
+	if node.Args[0].Typ == NodeString {
+		return ratioAgainstReference(ctx, node)
+	}
 
 	rowsA, err := node.Args[0].Eval(ctx)
 	if err != nil {
@@ -255,9 +266,63 @@ func (RatioFunc) Eval(ctx *Context, node *Node) (types.TraceSet, error) {
 	return types.TraceSet{ctx.formula: ret}, nil
 }
 
+// ratioAgainstReference implements ratio(query, ref_query), where each trace
+// that matches query is divided by the reference trace.
+func ratioAgainstReference(ctx *Context, node *Node) (types.TraceSet, error) {
+	rows, err := ctx.RowsFromQuery(node.Args[0].Val)
+	if err != nil {
+		return nil, fmt.Errorf("ratio() query failed to evaluate: %s", err)
+	}
+
+	var refRows types.TraceSet
+	if node.Args[1].Typ == NodeString {
+		refRows, err = ctx.RowsFromQuery(node.Args[1].Val)
+	} else {
+		refRows, err = node.Args[1].Eval(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ratio() reference failed to evaluate: %s", err)
+	}
+	if len(refRows) == 0 {
+		return nil, fmt.Errorf("ratio() reference matched no traces")
+	}
+	ref := AveFuncImpl(refRows)
+
+	ret := types.TraceSet{}
+	for key, r := range rows {
+		ret["ratio("+key+")"] = RatioFuncImpl(r, ref)
+	}
+	return ret, nil
+}
+
+// RatioFuncImpl returns the trace a[i]/ref[i]. Points are aligned by index,
+// i.e. by commit offset. The result is vec32.MissingDataSentinel where either
+// value is missing, where ref doesn't have a value at that offset, or where
+// the ratio isn't a finite number.
+func RatioFuncImpl(a, ref types.Trace) types.Trace {
+	ret := vec32.New(len(a))
+	for i, v := range a {
+		if i >= len(ref) || v == vec32.MissingDataSentinel || ref[i] == vec32.MissingDataSentinel {
+			continue
+		}
+		r := v / ref[i]
+		if math.IsInf(float64(r), 0) || math.IsNaN(float64(r)) {
+			continue
+		}
+		ret[i] = r
+	}
+	return ret
+}
+
 func (RatioFunc) Describe() string {
 	return `ratio(a, b) returns the point by point ratio of two rows.
-                That is, it returns a trace with a[i]/b[i] for every point in a and b.`
+                That is, it returns a trace with a[i]/b[i] for every point in a and b.
+
+                ratio("query", "ref_query") divides every trace that matches query
+                by the reference trace that matches ref_query, point by point at
+                each commit. If ref_query matches more than one trace they are
+                averaged into a single reference trace. ref_query may also be a
+                function, such as ratio("config=8888", ave(filter("config=565"))).`
 }
 
 var ratioFunc = RatioFunc{}
@@ -25,6 +25,7 @@ const eof byte = 0xff
 type item struct {
 	typ itemType
 	val string
+	pos int // The offset of the item in the input.
 }
 
 // stateFn is a function that represents the current state of the lexer.
@@ -83,7 +84,7 @@ func (l *lexer) ignore() {
 // errorf returns an error token and terminates the scan by passing
 // back a nil pointer that will be the next state, terminating l.run.
 func (l *lexer) errorf(format string, args ...interface{}) stateFn {
-	l.items <- item{typ: itemError, val: fmt.Sprintf(format, args...)}
+	l.items <- item{typ: itemError, val: fmt.Sprintf(format, args...), pos: l.start}
 	return nil
 }
 
@@ -127,6 +128,7 @@ func (l *lexer) emit(t itemType) {
 	l.items <- item{
 		typ: t,
 		val: l.input[l.start:l.pos],
+		pos: l.start,
 	}
 	l.start = l.pos
 }
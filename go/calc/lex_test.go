@@ -12,51 +12,51 @@ func TestLex(t *testing.T) {
 		{
 			input: "foo()",
 			items: []item{
-				{itemIdentifier, "foo"},
-				{itemLParen, "("},
-				{itemRParen, ")"},
-				{itemEOF, ""},
+				{typ: itemIdentifier, val: "foo"},
+				{typ: itemLParen, val: "("},
+				{typ: itemRParen, val: ")"},
+				{typ: itemEOF, val: ""},
 			},
 		},
 		{
 			input: "foo(a, b) ",
 			items: []item{
-				{itemIdentifier, "foo"},
-				{itemLParen, "("},
-				{itemIdentifier, "a"},
-				{itemComma, ","},
-				{itemIdentifier, "b"},
-				{itemRParen, ")"},
-				{itemEOF, ""},
+				{typ: itemIdentifier, val: "foo"},
+				{typ: itemLParen, val: "("},
+				{typ: itemIdentifier, val: "a"},
+				{typ: itemComma, val: ","},
+				{typ: itemIdentifier, val: "b"},
+				{typ: itemRParen, val: ")"},
+				{typ: itemEOF, val: ""},
 			},
 		},
 		{
 			input: " foo( \"stuff goes here\")",
 			items: []item{
-				{itemIdentifier, "foo"},
-				{itemLParen, "("},
-				{itemString, "stuff goes here"},
-				{itemRParen, ")"},
-				{itemEOF, ""},
+				{typ: itemIdentifier, val: "foo"},
+				{typ: itemLParen, val: "("},
+				{typ: itemString, val: "stuff goes here"},
+				{typ: itemRParen, val: ")"},
+				{typ: itemEOF, val: ""},
 			},
 		},
 		{
 			input: " foo(bar(\"stuff goes here\", 1e-9,  baz()))",
 			items: []item{
-				{itemIdentifier, "foo"},
-				{itemLParen, "("},
-				{itemIdentifier, "bar"},
-				{itemLParen, "("},
-				{itemString, "stuff goes here"},
-				{itemComma, ","},
-				{itemNum, "1e-9"},
-				{itemComma, ","},
-				{itemIdentifier, "baz"},
-				{itemLParen, "("},
-				{itemRParen, ")"},
-				{itemRParen, ")"},
-				{itemRParen, ")"},
-				{itemEOF, ""},
+				{typ: itemIdentifier, val: "foo"},
+				{typ: itemLParen, val: "("},
+				{typ: itemIdentifier, val: "bar"},
+				{typ: itemLParen, val: "("},
+				{typ: itemString, val: "stuff goes here"},
+				{typ: itemComma, val: ","},
+				{typ: itemNum, val: "1e-9"},
+				{typ: itemComma, val: ","},
+				{typ: itemIdentifier, val: "baz"},
+				{typ: itemLParen, val: "("},
+				{typ: itemRParen, val: ")"},
+				{typ: itemRParen, val: ")"},
+				{typ: itemRParen, val: ")"},
+				{typ: itemEOF, val: ""},
 			},
 		},
 	}
@@ -2,6 +2,7 @@ package calc
 
 import (
 	"fmt"
+	"strings"
 
 	"go.skia.org/infra/go/vec32"
 	"go.skia.org/infra/perf/go/types"
@@ -41,14 +42,16 @@ type Node struct {
 	Typ  NodeType
 	Val  string
 	Args []*Node
+	Pos  int // The offset of the node in the formula.
 }
 
 // newNode creates a new Node of the given type and value.
-func newNode(val string, typ NodeType) *Node {
+func newNode(val string, typ NodeType, pos int) *Node {
 	return &Node{
 		Typ:  typ,
 		Val:  val,
 		Args: []*Node{},
+		Pos:  pos,
 	}
 }
 
@@ -64,6 +67,24 @@ func (n *Node) Eval(ctx *Context) (types.TraceSet, error) {
 	}
 }
 
+// String returns the formula for the subtree rooted at n in a canonical form,
+// i.e. without any of the whitespace that may have been in the original
+// formula.
+func (n *Node) String() string {
+	switch n.Typ {
+	case NodeString:
+		return `"` + n.Val + `"`
+	case NodeFunc:
+		args := make([]string, 0, len(n.Args))
+		for _, arg := range n.Args {
+			args = append(args, arg.String())
+		}
+		return n.Val + "(" + strings.Join(args, ",") + ")"
+	default:
+		return n.Val
+	}
+}
+
 // Func defines a type for functions that can be used in the parser.
 //
 // The traces returned will always have a Param of "id" that identifies
@@ -83,9 +104,10 @@ type Context struct {
 	formula          string // The current formula being evaluated.
 }
 
-// NewContext create a new parsing context that includes the basic functions.
+// NewContext create a new parsing context that includes the basic functions
+// and any functions added via RegisterFunc.
 func NewContext(rowsFromQuery RowsFromQuery, rowsFromShortcut RowsFromShortcut) *Context {
-	return &Context{
+	ret := &Context{
 		RowsFromQuery:    rowsFromQuery,
 		RowsFromShortcut: rowsFromShortcut,
 		Funcs: map[string]Func{
@@ -110,6 +132,10 @@ func NewContext(rowsFromQuery RowsFromQuery, rowsFromShortcut RowsFromShortcut)
 			"iqrr":         iqrrFunc,
 		},
 	}
+	for name, r := range registeredFuncs() {
+		ret.Funcs[name] = r.f
+	}
+	return ret
 }
 
 // Eval parses and evaluates the given string expression and returns the Traces, or
@@ -120,9 +146,24 @@ func (ctx *Context) Eval(exp string) (types.TraceSet, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Eval: failed to parse the expression: %s", err)
 	}
+	if err := ctx.validate(n); err != nil {
+		return nil, fmt.Errorf("Eval: invalid expression: %s", err)
+	}
 	return n.Eval(ctx)
 }
 
+// Normalize returns the given formula in a canonical form, so that formulas
+// which only differ in formatting, e.g. `ave( filter("a=b"))` and
+// `ave(filter("a=b"))`, compare equal. This makes the result suitable for use
+// as a cache key.
+func Normalize(exp string) (string, error) {
+	n, err := parse(exp)
+	if err != nil {
+		return "", fmt.Errorf("Normalize: failed to parse the expression: %s", err)
+	}
+	return n.String(), nil
+}
+
 // parse starts the parsing.
 func parse(input string) (*Node, error) {
 	l := newLexer(input)
@@ -139,7 +180,7 @@ func parseExp(l *lexer) (*Node, error) {
 	if it.typ != itemIdentifier {
 		return nil, fmt.Errorf("Expression: must begin with an identifier")
 	}
-	n := newNode(it.val, NodeFunc)
+	n := newNode(it.val, NodeFunc, it.pos)
 	it = l.nextItem()
 	if it.typ != itemLParen {
 		return nil, fmt.Errorf("Expression: didn't find '(' after an identifier.")
@@ -174,11 +215,11 @@ Loop:
 			p.Args = append(p.Args, next)
 		case itemString:
 			l.nextItem()
-			node := newNode(it.val, NodeString)
+			node := newNode(it.val, NodeString, it.pos)
 			p.Args = append(p.Args, node)
 		case itemNum:
 			l.nextItem()
-			node := newNode(it.val, NodeNum)
+			node := newNode(it.val, NodeNum, it.pos)
 			p.Args = append(p.Args, node)
 		case itemComma:
 			l.nextItem()
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.skia.org/infra/go/query"
 	"go.skia.org/infra/go/vec32"
 	"go.skia.org/infra/perf/go/types"
@@ -248,6 +249,57 @@ func TestRatio(t *testing.T) {
 	}
 }
 
+func TestRatio_QueryAndReferenceQuery_EachTraceIsDividedByReference(t *testing.T) {
+	ctx := newTestContext(types.TraceSet{
+		",config=8888,name=t1,": []float32{10, 4, e, 50, 9999},
+		",config=8888,name=t2,": []float32{20, 8, 6, e, 1},
+		",config=ref,name=t3,":  []float32{5, 2, 3, 5, 0},
+	}, nil)
+
+	rows, err := ctx.Eval(`ratio("config=8888", "config=ref")`)
+	require.NoError(t, err)
+	assert.Equal(t, types.TraceSet{
+		"ratio(,config=8888,name=t1,)": []float32{2, 2, e, 10, e},
+		"ratio(,config=8888,name=t2,)": []float32{4, 4, 2, e, e},
+	}, rows)
+}
+
+func TestRatio_ReferenceQueryMatchesMultipleTraces_ReferenceIsAveraged(t *testing.T) {
+	ctx := newTestContext(types.TraceSet{
+		",config=8888,name=t1,": []float32{12, 6},
+		",config=ref,name=t2,":  []float32{2, 2},
+		",config=ref,name=t3,":  []float32{4, e},
+	}, nil)
+
+	rows, err := ctx.Eval(`ratio("config=8888", "config=ref")`)
+	require.NoError(t, err)
+	assert.Equal(t, types.TraceSet{
+		"ratio(,config=8888,name=t1,)": []float32{4, 3},
+	}, rows)
+}
+
+func TestRatio_ReferenceIsAFunction_Success(t *testing.T) {
+	ctx := newTestContext(types.TraceSet{
+		",config=8888,name=t1,": []float32{12, 6},
+		",config=ref,name=t2,":  []float32{e, 3},
+	}, nil)
+
+	rows, err := ctx.Eval(`ratio("config=8888", fill(filter("config=ref")))`)
+	require.NoError(t, err)
+	assert.Equal(t, types.TraceSet{
+		"ratio(,config=8888,name=t1,)": []float32{4, 2},
+	}, rows)
+}
+
+func TestRatio_ReferenceQueryMatchesNothing_ReturnsError(t *testing.T) {
+	ctx := newTestContext(types.TraceSet{
+		",config=8888,name=t1,": []float32{12, 6},
+	}, nil)
+
+	_, err := ctx.Eval(`ratio("config=8888", "config=ref")`)
+	require.Error(t, err)
+}
+
 func TestFill(t *testing.T) {
 	ctx := newTestContext(types.TraceSet{
 		",name=t1,": []float32{e, e, 2, 3, e, 5},
@@ -343,3 +395,26 @@ func TestIQRR(t *testing.T) {
 	expected := types.Trace{e, e, e, 15, 19, 21, 21, 22, 22, 23, 23, 23, 23, 23, 24, 24, 24, 24, 25}
 	assert.Equal(t, expected, rows["iqrr(,name=t1,)"])
 }
+
+func TestNormalize_EquivalentFormulas_ReturnSameCanonicalForm(t *testing.T) {
+	for _, input := range []string{
+		`ave(fill(filter("config=8888&os=Ubuntu12")),2)`,
+		`ave( fill(filter("config=8888&os=Ubuntu12")), 2)`,
+		` ave(fill( filter( "config=8888&os=Ubuntu12" ) ) ,2 ) `,
+	} {
+		actual, err := Normalize(input)
+		assert.NoError(t, err)
+		assert.Equal(t, `ave(fill(filter("config=8888&os=Ubuntu12")),2)`, actual, input)
+	}
+}
+
+func TestNormalize_WhitespaceInsideStringsIsPreserved(t *testing.T) {
+	actual, err := Normalize(`shortcut( "a b" )`)
+	assert.NoError(t, err)
+	assert.Equal(t, `shortcut("a b")`, actual)
+}
+
+func TestNormalize_InvalidFormula_ReturnsError(t *testing.T) {
+	_, err := Normalize(`ave(`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package calc
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// validFuncName matches the identifiers the lexer will produce.
+var validFuncName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// registeredFunc is a Func added via RegisterFunc, along with the types of
+// the arguments it accepts.
+type registeredFunc struct {
+	args []NodeType
+	f    Func
+}
+
+var (
+	// registryMutex protects registry.
+	registryMutex sync.Mutex
+
+	// registry contains all the functions added via RegisterFunc, keyed by
+	// function name.
+	registry = map[string]registeredFunc{}
+)
+
+// RegisterFunc adds a user-defined function to every Context subsequently
+// created by NewContext. This allows instances to add their own functions,
+// e.g. geo_mean() or ratio_to_ref(), without modifying this package.
+//
+// The args are the types of the arguments the function takes, in order, which
+// also defines the arity of the function. Use NodeFunc for an argument that is
+// itself an expression returning traces, NodeNum for a number, and NodeString
+// for a string. Every call of the function in a formula is checked against
+// args before the formula is evaluated, so f.Eval can rely on the number and
+// types of node.Args.
+//
+// It is an error to register a name that is already in use, either by a
+// built-in function or by a previously registered one.
+func RegisterFunc(name string, args []NodeType, f Func) error {
+	if !validFuncName.MatchString(name) {
+		return fmt.Errorf("RegisterFunc: invalid function name %q", name)
+	}
+	if f == nil {
+		return fmt.Errorf("RegisterFunc: %s() has a nil Func", name)
+	}
+	for i, typ := range args {
+		if typ != NodeFunc && typ != NodeNum && typ != NodeString {
+			return fmt.Errorf("RegisterFunc: %s() argument %d has an invalid type: %d", name, i+1, typ)
+		}
+	}
+	if _, ok := NewContext(nil, nil).Funcs[name]; ok {
+		return fmt.Errorf("RegisterFunc: %s() is already defined", name)
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("RegisterFunc: %s() is already registered", name)
+	}
+	registry[name] = registeredFunc{
+		args: append([]NodeType{}, args...),
+		f:    f,
+	}
+	return nil
+}
+
+// unregisterFunc removes a function added via RegisterFunc. Only used in
+// tests.
+func unregisterFunc(name string) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	delete(registry, name)
+}
+
+// registeredFuncs returns a copy of all the functions added via RegisterFunc.
+func registeredFuncs() map[string]registeredFunc {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	ret := make(map[string]registeredFunc, len(registry))
+	for name, r := range registry {
+		ret[name] = r
+	}
+	return ret
+}
+
+// nodeTypeName returns a human readable name for the given NodeType.
+func nodeTypeName(typ NodeType) string {
+	switch typ {
+	case NodeFunc:
+		return "function"
+	case NodeNum:
+		return "number"
+	case NodeString:
+		return "string"
+	default:
+		return "unknown"
+	}
+}
+
+// validate walks the parse tree and checks that every function exists in the
+// Context, and that calls to functions added via RegisterFunc have the right
+// number and types of arguments. The returned errors include the offset in the
+// formula of the offending node.
+func (ctx *Context) validate(n *Node) error {
+	return ctx.validateNode(n, registeredFuncs())
+}
+
+// validateNode implements validate for a single node and its descendants.
+func (ctx *Context) validateNode(n *Node, registered map[string]registeredFunc) error {
+	if n.Typ != NodeFunc {
+		return nil
+	}
+	if _, ok := ctx.Funcs[n.Val]; !ok {
+		return fmt.Errorf("unknown function name %s() at position %d", n.Val, n.Pos)
+	}
+	if r, ok := registered[n.Val]; ok {
+		if len(n.Args) != len(r.args) {
+			return fmt.Errorf("%s() at position %d takes %d arguments, got %d", n.Val, n.Pos, len(r.args), len(n.Args))
+		}
+		for i, arg := range n.Args {
+			if arg.Typ != r.args[i] {
+				return fmt.Errorf("%s() at position %d takes a %s as argument %d, got a %s at position %d", n.Val, n.Pos, nodeTypeName(r.args[i]), i+1, nodeTypeName(arg.Typ), arg.Pos)
+			}
+		}
+	}
+	for _, arg := range n.Args {
+		if err := ctx.validateNode(arg, registered); err != nil {
+			return err
+		}
+	}
+	return nil
+}
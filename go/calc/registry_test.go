@@ -0,0 +1,107 @@
+package calc
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/perf/go/types"
+)
+
+// scaleFunc is a test Func that multiplies every value in the traces returned
+// by its first argument by the number in its second argument.
+type scaleFunc struct{}
+
+func (scaleFunc) Eval(ctx *Context, node *Node) (types.TraceSet, error) {
+	factor, err := strconv.ParseFloat(node.Args[1].Val, 32)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := node.Args[0].Eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := types.TraceSet{}
+	for key, r := range rows {
+		row := make(types.Trace, len(r))
+		for i, v := range r {
+			row[i] = v * float32(factor)
+		}
+		ret["scale("+key+")"] = row
+	}
+	return ret, nil
+}
+
+func (scaleFunc) Describe() string {
+	return `scale() multiplies the traces by a number.`
+}
+
+func registerScaleFunc(t *testing.T) {
+	require.NoError(t, RegisterFunc("scale", []NodeType{NodeFunc, NodeNum}, scaleFunc{}))
+	t.Cleanup(func() {
+		unregisterFunc("scale")
+	})
+}
+
+func TestRegisterFunc_HappyPath_FuncIsAvailableInNewContexts(t *testing.T) {
+	registerScaleFunc(t)
+	ctx := newTestContext(nil, nil)
+
+	_, ok := ctx.Funcs["scale"]
+	require.True(t, ok)
+
+	rows, err := ctx.Eval(`scale(filter("config=8888"), 2)`)
+	require.NoError(t, err)
+	assert.Equal(t, types.TraceSet{
+		"scale(,config=8888,os=Ubuntu12,)": []float32{e * 2, 1.234 * 2, e * 2},
+	}, rows)
+}
+
+func TestRegisterFunc_NameIsBuiltIn_ReturnsError(t *testing.T) {
+	require.Error(t, RegisterFunc("norm", []NodeType{NodeFunc}, scaleFunc{}))
+}
+
+func TestRegisterFunc_NameAlreadyRegistered_ReturnsError(t *testing.T) {
+	registerScaleFunc(t)
+	require.Error(t, RegisterFunc("scale", []NodeType{NodeFunc}, scaleFunc{}))
+}
+
+func TestRegisterFunc_InvalidName_ReturnsError(t *testing.T) {
+	require.Error(t, RegisterFunc("not-valid", []NodeType{NodeFunc}, scaleFunc{}))
+	require.Error(t, RegisterFunc("", []NodeType{NodeFunc}, scaleFunc{}))
+}
+
+func TestRegisterFunc_InvalidArgType_ReturnsError(t *testing.T) {
+	require.Error(t, RegisterFunc("scale", []NodeType{NodeError}, scaleFunc{}))
+}
+
+func TestRegisterFunc_NilFunc_ReturnsError(t *testing.T) {
+	require.Error(t, RegisterFunc("scale", []NodeType{NodeFunc}, nil))
+}
+
+func TestEval_RegisteredFuncWrongNumberOfArgs_ReturnsErrorWithPosition(t *testing.T) {
+	registerScaleFunc(t)
+	ctx := newTestContext(nil, nil)
+
+	_, err := ctx.Eval(`norm(scale(filter("config=8888")))`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "scale() at position 5 takes 2 arguments, got 1")
+}
+
+func TestEval_RegisteredFuncWrongArgType_ReturnsErrorWithPosition(t *testing.T) {
+	registerScaleFunc(t)
+	ctx := newTestContext(nil, nil)
+
+	_, err := ctx.Eval(`scale(filter("config=8888"), "2")`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "scale() at position 0 takes a number as argument 2, got a string at position 30")
+}
+
+func TestEval_UnknownFunc_ReturnsErrorWithPosition(t *testing.T) {
+	ctx := newTestContext(nil, nil)
+
+	_, err := ctx.Eval(`norm(nosuchfunc())`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown function name nosuchfunc() at position 5")
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -31,6 +32,11 @@ const (
 )
 
 var (
+	// ErrNotFound is returned (wrapped) by Client methods when the requested
+	// repository, manifest or blob does not exist. Use errors.Is to check for
+	// it.
+	ErrNotFound = errors.New("not found in the Docker registry")
+
 	dockerImageRegex = regexp.MustCompile(`^([0-9a-zA-Z_\.-]+)/([0-9a-zA-Z_\.\/-]+)(:([0-9a-zA-Z_\.-]+)|@(sha256:[0-9a-f]{64})|)$`)
 )
 
@@ -54,7 +60,8 @@ func SplitImage(image string) (registry, repository, tagOrDigest string, err err
 // Client is used for interacting with a Docker registry.
 type Client interface {
 	// GetManifest retrieves the manifest for the given image. The reference may
-	// be a tag or a digest.
+	// be a tag or a digest. Returns an error wrapping ErrNotFound if the image
+	// does not exist.
 	GetManifest(ctx context.Context, registry, repository, reference string) (*Manifest, error)
 	// GetConfig retrieves an image config based on the config.digest from its
 	// Manifest.
@@ -84,12 +91,31 @@ func NewClient(ctx context.Context) (*ClientImpl, error) {
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
-	httpClient := httputils.DefaultClientConfig().WithTokenSource(ts).With2xxAnd3xx().Client()
+	// Response codes are checked in do(), so that a 404 can be reported as
+	// ErrNotFound.
+	httpClient := httputils.DefaultClientConfig().WithTokenSource(ts).Client()
 	return &ClientImpl{
 		client: httpClient,
 	}, nil
 }
 
+// do sends the request and returns an error for any response which is not 2xx
+// or 3xx. A 404 results in an error wrapping ErrNotFound.
+func (c *ClientImpl) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		util.Close(resp.Body)
+		return nil, skerr.Wrapf(ErrNotFound, "HTTP %s request to %s", req.Method, req.URL)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 399 {
+		return nil, skerr.Fmt("Got error response status code %d from the HTTP %s request to %s\nResponse: %s", resp.StatusCode, req.Method, req.URL, httputils.ReadAndClose(resp.Body))
+	}
+	return resp, nil
+}
+
 type MediaConfig struct {
 	MediaType string `json:"mediaType"`
 	Size      int    `json:"size"`
@@ -113,7 +139,7 @@ func (c *ClientImpl) GetManifest(ctx context.Context, registry, repository, refe
 		return nil, skerr.Wrap(err)
 	}
 	req.Header.Set(acceptHeader, manifestContentType)
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
@@ -150,7 +176,7 @@ func (c *ClientImpl) paginate(ctx context.Context, url string, cb func(paginated
 		if header != "" {
 			req.Header.Set("Link", header)
 		}
-		resp, err := c.client.Do(req)
+		resp, err := c.do(req)
 		if err != nil {
 			return skerr.Wrap(err)
 		}
@@ -337,7 +363,7 @@ func (c *ClientImpl) GetConfig(ctx context.Context, registry, repository, config
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
@@ -364,7 +390,7 @@ func (c *ClientImpl) SetTag(ctx context.Context, registry, repository, reference
 			return skerr.Wrap(err)
 		}
 		req.Header.Set(acceptHeader, manifestContentType)
-		resp, err := c.client.Do(req)
+		resp, err := c.do(req)
 		if err != nil {
 			return skerr.Wrap(err)
 		}
@@ -385,9 +411,11 @@ func (c *ClientImpl) SetTag(ctx context.Context, registry, repository, reference
 		req.Header.Set(contentTypeHeader, manifestContentType)
 		req.Body = io.NopCloser(bytes.NewReader(manifestBytes))
 		req.Header.Set(acceptHeader, manifestContentType)
-		if _, err := c.client.Do(req); err != nil {
+		resp, err := c.do(req)
+		if err != nil {
 			return skerr.Wrap(err)
 		}
+		util.Close(resp.Body)
 	}
 	return nil
 }
@@ -3,6 +3,7 @@ package docker
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -257,6 +258,34 @@ func TestGetManifest(t *testing.T) {
 	}, manifest)
 }
 
+func TestGetManifest_NotFound_ReturnsErrNotFound(t *testing.T) {
+	ctx := context.Background()
+	urlmock := mockhttpclient.NewURLMock()
+	fakeURL := fmt.Sprintf(manifestURLTemplate, fakeRegistry, fakeRepository, fakeTag)
+	urlmock.MockOnce(fakeURL, mockhttpclient.MockGetError("Not Found", http.StatusNotFound))
+	client := &ClientImpl{
+		client: urlmock.Client(),
+	}
+
+	_, err := client.GetManifest(ctx, fakeRegistry, fakeRepository, fakeTag)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetManifest_ServerError_ReturnsOtherError(t *testing.T) {
+	ctx := context.Background()
+	urlmock := mockhttpclient.NewURLMock()
+	fakeURL := fmt.Sprintf(manifestURLTemplate, fakeRegistry, fakeRepository, fakeTag)
+	urlmock.MockOnce(fakeURL, mockhttpclient.MockGetError("Forbidden", http.StatusForbidden))
+	client := &ClientImpl{
+		client: urlmock.Client(),
+	}
+
+	_, err := client.GetManifest(ctx, fakeRegistry, fakeRepository, fakeTag)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrNotFound)
+	require.Contains(t, err.Error(), "status code 403")
+}
+
 func TestGetDigest(t *testing.T) {
 	ctx := context.Background()
 	md := mockhttpclient.MockGetDialogue([]byte(getManifestResponse))
@@ -0,0 +1,282 @@
+// Package backup periodically exports the entities in a Cloud Datastore
+// namespace to Google Cloud Storage using managed exports, and deletes exports
+// once they are older than a retention period.
+//
+// Applications that store data in Datastore, such as am and leasing, can run a
+// Scheduler in-process instead of relying on a bespoke backup job.
+//
+// Exports are written to:
+//
+//	gs://<bucket>/<namespace>/YYYY/MM/DD/HH-MM/
+//
+// See https://cloud.google.com/datastore/docs/export-import-entities for how
+// to restore from an export.
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/gcs/gcsclient"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	datastore "google.golang.org/api/datastore/v1"
+	"google.golang.org/api/option"
+)
+
+const (
+	// timestampLayout is the layout of the part of the GCS path of an export
+	// that records when the export was started.
+	timestampLayout = "2006/01/02/15-04"
+
+	// defaultPollPeriod is how often to check if a running export has
+	// finished.
+	defaultPollPeriod = time.Minute
+
+	// exportTimeout is the longest we wait for a single export to finish.
+	exportTimeout = 6 * time.Hour
+)
+
+// Exporter starts managed Datastore exports and reports on their progress.
+type Exporter interface {
+	// Export starts exporting the given kinds from the namespace to the GCS
+	// URL prefix, e.g. "gs://bucket/path", and returns the name of the long
+	// running operation doing the export.
+	Export(ctx context.Context, namespace string, kinds []ds.Kind, outputURLPrefix string) (string, error)
+
+	// Done returns true if the named operation has finished. An error is
+	// returned if the operation failed.
+	Done(ctx context.Context, operation string) (bool, error)
+}
+
+// adminExporter implements Exporter using the Datastore Admin API.
+type adminExporter struct {
+	service *datastore.Service
+	project string
+}
+
+// NewExporter returns an Exporter that uses the Datastore Admin API for the
+// given project.
+func NewExporter(ctx context.Context, project string, opts ...option.ClientOption) (Exporter, error) {
+	service, err := datastore.NewService(ctx, opts...)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to create Datastore Admin client.")
+	}
+	return &adminExporter{
+		service: service,
+		project: project,
+	}, nil
+}
+
+// Export implements Exporter.
+func (a *adminExporter) Export(ctx context.Context, namespace string, kinds []ds.Kind, outputURLPrefix string) (string, error) {
+	kindNames := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		kindNames = append(kindNames, string(kind))
+	}
+	op, err := a.service.Projects.Export(a.project, &datastore.GoogleDatastoreAdminV1ExportEntitiesRequest{
+		EntityFilter: &datastore.GoogleDatastoreAdminV1EntityFilter{
+			Kinds:        kindNames,
+			NamespaceIds: []string{namespace},
+		},
+		OutputUrlPrefix: outputURLPrefix,
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", skerr.Wrapf(err, "Failed to start export of %q.", namespace)
+	}
+	return op.Name, nil
+}
+
+// Done implements Exporter.
+func (a *adminExporter) Done(ctx context.Context, operation string) (bool, error) {
+	op, err := a.service.Projects.Operations.Get(operation).Context(ctx).Do()
+	if err != nil {
+		return false, skerr.Wrapf(err, "Failed to get status of %q.", operation)
+	}
+	if !op.Done {
+		return false, nil
+	}
+	if op.Error != nil {
+		return true, skerr.Fmt("Export %q failed: %d %s", operation, op.Error.Code, op.Error.Message)
+	}
+	return true, nil
+}
+
+// Scheduler exports a single Datastore namespace to GCS and deletes old
+// exports.
+type Scheduler struct {
+	exporter   Exporter
+	gcsClient  gcs.GCSClient
+	namespace  string
+	kinds      []ds.Kind
+	retention  time.Duration
+	pollPeriod time.Duration
+
+	// Metrics.
+	success      metrics2.Liveness
+	failures     metrics2.Counter
+	duration     metrics2.Float64Metric
+	deletedFiles metrics2.Counter
+}
+
+// New returns a new Scheduler that backs up all the kinds listed for the
+// namespace in ds.KindsToBackup into the bucket of the gcsClient, and deletes
+// backups once they are older than retention.
+func New(exporter Exporter, gcsClient gcs.GCSClient, namespace string, retention time.Duration) (*Scheduler, error) {
+	kinds, ok := ds.KindsToBackup[namespace]
+	if !ok || len(kinds) == 0 {
+		return nil, skerr.Fmt("No kinds to back up for namespace %q, they should be added to ds.KindsToBackup.", namespace)
+	}
+	if retention <= 0 {
+		return nil, skerr.Fmt("Retention must be positive, got %s.", retention)
+	}
+	tags := map[string]string{"namespace": namespace}
+	return &Scheduler{
+		exporter:     exporter,
+		gcsClient:    gcsClient,
+		namespace:    namespace,
+		kinds:        kinds,
+		retention:    retention,
+		pollPeriod:   defaultPollPeriod,
+		success:      metrics2.NewLiveness("ds_backup_success", tags),
+		failures:     metrics2.GetCounter("ds_backup_failures", tags),
+		duration:     metrics2.GetFloat64Metric("ds_backup_duration_s", tags),
+		deletedFiles: metrics2.GetCounter("ds_backup_deleted_files", tags),
+	}, nil
+}
+
+// Start runs a backup immediately, and then once every period, until the
+// context is cancelled. It does not block.
+func (s *Scheduler) Start(ctx context.Context, period time.Duration) {
+	go util.RepeatCtx(ctx, period, func(ctx context.Context) {
+		if err := s.Step(ctx); err != nil {
+			sklog.Errorf("Failed to back up namespace %q: %s", s.namespace, err)
+		}
+	})
+}
+
+// Step runs a single export of the namespace, waits for it to finish, and then
+// deletes all the exports older than the retention period.
+//
+// Old exports are only deleted if the new export succeeded, so there is always
+// at least one good export in the bucket.
+func (s *Scheduler) Step(ctx context.Context) error {
+	if err := s.export(ctx); err != nil {
+		s.failures.Inc(1)
+		return skerr.Wrap(err)
+	}
+	s.success.Reset()
+	if err := s.rotate(ctx); err != nil {
+		return skerr.Wrapf(err, "Failed to delete old backups.")
+	}
+	return nil
+}
+
+// export runs a single export and waits for it to complete.
+func (s *Scheduler) export(ctx context.Context) error {
+	start := now.Now(ctx)
+	outputURLPrefix := fmt.Sprintf("gs://%s/%s/%s", s.gcsClient.Bucket(), s.namespace, start.UTC().Format(timestampLayout))
+	sklog.Infof("Starting backup of %q to %s", s.namespace, outputURLPrefix)
+	operation, err := s.exporter.Export(ctx, s.namespace, s.kinds, outputURLPrefix)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+	ticker := time.NewTicker(s.pollPeriod)
+	defer ticker.Stop()
+	for {
+		done, err := s.exporter.Done(ctx, operation)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		if done {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return skerr.Wrapf(ctx.Err(), "Waiting for export %q to finish.", operation)
+		case <-ticker.C:
+		}
+	}
+	s.duration.Update(now.Now(ctx).Sub(start).Seconds())
+	sklog.Infof("Finished backup of %q to %s", s.namespace, outputURLPrefix)
+	return nil
+}
+
+// rotate deletes all the files in exports that are older than the retention
+// period.
+func (s *Scheduler) rotate(ctx context.Context) error {
+	prefix := s.namespace + "/"
+	cutoff := now.Now(ctx).Add(-s.retention)
+	toDelete := []string{}
+	err := s.gcsClient.AllFilesInDirectory(ctx, prefix, func(item *storage.ObjectAttrs) error {
+		ts, ok := exportTime(strings.TrimPrefix(item.Name, prefix))
+		if !ok {
+			// Leave files that we didn't write alone.
+			return nil
+		}
+		if ts.Before(cutoff) {
+			toDelete = append(toDelete, item.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return skerr.Wrapf(err, "Failed to list backups.")
+	}
+	for _, name := range toDelete {
+		if err := s.gcsClient.DeleteFile(ctx, name); err != nil {
+			return skerr.Wrapf(err, "Failed to delete %q.", name)
+		}
+		s.deletedFiles.Inc(1)
+	}
+	if len(toDelete) > 0 {
+		sklog.Infof("Deleted %d files from backups of %q older than %s", len(toDelete), s.namespace, cutoff)
+	}
+	return nil
+}
+
+// exportTime returns the time an export was started given the path of a file
+// in the export relative to the namespace directory, e.g.
+// "2023/01/02/15-04/2023-01-02T15:04:00_12345.overall_export_metadata".
+func exportTime(path string) (time.Time, bool) {
+	parts := strings.SplitN(path, "/", 5)
+	if len(parts) < 4 {
+		return time.Time{}, false
+	}
+	ts, err := time.Parse(timestampLayout, strings.Join(parts[:4], "/"))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return ts, true
+}
+
+// StartForNamespace starts a Scheduler that backs up the namespace in the
+// given project to the bucket once every period, keeping backups for the
+// retention period. The same options are used to create both the Datastore
+// and GCS clients.
+func StartForNamespace(ctx context.Context, project, namespace, bucket string, period, retention time.Duration, opts ...option.ClientOption) error {
+	exporter, err := NewExporter(ctx, project, opts...)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	storageClient, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return skerr.Wrapf(err, "Failed to create GCS client.")
+	}
+	s, err := New(exporter, gcsclient.New(storageClient, bucket), namespace, retention)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	s.Start(ctx, period)
+	return nil
+}
@@ -0,0 +1,135 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/gcs/mem_gcsclient"
+	"go.skia.org/infra/go/now"
+)
+
+const (
+	testBucket    = "my-backups"
+	testOperation = "projects/my-project/operations/1234"
+	testRetention = 7 * 24 * time.Hour
+)
+
+var testTime = time.Date(2023, time.March, 10, 12, 30, 0, 0, time.UTC)
+
+// fakeExporter is an Exporter that records the exports it is asked to start.
+type fakeExporter struct {
+	exportErr error
+	doneErr   error
+
+	// pendingPolls is the number of calls to Done that return false before
+	// the operation finishes.
+	pendingPolls int
+
+	exported []string
+	polls    int
+}
+
+func (f *fakeExporter) Export(ctx context.Context, namespace string, kinds []ds.Kind, outputURLPrefix string) (string, error) {
+	if f.exportErr != nil {
+		return "", f.exportErr
+	}
+	f.exported = append(f.exported, outputURLPrefix)
+	return testOperation, nil
+}
+
+func (f *fakeExporter) Done(ctx context.Context, operation string) (bool, error) {
+	f.polls++
+	if f.polls <= f.pendingPolls {
+		return false, nil
+	}
+	return true, f.doneErr
+}
+
+func setupForTest(t *testing.T, exporter *fakeExporter) (context.Context, *mem_gcsclient.MemoryGCSClient, *Scheduler) {
+	ctx := context.WithValue(context.Background(), now.ContextKey, testTime)
+	gcsClient := mem_gcsclient.New(testBucket)
+	s, err := New(exporter, gcsClient, ds.LEASING_SERVER_NS, testRetention)
+	require.NoError(t, err)
+	s.pollPeriod = time.Millisecond
+	return ctx, gcsClient, s
+}
+
+func addFile(t *testing.T, gcsClient gcs.GCSClient, path string) {
+	require.NoError(t, gcsClient.SetFileContents(context.Background(), path, gcs.FileWriteOptions{}, []byte("data")))
+}
+
+func TestNew_NamespaceWithoutKinds_ReturnsError(t *testing.T) {
+	_, err := New(&fakeExporter{}, mem_gcsclient.New(testBucket), "not-a-known-namespace", testRetention)
+	require.Error(t, err)
+}
+
+func TestNew_ZeroRetention_ReturnsError(t *testing.T) {
+	_, err := New(&fakeExporter{}, mem_gcsclient.New(testBucket), ds.LEASING_SERVER_NS, 0)
+	require.Error(t, err)
+}
+
+func TestStep_ExportSucceeds_ExportsToTimestampedPathAndDeletesOldBackups(t *testing.T) {
+	exporter := &fakeExporter{pendingPolls: 2}
+	ctx, gcsClient, s := setupForTest(t, exporter)
+	old := "leasing-server/2023/03/01/12-00/output-0"
+	recent := "leasing-server/2023/03/09/12-00/output-0"
+	notABackup := "leasing-server/README.md"
+	otherNamespace := "alert-manager/2023/03/01/12-00/output-0"
+	for _, path := range []string{old, recent, notABackup, otherNamespace} {
+		addFile(t, gcsClient, path)
+	}
+
+	require.NoError(t, s.Step(ctx))
+
+	assert.Equal(t, []string{"gs://my-backups/leasing-server/2023/03/10/12-30"}, exporter.exported)
+	assert.Equal(t, 3, exporter.polls)
+	for path, exists := range map[string]bool{
+		old:            false,
+		recent:         true,
+		notABackup:     true,
+		otherNamespace: true,
+	} {
+		found, err := gcsClient.DoesFileExist(ctx, path)
+		require.NoError(t, err)
+		assert.Equal(t, exists, found, path)
+	}
+}
+
+func TestStep_ExportFails_OldBackupsAreKept(t *testing.T) {
+	exporter := &fakeExporter{doneErr: errors.New("export failed")}
+	ctx, gcsClient, s := setupForTest(t, exporter)
+	old := "leasing-server/2023/03/01/12-00/output-0"
+	addFile(t, gcsClient, old)
+
+	require.Error(t, s.Step(ctx))
+
+	found, err := gcsClient.DoesFileExist(ctx, old)
+	require.NoError(t, err)
+	assert.True(t, found)
+}
+
+func TestStep_ExportCannotStart_ReturnsError(t *testing.T) {
+	exporter := &fakeExporter{exportErr: errors.New("quota exceeded")}
+	ctx, _, s := setupForTest(t, exporter)
+
+	require.Error(t, s.Step(ctx))
+	assert.Equal(t, 0, exporter.polls)
+}
+
+func TestExportTime_ValidAndInvalidPaths(t *testing.T) {
+	ts, ok := exportTime("2023/03/01/12-00/2023-03-01T12:00:00_1234.overall_export_metadata")
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC), ts)
+
+	_, ok = exportTime("2023/03/01")
+	assert.False(t, ok)
+
+	_, ok = exportTime("some/other/file/here")
+	assert.False(t, ok)
+}
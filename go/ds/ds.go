@@ -74,6 +74,7 @@ const (
 	SILENCE_AM                Kind = "SilenceAm"
 	REMINDER_AM               Kind = "ReminderAm"
 	AUDITLOG_AM               Kind = "AuditLogAm"
+	APITOKEN_AM               Kind = "ApiTokenAm"
 )
 
 // Namespaces that are used in production, and thus might be backed up.
@@ -108,7 +109,7 @@ var (
 		ANDROID_COMPILE_NS:   {COMPILE_TASK, ANDROID_COMPILE_INSTANCES},
 		LEASING_SERVER_NS:    {TASK},
 		CT_NS:                {CAPTURE_SKPS_TASKS, CHROMIUM_ANALYSIS_TASKS, CHROMIUM_BUILD_TASKS, CHROMIUM_PERF_TASKS, LUA_SCRIPT_TASKS, METRICS_ANALYSIS_TASKS, PIXEL_DIFF_TASKS, RECREATE_PAGESETS_TASKS, RECREATE_WEBPAGE_ARCHIVES_TASKS, CLUSTER_TELEMETRY_IDS},
-		ALERT_MANAGER_NS:     {INCIDENT_AM, INCIDENT_ACTIVE_PARENT_AM, SILENCE_AM, SILENCE_ACTIVE_PARENT_AM, REMINDER_AM, AUDITLOG_AM},
+		ALERT_MANAGER_NS:     {INCIDENT_AM, INCIDENT_ACTIVE_PARENT_AM, SILENCE_AM, SILENCE_ACTIVE_PARENT_AM, REMINDER_AM, AUDITLOG_AM, APITOKEN_AM},
 	}
 )
 
@@ -1,18 +1,27 @@
 package gcsclient
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 
 	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
 	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/api/iterator"
 )
 
 // TODO(dogben, kjlubick): This should really have some tests.
 
+// maxComposeSources is the maximum number of source objects in a single
+// compose request.
+const maxComposeSources = 32
+
 // StorageClient holds the information needed to talk to cloud storage
 // and fulfill the gcs.GCSClient interface
 type StorageClient struct {
@@ -40,15 +49,19 @@ func (g *StorageClient) FileReader(ctx context.Context, path string) (io.ReadClo
 // See the GCSClient interface for more information about FileWriter.
 func (g *StorageClient) FileWriter(ctx context.Context, path string, opts gcs.FileWriteOptions) io.WriteCloser {
 	w := g.client.Bucket(g.bucket).Object(path).NewWriter(ctx)
-	w.ObjectAttrs.ContentEncoding = opts.ContentEncoding
-	w.ObjectAttrs.ContentType = opts.ContentType
-	w.ObjectAttrs.ContentLanguage = opts.ContentLanguage
-	w.ObjectAttrs.ContentDisposition = opts.ContentDisposition
-	w.ObjectAttrs.Metadata = opts.Metadata
-
+	setWriteOptions(&w.ObjectAttrs, opts)
 	return w
 }
 
+// setWriteOptions applies the given FileWriteOptions to the ObjectAttrs.
+func setWriteOptions(attrs *storage.ObjectAttrs, opts gcs.FileWriteOptions) {
+	attrs.ContentEncoding = opts.ContentEncoding
+	attrs.ContentType = opts.ContentType
+	attrs.ContentLanguage = opts.ContentLanguage
+	attrs.ContentDisposition = opts.ContentDisposition
+	attrs.Metadata = opts.Metadata
+}
+
 // See the GCSClient interface for more information about DoesFileExist.
 func (g *StorageClient) DoesFileExist(ctx context.Context, path string) (bool, error) {
 	if _, err := g.client.Bucket(g.bucket).Object(path).Attrs(ctx); err != nil {
@@ -105,6 +118,129 @@ func (g *StorageClient) DeleteFile(ctx context.Context, path string) error {
 	return g.client.Bucket(g.bucket).Object(path).Delete(ctx)
 }
 
+// See the GCSClient interface for more information about UploadFile.
+func (g *StorageClient) UploadFile(ctx context.Context, path string, opts gcs.FileWriteOptions, uploadOpts gcs.UploadOptions, r io.Reader) error {
+	if uploadOpts.PartSize <= 0 {
+		return g.uploadResumable(ctx, path, opts, uploadOpts, r)
+	}
+	// Don't bother with a composite upload if the contents fit in one part.
+	first, err := readPart(r, uploadOpts.PartSize)
+	if err != nil {
+		return skerr.Wrapf(err, "failed to read contents of %s", path)
+	}
+	if int64(len(first)) < uploadOpts.PartSize {
+		return g.uploadResumable(ctx, path, opts, uploadOpts, bytes.NewReader(first))
+	}
+	return g.uploadComposite(ctx, path, opts, uploadOpts, first, r)
+}
+
+// uploadResumable performs a resumable upload of the contents of r to the
+// given path. Each chunk is retried on failure, even though overwriting an
+// object is not considered idempotent by the storage library.
+func (g *StorageClient) uploadResumable(ctx context.Context, path string, opts gcs.FileWriteOptions, uploadOpts gcs.UploadOptions, r io.Reader) error {
+	// Cancelling the context aborts the upload if we fail before Close.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	obj := g.client.Bucket(g.bucket).Object(path).Retryer(storage.WithPolicy(storage.RetryAlways))
+	w := obj.NewWriter(ctx)
+	setWriteOptions(&w.ObjectAttrs, opts)
+	w.ChunkSize = uploadOpts.ChunkSize
+	if w.ChunkSize <= 0 {
+		w.ChunkSize = gcs.DefaultUploadChunkSize
+	}
+	w.ChunkRetryDeadline = uploadOpts.ChunkRetryDeadline
+	if _, err := io.Copy(w, r); err != nil {
+		return skerr.Wrapf(err, "failed to upload %s", path)
+	}
+	return skerr.Wrapf(w.Close(), "failed to upload %s", path)
+}
+
+// uploadComposite uploads the given first part and the remaining contents of r
+// as parallel parts, then composes them into the given path. The temporary
+// objects are deleted afterward.
+func (g *StorageClient) uploadComposite(ctx context.Context, path string, opts gcs.FileWriteOptions, uploadOpts gcs.UploadOptions, first []byte, r io.Reader) error {
+	tmpPrefix := fmt.Sprintf("%s.upload-%s/", path, uuid.New().String())
+	var tmpObjects []string
+	defer func() {
+		for _, obj := range tmpObjects {
+			if err := g.DeleteFile(ctx, obj); err != nil && err != storage.ErrObjectNotExist {
+				sklog.Warningf("Failed to delete temporary object %s: %s", obj, err)
+			}
+		}
+	}()
+
+	parallelism := uploadOpts.Parallelism
+	if parallelism <= 0 {
+		parallelism = gcs.DefaultUploadParallelism
+	}
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(parallelism)
+	part := first
+	for len(part) > 0 && egCtx.Err() == nil {
+		name := fmt.Sprintf("%spart-%05d", tmpPrefix, len(tmpObjects))
+		tmpObjects = append(tmpObjects, name)
+		data := part
+		eg.Go(func() error {
+			return g.uploadResumable(egCtx, name, gcs.FileWriteOptions{}, uploadOpts, bytes.NewReader(data))
+		})
+		if int64(len(part)) < uploadOpts.PartSize {
+			break
+		}
+		var err error
+		part, err = readPart(r, uploadOpts.PartSize)
+		if err != nil {
+			_ = eg.Wait()
+			return skerr.Wrapf(err, "failed to read contents of %s", path)
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// A single compose request accepts a limited number of sources, so
+	// compose the parts in batches until few enough remain.
+	srcs := tmpObjects
+	for len(srcs) > maxComposeSources {
+		var next []string
+		if err := util.ChunkIter(len(srcs), maxComposeSources, func(startIdx, endIdx int) error {
+			name := fmt.Sprintf("%scompose-%05d", tmpPrefix, len(tmpObjects))
+			tmpObjects = append(tmpObjects, name)
+			next = append(next, name)
+			return g.compose(ctx, name, gcs.FileWriteOptions{}, srcs[startIdx:endIdx])
+		}); err != nil {
+			return err
+		}
+		srcs = next
+	}
+	return g.compose(ctx, path, opts, srcs)
+}
+
+// compose concatenates the given source objects into the destination object.
+func (g *StorageClient) compose(ctx context.Context, dst string, opts gcs.FileWriteOptions, srcs []string) error {
+	bucket := g.client.Bucket(g.bucket)
+	srcObjs := make([]*storage.ObjectHandle, 0, len(srcs))
+	for _, src := range srcs {
+		srcObjs = append(srcObjs, bucket.Object(src))
+	}
+	c := bucket.Object(dst).Retryer(storage.WithPolicy(storage.RetryAlways)).ComposerFrom(srcObjs...)
+	setWriteOptions(&c.ObjectAttrs, opts)
+	if _, err := c.Run(ctx); err != nil {
+		return skerr.Wrapf(err, "failed to compose %s", dst)
+	}
+	return nil
+}
+
+// readPart reads up to size bytes from r. It returns fewer bytes only when the
+// end of r is reached.
+func readPart(r io.Reader, size int64) ([]byte, error) {
+	buf := make([]byte, size)
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return buf[:n], err
+}
+
 // See the GCSClient interface for more information about Bucket.
 func (g *StorageClient) Bucket() string {
 	return g.bucket
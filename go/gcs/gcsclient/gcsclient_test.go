@@ -0,0 +1,21 @@
+package gcsclient
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPart(t *testing.T) {
+	r := bytes.NewReader([]byte("abcdefg"))
+	test := func(expect string) {
+		part, err := readPart(r, 3)
+		require.NoError(t, err)
+		require.Equal(t, expect, string(part))
+	}
+	test("abc")
+	test("def")
+	test("g")
+	test("")
+}
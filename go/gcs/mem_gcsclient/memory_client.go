@@ -114,6 +114,14 @@ func (c *MemoryGCSClient) SetFileContents(ctx context.Context, path string, opts
 	})
 }
 
+// See documentation for GCSClient interface. The upload options are ignored.
+func (c *MemoryGCSClient) UploadFile(ctx context.Context, path string, opts gcs.FileWriteOptions, _ gcs.UploadOptions, r io.Reader) error {
+	return gcs.WithWriteFile(c, ctx, path, opts, func(w io.Writer) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+}
+
 // See documentation for GCSClient interface.
 func (c *MemoryGCSClient) GetFileObjectAttrs(ctx context.Context, path string) (*storage.ObjectAttrs, error) {
 	c.mtx.RLock()
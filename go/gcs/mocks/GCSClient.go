@@ -228,6 +228,24 @@ func (_m *GCSClient) SetFileContents(ctx context.Context, path string, opts gcs.
 	return r0
 }
 
+// UploadFile provides a mock function with given fields: ctx, path, opts, uploadOpts, r
+func (_m *GCSClient) UploadFile(ctx context.Context, path string, opts gcs.FileWriteOptions, uploadOpts gcs.UploadOptions, r io.Reader) error {
+	ret := _m.Called(ctx, path, opts, uploadOpts, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, gcs.FileWriteOptions, gcs.UploadOptions, io.Reader) error); ok {
+		r0 = rf(ctx, path, opts, uploadOpts, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewGCSClient creates a new instance of GCSClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewGCSClient(t interface {
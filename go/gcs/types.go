@@ -3,6 +3,7 @@ package gcs
 import (
 	"context"
 	"io"
+	"time"
 
 	"cloud.google.com/go/storage"
 )
@@ -54,6 +55,13 @@ type GCSClient interface {
 	AllFilesInDirectory(ctx context.Context, prefix string, callback func(item *storage.ObjectAttrs) error) error
 	// DeleteFile deletes the given file, returning any error.
 	DeleteFile(ctx context.Context, path string) error
+	// UploadFile writes the contents of r to the GCS file at path, creating or
+	// overwriting it. Unlike FileWriter, the upload is resumable; each chunk is
+	// retried independently, which makes it suitable for large artifacts sent
+	// over unreliable connections. If uploadOpts.PartSize is set, large
+	// contents are uploaded as parallel parts which are then composed into
+	// the destination file.
+	UploadFile(ctx context.Context, path string, opts FileWriteOptions, uploadOpts UploadOptions, r io.Reader) error
 	// Bucket returns the bucket name of this client
 	Bucket() string
 }
@@ -68,5 +76,31 @@ type FileWriteOptions struct {
 	Metadata           map[string]string
 }
 
+// UploadOptions configures GCSClient.UploadFile.
+type UploadOptions struct {
+	// ChunkSize is the number of bytes sent in each request of a resumable
+	// upload; a failed request only needs to resend its own chunk. If zero,
+	// DefaultUploadChunkSize is used.
+	ChunkSize int
+	// ChunkRetryDeadline is how long a single chunk is retried before the
+	// upload fails. If zero, the storage library's default is used.
+	ChunkRetryDeadline time.Duration
+	// PartSize enables parallel composite uploads. If positive, contents
+	// larger than PartSize are split into parts of PartSize bytes, which are
+	// uploaded concurrently as temporary objects and then composed into the
+	// destination file. Note that composite objects have no MD5 hash.
+	PartSize int64
+	// Parallelism is the maximum number of parts uploaded concurrently. If
+	// zero, DefaultUploadParallelism is used.
+	Parallelism int
+}
+
+const (
+	// DefaultUploadChunkSize is the default UploadOptions.ChunkSize.
+	DefaultUploadChunkSize = 16 * 1024 * 1024
+	// DefaultUploadParallelism is the default UploadOptions.Parallelism.
+	DefaultUploadParallelism = 4
+)
+
 // FILE_WRITE_OPTS_TEXT are default options for writing a text file.
 var FILE_WRITE_OPTS_TEXT = FileWriteOptions{ContentType: "text/plain"}
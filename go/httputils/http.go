@@ -465,6 +465,21 @@ func (rp *responseProxy) WriteHeader(code int) {
 	}
 }
 
+// Flush implements http.Flusher, so that handlers which stream their responses,
+// eg. Server-Sent Events, keep working behind the logging middleware. It is a
+// no-op if the wrapped http.ResponseWriter doesn't support flushing.
+func (rp *responseProxy) Flush() {
+	if f, ok := rp.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped http.ResponseWriter, for use by
+// http.ResponseController.
+func (rp *responseProxy) Unwrap() http.ResponseWriter {
+	return rp.ResponseWriter
+}
+
 // recordResponse returns a wrapped http.Handler that records the status codes of the
 // responses.
 //
@@ -300,3 +300,26 @@ func TestCrossOriginResourcePolicy_Success(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Equal(t, "cross-origin", w.Header().Get("Cross-Origin-Resource-Policy"))
 }
+
+func TestLoggingRequestResponse_ResponseWriterSupportsFlush(t *testing.T) {
+	h := LoggingRequestResponse(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+		_, err := w.Write([]byte("data: hello\n\n"))
+		require.NoError(t, err)
+		flusher.Flush()
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.True(t, w.Flushed)
+	require.Equal(t, "data: hello\n\n", w.Body.String())
+}
+
+func TestLoggingGzipRequestResponse_ResponseWriterSupportsFlush(t *testing.T) {
+	h := LoggingGzipRequestResponse(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, http.NewResponseController(w).Flush())
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.True(t, w.Flushed)
+}
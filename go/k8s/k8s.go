@@ -22,6 +22,9 @@ type Client interface {
 	// ListNamespaces retrieves all namespaces in the cluster.
 	ListNamespaces(ctx context.Context, opts metav1.ListOptions) ([]corev1.Namespace, error)
 
+	// ListNodes retrieves all nodes in the cluster.
+	ListNodes(ctx context.Context, opts metav1.ListOptions) ([]corev1.Node, error)
+
 	// ListPods retrieves all pods in the namespace.
 	ListPods(ctx context.Context, namespace string, opts metav1.ListOptions) ([]corev1.Pod, error)
 
@@ -60,6 +63,15 @@ func (c *ClientImpl) ListNamespaces(ctx context.Context, opts metav1.ListOptions
 	return result.Items, nil
 }
 
+// ListNodes implements Client.
+func (c *ClientImpl) ListNodes(ctx context.Context, opts metav1.ListOptions) ([]corev1.Node, error) {
+	result, err := c.c.CoreV1().Nodes().List(ctx, opts)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return result.Items, nil
+}
+
 // GetPods implements Client.
 func (c *ClientImpl) ListPods(ctx context.Context, namespace string, opts metav1.ListOptions) ([]corev1.Pod, error) {
 	result, err := c.c.CoreV1().Pods(namespace).List(ctx, opts)
@@ -35,6 +35,29 @@ func (_m *Client) DeletePod(ctx context.Context, namespace string, name string,
 	return r0
 }
 
+// GetEvents provides a mock function with given fields: ctx, namespace
+func (_m *Client) GetEvents(ctx context.Context, namespace string) ([]corev1.Event, error) {
+	ret := _m.Called(ctx, namespace)
+
+	var r0 []corev1.Event
+	if rf, ok := ret.Get(0).(func(context.Context, string) []corev1.Event); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]corev1.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStatefulSet provides a mock function with given fields: ctx, namespace, name, opts
 func (_m *Client) GetStatefulSet(ctx context.Context, namespace string, name string, opts v1.GetOptions) (*appsv1.StatefulSet, error) {
 	ret := _m.Called(ctx, namespace, name, opts)
@@ -81,6 +104,29 @@ func (_m *Client) ListNamespaces(ctx context.Context, opts v1.ListOptions) ([]co
 	return r0, r1
 }
 
+// ListNodes provides a mock function with given fields: ctx, opts
+func (_m *Client) ListNodes(ctx context.Context, opts v1.ListOptions) ([]corev1.Node, error) {
+	ret := _m.Called(ctx, opts)
+
+	var r0 []corev1.Node
+	if rf, ok := ret.Get(0).(func(context.Context, v1.ListOptions) []corev1.Node); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]corev1.Node)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, v1.ListOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListPods provides a mock function with given fields: ctx, namespace, opts
 func (_m *Client) ListPods(ctx context.Context, namespace string, opts v1.ListOptions) ([]corev1.Pod, error) {
 	ret := _m.Called(ctx, namespace, opts)
@@ -46,6 +46,11 @@ type Config struct {
 
 	// If present, all messages inherit this subject line.
 	Subject string `json:"subject,omitempty"`
+
+	// If present, only messages with one of these FailureClasses are sent.
+	// Messages which do not describe a failure are not sent. This is applied
+	// in addition to Filter or IncludeMsgTypes.
+	IncludeFailureClasses []string `json:"includeFailureClasses,omitempty"`
 }
 
 // Validate the Config.
@@ -110,9 +115,10 @@ func (c *Config) Create(ctx context.Context, client *http.Client, emailer emailc
 // Create a copy of this Config.
 func (c *Config) Copy() *Config {
 	configCopy := &Config{
-		Filter:          c.Filter,
-		IncludeMsgTypes: util.CopyStringSlice(c.IncludeMsgTypes),
-		Subject:         c.Subject,
+		Filter:                c.Filter,
+		IncludeMsgTypes:       util.CopyStringSlice(c.IncludeMsgTypes),
+		Subject:               c.Subject,
+		IncludeFailureClasses: util.CopyStringSlice(c.IncludeFailureClasses),
 	}
 	if c.Email != nil {
 		configCopy.Email = &EmailNotifierConfig{
@@ -97,9 +97,10 @@ func TestConfigs(t *testing.T) {
 func TestConfigCopy(t *testing.T) {
 
 	c := &Config{
-		Filter:          "info",
-		IncludeMsgTypes: []string{"a", "b"},
-		Subject:         "blah blah",
+		Filter:                "info",
+		IncludeMsgTypes:       []string{"a", "b"},
+		Subject:               "blah blah",
+		IncludeFailureClasses: []string{"infra"},
 		Chat: &ChatNotifierConfig{
 			RoomID: "my-room",
 		},
@@ -28,6 +28,10 @@ type Message struct {
 	// ExtraRecipients who should also be sent this Message. Not supported for
 	// all types of notification.
 	ExtraRecipients []string
+	// FailureClass categorizes the cause of the failure which this Message
+	// describes, if any. This is used with the optional
+	// IncludeFailureClasses to route failures to the people who can fix them.
+	FailureClass string
 }
 
 // Validate the Message.
@@ -47,10 +51,11 @@ func (m *Message) Validate() error {
 // filteredThreadedNotifier groups a Notifier with a Filter and an optional
 // static subject line for all messages to this Notifier.
 type filteredThreadedNotifier struct {
-	includeMsgTypes     []string
-	notifier            Notifier
-	filter              Filter
-	singleThreadSubject string
+	includeFailureClasses []string
+	includeMsgTypes       []string
+	notifier              Notifier
+	filter                Filter
+	singleThreadSubject   string
 }
 
 // Router is a struct used for sending notification through zero or more
@@ -76,6 +81,10 @@ func (r *Router) Send(ctx context.Context, msg *Message) error {
 				subject = n.singleThreadSubject
 			}
 			msgLog := fmt.Sprintf("(%s; %s): %s\n\n%s", msg.Severity.String(), msg.Type, subject, msg.Body)
+			if n.includeFailureClasses != nil && !util.In(msg.FailureClass, n.includeFailureClasses) {
+				sklog.Debugf("Not sending notification (failure class %q not in %v): %s", msg.FailureClass, n.includeFailureClasses, msgLog)
+				return nil
+			}
 			if n.includeMsgTypes != nil {
 				if !util.In(msg.Type, n.includeMsgTypes) {
 					sklog.Debugf("Not sending notification (%s not in %v): %s", msg.Type, n.includeMsgTypes, msgLog)
@@ -123,7 +132,13 @@ func (r *Router) AddFromConfig(ctx context.Context, c *Config) error {
 	if err != nil {
 		return err
 	}
-	r.Add(n, f, wl, s)
+	r.notifiers = append(r.notifiers, &filteredThreadedNotifier{
+		includeFailureClasses: c.IncludeFailureClasses,
+		includeMsgTypes:       wl,
+		notifier:              n,
+		filter:                f,
+		singleThreadSubject:   s,
+	})
 	return nil
 }
 
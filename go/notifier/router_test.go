@@ -67,3 +67,31 @@ func TestRouter(t *testing.T) {
 	require.Equal(t, "My subject", n3.sent[0].subject)
 	require.Equal(t, "Second Message", n3.sent[0].msg.Body)
 }
+
+func TestRouter_IncludeFailureClasses(t *testing.T) {
+	m := NewRouter(nil, emailclient.New(), nil)
+	ctx := context.Background()
+
+	all := &testNotifier{}
+	m.Add(all, FILTER_DEBUG, nil, "")
+	infra := &testNotifier{}
+	m.Add(infra, FILTER_DEBUG, nil, "")
+	m.notifiers[len(m.notifiers)-1].includeFailureClasses = []string{"infra"}
+
+	send := func(failureClass string) {
+		require.NoError(t, m.Send(ctx, &Message{
+			Subject:      "Subject",
+			Body:         "Body",
+			Severity:     SEVERITY_ERROR,
+			Type:         "my-msg-type",
+			FailureClass: failureClass,
+		}))
+	}
+	send("infra")
+	send("test")
+	send("")
+
+	require.Len(t, all.sent, 3)
+	require.Len(t, infra.sent, 1)
+	require.Equal(t, "infra", infra.sent[0].msg.FailureClass)
+}
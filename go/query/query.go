@@ -269,6 +269,17 @@ func (q queryParam) Key() string {
 //
 //	q := New(url.Values{"arch": []string{"~^x"}})
 //
+// A regular expression can also be negated, i.e. this will match all keys that
+// have a parameter named 'model' whose value does not contain 'a' or 'b':
+//
+//	q := New(url.Values{"model": []string{"!~a|b"}})
+//
+// A parameter name that ends in '!' negates all of its values, which means
+// query strings can be written with '!=', e.g. the following are equivalent:
+//
+//	q := NewFromString("config!=565&config!=8888")
+//	q := NewFromString("config=!565&config=!8888")
+//
 // Here is more complex example that matches all tests that have the 'name'
 // parameter with a value of 'desk_nytimes.skp', a 'config' param that does not
 // equal '565' or '8888', and has an 'extra_config' parameter of any value.
@@ -304,6 +315,10 @@ func NewFromString(s string) (*Query, error) {
 // New creates a Query from the given url.Values. It represents a query to be
 // used against keys.
 func New(q url.Values) (*Query, error) {
+	q, err := foldNegatedKeys(q)
+	if err != nil {
+		return nil, err
+	}
 	keys := make([]string, 0, len(q))
 	for k := range q {
 		keys = append(keys, k)
@@ -319,33 +334,27 @@ func New(q url.Values) (*Query, error) {
 		values := q[key]
 		var reg *regexp.Regexp
 		var err error
-		// Is this param query a wildcard?
-		if len(q[key]) == 1 {
+		// Is this param query a negative match?
+		if len(values) >= 1 && strings.HasPrefix(values[0], "!") {
+			isNegative = true
+			values = make([]string, 0, len(q[key]))
+			for _, v := range q[key] {
+				values = append(values, strings.TrimPrefix(v, "!"))
+			}
+		}
+		// Is this param query a wildcard or a regex?
+		if len(values) == 1 {
 			if q[key][0] == "" {
 				return nil, fmt.Errorf("Invalid query")
 			}
 			if q[key][0] == "*" {
 				isWildCard = true
 			}
-			if q[key][0][:1] == "~" {
+			if strings.HasPrefix(values[0], "~") {
 				isRegex = true
-				reg, err = regexp.Compile(q[key][0][1:])
+				reg, err = regexp.Compile(values[0][1:])
 				if err != nil {
-					return nil, fmt.Errorf("Error compiling regexp %q: %s", q[key][0][1:], err)
-				}
-			}
-		}
-		// Is this param query a negative match?
-		if len(q[key]) >= 1 {
-			if strings.HasPrefix(q[key][0], "!") {
-				isNegative = true
-				values = []string{}
-				for _, v := range q[key] {
-					if strings.HasPrefix(v, "!") {
-						values = append(values, v[1:])
-					} else {
-						values = append(values, v)
-					}
+					return nil, fmt.Errorf("Error compiling regexp %q: %s", values[0][1:], err)
 				}
 			}
 		}
@@ -363,6 +372,46 @@ func New(q url.Values) (*Query, error) {
 	return &Query{params: params}, nil
 }
 
+// foldNegatedKeys returns a copy of q where each parameter name that ends in
+// '!', e.g. from parsing "config!=565", is replaced by the parameter name
+// without the '!' and with each of its values negated, i.e. "config=!565".
+//
+// It is an error to have both negated and non-negated values for the same
+// parameter name.
+func foldNegatedKeys(q url.Values) (url.Values, error) {
+	hasNegatedKey := false
+	for key := range q {
+		if strings.HasSuffix(key, "!") {
+			hasNegatedKey = true
+			break
+		}
+	}
+	if !hasNegatedKey {
+		return q, nil
+	}
+	ret := make(url.Values, len(q))
+	for key, values := range q {
+		if !strings.HasSuffix(key, "!") {
+			ret[key] = values
+		}
+	}
+	for key, values := range q {
+		if !strings.HasSuffix(key, "!") {
+			continue
+		}
+		key = strings.TrimSuffix(key, "!")
+		if _, ok := ret[key]; ok {
+			return nil, skerr.Fmt("Query has both %s= and %s!=", key, key)
+		}
+		negated := make([]string, 0, len(values))
+		for _, v := range values {
+			negated = append(negated, "!"+v)
+		}
+		ret[key] = negated
+	}
+	return ret, nil
+}
+
 // Empty returns true of the Query is empty, i.e. it will match any trace.
 func (q *Query) Empty() bool {
 	return len(q.params) == 0
@@ -390,7 +439,7 @@ func (q *Query) Matches(s string) bool {
 		valueIndex := strings.Index(s, ",")
 		value := s[:valueIndex]
 		if part.isRegex {
-			if !part.reg.MatchString(value) {
+			if part.reg.MatchString(value) == part.isNegative {
 				return false
 			}
 		} else if part.isNegative == util.In(value, part.values) {
@@ -480,7 +529,7 @@ func (q *Query) QueryPlan(ps paramtools.ReadOnlyParamSet) (paramtools.ParamSet,
 			ret[partKey] = append([]string{}, ps[partKey]...)
 		} else if part.isRegex {
 			err = appendValueForFilter(partKey, values, part, &ret, func(value string) bool {
-				return part.reg.MatchString(value)
+				return part.reg.MatchString(value) != part.isNegative
 			})
 		} else if part.isNegative {
 			err = appendValueForFilter(partKey, values, part, &ret, func(value string) bool {
@@ -255,6 +255,33 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, 0, len(q.params))
 }
 
+func TestNew_NegatedKey_ValuesAreNegated(t *testing.T) {
+	q, err := NewFromString("config!=565&config!=8888&debug=*")
+	require.NoError(t, err)
+	require.Len(t, q.params, 2)
+	assert.Equal(t, ",config=", q.params[0].keyMatch)
+	assert.Equal(t, []string{"565", "8888"}, q.params[0].values)
+	assert.True(t, q.params[0].isNegative)
+	assert.Equal(t, ",debug=", q.params[1].keyMatch)
+	assert.True(t, q.params[1].isWildCard)
+	assert.False(t, q.params[1].isNegative)
+}
+
+func TestNew_NegatedKeyWithRegex_IsNegatedRegex(t *testing.T) {
+	q, err := NewFromString("model!=~a|b")
+	require.NoError(t, err)
+	require.Len(t, q.params, 1)
+	assert.Equal(t, ",model=", q.params[0].keyMatch)
+	assert.True(t, q.params[0].isRegex)
+	assert.True(t, q.params[0].isNegative)
+	assert.Equal(t, "a|b", q.params[0].reg.String())
+}
+
+func TestNew_SameKeyNegatedAndNotNegated_ReturnsError(t *testing.T) {
+	_, err := NewFromString("config=565&config!=8888")
+	require.Error(t, err)
+}
+
 func TestMatches(t *testing.T) {
 	testCases := []struct {
 		key     string
@@ -388,6 +415,48 @@ func TestMatches(t *testing.T) {
 			matches: false,
 			reason:  "Negative, wildcard, and miss regexp",
 		},
+		{
+			key:     ",arch=x86,config=565,debug=true,",
+			query:   url.Values{"config": []string{"~^(8888|gpu)$"}},
+			matches: false,
+			reason:  "Regexp alternation miss",
+		},
+		{
+			key:     ",arch=x86,config=gpu,debug=true,",
+			query:   url.Values{"config": []string{"~^(8888|gpu)$"}},
+			matches: true,
+			reason:  "Regexp alternation match",
+		},
+		{
+			key:     ",arch=x86,config=565,debug=true,",
+			query:   url.Values{"config": []string{"!~^(8888|gpu)$"}},
+			matches: true,
+			reason:  "Negative regexp match",
+		},
+		{
+			key:     ",arch=x86,config=gpu,debug=true,",
+			query:   url.Values{"config": []string{"!~^(8888|gpu)$"}},
+			matches: false,
+			reason:  "Negative regexp miss",
+		},
+		{
+			key:     ",arch=x86,config=8888,debug=true,",
+			query:   url.Values{"config!": []string{"565"}},
+			matches: true,
+			reason:  "Negated key match",
+		},
+		{
+			key:     ",arch=x86,config=565,debug=true,",
+			query:   url.Values{"config!": []string{"565", "gpu"}},
+			matches: false,
+			reason:  "Negated key miss",
+		},
+		{
+			key:     ",arch=x86,debug=true,",
+			query:   url.Values{"config!": []string{"565"}},
+			matches: false,
+			reason:  "Negated key, missing param",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -740,6 +809,16 @@ func TestQueryPlan(t *testing.T) {
 			hasError: true,
 			reason:   "Negative, wildcard, and miss regexp",
 		},
+		{
+			query:  url.Values{"config": []string{"!~^(8888|gpu)$"}},
+			want:   paramtools.ParamSet{"config": []string{"565"}},
+			reason: "Negative regexp",
+		},
+		{
+			query:  url.Values{"config!": []string{"565"}, "arch": []string{"~^(x86|arm)$"}},
+			want:   paramtools.ParamSet{"arch": []string{"x86", "arm"}, "config": []string{"8888", "gpu"}},
+			reason: "Negated key and regexp alternation",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,72 @@
+// Package sse implements the server side of Server-Sent Events, which push a
+// stream of events to web clients over a single long-lived HTTP response.
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+)
+
+const (
+	// BufferSize is the number of events which should be buffered for each
+	// client. If a client falls further behind than this then events should be
+	// dropped rather than blocking the publisher.
+	BufferSize = 32
+
+	// keepAlivePeriod is how often a comment is sent to idle clients so that
+	// proxies don't close the connection.
+	keepAlivePeriod = 30 * time.Second
+)
+
+// Serve sends each event received from the channel to the client as JSON,
+// until the client disconnects. A 500 is returned to the client if the
+// http.ResponseWriter doesn't support streaming.
+//
+// The response must not be gzipped, as that would buffer the events.
+func Serve[T any](w http.ResponseWriter, r *http.Request, events <-chan T) {
+	serve(w, r, events, keepAlivePeriod)
+}
+
+// serve implements Serve with a configurable keep-alive period, for testing.
+func serve[T any](w http.ResponseWriter, r *http.Request, events <-chan T, keepAlive time.Duration) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		httputils.ReportError(w, skerr.Fmt("ResponseWriter does not implement http.Flusher"), "Streaming is not supported.", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event := <-events:
+			b, err := json.Marshal(event)
+			if err != nil {
+				sklog.Errorf("Failed to encode event: %s", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", b); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,62 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/httputils"
+)
+
+type testEvent struct {
+	Name string `json:"name"`
+}
+
+// readLine reads the next non-empty line from the stream.
+func readLine(t *testing.T, r *bufio.Reader) string {
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		if line != "\n" {
+			return line
+		}
+	}
+}
+
+func TestServe_BehindLoggingMiddleware_EventsAreStreamed(t *testing.T) {
+	events := make(chan testEvent, BufferSize)
+	h := httputils.LoggingRequestResponse(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serve(w, r, events, 10*time.Millisecond)
+	}))
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	r := bufio.NewReader(resp.Body)
+	require.Equal(t, ": keep-alive\n", readLine(t, r))
+	events <- testEvent{Name: "first"}
+	line := readLine(t, r)
+	for line == ": keep-alive\n" {
+		line = readLine(t, r)
+	}
+	require.Equal(t, "data: {\"name\":\"first\"}\n", line)
+}
+
+func TestServe_ResponseWriterDoesNotSupportFlush_ReturnsError(t *testing.T) {
+	w := httptest.NewRecorder()
+	Serve(struct{ http.ResponseWriter }{w}, httptest.NewRequest(http.MethodGet, "/", nil), make(chan testEvent))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
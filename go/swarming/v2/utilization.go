@@ -0,0 +1,358 @@
+package swarmingv2
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	apipb "go.chromium.org/luci/swarming/proto/api_v2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxTaskLookback is how long before the start of a utilization window we
+// look for tasks, so that tasks which started before the window but were
+// still running in it are counted.
+const maxTaskLookback = 4 * time.Hour
+
+// deadEventTypes are the bot events after which a bot is considered dead
+// until it next contacts the server.
+var deadEventTypes = map[string]bool{
+	"bot_deleted":   true,
+	"bot_missing":   true,
+	"bot_shutdown":  true,
+	"bot_terminate": true,
+}
+
+// BotUtilization is how a single bot spent its time in a window.
+type BotUtilization struct {
+	BotId string        `json:"bot_id"`
+	Busy  time.Duration `json:"busy"`
+	Idle  time.Duration `json:"idle"`
+	Dead  time.Duration `json:"dead"`
+}
+
+// PoolUtilization is how the bots in a pool spent their time in a window.
+type PoolUtilization struct {
+	Pool  string    `json:"pool"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// Busy, Idle and Dead are the totals over all of the Bots.
+	Busy time.Duration `json:"busy"`
+	Idle time.Duration `json:"idle"`
+	Dead time.Duration `json:"dead"`
+
+	Bots []*BotUtilization `json:"bots"`
+}
+
+// interval is a period of time in [start, end).
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// clip returns the interval clipped to [start, end), and false if nothing of
+// it remains.
+func (i interval) clip(start, end time.Time) (interval, bool) {
+	if i.start.Before(start) {
+		i.start = start
+	}
+	if i.end.After(end) {
+		i.end = end
+	}
+	return i, i.start.Before(i.end)
+}
+
+// mergeIntervals sorts the intervals and merges those that overlap.
+func mergeIntervals(intervals []interval) []interval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+	rv := []interval{}
+	for _, i := range intervals {
+		if len(rv) > 0 && !i.start.After(rv[len(rv)-1].end) {
+			if i.end.After(rv[len(rv)-1].end) {
+				rv[len(rv)-1].end = i.end
+			}
+			continue
+		}
+		rv = append(rv, i)
+	}
+	return rv
+}
+
+// totalDuration returns the total duration of the merged intervals.
+func totalDuration(intervals []interval) time.Duration {
+	var rv time.Duration
+	for _, i := range intervals {
+		rv += i.end.Sub(i.start)
+	}
+	return rv
+}
+
+// overlap returns the duration for which the two sets of merged intervals
+// overlap.
+func overlap(a, b []interval) time.Duration {
+	var rv time.Duration
+	for _, i := range a {
+		for _, j := range b {
+			if o, ok := i.clip(j.start, j.end); ok {
+				rv += o.end.Sub(o.start)
+			}
+		}
+	}
+	return rv
+}
+
+// busyIntervals returns the merged intervals in [start, end) during which the
+// tasks were running.
+func busyIntervals(tasks []*apipb.TaskResultResponse, start, end time.Time) []interval {
+	intervals := []interval{}
+	for _, task := range tasks {
+		if task.StartedTs == nil {
+			continue
+		}
+		taskEnd := end
+		if task.CompletedTs != nil {
+			taskEnd = task.CompletedTs.AsTime()
+		} else if task.AbandonedTs != nil {
+			taskEnd = task.AbandonedTs.AsTime()
+		} else if task.State != apipb.TaskState_RUNNING && task.ModifiedTs != nil {
+			taskEnd = task.ModifiedTs.AsTime()
+		}
+		if i, ok := (interval{start: task.StartedTs.AsTime(), end: taskEnd}).clip(start, end); ok {
+			intervals = append(intervals, i)
+		}
+	}
+	return mergeIntervals(intervals)
+}
+
+// deadIntervals returns the merged intervals in [start, end) during which the
+// bot was dead, according to its events. If there are no events in the window
+// then the bot's current state is used.
+func deadIntervals(bot *apipb.BotInfo, events []*apipb.BotEventResponse, start, end time.Time) []interval {
+	if len(events) == 0 {
+		if bot.IsDead && bot.LastSeenTs != nil {
+			if i, ok := (interval{start: bot.LastSeenTs.AsTime(), end: end}).clip(start, end); ok {
+				return []interval{i}
+			}
+		}
+		return []interval{}
+	}
+	sorted := make([]*apipb.BotEventResponse, len(events))
+	copy(sorted, events)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Ts.AsTime().Before(sorted[j].Ts.AsTime())
+	})
+	intervals := []interval{}
+	var deadSince *time.Time
+	for _, ev := range sorted {
+		ts := ev.Ts.AsTime()
+		if deadEventTypes[ev.EventType] {
+			if deadSince == nil {
+				deadSince = &ts
+			}
+		} else if deadSince != nil {
+			if i, ok := (interval{start: *deadSince, end: ts}).clip(start, end); ok {
+				intervals = append(intervals, i)
+			}
+			deadSince = nil
+		}
+	}
+	if deadSince != nil {
+		if i, ok := (interval{start: *deadSince, end: end}).clip(start, end); ok {
+			intervals = append(intervals, i)
+		}
+	}
+	return mergeIntervals(intervals)
+}
+
+// summarizeBot returns the BotUtilization for the bot in [start, end), given
+// its tasks and events. Time spent running a task is always counted as busy,
+// even if the bot was also considered dead.
+func summarizeBot(bot *apipb.BotInfo, tasks []*apipb.TaskResultResponse, events []*apipb.BotEventResponse, start, end time.Time) *BotUtilization {
+	busy := busyIntervals(tasks, start, end)
+	dead := deadIntervals(bot, events, start, end)
+	busyDur := totalDuration(busy)
+	deadDur := totalDuration(dead) - overlap(dead, busy)
+	idleDur := end.Sub(start) - busyDur - deadDur
+	if idleDur < 0 {
+		idleDur = 0
+	}
+	return &BotUtilization{
+		BotId: bot.BotId,
+		Busy:  busyDur,
+		Idle:  idleDur,
+		Dead:  deadDur,
+	}
+}
+
+// ListBotEventsHelper makes multiple paginated requests to ListBotEvents to
+// retrieve all results.
+func ListBotEventsHelper(ctx context.Context, c apipb.BotsClient, req *apipb.BotEventsRequest) ([]*apipb.BotEventResponse, error) {
+	req.Limit = 1000
+	req.Cursor = ""
+	rv := make([]*apipb.BotEventResponse, 0, req.Limit)
+	for {
+		resp, err := c.ListBotEvents(ctx, req)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		rv = append(rv, resp.Items...)
+		req.Cursor = resp.Cursor
+		if req.Cursor == "" {
+			break
+		}
+	}
+	return rv, nil
+}
+
+// ListBotTasksHelper makes multiple paginated requests to ListBotTasks to
+// retrieve all results.
+func ListBotTasksHelper(ctx context.Context, c apipb.BotsClient, req *apipb.BotTasksRequest) ([]*apipb.TaskResultResponse, error) {
+	req.Limit = 1000
+	req.Cursor = ""
+	rv := make([]*apipb.TaskResultResponse, 0, req.Limit)
+	for {
+		resp, err := c.ListBotTasks(ctx, req)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		rv = append(rv, resp.Items...)
+		req.Cursor = resp.Cursor
+		if req.Cursor == "" {
+			break
+		}
+	}
+	return rv, nil
+}
+
+// SummarizePoolUtilization returns how the bots in the given pool spent their
+// time in [start, end), based on the tasks they ran and their bot events.
+//
+// This makes several requests per bot, so prefer to use a UtilizationCache
+// where the same summary may be requested more than once.
+func SummarizePoolUtilization(ctx context.Context, c apipb.BotsClient, pool string, start, end time.Time) (*PoolUtilization, error) {
+	if !start.Before(end) {
+		return nil, skerr.Fmt("invalid window [%s, %s)", start, end)
+	}
+	bots, err := ListBotsForPool(ctx, c, pool)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "listing bots in pool %q", pool)
+	}
+	botUtils := make([]*BotUtilization, len(bots))
+	g := multierror.Group{}
+	for idx, bot := range bots {
+		idx := idx // https://golang.org/doc/faq#closures_and_goroutines
+		bot := bot
+		g.Go(func() error {
+			tasks, err := ListBotTasksHelper(ctx, c, &apipb.BotTasksRequest{
+				BotId: bot.BotId,
+				Start: timestamppb.New(start.Add(-maxTaskLookback)),
+				End:   timestamppb.New(end),
+				// Default is PENDING, which isn't what we want.
+				State: apipb.StateQuery_QUERY_ALL,
+				Sort:  apipb.SortQuery_QUERY_STARTED_TS,
+			})
+			if err != nil {
+				return skerr.Wrapf(err, "listing tasks for bot %q", bot.BotId)
+			}
+			events, err := ListBotEventsHelper(ctx, c, &apipb.BotEventsRequest{
+				BotId: bot.BotId,
+				Start: timestamppb.New(start),
+				End:   timestamppb.New(end),
+			})
+			if err != nil {
+				return skerr.Wrapf(err, "listing events for bot %q", bot.BotId)
+			}
+			botUtils[idx] = summarizeBot(bot, tasks, events, start, end)
+			return nil
+		})
+	}
+	if err := g.Wait().ErrorOrNil(); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	sort.Slice(botUtils, func(i, j int) bool {
+		return botUtils[i].BotId < botUtils[j].BotId
+	})
+	rv := &PoolUtilization{
+		Pool:  pool,
+		Start: start,
+		End:   end,
+		Bots:  botUtils,
+	}
+	for _, b := range botUtils {
+		rv.Busy += b.Busy
+		rv.Idle += b.Idle
+		rv.Dead += b.Dead
+	}
+	return rv, nil
+}
+
+// utilizationCacheKey identifies a cached PoolUtilization.
+type utilizationCacheKey struct {
+	pool   string
+	window time.Duration
+}
+
+// utilizationCacheEntry is a single cached PoolUtilization. The mutex is held
+// while the summary is computed, so that concurrent requests for the same
+// summary only compute it once.
+type utilizationCacheEntry struct {
+	mtx      sync.Mutex
+	value    *PoolUtilization
+	computed time.Time
+}
+
+// UtilizationCache computes PoolUtilizations for the most recent window of
+// time and caches them, so that the many requests needed to compute them are
+// shared by all users.
+type UtilizationCache struct {
+	client apipb.BotsClient
+	ttl    time.Duration
+
+	mtx     sync.Mutex
+	entries map[utilizationCacheKey]*utilizationCacheEntry
+}
+
+// NewUtilizationCache returns a UtilizationCache which recomputes summaries
+// once they are older than ttl.
+func NewUtilizationCache(c apipb.BotsClient, ttl time.Duration) *UtilizationCache {
+	return &UtilizationCache{
+		client:  c,
+		ttl:     ttl,
+		entries: map[utilizationCacheKey]*utilizationCacheEntry{},
+	}
+}
+
+// Get returns the PoolUtilization for the given pool over the window of time
+// which ends now. The returned summary may be up to the cache's ttl old and
+// must not be modified.
+func (u *UtilizationCache) Get(ctx context.Context, pool string, window time.Duration) (*PoolUtilization, error) {
+	key := utilizationCacheKey{pool: pool, window: window}
+	u.mtx.Lock()
+	entry, ok := u.entries[key]
+	if !ok {
+		entry = &utilizationCacheEntry{}
+		u.entries[key] = entry
+	}
+	u.mtx.Unlock()
+
+	entry.mtx.Lock()
+	defer entry.mtx.Unlock()
+	ts := now.Now(ctx)
+	if entry.value != nil && ts.Sub(entry.computed) < u.ttl {
+		return entry.value, nil
+	}
+	value, err := SummarizePoolUtilization(ctx, u.client, pool, ts.Add(-window), ts)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	entry.value = value
+	entry.computed = ts
+	return value, nil
+}
@@ -0,0 +1,115 @@
+package swarmingv2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apipb "go.chromium.org/luci/swarming/proto/api_v2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/swarming/v2/mocks"
+	"go.skia.org/infra/go/testutils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	utilStart = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	utilEnd   = utilStart.Add(10 * time.Hour)
+)
+
+func ts(hours float64) *timestamppb.Timestamp {
+	return timestamppb.New(utilStart.Add(time.Duration(hours * float64(time.Hour))))
+}
+
+func TestSummarizeBot_TasksAndEvents_Success(t *testing.T) {
+	bot := &apipb.BotInfo{BotId: "bot-a"}
+	tasks := []*apipb.TaskResultResponse{
+		// Started before the window, only the last hour counts.
+		{StartedTs: ts(-1), CompletedTs: ts(1), State: apipb.TaskState_COMPLETED},
+		// Overlaps the next task, so counts once.
+		{StartedTs: ts(2), CompletedTs: ts(4), State: apipb.TaskState_COMPLETED},
+		{StartedTs: ts(3), AbandonedTs: ts(5), State: apipb.TaskState_KILLED},
+		// Never started.
+		{State: apipb.TaskState_EXPIRED},
+		// Still running at the end of the window.
+		{StartedTs: ts(9), State: apipb.TaskState_RUNNING},
+	}
+	events := []*apipb.BotEventResponse{
+		{Ts: ts(6), EventType: "bot_missing"},
+		{Ts: ts(8), EventType: "bot_connected"},
+	}
+	require.Equal(t, &BotUtilization{
+		BotId: "bot-a",
+		Busy:  5 * time.Hour,
+		Dead:  2 * time.Hour,
+		Idle:  3 * time.Hour,
+	}, summarizeBot(bot, tasks, events, utilStart, utilEnd))
+}
+
+func TestSummarizeBot_DeadWhileRunningTask_CountedAsBusy(t *testing.T) {
+	bot := &apipb.BotInfo{BotId: "bot-a"}
+	tasks := []*apipb.TaskResultResponse{
+		{StartedTs: ts(0), CompletedTs: ts(2), State: apipb.TaskState_BOT_DIED},
+	}
+	events := []*apipb.BotEventResponse{
+		// Events are not guaranteed to be sorted.
+		{Ts: ts(4), EventType: "request_sleep"},
+		{Ts: ts(1), EventType: "bot_missing"},
+	}
+	require.Equal(t, &BotUtilization{
+		BotId: "bot-a",
+		Busy:  2 * time.Hour,
+		Dead:  2 * time.Hour,
+		Idle:  6 * time.Hour,
+	}, summarizeBot(bot, tasks, events, utilStart, utilEnd))
+}
+
+func TestSummarizeBot_NoEventsAndBotIsDead_DeadSinceLastSeen(t *testing.T) {
+	bot := &apipb.BotInfo{BotId: "bot-a", IsDead: true, LastSeenTs: ts(7)}
+	require.Equal(t, &BotUtilization{
+		BotId: "bot-a",
+		Dead:  3 * time.Hour,
+		Idle:  7 * time.Hour,
+	}, summarizeBot(bot, nil, nil, utilStart, utilEnd))
+}
+
+func TestUtilizationCache_Get_CachedUntilTTLExpires(t *testing.T) {
+	c := mocks.NewSwarmingV2Client(t)
+	c.On("ListBots", testutils.AnyContext, mock.Anything).Return(&apipb.BotInfoListResponse{
+		Items: []*apipb.BotInfo{{BotId: "bot-a"}},
+	}, nil).Times(2)
+	c.On("ListBotTasks", testutils.AnyContext, mock.Anything).Return(&apipb.TaskListResponse{
+		Items: []*apipb.TaskResultResponse{
+			{StartedTs: ts(8), CompletedTs: ts(9), State: apipb.TaskState_COMPLETED},
+		},
+	}, nil).Times(2)
+	c.On("ListBotEvents", testutils.AnyContext, mock.Anything).Return(&apipb.BotEventsResponse{}, nil).Times(2)
+
+	cache := NewUtilizationCache(c, time.Hour)
+	ctx := now.TimeTravelingContext(utilEnd)
+	util, err := cache.Get(ctx, "Skia", 10*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, &PoolUtilization{
+		Pool:  "Skia",
+		Start: utilStart,
+		End:   utilEnd,
+		Busy:  time.Hour,
+		Idle:  9 * time.Hour,
+		Bots: []*BotUtilization{
+			{BotId: "bot-a", Busy: time.Hour, Idle: 9 * time.Hour},
+		},
+	}, util)
+
+	// Served from the cache.
+	ctx.SetTime(utilEnd.Add(30 * time.Minute))
+	cached, err := cache.Get(ctx, "Skia", 10*time.Hour)
+	require.NoError(t, err)
+	require.Same(t, util, cached)
+
+	// The TTL has expired, so it is recomputed.
+	ctx.SetTime(utilEnd.Add(2 * time.Hour))
+	recomputed, err := cache.Get(ctx, "Skia", 10*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, utilEnd.Add(2*time.Hour), recomputed.End)
+}
@@ -281,6 +281,33 @@ func Fill(a []float32) {
 	}
 }
 
+// FillLinear fills in all the MissingDataSentinel values that lie between two
+// non-sentinel values by linear interpolation. For example:
+//
+//	[1, e, e, 4, e]
+//
+// becomes
+//
+//	[1, 2, 3, 4, e]
+//
+// Leading and trailing sentinels are left unchanged, since there is nothing to
+// interpolate between.
+func FillLinear(a []float32) {
+	prev := -1
+	for i, x := range a {
+		if x == MissingDataSentinel {
+			continue
+		}
+		if prev != -1 && i-prev > 1 {
+			step := (x - a[prev]) / float32(i-prev)
+			for j := prev + 1; j < i; j++ {
+				a[j] = a[prev] + step*float32(j-prev)
+			}
+		}
+		prev = i
+	}
+}
+
 // FillAt returns the value at the given index of a vector, using non-sentinel
 // values with nearby points if the original is MissingDataSentinenl.
 //
@@ -562,3 +589,26 @@ func Max(a []float32) float32 {
 	}
 	return ret
 }
+
+// Percentile returns the p-th percentile, where 0 <= p <= 100, of the non
+// MissingDataSentinel values in the vector, linearly interpolating between the
+// closest ranks. Returns MissingDataSentinel if no non-MissingDataSentinel
+// values are found.
+func Percentile(a []float32, p float32) float32 {
+	values := RemoveMissingDataSentinel(a)
+	if len(values) == 0 {
+		return MissingDataSentinel
+	}
+	sort.Sort(float32Slice(values))
+	if p <= 0 {
+		return values[0]
+	}
+	if p >= 100 {
+		return values[len(values)-1]
+	}
+	rank := float64(p) / 100 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	frac := float32(rank - float64(lower))
+	return values[lower] + (values[upper]-values[lower])*frac
+}
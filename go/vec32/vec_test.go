@@ -114,6 +114,34 @@ func TestFill(t *testing.T) {
 
 }
 
+func TestFillLinear(t *testing.T) {
+	testCases := []struct {
+		Slice    []float32
+		Expected []float32
+	}{
+		{
+			Slice:    []float32{1, e, e, 4, e},
+			Expected: []float32{1, 2, 3, 4, e},
+		},
+		{
+			Slice:    []float32{e, 2, e, 1, e, e},
+			Expected: []float32{e, 2, 1.5, 1, e, e},
+		},
+		{
+			Slice:    []float32{e, e},
+			Expected: []float32{e, e},
+		},
+		{
+			Slice:    []float32{},
+			Expected: []float32{},
+		},
+	}
+	for _, tc := range testCases {
+		FillLinear(tc.Slice)
+		assert.True(t, vecNear(tc.Expected, tc.Slice), "Got %v, want %v", tc.Slice, tc.Expected)
+	}
+}
+
 func TestFillAtErrors(t *testing.T) {
 	testCases := []struct {
 		Slice []float32
@@ -730,3 +758,13 @@ func TestMax(t *testing.T) {
 	assert.Equal(t, float32(2), Max([]float32{2}))
 	assert.Equal(t, float32(5), Max([]float32{5, e, 3}))
 }
+
+func TestPercentile(t *testing.T) {
+	assert.Equal(t, float32(e), Percentile([]float32{}, 50), "Empty returns MissingDataSentinel.")
+	assert.Equal(t, float32(e), Percentile([]float32{e}, 50), "MissingDataSentinels are ignored.")
+	assert.Equal(t, float32(3), Percentile([]float32{3}, 90))
+	assert.Equal(t, float32(3), Percentile([]float32{5, e, 1, 3}, 50), "Ignores MissingDataSentinels.")
+	assert.Equal(t, float32(2.5), Percentile([]float32{4, 1, 3, 2}, 50), "Interpolates between ranks.")
+	assert.Equal(t, float32(1), Percentile([]float32{4, 1, 3, 2}, 0))
+	assert.Equal(t, float32(4), Percentile([]float32{4, 1, 3, 2}, 100))
+}
@@ -717,6 +717,13 @@ def go_repositories():
         version = "v1.0.1",
     )
 
+    go_repository(
+        name = "com_github_gen2brain_avif",
+        importpath = "github.com/gen2brain/avif",
+        sum = "h1:aXaX5rtx13iDrqo2rCdvtUmI6vfd2ClL3lNsuKhbd8k=",
+        version = "v0.1.0",
+    )
+
     go_repository(
         name = "com_github_ghodss_yaml",
         importpath = "github.com/ghodss/yaml",
@@ -2515,6 +2522,13 @@ def go_repositories():
         version = "v0.0.0-20180830185346-98f6abe2eb07",
     )
 
+    go_repository(
+        name = "com_github_tetratelabs_wazero",
+        importpath = "github.com/tetratelabs/wazero",
+        sum = "h1:z0H1iikCdP8t+q341xqepY4EWvHEw8Es7tlqiVzlP3g=",
+        version = "v1.6.0",
+    )
+
     go_repository(
         name = "com_github_texttheater_golang_levenshtein",
         importpath = "github.com/texttheater/golang-levenshtein",
@@ -4024,8 +4038,8 @@ def go_repositories():
     go_repository(
         name = "org_golang_x_image",
         importpath = "golang.org/x/image",
-        sum = "h1:+qEpEAPhDZ1o0x3tHzZTQDArnOixOzGD9HUJfcg0mb4=",
-        version = "v0.0.0-20190802002840-cff245a6509b",
+        sum = "h1:kOELfmgrmJlw4Cdb7g/QGuB3CvDrXbqEIww/pNtNBm8=",
+        version = "v0.15.0",
     )
 
     go_repository(
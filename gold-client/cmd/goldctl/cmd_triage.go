@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"go.skia.org/infra/go/fileutil"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/gold-client/go/goldclient"
+	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/types"
+	"go.skia.org/infra/golden/go/validation"
+)
+
+// triageQueueFile is the name of the file in the work directory which holds the triage operations
+// that could not be sent to Gold yet.
+const triageQueueFile = "triage-queue.json"
+
+// triageEnv provides the environment for the triage command.
+type triageEnv struct {
+	workDir     string
+	instanceID  string
+	urlOverride string
+
+	testName string
+	digest   string
+	label    string
+}
+
+// queuedTriageOp is a triage operation which was not applied because Gold was unavailable.
+type queuedTriageOp struct {
+	TestName types.TestName     `json:"test_name"`
+	Digest   types.Digest       `json:"digest"`
+	Label    expectations.Label `json:"label"`
+}
+
+// getTriageCmd returns the definition of the triage command.
+func getTriageCmd() *cobra.Command {
+	env := &triageEnv{}
+	cmd := &cobra.Command{
+		Use:   "triage",
+		Short: "Triage a digest as positive, negative or untriaged.",
+		Long: `
+Applies a label to a digest of the given test by making a request to Gold's triage endpoint.
+
+If Gold cannot be reached, the operation is queued in the work directory and goldctl exits
+successfully. Queued operations are replayed, in order, the next time goldctl triage is run
+with the same work directory. To only replay queued operations, omit --test and --digest.`,
+		PreRunE: env.validate,
+		Run:     env.runTriageCmd,
+	}
+
+	cmd.Flags().StringVar(&env.workDir, fstrWorkDir, "", "Work directory for intermediate results")
+	cmd.Flags().StringVar(&env.instanceID, "instance", "", "ID of the Gold instance. If omitted, the instance and changelist from a previous 'goldctl imgtest init' in the work directory are used.")
+	cmd.Flags().StringVar(&env.urlOverride, "url", "", "URL of the Gold instance. If empty the URL will be derived from the value of 'instance'")
+	cmd.Flags().StringVar(&env.testName, "test", "", "Name of the test the digest belongs to.")
+	cmd.Flags().StringVar(&env.digest, "digest", "", "Digest to triage.")
+	cmd.Flags().StringVar(&env.label, "label", string(expectations.Positive), "Label to apply to the digest, one of positive, negative or untriaged.")
+	must(cmd.MarkFlagRequired(fstrWorkDir))
+
+	return cmd
+}
+
+// validate makes sure the flags describe either a single triage operation or none at all.
+func (t *triageEnv) validate(_ *cobra.Command, _ []string) error {
+	if (t.testName == "") != (t.digest == "") {
+		return skerr.Fmt("--test and --digest must be provided together")
+	}
+	if t.digest != "" && !validation.IsValidDigest(t.digest) {
+		return skerr.Fmt("invalid digest %q", t.digest)
+	}
+	if !expectations.ValidLabel(expectations.Label(t.label)) {
+		return skerr.Fmt("invalid label %q", t.label)
+	}
+	return nil
+}
+
+func (t *triageEnv) runTriageCmd(cmd *cobra.Command, _ []string) {
+	ctx := cmd.Context()
+	t.Triage(ctx)
+}
+
+// Triage replays any queued triage operations followed by the one given on the command line.
+// Operations which fail because Gold is unavailable are (re-)queued in the work directory.
+func (t *triageEnv) Triage(ctx context.Context) {
+	ctx = loadAuthenticatedClients(ctx, t.workDir)
+
+	goldClient, err := t.getGoldClient()
+	ifErrLogExit(ctx, err)
+
+	queuePath := filepath.Join(t.workDir, triageQueueFile)
+	ops, err := loadTriageQueue(queuePath)
+	ifErrLogExit(ctx, err)
+	if t.testName != "" {
+		ops = append(ops, queuedTriageOp{
+			TestName: types.TestName(t.testName),
+			Digest:   types.Digest(t.digest),
+			Label:    expectations.Label(t.label),
+		})
+	}
+
+	if flagDryRun {
+		for _, op := range ops {
+			logInfof(ctx, "Dryrun: would triage digest %s for test %s as %s\n", op.Digest, op.TestName, op.Label)
+		}
+		exitProcess(ctx, 0)
+	}
+
+	var remaining []queuedTriageOp
+	failed := false
+	for i, op := range ops {
+		err := goldClient.Triage(ctx, op.TestName, op.Digest, op.Label)
+		if err == nil {
+			logInfof(ctx, "Triaged digest %s for test %s as %s\n", op.Digest, op.TestName, op.Label)
+			continue
+		}
+		if goldclient.IsServerUnavailable(err) {
+			// Keep this and all following operations so they are applied in order later.
+			remaining = ops[i:]
+			logInfof(ctx, "Gold is unavailable, queued %d triage operation(s) in %s: %s\n", len(remaining), queuePath, err)
+			break
+		}
+		// The server rejected the operation, so retrying it would not help.
+		logErrf(ctx, "Could not triage digest %s for test %s as %s: %s\n", op.Digest, op.TestName, op.Label, err)
+		failed = true
+	}
+	ifErrLogExit(ctx, saveTriageQueue(queuePath, remaining))
+
+	if failed {
+		exitProcess(ctx, 1)
+	}
+	exitProcess(ctx, 0)
+}
+
+// getGoldClient returns a client for the instance given on the command line, or the one stored in
+// the work directory if no instance was given.
+func (t *triageEnv) getGoldClient() (*goldclient.CloudClient, error) {
+	if t.instanceID == "" {
+		return goldclient.LoadCloudClient(t.workDir)
+	}
+	return goldclient.NewCloudClient(goldclient.GoldClientConfig{
+		InstanceID:      t.instanceID,
+		WorkDir:         t.workDir,
+		OverrideGoldURL: t.urlOverride,
+	})
+}
+
+// loadTriageQueue returns the triage operations queued in the given file, if it exists.
+func loadTriageQueue(path string) ([]queuedTriageOp, error) {
+	if !fileutil.FileExists(path) {
+		return nil, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading triage queue %s", path)
+	}
+	var ops []queuedTriageOp
+	if err := json.Unmarshal(b, &ops); err != nil {
+		return nil, skerr.Wrapf(err, "parsing triage queue %s", path)
+	}
+	return ops, nil
+}
+
+// saveTriageQueue writes the given triage operations to the given file. If there are none, the
+// file is removed.
+func saveTriageQueue(path string, ops []queuedTriageOp) error {
+	if len(ops) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return skerr.Wrapf(err, "removing triage queue %s", path)
+		}
+		return nil
+	}
+	b, err := json.MarshalIndent(ops, "", "  ")
+	if err != nil {
+		return skerr.Wrapf(err, "encoding triage queue")
+	}
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return skerr.Wrapf(err, "writing triage queue %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/fileutil"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/gold-client/go/goldclient"
+	"go.skia.org/infra/gold-client/go/mocks"
+	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/types"
+	"go.skia.org/infra/golden/go/web/frontend"
+)
+
+const (
+	triageURL     = "https://my-test-instance-gold.skia.org/json/v2/triage"
+	triageDigestA = types.Digest("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	triageDigestB = types.Digest("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+)
+
+func TestTriage_ServerAvailable_DigestTriaged(t *testing.T) {
+	workDir := t.TempDir()
+	setupAuthWithGSUtil(t, workDir)
+
+	mh := &mocks.HTTPClient{}
+	var requests []frontend.TriageRequestV2
+	mh.On("Post", testutils.AnyContext, triageURL, "application/json", mock.Anything).Return(recordTriageRequest(t, &requests, http.StatusOK, nil))
+
+	output, exit := runTriage(t, mh, workDir, "MyTest", triageDigestA, expectations.Negative)
+	exit.AssertWasCalledWithCode(t, 0, output)
+	assert.Contains(t, output, "Triaged digest aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa for test MyTest as negative")
+	assert.Equal(t, []frontend.TriageRequestV2{
+		triageRequest("MyTest", triageDigestA, expectations.Negative),
+	}, requests)
+	assert.False(t, fileutil.FileExists(filepath.Join(workDir, triageQueueFile)))
+}
+
+func TestTriage_ServerUnavailable_QueuedAndReplayedInOrder(t *testing.T) {
+	workDir := t.TempDir()
+	setupAuthWithGSUtil(t, workDir)
+
+	// The server is down, so the operation is queued and the command succeeds.
+	mh := &mocks.HTTPClient{}
+	var requests []frontend.TriageRequestV2
+	mh.On("Post", testutils.AnyContext, triageURL, "application/json", mock.Anything).Return(recordTriageRequest(t, &requests, 0, errors.New("connection refused")))
+
+	output, exit := runTriage(t, mh, workDir, "MyTest", triageDigestA, expectations.Positive)
+	exit.AssertWasCalledWithCode(t, 0, output)
+	assert.Contains(t, output, "Gold is unavailable, queued 1 triage operation(s)")
+	queue, err := loadTriageQueue(filepath.Join(workDir, triageQueueFile))
+	require.NoError(t, err)
+	assert.Equal(t, []queuedTriageOp{
+		{TestName: "MyTest", Digest: triageDigestA, Label: expectations.Positive},
+	}, queue)
+
+	// The server is still failing, so both operations are queued.
+	mh = &mocks.HTTPClient{}
+	requests = nil
+	mh.On("Post", testutils.AnyContext, triageURL, "application/json", mock.Anything).Return(recordTriageRequest(t, &requests, http.StatusServiceUnavailable, nil))
+
+	output, exit = runTriage(t, mh, workDir, "OtherTest", triageDigestB, expectations.Negative)
+	exit.AssertWasCalledWithCode(t, 0, output)
+	assert.Contains(t, output, "Gold is unavailable, queued 2 triage operation(s)")
+	// Nothing after the first failure should be attempted.
+	assert.Len(t, requests, 1)
+	queue, err = loadTriageQueue(filepath.Join(workDir, triageQueueFile))
+	require.NoError(t, err)
+	assert.Equal(t, []queuedTriageOp{
+		{TestName: "MyTest", Digest: triageDigestA, Label: expectations.Positive},
+		{TestName: "OtherTest", Digest: triageDigestB, Label: expectations.Negative},
+	}, queue)
+
+	// The server is back, so the queued operations are replayed without adding a new one.
+	mh = &mocks.HTTPClient{}
+	requests = nil
+	mh.On("Post", testutils.AnyContext, triageURL, "application/json", mock.Anything).Return(recordTriageRequest(t, &requests, http.StatusOK, nil))
+
+	output, exit = runTriage(t, mh, workDir, "", "", expectations.Positive)
+	exit.AssertWasCalledWithCode(t, 0, output)
+	assert.Equal(t, []frontend.TriageRequestV2{
+		triageRequest("MyTest", triageDigestA, expectations.Positive),
+		triageRequest("OtherTest", triageDigestB, expectations.Negative),
+	}, requests)
+	assert.False(t, fileutil.FileExists(filepath.Join(workDir, triageQueueFile)))
+}
+
+func TestTriage_ServerRejectsRequest_NotQueuedAndExitsWithError(t *testing.T) {
+	workDir := t.TempDir()
+	setupAuthWithGSUtil(t, workDir)
+
+	mh := &mocks.HTTPClient{}
+	var requests []frontend.TriageRequestV2
+	mh.On("Post", testutils.AnyContext, triageURL, "application/json", mock.Anything).Return(recordTriageRequest(t, &requests, http.StatusForbidden, nil))
+
+	output, exit := runTriage(t, mh, workDir, "MyTest", triageDigestA, expectations.Positive)
+	exit.AssertWasCalledWithCode(t, 1, output)
+	assert.Contains(t, output, "Could not triage digest aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa for test MyTest as positive")
+	assert.Len(t, requests, 1)
+	assert.False(t, fileutil.FileExists(filepath.Join(workDir, triageQueueFile)))
+}
+
+func TestTriageValidate_InvalidFlags_ReturnsError(t *testing.T) {
+	test := func(name string, env triageEnv, errSubstring string) {
+		t.Run(name, func(t *testing.T) {
+			err := env.validate(nil, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), errSubstring)
+		})
+	}
+	test("missing digest", triageEnv{testName: "MyTest", label: "positive"}, "must be provided together")
+	test("missing test", triageEnv{digest: string(triageDigestA), label: "positive"}, "must be provided together")
+	test("invalid digest", triageEnv{testName: "MyTest", digest: "not-a-digest", label: "positive"}, "invalid digest")
+	test("invalid label", triageEnv{testName: "MyTest", digest: string(triageDigestA), label: "fantastic"}, "invalid label")
+
+	assert.NoError(t, (&triageEnv{label: "positive"}).validate(nil, nil))
+}
+
+// runTriage runs the triage command against the test instance using the given HTTP client and
+// returns its output.
+func runTriage(t *testing.T, mh *mocks.HTTPClient, workDir string, testName string, digest types.Digest, label expectations.Label) (string, *exitCodeRecorder) {
+	env := triageEnv{
+		workDir:    workDir,
+		instanceID: "my-test-instance",
+		testName:   testName,
+		digest:     string(digest),
+		label:      string(label),
+	}
+	require.NoError(t, env.validate(nil, nil))
+
+	output := bytes.Buffer{}
+	exit := &exitCodeRecorder{}
+	ctx := executionContext(context.Background(), &output, &output, exit.ExitWithCode)
+	ctx = goldclient.WithContext(ctx, nil, mh, nil)
+
+	runUntilExit(t, func() {
+		env.Triage(ctx)
+	})
+	return output.String(), exit
+}
+
+// recordTriageRequest returns a function for mocks.HTTPClient's Post method which appends the
+// request body to requests and responds with the given status code or error.
+func recordTriageRequest(t *testing.T, requests *[]frontend.TriageRequestV2, statusCode int, err error) func(context.Context, string, string, io.Reader) (*http.Response, error) {
+	return func(_ context.Context, _ string, _ string, body io.Reader) (*http.Response, error) {
+		var req frontend.TriageRequestV2
+		require.NoError(t, json.NewDecoder(body).Decode(&req))
+		*requests = append(*requests, req)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			Body:       io.NopCloser(strings.NewReader("")),
+			Status:     http.StatusText(statusCode),
+			StatusCode: statusCode,
+		}, nil
+	}
+}
+
+func triageRequest(testName types.TestName, digest types.Digest, label expectations.Label) frontend.TriageRequestV2 {
+	return frontend.TriageRequestV2{
+		TestDigestStatus: map[types.TestName]map[types.Digest]expectations.Label{
+			testName: {digest: label},
+		},
+	}
+}
@@ -47,6 +47,7 @@ It can be used directly or in a scripted environment. `,
 	rootCmd.AddCommand(getDiffCmd())
 	rootCmd.AddCommand(getMatchCmd())
 	rootCmd.AddCommand(getWhoamiCmd())
+	rootCmd.AddCommand(getTriageCmd())
 
 	ctx := executionContext(context.Background(), os.Stdout, os.Stderr, os.Exit)
 
@@ -3,6 +3,7 @@ package goldclient
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,6 +18,24 @@ import (
 
 const requestTimeout = 10 * time.Second
 
+// serverUnavailableError indicates that a request did not reach the Gold server, or that the
+// server failed to handle it. Retrying the request later might succeed.
+type serverUnavailableError struct {
+	msg string
+}
+
+// Error implements the error interface.
+func (e serverUnavailableError) Error() string {
+	return e.msg
+}
+
+// IsServerUnavailable returns true if the given error was caused by the Gold server being
+// unreachable or failing with a 5xx status code, as opposed to rejecting the request.
+func IsServerUnavailable(err error) bool {
+	var sue serverUnavailableError
+	return errors.As(err, &sue)
+}
+
 // getWithRetries makes a GET request with retries to work around the rare unexpected EOF error.
 // See https://crbug.com/skia/9108.
 func getWithRetries(ctx context.Context, url string) ([]byte, error) {
@@ -70,10 +89,13 @@ func post(ctx context.Context, url, contentType string, body io.Reader) ([]byte,
 	defer cancel()
 	resp, err := httpClient.Post(rctx, url, contentType, body)
 	if err != nil {
-		return nil, skerr.Fmt("error on POST %s: %s", url, err)
+		return nil, skerr.Wrap(serverUnavailableError{msg: fmt.Sprintf("error on POST %s: %s", url, err)})
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, skerr.Wrap(serverUnavailableError{msg: fmt.Sprintf("POST %s resulted in a %d: %s", url, resp.StatusCode, resp.Status)})
+	}
 	if resp.StatusCode >= http.StatusBadRequest {
 		return nil, skerr.Fmt("POST %s resulted in a %d: %s", url, resp.StatusCode, resp.Status)
 	}
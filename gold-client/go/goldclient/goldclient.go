@@ -111,6 +111,12 @@ type GoldClient interface {
 	// of the triage operation.
 	TriageAsPositive(ctx context.Context, testName types.TestName, digest types.Digest, algorithmName string) error
 
+	// Triage applies the given label to the given digest for the given test by making a request to
+	// Gold's /json/v2/triage endpoint. If the client was initialized for a changelist, the digest is
+	// only triaged on that changelist. Errors caused by the server being unavailable can be
+	// identified with IsServerUnavailable.
+	Triage(ctx context.Context, testName types.TestName, digest types.Digest, label expectations.Label) error
+
 	// MostRecentPositiveDigest retrieves the most recent positive digest for the given trace via
 	// Gold's /json/v2/latestpositivedigest/{traceId} endpoint.
 	MostRecentPositiveDigest(ctx context.Context, traceId tiling.TraceIDV2) (types.Digest, error)
@@ -817,22 +823,36 @@ func (c *CloudClient) Whoami(ctx context.Context) (string, error) {
 
 // TriageAsPositive fulfills the GoldClient interface.
 func (c *CloudClient) TriageAsPositive(ctx context.Context, testName types.TestName, digest types.Digest, algorithmName string) error {
+	return c.triage(ctx, testName, digest, expectations.Positive, algorithmName)
+}
+
+// Triage fulfills the GoldClient interface.
+func (c *CloudClient) Triage(ctx context.Context, testName types.TestName, digest types.Digest, label expectations.Label) error {
+	if !expectations.ValidLabel(label) {
+		return skerr.Fmt("invalid label %q", label)
+	}
+	return c.triage(ctx, testName, digest, label, "")
+}
+
+// triage applies the label to the digest for the given test. If the triage operation is made on
+// behalf of an image matching algorithm, algorithmName will be used as its author.
+func (c *CloudClient) triage(ctx context.Context, testName types.TestName, digest types.Digest, label expectations.Label, algorithmName string) error {
 	// Build TriageRequest struct and encode it into JSON.
 	triageRequest := &frontend.TriageRequestV2{
-		TestDigestStatus:       map[types.TestName]map[types.Digest]expectations.Label{testName: {digest: expectations.Positive}},
+		TestDigestStatus:       map[types.TestName]map[types.Digest]expectations.Label{testName: {digest: label}},
 		CodeReviewSystem:       c.resultState.SharedConfig.CodeReviewSystem,
 		ChangelistID:           c.resultState.SharedConfig.ChangelistID,
 		ImageMatchingAlgorithm: algorithmName,
 	}
 	jsonTriageRequest, err := json.Marshal(triageRequest)
 	if err != nil {
-		return skerr.Wrapf(err, `encoding frontend.TriageRequest into JSON for test %q, digest %q, algorithm %q and CL %q`, testName, digest, algorithmName, c.resultState.SharedConfig.ChangelistID)
+		return skerr.Wrapf(err, `encoding frontend.TriageRequest into JSON for test %q, digest %q, label %q, algorithm %q and CL %q`, testName, digest, label, algorithmName, c.resultState.SharedConfig.ChangelistID)
 	}
 
 	// Make /json/v1/triage request. Response is always empty.
 	_, err = post(ctx, c.resultState.GoldURL+"/json/v2/triage", "application/json", bytes.NewReader(jsonTriageRequest))
 	if err != nil {
-		return skerr.Wrapf(err, `making POST request to %s/json/v2/triage for test %q, digest %q, algorithm %q and CL %q`, c.resultState.GoldURL, testName, digest, algorithmName, c.resultState.SharedConfig.ChangelistID)
+		return skerr.Wrapf(err, `making POST request to %s/json/v2/triage for test %q, digest %q, label %q, algorithm %q and CL %q`, c.resultState.GoldURL, testName, digest, label, algorithmName, c.resultState.SharedConfig.ChangelistID)
 	}
 
 	return nil
@@ -2486,6 +2486,110 @@ func TestCloudClient_TriageAsPositive_InternalServerError_Failure(t *testing.T)
 	assert.Contains(t, err.Error(), "500")
 }
 
+func TestCloudClient_Triage_Negative_Success(t *testing.T) {
+	// This test reads and writes a small amount of data from/to disk.
+
+	wd := t.TempDir()
+
+	// Pretend "goldctl imgtest init" was called.
+	j := resultState{
+		GoldURL:      "https://testing-gold.skia.org",
+		SharedConfig: jsonio.GoldResults{},
+	}
+	jsonToWrite := testutils.MarshalJSON(t, &j)
+	testutils.WriteFile(t, filepath.Join(wd, stateFile), jsonToWrite)
+
+	ctx, httpClient, _, _ := makeMocks()
+	defer httpClient.AssertExpectations(t)
+
+	goldClient, err := LoadCloudClient(wd)
+	assert.NoError(t, err)
+
+	url := "https://testing-gold.skia.org/json/v2/triage"
+	contentType := "application/json"
+	bodyMatcher := mock.MatchedBy(func(r io.Reader) bool {
+		b, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		if len(b) == 0 {
+			// This matcher can get called a second time during AssertExpectations. This check makes sure
+			// we don't erroniously fail.
+			return false
+		}
+		tr := frontend.TriageRequestV2{}
+		assert.NoError(t, json.Unmarshal(b, &tr))
+		assert.Equal(t, frontend.TriageRequestV2{
+			TestDigestStatus: map[types.TestName]map[types.Digest]expectations.Label{
+				"MyTest": {
+					"deadbeefcafefe771d61bf0ed3d84bc2": expectations.Negative,
+				},
+			},
+		}, tr)
+		return true
+	})
+	httpClient.On("Post", testutils.AnyContext, url, contentType, bodyMatcher).Return(httpResponse("", "200 OK", http.StatusOK), nil)
+
+	err = goldClient.Triage(ctx, "MyTest", "deadbeefcafefe771d61bf0ed3d84bc2", expectations.Negative)
+	assert.NoError(t, err)
+}
+
+func TestCloudClient_Triage_InvalidLabel_ReturnsError(t *testing.T) {
+	wd := t.TempDir()
+
+	// Pretend "goldctl imgtest init" was called.
+	j := resultState{
+		GoldURL:      "https://testing-gold.skia.org",
+		SharedConfig: jsonio.GoldResults{},
+	}
+	jsonToWrite := testutils.MarshalJSON(t, &j)
+	testutils.WriteFile(t, filepath.Join(wd, stateFile), jsonToWrite)
+
+	ctx, httpClient, _, _ := makeMocks()
+	defer httpClient.AssertExpectations(t)
+
+	goldClient, err := LoadCloudClient(wd)
+	assert.NoError(t, err)
+
+	err = goldClient.Triage(ctx, "MyTest", "deadbeefcafefe771d61bf0ed3d84bc2", "fantastic")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid label "fantastic"`)
+}
+
+func TestCloudClient_Triage_ServerErrors_UnavailableErrorsIdentified(t *testing.T) {
+	wd := t.TempDir()
+
+	// Pretend "goldctl imgtest init" was called.
+	j := resultState{
+		GoldURL:      "https://testing-gold.skia.org",
+		SharedConfig: jsonio.GoldResults{},
+	}
+	jsonToWrite := testutils.MarshalJSON(t, &j)
+	testutils.WriteFile(t, filepath.Join(wd, stateFile), jsonToWrite)
+
+	goldClient, err := LoadCloudClient(wd)
+	require.NoError(t, err)
+
+	const url = "https://testing-gold.skia.org/json/v2/triage"
+	test := func(name string, setupMock func(*mocks.HTTPClient), expectedUnavailable bool) {
+		t.Run(name, func(t *testing.T) {
+			ctx, httpClient, _, _ := makeMocks()
+			setupMock(httpClient)
+
+			err := goldClient.Triage(ctx, "MyTest", "deadbeefcafefe771d61bf0ed3d84bc2", expectations.Positive)
+			require.Error(t, err)
+			assert.Equal(t, expectedUnavailable, IsServerUnavailable(err))
+		})
+	}
+	test("connection refused", func(httpClient *mocks.HTTPClient) {
+		httpClient.On("Post", testutils.AnyContext, url, "application/json", mock.Anything).Return(nil, errors.New("connection refused"))
+	}, true)
+	test("internal server error", func(httpClient *mocks.HTTPClient) {
+		httpClient.On("Post", testutils.AnyContext, url, "application/json", mock.Anything).Return(httpResponse("", "503 Service Unavailable", http.StatusServiceUnavailable), nil)
+	}, true)
+	test("forbidden", func(httpClient *mocks.HTTPClient) {
+		httpClient.On("Post", testutils.AnyContext, url, "application/json", mock.Anything).Return(httpResponse("", "403 Forbidden", http.StatusForbidden), nil)
+	}, false)
+}
+
 func TestCloudClient_MostRecentPositiveDigest_Success(t *testing.T) {
 	// This test reads and writes a small amount of data from/to disk.
 
@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"io"
 	"math/rand"
@@ -18,6 +19,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"go.opencensus.io/trace"
+	"google.golang.org/api/googleapi"
 
 	"go.skia.org/infra/go/common"
 	"go.skia.org/infra/go/httputils"
@@ -29,9 +31,12 @@ import (
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/golden/go/config"
 	"go.skia.org/infra/golden/go/diff"
+	"go.skia.org/infra/golden/go/diff/diffcache"
 	"go.skia.org/infra/golden/go/diff/worker"
+	"go.skia.org/infra/golden/go/image/codec"
 	"go.skia.org/infra/golden/go/sql"
 	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/storage"
 	"go.skia.org/infra/golden/go/tracing"
 	"go.skia.org/infra/golden/go/types"
 )
@@ -58,6 +63,26 @@ type diffCalculatorConfig struct {
 	// HighContentionMode indicates to use fewer transactions when getting diff work. This can help
 	// for instances with high amounts of secondary branches.
 	HighContentionMode bool `json:"high_contention_mode"`
+
+	// StoreDiffImages indicates to store the diff image of each computed diff in GCS. Diff images
+	// are content-addressed, so identical diff images are only stored once. This requires the
+	// diff_image_digest column in the DiffMetrics table, see worker.WithDiffImageStore.
+	StoreDiffImages bool `json:"store_diff_images"`
+
+	// DiffMetricByCorpus is an optional map from corpus name to the name of the metric used to
+	// compute the combined metric of diffs in that corpus (e.g. "ssim"). Corpora not in this map
+	// use the default "combined" metric. See diff.RegisterMetric for the available metrics.
+	DiffMetricByCorpus map[string]string `json:"diff_metric_by_corpus" optional:"true"`
+
+	// CacheDiffResults indicates to cache the result of each computed diff in the cache configured
+	// by cache_type (e.g. Redis), so that diffs are not recomputed after a restart or by other
+	// replicas. See worker.WithResultCache.
+	CacheDiffResults bool `json:"cache_diff_results" optional:"true"`
+
+	// StoreThumbnails indicates to store downscaled renditions of each image that is diffed in
+	// GCS (see pyramid.ThumbnailSizes), so that the frontend can serve them directly instead of
+	// downscaling the full-size images. See worker.WithThumbnailStore.
+	StoreThumbnails bool `json:"store_thumbnails" optional:"true"`
 }
 
 func main() {
@@ -104,8 +129,34 @@ func main() {
 		sklog.Fatalf("Could not initialize cache: %s", err)
 	}
 
+	calculator := worker.New(db, gis, dcc.WindowSize)
+	if dcc.StoreDiffImages {
+		calculator = calculator.WithDiffImageStore(gis)
+	}
+	if dcc.StoreThumbnails {
+		calculator = calculator.WithThumbnailStore(gis)
+	}
+	if len(dcc.DiffMetricByCorpus) > 0 {
+		for corpus, name := range dcc.DiffMetricByCorpus {
+			if _, err := diff.GetMetric(name); err != nil {
+				sklog.Fatalf("Invalid diff metric for corpus %q: %s", corpus, err)
+			}
+		}
+		calculator = calculator.WithMetricsByCorpus(dcc.DiffMetricByCorpus)
+	}
+	if dcc.CacheDiffResults {
+		cacheClient, err := dcc.GetCacheClient(ctx)
+		if err != nil {
+			sklog.Fatalf("Could not initialize cache client: %s", err)
+		}
+		if cacheClient == nil {
+			sklog.Fatalf("cache_diff_results requires a cache_type to be configured")
+		}
+		calculator = calculator.WithResultCache(diffcache.New(cacheClient))
+	}
+
 	sqlProcessor := &processor{
-		calculator:         worker.New(db, gis, dcc.WindowSize),
+		calculator:         calculator,
 		db:                 db,
 		groupingCache:      gc,
 		primaryCounter:     metrics2.GetCounter("diffcalculator_primarybranch_processed"),
@@ -208,7 +259,7 @@ func mustInitSQLDatabase(ctx context.Context, dcc diffCalculatorConfig) *pgxpool
 	return db
 }
 
-func mustMakeGCSImageSource(ctx context.Context, dcc diffCalculatorConfig) worker.ImageSource {
+func mustMakeGCSImageSource(ctx context.Context, dcc diffCalculatorConfig) *gcsImageDownloader {
 	// Reads credentials from the env variable GOOGLE_APPLICATION_CREDENTIALS.
 	storageClient, err := gstorage.NewClient(ctx)
 	if err != nil {
@@ -240,6 +291,48 @@ func (g *gcsImageDownloader) GetImage(ctx context.Context, digest types.Digest)
 	return b, skerr.Wrap(err)
 }
 
+// WriteDiffImage uploads the diff image with the corresponding digest to GCS, unless it already
+// exists there.
+func (g *gcsImageDownloader) WriteDiffImage(ctx context.Context, digest types.Digest, png []byte) error {
+	imgPath := path.Join(storage.DiffImgFolder, string(digest)+".png")
+	w := g.client.Bucket(g.bucket).Object(imgPath).If(gstorage.Conditions{DoesNotExist: true}).NewWriter(ctx)
+	w.ObjectAttrs.ContentType = "image/png"
+	if _, err := w.Write(png); err != nil {
+		_ = w.Close()
+		return skerr.Wrapf(err, "writing diff image %s", digest)
+	}
+	if err := w.Close(); err != nil {
+		var gErr *googleapi.Error
+		if errors.As(err, &gErr) && gErr.Code == http.StatusPreconditionFailed {
+			// Another diffcalculator already stored this diff image.
+			return nil
+		}
+		return skerr.Wrapf(err, "writing diff image %s", digest)
+	}
+	return nil
+}
+
+// WriteThumbnail uploads the thumbnail of the given size of the image with the corresponding
+// digest to GCS, unless it already exists there.
+func (g *gcsImageDownloader) WriteThumbnail(ctx context.Context, digest types.Digest, size int, b []byte) error {
+	imgPath := path.Join(storage.ThumbnailPath(size), string(digest)+".png")
+	w := g.client.Bucket(g.bucket).Object(imgPath).If(gstorage.Conditions{DoesNotExist: true}).NewWriter(ctx)
+	w.ObjectAttrs.ContentType = codec.DetectFormat(b).ContentType()
+	if _, err := w.Write(b); err != nil {
+		_ = w.Close()
+		return skerr.Wrapf(err, "writing thumbnail %s", imgPath)
+	}
+	if err := w.Close(); err != nil {
+		var gErr *googleapi.Error
+		if errors.As(err, &gErr) && gErr.Code == http.StatusPreconditionFailed {
+			// Another diffcalculator already stored this thumbnail.
+			return nil
+		}
+		return skerr.Wrapf(err, "writing thumbnail %s", imgPath)
+	}
+	return nil
+}
+
 type processor struct {
 	db             *pgxpool.Pool
 	calculator     diff.Calculator
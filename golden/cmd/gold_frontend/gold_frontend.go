@@ -33,6 +33,7 @@ import (
 	"go.skia.org/infra/go/gerrit"
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/tracing/loggingtracer"
 	"go.skia.org/infra/golden/go/clstore"
@@ -40,8 +41,11 @@ import (
 	"go.skia.org/infra/golden/go/code_review/gerrit_crs"
 	"go.skia.org/infra/golden/go/code_review/github_crs"
 	"go.skia.org/infra/golden/go/config"
+	"go.skia.org/infra/golden/go/corpusaccess"
+	"go.skia.org/infra/golden/go/diff"
 	"go.skia.org/infra/golden/go/ignore"
 	"go.skia.org/infra/golden/go/ignore/sqlignorestore"
+	"go.skia.org/infra/golden/go/mirror"
 	"go.skia.org/infra/golden/go/publicparams"
 	"go.skia.org/infra/golden/go/search"
 	"go.skia.org/infra/golden/go/sql"
@@ -73,6 +77,11 @@ var (
 type frontendServerConfig struct {
 	config.Common
 
+	// CorpusAccess optionally restricts who may view and triage the given corpora, e.g. to host
+	// an internal corpus alongside public ones. Corpora not listed may be viewed by anybody who
+	// may use this instance.
+	CorpusAccess corpusaccess.Rules `json:"corpus_access" optional:"true"`
+
 	// Force the user to be authenticated for all requests.
 	ForceLogin bool `json:"force_login"`
 
@@ -90,8 +99,25 @@ type frontendServerConfig struct {
 	// this instance.
 	PubliclyAllowableParams publicparams.MatchingRules `json:"publicly_allowed_params" optional:"true"`
 
+	// PublicMirrorURL is the URL of the public_mirror endpoint of the authoritative instance (see
+	// serve_public_mirror). If set on a public view, the publicly viewable traces and the primary
+	// branch baseline are mirrored from there instead of being computed from the database.
+	PublicMirrorURL string `json:"public_mirror_url" optional:"true"`
+
 	// Path to a directory with static assets that should be served to the frontend (JS, CSS, etc.).
 	ResourcesPath string `json:"resources_path"`
+
+	// ServeStoredDiffImages indicates to serve diff images stored by the diffcalculator (see its
+	// store_diff_images setting), if available, instead of always computing them.
+	ServeStoredDiffImages bool `json:"serve_stored_diff_images"`
+
+	// ServePublicMirror indicates to compute the publicly viewable traces and their expectations,
+	// using publicly_allowed_params, and serve them to the public views of this instance.
+	ServePublicMirror bool `json:"serve_public_mirror" optional:"true"`
+
+	// ServeThumbnails indicates to serve the thumbnails stored by the diffcalculator (see its
+	// store_thumbnails setting), if available, instead of always downscaling the full-size images.
+	ServeThumbnails bool `json:"serve_thumbnails" optional:"true"`
 }
 
 // IsAuthoritative indicates that this instance can write to known_hashes, update CL statuses, etc.
@@ -106,6 +132,8 @@ type frontendConfig struct {
 	CustomTriagingDisallowedMsg  string `json:"customTriagingDisallowedMsg,omitempty" optional:"true"`
 	IsPublic                     bool   `json:"isPublic"`
 	GoogleAnalyticsMeasurementID string `json:"ga_measurement_id" optional:"true"`
+	// Populated from the common config's diff_thresholds_by_corpus.
+	DiffThresholdsByCorpus map[string]diff.Thresholds `json:"diffThresholdsByCorpus,omitempty" optional:"true"`
 }
 
 func main() {
@@ -161,15 +189,21 @@ func main() {
 
 	publiclyViewableParams := mustMakePubliclyViewableParams(fsc)
 
+	corpusAccess := mustMakeCorpusAccess(ctx, fsc, sqlDB)
+
 	ignoreStore := mustMakeIgnoreStore(ctx, sqlDB)
 
 	reviewSystems := mustInitializeReviewSystems(fsc, client)
 
 	s2a := mustLoadSearchAPI(ctx, fsc, sqlDB, publiclyViewableParams, reviewSystems)
 
+	publicMirror := mustMakePublicMirror(ctx, fsc, sqlDB, publiclyViewableParams)
+
 	plogin := proxylogin.NewWithDefaults()
 
-	handlers := mustMakeWebHandlers(ctx, fsc, sqlDB, gsClient, ignoreStore, reviewSystems, s2a, plogin)
+	handlers := mustMakeWebHandlers(ctx, fsc, sqlDB, gsClient, ignoreStore, reviewSystems, s2a, corpusAccess, publicMirror, plogin)
+
+	mustStartMirroringPublicView(ctx, fsc, client, s2a, handlers)
 
 	rootRouter := mustMakeRootRouter(fsc, handlers, plogin)
 
@@ -196,6 +230,7 @@ func mustLoadSearchAPI(ctx context.Context, fsc *frontendServerConfig, sqlDB *pg
 
 	s2a.SetDatabaseType(fsc.SQLDatabaseType)
 	s2a.SetReviewSystemTemplates(templates)
+	s2a.SetDiffThresholds(fsc.DiffThresholdsByCorpus)
 	sklog.Infof("SQL Search loaded with CRS templates %s", templates)
 	err = s2a.StartCacheProcess(ctx, 5*time.Minute, fsc.WindowSize)
 	if err != nil {
@@ -206,7 +241,7 @@ func mustLoadSearchAPI(ctx context.Context, fsc *frontendServerConfig, sqlDB *pg
 			sklog.Fatalf("Cannot create materialized views %s: %s", fsc.MaterializedViewCorpora, err)
 		}
 	}
-	if fsc.IsPublicView {
+	if fsc.IsPublicView && fsc.PublicMirrorURL == "" {
 		if err := s2a.StartApplyingPublicParams(ctx, publiclyViewableParams, 5*time.Minute); err != nil {
 			sklog.Fatalf("Could not apply public params: %s", err)
 		}
@@ -298,6 +333,24 @@ func mustMakeGCSClient(ctx context.Context, fsc *frontendServerConfig, client *h
 	return gsClient
 }
 
+// mustMakeCorpusAccess validates the corpus access rules specified in the JSON configuration files
+// and starts keeping track of the digests produced by the restricted corpora. It returns nil if no
+// corpus is restricted.
+func mustMakeCorpusAccess(ctx context.Context, fsc *frontendServerConfig, db *pgxpool.Pool) *corpusaccess.Checker {
+	if len(fsc.CorpusAccess) == 0 {
+		return nil
+	}
+	c, err := corpusaccess.CheckerFromRules(fsc.CorpusAccess)
+	if err != nil {
+		sklog.Fatalf("Could not load corpus access rules: %s", err)
+	}
+	if err := c.StartUpdatingRestrictedDigests(ctx, db, 5*time.Minute); err != nil {
+		sklog.Fatalf("Could not load digests of restricted corpora: %s", err)
+	}
+	sklog.Infof("Restricted access to corpora %q", c.RestrictedCorpora())
+	return c
+}
+
 // mustMakePubliclyViewableParams validates and computes a publicparams.Matcher from the publicly
 // allowed params specified in the JSON configuration files.
 func mustMakePubliclyViewableParams(fsc *frontendServerConfig) publicparams.Matcher {
@@ -319,6 +372,50 @@ func mustMakePubliclyViewableParams(fsc *frontendServerConfig) publicparams.Matc
 	return publiclyViewableParams
 }
 
+// mustMakePublicMirror returns a mirror.Publisher which serves the sanitized data of the publicly
+// viewable traces to the public views of this instance, or nil if this instance doesn't serve it.
+func mustMakePublicMirror(ctx context.Context, fsc *frontendServerConfig, db *pgxpool.Pool, publiclyViewableParams publicparams.Matcher) *mirror.Publisher {
+	if !fsc.ServePublicMirror {
+		return nil
+	}
+	if fsc.IsPublicView {
+		sklog.Fatal("A public view cannot serve a public mirror.")
+	}
+	if publiclyViewableParams == nil {
+		sklog.Fatal("A non-empty map of publiclyViewableParams must be provided to serve a public mirror.")
+	}
+	p := mirror.NewPublisher(db, publiclyViewableParams)
+	if err := p.Start(ctx, 5*time.Minute); err != nil {
+		sklog.Fatalf("Could not start public mirror: %s", err)
+	}
+	return p
+}
+
+// mustStartMirroringPublicView makes a public view use the publicly viewable traces and the primary
+// branch baseline mirrored from the authoritative instance, if configured.
+func mustStartMirroringPublicView(ctx context.Context, fsc *frontendServerConfig, client *http.Client, s2a *search.Impl, handlers *web.Handlers) {
+	if fsc.PublicMirrorURL == "" {
+		return
+	}
+	if !fsc.IsPublicView {
+		sklog.Fatal("Only a public view can mirror another instance.")
+	}
+	sub := mirror.NewSubscriber(client, fsc.PublicMirrorURL)
+	err := sub.Start(ctx, time.Minute, func(a *mirror.Artifact) error {
+		traces, err := a.PublicTraces()
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		s2a.SetPublicTraces(traces, a.PublicCorpora())
+		handlers.SetMirroredBaseline(a.Baseline())
+		sklog.Infof("Mirrored %d public traces and %d expectations exported at %s", len(a.TraceIDs), len(a.Expectations), a.ExportedAt)
+		return nil
+	})
+	if err != nil {
+		sklog.Fatalf("Could not mirror public view from %s: %s", fsc.PublicMirrorURL, err)
+	}
+}
+
 // mustMakeIgnoreStore returns a new ignore.Store and starts a monitoring routine that counts the
 // the number of expired ignore rules and exposes this as a metric.
 func mustMakeIgnoreStore(ctx context.Context, db *pgxpool.Pool) ignore.Store {
@@ -375,7 +472,7 @@ func mustInitializeReviewSystems(fsc *frontendServerConfig, hc *http.Client) []c
 }
 
 // mustMakeWebHandlers returns a new web.Handlers.
-func mustMakeWebHandlers(ctx context.Context, fsc *frontendServerConfig, db *pgxpool.Pool, gsClient storage.GCSClient, ignoreStore ignore.Store, reviewSystems []clstore.ReviewSystem, s2a search.API, alogin alogin.Login) *web.Handlers {
+func mustMakeWebHandlers(ctx context.Context, fsc *frontendServerConfig, db *pgxpool.Pool, gsClient storage.GCSClient, ignoreStore ignore.Store, reviewSystems []clstore.ReviewSystem, s2a search.API, corpusAccess *corpusaccess.Checker, publicMirror *mirror.Publisher, alogin alogin.Login) *web.Handlers {
 	handlers, err := web.NewHandlers(web.HandlersConfig{
 		DB:                        db,
 		GCSClient:                 gsClient,
@@ -384,6 +481,10 @@ func mustMakeWebHandlers(ctx context.Context, fsc *frontendServerConfig, db *pgx
 		Search2API:                s2a,
 		WindowSize:                fsc.WindowSize,
 		GroupingParamKeysByCorpus: fsc.GroupingParamKeysByCorpus,
+		ServeStoredDiffImages:     fsc.ServeStoredDiffImages,
+		ServeThumbnails:           fsc.ServeThumbnails,
+		CorpusAccess:              corpusAccess,
+		PublicMirror:              publicMirror,
 	}, web.FullFrontEnd, alogin)
 	if err != nil {
 		sklog.Fatalf("Failed to initialize web handlers: %s", err)
@@ -456,6 +557,7 @@ func addUIRoutes(router chi.Router, fsc *frontendServerConfig, handlers *web.Han
 
 	fsc.FrontendConfig.BaseRepoURL = fsc.GitRepoURL
 	fsc.FrontendConfig.IsPublic = fsc.IsPublicView
+	fsc.FrontendConfig.DiffThresholdsByCorpus = fsc.DiffThresholdsByCorpus
 
 	frontendConfigBytes, err := json.Marshal(fsc.FrontendConfig)
 	if err != nil {
@@ -533,19 +635,27 @@ func addAuthenticatedJSONRoutes(router chi.Router, fsc *frontendServerConfig, ha
 
 	add("/json/v2/byblame", handlers.ByBlameHandler, "GET")
 	add("/json/v2/changelists", handlers.ChangelistsHandler, "GET")
+	add("/json/v1/changelist/{system}/{id}/compare", handlers.ComparePatchsetsHandler, "GET")
 	add("/json/v2/clusterdiff", handlers.ClusterDiffHandler, "GET")
 	add("/json/v2/commits", handlers.CommitsHandler, "GET")
 	add("/json/v1/positivedigestsbygrouping/{groupingID}", handlers.PositiveDigestsByGroupingIDHandler, "GET")
 	add("/json/v2/details", handlers.DetailsHandler, "POST")
 	add("/json/v2/diff", handlers.DiffHandler, "POST")
 	add("/json/v2/digests", handlers.DigestListHandler, "GET")
+	add("/json/v1/digest/occurrences", handlers.DigestOccurrencesHandler, "GET")
+	add("/json/v1/expectations/audit", handlers.ExpectationAuditHandler, "GET")
+	add("/json/v1/expectations/export", handlers.ExportBaselineHandler, "GET")
+	add("/json/v1/expectations/import", handlers.ImportBaselineHandler, "POST")
+	add("/json/v1/flaky", handlers.FlakyTestsHandler, "GET")
 	add("/json/v2/latestpositivedigest/{traceID}", handlers.LatestPositiveDigestHandler, "GET")
 	add("/json/v2/list", handlers.ListTestsHandler, "GET")
 	add("/json/v2/paramset", handlers.ParamsHandler, "GET")
 	add("/json/v2/search", handlers.SearchHandler, "GET")
+	add("/json/v1/search/stream", handlers.SearchStreamHandler, "GET")
 	add("/json/v2/triage", handlers.TriageHandlerV2, "POST") // TODO(lovisolo): Delete when unused.
 	add("/json/v3/triage", handlers.TriageHandlerV3, "POST")
 	add("/json/v2/triagelog", handlers.TriageLogHandler, "GET")
+	add("/json/v1/triagequeue", handlers.TriageQueueHandler, "GET")
 	add("/json/v2/triagelog/undo", handlers.TriageUndoHandler, "POST")
 	add("/json/whoami", handlers.Whoami, "GET")
 	add("/json/v1/whoami", handlers.Whoami, "GET")
@@ -569,7 +679,7 @@ func addAuthenticatedJSONRoutes(router chi.Router, fsc *frontendServerConfig, ha
 
 // addUnauthenticatedJSONRoutes populates the given router with the subset of Gold's JSON RPC routes
 // that do not require authentication.
-func addUnauthenticatedJSONRoutes(router chi.Router, _ *frontendServerConfig, handlers *web.Handlers) {
+func addUnauthenticatedJSONRoutes(router chi.Router, fsc *frontendServerConfig, handlers *web.Handlers) {
 	add := func(jsonRoute string, handlerFunc http.HandlerFunc) {
 		addJSONRoute("GET", jsonRoute, httputils.CorsHandler(handlerFunc), router, "")
 	}
@@ -585,7 +695,13 @@ func addUnauthenticatedJSONRoutes(router chi.Router, _ *frontendServerConfig, ha
 	// Retrieving a baseline for the primary branch and a Gerrit issue are handled the same way.
 	// These routes can be served with baseline_server for higher availability.
 	add(frontend.ExpectationsRouteV2, handlers.BaselineHandlerV2)
+	add(frontend.ExpectationsDiffRouteV1, handlers.BaselineDiffHandler)
 	add(frontend.GroupingsRouteV1, handlers.GroupingsHandler)
+
+	// The public mirror only contains publicly viewable data. It can be large, so it is gzipped.
+	if fsc.ServePublicMirror {
+		add("/json/v1/public_mirror", httputils.GzipRequestResponse(http.HandlerFunc(handlers.PublicMirrorHandler)).ServeHTTP)
+	}
 }
 
 var (
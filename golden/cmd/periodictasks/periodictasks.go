@@ -17,6 +17,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"go.opencensus.io/trace"
+	"go.skia.org/infra/email/go/emailclient"
 	"go.skia.org/infra/go/auth"
 	"go.skia.org/infra/go/cache"
 	"go.skia.org/infra/go/common"
@@ -35,6 +36,7 @@ import (
 	"go.skia.org/infra/golden/go/code_review/gerrit_crs"
 	"go.skia.org/infra/golden/go/code_review/github_crs"
 	"go.skia.org/infra/golden/go/config"
+	"go.skia.org/infra/golden/go/ignore/reminder"
 	"go.skia.org/infra/golden/go/ignore/sqlignorestore"
 	searchCache "go.skia.org/infra/golden/go/search/caching"
 	"go.skia.org/infra/golden/go/sql"
@@ -54,6 +56,23 @@ const (
 	maxSQLConnections = 4
 
 	clScanRange = 5 * 24 * time.Hour
+
+	// defaultTriageSuggestionsMaxDiff is used if TriageSuggestionsMaxDiff is not configured. The
+	// combined diff metric ranges from 0 to 10.
+	defaultTriageSuggestionsMaxDiff = 1.0
+
+	// triageSuggestionsBatchSize is the number of TriageSuggestions rows to write at a time.
+	triageSuggestionsBatchSize = 1000
+
+	// defaultIgnoreRuleReminderLeadTime is used if IgnoreRuleReminderLeadTime is not configured.
+	defaultIgnoreRuleReminderLeadTime = 3 * 24 * time.Hour
+
+	// defaultArchiveExpiredIgnoreRulesAfter is used if ArchiveExpiredIgnoreRulesAfter is not
+	// configured. It gives rule authors time to extend a rule after being told it expired.
+	defaultArchiveExpiredIgnoreRulesAfter = 7 * 24 * time.Hour
+
+	// defaultIgnoreRuleReminderFrom is used if IgnoreRuleReminderFrom is not configured.
+	defaultIgnoreRuleReminderFrom = "Skia Gold <noreply@skia.org>"
 )
 
 // TODO(kjlubick) Add a task to check for abandoned CLs.
@@ -61,6 +80,14 @@ const (
 type periodicTasksConfig struct {
 	config.Common
 
+	// ArchiveExpiredIgnoreRulesPeriod, if positive, is how often to archive ignore rules which
+	// expired at least ArchiveExpiredIgnoreRulesAfter ago, so they stop matching traces.
+	ArchiveExpiredIgnoreRulesPeriod config.Duration `json:"archive_expired_ignore_rules_period" optional:"true"`
+
+	// ArchiveExpiredIgnoreRulesAfter is how long after an ignore rule expires it is archived. If
+	// zero, a default is used.
+	ArchiveExpiredIgnoreRulesAfter config.Duration `json:"archive_expired_ignore_rules_after" optional:"true"`
+
 	// ChangelistDiffPeriod is how often to look at recently updated CLs and tabulate the diffs
 	// for the digests produced.
 	// The diffs are not calculated in this service, but the tasks are generated here and
@@ -71,19 +98,57 @@ type periodicTasksConfig struct {
 	// commenter.commentTemplateContext for the exact fields.
 	CLCommentTemplate string `json:"cl_comment_template" optional:"true"`
 
+	// CLCommentMaxDigests is the number of untriaged digests the CL comment template can list
+	// (as links or, on GitHub, inline images). If zero, a default is used.
+	CLCommentMaxDigests int `json:"cl_comment_max_digests" optional:"true"`
+
 	// CommentOnCLsPeriod, if positive, is how often to check recent CLs and Patchsets for
 	// untriaged digests and comment on them if appropriate.
 	CommentOnCLsPeriod config.Duration `json:"comment_on_cls_period" optional:"true"`
 
+	// IgnoreRuleMetricsPeriod, if positive, is how often to count the traces matched by each
+	// ignore rule and report them as metrics.
+	IgnoreRuleMetricsPeriod config.Duration `json:"ignore_rule_metrics_period" optional:"true"`
+
+	// IgnoreRuleRemindersPeriod, if positive, is how often to check for ignore rules which are
+	// about to expire or have just expired and email their authors about them.
+	IgnoreRuleRemindersPeriod config.Duration `json:"ignore_rule_reminders_period" optional:"true"`
+
+	// IgnoreRuleReminderLeadTime is how long before an ignore rule expires its authors are first
+	// reminded. If zero, a default is used.
+	IgnoreRuleReminderLeadTime config.Duration `json:"ignore_rule_reminder_lead_time" optional:"true"`
+
+	// IgnoreRuleReminderFrom is the sender of the ignore rule reminder emails. If empty, a
+	// default is used.
+	IgnoreRuleReminderFrom string `json:"ignore_rule_reminder_from" optional:"true"`
+
 	// PerfSummaries configures summary data (e.g. triage status, ignore count) that is fed into
 	// a GCS bucket which an instance of Perf can ingest from.
 	PerfSummaries *perfSummariesConfig `json:"perf_summaries" optional:"true"`
 
+	// RevertSuggestionsPeriod, if positive, is how often to check for digests which were triaged as
+	// negative on the primary branch and were produced by a recently landed CL, and comment on that
+	// CL suggesting it be reverted.
+	RevertSuggestionsPeriod config.Duration `json:"revert_suggestions_period" optional:"true"`
+
+	// RevertSuggestionTemplate is a string with placeholders for generating the comment which
+	// suggests a revert. See commenter.revertTemplateContext for the exact fields. If empty,
+	// commenter.DefaultRevertTemplate is used.
+	RevertSuggestionTemplate string `json:"revert_suggestion_template" optional:"true"`
+
 	// PrimaryBranchDiffPeriod is how often to look at the most recent window of commits and
 	// tabulate diffs between all groupings based on the digests produced on the primary branch.
 	// The diffs are not calculated in this service, but sent via Pub/Sub to the appropriate workers.
 	PrimaryBranchDiffPeriod config.Duration `json:"primary_branch_diff_period"`
 
+	// TriageSuggestionsPeriod, if positive, is how often to compare the untriaged digests at head
+	// against the triaged digests of the same grouping and suggest labels for them.
+	TriageSuggestionsPeriod config.Duration `json:"triage_suggestions_period" optional:"true"`
+
+	// TriageSuggestionsMaxDiff is the largest combined diff metric between an untriaged digest and
+	// the closest positive digest for which a suggestion is made. If zero, a default is used.
+	TriageSuggestionsMaxDiff float32 `json:"triage_suggestions_max_diff" optional:"true"`
+
 	// UpdateIgnorePeriod is how often we should try to apply the ignore rules to all traces.
 	UpdateIgnorePeriod config.Duration `json:"update_traces_ignore_period"` // TODO(kjlubick) change JSON
 
@@ -144,6 +209,7 @@ func main() {
 	startUpdateTracesIgnoreStatus(ctx, db, ptc)
 
 	startCommentOnCLs(ctx, db, ptc)
+	startRevertSuggestions(ctx, db, ptc)
 
 	gatherer := &diffWorkGatherer{
 		db:               db,
@@ -163,6 +229,12 @@ func main() {
 		startPerfSummarization(ctx, db, ptc.PerfSummaries)
 	}
 
+	startTriageSuggestions(ctx, db, ptc)
+
+	startIgnoreRuleReminders(ctx, db, ptc)
+	startIgnoreRuleArchival(ctx, db, ptc)
+	startIgnoreRuleMetrics(ctx, db, ptc)
+
 	sklog.Infof("Starting cache population tasks.")
 	runCachingTasks(ctx, ptc, db)
 
@@ -216,7 +288,7 @@ func startCommentOnCLs(ctx context.Context, db *pgxpool.Pool, ptc periodicTasksC
 		return
 	}
 	systems := mustInitializeSystems(ctx, ptc)
-	cmntr, err := commenter.New(db, systems, ptc.CLCommentTemplate, ptc.SiteURL, ptc.WindowSize)
+	cmntr, err := commenter.New(db, systems, ptc.CLCommentTemplate, ptc.SiteURL, ptc.WindowSize, ptc.CLCommentMaxDigests)
 	if err != nil {
 		sklog.Fatalf("Could not initialize commenting: %s", err)
 	}
@@ -236,6 +308,34 @@ func startCommentOnCLs(ctx context.Context, db *pgxpool.Pool, ptc periodicTasksC
 	})
 }
 
+// startRevertSuggestions starts the process that periodically suggests reverting landed CLs which
+// produced digests that were then triaged as negative on the primary branch.
+func startRevertSuggestions(ctx context.Context, db *pgxpool.Pool, ptc periodicTasksConfig) {
+	if ptc.RevertSuggestionsPeriod.Duration <= 0 {
+		sklog.Infof("Not suggesting reverts because duration was zero.")
+		return
+	}
+	systems := mustInitializeSystems(ctx, ptc)
+	suggester, err := commenter.NewRevertSuggester(db, systems, ptc.RevertSuggestionTemplate, ptc.SiteURL, ptc.CLCommentMaxDigests)
+	if err != nil {
+		sklog.Fatalf("Could not initialize revert suggestions: %s", err)
+	}
+	liveness := metrics2.NewLiveness("periodic_tasks", map[string]string{
+		"task": "suggestReverts",
+	})
+	go util.RepeatCtx(ctx, ptc.RevertSuggestionsPeriod.Duration, func(ctx context.Context) {
+		sklog.Infof("Checking landed CLs for new negatives")
+		ctx, span := trace.StartSpan(ctx, "periodic_suggestReverts")
+		defer span.End()
+		if err := suggester.SuggestRevertsForNewNegatives(ctx, ptc.RevertSuggestionsPeriod.Duration); err != nil {
+			sklog.Errorf("Error while suggesting reverts: %s", err)
+			return // return so the liveness is not updated
+		}
+		liveness.Reset()
+		sklog.Infof("Done checking landed CLs for new negatives")
+	})
+}
+
 // mustInitializeSystems creates code_review.Clients and returns them wrapped as a ReviewSystem.
 // It panics if any part of configuration fails.
 func mustInitializeSystems(ctx context.Context, ptc periodicTasksConfig) []commenter.ReviewSystem {
@@ -274,6 +374,8 @@ func mustInitializeSystems(ctx context.Context, ptc periodicTasksConfig) []comme
 		rv = append(rv, commenter.ReviewSystem{
 			ID:     cfg.ID,
 			Client: crs,
+			// GitHub renders comments as Markdown, Gerrit does not.
+			InlineImages: cfg.Flavor == "github",
 		})
 	}
 	return rv
@@ -1198,6 +1300,7 @@ func runCachingTasks(ctx context.Context, ptc periodicTasksConfig, db *pgxpool.P
 // Runs the caching tasks related to the search functionality.
 func populateSearchCache(ctx context.Context, ptc periodicTasksConfig, db *pgxpool.Pool, cache cache.Cache) {
 	searchCacheManager := searchCache.New(cache, db, ptc.CachingCorpora, ptc.WindowSize)
+	searchCacheManager.SetMaxConcurrentShards(ptc.CachingMaxConcurrentShards)
 	err := searchCacheManager.RunCachePopulation(ctx)
 	if err != nil {
 		sklog.Fatalf("Error running cache population: %v", err)
@@ -1224,3 +1327,275 @@ func runExpiryMonitoringTasks(ctx context.Context, db *pgxpool.Pool, ptc periodi
 		sklog.Info("Expiration monitoring is not configured for this instance.")
 	}
 }
+
+// startTriageSuggestions starts the process that periodically suggests labels for the untriaged
+// digests at head, based on the triaged digests of the same grouping which are closest to them.
+func startTriageSuggestions(ctx context.Context, db *pgxpool.Pool, ptc periodicTasksConfig) {
+	if ptc.TriageSuggestionsPeriod.Duration <= 0 {
+		sklog.Infof("Not computing triage suggestions because duration was zero.")
+		return
+	}
+	maxDiff := ptc.TriageSuggestionsMaxDiff
+	if maxDiff <= 0 {
+		maxDiff = defaultTriageSuggestionsMaxDiff
+	}
+	liveness := metrics2.NewLiveness("periodic_tasks", map[string]string{
+		"task": "triageSuggestions",
+	})
+	go util.RepeatCtx(ctx, ptc.TriageSuggestionsPeriod.Duration, func(ctx context.Context) {
+		sklog.Infof("Computing triage suggestions for untriaged digests")
+		ctx, span := trace.StartSpan(ctx, "periodic_triageSuggestions")
+		defer span.End()
+		if err := updateTriageSuggestions(ctx, db, ptc.WindowSize, maxDiff); err != nil {
+			sklog.Errorf("Error while computing triage suggestions: %s", err)
+			return // return so the liveness is not updated
+		}
+		liveness.Reset()
+		sklog.Infof("Done computing triage suggestions")
+	})
+}
+
+// startIgnoreRuleReminders starts a goroutine which emails the authors of ignore rules that are
+// about to expire or have just expired.
+func startIgnoreRuleReminders(ctx context.Context, db *pgxpool.Pool, ptc periodicTasksConfig) {
+	if ptc.IgnoreRuleRemindersPeriod.Duration <= 0 {
+		sklog.Infof("Not sending ignore rule reminders because duration was zero.")
+		return
+	}
+	leadTime := ptc.IgnoreRuleReminderLeadTime.Duration
+	if leadTime <= 0 {
+		leadTime = defaultIgnoreRuleReminderLeadTime
+	}
+	from := ptc.IgnoreRuleReminderFrom
+	if from == "" {
+		from = defaultIgnoreRuleReminderFrom
+	}
+	r := reminder.New(sqlignorestore.New(db), emailclient.New(), from, ptc.SiteURL, leadTime)
+	reminder.Start(ctx, r, ptc.IgnoreRuleRemindersPeriod.Duration)
+}
+
+// startIgnoreRuleArchival starts a goroutine which archives ignore rules that expired a while ago,
+// so they no longer hide traces.
+func startIgnoreRuleArchival(ctx context.Context, db *pgxpool.Pool, ptc periodicTasksConfig) {
+	if ptc.ArchiveExpiredIgnoreRulesPeriod.Duration <= 0 {
+		sklog.Infof("Not archiving expired ignore rules because duration was zero.")
+		return
+	}
+	after := ptc.ArchiveExpiredIgnoreRulesAfter.Duration
+	if after <= 0 {
+		after = defaultArchiveExpiredIgnoreRulesAfter
+	}
+	store := sqlignorestore.New(db)
+	archivedCounter := metrics2.GetCounter("gold_ignore_rules_archived")
+	liveness := metrics2.NewLiveness("periodic_tasks", map[string]string{
+		"task": "archiveExpiredIgnoreRules",
+	})
+	go util.RepeatCtx(ctx, ptc.ArchiveExpiredIgnoreRulesPeriod.Duration, func(ctx context.Context) {
+		ctx, span := trace.StartSpan(ctx, "periodic_archiveExpiredIgnoreRules")
+		defer span.End()
+		archived, err := store.ArchiveExpired(ctx, now.Now(ctx).Add(-after))
+		for _, rule := range archived {
+			sklog.Infof("Archived ignore rule %s (%s) which expired on %s", rule.ID, rule.Query, rule.Expires)
+		}
+		archivedCounter.Inc(int64(len(archived)))
+		if err != nil {
+			sklog.Errorf("Error while archiving expired ignore rules: %s", err)
+			return // return so the liveness is not updated
+		}
+		liveness.Reset()
+	})
+}
+
+// startIgnoreRuleMetrics starts a goroutine which reports how many traces each ignore rule
+// matches.
+func startIgnoreRuleMetrics(ctx context.Context, db *pgxpool.Pool, ptc periodicTasksConfig) {
+	if ptc.IgnoreRuleMetricsPeriod.Duration <= 0 {
+		sklog.Infof("Not reporting ignore rule metrics because duration was zero.")
+		return
+	}
+	store := sqlignorestore.New(db)
+	liveness := metrics2.NewLiveness("periodic_tasks", map[string]string{
+		"task": "ignoreRuleMetrics",
+	})
+	reported := map[string]metrics2.Int64Metric{}
+	go util.RepeatCtx(ctx, ptc.IgnoreRuleMetricsPeriod.Duration, func(ctx context.Context) {
+		ctx, span := trace.StartSpan(ctx, "periodic_ignoreRuleMetrics")
+		defer span.End()
+		counts, err := store.CountMatchingTraces(ctx)
+		if err != nil {
+			sklog.Errorf("Error while counting traces matched by ignore rules: %s", err)
+			return // return so the liveness is not updated
+		}
+		for id, count := range counts {
+			m, ok := reported[id]
+			if !ok {
+				m = metrics2.GetInt64Metric("gold_ignore_rule_matching_traces", map[string]string{"rule_id": id})
+				reported[id] = m
+			}
+			m.Update(count)
+		}
+		// Stop reporting rules which were deleted or archived.
+		for id, m := range reported {
+			if _, ok := counts[id]; !ok {
+				if err := m.Delete(); err != nil {
+					sklog.Warningf("Could not delete metric for ignore rule %s: %s", id, err)
+				}
+				delete(reported, id)
+			}
+		}
+		liveness.Reset()
+	})
+}
+
+// triageCandidate is an untriaged digest along with the closest positive and negative digests
+// in the same grouping.
+type triageCandidate struct {
+	groupingID schema.GroupingID
+	digest     schema.DigestBytes
+
+	closestPositive schema.DigestBytes
+	positiveMetric  float32
+	// closestNegative is nil if there are no negative digests with diffs to digest.
+	closestNegative schema.DigestBytes
+	negativeMetric  float32
+}
+
+// updateTriageSuggestions replaces the contents of the TriageSuggestions table with suggestions
+// for the untriaged digests seen in the most recent window of commits.
+func updateTriageSuggestions(ctx context.Context, db *pgxpool.Pool, windowSize int, maxDiff float32) error {
+	ctx, span := trace.StartSpan(ctx, "updateTriageSuggestions")
+	defer span.End()
+	oldestCommitID, _, err := getWindowCommitBounds(ctx, db, windowSize)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	candidates, err := getTriageCandidates(ctx, db, oldestCommitID)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	computedTS := now.Now(ctx)
+	suggestions := computeTriageSuggestions(candidates, maxDiff, computedTS)
+	span.AddAttributes(trace.Int64Attribute("num_suggestions", int64(len(suggestions))))
+
+	const valuesPerRow = 6
+	err = util.ChunkIter(len(suggestions), triageSuggestionsBatchSize, func(startIdx int, endIdx int) error {
+		batch := suggestions[startIdx:endIdx]
+		statement := `UPSERT INTO TriageSuggestions (grouping_id, digest, label, closest_digest,
+confidence, last_computed_ts) VALUES `
+		statement += sqlutil.ValuesPlaceholders(valuesPerRow, len(batch))
+		arguments := make([]interface{}, 0, valuesPerRow*len(batch))
+		for _, s := range batch {
+			arguments = append(arguments, s.GroupingID, s.Digest, s.Label, s.ClosestDigest,
+				s.Confidence, s.LastComputedTS)
+		}
+		err := crdbpgx.ExecuteTx(ctx, db, pgx.TxOptions{}, func(tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, statement, arguments...)
+			return err // may be retried
+		})
+		return skerr.Wrap(err)
+	})
+	if err != nil {
+		return skerr.Wrapf(err, "writing %d triage suggestions", len(suggestions))
+	}
+
+	// Anything not updated above has either been triaged or no longer has a suggestion.
+	const deleteStatement = `DELETE FROM TriageSuggestions WHERE last_computed_ts < $1`
+	err = crdbpgx.ExecuteTx(ctx, db, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, deleteStatement, computedTS)
+		return err // may be retried
+	})
+	return skerr.Wrap(err)
+}
+
+// getTriageCandidates returns the untriaged, non-ignored digests at head which have been compared
+// against at least one positive digest of the same grouping.
+func getTriageCandidates(ctx context.Context, db *pgxpool.Pool, oldestCommitID schema.CommitID) ([]triageCandidate, error) {
+	ctx, span := trace.StartSpan(ctx, "getTriageCandidates")
+	defer span.End()
+	const statement = `WITH
+UntriagedAtHead AS (
+	SELECT DISTINCT ValuesAtHead.grouping_id, ValuesAtHead.digest FROM ValuesAtHead
+	JOIN Expectations ON ValuesAtHead.grouping_id = Expectations.grouping_id AND
+		ValuesAtHead.digest = Expectations.digest
+	WHERE most_recent_commit_id >= $1 AND matches_any_ignore_rule = FALSE AND label = 'u'
+)
+SELECT DISTINCT ON (UntriagedAtHead.grouping_id, UntriagedAtHead.digest, Expectations.label)
+	UntriagedAtHead.grouping_id, UntriagedAtHead.digest, Expectations.label,
+	DiffMetrics.right_digest, DiffMetrics.combined_metric
+FROM UntriagedAtHead
+JOIN DiffMetrics ON UntriagedAtHead.digest = DiffMetrics.left_digest
+JOIN Expectations ON UntriagedAtHead.grouping_id = Expectations.grouping_id AND
+	DiffMetrics.right_digest = Expectations.digest
+WHERE Expectations.label IN ('p', 'n')
+ORDER BY UntriagedAtHead.grouping_id, UntriagedAtHead.digest, Expectations.label,
+	DiffMetrics.combined_metric ASC, DiffMetrics.right_digest ASC`
+
+	rows, err := db.Query(ctx, statement, oldestCommitID)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	defer rows.Close()
+	// The rows are ordered, so all rows for a given digest are adjacent.
+	var rv []triageCandidate
+	for rows.Next() {
+		var groupingID schema.GroupingID
+		var digest schema.DigestBytes
+		var label schema.ExpectationLabel
+		var closest schema.DigestBytes
+		var metric float32
+		if err := rows.Scan(&groupingID, &digest, &label, &closest, &metric); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		if len(rv) == 0 || !bytes.Equal(rv[len(rv)-1].groupingID, groupingID) ||
+			!bytes.Equal(rv[len(rv)-1].digest, digest) {
+			rv = append(rv, triageCandidate{groupingID: groupingID, digest: digest})
+		}
+		c := &rv[len(rv)-1]
+		if label == schema.LabelPositive {
+			c.closestPositive, c.positiveMetric = closest, metric
+		} else {
+			c.closestNegative, c.negativeMetric = closest, metric
+		}
+	}
+	return rv, nil
+}
+
+// computeTriageSuggestions returns a TriageSuggestionRow for each candidate that is likely to be
+// positive. Only positive labels are suggested; marking a digest as negative should be a deliberate
+// choice by a human.
+func computeTriageSuggestions(candidates []triageCandidate, maxDiff float32, ts time.Time) []schema.TriageSuggestionRow {
+	var rv []schema.TriageSuggestionRow
+	for _, c := range candidates {
+		confidence := positiveConfidence(c, maxDiff)
+		if confidence <= 0 {
+			continue
+		}
+		rv = append(rv, schema.TriageSuggestionRow{
+			GroupingID:     c.groupingID,
+			Digest:         c.digest,
+			Label:          schema.LabelPositive,
+			ClosestDigest:  c.closestPositive,
+			Confidence:     confidence,
+			LastComputedTS: ts,
+		})
+	}
+	return rv
+}
+
+// positiveConfidence returns how confident we are that the given candidate is positive, in the
+// range [0, 1]. The closer the candidate is to its closest positive digest (relative to maxDiff)
+// and the further away it is from its closest negative digest, the higher the confidence. Zero
+// means no suggestion should be made.
+func positiveConfidence(c triageCandidate, maxDiff float32) float32 {
+	if c.closestPositive == nil || c.positiveMetric >= maxDiff {
+		return 0
+	}
+	margin := float32(1)
+	if c.closestNegative != nil {
+		if c.negativeMetric <= c.positiveMetric {
+			return 0
+		}
+		margin = (c.negativeMetric - c.positiveMetric) / c.negativeMetric
+	}
+	return (1 - c.positiveMetric/maxDiff) * margin
+}
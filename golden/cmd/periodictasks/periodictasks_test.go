@@ -885,6 +885,70 @@ func TestLoadExistingConfigs_Valid(t *testing.T) {
 	assert.NotZero(t, ptc, "Config object should not be nil.")
 }
 
+func TestPositiveConfidence_NoPositive_ReturnsZero(t *testing.T) {
+	c := triageCandidate{closestNegative: schema.DigestBytes{0x02}, negativeMetric: 0.1}
+	assert.Equal(t, float32(0), positiveConfidence(c, 1))
+}
+
+func TestPositiveConfidence_PositiveTooFarAway_ReturnsZero(t *testing.T) {
+	c := triageCandidate{closestPositive: schema.DigestBytes{0x01}, positiveMetric: 1.5}
+	assert.Equal(t, float32(0), positiveConfidence(c, 1))
+}
+
+func TestPositiveConfidence_NegativeIsCloser_ReturnsZero(t *testing.T) {
+	c := triageCandidate{
+		closestPositive: schema.DigestBytes{0x01}, positiveMetric: 0.5,
+		closestNegative: schema.DigestBytes{0x02}, negativeMetric: 0.25,
+	}
+	assert.Equal(t, float32(0), positiveConfidence(c, 1))
+}
+
+func TestPositiveConfidence_OnlyPositive_ScalesWithDistance(t *testing.T) {
+	c := triageCandidate{closestPositive: schema.DigestBytes{0x01}, positiveMetric: 0.25}
+	assert.Equal(t, float32(0.75), positiveConfidence(c, 1))
+	c.positiveMetric = 0.5
+	assert.Equal(t, float32(0.5), positiveConfidence(c, 1))
+}
+
+func TestPositiveConfidence_NegativeNearby_ReducesConfidence(t *testing.T) {
+	c := triageCandidate{
+		closestPositive: schema.DigestBytes{0x01}, positiveMetric: 0.5,
+		closestNegative: schema.DigestBytes{0x02}, negativeMetric: 1,
+	}
+	// (1 - 0.5/2) * (1 - 0.5)/1
+	assert.Equal(t, float32(0.375), positiveConfidence(c, 2))
+}
+
+func TestComputeTriageSuggestions_OnlyLikelyPositivesSuggested(t *testing.T) {
+	computedTS := ts("2022-10-10T10:10:10Z")
+	candidates := []triageCandidate{{
+		groupingID:      schema.GroupingID{0xaa},
+		digest:          schema.DigestBytes{0x10},
+		closestPositive: schema.DigestBytes{0x01},
+		positiveMetric:  0.5,
+	}, {
+		groupingID:      schema.GroupingID{0xaa},
+		digest:          schema.DigestBytes{0x11},
+		closestPositive: schema.DigestBytes{0x01},
+		positiveMetric:  0.5,
+		closestNegative: schema.DigestBytes{0x02},
+		negativeMetric:  0.1,
+	}, {
+		groupingID:      schema.GroupingID{0xbb},
+		digest:          schema.DigestBytes{0x12},
+		closestNegative: schema.DigestBytes{0x03},
+		negativeMetric:  0.1,
+	}}
+	assert.Equal(t, []schema.TriageSuggestionRow{{
+		GroupingID:     schema.GroupingID{0xaa},
+		Digest:         schema.DigestBytes{0x10},
+		Label:          schema.LabelPositive,
+		ClosestDigest:  schema.DigestBytes{0x01},
+		Confidence:     0.5,
+		LastComputedTS: computedTS,
+	}}, computeTriageSuggestions(candidates, 1, computedTS))
+}
+
 var beginningOfTime = ts("1970-01-01T00:00:00Z")
 
 func ts(s string) time.Time {
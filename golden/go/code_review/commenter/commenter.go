@@ -5,6 +5,10 @@ package commenter
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
 	"text/template"
 	"time"
 
@@ -14,42 +18,58 @@ import (
 
 	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/golden/go/code_review"
 	"go.skia.org/infra/golden/go/sql"
 	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/types"
 )
 
 const (
 	numRecentOpenCLsMetric = "gold_num_recent_open_cls"
+
+	// defaultMaxListedDigests is used if New is not given a positive number of untriaged digests
+	// to list in a comment.
+	defaultMaxListedDigests = 5
 )
 
 type ReviewSystem struct {
 	ID     string // e.g. "gerrit", "gerrit-internal"
 	Client code_review.Client
+	// InlineImages is true if the CRS renders images linked from a comment, e.g. GitHub Markdown.
+	InlineImages bool
 }
 
 type Impl struct {
-	db              *pgxpool.Pool
-	instanceURL     string
-	messageTemplate *template.Template
-	systems         []ReviewSystem
-	lastCheck       time.Time
-	commitsInWindow int
+	db               *pgxpool.Pool
+	instanceURL      string
+	messageTemplate  *template.Template
+	systems          []ReviewSystem
+	lastCheck        time.Time
+	commitsInWindow  int
+	maxListedDigests int
 }
 
-func New(db *pgxpool.Pool, systems []ReviewSystem, messageTemplate, instanceURL string, windowSize int) (*Impl, error) {
+// New returns a commenter which comments on CLs using the given template. At most
+// maxListedDigests untriaged digests are made available to the template; if it is not positive,
+// a default is used.
+func New(db *pgxpool.Pool, systems []ReviewSystem, messageTemplate, instanceURL string, windowSize, maxListedDigests int) (*Impl, error) {
 	templ, err := template.New("message").Parse(messageTemplate)
 	if err != nil && messageTemplate != "" {
 		return nil, skerr.Wrapf(err, "Message template %q", messageTemplate)
 	}
+	if maxListedDigests <= 0 {
+		maxListedDigests = defaultMaxListedDigests
+	}
 	return &Impl{
-		db:              db,
-		instanceURL:     instanceURL,
-		messageTemplate: templ,
-		systems:         systems,
-		commitsInWindow: windowSize,
+		db:               db,
+		instanceURL:      instanceURL,
+		messageTemplate:  templ,
+		systems:          systems,
+		commitsInWindow:  windowSize,
+		maxListedDigests: maxListedDigests,
 	}, nil
 }
 
@@ -95,6 +115,16 @@ type patchsetInfo struct {
 	patchsetID    string // qualified id
 	order         int
 	numNewDigests int // an approximate count
+	// newDigestsByCorpus is the number of new digests in each corpus.
+	newDigestsByCorpus map[string]int
+	// untriagedDigests are the new digests which are untriaged on this CL.
+	untriagedDigests []untriagedDigest
+}
+
+// untriagedDigest is a new digest produced by a patchset which has not been triaged.
+type untriagedDigest struct {
+	grouping paramtools.Params
+	digest   types.Digest
 }
 
 // getNewestPatchsets returns the newest patchset for each open CL that had new data since the
@@ -153,27 +183,57 @@ func (i *Impl) addNewDigestCounts(ctx context.Context, patchsets []*patchsetInfo
 	for idx := range patchsets {
 		ps := patchsets[idx]
 		eg.Go(func() error {
-			const statement = `SELECT DISTINCT digest
-FROM SecondaryBranchValues WHERE branch_name = $1 AND version_name = $2`
+			// The label on the CL takes precedence over the one on the primary branch.
+			const statement = `SELECT DISTINCT SecondaryBranchValues.digest, Groupings.keys,
+	COALESCE(SecondaryBranchExpectations.label, Expectations.label, 'u')
+FROM SecondaryBranchValues
+JOIN Groupings ON SecondaryBranchValues.grouping_id = Groupings.grouping_id
+LEFT JOIN Expectations ON SecondaryBranchValues.grouping_id = Expectations.grouping_id
+	AND SecondaryBranchValues.digest = Expectations.digest
+LEFT JOIN SecondaryBranchExpectations ON SecondaryBranchExpectations.branch_name = $1
+	AND SecondaryBranchValues.grouping_id = SecondaryBranchExpectations.grouping_id
+	AND SecondaryBranchValues.digest = SecondaryBranchExpectations.digest
+WHERE SecondaryBranchValues.branch_name = $1 AND SecondaryBranchValues.version_name = $2`
 			rows, err := i.db.Query(eCtx, statement, ps.changelistID, ps.patchsetID)
 			if err != nil {
 				return skerr.Wrapf(err, "patchset %#v", *ps)
 			}
 			defer rows.Close()
-			newDigests := 0
+			newDigests := map[schema.MD5Hash]struct{}{}
+			newDigestsByCorpus := map[string]map[schema.MD5Hash]struct{}{}
+			var untriaged []untriagedDigest
 			var digestBytes schema.DigestBytes
 			var digestKey schema.MD5Hash
 			digest := digestKey[:]
 			for rows.Next() {
-				if err := rows.Scan(&digestBytes); err != nil {
+				var grouping paramtools.Params
+				var label schema.ExpectationLabel
+				if err := rows.Scan(&digestBytes, &grouping, &label); err != nil {
 					return skerr.Wrap(err)
 				}
 				copy(digest, digestBytes)
-				if _, ok := digestsOnPrimary[digestKey]; !ok {
-					newDigests++
+				if _, ok := digestsOnPrimary[digestKey]; ok {
+					continue
+				}
+				newDigests[digestKey] = struct{}{}
+				corpus := grouping[types.CorpusField]
+				if newDigestsByCorpus[corpus] == nil {
+					newDigestsByCorpus[corpus] = map[schema.MD5Hash]struct{}{}
 				}
+				newDigestsByCorpus[corpus][digestKey] = struct{}{}
+				if label == schema.LabelUntriaged {
+					untriaged = append(untriaged, untriagedDigest{
+						grouping: grouping,
+						digest:   types.Digest(hex.EncodeToString(digestBytes)),
+					})
+				}
+			}
+			ps.numNewDigests = len(newDigests)
+			ps.newDigestsByCorpus = make(map[string]int, len(newDigestsByCorpus))
+			for corpus, digests := range newDigestsByCorpus {
+				ps.newDigestsByCorpus[corpus] = len(digests)
 			}
-			ps.numNewDigests = newDigests
+			ps.untriagedDigests = untriaged
 			return nil
 		})
 	}
@@ -219,21 +279,27 @@ SELECT DISTINCT digest FROM TiledTraceDigests
 // logs if this commenter is configured to not actually comment.
 func (i *Impl) commentOn(ctx context.Context, ps patchsetInfo) error {
 	clID := sql.Unqualify(ps.changelistID)
-	msg, err := i.untriagedMessage(commentTemplateContext{
-		CRS:           ps.system,
-		ChangelistID:  clID,
-		PatchsetOrder: ps.order,
-		NumNewDigests: ps.numNewDigests,
-	})
-	if err != nil {
-		return skerr.Wrap(err)
-	}
 	var client code_review.Client
+	inlineImages := false
 	for _, c := range i.systems {
 		if c.ID == ps.system {
 			client = c.Client
+			inlineImages = c.InlineImages
 		}
 	}
+	msg, err := i.untriagedMessage(commentTemplateContext{
+		CRS:                 ps.system,
+		ChangelistID:        clID,
+		PatchsetOrder:       ps.order,
+		NumNewDigests:       ps.numNewDigests,
+		NewDigestsByCorpus:  corpusCounts(ps.newDigestsByCorpus),
+		UntriagedDigests:    i.digestLinks(ps.system, clID, ps.untriagedDigests),
+		NumUntriagedDigests: len(ps.untriagedDigests),
+		InlineImages:        inlineImages,
+	})
+	if err != nil {
+		return skerr.Wrap(err)
+	}
 	if client == nil {
 		sklog.Errorf("Could not make comment for system %s - not configured", ps.system)
 		return nil
@@ -270,6 +336,89 @@ type commentTemplateContext struct {
 	InstanceURL   string
 	NumNewDigests int
 	PatchsetOrder int
+	// NewDigestsByCorpus has the number of new digests for each corpus, sorted by corpus.
+	NewDigestsByCorpus []CorpusCount
+	// UntriagedDigests are some of the new digests which are untriaged, sorted by corpus, test
+	// and digest.
+	UntriagedDigests []DigestLink
+	// NumUntriagedDigests is the number of new untriaged digests, which can be more than the
+	// number listed in UntriagedDigests.
+	NumUntriagedDigests int
+	// InlineImages is true if the CRS renders images linked from the comment.
+	InlineImages bool
+}
+
+// CorpusCount is the number of new digests produced in a corpus.
+type CorpusCount struct {
+	Corpus        string
+	NumNewDigests int
+}
+
+// DigestLink describes an untriaged digest and where to see it in Gold.
+type DigestLink struct {
+	Corpus string
+	Test   types.TestName
+	Digest types.Digest
+	// DetailsURL is the details page of the digest in the context of the CL.
+	DetailsURL string
+	// ImageURL is a thumbnail of the digest.
+	ImageURL string
+}
+
+// corpusCounts returns the given counts sorted by corpus.
+func corpusCounts(byCorpus map[string]int) []CorpusCount {
+	rv := make([]CorpusCount, 0, len(byCorpus))
+	for corpus, n := range byCorpus {
+		rv = append(rv, CorpusCount{Corpus: corpus, NumNewDigests: n})
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		return rv[i].Corpus < rv[j].Corpus
+	})
+	return rv
+}
+
+// digestLinks returns links for at most maxListedDigests of the given digests, sorted by corpus,
+// test and digest.
+func (i *Impl) digestLinks(crs, clID string, digests []untriagedDigest) []DigestLink {
+	rv := make([]DigestLink, 0, len(digests))
+	for _, d := range digests {
+		rv = append(rv, DigestLink{
+			Corpus: d.grouping[types.CorpusField],
+			Test:   types.TestName(d.grouping[types.PrimaryKeyField]),
+			Digest: d.digest,
+			DetailsURL: fmt.Sprintf("%s/detail?grouping=%s&digest=%s&changelist_id=%s&crs=%s",
+				i.instanceURL, url.QueryEscape(groupingQuery(d.grouping)), d.digest, clID, crs),
+			ImageURL: fmt.Sprintf("%s/img/images/%s.png?size=128", i.instanceURL, d.digest),
+		})
+	}
+	return sortAndTruncateDigestLinks(rv, i.maxListedDigests)
+}
+
+// sortAndTruncateDigestLinks sorts the given links by corpus, test and digest and returns at most
+// limit of them.
+func sortAndTruncateDigestLinks(links []DigestLink, limit int) []DigestLink {
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Corpus != links[j].Corpus {
+			return links[i].Corpus < links[j].Corpus
+		}
+		if links[i].Test != links[j].Test {
+			return links[i].Test < links[j].Test
+		}
+		return links[i].Digest < links[j].Digest
+	})
+	if len(links) > limit {
+		links = links[:limit]
+	}
+	return links
+}
+
+// groupingQuery encodes the grouping the same way the frontend does in its details page links.
+func groupingQuery(grouping paramtools.Params) string {
+	v := url.Values{}
+	for key, value := range grouping {
+		v.Set(key, value)
+	}
+	return v.Encode()
 }
 
 // untriagedMessage returns a message about untriaged images on the given CL/PS.
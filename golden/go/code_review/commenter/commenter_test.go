@@ -11,12 +11,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/testutils"
 	"go.skia.org/infra/golden/go/code_review"
 	mock_codereview "go.skia.org/infra/golden/go/code_review/mocks"
 	dks "go.skia.org/infra/golden/go/sql/datakitchensink"
 	"go.skia.org/infra/golden/go/sql/schema"
 	"go.skia.org/infra/golden/go/sql/sqltest"
+	"go.skia.org/infra/golden/go/types"
 )
 
 var (
@@ -53,7 +55,7 @@ func TestCommentOnCLs_MultiplePatchsetsNeedComments_CommentsMade(t *testing.T) {
 	c, err := New(db, []ReviewSystem{
 		{ID: dks.GerritCRS, Client: gerritClient},
 		{ID: dks.GerritInternalCRS, Client: gerritInternalClient},
-	}, basicTemplate, instanceURL, 100)
+	}, basicTemplate, instanceURL, 100, 0)
 	require.NoError(t, err)
 
 	c.lastCheck = beforeCLs // Fake this time so both CLs appear in the time window.
@@ -179,7 +181,7 @@ func TestCommentOnCLs_NoPatchsetsNeedComments_Success(t *testing.T) {
 	c, err := New(db, []ReviewSystem{
 		{ID: dks.GerritCRS, Client: nil}, // This test doesn't talk to the clients
 		{ID: dks.GerritInternalCRS, Client: nil},
-	}, basicTemplate, instanceURL, 100)
+	}, basicTemplate, instanceURL, 100, 0)
 	require.NoError(t, err)
 
 	c.lastCheck = beforeCLs // Fake this time so both CLs appear in the time window.
@@ -264,7 +266,7 @@ func TestCommentOnCLs_OnePatchsetNeedsComment_Success(t *testing.T) {
 	c, err := New(db, []ReviewSystem{
 		{ID: dks.GerritCRS, Client: nil},
 		{ID: dks.GerritInternalCRS, Client: gerritInternalClient},
-	}, basicTemplate, instanceURL, 100)
+	}, basicTemplate, instanceURL, 100, 0)
 	require.NoError(t, err)
 
 	c.lastCheck = beforeCLs // Fake this time so both CLs appear in the time window.
@@ -337,7 +339,7 @@ func TestCommentOnCLs_NoCLsInWindow_NothingUpdated(t *testing.T) {
 	c, err := New(db, []ReviewSystem{
 		{ID: dks.GerritCRS, Client: nil}, // This test doesn't talk to the clients
 		{ID: dks.GerritInternalCRS, Client: nil},
-	}, basicTemplate, instanceURL, 100)
+	}, basicTemplate, instanceURL, 100, 0)
 	require.NoError(t, err)
 
 	// Don't fake the time, comments should all be in the distant past
@@ -412,7 +414,7 @@ func TestCommentOnCLs_CLWasAbandoned_DBNotUpdated(t *testing.T) {
 
 	c, err := New(db, []ReviewSystem{
 		{ID: dks.GerritInternalCRS, Client: gerritInternalClient},
-	}, basicTemplate, instanceURL, 100)
+	}, basicTemplate, instanceURL, 100, 0)
 	require.NoError(t, err)
 
 	// Only one CL should appear in the window
@@ -540,7 +542,7 @@ func TestCommentOnCLs_CommentingResultsInError_ErrorLoggedNotReturned(t *testing
 
 	c, err := New(db, []ReviewSystem{
 		{ID: dks.GerritInternalCRS, Client: gerritInternalClient},
-	}, basicTemplate, instanceURL, 100)
+	}, basicTemplate, instanceURL, 100, 0)
 	require.NoError(t, err)
 
 	// Only one CL should appear in the window
@@ -619,7 +621,7 @@ func TestCommentOnCLs_CLNotFound_NoError(t *testing.T) {
 
 	c, err := New(db, []ReviewSystem{
 		{ID: dks.GerritInternalCRS, Client: gerritInternalClient},
-	}, basicTemplate, instanceURL, 100)
+	}, basicTemplate, instanceURL, 100, 0)
 	require.NoError(t, err)
 
 	// Only one CL should appear in the window
@@ -688,3 +690,50 @@ const (
 	basicTemplate = `Gold has detected about {{.NumNewDigests}} new digest(s) on patchset {{.PatchsetOrder}}.
 Please triage them at {{.InstanceURL}}/cl/{{.CRS}}/{{.ChangelistID}}.`
 )
+
+func TestUntriagedMessage_DetailedTemplate_ListsCorporaAndTopDigests(t *testing.T) {
+	c, err := New(nil, nil, detailedTemplate, instanceURL, 100, 2)
+	require.NoError(t, err)
+
+	digests := []untriagedDigest{
+		{grouping: paramtools.Params{types.CorpusField: dks.RoundCorpus, types.PrimaryKeyField: dks.CircleTest}, digest: dks.DigestC03Unt},
+		{grouping: paramtools.Params{types.CorpusField: dks.CornersCorpus, types.PrimaryKeyField: dks.TriangleTest}, digest: dks.DigestB01Pos},
+		{grouping: paramtools.Params{types.CorpusField: dks.CornersCorpus, types.PrimaryKeyField: dks.SquareTest}, digest: dks.DigestA04Unt},
+	}
+	ctx := commentTemplateContext{
+		CRS:                 dks.GerritCRS,
+		ChangelistID:        "CL_fix_ios",
+		PatchsetOrder:       3,
+		NumNewDigests:       4,
+		NewDigestsByCorpus:  corpusCounts(map[string]int{dks.RoundCorpus: 1, dks.CornersCorpus: 3}),
+		UntriagedDigests:    c.digestLinks(dks.GerritCRS, "CL_fix_ios", digests),
+		NumUntriagedDigests: len(digests),
+	}
+	msg, err := c.untriagedMessage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, `Gold has detected about 4 new digest(s) on patchset 3.
+corners: 3
+round: 1
+Untriaged (showing 2 of 3):
+corners/square: gold.skia.org/detail?grouping=name%3Dsquare%26source_type%3Dcorners&digest=a04a04a04a04a04a04a04a04a04a04a0&changelist_id=CL_fix_ios&crs=gerrit
+corners/triangle: gold.skia.org/detail?grouping=name%3Dtriangle%26source_type%3Dcorners&digest=b01b01b01b01b01b01b01b01b01b01b0&changelist_id=CL_fix_ios&crs=gerrit
+`, msg)
+
+	// The same digests are rendered as images if the CRS supports it.
+	ctx.InlineImages = true
+	msg, err = c.untriagedMessage(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, msg, "![corners/square](gold.skia.org/img/images/a04a04a04a04a04a04a04a04a04a04a0.png?size=128)")
+}
+
+func TestNew_NoMaxListedDigests_DefaultUsed(t *testing.T) {
+	c, err := New(nil, nil, basicTemplate, instanceURL, 100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, defaultMaxListedDigests, c.maxListedDigests)
+}
+
+const detailedTemplate = `Gold has detected about {{.NumNewDigests}} new digest(s) on patchset {{.PatchsetOrder}}.
+{{range .NewDigestsByCorpus}}{{.Corpus}}: {{.NumNewDigests}}
+{{end}}Untriaged (showing {{len .UntriagedDigests}} of {{.NumUntriagedDigests}}):
+{{range .UntriagedDigests}}{{if $.InlineImages}}[![{{.Corpus}}/{{.Test}}]({{.ImageURL}})]({{.DetailsURL}}){{else}}{{.Corpus}}/{{.Test}}: {{.DetailsURL}}{{end}}
+{{end}}`
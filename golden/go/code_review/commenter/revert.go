@@ -0,0 +1,250 @@
+package commenter
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opencensus.io/trace"
+
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/golden/go/code_review"
+	"go.skia.org/infra/golden/go/sql"
+	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/types"
+)
+
+const (
+	// DefaultRevertTemplate is used by NewRevertSuggester if no template is given. See
+	// revertTemplateContext for the available fields.
+	DefaultRevertTemplate = `Gold has detected that {{.NumNegativeDigests}} digest(s) produced by this CL were triaged as negative on the primary branch after it landed. Please consider reverting it.
+{{range .NegativeDigests}}{{if $.InlineImages}}[![{{.Corpus}}/{{.Test}}]({{.ImageURL}})]({{.DetailsURL}}){{else}}{{.Corpus}}/{{.Test}}: {{.DetailsURL}}{{end}}
+{{end}}`
+
+	// landedChangelistWindow is how long after Gold last saw data for a landed CL we consider
+	// negatives on the primary branch to have been introduced by it.
+	landedChangelistWindow = 3 * 24 * time.Hour
+)
+
+// RevertSuggester finds landed CLs which produced digests that were then triaged as negative on
+// the primary branch, and suggests on the CRS that those CLs be reverted.
+type RevertSuggester struct {
+	db               *pgxpool.Pool
+	instanceURL      string
+	messageTemplate  *template.Template
+	systems          []ReviewSystem
+	lastCheck        time.Time
+	maxListedDigests int
+}
+
+// NewRevertSuggester returns a RevertSuggester which comments on CLs using the given template, or
+// DefaultRevertTemplate if it is empty. At most maxListedDigests negative digests are made
+// available to the template; if it is not positive, a default is used.
+func NewRevertSuggester(db *pgxpool.Pool, systems []ReviewSystem, messageTemplate, instanceURL string, maxListedDigests int) (*RevertSuggester, error) {
+	if messageTemplate == "" {
+		messageTemplate = DefaultRevertTemplate
+	}
+	templ, err := template.New("revert").Parse(messageTemplate)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Revert template %q", messageTemplate)
+	}
+	if maxListedDigests <= 0 {
+		maxListedDigests = defaultMaxListedDigests
+	}
+	return &RevertSuggester{
+		db:               db,
+		instanceURL:      instanceURL,
+		messageTemplate:  templ,
+		systems:          systems,
+		maxListedDigests: maxListedDigests,
+	}, nil
+}
+
+// negativeDigest is a digest produced by a landed CL which was triaged as negative on the primary
+// branch.
+type negativeDigest struct {
+	grouping paramtools.Params
+	digest   types.Digest
+}
+
+// landedChangelist is a landed CL and the negative digests it produced.
+type landedChangelist struct {
+	system       string
+	changelistID string // qualified id
+	negatives    []negativeDigest
+}
+
+// SuggestRevertsForNewNegatives looks at the digests which were triaged as negative on the primary
+// branch since the last check. For every recently landed CL which produced some of them, it
+// comments on the CL with the offending tests and images, suggesting that it be reverted. The
+// first check looks back over the given duration.
+func (r *RevertSuggester) SuggestRevertsForNewNegatives(ctx context.Context, firstLookback time.Duration) error {
+	ctx, span := trace.StartSpan(ctx, "commenter_SuggestRevertsForNewNegatives")
+	defer span.End()
+	if r.lastCheck.IsZero() {
+		r.lastCheck = now.Now(ctx).Add(-firstLookback)
+	}
+	checkUntil := now.Now(ctx)
+	cls, err := r.getChangelistsWithNewNegatives(ctx, r.lastCheck, checkUntil)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	for _, cl := range cls {
+		if err := r.suggestRevert(ctx, cl); err != nil {
+			sklog.Warningf("Could not suggest revert of CL %s: %s", cl.changelistID, err)
+			// Continue anyway - don't let one problematic CL stop the rest.
+		}
+	}
+	r.lastCheck = checkUntil
+	return nil
+}
+
+// getChangelistsWithNewNegatives returns the recently landed CLs which produced digests that were
+// triaged as negative on the primary branch in the given time range, and which are still negative.
+// Digests which were already triaged as negative on the CL itself are skipped, as landing those
+// was deliberate (and they are copied to the primary branch when the CL lands).
+func (r *RevertSuggester) getChangelistsWithNewNegatives(ctx context.Context, since, until time.Time) ([]*landedChangelist, error) {
+	ctx, span := trace.StartSpan(ctx, "getChangelistsWithNewNegatives")
+	defer span.End()
+	const statement = `WITH
+NewNegatives AS (
+	SELECT DISTINCT ExpectationDeltas.grouping_id, ExpectationDeltas.digest
+	FROM ExpectationRecords JOIN ExpectationDeltas
+		ON ExpectationRecords.expectation_record_id = ExpectationDeltas.expectation_record_id
+	WHERE ExpectationRecords.branch_name IS NULL AND ExpectationRecords.triage_time > $1
+		AND ExpectationRecords.triage_time <= $2 AND ExpectationDeltas.label_after = 'n'
+),
+StillNegative AS (
+	SELECT NewNegatives.grouping_id, NewNegatives.digest FROM NewNegatives
+	JOIN Expectations ON NewNegatives.grouping_id = Expectations.grouping_id
+		AND NewNegatives.digest = Expectations.digest
+	WHERE Expectations.label = 'n'
+),
+RecentlyLandedChangelists AS (
+	SELECT changelist_id, system FROM Changelists
+	WHERE status = 'landed' AND last_ingested_data > $3
+),
+ProducedByLandedChangelists AS (
+	SELECT DISTINCT system, SecondaryBranchValues.branch_name, SecondaryBranchValues.grouping_id,
+		SecondaryBranchValues.digest
+	FROM StillNegative
+	JOIN SecondaryBranchValues ON StillNegative.grouping_id = SecondaryBranchValues.grouping_id
+		AND StillNegative.digest = SecondaryBranchValues.digest
+	JOIN RecentlyLandedChangelists
+		ON SecondaryBranchValues.branch_name = RecentlyLandedChangelists.changelist_id
+)
+SELECT system, ProducedByLandedChangelists.branch_name, Groupings.keys, ProducedByLandedChangelists.digest
+FROM ProducedByLandedChangelists
+JOIN Groupings ON ProducedByLandedChangelists.grouping_id = Groupings.grouping_id
+LEFT JOIN SecondaryBranchExpectations
+	ON ProducedByLandedChangelists.branch_name = SecondaryBranchExpectations.branch_name
+	AND ProducedByLandedChangelists.grouping_id = SecondaryBranchExpectations.grouping_id
+	AND ProducedByLandedChangelists.digest = SecondaryBranchExpectations.digest
+WHERE SecondaryBranchExpectations.label IS NULL OR SecondaryBranchExpectations.label != 'n'
+ORDER BY ProducedByLandedChangelists.branch_name`
+	rows, err := r.db.Query(ctx, statement, since, until, until.Add(-landedChangelistWindow))
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	defer rows.Close()
+	var rv []*landedChangelist
+	for rows.Next() {
+		var system, clID string
+		var grouping paramtools.Params
+		var digest schema.DigestBytes
+		if err := rows.Scan(&system, &clID, &grouping, &digest); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		if len(rv) == 0 || rv[len(rv)-1].changelistID != clID {
+			rv = append(rv, &landedChangelist{system: system, changelistID: clID})
+		}
+		cl := rv[len(rv)-1]
+		cl.negatives = append(cl.negatives, negativeDigest{
+			grouping: grouping,
+			digest:   types.Digest(hex.EncodeToString(digest)),
+		})
+	}
+	return rv, nil
+}
+
+// suggestRevert comments on the given CL that it should be reverted.
+func (r *RevertSuggester) suggestRevert(ctx context.Context, cl *landedChangelist) error {
+	clID := sql.Unqualify(cl.changelistID)
+	var client code_review.Client
+	inlineImages := false
+	for _, c := range r.systems {
+		if c.ID == cl.system {
+			client = c.Client
+			inlineImages = c.InlineImages
+		}
+	}
+	if client == nil {
+		sklog.Errorf("Could not suggest revert for system %s - not configured", cl.system)
+		return nil
+	}
+	msg, err := r.revertMessage(revertTemplateContext{
+		CRS:                cl.system,
+		ChangelistID:       clID,
+		NegativeDigests:    r.digestLinks(cl.negatives),
+		NumNegativeDigests: len(cl.negatives),
+		InlineImages:       inlineImages,
+	})
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	sklog.Infof("Suggesting revert of CL %s, which produced %d digests now triaged negative", clID, len(cl.negatives))
+	if err := client.CommentOn(ctx, clID, msg); err != nil {
+		return skerr.Wrapf(err, "commenting on %s CL %s", cl.system, clID)
+	}
+	return nil
+}
+
+// revertTemplateContext contains the fields that can be substituted into the revert template.
+type revertTemplateContext struct {
+	ChangelistID string
+	CRS          string
+	InstanceURL  string
+	// NegativeDigests are some of the digests produced by the CL which were triaged as negative on
+	// the primary branch, sorted by corpus, test and digest.
+	NegativeDigests []DigestLink
+	// NumNegativeDigests is the number of such digests, which can be more than the number listed
+	// in NegativeDigests.
+	NumNegativeDigests int
+	// InlineImages is true if the CRS renders images linked from the comment.
+	InlineImages bool
+}
+
+// digestLinks returns links to the primary branch details pages of at most maxListedDigests of the
+// given digests, sorted by corpus, test and digest.
+func (r *RevertSuggester) digestLinks(digests []negativeDigest) []DigestLink {
+	rv := make([]DigestLink, 0, len(digests))
+	for _, d := range digests {
+		rv = append(rv, DigestLink{
+			Corpus: d.grouping[types.CorpusField],
+			Test:   types.TestName(d.grouping[types.PrimaryKeyField]),
+			Digest: d.digest,
+			DetailsURL: fmt.Sprintf("%s/detail?grouping=%s&digest=%s",
+				r.instanceURL, url.QueryEscape(groupingQuery(d.grouping)), d.digest),
+			ImageURL: fmt.Sprintf("%s/img/images/%s.png?size=128", r.instanceURL, d.digest),
+		})
+	}
+	return sortAndTruncateDigestLinks(rv, r.maxListedDigests)
+}
+
+// revertMessage returns a message suggesting the revert of a CL.
+func (r *RevertSuggester) revertMessage(c revertTemplateContext) (string, error) {
+	c.InstanceURL = r.instanceURL
+	var b bytes.Buffer
+	if err := r.messageTemplate.Execute(&b, c); err != nil {
+		return "", skerr.Wrapf(err, "With template context %#v", c)
+	}
+	return b.String(), nil
+}
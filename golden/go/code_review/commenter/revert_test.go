@@ -0,0 +1,139 @@
+package commenter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/testutils"
+	mock_codereview "go.skia.org/infra/golden/go/code_review/mocks"
+	"go.skia.org/infra/golden/go/sql"
+	dks "go.skia.org/infra/golden/go/sql/datakitchensink"
+	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/sql/sqltest"
+	"go.skia.org/infra/golden/go/types"
+)
+
+var (
+	squareGrouping = paramtools.Params{types.CorpusField: dks.CornersCorpus, types.PrimaryKeyField: dks.SquareTest}
+
+	// landedCLIngested is when we pretend Gold last saw data for dks.ChangelistIDThatHasLanded.
+	landedCLIngested = time.Date(2020, time.December, 12, 0, 0, 0, 0, time.UTC)
+)
+
+func TestSuggestReverts_LandedCLProducedNewNegative_CommentMade(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+	markLandedCLIngestedAt(ctx, t, db, landedCLIngested)
+	// The landed CL produced DigestA01Pos, which someone now decides is wrong.
+	triageOnPrimaryBranch(ctx, t, db, squareGrouping, dks.DigestA01Pos, schema.LabelPositive, schema.LabelNegative, "2020-12-12T10:00:00Z")
+
+	gerritClient := &mock_codereview.Client{}
+	gerritClient.On("CommentOn", testutils.AnyContext, dks.ChangelistIDThatHasLanded,
+		`Gold has detected that 1 digest(s) produced by this CL were triaged as negative on the primary branch after it landed. Please consider reverting it.
+corners/square: gold.skia.org/detail?grouping=name%3Dsquare%26source_type%3Dcorners&digest=a01a01a01a01a01a01a01a01a01a01a0
+`).Return(nil).Once()
+
+	r, err := NewRevertSuggester(db, []ReviewSystem{{ID: dks.GerritCRS, Client: gerritClient}}, "", instanceURL, 0)
+	require.NoError(t, err)
+	ctx = context.WithValue(ctx, now.ContextKey, time.Date(2020, time.December, 12, 11, 0, 0, 0, time.UTC))
+	require.NoError(t, r.SuggestRevertsForNewNegatives(ctx, 24*time.Hour))
+	gerritClient.AssertExpectations(t)
+
+	// The same negative is not reported again.
+	ctx = context.WithValue(ctx, now.ContextKey, time.Date(2020, time.December, 12, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, r.SuggestRevertsForNewNegatives(ctx, 24*time.Hour))
+	gerritClient.AssertExpectations(t)
+}
+
+func TestSuggestReverts_NegativeTriagedBeforeLastCheck_NoComment(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+	markLandedCLIngestedAt(ctx, t, db, landedCLIngested)
+	triageOnPrimaryBranch(ctx, t, db, squareGrouping, dks.DigestA01Pos, schema.LabelPositive, schema.LabelNegative, "2020-12-12T10:00:00Z")
+
+	gerritClient := &mock_codereview.Client{}
+
+	r, err := NewRevertSuggester(db, []ReviewSystem{{ID: dks.GerritCRS, Client: gerritClient}}, "", instanceURL, 0)
+	require.NoError(t, err)
+	ctx = context.WithValue(ctx, now.ContextKey, time.Date(2020, time.December, 12, 11, 0, 0, 0, time.UTC))
+	require.NoError(t, r.SuggestRevertsForNewNegatives(ctx, 30*time.Minute))
+	gerritClient.AssertExpectations(t)
+}
+
+func TestSuggestReverts_NegativeTriagedBackToPositive_NoComment(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+	markLandedCLIngestedAt(ctx, t, db, landedCLIngested)
+	triageOnPrimaryBranch(ctx, t, db, squareGrouping, dks.DigestA01Pos, schema.LabelPositive, schema.LabelNegative, "2020-12-12T10:00:00Z")
+	triageOnPrimaryBranch(ctx, t, db, squareGrouping, dks.DigestA01Pos, schema.LabelNegative, schema.LabelPositive, "2020-12-12T10:05:00Z")
+
+	gerritClient := &mock_codereview.Client{}
+
+	r, err := NewRevertSuggester(db, []ReviewSystem{{ID: dks.GerritCRS, Client: gerritClient}}, "", instanceURL, 0)
+	require.NoError(t, err)
+	ctx = context.WithValue(ctx, now.ContextKey, time.Date(2020, time.December, 12, 11, 0, 0, 0, time.UTC))
+	require.NoError(t, r.SuggestRevertsForNewNegatives(ctx, 24*time.Hour))
+	gerritClient.AssertExpectations(t)
+}
+
+func TestRevertMessage_InlineImages_ListsTopDigests(t *testing.T) {
+	r, err := NewRevertSuggester(nil, nil, "", instanceURL, 1)
+	require.NoError(t, err)
+
+	negatives := []negativeDigest{
+		{grouping: paramtools.Params{types.CorpusField: dks.RoundCorpus, types.PrimaryKeyField: dks.CircleTest}, digest: dks.DigestC03Unt},
+		{grouping: squareGrouping, digest: dks.DigestA01Pos},
+	}
+	msg, err := r.revertMessage(revertTemplateContext{
+		CRS:                "github",
+		ChangelistID:       "1234",
+		NegativeDigests:    r.digestLinks(negatives),
+		NumNegativeDigests: len(negatives),
+		InlineImages:       true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `Gold has detected that 2 digest(s) produced by this CL were triaged as negative on the primary branch after it landed. Please consider reverting it.
+[![corners/square](gold.skia.org/img/images/a01a01a01a01a01a01a01a01a01a01a0.png?size=128)](gold.skia.org/detail?grouping=name%3Dsquare%26source_type%3Dcorners&digest=a01a01a01a01a01a01a01a01a01a01a0)
+`, msg)
+}
+
+func TestNewRevertSuggester_InvalidTemplate_ReturnsError(t *testing.T) {
+	_, err := NewRevertSuggester(nil, nil, "{{.NoClosingBraces", instanceURL, 0)
+	require.Error(t, err)
+}
+
+func markLandedCLIngestedAt(ctx context.Context, t *testing.T, db *pgxpool.Pool, ts time.Time) {
+	_, err := db.Exec(ctx, `UPDATE Changelists SET last_ingested_data = $1 WHERE changelist_id = $2`,
+		ts, sql.Qualify(dks.GerritCRS, dks.ChangelistIDThatHasLanded))
+	require.NoError(t, err)
+}
+
+func triageOnPrimaryBranch(ctx context.Context, t *testing.T, db *pgxpool.Pool, grouping paramtools.Params, digest types.Digest, labelBefore, labelAfter schema.ExpectationLabel, ts string) {
+	row := db.QueryRow(ctx, `INSERT INTO ExpectationRecords (user_name, triage_time, num_changes)
+VALUES ($1, $2, 1) RETURNING expectation_record_id`, dks.UserOne, ts)
+	var recordID uuid.UUID
+	require.NoError(t, row.Scan(&recordID))
+	digestBytes, err := sql.DigestToBytes(digest)
+	require.NoError(t, err)
+	_, groupingID := sql.SerializeMap(grouping)
+	_, err = db.Exec(ctx, `INSERT INTO ExpectationDeltas (expectation_record_id, grouping_id, digest, label_before, label_after)
+VALUES ($1, $2, $3, $4, $5)`, recordID, groupingID, digestBytes, labelBefore, labelAfter)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `UPSERT INTO Expectations (grouping_id, digest, label, expectation_record_id)
+VALUES ($1, $2, $3, $4)`, groupingID, digestBytes, labelAfter, recordID)
+	require.NoError(t, err)
+}
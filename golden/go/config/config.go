@@ -13,6 +13,7 @@ import (
 	"go.skia.org/infra/go/config"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/golden/go/diff"
 )
 
 // CacheType defines the available types for caches.
@@ -104,6 +105,15 @@ type Common struct {
 
 	// Caching frequency in minutes.
 	CachingFrequencyMinutes int `json:"caching_frequency_minutes" optional:"true"`
+
+	// Maximum number of cache shards (one per corpus and cache type) to build at once. Higher
+	// values populate the cache faster but use more memory. If unset, a default is used.
+	CachingMaxConcurrentShards int `json:"caching_max_concurrent_shards" optional:"true"`
+
+	// DiffThresholdsByCorpus is an optional map from corpus name to the thresholds under which
+	// differences between two images of that corpus are considered negligible. Corpora not in
+	// this map have no negligible differences.
+	DiffThresholdsByCorpus map[string]diff.Thresholds `json:"diff_thresholds_by_corpus" optional:"true"`
 }
 
 // GetCacheClient returns a cache client based on the configuration.
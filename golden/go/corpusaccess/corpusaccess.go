@@ -0,0 +1,216 @@
+// Package corpusaccess restricts who may view and triage the data of individual corpora, so that a
+// single Gold instance can host corpora with different audiences (e.g. an internal corpus
+// alongside public ones). Corpora without a Rule are not restricted by this package.
+package corpusaccess
+
+import (
+	"context"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"go.skia.org/infra/go/allowed"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/golden/go/types"
+)
+
+// Rule lists who may access a corpus. Entries are email addresses (e.g. "someone@example.com") or
+// domains (e.g. "example.com"), as accepted by allowed.NewAllowedFromList.
+type Rule struct {
+	// Viewers may see the traces, digests and images of the corpus.
+	Viewers []string `json:"viewers"`
+	// Triagers may additionally change the expectations of the corpus. Triagers are implicitly
+	// viewers, and still need to have the editor role to triage.
+	Triagers []string `json:"triagers" optional:"true"`
+}
+
+// Rules maps corpus names to the Rule for that corpus.
+type Rules map[string]Rule
+
+// recentChangelistWindow is how far back to look for CLs when finding the digests produced by the
+// restricted corpora. Digests which were only produced on older CLs are not restricted.
+const recentChangelistWindow = 30 * 24 * time.Hour
+
+// Checker answers whether a user may view or triage a corpus. A nil *Checker restricts nothing.
+type Checker struct {
+	viewers  map[string]allowed.Allow
+	triagers map[string]allowed.Allow
+
+	mutex sync.RWMutex
+	// restrictedDigests maps the digests produced by the restricted corpora to those corpora.
+	restrictedDigests map[types.Digest][]string
+}
+
+// CheckerFromRules validates the given rules and returns a Checker which enforces them.
+func CheckerFromRules(rules Rules) (*Checker, error) {
+	c := &Checker{
+		viewers:  make(map[string]allowed.Allow, len(rules)),
+		triagers: make(map[string]allowed.Allow, len(rules)),
+	}
+	for corpus, rule := range rules {
+		if corpus == "" {
+			return nil, skerr.Fmt("empty corpus name in access rules")
+		}
+		if len(rule.Viewers) == 0 && len(rule.Triagers) == 0 {
+			return nil, skerr.Fmt("access rule for corpus %q must list at least one viewer or triager", corpus)
+		}
+		triagers := allowed.NewAllowedFromList(rule.Triagers)
+		c.triagers[corpus] = triagers
+		c.viewers[corpus] = allowed.UnionOf(allowed.NewAllowedFromList(rule.Viewers), triagers)
+	}
+	return c, nil
+}
+
+// IsRestricted returns true if the given corpus has an access rule.
+func (c *Checker) IsRestricted(corpus string) bool {
+	if c == nil {
+		return false
+	}
+	_, ok := c.viewers[corpus]
+	return ok
+}
+
+// RestrictedCorpora returns the sorted names of all corpora with an access rule.
+func (c *Checker) RestrictedCorpora() []string {
+	if c == nil {
+		return nil
+	}
+	rv := make([]string, 0, len(c.viewers))
+	for corpus := range c.viewers {
+		rv = append(rv, corpus)
+	}
+	sort.Strings(rv)
+	return rv
+}
+
+// CanView returns true if the given user may see the data of the given corpus. The user is empty
+// if nobody is logged in.
+func (c *Checker) CanView(user, corpus string) bool {
+	if c == nil {
+		return true
+	}
+	a, ok := c.viewers[corpus]
+	return !ok || (user != "" && a.Member(user))
+}
+
+// CanTriage returns true if the given user may change the expectations of the given corpus.
+func (c *Checker) CanTriage(user, corpus string) bool {
+	if c == nil {
+		return true
+	}
+	a, ok := c.triagers[corpus]
+	return !ok || (user != "" && a.Member(user))
+}
+
+// IsRestrictedDigest returns true if the given digest was produced by a restricted corpus, as of
+// the last update of the restricted digests.
+func (c *Checker) IsRestrictedDigest(digest types.Digest) bool {
+	if c == nil {
+		return false
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	_, ok := c.restrictedDigests[digest]
+	return ok
+}
+
+// CanViewDigest returns true if the given user may see the image with the given digest. Digests
+// which were produced by a restricted corpus may only be seen by viewers of (one of) those
+// corpora, even if an unrestricted corpus produced the same digest.
+func (c *Checker) CanViewDigest(user string, digest types.Digest) bool {
+	if c == nil {
+		return true
+	}
+	c.mutex.RLock()
+	corpora, ok := c.restrictedDigests[digest]
+	c.mutex.RUnlock()
+	if !ok {
+		return true
+	}
+	for _, corpus := range corpora {
+		if c.CanView(user, corpus) {
+			return true
+		}
+	}
+	return false
+}
+
+// StartUpdatingRestrictedDigests loads the digests produced by the restricted corpora on the
+// primary branch and on recent CLs, and then keeps them up to date in the background. It returns
+// an error if the initial load fails. It does nothing if no corpus is restricted.
+func (c *Checker) StartUpdatingRestrictedDigests(ctx context.Context, db *pgxpool.Pool, interval time.Duration) error {
+	if c == nil || len(c.viewers) == 0 {
+		return nil
+	}
+	cycle := func(ctx context.Context) error {
+		digests, err := c.getRestrictedDigests(ctx, db)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.restrictedDigests = digests
+		return nil
+	}
+	if err := cycle(ctx); err != nil {
+		return skerr.Wrapf(err, "initializing restricted digests")
+	}
+	sklog.Infof("Successfully initialized restricted digests.")
+
+	go util.RepeatCtx(ctx, interval, func(ctx context.Context) {
+		if err := cycle(ctx); err != nil {
+			sklog.Warningf("Could not update restricted digests: %s", err)
+		}
+	})
+	return nil
+}
+
+// SetRestrictedDigestsForTesting replaces the digests produced by the restricted corpora.
+func (c *Checker) SetRestrictedDigestsForTesting(digests map[types.Digest][]string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.restrictedDigests = digests
+}
+
+// getRestrictedDigests returns the digests produced by each restricted corpus. Every digest seen
+// on the primary branch has a row in the Expectations table, even if it is untriaged. Digests seen
+// only on CLs are looked up in the data of the CLs which were updated recently.
+func (c *Checker) getRestrictedDigests(ctx context.Context, db *pgxpool.Pool) (map[types.Digest][]string, error) {
+	const statement = `WITH
+RestrictedGroupings AS (
+	SELECT grouping_id, keys->>'source_type' AS corpus FROM Groupings
+	WHERE keys->>'source_type' = ANY($1)
+),
+RecentChangelists AS (
+	SELECT changelist_id FROM Changelists WHERE last_ingested_data > $2
+)
+SELECT DISTINCT corpus, digest FROM RestrictedGroupings
+JOIN Expectations ON RestrictedGroupings.grouping_id = Expectations.grouping_id
+UNION
+SELECT DISTINCT corpus, digest FROM RestrictedGroupings
+JOIN SecondaryBranchValues ON RestrictedGroupings.grouping_id = SecondaryBranchValues.grouping_id
+WHERE branch_name IN (SELECT changelist_id FROM RecentChangelists)`
+	since := now.Now(ctx).Add(-recentChangelistWindow)
+	rows, err := db.Query(ctx, statement, c.RestrictedCorpora(), since)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	defer rows.Close()
+	rv := map[types.Digest][]string{}
+	for rows.Next() {
+		var corpus string
+		var digest []byte
+		if err := rows.Scan(&corpus, &digest); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		d := types.Digest(hex.EncodeToString(digest))
+		rv[d] = append(rv[d], corpus)
+	}
+	return rv, nil
+}
@@ -0,0 +1,95 @@
+package corpusaccess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/now"
+	dks "go.skia.org/infra/golden/go/sql/datakitchensink"
+	"go.skia.org/infra/golden/go/sql/sqltest"
+)
+
+const (
+	internalViewer  = "viewer@example.com"
+	internalTriager = "triager@example.com"
+	googler         = "someone@google.com"
+)
+
+func testRules() Rules {
+	return Rules{
+		dks.RoundCorpus: {
+			Viewers:  []string{internalViewer, "google.com"},
+			Triagers: []string{internalTriager},
+		},
+	}
+}
+
+func TestCheckerFromRules_InvalidRules_ReturnsError(t *testing.T) {
+	_, err := CheckerFromRules(Rules{"": {Viewers: []string{internalViewer}}})
+	assert.Error(t, err)
+	_, err = CheckerFromRules(Rules{dks.RoundCorpus: {}})
+	assert.Error(t, err)
+}
+
+func TestCanView_RestrictedCorpus_OnlyViewersAndTriagers(t *testing.T) {
+	c, err := CheckerFromRules(testRules())
+	require.NoError(t, err)
+
+	assert.True(t, c.CanView(internalViewer, dks.RoundCorpus))
+	assert.True(t, c.CanView(internalTriager, dks.RoundCorpus))
+	assert.True(t, c.CanView(googler, dks.RoundCorpus))
+	assert.False(t, c.CanView("someone@example.com", dks.RoundCorpus))
+	assert.False(t, c.CanView("", dks.RoundCorpus))
+
+	assert.True(t, c.CanView("", dks.CornersCorpus))
+	assert.True(t, c.CanView("someone@example.com", dks.CornersCorpus))
+}
+
+func TestCanTriage_RestrictedCorpus_OnlyTriagers(t *testing.T) {
+	c, err := CheckerFromRules(testRules())
+	require.NoError(t, err)
+
+	assert.True(t, c.CanTriage(internalTriager, dks.RoundCorpus))
+	assert.False(t, c.CanTriage(internalViewer, dks.RoundCorpus))
+	assert.False(t, c.CanTriage(googler, dks.RoundCorpus))
+	assert.False(t, c.CanTriage("", dks.RoundCorpus))
+
+	assert.True(t, c.CanTriage(internalViewer, dks.CornersCorpus))
+}
+
+func TestChecker_Nil_RestrictsNothing(t *testing.T) {
+	var c *Checker
+	assert.False(t, c.IsRestricted(dks.RoundCorpus))
+	assert.Empty(t, c.RestrictedCorpora())
+	assert.True(t, c.CanView("", dks.RoundCorpus))
+	assert.True(t, c.CanTriage("", dks.RoundCorpus))
+	assert.True(t, c.CanViewDigest("", dks.DigestC01Pos))
+	assert.NoError(t, c.StartUpdatingRestrictedDigests(context.Background(), nil, time.Minute))
+}
+
+func TestStartUpdatingRestrictedDigests_PrimaryBranchAndCLDigestsRestricted(t *testing.T) {
+	ctx := context.WithValue(context.Background(), now.ContextKey, time.Date(2020, time.December, 14, 0, 0, 0, 0, time.UTC))
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+
+	c, err := CheckerFromRules(testRules())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	require.NoError(t, c.StartUpdatingRestrictedDigests(ctx, db, time.Hour))
+
+	// Seen on the primary branch.
+	assert.True(t, c.IsRestrictedDigest(dks.DigestC01Pos))
+	assert.True(t, c.CanViewDigest(internalViewer, dks.DigestC01Pos))
+	assert.False(t, c.CanViewDigest("someone@example.com", dks.DigestC01Pos))
+	// Only seen on a CL.
+	assert.True(t, c.IsRestrictedDigest(dks.DigestC07Unt_CL))
+	assert.False(t, c.CanViewDigest("", dks.DigestC07Unt_CL))
+	// Produced by a corpus without restrictions.
+	assert.False(t, c.IsRestrictedDigest(dks.DigestA01Pos))
+	assert.True(t, c.CanViewDigest("", dks.DigestA01Pos))
+}
@@ -45,6 +45,19 @@ var (
 	}
 )
 
+// maxDelta is the largest Manhattan distance between two RGBA colors.
+const maxDelta = 4 * 255
+
+// deltaOffsets caches deltaOffset for every possible difference between two colors, as computing
+// the logarithm dominates diffing images which differ in many pixels.
+var deltaOffsets = func() [maxDelta + 1]int {
+	var rv [maxDelta + 1]int
+	for n := 1; n <= maxDelta; n++ {
+		rv[n] = deltaOffset(n)
+	}
+	return rv
+}()
+
 // Returns the offset into the color slices (pixelDiffColor,
 // or pixelAlphaDiffColor) based on the delta passed in.
 //
@@ -102,6 +115,26 @@ func CombinedDiffMetric(channelDiffs [4]int, pixelDiffPercent float32) float32 {
 	return float32(math.Sqrt(float64(pixelDiffPercent) * normalizedRGBA))
 }
 
+// Thresholds are the largest differences between two images that are still considered
+// negligible. Some corpora (e.g. text rendering) need stricter thresholds than others (e.g.
+// photos), so these are typically configured per corpus.
+type Thresholds struct {
+	// MaxRGBADiff is the largest difference allowed in any single channel.
+	MaxRGBADiff int `json:"max_rgba_diff"`
+
+	// MaxPixelDiffPercent is the largest percentage of pixels that are allowed to differ.
+	MaxPixelDiffPercent float32 `json:"max_pixel_diff_percent"`
+}
+
+// IsNegligible returns true if a diff with the given metrics falls within the thresholds. Images
+// with different dimensions are never considered to have a negligible difference.
+func (t Thresholds) IsNegligible(maxRGBADiffs [4]int, pixelDiffPercent float32, dimDiffer bool) bool {
+	if dimDiffer {
+		return false
+	}
+	return util.MaxInt(maxRGBADiffs[:]...) <= t.MaxRGBADiff && pixelDiffPercent <= t.MaxPixelDiffPercent
+}
+
 // getPixelDiffPercent returns the percentage of pixels that differ, as a float between 0 and 100
 // (inclusive).
 func getPixelDiffPercent(numDiffPixels, totalPixels int) float32 {
@@ -214,43 +247,10 @@ func PixelDiff(img1, img2 image.Image) (*DiffMetrics, *image.NRGBA) {
 	p1 := GetNRGBA(img1).Pix
 	p2 := GetNRGBA(img2).Pix
 	// Compare the bounds, if they are the same then use this fast path.
-	// We cast to uint64 to compare 2 pixels at a time, so we also require
-	// an even number of pixels here.  If that's a big deal, we can easily
-	// fix that up, handling the straggler pixel separately at the end.
+	// We require an even number of pixels here so that the pure-Go
+	// implementation can compare 2 pixels at a time.
 	if img1Bounds.Eq(img2Bounds) && len(p1)%8 == 0 {
-		numDiffPixels = 0
-		// Note the += 8.  We're checking two pixels at a time here.
-		for i := 0; i < len(p1); i += 8 {
-			// Most pixels we compare will be the same, so from here to
-			// the 'continue' is the hot path in all this code.
-			rgba_2x := (*uint64)(unsafe.Pointer(&p1[i]))
-			RGBA_2x := (*uint64)(unsafe.Pointer(&p2[i]))
-			if *rgba_2x == *RGBA_2x {
-				continue
-			}
-
-			// When off == 0, we check the first pixel of the pair; when 4, the second.
-			for off := 0; off <= 4; off += 4 {
-				r, g, b, a := p1[off+i+0], p1[off+i+1], p1[off+i+2], p1[off+i+3]
-				R, G, B, A := p2[off+i+0], p2[off+i+1], p2[off+i+2], p2[off+i+3]
-				if r != R || g != G || b != B || a != A {
-					numDiffPixels++
-					dr := util.AbsInt(int(r) - int(R))
-					dg := util.AbsInt(int(g) - int(G))
-					db := util.AbsInt(int(b) - int(B))
-					da := util.AbsInt(int(a) - int(A))
-					maxRGBADiffs[0] = util.MaxInt(dr, maxRGBADiffs[0])
-					maxRGBADiffs[1] = util.MaxInt(dg, maxRGBADiffs[1])
-					maxRGBADiffs[2] = util.MaxInt(db, maxRGBADiffs[2])
-					maxRGBADiffs[3] = util.MaxInt(da, maxRGBADiffs[3])
-					if dr+dg+db > 0 {
-						copy(resultImg.Pix[off+i:], pixelDiffColor[deltaOffset(dr+dg+db+da)])
-					} else {
-						copy(resultImg.Pix[off+i:], pixelAlphaDiffColor[deltaOffset(da)])
-					}
-				}
-			}
-		}
+		numDiffPixels, maxRGBADiffs = diffSameSize(p1, p2, resultImg.Pix)
 	} else {
 		// Set pixels outside of the comparison area with the maximum diff color.
 		maxDiffColor := uint8ToColor(pixelDiffColor[deltaOffset(1024)])
@@ -279,6 +279,54 @@ func PixelDiff(img1, img2 image.Image) (*DiffMetrics, *image.NRGBA) {
 		DimDiffer:        (cmpWidth != resultWidth) || (cmpHeight != resultHeight)}, resultImg
 }
 
+// diffSameSizeGeneric is the pure-Go implementation of diffSameSize. It compares the pixels of two
+// images with the same bounds, given as the Pix of *image.NRGBA with a length that is a multiple of
+// 8, and writes the diff colors into resultPix, which must be at least as long. It returns the
+// number of pixels which differ and the maximum difference of each channel.
+func diffSameSizeGeneric(p1, p2, resultPix []uint8) (int, [4]int) {
+	numDiffPixels := 0
+	maxRGBADiffs := [4]int{0, 0, 0, 0}
+	// Note the += 8.  We're checking two pixels at a time here.
+	for i := 0; i < len(p1); i += 8 {
+		// Most pixels we compare will be the same, so from here to
+		// the 'continue' is the hot path in all this code.
+		rgba_2x := (*uint64)(unsafe.Pointer(&p1[i]))
+		RGBA_2x := (*uint64)(unsafe.Pointer(&p2[i]))
+		if *rgba_2x == *RGBA_2x {
+			continue
+		}
+
+		// Check the first pixel of the pair, then the second.
+		diffPixel(p1, p2, resultPix, i, &numDiffPixels, &maxRGBADiffs)
+		diffPixel(p1, p2, resultPix, i+4, &numDiffPixels, &maxRGBADiffs)
+	}
+	return numDiffPixels, maxRGBADiffs
+}
+
+// diffPixel compares the pixels starting at offset i in p1 and p2. If they differ, it increments
+// numDiffPixels, updates maxRGBADiffs and writes the diff color into resultPix.
+func diffPixel(p1, p2, resultPix []uint8, i int, numDiffPixels *int, maxRGBADiffs *[4]int) {
+	r, g, b, a := p1[i+0], p1[i+1], p1[i+2], p1[i+3]
+	R, G, B, A := p2[i+0], p2[i+1], p2[i+2], p2[i+3]
+	if r == R && g == G && b == B && a == A {
+		return
+	}
+	*numDiffPixels++
+	dr := util.AbsInt(int(r) - int(R))
+	dg := util.AbsInt(int(g) - int(G))
+	db := util.AbsInt(int(b) - int(B))
+	da := util.AbsInt(int(a) - int(A))
+	maxRGBADiffs[0] = util.MaxInt(dr, maxRGBADiffs[0])
+	maxRGBADiffs[1] = util.MaxInt(dg, maxRGBADiffs[1])
+	maxRGBADiffs[2] = util.MaxInt(db, maxRGBADiffs[2])
+	maxRGBADiffs[3] = util.MaxInt(da, maxRGBADiffs[3])
+	if dr+dg+db > 0 {
+		copy(resultPix[i:], pixelDiffColor[deltaOffsets[dr+dg+db+da]])
+	} else {
+		copy(resultPix[i:], pixelAlphaDiffColor[deltaOffsets[da]])
+	}
+}
+
 type Calculator interface {
 	// CalculateDiffs recomputes all diffs for the current grouping, including any digests provided.
 	CalculateDiffs(ctx context.Context, grouping paramtools.Params, additional []types.Digest) error
@@ -0,0 +1,8 @@
+//go:build !(amd64 && simddiff)
+
+package diff
+
+// diffSameSize compares the pixels of two images with the same bounds. See diffSameSizeGeneric.
+func diffSameSize(p1, p2, resultPix []uint8) (int, [4]int) {
+	return diffSameSizeGeneric(p1, p2, resultPix)
+}
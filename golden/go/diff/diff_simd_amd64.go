@@ -0,0 +1,88 @@
+//go:build amd64 && simddiff
+
+package diff
+
+import (
+	"encoding/binary"
+
+	"go.skia.org/infra/go/util"
+)
+
+// This file contains an implementation of diffSameSize which uses SSE2 (available on every amd64
+// CPU) to compare 16 bytes, i.e. 4 pixels, at a time. Runs of identical pixels are skipped 64
+// bytes at a time, and the channel differences of runs of differing pixels are computed in bulk
+// before being turned into diff colors. Build with `-tags simddiff` (or
+// `--@io_bazel_rules_go//go/config:tags=simddiff` under Bazel) to use it.
+
+// equalPrefixBlocks returns the length of the longest common prefix of a and b which consists of
+// whole 16-byte blocks. b must be at least as long as a. Implemented in diff_simd_amd64.s.
+//
+//go:noescape
+func equalPrefixBlocks(a, b []uint8) int
+
+// diffBlocks writes the absolute differences between the bytes of a and b into deltas, 16 bytes at
+// a time, until it reaches a block of 16 bytes which are identical in a and b or fewer than 16
+// bytes remain. maxDeltas is updated with the maximum difference seen at each offset in a block. It
+// returns the number of bytes written. b and deltas must be at least as long as a. Implemented in
+// diff_simd_amd64.s.
+//
+//go:noescape
+func diffBlocks(a, b, deltas []uint8, maxDeltas *[16]uint8) int
+
+// diffSameSize compares the pixels of two images with the same bounds. See diffSameSizeGeneric.
+// resultPix must be zeroed, i.e. contain only pixelMatchColor.
+func diffSameSize(p1, p2, resultPix []uint8) (int, [4]int) {
+	numDiffPixels := 0
+	var maxDeltas [16]uint8
+	// blocksEnd is the end of the part of the images made of whole 16-byte blocks.
+	blocksEnd := len(p1) &^ 15
+	for i := 0; i < blocksEnd; {
+		i += equalPrefixBlocks(p1[i:blocksEnd], p2[i:blocksEnd])
+		n := diffBlocks(p1[i:blocksEnd], p2[i:blocksEnd], resultPix[i:blocksEnd], &maxDeltas)
+		numDiffPixels += deltasToColors(resultPix[i : i+n])
+		i += n
+	}
+	maxRGBADiffs := [4]int{0, 0, 0, 0}
+	for i, d := range maxDeltas {
+		maxRGBADiffs[i%4] = util.MaxInt(maxRGBADiffs[i%4], int(d))
+	}
+	for i := blocksEnd; i < len(p1); i += 4 {
+		diffPixel(p1, p2, resultPix, i, &numDiffPixels, &maxRGBADiffs)
+	}
+	return numDiffPixels, maxRGBADiffs
+}
+
+// diffColorsByDelta and alphaDiffColorsByDelta contain the colors chosen by diffPixel for every
+// possible Manhattan distance between two colors and alpha difference respectively, as
+// little-endian uint32s. A difference of zero maps to pixelMatchColor.
+var diffColorsByDelta, alphaDiffColorsByDelta = func() ([maxDelta + 1]uint32, [256]uint32) {
+	var diffColors [maxDelta + 1]uint32
+	var alphaDiffColors [256]uint32
+	for n := 1; n <= maxDelta; n++ {
+		diffColors[n] = binary.LittleEndian.Uint32(pixelDiffColor[deltaOffsets[n]])
+		if n < len(alphaDiffColors) {
+			alphaDiffColors[n] = binary.LittleEndian.Uint32(pixelAlphaDiffColor[deltaOffsets[n]])
+		}
+	}
+	return diffColors, alphaDiffColors
+}()
+
+// deltasToColors replaces the absolute channel differences of each pixel in pix with the
+// corresponding diff color, as chosen by diffPixel. It returns the number of pixels which differ.
+func deltasToColors(pix []uint8) int {
+	numDiffPixels := 0
+	for i := 0; i+4 <= len(pix); i += 4 {
+		// This is branch-free (apart from a conditional move), as whether pixels differ is hard
+		// to predict. Identical pixels have d == 0, which maps to pixelMatchColor.
+		d := binary.LittleEndian.Uint32(pix[i:])
+		numDiffPixels += int((d | -d) >> 31)
+		rgb := d&0xff + d>>8&0xff + d>>16&0xff
+		da := d >> 24
+		c := alphaDiffColorsByDelta[da]
+		if rgb > 0 {
+			c = diffColorsByDelta[rgb+da]
+		}
+		binary.LittleEndian.PutUint32(pix[i:], c)
+	}
+	return numDiffPixels
+}
@@ -0,0 +1,95 @@
+//go:build amd64 && simddiff
+
+#include "textflag.h"
+
+// func equalPrefixBlocks(a, b []uint8) int
+TEXT ·equalPrefixBlocks(SB), NOSPLIT, $0-56
+	MOVQ a_base+0(FP), SI
+	MOVQ a_len+8(FP), CX
+	MOVQ b_base+24(FP), DI
+	XORQ AX, AX
+
+	// Compare 64 bytes per iteration while at least that many remain.
+	MOVQ CX, BX
+	ANDQ $-64, BX
+
+loop64:
+	CMPQ AX, BX
+	JAE  tail
+	MOVOU (SI)(AX*1), X0
+	MOVOU 16(SI)(AX*1), X1
+	MOVOU 32(SI)(AX*1), X2
+	MOVOU 48(SI)(AX*1), X3
+	MOVOU (DI)(AX*1), X4
+	MOVOU 16(DI)(AX*1), X5
+	MOVOU 32(DI)(AX*1), X6
+	MOVOU 48(DI)(AX*1), X7
+	PCMPEQB X4, X0
+	PCMPEQB X5, X1
+	PCMPEQB X6, X2
+	PCMPEQB X7, X3
+	PAND X1, X0
+	PAND X3, X2
+	PAND X2, X0
+	PMOVMSKB X0, DX
+	CMPL DX, $0xffff
+	JNE  tail
+	ADDQ $64, AX
+	JMP  loop64
+
+tail:
+	// Find the first differing 16 byte block, or stop when fewer than 16 bytes remain.
+	ANDQ $-16, CX
+
+loop16:
+	CMPQ AX, CX
+	JAE  done
+	MOVOU (SI)(AX*1), X0
+	MOVOU (DI)(AX*1), X1
+	PCMPEQB X1, X0
+	PMOVMSKB X0, DX
+	CMPL DX, $0xffff
+	JNE  done
+	ADDQ $16, AX
+	JMP  loop16
+
+done:
+	MOVQ AX, ret+48(FP)
+	RET
+
+// func diffBlocks(a, b, deltas []uint8, maxDeltas *[16]uint8) int
+TEXT ·diffBlocks(SB), NOSPLIT, $0-88
+	MOVQ a_base+0(FP), SI
+	MOVQ a_len+8(FP), CX
+	MOVQ b_base+24(FP), DI
+	MOVQ deltas_base+48(FP), R8
+	MOVQ maxDeltas+72(FP), R9
+	MOVOU (R9), X7
+	ANDQ $-16, CX
+	XORQ AX, AX
+
+loop:
+	CMPQ AX, CX
+	JAE  done
+	MOVOU (SI)(AX*1), X0
+	MOVOU (DI)(AX*1), X1
+	MOVO X0, X2
+	PCMPEQB X1, X2
+	PMOVMSKB X2, DX
+	CMPL DX, $0xffff
+	JEQ  done
+
+	// |a-b| is the OR of the two saturating differences, as one of them is always zero.
+	MOVO X0, X3
+	PSUBUSB X1, X3
+	PSUBUSB X0, X1
+	POR X3, X1
+	MOVOU X1, (R8)(AX*1)
+	PMAXUB X1, X7
+	ADDQ $16, AX
+	JMP  loop
+
+done:
+	MOVOU X7, (R9)
+	MOVQ AX, ret+80(FP)
+	RET
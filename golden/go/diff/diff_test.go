@@ -5,6 +5,7 @@ import (
 	"image"
 	"image/png"
 	"math"
+	"math/rand"
 	"strings"
 	"testing"
 
@@ -222,6 +223,44 @@ func TestCombinedDiffMetric(t *testing.T) {
 	assert.InDelta(t, math.Sqrt(0.5), CombinedDiffMetric([4]int{255, 255, 255, 255}, 0.5), 0.000001)
 }
 
+func TestThresholds_IsNegligible(t *testing.T) {
+	th := Thresholds{MaxRGBADiff: 5, MaxPixelDiffPercent: 0.5}
+	assert.True(t, th.IsNegligible([4]int{0, 0, 0, 0}, 0, false))
+	assert.True(t, th.IsNegligible([4]int{5, 1, 0, 0}, 0.5, false))
+	assert.False(t, th.IsNegligible([4]int{1, 6, 0, 0}, 0.1, false))
+	assert.False(t, th.IsNegligible([4]int{1, 1, 0, 0}, 0.6, false))
+	assert.False(t, th.IsNegligible([4]int{0, 0, 0, 0}, 0, true))
+
+	// The zero value only treats identical images as negligible.
+	assert.True(t, Thresholds{}.IsNegligible([4]int{0, 0, 0, 0}, 0, false))
+	assert.False(t, Thresholds{}.IsNegligible([4]int{1, 0, 0, 0}, 0.01, false))
+}
+
+func TestDiffSameSize_MatchesGeneric(t *testing.T) {
+	r := rand.New(rand.NewSource(1234))
+	// The sizes exercise both the 64 and 16 byte blocks of the optimized implementation, as well as
+	// the tail which is shorter than a block.
+	for _, numPixels := range []int{2, 4, 6, 16, 18, 34, 1000} {
+		for _, numChanged := range []int{0, 1, 3, numPixels} {
+			p1 := make([]uint8, numPixels*4)
+			r.Read(p1)
+			p2 := make([]uint8, len(p1))
+			copy(p2, p1)
+			for i := 0; i < numChanged; i++ {
+				p2[r.Intn(len(p2))] = uint8(r.Intn(256))
+			}
+
+			expectedPix := make([]uint8, len(p1))
+			expectedNum, expectedMax := diffSameSizeGeneric(p1, p2, expectedPix)
+			actualPix := make([]uint8, len(p1))
+			actualNum, actualMax := diffSameSize(p1, p2, actualPix)
+			assert.Equal(t, expectedNum, actualNum, "%d pixels, %d changed", numPixels, numChanged)
+			assert.Equal(t, expectedMax, actualMax, "%d pixels, %d changed", numPixels, numChanged)
+			assert.Equal(t, expectedPix, actualPix, "%d pixels, %d changed", numPixels, numChanged)
+		}
+	}
+}
+
 func benchmarkDiff(b *testing.B, img1, img2 image.Image) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -247,6 +286,48 @@ func BenchmarkDiffDifferentSize(b *testing.B) {
 	benchmarkDiff(b, openNRGBAFromFile(b, img1), openNRGBAFromFile(b, img3))
 }
 
+// make4KImages returns two 3840x2160 images which differ in the given fraction of pixels.
+func make4KImages(diffFraction float64) (*image.NRGBA, *image.NRGBA) {
+	r := rand.New(rand.NewSource(1234))
+	img1 := image.NewNRGBA(image.Rect(0, 0, 3840, 2160))
+	r.Read(img1.Pix)
+	img2 := image.NewNRGBA(img1.Rect)
+	copy(img2.Pix, img1.Pix)
+	numPixels := len(img2.Pix) / 4
+	for i := 0; i < int(float64(numPixels)*diffFraction); i++ {
+		img2.Pix[r.Intn(numPixels)*4] ^= 0xff
+	}
+	return img1, img2
+}
+
+func benchmarkDiffSameSize(b *testing.B, diffFn func(p1, p2, resultPix []uint8) (int, [4]int), diffFraction float64) {
+	img1, img2 := make4KImages(diffFraction)
+	resultPix := make([]uint8, len(img1.Pix))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		diffFn(img1.Pix, img2.Pix, resultPix)
+	}
+}
+
+// The 4K benchmarks compare diffSameSize, which is optimized when building with the simddiff tag,
+// against the pure-Go implementation.
+
+func BenchmarkDiffSameSize_4KIdentical(b *testing.B) {
+	benchmarkDiffSameSize(b, diffSameSize, 0)
+}
+
+func BenchmarkDiffSameSizeGeneric_4KIdentical(b *testing.B) {
+	benchmarkDiffSameSize(b, diffSameSizeGeneric, 0)
+}
+
+func BenchmarkDiffSameSize_4KSparseDiffs(b *testing.B) {
+	benchmarkDiffSameSize(b, diffSameSize, 0.001)
+}
+
+func BenchmarkDiffSameSizeGeneric_4KSparseDiffs(b *testing.B) {
+	benchmarkDiffSameSize(b, diffSameSizeGeneric, 0.001)
+}
+
 // openNRGBAFromFile opens the given file path to a PNG file and returns the image as image.NRGBA.
 func openNRGBAFromFile(t testing.TB, fileName string) *image.NRGBA {
 	b := testutils.ReadFileBytes(t, fileName)
@@ -0,0 +1,91 @@
+// Package diffcache contains a cache of the results of diffing two images. It is meant to be
+// backed by a distributed cache (e.g. Redis), so that the results survive restarts and are shared
+// between all the processes which compute diffs.
+package diffcache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.skia.org/infra/go/cache"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/golden/go/diff"
+	"go.skia.org/infra/golden/go/types"
+)
+
+// keyPrefix is prepended to all keys, so the diff results do not collide with other data in a
+// cache that is shared with other parts of Gold.
+const keyPrefix = "gold_diff"
+
+// Key identifies the result of diffing two images with a metric. Diffs are symmetric, so the order
+// of the two digests does not matter.
+type Key struct {
+	Left   types.Digest
+	Right  types.Digest
+	Metric string
+}
+
+// String returns the cache key for the diff result.
+func (k Key) String() string {
+	left, right := k.Left, k.Right
+	if right < left {
+		left, right = right, left
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", keyPrefix, k.Metric, left, right)
+}
+
+// Result is the cached result of diffing two images.
+type Result struct {
+	Metrics diff.DiffMetrics `json:"metrics"`
+	// DiffImageDigest is the digest of the stored diff image, or empty if the diff image was not
+	// stored.
+	DiffImageDigest types.Digest `json:"diff_image_digest,omitempty"`
+}
+
+// Cache stores the results of diffing two images.
+type Cache interface {
+	// Get returns the cached result for the given key, or nil if there is none.
+	Get(ctx context.Context, key Key) (*Result, error)
+
+	// Set caches the result for the given key.
+	Set(ctx context.Context, key Key, result Result) error
+}
+
+// CacheImpl implements Cache on top of a cache.Cache, e.g. one backed by Redis.
+type CacheImpl struct {
+	cache cache.Cache
+}
+
+// New returns a Cache which stores the results in the given cache.Cache.
+func New(c cache.Cache) *CacheImpl {
+	return &CacheImpl{cache: c}
+}
+
+// Get implements the Cache interface.
+func (c *CacheImpl) Get(ctx context.Context, key Key) (*Result, error) {
+	value, err := c.cache.GetValue(ctx, key.String())
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading diff result %s", key)
+	}
+	if value == "" {
+		return nil, nil
+	}
+	var rv Result
+	if err := json.Unmarshal([]byte(value), &rv); err != nil {
+		return nil, skerr.Wrapf(err, "decoding diff result %s", key)
+	}
+	return &rv, nil
+}
+
+// Set implements the Cache interface.
+func (c *CacheImpl) Set(ctx context.Context, key Key, result Result) error {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	return skerr.Wrapf(c.cache.SetValue(ctx, key.String(), string(b)), "writing diff result %s", key)
+}
+
+// Make sure CacheImpl fulfills the Cache interface.
+var _ Cache = (*CacheImpl)(nil)
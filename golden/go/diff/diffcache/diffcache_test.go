@@ -0,0 +1,57 @@
+package diffcache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/cache/local"
+	"go.skia.org/infra/golden/go/diff"
+	dks "go.skia.org/infra/golden/go/sql/datakitchensink"
+)
+
+func TestKey_String_IndependentOfDigestOrder(t *testing.T) {
+	k1 := Key{Left: dks.DigestA01Pos, Right: dks.DigestA02Pos, Metric: diff.CombinedMetricName}
+	k2 := Key{Left: dks.DigestA02Pos, Right: dks.DigestA01Pos, Metric: diff.CombinedMetricName}
+	assert.Equal(t, "gold_diff:combined:a01a01a01a01a01a01a01a01a01a01a0:a02a02a02a02a02a02a02a02a02a02a0", k1.String())
+	assert.Equal(t, k1.String(), k2.String())
+
+	k3 := Key{Left: dks.DigestA01Pos, Right: dks.DigestA02Pos, Metric: diff.SSIMMetricName}
+	assert.NotEqual(t, k1.String(), k3.String())
+}
+
+func TestCacheImpl_SetThenGet_ReturnsResult(t *testing.T) {
+	ctx := context.Background()
+	lc, err := local.New(10)
+	require.NoError(t, err)
+	c := New(lc)
+
+	key := Key{Left: dks.DigestA01Pos, Right: dks.DigestA02Pos, Metric: diff.CombinedMetricName}
+	result := Result{
+		Metrics: diff.DiffMetrics{
+			NumDiffPixels:    12,
+			PixelDiffPercent: 0.5,
+			MaxRGBADiffs:     [4]int{1, 2, 3, 4},
+			CombinedMetric:   0.25,
+		},
+		DiffImageDigest: dks.DigestBlank,
+	}
+	require.NoError(t, c.Set(ctx, key, result))
+
+	actual, err := c.Get(ctx, Key{Left: dks.DigestA02Pos, Right: dks.DigestA01Pos, Metric: diff.CombinedMetricName})
+	require.NoError(t, err)
+	assert.Equal(t, &result, actual)
+}
+
+func TestCacheImpl_Get_NotCached_ReturnsNil(t *testing.T) {
+	ctx := context.Background()
+	lc, err := local.New(10)
+	require.NoError(t, err)
+	c := New(lc)
+
+	actual, err := c.Get(ctx, Key{Left: dks.DigestA01Pos, Right: dks.DigestA02Pos, Metric: diff.CombinedMetricName})
+	require.NoError(t, err)
+	assert.Nil(t, actual)
+}
@@ -0,0 +1,160 @@
+package diff
+
+import (
+	"image"
+	"math"
+	"sort"
+	"sync"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+const (
+	// CombinedMetricName is the name of the default Metric, see CombinedDiffMetric.
+	CombinedMetricName = "combined"
+
+	// SSIMMetricName is the name of the Metric based on the structural similarity (SSIM) of the
+	// two images, which is less sensitive than pixel-wise metrics to noise like anti-aliasing
+	// differences that humans don't care about.
+	SSIMMetricName = "ssim"
+
+	// ssimWindowSize is the width and height of the windows over which SSIM is computed.
+	ssimWindowSize = 8
+
+	// maxMetricValue is the largest value a Metric can return.
+	maxMetricValue = 10
+)
+
+// Metric computes a single value that represents how different two images are. This value is
+// stored as the CombinedMetric of the diff and is what is used to find the closest reference
+// images to a digest.
+type Metric interface {
+	// Compute returns a value in [0, 10] for the given images, where 0 means they are identical.
+	// The pixel-wise metrics in m have already been computed for the two images.
+	Compute(left, right *image.NRGBA, m *DiffMetrics) float32
+}
+
+// MetricFunc is an adapter to allow using ordinary functions as a Metric.
+type MetricFunc func(left, right *image.NRGBA, m *DiffMetrics) float32
+
+// Compute implements Metric.
+func (f MetricFunc) Compute(left, right *image.NRGBA, m *DiffMetrics) float32 {
+	return f(left, right, m)
+}
+
+var (
+	// registeredMetricsMutex protects registeredMetrics.
+	registeredMetricsMutex sync.RWMutex
+
+	registeredMetrics = map[string]Metric{
+		CombinedMetricName: MetricFunc(func(_, _ *image.NRGBA, m *DiffMetrics) float32 {
+			return CombinedDiffMetric(m.MaxRGBADiffs, m.PixelDiffPercent)
+		}),
+		SSIMMetricName: MetricFunc(SSIMDiffMetric),
+	}
+)
+
+// RegisterMetric makes the given Metric available under the given name, e.g. to be selected in
+// the config of an instance. It returns an error if the name is already in use.
+func RegisterMetric(name string, m Metric) error {
+	registeredMetricsMutex.Lock()
+	defer registeredMetricsMutex.Unlock()
+	if _, ok := registeredMetrics[name]; ok {
+		return skerr.Fmt("a metric named %q is already registered", name)
+	}
+	registeredMetrics[name] = m
+	return nil
+}
+
+// GetMetric returns the Metric registered with the given name.
+func GetMetric(name string) (Metric, error) {
+	registeredMetricsMutex.RLock()
+	defer registeredMetricsMutex.RUnlock()
+	m, ok := registeredMetrics[name]
+	if !ok {
+		return nil, skerr.Fmt("unknown metric %q, must be one of %q", name, metricNames())
+	}
+	return m, nil
+}
+
+// metricNames returns the sorted names of the registered metrics. The caller must hold
+// registeredMetricsMutex.
+func metricNames() []string {
+	rv := make([]string, 0, len(registeredMetrics))
+	for name := range registeredMetrics {
+		rv = append(rv, name)
+	}
+	sort.Strings(rv)
+	return rv
+}
+
+// SSIMDiffMetric returns a value in [0, 10] based on the mean structural similarity (SSIM) of the
+// luminance of the two images, computed over non-overlapping windows. Images with different
+// dimensions are considered to be as different as possible. Implements the MetricFunc signature.
+func SSIMDiffMetric(left, right *image.NRGBA, m *DiffMetrics) float32 {
+	if m.DimDiffer {
+		return maxMetricValue
+	}
+	if m.NumDiffPixels == 0 {
+		return 0
+	}
+	bounds := left.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	ws := ssimWindowSize
+	if width < ws || height < ws {
+		ws = width
+		if height < ws {
+			ws = height
+		}
+	}
+	var total float64
+	windows := 0
+	for y := 0; y+ws <= height; y += ws {
+		for x := 0; x+ws <= width; x += ws {
+			total += ssimWindow(left, right, x, y, ws)
+			windows++
+		}
+	}
+	if windows == 0 {
+		return 0
+	}
+	// SSIM is in [-1, 1], with 1 meaning identical, so map it onto [0, 10].
+	v := (1 - total/float64(windows)) * maxMetricValue / 2
+	return float32(math.Max(0, math.Min(maxMetricValue, v)))
+}
+
+// ssimWindow returns the SSIM of the square window of size ws at (x0, y0) in both images.
+func ssimWindow(left, right *image.NRGBA, x0, y0, ws int) float64 {
+	const (
+		c1 = (0.01 * 255) * (0.01 * 255)
+		c2 = (0.03 * 255) * (0.03 * 255)
+	)
+	n := float64(ws * ws)
+	var sumL, sumR, sumLL, sumRR, sumLR float64
+	for y := y0; y < y0+ws; y++ {
+		for x := x0; x < x0+ws; x++ {
+			l := luminance(left, x, y)
+			r := luminance(right, x, y)
+			sumL += l
+			sumR += r
+			sumLL += l * l
+			sumRR += r * r
+			sumLR += l * r
+		}
+	}
+	meanL, meanR := sumL/n, sumR/n
+	varL := sumLL/n - meanL*meanL
+	varR := sumRR/n - meanR*meanR
+	covar := sumLR/n - meanL*meanR
+	return ((2*meanL*meanR + c1) * (2*covar + c2)) /
+		((meanL*meanL + meanR*meanR + c1) * (varL + varR + c2))
+}
+
+// luminance returns the luma of the pixel at (x, y), composited over black, in [0, 255].
+func luminance(img *image.NRGBA, x, y int) float64 {
+	b := img.Bounds()
+	i := img.PixOffset(b.Min.X+x, b.Min.Y+y)
+	p := img.Pix[i : i+4]
+	luma := 0.299*float64(p[0]) + 0.587*float64(p[1]) + 0.114*float64(p[2])
+	return luma * float64(p[3]) / 255
+}
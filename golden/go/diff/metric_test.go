@@ -0,0 +1,99 @@
+package diff
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkerboard returns a 16x16 image with 4x4 black and white squares.
+func checkerboard() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			c := color.NRGBA{R: 0, G: 0, B: 0, A: 255}
+			if (x/4+y/4)%2 == 0 {
+				c = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestSSIMDiffMetric_IdenticalImages_ReturnsZero(t *testing.T) {
+	img := checkerboard()
+	m, _ := PixelDiff(img, img)
+	assert.Equal(t, float32(0), SSIMDiffMetric(img, img, m))
+}
+
+func TestSSIMDiffMetric_DimensionsDiffer_ReturnsMax(t *testing.T) {
+	left := checkerboard()
+	right := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	m, _ := PixelDiff(left, right)
+	assert.Equal(t, float32(10), SSIMDiffMetric(left, right, m))
+}
+
+func TestSSIMDiffMetric_NoiseIsSmallerThanStructuralChange(t *testing.T) {
+	left := checkerboard()
+
+	// Slightly change the edge pixels of every square, like anti-aliasing would.
+	noisy := checkerboard()
+	for y := 0; y < 16; y++ {
+		for x := 3; x < 16; x += 4 {
+			c := noisy.NRGBAAt(x, y)
+			if c.R == 255 {
+				c.R, c.G, c.B = 240, 240, 240
+			} else {
+				c.R, c.G, c.B = 15, 15, 15
+			}
+			noisy.SetNRGBA(x, y, c)
+		}
+	}
+
+	// Invert one of the squares.
+	structural := checkerboard()
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			structural.SetNRGBA(x, y, color.NRGBA{R: 0, G: 0, B: 0, A: 255})
+		}
+	}
+
+	noisyMetrics, _ := PixelDiff(left, noisy)
+	structuralMetrics, _ := PixelDiff(left, structural)
+	// The noise touches more pixels than the structural change...
+	require.Greater(t, noisyMetrics.PixelDiffPercent, structuralMetrics.PixelDiffPercent)
+	// ... but is considered a much smaller difference by SSIM.
+	noisySSIM := SSIMDiffMetric(left, noisy, noisyMetrics)
+	structuralSSIM := SSIMDiffMetric(left, structural, structuralMetrics)
+	assert.Greater(t, noisySSIM, float32(0))
+	assert.Less(t, noisySSIM, structuralSSIM)
+	assert.LessOrEqual(t, structuralSSIM, float32(10))
+}
+
+func TestGetMetric_Combined_MatchesCombinedDiffMetric(t *testing.T) {
+	m, err := GetMetric(CombinedMetricName)
+	require.NoError(t, err)
+	dm := &DiffMetrics{MaxRGBADiffs: [4]int{255, 255, 255, 255}, PixelDiffPercent: 1}
+	assert.Equal(t, CombinedDiffMetric(dm.MaxRGBADiffs, dm.PixelDiffPercent), m.Compute(nil, nil, dm))
+}
+
+func TestGetMetric_UnknownName_ReturnsError(t *testing.T) {
+	_, err := GetMetric("not-a-metric")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "combined")
+}
+
+func TestRegisterMetric_NewAndDuplicateNames(t *testing.T) {
+	constant := MetricFunc(func(_, _ *image.NRGBA, _ *DiffMetrics) float32 { return 3 })
+	require.NoError(t, RegisterMetric("test-constant", constant))
+	require.Error(t, RegisterMetric("test-constant", constant))
+	require.Error(t, RegisterMetric(SSIMMetricName, constant))
+
+	m, err := GetMetric("test-constant")
+	require.NoError(t, err)
+	assert.Equal(t, float32(3), m.Compute(nil, nil, &DiffMetrics{}))
+}
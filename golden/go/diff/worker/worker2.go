@@ -3,6 +3,7 @@ package worker
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -25,6 +26,9 @@ import (
 	"go.skia.org/infra/go/sql/sqlutil"
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/golden/go/diff"
+	"go.skia.org/infra/golden/go/diff/diffcache"
+	"go.skia.org/infra/golden/go/image/codec"
+	"go.skia.org/infra/golden/go/image/pyramid"
 	"go.skia.org/infra/golden/go/sql"
 	"go.skia.org/infra/golden/go/sql/schema"
 	"go.skia.org/infra/golden/go/types"
@@ -52,6 +56,14 @@ const (
 	// This batch size corresponds to tens of seconds worth of computation. If we are
 	// interrupted, we hope not to lose more than this amount of work.
 	reportingBatchSize = 25
+
+	// A relatively small number of distinct diff images (e.g. fully transparent ones) account for
+	// most diffs, so we remember which ones we have already stored to avoid uploading them again.
+	storedDiffImageCacheSize = 10_000
+
+	// We remember which digests we have already stored thumbnails for, so that digests which are
+	// diffed over and over again are not downscaled and uploaded each time.
+	storedThumbnailCacheSize = 100_000
 )
 
 // ImageSource is an abstraction around a way to load the images. If images are stored in GCS, or
@@ -61,15 +73,56 @@ type ImageSource interface {
 	GetImage(ctx context.Context, digest types.Digest) ([]byte, error)
 }
 
+// DiffImageStore is an abstraction around a way to store diff images. Diff images are content
+// addressed, i.e. named by the MD5 hash of their PNG encoding, so identical diff images produced
+// by different pairs of images are only stored once.
+type DiffImageStore interface {
+	// WriteDiffImage stores the PNG-encoded diff image with the given digest. It should do
+	// nothing if an image with that digest has already been stored.
+	WriteDiffImage(ctx context.Context, digest types.Digest, png []byte) error
+}
+
+// ThumbnailStore is an abstraction around a way to store precomputed thumbnails of images, so the
+// UI can show them without downloading and downscaling the full-size images.
+type ThumbnailStore interface {
+	// WriteThumbnail stores the encoded thumbnail of the image with the given digest, downscaled
+	// to the given size (one of pyramid.ThumbnailSizes). It should do nothing if that thumbnail
+	// has already been stored.
+	WriteThumbnail(ctx context.Context, digest types.Digest, size int, b []byte) error
+}
+
 type WorkerImpl struct {
 	db              *pgxpool.Pool
 	imageSource     ImageSource
 	badDigestsCache *ttlcache.Cache
 	windowSize      int
 
+	// diffImageStore is nil if diff images should not be stored.
+	diffImageStore DiffImageStore
+	// storedDiffImages contains the digests of diff images known to be in diffImageStore.
+	storedDiffImages *lru.Cache
+
+	// thumbnailStore is nil if thumbnails should not be stored.
+	thumbnailStore ThumbnailStore
+	// storedThumbnails contains the digests of images whose thumbnails are known to be in
+	// thumbnailStore.
+	storedThumbnails *lru.Cache
+
+	// metricsByCorpus overrides the name of the metric used to compute the CombinedMetric of diffs
+	// for some corpora.
+	metricsByCorpus map[string]string
+
+	// resultCache is nil if diff results should not be cached.
+	resultCache diffcache.Cache
+
 	inputDigestsSummary      metrics2.Float64SummaryMetric
 	digestsOfInterestSummary metrics2.Float64SummaryMetric
 	metricsCalculatedCounter metrics2.Counter
+	diffImagesWrittenCounter metrics2.Counter
+	diffImagesDedupedCounter metrics2.Counter
+	thumbnailsWrittenCounter metrics2.Counter
+	resultCacheHitCounter    metrics2.Counter
+	resultCacheMissCounter   metrics2.Counter
 }
 
 // New returns a diff worker which uses the provided ImageSource.
@@ -82,9 +135,60 @@ func New(db *pgxpool.Pool, src ImageSource, windowSize int) *WorkerImpl {
 		metricsCalculatedCounter: metrics2.GetCounter("diffcalculator_metricscalculated"),
 		inputDigestsSummary:      metrics2.GetFloat64SummaryMetric("diffcalculator_inputdigests"),
 		digestsOfInterestSummary: metrics2.GetFloat64SummaryMetric("diffcalculator_digestsofinterest"),
+		diffImagesWrittenCounter: metrics2.GetCounter("diffcalculator_diffimages_written"),
+		diffImagesDedupedCounter: metrics2.GetCounter("diffcalculator_diffimages_deduplicated"),
+		thumbnailsWrittenCounter: metrics2.GetCounter("diffcalculator_thumbnails_written"),
+		resultCacheHitCounter:    metrics2.GetCounter("diffcalculator_resultcache_hits"),
+		resultCacheMissCounter:   metrics2.GetCounter("diffcalculator_resultcache_misses"),
 	}
 }
 
+// WithDiffImageStore makes the worker store the diff image of each pair of images it compares
+// and record the diff image's digest in the DiffMetrics table. This requires the
+// diff_image_digest column, which may need to be added to existing databases with:
+//
+//	ALTER TABLE DiffMetrics ADD COLUMN IF NOT EXISTS diff_image_digest BYTES;
+func (w *WorkerImpl) WithDiffImageStore(store DiffImageStore) *WorkerImpl {
+	c, err := lru.New(storedDiffImageCacheSize)
+	if err != nil {
+		panic(err) // Only happens if the size is not positive.
+	}
+	w.diffImageStore = store
+	w.storedDiffImages = c
+	return w
+}
+
+// WithThumbnailStore makes the worker store thumbnails of each image it downloads, in each of
+// pyramid.ThumbnailSizes, so that they can be served without downscaling the full-size image.
+func (w *WorkerImpl) WithThumbnailStore(store ThumbnailStore) *WorkerImpl {
+	c, err := lru.New(storedThumbnailCacheSize)
+	if err != nil {
+		panic(err) // Only happens if the size is not positive.
+	}
+	w.thumbnailStore = store
+	w.storedThumbnails = c
+	return w
+}
+
+// WithMetricsByCorpus makes the worker use the metric with the given name (see
+// diff.RegisterMetric), instead of diff.CombinedDiffMetric, to compute the CombinedMetric of diffs
+// in the given corpora. Because the DiffMetrics table is keyed by the pair of digests only, a
+// digest that is shared between corpora will have the metric of whichever corpus it was last
+// diffed for.
+func (w *WorkerImpl) WithMetricsByCorpus(m map[string]string) *WorkerImpl {
+	w.metricsByCorpus = m
+	return w
+}
+
+// WithResultCache makes the worker look up the result of diffing two images in the given cache
+// before downloading and diffing them, and store the results it computes there. Unlike the
+// DiffMetrics table, which only holds the diffs of digests that are currently of interest, the
+// cache is keyed by metric and can be shared between instances and survives restarts.
+func (w *WorkerImpl) WithResultCache(c diffcache.Cache) *WorkerImpl {
+	w.resultCache = c
+	return w
+}
+
 // CalculateDiffs calculates the diffs for the given grouping. It either computes all of the diffs
 // if there are only "a few" digests, otherwise it computes a subset of them, taking into account
 // recency and triage status.
@@ -94,6 +198,13 @@ func (w *WorkerImpl) CalculateDiffs(ctx context.Context, grouping paramtools.Par
 		addMetadata(span, grouping, len(additional))
 	}
 	defer span.End()
+	if name, ok := w.metricsByCorpus[grouping[types.CorpusField]]; ok {
+		metric, err := diff.GetMetric(name)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		ctx = addMetric(ctx, name, metric)
+	}
 	startingTile, endingTile, err := w.getTileBounds(ctx)
 	if err != nil {
 		return skerr.Wrapf(err, "get starting tile")
@@ -418,6 +529,13 @@ func (w *WorkerImpl) diff(ctx context.Context, left, right types.Digest) (schema
 	if err != nil {
 		return schema.DiffMetricRow{}, &imgError{digest: right, err: skerr.Wrap(err)}
 	}
+	metricName, metric := getMetric(ctx)
+	cacheKey := diffcache.Key{Left: left, Right: right, Metric: metricName}
+	if cached := w.getCachedResult(ctx, cacheKey); cached != nil {
+		// Cached diff image digests were computed by storeDiffImage, so they are always valid.
+		diffImageDigest, _ := sql.DigestToBytes(cached.DiffImageDigest)
+		return newDiffMetricRow(ctx, lb, rb, diffImageDigest, &cached.Metrics), nil
+	}
 	leftImg, err := w.getDecodedImage(ctx, left)
 	if err != nil {
 		return schema.DiffMetricRow{}, &imgError{digest: left, err: skerr.Wrap(err)}
@@ -426,10 +544,31 @@ func (w *WorkerImpl) diff(ctx context.Context, left, right types.Digest) (schema
 	if err != nil {
 		return schema.DiffMetricRow{}, &imgError{digest: right, err: skerr.Wrap(err)}
 	}
-	m := diff.ComputeDiffMetrics(leftImg, rightImg)
+	var m *diff.DiffMetrics
+	var diffImageDigest schema.DigestBytes
+	if w.diffImageStore == nil {
+		m = diff.ComputeDiffMetrics(leftImg, rightImg)
+	} else {
+		var diffImg *image.NRGBA
+		m, diffImg = diff.PixelDiff(leftImg, rightImg)
+		m.CombinedMetric = diff.CombinedDiffMetric(m.MaxRGBADiffs, m.PixelDiffPercent)
+		diffImageDigest = w.storeDiffImage(ctx, diffImg)
+	}
+	if metric != nil {
+		m.CombinedMetric = metric.Compute(leftImg, rightImg, m)
+	}
+	w.cacheResult(ctx, cacheKey, diffcache.Result{
+		Metrics:         *m,
+		DiffImageDigest: types.Digest(hex.EncodeToString(diffImageDigest)),
+	})
+	return newDiffMetricRow(ctx, lb, rb, diffImageDigest, m), nil
+}
+
+// newDiffMetricRow returns the row to insert into the DiffMetrics table for the given diff.
+func newDiffMetricRow(ctx context.Context, left, right, diffImageDigest schema.DigestBytes, m *diff.DiffMetrics) schema.DiffMetricRow {
 	return schema.DiffMetricRow{
-		LeftDigest:        lb,
-		RightDigest:       rb,
+		LeftDigest:        left,
+		RightDigest:       right,
 		NumPixelsDiff:     m.NumDiffPixels,
 		PercentPixelsDiff: m.PixelDiffPercent,
 		MaxRGBADiffs:      m.MaxRGBADiffs,
@@ -437,7 +576,95 @@ func (w *WorkerImpl) diff(ctx context.Context, left, right types.Digest) (schema
 		CombinedMetric:    m.CombinedMetric,
 		DimensionsDiffer:  m.DimDiffer,
 		Timestamp:         now.Now(ctx),
-	}, nil
+		DiffImageDigest:   diffImageDigest,
+	}
+}
+
+// getCachedResult returns the result for the given key from the resultCache, or nil if there is
+// no cache or it does not contain a usable result. If diff images are being stored, results
+// without a diff image are not usable, because the diff image would be missing. Errors are only
+// logged, because the diff can always be recomputed.
+func (w *WorkerImpl) getCachedResult(ctx context.Context, key diffcache.Key) *diffcache.Result {
+	if w.resultCache == nil {
+		return nil
+	}
+	ctx, span := trace.StartSpan(ctx, "getCachedResult")
+	defer span.End()
+	r, err := w.resultCache.Get(ctx, key)
+	if err != nil {
+		sklog.Warningf("Could not read cached diff result: %s", err)
+		r = nil
+	}
+	if r == nil || (w.diffImageStore != nil && r.DiffImageDigest == "") {
+		w.resultCacheMissCounter.Inc(1)
+		return nil
+	}
+	w.resultCacheHitCounter.Inc(1)
+	return r
+}
+
+// cacheResult stores the given result in the resultCache, if there is one.
+func (w *WorkerImpl) cacheResult(ctx context.Context, key diffcache.Key, r diffcache.Result) {
+	if w.resultCache == nil {
+		return
+	}
+	ctx, span := trace.StartSpan(ctx, "cacheResult")
+	defer span.End()
+	if err := w.resultCache.Set(ctx, key, r); err != nil {
+		sklog.Warningf("Could not cache diff result: %s", err)
+	}
+}
+
+// storeDiffImage encodes the given diff image and writes it to the diffImageStore, unless it is
+// known to already be there. It returns the digest of the diff image, or nil if it could not be
+// stored. Failing to store a diff image is not fatal, because diff images can be recomputed.
+func (w *WorkerImpl) storeDiffImage(ctx context.Context, img *image.NRGBA) schema.DigestBytes {
+	ctx, span := trace.StartSpan(ctx, "storeDiffImage")
+	defer span.End()
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := encoder.Encode(&buf, img); err != nil {
+		sklog.Warningf("Could not encode diff image: %s", err)
+		return nil
+	}
+	sum := md5.Sum(buf.Bytes())
+	digest := sum[:]
+	if _, ok := w.storedDiffImages.Get(string(digest)); ok {
+		w.diffImagesDedupedCounter.Inc(1)
+		return digest
+	}
+	if err := w.diffImageStore.WriteDiffImage(ctx, types.Digest(hex.EncodeToString(digest)), buf.Bytes()); err != nil {
+		sklog.Warningf("Could not store diff image: %s", err)
+		return nil
+	}
+	w.storedDiffImages.Add(string(digest), true)
+	w.diffImagesWrittenCounter.Inc(1)
+	return digest
+}
+
+// storeThumbnails downscales the given image, which was decoded from b, to each of
+// pyramid.ThumbnailSizes and writes the results to the thumbnailStore, unless they are known to
+// already be there. Failing to store thumbnails is not fatal, because the UI falls back to
+// downscaling the full-size image.
+func (w *WorkerImpl) storeThumbnails(ctx context.Context, digest types.Digest, b []byte, img *image.NRGBA) {
+	if _, ok := w.storedThumbnails.Get(string(digest)); ok {
+		return
+	}
+	ctx, span := trace.StartSpan(ctx, "storeThumbnails")
+	defer span.End()
+	for _, size := range pyramid.ThumbnailSizes {
+		thumbnail, err := pyramid.DownscaleDecoded(b, img, size)
+		if err != nil {
+			sklog.Warningf("Could not downscale image %s to %d: %s", digest, size, err)
+			return
+		}
+		if err := w.thumbnailStore.WriteThumbnail(ctx, digest, size, thumbnail); err != nil {
+			sklog.Warningf("Could not store thumbnail of image %s: %s", digest, err)
+			return
+		}
+		w.thumbnailsWrittenCounter.Inc(1)
+	}
+	w.storedThumbnails.Add(string(digest), true)
 }
 
 func max(diffs [4]int) int {
@@ -470,6 +697,9 @@ func (w *WorkerImpl) getDecodedImage(ctx context.Context, digest types.Digest) (
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
+	if w.thumbnailStore != nil {
+		w.storeThumbnails(ctx, digest, b, img)
+	}
 	// In memory, the image takes up 4 bytes per pixel.
 	s := img.Bounds().Size()
 	sizeInBytes := int64(s.X * s.Y * 4)
@@ -488,10 +718,18 @@ func (w *WorkerImpl) writeMetrics(ctx context.Context, metrics []schema.DiffMetr
 	}
 	ctx, span := trace.StartSpan(ctx, "writeMetrics")
 	defer span.End()
-	const baseStatement = `UPSERT INTO DiffMetrics
+	baseStatement := `UPSERT INTO DiffMetrics
 (left_digest, right_digest, num_pixels_diff, percent_pixels_diff, max_rgba_diffs,
 max_channel_diff, combined_metric, dimensions_differ, ts) VALUES `
-	const valuesPerRow = 9
+	valuesPerRow := 9
+	// Only write the diff_image_digest column if diff images are being stored, so instances
+	// which don't store diff images don't need the column.
+	if w.diffImageStore != nil {
+		baseStatement = `UPSERT INTO DiffMetrics
+(left_digest, right_digest, num_pixels_diff, percent_pixels_diff, max_rgba_diffs,
+max_channel_diff, combined_metric, dimensions_differ, ts, diff_image_digest) VALUES `
+		valuesPerRow = 10
+	}
 
 	arguments := make([]interface{}, 0, len(metrics)*valuesPerRow*2)
 	count := 0
@@ -501,8 +739,14 @@ max_channel_diff, combined_metric, dimensions_differ, ts) VALUES `
 		copy(rgba, r.MaxRGBADiffs[:])
 		arguments = append(arguments, r.LeftDigest, r.RightDigest, r.NumPixelsDiff, r.PercentPixelsDiff, rgba,
 			r.MaxChannelDiff, r.CombinedMetric, r.DimensionsDiffer, r.Timestamp)
+		if w.diffImageStore != nil {
+			arguments = append(arguments, r.DiffImageDigest)
+		}
 		arguments = append(arguments, r.RightDigest, r.LeftDigest, r.NumPixelsDiff, r.PercentPixelsDiff, rgba,
 			r.MaxChannelDiff, r.CombinedMetric, r.DimensionsDiffer, r.Timestamp)
+		if w.diffImageStore != nil {
+			arguments = append(arguments, r.DiffImageDigest)
+		}
 	}
 	vp := sqlutil.ValuesPlaceholders(valuesPerRow, count)
 	_, err := w.db.Exec(ctx, baseStatement+vp, arguments...)
@@ -694,7 +938,10 @@ type imgError struct {
 
 type contextType string
 
-const imgCacheContextKey contextType = "imgCache"
+const (
+	imgCacheContextKey contextType = "imgCache"
+	metricContextKey   contextType = "metric"
+)
 
 // addImgCache adds a cache of decoded images to the context, so we can use it in leaf
 // functions more easily.
@@ -710,11 +957,33 @@ func getImgCache(ctx context.Context) *lru.Cache {
 	return c
 }
 
-// decode decodes the provided bytes as a PNG and returns them.
+type namedMetric struct {
+	name   string
+	metric diff.Metric
+}
+
+// addMetric adds the diff.Metric to use for the grouping being diffed, and its name, to the
+// context.
+func addMetric(ctx context.Context, name string, metric diff.Metric) context.Context {
+	return context.WithValue(ctx, metricContextKey, namedMetric{name: name, metric: metric})
+}
+
+// getMetric returns the name of the diff.Metric added with addMetric and the metric itself. If
+// there is none, it returns diff.CombinedMetricName and a nil metric, in which case the default
+// metric should be used.
+func getMetric(ctx context.Context) (string, diff.Metric) {
+	m, ok := ctx.Value(metricContextKey).(namedMetric)
+	if !ok {
+		return diff.CombinedMetricName, nil
+	}
+	return m.name, m.metric
+}
+
+// decode decodes the provided PNG, WebP or AVIF bytes and returns them.
 func decode(ctx context.Context, b []byte) (*image.NRGBA, error) {
 	ctx, span := trace.StartSpan(ctx, "decode")
 	defer span.End()
-	im, err := png.Decode(bytes.NewReader(b))
+	im, err := codec.Decode(b)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
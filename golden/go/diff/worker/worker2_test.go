@@ -3,9 +3,13 @@ package worker
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
+	"image"
+	"image/png"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,9 +21,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.skia.org/infra/go/cache/local"
 	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/golden/go/diff"
+	"go.skia.org/infra/golden/go/diff/diffcache"
 	"go.skia.org/infra/golden/go/diff/mocks"
 	"go.skia.org/infra/golden/go/sql"
 	dks "go.skia.org/infra/golden/go/sql/datakitchensink"
@@ -296,6 +303,217 @@ func TestWorkerImpl_CalculateDiffs_CorruptedImage_PartialData(t *testing.T) {
 	assert.Equal(t, fakeNow, problem.ErrorTS)
 }
 
+func TestWorkerImpl_CalculateDiffs_WithDiffImageStore_DiffImagesStoredAndReferenced(t *testing.T) {
+
+	fakeNow := time.Date(2021, time.February, 1, 1, 1, 1, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), now.ContextKey, fakeNow)
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	waitForSystemTime()
+	store := &fakeDiffImageStore{}
+	w := newWorker2UsingImagesFromKitchenSink(t, db).WithDiffImageStore(store)
+
+	grouping := paramtools.Params{
+		types.CorpusField:     "not used",
+		types.PrimaryKeyField: "not used",
+	}
+	imagesToCalculateDiffsFor := []types.Digest{dks.DigestA01Pos, dks.DigestA02Pos, dks.DigestA04Unt}
+	require.NoError(t, w.CalculateDiffs(ctx, grouping, imagesToCalculateDiffsFor))
+
+	actualMetrics := getAllDiffMetricRows(t, db)
+	require.Len(t, actualMetrics, 6)
+	for _, row := range actualMetrics {
+		require.NotEmpty(t, row.DiffImageDigest)
+		assert.Contains(t, store.images, types.Digest(hex.EncodeToString(row.DiffImageDigest)))
+		// Apart from the diff image, the metrics are unchanged.
+		row.DiffImageDigest = nil
+		assert.Equal(t, expectedFromKS(t, types.Digest(hex.EncodeToString(row.LeftDigest)), types.Digest(hex.EncodeToString(row.RightDigest)), fakeNow), row)
+	}
+	// Each pair is diffed once, and both orderings reference the same diff image.
+	assert.LessOrEqual(t, len(store.images), 3)
+}
+
+func TestWorkerImpl_StoreDiffImage_IdenticalDiffImages_StoredOnce(t *testing.T) {
+
+	store := &fakeDiffImageStore{}
+	w := New(nil, nil, 0).WithDiffImageStore(store)
+	ctx := context.Background()
+
+	transparent := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	first := w.storeDiffImage(ctx, transparent)
+	second := w.storeDiffImage(ctx, image.NewNRGBA(image.Rect(0, 0, 10, 10)))
+	other := w.storeDiffImage(ctx, image.NewNRGBA(image.Rect(0, 0, 20, 10)))
+
+	require.NotEmpty(t, first)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+	assert.Equal(t, 2, store.writes)
+	assert.Len(t, store.images, 2)
+	assert.Contains(t, store.images, types.Digest(hex.EncodeToString(first)))
+}
+
+func TestWorkerImpl_StoreDiffImage_StoreFails_ReturnsNilAndRetriesLater(t *testing.T) {
+
+	store := &fakeDiffImageStore{err: errors.New("GCS is down")}
+	w := New(nil, nil, 0).WithDiffImageStore(store)
+	ctx := context.Background()
+
+	assert.Nil(t, w.storeDiffImage(ctx, image.NewNRGBA(image.Rect(0, 0, 10, 10))))
+	store.err = nil
+	assert.NotNil(t, w.storeDiffImage(ctx, image.NewNRGBA(image.Rect(0, 0, 10, 10))))
+	assert.Equal(t, 2, store.writes)
+}
+
+func TestWorkerImpl_StoreThumbnails_LargeImage_DownscaledThumbnailsStoredOnce(t *testing.T) {
+
+	store := &fakeThumbnailStore{}
+	w := New(nil, nil, 0).WithThumbnailStore(store)
+	ctx := context.Background()
+
+	img := image.NewNRGBA(image.Rect(0, 0, 1024, 300))
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	const digest = types.Digest("0123456789abcdef0123456789abcdef")
+
+	w.storeThumbnails(ctx, digest, buf.Bytes(), img)
+	w.storeThumbnails(ctx, digest, buf.Bytes(), img)
+
+	assert.Equal(t, 2, store.writes)
+	require.Len(t, store.thumbnails, 2)
+	small, err := png.DecodeConfig(bytes.NewReader(store.thumbnails[thumbnailKey{digest: digest, size: 256}]))
+	require.NoError(t, err)
+	assert.Equal(t, 256, small.Width)
+	assert.Equal(t, 75, small.Height)
+	medium, err := png.DecodeConfig(bytes.NewReader(store.thumbnails[thumbnailKey{digest: digest, size: 512}]))
+	require.NoError(t, err)
+	assert.Equal(t, 512, medium.Width)
+	assert.Equal(t, 150, medium.Height)
+}
+
+func TestWorkerImpl_StoreThumbnails_SmallImage_OriginalBytesStored(t *testing.T) {
+
+	store := &fakeThumbnailStore{}
+	w := New(nil, nil, 0).WithThumbnailStore(store)
+
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	const digest = types.Digest("0123456789abcdef0123456789abcdef")
+
+	w.storeThumbnails(context.Background(), digest, buf.Bytes(), img)
+
+	assert.Equal(t, buf.Bytes(), store.thumbnails[thumbnailKey{digest: digest, size: 256}])
+	assert.Equal(t, buf.Bytes(), store.thumbnails[thumbnailKey{digest: digest, size: 512}])
+}
+
+func TestWorkerImpl_StoreThumbnails_StoreFails_RetriedLater(t *testing.T) {
+
+	store := &fakeThumbnailStore{err: errors.New("GCS is down")}
+	w := New(nil, nil, 0).WithThumbnailStore(store)
+	ctx := context.Background()
+
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	const digest = types.Digest("0123456789abcdef0123456789abcdef")
+
+	w.storeThumbnails(ctx, digest, nil, img)
+	store.err = nil
+	w.storeThumbnails(ctx, digest, nil, img)
+	// The first attempt gives up after the first failed write.
+	assert.Equal(t, 3, store.writes)
+	assert.Len(t, store.thumbnails, 2)
+}
+
+func TestWorkerImpl_Diff_MetricInContext_OverridesCombinedMetric(t *testing.T) {
+
+	encode := func(img image.Image) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, img))
+		return buf.Bytes()
+	}
+	white := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := range white.Pix {
+		white.Pix[i] = 0xff
+	}
+	mis := &mocks.ImageSource{}
+	mis.On("GetImage", testutils.AnyContext, dks.DigestA01Pos).Return(encode(white), nil)
+	mis.On("GetImage", testutils.AnyContext, dks.DigestA02Pos).Return(encode(image.NewNRGBA(image.Rect(0, 0, 4, 4))), nil)
+	w := New(nil, mis, 0)
+
+	row, imgErr := w.diff(context.Background(), dks.DigestA01Pos, dks.DigestA02Pos)
+	require.Nil(t, imgErr)
+	assert.Equal(t, float32(10), row.CombinedMetric)
+
+	ctx := addMetric(context.Background(), "half", diff.MetricFunc(func(_, _ *image.NRGBA, _ *diff.DiffMetrics) float32 {
+		return 0.5
+	}))
+	row, imgErr = w.diff(ctx, dks.DigestA01Pos, dks.DigestA02Pos)
+	require.Nil(t, imgErr)
+	assert.Equal(t, float32(0.5), row.CombinedMetric)
+	// The pixel-wise metrics are unchanged.
+	assert.Equal(t, 16, row.NumPixelsDiff)
+}
+
+func TestWorkerImpl_Diff_WithResultCache_SecondDiffUsesCachedResult(t *testing.T) {
+
+	white := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := range white.Pix {
+		white.Pix[i] = 0xff
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, white))
+	mis := &mocks.ImageSource{}
+	// Each image is only fetched once, for the first diff.
+	mis.On("GetImage", testutils.AnyContext, dks.DigestA01Pos).Return(buf.Bytes(), nil).Once()
+	buf = bytes.Buffer{}
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 4, 4))))
+	mis.On("GetImage", testutils.AnyContext, dks.DigestA02Pos).Return(buf.Bytes(), nil).Once()
+	defer mis.AssertExpectations(t)
+
+	lc, err := local.New(10)
+	require.NoError(t, err)
+	rc := diffcache.New(lc)
+	w := New(nil, mis, 0).WithResultCache(rc)
+	ctx := context.Background()
+
+	first, imgErr := w.diff(ctx, dks.DigestA01Pos, dks.DigestA02Pos)
+	require.Nil(t, imgErr)
+	cached, err := rc.Get(ctx, diffcache.Key{Left: dks.DigestA01Pos, Right: dks.DigestA02Pos, Metric: diff.CombinedMetricName})
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	assert.Equal(t, 16, cached.Metrics.NumDiffPixels)
+
+	// The opposite direction is served from the cache too.
+	second, imgErr := w.diff(ctx, dks.DigestA02Pos, dks.DigestA01Pos)
+	require.Nil(t, imgErr)
+	assert.Equal(t, first.NumPixelsDiff, second.NumPixelsDiff)
+	assert.Equal(t, first.CombinedMetric, second.CombinedMetric)
+	assert.Equal(t, first.MaxRGBADiffs, second.MaxRGBADiffs)
+	assert.Equal(t, d(dks.DigestA02Pos), second.LeftDigest)
+	assert.Equal(t, d(dks.DigestA01Pos), second.RightDigest)
+}
+
+func TestWorkerImpl_Diff_CachedResultForOtherMetric_Recomputed(t *testing.T) {
+
+	mis := &mocks.ImageSource{}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 4, 4))))
+	mis.On("GetImage", testutils.AnyContext, dks.DigestA01Pos).Return(buf.Bytes(), nil)
+	mis.On("GetImage", testutils.AnyContext, dks.DigestA02Pos).Return(buf.Bytes(), nil)
+
+	lc, err := local.New(10)
+	require.NoError(t, err)
+	rc := diffcache.New(lc)
+	ctx := context.Background()
+	require.NoError(t, rc.Set(ctx, diffcache.Key{Left: dks.DigestA01Pos, Right: dks.DigestA02Pos, Metric: diff.SSIMMetricName}, diffcache.Result{
+		Metrics: diff.DiffMetrics{NumDiffPixels: 1234},
+	}))
+	w := New(nil, mis, 0).WithResultCache(rc)
+
+	row, imgErr := w.diff(ctx, dks.DigestA01Pos, dks.DigestA02Pos)
+	require.Nil(t, imgErr)
+	assert.Equal(t, 0, row.NumPixelsDiff)
+	mis.AssertNumberOfCalls(t, "GetImage", 2)
+}
+
 func TestWorkerImpl_GetTriagedDigests_Success(t *testing.T) {
 
 	ctx := context.Background()
@@ -442,6 +660,55 @@ func (f fsImageSource) GetImage(_ context.Context, digest types.Digest) ([]byte,
 	return os.ReadFile(p)
 }
 
+type thumbnailKey struct {
+	digest types.Digest
+	size   int
+}
+
+// fakeThumbnailStore stores thumbnails in memory.
+type fakeThumbnailStore struct {
+	mutex      sync.Mutex
+	thumbnails map[thumbnailKey][]byte
+	writes     int
+	err        error
+}
+
+func (f *fakeThumbnailStore) WriteThumbnail(_ context.Context, digest types.Digest, size int, b []byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.writes++
+	if f.err != nil {
+		return f.err
+	}
+	if f.thumbnails == nil {
+		f.thumbnails = map[thumbnailKey][]byte{}
+	}
+	f.thumbnails[thumbnailKey{digest: digest, size: size}] = b
+	return nil
+}
+
+// fakeDiffImageStore stores diff images in memory.
+type fakeDiffImageStore struct {
+	mutex  sync.Mutex
+	images map[types.Digest][]byte
+	writes int
+	err    error
+}
+
+func (f *fakeDiffImageStore) WriteDiffImage(_ context.Context, digest types.Digest, png []byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.writes++
+	if f.err != nil {
+		return f.err
+	}
+	if f.images == nil {
+		f.images = map[types.Digest][]byte{}
+	}
+	f.images[digest] = png
+	return nil
+}
+
 func kitchenSinkRoot(t *testing.T) string {
 	root, err := repo_root.Get()
 	if err != nil {
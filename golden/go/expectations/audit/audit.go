@@ -0,0 +1,213 @@
+// Package audit answers questions about who changed which expectations, when, and in which
+// context, e.g. for compliance reviews of baseline changes.
+//
+// Every change to the expectations, whether on the primary branch or on a CL, is recorded as an
+// ExpectationRecord (who, when, where) with one ExpectationDelta per digest (what). This package
+// queries those tables directly, so that nothing needs to be written besides what is already
+// written when triaging. Instances which predate the patchset_id column must add it with:
+//
+//	ALTER TABLE ExpectationRecords ADD COLUMN IF NOT EXISTS patchset_id STRING;
+//	CREATE INDEX IF NOT EXISTS user_ts_idx ON ExpectationRecords (user_name, triage_time);
+package audit
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opencensus.io/trace"
+
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/sql"
+	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/types"
+)
+
+const (
+	// DefaultLimit is the number of entries returned if Query.Limit is not set.
+	DefaultLimit = 100
+	// MaxLimit is the maximum number of entries that can be returned at once.
+	MaxLimit = 10_000
+)
+
+// Query filters the expectation changes. All fields are optional; the zero Query returns the most
+// recent changes on all branches.
+type Query struct {
+	// User is the email address of the user who made the changes, or the name of the image
+	// matching algorithm which made them.
+	User string
+	// Test and Corpus restrict the changes to those made to digests of the given test and corpus.
+	Test   types.TestName
+	Corpus string
+	// ExcludedCorpora hides the changes made to digests of the given corpora, e.g. those the
+	// requesting user may not view.
+	ExcludedCorpora []string
+	// Begin (inclusive) and End (exclusive) restrict the changes to those made in the given time
+	// range. A zero time means the range is open on that side.
+	Begin time.Time
+	End   time.Time
+	// CodeReviewSystem and ChangelistID restrict the changes to those made on the given CL. Both
+	// must be set, or neither.
+	CodeReviewSystem string
+	ChangelistID     string
+	// PrimaryBranchOnly restricts the changes to those made on the primary branch. It cannot be
+	// combined with ChangelistID.
+	PrimaryBranchOnly bool
+
+	Offset int
+	Limit  int
+}
+
+// Entry is a change to the expectation of a single digest.
+type Entry struct {
+	// RecordID identifies the triage event this change was a part of. Bulk triage events change
+	// many digests at once.
+	RecordID string    `json:"record_id"`
+	User     string    `json:"user"`
+	TS       time.Time `json:"ts"`
+	// CodeReviewSystem, ChangelistID and PatchsetID are empty for changes to the primary branch.
+	// PatchsetID may also be empty for changes to CLs if the triager did not provide it.
+	CodeReviewSystem string             `json:"crs"`
+	ChangelistID     string             `json:"changelist_id"`
+	PatchsetID       string             `json:"patchset_id"`
+	Grouping         paramtools.Params  `json:"grouping"`
+	Digest           types.Digest       `json:"digest"`
+	LabelBefore      expectations.Label `json:"label_before"`
+	LabelAfter       expectations.Label `json:"label_after"`
+}
+
+// Page is a page of the changes matching a Query, most recent first.
+type Page struct {
+	Entries []Entry `json:"entries"`
+	Offset  int     `json:"offset"`
+	// Total is the number of changes matching the query across all pages.
+	Total int `json:"total"`
+}
+
+// Validate returns an error if the query is inconsistent or the pagination is out of bounds.
+func (q Query) Validate() error {
+	if (q.CodeReviewSystem == "") != (q.ChangelistID == "") {
+		return skerr.Fmt("crs and changelist_id must be specified together")
+	}
+	if q.PrimaryBranchOnly && q.ChangelistID != "" {
+		return skerr.Fmt("cannot restrict to both the primary branch and a changelist")
+	}
+	if !q.Begin.IsZero() && !q.End.IsZero() && !q.Begin.Before(q.End) {
+		return skerr.Fmt("begin %s must be before end %s", q.Begin, q.End)
+	}
+	if q.Offset < 0 || q.Limit < 0 || q.Limit > MaxLimit {
+		return skerr.Fmt("invalid offset %d or limit %d; limit must be at most %d", q.Offset, q.Limit, MaxLimit)
+	}
+	return nil
+}
+
+// Search returns the page of expectation changes matching the given query.
+func Search(ctx context.Context, db *pgxpool.Pool, q Query) (Page, error) {
+	ctx, span := trace.StartSpan(ctx, "audit_Search")
+	defer span.End()
+	if err := q.Validate(); err != nil {
+		return Page{}, skerr.Wrap(err)
+	}
+	limit := q.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	where, args := whereClause(q)
+	const from = `
+FROM ExpectationRecords
+JOIN ExpectationDeltas ON ExpectationRecords.expectation_record_id = ExpectationDeltas.expectation_record_id
+JOIN Groupings ON ExpectationDeltas.grouping_id = Groupings.grouping_id
+LEFT JOIN Changelists ON ExpectationRecords.branch_name = Changelists.changelist_id`
+
+	var total int
+	if err := db.QueryRow(ctx, `SELECT COUNT(*)`+from+where, args...).Scan(&total); err != nil {
+		return Page{}, skerr.Wrapf(err, "counting expectation changes")
+	}
+	page := Page{Entries: []Entry{}, Offset: q.Offset, Total: total}
+	if total <= q.Offset {
+		return page, nil
+	}
+
+	statement := `SELECT ExpectationRecords.expectation_record_id, user_name, triage_time,
+	Changelists.system, branch_name, patchset_id, Groupings.keys, digest, label_before, label_after` +
+		from + where + `
+ORDER BY triage_time DESC, ExpectationRecords.expectation_record_id, digest
+OFFSET ` + strconv.Itoa(q.Offset) + ` LIMIT ` + strconv.Itoa(limit)
+	rows, err := db.Query(ctx, statement, args...)
+	if err != nil {
+		return Page{}, skerr.Wrapf(err, "querying expectation changes")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var record schema.ExpectationRecordRow
+		var delta schema.ExpectationDeltaRow
+		var system *string
+		var entry Entry
+		if err := rows.Scan(&record.ExpectationRecordID, &record.UserName, &record.TriageTime,
+			&system, &record.BranchName, &record.PatchsetID, &entry.Grouping, &delta.Digest,
+			&delta.LabelBefore, &delta.LabelAfter); err != nil {
+			return Page{}, skerr.Wrap(err)
+		}
+		entry.RecordID = record.ExpectationRecordID.String()
+		entry.User = record.UserName
+		entry.TS = record.TriageTime.UTC()
+		if system != nil {
+			entry.CodeReviewSystem = *system
+		}
+		if record.BranchName != nil {
+			entry.ChangelistID = sql.Unqualify(*record.BranchName)
+		}
+		if record.PatchsetID != nil {
+			entry.PatchsetID = sql.Unqualify(*record.PatchsetID)
+		}
+		entry.Digest = types.Digest(hex.EncodeToString(delta.Digest))
+		entry.LabelBefore = delta.LabelBefore.ToExpectation()
+		entry.LabelAfter = delta.LabelAfter.ToExpectation()
+		page.Entries = append(page.Entries, entry)
+	}
+	return page, nil
+}
+
+// whereClause returns the WHERE clause implementing the filters of the given query and the
+// arguments for its placeholders.
+func whereClause(q Query) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.Replace(condition, "?", "$"+strconv.Itoa(len(args)), 1))
+	}
+	if q.User != "" {
+		add("user_name = ?", q.User)
+	}
+	if q.Test != "" {
+		add("Groupings.keys->>'"+types.PrimaryKeyField+"' = ?", string(q.Test))
+	}
+	if q.Corpus != "" {
+		add("Groupings.keys->>'"+types.CorpusField+"' = ?", q.Corpus)
+	}
+	if len(q.ExcludedCorpora) > 0 {
+		add("NOT (Groupings.keys->>'"+types.CorpusField+"' = ANY(?))", q.ExcludedCorpora)
+	}
+	if !q.Begin.IsZero() {
+		add("triage_time >= ?", q.Begin)
+	}
+	if !q.End.IsZero() {
+		add("triage_time < ?", q.End)
+	}
+	if q.ChangelistID != "" {
+		add("branch_name = ?", sql.Qualify(q.CodeReviewSystem, q.ChangelistID))
+	} else if q.PrimaryBranchOnly {
+		conditions = append(conditions, "branch_name IS NULL")
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "\nWHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,188 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/sql"
+	dks "go.skia.org/infra/golden/go/sql/datakitchensink"
+	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/sql/sqltest"
+	"go.skia.org/infra/golden/go/types"
+)
+
+const (
+	firstRecordID  = "00000000-0000-0000-0000-000000000001"
+	secondRecordID = "00000000-0000-0000-0000-000000000002"
+	thirdRecordID  = "00000000-0000-0000-0000-000000000003"
+)
+
+var (
+	circleGrouping = paramtools.Params{types.CorpusField: dks.RoundCorpus, types.PrimaryKeyField: dks.CircleTest}
+	squareGrouping = paramtools.Params{types.CorpusField: dks.CornersCorpus, types.PrimaryKeyField: dks.SquareTest}
+
+	firstTS  = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	secondTS = time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)
+	thirdTS  = time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC)
+
+	// The entries in testData, most recent first.
+	thirdCircleEntry = Entry{
+		RecordID: thirdRecordID, User: dks.UserOne, TS: thirdTS, Grouping: circleGrouping,
+		Digest: dks.DigestC01Pos, LabelBefore: expectations.Positive, LabelAfter: expectations.Negative,
+	}
+	secondSquareEntry = Entry{
+		RecordID: secondRecordID, User: dks.UserTwo, TS: secondTS,
+		CodeReviewSystem: dks.GerritCRS, ChangelistID: "cl1", PatchsetID: "ps2", Grouping: squareGrouping,
+		Digest: dks.DigestA05Unt, LabelBefore: expectations.Untriaged, LabelAfter: expectations.Negative,
+	}
+	firstSquareEntry = Entry{
+		RecordID: firstRecordID, User: dks.UserOne, TS: firstTS, Grouping: squareGrouping,
+		Digest: dks.DigestA01Pos, LabelBefore: expectations.Untriaged, LabelAfter: expectations.Positive,
+	}
+	firstCircleEntry = Entry{
+		RecordID: firstRecordID, User: dks.UserOne, TS: firstTS, Grouping: circleGrouping,
+		Digest: dks.DigestC01Pos, LabelBefore: expectations.Untriaged, LabelAfter: expectations.Positive,
+	}
+)
+
+func TestSearch_NoFilters_AllChangesReturnedMostRecentFirst(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(ctx, t)
+
+	page, err := Search(ctx, db, Query{})
+	require.NoError(t, err)
+	assert.Equal(t, Page{
+		Entries: []Entry{thirdCircleEntry, secondSquareEntry, firstSquareEntry, firstCircleEntry},
+		Total:   4,
+	}, page)
+}
+
+func TestSearch_FilteredByUserAndTest_MatchingChangesReturned(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(ctx, t)
+
+	page, err := Search(ctx, db, Query{User: dks.UserOne, Test: dks.CircleTest, Corpus: dks.RoundCorpus})
+	require.NoError(t, err)
+	assert.Equal(t, Page{
+		Entries: []Entry{thirdCircleEntry, firstCircleEntry},
+		Total:   2,
+	}, page)
+}
+
+func TestSearch_ExcludedCorpora_ChangesToThoseCorporaNotReturned(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(ctx, t)
+
+	page, err := Search(ctx, db, Query{ExcludedCorpora: []string{dks.RoundCorpus}})
+	require.NoError(t, err)
+	assert.Equal(t, Page{
+		Entries: []Entry{secondSquareEntry, firstSquareEntry},
+		Total:   2,
+	}, page)
+}
+
+func TestSearch_FilteredByTimeRange_BeginInclusiveEndExclusive(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(ctx, t)
+
+	page, err := Search(ctx, db, Query{Begin: secondTS, End: thirdTS})
+	require.NoError(t, err)
+	assert.Equal(t, Page{
+		Entries: []Entry{secondSquareEntry},
+		Total:   1,
+	}, page)
+}
+
+func TestSearch_FilteredByBranch_MatchingChangesReturned(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(ctx, t)
+
+	page, err := Search(ctx, db, Query{CodeReviewSystem: dks.GerritCRS, ChangelistID: "cl1"})
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{secondSquareEntry}, page.Entries)
+
+	page, err = Search(ctx, db, Query{PrimaryBranchOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{thirdCircleEntry, firstSquareEntry, firstCircleEntry}, page.Entries)
+}
+
+func TestSearch_Paginated_TotalCountsAllPages(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(ctx, t)
+
+	page, err := Search(ctx, db, Query{Offset: 1, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, Page{
+		Entries: []Entry{secondSquareEntry, firstSquareEntry},
+		Offset:  1,
+		Total:   4,
+	}, page)
+
+	page, err = Search(ctx, db, Query{Offset: 10})
+	require.NoError(t, err)
+	assert.Equal(t, Page{Entries: []Entry{}, Offset: 10, Total: 4}, page)
+}
+
+func TestQueryValidate_InvalidQueries_ReturnError(t *testing.T) {
+	test := func(name string, q Query) {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, q.Validate())
+		})
+	}
+	test("CL without CRS", Query{ChangelistID: "cl1"})
+	test("CRS without CL", Query{CodeReviewSystem: dks.GerritCRS})
+	test("CL and primary branch", Query{CodeReviewSystem: dks.GerritCRS, ChangelistID: "cl1", PrimaryBranchOnly: true})
+	test("empty time range", Query{Begin: secondTS, End: secondTS})
+	test("negative offset", Query{Offset: -1})
+	test("limit too large", Query{Limit: MaxLimit + 1})
+	assert.NoError(t, Query{}.Validate())
+}
+
+func setupDB(ctx context.Context, t *testing.T) *pgxpool.Pool {
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, testData()))
+	return db
+}
+
+func testData() schema.Tables {
+	_, circleID := sql.SerializeMap(circleGrouping)
+	_, squareID := sql.SerializeMap(squareGrouping)
+	branch := sql.Qualify(dks.GerritCRS, "cl1")
+	patchset := sql.Qualify(dks.GerritCRS, "ps2")
+	digest := func(d types.Digest) schema.DigestBytes {
+		b, err := sql.DigestToBytes(d)
+		if err != nil {
+			panic(err)
+		}
+		return b
+	}
+	return schema.Tables{
+		Groupings: []schema.GroupingRow{
+			{GroupingID: circleID, Keys: circleGrouping},
+			{GroupingID: squareID, Keys: squareGrouping},
+		},
+		Changelists: []schema.ChangelistRow{{
+			ChangelistID: branch, System: dks.GerritCRS, Status: schema.StatusOpen,
+			OwnerEmail: dks.UserTwo, Subject: "Update squares", LastIngestedData: secondTS,
+		}},
+		ExpectationRecords: []schema.ExpectationRecordRow{
+			{ExpectationRecordID: uuid.MustParse(firstRecordID), UserName: dks.UserOne, TriageTime: firstTS, NumChanges: 2},
+			{ExpectationRecordID: uuid.MustParse(secondRecordID), BranchName: &branch, PatchsetID: &patchset, UserName: dks.UserTwo, TriageTime: secondTS, NumChanges: 1},
+			{ExpectationRecordID: uuid.MustParse(thirdRecordID), UserName: dks.UserOne, TriageTime: thirdTS, NumChanges: 1},
+		},
+		ExpectationDeltas: []schema.ExpectationDeltaRow{
+			{ExpectationRecordID: uuid.MustParse(firstRecordID), GroupingID: circleID, Digest: digest(dks.DigestC01Pos), LabelBefore: schema.LabelUntriaged, LabelAfter: schema.LabelPositive},
+			{ExpectationRecordID: uuid.MustParse(firstRecordID), GroupingID: squareID, Digest: digest(dks.DigestA01Pos), LabelBefore: schema.LabelUntriaged, LabelAfter: schema.LabelPositive},
+			{ExpectationRecordID: uuid.MustParse(secondRecordID), GroupingID: squareID, Digest: digest(dks.DigestA05Unt), LabelBefore: schema.LabelUntriaged, LabelAfter: schema.LabelNegative},
+			{ExpectationRecordID: uuid.MustParse(thirdRecordID), GroupingID: circleID, Digest: digest(dks.DigestC01Pos), LabelBefore: schema.LabelPositive, LabelAfter: schema.LabelNegative},
+		},
+	}
+}
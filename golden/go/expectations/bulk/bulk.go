@@ -0,0 +1,346 @@
+// Package bulk exports the expectations of a corpus as a single, versioned JSON blob and imports
+// such blobs back in. This makes it possible to clone the baselines of one instance into another,
+// and to restore them after a disaster without editing the database by hand.
+package bulk
+
+import (
+	"context"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbpgx"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opencensus.io/trace"
+
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sql/sqlutil"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/sql"
+	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/types"
+)
+
+const (
+	// CurrentVersion is the version of the Baseline format written by Export. Import rejects
+	// baselines of any other version.
+	CurrentVersion = 1
+
+	// importBatchSize is the maximum number of expectations written in a single transaction. Each
+	// batch shows up as a separate entry in the triage log.
+	importBatchSize = 1000
+)
+
+// Baseline is the set of triaged digests of a single corpus at the time it was exported.
+type Baseline struct {
+	Version      int           `json:"version"`
+	Corpus       string        `json:"corpus"`
+	ExportedAt   time.Time     `json:"exported_at"`
+	Expectations []Expectation `json:"expectations"`
+}
+
+// Expectation is the label of one digest in one grouping. Groupings are stored by their keys rather
+// than their IDs so the blob is readable and does not depend on how IDs are computed.
+type Expectation struct {
+	Grouping paramtools.Params  `json:"grouping"`
+	Digest   types.Digest       `json:"digest"`
+	Label    expectations.Label `json:"label"`
+}
+
+// Change describes an expectation which an import changes (or, for a dry run, would change).
+type Change struct {
+	Grouping    paramtools.Params  `json:"grouping"`
+	Digest      types.Digest       `json:"digest"`
+	LabelBefore expectations.Label `json:"label_before"`
+	LabelAfter  expectations.Label `json:"label_after"`
+}
+
+// ImportReport summarizes the result of an import.
+type ImportReport struct {
+	// DryRun is true if the changes were only computed and not written.
+	DryRun bool `json:"dry_run"`
+	// Unchanged is the number of expectations in the baseline which already had the same label.
+	Unchanged int `json:"unchanged"`
+	// Changes are the expectations whose label differs from the current one.
+	Changes []Change `json:"changes"`
+}
+
+// Export returns the triaged (i.e. positive or negative) expectations on the primary branch for
+// all groupings of the given corpus.
+func Export(ctx context.Context, db *pgxpool.Pool, corpus string) (*Baseline, error) {
+	ctx, span := trace.StartSpan(ctx, "bulk_Export")
+	defer span.End()
+	current, err := getExpectations(ctx, db, corpus)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	rv := &Baseline{
+		Version:      CurrentVersion,
+		Corpus:       corpus,
+		ExportedAt:   now.Now(ctx),
+		Expectations: make([]Expectation, 0, len(current)),
+	}
+	for _, e := range current {
+		if e.label == schema.LabelUntriaged {
+			continue
+		}
+		rv.Expectations = append(rv.Expectations, Expectation{
+			Grouping: e.grouping,
+			Digest:   e.digest,
+			Label:    e.label.ToExpectation(),
+		})
+	}
+	sort.Slice(rv.Expectations, func(i, j int) bool {
+		a, b := rv.Expectations[i], rv.Expectations[j]
+		if a.Grouping[types.PrimaryKeyField] != b.Grouping[types.PrimaryKeyField] {
+			return a.Grouping[types.PrimaryKeyField] < b.Grouping[types.PrimaryKeyField]
+		}
+		if ka, kb := groupingKey(a.Grouping), groupingKey(b.Grouping); ka != kb {
+			return ka < kb
+		}
+		return a.Digest < b.Digest
+	})
+	span.AddAttributes(trace.Int64Attribute("num_expectations", int64(len(rv.Expectations))))
+	return rv, nil
+}
+
+// Import applies the given baseline to the primary branch, as if the given user had triaged every
+// changed digest. Expectations which are not in the baseline are left as they are. If dryRun is
+// true, nothing is written and the returned report lists the changes which would have been made.
+func Import(ctx context.Context, db *pgxpool.Pool, b *Baseline, userID string, dryRun bool) (*ImportReport, error) {
+	ctx, span := trace.StartSpan(ctx, "bulk_Import")
+	defer span.End()
+	if err := Validate(b); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	current, err := getExpectations(ctx, db, b.Corpus)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	report := &ImportReport{DryRun: dryRun, Changes: []Change{}}
+	var groupings []schema.GroupingRow
+	seenGroupings := map[schema.MD5Hash]bool{}
+	var deltas []schema.ExpectationDeltaRow
+	for _, e := range b.Expectations {
+		_, groupingID := sql.SerializeMap(e.Grouping)
+		digestBytes, err := sql.DigestToBytes(e.Digest)
+		if err != nil {
+			return nil, skerr.Wrap(err) // Should have been caught by Validate.
+		}
+		before := schema.LabelUntriaged
+		if existing, ok := current[expectationKey{
+			groupingID: sql.AsMD5Hash(groupingID),
+			digest:     sql.AsMD5Hash(digestBytes),
+		}]; ok {
+			before = existing.label
+		}
+		after := schema.FromExpectationLabel(e.Label)
+		if before == after {
+			report.Unchanged++
+			continue
+		}
+		report.Changes = append(report.Changes, Change{
+			Grouping:    e.Grouping,
+			Digest:      e.Digest,
+			LabelBefore: before.ToExpectation(),
+			LabelAfter:  e.Label,
+		})
+		if !seenGroupings[sql.AsMD5Hash(groupingID)] {
+			seenGroupings[sql.AsMD5Hash(groupingID)] = true
+			groupings = append(groupings, schema.GroupingRow{GroupingID: groupingID, Keys: e.Grouping})
+		}
+		deltas = append(deltas, schema.ExpectationDeltaRow{
+			GroupingID:  groupingID,
+			Digest:      digestBytes,
+			LabelBefore: before,
+			LabelAfter:  after,
+		})
+	}
+	span.AddAttributes(trace.Int64Attribute("num_changes", int64(len(deltas))))
+	if dryRun || len(deltas) == 0 {
+		return report, nil
+	}
+	// The digests might not have been seen by this instance yet (e.g. when cloning another one), so
+	// make sure the groupings exist, otherwise the expectations would not be joinable.
+	if err := writeGroupings(ctx, db, groupings); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	err = util.ChunkIter(len(deltas), importBatchSize, func(startIdx int, endIdx int) error {
+		batch := deltas[startIdx:endIdx]
+		err := crdbpgx.ExecuteTx(ctx, db, pgx.TxOptions{}, func(tx pgx.Tx) error {
+			recordID, err := writeRecord(ctx, tx, userID, len(batch))
+			if err != nil {
+				return err
+			}
+			for i := range batch {
+				batch[i].ExpectationRecordID = recordID
+			}
+			if err := writeDeltas(ctx, tx, batch); err != nil {
+				return err
+			}
+			return applyDeltas(ctx, tx, batch)
+		})
+		return skerr.Wrapf(err, "importing %d expectations from %s", len(batch), userID)
+	})
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return report, nil
+}
+
+// Validate returns an error if the given baseline cannot be imported, e.g. because it was written
+// by an incompatible version or it contains expectations for other corpora.
+func Validate(b *Baseline) error {
+	if b == nil {
+		return skerr.Fmt("missing baseline")
+	}
+	if b.Version != CurrentVersion {
+		return skerr.Fmt("unsupported baseline version %d; expected %d", b.Version, CurrentVersion)
+	}
+	if b.Corpus == "" {
+		return skerr.Fmt("baseline does not specify a corpus")
+	}
+	type baselineKey struct {
+		grouping string
+		digest   types.Digest
+	}
+	seen := map[baselineKey]bool{}
+	for i, e := range b.Expectations {
+		if c := e.Grouping[types.CorpusField]; c != b.Corpus {
+			return skerr.Fmt("expectation %d belongs to corpus %q, not %q", i, c, b.Corpus)
+		}
+		if _, err := sql.DigestToBytes(e.Digest); err != nil {
+			return skerr.Wrapf(err, "expectation %d", i)
+		}
+		if !expectations.ValidLabel(e.Label) {
+			return skerr.Fmt("expectation %d has invalid label %q", i, e.Label)
+		}
+		key := baselineKey{grouping: groupingKey(e.Grouping), digest: e.Digest}
+		if seen[key] {
+			return skerr.Fmt("expectation %d is a duplicate of digest %s in grouping %v", i, e.Digest, e.Grouping)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// groupingKey returns a string which uniquely identifies the given grouping.
+func groupingKey(grouping paramtools.Params) string {
+	key, _ := sql.SerializeMap(grouping)
+	return key
+}
+
+type expectationKey struct {
+	groupingID schema.MD5Hash
+	digest     schema.MD5Hash
+}
+
+type currentExpectation struct {
+	grouping paramtools.Params
+	digest   types.Digest
+	label    schema.ExpectationLabel
+}
+
+// getExpectations returns the expectations on the primary branch for all groupings of the given
+// corpus.
+func getExpectations(ctx context.Context, db *pgxpool.Pool, corpus string) (map[expectationKey]currentExpectation, error) {
+	ctx, span := trace.StartSpan(ctx, "getExpectations")
+	defer span.End()
+	const statement = `SELECT Groupings.grouping_id, Groupings.keys, Expectations.digest, Expectations.label
+FROM Expectations JOIN Groupings ON Expectations.grouping_id = Groupings.grouping_id
+WHERE Groupings.keys->>'source_type' = $1`
+	rows, err := db.Query(ctx, statement, corpus)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "fetching expectations for corpus %q", corpus)
+	}
+	defer rows.Close()
+	rv := map[expectationKey]currentExpectation{}
+	for rows.Next() {
+		var groupingID schema.GroupingID
+		var keys paramtools.Params
+		var digest schema.DigestBytes
+		var label schema.ExpectationLabel
+		if err := rows.Scan(&groupingID, &keys, &digest, &label); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		rv[expectationKey{
+			groupingID: sql.AsMD5Hash(groupingID),
+			digest:     sql.AsMD5Hash(digest),
+		}] = currentExpectation{
+			grouping: keys,
+			digest:   types.Digest(hex.EncodeToString(digest)),
+			label:    label,
+		}
+	}
+	return rv, nil
+}
+
+// writeGroupings creates the given groupings if they do not exist yet.
+func writeGroupings(ctx context.Context, db *pgxpool.Pool, rows []schema.GroupingRow) error {
+	ctx, span := trace.StartSpan(ctx, "writeGroupings")
+	defer span.End()
+	const chunkSize = 200 // Arbitrarily picked
+	return util.ChunkIter(len(rows), chunkSize, func(startIdx int, endIdx int) error {
+		batch := rows[startIdx:endIdx]
+		statement := `INSERT INTO Groupings (grouping_id, keys) VALUES `
+		const valuesPerRow = 2
+		statement += sqlutil.ValuesPlaceholders(valuesPerRow, len(batch))
+		arguments := make([]interface{}, 0, valuesPerRow*len(batch))
+		for _, row := range batch {
+			arguments = append(arguments, row.GroupingID, row.Keys)
+		}
+		// Groupings are immutable, so existing rows are already correct.
+		statement += ` ON CONFLICT DO NOTHING`
+		err := crdbpgx.ExecuteTx(ctx, db, pgx.TxOptions{}, func(tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, statement, arguments...)
+			return err // Don't wrap - crdbpgx might retry
+		})
+		return skerr.Wrapf(err, "storing %d groupings", len(batch))
+	})
+}
+
+// writeRecord creates a new ExpectationRecord on the primary branch and returns its ID.
+func writeRecord(ctx context.Context, tx pgx.Tx, userID string, numChanges int) (uuid.UUID, error) {
+	const statement = `INSERT INTO ExpectationRecords
+(user_name, triage_time, num_changes) VALUES ($1, $2, $3) RETURNING expectation_record_id`
+	row := tx.QueryRow(ctx, statement, userID, now.Now(ctx), numChanges)
+	var recordID uuid.UUID
+	if err := row.Scan(&recordID); err != nil {
+		return uuid.UUID{}, err // Don't wrap - crdbpgx might retry
+	}
+	return recordID, nil
+}
+
+// writeDeltas writes the given deltas to the ExpectationDeltas table, so they show up in the
+// triage log and can be undone.
+func writeDeltas(ctx context.Context, tx pgx.Tx, deltas []schema.ExpectationDeltaRow) error {
+	const statement = `INSERT INTO ExpectationDeltas
+(expectation_record_id, grouping_id, digest, label_before, label_after) VALUES `
+	const valuesPerRow = 5
+	vp := sqlutil.ValuesPlaceholders(valuesPerRow, len(deltas))
+	arguments := make([]interface{}, 0, len(deltas)*valuesPerRow)
+	for _, d := range deltas {
+		arguments = append(arguments, d.ExpectationRecordID, d.GroupingID, d.Digest, d.LabelBefore, d.LabelAfter)
+	}
+	_, err := tx.Exec(ctx, statement+vp, arguments...)
+	return err // Don't wrap - crdbpgx might retry
+}
+
+// applyDeltas applies the given deltas to the primary branch expectations.
+func applyDeltas(ctx context.Context, tx pgx.Tx, deltas []schema.ExpectationDeltaRow) error {
+	const statement = `UPSERT INTO Expectations
+(grouping_id, digest, label, expectation_record_id) VALUES `
+	const valuesPerRow = 4
+	vp := sqlutil.ValuesPlaceholders(valuesPerRow, len(deltas))
+	arguments := make([]interface{}, 0, len(deltas)*valuesPerRow)
+	for _, d := range deltas {
+		arguments = append(arguments, d.GroupingID, d.Digest, d.LabelAfter, d.ExpectationRecordID)
+	}
+	_, err := tx.Exec(ctx, statement+vp, arguments...)
+	return err // Don't wrap - crdbpgx might retry
+}
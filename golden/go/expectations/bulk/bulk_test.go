@@ -0,0 +1,181 @@
+package bulk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/sql"
+	dks "go.skia.org/infra/golden/go/sql/datakitchensink"
+	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/sql/sqltest"
+	"go.skia.org/infra/golden/go/types"
+)
+
+var circleGrouping = paramtools.Params{
+	types.CorpusField:     dks.RoundCorpus,
+	types.PrimaryKeyField: dks.CircleTest,
+}
+
+func TestExport_ReturnsTriagedExpectationsForCorpus(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+	exportTime := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+	ctx = context.WithValue(ctx, now.ContextKey, exportTime)
+
+	b, err := Export(ctx, db, dks.RoundCorpus)
+	require.NoError(t, err)
+	assert.Equal(t, &Baseline{
+		Version:    CurrentVersion,
+		Corpus:     dks.RoundCorpus,
+		ExportedAt: exportTime,
+		Expectations: []Expectation{
+			{Grouping: circleGrouping, Digest: dks.DigestC01Pos, Label: expectations.Positive},
+			{Grouping: circleGrouping, Digest: dks.DigestC02Pos, Label: expectations.Positive},
+		},
+	}, b)
+}
+
+func TestImport_DryRun_ReportsChangesWithoutWriting(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+	before := sqltest.GetAllRows(ctx, t, db, "Expectations", &schema.ExpectationRow{}).([]schema.ExpectationRow)
+
+	report, err := Import(ctx, db, &Baseline{
+		Version: CurrentVersion,
+		Corpus:  dks.RoundCorpus,
+		Expectations: []Expectation{
+			{Grouping: circleGrouping, Digest: dks.DigestC01Pos, Label: expectations.Positive},
+			{Grouping: circleGrouping, Digest: dks.DigestC02Pos, Label: expectations.Negative},
+			{Grouping: circleGrouping, Digest: dks.DigestC03Unt, Label: expectations.Positive},
+		},
+	}, "importer@example.com", true)
+	require.NoError(t, err)
+	assert.Equal(t, &ImportReport{
+		DryRun:    true,
+		Unchanged: 1,
+		Changes: []Change{
+			{Grouping: circleGrouping, Digest: dks.DigestC02Pos, LabelBefore: expectations.Positive, LabelAfter: expectations.Negative},
+			{Grouping: circleGrouping, Digest: dks.DigestC03Unt, LabelBefore: expectations.Untriaged, LabelAfter: expectations.Positive},
+		},
+	}, report)
+
+	after := sqltest.GetAllRows(ctx, t, db, "Expectations", &schema.ExpectationRow{}).([]schema.ExpectationRow)
+	assert.Equal(t, before, after)
+}
+
+func TestImport_ExportedFromOtherInstance_ExpectationsAndTriageLogWritten(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	importTime := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+	ctx = context.WithValue(ctx, now.ContextKey, importTime)
+
+	// The database is empty, as it would be for a new instance.
+	report, err := Import(ctx, db, &Baseline{
+		Version: CurrentVersion,
+		Corpus:  dks.RoundCorpus,
+		Expectations: []Expectation{
+			{Grouping: circleGrouping, Digest: dks.DigestC01Pos, Label: expectations.Positive},
+		},
+	}, "importer@example.com", false)
+	require.NoError(t, err)
+	assert.False(t, report.DryRun)
+	assert.Len(t, report.Changes, 1)
+
+	records := sqltest.GetAllRows(ctx, t, db, "ExpectationRecords", &schema.ExpectationRecordRow{}).([]schema.ExpectationRecordRow)
+	require.Len(t, records, 1)
+	assert.Equal(t, "importer@example.com", records[0].UserName)
+	assert.Equal(t, importTime, records[0].TriageTime)
+	assert.Equal(t, 1, records[0].NumChanges)
+	recordID := records[0].ExpectationRecordID
+
+	_, groupingID := sql.SerializeMap(circleGrouping)
+	assert.Equal(t, []schema.GroupingRow{{
+		GroupingID: groupingID,
+		Keys:       circleGrouping,
+	}}, sqltest.GetAllRows(ctx, t, db, "Groupings", &schema.GroupingRow{}).([]schema.GroupingRow))
+	assert.Equal(t, []schema.ExpectationRow{{
+		GroupingID:          groupingID,
+		Digest:              d(dks.DigestC01Pos),
+		Label:               schema.LabelPositive,
+		ExpectationRecordID: &recordID,
+	}}, sqltest.GetAllRows(ctx, t, db, "Expectations", &schema.ExpectationRow{}).([]schema.ExpectationRow))
+
+	// The import is now a no-op.
+	b, err := Export(ctx, db, dks.RoundCorpus)
+	require.NoError(t, err)
+	report, err = Import(ctx, db, b, "importer@example.com", false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Empty(t, report.Changes)
+}
+
+func TestValidate_ValidBaseline_Success(t *testing.T) {
+	assert.NoError(t, Validate(&Baseline{
+		Version: CurrentVersion,
+		Corpus:  dks.RoundCorpus,
+		Expectations: []Expectation{
+			{Grouping: circleGrouping, Digest: dks.DigestC01Pos, Label: expectations.Positive},
+			{Grouping: circleGrouping, Digest: dks.DigestC02Pos, Label: expectations.Negative},
+		},
+	}))
+}
+
+func TestValidate_InvalidBaselines_ReturnsError(t *testing.T) {
+	test := func(name string, b *Baseline, errSubstring string) {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(b)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), errSubstring)
+		})
+	}
+	test("nil", nil, "missing baseline")
+	test("wrong version", &Baseline{Version: CurrentVersion + 1, Corpus: dks.RoundCorpus}, "unsupported baseline version")
+	test("no corpus", &Baseline{Version: CurrentVersion}, "does not specify a corpus")
+	test("other corpus", &Baseline{
+		Version: CurrentVersion,
+		Corpus:  dks.CornersCorpus,
+		Expectations: []Expectation{
+			{Grouping: circleGrouping, Digest: dks.DigestC01Pos, Label: expectations.Positive},
+		},
+	}, `belongs to corpus "round"`)
+	test("invalid digest", &Baseline{
+		Version: CurrentVersion,
+		Corpus:  dks.RoundCorpus,
+		Expectations: []Expectation{
+			{Grouping: circleGrouping, Digest: "not a digest", Label: expectations.Positive},
+		},
+	}, "invalid digest")
+	test("invalid label", &Baseline{
+		Version: CurrentVersion,
+		Corpus:  dks.RoundCorpus,
+		Expectations: []Expectation{
+			{Grouping: circleGrouping, Digest: dks.DigestC01Pos, Label: "fantastic"},
+		},
+	}, "invalid label")
+	test("duplicate", &Baseline{
+		Version: CurrentVersion,
+		Corpus:  dks.RoundCorpus,
+		Expectations: []Expectation{
+			{Grouping: circleGrouping, Digest: dks.DigestC01Pos, Label: expectations.Positive},
+			{Grouping: circleGrouping, Digest: dks.DigestC01Pos, Label: expectations.Negative},
+		},
+	}, "duplicate")
+}
+
+// d converts the given digest to its corresponding DigestBytes types. It panics on a failure.
+func d(d types.Digest) schema.DigestBytes {
+	b, err := sql.DigestToBytes(d)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
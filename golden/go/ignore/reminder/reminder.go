@@ -0,0 +1,170 @@
+// Package reminder emails the authors of ignore rules shortly before their rules expire, and
+// again once they have expired. Expired rules keep hiding data until somebody extends or deletes
+// them, so this makes sure a human gets to reconsider them.
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"strings"
+	"time"
+
+	"go.opencensus.io/trace"
+
+	"go.skia.org/infra/go/email"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/golden/go/ignore"
+)
+
+const (
+	fromDisplayName = "Skia Gold"
+
+	emailTemplate = `
+Hi,
+<br/><br/>
+{{if .Expired}}
+An ignore rule you created or last updated on {{.SiteURL}} expired on {{.Expires}}.
+It is still hiding the matching traces.
+{{else}}
+An ignore rule you created or last updated on {{.SiteURL}} will expire on {{.Expires}}.
+{{end}}
+<ul>
+  <li>Query: {{.Query}}</li>
+  <li>Note: {{.Note}}</li>
+</ul>
+If the rule is still needed, <a href="{{.IgnoresURL}}">extend it</a> by editing its expiration.
+Otherwise, <a href="{{.IgnoresURL}}">delete it</a> so the traces are visible again.
+<br/><br/>
+Thanks!
+`
+)
+
+var emailTemplateParsed = template.Must(template.New("ignore_rule_reminder").Parse(emailTemplate))
+
+// Sender sends emails. It is implemented by emailclient.Client.
+type Sender interface {
+	SendWithMarkup(fromDisplayName string, from string, to []string, subject, body, markup, threadingReference string) (string, error)
+}
+
+// Reminder sends reminders about expiring ignore rules.
+type Reminder struct {
+	store   ignore.Store
+	sender  Sender
+	from    string
+	siteURL string
+	// leadTime is how long before a rule expires the first reminder is sent.
+	leadTime time.Duration
+
+	remindersSent metrics2.Counter
+}
+
+// New returns a Reminder which emails the authors of the rules in the given store, from the given
+// address. siteURL is the URL of the Gold instance, e.g. "https://gold.skia.org".
+func New(store ignore.Store, sender Sender, from, siteURL string, leadTime time.Duration) *Reminder {
+	return &Reminder{
+		store:         store,
+		sender:        sender,
+		from:          from,
+		siteURL:       strings.TrimSuffix(siteURL, "/"),
+		leadTime:      leadTime,
+		remindersSent: metrics2.GetCounter("gold_ignore_rule_reminders_sent"),
+	}
+}
+
+// SendReminders emails the authors of all rules which reached their reminder time in
+// (start, end]. A rule reaches its first reminder time leadTime before it expires and its second
+// one when it expires. Calling this for consecutive windows makes sure each reminder is sent once.
+func (r *Reminder) SendReminders(ctx context.Context, start, end time.Time) error {
+	ctx, span := trace.StartSpan(ctx, "reminder_SendReminders")
+	defer span.End()
+	rules, err := r.store.List(ctx)
+	if err != nil {
+		return skerr.Wrapf(err, "listing ignore rules")
+	}
+	for _, rule := range rules {
+		expired := inWindow(rule.Expires, start, end)
+		upcoming := inWindow(rule.Expires.Add(-r.leadTime), start, end)
+		if !expired && !upcoming {
+			continue
+		}
+		if err := r.remind(rule, expired); err != nil {
+			return skerr.Wrapf(err, "reminding authors of rule %s", rule.ID)
+		}
+	}
+	return nil
+}
+
+// inWindow returns true if ts is in (start, end].
+func inWindow(ts, start, end time.Time) bool {
+	return ts.After(start) && !ts.After(end)
+}
+
+// remind emails the authors of the given rule.
+func (r *Reminder) remind(rule ignore.Rule, expired bool) error {
+	var to []string
+	for _, author := range []string{rule.CreatedBy, rule.UpdatedBy} {
+		if strings.Contains(author, "@") {
+			to = append(to, author)
+		}
+	}
+	if len(to) == 0 {
+		sklog.Warningf("Ignore rule %s has no author to remind", rule.ID)
+		return nil
+	}
+
+	ignoresURL := r.siteURL + "/ignores"
+	var body bytes.Buffer
+	if err := emailTemplateParsed.Execute(&body, struct {
+		Expired    bool
+		Expires    string
+		SiteURL    string
+		IgnoresURL string
+		Query      string
+		Note       string
+	}{
+		Expired:    expired,
+		Expires:    rule.Expires.UTC().Format(time.RFC1123),
+		SiteURL:    r.siteURL,
+		IgnoresURL: ignoresURL,
+		Query:      rule.Query,
+		Note:       rule.Note,
+	}); err != nil {
+		return skerr.Wrapf(err, "executing email template")
+	}
+
+	subject := "Your Gold ignore rule expires soon"
+	if expired {
+		subject = "Your Gold ignore rule has expired"
+	}
+	markup, err := email.GetViewActionMarkup(ignoresURL, "View Ignore Rules", "Extend or delete the ignore rule")
+	if err != nil {
+		return skerr.Wrapf(err, "creating view action markup")
+	}
+	if _, err := r.sender.SendWithMarkup(fromDisplayName, r.from, to, subject, body.String(), markup, ""); err != nil {
+		return skerr.Wrapf(err, "sending email to %s", to)
+	}
+	r.remindersSent.Inc(1)
+	sklog.Infof("Reminded %s about ignore rule %s (expired: %t)", to, rule.ID, expired)
+	return nil
+}
+
+// Start sends reminders every period until the context is cancelled. The first check covers the
+// period before it was started.
+func Start(ctx context.Context, r *Reminder, period time.Duration) {
+	liveness := metrics2.NewLiveness("gold_ignore_rule_reminders")
+	start := now.Now(ctx).Add(-period)
+	go util.RepeatCtx(ctx, period, func(ctx context.Context) {
+		end := now.Now(ctx)
+		if err := r.SendReminders(ctx, start, end); err != nil {
+			sklog.Errorf("Failed to send ignore rule reminders: %s", err)
+			return // return so the window is retried and the liveness is not updated
+		}
+		start = end
+		liveness.Reset()
+	})
+}
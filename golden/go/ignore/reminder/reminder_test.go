@@ -0,0 +1,132 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/golden/go/ignore"
+	"go.skia.org/infra/golden/go/ignore/mocks"
+)
+
+var (
+	windowStart = time.Date(2021, time.March, 4, 0, 0, 0, 0, time.UTC)
+	windowEnd   = windowStart.Add(time.Hour)
+)
+
+const leadTime = 3 * 24 * time.Hour
+
+func TestSendReminders_RulesInWindow_AuthorsEmailed(t *testing.T) {
+	store := &mocks.Store{}
+	store.On("List", testutils.AnyContext).Return([]ignore.Rule{
+		{
+			ID:        "expires-in-three-days",
+			CreatedBy: "alpha@example.com",
+			UpdatedBy: "beta@example.com",
+			Expires:   windowStart.Add(leadTime + 30*time.Minute),
+			Query:     "config=gles",
+			Note:      "skbug.com/1234",
+		},
+		{
+			ID:        "just-expired",
+			CreatedBy: "gamma@example.com",
+			UpdatedBy: "gamma@example.com",
+			Expires:   windowEnd,
+			Query:     "device=taimen",
+			Note:      "flaky",
+		},
+		{
+			ID:        "expires-later",
+			CreatedBy: "alpha@example.com",
+			UpdatedBy: "alpha@example.com",
+			Expires:   windowEnd.Add(leadTime + time.Second),
+		},
+		{
+			ID:        "expired-before-window",
+			CreatedBy: "alpha@example.com",
+			UpdatedBy: "alpha@example.com",
+			Expires:   windowStart,
+		},
+	}, nil)
+	sender := &fakeSender{}
+
+	r := New(store, sender, "gold@example.com", "https://gold.example.com/", leadTime)
+	require.NoError(t, r.SendReminders(context.Background(), windowStart, windowEnd))
+
+	require.Len(t, sender.sent, 2)
+	upcoming := sender.sent[0]
+	assert.Equal(t, []string{"alpha@example.com", "beta@example.com"}, upcoming.to)
+	assert.Equal(t, "Your Gold ignore rule expires soon", upcoming.subject)
+	assert.Contains(t, upcoming.body, "will expire on Sun, 07 Mar 2021 00:30:00 UTC")
+	assert.Contains(t, upcoming.body, "config=gles")
+	assert.Contains(t, upcoming.body, "skbug.com/1234")
+	assert.Contains(t, upcoming.body, `href="https://gold.example.com/ignores"`)
+	assert.Contains(t, upcoming.markup, "https://gold.example.com/ignores")
+
+	expired := sender.sent[1]
+	assert.Equal(t, []string{"gamma@example.com", "gamma@example.com"}, expired.to)
+	assert.Equal(t, "Your Gold ignore rule has expired", expired.subject)
+	assert.Contains(t, expired.body, "expired on Thu, 04 Mar 2021 01:00:00 UTC")
+	assert.Contains(t, expired.body, "device=taimen")
+}
+
+func TestSendReminders_NoAuthorEmail_Skipped(t *testing.T) {
+	store := &mocks.Store{}
+	store.On("List", testutils.AnyContext).Return([]ignore.Rule{
+		{
+			ID:        "anonymous",
+			CreatedBy: "",
+			UpdatedBy: "automation",
+			Expires:   windowEnd,
+		},
+	}, nil)
+	sender := &fakeSender{}
+
+	r := New(store, sender, "gold@example.com", "https://gold.example.com", leadTime)
+	require.NoError(t, r.SendReminders(context.Background(), windowStart, windowEnd))
+	assert.Empty(t, sender.sent)
+}
+
+func TestSendReminders_SendFails_ReturnsError(t *testing.T) {
+	store := &mocks.Store{}
+	store.On("List", testutils.AnyContext).Return([]ignore.Rule{
+		{
+			ID:        "just-expired",
+			CreatedBy: "alpha@example.com",
+			Expires:   windowEnd,
+		},
+	}, nil)
+	sender := &fakeSender{err: errors.New("mail server down")}
+
+	r := New(store, sender, "gold@example.com", "https://gold.example.com", leadTime)
+	err := r.SendReminders(context.Background(), windowStart, windowEnd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mail server down")
+	assert.Contains(t, err.Error(), "just-expired")
+}
+
+type sentEmail struct {
+	to      []string
+	subject string
+	body    string
+	markup  string
+}
+
+// fakeSender records the emails sent, or fails to send them if err is set.
+type fakeSender struct {
+	sent []sentEmail
+	err  error
+}
+
+func (f *fakeSender) SendWithMarkup(_ string, _ string, to []string, subject, body, markup, _ string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.sent = append(f.sent, sentEmail{to: to, subject: subject, body: body, markup: markup})
+	return "message-id", nil
+}
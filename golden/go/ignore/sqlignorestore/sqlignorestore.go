@@ -4,12 +4,15 @@ package sqlignorestore
 import (
 	"context"
 	"net/url"
+	"time"
 
 	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbpgx"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"go.opencensus.io/trace"
 
+	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/golden/go/ignore"
@@ -225,23 +228,142 @@ func (s *StoreImpl) Delete(ctx context.Context, id string) error {
 DELETE FROM IgnoreRules WHERE ignore_rule_id = $1`, id)
 		return err // Don't wrap - crdbpgx might retry
 	})
+	return skerr.Wrap(s.reapplyRemainingRules(ctx, id, existingRulePS))
+}
+
+// reapplyRemainingRules marks the traces that matched the given (removed) rule as "ignored" or
+// not depending on how the rules other than the given one affect them.
+func (s *StoreImpl) reapplyRemainingRules(ctx context.Context, id string, removedRulePS paramtools.ParamSet) error {
 	// We could be updating a lot of traces and values at head here. If done as one big transaction,
 	// that could take a while to land if we are ingesting a lot of new data at the time. As such,
 	// we update in separate transactions.
 	remainingRules, err := s.getOtherRules(ctx, id)
 	if err != nil {
-		return skerr.Wrapf(err, "getting other rules when deleting %s", id)
+		return skerr.Wrapf(err, "getting other rules when removing %s", id)
 	}
 	// Apply those old rules to the traces that match the old paramset
-	if err := conditionallyMarkTracesAsIgnored(ctx, s.db, existingRulePS, remainingRules); err != nil {
+	if err := conditionallyMarkTracesAsIgnored(ctx, s.db, removedRulePS, remainingRules); err != nil {
 		return skerr.Wrap(err)
 	}
-	if err := conditionallyMarkValuesAtHeadAsIgnored(ctx, s.db, existingRulePS, remainingRules); err != nil {
+	if err := conditionallyMarkValuesAtHeadAsIgnored(ctx, s.db, removedRulePS, remainingRules); err != nil {
 		return skerr.Wrap(err)
 	}
 	return nil
 }
 
+// ArchiveExpired moves all rules which expired before the given cutoff to the
+// ArchivedIgnoreRules table, so they no longer match any traces. The traces that matched them are
+// updated as if the rules had been deleted. It returns the archived rules.
+func (s *StoreImpl) ArchiveExpired(ctx context.Context, cutoff time.Time) ([]ignore.Rule, error) {
+	ctx, span := trace.StartSpan(ctx, "ignorestore_ArchiveExpired", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+	rules, err := s.List(ctx)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	var archived []ignore.Rule
+	for _, rule := range rules {
+		if !rule.Expires.Before(cutoff) {
+			continue
+		}
+		if err := s.archive(ctx, rule.ID); err != nil {
+			return archived, skerr.Wrapf(err, "archiving rule %s", rule.ID)
+		}
+		archived = append(archived, rule)
+	}
+	return archived, nil
+}
+
+// archive moves the rule with the given id to the ArchivedIgnoreRules table and updates the
+// traces that matched it.
+func (s *StoreImpl) archive(ctx context.Context, id string) error {
+	ctx, span := trace.StartSpan(ctx, "archive")
+	defer span.End()
+	existingRulePS, err := s.getRuleParamSet(ctx, id)
+	if err != nil {
+		return skerr.Wrapf(err, "getting existing rule with id %s", id)
+	}
+	ts := now.Now(ctx)
+	err = crdbpgx.ExecuteTx(ctx, s.db, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+INSERT INTO ArchivedIgnoreRules (ignore_rule_id, creator_email, updated_email, expires, note, query, archived_ts)
+SELECT ignore_rule_id, creator_email, updated_email, expires, note, query, $2 FROM IgnoreRules
+WHERE ignore_rule_id = $1`, id, ts)
+		if err != nil {
+			return err // Don't wrap - crdbpgx might retry
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM IgnoreRules WHERE ignore_rule_id = $1`, id)
+		return err // Don't wrap - crdbpgx might retry
+	})
+	if err != nil {
+		return skerr.Wrapf(err, "moving rule %s to ArchivedIgnoreRules", id)
+	}
+	return skerr.Wrap(s.reapplyRemainingRules(ctx, id, existingRulePS))
+}
+
+// ListArchived returns all archived ignore rules, most recently expired first.
+func (s *StoreImpl) ListArchived(ctx context.Context) ([]ignore.Rule, error) {
+	ctx, span := trace.StartSpan(ctx, "ignorestore_ListArchived")
+	defer span.End()
+	var rv []ignore.Rule
+	rows, err := s.db.Query(ctx, `SELECT ignore_rule_id, creator_email, updated_email, expires, note, query
+FROM ArchivedIgnoreRules ORDER BY expires DESC`)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var r schema.ArchivedIgnoreRuleRow
+		err := rows.Scan(&r.IgnoreRuleID, &r.CreatorEmail, &r.UpdatedEmail, &r.Expires, &r.Note, &r.Query)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		rv = append(rv, ignore.Rule{
+			ID:        r.IgnoreRuleID.String(),
+			CreatedBy: r.CreatorEmail,
+			UpdatedBy: r.UpdatedEmail,
+			Expires:   r.Expires.UTC(),
+			Query:     url.Values(r.Query).Encode(),
+			Note:      r.Note,
+		})
+	}
+	return rv, nil
+}
+
+// CountMatchingTraces returns the number of traces each rule matches, keyed by rule id. Traces
+// matched by several rules are counted once for each of them.
+func (s *StoreImpl) CountMatchingTraces(ctx context.Context) (map[string]int64, error) {
+	ctx, span := trace.StartSpan(ctx, "ignorestore_CountMatchingTraces")
+	defer span.End()
+	rows, err := s.db.Query(ctx, `SELECT ignore_rule_id, query FROM IgnoreRules`)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	rules := map[string]paramtools.ParamSet{}
+	for rows.Next() {
+		var id uuid.UUID
+		var ps paramtools.ParamSet
+		if err := rows.Scan(&id, &ps); err != nil {
+			rows.Close()
+			return nil, skerr.Wrap(err)
+		}
+		rules[id.String()] = ps
+	}
+	rows.Close()
+
+	rv := make(map[string]int64, len(rules))
+	for id, ps := range rules {
+		condition, arguments := ConvertIgnoreRules([]paramtools.ParamSet{ps})
+		row := s.db.QueryRow(ctx, `SELECT count(*) FROM Traces WHERE `+condition, arguments...)
+		var count int64
+		if err := row.Scan(&count); err != nil {
+			return nil, skerr.Wrapf(err, "counting traces matching rule %s", id)
+		}
+		rv[id] = count
+	}
+	return rv, nil
+}
+
 // getRuleParamSet returns the ParamSet for a given rule.
 func (s *StoreImpl) getRuleParamSet(ctx context.Context, id string) (paramtools.ParamSet, error) {
 	ctx, span := trace.StartSpan(ctx, "getRuleParamSet")
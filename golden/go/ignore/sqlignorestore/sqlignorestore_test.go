@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/golden/go/ignore"
 	"go.skia.org/infra/golden/go/sql/databuilder"
@@ -713,3 +714,94 @@ func TestList_Success(t *testing.T) {
 		Note:      "Taimen isn't drawing correctly enough yet",
 	}}, rules)
 }
+
+func TestArchiveExpired_OnlyExpiredRuleArchived_RemainingRuleStillApplies(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	existingData := dks.Build()
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, existingData))
+	archiveTime := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+	ctx = context.WithValue(ctx, now.ContextKey, archiveTime)
+	store := New(db)
+
+	archived, err := store.ArchiveExpired(ctx, archiveTime)
+	require.NoError(t, err)
+	expiredRule := ignore.Rule{
+		ID:        idForRule(existingData.IgnoreRules, "expired"),
+		CreatedBy: dks.UserTwo,
+		UpdatedBy: dks.UserOne,
+		Expires:   time.Date(2020, time.February, 14, 13, 12, 11, 0, time.UTC),
+		Query:     "device=Nokia4&source_type=corners",
+		Note:      "This rule has expired (and does not apply to anything)",
+	}
+	assert.Equal(t, []ignore.Rule{expiredRule}, archived)
+
+	rules, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, idForRule(existingData.IgnoreRules, "Taimen"), rules[0].ID)
+
+	archivedRules, err := store.ListArchived(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ignore.Rule{expiredRule}, archivedRules)
+
+	rows := sqltest.GetAllRows(ctx, t, db, "ArchivedIgnoreRules", &schema.ArchivedIgnoreRuleRow{}).([]schema.ArchivedIgnoreRuleRow)
+	require.Len(t, rows, 1)
+	assert.Equal(t, archiveTime, rows[0].ArchivedTS)
+
+	actualTraces := getTracesAndStatus(ctx, t, db, "device", "name")
+	assert.Equal(t, schema.NBTrue, actualTraces["taimencircle"])    // Still ignored
+	assert.Equal(t, schema.NBTrue, actualTraces["taimensquare"])    // Still ignored
+	assert.Equal(t, schema.NBFalse, actualTraces["taimentriangle"]) // not affected
+}
+
+func TestArchiveExpired_AllRulesExpired_NoTracesAreIgnored(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	existingData := dks.Build()
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, existingData))
+	store := New(db)
+
+	archived, err := store.ArchiveExpired(ctx, time.Date(2031, time.January, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Len(t, archived, 2)
+
+	rules, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	actualTraces := getTracesAndStatus(ctx, t, db, "device", "name")
+	assert.Equal(t, schema.NBFalse, actualTraces["taimencircle"]) // no longer ignored
+	assert.Equal(t, schema.NBFalse, actualTraces["taimensquare"]) // no longer ignored
+
+	actualValuesAtHead := getValuesAtHeadAndStatus(ctx, t, db, "device", "name")
+	assert.Equal(t, schema.NBFalse, actualValuesAtHead["taimencircle"]) // no longer ignored
+	assert.Equal(t, schema.NBFalse, actualValuesAtHead["taimensquare"]) // no longer ignored
+}
+
+func TestCountMatchingTraces_Success(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	existingData := dks.Build()
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, existingData))
+	store := New(db)
+
+	expectedTaimen := int64(0)
+	for _, tr := range existingData.Traces {
+		if tr.Keys[dks.DeviceKey] == dks.TaimenDevice &&
+			(tr.Keys[types.PrimaryKeyField] == dks.SquareTest || tr.Keys[types.PrimaryKeyField] == dks.CircleTest) {
+			expectedTaimen++
+		}
+	}
+	require.NotZero(t, expectedTaimen)
+
+	counts, err := store.CountMatchingTraces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		idForRule(existingData.IgnoreRules, "expired"): 0,
+		idForRule(existingData.IgnoreRules, "Taimen"):  expectedTaimen,
+	}, counts)
+}
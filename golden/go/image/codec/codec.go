@@ -0,0 +1,129 @@
+// Package codec detects and decodes the image formats Gold accepts for digests. Digests are
+// stored in GCS as <digest>.png regardless of their actual encoding, so the format is always
+// detected from the image bytes themselves.
+//
+// WebP is decoded with golang.org/x/image, which has no AVIF decoder. AVIF is decoded with
+// github.com/gen2brain/avif, which runs libavif compiled to WASM on the pure Go wazero runtime.
+// Unlike the cgo bindings to libavif it needs no C toolchain or system libraries, so Gold's
+// binaries and images are built exactly as before.
+package codec
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"sync"
+
+	"github.com/gen2brain/avif"
+	"golang.org/x/image/webp"
+
+	"go.skia.org/infra/go/skerr"
+)
+
+// Format is an image encoding supported by Gold.
+type Format string
+
+const (
+	Unknown Format = ""
+	PNG     Format = "png"
+	WebP    Format = "webp"
+	AVIF    Format = "avif"
+)
+
+var (
+	pngMagic = []byte("\x89PNG\r\n\x1a\n")
+
+	// avifMutex guards the AVIF decoder, which is backed by a single WASM module instance that
+	// is not safe for concurrent use.
+	avifMutex sync.Mutex
+)
+
+// DetectFormat returns the format of the encoded image in b, or Unknown if it is not one of
+// the supported formats.
+func DetectFormat(b []byte) Format {
+	switch {
+	case bytes.HasPrefix(b, pngMagic):
+		return PNG
+	case len(b) >= 12 && string(b[0:4]) == "RIFF" && string(b[8:12]) == "WEBP":
+		return WebP
+	case len(b) >= 12 && string(b[4:8]) == "ftyp" && (string(b[8:12]) == "avif" || string(b[8:12]) == "avis"):
+		return AVIF
+	default:
+		return Unknown
+	}
+}
+
+// ContentType returns the MIME type of the given format. Unknown formats are reported as
+// application/octet-stream.
+func (f Format) ContentType() string {
+	switch f {
+	case PNG:
+		return "image/png"
+	case WebP:
+		return "image/webp"
+	case AVIF:
+		return "image/avif"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Decode decodes the PNG, WebP or AVIF image in b.
+func Decode(b []byte) (image.Image, error) {
+	switch f := DetectFormat(b); f {
+	case PNG:
+		img, err := png.Decode(bytes.NewReader(b))
+		return img, skerr.Wrapf(err, "decoding PNG")
+	case WebP:
+		img, err := webp.Decode(bytes.NewReader(b))
+		return img, skerr.Wrapf(err, "decoding WebP")
+	case AVIF:
+		return decodeAVIF(b)
+	default:
+		return nil, skerr.Fmt("unsupported image format")
+	}
+}
+
+// DecodeConfig returns the dimensions of the PNG, WebP or AVIF image in b without decoding all
+// of its pixels.
+func DecodeConfig(b []byte) (image.Config, error) {
+	switch f := DetectFormat(b); f {
+	case PNG:
+		cfg, err := png.DecodeConfig(bytes.NewReader(b))
+		return cfg, skerr.Wrapf(err, "decoding PNG config")
+	case WebP:
+		cfg, err := webp.DecodeConfig(bytes.NewReader(b))
+		return cfg, skerr.Wrapf(err, "decoding WebP config")
+	case AVIF:
+		avifMutex.Lock()
+		defer avifMutex.Unlock()
+		cfg, err := avif.DecodeConfig(bytes.NewReader(b))
+		return cfg, skerr.Wrapf(err, "decoding AVIF config")
+	default:
+		return image.Config{}, skerr.Fmt("unsupported image format")
+	}
+}
+
+// decodeAVIF decodes the AVIF image in b. The decoder returns an *image.RGBA, but its pixels
+// are not premultiplied by alpha, so they are returned as an *image.NRGBA.
+func decodeAVIF(b []byte) (image.Image, error) {
+	avifMutex.Lock()
+	defer avifMutex.Unlock()
+	img, err := avif.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "decoding AVIF")
+	}
+	// The decoder does not report all malformed inputs as errors, some produce an empty image.
+	if img.Bounds().Empty() {
+		return nil, skerr.Fmt("decoding AVIF: empty or malformed image")
+	}
+	rgba, ok := img.(*image.RGBA)
+	if !ok {
+		return img, nil
+	}
+	return &image.NRGBA{
+		Pix:    rgba.Pix,
+		Stride: rgba.Stride,
+		Rect:   rgba.Rect,
+	}, nil
+}
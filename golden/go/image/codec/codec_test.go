@@ -0,0 +1,80 @@
+package codec
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transparentWebP is a lossless 1x1 WebP image with a single fully transparent pixel.
+const transparentWebP = "RIFF\x1a\x00\x00\x00WEBPVP8L\x0d\x00\x00\x00\x2f\x00\x00\x00\x10\x07\x10\x11\x11\x88\x88\xfe\x07\x00"
+
+func TestDetectFormat_Success(t *testing.T) {
+	assert.Equal(t, PNG, DetectFormat(encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 1, 1)))))
+	assert.Equal(t, WebP, DetectFormat([]byte(transparentWebP)))
+	assert.Equal(t, AVIF, DetectFormat([]byte("\x00\x00\x00\x1cftypavif\x00\x00\x00\x00")))
+	assert.Equal(t, AVIF, DetectFormat([]byte("\x00\x00\x00\x1cftypavis\x00\x00\x00\x00")))
+	assert.Equal(t, Unknown, DetectFormat([]byte("GIF89a")))
+	assert.Equal(t, Unknown, DetectFormat(nil))
+}
+
+func TestContentType_Success(t *testing.T) {
+	assert.Equal(t, "image/png", PNG.ContentType())
+	assert.Equal(t, "image/webp", WebP.ContentType())
+	assert.Equal(t, "image/avif", AVIF.ContentType())
+	assert.Equal(t, "application/octet-stream", Unknown.ContentType())
+}
+
+func TestDecode_PNG_Success(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 0xff, A: 0xff})
+	img.SetNRGBA(1, 0, color.NRGBA{B: 0xff, A: 0x80})
+
+	decoded, err := Decode(encodePNG(t, img))
+	require.NoError(t, err)
+	assert.Equal(t, img, decoded)
+
+	cfg, err := DecodeConfig(encodePNG(t, img))
+	require.NoError(t, err)
+	assert.Equal(t, 2, cfg.Width)
+	assert.Equal(t, 1, cfg.Height)
+}
+
+func TestDecode_WebP_Success(t *testing.T) {
+	decoded, err := Decode([]byte(transparentWebP))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 1, 1), decoded.Bounds())
+	assert.Equal(t, color.NRGBA{}, color.NRGBAModel.Convert(decoded.At(0, 0)))
+
+	cfg, err := DecodeConfig([]byte(transparentWebP))
+	require.NoError(t, err)
+	assert.Equal(t, 1, cfg.Width)
+	assert.Equal(t, 1, cfg.Height)
+}
+
+func TestDecode_UnsupportedFormat_ReturnsError(t *testing.T) {
+	_, err := Decode([]byte("GIF89a"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported image format")
+
+	_, err = DecodeConfig([]byte("GIF89a"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported image format")
+}
+
+func TestDecode_CorruptAVIF_ReturnsError(t *testing.T) {
+	_, err := Decode([]byte("\x00\x00\x00\x1cftypavif\x00\x00\x00\x00"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "decoding AVIF")
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
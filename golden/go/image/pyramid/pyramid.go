@@ -0,0 +1,150 @@
+// Package pyramid generates downscaled versions of images, so that the UI can
+// show thumbnails without downloading full-size PNGs.
+//
+// Each level of the pyramid is identified by the length in pixels of the
+// longest side of the image, see Sizes.
+package pyramid
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/golden/go/image/codec"
+)
+
+// Sizes are the supported levels of the pyramid, i.e. the maximum width and
+// height of a downscaled image.
+var Sizes = []int{256, 512, 1024}
+
+// ThumbnailSizes are the levels of the pyramid which are precomputed for every digest by the
+// diffcalculator, i.e. the small and medium renditions shown in the triage grid. Other sizes are
+// downscaled on demand.
+var ThumbnailSizes = []int{256, 512}
+
+// IsValidSize returns true if size is one of Sizes.
+func IsValidSize(size int) bool {
+	for _, s := range Sizes {
+		if s == size {
+			return true
+		}
+	}
+	return false
+}
+
+// IsThumbnailSize returns true if size is one of ThumbnailSizes.
+func IsThumbnailSize(size int) bool {
+	for _, s := range ThumbnailSizes {
+		if s == size {
+			return true
+		}
+	}
+	return false
+}
+
+// Downscale returns the img scaled down so that neither side is longer than
+// size pixels, keeping the aspect ratio. Each destination pixel is the alpha
+// weighted average of the source pixels it covers. If the image already fits
+// then it is returned as an *image.NRGBA unscaled.
+func Downscale(img image.Image, size int) *image.NRGBA {
+	src := toNRGBA(img)
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw <= size && sh <= size {
+		return src
+	}
+	dw, dh := size, size
+	if sw > sh {
+		dh = max(1, sh*size/sw)
+	} else {
+		dw = max(1, sw*size/sh)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		y0, y1 := dy*sh/dh, max((dy+1)*sh/dh, dy*sh/dh+1)
+		for dx := 0; dx < dw; dx++ {
+			x0, x1 := dx*sw/dw, max((dx+1)*sw/dw, dx*sw/dw+1)
+			var r, g, b, a, n uint64
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					c := src.NRGBAAt(x, y)
+					ca := uint64(c.A)
+					r += uint64(c.R) * ca
+					g += uint64(c.G) * ca
+					b += uint64(c.B) * ca
+					a += ca
+					n++
+				}
+			}
+			if a == 0 {
+				continue
+			}
+			dst.SetNRGBA(dx, dy, color.NRGBA{
+				R: uint8(r / a),
+				G: uint8(g / a),
+				B: uint8(b / a),
+				A: uint8(a / n),
+			})
+		}
+	}
+	return dst
+}
+
+// DownscalePNG decodes the PNG, WebP or AVIF image in b and returns it
+// downscaled to the given size, encoded as a PNG. If the image already fits
+// within size then b is returned unaltered, so that color profiles and 16-bit
+// images are preserved where possible.
+func DownscalePNG(b []byte, size int) ([]byte, error) {
+	if !IsValidSize(size) {
+		return nil, skerr.Fmt("invalid size %d, must be one of %v", size, Sizes)
+	}
+	cfg, err := codec.DecodeConfig(b)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if cfg.Width <= size && cfg.Height <= size {
+		return b, nil
+	}
+	img, err := codec.Decode(b)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return encodeDownscaled(img, size)
+}
+
+// DownscaleDecoded is like DownscalePNG, but for an image which has already been decoded from b,
+// so that it does not need to be decoded again.
+func DownscaleDecoded(b []byte, img image.Image, size int) ([]byte, error) {
+	if !IsValidSize(size) {
+		return nil, skerr.Fmt("invalid size %d, must be one of %v", size, Sizes)
+	}
+	if img.Bounds().Dx() <= size && img.Bounds().Dy() <= size {
+		return b, nil
+	}
+	return encodeDownscaled(img, size)
+}
+
+// encodeDownscaled returns img downscaled to the given size, encoded as a PNG.
+func encodeDownscaled(img image.Image, size int) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := encoder.Encode(&buf, Downscale(img, size)); err != nil {
+		return nil, skerr.Wrapf(err, "encoding PNG")
+	}
+	return buf.Bytes(), nil
+}
+
+// toNRGBA returns the image as an *image.NRGBA whose bounds start at the
+// origin.
+func toNRGBA(img image.Image) *image.NRGBA {
+	if n, ok := img.(*image.NRGBA); ok && n.Bounds().Min == (image.Point{}) {
+		return n
+	}
+	b := img.Bounds()
+	ret := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(ret, ret.Bounds(), img, b.Min, draw.Src)
+	return ret
+}
@@ -0,0 +1,128 @@
+package pyramid
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/golden/go/image/text"
+)
+
+func TestIsValidSize(t *testing.T) {
+	assert.True(t, IsValidSize(256))
+	assert.True(t, IsValidSize(512))
+	assert.True(t, IsValidSize(1024))
+	assert.False(t, IsValidSize(0))
+	assert.False(t, IsValidSize(300))
+}
+
+func TestIsThumbnailSize(t *testing.T) {
+	assert.True(t, IsThumbnailSize(256))
+	assert.True(t, IsThumbnailSize(512))
+	assert.False(t, IsThumbnailSize(1024))
+	assert.False(t, IsThumbnailSize(0))
+}
+
+func TestDownscale_ImageAlreadyFits_ReturnedUnscaled(t *testing.T) {
+	img := text.MustToNRGBA(`! SKTEXTSIMPLE
+2 1
+0x000000ff 0xffffffff`)
+	assert.Equal(t, img, Downscale(img, 256))
+}
+
+func TestDownscale_WideImage_AveragesPixelsAndKeepsAspectRatio(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1024, 512))
+	for y := 0; y < 512; y++ {
+		for x := 0; x < 1024; x++ {
+			// Alternate black and white columns, which should average to gray.
+			if x%2 == 0 {
+				img.SetNRGBA(x, y, color.NRGBA{A: 0xff})
+			} else {
+				img.SetNRGBA(x, y, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+			}
+		}
+	}
+
+	scaled := Downscale(img, 256)
+	assert.Equal(t, image.Rect(0, 0, 256, 128), scaled.Bounds())
+	assert.Equal(t, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff}, scaled.NRGBAAt(10, 10))
+}
+
+func TestDownscale_TallImageWithTransparentPixels_ColorNotDarkened(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 600))
+	for y := 0; y < 600; y++ {
+		img.SetNRGBA(0, y, color.NRGBA{R: 0xff, A: 0xff})
+		// Fully transparent black should not pull the red towards black.
+		img.SetNRGBA(1, y, color.NRGBA{})
+	}
+
+	scaled := Downscale(img, 256)
+	assert.Equal(t, image.Rect(0, 0, 1, 256), scaled.Bounds())
+	assert.Equal(t, color.NRGBA{R: 0xff, A: 0x7f}, scaled.NRGBAAt(0, 0))
+}
+
+func TestDownscalePNG_LargeImage_ReturnsSmallerPNG(t *testing.T) {
+	b := encodeForTest(t, image.NewNRGBA(image.Rect(0, 0, 2000, 1000)))
+
+	scaled, err := DownscalePNG(b, 512)
+	require.NoError(t, err)
+	cfg, err := png.DecodeConfig(bytes.NewReader(scaled))
+	require.NoError(t, err)
+	assert.Equal(t, 512, cfg.Width)
+	assert.Equal(t, 256, cfg.Height)
+}
+
+func TestDownscalePNG_SmallImage_ReturnsOriginalBytes(t *testing.T) {
+	b := encodeForTest(t, image.NewNRGBA(image.Rect(0, 0, 100, 100)))
+
+	scaled, err := DownscalePNG(b, 256)
+	require.NoError(t, err)
+	assert.Equal(t, b, scaled)
+}
+
+func TestDownscalePNG_InvalidSize_ReturnsError(t *testing.T) {
+	_, err := DownscalePNG(encodeForTest(t, image.NewNRGBA(image.Rect(0, 0, 1, 1))), 100)
+	require.Error(t, err)
+}
+
+func TestDownscalePNG_NotAPNG_ReturnsError(t *testing.T) {
+	_, err := DownscalePNG([]byte("not a png"), 256)
+	require.Error(t, err)
+}
+
+func TestDownscaleDecoded_LargeImage_ReturnsSmallerPNG(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1000, 2000))
+	b := encodeForTest(t, img)
+
+	scaled, err := DownscaleDecoded(b, img, 256)
+	require.NoError(t, err)
+	cfg, err := png.DecodeConfig(bytes.NewReader(scaled))
+	require.NoError(t, err)
+	assert.Equal(t, 128, cfg.Width)
+	assert.Equal(t, 256, cfg.Height)
+}
+
+func TestDownscaleDecoded_SmallImage_ReturnsOriginalBytes(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 100))
+	b := encodeForTest(t, img)
+
+	scaled, err := DownscaleDecoded(b, img, 512)
+	require.NoError(t, err)
+	assert.Equal(t, b, scaled)
+}
+
+func TestThumbnailSizes_AreValidSizes(t *testing.T) {
+	for _, size := range ThumbnailSizes {
+		assert.True(t, IsValidSize(size), size)
+	}
+}
+
+func encodeForTest(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
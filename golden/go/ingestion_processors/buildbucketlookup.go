@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strconv"
 
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
 	"go.opencensus.io/trace"
 	"golang.org/x/time/rate"
 
@@ -19,6 +20,13 @@ const (
 	// by previous implementation in production.
 	maxQPS   = rate.Limit(10.0)
 	maxBurst = 40
+
+	// Buildbucket tags which identify the CL and PS of a tryjob which was not
+	// triggered by the CQ, and therefore has no Gerrit changes in its input.
+	// The CRS tag is optional and defaults to gerrit.
+	goldCRSTag           = "gold_crs"
+	goldChangelistIDTag  = "gold_changelist_id"
+	goldPatchsetOrderTag = "gold_patchset_order"
 )
 
 type LookupSystem interface {
@@ -27,7 +35,7 @@ type LookupSystem interface {
 	Lookup(ctx context.Context, tjID string) (string, string, int, error)
 }
 
-func newBuildbucketLookupClient(client *buildbucket.Client) *bbLookupClient {
+func newBuildbucketLookupClient(client buildbucket.BuildBucketInterface) *bbLookupClient {
 	return &bbLookupClient{
 		client: client,
 		rl:     rate.NewLimiter(maxQPS, maxBurst),
@@ -35,12 +43,13 @@ func newBuildbucketLookupClient(client *buildbucket.Client) *bbLookupClient {
 }
 
 type bbLookupClient struct {
-	client *buildbucket.Client
+	client buildbucket.BuildBucketInterface
 	rl     *rate.Limiter
 }
 
 // Lookup takes the given Buildbucket ID and looks up the associated Gerrit CL. There may be more
-// than one, so for now, it returns the one with the highest CL ID.
+// than one, so for now, it returns the one with the highest CL ID. Builds which were not triggered
+// by the CQ may instead identify their CL using the gold_* tags.
 func (b *bbLookupClient) Lookup(ctx context.Context, tjID string) (string, string, int, error) {
 	// Respect the rate limit.
 	if err := b.rl.Wait(ctx); err != nil {
@@ -59,10 +68,35 @@ func (b *bbLookupClient) Lookup(ctx context.Context, tjID string) (string, strin
 	}
 	cls := build.Input.GerritChanges
 	if len(cls) == 0 {
-		return "", "", 0, skerr.Fmt("Tryjob %s had no CLs associated with it", tjID)
+		return lookupFromTags(tjID, build.Tags)
 	}
 	sort.Slice(cls, func(i, j int) bool {
 		return cls[i].Change > cls[j].Change
 	})
 	return "gerrit", strconv.FormatInt(cls[0].Change, 10), int(cls[0].Patchset), nil
 }
+
+// lookupFromTags returns the CRS, CL ID and PS Order given by the gold_* tags of a build.
+func lookupFromTags(tjID string, tags []*buildbucketpb.StringPair) (string, string, int, error) {
+	crs := gerritCRS
+	clID := ""
+	psOrder := 0
+	for _, tag := range tags {
+		switch tag.Key {
+		case goldCRSTag:
+			crs = tag.Value
+		case goldChangelistIDTag:
+			clID = tag.Value
+		case goldPatchsetOrderTag:
+			var err error
+			psOrder, err = strconv.Atoi(tag.Value)
+			if err != nil {
+				return "", "", 0, skerr.Wrapf(err, "Tryjob %s has invalid %s tag %q", tjID, goldPatchsetOrderTag, tag.Value)
+			}
+		}
+	}
+	if clID == "" || psOrder <= 0 {
+		return "", "", 0, skerr.Fmt("Tryjob %s had no CLs associated with it", tjID)
+	}
+	return crs, clID, psOrder, nil
+}
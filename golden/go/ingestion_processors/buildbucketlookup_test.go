@@ -0,0 +1,84 @@
+package ingestion_processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+
+	"go.skia.org/infra/go/buildbucket/mocks"
+	"go.skia.org/infra/go/testutils"
+)
+
+const lookupTestBuildID = int64(8851407306039688080)
+
+func TestBuildbucketLookup_GerritChanges_UsesHighestCL(t *testing.T) {
+	mbi := &mocks.BuildBucketInterface{}
+	mbi.On("GetBuild", testutils.AnyContext, lookupTestBuildID).Return(&buildbucketpb.Build{
+		Input: &buildbucketpb.Build_Input{
+			GerritChanges: []*buildbucketpb.GerritChange{
+				{Change: 389927, Patchset: 1},
+				{Change: 390001, Patchset: 3},
+			},
+		},
+	}, nil)
+	bc := newBuildbucketLookupClient(mbi)
+
+	crsName, clID, psOrder, err := bc.Lookup(context.Background(), "8851407306039688080")
+	require.NoError(t, err)
+	assert.Equal(t, "gerrit", crsName)
+	assert.Equal(t, "390001", clID)
+	assert.Equal(t, 3, psOrder)
+}
+
+func TestBuildbucketLookup_NoGerritChanges_UsesGoldTags(t *testing.T) {
+	mbi := &mocks.BuildBucketInterface{}
+	mbi.On("GetBuild", testutils.AnyContext, lookupTestBuildID).Return(&buildbucketpb.Build{
+		Input: &buildbucketpb.Build_Input{},
+		Tags: []*buildbucketpb.StringPair{
+			{Key: "buildset", Value: "some-buildset"},
+			{Key: goldCRSTag, Value: "github"},
+			{Key: goldChangelistIDTag, Value: "1234"},
+			{Key: goldPatchsetOrderTag, Value: "5"},
+		},
+	}, nil)
+	bc := newBuildbucketLookupClient(mbi)
+
+	crsName, clID, psOrder, err := bc.Lookup(context.Background(), "8851407306039688080")
+	require.NoError(t, err)
+	assert.Equal(t, "github", crsName)
+	assert.Equal(t, "1234", clID)
+	assert.Equal(t, 5, psOrder)
+}
+
+func TestLookupFromTags_DefaultsToGerrit(t *testing.T) {
+	crsName, clID, psOrder, err := lookupFromTags("123", []*buildbucketpb.StringPair{
+		{Key: goldChangelistIDTag, Value: "1234"},
+		{Key: goldPatchsetOrderTag, Value: "2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gerritCRS, crsName)
+	assert.Equal(t, "1234", clID)
+	assert.Equal(t, 2, psOrder)
+}
+
+func TestLookupFromTags_MissingOrInvalidTags_Error(t *testing.T) {
+	_, _, _, err := lookupFromTags("123", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "had no CLs associated with it")
+
+	_, _, _, err = lookupFromTags("123", []*buildbucketpb.StringPair{
+		{Key: goldChangelistIDTag, Value: "1234"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "had no CLs associated with it")
+
+	_, _, _, err = lookupFromTags("123", []*buildbucketpb.StringPair{
+		{Key: goldChangelistIDTag, Value: "1234"},
+		{Key: goldPatchsetOrderTag, Value: "latest"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid gold_patchset_order tag")
+}
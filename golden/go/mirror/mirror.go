@@ -0,0 +1,367 @@
+// Package mirror lets public views of a Gold instance reuse work done by the authoritative
+// instance. The authoritative instance periodically computes which traces are publicly viewable
+// and the triaged expectations of those traces, and publishes them as a single sanitized
+// Artifact. Public views subscribe to that Artifact instead of scanning the Traces and
+// Expectations tables themselves. Because the Artifact only contains data which matches the
+// publicly viewable params, it never leaks the keys or expectations of private traces.
+package mirror
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opencensus.io/trace"
+
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/expectations/bulk"
+	"go.skia.org/infra/golden/go/publicparams"
+	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/types"
+)
+
+const (
+	// CurrentVersion is the version of the Artifact format written by the Publisher. Subscribers
+	// reject Artifacts of any other version.
+	CurrentVersion = 1
+
+	// maxArtifactBytes is the maximum size of an Artifact which a Subscriber will accept.
+	maxArtifactBytes = 512 * 1024 * 1024
+)
+
+// Artifact is the sanitized data which a public view needs from the authoritative instance.
+type Artifact struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	// Corpora are the corpora with at least one publicly viewable trace, sorted.
+	Corpora []string `json:"corpora"`
+	// TraceIDs are the hex-encoded IDs of the publicly viewable traces, sorted.
+	TraceIDs []string `json:"trace_ids"`
+	// Expectations are the triaged digests on the primary branch of the groupings which have at
+	// least one publicly viewable trace.
+	Expectations []bulk.Expectation `json:"expectations"`
+}
+
+// PublicTraces returns the IDs of the publicly viewable traces.
+func (a *Artifact) PublicTraces() (map[schema.MD5Hash]struct{}, error) {
+	rv := make(map[schema.MD5Hash]struct{}, len(a.TraceIDs))
+	for _, id := range a.TraceIDs {
+		b, err := hex.DecodeString(id)
+		if err != nil || len(b) != len(schema.MD5Hash{}) {
+			return nil, skerr.Fmt("invalid trace ID %q", id)
+		}
+		var traceKey schema.MD5Hash
+		copy(traceKey[:], b)
+		rv[traceKey] = struct{}{}
+	}
+	return rv, nil
+}
+
+// PublicCorpora returns the corpora with at least one publicly viewable trace.
+func (a *Artifact) PublicCorpora() map[string]struct{} {
+	rv := make(map[string]struct{}, len(a.Corpora))
+	for _, c := range a.Corpora {
+		rv[c] = struct{}{}
+	}
+	return rv
+}
+
+// Baseline returns the Expectations of the Artifact as a Baseline keyed by test name.
+func (a *Artifact) Baseline() expectations.Baseline {
+	rv := expectations.Baseline{}
+	for _, e := range a.Expectations {
+		testName := types.TestName(e.Grouping[types.PrimaryKeyField])
+		byDigest, ok := rv[testName]
+		if !ok {
+			byDigest = map[types.Digest]expectations.Label{}
+			rv[testName] = byDigest
+		}
+		byDigest[e.Digest] = e.Label
+	}
+	return rv
+}
+
+// Build computes the Artifact for the traces which match the given publicly viewable params.
+func Build(ctx context.Context, db *pgxpool.Pool, matcher publicparams.Matcher) (*Artifact, error) {
+	ctx, span := trace.StartSpan(ctx, "mirror_Build")
+	defer span.End()
+	if matcher == nil {
+		return nil, skerr.Fmt("publicly viewable params are required")
+	}
+	rows, err := db.Query(ctx, `SELECT trace_id, grouping_id, keys FROM Traces AS OF SYSTEM TIME '-0.1s'`)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	corpora := util.StringSet{}
+	traceIDs := []string{}
+	groupings := map[schema.MD5Hash]bool{}
+	for rows.Next() {
+		var traceID schema.TraceID
+		var groupingID schema.GroupingID
+		var keys paramtools.Params
+		if err := rows.Scan(&traceID, &groupingID, &keys); err != nil {
+			rows.Close()
+			return nil, skerr.Wrap(err)
+		}
+		if !matcher.Matches(keys) {
+			continue
+		}
+		corpora[keys[types.CorpusField]] = true
+		traceIDs = append(traceIDs, hex.EncodeToString(traceID))
+		var groupingKey schema.MD5Hash
+		copy(groupingKey[:], groupingID)
+		groupings[groupingKey] = true
+	}
+	rows.Close()
+
+	exps, err := getExpectations(ctx, db, groupings)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	sortedCorpora := corpora.Keys()
+	sort.Strings(sortedCorpora)
+	sort.Strings(traceIDs)
+	span.AddAttributes(
+		trace.Int64Attribute("numTraces", int64(len(traceIDs))),
+		trace.Int64Attribute("numExpectations", int64(len(exps))))
+	return &Artifact{
+		Version:      CurrentVersion,
+		ExportedAt:   now.Now(ctx).UTC(),
+		Corpora:      sortedCorpora,
+		TraceIDs:     traceIDs,
+		Expectations: exps,
+	}, nil
+}
+
+// getExpectations returns the triaged expectations on the primary branch of the given groupings,
+// sorted by grouping and digest.
+func getExpectations(ctx context.Context, db *pgxpool.Pool, groupings map[schema.MD5Hash]bool) ([]bulk.Expectation, error) {
+	ctx, span := trace.StartSpan(ctx, "getExpectations")
+	defer span.End()
+	const statement = `SELECT Expectations.grouping_id, Groupings.keys, encode(digest, 'hex'), label
+FROM Expectations JOIN Groupings ON Expectations.grouping_id = Groupings.grouping_id
+AS OF SYSTEM TIME '-0.1s'
+WHERE label = 'p' OR label = 'n'`
+	rows, err := db.Query(ctx, statement)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	defer rows.Close()
+	rv := []bulk.Expectation{}
+	for rows.Next() {
+		var groupingID schema.GroupingID
+		var grouping paramtools.Params
+		var digest types.Digest
+		var label schema.ExpectationLabel
+		if err := rows.Scan(&groupingID, &grouping, &digest, &label); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		var groupingKey schema.MD5Hash
+		copy(groupingKey[:], groupingID)
+		if !groupings[groupingKey] {
+			continue
+		}
+		rv = append(rv, bulk.Expectation{
+			Grouping: grouping,
+			Digest:   digest,
+			Label:    label.ToExpectation(),
+		})
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		ti, tj := rv[i].Grouping[types.PrimaryKeyField], rv[j].Grouping[types.PrimaryKeyField]
+		if ti != tj {
+			return ti < tj
+		}
+		ci, cj := rv[i].Grouping[types.CorpusField], rv[j].Grouping[types.CorpusField]
+		if ci != cj {
+			return ci < cj
+		}
+		return rv[i].Digest < rv[j].Digest
+	})
+	return rv, nil
+}
+
+// Publisher serves the latest Artifact of the authoritative instance to its Subscribers.
+type Publisher struct {
+	db      *pgxpool.Pool
+	matcher publicparams.Matcher
+
+	mutex   sync.RWMutex
+	encoded []byte
+	etag    string
+}
+
+// NewPublisher returns a Publisher for the traces which match the given publicly viewable params.
+func NewPublisher(db *pgxpool.Pool, matcher publicparams.Matcher) *Publisher {
+	return &Publisher{
+		db:      db,
+		matcher: matcher,
+	}
+}
+
+// Start builds the Artifact and then keeps it up to date in the background. It returns an error if
+// the initial build fails.
+func (p *Publisher) Start(ctx context.Context, interval time.Duration) error {
+	if err := p.update(ctx); err != nil {
+		return skerr.Wrapf(err, "initializing public mirror artifact")
+	}
+	sklog.Infof("Successfully initialized public mirror artifact.")
+
+	go util.RepeatCtx(ctx, interval, func(ctx context.Context) {
+		if err := p.update(ctx); err != nil {
+			sklog.Warningf("Could not update public mirror artifact: %s", err)
+		}
+	})
+	return nil
+}
+
+// update rebuilds the Artifact.
+func (p *Publisher) update(ctx context.Context) error {
+	a, err := Build(ctx, p.db, p.matcher)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	return skerr.Wrap(p.set(a))
+}
+
+// set encodes and stores the given Artifact. The ETag only depends on the contents of the Artifact,
+// so Subscribers don't download it again if nothing has changed since it was last built.
+func (p *Publisher) set(a *Artifact) error {
+	contents := *a
+	contents.ExportedAt = time.Time{}
+	b, err := json.Marshal(contents)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	h := sha256.Sum256(b)
+	etag := fmt.Sprintf("%q", hex.EncodeToString(h[:]))
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if etag == p.etag {
+		return nil
+	}
+	encoded, err := json.Marshal(a)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	p.encoded = encoded
+	p.etag = etag
+	return nil
+}
+
+// ServeHTTP implements http.Handler. It responds with the latest Artifact, or with 304 Not Modified
+// if the client already has it.
+func (p *Publisher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mutex.RLock()
+	encoded, etag := p.encoded, p.etag
+	p.mutex.RUnlock()
+	if encoded == nil {
+		http.Error(w, "Public mirror is not ready yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(encoded); err != nil {
+		sklog.Errorf("Failed to write public mirror artifact: %s", err)
+	}
+}
+
+// Subscriber retrieves the Artifact published by the authoritative instance.
+type Subscriber struct {
+	client *http.Client
+	url    string
+
+	// etag is the ETag of the last Artifact retrieved. Only accessed by Fetch, which must not be
+	// called concurrently.
+	etag string
+}
+
+// NewSubscriber returns a Subscriber for the Artifact served at the given URL.
+func NewSubscriber(client *http.Client, url string) *Subscriber {
+	return &Subscriber{
+		client: client,
+		url:    url,
+	}
+}
+
+// Fetch retrieves the Artifact. It returns nil if the Artifact has not changed since the last call.
+func (s *Subscriber) Fetch(ctx context.Context) (*Artifact, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "retrieving public mirror artifact from %s", s.url)
+	}
+	defer util.Close(resp.Body)
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, skerr.Fmt("retrieving public mirror artifact from %s: %s", s.url, httputils.ReadAndClose(resp.Body))
+	}
+	var a Artifact
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxArtifactBytes)).Decode(&a); err != nil {
+		return nil, skerr.Wrapf(err, "decoding public mirror artifact")
+	}
+	if a.Version != CurrentVersion {
+		return nil, skerr.Fmt("unsupported public mirror artifact version %d; expected %d", a.Version, CurrentVersion)
+	}
+	s.etag = resp.Header.Get("ETag")
+	return &a, nil
+}
+
+// Start retrieves the Artifact and calls apply with it, and then keeps polling for changes in the
+// background, calling apply each time the Artifact changes. It returns an error if the initial
+// retrieval fails or if apply returns an error for it.
+func (s *Subscriber) Start(ctx context.Context, interval time.Duration, apply func(*Artifact) error) error {
+	cycle := func(ctx context.Context) error {
+		a, err := s.Fetch(ctx)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		if a == nil {
+			return nil
+		}
+		if err := apply(a); err != nil {
+			// Retrieve the Artifact again next time, even if it hasn't changed.
+			s.etag = ""
+			return skerr.Wrap(err)
+		}
+		return nil
+	}
+	if err := cycle(ctx); err != nil {
+		return skerr.Wrapf(err, "initializing public mirror")
+	}
+	sklog.Infof("Successfully initialized public mirror from %s.", s.url)
+
+	go util.RepeatCtx(ctx, interval, func(ctx context.Context) {
+		if err := cycle(ctx); err != nil {
+			sklog.Warningf("Could not update public mirror: %s", err)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,190 @@
+package mirror
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/expectations/bulk"
+	"go.skia.org/infra/golden/go/publicparams"
+	dks "go.skia.org/infra/golden/go/sql/datakitchensink"
+	"go.skia.org/infra/golden/go/sql/schema"
+	"go.skia.org/infra/golden/go/sql/sqltest"
+	"go.skia.org/infra/golden/go/types"
+)
+
+const fakeTraceID = "00112233445566778899aabbccddeeff"
+
+var circleGrouping = paramtools.Params{
+	types.CorpusField:     dks.RoundCorpus,
+	types.PrimaryKeyField: dks.CircleTest,
+}
+
+func testArtifact() *Artifact {
+	return &Artifact{
+		Version:    CurrentVersion,
+		ExportedAt: time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC),
+		Corpora:    []string{dks.RoundCorpus},
+		TraceIDs:   []string{fakeTraceID},
+		Expectations: []bulk.Expectation{
+			{Grouping: circleGrouping, Digest: dks.DigestC01Pos, Label: expectations.Positive},
+			{Grouping: circleGrouping, Digest: dks.DigestC05Unt, Label: expectations.Negative},
+		},
+	}
+}
+
+func TestBuild_OnlyIncludesPubliclyViewableData(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	data := dks.Build()
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, data))
+	exportTime := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+	ctx = context.WithValue(ctx, now.ContextKey, exportTime)
+
+	matcher, err := publicparams.MatcherFromRules(publicparams.MatchingRules{
+		dks.RoundCorpus: {
+			dks.DeviceKey: {dks.QuadroDevice},
+		},
+	})
+	require.NoError(t, err)
+
+	a, err := Build(ctx, db, matcher)
+	require.NoError(t, err)
+	assert.Equal(t, CurrentVersion, a.Version)
+	assert.Equal(t, exportTime, a.ExportedAt)
+	assert.Equal(t, []string{dks.RoundCorpus}, a.Corpora)
+
+	var expectedTraceIDs []string
+	for _, tr := range data.Traces {
+		if matcher.Matches(tr.Keys) {
+			expectedTraceIDs = append(expectedTraceIDs, hex.EncodeToString(tr.TraceID))
+		}
+	}
+	require.NotEmpty(t, expectedTraceIDs)
+	assert.ElementsMatch(t, expectedTraceIDs, a.TraceIDs)
+
+	require.NotEmpty(t, a.Expectations)
+	for _, e := range a.Expectations {
+		assert.Equal(t, dks.RoundCorpus, e.Grouping[types.CorpusField])
+		assert.NotEqual(t, expectations.Untriaged, e.Label)
+	}
+	assert.Contains(t, a.Expectations, bulk.Expectation{Grouping: circleGrouping, Digest: dks.DigestC01Pos, Label: expectations.Positive})
+}
+
+func TestBuild_NoMatcher_ReturnsError(t *testing.T) {
+	_, err := Build(context.Background(), nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "publicly viewable params are required")
+}
+
+func TestArtifact_PublicTracesCorporaAndBaseline(t *testing.T) {
+	a := testArtifact()
+
+	traces, err := a.PublicTraces()
+	require.NoError(t, err)
+	var traceKey schema.MD5Hash
+	b, err := hex.DecodeString(fakeTraceID)
+	require.NoError(t, err)
+	copy(traceKey[:], b)
+	assert.Equal(t, map[schema.MD5Hash]struct{}{traceKey: {}}, traces)
+
+	assert.Equal(t, map[string]struct{}{dks.RoundCorpus: {}}, a.PublicCorpora())
+
+	assert.Equal(t, expectations.Baseline{
+		dks.CircleTest: {
+			dks.DigestC01Pos: expectations.Positive,
+			dks.DigestC05Unt: expectations.Negative,
+		},
+	}, a.Baseline())
+}
+
+func TestArtifact_PublicTraces_InvalidID_ReturnsError(t *testing.T) {
+	a := testArtifact()
+	a.TraceIDs = []string{"not-hex"}
+	_, err := a.PublicTraces()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid trace ID")
+}
+
+func TestPublisher_NotReady_ReturnsUnavailable(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewPublisher(nil, nil).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestSubscriber_Fetch_OnlyReturnsChangedArtifacts(t *testing.T) {
+	ctx := context.Background()
+	p := NewPublisher(nil, nil)
+	require.NoError(t, p.set(testArtifact()))
+	srv := httptest.NewServer(p)
+	defer srv.Close()
+	s := NewSubscriber(srv.Client(), srv.URL)
+
+	a, err := s.Fetch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, testArtifact(), a)
+
+	// Nothing changed.
+	a, err = s.Fetch(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	// Rebuilding the same data doesn't change the artifact.
+	rebuilt := testArtifact()
+	rebuilt.ExportedAt = rebuilt.ExportedAt.Add(time.Hour)
+	require.NoError(t, p.set(rebuilt))
+	a, err = s.Fetch(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	changed := testArtifact()
+	changed.Expectations = changed.Expectations[:1]
+	require.NoError(t, p.set(changed))
+	a, err = s.Fetch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, changed, a)
+}
+
+func TestSubscriber_Fetch_WrongVersion_ReturnsError(t *testing.T) {
+	p := NewPublisher(nil, nil)
+	a := testArtifact()
+	a.Version = CurrentVersion + 1
+	require.NoError(t, p.set(a))
+	srv := httptest.NewServer(p)
+	defer srv.Close()
+
+	_, err := NewSubscriber(srv.Client(), srv.URL).Fetch(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported public mirror artifact version")
+}
+
+func TestSubscriber_Start_ApplyFails_RetriesUnchangedArtifact(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := NewPublisher(nil, nil)
+	require.NoError(t, p.set(testArtifact()))
+	srv := httptest.NewServer(p)
+	defer srv.Close()
+	s := NewSubscriber(srv.Client(), srv.URL)
+
+	err := s.Start(ctx, time.Hour, func(*Artifact) error {
+		return assert.AnError
+	})
+	require.Error(t, err)
+
+	var applied *Artifact
+	require.NoError(t, s.Start(ctx, time.Hour, func(a *Artifact) error {
+		applied = a
+		return nil
+	}))
+	assert.Equal(t, testArtifact(), applied)
+}
@@ -18,6 +18,36 @@ type GCSClient struct {
 	mock.Mock
 }
 
+// GetDiffImage provides a mock function with given fields: ctx, digest
+func (_m *GCSClient) GetDiffImage(ctx context.Context, digest types.Digest) ([]byte, error) {
+	ret := _m.Called(ctx, digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDiffImage")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Digest) ([]byte, error)); ok {
+		return rf(ctx, digest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.Digest) []byte); ok {
+		r0 = rf(ctx, digest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.Digest) error); ok {
+		r1 = rf(ctx, digest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImage provides a mock function with given fields: ctx, digest
 func (_m *GCSClient) GetImage(ctx context.Context, digest types.Digest) ([]byte, error) {
 	ret := _m.Called(ctx, digest)
@@ -48,6 +78,36 @@ func (_m *GCSClient) GetImage(ctx context.Context, digest types.Digest) ([]byte,
 	return r0, r1
 }
 
+// GetThumbnail provides a mock function with given fields: ctx, digest, size
+func (_m *GCSClient) GetThumbnail(ctx context.Context, digest types.Digest, size int) ([]byte, error) {
+	ret := _m.Called(ctx, digest, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThumbnail")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Digest, int) ([]byte, error)); ok {
+		return rf(ctx, digest, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.Digest, int) []byte); ok {
+		r0 = rf(ctx, digest, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.Digest, int) error); ok {
+		r1 = rf(ctx, digest, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadKnownDigests provides a mock function with given fields: ctx, w
 func (_m *GCSClient) LoadKnownDigests(ctx context.Context, w io.Writer) error {
 	ret := _m.Called(ctx, w)
@@ -34,6 +34,7 @@ func (prov cacheDataProvider) GetDataForCorpus(ctx context.Context, firstCommitI
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	for rows.Next() {
 		cacheDataObj := SearchCacheData{}
 		if err := rows.Scan(&cacheDataObj.TraceID, &cacheDataObj.GroupingID, &cacheDataObj.Digest); err != nil {
@@ -45,25 +46,19 @@ func (prov cacheDataProvider) GetDataForCorpus(ctx context.Context, firstCommitI
 	return cacheData, nil
 }
 
-// GetCacheData implements cacheDataProvider.
-func (prov cacheDataProvider) GetCacheData(ctx context.Context, firstCommitId string) (map[string]string, error) {
-	cacheMap := map[string]string{}
-
-	// For each of the corpora, execute the sql query and add the results to the map.
-	for _, corpus := range prov.corpora {
-		cacheData, err := prov.GetDataForCorpus(ctx, firstCommitId, corpus)
-		if err != nil {
-			return nil, err
-		}
-		if len(cacheData) > 0 {
-			key := prov.cacheKeyFunc(corpus)
-			cacheDataStr, err := toJSON(cacheData)
-			if err != nil {
-				return nil, skerr.Wrap(err)
-			}
-			cacheMap[key] = cacheDataStr
-		}
+// getShard returns the cache key and JSON-encoded cache data for the given corpus. It returns an
+// empty key if there is no data for the corpus.
+func (prov cacheDataProvider) getShard(ctx context.Context, firstCommitId string, corpus string) (string, string, error) {
+	cacheData, err := prov.GetDataForCorpus(ctx, firstCommitId, corpus)
+	if err != nil {
+		return "", "", err
 	}
-
-	return cacheMap, nil
+	if len(cacheData) == 0 {
+		return "", "", nil
+	}
+	cacheDataStr, err := toJSON(cacheData)
+	if err != nil {
+		return "", "", skerr.Wrap(err)
+	}
+	return prov.cacheKeyFunc(corpus), cacheDataStr, nil
 }
@@ -5,8 +5,13 @@ import (
 	"encoding/json"
 
 	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opencensus.io/trace"
+	"golang.org/x/sync/errgroup"
+
 	"go.skia.org/infra/go/cache"
+	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/golden/go/search/common"
 )
 
@@ -19,6 +24,23 @@ const (
 	Unignored_Corpus
 )
 
+// String returns a name for the cache type, suitable for use in metrics.
+func (t SearchCacheType) String() string {
+	switch t {
+	case ByBlame_Corpus:
+		return "byblame"
+	case Unignored_Corpus:
+		return "unignored"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultMaxConcurrentShards is the default number of shards which are built at once during
+// cache population. Each shard holds the data for one corpus and cache type in memory until it
+// is written to the cache, so this bounds the memory used by cache population.
+const DefaultMaxConcurrentShards = 2
+
 // SearchCacheManager provides a struct to handle the cache operations for gold search.
 type SearchCacheManager struct {
 	cacheClient   cache.Cache
@@ -26,15 +48,18 @@ type SearchCacheManager struct {
 	corpora       []string
 	commitWindow  int
 	dataProviders map[SearchCacheType]cacheDataProvider
+
+	maxConcurrentShards int
 }
 
 // New returns a new instance of the SearchCacheManager.
 func New(cacheClient cache.Cache, db *pgxpool.Pool, corpora []string, commitWindow int) *SearchCacheManager {
 	return &SearchCacheManager{
-		cacheClient:  cacheClient,
-		db:           db,
-		corpora:      corpora,
-		commitWindow: commitWindow,
+		cacheClient:         cacheClient,
+		db:                  db,
+		corpora:             corpora,
+		commitWindow:        commitWindow,
+		maxConcurrentShards: DefaultMaxConcurrentShards,
 		dataProviders: map[SearchCacheType]cacheDataProvider{
 			ByBlame_Corpus:   NewCacheDataProvider(db, corpora, commitWindow, ByBlameQuery, ByBlameKey),
 			Unignored_Corpus: NewCacheDataProvider(db, corpora, commitWindow, UnignoredQuery, UnignoredKey),
@@ -42,26 +67,62 @@ func New(cacheClient cache.Cache, db *pgxpool.Pool, corpora []string, commitWind
 	}
 }
 
+// SetMaxConcurrentShards sets the maximum number of shards which are built at once during cache
+// population. If n is not positive, DefaultMaxConcurrentShards is used.
+func (s *SearchCacheManager) SetMaxConcurrentShards(n int) {
+	if n <= 0 {
+		n = DefaultMaxConcurrentShards
+	}
+	s.maxConcurrentShards = n
+}
+
 // RunCachePopulation gets the cache data from the providers and stores it in the cache instance.
+// The data for each corpus and cache type is built as a separate shard, with at most
+// maxConcurrentShards shards being built at once. Each shard is written to the cache as soon as it
+// is built, so that its data can be released.
 func (s SearchCacheManager) RunCachePopulation(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "caching_RunCachePopulation")
+	defer span.End()
 	ctx, err := common.AddCommitsData(ctx, s.db, s.commitWindow)
 	if err != nil {
 		return skerr.Wrap(err)
 	}
-	for _, prov := range s.dataProviders {
-		data, err := prov.GetCacheData(ctx, string(common.GetFirstCommitID(ctx)))
-		if err != nil {
-			return skerr.Wrapf(err, "Error while running cache population with provider %v", prov)
-		}
-
-		for key, val := range data {
-			err := s.cacheClient.SetValue(ctx, key, val)
-			if err != nil {
-				return skerr.Wrapf(err, "Error while setting value in cache.")
-			}
+	firstCommitID := string(common.GetFirstCommitID(ctx))
+	eg, eCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(s.maxConcurrentShards)
+	for cacheType, prov := range s.dataProviders {
+		for _, corpus := range s.corpora {
+			cacheType, prov, corpus := cacheType, prov, corpus
+			eg.Go(func() error {
+				return s.populateShard(eCtx, cacheType, prov, firstCommitID, corpus)
+			})
 		}
 	}
+	return skerr.Wrap(eg.Wait())
+}
 
+// populateShard builds the cache data for the given corpus and cache type and stores it in the
+// cache instance. Corpora without any data are not stored.
+func (s SearchCacheManager) populateShard(ctx context.Context, cacheType SearchCacheType, prov cacheDataProvider, firstCommitID, corpus string) error {
+	ctx, span := trace.StartSpan(ctx, "caching_populateShard")
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("corpus", corpus), trace.StringAttribute("type", cacheType.String()))
+	tags := map[string]string{"corpus": corpus, "type": cacheType.String()}
+	timer := metrics2.NewTimer("gold_search_cache_shard_build", tags)
+	key, val, err := prov.getShard(ctx, firstCommitID, corpus)
+	if err != nil {
+		return skerr.Wrapf(err, "building %s cache shard for corpus %s", cacheType, corpus)
+	}
+	if key == "" {
+		timer.Stop()
+		return nil
+	}
+	if err := s.cacheClient.SetValue(ctx, key, val); err != nil {
+		return skerr.Wrapf(err, "Error while setting value in cache.")
+	}
+	d := timer.Stop()
+	metrics2.GetInt64Metric("gold_search_cache_shard_size_bytes", tags).Update(int64(len(val)))
+	sklog.Debugf("Built %s cache shard for corpus %s (%d bytes) in %s", cacheType, corpus, len(val), d)
 	return nil
 }
 
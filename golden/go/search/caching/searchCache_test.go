@@ -35,6 +35,22 @@ func TestPopulateCache_WithData(t *testing.T) {
 	cacheClient.AssertNumberOfCalls(t, "SetValue", 2)
 }
 
+func TestPopulateCache_MultipleCorpora_OneShardAtATime_AllShardsWritten(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := useKitchenSinkData(ctx, t)
+	cacheClient := mockCache.NewCache(t)
+	for _, corpus := range []string{dks.RoundCorpus, dks.CornersCorpus} {
+		cacheClient.On("SetValue", testutils.AnyContext, ByBlameKey(corpus), mock.AnythingOfType("string")).Return(nil)
+		cacheClient.On("SetValue", testutils.AnyContext, UnignoredKey(corpus), mock.AnythingOfType("string")).Return(nil)
+	}
+	searchCacheManager := New(cacheClient, db, []string{dks.RoundCorpus, dks.CornersCorpus}, 5)
+	searchCacheManager.SetMaxConcurrentShards(1)
+	err := searchCacheManager.RunCachePopulation(ctx)
+	assert.Nil(t, err)
+	cacheClient.AssertNumberOfCalls(t, "SetValue", 4)
+}
+
 func TestPopulateCache_NoData(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -308,6 +308,62 @@ func (_m *API) GetDigestsForGrouping(ctx context.Context, grouping paramtools.Pa
 	return r0, r1
 }
 
+// GetDigestOccurrences provides a mock function with given fields: ctx, q
+func (_m *API) GetDigestOccurrences(ctx context.Context, q frontend.DigestOccurrencesQuery) (frontend.DigestOccurrencesResponse, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDigestOccurrences")
+	}
+
+	var r0 frontend.DigestOccurrencesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, frontend.DigestOccurrencesQuery) (frontend.DigestOccurrencesResponse, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, frontend.DigestOccurrencesQuery) frontend.DigestOccurrencesResponse); ok {
+		r0 = rf(ctx, q)
+	} else {
+		r0 = ret.Get(0).(frontend.DigestOccurrencesResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, frontend.DigestOccurrencesQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFlakyTests provides a mock function with given fields: ctx, q
+func (_m *API) GetFlakyTests(ctx context.Context, q frontend.FlakyTestsQuery) (frontend.FlakyTestsResponse, error) {
+	ret := _m.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFlakyTests")
+	}
+
+	var r0 frontend.FlakyTestsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, frontend.FlakyTestsQuery) (frontend.FlakyTestsResponse, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, frontend.FlakyTestsQuery) frontend.FlakyTestsResponse); ok {
+		r0 = rf(ctx, q)
+	} else {
+		r0 = ret.Get(0).(frontend.FlakyTestsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, frontend.FlakyTestsQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPrimaryBranchParamset provides a mock function with given fields: ctx
 func (_m *API) GetPrimaryBranchParamset(ctx context.Context) (paramtools.ReadOnlyParamSet, error) {
 	ret := _m.Called(ctx)
@@ -396,6 +452,24 @@ func (_m *API) Search(_a0 context.Context, _a1 *query.Search) (*frontend.SearchR
 	return r0, r1
 }
 
+// SearchStream provides a mock function with given fields: ctx, q, fn
+func (_m *API) SearchStream(ctx context.Context, q *query.Search, fn func(*frontend.SearchStreamChunk) error) error {
+	ret := _m.Called(ctx, q, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchStream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *query.Search, func(*frontend.SearchStreamChunk) error) error); ok {
+		r0 = rf(ctx, q, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAPI creates a new instance of API. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAPI(t interface {
@@ -4,6 +4,7 @@ package query
 
 import (
 	"net/http"
+	"strconv"
 
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/util"
@@ -52,6 +53,14 @@ func ParseSearch(r *http.Request, q *Search) error {
 	q.Limit = int(validate.Int64FormValue(r, "limit", 50))
 	q.Offset = int(validate.Int64FormValue(r, "offset", 0))
 	q.Offset = util.MaxInt(q.Offset, 0)
+	// A cursor returned by a previous search takes precedence over the offset.
+	if cursor := r.FormValue("cursor"); cursor != "" {
+		offset, err := parseCursor(cursor)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		q.Offset = offset
+	}
 
 	validate.StrFormValue(r, "metric", &q.Metric, []string{CombinedMetric, PercentMetric, PixelMetric}, CombinedMetric)
 	validate.StrFormValue(r, "sort", &q.Sort, []string{SortDescending, SortAscending}, SortDescending)
@@ -84,3 +93,20 @@ func ParseSearch(r *http.Request, q *Search) error {
 
 	return nil
 }
+
+// Cursor returns an opaque cursor which resumes a search at the given offset into its results.
+func Cursor(offset int) string {
+	return strconv.Itoa(offset)
+}
+
+// parseCursor returns the offset encoded by a cursor returned by Cursor.
+func parseCursor(cursor string) (int, error) {
+	offset, err := strconv.Atoi(cursor)
+	if err != nil {
+		return 0, skerr.Wrapf(err, "invalid cursor %q", cursor)
+	}
+	if offset < 0 {
+		return 0, skerr.Fmt("invalid cursor %q", cursor)
+	}
+	return offset, nil
+}
@@ -63,6 +63,22 @@ func TestParseSearchValidList(t *testing.T) {
 	}
 }
 
+func TestParseSearch_Cursor_OverridesOffset(t *testing.T) {
+	q := &Search{}
+	require.NoError(t, clearParseQuery(q, "offset=10&limit=20&cursor="+Cursor(40)))
+	require.Equal(t, 40, q.Offset)
+	require.Equal(t, 20, q.Limit)
+
+	require.NoError(t, clearParseQuery(q, "offset=10"))
+	require.Equal(t, 10, q.Offset)
+}
+
+func TestParseSearch_InvalidCursor_ReturnsError(t *testing.T) {
+	q := &Search{}
+	require.Error(t, clearParseQuery(q, "cursor=not-a-cursor"))
+	require.Error(t, clearParseQuery(q, "cursor=-5"))
+}
+
 func clearParseQuery(q *Search, qStr string) error {
 	*q = Search{}
 	r, err := http.NewRequest("GET", "/?"+qStr, nil)
@@ -26,6 +26,7 @@ import (
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/golden/go/config"
+	"go.skia.org/infra/golden/go/diff"
 	"go.skia.org/infra/golden/go/expectations"
 	"go.skia.org/infra/golden/go/publicparams"
 	"go.skia.org/infra/golden/go/search/caching"
@@ -53,6 +54,12 @@ type API interface {
 	// the instance of the *query.Search.
 	Search(context.Context, *query.Search) (*frontend.SearchResponse, error)
 
+	// SearchStream is like Search, but instead of returning a single page of results, it calls fn
+	// with consecutive chunks of at most q.Limit results, starting at q.Offset, until all results
+	// have been returned or fn returns an error. Only one chunk of fully populated results is held
+	// in memory at a time.
+	SearchStream(ctx context.Context, q *query.Search, fn func(*frontend.SearchStreamChunk) error) error
+
 	// GetPrimaryBranchParamset returns all params that are on the most recent few tiles. If
 	// this is public view, it will only return the params on the traces which match the publicly
 	// visible rules.
@@ -91,6 +98,15 @@ type API interface {
 	// and breaks it down by test.
 	CountDigestsByTest(ctx context.Context, q frontend.ListTestsQuery) (frontend.ListTestsResponse, error)
 
+	// GetFlakyTests computes how much the digests of each test in the given corpus changed over
+	// the commits in the current window, and returns the tests sorted flakiest first.
+	GetFlakyTests(ctx context.Context, q frontend.FlakyTestsQuery) (frontend.FlakyTestsResponse, error)
+
+	// GetDigestOccurrences returns every trace on the primary branch which ever produced the given
+	// digest, regardless of test or corpus, along with the commits at which it did so. If
+	// requested, it also returns the tests which produced the digest on recently updated CLs.
+	GetDigestOccurrences(ctx context.Context, q frontend.DigestOccurrencesQuery) (frontend.DigestOccurrencesResponse, error)
+
 	// ComputeGUIStatus looks at all visible traces at head and returns a summary of how many are
 	// untriaged for each corpus, as well as the most recent commit for which we have data.
 	ComputeGUIStatus(ctx context.Context) (frontend.GUIStatus, error)
@@ -164,6 +180,10 @@ const (
 	commitCacheSize          = 5_000
 	optionsGroupingCacheSize = 50_000
 	traceCacheSize           = 1_000_000
+
+	// defaultStreamChunkSize is the number of results per chunk returned by SearchStream if the
+	// query does not specify a limit.
+	defaultStreamChunkSize = 50
 )
 
 type Impl struct {
@@ -171,6 +191,8 @@ type Impl struct {
 	windowLength int
 	// Lets us create links from CL data to the Code Review System that produced it.
 	reviewSystemMapping map[string]string
+	// The thresholds under which diffs are considered negligible, keyed by corpus.
+	diffThresholds map[string]diff.Thresholds
 
 	// mutex protects the caches, e.g. digestsOnPrimary and publiclyVisibleTraces
 	mutex sync.RWMutex
@@ -215,6 +237,7 @@ func New(sqlDB *pgxpool.Pool, windowLength int, cacheClient cache.Cache, cache_c
 		traceCache:               tc,
 		paramsetCache:            pc,
 		reviewSystemMapping:      map[string]string{},
+		diffThresholds:           map[string]diff.Thresholds{},
 		cacheManager:             caching.New(cacheClient, sqlDB, cache_corpora, windowLength),
 		statusProvider:           providers.NewStatusProvider(sqlDB, windowLength),
 		changeDataProvider:       providers.NewChangelistProvider(sqlDB),
@@ -234,6 +257,22 @@ func (s *Impl) SetReviewSystemTemplates(m map[string]string) {
 	s.reviewSystemMapping = m
 }
 
+// SetDiffThresholds sets the per-corpus thresholds under which the difference between a digest
+// and its closest reference is considered negligible.
+func (s *Impl) SetDiffThresholds(m map[string]diff.Thresholds) {
+	s.diffThresholds = m
+}
+
+// markNegligible sets the Negligible field of the given diff based on the thresholds configured
+// for the given corpus. It is a no-op if srdd is nil.
+func (s *Impl) markNegligible(corpus string, srdd *frontend.SRDiffDigest) {
+	if srdd == nil {
+		return
+	}
+	th, ok := s.diffThresholds[corpus]
+	srdd.Negligible = ok && th.IsNegligible(srdd.MaxRGBADiffs, srdd.PixelDiffPercent, srdd.DimDiffer)
+}
+
 // StartCacheProcess loads the caches used for searching and starts a goroutine to keep those
 // up to date.
 func (s *Impl) StartCacheProcess(ctx context.Context, interval time.Duration, commitsWithData int) error {
@@ -376,13 +415,7 @@ func (s *Impl) StartApplyingPublicParams(ctx context.Context, matcher publicpara
 				publiclyVisibleCorpora[keys[types.CorpusField]] = yes
 			}
 		}
-		s.mutex.Lock()
-		defer s.mutex.Unlock()
-		s.publiclyVisibleTraces = publiclyVisibleTraces
-		s.statusProvider.SetPublicTraces(publiclyVisibleTraces)
-
-		s.publiclyVisibleCorpora = publiclyVisibleCorpora
-		s.statusProvider.SetPublicCorpora(publiclyVisibleCorpora)
+		s.SetPublicTraces(publiclyVisibleTraces, publiclyVisibleCorpora)
 		return nil
 	}
 	if err := cycle(ctx); err != nil {
@@ -399,6 +432,23 @@ func (s *Impl) StartApplyingPublicParams(ctx context.Context, matcher publicpara
 	return nil
 }
 
+// SetPublicTraces restricts the results to the given publicly visible traces and corpora. It is
+// used by public views which get the publicly visible traces from elsewhere (e.g. a mirror of the
+// authoritative instance) instead of calling StartApplyingPublicParams. The first call must happen
+// before the Impl starts serving requests.
+func (s *Impl) SetPublicTraces(traces map[schema.MD5Hash]struct{}, corpora map[string]struct{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.isPublicView {
+		s.isPublicView = true
+	}
+	s.publiclyVisibleTraces = traces
+	s.statusProvider.SetPublicTraces(traces)
+
+	s.publiclyVisibleCorpora = corpora
+	s.statusProvider.SetPublicCorpora(corpora)
+}
+
 // NewAndUntriagedSummaryForCL queries all the patchsets in parallel (to keep the query less
 // complex). If there are no patchsets for the provided CL, it returns an error.
 func (s *Impl) NewAndUntriagedSummaryForCL(ctx context.Context, qCLID string) (NewAndUntriagedSummary, error) {
@@ -494,13 +544,7 @@ func (s *Impl) Search(ctx context.Context, q *query.Search) (*frontend.SearchRes
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
-	// Go fetch history and paramset (within this grouping, and respecting publiclyAllowedParams).
-	paramsetsByDigest, err := s.getParamsetsForRightSide(ctx, closestDiffs)
-	if err != nil {
-		return nil, skerr.Wrap(err)
-	}
-	// Flesh out the trace history with enough data to draw the dots diagram on the frontend.
-	results, err := s.fillOutTraceHistory(ctx, closestDiffs)
+	results, err := s.fillOutResults(ctx, closestDiffs, true)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
@@ -510,15 +554,6 @@ func (s *Impl) Search(ctx context.Context, q *query.Search) (*frontend.SearchRes
 		return nil, skerr.Wrap(err)
 	}
 
-	// Fill in the paramsets of the reference images.
-	for _, sr := range results {
-		for _, srdd := range sr.RefDiffs {
-			if srdd != nil {
-				srdd.ParamSet = paramsetsByDigest[srdd.Digest]
-			}
-		}
-	}
-
 	// Populate the optionsIDs fields of each extendedBulkTriageDeltaInfo.
 	if err := s.populateExtendedBulkTriageDeltaInfosOptionsIDs(ctx, extendedBulkTriageDeltaInfos); err != nil {
 		return nil, skerr.Wrap(err)
@@ -535,9 +570,134 @@ func (s *Impl) Search(ctx context.Context, q *query.Search) (*frontend.SearchRes
 		Size:                 len(extendedBulkTriageDeltaInfos),
 		BulkTriageDeltaInfos: bulkTriageDeltaInfos,
 		Commits:              commits,
+		NextCursor:           nextCursor(*q, len(results), len(extendedBulkTriageDeltaInfos)),
 	}, nil
 }
 
+// SearchStream implements the SearchAPI interface.
+func (s *Impl) SearchStream(ctx context.Context, q *query.Search, fn func(*frontend.SearchStreamChunk) error) error {
+	ctx, span := trace.StartSpan(ctx, "search2_SearchStream")
+	defer span.End()
+
+	chunkSize := q.Limit
+	if chunkSize <= 0 {
+		chunkSize = defaultStreamChunkSize
+	}
+	// Find all the matching digests and their closest diffs up front, so they can be sorted.
+	// These are small compared to the results sent to the client.
+	allQuery := *q
+	allQuery.Offset = 0
+	allQuery.Limit = 0
+	ctx = context.WithValue(ctx, common.QueryKey, allQuery)
+	ctx, err := s.addCommitsData(ctx)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	commits, err := s.commitsProvider.GetCommits(ctx)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	isCL := q.ChangelistID != ""
+	var traceDigests []digestWithTraceAndGrouping
+	if isCL {
+		if q.CodeReviewSystemID == "" {
+			return skerr.Fmt("Code Review System (crs) must be specified")
+		}
+		if ctx, err = s.addCLData(ctx); err != nil {
+			return skerr.Wrap(err)
+		}
+		if commits, err = s.addCLCommit(ctx, commits); err != nil {
+			return skerr.Wrap(err)
+		}
+		traceDigests, err = s.getMatchingDigestsAndTracesForCL(ctx)
+	} else {
+		traceDigests, err = s.getMatchingDigestsAndTraces(ctx)
+	}
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	var closestDiffs []digestAndClosestDiffs
+	if len(traceDigests) > 0 {
+		closestDiffs, _, err = s.getClosestDiffs(ctx, traceDigests)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+	}
+	total := len(closestDiffs)
+	closestDiffs = closestDiffs[util.MinInt(q.Offset, total):]
+
+	first := &frontend.SearchStreamChunk{
+		Results: []*frontend.SearchResult{},
+		Size:    total,
+		Commits: commits,
+	}
+	if len(closestDiffs) == 0 {
+		return skerr.Wrap(fn(first))
+	}
+	for start := 0; start < len(closestDiffs); start += chunkSize {
+		end := util.MinInt(len(closestDiffs), start+chunkSize)
+		results, err := s.fillOutResults(ctx, closestDiffs[start:end], !isCL)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		chunk := &frontend.SearchStreamChunk{Results: results}
+		if start == 0 {
+			first.Results = results
+			chunk = first
+		}
+		if end < len(closestDiffs) {
+			chunk.NextCursor = query.Cursor(q.Offset + end)
+		}
+		if err := fn(chunk); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// nextCursor returns the cursor for the page of results following the one which starts at the
+// query's offset and contains numResults of the total results, or the empty string if there are
+// no more results.
+func nextCursor(q query.Search, numResults, total int) string {
+	if q.Limit <= 0 || q.Offset+numResults >= total {
+		return ""
+	}
+	return query.Cursor(q.Offset + numResults)
+}
+
+// fillOutResults turns the given digests and their closest diffs into search results. This
+// includes the trace history, the paramsets of the reference images and, if includeSuggestions
+// is true, the suggested labels of untriaged digests.
+func (s *Impl) fillOutResults(ctx context.Context, closestDiffs []digestAndClosestDiffs, includeSuggestions bool) ([]*frontend.SearchResult, error) {
+	ctx, span := trace.StartSpan(ctx, "fillOutResults")
+	defer span.End()
+	// Go fetch history and paramset (within this grouping, and respecting publiclyAllowedParams).
+	paramsetsByDigest, err := s.getParamsetsForRightSide(ctx, closestDiffs)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	// Flesh out the trace history with enough data to draw the dots diagram on the frontend.
+	results, err := s.fillOutTraceHistory(ctx, closestDiffs)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if includeSuggestions {
+		// Attach any suggested labels to the untriaged results.
+		if err := s.fillInTriageSuggestions(ctx, closestDiffs, results); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+	}
+	// Fill in the paramsets of the reference images.
+	for _, sr := range results {
+		for _, srdd := range sr.RefDiffs {
+			if srdd != nil {
+				srdd.ParamSet = paramsetsByDigest[srdd.Digest]
+			}
+		}
+	}
+	return results, nil
+}
+
 // addCommitsData finds the current sliding window of data (The last N commits) and adds the
 // derived data to the given context and returns it.
 func (s *Impl) addCommitsData(ctx context.Context) (context.Context, error) {
@@ -965,6 +1125,8 @@ func (s *Impl) getClosestDiffs(ctx context.Context, inputs []digestWithTraceAndG
 			digest:     s2.leftDigest,
 			optionsIDs: s2.optionsIDs,
 		}
+		s.markNegligible(grouping[types.CorpusField], s2.closestPositive)
+		s.markNegligible(grouping[types.CorpusField], s2.closestNegative)
 		if s2.closestDigest != nil {
 			// Apply RGBA Filter here - if the closest digest isn't within range, we remove it.
 			maxDiff := util.MaxInt(s2.closestDigest.MaxRGBADiffs[:]...)
@@ -1654,6 +1816,61 @@ func (s *Impl) fillInTraceParams(ctx context.Context, tg *frontend.TraceGroup) e
 	return nil
 }
 
+// fillInTriageSuggestions sets the Suggestion of the untriaged results, using the suggestions
+// computed periodically and stored in the TriageSuggestions table. The inputs and results are
+// expected to line up, as returned by fillOutTraceHistory.
+func (s *Impl) fillInTriageSuggestions(ctx context.Context, inputs []digestAndClosestDiffs, results []*frontend.SearchResult) error {
+	ctx, span := trace.StartSpan(ctx, "fillInTriageSuggestions")
+	defer span.End()
+	var parts []string
+	var args []interface{}
+	byKey := map[common.GroupingDigestKey]*frontend.SearchResult{}
+	for i, input := range inputs {
+		if results[i].Status != expectations.Untriaged {
+			continue
+		}
+		byKey[common.GroupingDigestKey{
+			GroupingID: sql.AsMD5Hash(input.groupingID),
+			Digest:     sql.AsMD5Hash(input.leftDigest),
+		}] = results[i]
+		parts = append(parts, fmt.Sprintf("(grouping_id = $%d AND digest = $%d)", len(args)+1, len(args)+2))
+		args = append(args, input.groupingID, input.leftDigest)
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	statement := `SELECT grouping_id, digest, label, closest_digest, confidence
+FROM TriageSuggestions WHERE ` + strings.Join(parts, " OR ")
+	rows, err := s.db.Query(ctx, statement, args...)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var groupingID schema.GroupingID
+		var digest schema.DigestBytes
+		var label schema.ExpectationLabel
+		var closest schema.DigestBytes
+		var confidence float32
+		if err := rows.Scan(&groupingID, &digest, &label, &closest, &confidence); err != nil {
+			return skerr.Wrap(err)
+		}
+		sr, ok := byKey[common.GroupingDigestKey{
+			GroupingID: sql.AsMD5Hash(groupingID),
+			Digest:     sql.AsMD5Hash(digest),
+		}]
+		if !ok {
+			continue
+		}
+		sr.Suggestion = &frontend.TriageSuggestion{
+			Label:         label.ToExpectation(),
+			ClosestDigest: types.Digest(hex.EncodeToString(closest)),
+			Confidence:    confidence,
+		}
+	}
+	return nil
+}
+
 // makeGroupingAndDigestWhereClause builds the part of a "WHERE" clause that filters by grouping ID
 // and digest. It returns the SQL clause and a list of parameter values.
 func makeGroupingAndDigestWhereClause(triageDeltaInfos []extendedBulkTriageDeltaInfo, startingPlaceholderNum int) (string, []interface{}) {
@@ -1929,13 +2146,7 @@ func (s *Impl) searchCLData(ctx context.Context) (*frontend.SearchResponse, erro
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
-	// Go fetch history and paramset (within this grouping, and respecting publiclyAllowedParams).
-	paramsetsByDigest, err := s.getParamsetsForRightSide(ctx, closestDiffs)
-	if err != nil {
-		return nil, skerr.Wrap(err)
-	}
-	// Flesh out the trace history with enough data to draw the dots diagram on the frontend.
-	results, err := s.fillOutTraceHistory(ctx, closestDiffs)
+	results, err := s.fillOutResults(ctx, closestDiffs, false)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
@@ -1945,26 +2156,19 @@ func (s *Impl) searchCLData(ctx context.Context) (*frontend.SearchResponse, erro
 		return nil, skerr.Wrap(err)
 	}
 
-	// Fill in the paramsets of the reference images.
-	for _, sr := range results {
-		for _, srdd := range sr.RefDiffs {
-			if srdd != nil {
-				srdd.ParamSet = paramsetsByDigest[srdd.Digest]
-			}
-		}
-	}
-
 	bulkTriageDeltaInfos, err := s.prepareExtendedBulkTriageDeltaInfosForFrontend(ctx, extendedBulkTriageDeltaInfos)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
 
+	q := common.GetQuery(ctx)
 	return &frontend.SearchResponse{
 		Results:              results,
-		Offset:               common.GetQuery(ctx).Offset,
+		Offset:               q.Offset,
 		Size:                 len(extendedBulkTriageDeltaInfos),
 		BulkTriageDeltaInfos: bulkTriageDeltaInfos,
 		Commits:              commits,
+		NextCursor:           nextCursor(q, len(results), len(extendedBulkTriageDeltaInfos)),
 	}, nil
 }
 
@@ -3638,6 +3842,297 @@ func digestCountTracesStatement(q frontend.ListTestsQuery) (string, []interface{
 	return statement, arguments, nil
 }
 
+// GetFlakyTests implements the API interface. For each trace in the window, the digest at each
+// data point is compared with the digest at the previous data point of the same trace, and a
+// transition is counted whenever they differ. The transitions are then summed up by test.
+func (s *Impl) GetFlakyTests(ctx context.Context, q frontend.FlakyTestsQuery) (frontend.FlakyTestsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "search2_GetFlakyTests")
+	defer span.End()
+
+	statement := `WITH
+CommitsInWindow AS (
+	SELECT commit_id FROM CommitsWithData
+	ORDER BY commit_id DESC LIMIT $1
+),
+OldestCommitInWindow AS (
+	SELECT commit_id FROM CommitsInWindow
+	ORDER BY commit_id ASC LIMIT 1
+),
+TracesOfInterest AS (
+	SELECT trace_id, grouping_id FROM ValuesAtHead
+	JOIN OldestCommitInWindow ON ValuesAtHead.most_recent_commit_id >= OldestCommitInWindow.commit_id
+	WHERE corpus = $2`
+	if q.IgnoreState == types.ExcludeIgnoredTraces {
+		statement += ` AND matches_any_ignore_rule = FALSE`
+	}
+	statement += `
+),
+ValuesInWindow AS (
+	SELECT TracesOfInterest.grouping_id, TraceValues.trace_id, TraceValues.commit_id, TraceValues.digest,
+		LAG(TraceValues.digest) OVER (PARTITION BY TraceValues.trace_id ORDER BY TraceValues.commit_id) AS previous_digest
+	FROM TraceValues
+	JOIN TracesOfInterest ON TraceValues.trace_id = TracesOfInterest.trace_id
+	JOIN OldestCommitInWindow ON TraceValues.commit_id >= OldestCommitInWindow.commit_id
+),
+StatsByGrouping AS (
+	SELECT grouping_id,
+		COUNT(DISTINCT trace_id) AS traces,
+		COUNT(DISTINCT digest) AS unique_digests,
+		COUNT(DISTINCT commit_id) AS commits_with_data,
+		COUNT(previous_digest) AS comparisons,
+		COUNT(CASE WHEN previous_digest != digest THEN 1 END) AS transitions
+	FROM ValuesInWindow
+	GROUP BY grouping_id
+)
+SELECT Groupings.keys, traces, unique_digests, commits_with_data, comparisons, transitions
+FROM StatsByGrouping
+JOIN Groupings ON StatsByGrouping.grouping_id = Groupings.grouping_id`
+
+	rows, err := s.db.Query(ctx, statement, s.windowLength, q.Corpus)
+	if err != nil {
+		return frontend.FlakyTestsResponse{}, skerr.Wrap(err)
+	}
+	defer rows.Close()
+	tests := []frontend.FlakyTestSummary{}
+	for rows.Next() {
+		var summary frontend.FlakyTestSummary
+		var comparisons int
+		if err := rows.Scan(&summary.Grouping, &summary.Traces, &summary.UniqueDigests,
+			&summary.CommitsWithData, &comparisons, &summary.Transitions); err != nil {
+			return frontend.FlakyTestsResponse{}, skerr.Wrap(err)
+		}
+		if comparisons > 0 {
+			summary.ChurnRate = float64(summary.Transitions) / float64(comparisons)
+		}
+		if summary.CommitsWithData > 0 {
+			summary.TransitionsPerCommit = float64(summary.Transitions) / float64(summary.CommitsWithData)
+		}
+		tests = append(tests, summary)
+	}
+	sortFlakyTests(tests, q.Order)
+	if q.Limit > 0 && len(tests) > q.Limit {
+		tests = tests[:q.Limit]
+	}
+	return frontend.FlakyTestsResponse{Tests: tests}, nil
+}
+
+// sortFlakyTests sorts the given tests, flakiest first, according to the given order. Ties are
+// broken by the other metrics and then by test name, so the order is deterministic.
+func sortFlakyTests(tests []frontend.FlakyTestSummary, order frontend.FlakyTestsOrder) {
+	metrics := func(t frontend.FlakyTestSummary) []float64 {
+		switch order {
+		case frontend.FlakyTestsOrderChurnRate:
+			return []float64{t.ChurnRate, t.TransitionsPerCommit, float64(t.UniqueDigests)}
+		case frontend.FlakyTestsOrderUniqueDigests:
+			return []float64{float64(t.UniqueDigests), t.TransitionsPerCommit, t.ChurnRate}
+		default:
+			return []float64{t.TransitionsPerCommit, t.ChurnRate, float64(t.UniqueDigests)}
+		}
+	}
+	sort.Slice(tests, func(i, j int) bool {
+		mi, mj := metrics(tests[i]), metrics(tests[j])
+		for k := range mi {
+			if mi[k] != mj[k] {
+				return mi[k] > mj[k]
+			}
+		}
+		return tests[i].Grouping[types.PrimaryKeyField] < tests[j].Grouping[types.PrimaryKeyField]
+	})
+}
+
+// maxChangelistsForOccurrences is the number of most recently updated CLs that are searched by
+// GetDigestOccurrences. Data from older CLs is not indexed by digest, so searching all of it
+// would be too slow.
+const maxChangelistsForOccurrences = 1000
+
+// GetDigestOccurrences implements the API interface.
+func (s *Impl) GetDigestOccurrences(ctx context.Context, q frontend.DigestOccurrencesQuery) (frontend.DigestOccurrencesResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "search2_GetDigestOccurrences")
+	defer span.End()
+
+	digestBytes, err := sql.DigestToBytes(q.Digest)
+	if err != nil {
+		return frontend.DigestOccurrencesResponse{}, skerr.Wrap(err)
+	}
+	traces, err := s.getTraceOccurrences(ctx, digestBytes)
+	if err != nil {
+		return frontend.DigestOccurrencesResponse{}, skerr.Wrapf(err, "finding traces which produced %s", q.Digest)
+	}
+	resp := frontend.DigestOccurrencesResponse{
+		Digest:      q.Digest,
+		Traces:      traces,
+		Changelists: []frontend.ChangelistOccurrence{},
+	}
+	if q.IncludeChangelists {
+		resp.Changelists, err = s.getChangelistOccurrences(ctx, digestBytes)
+		if err != nil {
+			return frontend.DigestOccurrencesResponse{}, skerr.Wrapf(err, "finding CLs which produced %s", q.Digest)
+		}
+	}
+	return resp, nil
+}
+
+// getTraceOccurrences returns the traces on the primary branch which produced the given digest
+// at least once, sorted by test and then by most recent occurrence. TiledTraceDigests is used to
+// find those traces, so that only their TraceValues need to be read.
+func (s *Impl) getTraceOccurrences(ctx context.Context, digest schema.DigestBytes) ([]frontend.TraceOccurrence, error) {
+	ctx, span := trace.StartSpan(ctx, "getTraceOccurrences")
+	defer span.End()
+
+	const statement = `WITH
+TracesWithDigest AS (
+	SELECT DISTINCT trace_id FROM TiledTraceDigests WHERE digest = $1
+),
+Occurrences AS (
+	SELECT TraceValues.trace_id, COUNT(*) AS occurrences,
+		MIN(TraceValues.commit_id) AS first_commit_id, MAX(TraceValues.commit_id) AS last_commit_id
+	FROM TraceValues
+	JOIN TracesWithDigest ON TraceValues.trace_id = TracesWithDigest.trace_id
+	WHERE TraceValues.digest = $1
+	GROUP BY TraceValues.trace_id
+)
+SELECT Occurrences.trace_id, Groupings.keys, Traces.keys, occurrences,
+	first_commit_id, FirstCommits.git_hash, FirstCommits.commit_time, FirstCommits.author_email, FirstCommits.subject,
+	last_commit_id, LastCommits.git_hash, LastCommits.commit_time, LastCommits.author_email, LastCommits.subject,
+	COALESCE(ValuesAtHead.digest = $1, FALSE), COALESCE(Traces.matches_any_ignore_rule, FALSE)
+FROM Occurrences
+JOIN Traces ON Occurrences.trace_id = Traces.trace_id
+JOIN Groupings ON Traces.grouping_id = Groupings.grouping_id
+LEFT JOIN GitCommits AS FirstCommits ON Occurrences.first_commit_id = FirstCommits.commit_id
+LEFT JOIN GitCommits AS LastCommits ON Occurrences.last_commit_id = LastCommits.commit_id
+LEFT JOIN ValuesAtHead ON Occurrences.trace_id = ValuesAtHead.trace_id`
+
+	rows, err := s.db.Query(ctx, statement, digest)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	defer rows.Close()
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var traceKey schema.MD5Hash
+	rv := []frontend.TraceOccurrence{}
+	for rows.Next() {
+		var traceID schema.TraceID
+		var occ frontend.TraceOccurrence
+		var first, last nullableCommit
+		if err := rows.Scan(&traceID, &occ.Grouping, &occ.Params, &occ.Occurrences,
+			&first.id, &first.hash, &first.ts, &first.author, &first.subject,
+			&last.id, &last.hash, &last.ts, &last.author, &last.subject,
+			&occ.AtHead, &occ.Ignored); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		if s.publiclyVisibleTraces != nil {
+			copy(traceKey[:], traceID)
+			if _, ok := s.publiclyVisibleTraces[traceKey]; !ok {
+				continue
+			}
+		}
+		occ.TraceID = hex.EncodeToString(traceID)
+		occ.FirstCommit = first.toFrontend()
+		occ.LastCommit = last.toFrontend()
+		rv = append(rv, occ)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		gi, gj := rv[i].Grouping, rv[j].Grouping
+		if gi[types.PrimaryKeyField] != gj[types.PrimaryKeyField] {
+			return gi[types.PrimaryKeyField] < gj[types.PrimaryKeyField]
+		}
+		if gi[types.CorpusField] != gj[types.CorpusField] {
+			return gi[types.CorpusField] < gj[types.CorpusField]
+		}
+		if rv[i].LastCommit.ID != rv[j].LastCommit.ID {
+			return rv[i].LastCommit.ID > rv[j].LastCommit.ID
+		}
+		return rv[i].TraceID < rv[j].TraceID
+	})
+	return rv, nil
+}
+
+// nullableCommit is a commit which may not have a corresponding row in GitCommits, e.g. for
+// instances that do not use git commit IDs.
+type nullableCommit struct {
+	id      schema.CommitID
+	hash    *string
+	ts      *time.Time
+	author  *string
+	subject *string
+}
+
+func (c nullableCommit) toFrontend() frontend.Commit {
+	rv := frontend.Commit{ID: string(c.id)}
+	if c.hash != nil {
+		rv.Hash = *c.hash
+	}
+	if c.ts != nil {
+		rv.CommitTime = c.ts.UTC().Unix()
+	}
+	if c.author != nil {
+		rv.Author = *c.author
+	}
+	if c.subject != nil {
+		rv.Subject = *c.subject
+	}
+	return rv
+}
+
+// getChangelistOccurrences returns the tests which produced the given digest at any patchset of
+// the maxChangelistsForOccurrences most recently updated CLs, most recently updated CL first. In
+// a public view, only tests in publicly visible corpora are returned.
+func (s *Impl) getChangelistOccurrences(ctx context.Context, digest schema.DigestBytes) ([]frontend.ChangelistOccurrence, error) {
+	ctx, span := trace.StartSpan(ctx, "getChangelistOccurrences")
+	defer span.End()
+
+	const statement = `WITH
+RecentChangelists AS (
+	SELECT changelist_id, system, owner_email, subject, last_ingested_data FROM Changelists
+	ORDER BY last_ingested_data DESC LIMIT $2
+),
+CLOccurrences AS (
+	SELECT branch_name, version_name, grouping_id,
+		COUNT(DISTINCT secondary_branch_trace_id) AS traces
+	FROM SecondaryBranchValues
+	JOIN RecentChangelists ON SecondaryBranchValues.branch_name = RecentChangelists.changelist_id
+	WHERE digest = $1
+	GROUP BY branch_name, version_name, grouping_id
+)
+SELECT RecentChangelists.changelist_id, system, owner_email, subject,
+	Patchsets.patchset_id, Patchsets.ps_order, Groupings.keys, traces
+FROM CLOccurrences
+JOIN RecentChangelists ON CLOccurrences.branch_name = RecentChangelists.changelist_id
+JOIN Patchsets ON CLOccurrences.version_name = Patchsets.patchset_id
+JOIN Groupings ON CLOccurrences.grouping_id = Groupings.grouping_id
+ORDER BY last_ingested_data DESC, RecentChangelists.changelist_id, Patchsets.ps_order DESC,
+	CLOccurrences.grouping_id`
+
+	rows, err := s.db.Query(ctx, statement, digest, maxChangelistsForOccurrences)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	defer rows.Close()
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	rv := []frontend.ChangelistOccurrence{}
+	for rows.Next() {
+		var occ frontend.ChangelistOccurrence
+		if err := rows.Scan(&occ.ChangelistID, &occ.System, &occ.Owner, &occ.Subject,
+			&occ.PatchsetID, &occ.PatchsetOrder, &occ.Grouping, &occ.Traces); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		if s.isPublicView {
+			if _, ok := s.publiclyVisibleCorpora[occ.Grouping[types.CorpusField]]; !ok {
+				continue
+			}
+		}
+		occ.ChangelistID = sql.Unqualify(occ.ChangelistID)
+		occ.PatchsetID = sql.Unqualify(occ.PatchsetID)
+		if urlTemplate, ok := s.reviewSystemMapping[occ.System]; ok {
+			occ.ChangelistURL = fmt.Sprintf(urlTemplate, occ.ChangelistID)
+		}
+		rv = append(rv, occ)
+	}
+	return rv, nil
+}
+
 // ComputeGUIStatus implements the API interface. It has special logic for public views vs the
 // normal views to avoid leaking.
 func (s *Impl) ComputeGUIStatus(ctx context.Context) (frontend.GUIStatus, error) {
@@ -3648,6 +4143,11 @@ func (s *Impl) ComputeGUIStatus(ctx context.Context) (frontend.GUIStatus, error)
 	if err != nil {
 		return frontend.GUIStatus{}, err
 	}
+	for i := range xcs {
+		if th, ok := s.diffThresholds[xcs[i].Name]; ok {
+			xcs[i].DiffThresholds = &th
+		}
+	}
 	return frontend.GUIStatus{
 		LastCommit: commit,
 		CorpStatus: xcs,
@@ -3,6 +3,9 @@ package search
 import (
 	"context"
 	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -17,6 +20,7 @@ import (
 
 	"go.skia.org/infra/go/cache/local"
 	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/golden/go/diff"
 	"go.skia.org/infra/golden/go/expectations"
 	"go.skia.org/infra/golden/go/publicparams"
 	"go.skia.org/infra/golden/go/search/common"
@@ -573,6 +577,163 @@ func TestSearch_UntriagedDigestsAtHead_Success(t *testing.T) {
 	assertUntriagedDigestsAtHead(t, res)
 }
 
+func TestSearch_Limit_NextCursorResumesSearch(t *testing.T) {
+	ctx := context.Background()
+	db := useKitchenSinkData(ctx, t)
+
+	cache, err := local.New(100)
+	require.NoError(t, err)
+	s := New(db, 100, cache, nil)
+	q := query.Search{
+		IncludePositiveDigests:  true,
+		IncludeNegativeDigests:  true,
+		IncludeUntriagedDigests: true,
+		Sort:                    query.SortDescending,
+		TraceValues: paramtools.ParamSet{
+			types.CorpusField: []string{dks.CornersCorpus},
+		},
+		RGBAMinFilter: 0,
+		RGBAMaxFilter: 255,
+	}
+	all, err := s.Search(ctx, &q)
+	require.NoError(t, err)
+	require.Greater(t, len(all.Results), 2)
+	assert.Empty(t, all.NextCursor)
+
+	q.Limit = 2
+	var results []*frontend.SearchResult
+	for {
+		res, err := s.Search(ctx, &q)
+		require.NoError(t, err)
+		assert.Equal(t, all.Size, res.Size)
+		results = append(results, res.Results...)
+		if res.NextCursor == "" {
+			break
+		}
+		assert.Equal(t, query.Cursor(q.Offset+2), res.NextCursor)
+		q.Offset += 2
+	}
+	assert.Equal(t, all.Results, results)
+}
+
+func TestSearchStream_ReturnsSameResultsAsSearchInChunks(t *testing.T) {
+	ctx := context.Background()
+	db := useKitchenSinkData(ctx, t)
+
+	cache, err := local.New(100)
+	require.NoError(t, err)
+	s := New(db, 100, cache, nil)
+	q := query.Search{
+		IncludePositiveDigests:  true,
+		IncludeNegativeDigests:  true,
+		IncludeUntriagedDigests: true,
+		Sort:                    query.SortDescending,
+		TraceValues: paramtools.ParamSet{
+			types.CorpusField: []string{dks.CornersCorpus},
+		},
+		RGBAMinFilter: 0,
+		RGBAMaxFilter: 255,
+	}
+	all, err := s.Search(ctx, &q)
+	require.NoError(t, err)
+	require.Greater(t, len(all.Results), 3)
+
+	// Skip the first result and stream the rest two at a time.
+	q.Offset = 1
+	q.Limit = 2
+	var chunks []*frontend.SearchStreamChunk
+	require.NoError(t, s.SearchStream(ctx, &q, func(chunk *frontend.SearchStreamChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	}))
+	require.NotEmpty(t, chunks)
+	assert.Equal(t, all.Size, chunks[0].Size)
+	assert.Equal(t, all.Commits, chunks[0].Commits)
+	var results []*frontend.SearchResult
+	for i, chunk := range chunks {
+		assert.LessOrEqual(t, len(chunk.Results), 2)
+		results = append(results, chunk.Results...)
+		if i == len(chunks)-1 {
+			assert.Empty(t, chunk.NextCursor)
+		} else {
+			assert.Equal(t, query.Cursor(1+len(results)), chunk.NextCursor)
+		}
+	}
+	assert.Equal(t, all.Results[1:], results)
+}
+
+func TestSearchStream_CallbackReturnsError_StopsStreaming(t *testing.T) {
+	ctx := context.Background()
+	db := useKitchenSinkData(ctx, t)
+
+	cache, err := local.New(100)
+	require.NoError(t, err)
+	s := New(db, 100, cache, nil)
+	calls := 0
+	err = s.SearchStream(ctx, &query.Search{
+		IncludePositiveDigests: true,
+		Sort:                   query.SortDescending,
+		TraceValues: paramtools.ParamSet{
+			types.CorpusField: []string{dks.CornersCorpus},
+		},
+		RGBAMaxFilter: 255,
+		Limit:         1,
+	}, func(*frontend.SearchStreamChunk) error {
+		calls++
+		return errors.New("client went away")
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "client went away")
+	assert.Equal(t, 1, calls)
+}
+
+func TestSearch_UntriagedDigestWithSuggestion_SuggestionIncluded(t *testing.T) {
+	ctx := context.Background()
+	db := useKitchenSinkData(ctx, t)
+
+	unt, err := sql.DigestToBytes(dks.DigestC05Unt)
+	require.NoError(t, err)
+	closest, err := sql.DigestToBytes(dks.DigestC01Pos)
+	require.NoError(t, err)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, schema.Tables{
+		TriageSuggestions: []schema.TriageSuggestionRow{{
+			GroupingID:     dks.CircleGroupingID,
+			Digest:         unt,
+			Label:          schema.LabelPositive,
+			ClosestDigest:  closest,
+			Confidence:     0.75,
+			LastComputedTS: time.Date(2021, time.March, 1, 1, 1, 1, 0, time.UTC),
+		}},
+	}))
+
+	cache, err := local.New(100)
+	require.NoError(t, err)
+	s := New(db, 100, cache, nil)
+	res, err := s.Search(ctx, &query.Search{
+		OnlyIncludeDigestsProducedAtHead: true,
+		IncludeUntriagedDigests:          true,
+		Sort:                             query.SortDescending,
+		TraceValues: paramtools.ParamSet{
+			types.CorpusField: []string{dks.RoundCorpus},
+		},
+		RGBAMinFilter: 0,
+		RGBAMaxFilter: 255,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Results)
+	for _, sr := range res.Results {
+		if sr.Digest == dks.DigestC05Unt && sr.Test == dks.CircleTest {
+			assert.Equal(t, &frontend.TriageSuggestion{
+				Label:         expectations.Positive,
+				ClosestDigest: dks.DigestC01Pos,
+				Confidence:    0.75,
+			}, sr.Suggestion)
+		} else {
+			assert.Nil(t, sr.Suggestion, sr.Digest)
+		}
+	}
+}
+
 func TestSearch_UntriagedDigestsAtHead_WithMaterializedViews(t *testing.T) {
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -7778,6 +7939,326 @@ func TestCountDigestsByTest_WithIgnored_Success(t *testing.T) {
 	}, resp)
 }
 
+func TestGetFlakyTests_ExcludeIgnored_SortedByTransitionsPerCommit(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, buildFlakyTests()))
+	waitForSystemTime()
+
+	cache, err := local.New(100)
+	require.NoError(t, err)
+	s := New(db, 100, cache, nil)
+	resp, err := s.GetFlakyTests(ctx, frontend.FlakyTestsQuery{
+		Corpus:      "test_corpus",
+		IgnoreState: types.ExcludeIgnoredTraces,
+		Order:       frontend.FlakyTestsOrderTransitionsPerCommit,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, frontend.FlakyTestsResponse{
+		Tests: []frontend.FlakyTestSummary{
+			{
+				Grouping:             paramtools.Params{types.CorpusField: "test_corpus", types.PrimaryKeyField: "flaky"},
+				Traces:               2,
+				UniqueDigests:        2,
+				CommitsWithData:      4,
+				Transitions:          4,
+				ChurnRate:            0.8, // 4 transitions in 5 pairs of consecutive data points.
+				TransitionsPerCommit: 1,
+			},
+			{
+				Grouping:             paramtools.Params{types.CorpusField: "test_corpus", types.PrimaryKeyField: "medium"},
+				Traces:               1,
+				UniqueDigests:        3,
+				CommitsWithData:      4,
+				Transitions:          2,
+				ChurnRate:            2.0 / 3,
+				TransitionsPerCommit: 0.5,
+			},
+			{
+				Grouping:        paramtools.Params{types.CorpusField: "test_corpus", types.PrimaryKeyField: "stable"},
+				Traces:          2,
+				UniqueDigests:   1,
+				CommitsWithData: 4,
+			},
+		},
+	}, resp)
+}
+
+func TestGetFlakyTests_IncludeIgnored_IgnoredTracesCounted(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, buildFlakyTests()))
+	waitForSystemTime()
+
+	cache, err := local.New(100)
+	require.NoError(t, err)
+	s := New(db, 100, cache, nil)
+	resp, err := s.GetFlakyTests(ctx, frontend.FlakyTestsQuery{
+		Corpus:      "test_corpus",
+		IgnoreState: types.IncludeIgnoredTraces,
+		Order:       frontend.FlakyTestsOrderTransitionsPerCommit,
+		Limit:       1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, frontend.FlakyTestsResponse{
+		Tests: []frontend.FlakyTestSummary{
+			{
+				Grouping:             paramtools.Params{types.CorpusField: "test_corpus", types.PrimaryKeyField: "flaky"},
+				Traces:               3,
+				UniqueDigests:        3,
+				CommitsWithData:      4,
+				Transitions:          7,
+				ChurnRate:            0.875,
+				TransitionsPerCommit: 1.75,
+			},
+		},
+	}, resp)
+}
+
+func TestGetFlakyTests_SortedByUniqueDigests(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, buildFlakyTests()))
+	waitForSystemTime()
+
+	cache, err := local.New(100)
+	require.NoError(t, err)
+	s := New(db, 100, cache, nil)
+	resp, err := s.GetFlakyTests(ctx, frontend.FlakyTestsQuery{
+		Corpus:      "test_corpus",
+		IgnoreState: types.ExcludeIgnoredTraces,
+		Order:       frontend.FlakyTestsOrderUniqueDigests,
+	})
+	require.NoError(t, err)
+	var tests []string
+	for _, ft := range resp.Tests {
+		tests = append(tests, ft.Grouping[types.PrimaryKeyField])
+	}
+	assert.Equal(t, []string{"medium", "flaky", "stable"}, tests)
+}
+
+func TestSortFlakyTests_TiesBrokenByOtherMetricsThenName(t *testing.T) {
+	summary := func(name string, uniqueDigests int, churnRate, transitionsPerCommit float64) frontend.FlakyTestSummary {
+		return frontend.FlakyTestSummary{
+			Grouping:             paramtools.Params{types.PrimaryKeyField: name},
+			UniqueDigests:        uniqueDigests,
+			ChurnRate:            churnRate,
+			TransitionsPerCommit: transitionsPerCommit,
+		}
+	}
+	tests := []frontend.FlakyTestSummary{
+		summary("delta", 2, 0.1, 0.5),
+		summary("charlie", 2, 0.1, 0.5),
+		summary("bravo", 5, 0.1, 0.5),
+		summary("alpha", 1, 0.9, 0.2),
+	}
+	names := func() []string {
+		var rv []string
+		for _, ft := range tests {
+			rv = append(rv, ft.Grouping[types.PrimaryKeyField])
+		}
+		return rv
+	}
+
+	sortFlakyTests(tests, frontend.FlakyTestsOrderTransitionsPerCommit)
+	assert.Equal(t, []string{"bravo", "charlie", "delta", "alpha"}, names())
+
+	sortFlakyTests(tests, frontend.FlakyTestsOrderChurnRate)
+	assert.Equal(t, []string{"alpha", "bravo", "charlie", "delta"}, names())
+
+	sortFlakyTests(tests, frontend.FlakyTestsOrderUniqueDigests)
+	assert.Equal(t, []string{"bravo", "charlie", "delta", "alpha"}, names())
+}
+
+// buildFlakyTests returns a data set with three tests whose digests change at different rates
+// over four commits, and one ignored trace.
+func buildFlakyTests() schema.Tables {
+	b := databuilder.TablesBuilder{}
+	b.CommitsWithData().
+		Insert("01", "user", "commit 1", "2020-12-01T00:00:01Z").
+		Insert("02", "user", "commit 2", "2020-12-01T00:00:02Z").
+		Insert("03", "user", "commit 3", "2020-12-01T00:00:03Z").
+		Insert("04", "user", "commit 4", "2020-12-01T00:00:04Z")
+
+	b.SetDigests(map[rune]types.Digest{
+		'A': dks.DigestA01Pos,
+		'b': dks.DigestA04Unt,
+		'c': dks.DigestA05Unt,
+	})
+	b.SetGroupingKeys(types.CorpusField, types.PrimaryKeyField)
+
+	b.AddTracesWithCommonKeys(paramtools.Params{
+		types.CorpusField: "test_corpus",
+	}).History(
+		"AAAA",
+		"AAAA",
+		"AbAb",
+		"AA-b",
+		"Abcc",
+		"cbAc",
+	).Keys([]paramtools.Params{
+		{types.PrimaryKeyField: "stable", dks.DeviceKey: "one"},
+		{types.PrimaryKeyField: "stable", dks.DeviceKey: "two"},
+		{types.PrimaryKeyField: "flaky", dks.DeviceKey: "one"},
+		{types.PrimaryKeyField: "flaky", dks.DeviceKey: "two"},
+		{types.PrimaryKeyField: "medium", dks.DeviceKey: "one"},
+		{types.PrimaryKeyField: "flaky", dks.DeviceKey: "ignored"},
+	}).OptionsAll(paramtools.Params{}).
+		IngestedFrom([]string{"x", "x", "x", "x"},
+			[]string{"2020-12-12T12:12:12Z", "2020-12-12T12:12:12Z", "2020-12-12T12:12:12Z", "2020-12-12T12:12:12Z"})
+
+	b.AddTriageEvent("user", "2020-06-07T08:09:10Z").
+		ExpectationsForGrouping(map[string]string{types.CorpusField: "test_corpus", types.PrimaryKeyField: "stable"}).
+		Positive(dks.DigestA01Pos)
+
+	b.AddIgnoreRule("user", "user", "2030-12-30T15:16:17Z", "ignore a device",
+		paramtools.ParamSet{
+			dks.DeviceKey: []string{"ignored"},
+		})
+	return b.Build()
+}
+
+func TestGetDigestOccurrences_IncludeChangelists_AllOccurrencesReturned(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, buildDigestOccurrences()))
+	waitForSystemTime()
+
+	cache, err := local.New(100)
+	require.NoError(t, err)
+	s := New(db, 100, cache, nil)
+	s.SetReviewSystemTemplates(map[string]string{
+		dks.GerritCRS: "http://example.com/public/%s",
+	})
+	resp, err := s.GetDigestOccurrences(ctx, frontend.DigestOccurrencesQuery{
+		Digest:             dks.DigestA04Unt,
+		IncludeChangelists: true,
+	})
+	require.NoError(t, err)
+
+	traceID := func(keys paramtools.Params) string {
+		_, id := sql.SerializeMap(keys)
+		return hex.EncodeToString(id)
+	}
+	commit := func(id, subject string, ts int64) frontend.Commit {
+		h := sha1.Sum([]byte(id))
+		return frontend.Commit{ID: id, Hash: hex.EncodeToString(h[:]), Author: "user", Subject: subject, CommitTime: ts}
+	}
+	circle := paramtools.Params{types.CorpusField: "round", types.PrimaryKeyField: "circle", dks.DeviceKey: "one"}
+	square := paramtools.Params{types.CorpusField: "corners", types.PrimaryKeyField: "square", dks.DeviceKey: "one"}
+	triangle := paramtools.Params{types.CorpusField: "corners", types.PrimaryKeyField: "triangle", dks.DeviceKey: "ignored"}
+	assert.Equal(t, frontend.DigestOccurrencesResponse{
+		Digest: dks.DigestA04Unt,
+		Traces: []frontend.TraceOccurrence{{
+			TraceID:     traceID(circle),
+			Grouping:    paramtools.Params{types.CorpusField: "round", types.PrimaryKeyField: "circle"},
+			Params:      circle,
+			Occurrences: 3,
+			FirstCommit: commit("02", "commit 2", 1606780802),
+			LastCommit:  commit("04", "commit 4", 1606780804),
+			AtHead:      true,
+		}, {
+			TraceID:     traceID(square),
+			Grouping:    paramtools.Params{types.CorpusField: "corners", types.PrimaryKeyField: "square"},
+			Params:      square,
+			Occurrences: 1,
+			FirstCommit: commit("01", "commit 1", 1606780801),
+			LastCommit:  commit("01", "commit 1", 1606780801),
+		}, {
+			TraceID:     traceID(triangle),
+			Grouping:    paramtools.Params{types.CorpusField: "corners", types.PrimaryKeyField: "triangle"},
+			Params:      triangle,
+			Occurrences: 1,
+			FirstCommit: commit("02", "commit 2", 1606780802),
+			LastCommit:  commit("02", "commit 2", 1606780802),
+			AtHead:      true,
+			Ignored:     true,
+		}},
+		Changelists: []frontend.ChangelistOccurrence{{
+			System:        dks.GerritCRS,
+			ChangelistID:  "cl1",
+			ChangelistURL: "http://example.com/public/cl1",
+			Owner:         "owner",
+			Subject:       "Make squares round",
+			PatchsetID:    "ps1",
+			PatchsetOrder: 1,
+			Grouping:      paramtools.Params{types.CorpusField: "corners", types.PrimaryKeyField: "square"},
+			Traces:        1,
+		}},
+	}, resp)
+}
+
+func TestGetDigestOccurrences_ChangelistsNotIncluded_OnlyPrimaryBranchSearched(t *testing.T) {
+
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, buildDigestOccurrences()))
+	waitForSystemTime()
+
+	cache, err := local.New(100)
+	require.NoError(t, err)
+	s := New(db, 100, cache, nil)
+	resp, err := s.GetDigestOccurrences(ctx, frontend.DigestOccurrencesQuery{
+		Digest: dks.DigestB01Pos,
+	})
+	require.NoError(t, err)
+	// DigestB01Pos was only produced on the CL.
+	assert.Equal(t, frontend.DigestOccurrencesResponse{
+		Digest:      dks.DigestB01Pos,
+		Traces:      []frontend.TraceOccurrence{},
+		Changelists: []frontend.ChangelistOccurrence{},
+	}, resp)
+}
+
+func buildDigestOccurrences() schema.Tables {
+	b := databuilder.TablesBuilder{}
+	b.CommitsWithData().
+		Insert("01", "user", "commit 1", "2020-12-01T00:00:01Z").
+		Insert("02", "user", "commit 2", "2020-12-01T00:00:02Z").
+		Insert("03", "user", "commit 3", "2020-12-01T00:00:03Z").
+		Insert("04", "user", "commit 4", "2020-12-01T00:00:04Z")
+
+	b.SetDigests(map[rune]types.Digest{
+		'A': dks.DigestA01Pos,
+		'b': dks.DigestA04Unt,
+	})
+	b.SetGroupingKeys(types.CorpusField, types.PrimaryKeyField)
+
+	b.AddTracesWithCommonKeys(paramtools.Params{}).History(
+		"Abbb",
+		"bA-A",
+		"AAAA",
+		"-b--",
+	).Keys([]paramtools.Params{
+		{types.CorpusField: "round", types.PrimaryKeyField: "circle", dks.DeviceKey: "one"},
+		{types.CorpusField: "corners", types.PrimaryKeyField: "square", dks.DeviceKey: "one"},
+		{types.CorpusField: "corners", types.PrimaryKeyField: "square", dks.DeviceKey: "two"},
+		{types.CorpusField: "corners", types.PrimaryKeyField: "triangle", dks.DeviceKey: "ignored"},
+	}).OptionsAll(paramtools.Params{}).
+		IngestedFrom([]string{"x", "x", "x", "x"},
+			[]string{"2020-12-12T12:12:12Z", "2020-12-12T12:12:12Z", "2020-12-12T12:12:12Z", "2020-12-12T12:12:12Z"})
+
+	cl := b.AddChangelist("cl1", dks.GerritCRS, "owner", "Make squares round", schema.StatusOpen)
+	cl.AddPatchset("ps1", "5555555555555555555555555555555555555555", 1).
+		DataWithCommonKeys(paramtools.Params{dks.DeviceKey: "three"}).
+		Digests(dks.DigestA04Unt, dks.DigestB01Pos).
+		Keys([]paramtools.Params{
+			{types.CorpusField: "corners", types.PrimaryKeyField: "square"},
+			{types.CorpusField: "round", types.PrimaryKeyField: "circle"},
+		}).OptionsAll(paramtools.Params{}).
+		FromTryjob("tryjob 1", dks.BuildBucketCIS, "My-Test", "whatever", "2020-12-12T12:12:12Z")
+
+	b.AddIgnoreRule("user", "user", "2030-12-30T15:16:17Z", "ignore a device",
+		paramtools.ParamSet{
+			dks.DeviceKey: []string{"ignored"},
+		})
+	return b.Build()
+}
+
 func TestCountDigestsByTest_FilteredByParams_Success(t *testing.T) {
 
 	ctx := context.Background()
@@ -8213,3 +8694,28 @@ func digestToBytes(t *testing.T, digest types.Digest) []byte {
 	require.NoError(t, err)
 	return bytes
 }
+
+func TestMarkNegligible_UsesThresholdsForCorpus(t *testing.T) {
+	s := &Impl{diffThresholds: map[string]diff.Thresholds{
+		dks.CornersCorpus: {MaxRGBADiff: 10, MaxPixelDiffPercent: 1},
+	}}
+	small := func() *frontend.SRDiffDigest {
+		return &frontend.SRDiffDigest{MaxRGBADiffs: [4]int{3, 10, 0, 0}, PixelDiffPercent: 0.5}
+	}
+
+	srdd := small()
+	s.markNegligible(dks.CornersCorpus, srdd)
+	assert.True(t, srdd.Negligible)
+
+	// No thresholds configured for this corpus.
+	srdd = small()
+	s.markNegligible(dks.RoundCorpus, srdd)
+	assert.False(t, srdd.Negligible)
+
+	srdd = &frontend.SRDiffDigest{MaxRGBADiffs: [4]int{3, 11, 0, 0}, PixelDiffPercent: 0.5}
+	s.markNegligible(dks.CornersCorpus, srdd)
+	assert.False(t, srdd.Negligible)
+
+	// nil is safe to pass in.
+	s.markNegligible(dks.CornersCorpus, nil)
+}
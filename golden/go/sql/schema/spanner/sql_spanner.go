@@ -3,7 +3,17 @@ package spanner
 // Generated by //go/sql/exporter/
 // DO NOT EDIT
 
-const Schema = `CREATE TABLE IF NOT EXISTS Changelists (
+const Schema = `CREATE TABLE IF NOT EXISTS ArchivedIgnoreRules (
+  ignore_rule_id TEXT PRIMARY KEY,
+  creator_email TEXT NOT NULL,
+  updated_email TEXT NOT NULL,
+  expires TIMESTAMP WITH TIME ZONE NOT NULL,
+  note TEXT,
+  query JSONB NOT NULL,
+  archived_ts TIMESTAMP WITH TIME ZONE NOT NULL,
+  createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+) TTL INTERVAL '1095 days' ON createdat;
+CREATE TABLE IF NOT EXISTS Changelists (
   changelist_id TEXT PRIMARY KEY,
   system TEXT NOT NULL,
   status TEXT NOT NULL,
@@ -27,6 +37,7 @@ CREATE TABLE IF NOT EXISTS DiffMetrics (
   combined_metric FLOAT4 NOT NULL,
   dimensions_differ BOOL NOT NULL,
   ts TIMESTAMP WITH TIME ZONE NOT NULL,
+  diff_image_digest BYTEA,
   PRIMARY KEY (left_digest, right_digest),
   createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
 ) TTL INTERVAL '1095 days' ON createdat;
@@ -45,6 +56,7 @@ CREATE TABLE IF NOT EXISTS ExpectationRecords (
   user_name TEXT NOT NULL,
   triage_time TIMESTAMP WITH TIME ZONE NOT NULL,
   num_changes INT8 NOT NULL,
+  patchset_id TEXT,
   createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
 ) TTL INTERVAL '1095 days' ON createdat;
 CREATE TABLE IF NOT EXISTS Expectations (
@@ -194,6 +206,16 @@ CREATE TABLE IF NOT EXISTS TrackingCommits (
   last_git_hash TEXT NOT NULL,
   createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
 ) TTL INTERVAL '1095 days' ON createdat;
+CREATE TABLE IF NOT EXISTS TriageSuggestions (
+  grouping_id BYTEA,
+  digest BYTEA,
+  label VARCHAR(1) NOT NULL,
+  closest_digest BYTEA NOT NULL,
+  confidence FLOAT4 NOT NULL,
+  last_computed_ts TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (grouping_id, digest),
+  createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+) TTL INTERVAL '1095 days' ON createdat;
 CREATE TABLE IF NOT EXISTS Tryjobs (
   tryjob_id TEXT PRIMARY KEY,
   system TEXT NOT NULL,
@@ -230,6 +252,7 @@ CREATE TABLE IF NOT EXISTS DeprecatedExpectationUndos (
 CREATE INDEX IF NOT EXISTS system_status_ingested_idx on Changelists (system, status, last_ingested_data);
 CREATE INDEX IF NOT EXISTS status_ingested_idx on Changelists (status, last_ingested_data DESC);
 CREATE INDEX IF NOT EXISTS branch_ts_idx on ExpectationRecords (branch_name, triage_time);
+CREATE INDEX IF NOT EXISTS user_ts_idx on ExpectationRecords (user_name, triage_time);
 CREATE INDEX IF NOT EXISTS label_idx on Expectations (label);
 CREATE INDEX IF NOT EXISTS commit_idx on GitCommits (commit_id);
 CREATE INDEX IF NOT EXISTS cl_order_idx on Patchsets (changelist_id, ps_order);
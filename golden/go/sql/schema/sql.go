@@ -3,7 +3,16 @@ package schema
 // Generated by //go/sql/exporter/
 // DO NOT EDIT
 
-const Schema = `CREATE TABLE IF NOT EXISTS Changelists (
+const Schema = `CREATE TABLE IF NOT EXISTS ArchivedIgnoreRules (
+  ignore_rule_id UUID PRIMARY KEY,
+  creator_email STRING NOT NULL,
+  updated_email STRING NOT NULL,
+  expires TIMESTAMP WITH TIME ZONE NOT NULL,
+  note STRING,
+  query JSONB NOT NULL,
+  archived_ts TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE TABLE IF NOT EXISTS Changelists (
   changelist_id STRING PRIMARY KEY,
   system STRING NOT NULL,
   status STRING NOT NULL,
@@ -27,6 +36,7 @@ CREATE TABLE IF NOT EXISTS DiffMetrics (
   combined_metric FLOAT4 NOT NULL,
   dimensions_differ BOOL NOT NULL,
   ts TIMESTAMP WITH TIME ZONE NOT NULL,
+  diff_image_digest BYTES,
   PRIMARY KEY (left_digest, right_digest)
 );
 CREATE TABLE IF NOT EXISTS ExpectationDeltas (
@@ -43,7 +53,9 @@ CREATE TABLE IF NOT EXISTS ExpectationRecords (
   user_name STRING NOT NULL,
   triage_time TIMESTAMP WITH TIME ZONE NOT NULL,
   num_changes INT4 NOT NULL,
-  INDEX branch_ts_idx (branch_name, triage_time)
+  patchset_id STRING,
+  INDEX branch_ts_idx (branch_name, triage_time),
+  INDEX user_ts_idx (user_name, triage_time)
 );
 CREATE TABLE IF NOT EXISTS Expectations (
   grouping_id BYTES,
@@ -184,6 +196,15 @@ CREATE TABLE IF NOT EXISTS TrackingCommits (
   repo STRING PRIMARY KEY,
   last_git_hash STRING NOT NULL
 );
+CREATE TABLE IF NOT EXISTS TriageSuggestions (
+  grouping_id BYTES,
+  digest BYTES,
+  label CHAR NOT NULL,
+  closest_digest BYTES NOT NULL,
+  confidence FLOAT4 NOT NULL,
+  last_computed_ts TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (grouping_id, digest)
+);
 CREATE TABLE IF NOT EXISTS Tryjobs (
   tryjob_id STRING PRIMARY KEY,
   system STRING NOT NULL,
@@ -125,6 +125,7 @@ const (
 //go:generate bazelisk run --config=mayberemote //:go -- run ../exporter/tosql --output_file sql.go --output_pkg schema
 //go:generate bazelisk run --config=mayberemote //:go -- run ../exporter/tosql --output_file ./spanner/sql_spanner.go --output_pkg spanner --schemaTarget spanner
 type Tables struct {
+	ArchivedIgnoreRules                []ArchivedIgnoreRuleRow             `sql_backup:"weekly"`
 	Changelists                        []ChangelistRow                     `sql_backup:"weekly"`
 	CommitsWithData                    []CommitWithDataRow                 `sql_backup:"daily"`
 	DiffMetrics                        []DiffMetricRow                     `sql_backup:"monthly"`
@@ -149,6 +150,7 @@ type Tables struct {
 	TraceValues                        []TraceValueRow                     `sql_backup:"monthly"`
 	Traces                             []TraceRow                          `sql_backup:"monthly"`
 	TrackingCommits                    []TrackingCommitRow                 `sql_backup:"daily"`
+	TriageSuggestions                  []TriageSuggestionRow               `sql_backup:"none"`
 	Tryjobs                            []TryjobRow                         `sql_backup:"weekly"`
 	ValuesAtHead                       []ValueAtHeadRow                    `sql_backup:"monthly"`
 
@@ -459,14 +461,20 @@ type ExpectationRecordRow struct {
 	TriageTime time.Time `sql:"triage_time TIMESTAMP WITH TIME ZONE NOT NULL"`
 	// NumChanges is how many digests were affected. It corresponds to the number of
 	// ExpectationDelta rows have this record as their parent. It is a denormalized field.
-	NumChanges         int      `sql:"num_changes INT4 NOT NULL"`
+	NumChanges int `sql:"num_changes INT4 NOT NULL"`
+	// PatchsetID identifies the patchset of the CL identified by BranchName that the user was
+	// looking at when triaging. It is nil for the primary branch, and for triage events which
+	// were made without patchset context (e.g. undos and older events).
+	PatchsetID         *string  `sql:"patchset_id STRING"`
 	branchTriagedIndex struct{} `sql:"INDEX branch_ts_idx (branch_name, triage_time)"`
+	// This index makes it fast to audit the expectation changes made by a given user.
+	userTriagedIndex struct{} `sql:"INDEX user_ts_idx (user_name, triage_time)"`
 }
 
 // ToSQLRow implements the sqltest.SQLExporter interface.
 func (r ExpectationRecordRow) ToSQLRow() (colNames []string, colData []interface{}) {
-	return []string{"expectation_record_id", "branch_name", "user_name", "triage_time", "num_changes"},
-		[]interface{}{r.ExpectationRecordID, r.BranchName, r.UserName, r.TriageTime, r.NumChanges}
+	return []string{"expectation_record_id", "branch_name", "user_name", "triage_time", "num_changes", "patchset_id"},
+		[]interface{}{r.ExpectationRecordID, r.BranchName, r.UserName, r.TriageTime, r.NumChanges, r.PatchsetID}
 }
 
 // GetPrimaryKeyCols implements the sqltest.SQLExporter interface.
@@ -476,7 +484,7 @@ func (r ExpectationRecordRow) GetPrimaryKeyCols() []string {
 
 // ScanFrom implements the sqltest.SQLScanner interface.
 func (r *ExpectationRecordRow) ScanFrom(scan func(...interface{}) error) error {
-	err := scan(&r.ExpectationRecordID, &r.BranchName, &r.UserName, &r.TriageTime, &r.NumChanges)
+	err := scan(&r.ExpectationRecordID, &r.BranchName, &r.UserName, &r.TriageTime, &r.NumChanges, &r.PatchsetID)
 	if err != nil {
 		return skerr.Wrap(err)
 	}
@@ -595,16 +603,20 @@ type DiffMetricRow struct {
 	DimensionsDiffer bool `sql:"dimensions_differ BOOL NOT NULL"`
 	// Timestamp represents when this metric was computed or verified (i.e. still in use). This
 	// allows for us to periodically clean up this large table.
-	Timestamp  time.Time `sql:"ts TIMESTAMP WITH TIME ZONE NOT NULL"`
-	primaryKey struct{}  `sql:"PRIMARY KEY (left_digest, right_digest)"`
+	Timestamp time.Time `sql:"ts TIMESTAMP WITH TIME ZONE NOT NULL"`
+	// DiffImageDigest is the MD5 hash of the PNG-encoded diff image, which is stored in GCS
+	// content-addressed by this hash. Many pairs of images produce identical diff images, so they
+	// share a single stored copy. It is nil if the diff image was not stored.
+	DiffImageDigest DigestBytes `sql:"diff_image_digest BYTES"`
+	primaryKey      struct{}    `sql:"PRIMARY KEY (left_digest, right_digest)"`
 }
 
 // ToSQLRow implements the sqltest.SQLExporter interface.
 func (r DiffMetricRow) ToSQLRow() (colNames []string, colData []interface{}) {
 	return []string{"left_digest", "right_digest", "num_pixels_diff", "percent_pixels_diff", "max_rgba_diffs",
-			"max_channel_diff", "combined_metric", "dimensions_differ", "ts"},
+			"max_channel_diff", "combined_metric", "dimensions_differ", "ts", "diff_image_digest"},
 		[]interface{}{r.LeftDigest, r.RightDigest, r.NumPixelsDiff, r.PercentPixelsDiff, r.MaxRGBADiffs,
-			r.MaxChannelDiff, r.CombinedMetric, r.DimensionsDiffer, r.Timestamp}
+			r.MaxChannelDiff, r.CombinedMetric, r.DimensionsDiffer, r.Timestamp, r.DiffImageDigest}
 }
 
 // GetPrimaryKeyCols implements the sqltest.SQLExporter interface.
@@ -615,7 +627,8 @@ func (r DiffMetricRow) GetPrimaryKeyCols() []string {
 // ScanFrom implements the sqltest.SQLScanner interface.
 func (r *DiffMetricRow) ScanFrom(scan func(...interface{}) error) error {
 	err := scan(&r.LeftDigest, &r.RightDigest, &r.NumPixelsDiff, &r.PercentPixelsDiff,
-		&r.MaxRGBADiffs, &r.MaxChannelDiff, &r.CombinedMetric, &r.DimensionsDiffer, &r.Timestamp)
+		&r.MaxRGBADiffs, &r.MaxChannelDiff, &r.CombinedMetric, &r.DimensionsDiffer, &r.Timestamp,
+		&r.DiffImageDigest)
 	if err != nil {
 		return skerr.Wrap(err)
 	}
@@ -812,6 +825,53 @@ func (r IgnoreRuleRow) RowsOrderBy() string {
 	return `ORDER BY expires ASC`
 }
 
+// ArchivedIgnoreRuleRow represents an ignore rule which expired and was archived. Archived rules
+// no longer match any traces, but are kept so humans can see (and restore) what used to be
+// ignored.
+type ArchivedIgnoreRuleRow struct {
+	// IgnoreRuleID is the id the rule had before it was archived.
+	IgnoreRuleID uuid.UUID `sql:"ignore_rule_id UUID PRIMARY KEY"`
+	// CreatorEmail is the email address of the user who originally created this rule.
+	CreatorEmail string `sql:"creator_email STRING NOT NULL"`
+	// UpdatedEmail is the email address of the user who most recently updated this rule.
+	UpdatedEmail string `sql:"updated_email STRING NOT NULL"`
+	// Expires is when this rule expired.
+	Expires time.Time `sql:"expires TIMESTAMP WITH TIME ZONE NOT NULL"`
+	// Note is a comment explaining this rule. It typically links to a bug.
+	Note string `sql:"note STRING"`
+	// Query is a map[string][]string that describe which traces used to be ignored.
+	Query paramtools.ReadOnlyParamSet `sql:"query JSONB NOT NULL"`
+	// ArchivedTS is when this rule was archived.
+	ArchivedTS time.Time `sql:"archived_ts TIMESTAMP WITH TIME ZONE NOT NULL"`
+}
+
+// ToSQLRow implements the sqltest.SQLExporter interface.
+func (r ArchivedIgnoreRuleRow) ToSQLRow() (colNames []string, colData []interface{}) {
+	return []string{"ignore_rule_id", "creator_email", "updated_email", "expires", "note", "query", "archived_ts"},
+		[]interface{}{r.IgnoreRuleID, r.CreatorEmail, r.UpdatedEmail, r.Expires, r.Note, r.Query, r.ArchivedTS}
+}
+
+// GetPrimaryKeyCols implements the sqltest.SQLExporter interface.
+func (r ArchivedIgnoreRuleRow) GetPrimaryKeyCols() []string {
+	return []string{"ignore_rule_id"}
+}
+
+// ScanFrom implements the sqltest.SQLScanner interface.
+func (r *ArchivedIgnoreRuleRow) ScanFrom(scan func(...interface{}) error) error {
+	if err := scan(&r.IgnoreRuleID, &r.CreatorEmail, &r.UpdatedEmail, &r.Expires, &r.Note, &r.Query, &r.ArchivedTS); err != nil {
+		return skerr.Wrap(err)
+	}
+	r.Expires = r.Expires.UTC()
+	r.ArchivedTS = r.ArchivedTS.UTC()
+	paramtools.ParamSet(r.Query).Normalize()
+	return nil
+}
+
+// RowsOrderBy implements the sqltest.RowsOrder interface.
+func (r ArchivedIgnoreRuleRow) RowsOrderBy() string {
+	return `ORDER BY archived_ts ASC`
+}
+
 type ChangelistRow struct {
 	// ChangelistID is the fully qualified id of this changelist. "Fully qualified" means it has
 	// the system as a prefix (e.g "gerrit_1234") which simplifies joining logic and ensures
@@ -1107,6 +1167,53 @@ func (r ProblemImageRow) RowsOrderBy() string {
 	return `ORDER BY digest ASC`
 }
 
+// TriageSuggestionRow represents a suggested label for an untriaged digest, based on the triaged
+// digests of the same grouping which are most similar to it (according to DiffMetrics). These rows
+// are recomputed periodically and can be regenerated at any time, so they are not backed up.
+type TriageSuggestionRow struct {
+	// GroupingID identifies the grouping of the untriaged digest. This is a foreign key into the
+	// Groupings table.
+	GroupingID GroupingID `sql:"grouping_id BYTES"`
+	// Digest is the untriaged digest for which a label is suggested.
+	Digest DigestBytes `sql:"digest BYTES"`
+	// Label is the suggested label.
+	Label ExpectationLabel `sql:"label CHAR NOT NULL"`
+	// ClosestDigest is the triaged digest in the same grouping which is most similar to Digest and
+	// has the suggested label.
+	ClosestDigest DigestBytes `sql:"closest_digest BYTES NOT NULL"`
+	// Confidence is a value in (0, 1] indicating how confident we are in the suggested label, with
+	// 1 being the most confident.
+	Confidence float32 `sql:"confidence FLOAT4 NOT NULL"`
+	// LastComputedTS is when this suggestion was last computed.
+	LastComputedTS time.Time `sql:"last_computed_ts TIMESTAMP WITH TIME ZONE NOT NULL"`
+	primaryKey     struct{}  `sql:"PRIMARY KEY (grouping_id, digest)"`
+}
+
+// ToSQLRow implements the sqltest.SQLExporter interface.
+func (r TriageSuggestionRow) ToSQLRow() (colNames []string, colData []interface{}) {
+	return []string{"grouping_id", "digest", "label", "closest_digest", "confidence", "last_computed_ts"},
+		[]interface{}{r.GroupingID, r.Digest, r.Label, r.ClosestDigest, r.Confidence, r.LastComputedTS}
+}
+
+// GetPrimaryKeyCols implements the sqltest.SQLExporter interface.
+func (r TriageSuggestionRow) GetPrimaryKeyCols() []string {
+	return []string{"grouping_id", "digest"}
+}
+
+// ScanFrom implements the sqltest.SQLScanner interface.
+func (r *TriageSuggestionRow) ScanFrom(scan func(...interface{}) error) error {
+	if err := scan(&r.GroupingID, &r.Digest, &r.Label, &r.ClosestDigest, &r.Confidence, &r.LastComputedTS); err != nil {
+		return skerr.Wrap(err)
+	}
+	r.LastComputedTS = r.LastComputedTS.UTC()
+	return nil
+}
+
+// RowsOrderBy implements the sqltest.RowsOrder interface.
+func (r TriageSuggestionRow) RowsOrderBy() string {
+	return `ORDER BY grouping_id, digest ASC`
+}
+
 // DeprecatedExpectationUndoRow represents an undo operation that we could not automatically
 // apply during the transitional period of expectations. A human will manually apply these when
 // removing the firestore implementation from the loop.
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"path"
+	"strconv"
 
 	"go.opencensus.io/trace"
 
@@ -14,6 +15,7 @@ import (
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/golden/go/image/codec"
 	"go.skia.org/infra/golden/go/types"
 	"google.golang.org/api/option"
 )
@@ -42,6 +44,14 @@ type GCSClient interface {
 	// GetImage returns the raw bytes of an image with the corresponding Digest.
 	GetImage(ctx context.Context, digest types.Digest) ([]byte, error)
 
+	// GetDiffImage returns the raw bytes of a stored diff image, which is addressed by the MD5
+	// hash of its contents.
+	GetDiffImage(ctx context.Context, digest types.Digest) ([]byte, error)
+
+	// GetThumbnail returns the raw bytes of a precomputed thumbnail of the image with the given
+	// Digest, downscaled to the given size (one of pyramid.ThumbnailSizes).
+	GetThumbnail(ctx context.Context, digest types.Digest, size int) ([]byte, error)
+
 	// Options returns the options that were used to initialize the client
 	Options() GCSClientOptions
 }
@@ -49,6 +59,14 @@ type GCSClient interface {
 const (
 	// The GCS folder that contains the images, named by their digests.
 	imgFolder = "dm-images-v1"
+
+	// DiffImgFolder is the GCS folder that contains the diff images, named by the MD5 hash of
+	// their contents.
+	DiffImgFolder = "diff-images-v1"
+
+	// ThumbnailFolder is the GCS folder that contains the precomputed thumbnails of images, in one
+	// subfolder per size, named by the digest of the full-size image.
+	ThumbnailFolder = "thumbnails-v1"
 )
 
 // ClientImpl implements the GCSClient interface.
@@ -162,8 +180,36 @@ func (g *ClientImpl) writeToPath(ctx context.Context, targetPath, contentType st
 func (g *ClientImpl) GetImage(ctx context.Context, digest types.Digest) ([]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "gcsclient_GetImage")
 	defer span.End()
+	return g.readImage(ctx, imgFolder, digest)
+}
+
+// GetDiffImage fulfills the GCSClient interface. It returns an error if the diff image is not
+// found.
+func (g *ClientImpl) GetDiffImage(ctx context.Context, digest types.Digest) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "gcsclient_GetDiffImage")
+	defer span.End()
+	return g.readImage(ctx, DiffImgFolder, digest)
+}
+
+// GetThumbnail fulfills the GCSClient interface. It returns an error if the thumbnail is not
+// found.
+func (g *ClientImpl) GetThumbnail(ctx context.Context, digest types.Digest, size int) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "gcsclient_GetThumbnail")
+	defer span.End()
+	return g.readImage(ctx, ThumbnailPath(size), digest)
+}
+
+// ThumbnailPath returns the GCS folder that contains the thumbnails of the given size.
+func ThumbnailPath(size int) string {
+	return path.Join(ThumbnailFolder, strconv.Itoa(size))
+}
+
+// readImage returns the bytes of the image with the given digest in the given folder. Images are
+// always stored with a .png extension, but may be encoded as PNG, WebP or AVIF. An error is
+// returned if the stored bytes are not in one of those formats.
+func (g *ClientImpl) readImage(ctx context.Context, folder string, digest types.Digest) ([]byte, error) {
 	// intentionally using path because gcs is forward slashes
-	imgPath := path.Join(imgFolder, string(digest)+".png")
+	imgPath := path.Join(folder, string(digest)+".png")
 	r, err := g.storageClient.Bucket(g.options.Bucket).Object(imgPath).NewReader(ctx)
 	if err != nil {
 		// If not image not found, this error path will be taken.
@@ -171,7 +217,13 @@ func (g *ClientImpl) GetImage(ctx context.Context, digest types.Digest) ([]byte,
 	}
 	defer util.Close(r)
 	b, err := io.ReadAll(r)
-	return b, skerr.Wrap(err)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if codec.DetectFormat(b) == codec.Unknown {
+		return nil, skerr.Fmt("image %s has unsupported format (content type %q)", imgPath, r.Attrs.ContentType)
+	}
+	return b, nil
 }
 
 // Ensure ClientImpl fulfills the GCSClient interface.
@@ -71,6 +71,15 @@ func addTypes(generator *go2ts.Go2TS) {
 	// Response for the /json/v1/byblame RPC endpoint.
 	generator.Add(frontend.ByBlameResponse{})
 
+	// Response for the /json/v1/triagequeue RPC endpoint.
+	generator.Add(frontend.TriageQueueResponse{})
+
+	// Response for the /json/v1/flaky RPC endpoint.
+	generator.Add(frontend.FlakyTestsResponse{})
+
+	// Response for the /json/v1/digest/occurrences RPC endpoint.
+	generator.Add(frontend.DigestOccurrencesResponse{})
+
 	// Response for the /json/v2/triagelog RPC endpoint.
 	generator.Add(frontend.TriageLogResponse{})
 
@@ -107,4 +116,6 @@ func addTypes(generator *go2ts.Go2TS) {
 	generator.AddUnionWithName([]frontend.RefClosest{frontend.PositiveRef, frontend.NegativeRef, frontend.NoRef}, "RefClosest")
 	generator.AddUnionWithName(frontend.AllTriageResponseStatus, "TriageResponseStatus")
 	generator.AddUnionWithName(frontend.AllClosestDiffLabels, "ClosestDiffLabel")
+	generator.AddUnionWithName(frontend.AllTriageQueueOrders, "TriageQueueOrder")
+	generator.AddUnionWithName(frontend.AllFlakyTestsOrders, "FlakyTestsOrder")
 }
@@ -6,6 +6,8 @@ package frontend
 import (
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"time"
 
 	"go.skia.org/infra/golden/go/validation"
@@ -13,6 +15,7 @@ import (
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/golden/go/diff"
 	"go.skia.org/infra/golden/go/expectations"
 	"go.skia.org/infra/golden/go/ignore"
 	"go.skia.org/infra/golden/go/tiling"
@@ -45,6 +48,10 @@ const (
 	KnownHashesRouteV1 = "/json/v1/hashes"
 
 	GroupingsRouteV1 = "/json/v1/groupings"
+
+	// ExpectationsDiffRouteV1 serves the difference between the expectations of the master branch
+	// and those of the CL given by the "crs" and "issue" GET parameters.
+	ExpectationsDiffRouteV1 = "/json/v1/expectations/diff"
 )
 
 // Changelist encapsulates how the frontend expects to get information
@@ -137,6 +144,10 @@ type TriageRequestV3 struct {
 	// CodeReviewSystem should be also.
 	CodeReviewSystem string `json:"crs,omitempty"`
 
+	// PatchsetID is the ID of the Patchset of the Changelist that the user was looking at when
+	// triaging. It is optional and only recorded for auditing purposes.
+	PatchsetID string `json:"patchset_id,omitempty"`
+
 	// ImageMatchingAlgorithm is the name of the non-exact image matching algorithm requesting the
 	// triage (see http://go/gold-non-exact-matching). If set, the algorithm name will be used as
 	// the author of the triage action.
@@ -281,6 +292,43 @@ type Commit struct {
 	ChangelistURL string `json:"cl_url"`
 }
 
+// TriageQueueOrder is the order in which the /json/v1/triagequeue RPC returns untriaged digests.
+type TriageQueueOrder string
+
+const (
+	// TriageQueueOrderCluster groups the digests by test and, within a test, places digests that
+	// share the same closest reference digest next to each other, as they tend to look alike.
+	TriageQueueOrderCluster = TriageQueueOrder("cluster")
+	// TriageQueueOrderBlame orders the digests by the commit range believed to have introduced
+	// them, in the same order as the ranges are shown on the "By Blame" page.
+	TriageQueueOrderBlame = TriageQueueOrder("blame")
+	// TriageQueueOrderDiff orders the digests from the largest to the smallest diff against their
+	// closest reference digest. Digests without a reference come last.
+	TriageQueueOrderDiff = TriageQueueOrder("diff")
+)
+
+// AllTriageQueueOrders is the list of all possible TriageQueueOrder values.
+var AllTriageQueueOrders = []TriageQueueOrder{TriageQueueOrderCluster, TriageQueueOrderBlame, TriageQueueOrderDiff}
+
+// TriageQueueEntry identifies a single untriaged digest in the triage queue.
+type TriageQueueEntry struct {
+	Test   types.TestName `json:"test"`
+	Digest types.Digest   `json:"digest"`
+}
+
+// TriageQueueResponse is the response for /json/v1/triagequeue.
+type TriageQueueResponse struct {
+	Entries []TriageQueueEntry `json:"entries"`
+	// Total is the number of untriaged digests in the whole queue.
+	Total int `json:"total"`
+	// NextCursor can be passed as the cursor parameter to fetch the next page of the queue. It is
+	// empty if this is the last page.
+	NextCursor string `json:"next_cursor"`
+	// PrevCursor can be passed as the cursor parameter to fetch the previous page of the queue.
+	// It is empty if this is the first page.
+	PrevCursor string `json:"prev_cursor"`
+}
+
 // ByBlameResponse is the response for /json/v1/byblame.
 type ByBlameResponse struct {
 	Data []ByBlameEntry `json:"data"`
@@ -351,6 +399,160 @@ type ListTestsResponse struct {
 	Tests []TestSummary `json:"tests"`
 }
 
+// FlakyTestsOrder is the metric by which the /json/v1/flaky RPC sorts tests, flakiest first.
+type FlakyTestsOrder string
+
+const (
+	// FlakyTestsOrderTransitionsPerCommit sorts tests by how often, on average, one of their
+	// traces produced a different digest than it did at the previous commit.
+	FlakyTestsOrderTransitionsPerCommit = FlakyTestsOrder("transitions_per_commit")
+	// FlakyTestsOrderChurnRate sorts tests by the fraction of their consecutive trace data points
+	// that have different digests.
+	FlakyTestsOrderChurnRate = FlakyTestsOrder("churn_rate")
+	// FlakyTestsOrderUniqueDigests sorts tests by the number of distinct digests they produced.
+	FlakyTestsOrderUniqueDigests = FlakyTestsOrder("unique_digests")
+)
+
+// AllFlakyTestsOrders is the list of all possible FlakyTestsOrder values.
+var AllFlakyTestsOrders = []FlakyTestsOrder{FlakyTestsOrderTransitionsPerCommit, FlakyTestsOrderChurnRate, FlakyTestsOrderUniqueDigests}
+
+// FlakyTestsQuery encapsulates the inputs to FlakyTestsHandler.
+type FlakyTestsQuery struct {
+	Corpus      string
+	IgnoreState types.IgnoreState
+	Order       FlakyTestsOrder
+	// Limit is the maximum number of tests to return, or 0 to return all of them.
+	Limit int
+}
+
+// ParseFlakyTestsQuery returns a FlakyTestsQuery by parsing the given request or error if the
+// inputs are invalid.
+func ParseFlakyTestsQuery(r *http.Request) (FlakyTestsQuery, error) {
+	if err := r.ParseForm(); err != nil {
+		return FlakyTestsQuery{}, skerr.Wrapf(err, "parsing form")
+	}
+
+	ftq := FlakyTestsQuery{}
+	ftq.Corpus = r.FormValue("corpus")
+	if ftq.Corpus == "" {
+		return FlakyTestsQuery{}, skerr.Fmt("must include corpus")
+	}
+
+	if r.FormValue("include_ignored_traces") == "true" {
+		ftq.IgnoreState = types.IncludeIgnoredTraces
+	} else {
+		ftq.IgnoreState = types.ExcludeIgnoredTraces
+	}
+
+	ftq.Order = FlakyTestsOrderTransitionsPerCommit
+	if order := r.FormValue("sort"); order != "" {
+		ftq.Order = FlakyTestsOrder(order)
+		if !slices.Contains(AllFlakyTestsOrders, ftq.Order) {
+			return FlakyTestsQuery{}, skerr.Fmt("invalid sort %q, must be one of %v", order, AllFlakyTestsOrders)
+		}
+	}
+
+	if limit := r.FormValue("limit"); limit != "" {
+		var err error
+		ftq.Limit, err = strconv.Atoi(limit)
+		if err != nil || ftq.Limit < 0 {
+			return FlakyTestsQuery{}, skerr.Fmt("invalid limit %q", limit)
+		}
+	}
+	return ftq, nil
+}
+
+// FlakyTestSummary describes how much the digests produced by a test changed over the commits in
+// the current window.
+type FlakyTestSummary struct {
+	Grouping paramtools.Params `json:"grouping"`
+	// Traces is the number of traces of this test with data in the window.
+	Traces int `json:"traces"`
+	// UniqueDigests is the number of distinct digests produced by all traces of this test.
+	UniqueDigests int `json:"unique_digests"`
+	// CommitsWithData is the number of commits in the window at which this test produced data.
+	CommitsWithData int `json:"commits_with_data"`
+	// Transitions is the number of times, summed over all traces, that a trace produced a
+	// different digest than at its previous data point.
+	Transitions int `json:"transitions"`
+	// ChurnRate is Transitions divided by the number of pairs of consecutive data points, i.e. the
+	// probability of a trace producing a new digest from one data point to the next.
+	ChurnRate float64 `json:"churn_rate"`
+	// TransitionsPerCommit is Transitions divided by CommitsWithData.
+	TransitionsPerCommit float64 `json:"transitions_per_commit"`
+}
+
+// FlakyTestsResponse is the response for /json/v1/flaky.
+type FlakyTestsResponse struct {
+	Tests []FlakyTestSummary `json:"tests"`
+}
+
+// DigestOccurrencesQuery encapsulates the inputs to DigestOccurrencesHandler.
+type DigestOccurrencesQuery struct {
+	Digest types.Digest
+	// IncludeChangelists indicates to also search the data produced by the most recently updated
+	// changelists.
+	IncludeChangelists bool
+}
+
+// ParseDigestOccurrencesQuery returns a DigestOccurrencesQuery by parsing the given request or
+// error if the inputs are invalid.
+func ParseDigestOccurrencesQuery(r *http.Request) (DigestOccurrencesQuery, error) {
+	if err := r.ParseForm(); err != nil {
+		return DigestOccurrencesQuery{}, skerr.Wrapf(err, "parsing form")
+	}
+
+	doq := DigestOccurrencesQuery{}
+	doq.Digest = types.Digest(r.FormValue("digest"))
+	if !validation.IsValidDigest(string(doq.Digest)) {
+		return DigestOccurrencesQuery{}, skerr.Fmt("invalid digest %q", doq.Digest)
+	}
+	doq.IncludeChangelists = r.FormValue("include_changelists") == "true"
+	return doq, nil
+}
+
+// TraceOccurrence describes a trace on the primary branch which produced a given digest.
+type TraceOccurrence struct {
+	TraceID  string            `json:"trace_id"`
+	Grouping paramtools.Params `json:"grouping"`
+	Params   paramtools.Params `json:"params"`
+	// Occurrences is the number of commits at which the trace produced the digest.
+	Occurrences int `json:"occurrences"`
+	// FirstCommit and LastCommit are the first and last commits at which the trace produced the
+	// digest. Only the ID is set for commits which do not correspond to a git commit.
+	FirstCommit Commit `json:"first_commit"`
+	LastCommit  Commit `json:"last_commit"`
+	// AtHead is true if the digest is the most recent one produced by the trace.
+	AtHead bool `json:"at_head"`
+	// Ignored is true if the trace matches an ignore rule.
+	Ignored bool `json:"ignored"`
+}
+
+// ChangelistOccurrence describes a test which produced a given digest at a patchset of a
+// changelist.
+type ChangelistOccurrence struct {
+	System        string            `json:"system"`
+	ChangelistID  string            `json:"changelist_id"`
+	ChangelistURL string            `json:"cl_url"`
+	Owner         string            `json:"owner"`
+	Subject       string            `json:"subject"`
+	PatchsetID    string            `json:"patchset_id"`
+	PatchsetOrder int               `json:"patchset_order"`
+	Grouping      paramtools.Params `json:"grouping"`
+	// Traces is the number of traces of this test which produced the digest at this patchset.
+	Traces int `json:"traces"`
+}
+
+// DigestOccurrencesResponse is the response for /json/v1/digest/occurrences.
+type DigestOccurrencesResponse struct {
+	Digest types.Digest `json:"digest"`
+	// Traces are sorted by test, then by the most recent occurrence first.
+	Traces []TraceOccurrence `json:"traces"`
+	// Changelists are sorted by the most recently updated changelist first. It is empty unless
+	// changelists were included in the query.
+	Changelists []ChangelistOccurrence `json:"changelists"`
+}
+
 // SearchResponse is the structure returned by the Search(...) function of SearchAPI and intended
 // to be returned as JSON in an HTTP response.
 type SearchResponse struct {
@@ -364,6 +566,25 @@ type SearchResponse struct {
 	// contains the information necessary to create a TriageDelta that can be used in a bulk triage
 	// operation.
 	BulkTriageDeltaInfos []BulkTriageDeltaInfo `json:"bulk_triage_delta_infos" go2ts:"ignorenil"`
+	// NextCursor can be passed as the cursor parameter to fetch the next page of results. It is
+	// empty if this is the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// SearchStreamChunk is a single line of the newline-delimited JSON returned by the streaming
+// search RPC. Unlike SearchResponse, it does not include BulkTriageDeltaInfos, which would
+// require holding an entry for every matching digest in memory.
+type SearchStreamChunk struct {
+	Results []*SearchResult `json:"digests"`
+	// Size is the total number of digests that match the query. It is only set on the first
+	// chunk, along with Commits.
+	Size    int      `json:"size,omitempty"`
+	Commits []Commit `json:"commits,omitempty"`
+	// NextCursor can be passed as the cursor parameter to resume the search after this chunk,
+	// e.g. if the connection was lost. It is empty on the last chunk.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Error is set on the last chunk if the search failed part way through.
+	Error string `json:"error,omitempty"`
 }
 
 // TriageHistory represents who last triaged a certain digest for a certain test.
@@ -406,6 +627,20 @@ type SearchResult struct {
 	// ClosestRef labels the reference from RefDiffs that is the absolute closest to the primary
 	// digest.
 	ClosestRef RefClosest `json:"closestRef"` // "pos" or "neg"
+	// Suggestion is a suggested label for an untriaged primary digest, based on the triaged digests
+	// which are most similar to it. It is nil if there is no suggestion.
+	Suggestion *TriageSuggestion `json:"suggestion,omitempty"`
+}
+
+// TriageSuggestion is a label suggested for an untriaged digest.
+type TriageSuggestion struct {
+	// Label is the suggested label.
+	Label expectations.Label `json:"label"`
+	// ClosestDigest is the triaged digest most similar to the untriaged digest, whose label is
+	// being suggested.
+	ClosestDigest types.Digest `json:"closestDigest"`
+	// Confidence is a value in (0, 1], with 1 meaning most confident.
+	Confidence float32 `json:"confidence"`
 }
 
 // SRDiffDigest captures the diff information between a primary digest and the digest given here.
@@ -432,6 +667,10 @@ type SRDiffDigest struct {
 	// DimDiffer is true if the dimensions between the two images are different.
 	DimDiffer bool `json:"dimDiffer"`
 
+	// Negligible is true if the diff falls within the thresholds configured for the corpus, that
+	// is, if the two images can be considered the same for triaging purposes.
+	Negligible bool `json:"negligible,omitempty"`
+
 	// Digest identifies which image we are comparing the primary digest to. Put another way, what
 	// is the image on the right side of the comparison.
 	Digest types.Digest `json:"digest"`
@@ -652,6 +891,65 @@ type BaselineV2Response struct {
 	CodeReviewSystem string `json:"crs,omitempty"`
 }
 
+// BaselineDiffResponse is the difference between the baseline of the master branch and the
+// baseline of a CL, i.e. what a client would get by comparing the two BaselineV2Responses.
+type BaselineDiffResponse struct {
+	// Added are the digests which are positive or negative on the CL but untriaged on the master
+	// branch, with their label on the CL.
+	Added expectations.Baseline `json:"added"`
+
+	// Changed are the digests which are positive on one branch and negative on the other, with
+	// their label on the CL.
+	Changed expectations.Baseline `json:"changed"`
+
+	// Removed are the digests which are positive or negative on the master branch but have been
+	// triaged as untriaged on the CL, with their label on the master branch.
+	Removed expectations.Baseline `json:"removed"`
+
+	// ChangelistID is the Gerrit or GitHub issue id of the CL.
+	ChangelistID string `json:"cl_id"`
+
+	// CodeReviewSystem is the CRS system of the CL (e.g. "gerrit", "github").
+	CodeReviewSystem string `json:"crs"`
+}
+
+// PatchsetRef identifies a patchset of a CL.
+type PatchsetRef struct {
+	CodeReviewSystem string `json:"crs"`
+	ChangelistID     string `json:"cl_id"`
+	PatchsetID       string `json:"ps_id"`
+	Order            int    `json:"ps_order"`
+}
+
+// PatchsetComparisonDigest is a digest produced by a test on one or both of the compared
+// patchsets.
+type PatchsetComparisonDigest struct {
+	Grouping paramtools.Params `json:"grouping"`
+	Digest   types.Digest      `json:"digest"`
+	// Label is the label of the digest according to the expectations of the CL being compared.
+	// It is empty for digests which are only produced by the base patchset.
+	Label expectations.Label `json:"label,omitempty"`
+	// BaseLabel is the label of the digest according to the expectations of the base CL. It is
+	// empty for digests which are only produced by the patchset being compared.
+	BaseLabel expectations.Label `json:"base_label,omitempty"`
+}
+
+// PatchsetComparisonResponse is the difference between the digests produced by the tryjobs of
+// two patchsets, which can belong to the same CL or to different CLs (e.g. a revert and its
+// reland). Digests produced by ignored traces are not included.
+type PatchsetComparisonResponse struct {
+	Patchset     PatchsetRef `json:"patchset"`
+	BasePatchset PatchsetRef `json:"base_patchset"`
+
+	// Added are the digests which are produced by the patchset but not by the base patchset.
+	Added []PatchsetComparisonDigest `json:"added"`
+	// Removed are the digests which are produced by the base patchset but not by the patchset.
+	Removed []PatchsetComparisonDigest `json:"removed"`
+	// Changed are the digests which are produced by both patchsets, but have a different label
+	// on each CL (e.g. because they were only triaged on one of them).
+	Changed []PatchsetComparisonDigest `json:"changed"`
+}
+
 // GUIStatus reflects the current triage status of the various corpora at head.
 type GUIStatus struct {
 	// Last commit for which data was ingested..
@@ -666,6 +964,10 @@ type GUICorpusStatus struct {
 
 	// Number of untriaged digests in HEAD.
 	UntriagedCount int `json:"untriagedCount"`
+
+	// DiffThresholds are the thresholds under which differences in this corpus are considered
+	// negligible, if any are configured.
+	DiffThresholds *diff.Thresholds `json:"diffThresholds,omitempty"`
 }
 
 type PositiveDigestsByGroupingIDResponse struct {
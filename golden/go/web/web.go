@@ -5,14 +5,15 @@ import (
 	"context"
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"image"
-	"image/png"
 	"net/http"
 	"net/url"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -41,9 +42,15 @@ import (
 	"go.skia.org/infra/go/sql/sqlutil"
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/golden/go/clstore"
+	"go.skia.org/infra/golden/go/corpusaccess"
 	"go.skia.org/infra/golden/go/diff"
 	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/expectations/audit"
+	"go.skia.org/infra/golden/go/expectations/bulk"
 	"go.skia.org/infra/golden/go/ignore"
+	"go.skia.org/infra/golden/go/image/codec"
+	"go.skia.org/infra/golden/go/image/pyramid"
+	"go.skia.org/infra/golden/go/mirror"
 	"go.skia.org/infra/golden/go/search"
 	search_query "go.skia.org/infra/golden/go/search/query"
 	"go.skia.org/infra/golden/go/sql"
@@ -75,6 +82,9 @@ const (
 
 	changelistSummaryCacheSize = 10000
 
+	// scaledImageCacheSize is the number of downscaled images and diffs to keep in memory.
+	scaledImageCacheSize = 2000
+
 	// RPCCallCounterMetric is the metric that should be used when counting how many times a given
 	// RPC route is called from clients.
 	RPCCallCounterMetric = "gold_rpc_call_counter"
@@ -82,6 +92,10 @@ const (
 	baselineCachePrimaryBranchEntryTTL   = 10 * time.Second
 	baselineCacheSecondaryBranchEntryTTL = time.Minute
 	baselineCacheCleanupInterval         = 10 * time.Minute
+
+	// searchStreamTimeout bounds how long a streaming search may run. It is longer than the
+	// timeout of regular searches because results are sent to the client as they are ready.
+	searchStreamTimeout = 15 * time.Minute
 )
 
 type validateFields int
@@ -102,6 +116,18 @@ type HandlersConfig struct {
 	Search2API                search.API
 	WindowSize                int
 	GroupingParamKeysByCorpus map[string][]string
+	// ServeStoredDiffImages indicates to serve diff images from GCS if the diffcalculator stored
+	// one for the requested pair of digests.
+	ServeStoredDiffImages bool
+	// ServeThumbnails indicates to serve downscaled images from the thumbnails precomputed by the
+	// diffcalculator, if it stored one for the requested digest and size.
+	ServeThumbnails bool
+	// CorpusAccess restricts who may view and triage some corpora. If nil, anybody who may use
+	// this instance may view and triage all corpora.
+	CorpusAccess *corpusaccess.Checker
+	// PublicMirror, if set, serves the sanitized data of the publicly viewable traces to the public
+	// views of this instance.
+	PublicMirror *mirror.Publisher
 }
 
 // Handlers represents all the handlers (e.g. JSON endpoints) of Gold.
@@ -113,8 +139,9 @@ type Handlers struct {
 	anonymousCheapQuota     *rate.Limiter
 	anonymousGerritQuota    *rate.Limiter
 
-	clSummaryCache *lru.Cache
-	baselineCache  *ttlcache.Cache
+	clSummaryCache   *lru.Cache
+	baselineCache    *ttlcache.Cache
+	scaledImageCache *lru.Cache
 
 	statusCache      frontend.GUIStatus
 	statusCacheMutex sync.RWMutex
@@ -125,6 +152,11 @@ type Handlers struct {
 	knownHashesMutex sync.RWMutex
 	knownHashesCache string
 
+	// mirroredBaseline is the primary branch baseline mirrored from the authoritative instance, if
+	// this is a public view which mirrors it. See SetMirroredBaseline.
+	mirroredBaseline      *frontend.BaselineV2Response
+	mirroredBaselineMutex sync.RWMutex
+
 	alogin alogin.Login
 }
 
@@ -152,6 +184,11 @@ func NewHandlers(conf HandlersConfig, val validateFields, alogin alogin.Login) (
 		return nil, skerr.Wrap(err)
 	}
 
+	scaledImageCache, err := lru.New(scaledImageCacheSize)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
 	return &Handlers{
 		HandlersConfig:          conf,
 		anonymousExpensiveQuota: rate.NewLimiter(maxAnonQPSExpensive, maxAnonBurstExpensive),
@@ -159,6 +196,7 @@ func NewHandlers(conf HandlersConfig, val validateFields, alogin alogin.Login) (
 		anonymousGerritQuota:    rate.NewLimiter(maxAnonQPSGerritPlugin, maxAnonBurstGerritPlugin),
 		clSummaryCache:          clcache,
 		baselineCache:           ttlcache.New(baselineCachePrimaryBranchEntryTTL, baselineCacheCleanupInterval),
+		scaledImageCache:        scaledImageCache,
 		alogin:                  alogin,
 	}, nil
 }
@@ -179,6 +217,57 @@ func (wh *Handlers) cheapLimitForAnonUsers(r *http.Request) error {
 	return wh.anonymousCheapQuota.Wait(r.Context())
 }
 
+// requireCorpusViewer writes a 403 error and returns false if the logged-in user may not view all
+// the given corpora.
+func (wh *Handlers) requireCorpusViewer(w http.ResponseWriter, r *http.Request, corpora ...string) bool {
+	if wh.CorpusAccess == nil {
+		return true
+	}
+	user := wh.alogin.LoggedInAs(r).String()
+	for _, corpus := range corpora {
+		if !wh.CorpusAccess.CanView(user, corpus) {
+			http.Error(w, fmt.Sprintf("You do not have access to corpus %q.", corpus), http.StatusForbidden)
+			return false
+		}
+	}
+	return true
+}
+
+// requireCorpusTriager writes a 403 error and returns false if the logged-in user may not triage
+// all the given corpora.
+func (wh *Handlers) requireCorpusTriager(w http.ResponseWriter, r *http.Request, corpora ...string) bool {
+	if wh.CorpusAccess == nil {
+		return true
+	}
+	user := wh.alogin.LoggedInAs(r).String()
+	for _, corpus := range corpora {
+		if !wh.CorpusAccess.CanTriage(user, corpus) {
+			http.Error(w, fmt.Sprintf("You are not allowed to triage corpus %q.", corpus), http.StatusForbidden)
+			return false
+		}
+	}
+	return true
+}
+
+// canViewCorpus returns true if the logged-in user may view the given corpus.
+func (wh *Handlers) canViewCorpus(r *http.Request, corpus string) bool {
+	if wh.CorpusAccess == nil {
+		return true
+	}
+	return wh.CorpusAccess.CanView(wh.alogin.LoggedInAs(r).String(), corpus)
+}
+
+// hiddenCorpora returns the restricted corpora the logged-in user may not view.
+func (wh *Handlers) hiddenCorpora(r *http.Request) []string {
+	var rv []string
+	for _, corpus := range wh.CorpusAccess.RestrictedCorpora() {
+		if !wh.canViewCorpus(r, corpus) {
+			rv = append(rv, corpus)
+		}
+	}
+	return rv
+}
+
 // cheapLimitForGerritPlugin blocks using the configured rate.Limiter for queries for the
 // Gerrit Plugin.
 func (wh *Handlers) cheapLimitForGerritPlugin(r *http.Request) error {
@@ -215,6 +304,9 @@ func (wh *Handlers) ByBlameHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "did not receive value for search query", http.StatusBadRequest)
 		return
 	}
+	if !wh.requireCorpusViewer(w, r, corpus) {
+		return
+	}
 	summary, err := wh.Search2API.GetBlamesForUntriagedDigests(ctx, corpus)
 	if err != nil {
 		httputils.ReportError(w, err, "Could not compute blames", http.StatusInternalServerError)
@@ -430,6 +522,266 @@ ORDER BY Patchsets.patchset_id
 	return rv, nil
 }
 
+// ComparePatchsetsHandler returns the digests which were added, removed or changed between two
+// patchsets. The patchset being compared is identified by the "system" and "id" URL params and the
+// optional "patchset" query param, which is a patchset order and defaults to the latest patchset.
+// The base patchset is identified by the "base_crs", "base_cl" and "base_patchset" query params,
+// which default to the same CRS, the same CL and its latest patchset respectively. This lets
+// users verify a reland against the reverted CL, or a fix against an earlier patchset, without
+// triaging the same digests a second time.
+func (wh *Handlers) ComparePatchsetsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "web_ComparePatchsetsHandler")
+	defer span.End()
+	if err := wh.limitForAnonUsers(r); err != nil {
+		httputils.ReportError(w, err, "Try again later", http.StatusInternalServerError)
+		return
+	}
+	crs := chi.URLParam(r, "system")
+	if crs == "" {
+		http.Error(w, "Must specify 'system' of Changelist.", http.StatusBadRequest)
+		return
+	}
+	clID := chi.URLParam(r, "id")
+	if clID == "" {
+		http.Error(w, "Must specify 'id' of Changelist.", http.StatusBadRequest)
+		return
+	}
+	q := r.URL.Query()
+	baseCRS := q.Get("base_crs")
+	if baseCRS == "" {
+		baseCRS = crs
+	}
+	baseCLID := q.Get("base_cl")
+	if baseCLID == "" {
+		baseCLID = clID
+	}
+	if _, ok := wh.getCodeReviewSystem(crs); !ok {
+		http.Error(w, "Invalid Code Review System", http.StatusBadRequest)
+		return
+	}
+	if _, ok := wh.getCodeReviewSystem(baseCRS); !ok {
+		http.Error(w, "Invalid base Code Review System", http.StatusBadRequest)
+		return
+	}
+	psOrder, err := parsePatchsetOrder(q.Get("patchset"))
+	if err != nil {
+		http.Error(w, "Invalid patchset: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	basePSOrder, err := parsePatchsetOrder(q.Get("base_patchset"))
+	if err != nil {
+		http.Error(w, "Invalid base_patchset: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if crs == baseCRS && clID == baseCLID && psOrder == basePSOrder {
+		http.Error(w, "Must specify a different base patchset or CL.", http.StatusBadRequest)
+		return
+	}
+
+	ps, err := wh.getPatchsetRef(ctx, crs, clID, psOrder)
+	if err != nil {
+		httputils.ReportError(w, err, "Could not find patchset", http.StatusNotFound)
+		return
+	}
+	basePS, err := wh.getPatchsetRef(ctx, baseCRS, baseCLID, basePSOrder)
+	if err != nil {
+		httputils.ReportError(w, err, "Could not find base patchset", http.StatusNotFound)
+		return
+	}
+	digests, err := wh.getPatchsetDigests(ctx, ps)
+	if err != nil {
+		httputils.ReportError(w, err, "Could not get digests for patchset", http.StatusInternalServerError)
+		return
+	}
+	baseDigests, err := wh.getPatchsetDigests(ctx, basePS)
+	if err != nil {
+		httputils.ReportError(w, err, "Could not get digests for base patchset", http.StatusInternalServerError)
+		return
+	}
+	rv := comparePatchsetDigests(digests, baseDigests)
+	rv.Patchset = ps
+	rv.BasePatchset = basePS
+	rv.Added = wh.filterViewableComparisonDigests(r, rv.Added)
+	rv.Removed = wh.filterViewableComparisonDigests(r, rv.Removed)
+	rv.Changed = wh.filterViewableComparisonDigests(r, rv.Changed)
+	sendJSONResponse(w, rv)
+}
+
+// filterViewableComparisonDigests removes the digests in corpora the logged-in user may not view.
+func (wh *Handlers) filterViewableComparisonDigests(r *http.Request, digests []frontend.PatchsetComparisonDigest) []frontend.PatchsetComparisonDigest {
+	rv := digests[:0]
+	for _, d := range digests {
+		if wh.canViewCorpus(r, d.Grouping[types.CorpusField]) {
+			rv = append(rv, d)
+		}
+	}
+	return rv
+}
+
+// parsePatchsetOrder parses the given patchset order. The empty string is parsed as 0, which
+// stands for the latest patchset.
+func parsePatchsetOrder(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	order, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, skerr.Wrap(err)
+	}
+	if order <= 0 {
+		return 0, skerr.Fmt("must be positive, got %d", order)
+	}
+	return order, nil
+}
+
+// getPatchsetRef returns the patchset of the given CL with the given order, or the latest patchset
+// if order is 0.
+func (wh *Handlers) getPatchsetRef(ctx context.Context, crs, clID string, order int) (frontend.PatchsetRef, error) {
+	ctx, span := trace.StartSpan(ctx, "getPatchsetRef")
+	defer span.End()
+	rv := frontend.PatchsetRef{
+		CodeReviewSystem: crs,
+		ChangelistID:     clID,
+	}
+	if order == 0 {
+		qPSID, latestOrder, err := wh.getLatestPatchset(ctx, crs, clID)
+		if err != nil {
+			return frontend.PatchsetRef{}, skerr.Wrapf(err, "getting latest patchset of CL %s/%s", crs, clID)
+		}
+		rv.PatchsetID = sql.Unqualify(qPSID)
+		rv.Order = latestOrder
+		return rv, nil
+	}
+	const statement = `SELECT patchset_id FROM Patchsets
+WHERE changelist_id = $1 AND ps_order = $2
+ORDER BY created_ts DESC
+LIMIT 1`
+	var qPSID string
+	if err := wh.DB.QueryRow(ctx, statement, sql.Qualify(crs, clID), order).Scan(&qPSID); err != nil {
+		return frontend.PatchsetRef{}, skerr.Wrapf(err, "getting patchset %d of CL %s/%s", order, crs, clID)
+	}
+	rv.PatchsetID = sql.Unqualify(qPSID)
+	rv.Order = order
+	return rv, nil
+}
+
+// patchsetDigest identifies a digest produced by a grouping (i.e. a test).
+type patchsetDigest struct {
+	groupingID schema.MD5Hash
+	digest     types.Digest
+}
+
+// labeledDigest is a digest produced by a patchset, along with its grouping and its label
+// according to the expectations of the patchset's CL.
+type labeledDigest struct {
+	grouping paramtools.Params
+	label    expectations.Label
+}
+
+// getPatchsetDigests returns the digests produced by the non-ignored traces of the given patchset,
+// labeled according to the expectations of its CL.
+func (wh *Handlers) getPatchsetDigests(ctx context.Context, ps frontend.PatchsetRef) (map[patchsetDigest]labeledDigest, error) {
+	ctx, span := trace.StartSpan(ctx, "getPatchsetDigests")
+	defer span.End()
+
+	const statement = `WITH
+DataFromPS AS (
+    SELECT DISTINCT SecondaryBranchValues.grouping_id, digest
+    FROM SecondaryBranchValues
+    JOIN Traces ON SecondaryBranchValues.secondary_branch_trace_id = Traces.trace_id
+        AND matches_any_ignore_rule = FALSE
+    WHERE branch_name = $1 AND version_name = $2
+),
+ExpectationsForCL AS (
+    SELECT grouping_id, digest, label
+    FROM SecondaryBranchExpectations
+    WHERE branch_name = $1
+)
+SELECT DataFromPS.grouping_id, Groupings.keys, DataFromPS.digest,
+    COALESCE(ExpectationsForCL.label, Expectations.label, 'u')
+FROM DataFromPS
+JOIN Groupings ON DataFromPS.grouping_id = Groupings.grouping_id
+LEFT JOIN ExpectationsForCL ON DataFromPS.grouping_id = ExpectationsForCL.grouping_id
+    AND DataFromPS.digest = ExpectationsForCL.digest
+LEFT JOIN Expectations ON DataFromPS.grouping_id = Expectations.grouping_id
+    AND DataFromPS.digest = Expectations.digest`
+
+	qCLID := sql.Qualify(ps.CodeReviewSystem, ps.ChangelistID)
+	qPSID := sql.Qualify(ps.CodeReviewSystem, ps.PatchsetID)
+	rows, err := wh.DB.Query(ctx, statement, qCLID, qPSID)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "getting digests for patchset %s", qPSID)
+	}
+	defer rows.Close()
+	rv := map[patchsetDigest]labeledDigest{}
+	for rows.Next() {
+		var groupingID schema.GroupingID
+		var keys paramtools.Params
+		var digest schema.DigestBytes
+		var label schema.ExpectationLabel
+		if err := rows.Scan(&groupingID, &keys, &digest, &label); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		rv[patchsetDigest{
+			groupingID: sql.AsMD5Hash(groupingID),
+			digest:     types.Digest(hex.EncodeToString(digest)),
+		}] = labeledDigest{
+			grouping: keys,
+			label:    label.ToExpectation(),
+		}
+	}
+	return rv, nil
+}
+
+// comparePatchsetDigests returns the digests which were added, removed or had their label changed
+// going from the base digests to the given digests. Each list is sorted by grouping and digest.
+func comparePatchsetDigests(digests, baseDigests map[patchsetDigest]labeledDigest) frontend.PatchsetComparisonResponse {
+	rv := frontend.PatchsetComparisonResponse{
+		Added:   []frontend.PatchsetComparisonDigest{},
+		Removed: []frontend.PatchsetComparisonDigest{},
+		Changed: []frontend.PatchsetComparisonDigest{},
+	}
+	for pd, ld := range digests {
+		base, ok := baseDigests[pd]
+		if !ok {
+			rv.Added = append(rv.Added, frontend.PatchsetComparisonDigest{
+				Grouping: ld.grouping,
+				Digest:   pd.digest,
+				Label:    ld.label,
+			})
+		} else if base.label != ld.label {
+			rv.Changed = append(rv.Changed, frontend.PatchsetComparisonDigest{
+				Grouping:  ld.grouping,
+				Digest:    pd.digest,
+				Label:     ld.label,
+				BaseLabel: base.label,
+			})
+		}
+	}
+	for pd, base := range baseDigests {
+		if _, ok := digests[pd]; !ok {
+			rv.Removed = append(rv.Removed, frontend.PatchsetComparisonDigest{
+				Grouping:  base.grouping,
+				Digest:    pd.digest,
+				BaseLabel: base.label,
+			})
+		}
+	}
+	for _, list := range [][]frontend.PatchsetComparisonDigest{rv.Added, rv.Removed, rv.Changed} {
+		sort.Slice(list, func(i, j int) bool {
+			gi, gj := list[i].Grouping, list[j].Grouping
+			if gi[types.CorpusField] != gj[types.CorpusField] {
+				return gi[types.CorpusField] < gj[types.CorpusField]
+			}
+			if gi[types.PrimaryKeyField] != gj[types.PrimaryKeyField] {
+				return gi[types.PrimaryKeyField] < gj[types.PrimaryKeyField]
+			}
+			return list[i].Digest < list[j].Digest
+		})
+	}
+	return rv
+}
+
 // SearchHandler searches the data in the new SQL backend. It times out after 3 minutes, to prevent
 // outstanding requests from growing unbounded.
 func (wh *Handlers) SearchHandler(w http.ResponseWriter, r *http.Request) {
@@ -443,6 +795,9 @@ func (wh *Handlers) SearchHandler(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	if !wh.requireCorpusViewer(w, r, q.TraceValues[types.CorpusField]...) {
+		return
+	}
 	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Minute)
 	defer cancel()
 	ctx, span := trace.StartSpan(ctx, "web_SearchHandler", trace.WithSampler(trace.AlwaysSample()))
@@ -456,6 +811,59 @@ func (wh *Handlers) SearchHandler(w http.ResponseWriter, r *http.Request) {
 	sendJSONResponse(w, searchResponse)
 }
 
+// SearchStreamHandler is like SearchHandler, but returns every result matching the query, starting
+// at the given "cursor" or "offset", as newline-delimited JSON. Each line is a
+// frontend.SearchStreamChunk of at most "limit" results, which is written as soon as it is ready,
+// so searches matching a very large number of digests neither need to be held in memory at once
+// nor hit a timeout before anything is returned. If the search fails part way through, the last
+// chunk contains the error; clients can resume from the cursor of the last chunk they received.
+func (wh *Handlers) SearchStreamHandler(w http.ResponseWriter, r *http.Request) {
+	defer metrics2.FuncTimer().Stop()
+	if err := wh.limitForAnonUsers(r); err != nil {
+		httputils.ReportError(w, err, "Try again later", http.StatusInternalServerError)
+		return
+	}
+
+	q, ok := parseSearchQuery(w, r)
+	if !ok {
+		return
+	}
+	if !wh.requireCorpusViewer(w, r, q.TraceValues[types.CorpusField]...) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), searchStreamTimeout)
+	defer cancel()
+	ctx, span := trace.StartSpan(ctx, "web_SearchStreamHandler", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	wroteChunk := false
+	err := wh.Search2API.SearchStream(ctx, q, func(chunk *frontend.SearchStreamChunk) error {
+		wroteChunk = true
+		if err := enc.Encode(chunk); err != nil {
+			return skerr.Wrapf(err, "writing search results")
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		return
+	}
+	if !wroteChunk {
+		httputils.ReportError(w, err, "Search for digests failed in the SQL backend.", http.StatusInternalServerError)
+		return
+	}
+	// The status code has already been sent, so report the error in a final chunk.
+	sklog.Errorf("Streaming search failed: %s", err)
+	if err := enc.Encode(frontend.SearchStreamChunk{Error: "Search for digests failed in the SQL backend."}); err != nil {
+		sklog.Warningf("Could not write error chunk: %s", err)
+	}
+}
+
 // parseSearchQuery extracts the search query from request.
 func parseSearchQuery(w http.ResponseWriter, r *http.Request) (*search_query.Search, bool) {
 	q := search_query.Search{Limit: 50}
@@ -473,6 +881,217 @@ func parseSearchQuery(w http.ResponseWriter, r *http.Request) (*search_query.Sea
 	return &q, true
 }
 
+// TriageQueueHandler returns an ordered page of the untriaged digests matching a search query, so
+// that the details page can step through them with next/previous keyboard shortcuts. It accepts
+// the same query parameters as the search RPC (though only untriaged digests are ever returned),
+// plus "order" (one of frontend.AllTriageQueueOrders), "cursor" (as returned in a previous
+// response), and "limit" for the page size.
+func (wh *Handlers) TriageQueueHandler(w http.ResponseWriter, r *http.Request) {
+	defer metrics2.FuncTimer().Stop()
+	if err := wh.limitForAnonUsers(r); err != nil {
+		httputils.ReportError(w, err, "Try again later", http.StatusInternalServerError)
+		return
+	}
+
+	// The cursor is validated first, as parseSearchQuery would reject it as an internal error.
+	offset, err := parseTriageQueueCursor(r.FormValue("cursor"))
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid cursor", http.StatusBadRequest)
+		return
+	}
+	q, ok := parseSearchQuery(w, r)
+	if !ok {
+		return
+	}
+	if !wh.requireCorpusViewer(w, r, q.TraceValues[types.CorpusField]...) {
+		return
+	}
+	order := frontend.TriageQueueOrder(r.FormValue("order"))
+	if order == "" {
+		order = frontend.TriageQueueOrderCluster
+	}
+	if !util.In(string(order), triageQueueOrderStrings()) {
+		http.Error(w, fmt.Sprintf("Invalid order %q", order), http.StatusBadRequest)
+		return
+	}
+	pageSize := q.Limit
+	if pageSize <= 0 {
+		http.Error(w, "limit must be positive", http.StatusBadRequest)
+		return
+	}
+	corpora := q.TraceValues[types.CorpusField]
+	if order == frontend.TriageQueueOrderBlame && len(corpora) != 1 {
+		http.Error(w, "Exactly one corpus must be specified to order by blame", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Minute)
+	defer cancel()
+	ctx, span := trace.StartSpan(ctx, "web_TriageQueueHandler", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+
+	// Fetch the whole queue, so it can be ordered before it is paginated.
+	q.IncludeUntriagedDigests = true
+	q.IncludePositiveDigests = false
+	q.IncludeNegativeDigests = false
+	q.Offset = 0
+	q.Limit = 0
+	searchResponse, err := wh.Search2API.Search(ctx, q)
+	if err != nil {
+		httputils.ReportError(w, err, "Search for untriaged digests failed.", http.StatusInternalServerError)
+		return
+	}
+
+	var blameRanks map[frontend.TriageQueueEntry]int
+	if order == frontend.TriageQueueOrderBlame {
+		blameRanks, err = wh.getBlameRanks(ctx, corpora[0], q)
+		if err != nil {
+			httputils.ReportError(w, err, "Could not compute blames", http.StatusInternalServerError)
+			return
+		}
+	}
+	entries := orderTriageQueue(searchResponse.Results, order, blameRanks)
+
+	resp := frontend.TriageQueueResponse{
+		Entries: []frontend.TriageQueueEntry{},
+		Total:   len(entries),
+	}
+	if offset < len(entries) {
+		end := util.MinInt(len(entries), offset+pageSize)
+		resp.Entries = entries[offset:end]
+		if end < len(entries) {
+			resp.NextCursor = strconv.Itoa(end)
+		}
+	}
+	if offset > 0 {
+		resp.PrevCursor = strconv.Itoa(util.MaxInt(0, util.MinInt(offset, len(entries))-pageSize))
+	}
+	sendJSONResponse(w, resp)
+}
+
+// triageQueueOrderStrings returns frontend.AllTriageQueueOrders as strings.
+func triageQueueOrderStrings() []string {
+	rv := make([]string, 0, len(frontend.AllTriageQueueOrders))
+	for _, o := range frontend.AllTriageQueueOrders {
+		rv = append(rv, string(o))
+	}
+	return rv
+}
+
+// parseTriageQueueCursor returns the offset into the triage queue encoded by the given cursor. An
+// empty cursor refers to the start of the queue.
+func parseTriageQueueCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(cursor)
+	if err != nil {
+		return 0, skerr.Wrapf(err, "parsing cursor %q", cursor)
+	}
+	if offset < 0 {
+		return 0, skerr.Fmt("cursor %q must not be negative", cursor)
+	}
+	return offset, nil
+}
+
+// getBlameRanks returns the index of the blame range (as returned by
+// GetBlamesForUntriagedDigests) which is believed to have introduced each untriaged digest
+// in the given corpus matching the given query. Digests which could not be blamed are not included.
+func (wh *Handlers) getBlameRanks(ctx context.Context, corpus string, q *search_query.Search) (map[frontend.TriageQueueEntry]int, error) {
+	summary, err := wh.Search2API.GetBlamesForUntriagedDigests(ctx, corpus)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	rv := map[frontend.TriageQueueEntry]int{}
+	for i, br := range summary.Ranges {
+		blameQuery := *q
+		blameQuery.BlameGroupID = br.CommitRange
+		// We only need the bulk triage infos, which cover every match regardless of the limit.
+		blameQuery.Limit = 1
+		resp, err := wh.Search2API.Search(ctx, &blameQuery)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "searching for blame %q", br.CommitRange)
+		}
+		for _, info := range resp.BulkTriageDeltaInfos {
+			e := frontend.TriageQueueEntry{
+				Test:   types.TestName(info.Grouping[types.PrimaryKeyField]),
+				Digest: info.Digest,
+			}
+			if _, ok := rv[e]; !ok {
+				rv[e] = i
+			}
+		}
+	}
+	return rv, nil
+}
+
+// orderTriageQueue returns the untriaged digests in the given search results in the given order.
+// blameRanks is only used when ordering by blame.
+func orderTriageQueue(results []*frontend.SearchResult, order frontend.TriageQueueOrder, blameRanks map[frontend.TriageQueueEntry]int) []frontend.TriageQueueEntry {
+	closest := func(sr *frontend.SearchResult) *frontend.SRDiffDigest {
+		if sr.ClosestRef == frontend.NoRef {
+			return nil
+		}
+		return sr.RefDiffs[sr.ClosestRef]
+	}
+	untriaged := make([]*frontend.SearchResult, 0, len(results))
+	for _, sr := range results {
+		if sr.Status == expectations.Untriaged {
+			untriaged = append(untriaged, sr)
+		}
+	}
+	// Ties are always broken by test name and then digest, so the order is stable between calls.
+	byTestAndDigest := func(a, b *frontend.SearchResult) bool {
+		if a.Test != b.Test {
+			return a.Test < b.Test
+		}
+		return a.Digest < b.Digest
+	}
+	sort.SliceStable(untriaged, func(i, j int) bool {
+		a, b := untriaged[i], untriaged[j]
+		switch order {
+		case frontend.TriageQueueOrderDiff:
+			ca, cb := closest(a), closest(b)
+			if (ca == nil) != (cb == nil) {
+				return cb == nil
+			}
+			if ca != nil && ca.CombinedMetric != cb.CombinedMetric {
+				return ca.CombinedMetric > cb.CombinedMetric
+			}
+		case frontend.TriageQueueOrderBlame:
+			ra, okA := blameRanks[frontend.TriageQueueEntry{Test: a.Test, Digest: a.Digest}]
+			rb, okB := blameRanks[frontend.TriageQueueEntry{Test: b.Test, Digest: b.Digest}]
+			if okA != okB {
+				return okA
+			}
+			if ra != rb {
+				return ra < rb
+			}
+		case frontend.TriageQueueOrderCluster:
+			if a.Test != b.Test {
+				return a.Test < b.Test
+			}
+			var refA, refB types.Digest
+			if ca := closest(a); ca != nil {
+				refA = ca.Digest
+			}
+			if cb := closest(b); cb != nil {
+				refB = cb.Digest
+			}
+			if refA != refB {
+				return refA < refB
+			}
+		}
+		return byTestAndDigest(a, b)
+	})
+
+	rv := make([]frontend.TriageQueueEntry, 0, len(untriaged))
+	for _, sr := range untriaged {
+		rv = append(rv, frontend.TriageQueueEntry{Test: sr.Test, Digest: sr.Digest})
+	}
+	return rv
+}
+
 // DetailsHandler returns the details about a single digest.
 func (wh *Handlers) DetailsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "web_DetailsHandler", trace.WithSampler(trace.AlwaysSample()))
@@ -504,6 +1123,10 @@ func (wh *Handlers) DetailsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if !wh.requireCorpusViewer(w, r, req.Grouping[types.CorpusField]) {
+		return
+	}
+
 	ret, err := wh.Search2API.GetDigestDetails(ctx, req.Grouping, types.Digest(req.Digest), req.ChangelistID, req.CodeReviewSystem)
 	if err != nil {
 		httputils.ReportError(w, err, "Unable to get digest details.", http.StatusInternalServerError)
@@ -561,6 +1184,10 @@ func (wh *Handlers) DiffHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if !wh.requireCorpusViewer(w, r, req.Grouping[types.CorpusField]) {
+		return
+	}
+
 	ret, err := wh.Search2API.GetDigestsDiff(ctx, req.Grouping, req.LeftDigest, req.RightDigest, req.ChangelistID, req.CodeReviewSystem)
 	if err != nil {
 		httputils.ReportError(w, err, "Unable to get diff for digests.", http.StatusInternalServerError)
@@ -817,6 +1444,21 @@ func (wh *Handlers) TriageHandlerV2(w http.ResponseWriter, r *http.Request) {
 	}
 	sklog.Infof("Triage v2 request: %#v", req)
 
+	if wh.CorpusAccess != nil {
+		var corpora []string
+		for test := range req.TestDigestStatus {
+			grouping, err := wh.getGroupingForTest(ctx, string(test))
+			if err != nil {
+				httputils.ReportError(w, err, "Could not triage", http.StatusInternalServerError)
+				return
+			}
+			corpora = append(corpora, grouping[types.CorpusField])
+		}
+		if !wh.requireCorpusTriager(w, r, corpora...) {
+			return
+		}
+	}
+
 	if err := wh.triage2(ctx, user.String(), req); err != nil {
 		httputils.ReportError(w, err, "Could not triage", http.StatusInternalServerError)
 		return
@@ -852,7 +1494,7 @@ func (wh *Handlers) triage2(ctx context.Context, userID string, req frontend.Tri
 	return util.ChunkIter(len(allDeltas), maxTriageBatchSize, func(startIdx int, endIdx int) error {
 		deltas := allDeltas[startIdx:endIdx]
 		err = crdbpgx.ExecuteTx(ctx, wh.DB, pgx.TxOptions{}, func(tx pgx.Tx) error {
-			newRecordID, err := writeRecord(ctx, tx, userID, len(deltas), branch)
+			newRecordID, err := writeRecord(ctx, tx, userID, len(deltas), branch, "")
 			if err != nil {
 				return err
 			}
@@ -988,6 +1630,14 @@ func (wh *Handlers) TriageHandlerV3(w http.ResponseWriter, r *http.Request) {
 	}
 	sklog.Infof("Triage v3 request: %#v", req)
 
+	corpora := make([]string, 0, len(req.Deltas))
+	for _, delta := range req.Deltas {
+		corpora = append(corpora, delta.Grouping[types.CorpusField])
+	}
+	if !wh.requireCorpusTriager(w, r, corpora...) {
+		return
+	}
+
 	res, err := wh.triage3(ctx, user.String(), req)
 	if err != nil {
 		httputils.ReportError(w, err, "Could not triage", http.StatusInternalServerError)
@@ -1001,9 +1651,12 @@ func (wh *Handlers) triage3(ctx context.Context, userID string, req frontend.Tri
 	ctx, span := trace.StartSpan(ctx, "triage3")
 	defer span.End()
 
-	branch := ""
+	branch, patchset := "", ""
 	if req.ChangelistID != "" && req.CodeReviewSystem != "" {
 		branch = sql.Qualify(req.CodeReviewSystem, req.ChangelistID)
+		if req.PatchsetID != "" {
+			patchset = sql.Qualify(req.CodeReviewSystem, req.PatchsetID)
+		}
 
 		// We disallow changes on closed CLs to avoid confusion (skbug.com/12122).
 		const statement = "SELECT status FROM Changelists WHERE changelist_id = $1"
@@ -1043,7 +1696,7 @@ func (wh *Handlers) triage3(ctx context.Context, userID string, req frontend.Tri
 				// their expected value. This error is handled outside of the transaction.
 				return err
 			}
-			newRecordID, err := writeRecord(ctx, tx, userID, len(deltas), branch)
+			newRecordID, err := writeRecord(ctx, tx, userID, len(deltas), branch, patchset)
 			if err != nil {
 				return err
 			}
@@ -1468,64 +2121,146 @@ func (wh *Handlers) ClusterDiffHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q, err := parseClusterDiffQuery(r)
+	q, err := parseClusterDiffQuery(r)
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid requrest", http.StatusBadRequest)
+		return
+	}
+
+	testNames, ok := q.Filters[types.PrimaryKeyField]
+	if !ok || len(testNames) == 0 {
+		http.Error(w, "Must include test name", http.StatusBadRequest)
+		return
+	}
+	if !wh.requireCorpusViewer(w, r, q.Corpus) {
+		return
+	}
+	leftGrouping := paramtools.Params{
+		types.CorpusField:     q.Corpus,
+		types.PrimaryKeyField: testNames[0],
+	}
+	delete(q.Filters, types.PrimaryKeyField)
+	clusterOpts := search.ClusterOptions{
+		Grouping:                leftGrouping,
+		Filters:                 q.Filters,
+		IncludePositiveDigests:  q.IncludePositiveDigests,
+		IncludeNegativeDigests:  q.IncludeNegativeDigests,
+		IncludeUntriagedDigests: q.IncludeUntriagedDigests,
+
+		CodeReviewSystem: q.CodeReviewSystemID,
+		ChangelistID:     q.ChangelistID,
+		PatchsetID:       q.PatchsetID,
+	}
+	clusterResp, err := wh.Search2API.GetCluster(ctx, clusterOpts)
+	if err != nil {
+		httputils.ReportError(w, err, "Unable to compute cluster.", http.StatusInternalServerError)
+		return
+	}
+	sendJSONResponse(w, clusterResp)
+}
+
+// ListTestsHandler returns all the tests in the given corpus and a count of how many digests
+// have been seen for that.
+func (wh *Handlers) ListTestsHandler(w http.ResponseWriter, r *http.Request) {
+	defer metrics2.FuncTimer().Stop()
+	ctx, span := trace.StartSpan(r.Context(), "web_ListTestsHandler", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+	if err := wh.limitForAnonUsers(r); err != nil {
+		httputils.ReportError(w, err, "Try again later", http.StatusInternalServerError)
+		return
+	}
+	// Inputs: (head, ignored, corpus, keys)
+	q, err := frontend.ParseListTestsQuery(r)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to parse form data.", http.StatusBadRequest)
+		return
+	}
+
+	if !wh.requireCorpusViewer(w, r, q.Corpus) {
+		return
+	}
+
+	counts, err := wh.Search2API.CountDigestsByTest(ctx, q)
+	if err != nil {
+		httputils.ReportError(w, err, "Could not compute query.", http.StatusInternalServerError)
+		return
+	}
+	sendJSONResponse(w, counts)
+}
+
+// FlakyTestsHandler returns the tests of a corpus sorted by how much their digests changed over
+// the commits in the current window, flakiest first.
+func (wh *Handlers) FlakyTestsHandler(w http.ResponseWriter, r *http.Request) {
+	defer metrics2.FuncTimer().Stop()
+	ctx, span := trace.StartSpan(r.Context(), "web_FlakyTestsHandler", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+	if err := wh.limitForAnonUsers(r); err != nil {
+		httputils.ReportError(w, err, "Try again later", http.StatusInternalServerError)
+		return
+	}
+	q, err := frontend.ParseFlakyTestsQuery(r)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to parse form data.", http.StatusBadRequest)
+		return
+	}
+
+	if !wh.requireCorpusViewer(w, r, q.Corpus) {
+		return
+	}
+
+	resp, err := wh.Search2API.GetFlakyTests(ctx, q)
 	if err != nil {
-		httputils.ReportError(w, err, "Invalid requrest", http.StatusBadRequest)
+		httputils.ReportError(w, err, "Could not compute flakiness.", http.StatusInternalServerError)
 		return
 	}
+	sendJSONResponse(w, resp)
+}
 
-	testNames, ok := q.Filters[types.PrimaryKeyField]
-	if !ok || len(testNames) == 0 {
-		http.Error(w, "Must include test name", http.StatusBadRequest)
+// filterViewableOccurrences removes the occurrences in corpora the logged-in user may not view.
+func (wh *Handlers) filterViewableOccurrences(r *http.Request, resp *frontend.DigestOccurrencesResponse) {
+	if wh.CorpusAccess == nil {
 		return
 	}
-	leftGrouping := paramtools.Params{
-		types.CorpusField:     q.Corpus,
-		types.PrimaryKeyField: testNames[0],
-	}
-	delete(q.Filters, types.PrimaryKeyField)
-	clusterOpts := search.ClusterOptions{
-		Grouping:                leftGrouping,
-		Filters:                 q.Filters,
-		IncludePositiveDigests:  q.IncludePositiveDigests,
-		IncludeNegativeDigests:  q.IncludeNegativeDigests,
-		IncludeUntriagedDigests: q.IncludeUntriagedDigests,
-
-		CodeReviewSystem: q.CodeReviewSystemID,
-		ChangelistID:     q.ChangelistID,
-		PatchsetID:       q.PatchsetID,
+	user := wh.alogin.LoggedInAs(r).String()
+	traces := resp.Traces[:0]
+	for _, tr := range resp.Traces {
+		if wh.CorpusAccess.CanView(user, tr.Grouping[types.CorpusField]) {
+			traces = append(traces, tr)
+		}
 	}
-	clusterResp, err := wh.Search2API.GetCluster(ctx, clusterOpts)
-	if err != nil {
-		httputils.ReportError(w, err, "Unable to compute cluster.", http.StatusInternalServerError)
-		return
+	resp.Traces = traces
+	changelists := resp.Changelists[:0]
+	for _, cl := range resp.Changelists {
+		if wh.CorpusAccess.CanView(user, cl.Grouping[types.CorpusField]) {
+			changelists = append(changelists, cl)
+		}
 	}
-	sendJSONResponse(w, clusterResp)
+	resp.Changelists = changelists
 }
 
-// ListTestsHandler returns all the tests in the given corpus and a count of how many digests
-// have been seen for that.
-func (wh *Handlers) ListTestsHandler(w http.ResponseWriter, r *http.Request) {
+// DigestOccurrencesHandler returns every trace which produced a given digest, across all tests
+// and corpora, so that triagers can see where else a bad digest shows up.
+func (wh *Handlers) DigestOccurrencesHandler(w http.ResponseWriter, r *http.Request) {
 	defer metrics2.FuncTimer().Stop()
-	ctx, span := trace.StartSpan(r.Context(), "web_ListTestsHandler", trace.WithSampler(trace.AlwaysSample()))
+	ctx, span := trace.StartSpan(r.Context(), "web_DigestOccurrencesHandler", trace.WithSampler(trace.AlwaysSample()))
 	defer span.End()
 	if err := wh.limitForAnonUsers(r); err != nil {
 		httputils.ReportError(w, err, "Try again later", http.StatusInternalServerError)
 		return
 	}
-	// Inputs: (head, ignored, corpus, keys)
-	q, err := frontend.ParseListTestsQuery(r)
+	q, err := frontend.ParseDigestOccurrencesQuery(r)
 	if err != nil {
 		httputils.ReportError(w, err, "Failed to parse form data.", http.StatusBadRequest)
 		return
 	}
 
-	counts, err := wh.Search2API.CountDigestsByTest(ctx, q)
+	resp, err := wh.Search2API.GetDigestOccurrences(ctx, q)
 	if err != nil {
-		httputils.ReportError(w, err, "Could not compute query.", http.StatusInternalServerError)
+		httputils.ReportError(w, err, "Could not find occurrences of digest.", http.StatusInternalServerError)
 		return
 	}
-	sendJSONResponse(w, counts)
+	wh.filterViewableOccurrences(r, &resp)
+	sendJSONResponse(w, resp)
 }
 
 // TriageLogHandler returns what has been triaged recently.
@@ -1562,6 +2297,7 @@ func (wh *Handlers) TriageLogHandler(w http.ResponseWriter, r *http.Request) {
 		httputils.ReportError(w, err, "Unable to retrieve triage logs", http.StatusInternalServerError)
 		return
 	}
+	logEntries = wh.filterViewableTriageLog(r, logEntries)
 
 	response := frontend.TriageLogResponse{
 		Entries: logEntries,
@@ -1575,6 +2311,29 @@ func (wh *Handlers) TriageLogHandler(w http.ResponseWriter, r *http.Request) {
 	sendJSONResponse(w, response)
 }
 
+// filterViewableTriageLog removes the changes to corpora the logged-in user may not view, and the
+// entries which only changed such corpora. Note that the pagination is unaffected, so a page may
+// have fewer entries than requested.
+func (wh *Handlers) filterViewableTriageLog(r *http.Request, entries []frontend.TriageLogEntry) []frontend.TriageLogEntry {
+	if wh.CorpusAccess == nil {
+		return entries
+	}
+	rv := entries[:0]
+	for _, entry := range entries {
+		details := entry.Details[:0]
+		for _, d := range entry.Details {
+			if wh.canViewCorpus(r, d.Grouping[types.CorpusField]) {
+				details = append(details, d)
+			}
+		}
+		if len(details) > 0 {
+			entry.Details = details
+			rv = append(rv, entry)
+		}
+	}
+	return rv
+}
+
 // getTriageLog returns the specified entries and the total count of expectation records.
 func (wh *Handlers) getTriageLog(ctx context.Context, crs, clid string, offset, size int) ([]frontend.TriageLogEntry, int, error) {
 	ctx, span := trace.StartSpan(ctx, "getTriageLog2")
@@ -1689,6 +2448,17 @@ func (wh *Handlers) TriageUndoHandler(w http.ResponseWriter, r *http.Request) {
 	// Extract the id to undo.
 	changeID := r.URL.Query().Get("id")
 
+	if wh.CorpusAccess != nil {
+		corpora, err := wh.getCorporaForRecord(ctx, changeID)
+		if err != nil {
+			httputils.ReportError(w, err, "Unable to undo.", http.StatusInternalServerError)
+			return
+		}
+		if !wh.requireCorpusTriager(w, r, corpora...) {
+			return
+		}
+	}
+
 	// Do the undo procedure.
 	if err := wh.undoExpectationChanges(ctx, changeID, user.String()); err != nil {
 		httputils.ReportError(w, err, "Unable to undo.", http.StatusInternalServerError)
@@ -1699,6 +2469,31 @@ func (wh *Handlers) TriageUndoHandler(w http.ResponseWriter, r *http.Request) {
 	wh.TriageLogHandler(w, r)
 }
 
+// getCorporaForRecord returns the corpora of the digests whose expectations were changed by the
+// record with the given ID.
+func (wh *Handlers) getCorporaForRecord(ctx context.Context, recordID string) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "getCorporaForRecord")
+	defer span.End()
+	const statement = `SELECT DISTINCT Groupings.keys->>'` + types.CorpusField + `'
+FROM ExpectationDeltas
+JOIN Groupings ON ExpectationDeltas.grouping_id = Groupings.grouping_id
+WHERE expectation_record_id = $1`
+	rows, err := wh.DB.Query(ctx, statement, recordID)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "getting corpora for record %s", recordID)
+	}
+	defer rows.Close()
+	var rv []string
+	for rows.Next() {
+		var corpus string
+		if err := rows.Scan(&corpus); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		rv = append(rv, corpus)
+	}
+	return rv, nil
+}
+
 // undoExpectationChanges will look up all ExpectationDeltas associated with the record that has
 // the given ID. It will set the current expectations for those digests/groupings to be the
 // label_before value. This will all be done in a transaction.
@@ -1720,7 +2515,7 @@ func (wh *Handlers) undoExpectationChanges(ctx context.Context, recordID, userID
 			return err
 		}
 
-		newRecordID, err := writeRecord(ctx, tx, userID, len(deltas), branchOfOriginal.String)
+		newRecordID, err := writeRecord(ctx, tx, userID, len(deltas), branchOfOriginal.String, "")
 		if err != nil {
 			return err
 		}
@@ -1743,18 +2538,22 @@ func (wh *Handlers) undoExpectationChanges(ctx context.Context, recordID, userID
 	return nil
 }
 
-// writeRecord writes a new ExpectationRecord to the DB.
-func writeRecord(ctx context.Context, tx pgx.Tx, userID string, numChanges int, branch string) (uuid.UUID, error) {
+// writeRecord writes a new ExpectationRecord to the DB. The branch and patchset are qualified IDs
+// and may be empty.
+func writeRecord(ctx context.Context, tx pgx.Tx, userID string, numChanges int, branch, patchset string) (uuid.UUID, error) {
 	ctx, span := trace.StartSpan(ctx, "writeRecord")
 	defer span.End()
 
-	var br *string
+	var br, ps *string
 	if branch != "" {
 		br = &branch
 	}
+	if patchset != "" {
+		ps = &patchset
+	}
 	const statement = `INSERT INTO ExpectationRecords
-(user_name, triage_time, num_changes, branch_name) VALUES ($1, $2, $3, $4) RETURNING expectation_record_id`
-	row := tx.QueryRow(ctx, statement, userID, now.Now(ctx), numChanges, br)
+(user_name, triage_time, num_changes, branch_name, patchset_id) VALUES ($1, $2, $3, $4, $5) RETURNING expectation_record_id`
+	row := tx.QueryRow(ctx, statement, userID, now.Now(ctx), numChanges, br, ps)
 	var recordUUID uuid.UUID
 	err := row.Scan(&recordUUID)
 	if err != nil {
@@ -1852,6 +2651,136 @@ func applyDeltasToBranch(ctx context.Context, tx pgx.Tx, deltas []schema.Expecta
 	return err // don't wrap, could be retryable
 }
 
+// ExportBaselineHandler returns all triaged expectations on the primary branch for the corpus
+// given by the "corpus" parameter, as a versioned blob which can be passed to
+// ImportBaselineHandler.
+func (wh *Handlers) ExportBaselineHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "web_ExportBaselineHandler", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+	if err := wh.limitForAnonUsers(r); err != nil {
+		httputils.ReportError(w, err, "Try again later", http.StatusInternalServerError)
+		return
+	}
+	corpus := r.FormValue("corpus")
+	if corpus == "" {
+		http.Error(w, "Must specify corpus", http.StatusBadRequest)
+		return
+	}
+	if !wh.requireCorpusViewer(w, r, corpus) {
+		return
+	}
+	baseline, err := bulk.Export(ctx, wh.DB, corpus)
+	if err != nil {
+		httputils.ReportError(w, err, "Could not export baseline", http.StatusInternalServerError)
+		return
+	}
+	sendJSONResponse(w, baseline)
+}
+
+// PublicMirrorHandler returns the sanitized data of the publicly viewable traces, which the public
+// views of this instance mirror. See the mirror package for details.
+func (wh *Handlers) PublicMirrorHandler(w http.ResponseWriter, r *http.Request) {
+	if wh.PublicMirror == nil {
+		http.NotFound(w, r)
+		return
+	}
+	wh.PublicMirror.ServeHTTP(w, r)
+}
+
+// ExpectationAuditHandler returns the changes to the expectations matching the filters in the
+// request, most recent first. Unlike the triage log, it can be filtered by user, test, corpus and
+// time range, and spans all branches unless restricted to one.
+func (wh *Handlers) ExpectationAuditHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "web_ExpectationAuditHandler", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+	if err := wh.limitForAnonUsers(r); err != nil {
+		httputils.ReportError(w, err, "Try again later", http.StatusInternalServerError)
+		return
+	}
+	q, err := wh.parseAuditQuery(r)
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid audit query.", http.StatusBadRequest)
+		return
+	}
+	if q.Corpus != "" && !wh.requireCorpusViewer(w, r, q.Corpus) {
+		return
+	}
+	q.ExcludedCorpora = wh.hiddenCorpora(r)
+	page, err := audit.Search(ctx, wh.DB, q)
+	if err != nil {
+		httputils.ReportError(w, err, "Could not retrieve expectation changes", http.StatusInternalServerError)
+		return
+	}
+	sendJSONResponse(w, page)
+}
+
+// parseAuditQuery returns the audit.Query described by the parameters of the given request.
+// The begin and end parameters are RFC 3339 timestamps.
+func (wh *Handlers) parseAuditQuery(r *http.Request) (audit.Query, error) {
+	values := r.URL.Query()
+	offset, size, err := httputils.PaginationParams(values, 0, audit.DefaultLimit, audit.MaxLimit)
+	if err != nil {
+		return audit.Query{}, skerr.Wrap(err)
+	}
+	q := audit.Query{
+		User:              values.Get("user"),
+		Test:              types.TestName(values.Get("test")),
+		Corpus:            values.Get("corpus"),
+		CodeReviewSystem:  values.Get("crs"),
+		ChangelistID:      values.Get("changelist_id"),
+		PrimaryBranchOnly: values.Get("primary_branch_only") == "true",
+		Offset:            offset,
+		Limit:             size,
+	}
+	for param, t := range map[string]*time.Time{"begin": &q.Begin, "end": &q.End} {
+		if v := values.Get(param); v != "" {
+			if *t, err = time.Parse(time.RFC3339, v); err != nil {
+				return audit.Query{}, skerr.Wrapf(err, "parsing %s", param)
+			}
+		}
+	}
+	if q.CodeReviewSystem != "" {
+		if _, ok := wh.getCodeReviewSystem(q.CodeReviewSystem); !ok {
+			return audit.Query{}, skerr.Fmt("unknown crs %q", q.CodeReviewSystem)
+		}
+	}
+	return q, skerr.Wrap(q.Validate())
+}
+
+// ImportBaselineHandler applies a blob returned by ExportBaselineHandler to the primary branch and
+// returns the changes it made. If the "dry_run" parameter is "true", the changes are only reported.
+// Because an import can overwrite the triage status of a whole corpus, it is restricted to admins.
+func (wh *Handlers) ImportBaselineHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "web_ImportBaselineHandler", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+	user := wh.alogin.LoggedInAs(r)
+	if user == alogin.NotLoggedIn {
+		http.Error(w, "You must be logged in to change expectations", http.StatusUnauthorized)
+		return
+	}
+	if !wh.alogin.HasRole(r, roles.Admin) {
+		http.Error(w, "You must be logged in as an admin to import expectations", http.StatusUnauthorized)
+		return
+	}
+	var baseline bulk.Baseline
+	if err := parseJSON(r, &baseline); err != nil {
+		httputils.ReportError(w, err, "Failed to parse JSON request.", http.StatusBadRequest)
+		return
+	}
+	if err := bulk.Validate(&baseline); err != nil {
+		httputils.ReportError(w, err, "Invalid baseline", http.StatusBadRequest)
+		return
+	}
+	dryRun := r.FormValue("dry_run") == "true"
+	sklog.Infof("%s is importing %d expectations for corpus %s (dry run: %t)", user, len(baseline.Expectations), baseline.Corpus, dryRun)
+	report, err := bulk.Import(ctx, wh.DB, &baseline, user.String(), dryRun)
+	if err != nil {
+		httputils.ReportError(w, err, "Could not import baseline", http.StatusInternalServerError)
+		return
+	}
+	sendJSONResponse(w, report)
+}
+
 // ParamsHandler returns all Params that could be searched over. It uses the SQL Backend and
 // returns *only* the keys, not the options.
 func (wh *Handlers) ParamsHandler(w http.ResponseWriter, r *http.Request) {
@@ -1875,7 +2804,7 @@ func (wh *Handlers) ParamsHandler(w http.ResponseWriter, r *http.Request) {
 			httputils.ReportError(w, err, "Could not get paramset for primary branch", http.StatusInternalServerError)
 			return
 		}
-		sendJSONResponse(w, ps)
+		sendJSONResponse(w, wh.filterViewableParamset(r, ps))
 		return
 	}
 
@@ -1888,7 +2817,28 @@ func (wh *Handlers) ParamsHandler(w http.ResponseWriter, r *http.Request) {
 		httputils.ReportError(w, err, "Could not get paramset for given CL", http.StatusInternalServerError)
 		return
 	}
-	sendJSONResponse(w, ps)
+	sendJSONResponse(w, wh.filterViewableParamset(r, ps))
+}
+
+// filterViewableParamset returns a copy of the given paramset without the corpora the logged-in
+// user may not view. The given paramset is not modified, as it may be cached.
+func (wh *Handlers) filterViewableParamset(r *http.Request, ps paramtools.ReadOnlyParamSet) paramtools.ReadOnlyParamSet {
+	hidden := wh.hiddenCorpora(r)
+	if len(hidden) == 0 {
+		return ps
+	}
+	rv := make(paramtools.ReadOnlyParamSet, len(ps))
+	for key, values := range ps {
+		rv[key] = values
+	}
+	corpora := []string{}
+	for _, corpus := range ps[types.CorpusField] {
+		if !util.In(corpus, hidden) {
+			corpora = append(corpora, corpus)
+		}
+	}
+	rv[types.CorpusField] = corpora
+	return rv
 }
 
 // CommitsHandler returns the last n commits with data that make up the sliding window.
@@ -1960,7 +2910,41 @@ func (wh *Handlers) BaselineHandlerV2(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sendJSONResponse(w, bl)
+	sendJSONResponse(w, wh.filterViewableBaseline(r, bl))
+}
+
+// filterViewableBaseline returns a copy of the given baseline without the digests the logged-in
+// user may not view. The given baseline is not modified, as it may be cached.
+func (wh *Handlers) filterViewableBaseline(r *http.Request, bl frontend.BaselineV2Response) frontend.BaselineV2Response {
+	if wh.CorpusAccess == nil {
+		return bl
+	}
+	user := wh.alogin.LoggedInAs(r).String()
+	rv := bl
+	rv.Expectations = make(expectations.Baseline, len(bl.Expectations))
+	for test, digests := range bl.Expectations {
+		viewable := make(map[types.Digest]expectations.Label, len(digests))
+		for d, label := range digests {
+			if wh.CorpusAccess.CanViewDigest(user, d) {
+				viewable[d] = label
+			}
+		}
+		if len(viewable) > 0 {
+			rv.Expectations[test] = viewable
+		}
+	}
+	return rv
+}
+
+// SetMirroredBaseline makes the handlers serve the given baseline for the primary branch instead of
+// reading it from the database. Public views use it to serve only the expectations of publicly
+// viewable traces, as mirrored from the authoritative instance.
+func (wh *Handlers) SetMirroredBaseline(baseline expectations.Baseline) {
+	wh.mirroredBaselineMutex.Lock()
+	defer wh.mirroredBaselineMutex.Unlock()
+	wh.mirroredBaseline = &frontend.BaselineV2Response{
+		Expectations: baseline,
+	}
 }
 
 // fetchBaseline returns an object that contains all the positive and negatively triaged digests
@@ -1970,6 +2954,16 @@ func (wh *Handlers) fetchBaseline(ctx context.Context, crs, clID string) (fronte
 	ctx, span := trace.StartSpan(ctx, "fetchBaseline")
 	defer span.End()
 
+	if crs == "" {
+		wh.mirroredBaselineMutex.RLock()
+		mirrored := wh.mirroredBaseline
+		wh.mirroredBaselineMutex.RUnlock()
+		if mirrored != nil {
+			span.AddAttributes(trace.BoolAttribute("mirrored", true))
+			return *mirrored, nil
+		}
+	}
+
 	span.AddAttributes(trace.BoolAttribute("fromCache", false))
 
 	// Return the baseline from the cache if possible.
@@ -2063,6 +3057,78 @@ WHERE label = 'n' OR label = 'p'`
 	return response, nil
 }
 
+// BaselineDiffHandler returns the expectations which differ between the primary branch and the CL
+// identified by the "crs" and "issue" parameters. It is served by the baseline servers, so clients
+// such as goldctl do not need to fetch and compare both baselines themselves.
+func (wh *Handlers) BaselineDiffHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "frontend_BaselineDiffHandler")
+	defer span.End()
+	// No limit for anon users - this is an endpoint backed up by baseline servers, and
+	// should be able to handle a large load.
+
+	q := r.URL.Query()
+	clID := q.Get("issue")
+	crs := q.Get("crs")
+	if clID == "" {
+		http.Error(w, "Must specify issue.", http.StatusBadRequest)
+		return
+	}
+	if _, ok := wh.getCodeReviewSystem(crs); !ok {
+		http.Error(w, "Invalid CRS provided.", http.StatusBadRequest)
+		return
+	}
+
+	// Both baselines are usually cached, which makes this much cheaper than a dedicated query.
+	primary, err := wh.fetchBaseline(ctx, "", "")
+	if err != nil {
+		httputils.ReportError(w, err, "Fetching primary baseline failed.", http.StatusInternalServerError)
+		return
+	}
+	cl, err := wh.fetchBaseline(ctx, crs, clID)
+	if err != nil {
+		httputils.ReportError(w, err, "Fetching CL baseline failed.", http.StatusInternalServerError)
+		return
+	}
+	resp := diffBaselines(primary.Expectations, cl.Expectations)
+	resp.CodeReviewSystem = crs
+	resp.ChangelistID = clID
+	sendJSONResponse(w, resp)
+}
+
+// diffBaselines returns the expectations which differ between the given baselines. Both baselines
+// only contain positive and negative digests, so a digest missing from one of them is untriaged.
+func diffBaselines(primary, cl expectations.Baseline) frontend.BaselineDiffResponse {
+	rv := frontend.BaselineDiffResponse{
+		Added:   expectations.Baseline{},
+		Changed: expectations.Baseline{},
+		Removed: expectations.Baseline{},
+	}
+	add := func(b expectations.Baseline, test types.TestName, digest types.Digest, label expectations.Label) {
+		if _, ok := b[test]; !ok {
+			b[test] = map[types.Digest]expectations.Label{}
+		}
+		b[test][digest] = label
+	}
+	for test, digests := range cl {
+		for digest, label := range digests {
+			primaryLabel, ok := primary[test][digest]
+			if !ok {
+				add(rv.Added, test, digest, label)
+			} else if primaryLabel != label {
+				add(rv.Changed, test, digest, label)
+			}
+		}
+	}
+	for test, digests := range primary {
+		for digest, label := range digests {
+			if _, ok := cl[test][digest]; !ok {
+				add(rv.Removed, test, digest, label)
+			}
+		}
+	}
+	return rv
+}
+
 // DigestListHandler returns a list of digests for a given test. This is used by goldctl's
 // local diff tech.
 func (wh *Handlers) DigestListHandler(w http.ResponseWriter, r *http.Request) {
@@ -2097,6 +3163,10 @@ func (wh *Handlers) DigestListHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// If needed, we could add a TTL cache here.
+	if !wh.requireCorpusViewer(w, r, grouping[types.CorpusField]) {
+		return
+	}
+
 	out, err := wh.Search2API.GetDigestsForGrouping(ctx, grouping)
 	if err != nil {
 		httputils.ReportError(w, err, "Could not retrieve digests", http.StatusInternalServerError)
@@ -2340,6 +3410,10 @@ const (
 
 // ImageHandler returns either a single image or a diff between two images identified by their
 // respective digests.
+//
+// The optional "size" query parameter, which must be one of pyramid.Sizes, returns the image
+// downscaled so that neither side is longer than size pixels. Downscaled images are generated on
+// the first request and then cached in memory.
 func (wh *Handlers) ImageHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "web_ImageHandler")
 	defer span.End()
@@ -2351,41 +3425,148 @@ func (wh *Handlers) ImageHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	size := 0
+	if sizeStr := r.URL.Query().Get("size"); sizeStr != "" {
+		var err error
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil || !pyramid.IsValidSize(size) {
+			http.Error(w, fmt.Sprintf("Invalid size %q, must be one of %v.", sizeStr, pyramid.Sizes), http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Trim the image extension to get the image or diff ID.
 	imgID := imgFile[:len(imgFile)-len(dotPNG)]
-	// Cache images for 12 hours.
-	w.Header().Set("Cache-Control", "public, max-age=43200")
 	if len(imgID) == validDigestLength {
 		// Example request:
-		// https://skia-infra-gold.skia.org/img/images/8588cad6f3821b948468df35b67778ef.png
-		wh.serveImageWithDigest(ctx, w, types.Digest(imgID))
+		// https://skia-infra-gold.skia.org/img/images/8588cad6f3821b948468df35b67778ef.png?size=256
+		digest := types.Digest(imgID)
+		if !wh.setImageCacheControl(w, r, digest) {
+			return
+		}
+		if wh.serveCachedScaledImage(w, imgID, size) {
+			return
+		}
+		wh.serveImageWithDigest(ctx, w, digest, size)
 	} else if len(imgID) == validDigestLength*2+1 {
 		// Example request:
 		// https://skia-infra-gold.skia.org/img/diffs/81c4d3a64cf32143ff6c1fbf4cbbec2d-d20731492287002a3f046eae4bd4ce7d.png
 		left := types.Digest(imgID[:validDigestLength])
 		// + 1 for the dash
 		right := types.Digest(imgID[validDigestLength+1:])
-		wh.serveImageDiff(ctx, w, left, right)
+		if !wh.setImageCacheControl(w, r, left, right) {
+			return
+		}
+		if wh.serveCachedScaledImage(w, imgID, size) {
+			return
+		}
+		wh.serveImageDiff(ctx, w, left, right, size)
 	} else {
 		noCacheNotFound(w)
 		return
 	}
 }
 
-// serveImageWithDigest downloads the image from GCS and returns it. If there is an error, a 404
-// or 500 error is returned, as appropriate.
-func (wh *Handlers) serveImageWithDigest(ctx context.Context, w http.ResponseWriter, digest types.Digest) {
+// setImageCacheControl sets the Cache-Control header for an image made from the given digests.
+// Images are cached for 12 hours, though only privately if a digest was produced by a restricted
+// corpus. If the logged-in user may not view all the digests, it returns false after writing a
+// 404 error, so as not to reveal which digests exist.
+func (wh *Handlers) setImageCacheControl(w http.ResponseWriter, r *http.Request, digests ...types.Digest) bool {
+	cacheControl := "public, max-age=43200"
+	if wh.CorpusAccess != nil {
+		user := wh.alogin.LoggedInAs(r).String()
+		for _, d := range digests {
+			if !wh.CorpusAccess.CanViewDigest(user, d) {
+				noCacheNotFound(w)
+				return false
+			}
+			if wh.CorpusAccess.IsRestrictedDigest(d) {
+				cacheControl = "private, max-age=43200"
+			}
+		}
+	}
+	w.Header().Set("Cache-Control", cacheControl)
+	return true
+}
+
+// scaledImageKey returns the key of an image or diff downscaled to the given size in
+// scaledImageCache.
+func scaledImageKey(imgID string, size int) string {
+	return fmt.Sprintf("%s@%d", imgID, size)
+}
+
+// serveCachedScaledImage writes the image or diff downscaled to size if it is in the cache and
+// returns true. It returns false if size is zero, i.e. the full-size image was requested, or if
+// the image has not been downscaled yet.
+func (wh *Handlers) serveCachedScaledImage(w http.ResponseWriter, imgID string, size int) bool {
+	if size == 0 || wh.scaledImageCache == nil {
+		return false
+	}
+	b, ok := wh.scaledImageCache.Get(scaledImageKey(imgID, size))
+	if !ok {
+		return false
+	}
+	setImageContentType(w, b.([]byte))
+	if _, err := w.Write(b.([]byte)); err != nil {
+		sklog.Warningf("Could not write cached image %s: %s", imgID, err)
+	}
+	return true
+}
+
+// setImageContentType sets the Content-Type of the response according to the format of the
+// encoded image in b. Digests are always served with a .png extension, and the automatic content
+// sniffing done by the ResponseWriter does not recognize AVIF images.
+func setImageContentType(w http.ResponseWriter, b []byte) {
+	if f := codec.DetectFormat(b); f != codec.Unknown {
+		w.Header().Set("Content-Type", f.ContentType())
+	}
+}
+
+// cacheScaledImage stores the encoded bytes of an image or diff downscaled to size. Images which
+// already fit within size are stored in their original format.
+func (wh *Handlers) cacheScaledImage(imgID string, size int, b []byte) {
+	if wh.scaledImageCache == nil {
+		return
+	}
+	wh.scaledImageCache.Add(scaledImageKey(imgID, size), b)
+}
+
+// serveImageWithDigest downloads the image from GCS and returns it. If size is not zero the image
+// is downscaled to that size. If there is an error, a 404 or 500 error is returned, as
+// appropriate.
+func (wh *Handlers) serveImageWithDigest(ctx context.Context, w http.ResponseWriter, digest types.Digest, size int) {
 	ctx, span := trace.StartSpan(ctx, "serveImageWithDigest")
 	defer span.End()
 	// Go's image package has no color profile support and we convert to 8-bit NRGBA to diff,
 	// but our source images may have embedded color profiles and be up to 16-bit. So we must
-	// at least take care to serve the original .pngs unaltered.
+	// at least take care to serve the original PNG, WebP or AVIF images unaltered.
+	if wh.ServeThumbnails && pyramid.IsThumbnailSize(size) {
+		if b, err := wh.GCSClient.GetThumbnail(ctx, digest, size); err == nil {
+			wh.cacheScaledImage(string(digest), size, b)
+			setImageContentType(w, b)
+			if _, err := w.Write(b); err != nil {
+				sklog.Warningf("Could not write thumbnail: %s", err)
+			}
+			return
+		}
+		// The thumbnail has not been stored (yet), so fall back to downscaling the image.
+	}
 	b, err := wh.GCSClient.GetImage(ctx, digest)
 	if err != nil {
 		sklog.Warningf("Could not get image with digest %s: %s", digest, err)
 		noCacheNotFound(w)
 		return
 	}
+	if size != 0 {
+		// Downscaled images are only used for display, so losing the color profile is acceptable.
+		b, err = pyramid.DownscalePNG(b, size)
+		if err != nil {
+			httputils.ReportError(w, err, "Could not downscale image.", http.StatusInternalServerError)
+			return
+		}
+		wh.cacheScaledImage(string(digest), size, b)
+	}
+	setImageContentType(w, b)
 	if _, err := w.Write(b); err != nil {
 		httputils.ReportError(w, err, "Could not load image. Try again later.", http.StatusInternalServerError)
 		return
@@ -2393,11 +3574,29 @@ func (wh *Handlers) serveImageWithDigest(ctx context.Context, w http.ResponseWri
 }
 
 // serveImageDiff downloads the left and right images, computes the diff between them, encodes
-// the diff as a PNG image and writes it to the provided ResponseWriter. If there is an error, it
-// returns a 404 or 500 error as appropriate.
-func (wh *Handlers) serveImageDiff(ctx context.Context, w http.ResponseWriter, left types.Digest, right types.Digest) {
+// the diff as a PNG image and writes it to the provided ResponseWriter. If size is not zero the
+// diff is downscaled to that size. If there is an error, it returns a 404 or 500 error as
+// appropriate.
+func (wh *Handlers) serveImageDiff(ctx context.Context, w http.ResponseWriter, left types.Digest, right types.Digest, size int) {
 	ctx, span := trace.StartSpan(ctx, "serveImageDiff")
 	defer span.End()
+	if wh.ServeStoredDiffImages {
+		if b, ok := wh.getStoredDiffImage(ctx, left, right); ok {
+			if size != 0 {
+				var err error
+				b, err = pyramid.DownscalePNG(b, size)
+				if err != nil {
+					httputils.ReportError(w, err, "could not serve diff image", http.StatusInternalServerError)
+					return
+				}
+				wh.cacheScaledImage(string(left)+"-"+string(right), size, b)
+			}
+			if _, err := w.Write(b); err != nil {
+				sklog.Warningf("Could not write diff image: %s", err)
+			}
+			return
+		}
+	}
 	// TODO(lovisolo): Diff in NRGBA64?
 	// TODO(lovisolo): Make sure each pair of images is in the same color space before diffing?
 	//                 (They probably are today but it'd be a good correctness check to make sure.)
@@ -2428,6 +3627,19 @@ func (wh *Handlers) serveImageDiff(ctx context.Context, w http.ResponseWriter, l
 	// Compute the diff image.
 	_, diffImg := diff.PixelDiff(leftImg, rightImg)
 
+	if size != 0 {
+		var buf bytes.Buffer
+		if err := encodeImg(&buf, pyramid.Downscale(diffImg, size)); err != nil {
+			httputils.ReportError(w, err, "could not serve diff image", http.StatusInternalServerError)
+			return
+		}
+		wh.cacheScaledImage(string(left)+"-"+string(right), size, buf.Bytes())
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			sklog.Warningf("Could not write diff image: %s", err)
+		}
+		return
+	}
+
 	// Write output image to the http.ResponseWriter. Content-Type is set automatically
 	// based on the first 512 bytes of written data. See docs for ResponseWriter.Write()
 	// for details.
@@ -2442,9 +3654,43 @@ func (wh *Handlers) serveImageDiff(ctx context.Context, w http.ResponseWriter, l
 	}
 }
 
-// decode decodes the provided bytes as a PNG and returns them as an *image.NRGBA.
+// getStoredDiffImage returns the PNG-encoded diff image stored by the diffcalculator for the given
+// pair of digests. It returns false if there is no stored diff image or it cannot be read, in
+// which case the diff image should be computed instead.
+func (wh *Handlers) getStoredDiffImage(ctx context.Context, left, right types.Digest) ([]byte, bool) {
+	ctx, span := trace.StartSpan(ctx, "getStoredDiffImage")
+	defer span.End()
+	lb, err := sql.DigestToBytes(left)
+	if err != nil {
+		return nil, false
+	}
+	rb, err := sql.DigestToBytes(right)
+	if err != nil {
+		return nil, false
+	}
+	const statement = `SELECT diff_image_digest FROM DiffMetrics
+WHERE left_digest = $1 AND right_digest = $2`
+	var diffImageDigest schema.DigestBytes
+	if err := wh.DB.QueryRow(ctx, statement, lb, rb).Scan(&diffImageDigest); err != nil {
+		if err != pgx.ErrNoRows {
+			sklog.Warningf("Could not look up stored diff image for %s and %s: %s", left, right, err)
+		}
+		return nil, false
+	}
+	if len(diffImageDigest) == 0 {
+		return nil, false
+	}
+	b, err := wh.GCSClient.GetDiffImage(ctx, types.Digest(hex.EncodeToString(diffImageDigest)))
+	if err != nil {
+		sklog.Warningf("Could not get stored diff image for %s and %s: %s", left, right, err)
+		return nil, false
+	}
+	return b, true
+}
+
+// decode decodes the provided PNG, WebP or AVIF bytes and returns them as an *image.NRGBA.
 func decode(b []byte) (*image.NRGBA, error) {
-	im, err := png.Decode(bytes.NewReader(b))
+	im, err := codec.Decode(b)
 	if err != nil {
 		return nil, skerr.Wrap(err)
 	}
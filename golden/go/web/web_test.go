@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
+	"image/png"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -37,7 +39,9 @@ import (
 	"go.skia.org/infra/go/testutils"
 	"go.skia.org/infra/golden/go/clstore"
 	mock_crs "go.skia.org/infra/golden/go/code_review/mocks"
+	"go.skia.org/infra/golden/go/corpusaccess"
 	"go.skia.org/infra/golden/go/expectations"
+	"go.skia.org/infra/golden/go/expectations/audit"
 	"go.skia.org/infra/golden/go/ignore"
 	mock_ignore "go.skia.org/infra/golden/go/ignore/mocks"
 	"go.skia.org/infra/golden/go/ignore/sqlignorestore"
@@ -46,6 +50,7 @@ import (
 	"go.skia.org/infra/golden/go/search"
 	mock_search "go.skia.org/infra/golden/go/search/mocks"
 	search_providers "go.skia.org/infra/golden/go/search/providers"
+	search_query "go.skia.org/infra/golden/go/search/query"
 	"go.skia.org/infra/golden/go/sql"
 	dks "go.skia.org/infra/golden/go/sql/datakitchensink"
 	"go.skia.org/infra/golden/go/sql/schema"
@@ -82,6 +87,17 @@ func userIsLoggedInButNotEditor(t *testing.T) Handlers {
 	}
 }
 
+func userIsAdmin(t *testing.T) Handlers {
+	mockLogin := mock_alogin.NewLogin(t)
+	mockLogin.On("LoggedInAs", mock.Anything).Return(alogin.EMail("user@example.com")).Maybe()
+	mockLogin.On("HasRole", mock.Anything, mock.Anything).Return(true).Maybe()
+	mockLogin.On("Roles", mock.Anything).Return(roles.Roles{roles.Editor, roles.Admin}).Maybe()
+
+	return Handlers{
+		alogin: mockLogin,
+	}
+}
+
 func userIsNotLoggedIn(t *testing.T) Handlers {
 	mockLogin := mock_alogin.NewLogin(t)
 	mockLogin.On("LoggedInAs", mock.Anything).Return(alogin.NotLoggedIn).Maybe()
@@ -180,6 +196,7 @@ func TestHandlersThatRequireLogin_NotLoggedIn_UnauthorizedError(t *testing.T) {
 	test("triagev2", wh.TriageHandlerV2)
 	test("triagev3", wh.TriageHandlerV3)
 	test("triageUndo", wh.TriageUndoHandler)
+	test("importBaseline", wh.ImportBaselineHandler)
 }
 
 func TestHandlersThatRequireLogin_LoggedInNotEditor_UnauthorizedError(t *testing.T) {
@@ -201,6 +218,7 @@ func TestHandlersThatRequireLogin_LoggedInNotEditor_UnauthorizedError(t *testing
 	test("triagev2", wh.TriageHandlerV2)
 	test("triagev3", wh.TriageHandlerV3)
 	test("triageUndo", wh.TriageUndoHandler)
+	test("importBaseline", wh.ImportBaselineHandler)
 }
 
 // TestHandlersWhichTakeJSON_BadInput_BadRequestError tests a list of handlers which take JSON as an
@@ -223,6 +241,136 @@ func TestHandlersWhichTakeJSON_BadInput_BadRequestError(t *testing.T) {
 	// TODO(kjlubick): check all handlers that process JSON
 }
 
+func TestImportBaselineHandler_UnsupportedVersion_BadRequestError(t *testing.T) {
+	wh := userIsAdmin(t)
+
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"version": 999, "corpus": "round", "expectations": []}`)
+	r := httptest.NewRequest(http.MethodPost, requestURL, body)
+	wh.ImportBaselineHandler(w, r)
+
+	resp := w.Result()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestParseAuditQuery_ValidParams_Success(t *testing.T) {
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			ReviewSystems: []clstore.ReviewSystem{{ID: "gerrit"}},
+		},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/expectations/audit?user=user@example.com&test=circle&corpus=round"+
+		"&begin=2021-01-01T00:00:00Z&end=2021-02-01T00:00:00Z&crs=gerrit&changelist_id=123&offset=10&size=20", nil)
+	q, err := wh.parseAuditQuery(r)
+	require.NoError(t, err)
+	assert.Equal(t, audit.Query{
+		User:             "user@example.com",
+		Test:             "circle",
+		Corpus:           "round",
+		Begin:            time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		End:              time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC),
+		CodeReviewSystem: "gerrit",
+		ChangelistID:     "123",
+		Offset:           10,
+		Limit:            20,
+	}, q)
+}
+
+func TestParseAuditQuery_NoParams_DefaultsUsed(t *testing.T) {
+	wh := Handlers{}
+
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/expectations/audit?primary_branch_only=true", nil)
+	q, err := wh.parseAuditQuery(r)
+	require.NoError(t, err)
+	assert.Equal(t, audit.Query{PrimaryBranchOnly: true, Limit: audit.DefaultLimit}, q)
+}
+
+func TestExpectationAuditHandler_RestrictedCorpus_403Returned(t *testing.T) {
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			CorpusAccess: restrictRoundCorpus(t),
+		},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/expectations/audit?corpus="+dks.RoundCorpus, nil)
+	wh.ExpectationAuditHandler(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+}
+
+func TestExportBaselineHandler_RestrictedCorpus_403Returned(t *testing.T) {
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			CorpusAccess: restrictRoundCorpus(t),
+		},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/expectations/export?corpus="+dks.RoundCorpus, nil)
+	wh.ExportBaselineHandler(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+}
+
+func TestParamsHandler_RestrictedCorpus_CorpusNotReturned(t *testing.T) {
+	ms := &mock_search.API{}
+	ps := paramtools.ReadOnlyParamSet{
+		types.CorpusField:     []string{dks.CornersCorpus, dks.RoundCorpus},
+		types.PrimaryKeyField: []string{dks.CircleTest, dks.SquareTest},
+	}
+	ms.On("GetPrimaryBranchParamset", testutils.AnyContext).Return(ps, nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			Search2API:   ms,
+			CorpusAccess: restrictRoundCorpus(t),
+		},
+		anonymousCheapQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:              userIsNotLoggedIn(t).alogin,
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v2/paramset", nil)
+	wh.ParamsHandler(w, r)
+	assertJSONResponseWas(t, http.StatusOK, `{"name":["circle","square"],"source_type":["corners"]}`, w)
+	// The paramset may be cached, so it must not be modified.
+	assert.Equal(t, []string{dks.CornersCorpus, dks.RoundCorpus}, ps[types.CorpusField])
+
+	wh.alogin = userIsEditor(t).alogin
+	w = httptest.NewRecorder()
+	wh.ParamsHandler(w, r)
+	assertJSONResponseWas(t, http.StatusOK, `{"name":["circle","square"],"source_type":["corners","round"]}`, w)
+}
+
+func TestExpectationAuditHandler_InvalidParams_BadRequestError(t *testing.T) {
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			ReviewSystems: []clstore.ReviewSystem{{ID: "gerrit"}},
+		},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	}
+
+	test := func(name, url string) {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, url, nil)
+			wh.ExpectationAuditHandler(w, r)
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		})
+	}
+	test("invalid begin", "/json/v1/expectations/audit?begin=yesterday")
+	test("begin after end", "/json/v1/expectations/audit?begin=2021-02-01T00:00:00Z&end=2021-01-01T00:00:00Z")
+	test("unknown crs", "/json/v1/expectations/audit?crs=github&changelist_id=123")
+	test("changelist without crs", "/json/v1/expectations/audit?changelist_id=123")
+	test("changelist and primary branch", "/json/v1/expectations/audit?crs=gerrit&changelist_id=123&primary_branch_only=true")
+	test("invalid offset", "/json/v1/expectations/audit?offset=abc")
+}
+
 // TestAddIgnoreRule_SunnyDay_Success tests a typical case of adding an ignore rule (which ends
 // up in the IgnoreStore).
 func TestAddIgnoreRule_SunnyDay_Success(t *testing.T) {
@@ -471,6 +619,22 @@ func TestBaselineHandlerV2_PrimaryBranch_Success(t *testing.T) {
 	assertJSONResponseWas(t, http.StatusOK, expectedJSONResponse, w)
 }
 
+func TestBaselineHandlerV2_MirroredPrimaryBranch_DoesNotUseDB(t *testing.T) {
+	wh := Handlers{
+		baselineCache: ttlcache.New(time.Minute, 10*time.Minute),
+	}
+	wh.SetMirroredBaseline(expectations.Baseline{
+		dks.CircleTest: {
+			dks.DigestC01Pos: expectations.Positive,
+		},
+	})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, frontend.ExpectationsRouteV2, nil)
+
+	wh.BaselineHandlerV2(w, r)
+	assertJSONResponseWas(t, http.StatusOK, `{"primary":{"circle":{"c01c01c01c01c01c01c01c01c01c01c0":"positive"}}}`, w)
+}
+
 func TestBaselineHandlerV2_ValidChangelist_Success(t *testing.T) {
 	ctx := context.Background()
 	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
@@ -603,6 +767,38 @@ func TestBaselineHandlerV2_CachedPrimaryBranch_ReturnsCachedBaseline(t *testing.
 	assertJSONResponseWas(t, http.StatusOK, expectedJSONResponse, w)
 }
 
+func TestBaselineHandlerV2_RestrictedDigest_OnlyReturnedToViewers(t *testing.T) {
+	corpusAccess := restrictRoundCorpus(t)
+	corpusAccess.SetRestrictedDigestsForTesting(map[types.Digest][]string{dks.DigestC01Pos: {dks.RoundCorpus}})
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			CorpusAccess: corpusAccess,
+		},
+		baselineCache: ttlcache.New(time.Minute, 10*time.Minute),
+		alogin:        userIsNotLoggedIn(t).alogin,
+	}
+	wh.baselineCache.Set("primary", frontend.BaselineV2Response{
+		Expectations: expectations.Baseline{
+			dks.CircleTest: {
+				dks.DigestC01Pos: expectations.Positive,
+			},
+			dks.SquareTest: {
+				dks.DigestA01Pos: expectations.Positive,
+			},
+		},
+	}, ttlcache.DefaultExpiration)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, frontend.ExpectationsRouteV2, nil)
+	wh.BaselineHandlerV2(w, r)
+	assertJSONResponseWas(t, http.StatusOK, `{"primary":{"square":{"a01a01a01a01a01a01a01a01a01a01a0":"positive"}}}`, w)
+
+	wh.alogin = userIsEditor(t).alogin
+	w = httptest.NewRecorder()
+	wh.BaselineHandlerV2(w, r)
+	assertJSONResponseWas(t, http.StatusOK, `{"primary":{"circle":{"c01c01c01c01c01c01c01c01c01c01c0":"positive"},"square":{"a01a01a01a01a01a01a01a01a01a01a0":"positive"}}}`, w)
+}
+
 func TestBaselineHandlerV2_CachedChangelist_ReturnsCachedBaseline(t *testing.T) {
 	// Note that we do not initialize a test database. This is intentional: reading from the
 	// database would defeat the purpose of caching, and such an attempt would make this test fail.
@@ -635,6 +831,103 @@ func TestBaselineHandlerV2_CachedChangelist_ReturnsCachedBaseline(t *testing.T)
 	assertJSONResponseWas(t, http.StatusOK, expectedJSONResponse, w)
 }
 
+func TestBaselineDiffHandler_ValidChangelist_Success(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+	waitForSystemTime()
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			DB: db,
+			ReviewSystems: []clstore.ReviewSystem{
+				{
+					ID: dks.GerritCRS,
+				},
+			},
+		},
+		baselineCache: ttlcache.New(time.Minute, 10*time.Minute),
+	}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, frontend.ExpectationsDiffRouteV1+"?issue=CL_fix_ios&crs=gerrit", nil)
+
+	// DigestC06Pos_CL was triaged on the CL, and DigestB01Pos was (accidentally) marked as
+	// untriaged on the CL.
+	expectedJSONResponse := `{"added":{"circle":{"c06c06c06c06c06c06c06c06c06c06c0":"positive"}},"changed":{},"removed":{"triangle":{"b01b01b01b01b01b01b01b01b01b01b0":"positive"}},"cl_id":"CL_fix_ios","crs":"gerrit"}`
+
+	wh.BaselineDiffHandler(w, r)
+	assertJSONResponseWas(t, http.StatusOK, expectedJSONResponse, w)
+}
+
+func TestBaselineDiffHandler_CachedBaselines_ReturnsDiffOfCachedBaselines(t *testing.T) {
+	// Note that we do not initialize a test database, so both baselines must come from the cache.
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			ReviewSystems: []clstore.ReviewSystem{
+				{
+					ID: dks.GerritCRS,
+				},
+			},
+		},
+		baselineCache: ttlcache.New(time.Minute, 10*time.Minute),
+	}
+	wh.baselineCache.Set("primary", frontend.BaselineV2Response{
+		Expectations: expectations.Baseline{
+			dks.CircleTest: {
+				dks.DigestC01Pos: expectations.Positive,
+				dks.DigestC02Pos: expectations.Positive,
+			},
+			dks.SquareTest: {
+				dks.DigestA01Pos: expectations.Positive,
+			},
+		},
+	}, ttlcache.DefaultExpiration)
+	wh.baselineCache.Set("gerrit_CLID", frontend.BaselineV2Response{
+		CodeReviewSystem: dks.GerritCRS,
+		ChangelistID:     "CLID",
+		Expectations: expectations.Baseline{
+			dks.CircleTest: {
+				dks.DigestC01Pos: expectations.Positive,
+				dks.DigestC02Pos: expectations.Negative,
+				dks.DigestC03Unt: expectations.Positive,
+			},
+		},
+	}, ttlcache.DefaultExpiration)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, frontend.ExpectationsDiffRouteV1+"?issue=CLID&crs=gerrit", nil)
+
+	expectedJSONResponse := `{"added":{"circle":{"c03c03c03c03c03c03c03c03c03c03c0":"positive"}},"changed":{"circle":{"c02c02c02c02c02c02c02c02c02c02c0":"negative"}},"removed":{"square":{"a01a01a01a01a01a01a01a01a01a01a0":"positive"}},"cl_id":"CLID","crs":"gerrit"}`
+
+	wh.BaselineDiffHandler(w, r)
+	assertJSONResponseWas(t, http.StatusOK, expectedJSONResponse, w)
+}
+
+func TestBaselineDiffHandler_MissingIssueOrInvalidCRS_ReturnsError(t *testing.T) {
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			ReviewSystems: []clstore.ReviewSystem{
+				{
+					ID: dks.GerritCRS,
+				},
+			},
+		},
+		baselineCache: ttlcache.New(time.Minute, 10*time.Minute),
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, frontend.ExpectationsDiffRouteV1+"?crs=gerrit", nil)
+	wh.BaselineDiffHandler(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "Must specify issue")
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, frontend.ExpectationsDiffRouteV1+"?issue=CLID&crs=wrong", nil)
+	wh.BaselineDiffHandler(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "Invalid CRS")
+}
+
 // TestWhoami_NotLoggedIn_Success tests that /json/whoami returns the expected empty response when
 // no user is logged in.
 func TestWhoami_NotLoggedIn_Success(t *testing.T) {
@@ -841,6 +1134,48 @@ func TestImageHandler_SingleKnownImage_CorrectBytesReturned(t *testing.T) {
 	assertImageResponseWas(t, []byte("some png bytes"), w)
 }
 
+func TestImageHandler_SingleKnownAVIFImage_ContentTypeSet(t *testing.T) {
+	avifBytes := []byte("\x00\x00\x00\x1cftypavif\x00\x00\x00\x00 some avif bytes")
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("0123456789abcdef0123456789abcdef")).Return(avifBytes, nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GCSClient: mgc,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/images/0123456789abcdef0123456789abcdef.png", nil)
+	wh.ImageHandler(w, r)
+	assertImageResponseWas(t, avifBytes, w)
+	assert.Equal(t, "image/avif", w.Header().Get("Content-Type"))
+}
+
+func TestImageHandler_WebPAndPNGImages_DiffReturned(t *testing.T) {
+	// A lossless 1x1 WebP image with a single fully transparent pixel.
+	webpImage := []byte("RIFF\x1a\x00\x00\x00WEBPVP8L\x0d\x00\x00\x00\x2f\x00\x00\x00\x10\x07\x10\x11\x11\x88\x88\xfe\x07\x00")
+	var pngImage bytes.Buffer
+	require.NoError(t, encodeImg(&pngImage, image.NewNRGBA(image.Rect(0, 0, 1, 1))))
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("11111111111111111111111111111111")).Return(webpImage, nil)
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("22222222222222222222222222222222")).Return(pngImage.Bytes(), nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GCSClient: mgc,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/diffs/11111111111111111111111111111111-22222222222222222222222222222222.png", nil)
+	wh.ImageHandler(w, r)
+	// Both images are a single transparent pixel, so the diff is an identical transparent pixel.
+	assertDiffImageWas(t, w, `! SKTEXTSIMPLE
+1 1
+0x00000000`)
+}
+
 func TestImageHandler_SingleUnknownImage_404Returned(t *testing.T) {
 	mgc := &mocks.GCSClient{}
 	mgc.On("GetImage", testutils.AnyContext, mock.Anything).Return(nil, errors.New("unknown"))
@@ -939,6 +1274,240 @@ func TestImageHandler_InvalidImageFormat_404Returned(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
 }
 
+func TestImageHandler_SingleKnownImageWithSize_DownscaledImageReturnedAndCached(t *testing.T) {
+	var original bytes.Buffer
+	require.NoError(t, encodeImg(&original, image.NewNRGBA(image.Rect(0, 0, 600, 300))))
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("0123456789abcdef0123456789abcdef")).Return(original.Bytes(), nil).Once()
+	cache, err := lru.New(scaledImageCacheSize)
+	require.NoError(t, err)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GCSClient: mgc,
+		},
+		scaledImageCache: cache,
+	}
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/img/images/0123456789abcdef0123456789abcdef.png?size=256", nil)
+		wh.ImageHandler(w, r)
+		resp := w.Result()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		cfg, err := png.DecodeConfig(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, 256, cfg.Width)
+		assert.Equal(t, 128, cfg.Height)
+	}
+	// The second request is served from the cache.
+	mgc.AssertExpectations(t)
+}
+
+func TestImageHandler_SmallImageWithSize_OriginalBytesReturned(t *testing.T) {
+	image1 := loadAsPNGBytes(t, one_by_five.ImageOne)
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("0123456789abcdef0123456789abcdef")).Return(image1, nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GCSClient: mgc,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/images/0123456789abcdef0123456789abcdef.png?size=1024", nil)
+	wh.ImageHandler(w, r)
+	assertImageResponseWas(t, image1, w)
+}
+
+func TestImageHandler_StoredThumbnail_ThumbnailReturned(t *testing.T) {
+	thumbnail := loadAsPNGBytes(t, one_by_five.ImageOne)
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetThumbnail", testutils.AnyContext, types.Digest("0123456789abcdef0123456789abcdef"), 256).Return(thumbnail, nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GCSClient:       mgc,
+			ServeThumbnails: true,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/images/0123456789abcdef0123456789abcdef.png?size=256", nil)
+	wh.ImageHandler(w, r)
+	assertImageResponseWas(t, thumbnail, w)
+	// The full-size image was not needed.
+	mgc.AssertNotCalled(t, "GetImage", testutils.AnyContext, mock.Anything)
+}
+
+func TestImageHandler_NoStoredThumbnail_DownscaledImageReturned(t *testing.T) {
+	var original bytes.Buffer
+	require.NoError(t, encodeImg(&original, image.NewNRGBA(image.Rect(0, 0, 600, 300))))
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetThumbnail", testutils.AnyContext, types.Digest("0123456789abcdef0123456789abcdef"), 512).Return(nil, errors.New("not found"))
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("0123456789abcdef0123456789abcdef")).Return(original.Bytes(), nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GCSClient:       mgc,
+			ServeThumbnails: true,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/images/0123456789abcdef0123456789abcdef.png?size=512", nil)
+	wh.ImageHandler(w, r)
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	cfg, err := png.DecodeConfig(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 512, cfg.Width)
+	assert.Equal(t, 256, cfg.Height)
+}
+
+func TestImageHandler_TwoKnownImagesWithSize_DiffReturned(t *testing.T) {
+	image1 := loadAsPNGBytes(t, one_by_five.ImageOne)
+	image2 := loadAsPNGBytes(t, one_by_five.ImageTwo)
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("11111111111111111111111111111111")).Return(image1, nil)
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("22222222222222222222222222222222")).Return(image2, nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GCSClient: mgc,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/diffs/11111111111111111111111111111111-22222222222222222222222222222222.png?size=256", nil)
+	wh.ImageHandler(w, r)
+	// The diff already fits, so it is the same as the full-size diff.
+	assertDiffImageWas(t, w, `! SKTEXTSIMPLE
+1 5
+0xfdd0a2ff
+0xfdd0a2ff
+0xfdd0a2ff
+0xfdd0a2ff
+0xc6dbefff`)
+}
+
+func TestImageHandler_StoredDiffImage_StoredDiffImageReturned(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	const diffImageDigest = types.Digest("33333333333333333333333333333333")
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, schema.Tables{
+		DiffMetrics: []schema.DiffMetricRow{{
+			LeftDigest:      d("11111111111111111111111111111111"),
+			RightDigest:     d("22222222222222222222222222222222"),
+			NumPixelsDiff:   5,
+			MaxRGBADiffs:    [4]int{1, 1, 1, 1},
+			MaxChannelDiff:  1,
+			Timestamp:       time.Date(2021, time.February, 1, 1, 1, 1, 0, time.UTC),
+			DiffImageDigest: d(diffImageDigest),
+		}},
+	}))
+	storedDiffImage := loadAsPNGBytes(t, one_by_five.ImageOne)
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetDiffImage", testutils.AnyContext, diffImageDigest).Return(storedDiffImage, nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			DB:                    db,
+			GCSClient:             mgc,
+			ServeStoredDiffImages: true,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/diffs/11111111111111111111111111111111-22222222222222222222222222222222.png", nil)
+	wh.ImageHandler(w, r)
+	assertImageResponseWas(t, storedDiffImage, w)
+	// The original images were not needed.
+	mgc.AssertNotCalled(t, "GetImage", testutils.AnyContext, mock.Anything)
+}
+
+func TestImageHandler_NoStoredDiffImage_DiffComputed(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	image1 := loadAsPNGBytes(t, one_by_five.ImageOne)
+	image2 := loadAsPNGBytes(t, one_by_five.ImageTwo)
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("11111111111111111111111111111111")).Return(image1, nil)
+	mgc.On("GetImage", testutils.AnyContext, types.Digest("22222222222222222222222222222222")).Return(image2, nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			DB:                    db,
+			GCSClient:             mgc,
+			ServeStoredDiffImages: true,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/diffs/11111111111111111111111111111111-22222222222222222222222222222222.png", nil)
+	wh.ImageHandler(w, r)
+	assertDiffImageWas(t, w, `! SKTEXTSIMPLE
+1 5
+0xfdd0a2ff
+0xfdd0a2ff
+0xfdd0a2ff
+0xfdd0a2ff
+0xc6dbefff`)
+}
+
+func TestImageHandler_InvalidSize_400Returned(t *testing.T) {
+	wh := Handlers{}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/images/0123456789abcdef0123456789abcdef.png?size=100", nil)
+	wh.ImageHandler(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestImageHandler_RestrictedDigest_OnlyServedToViewers(t *testing.T) {
+	const restrictedDigest = types.Digest("0123456789abcdef0123456789abcdef")
+	mgc := &mocks.GCSClient{}
+	mgc.On("GetImage", testutils.AnyContext, restrictedDigest).Return([]byte("some png bytes"), nil).Once()
+	corpusAccess := restrictRoundCorpus(t)
+	corpusAccess.SetRestrictedDigestsForTesting(map[types.Digest][]string{restrictedDigest: {dks.RoundCorpus}})
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GCSClient:    mgc,
+			CorpusAccess: corpusAccess,
+		},
+		alogin: userIsNotLoggedIn(t).alogin,
+	}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/img/images/0123456789abcdef0123456789abcdef.png", nil)
+	wh.ImageHandler(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/img/diffs/0123456789abcdef0123456789abcdef-fedcba9876543210fedcba9876543210.png", nil)
+	wh.ImageHandler(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+
+	wh.alogin = userIsEditor(t).alogin
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/img/images/0123456789abcdef0123456789abcdef.png", nil)
+	wh.ImageHandler(w, r)
+	assertImageResponseWas(t, []byte("some png bytes"), w)
+	assert.Equal(t, "private, max-age=43200", w.Header().Get("Cache-Control"))
+	mgc.AssertExpectations(t)
+}
+
+// restrictRoundCorpus returns a corpusaccess.Checker which allows fakeUser to view, but not
+// triage, the round corpus.
+func restrictRoundCorpus(t *testing.T) *corpusaccess.Checker {
+	c, err := corpusaccess.CheckerFromRules(corpusaccess.Rules{
+		dks.RoundCorpus: {Viewers: []string{string(fakeUser)}, Triagers: []string{"triager@example.com"}},
+	})
+	require.NoError(t, err)
+	return c
+}
+
 func loadAsPNGBytes(t *testing.T, textImage string) []byte {
 	img := text.MustToNRGBA(textImage)
 	var buf bytes.Buffer
@@ -1253,51 +1822,486 @@ func TestStatusHandler_Success(t *testing.T) {
 				UntriagedCount: 3,
 			},
 		},
-	}}
+	}}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, requestURL, nil)
+	wh.StatusHandler(w, r)
+	const expectedJSON = `{"lastCommit":{"commit_time":1607644800,"id":"0000000110","hash":"f4412901bfb130a8774c0c719450d1450845f471","author":"userTwo@example.com","message":"commit 110","cl_url":""},"corpStatus":[{"name":"corners","untriagedCount":0},{"name":"round","untriagedCount":3}]}`
+	assertJSONResponseWas(t, http.StatusOK, expectedJSON, w)
+}
+
+func TestGroupingsHandler_NonEmptyStatusCache_ReturnsUnionBetweenJSON5ConfigAndStatusCache_JSON5ConfigTakesPrecedence(t *testing.T) {
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GroupingParamKeysByCorpus: map[string][]string{
+				dks.TextCorpus:  {types.PrimaryKeyField, types.CorpusField, dks.DeviceKey},
+				dks.RoundCorpus: {types.PrimaryKeyField, types.CorpusField, dks.OSKey},
+			},
+		},
+		statusCache: frontend.GUIStatus{
+			CorpStatus: []frontend.GUICorpusStatus{{Name: dks.CornersCorpus}, {Name: dks.RoundCorpus}},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, requestURL, nil)
+	wh.GroupingsHandler(w, r)
+	const expectedJSON = `{"grouping_param_keys_by_corpus":{"corners":["name","source_type"],"round":["name","source_type","os"],"text":["name","source_type","device"]}}`
+	assertJSONResponseWas(t, http.StatusOK, expectedJSON, w)
+}
+
+func TestGroupingsHandler_EmptyStatusCache_Success(t *testing.T) {
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			GroupingParamKeysByCorpus: map[string][]string{
+				dks.TextCorpus: {types.PrimaryKeyField, types.CorpusField, dks.DeviceKey},
+			},
+		},
+		statusCache: frontend.GUIStatus{
+			CorpStatus: []frontend.GUICorpusStatus{},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, requestURL, nil)
+	wh.GroupingsHandler(w, r)
+	const expectedJSON = `{"grouping_param_keys_by_corpus":{"text":["name","source_type","device"]}}`
+	assertJSONResponseWas(t, http.StatusOK, expectedJSON, w)
+}
+
+func makeTriageQueueSearchResults() *frontend.SearchResponse {
+	return &frontend.SearchResponse{
+		Results: []*frontend.SearchResult{{
+			Test:       "beta",
+			Digest:     "d1",
+			Status:     expectations.Untriaged,
+			ClosestRef: frontend.PositiveRef,
+			RefDiffs: map[frontend.RefClosest]*frontend.SRDiffDigest{
+				frontend.PositiveRef: {Digest: "ref2", CombinedMetric: 1},
+			},
+		}, {
+			Test:       "alpha",
+			Digest:     "d2",
+			Status:     expectations.Untriaged,
+			ClosestRef: frontend.PositiveRef,
+			RefDiffs: map[frontend.RefClosest]*frontend.SRDiffDigest{
+				frontend.PositiveRef: {Digest: "ref2", CombinedMetric: 5},
+			},
+		}, {
+			Test:       "alpha",
+			Digest:     "d3",
+			Status:     expectations.Untriaged,
+			ClosestRef: frontend.PositiveRef,
+			RefDiffs: map[frontend.RefClosest]*frontend.SRDiffDigest{
+				frontend.PositiveRef: {Digest: "ref1", CombinedMetric: 3},
+			},
+		}, {
+			Test:       "alpha",
+			Digest:     "d4",
+			Status:     expectations.Untriaged,
+			ClosestRef: frontend.NoRef,
+		}, {
+			// Positive digests should never show up in the queue.
+			Test:   "alpha",
+			Digest: "d5",
+			Status: expectations.Positive,
+		}},
+	}
+}
+
+func TestSearchStreamHandler_MultipleChunks_WrittenAsNewlineDelimitedJSON(t *testing.T) {
+	ms := &mock_search.API{}
+	ms.On("SearchStream", testutils.AnyContext, mock.MatchedBy(func(q *search_query.Search) bool {
+		return q.Offset == 4 && q.Limit == 2
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*frontend.SearchStreamChunk) error)
+		require.NoError(t, fn(&frontend.SearchStreamChunk{
+			Results:    []*frontend.SearchResult{{Test: "alpha", Digest: "d1"}, {Test: "alpha", Digest: "d2"}},
+			Size:       7,
+			NextCursor: "6",
+		}))
+		require.NoError(t, fn(&frontend.SearchStreamChunk{
+			Results: []*frontend.SearchResult{{Test: "beta", Digest: "d3"}},
+		}))
+	}).Return(nil)
+
+	wh := Handlers{
+		HandlersConfig:          HandlersConfig{Search2API: ms},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/search/stream?limit=2&cursor=4", nil)
+	wh.SearchStreamHandler(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	var chunk frontend.SearchStreamChunk
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &chunk))
+	assert.Len(t, chunk.Results, 2)
+	assert.Equal(t, 7, chunk.Size)
+	assert.Equal(t, "6", chunk.NextCursor)
+	chunk = frontend.SearchStreamChunk{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &chunk))
+	assert.Equal(t, types.Digest("d3"), chunk.Results[0].Digest)
+	assert.Empty(t, chunk.NextCursor)
+	assert.Empty(t, chunk.Error)
+}
+
+func TestSearchStreamHandler_FailsAfterFirstChunk_ErrorReportedInLastChunk(t *testing.T) {
+	ms := &mock_search.API{}
+	ms.On("SearchStream", testutils.AnyContext, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*frontend.SearchStreamChunk) error)
+		require.NoError(t, fn(&frontend.SearchStreamChunk{
+			Results:    []*frontend.SearchResult{{Test: "alpha", Digest: "d1"}},
+			NextCursor: "1",
+		}))
+	}).Return(errors.New("boom"))
+
+	wh := Handlers{
+		HandlersConfig:          HandlersConfig{Search2API: ms},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/search/stream", nil)
+	wh.SearchStreamHandler(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	var chunk frontend.SearchStreamChunk
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &chunk))
+	assert.NotEmpty(t, chunk.Error)
+	assert.Empty(t, chunk.Results)
+}
+
+func TestSearchStreamHandler_FailsBeforeFirstChunk_ReturnsInternalServerError(t *testing.T) {
+	ms := &mock_search.API{}
+	ms.On("SearchStream", testutils.AnyContext, mock.Anything, mock.Anything).Return(errors.New("boom"))
+
+	wh := Handlers{
+		HandlersConfig:          HandlersConfig{Search2API: ms},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/search/stream", nil)
+	wh.SearchStreamHandler(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestTriageQueueHandler_DefaultOrder_ClusteredByTestAndClosestRef(t *testing.T) {
+	ms := &mock_search.API{}
+	ms.On("Search", testutils.AnyContext, mock.MatchedBy(func(q *search_query.Search) bool {
+		return q.IncludeUntriagedDigests && !q.IncludePositiveDigests && !q.IncludeNegativeDigests &&
+			q.Offset == 0 && q.Limit == 0
+	})).Return(makeTriageQueueSearchResults(), nil)
+
+	wh := initCaches(&Handlers{
+		HandlersConfig:          HandlersConfig{Search2API: ms},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/triagequeue?limit=2&positive=true", nil)
+	wh.TriageQueueHandler(w, r)
+	const expectedJSON = `{"entries":[{"test":"alpha","digest":"d4"},{"test":"alpha","digest":"d3"}],"total":4,"next_cursor":"2","prev_cursor":""}`
+	assertJSONResponseWas(t, http.StatusOK, expectedJSON, w)
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/json/v1/triagequeue?limit=2&cursor=2", nil)
+	wh.TriageQueueHandler(w, r)
+	const expectedNextPageJSON = `{"entries":[{"test":"alpha","digest":"d2"},{"test":"beta","digest":"d1"}],"total":4,"next_cursor":"","prev_cursor":"0"}`
+	assertJSONResponseWas(t, http.StatusOK, expectedNextPageJSON, w)
+}
+
+func TestTriageQueueHandler_DiffOrder_LargestDiffFirst(t *testing.T) {
+	ms := &mock_search.API{}
+	ms.On("Search", testutils.AnyContext, mock.Anything).Return(makeTriageQueueSearchResults(), nil)
+
+	wh := initCaches(&Handlers{
+		HandlersConfig:          HandlersConfig{Search2API: ms},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/triagequeue?order=diff", nil)
+	wh.TriageQueueHandler(w, r)
+	const expectedJSON = `{"entries":[{"test":"alpha","digest":"d2"},{"test":"alpha","digest":"d3"},{"test":"beta","digest":"d1"},{"test":"alpha","digest":"d4"}],"total":4,"next_cursor":"","prev_cursor":""}`
+	assertJSONResponseWas(t, http.StatusOK, expectedJSON, w)
+}
+
+func TestTriageQueueHandler_BlameOrder_GroupedByBlameRange(t *testing.T) {
+	ms := &mock_search.API{}
+	ms.On("Search", testutils.AnyContext, mock.MatchedBy(func(q *search_query.Search) bool {
+		return q.BlameGroupID == ""
+	})).Return(makeTriageQueueSearchResults(), nil)
+	ms.On("GetBlamesForUntriagedDigests", testutils.AnyContext, "my_corpus").Return(search.BlameSummaryV1{
+		Ranges: []search.BlameEntry{{CommitRange: "commit3"}, {CommitRange: "commit1:commit2"}},
+	}, nil)
+	ms.On("Search", testutils.AnyContext, mock.MatchedBy(func(q *search_query.Search) bool {
+		return q.BlameGroupID == "commit3"
+	})).Return(&frontend.SearchResponse{
+		BulkTriageDeltaInfos: []frontend.BulkTriageDeltaInfo{{
+			Grouping: paramtools.Params{types.PrimaryKeyField: "beta"},
+			Digest:   "d1",
+		}},
+	}, nil)
+	ms.On("Search", testutils.AnyContext, mock.MatchedBy(func(q *search_query.Search) bool {
+		return q.BlameGroupID == "commit1:commit2"
+	})).Return(&frontend.SearchResponse{
+		BulkTriageDeltaInfos: []frontend.BulkTriageDeltaInfo{{
+			Grouping: paramtools.Params{types.PrimaryKeyField: "alpha"},
+			Digest:   "d3",
+		}, {
+			Grouping: paramtools.Params{types.PrimaryKeyField: "beta"},
+			Digest:   "d1",
+		}},
+	}, nil)
+
+	wh := initCaches(&Handlers{
+		HandlersConfig:          HandlersConfig{Search2API: ms},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/triagequeue?order=blame&query=source_type%3Dmy_corpus", nil)
+	wh.TriageQueueHandler(w, r)
+	// Digests which could not be blamed go last.
+	const expectedJSON = `{"entries":[{"test":"beta","digest":"d1"},{"test":"alpha","digest":"d3"},{"test":"alpha","digest":"d2"},{"test":"alpha","digest":"d4"}],"total":4,"next_cursor":"","prev_cursor":""}`
+	assertJSONResponseWas(t, http.StatusOK, expectedJSON, w)
+}
+
+func TestTriageQueueHandler_BlameOrderWithoutCorpus_ReturnsError(t *testing.T) {
+	wh := initCaches(&Handlers{
+		HandlersConfig:          HandlersConfig{Search2API: &mock_search.API{}},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/triagequeue?order=blame", nil)
+	wh.TriageQueueHandler(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestTriageQueueHandler_InvalidInput_ReturnsError(t *testing.T) {
+	wh := initCaches(&Handlers{
+		HandlersConfig:          HandlersConfig{Search2API: &mock_search.API{}},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	})
+
+	test := func(name, url string) {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, url, nil)
+			wh.TriageQueueHandler(w, r)
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		})
+	}
+	test("unknown order", "/json/v1/triagequeue?order=alphabetical")
+	test("non-numeric cursor", "/json/v1/triagequeue?cursor=abc")
+	test("negative cursor", "/json/v1/triagequeue?cursor=-2")
+}
+
+func TestFlakyTestsHandler_ValidInput_CorrectJSONReturned(t *testing.T) {
+	ms := &mock_search.API{}
+	ms.On("GetFlakyTests", testutils.AnyContext, frontend.FlakyTestsQuery{
+		Corpus:      "the_corpus",
+		IgnoreState: types.ExcludeIgnoredTraces,
+		Order:       frontend.FlakyTestsOrderChurnRate,
+		Limit:       10,
+	}).Return(frontend.FlakyTestsResponse{
+		Tests: []frontend.FlakyTestSummary{{
+			Grouping: paramtools.Params{
+				types.CorpusField:     "the_corpus",
+				types.PrimaryKeyField: "alpha",
+			},
+			Traces:               2,
+			UniqueDigests:        3,
+			CommitsWithData:      4,
+			Transitions:          4,
+			ChurnRate:            0.5,
+			TransitionsPerCommit: 1,
+		}},
+	}, nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			Search2API: ms,
+		},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsEditor(t).alogin,
+	}
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodGet, requestURL, nil)
-	wh.StatusHandler(w, r)
-	const expectedJSON = `{"lastCommit":{"commit_time":1607644800,"id":"0000000110","hash":"f4412901bfb130a8774c0c719450d1450845f471","author":"userTwo@example.com","message":"commit 110","cl_url":""},"corpStatus":[{"name":"corners","untriagedCount":0},{"name":"round","untriagedCount":3}]}`
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/flaky?corpus=the_corpus&sort=churn_rate&limit=10", nil)
+	wh.FlakyTestsHandler(w, r)
+	const expectedJSON = `{"tests":[{"grouping":{"name":"alpha","source_type":"the_corpus"},` +
+		`"traces":2,"unique_digests":3,"commits_with_data":4,"transitions":4,` +
+		`"churn_rate":0.5,"transitions_per_commit":1}]}`
 	assertJSONResponseWas(t, http.StatusOK, expectedJSON, w)
 }
 
-func TestGroupingsHandler_NonEmptyStatusCache_ReturnsUnionBetweenJSON5ConfigAndStatusCache_JSON5ConfigTakesPrecedence(t *testing.T) {
+func TestFlakyTestsHandler_DefaultsUsed(t *testing.T) {
+	ms := &mock_search.API{}
+	ms.On("GetFlakyTests", testutils.AnyContext, frontend.FlakyTestsQuery{
+		Corpus:      "the_corpus",
+		IgnoreState: types.IncludeIgnoredTraces,
+		Order:       frontend.FlakyTestsOrderTransitionsPerCommit,
+	}).Return(frontend.FlakyTestsResponse{Tests: []frontend.FlakyTestSummary{}}, nil)
+
 	wh := Handlers{
 		HandlersConfig: HandlersConfig{
-			GroupingParamKeysByCorpus: map[string][]string{
-				dks.TextCorpus:  {types.PrimaryKeyField, types.CorpusField, dks.DeviceKey},
-				dks.RoundCorpus: {types.PrimaryKeyField, types.CorpusField, dks.OSKey},
-			},
+			Search2API: ms,
 		},
-		statusCache: frontend.GUIStatus{
-			CorpStatus: []frontend.GUICorpusStatus{{Name: dks.CornersCorpus}, {Name: dks.RoundCorpus}},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsEditor(t).alogin,
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/flaky?corpus=the_corpus&include_ignored_traces=true", nil)
+	wh.FlakyTestsHandler(w, r)
+	assertJSONResponseWas(t, http.StatusOK, `{"tests":[]}`, w)
+}
+
+func TestFlakyTestsHandler_InvalidInput_ReturnsError(t *testing.T) {
+	wh := Handlers{
+		HandlersConfig:          HandlersConfig{Search2API: &mock_search.API{}},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	}
+
+	test := func(name, url string) {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, url, nil)
+			wh.FlakyTestsHandler(w, r)
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		})
+	}
+	test("missing corpus", "/json/v1/flaky")
+	test("unknown sort", "/json/v1/flaky?corpus=the_corpus&sort=alphabetical")
+	test("non-numeric limit", "/json/v1/flaky?corpus=the_corpus&limit=abc")
+	test("negative limit", "/json/v1/flaky?corpus=the_corpus&limit=-2")
+}
+
+func TestDigestOccurrencesHandler_ValidInput_CorrectJSONReturned(t *testing.T) {
+	ms := &mock_search.API{}
+	ms.On("GetDigestOccurrences", testutils.AnyContext, frontend.DigestOccurrencesQuery{
+		Digest:             "0123456789abcdef0123456789abcdef",
+		IncludeChangelists: true,
+	}).Return(frontend.DigestOccurrencesResponse{
+		Digest: "0123456789abcdef0123456789abcdef",
+		Traces: []frontend.TraceOccurrence{{
+			TraceID:     "aaaa",
+			Grouping:    paramtools.Params{types.CorpusField: "the_corpus", types.PrimaryKeyField: "alpha"},
+			Params:      paramtools.Params{types.CorpusField: "the_corpus", types.PrimaryKeyField: "alpha", "os": "Android"},
+			Occurrences: 2,
+			FirstCommit: frontend.Commit{ID: "0001", Hash: "a1", Author: "user", Subject: "first", CommitTime: 100},
+			LastCommit:  frontend.Commit{ID: "0002", Hash: "a2", Author: "user", Subject: "second", CommitTime: 200},
+			AtHead:      true,
+		}},
+		Changelists: []frontend.ChangelistOccurrence{{
+			System:        "gerrit",
+			ChangelistID:  "123",
+			ChangelistURL: "https://example.com/123",
+			Owner:         "user",
+			Subject:       "Fix alpha",
+			PatchsetID:    "ps1",
+			PatchsetOrder: 1,
+			Grouping:      paramtools.Params{types.CorpusField: "the_corpus", types.PrimaryKeyField: "beta"},
+			Traces:        3,
+		}},
+	}, nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			Search2API: ms,
 		},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsEditor(t).alogin,
 	}
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodGet, requestURL, nil)
-	wh.GroupingsHandler(w, r)
-	const expectedJSON = `{"grouping_param_keys_by_corpus":{"corners":["name","source_type"],"round":["name","source_type","os"],"text":["name","source_type","device"]}}`
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/digest/occurrences?digest=0123456789abcdef0123456789abcdef&include_changelists=true", nil)
+	wh.DigestOccurrencesHandler(w, r)
+	const expectedJSON = `{"digest":"0123456789abcdef0123456789abcdef","traces":[{"trace_id":"aaaa",` +
+		`"grouping":{"name":"alpha","source_type":"the_corpus"},` +
+		`"params":{"name":"alpha","os":"Android","source_type":"the_corpus"},"occurrences":2,` +
+		`"first_commit":{"commit_time":100,"id":"0001","hash":"a1","author":"user","message":"first","cl_url":""},` +
+		`"last_commit":{"commit_time":200,"id":"0002","hash":"a2","author":"user","message":"second","cl_url":""},` +
+		`"at_head":true,"ignored":false}],` +
+		`"changelists":[{"system":"gerrit","changelist_id":"123","cl_url":"https://example.com/123",` +
+		`"owner":"user","subject":"Fix alpha","patchset_id":"ps1","patchset_order":1,` +
+		`"grouping":{"name":"beta","source_type":"the_corpus"},"traces":3}]}`
 	assertJSONResponseWas(t, http.StatusOK, expectedJSON, w)
 }
 
-func TestGroupingsHandler_EmptyStatusCache_Success(t *testing.T) {
+func TestDigestOccurrencesHandler_InvalidDigest_ReturnsError(t *testing.T) {
 	wh := Handlers{
-		HandlersConfig: HandlersConfig{
-			GroupingParamKeysByCorpus: map[string][]string{
-				dks.TextCorpus: {types.PrimaryKeyField, types.CorpusField, dks.DeviceKey},
-			},
+		HandlersConfig:          HandlersConfig{Search2API: &mock_search.API{}},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	}
+
+	test := func(name, url string) {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, url, nil)
+			wh.DigestOccurrencesHandler(w, r)
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		})
+	}
+	test("missing digest", "/json/v1/digest/occurrences")
+	test("invalid digest", "/json/v1/digest/occurrences?digest=not-a-digest")
+}
+
+func TestDigestOccurrencesHandler_RestrictedCorpus_OccurrencesRemoved(t *testing.T) {
+	const digest = types.Digest("0123456789abcdef0123456789abcdef")
+	squareGrouping := paramtools.Params{types.CorpusField: dks.CornersCorpus, types.PrimaryKeyField: dks.SquareTest}
+	circleGrouping := paramtools.Params{types.CorpusField: dks.RoundCorpus, types.PrimaryKeyField: dks.CircleTest}
+	ms := &mock_search.API{}
+	ms.On("GetDigestOccurrences", testutils.AnyContext, frontend.DigestOccurrencesQuery{
+		Digest:             digest,
+		IncludeChangelists: true,
+	}).Return(frontend.DigestOccurrencesResponse{
+		Digest: digest,
+		Traces: []frontend.TraceOccurrence{
+			{TraceID: "aaaa", Grouping: circleGrouping},
+			{TraceID: "bbbb", Grouping: squareGrouping},
 		},
-		statusCache: frontend.GUIStatus{
-			CorpStatus: []frontend.GUICorpusStatus{},
+		Changelists: []frontend.ChangelistOccurrence{
+			{ChangelistID: "123", Grouping: circleGrouping},
+		},
+	}, nil)
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			Search2API:   ms,
+			CorpusAccess: restrictRoundCorpus(t),
 		},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
 	}
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodGet, requestURL, nil)
-	wh.GroupingsHandler(w, r)
-	const expectedJSON = `{"grouping_param_keys_by_corpus":{"text":["name","source_type","device"]}}`
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/digest/occurrences?digest=0123456789abcdef0123456789abcdef&include_changelists=true", nil)
+	wh.DigestOccurrencesHandler(w, r)
+	const expectedJSON = `{"digest":"0123456789abcdef0123456789abcdef","traces":[{"trace_id":"bbbb",` +
+		`"grouping":{"name":"square","source_type":"corners"},"params":null,"occurrences":0,` +
+		`"first_commit":{"commit_time":0,"id":"","hash":"","author":"","message":"","cl_url":""},` +
+		`"last_commit":{"commit_time":0,"id":"","hash":"","author":"","message":"","cl_url":""},` +
+		`"at_head":false,"ignored":false}],"changelists":[]}`
 	assertJSONResponseWas(t, http.StatusOK, expectedJSON, w)
 }
 
@@ -1580,6 +2584,180 @@ func TestPatchsetsAndTryjobsForCL2_InvalidCL_ReturnsErrorCode(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 }
 
+func TestComparePatchsetsHandler_TwoPatchsetsOfSameCL_Success(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			DB: db,
+			ReviewSystems: []clstore.ReviewSystem{
+				{ID: dks.GerritInternalCRS},
+			},
+		},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsEditor(t).alogin,
+	}
+
+	w := httptest.NewRecorder()
+	// The latest patchset (4) is compared against the first one.
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/changelist/gerrit-internal/CL_new_tests/compare?base_patchset=1", nil)
+	r = setChiURLParams(r, map[string]string{
+		"system": dks.GerritInternalCRS,
+		"id":     dks.ChangelistIDThatAddsNewTests,
+	})
+	wh.ComparePatchsetsHandler(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp frontend.PatchsetComparisonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	grouping := func(corpus, test string) paramtools.Params {
+		return paramtools.Params{types.CorpusField: corpus, types.PrimaryKeyField: test}
+	}
+	square := grouping(dks.CornersCorpus, dks.SquareTest)
+	circle := grouping(dks.RoundCorpus, dks.CircleTest)
+	roundRect := grouping(dks.RoundCorpus, dks.RoundRectTest)
+	seven := grouping(dks.TextCorpus, dks.SevenTest)
+	assert.Equal(t, frontend.PatchsetComparisonResponse{
+		Patchset: frontend.PatchsetRef{
+			CodeReviewSystem: dks.GerritInternalCRS,
+			ChangelistID:     dks.ChangelistIDThatAddsNewTests,
+			PatchsetID:       dks.PatchsetIDAddsNewCorpusAndTest,
+			Order:            4,
+		},
+		BasePatchset: frontend.PatchsetRef{
+			CodeReviewSystem: dks.GerritInternalCRS,
+			ChangelistID:     dks.ChangelistIDThatAddsNewTests,
+			PatchsetID:       dks.PatchsetIDAddsNewCorpus,
+			Order:            1,
+		},
+		Added: []frontend.PatchsetComparisonDigest{
+			// The walleye only produced data on the second patchset.
+			{Grouping: square, Digest: dks.DigestA02Pos, Label: expectations.Positive},
+			{Grouping: square, Digest: dks.DigestA07Pos, Label: expectations.Positive},
+			{Grouping: circle, Digest: dks.DigestC01Pos, Label: expectations.Positive},
+			{Grouping: circle, Digest: dks.DigestC02Pos, Label: expectations.Positive},
+			// The round rect test was added on the second patchset.
+			{Grouping: roundRect, Digest: dks.DigestE01Pos_CL, Label: expectations.Positive},
+			{Grouping: roundRect, Digest: dks.DigestE02Pos_CL, Label: expectations.Positive},
+			{Grouping: roundRect, Digest: dks.DigestE03Unt_CL, Label: expectations.Untriaged},
+			// The text test was fixed on the second patchset.
+			{Grouping: seven, Digest: dks.DigestD01Pos_CL, Label: expectations.Positive},
+		},
+		Removed: []frontend.PatchsetComparisonDigest{
+			{Grouping: seven, Digest: dks.DigestBlank, BaseLabel: expectations.Untriaged},
+		},
+		// Both patchsets belong to the same CL, so the labels are the same.
+		Changed: []frontend.PatchsetComparisonDigest{},
+	}, resp)
+}
+
+func TestComparePatchsetsHandler_RestrictedCorpus_OnlyViewableDigestsReturned(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			DB: db,
+			ReviewSystems: []clstore.ReviewSystem{
+				{ID: dks.GerritInternalCRS},
+			},
+			CorpusAccess: restrictRoundCorpus(t),
+		},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsNotLoggedIn(t).alogin,
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v1/changelist/gerrit-internal/CL_new_tests/compare?base_patchset=1", nil)
+	r = setChiURLParams(r, map[string]string{
+		"system": dks.GerritInternalCRS,
+		"id":     dks.ChangelistIDThatAddsNewTests,
+	})
+	wh.ComparePatchsetsHandler(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp frontend.PatchsetComparisonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	square := paramtools.Params{types.CorpusField: dks.CornersCorpus, types.PrimaryKeyField: dks.SquareTest}
+	seven := paramtools.Params{types.CorpusField: dks.TextCorpus, types.PrimaryKeyField: dks.SevenTest}
+	assert.Equal(t, []frontend.PatchsetComparisonDigest{
+		{Grouping: square, Digest: dks.DigestA02Pos, Label: expectations.Positive},
+		{Grouping: square, Digest: dks.DigestA07Pos, Label: expectations.Positive},
+		{Grouping: seven, Digest: dks.DigestD01Pos_CL, Label: expectations.Positive},
+	}, resp.Added)
+	assert.Equal(t, []frontend.PatchsetComparisonDigest{
+		{Grouping: seven, Digest: dks.DigestBlank, BaseLabel: expectations.Untriaged},
+	}, resp.Removed)
+	assert.Empty(t, resp.Changed)
+}
+
+func TestComparePatchsetsHandler_InvalidParams_ReturnsBadRequest(t *testing.T) {
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			ReviewSystems: []clstore.ReviewSystem{
+				{ID: dks.GerritCRS},
+			},
+		},
+		anonymousExpensiveQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:                  userIsEditor(t).alogin,
+	}
+
+	test := func(name, target, crs string) {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, target, nil)
+			r = setChiURLParams(r, map[string]string{
+				"system": crs,
+				"id":     "my_cl",
+			})
+			wh.ComparePatchsetsHandler(w, r)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+	test("invalid CRS", "/json/v1/changelist/nope/my_cl/compare?base_patchset=1", "nope")
+	test("invalid base CRS", "/json/v1/changelist/gerrit/my_cl/compare?base_crs=nope", dks.GerritCRS)
+	test("invalid patchset", "/json/v1/changelist/gerrit/my_cl/compare?patchset=two", dks.GerritCRS)
+	test("negative base patchset", "/json/v1/changelist/gerrit/my_cl/compare?base_patchset=-1", dks.GerritCRS)
+	test("same patchset", "/json/v1/changelist/gerrit/my_cl/compare?patchset=2&base_patchset=2", dks.GerritCRS)
+	test("no base", "/json/v1/changelist/gerrit/my_cl/compare", dks.GerritCRS)
+}
+
+func TestComparePatchsetDigests_AddedRemovedAndChangedDigests(t *testing.T) {
+	groupingA := paramtools.Params{types.CorpusField: "corpus", types.PrimaryKeyField: "alpha"}
+	groupingB := paramtools.Params{types.CorpusField: "corpus", types.PrimaryKeyField: "beta"}
+	key := func(groupingID byte, digest types.Digest) patchsetDigest {
+		return patchsetDigest{groupingID: schema.MD5Hash{groupingID}, digest: digest}
+	}
+
+	digests := map[patchsetDigest]labeledDigest{
+		key(1, "aa"): {grouping: groupingA, label: expectations.Positive},
+		key(1, "bb"): {grouping: groupingA, label: expectations.Positive},
+		key(2, "dd"): {grouping: groupingB, label: expectations.Untriaged},
+		key(2, "cc"): {grouping: groupingB, label: expectations.Untriaged},
+	}
+	baseDigests := map[patchsetDigest]labeledDigest{
+		key(1, "aa"): {grouping: groupingA, label: expectations.Positive},
+		key(1, "bb"): {grouping: groupingA, label: expectations.Untriaged},
+		key(2, "ee"): {grouping: groupingB, label: expectations.Negative},
+	}
+
+	assert.Equal(t, frontend.PatchsetComparisonResponse{
+		Added: []frontend.PatchsetComparisonDigest{
+			{Grouping: groupingB, Digest: "cc", Label: expectations.Untriaged},
+			{Grouping: groupingB, Digest: "dd", Label: expectations.Untriaged},
+		},
+		Removed: []frontend.PatchsetComparisonDigest{
+			{Grouping: groupingB, Digest: "ee", BaseLabel: expectations.Negative},
+		},
+		Changed: []frontend.PatchsetComparisonDigest{
+			{Grouping: groupingA, Digest: "bb", Label: expectations.Positive, BaseLabel: expectations.Untriaged},
+		},
+	}, comparePatchsetDigests(digests, baseDigests))
+}
+
 func TestTriageLogHandler_PrimaryBranch_Success(t *testing.T) {
 	ctx := context.Background()
 	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
@@ -1681,6 +2859,62 @@ func TestTriageLogHandler_InvalidChangelist_ReturnsEmptyEntries(t *testing.T) {
 	assertJSONResponseWas(t, http.StatusOK, expectedJSON, w)
 }
 
+func TestTriageLogHandler_RestrictedCorpus_OnlyViewableChangesReturned(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			DB:           db,
+			CorpusAccess: restrictRoundCorpus(t),
+		},
+		anonymousCheapQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:              userIsNotLoggedIn(t).alogin,
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/json/v2/triagelog", nil)
+	wh.TriageLogHandler(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp frontend.TriageLogResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	// The two entries which triaged the round corpus are hidden.
+	assert.Len(t, resp.Entries, 9)
+	for _, entry := range resp.Entries {
+		for _, d := range entry.Details {
+			assert.NotEqual(t, dks.RoundCorpus, d.Grouping[types.CorpusField])
+		}
+	}
+}
+
+func TestTriageUndoHandler_ViewerOfRestrictedCorpus_403Returned(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			DB:           db,
+			CorpusAccess: restrictRoundCorpus(t),
+		},
+		anonymousCheapQuota: rate.NewLimiter(rate.Inf, 1),
+		alogin:              userIsEditor(t).alogin,
+	}
+
+	// This record triaged two digests of the circle test, in the round corpus.
+	const recordID = "94a63df2-33d3-97ad-f4d7-341f76ff8cb6"
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/json/v2/triagelog/undo?id="+recordID, nil)
+	wh.TriageUndoHandler(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+
+	var label schema.ExpectationLabel
+	row := db.QueryRow(ctx, `SELECT label FROM Expectations WHERE digest = $1`, d(dks.DigestC01Pos))
+	require.NoError(t, row.Scan(&label))
+	assert.Equal(t, schema.LabelPositive, label)
+}
+
 func TestUndoExpectationChanges_ExistingRecordOnPrimaryBranch_Success(t *testing.T) {
 	ctx := context.Background()
 	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
@@ -2882,6 +4116,53 @@ func TestTriage3_BulkTriageOnOpenCL_Success(t *testing.T) {
 	}}, newDeltas)
 }
 
+func TestTriage3_OnOpenCLWithPatchset_PatchsetRecorded(t *testing.T) {
+	ctx := context.Background()
+	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
+	require.NoError(t, sqltest.BulkInsertDataTables(ctx, db, dks.Build()))
+
+	const user = "single_triage@example.com"
+	fakeNow := time.Date(2021, time.July, 4, 4, 4, 4, 0, time.UTC)
+	expectedBranch := "gerrit_CL_fix_ios"
+	expectedPatchset := "gerrit_" + dks.PatchSetIDFixesIPadButNotIPhone
+
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			DB: db,
+		},
+	}
+
+	tr := frontend.TriageRequestV3{
+		Deltas: []frontend.TriageDelta{{
+			Grouping: paramtools.Params{
+				types.CorpusField:     dks.RoundCorpus,
+				types.PrimaryKeyField: dks.CircleTest,
+			},
+			Digest:      dks.DigestC03Unt,
+			LabelBefore: expectations.Untriaged,
+			LabelAfter:  expectations.Positive,
+		}},
+		CodeReviewSystem: dks.GerritCRS,
+		ChangelistID:     dks.ChangelistIDThatAttemptsToFixIOS,
+		PatchsetID:       dks.PatchSetIDFixesIPadButNotIPhone,
+	}
+	ctx = now.TimeTravelingContext(fakeNow)
+	tsBeforeTriage := time.Now()
+	res, err := wh.triage3(ctx, user, tr)
+	require.NoError(t, err)
+	assert.Equal(t, frontend.TriageResponse{Status: frontend.TriageResponseStatusOK}, res)
+
+	_, newRecords := sqltest.GetRowChanges[schema.ExpectationRecordRow](ctx, t, db, "ExpectationRecords", tsBeforeTriage)
+	assert.Equal(t, []schema.ExpectationRecordRow{{
+		ExpectationRecordID: newRecords[0].ExpectationRecordID, // Randomly generated.
+		BranchName:          &expectedBranch,
+		PatchsetID:          &expectedPatchset,
+		UserName:            user,
+		TriageTime:          fakeNow,
+		NumChanges:          1,
+	}}, newRecords)
+}
+
 func TestTriage3_BulkTriageOnLandedCL_Error(t *testing.T) {
 	ctx := context.Background()
 	db := sqltest.NewCockroachDBForTestsWithProductionSchema(ctx, t)
@@ -3585,3 +4866,47 @@ func initCaches(handlers *Handlers) *Handlers {
 func overwriteNow(r *http.Request, fakeNow time.Time) *http.Request {
 	return r.WithContext(context.WithValue(r.Context(), now.ContextKey, fakeNow))
 }
+
+func TestDetailsHandler_RestrictedCorpus_403Returned(t *testing.T) {
+	wh := Handlers{
+		anonymousCheapQuota: rate.NewLimiter(rate.Inf, 1),
+		HandlersConfig: HandlersConfig{
+			Search2API:   &mock_search.API{},
+			CorpusAccess: restrictRoundCorpus(t),
+		},
+		alogin: userIsNotLoggedIn(t).alogin,
+	}
+	reqBytes, err := json.Marshal(frontend.DetailsRequest{
+		Grouping: paramtools.Params{types.CorpusField: dks.RoundCorpus, types.PrimaryKeyField: dks.CircleTest},
+		Digest:   dks.DigestC01Pos,
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/json/v2/details", bytes.NewReader(reqBytes))
+	wh.DetailsHandler(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+}
+
+func TestTriageHandlerV3_ViewerOfRestrictedCorpus_403Returned(t *testing.T) {
+	wh := Handlers{
+		HandlersConfig: HandlersConfig{
+			CorpusAccess: restrictRoundCorpus(t),
+		},
+		alogin: userIsEditor(t).alogin,
+	}
+	reqBytes, err := json.Marshal(frontend.TriageRequestV3{
+		Deltas: []frontend.TriageDelta{{
+			Grouping:    paramtools.Params{types.CorpusField: dks.RoundCorpus, types.PrimaryKeyField: dks.CircleTest},
+			Digest:      dks.DigestC01Pos,
+			LabelBefore: expectations.Positive,
+			LabelAfter:  expectations.Negative,
+		}},
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/json/v3/triage", bytes.NewReader(reqBytes))
+	wh.TriageHandlerV3(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+}
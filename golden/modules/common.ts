@@ -22,28 +22,43 @@ export function humanReadableQuery(queryStr: string): string {
 const imagePrefix = '/img/images';
 const diffPrefix = '/img/diffs';
 
+/**
+ * The sizes, in pixels, that the server can downscale images to. A size limits the length of the
+ * longest side of the image. See golden/go/image/pyramid.
+ */
+export type ImageSize = 256 | 512 | 1024;
+
+/** Appends the size query parameter to an image path, if a size is given. */
+function withSize(path: string, size?: ImageSize): string {
+  return size ? `${path}?size=${size}` : path;
+}
+
 /**
  * Returns a link to the PNG image associated with the given digest.
  * @param digest {string}
+ * @param size {ImageSize} Optional, omit to get the full-size image.
  * @return {string}
  */
-export function digestImagePath(digest: string): string {
+export function digestImagePath(digest: string, size?: ImageSize): string {
   if (!digest) {
     return '';
   }
 
-  return `${imagePrefix}/${digest}.png`;
+  return withSize(`${imagePrefix}/${digest}.png`, size);
 }
 
-/** Returns a link to the PNG image associated with the diff between the given digests. */
-export function digestDiffImagePath(d1: string, d2: string): string {
+/**
+ * Returns a link to the PNG image associated with the diff between the given digests. Omit size
+ * to get the full-size diff.
+ */
+export function digestDiffImagePath(d1: string, d2: string, size?: ImageSize): string {
   if (!d1 || !d2) {
     return '';
   }
   // We have a canonical diff order where we sort the two digests alphabetically then join them
   // in order.
   const order = d1 < d2 ? `${d1}-${d2}` : `${d2}-${d1}`;
-  return `${diffPrefix}/${order}.png`;
+  return withSize(`${diffPrefix}/${order}.png`, size);
 }
 
 /**
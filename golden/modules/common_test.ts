@@ -33,6 +33,12 @@ describe('digestImagePath', () => {
     expect(digestImagePath(aDigest)).to.equal('/img/images/aaab78c9711cb79197d47f448ba51338.png');
     expect(digestImagePath(bDigest)).to.equal('/img/images/bbb8b07beb4e1247c2cbafdb92b93e55.png');
   });
+
+  it('returns links to downscaled PNGs if a size is given', () => {
+    expect(digestImagePath(aDigest, 256)).to.equal(
+      '/img/images/aaab78c9711cb79197d47f448ba51338.png?size=256'
+    );
+  });
 });
 
 describe('digestDiffImagePath', () => {
@@ -44,6 +50,12 @@ describe('digestDiffImagePath', () => {
       '/img/diffs/aaab78c9711cb79197d47f448ba51338-bbb8b07beb4e1247c2cbafdb92b93e55.png'
     );
   });
+
+  it('returns links to downscaled diffs if a size is given', () => {
+    expect(digestDiffImagePath(aDigest, bDigest, 512)).to.equal(
+      '/img/diffs/aaab78c9711cb79197d47f448ba51338-bbb8b07beb4e1247c2cbafdb92b93e55.png?size=512'
+    );
+  });
 });
 
 describe('detailHref', () => {
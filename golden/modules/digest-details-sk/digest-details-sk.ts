@@ -160,6 +160,7 @@ export class DigestDetailsSk extends ElementSk {
             .value=${ele._details.status}
             .readOnly=${disallowTriaging}>
           </triage-sk>
+          ${DigestDetailsSk.suggestionTemplate(ele, disallowTriaging)}
           ${DigestDetailsSk.triageHistoryTemplate(ele)} ${disallowTriagingMessage}
         </div>
       `;
@@ -203,6 +204,7 @@ export class DigestDetailsSk extends ElementSk {
       <div class="metrics_and_triage">
         ${diffPageLinkTemplate}
         <div class="size_warning" ?hidden=${!ele.right.dimDiffer}>Images differ in size!</div>
+        <div class="negligible" ?hidden=${!ele.right.negligible}>Negligible difference</div>
         <div class="metric">
           <span>Diff metric:</span>
           <span>${ele.right.combinedMetric.toFixed(3)}</span>
@@ -224,11 +226,28 @@ export class DigestDetailsSk extends ElementSk {
           .value=${ele._details.status}
           .readOnly=${disallowTriaging}>
         </triage-sk>
+        ${DigestDetailsSk.suggestionTemplate(ele, disallowTriaging)}
         ${DigestDetailsSk.triageHistoryTemplate(ele)} ${disallowTriagingMessage}
       </div>
     `;
   };
 
+  private static suggestionTemplate = (ele: DigestDetailsSk, disallowTriaging: boolean) => {
+    const suggestion = ele._details.suggestion;
+    if (!suggestion || disallowTriaging || ele._details.status !== 'untriaged') return '';
+
+    return html`
+      <div
+        class="suggestion"
+        title="The closest ${suggestion.label} digest is ${suggestion.closestDigest}">
+        Probably ${suggestion.label} (${Math.round(suggestion.confidence * 100)}% confidence)
+        <button class="accept_suggestion" @click=${() => ele.setTriaged(suggestion.label)}>
+          Accept suggestion
+        </button>
+      </div>
+    `;
+  };
+
   private static triageHistoryTemplate = (ele: DigestDetailsSk) => {
     if (!ele._details.triage_history || ele._details.triage_history.length === 0) return '';
 
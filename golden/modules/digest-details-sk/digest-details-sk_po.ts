@@ -57,6 +57,14 @@ export class DigestDetailsSkPO extends PageObject {
     return this.bySelector('.metrics_and_triage .triage-history');
   }
 
+  private get suggestion(): PageObjectElement {
+    return this.bySelector('.metrics_and_triage .suggestion');
+  }
+
+  private get acceptSuggestionBtn(): PageObjectElement {
+    return this.bySelector('.metrics_and_triage button.accept_suggestion');
+  }
+
   private get toggleReferenceBtn(): PageObjectElement {
     return this.bySelector('button.toggle_ref');
   }
@@ -114,6 +122,18 @@ export class DigestDetailsSkPO extends PageObject {
     return this.triageHistory.innerText;
   }
 
+  async isSuggestionVisible(): Promise<boolean> {
+    return !(await this.suggestion.isEmpty());
+  }
+
+  getSuggestion(): Promise<string> {
+    return this.suggestion.innerText;
+  }
+
+  async clickAcceptSuggestionBtn() {
+    await this.acceptSuggestionBtn.click();
+  }
+
   async clickToggleReferenceBtn() {
     await this.toggleReferenceBtn.click();
   }
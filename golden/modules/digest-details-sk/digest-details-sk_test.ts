@@ -8,7 +8,12 @@ import {
   noEventPromise,
   setUpElementUnderTest,
 } from '../../../infra-sk/modules/test_util';
-import { twoHundredCommits, typicalDetails, typicalDetailsDisallowTriaging } from './test_data';
+import {
+  twoHundredCommits,
+  typicalDetails,
+  typicalDetailsDisallowTriaging,
+  untriagedWithSuggestion,
+} from './test_data';
 import { DigestDetailsSk } from './digest-details-sk';
 import { DigestDetailsSkPO } from './digest-details-sk_po';
 import { Label, TriageRequestV3, TriageResponse } from '../rpc_types';
@@ -218,6 +223,64 @@ describe('digest-details-sk', () => {
     });
   });
 
+  describe('layout with a triage suggestion', () => {
+    beforeEach(() => {
+      digestDetailsSk.groupings = deepCopy(groupingsResponse);
+      digestDetailsSk.details = deepCopy(untriagedWithSuggestion);
+      digestDetailsSk.commits = deepCopy(twoHundredCommits);
+    });
+
+    afterEach(() => {
+      fetchMock.reset();
+    });
+
+    it('shows the suggestion', async () => {
+      expect(await digestDetailsSkPO.isSuggestionVisible()).to.be.true;
+      expect(await digestDetailsSkPO.getSuggestion()).to.contain(
+        'Probably positive (75% confidence)'
+      );
+    });
+
+    it('triages the digest with the suggested label when accepted', async () => {
+      const triageRequest: TriageRequestV3 = {
+        deltas: [
+          {
+            grouping: {
+              source_type: 'infra',
+              name: 'dots-legend-sk_too-many-digests',
+            },
+            digest: '6246b773851984c726cb2e1cb13510c2',
+            label_before: 'untriaged',
+            label_after: 'positive',
+          },
+        ],
+      };
+      const triageResponse: TriageResponse = { status: 'ok' };
+      fetchMock.post(
+        { url: '/json/v3/triage', body: triageRequest },
+        { status: 200, body: triageResponse }
+      );
+
+      const triageEventPromise = eventPromise<CustomEvent<Label>>('triage');
+      const endPromise = eventPromise('end-task');
+      await digestDetailsSkPO.clickAcceptSuggestionBtn();
+      expect((await triageEventPromise).detail).to.equal('positive');
+      await endPromise;
+      expect(fetchMock.done()).to.be.true;
+
+      // The suggestion is no longer relevant once the digest is triaged.
+      expect(await digestDetailsSkPO.triageSkPO.getLabel()).to.equal('positive');
+      expect(await digestDetailsSkPO.isSuggestionVisible()).to.be.false;
+    });
+
+    it('does not show the suggestion if triaging is disallowed', async () => {
+      const details = deepCopy(untriagedWithSuggestion);
+      details.paramset.disallow_triaging = ['true'];
+      digestDetailsSk.details = details;
+      expect(await digestDetailsSkPO.isSuggestionVisible()).to.be.false;
+    });
+  });
+
   describe('layout with changelist id, positive and negative references', () => {
     beforeEach(() => {
       digestDetailsSk.groupings = deepCopy(groupingsResponse);
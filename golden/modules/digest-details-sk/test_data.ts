@@ -128,6 +128,17 @@ const disallowTriaging = (searchResult: SearchResult): SearchResult => {
 
 export const typicalDetailsDisallowTriaging = disallowTriaging(typicalDetails);
 
+export const untriagedWithSuggestion: SearchResult = {
+  ...deepCopy(typicalDetails),
+  status: 'untriaged',
+  triage_history: null,
+  suggestion: {
+    label: 'positive',
+    closestDigest: '99c58c7002073346ff55f446d47d6311',
+    confidence: 0.75,
+  },
+};
+
 export const negativeOnly: SearchResult = {
   test: 'dots-legend-sk_too-many-digests',
   digest: '6246b773851984c726cb2e1cb13510c2',
@@ -12,10 +12,14 @@ import { ElementSk } from '../../../infra-sk/modules/ElementSk';
 import { MultiZoomSk } from '../multi-zoom-sk/multi-zoom-sk';
 
 import '../../../elements-sk/modules/icons/open-in-new-icon-sk';
-import { digestDiffImagePath, digestImagePath } from '../common';
+import { digestDiffImagePath, digestImagePath, ImageSize } from '../common';
 
 import '../multi-zoom-sk';
 
+// Thumbnails are shown at 128x128, so request images at twice that to look sharp on high-DPI
+// displays without downloading the full-size PNGs.
+const thumbnailSize: ImageSize = 256;
+
 export interface ImageComparisonData {
   digest: string;
   title: string;
@@ -29,7 +33,10 @@ export class ImageCompareSk extends ElementSk {
         <img
           class="thumbnail ${ele._fullSizeLeftImage ? 'fullsize' : ''}"
           alt="left image"
-          src=${digestImagePath(ele.left.digest)}
+          src=${digestImagePath(
+            ele.left.digest,
+            ele._fullSizeLeftImage ? undefined : thumbnailSize
+          )}
           @click=${ele.toggleFullSizeLeftImage} />
         <figcaption>
           <span class="legend_dot"></span>
@@ -62,7 +69,9 @@ export class ImageCompareSk extends ElementSk {
       <img
         class="thumbnail diff ${ele._fullSizeDiffImage ? 'fullsize' : ''}"
         alt="diff between left and right image"
-        src=${diffSrc}
+        src=${ele._fullSizeDiffImage
+          ? diffSrc
+          : digestDiffImagePath(ele.left.digest, ele.right.digest, thumbnailSize)}
         @click=${ele.toggleFullSizeDiffImage} />
       <a target="_blank" rel="noopener" href=${diffSrc}>
         <open-in-new-icon-sk></open-in-new-icon-sk>
@@ -72,7 +81,10 @@ export class ImageCompareSk extends ElementSk {
         <img
           class="thumbnail ${ele._fullSizeRightImage ? 'fullsize' : ''}"
           alt="right image"
-          src=${digestImagePath(ele.right.digest)}
+          src=${digestImagePath(
+            ele.right.digest,
+            ele._fullSizeRightImage ? undefined : thumbnailSize
+          )}
           @click=${ele.toggleFullSizeRightImage} />
         <figcaption>
           <a target="_blank" rel="noopener" href=${ele.right.detail}>${ele.right.title}</a>
@@ -31,9 +31,9 @@ describe('image-compare-sk', () => {
 
     it('has three images (left, diff, right) with a zoom button', async () => {
       expect(await imageCompareSkPO.getImageSrcs()).to.deep.equal([
-        '/img/images/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa.png',
-        '/img/diffs/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa-bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb.png',
-        '/img/images/bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb.png',
+        '/img/images/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa.png?size=256',
+        '/img/diffs/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa-bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb.png?size=256',
+        '/img/images/bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb.png?size=256',
       ]);
       expect(await imageCompareSkPO.isZoomBtnVisible()).to.be.true;
     });
@@ -54,6 +54,13 @@ describe('image-compare-sk', () => {
       await imageCompareSkPO.clickImage(0);
       await sizeToggledEvent;
     });
+
+    it('loads the full-size image when the thumbnail is clicked', async () => {
+      await imageCompareSkPO.clickImage(0);
+      expect((await imageCompareSkPO.getImageSrcs())[0]).to.equal(
+        '/img/images/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa.png'
+      );
+    });
   });
 
   describe('layout with just left', () => {
@@ -67,7 +74,7 @@ describe('image-compare-sk', () => {
 
     it('has one image and no zoom button', async () => {
       expect(await imageCompareSkPO.getImageSrcs()).to.deep.equal([
-        '/img/images/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa.png',
+        '/img/images/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa.png?size=256',
       ]);
       expect(await imageCompareSkPO.isZoomBtnVisible()).to.be.false;
     });
@@ -59,11 +59,18 @@ export interface SRDiffDigest {
 	pixelDiffPercent: number;
 	maxRGBADiffs: number[];
 	dimDiffer: boolean;
+	negligible?: boolean;
 	digest: Digest;
 	status: Label;
 	paramset: ParamSet;
 }
 
+export interface TriageSuggestion {
+	label: Label;
+	closestDigest: Digest;
+	confidence: number;
+}
+
 export interface SearchResult {
 	digest: Digest;
 	test: TestName;
@@ -73,6 +80,7 @@ export interface SearchResult {
 	traces: TraceGroup;
 	refDiffs: { [key: string]: SRDiffDigest | null } | null;
 	closestRef: RefClosest;
+	suggestion?: TriageSuggestion | null;
 }
 
 export interface Commit {
@@ -98,6 +106,7 @@ export interface SearchResponse {
 	size: number;
 	commits: Commit[] | null;
 	bulk_triage_delta_infos: BulkTriageDeltaInfo[];
+	next_cursor?: string;
 }
 
 export interface TriageRequest {
@@ -118,6 +127,7 @@ export interface TriageRequestV3 {
 	deltas: TriageDelta[];
 	changelist_id?: string;
 	crs?: string;
+	patchset_id?: string;
 	image_matching_algorithm?: string;
 }
 
@@ -133,9 +143,15 @@ export interface TriageResponse {
 	conflict?: TriageConflict;
 }
 
+export interface Thresholds {
+	max_rgba_diff: number;
+	max_pixel_diff_percent: number;
+}
+
 export interface GUICorpusStatus {
 	name: string;
 	untriagedCount: number;
+	diffThresholds?: Thresholds;
 }
 
 export interface StatusResponse {
@@ -165,6 +181,61 @@ export interface ByBlameResponse {
 	data: ByBlameEntry[] | null;
 }
 
+export interface TriageQueueEntry {
+	test: TestName;
+	digest: Digest;
+}
+
+export interface TriageQueueResponse {
+	entries: TriageQueueEntry[] | null;
+	total: number;
+	next_cursor: string;
+	prev_cursor: string;
+}
+
+export interface FlakyTestSummary {
+	grouping: Params;
+	traces: number;
+	unique_digests: number;
+	commits_with_data: number;
+	transitions: number;
+	churn_rate: number;
+	transitions_per_commit: number;
+}
+
+export interface FlakyTestsResponse {
+	tests: FlakyTestSummary[] | null;
+}
+
+export interface TraceOccurrence {
+	trace_id: string;
+	grouping: Params;
+	params: Params;
+	occurrences: number;
+	first_commit: Commit;
+	last_commit: Commit;
+	at_head: boolean;
+	ignored: boolean;
+}
+
+export interface ChangelistOccurrence {
+	system: string;
+	changelist_id: string;
+	cl_url: string;
+	owner: string;
+	subject: string;
+	patchset_id: string;
+	patchset_order: number;
+	grouping: Params;
+	traces: number;
+}
+
+export interface DigestOccurrencesResponse {
+	digest: Digest;
+	traces: TraceOccurrence[] | null;
+	changelists: ChangelistOccurrence[] | null;
+}
+
 export interface TriageLogEntry {
 	id: string;
 	name: string;
@@ -294,3 +365,7 @@ export type Params = { [key: string]: string };
 export type ClosestDiffLabel = 'none' | 'untriaged' | 'positive' | 'negative';
 
 export type TriageResponseStatus = 'ok' | 'conflict';
+
+export type TriageQueueOrder = 'cluster' | 'blame' | 'diff';
+
+export type FlakyTestsOrder = 'transitions_per_commit' | 'churn_rate' | 'unique_digests';
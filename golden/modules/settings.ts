@@ -6,11 +6,14 @@
  * for that, so as to demystify "where do these values come from?"
  */
 
+import { Thresholds } from './rpc_types';
+
 export interface GoldSettings {
   title?: string;
   defaultCorpus?: string;
   baseRepoURL?: string;
   customTriagingDisallowedMsg?: string;
+  diffThresholdsByCorpus?: { [corpus: string]: Thresholds };
 }
 
 function getSettings(): GoldSettings | undefined {
@@ -33,6 +36,14 @@ export function customTriagingDisallowedMsg(): string {
   return getSettings()?.customTriagingDisallowedMsg || '';
 }
 
+/**
+ * Returns the thresholds under which differences in the given corpus are considered negligible,
+ * or null if none are configured.
+ */
+export function diffThresholds(corpus: string): Thresholds | null {
+  return getSettings()?.diffThresholdsByCorpus?.[corpus] || null;
+}
+
 export function testOnlySetSettings(newSettings: GoldSettings) {
   (window as any).GoldSettings = newSettings;
 }
@@ -1,15 +1,23 @@
 // k8s_checker is an application that checks for the following and alerts if necessary:
 // * Dirty images checked into K8s config files.
 // * Dirty configs running in K8s.
+// * Images checked into K8s config files which do not exist in the registry.
+// * Images checked into K8s config files which are not pinned by digest.
+//
+// It also silences the alerts for nodes undergoing planned maintenance in
+// alert-manager.
 package main
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,8 +25,11 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"go.skia.org/infra/am/go/alertclient"
+	"go.skia.org/infra/am/go/types"
 	"go.skia.org/infra/go/auth"
 	"go.skia.org/infra/go/common"
+	"go.skia.org/infra/go/docker"
 	"go.skia.org/infra/go/git"
 	"go.skia.org/infra/go/gitiles"
 	"go.skia.org/infra/go/httputils"
@@ -26,6 +37,7 @@ import (
 	"go.skia.org/infra/go/kube/clusterconfig"
 	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
@@ -35,6 +47,7 @@ import (
 const (
 	appLabel         = "app"
 	dirtyImageSuffix = "-dirty"
+	digestPinPrefix  = "@sha256:"
 	namespaceDefault = "default"
 
 	// Metric names.
@@ -46,6 +59,7 @@ const (
 	eventsMetric                    = "k8s_events"
 	evictedPodMetric                = "evicted_pod_metric"
 	livenessMetric                  = "k8s_checker"
+	missingImageMetric              = "missing_image_metric"
 	podMaxReadyTimeMetric           = "pod_max_ready_time_s"
 	podReadyMetric                  = "pod_ready"
 	podRestartCountMetric           = "pod_restart_count"
@@ -54,10 +68,35 @@ const (
 	runningContainerHasConfigMetric = "running_container_has_config_metric"
 	staleImageMetric                = "stale_image_metric"
 	totalDiskRequestMetric          = "total_disk_requested"
+	unpinnedImageMetric             = "unpinned_image_metric"
 	podSecurityMetric               = "pod_security"
 	podUnschedulableMetric          = "pod_unschedulable"
 )
 
+const (
+	// scheduledMaintenanceLabel is set by GKE on nodes which have host
+	// maintenance scheduled. The value is the Unix timestamp of the start of
+	// the maintenance.
+	scheduledMaintenanceLabel = "cloud.google.com/scheduled-maintenance-time"
+
+	// maintenanceLookahead is how long before scheduled maintenance we start
+	// silencing the alerts for a node.
+	maintenanceLookahead = time.Hour
+
+	// maintenanceSilenceDuration is the duration of the silences created for
+	// nodes under maintenance. The silences are extended on every check for as
+	// long as the maintenance lasts.
+	maintenanceSilenceDuration = "1h"
+
+	// maintenanceSilenceSource identifies k8s-checker as the creator of
+	// maintenance silences.
+	maintenanceSilenceSource = "k8s-checker"
+
+	// nodeParam is the alert param which identifies the node an alert is
+	// about.
+	nodeParam = "node"
+)
+
 // The format of the image is expected to be:
 // "gcr.io/${PROJECT}/${APPNAME}:${DATETIME}-${USER}-${HASH:0:7}-${REPO_STATE}" (from bash/docker_build.sh).
 var imageRegex = regexp.MustCompile(`^.+:(.+)-.+-.+-.+$`)
@@ -65,6 +104,22 @@ var imageRegex = regexp.MustCompile(`^.+:(.+)-.+-.+-.+$`)
 // allowedAppsInNamespace maps a namespace to a list of allowed applications in that namespace.
 type allowedAppsInNamespace map[string][]string
 
+// unpinnedImage is a checked in image reference which is not pinned by digest.
+type unpinnedImage struct {
+	App       string `json:"app"`
+	Container string `json:"container"`
+	Yaml      string `json:"yaml"`
+	Namespace string `json:"namespace"`
+	Image     string `json:"image"`
+}
+
+// checkReport describes the findings of a single round of checks.
+type checkReport struct {
+	Cluster        string          `json:"cluster"`
+	Repo           string          `json:"repo"`
+	UnpinnedImages []unpinnedImage `json:"unpinned_images"`
+}
+
 func main() {
 	// Flags.
 	dirtyConfigChecksPeriod := flag.Duration("dirty_config_checks_period", 2*time.Minute, "How often to check for dirty configs/images in K8s.")
@@ -73,6 +128,9 @@ func main() {
 	promPort := flag.String("prom_port", ":20000", "Metrics service address (e.g., ':20000')")
 	ignoreNamespaces := common.NewMultiStringFlag("ignore_namespace", nil, "Namespaces to ignore.")
 	namespaceAllowFilter := common.NewMultiStringFlag("namespace_allow_filter", nil, "app names to ignore in a namespace. A namespace name, colon, list of comma separated app names. Ex: gmp-system:rule-evaluator,gmp-system:collector")
+	unpinnedImageAllowFilter := common.NewMultiStringFlag("unpinned_image_allow_filter", nil, "app names whose checked in images do not need to be pinned by a sha256 digest.")
+	checkImagesExist := flag.Bool("check_images_exist", true, "If true, verify that every image committed to the K8s config files exists in the container registry.")
+	alertManagerServer := flag.String("alert_manager_server", "", "Address of the internal alert-manager server, e.g. 'alert-manager:9000'. If set, the alerts for nodes undergoing planned maintenance are silenced.")
 
 	common.InitWithMust(
 		"k8s_checker",
@@ -109,16 +167,35 @@ func main() {
 	// Authenticated HTTP client.
 	httpClient := httputils.DefaultClientConfig().WithTokenSource(ts).With2xxOnly().Client()
 
+	// Client for the container registry.
+	var registryClient docker.Client
+	if *checkImagesExist {
+		registryClient, err = docker.NewClient(ctx)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+	}
+
+	var amClient alertclient.APIClient
+	if *alertManagerServer != "" {
+		amClient = alertclient.New(httputils.DefaultClientConfig().Client(), *alertManagerServer)
+	}
+
 	liveness := metrics2.NewLiveness(livenessMetric)
 	oldMetrics := map[metrics2.Int64Metric]struct{}{}
 	go util.RepeatCtx(ctx, *dirtyConfigChecksPeriod, func(ctx context.Context) {
-		newMetrics, err := performChecks(ctx, *cluster, clusterConfig.Repo, k8sClient, *ignoreNamespaces, gitiles.NewRepo(clusterConfig.Repo, httpClient), oldMetrics, allowedAppsByNamespace)
+		newMetrics, err := performChecks(ctx, *cluster, clusterConfig.Repo, k8sClient, *ignoreNamespaces, gitiles.NewRepo(clusterConfig.Repo, httpClient), registryClient, oldMetrics, allowedAppsByNamespace, *unpinnedImageAllowFilter)
 		if err != nil {
 			sklog.Errorf("Error when checking for dirty configs: %s", err)
 		} else {
 			liveness.Reset()
 			oldMetrics = newMetrics
 		}
+		if amClient != nil {
+			if err := silenceNodeMaintenance(ctx, k8sClient, amClient, now.Now(ctx)); err != nil {
+				sklog.Errorf("Failed to silence alerts for nodes under maintenance: %s", err)
+			}
+		}
 	})
 
 	select {}
@@ -140,6 +217,60 @@ func parseNamespaceAllowFilterFlag(namespaceAllowFilter []string) (allowedAppsIn
 	return ret, nil
 }
 
+// nodesUnderMaintenance returns the reason why each node which is undergoing,
+// or is about to undergo, planned maintenance is under maintenance, keyed by
+// node name. Nodes are under maintenance if they are cordoned, e.g. during a
+// node pool upgrade, or if GKE has scheduled host maintenance for them.
+func nodesUnderMaintenance(nodes []v1.Node, ts time.Time) map[string]string {
+	rv := map[string]string{}
+	for _, node := range nodes {
+		if node.Spec.Unschedulable {
+			rv[node.Name] = fmt.Sprintf("Node %s is cordoned.", node.Name)
+			continue
+		}
+		value, ok := node.Labels[scheduledMaintenanceLabel]
+		if !ok {
+			continue
+		}
+		unix, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			sklog.Warningf("Node %s has invalid %s label %q", node.Name, scheduledMaintenanceLabel, value)
+			continue
+		}
+		start := time.Unix(unix, 0).UTC()
+		if start.Sub(ts) <= maintenanceLookahead {
+			rv[node.Name] = fmt.Sprintf("Node %s has maintenance scheduled at %s.", node.Name, start.Format(time.RFC3339))
+		}
+	}
+	return rv
+}
+
+// silenceNodeMaintenance creates or extends a silence in alert-manager for
+// each node under maintenance.
+func silenceNodeMaintenance(ctx context.Context, k8sClient k8s.Client, amClient alertclient.APIClient, ts time.Time) error {
+	nodes, err := k8sClient.ListNodes(ctx, metav1.ListOptions{})
+	if err != nil {
+		return skerr.Wrapf(err, "listing nodes")
+	}
+	maintenance := nodesUnderMaintenance(nodes, ts)
+	names := make([]string, 0, len(maintenance))
+	for name := range maintenance {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := amClient.CreateMaintenanceSilence(types.MaintenanceSilenceRequest{
+			Source:   maintenanceSilenceSource,
+			ParamSet: paramtools.ParamSet{nodeParam: []string{name}},
+			Duration: maintenanceSilenceDuration,
+			Reason:   maintenance[name],
+		}); err != nil {
+			return skerr.Wrapf(err, "silencing node %s", name)
+		}
+	}
+	return nil
+}
+
 // fixupNamespace sets the namespace to the default, if necessary.
 func fixupNamespace(namespace string) string {
 	if namespace == "" {
@@ -337,13 +468,15 @@ func getLiveAppContainersToImages(ctx context.Context, namespace string, k8sClie
 // * Apps and containers running in K8s but not checked into the git repo.
 // * Apps and containers checked into the git repo but not running in K8s.
 // * Checks for evicted pods.
+// * Images checked into the git repo which do not exist in the registry. This
+// check is skipped if registryClient is nil.
 //
 // It takes in a map of oldMetrics, any metrics from that map that are not encountered during this
 // invocation of the function are deleted. This is done to handle the case when metric tags
 // change. Eg: liveImage in dirtyConfigMetricTags.
 // It returns a map of newMetrics, which are all the metrics that were used during this
 // invocation of the function.
-func performChecks(ctx context.Context, cluster, repo string, k8sClient k8s.Client, ignoreNamespaces []string, g *gitiles.Repo, oldMetrics map[metrics2.Int64Metric]struct{}, allowedAppsByNamespace allowedAppsInNamespace) (map[metrics2.Int64Metric]struct{}, error) {
+func performChecks(ctx context.Context, cluster, repo string, k8sClient k8s.Client, ignoreNamespaces []string, g *gitiles.Repo, registryClient docker.Client, oldMetrics map[metrics2.Int64Metric]struct{}, allowedAppsByNamespace allowedAppsInNamespace, unpinnedImageAllowedApps []string) (map[metrics2.Int64Metric]struct{}, error) {
 	sklog.Info("---------- New round of checking k8s ----------")
 	newMetrics := map[metrics2.Int64Metric]struct{}{}
 
@@ -394,6 +527,14 @@ func performChecks(ctx context.Context, cluster, repo string, k8sClient k8s.Clie
 	}
 
 	checkedInAppsToContainers := map[string]util.StringSet{}
+	report := checkReport{
+		Cluster:        cluster,
+		Repo:           repo,
+		UnpinnedImages: []unpinnedImage{},
+	}
+	// Cache the results of registry lookups, since many configs refer to the
+	// same images.
+	imageExistsCache := map[string]bool{}
 	for _, fi := range fileInfos {
 		if fi.IsDir() {
 			// Only interested in files.
@@ -421,6 +562,18 @@ func performChecks(ctx context.Context, cluster, repo string, k8sClient k8s.Clie
 				// Check if the image in the config is dirty.
 				addMetricForDirtyCommittedImage(f, repo, cluster, namespace, c.Image, newMetrics)
 
+				// Check if the image in the config is pinned by digest.
+				if addMetricForUnpinnedImage(c.Name, c.Name, f, repo, cluster, namespace, c.Image, unpinnedImageAllowedApps, newMetrics) {
+					report.UnpinnedImages = append(report.UnpinnedImages, unpinnedImage{App: c.Name, Container: c.Name, Yaml: f, Namespace: namespace, Image: c.Image})
+				}
+
+				// Check if the image in the config exists in the registry.
+				if registryClient != nil {
+					if err := addMetricForMissingImage(ctx, registryClient, f, repo, cluster, namespace, c.Image, imageExistsCache, newMetrics); err != nil {
+						sklog.Errorf("Could not add missing image metric for %s: %s", c.Image, err)
+					}
+				}
+
 				// Now add a metric for how many days old the committed image is.
 				if err := addMetricForImageAge(ctx, c.Name, c.Name, namespace, f, repo, c.Image, newMetrics); err != nil {
 					sklog.Errorf("Could not add image age metric for %s: %s", c.Name, err)
@@ -471,6 +624,18 @@ func performChecks(ctx context.Context, cluster, repo string, k8sClient k8s.Clie
 				// Check if the image in the config is dirty.
 				addMetricForDirtyCommittedImage(f, repo, cluster, namespace, committedImage, newMetrics)
 
+				// Check if the image in the config is pinned by digest.
+				if addMetricForUnpinnedImage(app, container, f, repo, cluster, namespace, committedImage, unpinnedImageAllowedApps, newMetrics) {
+					report.UnpinnedImages = append(report.UnpinnedImages, unpinnedImage{App: app, Container: container, Yaml: f, Namespace: namespace, Image: committedImage})
+				}
+
+				// Check if the image in the config exists in the registry.
+				if registryClient != nil {
+					if err := addMetricForMissingImage(ctx, registryClient, f, repo, cluster, namespace, committedImage, imageExistsCache, newMetrics); err != nil {
+						sklog.Errorf("Could not add missing image metric for %s: %s", committedImage, err)
+					}
+				}
+
 				// Check if the config specifies ephemeral disk requests.
 				diskRequestMetricTags := map[string]string{
 					"app":       app,
@@ -565,6 +730,11 @@ func performChecks(ctx context.Context, cluster, repo string, k8sClient k8s.Clie
 		sklog.Infof("Checked in apps to containers: %s", string(b))
 	}
 
+	b, err = json.MarshalIndent(report, "", "  ")
+	if err == nil {
+		sklog.Infof("Report: %s", string(b))
+	}
+
 	// Find out which apps and containers are live but not found in git repo.
 	for namespace, liveAppContainerToImages := range liveAppContainerToImagesByNamespace {
 		for liveApp := range liveAppContainerToImages {
@@ -643,6 +813,88 @@ func addMetricForDirtyCommittedImage(yaml, repo, cluster, namespace, committedIm
 	}
 }
 
+// isPinnedByDigest returns true if the given image reference includes a sha256
+// digest, e.g. "gcr.io/skia-public/app@sha256:..." or
+// "gcr.io/skia-public/app:tag@sha256:...". Tags can be moved in the registry,
+// so only digests identify the exact image which will be deployed.
+func isPinnedByDigest(image string) bool {
+	return strings.Contains(image, digestPinPrefix)
+}
+
+// addMetricForUnpinnedImage creates a metric for if the committed image is not
+// pinned by digest, and adds it to the metrics map. Apps in allowedApps are
+// never flagged. Returns true if the image was flagged.
+func addMetricForUnpinnedImage(app, container, yaml, repo, cluster, namespace, committedImage string, allowedApps []string, metrics map[metrics2.Int64Metric]struct{}) bool {
+	unpinnedImageMetricTags := map[string]string{
+		"app":            app,
+		"container":      container,
+		"yaml":           yaml,
+		"repo":           repo,
+		"cluster":        cluster,
+		"namespace":      fixupNamespace(namespace),
+		"committedImage": committedImage,
+	}
+	unpinnedImageMetric := metrics2.GetInt64Metric(unpinnedImageMetric, unpinnedImageMetricTags)
+	metrics[unpinnedImageMetric] = struct{}{}
+	if isPinnedByDigest(committedImage) || util.In(app, allowedApps) {
+		unpinnedImageMetric.Update(0)
+		return false
+	}
+	sklog.Infof("%s has an image which is not pinned by digest: %s", yaml, committedImage)
+	unpinnedImageMetric.Update(1)
+	return true
+}
+
+// imageExists reports whether the given image exists in its container
+// registry.
+func imageExists(ctx context.Context, client docker.Client, image string) (bool, error) {
+	registry, repository, tagOrDigest, err := docker.SplitImage(image)
+	if err != nil {
+		return false, skerr.Wrapf(err, "parsing image %q", image)
+	}
+	if tagOrDigest == "" {
+		tagOrDigest = "latest"
+	}
+	if _, err := client.GetManifest(ctx, registry, repository, tagOrDigest); err != nil {
+		if errors.Is(err, docker.ErrNotFound) {
+			return false, nil
+		}
+		return false, skerr.Wrapf(err, "retrieving manifest for %q", image)
+	}
+	return true, nil
+}
+
+// addMetricForMissingImage creates a metric for if the committed image does
+// not exist in the container registry, and adds it to the metrics map. The
+// cache is used to avoid repeated registry lookups for the same image.
+func addMetricForMissingImage(ctx context.Context, client docker.Client, yaml, repo, cluster, namespace, committedImage string, cache map[string]bool, metrics map[metrics2.Int64Metric]struct{}) error {
+	exists, ok := cache[committedImage]
+	if !ok {
+		var err error
+		exists, err = imageExists(ctx, client, committedImage)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		cache[committedImage] = exists
+	}
+	missingImageMetricTags := map[string]string{
+		"yaml":           yaml,
+		"repo":           repo,
+		"cluster":        cluster,
+		"namespace":      fixupNamespace(namespace),
+		"committedImage": committedImage,
+	}
+	missingImageMetric := metrics2.GetInt64Metric(missingImageMetric, missingImageMetricTags)
+	metrics[missingImageMetric] = struct{}{}
+	if exists {
+		missingImageMetric.Update(0)
+	} else {
+		sklog.Infof("%s refers to an image which does not exist in the registry: %s", yaml, committedImage)
+		missingImageMetric.Update(1)
+	}
+	return nil
+}
+
 // addMetricForImageAge creates a metric for how old the specified image is, and adds it to the
 // metrics map.
 func addMetricForImageAge(ctx context.Context, app, container, namespace, yaml, repo, image string, metrics map[metrics2.Int64Metric]struct{}) error {
@@ -1,6 +1,8 @@
 // k8s_checker is an application that checks for the following and alerts if necessary:
 // * Dirty images checked into K8s config files.
 // * Dirty configs running in K8s.
+// * Images checked into K8s config files which do not exist in the registry.
+// * Images checked into K8s config files which are not pinned by digest.
 package main
 
 import (
@@ -10,8 +12,20 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	alertmocks "go.skia.org/infra/am/go/alertclient/mocks"
+	"go.skia.org/infra/am/go/silence"
+	"go.skia.org/infra/am/go/types"
+	"go.skia.org/infra/go/docker"
+	dockermocks "go.skia.org/infra/go/docker/mocks"
+	k8smocks "go.skia.org/infra/go/k8s/mocks"
 	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/testutils"
 )
 
 func TestParseNamespaceAllowFilterFlag_MalFormed_ReturnsError(t *testing.T) {
@@ -81,3 +95,177 @@ func TestAddMetricForImageAge_NameHasInvalidDateEncoded_ReturnsError(t *testing.
 	err := addMetricForImageAge(context.Background(), "my-app", "my-app-container", "my-namepspace", "my-yaml", "my-repo", invalidDate, metrics)
 	require.Error(t, err)
 }
+
+func TestAddMetricForMissingImage_ImageExists_UpdatesMetricWithAZeroValue(t *testing.T) {
+	client := dockermocks.NewClient(t)
+	client.On("GetManifest", testutils.AnyContext, "gcr.io", "skia-public/emailservice", "2022-07-06T16_08_06Z-jcgregorio-e0bf15f-clean").Return(&docker.Manifest{}, nil)
+	metrics := map[metrics2.Int64Metric]struct{}{}
+	cache := map[string]bool{}
+	image := "gcr.io/skia-public/emailservice:2022-07-06T16_08_06Z-jcgregorio-e0bf15f-clean"
+
+	err := addMetricForMissingImage(context.Background(), client, "my-yaml", "my-repo", "my-cluster", "my-namespace", image, cache, metrics)
+	require.NoError(t, err)
+
+	require.Len(t, metrics, 1)
+	for missingMetric := range metrics {
+		require.Equal(t, int64(0), missingMetric.Get())
+	}
+	require.Equal(t, map[string]bool{image: true}, cache)
+}
+
+func TestAddMetricForMissingImage_ImageDoesNotExist_UpdatesMetricWithAOneValue(t *testing.T) {
+	client := dockermocks.NewClient(t)
+	client.On("GetManifest", testutils.AnyContext, "gcr.io", "skia-public/emailservice", "typo").Return(nil, skerr.Wrap(docker.ErrNotFound))
+	metrics := map[metrics2.Int64Metric]struct{}{}
+	cache := map[string]bool{}
+	image := "gcr.io/skia-public/emailservice:typo"
+
+	err := addMetricForMissingImage(context.Background(), client, "my-yaml", "my-repo", "my-cluster", "my-namespace", image, cache, metrics)
+	require.NoError(t, err)
+
+	require.Len(t, metrics, 1)
+	for missingMetric := range metrics {
+		require.Equal(t, int64(1), missingMetric.Get())
+	}
+	require.Equal(t, map[string]bool{image: false}, cache)
+}
+
+func TestAddMetricForMissingImage_ResultIsCached_DoesNotQueryRegistry(t *testing.T) {
+	metrics := map[metrics2.Int64Metric]struct{}{}
+	image := "gcr.io/skia-public/emailservice:typo"
+	cache := map[string]bool{image: false}
+
+	// The mock has no expectations, so it fails the test if we query the
+	// registry.
+	err := addMetricForMissingImage(context.Background(), dockermocks.NewClient(t), "my-yaml", "my-repo", "my-cluster", "my-namespace", image, cache, metrics)
+	require.NoError(t, err)
+
+	require.Len(t, metrics, 1)
+	for missingMetric := range metrics {
+		require.Equal(t, int64(1), missingMetric.Get())
+	}
+}
+
+func TestImageExists_InvalidImage_ReturnsError(t *testing.T) {
+	_, err := imageExists(context.Background(), dockermocks.NewClient(t), "not a valid image")
+	require.Error(t, err)
+}
+
+func TestImageExists_NoTag_LooksUpLatest(t *testing.T) {
+	client := dockermocks.NewClient(t)
+	client.On("GetManifest", testutils.AnyContext, "gcr.io", "skia-public/emailservice", "latest").Return(&docker.Manifest{}, nil)
+
+	exists, err := imageExists(context.Background(), client, "gcr.io/skia-public/emailservice")
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestImageExists_RegistryError_ReturnsError(t *testing.T) {
+	client := dockermocks.NewClient(t)
+	client.On("GetManifest", testutils.AnyContext, "gcr.io", "skia-public/emailservice", "typo").Return(nil, skerr.Fmt("permission denied"))
+
+	_, err := imageExists(context.Background(), client, "gcr.io/skia-public/emailservice:typo")
+	require.Error(t, err)
+}
+
+var maintenanceTestTime = time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func TestAddMetricForUnpinnedImage_ImageHasTag_UpdatesMetricWithAOneValue(t *testing.T) {
+	metrics := map[metrics2.Int64Metric]struct{}{}
+	image := "gcr.io/skia-public/emailservice:2022-07-06T16_08_06Z-jcgregorio-e0bf15f-clean"
+
+	flagged := addMetricForUnpinnedImage("my-app", "my-container", "my-yaml", "my-repo", "my-cluster", "my-namespace", image, nil, metrics)
+	require.True(t, flagged)
+
+	require.Len(t, metrics, 1)
+	for unpinnedMetric := range metrics {
+		require.Equal(t, int64(1), unpinnedMetric.Get())
+	}
+}
+
+func TestAddMetricForUnpinnedImage_ImageHasDigest_UpdatesMetricWithAZeroValue(t *testing.T) {
+	test := func(name, image string) {
+		t.Run(name, func(t *testing.T) {
+			metrics := map[metrics2.Int64Metric]struct{}{}
+			flagged := addMetricForUnpinnedImage("my-app", "my-container", "my-yaml", "my-repo", "my-cluster", "my-namespace", image, nil, metrics)
+			require.False(t, flagged)
+
+			require.Len(t, metrics, 1)
+			for unpinnedMetric := range metrics {
+				require.Equal(t, int64(0), unpinnedMetric.Get())
+			}
+		})
+	}
+	test("digest only", "gcr.io/skia-public/k8s-deployer@sha256:9f506a343f3e63174384d85e4ae75a1c1d16b896122170fe7ecc282bdfbdcf2d")
+	test("tag and digest", "gcr.io/skia-public/k8s-deployer:prod@sha256:9f506a343f3e63174384d85e4ae75a1c1d16b896122170fe7ecc282bdfbdcf2d")
+}
+
+func TestAddMetricForUnpinnedImage_AppIsAllowed_UpdatesMetricWithAZeroValue(t *testing.T) {
+	metrics := map[metrics2.Int64Metric]struct{}{}
+	image := "gcr.io/skia-public/emailservice:latest"
+
+	flagged := addMetricForUnpinnedImage("my-app", "my-container", "my-yaml", "my-repo", "my-cluster", "my-namespace", image, []string{"other-app", "my-app"}, metrics)
+	require.False(t, flagged)
+
+	require.Len(t, metrics, 1)
+	for unpinnedMetric := range metrics {
+		require.Equal(t, int64(0), unpinnedMetric.Get())
+	}
+}
+
+func maintenanceTestNodes() []v1.Node {
+	return []v1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "healthy"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cordoned"},
+			Spec:       v1.NodeSpec{Unschedulable: true},
+		},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name: "maintenance-soon",
+			Labels: map[string]string{
+				scheduledMaintenanceLabel: fmt.Sprintf("%d", maintenanceTestTime.Add(30*time.Minute).Unix()),
+			},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name: "maintenance-later",
+			Labels: map[string]string{
+				scheduledMaintenanceLabel: fmt.Sprintf("%d", maintenanceTestTime.Add(24*time.Hour).Unix()),
+			},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name: "invalid-label",
+			Labels: map[string]string{
+				scheduledMaintenanceLabel: "not a timestamp",
+			},
+		}},
+	}
+}
+
+func TestNodesUnderMaintenance_CordonedAndSoonScheduledNodesReturned(t *testing.T) {
+	require.Equal(t, map[string]string{
+		"cordoned":         "Node cordoned is cordoned.",
+		"maintenance-soon": "Node maintenance-soon has maintenance scheduled at 2022-03-01T12:30:00Z.",
+	}, nodesUnderMaintenance(maintenanceTestNodes(), maintenanceTestTime))
+}
+
+func TestSilenceNodeMaintenance_CreatesSilencePerNode(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := &k8smocks.Client{}
+	k8sClient.On("ListNodes", ctx, metav1.ListOptions{}).Return(maintenanceTestNodes(), nil)
+	amClient := alertmocks.NewAPIClient(t)
+	amClient.On("CreateMaintenanceSilence", types.MaintenanceSilenceRequest{
+		Source:   maintenanceSilenceSource,
+		ParamSet: paramtools.ParamSet{nodeParam: []string{"cordoned"}},
+		Duration: maintenanceSilenceDuration,
+		Reason:   "Node cordoned is cordoned.",
+	}).Return(&silence.Silence{}, nil).Once()
+	amClient.On("CreateMaintenanceSilence", types.MaintenanceSilenceRequest{
+		Source:   maintenanceSilenceSource,
+		ParamSet: paramtools.ParamSet{nodeParam: []string{"maintenance-soon"}},
+		Duration: maintenanceSilenceDuration,
+		Reason:   "Node maintenance-soon has maintenance scheduled at 2022-03-01T12:30:00Z.",
+	}).Return(&silence.Silence{}, nil).Once()
+
+	require.NoError(t, silenceNodeMaintenance(ctx, k8sClient, amClient, maintenanceTestTime))
+	k8sClient.AssertExpectations(t)
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"flag"
+	"fmt"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -14,6 +17,8 @@ import (
 	"golang.org/x/oauth2/google"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"go.skia.org/infra/am/go/alertclient"
+	"go.skia.org/infra/am/go/types"
 	"go.skia.org/infra/go/auth"
 	"go.skia.org/infra/go/common"
 	"go.skia.org/infra/go/exec"
@@ -29,6 +34,10 @@ import (
 const (
 	livenessMetric       = "k8s_deployer"
 	gitstoreSubscriberID = "k8s-deployer"
+
+	// deploymentSource is the source of the deployment events reported to
+	// alert-manager.
+	deploymentSource = "k8s-deployer"
 )
 
 var (
@@ -50,6 +59,7 @@ func main() {
 	prune := flag.Bool("prune", false, "Whether to run 'kubectl apply' with '--prune'")
 	kubectl := flag.String("kubectl", "kubectl", "Path to the kubectl executable.")
 	k8sServer := flag.String("k8s_server", "", "Address of the Kubernetes server.")
+	alertManagerServer := flag.String("alert_manager_server", "", "Address of the alert-manager internal server, e.g. 'alert-manager:9000'. If set, the apps whose configs changed are reported to it after they are applied, so that the incidents resolved by the deployment are archived.")
 
 	common.InitWithMust(
 		"k8s_deployer",
@@ -101,15 +111,28 @@ func main() {
 		}
 	}
 
+	var amClient alertclient.APIClient
+	if *alertManagerServer != "" {
+		amClient = alertclient.New(httputils.DefaultClientConfig().Client(), *alertManagerServer)
+	}
+
 	// Apply configurations in a loop.  Note that we could respond directly to
 	// commits in the repo via GitStore and PubSub, but that would require
 	// access to BigTable, and with a relatively small interval we won't notice
 	// too much of a delay.
 	liveness := metrics2.NewLiveness(livenessMetric)
+	// applied holds the contents of the configs which were last applied
+	// successfully, or nil if none have been applied since startup.
+	var applied map[string][]byte
 	go util.RepeatCtx(ctx, *interval, func(ctx context.Context) {
-		applyErr := applyConfigs(ctx, repo, *kubectl, *k8sServer, *configSubdir, configFileRegexes, *prune, client)
+		contents, hash, applyErr := applyConfigs(ctx, repo, *kubectl, *k8sServer, *configSubdir, configFileRegexes, *prune, client)
 		if applyErr != nil {
 			sklog.Errorf("Failed to apply configs to cluster: %s", applyErr)
+		} else {
+			if amClient != nil && applied != nil {
+				reportDeployments(amClient, changedApps(applied, contents), hash, fmt.Sprintf("%s/+/%s", *configRepo, hash))
+			}
+			applied = contents
 		}
 
 		// Delete any outdated and crash-looping StatefulSet pods.  These do not
@@ -131,18 +154,20 @@ func main() {
 	httputils.RunHealthCheckServer(*port)
 }
 
-func applyConfigs(ctx context.Context, repo *gitiles.Repo, kubectl, k8sServer, configSubdir string, configFileRegexes []*regexp.Regexp, prune bool, client k8s.Client) error {
+// applyConfigs applies the configs at the head of the config repo to the
+// cluster and returns their contents, keyed by path, and the commit hash.
+func applyConfigs(ctx context.Context, repo *gitiles.Repo, kubectl, k8sServer, configSubdir string, configFileRegexes []*regexp.Regexp, prune bool, client k8s.Client) (map[string][]byte, string, error) {
 	// Download the configs from Gitiles instead of maintaining a local Git
 	// checkout, to avoid dealing with Git, persistent checkouts, etc.
 
 	// Obtain the current set of configurations for the cluster.
 	head, err := repo.Details(ctx, "main")
 	if err != nil {
-		return skerr.Wrapf(err, "failed to get most recent commit")
+		return nil, "", skerr.Wrapf(err, "failed to get most recent commit")
 	}
 	files, err := repo.ListFilesRecursiveAtRef(ctx, configSubdir, head.Hash)
 	if err != nil {
-		return skerr.Wrapf(err, "failed to list configs")
+		return nil, "", skerr.Wrapf(err, "failed to list configs")
 	}
 
 	// Read the config contents in the given directory.
@@ -182,13 +207,13 @@ func applyConfigs(ctx context.Context, repo *gitiles.Repo, kubectl, k8sServer, c
 		})
 	}
 	if err := eg.Wait(); err != nil {
-		return skerr.Wrapf(err, "failed to download configs")
+		return nil, "", skerr.Wrapf(err, "failed to download configs")
 	}
 
 	// Write the config contents to a temporary dir.
 	tmp, err := os.MkdirTemp("", "")
 	if err != nil {
-		return skerr.Wrapf(err, "failed to create temp dir")
+		return nil, "", skerr.Wrapf(err, "failed to create temp dir")
 	}
 	defer util.RemoveAll(tmp)
 
@@ -197,11 +222,11 @@ func applyConfigs(ctx context.Context, repo *gitiles.Repo, kubectl, k8sServer, c
 		dir := filepath.Dir(fullPath)
 		if _, err := os.Stat(path); os.IsNotExist(err) {
 			if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-				return skerr.Wrapf(err, "failed to create %s", dir)
+				return nil, "", skerr.Wrapf(err, "failed to create %s", dir)
 			}
 		}
 		if err := os.WriteFile(fullPath, fileContents, os.ModePerm); err != nil {
-			return skerr.Wrapf(err, "failed to create %s", fullPath)
+			return nil, "", skerr.Wrapf(err, "failed to create %s", fullPath)
 		}
 	}
 
@@ -224,7 +249,7 @@ func applyConfigs(ctx context.Context, repo *gitiles.Repo, kubectl, k8sServer, c
 	cmd = append(cmd, "-f", ".")
 	output, err := exec.RunCwd(ctx, tmp, cmd...)
 	if err != nil {
-		return skerr.Wrapf(err, "failed to apply configs: %s", output)
+		return nil, "", skerr.Wrapf(err, "failed to apply configs: %s", output)
 	}
 	sklog.Info("Output from kubectl")
 	for _, line := range strings.Split(output, "\n") {
@@ -234,7 +259,46 @@ func applyConfigs(ctx context.Context, repo *gitiles.Repo, kubectl, k8sServer, c
 			sklog.Info(line)
 		}
 	}
-	return nil
+	return contents, head.Hash, nil
+}
+
+// changedApps returns the names of the apps whose configs were added or
+// modified, sorted. The app name is the base name of the config file without
+// its extension.
+func changedApps(prev, curr map[string][]byte) []string {
+	apps := []string{}
+	for file, contents := range curr {
+		if prevContents, ok := prev[file]; ok && bytes.Equal(prevContents, contents) {
+			continue
+		}
+		app := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		if !util.In(app, apps) {
+			apps = append(apps, app)
+		}
+	}
+	sort.Strings(apps)
+	return apps
+}
+
+// reportDeployments reports the deployment of the given apps to alert-manager.
+// Failures are only logged.
+func reportDeployments(amClient alertclient.APIClient, apps []string, version, url string) {
+	for _, app := range apps {
+		resp, err := amClient.ReportDeployment(types.DeploymentEvent{
+			Source:    deploymentSource,
+			App:       app,
+			Version:   version,
+			URL:       url,
+			Timestamp: time.Now().Unix(),
+		})
+		if err != nil {
+			sklog.Errorf("Failed to report deployment of %s to alert-manager: %s", app, err)
+			continue
+		}
+		if len(resp.Archived) > 0 {
+			sklog.Infof("Deployment of %s archived %d incidents.", app, len(resp.Archived))
+		}
+	}
 }
 
 func isError(logLine string) bool {
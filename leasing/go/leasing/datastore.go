@@ -7,12 +7,15 @@ package main
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/datastore"
+	"cloud.google.com/go/storage"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 
 	"go.skia.org/infra/go/ds"
+	"go.skia.org/infra/go/ds/backup"
 	"go.skia.org/infra/leasing/go/types"
 )
 
@@ -24,6 +27,16 @@ func DatastoreInit(ctx context.Context, project string, ns string) error {
 	return ds.InitWithOpt(project, ns, option.WithTokenSource(ts))
 }
 
+// DatastoreBackupInit starts periodically backing up the namespace to the
+// given GCS bucket.
+func DatastoreBackupInit(ctx context.Context, project, ns, bucket string, period, retention time.Duration) error {
+	ts, err := google.DefaultTokenSource(ctx, "https://www.googleapis.com/auth/datastore", storage.ScopeReadWrite)
+	if err != nil {
+		return fmt.Errorf("Problem setting up default token source: %s", err)
+	}
+	return backup.StartForNamespace(ctx, project, ns, bucket, period, retention, option.WithTokenSource(ts))
+}
+
 func GetRunningDSTasks() *datastore.Iterator {
 	q := ds.NewQuery(ds.TASK).EventualConsistency().Filter("Done =", false)
 	return ds.DS.Run(context.TODO(), q)
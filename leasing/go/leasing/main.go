@@ -67,6 +67,11 @@ var (
 	namespace   = flag.String("namespace", "leasing-server", "The Cloud Datastore namespace, such as 'leasing-server'.")
 	projectName = flag.String("project_name", "google.com:skia-buildbots", "The Google Cloud project name.")
 
+	// Backup params
+	backupBucket    = flag.String("backup_bucket", "", "The GCS bucket to back up the Cloud Datastore namespace to. Backups are disabled if empty.")
+	backupPeriod    = flag.Duration("backup_period", 24*time.Hour, "How often to back up the Cloud Datastore namespace.")
+	backupRetention = flag.Duration("backup_retention", 30*24*time.Hour, "How long to keep backups of the Cloud Datastore namespace.")
+
 	poolToDetails      map[string]*types.PoolDetails
 	poolToDetailsMutex sync.Mutex
 
@@ -96,6 +101,11 @@ func New() (baseapp.App, error) {
 	if err := DatastoreInit(ctx, *projectName, *namespace); err != nil {
 		sklog.Fatalf("Failed to init cloud datastore: %s", err)
 	}
+	if *backupBucket != "" {
+		if err := DatastoreBackupInit(ctx, *projectName, *namespace, *backupBucket, *backupPeriod, *backupRetention); err != nil {
+			sklog.Fatalf("Failed to start cloud datastore backups: %s", err)
+		}
+	}
 
 	var err error
 	poolToDetails, err = GetDetailsOfAllPools(ctx)
@@ -6,11 +6,13 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/machine/go/machine"
 	"go.skia.org/infra/machine/go/machine/event/source"
+	"go.skia.org/infra/machine/go/machineserver/rpc"
 )
 
 // HTTPSource implements event.Source and http.Handler.
@@ -41,6 +43,14 @@ func (h *HTTPSource) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		httputils.ReportError(w, err, "decoding event from machine", http.StatusBadRequest)
 		return
 	}
+	version, err := rpc.NegotiateProtocolVersion(event.ProtocolVersion)
+	if err != nil {
+		h.eventReceiveFailed.Inc(1)
+		httputils.ReportError(w, err, "unsupported protocol version", http.StatusBadRequest)
+		return
+	}
+	rpc.RecordProtocolVersion("event", event.ProtocolVersion)
+	w.Header().Set(rpc.ProtocolVersionHeader, strconv.Itoa(version))
 	h.outgoing <- event
 	h.eventReceiveSuccess.Inc(1)
 }
@@ -6,12 +6,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.skia.org/infra/go/deepequal/assertdeep"
 	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machineserver/rpc"
 )
 
 func TestHTTPServer_ValidRequestTriggersEvent(t *testing.T) {
@@ -35,3 +38,36 @@ func TestHTTPServer_ValidRequestTriggersEvent(t *testing.T) {
 	eventFromCh := <-outgoing
 	assertdeep.Equal(t, eventFromCh, event)
 }
+
+func TestHTTPServer_UnversionedEvent_IsAcceptedAsPreviousVersion(t *testing.T) {
+	source, err := New()
+	require.NoError(t, err)
+	outgoing, err := source.Start(context.Background())
+	require.NoError(t, err)
+
+	// An Event from a test_machine_monitor that predates protocol versioning.
+	body := bytes.NewReader([]byte(`{"type": "raw_state", "host": {"name": "skia-rpi2-rack4-shelf1-020"}}`))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", body)
+	source.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get(rpc.ProtocolVersionHeader))
+	eventFromCh := <-outgoing
+	assert.Equal(t, "skia-rpi2-rack4-shelf1-020", eventFromCh.Host.Name)
+}
+
+func TestHTTPServer_UnsupportedProtocolVersion_ReturnsBadRequest(t *testing.T) {
+	source, err := New()
+	require.NoError(t, err)
+
+	event := machine.NewEvent()
+	event.ProtocolVersion = machine.MinSupportedProtocolVersion - 1
+	b, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", bytes.NewReader(b))
+	source.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
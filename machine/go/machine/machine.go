@@ -140,6 +140,46 @@ const (
 
 var AllAttachedDevices = []AttachedDevice{AttachedDeviceNone, AttachedDeviceAdb, AttachedDeviceIOS, AttachedDevicePyOCD, AttachedDeviceSSH}
 
+// MaintenanceReason is why a machine was put into maintenance mode.
+type MaintenanceReason string
+
+const (
+	// MaintenanceReasonUnspecified is the reason for machines that aren't in
+	// maintenance mode, or that were put into maintenance mode before reasons
+	// were recorded.
+	MaintenanceReasonUnspecified MaintenanceReason = ""
+
+	// MaintenanceReasonHardwareRepair means the machine, or its attached
+	// device, is being physically repaired or replaced.
+	MaintenanceReasonHardwareRepair MaintenanceReason = "hardware_repair"
+
+	// MaintenanceReasonOSUpdate means the OS of the machine, or its attached
+	// device, is being updated.
+	MaintenanceReasonOSUpdate MaintenanceReason = "os_update"
+
+	// MaintenanceReasonInvestigation means someone is debugging the machine,
+	// e.g. looking into flaky tasks.
+	MaintenanceReasonInvestigation MaintenanceReason = "investigation"
+
+	// MaintenanceReasonReserved means the machine has been set aside for
+	// someone's exclusive use.
+	MaintenanceReasonReserved MaintenanceReason = "reserved"
+)
+
+// AllMaintenanceReasons are all the reasons that can be given when putting a
+// machine into maintenance mode.
+var AllMaintenanceReasons = []MaintenanceReason{MaintenanceReasonHardwareRepair, MaintenanceReasonOSUpdate, MaintenanceReasonInvestigation, MaintenanceReasonReserved}
+
+// IsValid returns true if r is one of AllMaintenanceReasons.
+func (r MaintenanceReason) IsValid() bool {
+	for _, reason := range AllMaintenanceReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
 // Annotation represents a timestamped message.
 type Annotation struct {
 	Message   string
@@ -155,6 +195,11 @@ type Description struct {
 	// change.
 	MaintenanceMode string `sql:"maintenance_mode STRING NOT NULL DEFAULT ''"`
 
+	// MaintenanceReason is why the machine was put into maintenance mode. It
+	// is MaintenanceReasonUnspecified if the machine isn't in maintenance
+	// mode.
+	MaintenanceReason MaintenanceReason `sql:"maintenance_reason STRING NOT NULL DEFAULT ''"`
+
 	// IsQuarantined is true if the machine has failed too many tasks and should
 	// stop running tasks pending user intervention. Recipes/Task Drivers can
 	// write a $HOME/${SWARMING_BOT_ID}.quarantined file to move a machine into
@@ -220,6 +265,11 @@ type Description struct {
 	// machineserver during Update.
 	TaskStarted time.Time `sql:"task_started TIMESTAMPTZ NOT NULL DEFAULT (0)::TIMESTAMPTZ"`
 
+	// RowVersion is incremented by the store every time the Description is
+	// written, and is used to detect concurrent Updates of the same machine.
+	// It is not exposed to the UI.
+	RowVersion int64 `sql:"row_version INT NOT NULL DEFAULT 0" json:"-"`
+
 	// Create a computed column with the machine id to use as the primary key.
 	machineIDComputed struct{} `sql:"machine_id STRING PRIMARY KEY AS (dimensions->'id'->>0) STORED"`
 
@@ -254,6 +304,7 @@ func (d Description) InMaintenanceMode() bool {
 func DestFromDescription(d *Description) []interface{} {
 	return []interface{}{
 		&d.MaintenanceMode,
+		&d.MaintenanceReason,
 		&d.IsQuarantined,
 		&d.Recovering,
 		&d.AttachedDevice,
@@ -274,6 +325,7 @@ func DestFromDescription(d *Description) []interface{} {
 		&d.Dimensions,
 		&d.TaskRequest,
 		&d.TaskStarted,
+		&d.RowVersion,
 	}
 }
 
@@ -436,8 +488,23 @@ func (s *Standalone) IsPopulated() bool {
 	return s.Cores > 0
 }
 
+// ProtocolVersion is the version of the RPC contract between
+// test_machine_monitor and machineserver, i.e. of Event and of the Description
+// returned by the description RPC. Bump it whenever either changes in a way
+// that the previous version of the other side can't handle.
+const ProtocolVersion = 1
+
+// MinSupportedProtocolVersion is the oldest ProtocolVersion machineserver still
+// accepts, which lets the fleet be upgraded one machine at a time. Version 0
+// is a test_machine_monitor from before the protocol was versioned.
+const MinSupportedProtocolVersion = ProtocolVersion - 1
+
 // Event is the information a machine should send via Source when its local state has changed.
 type Event struct {
+	// ProtocolVersion is the ProtocolVersion of the test_machine_monitor that
+	// sent the Event.
+	ProtocolVersion int `json:"protocol_version"`
+
 	EventType           EventType  `json:"type"`
 	Android             Android    `json:"android"`
 	ChromeOS            ChromeOS   `json:"chromeos"`
@@ -457,7 +524,8 @@ type Event struct {
 // NewEvent returns a new Event instance.
 func NewEvent() Event {
 	return Event{
-		EventType: EventTypeRawState,
+		ProtocolVersion: ProtocolVersion,
+		EventType:       EventTypeRawState,
 	}
 }
 
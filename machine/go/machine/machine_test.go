@@ -70,6 +70,7 @@ func TestAsMetricsTags_MultipleValues_ReturnsTagsWithMostSpecificValues(t *testi
 
 func TestNewEvent(t *testing.T) {
 	assert.Equal(t, machine.EventTypeRawState, machine.NewEvent().EventType)
+	assert.Equal(t, machine.ProtocolVersion, machine.NewEvent().ProtocolVersion)
 }
 
 func TestNewDescription(t *testing.T) {
@@ -137,3 +138,11 @@ func TestDescription_InMaintenanceMode_ReturnsTrueIfHasMaintenanceModeMessage(t
 func TestDescription_InMaintenanceMode_ReturnsFalseIfMaintenanceModeMessageIsEmpty(t *testing.T) {
 	require.False(t, machine.Description{}.InMaintenanceMode())
 }
+
+func TestMaintenanceReasonIsValid(t *testing.T) {
+	for _, reason := range machine.AllMaintenanceReasons {
+		require.True(t, reason.IsValid(), reason)
+	}
+	require.False(t, machine.MaintenanceReasonUnspecified.IsValid())
+	require.False(t, machine.MaintenanceReason("bored").IsValid())
+}
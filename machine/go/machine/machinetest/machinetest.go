@@ -14,10 +14,11 @@ var MockDuration = int32(5) // Seconds
 // FullyFilledInDescription is a Description filled in with non-default values,
 // useful in tests that round-trip Descriptions.
 var FullyFilledInDescription = machine.Description{
-	MaintenanceMode: "jcgregorio 2022-11-08",
-	IsQuarantined:   true,
-	Recovering:      "too hot",
-	AttachedDevice:  machine.AttachedDevice(machine.AttachedDeviceAdb),
+	MaintenanceMode:   "jcgregorio 2022-11-08",
+	MaintenanceReason: machine.MaintenanceReasonHardwareRepair,
+	IsQuarantined:     true,
+	Recovering:        "too hot",
+	AttachedDevice:    machine.AttachedDevice(machine.AttachedDeviceAdb),
 	Annotation: machine.Annotation{
 		Message:   "take offline",
 		User:      "barney@example.com",
@@ -56,4 +57,5 @@ var FullyFilledInDescription = machine.Description{
 		Command: []string{"./helloworld"},
 	},
 	TaskStarted: MockTime,
+	RowVersion:  3,
 }
@@ -0,0 +1,226 @@
+// Package perfexport periodically exports selected machine.Description fields
+// to GCS as Perf ingestion files, so that things like device temperature and
+// battery level can be charted over time in Perf.
+//
+// Machines don't have commits, so each export is given the commit number of
+// its export cycle, i.e. the number of Periods since the Unix epoch, encoded
+// in the git_hash as "CP:<number>". The Perf instance ingesting the files must
+// be configured to take commit numbers from the git_hash.
+package perfexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/query"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machine/store"
+	"go.skia.org/infra/machine/go/machineserver/config"
+	"go.skia.org/infra/perf/go/ingest/format"
+)
+
+// The names of the Description fields which can be exported.
+const (
+	// FieldBattery is the battery charge in percent. Only exported for
+	// machines which report a battery level.
+	FieldBattery = "battery"
+
+	// FieldTemperature is the temperature of each of the machine's sensors in
+	// Celsius.
+	FieldTemperature = "temperature"
+
+	// FieldQuarantined is 1 if the machine is quarantined, 0 otherwise.
+	FieldQuarantined = "quarantined"
+
+	// FieldMaintenance is 1 if the machine is in maintenance mode, 0
+	// otherwise.
+	FieldMaintenance = "maintenance"
+
+	// FieldRecovering is 1 if the machine is recovering, 0 otherwise.
+	FieldRecovering = "recovering"
+
+	// FieldRunningTask is 1 if the machine is running a Swarming task, 0
+	// otherwise.
+	FieldRunningTask = "running_task"
+
+	// FieldDeviceUptime is the uptime of the attached device in seconds.
+	FieldDeviceUptime = "device_uptime"
+)
+
+// Fields are all the names of the Description fields which can be exported.
+var Fields = []string{
+	FieldBattery,
+	FieldTemperature,
+	FieldQuarantined,
+	FieldMaintenance,
+	FieldRecovering,
+	FieldRunningTask,
+	FieldDeviceUptime,
+}
+
+// Exporter writes the configured fields of every machine to GCS.
+type Exporter struct {
+	store     store.Store
+	gcsClient gcs.GCSClient
+	prefix    string
+	period    time.Duration
+
+	// fieldsByPool maps pool names to the fields exported for that pool.
+	fieldsByPool map[string][]string
+}
+
+// New returns a new Exporter for the given config. It returns an error if the
+// config is invalid.
+func New(cfg config.InstanceConfig, s store.Store, gcsClient gcs.GCSClient) (*Exporter, error) {
+	if cfg.PerfExport == nil {
+		return nil, skerr.Fmt("perf_export must be supplied in the instance config")
+	}
+	period, err := time.ParseDuration(cfg.PerfExport.Period)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "parsing perf_export period")
+	}
+	if period < time.Minute {
+		return nil, skerr.Fmt("perf_export period must be at least a minute, got %s", period)
+	}
+	fieldsByPool := map[string][]string{}
+	for _, pool := range cfg.Pools {
+		for _, field := range pool.PerfExportFields {
+			if !util.In(field, Fields) {
+				return nil, skerr.Fmt("unknown perf_export_fields entry %q for pool %q; must be one of %q", field, pool.Name, Fields)
+			}
+		}
+		if len(pool.PerfExportFields) > 0 {
+			fieldsByPool[pool.Name] = pool.PerfExportFields
+		}
+	}
+	return &Exporter{
+		store:        s,
+		gcsClient:    gcsClient,
+		prefix:       cfg.PerfExport.Prefix,
+		period:       period,
+		fieldsByPool: fieldsByPool,
+	}, nil
+}
+
+// Start exports the fields every period until the context is cancelled.
+func (e *Exporter) Start(ctx context.Context) {
+	liveness := metrics2.NewLiveness("machineserver_perf_export")
+	go util.RepeatCtx(ctx, e.period, func(ctx context.Context) {
+		if err := e.ExportOnce(ctx); err != nil {
+			sklog.Errorf("Failed to export machines to Perf: %s", err)
+			return
+		}
+		liveness.Reset()
+	})
+}
+
+// ExportOnce writes one Perf ingestion file for each pool with fields to
+// export.
+func (e *Exporter) ExportOnce(ctx context.Context) error {
+	descriptions, err := e.store.List(ctx)
+	if err != nil {
+		return skerr.Wrapf(err, "listing machines")
+	}
+	byPool := map[string][]machine.Description{}
+	for _, d := range descriptions {
+		pool := d.Dimensions.GetDimensionValueOrEmptyString(machine.DimPool)
+		if _, ok := e.fieldsByPool[pool]; ok {
+			byPool[pool] = append(byPool[pool], d)
+		}
+	}
+
+	ts := now.Now(ctx)
+	commitNumber := ts.Unix() / int64(e.period.Seconds())
+	for pool, descriptions := range byPool {
+		f := format.Format{
+			Version: 1,
+			GitHash: fmt.Sprintf("CP:%d", commitNumber),
+			Key: map[string]string{
+				"source": "machineserver",
+				"pool":   pool,
+			},
+			Results: results(descriptions, e.fieldsByPool[pool]),
+		}
+		b, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		filename := path.Join(e.prefix, ts.UTC().Format("2006/01/02/15"), fmt.Sprintf("%s-%d.json", pool, ts.UnixNano()))
+		if err := e.gcsClient.SetFileContents(ctx, filename, gcs.FileWriteOptions{ContentType: "application/json"}, b); err != nil {
+			return skerr.Wrapf(err, "writing %q", filename)
+		}
+	}
+	return nil
+}
+
+// results returns the Perf results for the given fields of the given machines,
+// sorted by machine.
+func results(descriptions []machine.Description, fields []string) []format.Result {
+	sort.Slice(descriptions, func(i, j int) bool {
+		return descriptions[i].Dimensions.GetDimensionValueOrEmptyString(machine.DimID) < descriptions[j].Dimensions.GetDimensionValueOrEmptyString(machine.DimID)
+	})
+	rv := []format.Result{}
+	for _, d := range descriptions {
+		id := d.Dimensions.GetDimensionValueOrEmptyString(machine.DimID)
+		add := func(measurement, unit string, value float32, extraKeys ...string) {
+			key := map[string]string{
+				"machine":     id,
+				"measurement": measurement,
+				"unit":        unit,
+			}
+			for i := 0; i+1 < len(extraKeys); i += 2 {
+				key[extraKeys[i]] = extraKeys[i+1]
+			}
+			rv = append(rv, format.Result{
+				Key:         query.ForceValid(key),
+				Measurement: value,
+			})
+		}
+		for _, field := range fields {
+			switch field {
+			case FieldBattery:
+				// Machines without a battery report a level of zero or less.
+				if d.Battery > 0 {
+					add(FieldBattery, "percent", float32(d.Battery))
+				}
+			case FieldTemperature:
+				sensors := make([]string, 0, len(d.Temperature))
+				for sensor := range d.Temperature {
+					sensors = append(sensors, sensor)
+				}
+				sort.Strings(sensors)
+				for _, sensor := range sensors {
+					add(FieldTemperature, "celsius", float32(d.Temperature[sensor]), "sensor", sensor)
+				}
+			case FieldQuarantined:
+				add(FieldQuarantined, "bool", boolToFloat(d.IsQuarantined))
+			case FieldMaintenance:
+				add(FieldMaintenance, "bool", boolToFloat(d.InMaintenanceMode()))
+			case FieldRecovering:
+				add(FieldRecovering, "bool", boolToFloat(d.IsRecovering()))
+			case FieldRunningTask:
+				add(FieldRunningTask, "bool", boolToFloat(d.RunningSwarmingTask))
+			case FieldDeviceUptime:
+				add(FieldDeviceUptime, "s", float32(d.DeviceUptime))
+			}
+		}
+	}
+	return rv
+}
+
+func boolToFloat(b bool) float32 {
+	if b {
+		return 1
+	}
+	return 0
+}
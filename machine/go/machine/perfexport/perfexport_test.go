@@ -0,0 +1,162 @@
+package perfexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/gcs/mem_gcsclient"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machine/store/mocks"
+	"go.skia.org/infra/machine/go/machineserver/config"
+	"go.skia.org/infra/perf/go/ingest/format"
+)
+
+var exportTime = time.Date(2022, time.March, 4, 5, 6, 7, 0, time.UTC)
+
+func testConfig() config.InstanceConfig {
+	return config.InstanceConfig{
+		Pools: []config.Pool{
+			{
+				Name:             "Skia",
+				Regex:            "^skia-",
+				PerfExportFields: []string{FieldBattery, FieldTemperature, FieldQuarantined},
+			},
+			{
+				Name:  "SkiaInternal",
+				Regex: "^skia-i-",
+			},
+		},
+		PerfExport: &config.PerfExport{
+			Bucket: "skia-perf",
+			Prefix: "machines",
+			Period: "5m",
+		},
+	}
+}
+
+func newDescription(id, pool string) machine.Description {
+	d := machine.NewDescription(context.Background())
+	d.Dimensions[machine.DimID] = []string{id}
+	d.Dimensions[machine.DimPool] = []string{pool}
+	return d
+}
+
+func TestNew_UnknownField_ReturnsError(t *testing.T) {
+	cfg := testConfig()
+	cfg.Pools[0].PerfExportFields = []string{"not_a_field"}
+	_, err := New(cfg, mocks.NewStore(t), mem_gcsclient.New("skia-perf"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not_a_field")
+}
+
+func TestNew_InvalidPeriod_ReturnsError(t *testing.T) {
+	cfg := testConfig()
+	cfg.PerfExport.Period = "5 minutes"
+	_, err := New(cfg, mocks.NewStore(t), mem_gcsclient.New("skia-perf"))
+	require.Error(t, err)
+}
+
+func TestNew_PeriodTooShort_ReturnsError(t *testing.T) {
+	cfg := testConfig()
+	cfg.PerfExport.Period = "1s"
+	_, err := New(cfg, mocks.NewStore(t), mem_gcsclient.New("skia-perf"))
+	require.Error(t, err)
+}
+
+func TestExportOnce_HappyPath(t *testing.T) {
+	ctx := context.WithValue(context.Background(), now.ContextKey, exportTime)
+
+	withBattery := newDescription("skia-rpi2-0002", "Skia")
+	withBattery.Battery = 95
+	withBattery.Temperature = map[string]float64{"dumpsys_battery": 26, "thermal zone": 31.5}
+	quarantined := newDescription("skia-rpi2-0001", "Skia")
+	quarantined.IsQuarantined = true
+	notExported := newDescription("skia-i-rpi-001", "SkiaInternal")
+	notExported.Battery = 50
+
+	storeMock := mocks.NewStore(t)
+	storeMock.On("List", testutils.AnyContext).Return([]machine.Description{withBattery, quarantined, notExported}, nil)
+	gcsClient := mem_gcsclient.New("skia-perf")
+
+	e, err := New(testConfig(), storeMock, gcsClient)
+	require.NoError(t, err)
+	require.NoError(t, e.ExportOnce(ctx))
+
+	b, err := gcsClient.GetFileContents(ctx, "machines/2022/03/04/05/Skia-1646370367000000000.json")
+	require.NoError(t, err)
+	var f format.Format
+	require.NoError(t, json.Unmarshal(b, &f))
+	assert.Equal(t, format.Format{
+		Version: 1,
+		GitHash: "CP:5487901",
+		Key: map[string]string{
+			"source": "machineserver",
+			"pool":   "Skia",
+		},
+		Results: []format.Result{
+			{
+				Key: map[string]string{
+					"machine":     "skia-rpi2-0001",
+					"measurement": "quarantined",
+					"unit":        "bool",
+				},
+				Measurement: 1,
+			},
+			{
+				Key: map[string]string{
+					"machine":     "skia-rpi2-0002",
+					"measurement": "battery",
+					"unit":        "percent",
+				},
+				Measurement: 95,
+			},
+			{
+				Key: map[string]string{
+					"machine":     "skia-rpi2-0002",
+					"measurement": "temperature",
+					"unit":        "celsius",
+					"sensor":      "dumpsys_battery",
+				},
+				Measurement: 26,
+			},
+			{
+				Key: map[string]string{
+					"machine":     "skia-rpi2-0002",
+					"measurement": "temperature",
+					"unit":        "celsius",
+					"sensor":      "thermal_zone",
+				},
+				Measurement: 31.5,
+			},
+			{
+				Key: map[string]string{
+					"machine":     "skia-rpi2-0002",
+					"measurement": "quarantined",
+					"unit":        "bool",
+				},
+				Measurement: 0,
+			},
+		},
+	}, f)
+
+	exists, err := gcsClient.DoesFileExist(ctx, "machines/2022/03/04/05/SkiaInternal-1646370367000000000.json")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestExportOnce_StoreFails_ReturnsError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), now.ContextKey, exportTime)
+	storeMock := mocks.NewStore(t)
+	storeMock.On("List", testutils.AnyContext).Return(nil, errors.New("my fake error"))
+
+	e, err := New(testConfig(), storeMock, mem_gcsclient.New("skia-perf"))
+	require.NoError(t, err)
+	require.Error(t, e.ExportOnce(ctx))
+}
@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"go.skia.org/infra/go/metrics2"
@@ -91,6 +92,10 @@ var (
 type ProcessorImpl struct {
 	unknownEventTypeCount metrics2.Counter
 	eventsProcessedCount  metrics2.Counter
+
+	// rules derive additional dimensions from each event.
+	rules    *Rules
+	rulesMtx sync.RWMutex
 }
 
 // New returns a new Processor instance.
@@ -111,6 +116,10 @@ func (p *ProcessorImpl) Process(ctx context.Context, previous machine.Descriptio
 	}
 	next := p.processEvent(ctx, previous, event)
 
+	p.rulesMtx.RLock()
+	p.rules.Apply(event, next.Dimensions)
+	p.rulesMtx.RUnlock()
+
 	if event.ForcedQuarantine {
 		next.IsQuarantined = true
 	}
@@ -118,6 +127,13 @@ func (p *ProcessorImpl) Process(ctx context.Context, previous machine.Descriptio
 	return next
 }
 
+// SetRules replaces the rules which derive dimensions from each event.
+func (p *ProcessorImpl) SetRules(rules *Rules) {
+	p.rulesMtx.Lock()
+	defer p.rulesMtx.Unlock()
+	p.rules = rules
+}
+
 func (p *ProcessorImpl) processEvent(ctx context.Context, previous machine.Description, event machine.Event) machine.Description {
 	if event.Android.IsPopulated() {
 		return processAndroidEvent(ctx, previous, event)
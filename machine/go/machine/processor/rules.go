@@ -0,0 +1,246 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machineserver/config"
+)
+
+// The event fields which config.DimensionRules can be evaluated against.
+const (
+	SourceHostName                 = "host.name"
+	SourceHostVersion              = "host.version"
+	SourceChromeOSChannel          = "chromeos.channel"
+	SourceChromeOSMilestone        = "chromeos.milestone"
+	SourceChromeOSReleaseVersion   = "chromeos.release_version"
+	SourceIOSVersion               = "ios.version"
+	SourceIOSDeviceType            = "ios.device_type"
+	SourcePyOCDDeviceType          = "pyocd.device_type"
+	SourceStandaloneCPUs           = "standalone.cpus"
+	SourceStandaloneGPUs           = "standalone.gpus"
+	SourceStandaloneOSVersions     = "standalone.os_versions"
+	SourceStandaloneGCEMachineType = "standalone.machine_type"
+
+	// SourceAndroidPropPrefix is followed by the name of an Android property,
+	// e.g. "android.getprop:ro.product.model".
+	SourceAndroidPropPrefix = "android.getprop:"
+
+	// minRulesFilePeriod is the shortest allowed DimensionRulesFile period.
+	minRulesFilePeriod = time.Minute
+)
+
+// Sources are all the supported rule sources, apart from those starting with
+// SourceAndroidPropPrefix.
+var Sources = []string{
+	SourceHostName,
+	SourceHostVersion,
+	SourceChromeOSChannel,
+	SourceChromeOSMilestone,
+	SourceChromeOSReleaseVersion,
+	SourceIOSVersion,
+	SourceIOSDeviceType,
+	SourcePyOCDDeviceType,
+	SourceStandaloneCPUs,
+	SourceStandaloneGPUs,
+	SourceStandaloneOSVersions,
+	SourceStandaloneGCEMachineType,
+}
+
+// rule is a compiled config.DimensionRule.
+type rule struct {
+	source    string
+	regex     *regexp.Regexp
+	dimension string
+	value     string
+}
+
+// Rules derive dimensions from incoming events.
+type Rules struct {
+	rules []rule
+}
+
+// NewRules compiles the given rules. It returns an error if any of them is
+// invalid.
+func NewRules(cfg []config.DimensionRule) (*Rules, error) {
+	rules := make([]rule, 0, len(cfg))
+	for i, r := range cfg {
+		if !util.In(r.Source, Sources) && (!strings.HasPrefix(r.Source, SourceAndroidPropPrefix) || r.Source == SourceAndroidPropPrefix) {
+			return nil, skerr.Fmt("rule %d: unknown source %q; must be one of %q or start with %q", i, r.Source, Sources, SourceAndroidPropPrefix)
+		}
+		if r.Dimension == "" {
+			return nil, skerr.Fmt("rule %d: dimension is required", i)
+		}
+		if r.Dimension == machine.DimID || r.Dimension == machine.DimPool {
+			return nil, skerr.Fmt("rule %d: dimension %q can not be set by rules", i, r.Dimension)
+		}
+		regex, err := regexp.Compile(r.Regex)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "rule %d: compiling regex", i)
+		}
+		rules = append(rules, rule{
+			source:    r.Source,
+			regex:     regex,
+			dimension: r.Dimension,
+			value:     r.Value,
+		})
+	}
+	return &Rules{
+		rules: rules,
+	}, nil
+}
+
+// Apply sets the dimensions derived from the event. Dimensions set by the
+// rules replace any existing values; if several rules set the same dimension
+// its values are combined in rule order.
+func (r *Rules) Apply(event machine.Event, dimensions machine.SwarmingDimensions) {
+	if r == nil || len(r.rules) == 0 {
+		return
+	}
+	var props map[string]string
+	derived := map[string][]string{}
+	var order []string
+	for _, rule := range r.rules {
+		var values []string
+		if strings.HasPrefix(rule.source, SourceAndroidPropPrefix) {
+			if !event.Android.IsPopulated() {
+				continue
+			}
+			if props == nil {
+				props = parseAndroidProperties(event.Android.GetProp)
+			}
+			values = []string{props[strings.TrimPrefix(rule.source, SourceAndroidPropPrefix)]}
+		} else {
+			values = sourceValues(event, rule.source)
+		}
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			match := rule.regex.FindStringSubmatchIndex(value)
+			if match == nil {
+				continue
+			}
+			dimValue := value
+			if rule.value != "" {
+				dimValue = string(rule.regex.ExpandString(nil, rule.value, value, match))
+			}
+			if dimValue == "" || util.In(dimValue, derived[rule.dimension]) {
+				continue
+			}
+			if _, ok := derived[rule.dimension]; !ok {
+				order = append(order, rule.dimension)
+			}
+			derived[rule.dimension] = append(derived[rule.dimension], dimValue)
+		}
+	}
+	for _, dim := range order {
+		dimensions[dim] = derived[dim]
+	}
+}
+
+// sourceValues returns the values of the given event field. Fields of devices
+// which aren't attached have no values.
+func sourceValues(event machine.Event, source string) []string {
+	switch source {
+	case SourceHostName:
+		return []string{event.Host.Name}
+	case SourceHostVersion:
+		return []string{event.Host.Version}
+	}
+	if event.ChromeOS.IsPopulated() {
+		switch source {
+		case SourceChromeOSChannel:
+			return []string{event.ChromeOS.Channel}
+		case SourceChromeOSMilestone:
+			return []string{event.ChromeOS.Milestone}
+		case SourceChromeOSReleaseVersion:
+			return []string{event.ChromeOS.ReleaseVersion}
+		}
+	}
+	if event.IOS.IsPopulated() {
+		switch source {
+		case SourceIOSVersion:
+			return []string{event.IOS.OSVersion}
+		case SourceIOSDeviceType:
+			return []string{event.IOS.DeviceType}
+		}
+	}
+	if event.PyOCD.IsPopulated() && source == SourcePyOCDDeviceType {
+		return []string{event.PyOCD.DeviceType}
+	}
+	if event.Standalone.IsPopulated() {
+		switch source {
+		case SourceStandaloneCPUs:
+			return event.Standalone.CPUs
+		case SourceStandaloneGPUs:
+			return event.Standalone.GPUs
+		case SourceStandaloneOSVersions:
+			return event.Standalone.OSVersions
+		case SourceStandaloneGCEMachineType:
+			return []string{event.Standalone.GCEMachineType}
+		}
+	}
+	return nil
+}
+
+// loadRules returns the rules from the instance config followed by those in
+// the DimensionRulesFile, if any.
+func loadRules(ctx context.Context, cfg config.InstanceConfig, gcsClient gcs.GCSClient) (*Rules, error) {
+	ruleCfgs := append([]config.DimensionRule{}, cfg.DimensionRules...)
+	if cfg.DimensionRulesFile != nil {
+		b, err := gcsClient.GetFileContents(ctx, cfg.DimensionRulesFile.Path)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "reading %q", cfg.DimensionRulesFile.Path)
+		}
+		var fileRules []config.DimensionRule
+		if err := json.Unmarshal(b, &fileRules); err != nil {
+			return nil, skerr.Wrapf(err, "parsing %q", cfg.DimensionRulesFile.Path)
+		}
+		ruleCfgs = append(ruleCfgs, fileRules...)
+	}
+	return NewRules(ruleCfgs)
+}
+
+// StartRulesReload loads the rules from the instance config and the
+// DimensionRulesFile, and then reloads them every period until the context is
+// cancelled. If reloading fails the previous rules stay in effect. It returns
+// an error if the initial load fails.
+func (p *ProcessorImpl) StartRulesReload(ctx context.Context, cfg config.InstanceConfig, gcsClient gcs.GCSClient) error {
+	if cfg.DimensionRulesFile == nil {
+		return skerr.Fmt("dimension_rules_file must be supplied in the instance config")
+	}
+	period, err := time.ParseDuration(cfg.DimensionRulesFile.Period)
+	if err != nil {
+		return skerr.Wrapf(err, "parsing dimension_rules_file period")
+	}
+	if period < minRulesFilePeriod {
+		return skerr.Fmt("dimension_rules_file period must be at least %s, got %s", minRulesFilePeriod, period)
+	}
+	rules, err := loadRules(ctx, cfg, gcsClient)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	p.SetRules(rules)
+
+	liveness := metrics2.NewLiveness("machineserver_dimension_rules_reload")
+	go util.RepeatCtx(ctx, period, func(ctx context.Context) {
+		rules, err := loadRules(ctx, cfg, gcsClient)
+		if err != nil {
+			sklog.Errorf("Failed to reload dimension rules: %s", err)
+			return
+		}
+		p.SetRules(rules)
+		liveness.Reset()
+	})
+	return nil
+}
@@ -0,0 +1,181 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/gcs/mem_gcsclient"
+	"go.skia.org/infra/machine/go/machine"
+	"go.skia.org/infra/machine/go/machineserver/config"
+)
+
+func TestNewRules_InvalidRules_ReturnError(t *testing.T) {
+	for name, r := range map[string]config.DimensionRule{
+		"unknown source":     {Source: "android.uptime", Dimension: "foo"},
+		"empty property":     {Source: SourceAndroidPropPrefix, Dimension: "foo"},
+		"missing dimension":  {Source: SourceHostName},
+		"reserved dimension": {Source: SourceHostName, Dimension: machine.DimPool},
+		"invalid regex":      {Source: SourceHostName, Regex: "(", Dimension: "foo"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRules([]config.DimensionRule{r})
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRulesApply_AndroidEvent_DerivesDimensionsFromProperties(t *testing.T) {
+	rules, err := NewRules([]config.DimensionRule{
+		{
+			Source:    SourceAndroidPropPrefix + "ro.product.model",
+			Regex:     `^Pixel (\d+)([a-z]*)$`,
+			Dimension: "device_model",
+			Value:     "Pixel$1",
+		},
+		{
+			Source:    SourceAndroidPropPrefix + "ro.product.model",
+			Regex:     `^Pixel (\d+)([a-z]+)$`,
+			Dimension: "device_model",
+			Value:     "Pixel$1$2",
+		},
+		{
+			// Doesn't match.
+			Source:    SourceAndroidPropPrefix + "ro.product.model",
+			Regex:     `^Nexus`,
+			Dimension: "nexus",
+		},
+		{
+			// Overrides the built-in dimension.
+			Source:    SourceAndroidPropPrefix + "ro.product.device",
+			Dimension: machine.DimDeviceType,
+		},
+		{
+			// Not an iOS event.
+			Source:    SourceIOSDeviceType,
+			Dimension: "ios_device",
+		},
+	})
+	require.NoError(t, err)
+
+	event := machine.NewEvent()
+	event.Android = machine.Android{
+		GetProp: "[ro.product.model]: [Pixel 3a]\n[ro.product.device]: [sargo]",
+		Uptime:  time.Minute,
+	}
+	dimensions := machine.SwarmingDimensions{
+		machine.DimDeviceType: {"sargo", "bonito"},
+		machine.DimOS:         {"Android"},
+	}
+	rules.Apply(event, dimensions)
+	assert.Equal(t, machine.SwarmingDimensions{
+		"device_model":        {"Pixel3", "Pixel3a"},
+		machine.DimDeviceType: {"sargo"},
+		machine.DimOS:         {"Android"},
+	}, dimensions)
+}
+
+func TestRulesApply_StandaloneEvent_RuleIsAppliedToEachValue(t *testing.T) {
+	rules, err := NewRules([]config.DimensionRule{
+		{
+			Source:    SourceStandaloneGPUs,
+			Regex:     `^(\w+):`,
+			Dimension: "gpu_vendor",
+			Value:     "$1",
+		},
+	})
+	require.NoError(t, err)
+
+	event := machine.NewEvent()
+	event.Standalone = machine.Standalone{
+		Cores: 8,
+		GPUs:  []string{"10de:1cb3-25.21.14.1678", "10de:1cb3", "8086:591e"},
+	}
+	dimensions := machine.SwarmingDimensions{}
+	rules.Apply(event, dimensions)
+	assert.Equal(t, machine.SwarmingDimensions{
+		"gpu_vendor": {"10de", "8086"},
+	}, dimensions)
+}
+
+func TestRulesApply_NilRules_DoesNothing(t *testing.T) {
+	var rules *Rules
+	dimensions := machine.SwarmingDimensions{machine.DimOS: {"Android"}}
+	rules.Apply(machine.NewEvent(), dimensions)
+	assert.Equal(t, machine.SwarmingDimensions{machine.DimOS: {"Android"}}, dimensions)
+}
+
+func TestProcess_WithRules_DerivedDimensionsAreSet(t *testing.T) {
+	ctx := context.Background()
+	p := New(ctx)
+	rules, err := NewRules([]config.DimensionRule{
+		{
+			Source:    SourcePyOCDDeviceType,
+			Regex:     `^MIMXRT(\d+)`,
+			Dimension: "board_family",
+			Value:     "i.MX RT$1",
+		},
+	})
+	require.NoError(t, err)
+	p.SetRules(rules)
+
+	event := machine.NewEvent()
+	event.Host.Name = "skia-rpi2-0001"
+	event.PyOCD.DeviceType = "MIMXRT1170-EVK"
+	next := p.Process(ctx, machine.NewDescription(ctx), event)
+	assert.Equal(t, []string{"i.MX RT1170"}, next.Dimensions["board_family"])
+	assert.Equal(t, []string{"MIMXRT1170-EVK"}, next.Dimensions[machine.DimDeviceType])
+}
+
+func TestStartRulesReload_RulesFileIsLoaded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gcsClient := mem_gcsclient.New("skia-machines")
+	require.NoError(t, gcsClient.SetFileContents(ctx, "rules.json", gcs.FileWriteOptions{}, []byte(`[{"source": "host.name", "regex": "-(rpi)-", "dimension": "host_type", "value": "$1"}]`)))
+	cfg := config.InstanceConfig{
+		DimensionRules: []config.DimensionRule{
+			{
+				Source:    SourceHostVersion,
+				Dimension: "monitor",
+			},
+		},
+		DimensionRulesFile: &config.DimensionRulesFile{
+			Bucket: "skia-machines",
+			Path:   "rules.json",
+			Period: "5m",
+		},
+	}
+	p := New(ctx)
+	require.NoError(t, p.StartRulesReload(ctx, cfg, gcsClient))
+
+	event := machine.NewEvent()
+	event.Host.Name = "skia-rpi-001"
+	event.Host.Version = "v1"
+	dimensions := machine.SwarmingDimensions{}
+	p.rules.Apply(event, dimensions)
+	assert.Equal(t, machine.SwarmingDimensions{
+		"host_type": {"rpi"},
+		"monitor":   {"v1"},
+	}, dimensions)
+}
+
+func TestStartRulesReload_InvalidRulesFile_ReturnsError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gcsClient := mem_gcsclient.New("skia-machines")
+	require.NoError(t, gcsClient.SetFileContents(ctx, "rules.json", gcs.FileWriteOptions{}, []byte(`[{"source": "unknown", "dimension": "foo"}]`)))
+	cfg := config.InstanceConfig{
+		DimensionRulesFile: &config.DimensionRulesFile{
+			Bucket: "skia-machines",
+			Path:   "rules.json",
+			Period: "5m",
+		},
+	}
+	require.Error(t, New(ctx).StartRulesReload(ctx, cfg, gcsClient))
+
+	cfg.DimensionRulesFile.Period = "1s"
+	require.Error(t, New(ctx).StartRulesReload(ctx, cfg, gcsClient))
+}
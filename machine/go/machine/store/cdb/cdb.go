@@ -29,14 +29,27 @@ import (
 const (
 	// DatabaseName is the name of the database.
 	DatabaseName = "machineserver"
+
+	// maxUpdateAttempts is the number of times Update will try to write a
+	// Description before giving up because other writers keep changing the
+	// same machine.
+	maxUpdateAttempts = 10
+
+	// updateRetryDelay is how long Update waits after the first conflicting
+	// write. Each subsequent attempt waits an additional updateRetryDelay.
+	updateRetryDelay = 10 * time.Millisecond
 )
 
+// ErrConcurrentUpdate is returned by Update if the machine kept being modified
+// by other writers for maxUpdateAttempts attempts.
+var ErrConcurrentUpdate = errors.New("machine was concurrently modified")
+
 // statement is an SQL statement or fragment of an SQL statement.
 type statement int
 
 // All the different statements we need. Each statement will appear in Statements.
 const (
-	GetAndLockRow statement = iota
+	Insert statement = iota
 	Update
 	Get
 	ListPowerCycle
@@ -60,22 +73,30 @@ var (
 // a string and thus not substituted.
 //
 //	'{"id": ["$1"]}'
+//
+// Update only succeeds if the row_version of the row is still the one that was
+// read, i.e. no other writer has changed the machine in the meantime. Insert
+// similarly does nothing if another writer created the machine first.
 var Statements = map[statement]string{
-	GetAndLockRow: fmt.Sprintf(`
-SELECT
-	%s
-FROM
-	Description
-WHERE
-	dimensions @> CONCAT('{"id": ["', $1, '"]}')::JSONB
-FOR UPDATE`, descriptionAllNonComputedColumns),
-	Update: fmt.Sprintf(`
-UPSERT INTO
+	Insert: fmt.Sprintf(`
+INSERT INTO
 	Description (%s)
 VALUES
 	%s
+ON CONFLICT DO NOTHING
 `, descriptionAllNonComputedColumns, sqlutil.ValuesPlaceholders(len(Description), 1),
 	),
+	Update: fmt.Sprintf(`
+UPDATE
+	Description
+SET
+	(%s) = %s
+WHERE
+	machine_id = $%d
+	AND row_version = $%d
+`, descriptionAllNonComputedColumns, sqlutil.ValuesPlaceholders(len(Description), 1),
+		len(Description)+1, len(Description)+2,
+	),
 	Get: fmt.Sprintf(`
 SELECT
 	%s
@@ -116,6 +137,18 @@ AND
 `, descriptionAllNonComputedColumns),
 }
 
+// Migrations brings the tables of a database created with an older Schema up to
+// date. The statements are idempotent. New requires the up to date schema, so
+// they must be applied, e.g. by running mscdbinit, before rolling out a new
+// version of machineserver.
+const Migrations = `
+ALTER TABLE Description
+	ADD COLUMN IF NOT EXISTS maintenance_reason STRING NOT NULL DEFAULT '';
+
+ALTER TABLE Description
+	ADD COLUMN IF NOT EXISTS row_version INT NOT NULL DEFAULT 0;
+`
+
 // Tables represents all SQL tables used by machineserver.
 type Tables struct {
 	Description []machine.Description
@@ -126,6 +159,10 @@ type Tables struct {
 type Store struct {
 	db    pool.Pool
 	pools *pools.Pools
+
+	// updateConflicts counts the Update attempts that had to be retried
+	// because another writer modified the same machine.
+	updateConflicts metrics2.Counter
 }
 
 // New returns a new *Store that uses the give Pool.
@@ -145,8 +182,9 @@ func New(ctx context.Context, db pool.Pool, pools *pools.Pools) (*Store, error)
 	}
 
 	return &Store{
-		db:    db,
-		pools: pools,
+		db:              db,
+		pools:           pools,
+		updateConflicts: metrics2.GetCounter("machine_store_update_conflicts"),
 	}, nil
 }
 
@@ -182,46 +220,86 @@ func sanitizeDimensions(in machine.SwarmingDimensions) machine.SwarmingDimension
 }
 
 // Update implements ../store.Store.
+//
+// Update uses optimistic concurrency control. The Description is read along
+// with its RowVersion and only written back if the RowVersion hasn't changed,
+// otherwise the read, updateCallback, and write are retried, up to
+// maxUpdateAttempts times.
 func (s *Store) Update(ctx context.Context, machineID string, updateCallback store.UpdateCallback) error {
-	return s.db.BeginFunc(ctx, func(tx pgx.Tx) error {
-		// Load the current machine description, if one already exists.
-		d := machine.NewDescription(ctx)
-		d.Dimensions[machine.DimID] = []string{machineID}
-		err := tx.QueryRow(ctx, Statements[GetAndLockRow], machineID).Scan(machine.DestFromDescription(&d)...)
-		// Not finding any rows is fine, but all other errors should return.
-		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
-			return wrappedErrorForID(err, machineID)
+	for attempt := 1; ; attempt++ {
+		written, err := s.tryUpdate(ctx, machineID, updateCallback)
+		if err != nil {
+			return err
+		}
+		if written {
+			return nil
 		}
+		s.updateConflicts.Inc(1)
+		if attempt >= maxUpdateAttempts {
+			return wrappedErrorForID(skerr.Wrapf(ErrConcurrentUpdate, "gave up after %d attempts", attempt), machineID)
+		}
+		select {
+		case <-ctx.Done():
+			return wrappedErrorForID(ctx.Err(), machineID)
+		case <-time.After(time.Duration(attempt) * updateRetryDelay):
+		}
+	}
+}
 
-		newD := updateCallback(d)
+// tryUpdate does a single read, update, and compare-and-swap write of the
+// Description for the given machine. It returns false if the write was not
+// applied because another writer changed the machine after it was read.
+func (s *Store) tryUpdate(ctx context.Context, machineID string, updateCallback store.UpdateCallback) (bool, error) {
+	// Load the current machine description, if one already exists.
+	d := machine.NewDescription(ctx)
+	d.Dimensions[machine.DimID] = []string{machineID}
+	exists := true
+	err := s.db.QueryRow(ctx, Statements[Get], machineID).Scan(machine.DestFromDescription(&d)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Not finding any rows is fine, but all other errors should return.
+		exists = false
+	} else if err != nil {
+		return false, wrappedErrorForID(err, machineID)
+	}
+	rowVersion := d.RowVersion
 
-		newD.Dimensions = sanitizeDimensions(newD.Dimensions)
-		newD.SuppliedDimensions = sanitizeDimensions(newD.SuppliedDimensions)
+	newD := updateCallback(d)
 
-		// Default to a DimTaskType of Swarming if not set.
-		if len(newD.Dimensions[machine.DimTaskType]) == 0 {
-			newD.Dimensions[machine.DimTaskType] = []string{string(machine.Swarming)}
-		}
+	newD.Dimensions = sanitizeDimensions(newD.Dimensions)
+	newD.SuppliedDimensions = sanitizeDimensions(newD.SuppliedDimensions)
 
-		if !s.pools.HasValidPool(newD) {
-			s.pools.SetSwarmingPool(&newD)
-		}
+	// Default to a DimTaskType of Swarming if not set.
+	if len(newD.Dimensions[machine.DimTaskType]) == 0 {
+		newD.Dimensions[machine.DimTaskType] = []string{string(machine.Swarming)}
+	}
 
-		_ = machine.SetSwarmingQuarantinedMessage(&newD)
-		SetQuarantineMetrics(newD)
+	if !s.pools.HasValidPool(newD) {
+		s.pools.SetSwarmingPool(&newD)
+	}
 
-		// Normalize times so they appear consistent in the database.
-		newD.RecoveryStart = newD.RecoveryStart.UTC().Truncate(time.Millisecond)
-		newD.LastUpdated = newD.LastUpdated.UTC().Truncate(time.Millisecond)
-		newD.TaskStarted = newD.TaskStarted.UTC().Truncate(time.Millisecond)
+	_ = machine.SetSwarmingQuarantinedMessage(&newD)
+	SetQuarantineMetrics(newD)
 
-		// Write the updated value.
-		_, err = tx.Exec(ctx, Statements[Update], machine.DestFromDescription(&newD)...)
-		if err != nil {
-			return wrappedErrorForID(err, machineID)
-		}
-		return nil
-	})
+	// Normalize times so they appear consistent in the database.
+	newD.RecoveryStart = newD.RecoveryStart.UTC().Truncate(time.Millisecond)
+	newD.LastUpdated = newD.LastUpdated.UTC().Truncate(time.Millisecond)
+	newD.TaskStarted = newD.TaskStarted.UTC().Truncate(time.Millisecond)
+
+	// The callback doesn't get to choose the version.
+	newD.RowVersion = rowVersion + 1
+
+	// Write the updated value.
+	var tag pgconn.CommandTag
+	if exists {
+		args := append(machine.DestFromDescription(&newD), machineID, rowVersion)
+		tag, err = s.db.Exec(ctx, Statements[Update], args...)
+	} else {
+		tag, err = s.db.Exec(ctx, Statements[Insert], machine.DestFromDescription(&newD)...)
+	}
+	if err != nil {
+		return false, wrappedErrorForID(err, machineID)
+	}
+	return tag.RowsAffected() == 1, nil
 }
 
 var (
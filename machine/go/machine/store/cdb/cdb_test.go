@@ -3,9 +3,11 @@ package cdb_test
 import (
 	"context"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.skia.org/infra/go/deepequal"
 	"go.skia.org/infra/go/deepequal/assertdeep"
@@ -23,19 +25,21 @@ import (
 
 func Test_Statements_SprintfReturnsCorrectResults(t *testing.T) {
 	require.Equal(t, `
-SELECT
-	maintenance_mode,is_quarantined,recovering,attached_device,annotation,note,version,powercycle,powercycle_state,last_updated,battery,temperatures,running_swarmingTask,launched_swarming,recovery_start,device_uptime,ssh_user_ip,supplied_dimensions,dimensions,task_request,task_started
-FROM
-	Description
-WHERE
-	dimensions @> CONCAT('{"id": ["', $1, '"]}')::JSONB
-FOR UPDATE`, cdb.Statements[cdb.GetAndLockRow])
+INSERT INTO
+	Description (maintenance_mode,maintenance_reason,is_quarantined,recovering,attached_device,annotation,note,version,powercycle,powercycle_state,last_updated,battery,temperatures,running_swarmingTask,launched_swarming,recovery_start,device_uptime,ssh_user_ip,supplied_dimensions,dimensions,task_request,task_started,row_version)
+VALUES
+	($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23)
+ON CONFLICT DO NOTHING
+`, cdb.Statements[cdb.Insert])
 
 	require.Equal(t, `
-UPSERT INTO
-	Description (maintenance_mode,is_quarantined,recovering,attached_device,annotation,note,version,powercycle,powercycle_state,last_updated,battery,temperatures,running_swarmingTask,launched_swarming,recovery_start,device_uptime,ssh_user_ip,supplied_dimensions,dimensions,task_request,task_started)
-VALUES
-	($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21)
+UPDATE
+	Description
+SET
+	(maintenance_mode,maintenance_reason,is_quarantined,recovering,attached_device,annotation,note,version,powercycle,powercycle_state,last_updated,battery,temperatures,running_swarmingTask,launched_swarming,recovery_start,device_uptime,ssh_user_ip,supplied_dimensions,dimensions,task_request,task_started,row_version) = ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23)
+WHERE
+	machine_id = $24
+	AND row_version = $25
 `, cdb.Statements[cdb.Update])
 }
 
@@ -111,6 +115,8 @@ func TestStore_UpdateAndGetFullyRoundTripTheDescription_Success(t *testing.T) {
 	full.LastUpdated = full.LastUpdated.Truncate(time.Millisecond)
 	full.RecoveryStart = full.RecoveryStart.Truncate(time.Millisecond)
 	full.TaskStarted = full.TaskStarted.Truncate(time.Millisecond)
+	// The first write of a machine creates version 1.
+	full.RowVersion = 1
 
 	machineID := full.Dimensions[machine.DimID][0]
 	err := s.Update(ctx, machineID, func(in machine.Description) machine.Description {
@@ -124,6 +130,98 @@ func TestStore_UpdateAndGetFullyRoundTripTheDescription_Success(t *testing.T) {
 	assertdeep.Copy(t, d, full)
 }
 
+func TestStore_UpdateIncrementsRowVersion_CallbackCannotOverrideVersion(t *testing.T) {
+	ctx, s := setupForTest(t)
+
+	err := s.Update(ctx, machineID1, func(in machine.Description) machine.Description {
+		require.Equal(t, int64(1), in.RowVersion)
+		ret := in.Copy()
+		ret.RowVersion = 100
+		return ret
+	})
+	require.NoError(t, err)
+
+	d, err := s.Get(ctx, machineID1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), d.RowVersion)
+}
+
+func TestStore_UpdateConflictsWithConcurrentUpdate_RetriesAndKeepsBothWrites(t *testing.T) {
+	ctx, s := setupForTest(t)
+
+	calls := 0
+	err := s.Update(ctx, machineID1, func(in machine.Description) machine.Description {
+		calls++
+		if calls == 1 {
+			// Simulate another writer changing the machine between our read
+			// and our write.
+			err := s.Update(ctx, machineID1, func(in machine.Description) machine.Description {
+				ret := in.Copy()
+				ret.Version = "v2.0"
+				return ret
+			})
+			require.NoError(t, err)
+		}
+		ret := in.Copy()
+		ret.Battery = 12
+		return ret
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	d, err := s.Get(ctx, machineID1)
+	require.NoError(t, err)
+	require.Equal(t, "v2.0", d.Version)
+	require.Equal(t, 12, d.Battery)
+	require.Equal(t, int64(3), d.RowVersion)
+}
+
+func TestStore_UpdateAlwaysConflicts_ReturnsErrConcurrentUpdate(t *testing.T) {
+	ctx, s := setupForTest(t)
+
+	calls := 0
+	err := s.Update(ctx, machineID1, func(in machine.Description) machine.Description {
+		calls++
+		err := s.Update(ctx, machineID1, func(in machine.Description) machine.Description {
+			return in.Copy()
+		})
+		require.NoError(t, err)
+		return in.Copy()
+	})
+	require.ErrorIs(t, err, cdb.ErrConcurrentUpdate)
+	require.Equal(t, 10, calls)
+}
+
+func TestStore_ConcurrentUpdates_NoWritesLost(t *testing.T) {
+	ctx, s := setupForTest(t)
+	err := s.Update(ctx, machineID1, func(in machine.Description) machine.Description {
+		ret := in.Copy()
+		ret.Battery = 0
+		return ret
+	})
+	require.NoError(t, err)
+
+	const numWriters = 5
+	var wg sync.WaitGroup
+	for i := 0; i < numWriters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := s.Update(ctx, machineID1, func(in machine.Description) machine.Description {
+				ret := in.Copy()
+				ret.Battery++
+				return ret
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	d, err := s.Get(ctx, machineID1)
+	require.NoError(t, err)
+	require.Equal(t, numWriters, d.Battery)
+}
+
 func TestStore_NilTaskDescriptorFullyRoundTrips_Success(t *testing.T) {
 	ctx, s, full := setupForTestWithEmptyStore(t)
 	full.TaskRequest = nil
@@ -378,6 +476,8 @@ func Test_LiveToNextSchemaMigration(t *testing.T) {
 
 	_, err = db.Exec(ctx, FromLiveToNext)
 	require.NoError(t, err)
+	_, err = db.Exec(ctx, cdb.Migrations)
+	require.NoError(t, err)
 
 	migratedSchema := getSchema(t, db)
 
@@ -37,5 +37,11 @@ func main() {
 		sklog.Fatal(err)
 	}
 
+	// Add any columns missing from tables created with an older schema.
+	_, err = db.Exec(ctx, cdb.Migrations)
+	if err != nil {
+		sklog.Fatal(err)
+	}
+
 	db.Close()
 }
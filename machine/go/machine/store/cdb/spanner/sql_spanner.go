@@ -5,6 +5,7 @@ package spanner
 
 const Schema = `CREATE TABLE IF NOT EXISTS Description (
   maintenance_mode TEXT NOT NULL DEFAULT '',
+  maintenance_reason TEXT NOT NULL DEFAULT '',
   is_quarantined BOOL NOT NULL DEFAULT FALSE,
   recovering TEXT NOT NULL DEFAULT '',
   attached_device TEXT NOT NULL DEFAULT 'nodevice',
@@ -25,6 +26,7 @@ const Schema = `CREATE TABLE IF NOT EXISTS Description (
   dimensions JSONB NOT NULL,
   task_request JSONB,
   task_started TIMESTAMPTZ NOT NULL DEFAULT (0)::TIMESTAMPTZ,
+  row_version INT NOT NULL DEFAULT 0,
   machine_id TEXT PRIMARY KEY GENERATED ALWAYS AS (dimensions->'id'->>0) STORED,
   running_task bool GENERATED ALWAYS AS (task_request IS NOT NULL) STORED,
   createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
@@ -46,6 +48,7 @@ CREATE INDEX IF NOT EXISTS by_status on TaskResult (status);
 
 var Description = []string{
 	"maintenance_mode",
+	"maintenance_reason",
 	"is_quarantined",
 	"recovering",
 	"attached_device",
@@ -66,6 +69,7 @@ var Description = []string{
 	"dimensions",
 	"task_request",
 	"task_started",
+	"row_version",
 }
 
 var TaskResult = []string{
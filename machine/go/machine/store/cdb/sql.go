@@ -5,6 +5,7 @@ package cdb
 
 const Schema = `CREATE TABLE IF NOT EXISTS Description (
   maintenance_mode STRING NOT NULL DEFAULT '',
+  maintenance_reason STRING NOT NULL DEFAULT '',
   is_quarantined BOOL NOT NULL DEFAULT FALSE,
   recovering STRING NOT NULL DEFAULT '',
   attached_device STRING NOT NULL DEFAULT 'nodevice',
@@ -25,6 +26,7 @@ const Schema = `CREATE TABLE IF NOT EXISTS Description (
   dimensions JSONB NOT NULL,
   task_request JSONB,
   task_started TIMESTAMPTZ NOT NULL DEFAULT (0)::TIMESTAMPTZ,
+  row_version INT NOT NULL DEFAULT 0,
   machine_id STRING PRIMARY KEY AS (dimensions->'id'->>0) STORED,
   running_task bool AS (task_request IS NOT NULL) STORED,
   INVERTED INDEX dimensions_gin (dimensions),
@@ -44,6 +46,7 @@ CREATE TABLE IF NOT EXISTS TaskResult (
 
 var Description = []string{
 	"maintenance_mode",
+	"maintenance_reason",
 	"is_quarantined",
 	"recovering",
 	"attached_device",
@@ -64,6 +67,7 @@ var Description = []string{
 	"dimensions",
 	"task_request",
 	"task_started",
+	"row_version",
 }
 
 var TaskResult = []string{
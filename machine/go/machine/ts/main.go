@@ -24,9 +24,13 @@ func main() {
 		rpc.SetNoteRequest{},
 		rpc.SupplyChromeOSRequest{},
 		rpc.SetAttachedDevice{},
+		rpc.ToggleModeRequest{},
+		rpc.MaintenanceSummaryResponse{},
 	)
 	generator.AddIgnoreNil(rpc.ListMachinesResponse{})
 	generator.AddUnion(machine.AllAttachedDevices)
+	// Descriptions of machines not in maintenance mode have an unspecified reason.
+	generator.AddUnionWithName(append(machine.AllMaintenanceReasons, machine.MaintenanceReasonUnspecified), "MaintenanceReason")
 	generator.AddUnion(machine.AllPowerCycleStates)
 	generator.AddUnion(machine.AllTaskRequestorStates)
 
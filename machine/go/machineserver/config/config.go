@@ -9,6 +9,58 @@ type Pool struct {
 	// Regex is a regular expression that matches a machine id if that machine
 	// is in this pool.
 	Regex string `json:"regex"`
+
+	// PerfExportFields are the names of the Description fields which are
+	// periodically exported to Perf for the machines in this pool, see
+	// perfexport.Fields for the supported names. Nothing is exported for the
+	// pool if empty.
+	PerfExportFields []string `json:"perf_export_fields,omitempty"`
+}
+
+// PerfExport configures the periodic export of Description fields to Perf.
+type PerfExport struct {
+	// Bucket is the GCS bucket the Perf ingestion files are written to.
+	Bucket string `json:"bucket"`
+
+	// Prefix is the path in Bucket under which the files are written.
+	Prefix string `json:"prefix"`
+
+	// Period is how often the fields are exported, e.g. "5m".
+	Period string `json:"period"`
+}
+
+// DimensionRule derives a Swarming dimension from a field of the events sent by
+// test_machine_monitor, so that new kinds of devices can be supported without
+// changing the processor. See processor.Sources for the supported fields.
+type DimensionRule struct {
+	// Source is the name of the event field the rule is evaluated against,
+	// e.g. "android.getprop:ro.product.model" or "ios.device_type".
+	Source string `json:"source"`
+
+	// Regex is matched against the value of Source, and the rule only applies
+	// if it matches. If empty the rule applies to any non-empty value.
+	Regex string `json:"regex,omitempty"`
+
+	// Dimension is the name of the dimension which is set.
+	Dimension string `json:"dimension"`
+
+	// Value is the value the dimension is set to, which may refer to the
+	// submatches of Regex, e.g. "Pixel-$1". If empty the value of Source is
+	// used.
+	Value string `json:"value,omitempty"`
+}
+
+// DimensionRulesFile configures loading additional DimensionRules from GCS.
+type DimensionRulesFile struct {
+	// Bucket is the GCS bucket which contains the file.
+	Bucket string `json:"bucket"`
+
+	// Path is the path of the file in Bucket. The file contains a JSON list
+	// of DimensionRules.
+	Path string `json:"path"`
+
+	// Period is how often the file is reloaded, e.g. "5m".
+	Period string `json:"period"`
 }
 
 // InstanceConfig is the config for an instance of machineserver.
@@ -20,4 +72,16 @@ type InstanceConfig struct {
 	// Pools is a list of Pools. They are evaluated in the order they appear in
 	// the config file.
 	Pools []Pool `json:"pools"`
+
+	// PerfExport, if supplied, enables exporting the PerfExportFields of each
+	// Pool to Perf.
+	PerfExport *PerfExport `json:"perf_export,omitempty"`
+
+	// DimensionRules are applied, in the order they appear in the config
+	// file, to the dimensions of each machine after every event.
+	DimensionRules []DimensionRule `json:"dimension_rules,omitempty"`
+
+	// DimensionRulesFile, if supplied, is periodically reloaded and its
+	// rules are applied after DimensionRules.
+	DimensionRulesFile *DimensionRulesFile `json:"dimension_rules_file,omitempty"`
 }
@@ -6,16 +6,19 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
 	"time"
 
+	"cloud.google.com/go/storage"
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/unrolled/secure"
@@ -25,6 +28,7 @@ import (
 	"go.skia.org/infra/go/auditlog"
 	"go.skia.org/infra/go/baseapp"
 	"go.skia.org/infra/go/common"
+	"go.skia.org/infra/go/gcs/gcsclient"
 	"go.skia.org/infra/go/sql/pool/wrapper/timeout"
 
 	"go.skia.org/infra/go/httputils"
@@ -38,6 +42,7 @@ import (
 	changeSink "go.skia.org/infra/machine/go/machine/change/sink"
 	sseChangeSink "go.skia.org/infra/machine/go/machine/change/sink/sse"
 	httpEventSource "go.skia.org/infra/machine/go/machine/event/source/httpsource"
+	"go.skia.org/infra/machine/go/machine/perfexport"
 	"go.skia.org/infra/machine/go/machine/pools"
 	machineProcessor "go.skia.org/infra/machine/go/machine/processor"
 	machineStore "go.skia.org/infra/machine/go/machine/store"
@@ -119,6 +124,11 @@ func new(args []string) (*server, error) {
 	}
 
 	processor := machineProcessor.New(ctx)
+	rules, err := machineProcessor.NewRules(instanceConfig.DimensionRules)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "invalid dimension_rules")
+	}
+	processor.SetRules(rules)
 
 	if instanceConfig.ConnectionString == "" {
 		sklog.Fatal("ConnectionString must be supplied in the instance config")
@@ -139,6 +149,28 @@ func new(args []string) (*server, error) {
 		return nil, skerr.Wrap(err)
 	}
 
+	var storageClient *storage.Client
+	if instanceConfig.PerfExport != nil || instanceConfig.DimensionRulesFile != nil {
+		storageClient, err = storage.NewClient(ctx)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "create storage client")
+		}
+	}
+
+	if instanceConfig.DimensionRulesFile != nil {
+		if err := processor.StartRulesReload(ctx, instanceConfig, gcsclient.New(storageClient, instanceConfig.DimensionRulesFile.Bucket)); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+	}
+
+	if instanceConfig.PerfExport != nil {
+		exporter, err := perfexport.New(instanceConfig, store, gcsclient.New(storageClient, instanceConfig.PerfExport.Bucket))
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		exporter.Start(ctx)
+	}
+
 	httpSource, err := httpEventSource.New()
 	if err != nil {
 		return nil, skerr.Wrap(err)
@@ -251,6 +283,11 @@ func (s *server) machinesPageHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *server) machinesHandler(w http.ResponseWriter, r *http.Request) {
+	fieldMask, err := rpc.FieldMaskFromRequest(r)
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid fields", http.StatusBadRequest)
+		return
+	}
 	ctx, cancel := context.WithTimeout(r.Context(), defaultSQLTimeout)
 	defer cancel()
 	descriptions, err := s.store.List(ctx)
@@ -258,7 +295,12 @@ func (s *server) machinesHandler(w http.ResponseWriter, r *http.Request) {
 		httputils.ReportError(w, err, "Failed to read from datastore", http.StatusInternalServerError)
 		return
 	}
-	sendJSONResponse(descriptions, w)
+	resp, err := fieldMask.ApplyToList(descriptions)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to select fields", http.StatusInternalServerError)
+		return
+	}
+	sendJSONResponse(resp, w)
 }
 
 func (s *server) triggerDescriptionUpdateEvent(ctx context.Context, id string) {
@@ -268,16 +310,19 @@ func (s *server) triggerDescriptionUpdateEvent(ctx context.Context, id string) {
 }
 
 // toggleMode is used in machineToggleModeHandler and passed to s.store.Update
-// to toggle the Description mode between Available and Maintenance.
-func toggleMode(ctx context.Context, user string, in machine.Description) machine.Description {
+// to toggle the Description mode between Available and Maintenance. The reason
+// is only used when entering maintenance mode.
+func toggleMode(ctx context.Context, user string, reason machine.MaintenanceReason, in machine.Description) machine.Description {
 	ret := in.Copy()
 	ts := now.Now(ctx)
 	var annotation string
 	if !ret.InMaintenanceMode() {
 		ret.MaintenanceMode = fmt.Sprintf("%s %s", user, ts.Format(time.RFC3339))
-		annotation = "Enabled Maintenance Mode"
+		ret.MaintenanceReason = reason
+		annotation = fmt.Sprintf("Enabled Maintenance Mode: %s", reason)
 	} else {
 		ret.MaintenanceMode = ""
+		ret.MaintenanceReason = machine.MaintenanceReasonUnspecified
 		annotation = "Cleared Maintenance Mode."
 	}
 	ret.Annotation = machine.Annotation{
@@ -294,12 +339,34 @@ func (s *server) machineToggleModeHandler(w http.ResponseWriter, r *http.Request
 	if err != nil {
 		return
 	}
+
+	// The body is optional, since no reason is needed to leave maintenance mode.
+	var toggleModeRequest rpc.ToggleModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&toggleModeRequest); err != nil && err != io.EOF {
+		httputils.ReportError(w, err, "Failed to parse request.", http.StatusBadRequest)
+		return
+	}
+	if toggleModeRequest.Reason != machine.MaintenanceReasonUnspecified && !toggleModeRequest.Reason.IsValid() {
+		http.Error(w, fmt.Sprintf("Invalid maintenance reason: %q", toggleModeRequest.Reason), http.StatusBadRequest)
+		return
+	}
+
 	s.audit(w, r, "toggle-mode", id)
 	ctx, cancel := context.WithTimeout(r.Context(), defaultSQLTimeout)
 	defer cancel()
 
+	current, err := s.store.Get(ctx, id)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to read machine.", http.StatusInternalServerError)
+		return
+	}
+	if !current.InMaintenanceMode() && toggleModeRequest.Reason == machine.MaintenanceReasonUnspecified {
+		http.Error(w, "A reason is required to put a machine into maintenance mode.", http.StatusBadRequest)
+		return
+	}
+
 	err = s.store.Update(ctx, id, func(in machine.Description) machine.Description {
-		ret := toggleMode(ctx, string(s.login.LoggedInAs(r)), in)
+		ret := toggleMode(ctx, string(s.login.LoggedInAs(r)), toggleModeRequest.Reason, in)
 		return ret
 	})
 	if err != nil {
@@ -551,6 +618,23 @@ func (s *server) apiMachineDescriptionHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	clientVersion, err := rpc.ProtocolVersionFromRequest(r)
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid protocol version", http.StatusBadRequest)
+		return
+	}
+	version, err := rpc.NegotiateProtocolVersion(clientVersion)
+	if err != nil {
+		httputils.ReportError(w, err, "Unsupported protocol version", http.StatusBadRequest)
+		return
+	}
+	rpc.RecordProtocolVersion("description", clientVersion)
+	fieldMask, err := rpc.FieldMaskFromRequest(r)
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid fields", http.StatusBadRequest)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), defaultSQLTimeout)
 	defer cancel()
 
@@ -559,7 +643,25 @@ func (s *server) apiMachineDescriptionHandler(w http.ResponseWriter, r *http.Req
 		httputils.ReportError(w, err, "Failed to read from datastore", http.StatusInternalServerError)
 		return
 	}
-	sendJSONResponse(desc, w)
+	resp, err := fieldMask.Apply(desc)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to select fields", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(rpc.ProtocolVersionHeader, strconv.Itoa(version))
+	sendJSONResponse(resp, w)
+}
+
+func (s *server) apiMaintenanceSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultSQLTimeout)
+	defer cancel()
+
+	descriptions, err := s.store.List(ctx)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to read from datastore", http.StatusInternalServerError)
+		return
+	}
+	sendJSONResponse(rpc.ToMaintenanceSummaryResponse(descriptions), w)
 }
 
 func (s *server) apiPowerCycleListHandler(w http.ResponseWriter, r *http.Request) {
@@ -704,6 +806,7 @@ func (s *server) AddHandlers(r chi.Router) {
 	r.Get("/_/machines", gzip(http.HandlerFunc(s.machinesHandler)).ServeHTTP)
 	r.Get(rpc.MachineDescriptionURL, gzip(http.HandlerFunc(s.apiMachineDescriptionHandler)).ServeHTTP)
 	r.Get(rpc.PowerCycleListURL, gzip(http.HandlerFunc(s.apiPowerCycleListHandler)).ServeHTTP)
+	r.Get(rpc.MaintenanceSummaryURL, gzip(http.HandlerFunc(s.apiMaintenanceSummaryHandler)).ServeHTTP)
 	r.Get("/loginstatus/", gzip(http.HandlerFunc(s.loginStatus)).ServeHTTP)
 }
 
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -103,11 +104,54 @@ func TestMachineMainPageHandler_UnauthorizedRequest_Status401(t *testing.T) {
 	require.Equal(t, http.StatusSeeOther, w.Code)
 }
 
-func TestMachineToggleModeHandler_Success(t *testing.T) {
-	_, _, s, router, w := setupForTest(t)
+func TestMachineToggleModeHandler_EnterMaintenanceWithReason_Success(t *testing.T) {
+	_, desc, s, router, w := setupForTest(t)
 	changeSinkMock := s.sserChangeSink.(*changeSinkMocks.Sink)
 	changeSinkMock.On("Send", testutils.AnyContext, machineID).Return(nil)
 	storeMock := s.store.(*mocks.Store)
+	storeMock.On("Get", testutils.AnyContext, machineID).Return(desc, nil)
+	storeMock.On("Update", testutils.AnyContext, machineID, mock.Anything).Return(nil)
+	body := testutils.MarshalJSONReader(t, rpc.ToggleModeRequest{
+		Reason: machine.MaintenanceReasonOSUpdate,
+	})
+	r := newAuthorizedRequest("POST", fmt.Sprintf("/_/machine/toggle_mode/%s", machineID), body)
+
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMachineToggleModeHandler_EnterMaintenanceWithoutReason_ReturnsStatusBadRequest(t *testing.T) {
+	_, desc, s, router, w := setupForTest(t)
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("Get", testutils.AnyContext, machineID).Return(desc, nil)
+	r := newAuthorizedRequest("POST", fmt.Sprintf("/_/machine/toggle_mode/%s", machineID), nil)
+
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestMachineToggleModeHandler_InvalidReason_ReturnsStatusBadRequest(t *testing.T) {
+	_, _, _, router, w := setupForTest(t)
+	body := testutils.MarshalJSONReader(t, rpc.ToggleModeRequest{
+		Reason: "bored",
+	})
+	r := newAuthorizedRequest("POST", fmt.Sprintf("/_/machine/toggle_mode/%s", machineID), body)
+
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestMachineToggleModeHandler_LeaveMaintenanceWithoutReason_Success(t *testing.T) {
+	_, desc, s, router, w := setupForTest(t)
+	desc.MaintenanceMode = "barney@example.org 2022-11-09"
+	desc.MaintenanceReason = machine.MaintenanceReasonReserved
+	changeSinkMock := s.sserChangeSink.(*changeSinkMocks.Sink)
+	changeSinkMock.On("Send", testutils.AnyContext, machineID).Return(nil)
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("Get", testutils.AnyContext, machineID).Return(desc, nil)
 	storeMock.On("Update", testutils.AnyContext, machineID, mock.Anything).Return(nil)
 	r := newAuthorizedRequest("POST", fmt.Sprintf("/_/machine/toggle_mode/%s", machineID), nil)
 
@@ -120,15 +164,28 @@ func TestToggleMode_ChangesModeAndAddsAnnotation(t *testing.T) {
 	ctx, desc, _, _, _ := setupForTest(t)
 	desc.MaintenanceMode = ""
 
-	retDesc := toggleMode(ctx, testUser, desc)
+	retDesc := toggleMode(ctx, testUser, machine.MaintenanceReasonHardwareRepair, desc)
 
 	expected := machine.Annotation{
-		Message:   `Enabled Maintenance Mode`,
+		Message:   `Enabled Maintenance Mode: hardware_repair`,
 		User:      "somebody@example.org",
 		Timestamp: fakeTime,
 	}
 	require.Equal(t, expected, retDesc.Annotation)
 	require.Equal(t, "somebody@example.org 2021-09-01T02:03:04Z", retDesc.MaintenanceMode)
+	require.Equal(t, machine.MaintenanceReasonHardwareRepair, retDesc.MaintenanceReason)
+}
+
+func TestToggleMode_LeavingMaintenanceMode_ClearsReason(t *testing.T) {
+	ctx, desc, _, _, _ := setupForTest(t)
+	desc.MaintenanceMode = "barney@example.org 2022-11-09"
+	desc.MaintenanceReason = machine.MaintenanceReasonInvestigation
+
+	retDesc := toggleMode(ctx, testUser, machine.MaintenanceReasonUnspecified, desc)
+
+	require.Equal(t, "Cleared Maintenance Mode.", retDesc.Annotation.Message)
+	require.Empty(t, retDesc.MaintenanceMode)
+	require.Equal(t, machine.MaintenanceReasonUnspecified, retDesc.MaintenanceReason)
 }
 
 func TestMachineToggleModeHandler_FailOnMissingID(t *testing.T) {
@@ -414,6 +471,34 @@ func TestApiMachineDescriptionHandler_GoodMachineID_Description(t *testing.T) {
 	assert.Equal(t, desc, actual)
 }
 
+func TestApiMachineDescriptionHandler_ProtocolVersionSent_RespondsWithNegotiatedVersion(t *testing.T) {
+	ctx, desc, s, router, w := setupForTest(t)
+
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("Get", testutils.AnyContext, machineID).Return(desc, nil)
+
+	r := newAuthorizedRequest("GET", fmt.Sprintf("/json/v1/machine/description/%s", machineID), nil)
+	r.Header.Set(rpc.ProtocolVersionHeader, strconv.Itoa(machine.ProtocolVersion+1))
+	r = r.WithContext(ctx)
+
+	// Make the request.
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, strconv.Itoa(machine.ProtocolVersion), w.Header().Get(rpc.ProtocolVersionHeader))
+}
+
+func TestApiMachineDescriptionHandler_UnsupportedProtocolVersion_ReturnsBadRequest(t *testing.T) {
+	_, _, _, router, w := setupForTest(t)
+
+	r := newAuthorizedRequest("GET", fmt.Sprintf("/json/v1/machine/description/%s", machineID), nil)
+	r.Header.Set(rpc.ProtocolVersionHeader, strconv.Itoa(machine.MinSupportedProtocolVersion-1))
+
+	// Make the request.
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestApiMachineDescriptionHandler_StoreGetFails_ReturnsInternalServerError(t *testing.T) {
 	_, desc, s, router, w := setupForTest(t)
 
@@ -437,6 +522,120 @@ func TestApiMachineDescriptionHandler_NoMachineIDSupplied_ReturnsNotFound(t *tes
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestApiMachineDescriptionHandler_FieldsRequested_ReturnsOnlyThoseFields(t *testing.T) {
+	ctx, desc, s, router, w := setupForTest(t)
+
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("Get", testutils.AnyContext, machineID).Return(desc, nil)
+
+	r := newAuthorizedRequest("GET", fmt.Sprintf("/json/v1/machine/description/%s?fields=Dimensions.id,SSHUserIP", machineID), nil)
+	r = r.WithContext(ctx)
+
+	// Make the request.
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, fmt.Sprintf(`{"Dimensions": {"id": [%q]}, "SSHUserIP": %q}`, machineID, sshUserIP), w.Body.String())
+}
+
+func TestApiMachineDescriptionHandler_InvalidFields_ReturnsBadRequest(t *testing.T) {
+	_, _, _, router, w := setupForTest(t)
+
+	r := newAuthorizedRequest("GET", fmt.Sprintf("/json/v1/machine/description/%s?fields=NotAField", machineID), nil)
+
+	// Make the request.
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestMachinesHandler_NoFieldsRequested_ReturnsWholeDescriptions(t *testing.T) {
+	ctx, desc, s, router, w := setupForTest(t)
+
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("List", testutils.AnyContext).Return([]machine.Description{desc}, nil)
+
+	r := newAuthorizedRequest("GET", "/_/machines", nil)
+	r = r.WithContext(ctx)
+
+	// Make the request.
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var actual rpc.ListMachinesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+	assert.Equal(t, rpc.ListMachinesResponse{desc}, actual)
+}
+
+func TestMachinesHandler_FieldsRequested_ReturnsOnlyThoseFields(t *testing.T) {
+	ctx, desc, s, router, w := setupForTest(t)
+
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("List", testutils.AnyContext).Return([]machine.Description{desc}, nil)
+
+	r := newAuthorizedRequest("GET", "/_/machines?fields=Dimensions.id&fields=Battery", nil)
+	r = r.WithContext(ctx)
+
+	// Make the request.
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, fmt.Sprintf(`[{"Dimensions": {"id": [%q]}, "Battery": 0}]`, machineID), w.Body.String())
+}
+
+func TestMachinesHandler_InvalidFields_ReturnsBadRequest(t *testing.T) {
+	_, _, _, router, w := setupForTest(t)
+
+	r := newAuthorizedRequest("GET", "/_/machines?fields=Battery.level", nil)
+
+	// Make the request.
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestApiMaintenanceSummaryHandler_MachinesInMaintenance_ReturnsCountsPerReason(t *testing.T) {
+	ctx, _, s, router, w := setupForTest(t)
+	storeMock := s.store.(*mocks.Store)
+
+	available := machine.NewDescription(ctx)
+	repair1 := machine.NewDescription(ctx)
+	repair1.MaintenanceMode = "barney@example.org 2022-11-09"
+	repair1.MaintenanceReason = machine.MaintenanceReasonHardwareRepair
+	repair2 := repair1.Copy()
+	reserved := repair1.Copy()
+	reserved.MaintenanceReason = machine.MaintenanceReasonReserved
+	legacy := repair1.Copy()
+	legacy.MaintenanceReason = machine.MaintenanceReasonUnspecified
+	storeMock.On("List", testutils.AnyContext).Return([]machine.Description{available, repair1, repair2, reserved, legacy}, nil)
+
+	r := httptest.NewRequest("GET", "/json/v1/maintenance/summary", nil)
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var actual rpc.MaintenanceSummaryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+	assert.Equal(t, rpc.MaintenanceSummaryResponse{
+		Total: 4,
+		Reasons: []rpc.MaintenanceReasonCount{
+			{Reason: machine.MaintenanceReasonHardwareRepair, Count: 2},
+			{Reason: machine.MaintenanceReasonOSUpdate, Count: 0},
+			{Reason: machine.MaintenanceReasonInvestigation, Count: 0},
+			{Reason: machine.MaintenanceReasonReserved, Count: 1},
+			{Reason: machine.MaintenanceReasonUnspecified, Count: 1},
+		},
+	}, actual)
+}
+
+func TestApiMaintenanceSummaryHandler_ListFails_ReturnsInternalServerError(t *testing.T) {
+	_, _, s, router, w := setupForTest(t)
+	storeMock := s.store.(*mocks.Store)
+	storeMock.On("List", testutils.AnyContext).Return(nil, errFake)
+
+	r := httptest.NewRequest("GET", "/json/v1/maintenance/summary", nil)
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
 func TestApiPowerCycleListHandler_NoMachinesNeedPowerCycling_ReturnsEmptyList(t *testing.T) {
 	_, _, s, router, w := setupForTest(t)
 	storeMock := s.store.(*mocks.Store)
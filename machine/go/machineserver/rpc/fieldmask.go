@@ -0,0 +1,158 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/machine/go/machine"
+)
+
+// FieldsQueryParameter is the URL query parameter used to request only some
+// of the fields of each machine.Description, e.g.
+//
+//	/_/machines?fields=Dimensions.id,Battery,MaintenanceMode
+//
+// The parameter may also be repeated instead of using commas.
+const FieldsQueryParameter = "fields"
+
+// fieldPathSeparator separates a field name from a key within that field.
+const fieldPathSeparator = "."
+
+// FieldMask selects the parts of a machine.Description to include in a
+// response. Each path is the JSON name of a top-level field of
+// machine.Description, optionally followed by a key within that field if the
+// field is a map or a struct, e.g. "Dimensions.id" or "Note.Message". An empty
+// FieldMask selects the whole Description.
+type FieldMask [][]string
+
+// descriptionFields maps the JSON name of each of the fields of
+// machine.Description to its kind.
+var descriptionFields = func() map[string]reflect.Kind {
+	ret := map[string]reflect.Kind{}
+	t := reflect.TypeOf(machine.Description{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Name
+		if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		kind := f.Type.Kind()
+		if kind == reflect.Ptr {
+			kind = f.Type.Elem().Kind()
+		}
+		ret[name] = kind
+	}
+	return ret
+}()
+
+// ParseFieldMask returns the FieldMask for the given paths. It returns an
+// error if any of the paths do not refer to a field of machine.Description.
+func ParseFieldMask(paths []string) (FieldMask, error) {
+	var ret FieldMask
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		parts := strings.SplitN(path, fieldPathSeparator, 2)
+		kind, ok := descriptionFields[parts[0]]
+		if !ok {
+			valid := make([]string, 0, len(descriptionFields))
+			for name := range descriptionFields {
+				valid = append(valid, name)
+			}
+			sort.Strings(valid)
+			return nil, skerr.Fmt("unknown field %q, must be one of %v", parts[0], valid)
+		}
+		if len(parts) == 2 {
+			if parts[1] == "" {
+				return nil, skerr.Fmt("invalid field %q", path)
+			}
+			if kind != reflect.Map && kind != reflect.Struct {
+				return nil, skerr.Fmt("field %q does not have keys, so %q is invalid", parts[0], path)
+			}
+		}
+		ret = append(ret, parts)
+	}
+	return ret, nil
+}
+
+// FieldMaskFromRequest returns the FieldMask sent in the FieldsQueryParameter of
+// the request. A missing parameter selects the whole Description.
+func FieldMaskFromRequest(r *http.Request) (FieldMask, error) {
+	var paths []string
+	for _, value := range r.URL.Query()[FieldsQueryParameter] {
+		paths = append(paths, strings.Split(value, ",")...)
+	}
+	return ParseFieldMask(paths)
+}
+
+// Apply returns the parts of the Description selected by the FieldMask, in a
+// form that can be encoded as JSON. If the FieldMask is empty then the
+// Description is returned unchanged.
+func (m FieldMask) Apply(desc machine.Description) (interface{}, error) {
+	if len(m) == 0 {
+		return desc, nil
+	}
+	b, err := json.Marshal(desc)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	ret := map[string]interface{}{}
+	for _, path := range m {
+		value, ok := fields[path[0]]
+		if !ok {
+			// Fields tagged omitempty may be missing.
+			continue
+		}
+		if len(path) == 1 {
+			ret[path[0]] = value
+			continue
+		}
+		sub, ok := ret[path[0]].(map[string]json.RawMessage)
+		if !ok {
+			if _, ok := ret[path[0]]; ok {
+				// The whole field was already selected.
+				continue
+			}
+			sub = map[string]json.RawMessage{}
+			ret[path[0]] = sub
+		}
+		var keys map[string]json.RawMessage
+		if err := json.Unmarshal(value, &keys); err != nil {
+			return nil, skerr.Wrapf(err, "field %q", path[0])
+		}
+		if keyValue, ok := keys[path[1]]; ok {
+			sub[path[1]] = keyValue
+		}
+	}
+	return ret, nil
+}
+
+// ApplyToList returns the parts of each of the Descriptions selected by the
+// FieldMask. See Apply.
+func (m FieldMask) ApplyToList(descs []machine.Description) (interface{}, error) {
+	if len(m) == 0 {
+		return descs, nil
+	}
+	ret := make([]interface{}, 0, len(descs))
+	for _, desc := range descs {
+		masked, err := m.Apply(desc)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		ret = append(ret, masked)
+	}
+	return ret, nil
+}
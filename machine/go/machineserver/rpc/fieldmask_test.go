@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/machine/go/machine"
+)
+
+func testDescription() machine.Description {
+	return machine.Description{
+		MaintenanceMode: "somebody@example.org 2021-09-01T02:03:04Z",
+		Battery:         50,
+		Dimensions: machine.SwarmingDimensions{
+			machine.DimID: []string{"skia-rpi2-rack4-shelf1-001"},
+			machine.DimOS: []string{"Android"},
+		},
+		Note: machine.Annotation{
+			Message: "Flaky USB.",
+			User:    "somebody@example.org",
+		},
+	}
+}
+
+// applyAndEncode applies the FieldMask for the given paths to the test
+// Description and returns the resulting JSON.
+func applyAndEncode(t *testing.T, paths ...string) string {
+	m, err := ParseFieldMask(paths)
+	require.NoError(t, err)
+	masked, err := m.Apply(testDescription())
+	require.NoError(t, err)
+	b, err := json.Marshal(masked)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestFieldMaskApply_EmptyMask_ReturnsWholeDescription(t *testing.T) {
+	masked, err := FieldMask(nil).Apply(testDescription())
+	require.NoError(t, err)
+	assert.Equal(t, testDescription(), masked)
+}
+
+func TestFieldMaskApply_TopLevelFields_ReturnsOnlyThoseFields(t *testing.T) {
+	assert.JSONEq(t, `{
+		"Battery": 50,
+		"MaintenanceMode": "somebody@example.org 2021-09-01T02:03:04Z"
+	}`, applyAndEncode(t, "Battery", "MaintenanceMode"))
+}
+
+func TestFieldMaskApply_Keys_ReturnsOnlyThoseKeys(t *testing.T) {
+	assert.JSONEq(t, `{
+		"Dimensions": {"id": ["skia-rpi2-rack4-shelf1-001"]},
+		"Note": {"Message": "Flaky USB."}
+	}`, applyAndEncode(t, "Dimensions.id", "Dimensions.missing", "Note.Message"))
+}
+
+func TestFieldMaskApply_KeyAndWholeField_ReturnsWholeField(t *testing.T) {
+	expected := `{
+		"Dimensions": {
+			"id": ["skia-rpi2-rack4-shelf1-001"],
+			"os": ["Android"]
+		}
+	}`
+	assert.JSONEq(t, expected, applyAndEncode(t, "Dimensions.id", "Dimensions"))
+	assert.JSONEq(t, expected, applyAndEncode(t, "Dimensions", "Dimensions.id"))
+}
+
+func TestFieldMaskApply_OmittedField_IsNotReturned(t *testing.T) {
+	assert.JSONEq(t, `{"Battery": 50}`, applyAndEncode(t, "Battery", "TaskRequest"))
+}
+
+func TestFieldMaskApplyToList_AppliesMaskToEachDescription(t *testing.T) {
+	m, err := ParseFieldMask([]string{"Battery"})
+	require.NoError(t, err)
+	masked, err := m.ApplyToList([]machine.Description{testDescription(), {}})
+	require.NoError(t, err)
+	b, err := json.Marshal(masked)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"Battery": 50}, {"Battery": 0}]`, string(b))
+}
+
+func TestParseFieldMask_UnknownField_ReturnsError(t *testing.T) {
+	_, err := ParseFieldMask([]string{"NotAField"})
+	require.ErrorContains(t, err, `unknown field "NotAField"`)
+}
+
+func TestParseFieldMask_HiddenField_ReturnsError(t *testing.T) {
+	_, err := ParseFieldMask([]string{"RowVersion"})
+	require.ErrorContains(t, err, `unknown field "RowVersion"`)
+}
+
+func TestParseFieldMask_KeyOfScalarField_ReturnsError(t *testing.T) {
+	_, err := ParseFieldMask([]string{"Battery.level"})
+	require.ErrorContains(t, err, `field "Battery" does not have keys`)
+}
+
+func TestParseFieldMask_EmptyKey_ReturnsError(t *testing.T) {
+	_, err := ParseFieldMask([]string{"Dimensions."})
+	require.ErrorContains(t, err, `invalid field "Dimensions."`)
+}
+
+func TestFieldMaskFromRequest_CommaSeparatedAndRepeated_ReturnsAllPaths(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?fields=Battery,+Dimensions.id&fields=Version", nil)
+	m, err := FieldMaskFromRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, FieldMask{{"Battery"}, {"Dimensions", "id"}, {"Version"}}, m)
+}
+
+func TestFieldMaskFromRequest_ParameterMissing_ReturnsEmptyMask(t *testing.T) {
+	m, err := FieldMaskFromRequest(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+	assert.Empty(t, m)
+}
@@ -1,6 +1,11 @@
 package rpc
 
 import (
+	"net/http"
+	"strconv"
+
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/machine/go/machine"
 )
 
@@ -10,6 +15,7 @@ const (
 
 	MachineDescriptionRelativeURL           = "/machine/description/{id:.+}"
 	MachineEventRelativeURL                 = "/machine/event/"
+	MaintenanceSummaryRelativeURL           = "/maintenance/summary"
 	PowerCycleCompleteRelativeURL           = "/powercycle/complete/{id:.+}"
 	PowerCycleListRelativeURL               = "/powercycle/list"
 	PowerCycleStateUpdateRelativeURL        = "/powercycle/state/update"
@@ -17,12 +23,65 @@ const (
 
 	MachineDescriptionURL           = APIPrefix + MachineDescriptionRelativeURL
 	MachineEventURL                 = APIPrefix + MachineEventRelativeURL
+	MaintenanceSummaryURL           = APIPrefix + MaintenanceSummaryRelativeURL
 	PowerCycleCompleteURL           = APIPrefix + PowerCycleCompleteRelativeURL
 	PowerCycleListURL               = APIPrefix + PowerCycleListRelativeURL
 	PowerCycleStateUpdateURL        = APIPrefix + PowerCycleStateUpdateRelativeURL
 	SSEMachineDescriptionUpdatedURL = APIPrefix + SSEMachineDescriptionUpdatedRelativeURL
 )
 
+// ProtocolVersionHeader is the HTTP header test_machine_monitor uses to send
+// its machine.ProtocolVersion on requests without a body, and that
+// machineserver uses to reply with the negotiated protocol version.
+const ProtocolVersionHeader = "X-Machine-Protocol-Version"
+
+// DeprecatedProtocolVersionMetricName is the name of the counter incremented
+// each time a client makes a request using a protocol version older than
+// machine.ProtocolVersion.
+const DeprecatedProtocolVersionMetricName = "machineserver_deprecated_protocol_version"
+
+// NegotiateProtocolVersion returns the protocol version machineserver should
+// use to talk to a client that speaks clientVersion. Clients older than
+// machine.MinSupportedProtocolVersion are rejected, and clients newer than the
+// server are talked to using machine.ProtocolVersion.
+func NegotiateProtocolVersion(clientVersion int) (int, error) {
+	if clientVersion < machine.MinSupportedProtocolVersion {
+		return 0, skerr.Fmt("protocol version %d is no longer supported, the minimum supported version is %d", clientVersion, machine.MinSupportedProtocolVersion)
+	}
+	if clientVersion > machine.ProtocolVersion {
+		return machine.ProtocolVersion, nil
+	}
+	return clientVersion, nil
+}
+
+// ProtocolVersionFromRequest returns the protocol version sent in the
+// ProtocolVersionHeader of the request. A missing header means the client is
+// from before the protocol was versioned, i.e. version 0.
+func ProtocolVersionFromRequest(r *http.Request) (int, error) {
+	value := r.Header.Get(ProtocolVersionHeader)
+	if value == "" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, skerr.Wrapf(err, "invalid %s header %q", ProtocolVersionHeader, value)
+	}
+	return version, nil
+}
+
+// RecordProtocolVersion counts requests to the named RPC made by clients using
+// a deprecated protocol version, so we know when it is safe to drop support
+// for it.
+func RecordProtocolVersion(rpcName string, clientVersion int) {
+	if clientVersion >= machine.ProtocolVersion {
+		return
+	}
+	metrics2.GetCounter(DeprecatedProtocolVersionMetricName, map[string]string{
+		"rpc":     rpcName,
+		"version": strconv.Itoa(clientVersion),
+	}).Inc(1)
+}
+
 type SupplyChromeOSRequest struct {
 	SSHUserIP          string
 	SuppliedDimensions machine.SwarmingDimensions
@@ -33,6 +92,13 @@ type SetNoteRequest struct {
 	// User and Timestamp will be added by the server
 }
 
+// ToggleModeRequest is the body of a request to toggle maintenance mode. A
+// Reason is required when putting a machine into maintenance mode, and ignored
+// when taking it out.
+type ToggleModeRequest struct {
+	Reason machine.MaintenanceReason
+}
+
 type SetAttachedDevice struct {
 	AttachedDevice machine.AttachedDevice
 }
@@ -57,3 +123,45 @@ type ListPowerCycleResponse []string
 func ToListPowerCycleResponse(machineIDs []string) ListPowerCycleResponse {
 	return machineIDs
 }
+
+// MaintenanceReasonCount is the number of machines in maintenance mode for a
+// single reason.
+type MaintenanceReasonCount struct {
+	Reason machine.MaintenanceReason
+	Count  int
+}
+
+// MaintenanceSummaryResponse summarizes why machines across the fleet are in
+// maintenance mode.
+type MaintenanceSummaryResponse struct {
+	// Total is the number of machines in maintenance mode.
+	Total int
+
+	// Reasons has one entry for each of machine.AllMaintenanceReasons, in
+	// that order, followed by machine.MaintenanceReasonUnspecified for
+	// machines put into maintenance mode before reasons were recorded.
+	Reasons []MaintenanceReasonCount
+}
+
+// ToMaintenanceSummaryResponse counts the machines in maintenance mode in the
+// response from store.List by reason.
+func ToMaintenanceSummaryResponse(descriptions []machine.Description) MaintenanceSummaryResponse {
+	counts := map[machine.MaintenanceReason]int{}
+	ret := MaintenanceSummaryResponse{}
+	for _, d := range descriptions {
+		if !d.InMaintenanceMode() {
+			continue
+		}
+		ret.Total++
+		counts[d.MaintenanceReason]++
+	}
+	reasons := append([]machine.MaintenanceReason{}, machine.AllMaintenanceReasons...)
+	reasons = append(reasons, machine.MaintenanceReasonUnspecified)
+	for _, reason := range reasons {
+		ret.Reasons = append(ret.Reasons, MaintenanceReasonCount{
+			Reason: reason,
+			Count:  counts[reason],
+		})
+	}
+	return ret
+}
@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/machine/go/machine"
+)
+
+func TestNegotiateProtocolVersion_CurrentVersion_ReturnsCurrentVersion(t *testing.T) {
+	version, err := NegotiateProtocolVersion(machine.ProtocolVersion)
+	require.NoError(t, err)
+	assert.Equal(t, machine.ProtocolVersion, version)
+}
+
+func TestNegotiateProtocolVersion_PreviousVersion_ReturnsPreviousVersion(t *testing.T) {
+	version, err := NegotiateProtocolVersion(machine.ProtocolVersion - 1)
+	require.NoError(t, err)
+	assert.Equal(t, machine.ProtocolVersion-1, version)
+}
+
+func TestNegotiateProtocolVersion_NewerClient_ReturnsServerVersion(t *testing.T) {
+	version, err := NegotiateProtocolVersion(machine.ProtocolVersion + 1)
+	require.NoError(t, err)
+	assert.Equal(t, machine.ProtocolVersion, version)
+}
+
+func TestNegotiateProtocolVersion_UnsupportedVersion_ReturnsError(t *testing.T) {
+	_, err := NegotiateProtocolVersion(machine.MinSupportedProtocolVersion - 1)
+	require.Error(t, err)
+}
+
+func TestProtocolVersionFromRequest_HeaderMissing_ReturnsZero(t *testing.T) {
+	version, err := ProtocolVersionFromRequest(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 0, version)
+}
+
+func TestProtocolVersionFromRequest_HeaderPresent_ReturnsVersion(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set(ProtocolVersionHeader, "3")
+	version, err := ProtocolVersionFromRequest(r)
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+}
+
+func TestProtocolVersionFromRequest_InvalidHeader_ReturnsError(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set(ProtocolVersionHeader, "three")
+	_, err := ProtocolVersionFromRequest(r)
+	require.Error(t, err)
+}
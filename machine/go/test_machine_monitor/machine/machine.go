@@ -339,10 +339,14 @@ func (m *Machine) retrieveDescription(ctx context.Context) error {
 	if err != nil {
 		return skerr.Wrapf(err, "Failed to create HTTP request")
 	}
+	req.Header.Set(rpc.ProtocolVersionHeader, strconv.Itoa(machine.ProtocolVersion))
 	resp, err := m.client.Do(req)
 	if err != nil {
 		return skerr.Wrapf(err, "Failed to retrieve description from %q", m.machineDescriptionURL)
 	}
+	if serverVersion := resp.Header.Get(rpc.ProtocolVersionHeader); serverVersion != strconv.Itoa(machine.ProtocolVersion) {
+		sklog.Warningf("machineserver speaks protocol version %q, we speak %d", serverVersion, machine.ProtocolVersion)
+	}
 	var desc machine.Description
 	if err := json.NewDecoder(resp.Body).Decode(&desc); err != nil {
 		return skerr.Wrapf(err, "Failed to decode description from %q", m.machineDescriptionURL)
@@ -213,6 +213,7 @@ func TestInterrogate_NoDeviceAttached_Success(t *testing.T) {
 	actual, err := m.interrogate(ctx)
 	require.NoError(t, err)
 	assert.Equal(t, machine.Event{
+		ProtocolVersion:     machine.ProtocolVersion,
 		EventType:           machine.EventTypeRawState,
 		LaunchedSwarming:    true,
 		RunningSwarmingTask: true,
@@ -248,6 +249,7 @@ func TestInterrogate_AndroidDeviceAttached_Success(t *testing.T) {
 	actual, err := m.interrogate(ctx)
 	require.NoError(t, err)
 	assert.Equal(t, machine.Event{
+		ProtocolVersion:     machine.ProtocolVersion,
 		EventType:           machine.EventTypeRawState,
 		LaunchedSwarming:    true,
 		RunningSwarmingTask: true,
@@ -280,6 +282,7 @@ func goodIOSInterrogationResult(timePlaceholder time.Time) (*Machine, machine.Ev
 		},
 	}
 	expected := machine.Event{
+		ProtocolVersion:     machine.ProtocolVersion,
 		EventType:           machine.EventTypeRawState,
 		LaunchedSwarming:    true,
 		RunningSwarmingTask: true,
@@ -364,7 +367,8 @@ func TestInterrogate_EVKDeviceAttached_Success(t *testing.T) {
 	actual, err := m.interrogate(ctx)
 	require.NoError(t, err)
 	assert.Equal(t, machine.Event{
-		EventType: machine.EventTypeRawState,
+		ProtocolVersion: machine.ProtocolVersion,
+		EventType:       machine.EventTypeRawState,
 		Host: machine.Host{
 			Name:      "some-machine",
 			Version:   "some-version",
@@ -400,6 +404,7 @@ func TestInterrogate_ChromeOSDeviceAttached_Success(t *testing.T) {
 	actual, err := m.interrogate(ctx)
 	require.NoError(t, err)
 	assert.Equal(t, machine.Event{
+		ProtocolVersion:     machine.ProtocolVersion,
 		EventType:           machine.EventTypeRawState,
 		LaunchedSwarming:    true,
 		RunningSwarmingTask: true,
@@ -534,7 +539,8 @@ func TestInterrogateAndSend_InterrogateSuccessful_EmitsEventViaSink(t *testing.T
 
 	start := time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
 	expectedEvent := machine.Event{
-		EventType: "raw_state",
+		ProtocolVersion: machine.ProtocolVersion,
+		EventType:       "raw_state",
 		Android: machine.Android{
 			GetProp:               adbShellGetPropSuccess,
 			DumpsysBattery:        adbShellDumpSysBattery,
@@ -581,7 +587,8 @@ func TestInterrogateAndSend_AdbFailsToTalkToDevice_EmptyEventsSentToServer(t *te
 
 	start := time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
 	expectedEvent := machine.Event{
-		EventType: "raw_state",
+		ProtocolVersion: machine.ProtocolVersion,
+		EventType:       "raw_state",
 		Android: machine.Android{
 			GetProp:               "",
 			DumpsysBattery:        "",
@@ -13,6 +13,20 @@ export interface SetAttachedDevice {
 	AttachedDevice: AttachedDevice;
 }
 
+export interface ToggleModeRequest {
+	Reason: MaintenanceReason;
+}
+
+export interface MaintenanceReasonCount {
+	Reason: MaintenanceReason;
+	Count: number;
+}
+
+export interface MaintenanceSummaryResponse {
+	Total: number;
+	Reasons: MaintenanceReasonCount[] | null;
+}
+
 export interface Annotation {
 	Message: string;
 	User: string;
@@ -51,6 +65,7 @@ export interface TaskRequest {
 
 export interface Description {
 	MaintenanceMode: string;
+	MaintenanceReason: MaintenanceReason;
 	IsQuarantined: boolean;
 	Recovering: string;
 	AttachedDevice: AttachedDevice;
@@ -77,6 +92,8 @@ export type SwarmingDimensions = { [key: string]: string[] | null } | null;
 
 export type AttachedDevice = 'nodevice' | 'adb' | 'ios' | 'pyocd' | 'ssh';
 
+export type MaintenanceReason = 'hardware_repair' | 'os_update' | 'investigation' | 'reserved' | '';
+
 export type PowerCycleState = 'not_available' | 'available' | 'in_error';
 
 export type Duration = number;
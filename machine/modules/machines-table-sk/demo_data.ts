@@ -7,6 +7,7 @@ export const descriptions: ListMachinesResponse = [
   {
     AttachedDevice: 'adb',
     MaintenanceMode: '',
+    MaintenanceReason: '',
     Recovering: '',
     IsQuarantined: false,
     Annotation: {
@@ -51,6 +52,7 @@ export const descriptions: ListMachinesResponse = [
   },
   {
     MaintenanceMode: '',
+    MaintenanceReason: '',
     Recovering: '',
     IsQuarantined: false,
     AttachedDevice: 'ssh',
@@ -92,6 +94,7 @@ export const descriptions: ListMachinesResponse = [
   },
   {
     MaintenanceMode: '',
+    MaintenanceReason: '',
     Recovering: '',
     IsQuarantined: false,
     AttachedDevice: 'adb',
@@ -133,6 +136,7 @@ export const descriptions: ListMachinesResponse = [
   },
   {
     MaintenanceMode: '',
+    MaintenanceReason: '',
     Recovering: '',
     IsQuarantined: false,
     AttachedDevice: 'adb',
@@ -174,6 +178,7 @@ export const descriptions: ListMachinesResponse = [
   },
   {
     MaintenanceMode: '',
+    MaintenanceReason: '',
     Recovering: '',
     IsQuarantined: false,
     AttachedDevice: 'adb',
@@ -215,6 +220,7 @@ export const descriptions: ListMachinesResponse = [
   },
   {
     MaintenanceMode: '',
+    MaintenanceReason: '',
     Recovering: 'Low power.',
     IsQuarantined: false,
     AttachedDevice: 'adb',
@@ -260,6 +266,7 @@ export const descriptions: ListMachinesResponse = [
   },
   {
     MaintenanceMode: '',
+    MaintenanceReason: '',
     Recovering: '',
     IsQuarantined: false,
     AttachedDevice: 'adb',
@@ -301,6 +308,7 @@ export const descriptions: ListMachinesResponse = [
   },
   {
     MaintenanceMode: '',
+    MaintenanceReason: '',
     Recovering: '',
     IsQuarantined: false,
     AttachedDevice: 'adb',
@@ -346,6 +354,7 @@ export const descriptions: ListMachinesResponse = [
   },
   {
     MaintenanceMode: '',
+    MaintenanceReason: '',
     Recovering: 'Too hot.',
     IsQuarantined: false,
     AttachedDevice: 'adb',
@@ -392,6 +401,7 @@ export const descriptions: ListMachinesResponse = [
   },
   {
     MaintenanceMode: '',
+    MaintenanceReason: '',
     Recovering: '',
     IsQuarantined: false,
     AttachedDevice: 'ssh',
@@ -18,9 +18,11 @@ import {
   Annotation,
   AttachedDevice,
   Description,
+  MaintenanceReason,
   SetAttachedDevice,
   SetNoteRequest,
   SupplyChromeOSRequest,
+  ToggleModeRequest,
 } from '../json';
 
 import '../../../infra-sk/modules/theme-chooser-sk/theme-chooser-sk';
@@ -90,6 +92,14 @@ const attachedDeviceDisplayName: Record<string, AttachedDevice> = {
 /** attachedDeviceDisplayName keys sorted by display name. */
 const attachedDeviceDisplayNamesOrder: string[] = Object.keys(attachedDeviceDisplayName).sort();
 
+/** The reasons a machine can be put into maintenance mode, with display names. */
+const maintenanceReasonDisplayName: Record<string, MaintenanceReason> = {
+  'Hardware repair': 'hardware_repair',
+  'OS update': 'os_update',
+  Investigation: 'investigation',
+  Reserved: 'reserved',
+};
+
 /** sortBooleans is a utility function for sorting booleans, where true comes
  * before false. */
 const sortBooleans = (a: boolean, b: boolean): number => {
@@ -489,12 +499,27 @@ export class MachinesTableSk extends ElementSk {
 
   // eslint-disable-next-line no-use-before-define
   toggleModeElement(machine: Description): TemplateResult {
+    // A reason must be chosen to put a machine into maintenance mode.
+    if (machine.MaintenanceMode === '') {
+      return html`
+        <select
+          class="mode"
+          title="Put machine in maintenance mode"
+          @input=${(e: InputEvent) => this.maintenanceReasonChosen(e, machine.Dimensions!.id![0])}>
+          <option value="" selected>available</option>
+          ${Object.keys(maintenanceReasonDisplayName).map(
+            (key: string) =>
+              html`<option value=${maintenanceReasonDisplayName[key]}>maintenance: ${key}</option>`
+          )}
+        </select>
+      `;
+    }
     return html`
       <button
         class="mode"
         @click=${() => this.toggleMode(machine.Dimensions!.id![0])}
-        title="${machine.MaintenanceMode || 'Put machine in maintenance mode'}">
-        ${machine.MaintenanceMode === '' ? 'available' : 'maintenance'}
+        title="${machine.MaintenanceMode} ${machine.MaintenanceReason}">
+        maintenance
       </button>
     `;
   }
@@ -757,9 +782,25 @@ export class MachinesTableSk extends ElementSk {
     });
   }
 
-  async toggleMode(id: string): Promise<void> {
+  async maintenanceReasonChosen(e: InputEvent, id: string): Promise<void> {
+    const sel = e.target as HTMLSelectElement;
+    const reason = sel.selectedOptions[0].value as MaintenanceReason;
+    if (reason === '') {
+      return;
+    }
+    await this.toggleMode(id, reason);
+  }
+
+  async toggleMode(id: string, reason: MaintenanceReason = ''): Promise<void> {
+    const request: ToggleModeRequest = {
+      Reason: reason,
+    };
     await this.fetchCheckAndUpdate(`/_/machine/toggle_mode/${id}`, {
       method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify(request),
     });
   }
 
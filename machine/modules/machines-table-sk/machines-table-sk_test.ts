@@ -102,7 +102,7 @@ describe('machines-table-sk', () => {
     document.body.innerHTML = '';
   });
 
-  it('updates the mode when you click on the mode button', () =>
+  it('puts the machine into maintenance mode when you choose a reason', () =>
     window.customElements.whenDefined('machines-table-sk').then(async () => {
       const s = await setUpElement();
 
@@ -112,6 +112,7 @@ describe('machines-table-sk', () => {
       mockMachinesResponse([
         {
           MaintenanceMode: 'barney@example.com 2022-11-09',
+          MaintenanceReason: 'os_update',
           Recovering: '',
           IsQuarantined: false,
           AttachedDevice: 'ssh',
@@ -136,14 +137,22 @@ describe('machines-table-sk', () => {
         },
       ]);
 
-      // Click the button.
-      const button = $$<HTMLButtonElement>('button.mode', s)!;
-      button.click();
+      // Choose a reason.
+      const select = $$<HTMLSelectElement>('select.mode', s)!;
+      select.value = 'os_update';
+      select.dispatchEvent(new InputEvent('input'));
 
       // Wait for all requests to finish.
       await fetchMock.flush(true);
 
-      // Confirm the button text has been updated.
+      // Confirm the reason was sent.
+      assert.deepEqual(
+        JSON.parse(fetchMock.lastOptions('/_/machine/toggle_mode/skia-rpi2-rack4-shelf1-002')!.body as string),
+        { Reason: 'os_update' }
+      );
+
+      // Confirm the select has been replaced with a button to leave maintenance mode.
+      const button = $$<HTMLButtonElement>('button.mode', s)!;
       assert.equal('maintenance', button.textContent?.trim());
     }));
 
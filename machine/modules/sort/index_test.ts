@@ -86,6 +86,7 @@ describe('SortHistory', () => {
     // Start with a base Description;
     const desc1: Description = {
       MaintenanceMode: '',
+      MaintenanceReason: '',
       Recovering: '',
       IsQuarantined: false,
       AttachedDevice: 'nodevice',
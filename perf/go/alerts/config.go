@@ -155,6 +155,99 @@ type Alert struct {
 	// Subscription fields.
 	SubscriptionName     string `json:"sub_name,omitempty"`
 	SubscriptionRevision string `json:"sub_revision,omitempty"`
+
+	// NotificationSinks is a newline separated list of where notifications
+	// for this alert are sent, each of the form "type:target", see
+	// NotificationSink. If empty then notifications are sent using the
+	// instance's configured notifier to Alert and IssueTrackerComponent.
+	NotificationSinks string `json:"notification_sinks,omitempty"`
+
+	// OwnerRotationURL is the URL of a rotation, e.g. a sheriff rotation, that
+	// the owner of the alert is looked up from each time a notification is
+	// sent. Owner is used if the rotation can't be loaded.
+	OwnerRotationURL string `json:"owner_rotation_url,omitempty"`
+}
+
+// SinkType is the kind of destination a NotificationSink sends to.
+type SinkType string
+
+const (
+	// EmailSink sends an email, the Target is a comma separated list of email
+	// addresses.
+	EmailSink SinkType = "email"
+
+	// ChatSink posts to a Google Chat webhook, the Target is the URL of the
+	// webhook.
+	ChatSink SinkType = "chat"
+
+	// IssueTrackerSink files an issue, the Target is the issue tracker
+	// component id.
+	IssueTrackerSink SinkType = "issuetracker"
+)
+
+// AllSinkTypes is all the valid SinkType values.
+var AllSinkTypes = []SinkType{EmailSink, ChatSink, IssueTrackerSink}
+
+// chatWebhookPrefix is the prefix that all Google Chat webhook URLs start with.
+const chatWebhookPrefix = "https://chat.googleapis.com/"
+
+// NotificationSink is a single destination for an Alert's notifications.
+type NotificationSink struct {
+	Type   SinkType
+	Target string
+}
+
+// String returns the NotificationSink in the form used in
+// Alert.NotificationSinks, i.e. "type:target".
+func (n NotificationSink) String() string {
+	return fmt.Sprintf("%s:%s", n.Type, n.Target)
+}
+
+// Sinks returns the parsed and validated NotificationSinks of the Alert.
+func (c *Alert) Sinks() ([]NotificationSink, error) {
+	ret := []NotificationSink{}
+	for _, line := range strings.Split(c.NotificationSinks, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return nil, skerr.Fmt("Invalid Config: Notification sink %q must be of the form type:target.", line)
+		}
+		sink := NotificationSink{
+			Type:   SinkType(strings.TrimSpace(parts[0])),
+			Target: strings.TrimSpace(parts[1]),
+		}
+		if err := sink.Validate(); err != nil {
+			return nil, err
+		}
+		ret = append(ret, sink)
+	}
+	return ret, nil
+}
+
+// Validate returns an error if the NotificationSink is not valid.
+func (n NotificationSink) Validate() error {
+	if n.Target == "" {
+		return skerr.Fmt("Invalid Config: Notification sink of type %q has no target.", n.Type)
+	}
+	switch n.Type {
+	case EmailSink:
+		return nil
+	case ChatSink:
+		if !strings.HasPrefix(n.Target, chatWebhookPrefix) {
+			return skerr.Fmt("Invalid Config: Chat webhook %q must start with %q.", n.Target, chatWebhookPrefix)
+		}
+		return nil
+	case IssueTrackerSink:
+		if _, err := strconv.ParseInt(n.Target, 10, 64); err != nil {
+			return skerr.Fmt("Invalid Config: Issue tracker component %q must be an integer.", n.Target)
+		}
+		return nil
+	default:
+		return skerr.Fmt("Invalid Config: Unknown notification sink type %q, must be one of %v.", n.Type, AllSinkTypes)
+	}
 }
 
 type AlertsStatus struct {
@@ -301,11 +394,22 @@ func (c *Alert) Validate() error {
 	}
 	if c.GroupBy != "" {
 		for _, groupParam := range c.GroupedBy() {
-			if _, ok := parsed[groupParam]; ok {
+			_, ok := parsed[groupParam]
+			_, negatedOK := parsed[groupParam+"!"]
+			if ok || negatedOK {
 				return fmt.Errorf("Invalid Config: Group By values %q must not appear in the Query: %q ", c.GroupBy, c.Query)
 			}
 		}
 	}
+	if _, err := c.Sinks(); err != nil {
+		return err
+	}
+	if c.OwnerRotationURL != "" {
+		u, err := url.Parse(c.OwnerRotationURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("Invalid Config: Owner rotation URL must be an http(s) URL: %q", c.OwnerRotationURL)
+		}
+	}
 	if c.StepUpOnly {
 		c.StepUpOnly = false
 		c.DirectionAsString = UP
@@ -86,6 +86,19 @@ func TestValidate(t *testing.T) {
 	a.GroupBy = "foo"
 	a.Query = "bar=baz&foo=quux"
 	assert.Error(t, a.Validate())
+
+	a.GroupBy = "foo"
+	a.Query = "bar=baz&foo!=quux"
+	assert.Error(t, a.Validate())
+}
+
+func TestValidate_OwnerRotationURL(t *testing.T) {
+	a := NewConfig()
+	a.OwnerRotationURL = "https://rotations.example.org/current/sheriff"
+	assert.NoError(t, a.Validate())
+
+	a.OwnerRotationURL = "sheriff@example.org"
+	assert.Error(t, a.Validate())
 }
 
 func TestGroupedBy(t *testing.T) {
@@ -222,3 +235,36 @@ func TestIDAsStringToInt_InvalidID_Success(t *testing.T) {
 
 	assert.Equal(t, BadAlertID, IDAsStringToInt("not-a-number"))
 }
+
+func TestSinks_ValidSinks_ReturnsParsedSinks(t *testing.T) {
+	cfg := NewConfig()
+	cfg.NotificationSinks = "email:someone@example.org\n\n chat: https://chat.googleapis.com/v1/spaces/AAAA/messages?key=k \nissuetracker:1234"
+	sinks, err := cfg.Sinks()
+	require.NoError(t, err)
+	assert.Equal(t, []NotificationSink{
+		{Type: EmailSink, Target: "someone@example.org"},
+		{Type: ChatSink, Target: "https://chat.googleapis.com/v1/spaces/AAAA/messages?key=k"},
+		{Type: IssueTrackerSink, Target: "1234"},
+	}, sinks)
+}
+
+func TestSinks_NoSinks_ReturnsEmptySlice(t *testing.T) {
+	sinks, err := NewConfig().Sinks()
+	require.NoError(t, err)
+	assert.Empty(t, sinks)
+}
+
+func TestSinks_InvalidSinks_ReturnsError(t *testing.T) {
+	for name, sinks := range map[string]string{
+		"missing type":               "someone@example.org",
+		"unknown type":               "pager:someone",
+		"empty target":               "email:",
+		"chat webhook on other host": "chat:https://example.org/webhook",
+		"non-integer component":      "issuetracker:my-component",
+	} {
+		cfg := NewConfig()
+		cfg.NotificationSinks = sinks
+		_, err := cfg.Sinks()
+		assert.Error(t, err, name)
+	}
+}
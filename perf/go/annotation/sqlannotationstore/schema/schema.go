@@ -0,0 +1,29 @@
+package schema
+
+// AnnotationSchema represents the SQL schema of the Annotations table.
+type AnnotationSchema struct {
+	// Unique identifier of the annotation.
+	ID string `sql:"id UUID PRIMARY KEY DEFAULT gen_random_uuid()"`
+
+	// The first commit number the annotation applies to.
+	BeginCommit int `sql:"begin_commit INT NOT NULL"`
+
+	// The last commit number the annotation applies to, inclusive.
+	EndCommit int `sql:"end_commit INT NOT NULL"`
+
+	// The text of the annotation.
+	Note string `sql:"note STRING NOT NULL"`
+
+	// If true then regressions in the commit range are not reported.
+	SuppressAlerts bool `sql:"suppress_alerts BOOL NOT NULL DEFAULT false"`
+
+	// The user who created the annotation, as their email as returned by
+	// uber-proxy auth.
+	Author string `sql:"author STRING NOT NULL"`
+
+	// Stored as a Unix timestamp.
+	LastModified int `sql:"last_modified INT"`
+
+	// Index used to find the annotations that overlap a commit range.
+	byCommitRangeIndex struct{} `sql:"INDEX by_commit_range (begin_commit, end_commit)"`
+}
@@ -0,0 +1,115 @@
+// Package sqlannotationstore implements annotation.Store using an SQL
+// database.
+package sqlannotationstore
+
+import (
+	"context"
+	"time"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sql/pool"
+	"go.skia.org/infra/perf/go/annotation"
+	"go.skia.org/infra/perf/go/types"
+)
+
+// statement is an SQL statement identifier.
+type statement int
+
+const (
+	// The identifiers for all the SQL statements used.
+	insertAnnotation statement = iota
+	deleteAnnotation
+	listAnnotations
+)
+
+// statements holds all the raw SQL statements.
+var statements = map[statement]string{
+	insertAnnotation: `
+		INSERT INTO
+			Annotations (begin_commit, end_commit, note, suppress_alerts, author, last_modified)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING
+			id
+	`,
+	deleteAnnotation: `
+		DELETE
+		FROM
+			Annotations
+		WHERE
+			id=$1
+	`,
+	listAnnotations: `
+		SELECT
+			id, begin_commit, end_commit, note, suppress_alerts, author, last_modified
+		FROM
+			Annotations
+		WHERE
+			begin_commit <= $2 AND end_commit >= $1
+		ORDER BY
+			begin_commit, end_commit
+	`,
+}
+
+// AnnotationStore implements the annotation.Store interface using an SQL
+// database.
+type AnnotationStore struct {
+	db pool.Pool
+}
+
+// New returns a new *AnnotationStore.
+func New(db pool.Pool) *AnnotationStore {
+	return &AnnotationStore{
+		db: db,
+	}
+}
+
+// Create implements the annotation.Store interface.
+func (s *AnnotationStore) Create(ctx context.Context, a *annotation.Annotation) (string, error) {
+	if err := a.Validate(); err != nil {
+		return "", skerr.Wrap(err)
+	}
+	var id string
+	if err := s.db.QueryRow(ctx, statements[insertAnnotation], a.Begin, a.End, a.Note, a.SuppressAlerts, a.Author, time.Now().Unix()).Scan(&id); err != nil {
+		return "", skerr.Wrapf(err, "Failed to insert annotation")
+	}
+	return id, nil
+}
+
+// Delete implements the annotation.Store interface.
+func (s *AnnotationStore) Delete(ctx context.Context, id string) error {
+	call, err := s.db.Exec(ctx, statements[deleteAnnotation], id)
+	if err != nil {
+		return skerr.Wrapf(err, "Failed to delete annotation with id=%s", id)
+	}
+	if call.RowsAffected() != 1 {
+		return skerr.Fmt("No such annotation: %s", id)
+	}
+	return nil
+}
+
+// List implements the annotation.Store interface.
+func (s *AnnotationStore) List(ctx context.Context, begin, end types.CommitNumber) ([]*annotation.Annotation, error) {
+	rows, err := s.db.Query(ctx, statements[listAnnotations], begin, end)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to query annotations for [%d, %d]", begin, end)
+	}
+	defer rows.Close()
+
+	ret := []*annotation.Annotation{}
+	for rows.Next() {
+		a := &annotation.Annotation{}
+		var lastModified *int64
+		if err := rows.Scan(&a.ID, &a.Begin, &a.End, &a.Note, &a.SuppressAlerts, &a.Author, &lastModified); err != nil {
+			return nil, skerr.Wrapf(err, "Failed to read annotation")
+		}
+		if lastModified != nil {
+			a.LastModified = *lastModified
+		}
+		ret = append(ret, a)
+	}
+	return ret, nil
+}
+
+// Confirm AnnotationStore implements annotation.Store.
+var _ annotation.Store = (*AnnotationStore)(nil)
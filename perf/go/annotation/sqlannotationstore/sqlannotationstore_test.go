@@ -0,0 +1,88 @@
+package sqlannotationstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/perf/go/annotation"
+	"go.skia.org/infra/perf/go/sql/sqltest"
+)
+
+func setUp(t *testing.T) annotation.Store {
+	db := sqltest.NewCockroachDBForTests(t, "annotationstore")
+	return New(db)
+}
+
+func TestCreate_ListOverlappingRange_ReturnsAnnotation(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	id, err := store.Create(ctx, &annotation.Annotation{
+		Begin:          10,
+		End:            20,
+		Note:           "lab power outage",
+		SuppressAlerts: true,
+		Author:         "a@b.com",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+
+	annotations, err := store.List(ctx, 20, 30)
+	require.NoError(t, err)
+	require.Len(t, annotations, 1)
+	assert.Equal(t, id, annotations[0].ID)
+	assert.Equal(t, "lab power outage", annotations[0].Note)
+	assert.True(t, annotations[0].SuppressAlerts)
+	assert.Equal(t, "a@b.com", annotations[0].Author)
+	assert.NotZero(t, annotations[0].LastModified)
+}
+
+func TestList_RangeDoesNotOverlap_ReturnsEmpty(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	_, err := store.Create(ctx, &annotation.Annotation{Begin: 10, End: 20, Note: "toolchain roll", Author: "a@b.com"})
+	require.NoError(t, err)
+
+	annotations, err := store.List(ctx, 21, 30)
+	require.NoError(t, err)
+	assert.Empty(t, annotations)
+}
+
+func TestList_MultipleAnnotations_SortedByBegin(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	_, err := store.Create(ctx, &annotation.Annotation{Begin: 15, End: 15, Note: "second", Author: "a@b.com"})
+	require.NoError(t, err)
+	_, err = store.Create(ctx, &annotation.Annotation{Begin: 5, End: 12, Note: "first", Author: "a@b.com"})
+	require.NoError(t, err)
+
+	annotations, err := store.List(ctx, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, annotations, 2)
+	assert.Equal(t, "first", annotations[0].Note)
+	assert.Equal(t, "second", annotations[1].Note)
+}
+
+func TestCreate_InvalidAnnotation_ReturnsError(t *testing.T) {
+	store := setUp(t)
+	_, err := store.Create(context.Background(), &annotation.Annotation{Begin: 10, End: 5, Note: "backwards"})
+	require.Error(t, err)
+}
+
+func TestDelete_ExistingAnnotation_Removed(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	id, err := store.Create(ctx, &annotation.Annotation{Begin: 10, End: 20, Note: "toolchain roll", Author: "a@b.com"})
+	require.NoError(t, err)
+
+	require.NoError(t, store.Delete(ctx, id))
+	annotations, err := store.List(ctx, 0, 100)
+	require.NoError(t, err)
+	assert.Empty(t, annotations)
+}
+
+func TestDelete_UnknownID_ReturnsError(t *testing.T) {
+	store := setUp(t)
+	require.Error(t, store.Delete(context.Background(), "00000000-0000-0000-0000-000000000000"))
+}
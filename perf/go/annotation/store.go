@@ -0,0 +1,89 @@
+// Package annotation stores notes that users attach to a commit or a range of
+// commits, e.g. "lab power outage" or "toolchain roll".
+//
+// Annotations are displayed on graphs, and annotations with SuppressAlerts
+// set stop the regression detector from reporting regressions in their range.
+package annotation
+
+import (
+	"context"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/perf/go/types"
+)
+
+// maxNoteLength is the maximum length in bytes of Annotation.Note.
+const maxNoteLength = 1024
+
+// Annotation is a note attached to all the commits in [Begin, End].
+type Annotation struct {
+	// ID uniquely identifies the annotation. It is assigned by the Store.
+	ID string `json:"id"`
+
+	// Begin is the first commit the annotation applies to.
+	Begin types.CommitNumber `json:"begin"`
+
+	// End is the last commit the annotation applies to, inclusive. Equal to
+	// Begin for an annotation on a single commit.
+	End types.CommitNumber `json:"end"`
+
+	// Note is the text of the annotation.
+	Note string `json:"note"`
+
+	// SuppressAlerts is true if regressions found in [Begin, End] should not
+	// be reported, e.g. because the data in that range is known to be bad.
+	SuppressAlerts bool `json:"suppress_alerts"`
+
+	// Author is the email address of the user that created the annotation.
+	Author string `json:"author"`
+
+	// LastModified is the time the annotation was last changed, as a Unix
+	// timestamp.
+	LastModified int64 `json:"last_modified"`
+}
+
+// Validate returns an error if the Annotation is not valid.
+func (a *Annotation) Validate() error {
+	if a.Begin < 0 {
+		return skerr.Fmt("Begin must be a valid commit number, got %d.", a.Begin)
+	}
+	if a.End < a.Begin {
+		return skerr.Fmt("End (%d) must not come before Begin (%d).", a.End, a.Begin)
+	}
+	if a.Note == "" {
+		return skerr.Fmt("A note is required.")
+	}
+	if len(a.Note) > maxNoteLength {
+		return skerr.Fmt("Note is too long, the maximum length is %d.", maxNoteLength)
+	}
+	return nil
+}
+
+// Contains returns true if commitNumber is in [Begin, End].
+func (a *Annotation) Contains(commitNumber types.CommitNumber) bool {
+	return commitNumber >= a.Begin && commitNumber <= a.End
+}
+
+// SuppressesAlerts returns true if any of the annotations suppresses alerts
+// at the given commit.
+func SuppressesAlerts(annotations []*Annotation, commitNumber types.CommitNumber) bool {
+	for _, a := range annotations {
+		if a.SuppressAlerts && a.Contains(commitNumber) {
+			return true
+		}
+	}
+	return false
+}
+
+// Store is the interface used to persist annotations.
+type Store interface {
+	// Create stores a new annotation and returns its ID.
+	Create(ctx context.Context, a *Annotation) (string, error)
+
+	// Delete removes the annotation with the given ID.
+	Delete(ctx context.Context, id string) error
+
+	// List returns all the annotations that overlap the commit range [begin,
+	// end], sorted by Begin.
+	List(ctx context.Context, begin, end types.CommitNumber) ([]*Annotation, error)
+}
@@ -0,0 +1,43 @@
+package annotation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_ValidAnnotation_ReturnsNil(t *testing.T) {
+	a := &Annotation{Begin: 10, End: 10, Note: "toolchain roll"}
+	require.NoError(t, a.Validate())
+}
+
+func TestValidate_EndBeforeBegin_ReturnsError(t *testing.T) {
+	a := &Annotation{Begin: 10, End: 9, Note: "toolchain roll"}
+	require.Error(t, a.Validate())
+}
+
+func TestValidate_EmptyNote_ReturnsError(t *testing.T) {
+	a := &Annotation{Begin: 10, End: 12}
+	require.Error(t, a.Validate())
+}
+
+func TestValidate_NoteTooLong_ReturnsError(t *testing.T) {
+	a := &Annotation{Begin: 10, End: 12, Note: strings.Repeat("a", maxNoteLength+1)}
+	require.Error(t, a.Validate())
+}
+
+func TestSuppressesAlerts(t *testing.T) {
+	annotations := []*Annotation{
+		{Begin: 10, End: 20, Note: "lab power outage", SuppressAlerts: true},
+		{Begin: 30, End: 30, Note: "toolchain roll"},
+	}
+	assert.False(t, SuppressesAlerts(annotations, 9))
+	assert.True(t, SuppressesAlerts(annotations, 10))
+	assert.True(t, SuppressesAlerts(annotations, 20))
+	assert.False(t, SuppressesAlerts(annotations, 21))
+	// Annotations without SuppressAlerts don't suppress alerts.
+	assert.False(t, SuppressesAlerts(annotations, 30))
+	assert.False(t, SuppressesAlerts(nil, 10))
+}
@@ -7,13 +7,18 @@ package builders
 import (
 	"context"
 	"io/fs"
+	"strings"
 	"sync"
 
+	"cloud.google.com/go/storage"
+
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	_ "github.com/jackc/pgx/v4/stdlib" // pgx Go sql
 	"go.skia.org/infra/go/cache"
 	"go.skia.org/infra/go/deepequal/assertdeep"
+	gcsutil "go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/gcs/gcsclient"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/sql/pool"
@@ -21,6 +26,8 @@ import (
 	"go.skia.org/infra/go/sql/schema"
 	"go.skia.org/infra/perf/go/alerts"
 	"go.skia.org/infra/perf/go/alerts/sqlalertstore"
+	"go.skia.org/infra/perf/go/annotation"
+	annotation_store "go.skia.org/infra/perf/go/annotation/sqlannotationstore"
 	"go.skia.org/infra/perf/go/anomalygroup"
 	ag_store "go.skia.org/infra/perf/go/anomalygroup/sqlanomalygroupstore"
 	"go.skia.org/infra/perf/go/config"
@@ -31,6 +38,7 @@ import (
 	"go.skia.org/infra/perf/go/file"
 	"go.skia.org/infra/perf/go/file/dirsource"
 	"go.skia.org/infra/perf/go/file/gcssource"
+	"go.skia.org/infra/perf/go/file/pubsubsource"
 	"go.skia.org/infra/perf/go/filestore/gcs"
 	localfilestore "go.skia.org/infra/perf/go/filestore/local"
 	perfgit "go.skia.org/infra/perf/go/git"
@@ -39,8 +47,13 @@ import (
 	"go.skia.org/infra/perf/go/regression"
 	"go.skia.org/infra/perf/go/regression/sqlregression2store"
 	"go.skia.org/infra/perf/go/regression/sqlregressionstore"
+	"go.skia.org/infra/perf/go/regressiongroup"
+	"go.skia.org/infra/perf/go/regressiongroup/sqlregressiongroupstore"
+	"go.skia.org/infra/perf/go/report"
+	"go.skia.org/infra/perf/go/report/sqlreportstore"
 	"go.skia.org/infra/perf/go/shortcut"
 	"go.skia.org/infra/perf/go/shortcut/sqlshortcutstore"
+	"go.skia.org/infra/perf/go/snapshot"
 	"go.skia.org/infra/perf/go/sql"
 	"go.skia.org/infra/perf/go/sql/expectedschema"
 	"go.skia.org/infra/perf/go/subscription"
@@ -53,6 +66,8 @@ import (
 	gcp_redis "cloud.google.com/go/redis/apiv1"
 	localCache "go.skia.org/infra/go/cache/local"
 	redisCache "go.skia.org/infra/go/cache/redis"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
 )
 
 // pgxLogAdaptor allows bubbling pgx logs up into our application.
@@ -226,6 +241,8 @@ func NewSourceFromConfig(ctx context.Context, instanceConfig *config.InstanceCon
 			return nil, skerr.Fmt("For a source_type of 'dir' there must be a single entry for 'sources', found %d.", n)
 		}
 		return dirsource.New(instanceConfig.IngestionConfig.SourceConfig.Sources[0])
+	case config.PubSubSourceType:
+		return pubsubsource.New(ctx, instanceConfig, local)
 	default:
 		return nil, skerr.Fmt("Unknown source_type: %q", instanceConfig.IngestionConfig.SourceConfig.SourceType)
 	}
@@ -297,6 +314,51 @@ func NewUserIssueStoreFromConfig(ctx context.Context, instanceConfig *config.Ins
 	return userissue_store.New(db), nil
 }
 
+// NewAnnotationStoreFromConfig creates a new annotation.Store from the
+// InstanceConfig which provides access to the annotation data.
+func NewAnnotationStoreFromConfig(ctx context.Context, instanceConfig *config.InstanceConfig) (annotation.Store, error) {
+	db, err := getDBPool(ctx, instanceConfig)
+	if err != nil {
+		return nil, err
+	}
+	return annotation_store.New(db), nil
+}
+
+// NewRegressionGroupStoreFromConfig creates a new regressiongroup.Store from
+// the InstanceConfig which provides access to the regression group data.
+func NewRegressionGroupStoreFromConfig(ctx context.Context, instanceConfig *config.InstanceConfig) (regressiongroup.Store, error) {
+	db, err := getDBPool(ctx, instanceConfig)
+	if err != nil {
+		return nil, err
+	}
+	return sqlregressiongroupstore.New(db), nil
+}
+
+// NewReportStoreFromConfig creates a new report.Store from the InstanceConfig
+// which provides access to the report data.
+func NewReportStoreFromConfig(ctx context.Context, instanceConfig *config.InstanceConfig) (report.Store, error) {
+	db, err := getDBPool(ctx, instanceConfig)
+	if err != nil {
+		return nil, err
+	}
+	return sqlreportstore.New(db), nil
+}
+
+// NewSnapshotStoreFromConfig creates a new snapshot.Store from the
+// InstanceConfig which stores snapshots in config.SnapshotPath.
+func NewSnapshotStoreFromConfig(ctx context.Context, instanceConfig *config.InstanceConfig) (snapshot.Store, error) {
+	ts, err := google.DefaultTokenSource(ctx, storage.ScopeReadWrite)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to get TokenSource")
+	}
+	client, err := storage.NewClient(ctx, option.WithTokenSource(ts))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to authenticate to cloud storage")
+	}
+	bucket, dir := gcsutil.SplitGSPath(strings.TrimPrefix(instanceConfig.SnapshotPath, "gs://"))
+	return snapshot.NewGCSStore(gcsclient.New(client, bucket), dir), nil
+}
+
 // GetCacheFromConfig returns a cache.Cache instance based on the given configuration.
 func GetCacheFromConfig(ctx context.Context, instanceConfig config.InstanceConfig) (cache.Cache, error) {
 	var cache cache.Cache
@@ -67,6 +67,17 @@ type ClusterSummary struct {
 	// NotificationID is the ID of the notification sent for this regression.
 	// Will be the empty string if no notification has been sent.
 	NotificationID string `json:"notification_id,omitempty"`
+
+	// SinkNotificationIDs are the IDs of the notifications sent to each of the
+	// Alert's notification sinks, keyed by sink. Only set for Alerts that list
+	// notification sinks.
+	SinkNotificationIDs map[string]string `json:"sink_notification_ids,omitempty"`
+
+	// StepDetection is the algorithm that flagged this regression, recorded
+	// so that the false-positive rates of the algorithms can be compared.
+	// Regressions found before this was recorded, and those found with
+	// types.OriginalStep, leave this empty.
+	StepDetection types.StepDetection `json:"step_detection,omitempty"`
 }
 
 // NewClusterSummary returns a new ClusterSummary.
@@ -189,6 +189,10 @@ const (
 	// DirSourceType is for a local filesystem directory and is only appropriate
 	// for tests and demo mode.
 	DirSourceType SourceType = "dir"
+
+	// PubSubSourceType is for results sent directly as the data of PubSub
+	// messages, see //perf/FORMAT.md.
+	PubSubSourceType SourceType = "pubsub"
 )
 
 // SourceConfig is the config for where ingestable files come from.
@@ -197,12 +201,12 @@ type SourceConfig struct {
 	// how the rest of the SourceConfig values are interpreted.
 	SourceType SourceType `json:"source_type"`
 
-	// Project is the Google Cloud Project name. Only used for source of type
-	// "gcs".
+	// Project is the Google Cloud Project name. Only used for sources of type
+	// "gcs" and "pubsub".
 	Project string `json:"project"`
 
 	// Topic is the PubSub topic when new files arrive to be ingested. Only used
-	// for source of type "gcs".
+	// for sources of type "gcs" and "pubsub".
 	Topic string `json:"topic"`
 
 	// Subscription is the name of the subscription to use when requestion
@@ -217,7 +221,7 @@ type SourceConfig struct {
 	// Pub/Sub will forward the undeliverable message to a dead-letter topic
 	// Pub/Sub dead letter topic doc:
 	// https://cloud.google.com/pubsub/docs/handling-failures#dead_letter_topic
-	// Only used for source of type "gcs".
+	// Only used for sources of type "gcs" and "pubsub".
 	DeadLetterTopic string `json:"dl_topic,omitempty"`
 
 	// DeadLetterSubscription is the name of the dead letter subscription to use when
@@ -227,14 +231,14 @@ type SourceConfig struct {
 	// The dead-letter subscription receives messages from the dead-letter topic
 	// Pub/Sub dead-letter topic doc:
 	// https://cloud.google.com/pubsub/docs/handling-failures#configure_a_dead_letter_topic
-	// Only used for source of type "gcs".
+	// Only used for sources of type "gcs" and "pubsub".
 	DeadLetterSubscription string `json:"dl_subscription,omitempty"`
 
 	// Sources is the list of sources of data files. For a source of "gcs" this
 	// is a list of Google Cloud Storage URLs, e.g.
 	// "gs://skia-perf/nano-json-v1". For a source of type "dir" is must only
 	// have a single entry and be populated with a local filesystem directory
-	// name.
+	// name. Not used for a source of type "pubsub".
 	Sources []string `json:"sources"`
 
 	// RejectIfNameMatches is a regex. If it matches the file.Name then the file
@@ -281,6 +285,13 @@ type IngestionConfig struct {
 	// A secondary ingestion service needs to be set up to ingest data from this
 	// bucket as we don't want to add load to the main ingestion service.
 	SecondaryGCSPath string `json:"secondary_gcs_path,omitempty"`
+
+	// DedupeWindow, if non-zero, is how long the content hash of each
+	// successfully ingested file is remembered. Files whose contents exactly
+	// match a file ingested within this window, e.g. uploads that were retried
+	// after actually succeeding, are skipped so they don't create duplicate
+	// points.
+	DedupeWindow DurationAsString `json:"dedupe_window,omitempty"`
 }
 
 // GitAuthType is the type of authentication Git should use, if any.
@@ -431,6 +442,18 @@ type AnomalyConfig struct {
 	// results may arrive out of order causing Anomalies to be mis-attributed,
 	// or attributed to a series of different CLs as new data arrives.
 	SettlingTime DurationAsString `json:"settling_time,omitempty"`
+
+	// GroupingKeys are the param keys used to group regressions. Regressions
+	// found at the same commit whose traces have the same values for all of
+	// these keys are merged into a single regression group, which has a
+	// single triage status and sends a single notification.
+	//
+	// For example, ["benchmark", "bot"] sends one notification for all the
+	// tests of a benchmark that regress on a bot at a commit.
+	//
+	// Regressions are not grouped if this is empty. Grouping requires
+	// use_regression2_schema.
+	GroupingKeys []string `json:"grouping_keys,omitempty"`
 }
 
 // BackendFlags provide commandline flags for the Backend Service.
@@ -734,6 +757,7 @@ type MaintenanceFlags struct {
 	MigrateRegressions            bool
 	RefreshQueryCache             bool
 	DeleteShortcutsAndRegressions bool
+	SyncIssueTriage               bool
 	TilesForQueryCache            int
 }
 
@@ -788,6 +812,12 @@ func (flags *MaintenanceFlags) AsCliFlags() []cli.Flag {
 			Value:       false,
 			Usage:       "If true, periodically delete outdated regressions and corresponding shortcuts",
 		},
+		&cli.BoolFlag{
+			Destination: &flags.SyncIssueTriage,
+			Name:        "sync_issue_triage",
+			Value:       false,
+			Usage:       "If true, periodically sync the triage status of regressions with the state of the issues filed for them.",
+		},
 	}
 }
 
@@ -856,6 +886,9 @@ type QueryConfig struct {
 	// CacheConfig defines the caching config information for the query to reduce latency.
 	CacheConfig QueryCacheConfig `json:"cache_config,omitempty"`
 
+	// LimitConfig defines the quotas applied to expensive queries.
+	LimitConfig QueryLimitConfig `json:"limit_config,omitempty"`
+
 	// RedisConfig defines the Redis properties used to find the Redis instance.
 	RedisConfig redis.RedisConfig `json:"redis_config,omitempty"`
 }
@@ -887,6 +920,31 @@ type QueryCacheConfig struct {
 	Enabled bool `json:"enabled,omitempty"`
 }
 
+// QueryLimitConfig controls how expensive queries are limited in the
+// frontend, so that a single user, or a single runaway dashboard, can't
+// monopolize reads from the TraceStore.
+type QueryLimitConfig struct {
+	// The switch to turn query limits on and off.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// QPS is the sustained rate of expensive queries allowed per user, or per
+	// IP address for users that aren't logged in.
+	QPS float64 `json:"qps,omitempty"`
+
+	// Burst is the number of expensive queries a single user can make in a
+	// burst before being limited to QPS.
+	Burst int `json:"burst,omitempty"`
+
+	// MaxConcurrent is the maximum number of DataFrames that can be built
+	// concurrently. Zero means no limit.
+	MaxConcurrent int `json:"max_concurrent,omitempty"`
+
+	// ReservedForAlerts is the number of the MaxConcurrent slots that are
+	// reserved for alert evaluation, so that regression detection never
+	// starves behind ad-hoc queries.
+	ReservedForAlerts int `json:"reserved_for_alerts,omitempty"`
+}
+
 // InstanceConfig contains all the info needed by a Perf instance.
 type InstanceConfig struct {
 	// URL is the root URL at which this instance is available, for example: "https://example.com".
@@ -956,6 +1014,15 @@ type InstanceConfig struct {
 
 	EnableSheriffConfig bool `json:"enable_sheriff_config,omitempty"`
 
+	// EnableLiveDataStreaming serves an endpoint that pushes newly ingested
+	// data points to dashboards as ingestion completes. Requires
+	// ingestion_config.file_ingestion_pubsub_topic_name to be set.
+	EnableLiveDataStreaming bool `json:"enable_live_data_streaming,omitempty"`
+
+	// SnapshotPath is the GCS location, e.g. gs://skia-perf-snapshots/skia,
+	// where snapshots of graphs are stored. Snapshots are disabled if empty.
+	SnapshotPath string `json:"snapshot_path,omitempty"`
+
 	// Measurement ID to use when tracking user metrics with Google Analytics.
 	GoogleAnalyticsMeasurementID string `json:"ga_measurement_id,omitempty"`
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"regexp"
+	"strings"
 	"time"
 
 	_ "embed" // For embed functionality.
@@ -95,6 +96,30 @@ func Validate(i config.InstanceConfig) error {
 		}
 	}
 
+	if len(i.AnomalyConfig.GroupingKeys) > 0 && !i.UseRegression2 {
+		return skerr.Fmt("grouping_keys requires use_regression2_schema to be true.")
+	}
+
+	if i.EnableLiveDataStreaming && i.IngestionConfig.FileIngestionTopicName == "" {
+		return skerr.Fmt("enable_live_data_streaming requires file_ingestion_pubsub_topic_name to be set.")
+	}
+
+	if l := i.QueryConfig.LimitConfig; l.Enabled {
+		if l.QPS <= 0 || l.Burst < 1 {
+			return skerr.Fmt("limit_config requires a positive qps and burst.")
+		}
+		if l.MaxConcurrent < 0 || l.ReservedForAlerts < 0 {
+			return skerr.Fmt("limit_config max_concurrent and reserved_for_alerts must not be negative.")
+		}
+		if l.ReservedForAlerts > 0 && l.ReservedForAlerts >= l.MaxConcurrent {
+			return skerr.Fmt("limit_config reserved_for_alerts must be less than max_concurrent.")
+		}
+	}
+
+	if i.SnapshotPath != "" && !strings.HasPrefix(i.SnapshotPath, "gs://") {
+		return skerr.Fmt("snapshot_path must be a gs:// location, got %q.", i.SnapshotPath)
+	}
+
 	// Validate the Notify Config.
 	if i.NotifyConfig.Notifications == notifytypes.MarkdownIssueTracker && (len(i.NotifyConfig.Body) > 0 || i.NotifyConfig.Subject != "" || len(i.NotifyConfig.MissingBody) > 0 || i.NotifyConfig.MissingSubject != "") {
 		f, err := notify.NewMarkdownFormatter("", &(i.NotifyConfig))
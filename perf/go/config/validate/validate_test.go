@@ -109,3 +109,41 @@ func TestInstanceConfigValidate_InvalidParamCharRegexMatchesEqual_ReturnsError(t
 	}
 	require.Contains(t, Validate(i).Error(), "invalid_param_char_regex must match")
 }
+
+func TestInstanceConfigValidate_GroupingKeysWithoutRegression2_ReturnsError(t *testing.T) {
+	i := config.InstanceConfig{
+		AnomalyConfig: config.AnomalyConfig{
+			GroupingKeys: []string{"benchmark"},
+		},
+	}
+	require.Contains(t, Validate(i).Error(), "grouping_keys requires use_regression2_schema")
+}
+
+func TestInstanceConfigValidate_LiveDataStreamingWithoutIngestionTopic_ReturnsError(t *testing.T) {
+	i := config.InstanceConfig{
+		EnableLiveDataStreaming: true,
+	}
+	require.Contains(t, Validate(i).Error(), "enable_live_data_streaming requires file_ingestion_pubsub_topic_name")
+}
+
+func TestInstanceConfigValidate_SnapshotPathNotInGCS_ReturnsError(t *testing.T) {
+	i := config.InstanceConfig{
+		SnapshotPath: "/tmp/snapshots",
+	}
+	require.Contains(t, Validate(i).Error(), "snapshot_path must be a gs:// location")
+}
+
+func TestInstanceConfigValidate_QueryLimitReservesAllSlots_ReturnsError(t *testing.T) {
+	i := config.InstanceConfig{
+		QueryConfig: config.QueryConfig{
+			LimitConfig: config.QueryLimitConfig{
+				Enabled:           true,
+				QPS:               1,
+				Burst:             5,
+				MaxConcurrent:     4,
+				ReservedForAlerts: 4,
+			},
+		},
+	}
+	require.Contains(t, Validate(i).Error(), "reserved_for_alerts must be less than max_concurrent")
+}
@@ -216,6 +216,53 @@ func (d *DataFrame) FilterOut(f TraceFilter) {
 	d.BuildParamSet()
 }
 
+// MissingDataMode controls how gaps in traces, i.e. MissingDataSentinel
+// values, are handled before a DataFrame is returned or used in a formula.
+type MissingDataMode string
+
+const (
+	// MissingDataSkip leaves gaps as they are, so they are skipped by
+	// calculations. This is the default.
+	MissingDataSkip MissingDataMode = "skip"
+
+	// MissingDataZero replaces every gap with 0.
+	MissingDataZero MissingDataMode = "zero"
+
+	// MissingDataLinear linearly interpolates gaps between two values. Gaps at
+	// the start or end of a trace are left as they are.
+	MissingDataLinear MissingDataMode = "linear"
+)
+
+// AllMissingDataModes lists all MissingDataMode for use by go2ts.
+var AllMissingDataModes = []MissingDataMode{
+	MissingDataSkip,
+	MissingDataZero,
+	MissingDataLinear,
+}
+
+// FillMissing fills the gaps in every trace of the DataFrame according to
+// mode. The empty string is treated as MissingDataSkip.
+func (d *DataFrame) FillMissing(mode MissingDataMode) error {
+	switch mode {
+	case "", MissingDataSkip:
+	case MissingDataZero:
+		for _, tr := range d.TraceSet {
+			for i, x := range tr {
+				if x == vec32.MissingDataSentinel {
+					tr[i] = 0
+				}
+			}
+		}
+	case MissingDataLinear:
+		for _, tr := range d.TraceSet {
+			vec32.FillLinear(tr)
+		}
+	default:
+		return skerr.Fmt("Unknown missing data mode: %q", mode)
+	}
+	return nil
+}
+
 // Slice returns a dataframe that contains a subset of the current dataframe,
 // starting from 'offset', the next 'size' num points will be returned as a new
 // dataframe. Note that the data is composed of slices of the original data,
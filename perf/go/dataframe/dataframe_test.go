@@ -76,6 +76,40 @@ func TestFilter(t *testing.T) {
 	assert.Equal(t, 0, len(df.TraceSet))
 }
 
+func newDataFrameWithGaps() *DataFrame {
+	return &DataFrame{
+		TraceSet: types.TraceSet{
+			",arch=x86,": types.Trace([]float32{e, 1, e, e, 4, e}),
+		},
+		ParamSet: paramtools.NewReadOnlyParamSet(),
+	}
+}
+
+func TestFillMissing_Skip_GapsAreUnchanged(t *testing.T) {
+	for _, mode := range []MissingDataMode{"", MissingDataSkip} {
+		df := newDataFrameWithGaps()
+		require.NoError(t, df.FillMissing(mode))
+		assert.Equal(t, types.Trace([]float32{e, 1, e, e, 4, e}), df.TraceSet[",arch=x86,"])
+	}
+}
+
+func TestFillMissing_Zero_AllGapsAreZero(t *testing.T) {
+	df := newDataFrameWithGaps()
+	require.NoError(t, df.FillMissing(MissingDataZero))
+	assert.Equal(t, types.Trace([]float32{0, 1, 0, 0, 4, 0}), df.TraceSet[",arch=x86,"])
+}
+
+func TestFillMissing_Linear_InteriorGapsAreInterpolated(t *testing.T) {
+	df := newDataFrameWithGaps()
+	require.NoError(t, df.FillMissing(MissingDataLinear))
+	assert.Equal(t, types.Trace([]float32{e, 1, 2, 3, 4, e}), df.TraceSet[",arch=x86,"])
+}
+
+func TestFillMissing_UnknownMode_ReturnsError(t *testing.T) {
+	df := newDataFrameWithGaps()
+	require.Error(t, df.FillMissing("cubic"))
+}
+
 func TestSlice(t *testing.T) {
 	df := &DataFrame{
 		Header: []*ColumnHeader{
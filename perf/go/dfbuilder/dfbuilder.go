@@ -16,6 +16,7 @@ import (
 	"go.skia.org/infra/go/vec32"
 	"go.skia.org/infra/perf/go/dataframe"
 	perfgit "go.skia.org/infra/perf/go/git"
+	"go.skia.org/infra/perf/go/git/provider"
 	"go.skia.org/infra/perf/go/progress"
 	"go.skia.org/infra/perf/go/tracefilter"
 	"go.skia.org/infra/perf/go/tracesetbuilder"
@@ -45,6 +46,12 @@ const (
 	// long running queries.
 	singleTileQueryTimeout = time.Minute
 
+	// maxConcurrentTileQueries limits how many tiles are queried at the same
+	// time when building a DataFrame from a query, so that queries that span
+	// many tiles don't swamp the backend. Each tile query is itself spread
+	// across a pool of workers by the TraceStore.
+	maxConcurrentTileQueries = 4
+
 	// Filter parent traces
 	doFilterParentTraces Filtering = true
 
@@ -134,12 +141,13 @@ func sliceOfTileNumbersFromCommits(indices []types.CommitNumber, store tracestor
 
 // new builds a DataFrame for the given columns and populates it with traces that match the given query.
 //
-// The progress callback is triggered once for every tile.
+// The traces are merged into the DataFrame as they are read, and progress is
+// updated with the number of traces read so far, and again once for every
+// tile.
 func (b *builder) new(ctx context.Context, colHeaders []*dataframe.ColumnHeader, indices []types.CommitNumber, q *query.Query, progress progress.Progress, skip int) (*dataframe.DataFrame, error) {
 	ctx, span := trace.StartSpan(ctx, "dfbuilder.new")
 	defer span.End()
 
-	// TODO tickle progress as each Go routine completes.
 	defer timer.NewWithSummary("perfserver_dfbuilder_new", b.newTimer).Stop()
 	// Determine which tiles we are querying over, and how each tile maps into our results.
 	mapper := sliceOfTileNumbersFromCommits(indices, b.store)
@@ -152,33 +160,37 @@ func (b *builder) new(ctx context.Context, colHeaders []*dataframe.ColumnHeader,
 	traceSetBuilder := tracesetbuilder.New(len(indices))
 	defer traceSetBuilder.Close()
 
-	var mutex sync.Mutex // mutex protects tilesCompleted.
+	var mutex sync.Mutex // mutex protects tilesCompleted and tracesRead.
 	tilesCompleted := 0
+	tracesRead := 0
 	triggerProgress := func() {
 		mutex.Lock()
 		defer mutex.Unlock()
 		tilesCompleted++
 		progress.Message("Tiles", fmt.Sprintf("%d/%d", tilesCompleted, len(mapper)))
 	}
+	onTraces := func(traces types.TraceSet, commits []provider.Commit) {
+		traceSetBuilder.Add(commitNumberToOutputIndex, commits, traces)
+		mutex.Lock()
+		defer mutex.Unlock()
+		tracesRead += len(traces)
+		progress.Message("Traces", fmt.Sprintf("%d", tracesRead))
+	}
 
 	var g errgroup.Group
+	g.SetLimit(maxConcurrentTileQueries)
 	// For each tile.
 	for _, tileNumber := range mapper {
 		tileNumber := tileNumber
-		// TODO(jcgregorio) If we query across a large number of tiles N then this will spawn N*8 Go routines
-		// all hitting the backend at the same time. Maybe we need a worker pool if this becomes a problem.
 		g.Go(func() error {
 			defer timer.NewWithSummary("perfserver_dfbuilder_new_by_tile", b.newByTileTimer).Stop()
 
 			// Query for matching traces in the given tile.
 			queryContext, cancel := context.WithTimeout(ctx, singleTileQueryTimeout)
 			defer cancel()
-			traces, commits, err := b.store.QueryTraces(queryContext, tileNumber, q)
-			if err != nil {
+			if err := b.store.QueryTracesIncrementally(queryContext, tileNumber, q, onTraces); err != nil {
 				return err
 			}
-
-			traceSetBuilder.Add(commitNumberToOutputIndex, commits, traces)
 			triggerProgress()
 			return nil
 		})
@@ -1,22 +1,28 @@
 package dfbuilder
 
 import (
+	"bytes"
 	"context"
 	"net/url"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/query"
+	"go.skia.org/infra/go/testutils"
 	"go.skia.org/infra/perf/go/config"
 	"go.skia.org/infra/perf/go/dataframe"
 	perfgit "go.skia.org/infra/perf/go/git"
 	"go.skia.org/infra/perf/go/git/gittest"
+	"go.skia.org/infra/perf/go/git/provider"
 	"go.skia.org/infra/perf/go/progress"
 	"go.skia.org/infra/perf/go/sql/sqltest"
 	"go.skia.org/infra/perf/go/tracestore"
+	"go.skia.org/infra/perf/go/tracestore/mocks"
 	"go.skia.org/infra/perf/go/tracestore/sqltracestore"
 	"go.skia.org/infra/perf/go/types"
 )
@@ -444,3 +450,39 @@ func TestNumMatches_TilesContainDifferentNumberOfMatches_TheLargerOfTheTwoCounts
 	require.NoError(t, err)
 	assert.Equal(t, int64(3), count)
 }
+
+func TestNew_TracesArriveInBatches_MergedAndProgressReported(t *testing.T) {
+	ctx := context.Background()
+	store := &mocks.TraceStore{}
+	store.On("TileNumber", types.CommitNumber(0)).Return(types.TileNumber(0))
+	store.On("TileNumber", types.CommitNumber(1)).Return(types.TileNumber(0))
+	commits := []provider.Commit{{CommitNumber: 0}, {CommitNumber: 1}}
+	store.On("QueryTracesIncrementally", testutils.AnyContext, types.TileNumber(0), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		onTraces := args.Get(3).(tracestore.TracesCallback)
+		onTraces(types.TraceSet{",config=8888,": {1, 2}}, commits)
+		onTraces(types.TraceSet{",config=565,": {3, 4}}, commits)
+	}).Return(nil)
+
+	b := &builder{
+		store:          store,
+		newTimer:       metrics2.GetFloat64SummaryMetric("perfserver_dfbuilder_new_test"),
+		newByTileTimer: metrics2.GetFloat64SummaryMetric("perfserver_dfbuilder_newByTile_test"),
+	}
+	q, err := query.NewFromString("arch=x86")
+	require.NoError(t, err)
+	colHeaders := []*dataframe.ColumnHeader{{Offset: 0}, {Offset: 1}}
+	p := progress.New()
+
+	df, err := b.new(ctx, colHeaders, []types.CommitNumber{0, 1}, q, p, 0)
+	require.NoError(t, err)
+	assert.Equal(t, types.TraceSet{
+		",config=8888,": {1, 2},
+		",config=565,":  {3, 4},
+	}, df.TraceSet)
+
+	var buf bytes.Buffer
+	require.NoError(t, p.JSON(&buf))
+	assert.Contains(t, buf.String(), `{"key":"Traces","value":"2"}`)
+	assert.Contains(t, buf.String(), `{"key":"Tiles","value":"1/1"}`)
+	store.AssertExpectations(t)
+}
@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"time"
 
 	"go.skia.org/infra/go/auditlog"
 	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/perf/go/alerts"
 	"go.skia.org/infra/perf/go/config"
 	"go.skia.org/infra/perf/go/dataframe"
 	perfgit "go.skia.org/infra/perf/go/git"
@@ -27,6 +30,21 @@ type RegressionAtCommit struct {
 	Regression *regression.Regression `json:"regression"`
 }
 
+// maxRangeCommits is the largest number of commits a RangeRequest may cover.
+const maxRangeCommits = 5000
+
+// RangeRequest is a request to dry run an Alert over a range of commits, i.e.
+// to find all the regressions the Alert would have found at each commit in the
+// range.
+type RangeRequest struct {
+	Alert *alerts.Alert `json:"alert"`
+
+	// Begin and End are the first and last commits, inclusive, to look for
+	// regressions at.
+	Begin types.CommitNumber `json:"begin"`
+	End   types.CommitNumber `json:"end"`
+}
+
 // Requests handles HTTP request for doing dryruns.
 type Requests struct {
 	perfGit       perfgit.Git
@@ -50,8 +68,6 @@ func New(perfGit perfgit.Git, tracker progress.Tracker, shortcutStore shortcut.S
 
 // StartHandler starts a dryrun.
 func (d *Requests) StartHandler(w http.ResponseWriter, r *http.Request) {
-	// Do not use r.Context() since this kicks off a background process.
-	ctx := context.Background()
 	w.Header().Set("Content-Type", "application/json")
 
 	req := regression.NewRegressionDetectionRequest()
@@ -61,6 +77,78 @@ func (d *Requests) StartHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	auditlog.LogWithUser(r, "", "dryrun", req)
 	d.tracker.Add(req.Progress)
+	d.start(w, req, types.BadCommitNumber, types.BadCommitNumber)
+}
+
+// RangeHandler starts a dryrun of an Alert over a range of commits, see
+// RangeRequest. Only regressions found at commits in the range are reported.
+func (d *Requests) RangeHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var rangeReq RangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&rangeReq); err != nil {
+		httputils.ReportError(w, err, "Could not decode POST body.", http.StatusBadRequest)
+		return
+	}
+	auditlog.LogWithUser(r, "", "dryrun_range", rangeReq)
+	if rangeReq.Alert == nil {
+		httputils.ReportError(w, skerr.Fmt("missing alert"), "An alert must be supplied.", http.StatusBadRequest)
+		return
+	}
+	if rangeReq.Begin < 0 || rangeReq.End < rangeReq.Begin {
+		httputils.ReportError(w, skerr.Fmt("invalid range [%d, %d]", rangeReq.Begin, rangeReq.End), "Invalid commit range.", http.StatusBadRequest)
+		return
+	}
+	if rangeReq.End-rangeReq.Begin+1 > maxRangeCommits {
+		httputils.ReportError(w, skerr.Fmt("range [%d, %d] is too large", rangeReq.Begin, rangeReq.End), fmt.Sprintf("A commit range may cover at most %d commits.", maxRangeCommits), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Minute)
+	defer cancel()
+	domain, err := domainFromRange(ctx, d.perfGit, rangeReq)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to find the commits in the range.", http.StatusBadRequest)
+		return
+	}
+	req := regression.NewRegressionDetectionRequest()
+	req.Alert = rangeReq.Alert
+	req.Domain = domain
+	d.tracker.Add(req.Progress)
+	d.start(w, req, rangeReq.Begin, rangeReq.End)
+}
+
+// domainFromRange returns the Domain that contains all the data needed to look
+// for regressions at every commit in the RangeRequest, i.e. the range extended
+// by the Alert's Radius on both sides.
+func domainFromRange(ctx context.Context, perfGit perfgit.Git, req RangeRequest) (types.Domain, error) {
+	latest, err := perfGit.CommitNumberFromTime(ctx, time.Time{})
+	if err != nil {
+		return types.Domain{}, skerr.Wrapf(err, "finding the most recent commit")
+	}
+	if req.Begin > latest {
+		return types.Domain{}, skerr.Fmt("commit %d does not exist, the most recent commit is %d", req.Begin, latest)
+	}
+	end := req.End + types.CommitNumber(req.Alert.Radius)
+	if end > latest {
+		end = latest
+	}
+	commit, err := perfGit.CommitFromCommitNumber(ctx, end)
+	if err != nil {
+		return types.Domain{}, skerr.Wrapf(err, "looking up commit %d", end)
+	}
+	return types.Domain{
+		N:   int32(end-req.Begin) + int32(req.Alert.Radius) + 1,
+		End: time.Unix(commit.Timestamp, 0),
+	}, nil
+}
+
+// start runs the dryrun described by req in the background and writes the
+// Progress of it to w. If begin and end are not types.BadCommitNumber then
+// only regressions found at commits in [begin, end] are reported.
+func (d *Requests) start(w http.ResponseWriter, req *regression.RegressionDetectionRequest, begin, end types.CommitNumber) {
+	// Do not use the request Context since this kicks off a background process.
+	ctx := context.Background()
 
 	if req.Alert.Query == "" {
 		req.Progress.Error("Query must not be empty.")
@@ -98,6 +186,9 @@ func (d *Requests) StartHandler(w http.ResponseWriter, r *http.Request) {
 			if reg.Low == nil && reg.High == nil {
 				continue
 			}
+			if begin != types.BadCommitNumber && (c.CommitNumber < begin || c.CommitNumber > end) {
+				continue
+			}
 			if origReg, ok := foundRegressions[c.CommitNumber]; !ok {
 				foundRegressions[c.CommitNumber] = reg
 			} else {
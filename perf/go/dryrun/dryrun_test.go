@@ -0,0 +1,80 @@
+package dryrun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/perf/go/alerts"
+	"go.skia.org/infra/perf/go/git/mocks"
+	"go.skia.org/infra/perf/go/git/provider"
+	"go.skia.org/infra/perf/go/types"
+)
+
+const commitTimestamp = 1672531200
+
+func TestDomainFromRange_RangeWellBeforeLatestCommit_DomainIncludesRadiusOnBothSides(t *testing.T) {
+	ctx := context.Background()
+	perfGit := mocks.NewGit(t)
+	perfGit.On("CommitNumberFromTime", mock.Anything, time.Time{}).Return(types.CommitNumber(1000), nil)
+	perfGit.On("CommitFromCommitNumber", mock.Anything, types.CommitNumber(23)).Return(provider.Commit{Timestamp: commitTimestamp}, nil)
+
+	domain, err := domainFromRange(ctx, perfGit, RangeRequest{
+		Alert: &alerts.Alert{Radius: 3},
+		Begin: 10,
+		End:   20,
+	})
+	require.NoError(t, err)
+	// Commits [7, 23].
+	require.Equal(t, types.Domain{N: 17, End: time.Unix(commitTimestamp, 0)}, domain)
+}
+
+func TestDomainFromRange_RangeEndsAtLatestCommit_DomainEndsAtLatestCommit(t *testing.T) {
+	ctx := context.Background()
+	perfGit := mocks.NewGit(t)
+	perfGit.On("CommitNumberFromTime", mock.Anything, time.Time{}).Return(types.CommitNumber(21), nil)
+	perfGit.On("CommitFromCommitNumber", mock.Anything, types.CommitNumber(21)).Return(provider.Commit{Timestamp: commitTimestamp}, nil)
+
+	domain, err := domainFromRange(ctx, perfGit, RangeRequest{
+		Alert: &alerts.Alert{Radius: 3},
+		Begin: 10,
+		End:   20,
+	})
+	require.NoError(t, err)
+	// Commits [7, 21].
+	require.Equal(t, types.Domain{N: 15, End: time.Unix(commitTimestamp, 0)}, domain)
+}
+
+func TestDomainFromRange_BeginAfterLatestCommit_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	perfGit := mocks.NewGit(t)
+	perfGit.On("CommitNumberFromTime", mock.Anything, time.Time{}).Return(types.CommitNumber(5), nil)
+
+	_, err := domainFromRange(ctx, perfGit, RangeRequest{
+		Alert: &alerts.Alert{Radius: 3},
+		Begin: 10,
+		End:   20,
+	})
+	require.Error(t, err)
+}
+
+func TestRangeHandler_InvalidRequests_ReturnBadRequest(t *testing.T) {
+	for name, body := range map[string]string{
+		"missing alert":    `{"begin": 1, "end": 2}`,
+		"end before begin": `{"alert": {"query": "arch=x86"}, "begin": 10, "end": 2}`,
+		"range too large":  `{"alert": {"query": "arch=x86"}, "begin": 1, "end": 100000}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := New(nil, nil, nil, nil, nil)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/_/dryrun/range", strings.NewReader(body))
+			d.RangeHandler(w, r)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -0,0 +1,430 @@
+// Package export writes the traces in a DataFrame out in formats that are
+// convenient for analysis outside of Perf, such as in notebooks or BigQuery.
+//
+// Each format is written in "long" form, i.e. one row per point, with the
+// columns:
+//
+//	trace_id, commit_number, timestamp, value, <param keys...>
+//
+// There is one column for each param key found in the DataFrame's trace ids,
+// sorted, which holds the trace's value for that key, or is empty if the trace
+// doesn't have that key. A param key that is the same as one of the fixed
+// columns is prefixed with "param_". Points with missing data are not written.
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/schema"
+	"go.skia.org/infra/go/query"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/go/vec32"
+	"go.skia.org/infra/perf/go/dataframe"
+)
+
+// Format is a file format that a DataFrame can be exported as.
+type Format string
+
+const (
+	// CSV is comma separated values, with a header row.
+	CSV Format = "csv"
+
+	// Parquet is Apache Parquet, see https://parquet.apache.org/.
+	Parquet Format = "parquet"
+)
+
+// AllFormats is all the valid Format values.
+var AllFormats = []Format{CSV, Parquet}
+
+// ContentType returns the MIME type of the Format.
+func (f Format) ContentType() string {
+	if f == Parquet {
+		return "application/vnd.apache.parquet"
+	}
+	return "text/csv"
+}
+
+// The names of the columns that appear in every export.
+const (
+	traceIDColumn      = "trace_id"
+	commitNumberColumn = "commit_number"
+	timestampColumn    = "timestamp"
+	valueColumn        = "value"
+)
+
+var fixedColumns = []string{traceIDColumn, commitNumberColumn, timestampColumn, valueColumn}
+
+// parquetRowGroupSize is the maximum number of rows buffered before they are
+// written out as a Parquet row group.
+const parquetRowGroupSize = 64 * 1024
+
+// Write writes the traces in df to w in the given format.
+//
+// Rows are written to w as they are produced, rather than building up the
+// whole file in memory first.
+func Write(w io.Writer, format Format, df *dataframe.DataFrame) error {
+	traceIDs := make([]string, 0, len(df.TraceSet))
+	for traceID := range df.TraceSet {
+		traceIDs = append(traceIDs, traceID)
+	}
+	ew, err := NewWriter(w, format, traceIDs)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if err := ew.Write(df); err != nil {
+		return skerr.Wrap(err)
+	}
+	return skerr.Wrap(ew.Close())
+}
+
+// Writer writes traces to an io.Writer one DataFrame at a time, so that large
+// exports can be loaded and written in chunks of traces instead of all at
+// once. The columns are fixed when the Writer is created.
+type Writer struct {
+	e *exporter
+
+	// Only one of csv or parquet is set, depending on the Format.
+	csv      *csv.Writer
+	parquet  *file.Writer
+	rowGroup *parquetRowGroup
+}
+
+// NewWriter returns a Writer which writes to w in the given format. There is a
+// param column for each key found in the given trace ids, which must include
+// the ids of all the traces that will be written.
+func NewWriter(w io.Writer, format Format, traceIDs []string) (*Writer, error) {
+	e := newExporter(traceIDs)
+	switch format {
+	case CSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(e.columnNames()); err != nil {
+			return nil, skerr.Wrapf(err, "writing CSV header")
+		}
+		return &Writer{e: e, csv: cw}, nil
+	case Parquet:
+		sc, err := parquetSchema(e.columnNames())
+		if err != nil {
+			return nil, skerr.Wrapf(err, "building Parquet schema")
+		}
+		return &Writer{
+			e:        e,
+			parquet:  file.NewParquetWriter(w, sc),
+			rowGroup: newParquetRowGroup(len(e.paramKeys)),
+		}, nil
+	default:
+		return nil, skerr.Fmt("unknown export format: %q", format)
+	}
+}
+
+// Write writes the points of the traces in df, ordered by trace id and then by
+// commit number. The rows of CSV exports are flushed to the underlying
+// io.Writer before Write returns, while Parquet rows are buffered until a row
+// group is full.
+func (w *Writer) Write(df *dataframe.DataFrame) error {
+	if w.csv != nil {
+		return w.writeCSV(df)
+	}
+	return w.writeParquet(df)
+}
+
+// Close writes any buffered rows and the footer of the file, if the format has
+// one. It doesn't close the underlying io.Writer.
+func (w *Writer) Close() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		return skerr.Wrap(w.csv.Error())
+	}
+	if w.rowGroup.len() > 0 {
+		if err := w.rowGroup.writeTo(w.parquet); err != nil {
+			return skerr.Wrapf(err, "writing final Parquet row group")
+		}
+	}
+	return skerr.Wrap(w.parquet.Close())
+}
+
+// row is a single point in a trace.
+type row struct {
+	traceID      string
+	commitNumber int64
+	timestamp    time.Time
+	value        float32
+	// params has one entry for each param column, "" if the trace doesn't
+	// have that key.
+	params []string
+}
+
+// exporter turns DataFrames into rows.
+type exporter struct {
+	// paramKeys are the keys of the param columns, in column order.
+	paramKeys []string
+}
+
+func newExporter(traceIDs []string) *exporter {
+	// Don't rely on the ParamSet of a DataFrame, since it is cleared for
+	// calculated traces.
+	keys := util.StringSet{}
+	for _, traceID := range traceIDs {
+		params, err := query.ParseKey(traceID)
+		if err != nil {
+			continue
+		}
+		for key := range params {
+			keys[key] = true
+		}
+	}
+	paramKeys := keys.Keys()
+	sort.Strings(paramKeys)
+	return &exporter{
+		paramKeys: paramKeys,
+	}
+}
+
+// columnNames returns the names of all the columns, in order.
+func (e *exporter) columnNames() []string {
+	ret := append([]string{}, fixedColumns...)
+	for _, key := range e.paramKeys {
+		if util.In(key, fixedColumns) {
+			key = "param_" + key
+		}
+		ret = append(ret, key)
+	}
+	return ret
+}
+
+// forEachRow calls f for each point in the DataFrame, ordered by trace id and
+// then by commit number. The row passed to f is reused between calls.
+func (e *exporter) forEachRow(df *dataframe.DataFrame, f func(r *row) error) error {
+	traceIDs := make([]string, 0, len(df.TraceSet))
+	for traceID := range df.TraceSet {
+		traceIDs = append(traceIDs, traceID)
+	}
+	sort.Strings(traceIDs)
+
+	r := &row{
+		params: make([]string, len(e.paramKeys)),
+	}
+	for _, traceID := range traceIDs {
+		// Calculated traces, e.g. the result of a formula, don't have
+		// structured keys, so they will have no params.
+		params, err := query.ParseKey(traceID)
+		if err != nil {
+			params = map[string]string{}
+		}
+		r.traceID = traceID
+		for i, key := range e.paramKeys {
+			r.params[i] = params[key]
+		}
+		for i, value := range df.TraceSet[traceID] {
+			if value == vec32.MissingDataSentinel || i >= len(df.Header) {
+				continue
+			}
+			r.commitNumber = int64(df.Header[i].Offset)
+			r.timestamp = time.Unix(int64(df.Header[i].Timestamp), 0).UTC()
+			r.value = value
+			if err := f(r); err != nil {
+				return skerr.Wrap(err)
+			}
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes the traces in df to w as CSV.
+func WriteCSV(w io.Writer, df *dataframe.DataFrame) error {
+	return Write(w, CSV, df)
+}
+
+// writeCSV writes the rows of df and flushes them.
+func (w *Writer) writeCSV(df *dataframe.DataFrame) error {
+	record := make([]string, len(fixedColumns)+len(w.e.paramKeys))
+	err := w.e.forEachRow(df, func(r *row) error {
+		record[0] = r.traceID
+		record[1] = strconv.FormatInt(r.commitNumber, 10)
+		record[2] = r.timestamp.Format(time.RFC3339)
+		record[3] = strconv.FormatFloat(float64(r.value), 'g', -1, 32)
+		copy(record[len(fixedColumns):], r.params)
+		return w.csv.Write(record)
+	})
+	if err != nil {
+		return skerr.Wrapf(err, "writing CSV row")
+	}
+	w.csv.Flush()
+	return skerr.Wrap(w.csv.Error())
+}
+
+// parquetSchema returns the Parquet schema for the given column names.
+func parquetSchema(columnNames []string) (*schema.GroupNode, error) {
+	traceID, err := schema.NewPrimitiveNodeLogical(columnNames[0], parquet.Repetitions.Required, schema.StringLogicalType{}, parquet.Types.ByteArray, -1, -1)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	timestamp, err := schema.NewPrimitiveNodeLogical(columnNames[2], parquet.Repetitions.Required, schema.NewTimestampLogicalType(true, schema.TimeUnitMillis), parquet.Types.Int64, -1, -1)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	fields := schema.FieldList{
+		traceID,
+		schema.NewInt64Node(columnNames[1], parquet.Repetitions.Required, -1),
+		timestamp,
+		schema.NewFloat32Node(columnNames[3], parquet.Repetitions.Required, -1),
+	}
+	for _, name := range columnNames[len(fixedColumns):] {
+		param, err := schema.NewPrimitiveNodeLogical(name, parquet.Repetitions.Optional, schema.StringLogicalType{}, parquet.Types.ByteArray, -1, -1)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		fields = append(fields, param)
+	}
+	ret, err := schema.NewGroupNode("schema", parquet.Repetitions.Required, fields, -1)
+	return ret, skerr.Wrap(err)
+}
+
+// parquetRowGroup buffers the values of each column for a single row group.
+type parquetRowGroup struct {
+	traceIDs      []parquet.ByteArray
+	commitNumbers []int64
+	timestamps    []int64
+	values        []float32
+
+	// params and paramDefLevels are indexed by param column. Params that the
+	// trace doesn't have are null, i.e. they have a definition level of 0 and
+	// no value.
+	params         [][]parquet.ByteArray
+	paramDefLevels [][]int16
+}
+
+func newParquetRowGroup(numParams int) *parquetRowGroup {
+	return &parquetRowGroup{
+		params:         make([][]parquet.ByteArray, numParams),
+		paramDefLevels: make([][]int16, numParams),
+	}
+}
+
+func (g *parquetRowGroup) len() int {
+	return len(g.values)
+}
+
+func (g *parquetRowGroup) add(r *row) {
+	g.traceIDs = append(g.traceIDs, parquet.ByteArray(r.traceID))
+	g.commitNumbers = append(g.commitNumbers, r.commitNumber)
+	g.timestamps = append(g.timestamps, r.timestamp.UnixMilli())
+	g.values = append(g.values, r.value)
+	for i, value := range r.params {
+		if value == "" {
+			g.paramDefLevels[i] = append(g.paramDefLevels[i], 0)
+			continue
+		}
+		g.paramDefLevels[i] = append(g.paramDefLevels[i], 1)
+		g.params[i] = append(g.params[i], parquet.ByteArray(value))
+	}
+}
+
+func (g *parquetRowGroup) reset() {
+	g.traceIDs = g.traceIDs[:0]
+	g.commitNumbers = g.commitNumbers[:0]
+	g.timestamps = g.timestamps[:0]
+	g.values = g.values[:0]
+	for i := range g.params {
+		g.params[i] = g.params[i][:0]
+		g.paramDefLevels[i] = g.paramDefLevels[i][:0]
+	}
+}
+
+// writeTo writes the buffered rows to fw as a new row group.
+func (g *parquetRowGroup) writeTo(fw *file.Writer) error {
+	rgw := fw.AppendRowGroup()
+	next := func() (file.ColumnChunkWriter, error) {
+		cw, err := rgw.NextColumn()
+		return cw, skerr.Wrap(err)
+	}
+
+	cw, err := next()
+	if err != nil {
+		return err
+	}
+	if _, err := cw.(*file.ByteArrayColumnChunkWriter).WriteBatch(g.traceIDs, nil, nil); err != nil {
+		return skerr.Wrap(err)
+	}
+	if err := cw.Close(); err != nil {
+		return skerr.Wrap(err)
+	}
+
+	cw, err = next()
+	if err != nil {
+		return err
+	}
+	if _, err := cw.(*file.Int64ColumnChunkWriter).WriteBatch(g.commitNumbers, nil, nil); err != nil {
+		return skerr.Wrap(err)
+	}
+	if err := cw.Close(); err != nil {
+		return skerr.Wrap(err)
+	}
+
+	cw, err = next()
+	if err != nil {
+		return err
+	}
+	if _, err := cw.(*file.Int64ColumnChunkWriter).WriteBatch(g.timestamps, nil, nil); err != nil {
+		return skerr.Wrap(err)
+	}
+	if err := cw.Close(); err != nil {
+		return skerr.Wrap(err)
+	}
+
+	cw, err = next()
+	if err != nil {
+		return err
+	}
+	if _, err := cw.(*file.Float32ColumnChunkWriter).WriteBatch(g.values, nil, nil); err != nil {
+		return skerr.Wrap(err)
+	}
+	if err := cw.Close(); err != nil {
+		return skerr.Wrap(err)
+	}
+
+	for i := range g.params {
+		cw, err = next()
+		if err != nil {
+			return err
+		}
+		if _, err := cw.(*file.ByteArrayColumnChunkWriter).WriteBatch(g.params[i], g.paramDefLevels[i], nil); err != nil {
+			return skerr.Wrap(err)
+		}
+		if err := cw.Close(); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
+	return skerr.Wrap(rgw.Close())
+}
+
+// WriteParquet writes the traces in df to w as Parquet.
+//
+// Rows are buffered into row groups of at most parquetRowGroupSize rows, each
+// of which is written to w as soon as it is full.
+func WriteParquet(w io.Writer, df *dataframe.DataFrame) error {
+	return Write(w, Parquet, df)
+}
+
+// writeParquet buffers the rows of df, writing out each row group as soon as
+// it is full.
+func (w *Writer) writeParquet(df *dataframe.DataFrame) error {
+	err := w.e.forEachRow(df, func(r *row) error {
+		w.rowGroup.add(r)
+		if w.rowGroup.len() < parquetRowGroupSize {
+			return nil
+		}
+		if err := w.rowGroup.writeTo(w.parquet); err != nil {
+			return err
+		}
+		w.rowGroup.reset()
+		return nil
+	})
+	return skerr.Wrapf(err, "writing Parquet row group")
+}
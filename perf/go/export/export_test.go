@@ -0,0 +1,130 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/vec32"
+	"go.skia.org/infra/perf/go/dataframe"
+	"go.skia.org/infra/perf/go/types"
+)
+
+const e = vec32.MissingDataSentinel
+
+func dataFrameForTest() *dataframe.DataFrame {
+	df := dataframe.NewEmpty()
+	df.Header = []*dataframe.ColumnHeader{
+		{Offset: 10, Timestamp: 1672531200}, // 2023-01-01T00:00:00Z
+		{Offset: 11, Timestamp: 1672617600}, // 2023-01-02T00:00:00Z
+	}
+	df.TraceSet[",arch=x86,config=8888,"] = types.Trace{1.5, e}
+	df.TraceSet[",arch=arm,value=high,"] = types.Trace{2, 3}
+	df.TraceSet[`sum(filter("arch=x86"))`] = types.Trace{e, 4}
+	return df
+}
+
+func TestWriteCSV_DataFrameWithMixedTraces_WritesOneRowPerPoint(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, Write(&b, CSV, dataFrameForTest()))
+
+	expected := `trace_id,commit_number,timestamp,value,arch,config,param_value
+",arch=arm,value=high,",10,2023-01-01T00:00:00Z,2,arm,,high
+",arch=arm,value=high,",11,2023-01-02T00:00:00Z,3,arm,,high
+",arch=x86,config=8888,",10,2023-01-01T00:00:00Z,1.5,x86,8888,
+"sum(filter(""arch=x86""))",11,2023-01-02T00:00:00Z,4,,,
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestWriteCSV_EmptyDataFrame_WritesOnlyHeader(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, WriteCSV(&b, dataframe.NewEmpty()))
+	assert.Equal(t, "trace_id,commit_number,timestamp,value\n", b.String())
+}
+
+func TestWriter_CSVInChunks_SameAsWritingDataFrameAtOnce(t *testing.T) {
+	df := dataFrameForTest()
+	var expected bytes.Buffer
+	require.NoError(t, Write(&expected, CSV, df))
+
+	traceIDs := []string{",arch=arm,value=high,", ",arch=x86,config=8888,", `sum(filter("arch=x86"))`}
+	var b bytes.Buffer
+	w, err := NewWriter(&b, CSV, traceIDs)
+	require.NoError(t, err)
+	for _, traceID := range traceIDs {
+		chunk := dataframe.NewEmpty()
+		chunk.Header = df.Header
+		chunk.TraceSet[traceID] = df.TraceSet[traceID]
+		require.NoError(t, w.Write(chunk))
+	}
+	// Each chunk is flushed as soon as it is written.
+	assert.Equal(t, expected.String(), b.String())
+	require.NoError(t, w.Close())
+	assert.Equal(t, expected.String(), b.String())
+}
+
+func TestWrite_UnknownFormat_ReturnsError(t *testing.T) {
+	var b bytes.Buffer
+	require.Error(t, Write(&b, Format("xlsx"), dataFrameForTest()))
+}
+
+func TestWriteParquet_DataFrameWithMixedTraces_RoundTrips(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, Write(&b, Parquet, dataFrameForTest()))
+
+	r, err := file.NewParquetReader(bytes.NewReader(b.Bytes()))
+	require.NoError(t, err)
+	defer r.Close()
+
+	sc := r.MetaData().Schema
+	require.Equal(t, 7, sc.NumColumns())
+	names := []string{}
+	for i := 0; i < sc.NumColumns(); i++ {
+		names = append(names, sc.Column(i).Name())
+	}
+	assert.Equal(t, []string{"trace_id", "commit_number", "timestamp", "value", "arch", "config", "param_value"}, names)
+	require.Equal(t, 1, r.NumRowGroups())
+	rg := r.RowGroup(0)
+	require.Equal(t, int64(4), rg.NumRows())
+
+	col, err := rg.Column(0)
+	require.NoError(t, err)
+	traceIDs := make([]parquet.ByteArray, 4)
+	_, _, err = col.(*file.ByteArrayColumnChunkReader).ReadBatch(4, traceIDs, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []parquet.ByteArray{
+		parquet.ByteArray(",arch=arm,value=high,"),
+		parquet.ByteArray(",arch=arm,value=high,"),
+		parquet.ByteArray(",arch=x86,config=8888,"),
+		parquet.ByteArray(`sum(filter("arch=x86"))`),
+	}, traceIDs)
+
+	col, err = rg.Column(2)
+	require.NoError(t, err)
+	timestamps := make([]int64, 4)
+	_, _, err = col.(*file.Int64ColumnChunkReader).ReadBatch(4, timestamps, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1672531200000, 1672617600000, 1672531200000, 1672617600000}, timestamps)
+
+	col, err = rg.Column(3)
+	require.NoError(t, err)
+	values := make([]float32, 4)
+	_, _, err = col.(*file.Float32ColumnChunkReader).ReadBatch(4, values, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []float32{2, 3, 1.5, 4}, values)
+
+	// The config column is only present for one trace.
+	col, err = rg.Column(5)
+	require.NoError(t, err)
+	configs := make([]parquet.ByteArray, 4)
+	defLevels := make([]int16, 4)
+	_, valuesRead, err := col.(*file.ByteArrayColumnChunkReader).ReadBatch(4, configs, defLevels, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, valuesRead)
+	assert.Equal(t, parquet.ByteArray("8888"), configs[0])
+	assert.Equal(t, []int16{0, 0, 1, 0}, defLevels)
+}
@@ -0,0 +1,197 @@
+// Package pubsubsource implements file.Source by reading benchmark results
+// directly from the data of PubSub messages.
+//
+// Unlike gcssource, where each PubSub message only announces that a file was
+// written to Google Cloud Storage, here the message data is itself the
+// ingestion file, a JSON document in the format described in
+// //perf/FORMAT.md. This allows projects to push results to Perf without
+// writing to a bucket with the canonical directory structure.
+package pubsubsource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/pubsub"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/pubsub/sub"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/perf/go/config"
+	"go.skia.org/infra/perf/go/file"
+	"go.skia.org/infra/perf/go/ingest/filter"
+)
+
+const (
+	// maxParallelReceives is the number of Go routines we want to run.
+	maxParallelReceives = 1
+
+	// NameAttribute is the optional PubSub message attribute that names the
+	// results, e.g. "my-project/2023/01/02/results.json". It is used in place
+	// of a file name for filtering and for recording where traces came from.
+	// If not present a name is built from the topic and message id.
+	NameAttribute = "name"
+)
+
+// PubSubSource implements file.Source for results sent in PubSub messages.
+type PubSubSource struct {
+	// topic is the PubSub topic the results are published to.
+	topic string
+
+	// fileChannel is the output channel returned from Start.
+	fileChannel chan<- file.File
+
+	// subscription is the pubsub event subscription.
+	subscription *pubsub.Subscription
+
+	// started is true if Start has already been called.
+	started bool
+
+	// nackCounter is a metric of how many messages we've nacked.
+	nackCounter metrics2.Counter
+
+	// ackCounter is a metric of how many messages we've acked.
+	ackCounter metrics2.Counter
+
+	// emptyCounter is a metric of how many messages arrived without data.
+	emptyCounter metrics2.Counter
+
+	// filter to accept/reject results based on their name.
+	filter *filter.Filter
+
+	// deadLetterEnabled is true if the dead letter topic is configured.
+	deadLetterEnabled bool
+}
+
+// New returns a new *PubSubSource.
+func New(ctx context.Context, instanceConfig *config.InstanceConfig, local bool) (*PubSubSource, error) {
+	sourceConfig := instanceConfig.IngestionConfig.SourceConfig
+	if sourceConfig.Topic == "" {
+		return nil, skerr.Fmt("A topic is required for a source_type of %q.", config.PubSubSourceType)
+	}
+	subName := sourceConfig.Subscription
+	if subName == "" {
+		var err error
+		subName, err = sub.NewRoundRobinNameProvider(local, sourceConfig.Topic).SubName()
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+	}
+	subscription, err := sub.NewWithSubName(ctx, local, sourceConfig.Project, sourceConfig.Topic, subName, maxParallelReceives)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	// See gcssource.New for why automatic deadline extension is disabled.
+	subscription.ReceiveSettings.MaxExtension = -1
+
+	ret, err := newWithSubscription(instanceConfig, subscription)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return ret, nil
+}
+
+// newWithSubscription returns a new *PubSubSource which reads from the given
+// subscription, which may be nil in tests that don't call Start.
+func newWithSubscription(instanceConfig *config.InstanceConfig, subscription *pubsub.Subscription) (*PubSubSource, error) {
+	sourceConfig := instanceConfig.IngestionConfig.SourceConfig
+	f, err := filter.New(sourceConfig.AcceptIfNameMatches, sourceConfig.RejectIfNameMatches)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return &PubSubSource{
+		topic:             sourceConfig.Topic,
+		subscription:      subscription,
+		nackCounter:       metrics2.GetCounter("perf_file_pubsubsource_nack", nil),
+		ackCounter:        metrics2.GetCounter("perf_file_pubsubsource_ack", nil),
+		emptyCounter:      metrics2.GetCounter("perf_file_pubsubsource_empty", nil),
+		filter:            f,
+		deadLetterEnabled: config.IsDeadLetterCollectionEnabled(instanceConfig),
+	}, nil
+}
+
+// name returns the name to use for the results in the given message.
+func (s *PubSubSource) name(msg *pubsub.Message) string {
+	if name := msg.Attributes[NameAttribute]; name != "" {
+		return name
+	}
+	return fmt.Sprintf("pubsub://%s/%s", s.topic, msg.ID)
+}
+
+// receiveSingleEventWrapper is the func we pass to Subscription.Receive.
+func (s *PubSubSource) receiveSingleEventWrapper(ctx context.Context, msg *pubsub.Message) {
+	sklog.Debugf("Message received: %s", msg.ID)
+	ack := s.receiveSingleEvent(ctx, msg)
+	if s.deadLetterEnabled {
+		if !ack {
+			s.nackCounter.Inc(1)
+			msg.Nack()
+		}
+		return
+	}
+	if ack {
+		s.ackCounter.Inc(1)
+		msg.Ack()
+	} else {
+		s.nackCounter.Inc(1)
+		msg.Nack()
+	}
+}
+
+// receiveSingleEvent sends the results in the message on fileChannel and
+// returns true if the message should be ack'd, or false if it should be
+// nack'd. Messages that can never be ingested are ack'd so they aren't
+// redelivered.
+func (s *PubSubSource) receiveSingleEvent(ctx context.Context, msg *pubsub.Message) bool {
+	name := s.name(msg)
+	if len(msg.Data) == 0 {
+		sklog.Errorf("Message %q contains no results.", name)
+		s.emptyCounter.Inc(1)
+		return true
+	}
+	if s.filter.Reject(name) {
+		sklog.Errorf("Results are rejected by the name filter: %s", name)
+		return true
+	}
+	f := file.File{
+		Name:      name,
+		Contents:  io.NopCloser(bytes.NewReader(msg.Data)),
+		Created:   msg.PublishTime,
+		PubSubMsg: msg,
+	}
+	select {
+	case s.fileChannel <- f:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Start implements the file.Source interface.
+func (s *PubSubSource) Start(ctx context.Context) (<-chan file.File, error) {
+	if s.started {
+		return nil, skerr.Fmt("Start can only be called once.")
+	}
+	s.started = true
+	ret := make(chan file.File, maxParallelReceives)
+	s.fileChannel = ret
+	go func() {
+		for {
+			// Wait for PubSub events.
+			err := s.subscription.Receive(ctx, s.receiveSingleEventWrapper)
+			if err != nil {
+				sklog.Errorf("Failed receiving pubsub message: %s", err)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+
+	return ret, nil
+}
+
+// Confirm *PubSubSource implements the file.Source interface.
+var _ file.Source = (*PubSubSource)(nil)
@@ -0,0 +1,94 @@
+package pubsubsource
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/perf/go/config"
+	"go.skia.org/infra/perf/go/file"
+)
+
+const testTopic = "perf-results"
+
+var publishTime = time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+func newSourceForTest(t *testing.T, reject string) (*PubSubSource, chan file.File) {
+	instanceConfig := &config.InstanceConfig{
+		IngestionConfig: config.IngestionConfig{
+			SourceConfig: config.SourceConfig{
+				SourceType:          config.PubSubSourceType,
+				Topic:               testTopic,
+				RejectIfNameMatches: reject,
+			},
+		},
+	}
+	s, err := newWithSubscription(instanceConfig, nil)
+	require.NoError(t, err)
+	ch := make(chan file.File, 1)
+	s.fileChannel = ch
+	return s, ch
+}
+
+func TestReceiveSingleEvent_MessageWithData_SendsFile(t *testing.T) {
+	s, ch := newSourceForTest(t, "")
+	msg := &pubsub.Message{
+		ID:          "123",
+		Data:        []byte(`{"version": 1}`),
+		PublishTime: publishTime,
+	}
+
+	require.True(t, s.receiveSingleEvent(context.Background(), msg))
+	f := <-ch
+	assert.Equal(t, "pubsub://perf-results/123", f.Name)
+	assert.Equal(t, publishTime, f.Created)
+	assert.Equal(t, msg, f.PubSubMsg)
+	b, err := io.ReadAll(f.Contents)
+	require.NoError(t, err)
+	assert.Equal(t, `{"version": 1}`, string(b))
+}
+
+func TestReceiveSingleEvent_MessageWithNameAttribute_UsesNameAttribute(t *testing.T) {
+	s, ch := newSourceForTest(t, "")
+	msg := &pubsub.Message{
+		ID:         "123",
+		Data:       []byte(`{"version": 1}`),
+		Attributes: map[string]string{NameAttribute: "my-project/results.json"},
+	}
+
+	require.True(t, s.receiveSingleEvent(context.Background(), msg))
+	assert.Equal(t, "my-project/results.json", (<-ch).Name)
+}
+
+func TestReceiveSingleEvent_EmptyMessage_AcksWithoutSendingFile(t *testing.T) {
+	s, ch := newSourceForTest(t, "")
+
+	assert.True(t, s.receiveSingleEvent(context.Background(), &pubsub.Message{ID: "123"}))
+	assert.Empty(t, ch)
+}
+
+func TestReceiveSingleEvent_RejectedByFilter_AcksWithoutSendingFile(t *testing.T) {
+	s, ch := newSourceForTest(t, "^pubsub://")
+
+	assert.True(t, s.receiveSingleEvent(context.Background(), &pubsub.Message{ID: "123", Data: []byte("{}")}))
+	assert.Empty(t, ch)
+}
+
+func TestReceiveSingleEvent_ContextCancelledWhileWaiting_Nacks(t *testing.T) {
+	s, ch := newSourceForTest(t, "")
+	// Fill the channel so the send blocks.
+	ch <- file.File{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.False(t, s.receiveSingleEvent(ctx, &pubsub.Message{ID: "123", Data: []byte("{}")}))
+}
+
+func TestNew_NoTopic_ReturnsError(t *testing.T) {
+	_, err := New(context.Background(), &config.InstanceConfig{}, true)
+	assert.Error(t, err)
+}
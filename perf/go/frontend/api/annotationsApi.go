@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/auditlog"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/roles"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/perf/go/annotation"
+	"go.skia.org/infra/perf/go/types"
+)
+
+// annotationsApi provides a struct for handling commit range annotations.
+type annotationsApi struct {
+	loginProvider   alogin.Login
+	annotationStore annotation.Store
+}
+
+// NewAnnotationsApi returns a new instance of annotationsApi.
+func NewAnnotationsApi(loginProvider alogin.Login, annotationStore annotation.Store) annotationsApi {
+	return annotationsApi{
+		loginProvider:   loginProvider,
+		annotationStore: annotationStore,
+	}
+}
+
+// RegisterHandlers registers the api handlers for their respective routes.
+func (a annotationsApi) RegisterHandlers(router *chi.Mux) {
+	router.Post("/_/annotations/list", a.listAnnotationsHandler)
+	router.Post("/_/annotations/create", a.createAnnotationHandler)
+	router.Post("/_/annotations/delete", a.deleteAnnotationHandler)
+}
+
+// ListAnnotationsRequest is the request to fetch all the annotations that
+// overlap the commit range [Begin, End].
+type ListAnnotationsRequest struct {
+	Begin types.CommitNumber `json:"begin"`
+	End   types.CommitNumber `json:"end"`
+}
+
+// ListAnnotationsResponse is the response to ListAnnotationsRequest.
+type ListAnnotationsResponse struct {
+	Annotations []*annotation.Annotation `json:"annotations"`
+}
+
+// listAnnotationsHandler returns the annotations that overlap a commit range.
+func (a annotationsApi) listAnnotationsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	var req ListAnnotationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	if req.End < req.Begin {
+		httputils.ReportError(w, skerr.Fmt("Invalid range [%d, %d]", req.Begin, req.End), "Invalid commit range.", http.StatusBadRequest)
+		return
+	}
+
+	annotations, err := a.annotationStore.List(ctx, req.Begin, req.End)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to list annotations.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(ListAnnotationsResponse{Annotations: annotations}); err != nil {
+		sklog.Errorf("Failed to encode response: %s", err)
+	}
+}
+
+// CreateAnnotationRequest is the request to create a new annotation.
+type CreateAnnotationRequest struct {
+	Begin          types.CommitNumber `json:"begin"`
+	End            types.CommitNumber `json:"end"`
+	Note           string             `json:"note"`
+	SuppressAlerts bool               `json:"suppress_alerts"`
+}
+
+// CreateAnnotationResponse is the response to CreateAnnotationRequest.
+type CreateAnnotationResponse struct {
+	ID string `json:"id"`
+}
+
+// createAnnotationHandler creates a new annotation authored by the logged in
+// user.
+func (a annotationsApi) createAnnotationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	var req CreateAnnotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	if !a.isEditor(w, r, "annotation-create", req) {
+		return
+	}
+
+	an := &annotation.Annotation{
+		Begin:          req.Begin,
+		End:            req.End,
+		Note:           req.Note,
+		SuppressAlerts: req.SuppressAlerts,
+		Author:         a.loginProvider.LoggedInAs(r).String(),
+	}
+	if err := an.Validate(); err != nil {
+		httputils.ReportError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := a.annotationStore.Create(ctx, an)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to create annotation.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(CreateAnnotationResponse{ID: id}); err != nil {
+		sklog.Errorf("Failed to encode response: %s", err)
+	}
+}
+
+// DeleteAnnotationRequest is the request to delete an annotation.
+type DeleteAnnotationRequest struct {
+	ID string `json:"id"`
+}
+
+// deleteAnnotationHandler deletes an annotation.
+func (a annotationsApi) deleteAnnotationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	var req DeleteAnnotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	if !a.isEditor(w, r, "annotation-delete", req) {
+		return
+	}
+	if req.ID == "" {
+		httputils.ReportError(w, skerr.Fmt("Missing id"), "An annotation id is required.", http.StatusBadRequest)
+		return
+	}
+	if err := a.annotationStore.Delete(ctx, req.ID); err != nil {
+		httputils.ReportError(w, err, "Failed to delete annotation.", http.StatusInternalServerError)
+	}
+}
+
+func (a annotationsApi) isEditor(w http.ResponseWriter, r *http.Request, action string, body interface{}) bool {
+	user := a.loginProvider.LoggedInAs(r)
+	if !a.loginProvider.HasRole(r, roles.Editor) {
+		httputils.ReportError(w, skerr.Fmt("Not logged in."), "You must be logged in to complete this action.", http.StatusUnauthorized)
+		return false
+	}
+	auditlog.LogWithUser(r, user.String(), action, body)
+	return true
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/alogin/mocks"
+	"go.skia.org/infra/go/roles"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/perf/go/annotation"
+	annotationMocks "go.skia.org/infra/perf/go/annotation/mocks"
+	"go.skia.org/infra/perf/go/types"
+)
+
+func newAnnotationRequestForTest(t *testing.T, url string, body interface{}) *http.Request {
+	b, err := json.Marshal(body)
+	require.NoError(t, err)
+	return httptest.NewRequest("POST", url, bytes.NewReader(b))
+}
+
+func TestListAnnotationsHandler_Success(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/annotations/list", ListAnnotationsRequest{Begin: 1, End: 10})
+
+	store := annotationMocks.NewStore(t)
+	store.On("List", testutils.AnyContext, types.CommitNumber(1), types.CommitNumber(10)).Return([]*annotation.Annotation{
+		{ID: "abc", Begin: 2, End: 4, Note: "lab power outage", SuppressAlerts: true, Author: "a@b.com"},
+	}, nil)
+
+	NewAnnotationsApi(mocks.NewLogin(t), store).listAnnotationsHandler(w, r)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	var resp ListAnnotationsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Annotations, 1)
+	assert.Equal(t, "lab power outage", resp.Annotations[0].Note)
+}
+
+func TestCreateAnnotationHandler_Editor_CreatesAnnotationWithAuthor(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/annotations/create", CreateAnnotationRequest{Begin: 5, End: 8, Note: "toolchain roll"})
+
+	store := annotationMocks.NewStore(t)
+	store.On("Create", testutils.AnyContext, mock.MatchedBy(func(a *annotation.Annotation) bool {
+		return a.Author == "nobody@example.org" && a.Begin == 5 && a.End == 8 && a.Note == "toolchain roll"
+	})).Return("abc", nil)
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(true)
+
+	NewAnnotationsApi(login, store).createAnnotationHandler(w, r)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	var resp CreateAnnotationResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "abc", resp.ID)
+}
+
+func TestCreateAnnotationHandler_NotEditor_ReturnsUnauthorized(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/annotations/create", CreateAnnotationRequest{Begin: 5, End: 8, Note: "toolchain roll"})
+
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail(""))
+	login.On("HasRole", r, roles.Editor).Return(false)
+
+	NewAnnotationsApi(login, annotationMocks.NewStore(t)).createAnnotationHandler(w, r)
+
+	require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+}
+
+func TestCreateAnnotationHandler_InvalidRange_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/annotations/create", CreateAnnotationRequest{Begin: 8, End: 5, Note: "toolchain roll"})
+
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(true)
+
+	NewAnnotationsApi(login, annotationMocks.NewStore(t)).createAnnotationHandler(w, r)
+
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestDeleteAnnotationHandler_Editor_DeletesAnnotation(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/annotations/delete", DeleteAnnotationRequest{ID: "abc"})
+
+	store := annotationMocks.NewStore(t)
+	store.On("Delete", testutils.AnyContext, "abc").Return(nil)
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(true)
+
+	NewAnnotationsApi(login, store).deleteAnnotationHandler(w, r)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+}
@@ -15,15 +15,18 @@ import (
 	"go.skia.org/infra/go/auditlog"
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/query"
+	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/perf/go/anomalies"
 	"go.skia.org/infra/perf/go/config"
 	"go.skia.org/infra/perf/go/dataframe"
 	"go.skia.org/infra/perf/go/dfbuilder"
+	"go.skia.org/infra/perf/go/export"
 	perfgit "go.skia.org/infra/perf/go/git"
 	"go.skia.org/infra/perf/go/git/provider"
 	"go.skia.org/infra/perf/go/ingest/format"
+	"go.skia.org/infra/perf/go/pivot"
 	"go.skia.org/infra/perf/go/progress"
 	"go.skia.org/infra/perf/go/shortcut"
 	"go.skia.org/infra/perf/go/tracestore"
@@ -31,6 +34,9 @@ import (
 	"go.skia.org/infra/perf/go/ui/frame"
 )
 
+// exportChunkSize is the number of traces loaded at a time when exporting.
+const exportChunkSize = 1000
+
 // graphApi provides a struct to handle api requests related to graph plots.
 type graphApi struct {
 	loginProvider alogin.Login
@@ -61,6 +67,8 @@ type graphApi struct {
 // RegisterHandlers registers the api handlers for their respective routes.
 func (api graphApi) RegisterHandlers(router *chi.Mux) {
 	router.Post("/_/frame/start", api.frameStartHandler)
+	router.Post("/_/export", api.exportHandler)
+	router.Post("/_/pivot", api.pivotHandler)
 	router.Post("/_/cid/", api.cidHandler)
 	router.Post("/_/details/", api.detailsHandler)
 	router.Post("/_/shift/", api.shiftHandler)
@@ -143,6 +151,196 @@ func (api graphApi) frameStartHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// exportHandler runs the POST'd FrameRequest and streams the resulting traces
+// back as a file download.
+//
+// The format of the file is given by the "format" query parameter, which is
+// one of the export.Format values, and defaults to CSV.
+func (api graphApi) exportHandler(w http.ResponseWriter, r *http.Request) {
+	exportFormat := export.CSV
+	if f := r.FormValue("format"); f != "" {
+		exportFormat = export.Format(f)
+	}
+	if !util.In(string(exportFormat), exportFormatStrings()) {
+		httputils.ReportError(w, fmt.Errorf("Invalid format: %q", exportFormat), "Invalid export format.", http.StatusBadRequest)
+		return
+	}
+
+	fr := frame.NewFrameRequest()
+	if err := json.NewDecoder(r.Body).Decode(fr); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	auditlog.LogWithUser(r, api.loginProvider.LoggedInAs(r).String(), "export", fr)
+	// Remove all empty queries.
+	q := []string{}
+	for _, s := range fr.Queries {
+		if strings.TrimSpace(s) != "" {
+			q = append(q, s)
+		}
+	}
+	fr.Queries = q
+
+	if len(fr.Formulas) == 0 && len(fr.Queries) == 0 && fr.Keys == "" {
+		httputils.ReportError(w, fmt.Errorf("Invalid query."), "Empty queries are not allowed.", http.StatusBadRequest)
+		return
+	}
+
+	dfBuilder := api.dfBuilder
+	if fr.DoNotFilterParentTraces {
+		dfBuilder = dfbuilder.NewDataFrameBuilderFromTraceStore(
+			api.perfGit,
+			api.traceStore,
+			api.numParamSetsForQueries,
+			dfbuilder.Filtering(false))
+	}
+
+	ctx, span := trace.StartSpan(r.Context(), "exportRequest")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, config.QueryMaxRunTime)
+	defer cancel()
+	if !fr.CanLoadInChunks() {
+		df, err := frame.DataFrameFromRequest(ctx, fr, api.perfGit, dfBuilder, api.shortcutStore)
+		if err != nil {
+			httputils.ReportError(w, err, "Failed to load traces.", http.StatusInternalServerError)
+			return
+		}
+		setExportHeaders(w, exportFormat)
+		if err := export.Write(w, exportFormat, df); err != nil {
+			// The headers have already been sent, so all we can do is log.
+			sklog.Errorf("Failed to write export: %s", err)
+		}
+		return
+	}
+
+	traceIDs, err := frame.TraceIDsFromRequest(ctx, fr, api.perfGit, api.traceStore, api.shortcutStore)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to find traces.", http.StatusInternalServerError)
+		return
+	}
+	setExportHeaders(w, exportFormat)
+	if err := writeExportInChunks(ctx, w, exportFormat, fr, dfBuilder, traceIDs, exportChunkSize); err != nil {
+		// The headers have already been sent, so all we can do is log.
+		sklog.Errorf("Failed to write export: %s", err)
+	}
+}
+
+// setExportHeaders sets the headers for downloading an export in the given
+// format.
+func setExportHeaders(w http.ResponseWriter, exportFormat export.Format) {
+	w.Header().Set("Content-Type", exportFormat.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=perf.%s", exportFormat))
+}
+
+// writeExportInChunks loads and writes the given traces chunkSize at a time,
+// flushing the response after each chunk, so that the whole export never needs
+// to be in memory.
+func writeExportInChunks(ctx context.Context, w http.ResponseWriter, exportFormat export.Format, fr *frame.FrameRequest, dfBuilder dataframe.DataFrameBuilder, traceIDs []string, chunkSize int) error {
+	ew, err := export.NewWriter(w, exportFormat, traceIDs)
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	rc := http.NewResponseController(w)
+	for begin := 0; begin < len(traceIDs); begin += chunkSize {
+		end := begin + chunkSize
+		if end > len(traceIDs) {
+			end = len(traceIDs)
+		}
+		df, err := frame.DataFrameFromTraceIDs(ctx, fr, dfBuilder, traceIDs[begin:end])
+		if err != nil {
+			return skerr.Wrapf(err, "loading traces %d to %d", begin, end)
+		}
+		if err := ew.Write(df); err != nil {
+			return skerr.Wrap(err)
+		}
+		if err := rc.Flush(); err != nil {
+			sklog.Warningf("Failed to flush export: %s", err)
+		}
+	}
+	return skerr.Wrap(ew.Close())
+}
+
+// pivotHandler runs the POST'd FrameRequest and returns the matching traces
+// aggregated as described by the FrameRequest's pivot.Request, as a
+// pivot.Table.
+//
+// Unlike _/frame/start the traces themselves are never sent to the client, so
+// this works for queries that match too many traces to pivot in the browser.
+func (api graphApi) pivotHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fr := frame.NewFrameRequest()
+	if err := json.NewDecoder(r.Body).Decode(fr); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	auditlog.LogWithUser(r, api.loginProvider.LoggedInAs(r).String(), "pivot", fr)
+	if fr.Pivot == nil {
+		httputils.ReportError(w, fmt.Errorf("Missing pivot."), "A pivot request is required.", http.StatusBadRequest)
+		return
+	}
+	if err := fr.Pivot.Valid(); err != nil {
+		httputils.ReportError(w, err, "Invalid pivot request.", http.StatusBadRequest)
+		return
+	}
+	if len(fr.Pivot.Summary) == 0 {
+		httputils.ReportError(w, fmt.Errorf("Missing summary."), "At least one summary operation is required.", http.StatusBadRequest)
+		return
+	}
+	// Remove all empty queries.
+	q := []string{}
+	for _, s := range fr.Queries {
+		if strings.TrimSpace(s) != "" {
+			q = append(q, s)
+		}
+	}
+	fr.Queries = q
+
+	if len(fr.Formulas) == 0 && len(fr.Queries) == 0 && fr.Keys == "" {
+		httputils.ReportError(w, fmt.Errorf("Invalid query."), "Empty queries are not allowed.", http.StatusBadRequest)
+		return
+	}
+
+	dfBuilder := api.dfBuilder
+	if fr.DoNotFilterParentTraces {
+		dfBuilder = dfbuilder.NewDataFrameBuilderFromTraceStore(
+			api.perfGit,
+			api.traceStore,
+			api.numParamSetsForQueries,
+			dfbuilder.Filtering(false))
+	}
+
+	ctx, span := trace.StartSpan(r.Context(), "pivotRequest")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, config.QueryMaxRunTime)
+	defer cancel()
+
+	// Load the traces without the pivot applied, since PivotTable applies it.
+	pivotRequest := *fr.Pivot
+	fr.Pivot = nil
+	df, err := frame.DataFrameFromRequest(ctx, fr, api.perfGit, dfBuilder, api.shortcutStore)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load traces.", http.StatusInternalServerError)
+		return
+	}
+	table, err := pivot.PivotTable(ctx, pivotRequest, df)
+	if err != nil {
+		httputils.ReportError(w, err, "Pivot failed.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(table); err != nil {
+		sklog.Errorf("Failed to encode pivot table: %s", err)
+	}
+}
+
+// exportFormatStrings returns export.AllFormats as strings.
+func exportFormatStrings() []string {
+	ret := make([]string, 0, len(export.AllFormats))
+	for _, f := range export.AllFormats {
+		ret = append(ret, string(f))
+	}
+	return ret
+}
+
 // CIDHandlerResponse is the form of the response from the /_/cid/ endpoint.
 type CIDHandlerResponse struct {
 	// CommitSlice describes all the commits requested.
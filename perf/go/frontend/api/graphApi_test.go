@@ -2,12 +2,24 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/alogin/mocks"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/perf/go/dataframe"
+	dfMocks "go.skia.org/infra/perf/go/dataframe/mocks"
+	"go.skia.org/infra/perf/go/export"
+	"go.skia.org/infra/perf/go/pivot"
+	"go.skia.org/infra/perf/go/types"
+	"go.skia.org/infra/perf/go/ui/frame"
 )
 
 func TestFrontendDetailsHandler_InvalidTraceID_ReturnsErrorMessage(t *testing.T) {
@@ -27,3 +39,100 @@ func TestFrontendDetailsHandler_InvalidTraceID_ReturnsErrorMessage(t *testing.T)
 	require.Equal(t, http.StatusOK, w.Result().StatusCode)
 	require.Contains(t, w.Body.String(), "version\":0")
 }
+
+func TestExportHandler_InvalidFormat_ReturnsBadRequest(t *testing.T) {
+	api := graphApi{}
+	w := httptest.NewRecorder()
+
+	r := httptest.NewRequest("POST", "/_/export?format=xlsx", strings.NewReader(`{"queries":["arch=x86"]}`))
+	api.exportHandler(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestExportHandler_EmptyQueries_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/_/export?format=parquet", strings.NewReader(`{"queries":["  "]}`))
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	api := graphApi{loginProvider: login}
+
+	api.exportHandler(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestWriteExportInChunks_TwoChunks_WritesAllTracesAndFlushes(t *testing.T) {
+	fr := frame.NewFrameRequest()
+	header := []*dataframe.ColumnHeader{{Offset: 10, Timestamp: 1672531200}}
+	chunk := func(traceID string, value float32) *dataframe.DataFrame {
+		df := dataframe.NewEmpty()
+		df.Header = header
+		df.TraceSet[traceID] = types.Trace{value}
+		return df
+	}
+	dfb := dfMocks.NewDataFrameBuilder(t)
+	dfb.On("NewFromKeysAndRange", testutils.AnyContext, []string{",arch=arm,"}, mock.Anything, mock.Anything, true, mock.Anything).Return(chunk(",arch=arm,", 1), nil).Once()
+	dfb.On("NewFromKeysAndRange", testutils.AnyContext, []string{",arch=x86,"}, mock.Anything, mock.Anything, true, mock.Anything).Return(chunk(",arch=x86,", 2), nil).Once()
+
+	w := httptest.NewRecorder()
+	err := writeExportInChunks(context.Background(), w, export.CSV, fr, dfb, []string{",arch=arm,", ",arch=x86,"}, 1)
+	require.NoError(t, err)
+	require.True(t, w.Flushed)
+	require.Equal(t, `trace_id,commit_number,timestamp,value,arch
+",arch=arm,",10,2023-01-01T00:00:00Z,1,arm
+",arch=x86,",10,2023-01-01T00:00:00Z,2,x86
+`, w.Body.String())
+}
+
+func graphApiForPivotTest(t *testing.T, r *http.Request, dfBuilder dataframe.DataFrameBuilder) graphApi {
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	return graphApi{loginProvider: login, dfBuilder: dfBuilder}
+}
+
+func TestPivotHandler_MissingSummary_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/_/pivot", strings.NewReader(`{"queries":["arch=x86"],"pivot":{"group_by":["arch"],"operation":"sum"}}`))
+	api := graphApiForPivotTest(t, r, nil)
+
+	api.pivotHandler(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestPivotHandler_InvalidOperation_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/_/pivot", strings.NewReader(`{"queries":["arch=x86"],"pivot":{"group_by":["arch"],"operation":"median","summary":["avg"]}}`))
+	api := graphApiForPivotTest(t, r, nil)
+
+	api.pivotHandler(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestPivotHandler_ValidRequest_ReturnsTable(t *testing.T) {
+	df := dataframe.NewEmpty()
+	df.TraceSet = types.TraceSet{
+		",arch=arm,config=8888,": types.Trace{1, 2, 3},
+		",arch=arm,config=565,":  types.Trace{3, 4, 5},
+		",arch=x86,config=8888,": types.Trace{10, 20, 30},
+	}
+	df.Header = []*dataframe.ColumnHeader{{Offset: 0}, {Offset: 1}, {Offset: 2}}
+	df.BuildParamSet()
+	dfBuilder := dfMocks.NewDataFrameBuilder(t)
+	dfBuilder.On("NewFromQueryAndRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).Return(df, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/_/pivot", strings.NewReader(`{"queries":["config=8888&config=565"],"pivot":{"group_by":["arch"],"operation":"sum","summary":["max","p50"]}}`))
+	api := graphApiForPivotTest(t, r, dfBuilder)
+
+	api.pivotHandler(w, r)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	var table pivot.Table
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&table))
+	require.Equal(t, pivot.Table{
+		GroupBy: []string{"arch"},
+		Columns: []pivot.Operation{pivot.Max, pivot.P50},
+		Rows: []pivot.TableRow{
+			{Group: []string{"arm"}, Values: []float32{8, 6}},
+			{Group: []string{"x86"}, Values: []float32{30, 20}},
+		},
+	}, table)
+}
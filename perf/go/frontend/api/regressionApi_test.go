@@ -1,14 +1,17 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.skia.org/infra/go/alogin"
 	"go.skia.org/infra/go/alogin/mocks"
 	"go.skia.org/infra/go/roles"
+	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/testutils"
 	"go.skia.org/infra/perf/go/regression"
 	regressionMocks "go.skia.org/infra/perf/go/regression/mocks"
@@ -64,3 +67,78 @@ func TestFrontendIsEditor_UserIsOnlyViewer_ReportsError(t *testing.T) {
 	f.isEditor(w, r, "my-test-action", nil)
 	require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
 }
+
+func TestBulkTriageHandler_ValidRequest_TriagesAllRegressions(t *testing.T) {
+	login := mocks.NewLogin(t)
+	regMock := regressionMocks.NewStore(t)
+	triage := regression.TriageStatus{Status: regression.Negative, Message: "Noisy roll."}
+	regMock.On("TriageByIDs", testutils.AnyContext, []string{"r1", "r2"}, triage).Return(nil)
+	rApi := NewRegressionsApi(login, nil, nil, regMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/json/triage/bulk", strings.NewReader(`{"regression_ids": ["r2", "r1", "r2"], "triage": {"status": "negative", "message": "Noisy roll."}}`))
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(true)
+	rApi.bulkTriageHandler(w, r)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	require.JSONEq(t, `{"triaged": 2}`, w.Body.String())
+}
+
+func TestBulkTriageHandler_NoRegressionIDs_ReportsBadRequest(t *testing.T) {
+	rApi := NewRegressionsApi(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/json/triage/bulk", strings.NewReader(`{"regression_ids": [], "triage": {"status": "positive"}}`))
+	rApi.bulkTriageHandler(w, r)
+
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestBulkTriageHandler_InvalidStatus_ReportsBadRequest(t *testing.T) {
+	rApi := NewRegressionsApi(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/json/triage/bulk", strings.NewReader(`{"regression_ids": ["r1"], "triage": {"status": "maybe"}}`))
+	rApi.bulkTriageHandler(w, r)
+
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestBulkTriageHandler_UserIsOnlyViewer_ReportsError(t *testing.T) {
+	login := mocks.NewLogin(t)
+	rApi := NewRegressionsApi(login, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/json/triage/bulk", strings.NewReader(`{"regression_ids": ["r1"], "triage": {"status": "positive"}}`))
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(false)
+	rApi.bulkTriageHandler(w, r)
+
+	require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+}
+
+func TestBulkTriageHandler_RegressionNotFound_ReportsNotFound(t *testing.T) {
+	login := mocks.NewLogin(t)
+	regMock := regressionMocks.NewStore(t)
+	regMock.On("TriageByIDs", testutils.AnyContext, []string{"r1"}, regression.TriageStatus{Status: regression.Positive}).Return(skerr.Wrapf(regression.ErrNotFound, "Found 0 of the 1 regressions to triage"))
+	rApi := NewRegressionsApi(login, nil, nil, regMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/json/triage/bulk", strings.NewReader(`{"regression_ids": ["r1"], "triage": {"status": "positive"}}`))
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(true)
+	rApi.bulkTriageHandler(w, r)
+
+	require.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func TestBulkTriageHandler_StoreFails_ReportsError(t *testing.T) {
+	login := mocks.NewLogin(t)
+	regMock := regressionMocks.NewStore(t)
+	regMock.On("TriageByIDs", testutils.AnyContext, []string{"r1"}, regression.TriageStatus{Status: regression.Positive}).Return(errors.New("connection refused"))
+	rApi := NewRegressionsApi(login, nil, nil, regMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/json/triage/bulk", strings.NewReader(`{"regression_ids": ["r1"], "triage": {"status": "positive"}}`))
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(true)
+	rApi.bulkTriageHandler(w, r)
+
+	require.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/auditlog"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/roles"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/perf/go/regression"
+	"go.skia.org/infra/perf/go/regressiongroup"
+	"go.skia.org/infra/perf/go/types"
+)
+
+// regressionGroupsApi provides a struct for handling groups of regressions
+// found at the same commit.
+type regressionGroupsApi struct {
+	loginProvider alogin.Login
+	groupStore    regressiongroup.Store
+	regStore      regression.Store
+}
+
+// NewRegressionGroupsApi returns a new instance of regressionGroupsApi.
+func NewRegressionGroupsApi(loginProvider alogin.Login, groupStore regressiongroup.Store, regStore regression.Store) regressionGroupsApi {
+	return regressionGroupsApi{
+		loginProvider: loginProvider,
+		groupStore:    groupStore,
+		regStore:      regStore,
+	}
+}
+
+// RegisterHandlers registers the api handlers for their respective routes.
+func (a regressionGroupsApi) RegisterHandlers(router *chi.Mux) {
+	router.Post("/_/regression_groups/list", a.listRegressionGroupsHandler)
+	router.Post("/_/regression_groups/triage", a.triageRegressionGroupHandler)
+}
+
+// ListRegressionGroupsRequest is the request to fetch all the regression
+// groups found at commits in [Begin, End].
+type ListRegressionGroupsRequest struct {
+	Begin types.CommitNumber `json:"begin"`
+	End   types.CommitNumber `json:"end"`
+}
+
+// ListRegressionGroupsResponse is the response to ListRegressionGroupsRequest.
+type ListRegressionGroupsResponse struct {
+	Groups []*regressiongroup.Group `json:"groups"`
+}
+
+// listRegressionGroupsHandler returns the regression groups in a commit range.
+func (a regressionGroupsApi) listRegressionGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	var req ListRegressionGroupsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	if req.End < req.Begin {
+		httputils.ReportError(w, skerr.Fmt("Invalid range [%d, %d]", req.Begin, req.End), "Invalid commit range.", http.StatusBadRequest)
+		return
+	}
+
+	groups, err := a.groupStore.Range(ctx, req.Begin, req.End)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to list regression groups.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(ListRegressionGroupsResponse{Groups: groups}); err != nil {
+		sklog.Errorf("Failed to encode response: %s", err)
+	}
+}
+
+// TriageRegressionGroupRequest is the request to triage a regression group,
+// and with it every regression in the group.
+type TriageRegressionGroupRequest struct {
+	ID     string                  `json:"id"`
+	Triage regression.TriageStatus `json:"triage"`
+}
+
+// TriageRegressionGroupResponse is the response to
+// TriageRegressionGroupRequest.
+type TriageRegressionGroupResponse struct {
+	// Triaged is the number of regressions in the group that were triaged.
+	Triaged int `json:"triaged"`
+}
+
+// triageRegressionGroupHandler applies the triage status to a regression
+// group and to all the regressions in it.
+func (a regressionGroupsApi) triageRegressionGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	var req TriageRegressionGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" {
+		httputils.ReportError(w, skerr.Fmt("Missing id"), "A regression group id is required.", http.StatusBadRequest)
+		return
+	}
+	if !util.In(string(req.Triage.Status), []string{string(regression.Positive), string(regression.Negative), string(regression.Untriaged)}) {
+		httputils.ReportError(w, skerr.Fmt("Invalid triage status: %q", req.Triage.Status), "Invalid triage status.", http.StatusBadRequest)
+		return
+	}
+	if !a.isEditor(w, r, "triage_regression_group", req) {
+		return
+	}
+
+	group, err := a.groupStore.Get(ctx, req.ID)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load regression group.", http.StatusNotFound)
+		return
+	}
+	// Triage the regressions first, so the group is never shown as triaged
+	// while its regressions are not.
+	if err := a.regStore.TriageByIDs(ctx, group.RegressionIDs, req.Triage); err != nil {
+		httputils.ReportError(w, err, "Failed to triage the regressions in the group.", http.StatusInternalServerError)
+		return
+	}
+	if err := a.groupStore.SetTriage(ctx, group.ID, req.Triage); err != nil {
+		httputils.ReportError(w, err, "Failed to triage the regression group.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(TriageRegressionGroupResponse{Triaged: len(group.RegressionIDs)}); err != nil {
+		sklog.Errorf("Failed to encode response: %s", err)
+	}
+}
+
+func (a regressionGroupsApi) isEditor(w http.ResponseWriter, r *http.Request, action string, body interface{}) bool {
+	user := a.loginProvider.LoggedInAs(r)
+	if !a.loginProvider.HasRole(r, roles.Editor) {
+		httputils.ReportError(w, skerr.Fmt("Not logged in."), "You must be logged in to complete this action.", http.StatusUnauthorized)
+		return false
+	}
+	auditlog.LogWithUser(r, user.String(), action, body)
+	return true
+}
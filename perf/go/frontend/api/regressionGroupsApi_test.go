@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/alogin/mocks"
+	"go.skia.org/infra/go/roles"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/perf/go/regression"
+	regressionMocks "go.skia.org/infra/perf/go/regression/mocks"
+	"go.skia.org/infra/perf/go/regressiongroup"
+	regressionGroupMocks "go.skia.org/infra/perf/go/regressiongroup/mocks"
+	"go.skia.org/infra/perf/go/types"
+)
+
+func TestListRegressionGroupsHandler_Success(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/regression_groups/list", ListRegressionGroupsRequest{Begin: 1, End: 10})
+
+	groupStore := regressionGroupMocks.NewStore(t)
+	groupStore.On("Range", testutils.AnyContext, types.CommitNumber(1), types.CommitNumber(10)).Return([]*regressiongroup.Group{
+		{ID: "abc", CommitNumber: 5, GroupKey: "bot=linux", RegressionIDs: []string{"r1", "r2"}},
+	}, nil)
+
+	NewRegressionGroupsApi(mocks.NewLogin(t), groupStore, regressionMocks.NewStore(t)).listRegressionGroupsHandler(w, r)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	var resp ListRegressionGroupsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Groups, 1)
+	assert.Equal(t, []string{"r1", "r2"}, resp.Groups[0].RegressionIDs)
+}
+
+func TestTriageRegressionGroupHandler_Editor_TriagesGroupAndRegressions(t *testing.T) {
+	w := httptest.NewRecorder()
+	tr := regression.TriageStatus{Status: regression.Negative, Message: "Bad roll."}
+	r := newAnnotationRequestForTest(t, "/_/regression_groups/triage", TriageRegressionGroupRequest{ID: "abc", Triage: tr})
+
+	groupStore := regressionGroupMocks.NewStore(t)
+	groupStore.On("Get", testutils.AnyContext, "abc").Return(&regressiongroup.Group{ID: "abc", RegressionIDs: []string{"r1", "r2"}}, nil)
+	groupStore.On("SetTriage", testutils.AnyContext, "abc", tr).Return(nil)
+	regStore := regressionMocks.NewStore(t)
+	regStore.On("TriageByIDs", testutils.AnyContext, []string{"r1", "r2"}, tr).Return(nil)
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(true)
+
+	NewRegressionGroupsApi(login, groupStore, regStore).triageRegressionGroupHandler(w, r)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	var resp TriageRegressionGroupResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, 2, resp.Triaged)
+}
+
+func TestTriageRegressionGroupHandler_NotEditor_ReturnsUnauthorized(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/regression_groups/triage", TriageRegressionGroupRequest{ID: "abc", Triage: regression.TriageStatus{Status: regression.Positive}})
+
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail(""))
+	login.On("HasRole", r, roles.Editor).Return(false)
+
+	NewRegressionGroupsApi(login, regressionGroupMocks.NewStore(t), regressionMocks.NewStore(t)).triageRegressionGroupHandler(w, r)
+
+	require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+}
+
+func TestTriageRegressionGroupHandler_InvalidStatus_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/regression_groups/triage", TriageRegressionGroupRequest{ID: "abc", Triage: regression.TriageStatus{Status: "bogus"}})
+
+	NewRegressionGroupsApi(mocks.NewLogin(t), regressionGroupMocks.NewStore(t), regressionMocks.NewStore(t)).triageRegressionGroupHandler(w, r)
+
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -95,6 +96,7 @@ func (r regressionsApi) RegisterHandlers(router *chi.Mux) {
 	router.Get("/_/alertgroup", r.alertGroupQueryHandler)
 	router.Get("/_/anomaly", r.anomalyHandler)
 	router.Post("/_/triage/", r.triageHandler)
+	router.Post("/json/triage/bulk", r.bulkTriageHandler)
 	router.Post("/_/cluster/start", r.clusterStartHandler)
 }
 
@@ -612,6 +614,79 @@ func (rApi regressionsApi) triageHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// BulkTriageRequest is used in bulkTriageHandler.
+type BulkTriageRequest struct {
+	// RegressionIDs are the ids of the regressions in the regression2 schema.
+	RegressionIDs []string                `json:"regression_ids"`
+	Triage        regression.TriageStatus `json:"triage"`
+}
+
+// BulkTriageResponse is used in bulkTriageHandler.
+type BulkTriageResponse struct {
+	// Triaged is the number of distinct regressions that were triaged.
+	Triaged int `json:"triaged"`
+}
+
+// bulkTriageAuditEntry is the audit log body recorded for each regression
+// triaged by bulkTriageHandler.
+type bulkTriageAuditEntry struct {
+	RegressionID string                  `json:"regression_id"`
+	Triage       regression.TriageStatus `json:"triage"`
+}
+
+// bulkTriageHandler takes a POST'd BulkTriageRequest serialized as JSON and
+// applies the triage status to all the regressions. Either all of the
+// regressions are triaged or none of them are.
+//
+// If successful it returns a 200, a 400 for an invalid request, a 404 if any of
+// the regressions don't exist, or an HTTP status code of 500 otherwise.
+func (rApi regressionsApi) bulkTriageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	req := &BulkTriageRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	ids := util.NewStringSet(req.RegressionIDs).Keys()
+	sort.Strings(ids)
+	if len(ids) == 0 {
+		httputils.ReportError(w, skerr.Fmt("No regression ids supplied."), "At least one regression id must be supplied.", http.StatusBadRequest)
+		return
+	}
+	if !util.In(string(req.Triage.Status), []string{string(regression.Positive), string(regression.Negative), string(regression.Untriaged)}) {
+		httputils.ReportError(w, skerr.Fmt("Invalid triage status: %q", req.Triage.Status), "Invalid triage status.", http.StatusBadRequest)
+		return
+	}
+	if !rApi.isEditor(w, r, "triage_bulk", req) {
+		return
+	}
+
+	if err := rApi.regStore.TriageByIDs(ctx, ids, req.Triage); err != nil {
+		if errors.Is(err, regression.ErrNotFound) {
+			httputils.ReportError(w, err, "One or more of the regressions were not found.", http.StatusNotFound)
+			return
+		}
+		httputils.ReportError(w, err, "Failed to triage.", http.StatusInternalServerError)
+		return
+	}
+
+	// Record each triage event individually so the audit history of a single
+	// regression can be found without knowing it was part of a bulk triage.
+	user := rApi.loginProvider.LoggedInAs(r).String()
+	for _, id := range ids {
+		auditlog.LogWithUser(r, user, "triage", bulkTriageAuditEntry{
+			RegressionID: id,
+			Triage:       req.Triage,
+		})
+	}
+	if err := json.NewEncoder(w).Encode(BulkTriageResponse{Triaged: len(ids)}); err != nil {
+		sklog.Errorf("Failed to write or encode output: %s", err)
+	}
+}
+
 func (rApi regressionsApi) isEditor(w http.ResponseWriter, r *http.Request, action string, body interface{}) bool {
 	user := rApi.loginProvider.LoggedInAs(r)
 	if !rApi.loginProvider.HasRole(r, roles.Editor) {
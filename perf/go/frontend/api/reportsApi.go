@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/auditlog"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/roles"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/perf/go/report"
+)
+
+// reportsApi provides a struct for handling reports, which bundle multiple
+// graphs and their notes into a single page.
+type reportsApi struct {
+	loginProvider alogin.Login
+	reportStore   report.Store
+}
+
+// NewReportsApi returns a new instance of reportsApi.
+func NewReportsApi(loginProvider alogin.Login, reportStore report.Store) reportsApi {
+	return reportsApi{
+		loginProvider: loginProvider,
+		reportStore:   reportStore,
+	}
+}
+
+// RegisterHandlers registers the api handlers for their respective routes.
+func (a reportsApi) RegisterHandlers(router *chi.Mux) {
+	router.Get("/_/reports/list", a.listReportsHandler)
+	router.Post("/_/reports/get", a.getReportHandler)
+	router.Post("/_/reports/create", a.createReportHandler)
+	router.Post("/_/reports/update", a.updateReportHandler)
+}
+
+// ListReportsResponse is the response to a request to list all reports.
+type ListReportsResponse struct {
+	Reports []*report.Report `json:"reports"`
+}
+
+// listReportsHandler returns all the reports.
+func (a reportsApi) listReportsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	reports, err := a.reportStore.List(ctx)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to list reports.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(ListReportsResponse{Reports: reports}); err != nil {
+		sklog.Errorf("Failed to encode response: %s", err)
+	}
+}
+
+// GetReportRequest is the request to fetch a single report, e.g. to render it.
+type GetReportRequest struct {
+	ID string `json:"id"`
+}
+
+// getReportHandler returns a single report.
+func (a reportsApi) getReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	var req GetReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" {
+		httputils.ReportError(w, skerr.Fmt("Missing id"), "A report id is required.", http.StatusBadRequest)
+		return
+	}
+	rep, err := a.reportStore.Get(ctx, req.ID)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load report.", http.StatusNotFound)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(rep); err != nil {
+		sklog.Errorf("Failed to encode response: %s", err)
+	}
+}
+
+// CreateReportRequest is the request to create a new report.
+type CreateReportRequest struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Graphs      []report.Graph `json:"graphs"`
+}
+
+// CreateReportResponse is the response to CreateReportRequest.
+type CreateReportResponse struct {
+	ID string `json:"id"`
+}
+
+// createReportHandler creates a new report owned by the logged in user.
+func (a reportsApi) createReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	var req CreateReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	if !a.isEditor(w, r, "report-create", req) {
+		return
+	}
+
+	rep := &report.Report{
+		Name:        req.Name,
+		Description: req.Description,
+		Graphs:      req.Graphs,
+		Owner:       a.loginProvider.LoggedInAs(r).String(),
+	}
+	if err := rep.Validate(); err != nil {
+		httputils.ReportError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := a.reportStore.Create(ctx, rep)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to create report.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(CreateReportResponse{ID: id}); err != nil {
+		sklog.Errorf("Failed to encode response: %s", err)
+	}
+}
+
+// UpdateReportRequest is the request to change an existing report.
+type UpdateReportRequest struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Graphs      []report.Graph `json:"graphs"`
+}
+
+// updateReportHandler replaces the contents of an existing report. Any editor
+// may update a report, since reports are shared between sheriffs.
+func (a reportsApi) updateReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	var req UpdateReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	if !a.isEditor(w, r, "report-update", req) {
+		return
+	}
+	if req.ID == "" {
+		httputils.ReportError(w, skerr.Fmt("Missing id"), "A report id is required.", http.StatusBadRequest)
+		return
+	}
+
+	rep := &report.Report{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		Graphs:      req.Graphs,
+	}
+	if err := rep.Validate(); err != nil {
+		httputils.ReportError(w, err, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := a.reportStore.Update(ctx, rep); err != nil {
+		httputils.ReportError(w, err, "Failed to update report.", http.StatusInternalServerError)
+	}
+}
+
+func (a reportsApi) isEditor(w http.ResponseWriter, r *http.Request, action string, body interface{}) bool {
+	user := a.loginProvider.LoggedInAs(r)
+	if !a.loginProvider.HasRole(r, roles.Editor) {
+		httputils.ReportError(w, skerr.Fmt("Not logged in."), "You must be logged in to complete this action.", http.StatusUnauthorized)
+		return false
+	}
+	auditlog.LogWithUser(r, user.String(), action, body)
+	return true
+}
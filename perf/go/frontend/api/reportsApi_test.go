@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/alogin/mocks"
+	"go.skia.org/infra/go/roles"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/perf/go/report"
+	reportMocks "go.skia.org/infra/perf/go/report/mocks"
+)
+
+var reportGraphsForTest = []report.Graph{
+	{Queries: []string{"arch=x86&config=8888"}, Begin: 10, End: 20, Note: "Look at the jump at 15."},
+}
+
+func TestListReportsHandler_Success(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/_/reports/list", nil)
+
+	store := reportMocks.NewStore(t)
+	store.On("List", testutils.AnyContext).Return([]*report.Report{
+		{ID: "abc", Name: "Memory regressions", Graphs: reportGraphsForTest, Owner: "a@b.com"},
+	}, nil)
+
+	NewReportsApi(mocks.NewLogin(t), store).listReportsHandler(w, r)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	var resp ListReportsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Reports, 1)
+	assert.Equal(t, "Memory regressions", resp.Reports[0].Name)
+	assert.Equal(t, reportGraphsForTest, resp.Reports[0].Graphs)
+}
+
+func TestGetReportHandler_NoSuchReport_ReturnsNotFound(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/reports/get", GetReportRequest{ID: "abc"})
+
+	store := reportMocks.NewStore(t)
+	store.On("Get", testutils.AnyContext, "abc").Return(nil, errors.New("not found"))
+
+	NewReportsApi(mocks.NewLogin(t), store).getReportHandler(w, r)
+
+	require.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func TestCreateReportHandler_Editor_CreatesReportWithOwner(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/reports/create", CreateReportRequest{Name: "Memory regressions", Graphs: reportGraphsForTest})
+
+	store := reportMocks.NewStore(t)
+	store.On("Create", testutils.AnyContext, mock.MatchedBy(func(rep *report.Report) bool {
+		return rep.Owner == "nobody@example.org" && rep.Name == "Memory regressions" && len(rep.Graphs) == 1
+	})).Return("abc", nil)
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(true)
+
+	NewReportsApi(login, store).createReportHandler(w, r)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	var resp CreateReportResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "abc", resp.ID)
+}
+
+func TestCreateReportHandler_NotEditor_ReturnsUnauthorized(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/reports/create", CreateReportRequest{Name: "Memory regressions", Graphs: reportGraphsForTest})
+
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail(""))
+	login.On("HasRole", r, roles.Editor).Return(false)
+
+	NewReportsApi(login, reportMocks.NewStore(t)).createReportHandler(w, r)
+
+	require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+}
+
+func TestCreateReportHandler_NoGraphs_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/reports/create", CreateReportRequest{Name: "Memory regressions"})
+
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(true)
+
+	NewReportsApi(login, reportMocks.NewStore(t)).createReportHandler(w, r)
+
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestUpdateReportHandler_Editor_UpdatesReport(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/reports/update", UpdateReportRequest{ID: "abc", Name: "Renamed", Graphs: reportGraphsForTest})
+
+	store := reportMocks.NewStore(t)
+	store.On("Update", testutils.AnyContext, mock.MatchedBy(func(rep *report.Report) bool {
+		return rep.ID == "abc" && rep.Name == "Renamed"
+	})).Return(nil)
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", r).Return(alogin.EMail("nobody@example.org"))
+	login.On("HasRole", r, roles.Editor).Return(true)
+
+	NewReportsApi(login, store).updateReportHandler(w, r)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+}
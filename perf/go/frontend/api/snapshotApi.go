@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/perf/go/config"
+	"go.skia.org/infra/perf/go/dataframe"
+	perfgit "go.skia.org/infra/perf/go/git"
+	"go.skia.org/infra/perf/go/progress"
+	"go.skia.org/infra/perf/go/shortcut"
+	"go.skia.org/infra/perf/go/snapshot"
+	"go.skia.org/infra/perf/go/ui/frame"
+)
+
+// snapshotApi provides a struct for handling snapshots, which are permalinks
+// to graphs with frozen data.
+type snapshotApi struct {
+	loginProvider alogin.Login
+	snapshotStore snapshot.Store
+	dfBuilder     dataframe.DataFrameBuilder
+	perfGit       perfgit.Git
+	shortcutStore shortcut.Store
+}
+
+// NewSnapshotApi returns a new instance of snapshotApi.
+func NewSnapshotApi(loginProvider alogin.Login, snapshotStore snapshot.Store, dfBuilder dataframe.DataFrameBuilder, perfGit perfgit.Git, shortcutStore shortcut.Store) snapshotApi {
+	return snapshotApi{
+		loginProvider: loginProvider,
+		snapshotStore: snapshotStore,
+		dfBuilder:     dfBuilder,
+		perfGit:       perfGit,
+		shortcutStore: shortcutStore,
+	}
+}
+
+// RegisterHandlers registers the api handlers for their respective routes.
+func (a snapshotApi) RegisterHandlers(router *chi.Mux) {
+	router.Post("/_/snapshot/new", a.createSnapshotHandler)
+	router.Get("/_/snapshot/{id}", a.getSnapshotHandler)
+}
+
+// CreateSnapshotRequest is the request to take a snapshot of the data returned
+// by a FrameRequest.
+type CreateSnapshotRequest struct {
+	Request *frame.FrameRequest `json:"request"`
+
+	// GraphConfig is the configuration of the graph as rendered by the client,
+	// which is stored with the snapshot as-is.
+	GraphConfig map[string]interface{} `json:"graph_config,omitempty"`
+}
+
+// CreateSnapshotResponse is the response to CreateSnapshotRequest.
+type CreateSnapshotResponse struct {
+	// ID of the snapshot, which can be loaded from /_/snapshot/{id}.
+	ID string `json:"id"`
+}
+
+// createSnapshotHandler builds the DataFrame for the request and stores it,
+// along with the graph config, as a snapshot.
+func (a snapshotApi) createSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), config.QueryMaxRunTime)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	var req CreateSnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.ReportError(w, err, "Failed to decode JSON.", http.StatusBadRequest)
+		return
+	}
+	fr := req.Request
+	if fr == nil {
+		httputils.ReportError(w, skerr.Fmt("Missing request."), "A request is required.", http.StatusBadRequest)
+		return
+	}
+	// Remove all empty queries.
+	q := []string{}
+	for _, s := range fr.Queries {
+		if strings.TrimSpace(s) != "" {
+			q = append(q, s)
+		}
+	}
+	fr.Queries = q
+	if len(fr.Formulas) == 0 && len(fr.Queries) == 0 && fr.Keys == "" {
+		httputils.ReportError(w, skerr.Fmt("Invalid query."), "Empty queries are not allowed.", http.StatusBadRequest)
+		return
+	}
+	fr.Progress = progress.New()
+
+	df, err := frame.DataFrameFromRequest(ctx, fr, a.perfGit, a.dfBuilder, a.shortcutStore)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load data for the snapshot.", http.StatusInternalServerError)
+		return
+	}
+	// Do not truncate pivot requests, same as for frame requests.
+	truncate := fr.Pivot == nil || fr.Pivot.Valid() != nil
+	resp, err := frame.ResponseFromDataFrame(ctx, fr.Pivot, df, a.perfGit, truncate, fr.Progress)
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to build the snapshot.", http.StatusInternalServerError)
+		return
+	}
+
+	id, err := a.snapshotStore.Put(ctx, &snapshot.Snapshot{
+		Request:     fr,
+		Response:    resp,
+		GraphConfig: req.GraphConfig,
+		Created:     now.Now(ctx),
+		User:        a.loginProvider.LoggedInAs(r).String(),
+	})
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to store the snapshot.", http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(CreateSnapshotResponse{ID: id}); err != nil {
+		sklog.Errorf("Failed to encode response: %s", err)
+	}
+}
+
+// getSnapshotHandler returns a previously stored snapshot.
+func (a snapshotApi) getSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaultDatabaseTimeout)
+	defer cancel()
+	w.Header().Set("Content-Type", "application/json")
+
+	s, err := a.snapshotStore.Get(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		httputils.ReportError(w, err, "Failed to load snapshot.", http.StatusNotFound)
+		return
+	}
+	// Snapshots never change.
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		sklog.Errorf("Failed to encode response: %s", err)
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/alogin/mocks"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/perf/go/snapshot"
+	snapshotMocks "go.skia.org/infra/perf/go/snapshot/mocks"
+	"go.skia.org/infra/perf/go/ui/frame"
+)
+
+const snapshotIDForTest = "0123456789abcdef0123456789abcdef"
+
+func newSnapshotRouterForTest(t *testing.T, store snapshot.Store) *chi.Mux {
+	router := chi.NewRouter()
+	NewSnapshotApi(mocks.NewLogin(t), store, nil, nil, nil).RegisterHandlers(router)
+	return router
+}
+
+func TestGetSnapshotHandler_Success(t *testing.T) {
+	store := snapshotMocks.NewStore(t)
+	store.On("Get", testutils.AnyContext, snapshotIDForTest).Return(&snapshot.Snapshot{
+		Request:     &frame.FrameRequest{Queries: []string{"arch=x86"}},
+		GraphConfig: map[string]interface{}{"zoom": []interface{}{1.0, 2.0}},
+	}, nil)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/_/snapshot/"+snapshotIDForTest, nil)
+
+	newSnapshotRouterForTest(t, store).ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Cache-Control"), "immutable")
+	var resp snapshot.Snapshot
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, []string{"arch=x86"}, resp.Request.Queries)
+	assert.Equal(t, map[string]interface{}{"zoom": []interface{}{1.0, 2.0}}, resp.GraphConfig)
+}
+
+func TestGetSnapshotHandler_UnknownID_ReturnsNotFound(t *testing.T) {
+	store := snapshotMocks.NewStore(t)
+	store.On("Get", testutils.AnyContext, snapshotIDForTest).Return(nil, errors.New("not found"))
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/_/snapshot/"+snapshotIDForTest, nil)
+
+	newSnapshotRouterForTest(t, store).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreateSnapshotHandler_EmptyQueries_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/snapshot/new", CreateSnapshotRequest{
+		Request: &frame.FrameRequest{Queries: []string{" "}},
+	})
+
+	newSnapshotRouterForTest(t, snapshotMocks.NewStore(t)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateSnapshotHandler_MissingRequest_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := newAnnotationRequestForTest(t, "/_/snapshot/new", CreateSnapshotRequest{})
+
+	newSnapshotRouterForTest(t, snapshotMocks.NewStore(t)).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -30,11 +30,13 @@ import (
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/pubsub/sub"
 	"go.skia.org/infra/go/roles"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/sklog/sklogimpl"
 	"go.skia.org/infra/perf/go/alerts"
+	"go.skia.org/infra/perf/go/annotation"
 	"go.skia.org/infra/perf/go/anomalies"
 	"go.skia.org/infra/perf/go/anomalies/cache"
 	"go.skia.org/infra/perf/go/builders"
@@ -48,14 +50,19 @@ import (
 	"go.skia.org/infra/perf/go/frontend/api"
 	perfgit "go.skia.org/infra/perf/go/git"
 	"go.skia.org/infra/perf/go/graphsshortcut"
+	"go.skia.org/infra/perf/go/livestream"
 	"go.skia.org/infra/perf/go/notify"
 	"go.skia.org/infra/perf/go/notifytypes"
 	"go.skia.org/infra/perf/go/pinpoint"
 	"go.skia.org/infra/perf/go/progress"
 	"go.skia.org/infra/perf/go/psrefresh"
+	"go.skia.org/infra/perf/go/querylimit"
 	"go.skia.org/infra/perf/go/regression"
 	"go.skia.org/infra/perf/go/regression/continuous"
+	"go.skia.org/infra/perf/go/regressiongroup"
+	"go.skia.org/infra/perf/go/report"
 	"go.skia.org/infra/perf/go/shortcut"
+	"go.skia.org/infra/perf/go/snapshot"
 	"go.skia.org/infra/perf/go/subscription"
 	"go.skia.org/infra/perf/go/tracestore"
 	"go.skia.org/infra/perf/go/tracing"
@@ -95,6 +102,11 @@ const (
 	// then the pod will restart. Queries to the CDB regressions table takes
 	// < 1 second.
 	livenessTimeout = 10 * time.Second
+
+	// liveStreamSubscriptionExpiration is how long the per-replica PubSub
+	// subscriptions used for live data streaming are kept after a replica
+	// stops.
+	liveStreamSubscriptionExpiration = 24 * time.Hour
 )
 
 var (
@@ -109,6 +121,22 @@ var (
 	// of the body element otherwise.
 	//go:embed cookieconsent.html
 	cookieConsentSnippet string
+
+	// expensiveQueryPaths are the endpoints that read from the TraceStore and
+	// are subject to the per-user query budget, if configured.
+	expensiveQueryPaths = []string{
+		"/_/cid/",
+		"/_/cluster/start",
+		"/_/count/",
+		"/_/details/",
+		"/_/dryrun/start",
+		"/_/export",
+		"/_/frame/start",
+		"/_/nextParamList/",
+		"/_/pivot",
+		"/_/shift/",
+		"/_/snapshot/new",
+	}
 )
 
 // Frontend is the server for the Perf web UI.
@@ -144,12 +172,35 @@ type Frontend struct {
 
 	userIssueStore userissue.Store
 
+	annotationStore annotation.Store
+
+	regressionGroupStore regressiongroup.Store
+
+	reportStore report.Store
+
+	// liveStream pushes newly ingested data points to clients. Nil if
+	// config.Config.EnableLiveDataStreaming is false.
+	liveStream *livestream.Server
+
+	// snapshotStore stores snapshots of graphs. Nil if
+	// config.Config.SnapshotPath is empty.
+	snapshotStore snapshot.Store
+
 	dryrunRequests *dryrun.Requests
 
 	paramsetRefresher psrefresh.ParamSetRefresher
 
 	dfBuilder dataframe.DataFrameBuilder
 
+	// alertDfBuilder is used for alert evaluation. It is the same as dfBuilder
+	// unless query limits are configured, in which case it has priority over
+	// dfBuilder.
+	alertDfBuilder dataframe.DataFrameBuilder
+
+	// queryRateLimiter limits the rate of expensive queries per user. Nil if
+	// query limits aren't enabled.
+	queryRateLimiter *querylimit.RateLimiter
+
 	trybotResultsLoader results.Loader
 
 	// distFileSystem is the ./dist directory of files produced by Bazel.
@@ -399,6 +450,13 @@ func (f *Frontend) initialize() {
 		sklog.Fatalf("Failed to initialize login: %s", err)
 	}
 
+	if limits := cfg.QueryConfig.LimitConfig; limits.Enabled {
+		f.queryRateLimiter, err = querylimit.NewRateLimiter(f.loginProvider, limits.QPS, limits.Burst)
+		if err != nil {
+			sklog.Fatalf("Failed to build query rate limiter: %s", err)
+		}
+	}
+
 	// Fix up resources dir values.
 	if f.flags.ResourcesDir == "" {
 		_, filename, _, _ := runtime.Caller(1)
@@ -442,6 +500,12 @@ func (f *Frontend) initialize() {
 		f.traceStore,
 		f.flags.NumParamSetsForQueries,
 		dfbuilder.Filtering(config.Config.FilterParentTraces))
+	f.alertDfBuilder = f.dfBuilder
+	if limits := config.Config.QueryConfig.LimitConfig; limits.Enabled && limits.MaxConcurrent > 0 {
+		gate := querylimit.NewGate(limits.MaxConcurrent, limits.ReservedForAlerts)
+		f.alertDfBuilder = querylimit.NewDataFrameBuilder(f.dfBuilder, gate, querylimit.High)
+		f.dfBuilder = querylimit.NewDataFrameBuilder(f.dfBuilder, gate, querylimit.Low)
+	}
 
 	sklog.Info("About to build paramset refresher.")
 
@@ -540,6 +604,41 @@ func (f *Frontend) initialize() {
 		sklog.Fatalf("Failed to build userissue.Store: %s", err)
 	}
 
+	f.annotationStore, err = builders.NewAnnotationStoreFromConfig(ctx, cfg)
+	if err != nil {
+		sklog.Fatalf("Failed to build annotation.Store: %s", err)
+	}
+
+	f.regressionGroupStore, err = builders.NewRegressionGroupStoreFromConfig(ctx, cfg)
+	if err != nil {
+		sklog.Fatalf("Failed to build regressiongroup.Store: %s", err)
+	}
+
+	f.reportStore, err = builders.NewReportStoreFromConfig(ctx, cfg)
+	if err != nil {
+		sklog.Fatalf("Failed to build report.Store: %s", err)
+	}
+
+	if cfg.EnableLiveDataStreaming {
+		f.liveStream = livestream.New()
+		// Every replica needs to see every event, so use a subscription per
+		// replica.
+		topicName := cfg.IngestionConfig.FileIngestionTopicName
+		expiration := liveStreamSubscriptionExpiration
+		liveStreamSub, err := sub.NewWithSubNameProviderAndExpirationPolicy(ctx, f.flags.Local, cfg.IngestionConfig.SourceConfig.Project, topicName, sub.NewBroadcastNameProvider(f.flags.Local, topicName), &expiration, 1)
+		if err != nil {
+			sklog.Fatalf("Failed to create PubSub subscription for live data streaming: %s", err)
+		}
+		f.liveStream.Start(ctx, liveStreamSub)
+	}
+
+	if cfg.SnapshotPath != "" {
+		f.snapshotStore, err = builders.NewSnapshotStoreFromConfig(ctx, cfg)
+		if err != nil {
+			sklog.Fatalf("Failed to build snapshot.Store: %s", err)
+		}
+	}
+
 	paramsProvider := newParamsetProvider(f.paramsetRefresher)
 
 	f.dryrunRequests = dryrun.New(f.perfGit, f.progressTracker, f.shortcutStore, f.dfBuilder, paramsProvider)
@@ -549,8 +648,8 @@ func (f *Frontend) initialize() {
 			for i := 0; i < f.flags.NumContinuousParallel; i++ {
 				// Start running continuous clustering looking for regressions.
 				time.Sleep(startClusterDelay)
-				c := continuous.New(f.perfGit, f.shortcutStore, f.configProvider, f.regStore, f.notifier, paramsProvider, *f.urlProvider,
-					f.dfBuilder, cfg, f.flags)
+				c := continuous.New(f.perfGit, f.shortcutStore, f.configProvider, f.regStore, f.annotationStore, f.regressionGroupStore, f.notifier, paramsProvider, *f.urlProvider,
+					f.alertDfBuilder, cfg, f.flags)
 				f.continuous = append(f.continuous, c)
 				go c.Run(context.Background())
 			}
@@ -587,6 +686,21 @@ func (f *Frontend) helpHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// skipGzipForLiveStream gzips and logs all responses except those of the live
+// data stream, since gzip buffers the response and Server-Sent Events must be
+// delivered as they are written.
+func skipGzipForLiveStream(h http.Handler) http.Handler {
+	gzipped := httputils.LoggingGzipRequestResponse(h)
+	logged := httputils.LoggingRequestResponse(h)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == livestream.URLPath {
+			logged.ServeHTTP(w, r)
+			return
+		}
+		gzipped.ServeHTTP(w, r)
+	})
+}
+
 // liveness is used by the front end service to verify that cockroachDB
 // connections are still working. /liveness handler is polled by
 // kubernetes probes. If the connection is down, the pod will restart
@@ -870,6 +984,9 @@ func (f *Frontend) GetHandler(allowedHosts []string) http.Handler {
 		local = f.flags.Local
 	}
 	router.Use(baseapp.SecurityMiddleware(ah, local, nil))
+	if f.queryRateLimiter != nil {
+		router.Use(f.queryRateLimiter.Middleware(expensiveQueryPaths...))
+	}
 
 	router.HandleFunc("/dist/*", f.makeDistHandler())
 
@@ -928,12 +1045,16 @@ func (f *Frontend) GetHandler(allowedHosts []string) http.Handler {
 	router.Get("/_/defaults/", f.defaultsHandler)
 	router.Get("/_/revision/", f.revisionHandler)
 
+	if f.liveStream != nil {
+		router.Get(livestream.URLPath, f.liveStream.ServeHTTP)
+	}
+
 	return router
 }
 
 // getFrontendApis returns a list of apis supported by the Frontend service.
 func (f *Frontend) getFrontendApis() []api.FrontendApi {
-	return []api.FrontendApi{
+	apis := []api.FrontendApi{
 		api.NewFavoritesApi(f.loginProvider, f.favStore),
 		api.NewAlertsApi(f.loginProvider, f.configProvider, f.alertStore, f.notifier, f.subStore, f.dryrunRequests),
 		api.NewAnomaliesApi(f.loginProvider, f.chromeperfClient, f.perfGit),
@@ -945,7 +1066,14 @@ func (f *Frontend) getFrontendApis() []api.FrontendApi {
 		api.NewSheriffConfigApi(f.loginProvider),
 		api.NewTriageApi(f.loginProvider, f.chromeperfClient, f.anomalyStore),
 		api.NewUserIssueApi(f.loginProvider, f.userIssueStore),
+		api.NewAnnotationsApi(f.loginProvider, f.annotationStore),
+		api.NewRegressionGroupsApi(f.loginProvider, f.regressionGroupStore, f.regStore),
+		api.NewReportsApi(f.loginProvider, f.reportStore),
+	}
+	if f.snapshotStore != nil {
+		apis = append(apis, api.NewSnapshotApi(f.loginProvider, f.snapshotStore, f.dfBuilder, f.perfGit, f.shortcutStore))
 	}
+	return apis
 }
 
 // Serve content on the configured endpoints.Serve.
@@ -961,7 +1089,7 @@ func (f *Frontend) Serve() {
 	}
 
 	var h http.Handler = f.GetHandler(config.Config.AllowedHosts)
-	h = httputils.LoggingGzipRequestResponse(h)
+	h = skipGzipForLiveStream(h)
 	if !f.flags.Local {
 		h = httputils.HealthzAndHTTPS(h)
 		// add liveness handler after https routing since these are applied in
@@ -2,6 +2,8 @@
 package frontend
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +15,8 @@ import (
 	"go.skia.org/infra/go/roles"
 	"go.skia.org/infra/go/testutils"
 	"go.skia.org/infra/perf/go/config"
+	"go.skia.org/infra/perf/go/ingestevents"
+	"go.skia.org/infra/perf/go/livestream"
 )
 
 func TestFrontend_ShouldInitAllHandlers(t *testing.T) {
@@ -101,3 +105,31 @@ func TestFrontend_SpecifiedRedirectUrl_Redirects(t *testing.T) {
 	require.Equal(t, http.StatusMovedPermanently, w.Result().StatusCode)
 	require.Equal(t, "/m/", w.Result().Header.Get("Location"))
 }
+
+func TestSkipGzipForLiveStream_LiveStreamRequest_EventsAreStreamed(t *testing.T) {
+	live := livestream.New()
+	s := httptest.NewServer(skipGzipForLiveStream(live))
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+livestream.URLPath+"?q=arch%3Dx86", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	require.Empty(t, resp.Header.Get("Content-Encoding"))
+
+	// The client is subscribed once the headers have been flushed.
+	live.Publish(&ingestevents.IngestEvent{
+		TraceIDs:     []string{",arch=x86,config=8888,"},
+		Values:       []float32{1.5},
+		CommitNumber: 12,
+	})
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "data: {\"commit_number\":12,\"points\":[{\"trace_id\":\",arch=x86,config=8888,\",\"value\":1.5}]}\n", line)
+}
@@ -0,0 +1,105 @@
+// Package dedupe detects files whose contents exactly match a file that was
+// already ingested recently, e.g. because an uploader retried an upload that
+// had in fact succeeded.
+package dedupe
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/go/now"
+)
+
+// unknownUploader is reported by Uploader for files that are not in a
+// directory.
+const unknownUploader = "unknown"
+
+// Hash returns the content hash of the given file contents.
+func Hash(contents []byte) string {
+	h := sha256.Sum256(contents)
+	return hex.EncodeToString(h[:])
+}
+
+// Uploader returns the name of the uploader of the given file, which by
+// convention is the name of the directory the file was uploaded to, e.g.
+// "Perf-Linux-Clang" for "gs://bucket/nano/2024/01/02/03/Perf-Linux-Clang/1.json".
+func Uploader(filename string) string {
+	dir := path.Base(path.Dir(filename))
+	if dir == "." || dir == "/" || dir == "" {
+		return unknownUploader
+	}
+	return dir
+}
+
+// entry records when a content hash was last ingested.
+type entry struct {
+	hash string
+	ts   time.Time
+}
+
+// Window remembers the content hashes of ingested files for a rolling window
+// of time. It is safe for concurrent use.
+type Window struct {
+	duration time.Duration
+
+	mutex sync.Mutex
+	// seen maps content hashes to the time they were last ingested.
+	seen map[string]time.Time
+	// entries is ordered by time, and may contain stale entries for hashes
+	// that were ingested again since.
+	entries []entry
+}
+
+// New returns a new Window which remembers hashes for the given duration.
+func New(duration time.Duration) *Window {
+	return &Window{
+		duration: duration,
+		seen:     map[string]time.Time{},
+	}
+}
+
+// Contains returns true if a file with the given content hash was ingested
+// within the window.
+func (w *Window) Contains(ctx context.Context, hash string) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.expire(now.Now(ctx))
+	_, ok := w.seen[hash]
+	return ok
+}
+
+// Add records that a file with the given content hash was ingested.
+func (w *Window) Add(ctx context.Context, hash string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	ts := now.Now(ctx)
+	w.expire(ts)
+	w.seen[hash] = ts
+	w.entries = append(w.entries, entry{hash: hash, ts: ts})
+}
+
+// Len returns the number of hashes in the window.
+func (w *Window) Len(ctx context.Context) int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.expire(now.Now(ctx))
+	return len(w.seen)
+}
+
+// expire drops all hashes that were last ingested before the start of the
+// window. The caller must hold the mutex.
+func (w *Window) expire(ts time.Time) {
+	start := ts.Add(-w.duration)
+	i := 0
+	for ; i < len(w.entries) && !w.entries[i].ts.After(start); i++ {
+		e := w.entries[i]
+		if w.seen[e.hash].Equal(e.ts) {
+			delete(w.seen, e.hash)
+		}
+	}
+	w.entries = w.entries[i:]
+}
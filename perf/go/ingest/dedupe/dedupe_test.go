@@ -0,0 +1,60 @@
+package dedupe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.skia.org/infra/go/now"
+)
+
+var startTime = time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+func TestHash_SameContents_SameHash(t *testing.T) {
+	assert.Equal(t, Hash([]byte(`{"git_hash": "abc"}`)), Hash([]byte(`{"git_hash": "abc"}`)))
+	assert.NotEqual(t, Hash([]byte(`{"git_hash": "abc"}`)), Hash([]byte(`{"git_hash": "abd"}`)))
+}
+
+func TestUploader_Success(t *testing.T) {
+	assert.Equal(t, "Perf-Linux-Clang", Uploader("gs://bucket/nano/2024/01/02/03/Perf-Linux-Clang/1.json"))
+	assert.Equal(t, "data", Uploader("./demo/data/demo_data_commit_1.json"))
+	assert.Equal(t, "unknown", Uploader("1.json"))
+	assert.Equal(t, "unknown", Uploader("/1.json"))
+}
+
+func TestWindow_AddedHash_ContainedUntilWindowExpires(t *testing.T) {
+	w := New(time.Hour)
+	ctx := atTime(startTime)
+	assert.False(t, w.Contains(ctx, "a"))
+
+	w.Add(ctx, "a")
+	assert.True(t, w.Contains(ctx, "a"))
+	assert.False(t, w.Contains(ctx, "b"))
+
+	assert.True(t, w.Contains(atTime(startTime.Add(59*time.Minute)), "a"))
+	assert.False(t, w.Contains(atTime(startTime.Add(time.Hour)), "a"))
+	assert.Equal(t, 0, w.Len(atTime(startTime.Add(time.Hour))))
+}
+
+func TestWindow_HashAddedAgain_WindowRestarts(t *testing.T) {
+	w := New(time.Hour)
+	w.Add(atTime(startTime), "a")
+	w.Add(atTime(startTime.Add(30*time.Minute)), "b")
+	w.Add(atTime(startTime.Add(45*time.Minute)), "a")
+
+	ctx := atTime(startTime.Add(time.Hour + time.Minute))
+	assert.True(t, w.Contains(ctx, "a"))
+	assert.True(t, w.Contains(ctx, "b"))
+	assert.Equal(t, 2, w.Len(ctx))
+
+	ctx = atTime(startTime.Add(time.Hour + 40*time.Minute))
+	assert.True(t, w.Contains(ctx, "a"))
+	assert.False(t, w.Contains(ctx, "b"))
+	assert.Equal(t, 1, w.Len(ctx))
+}
+
+func atTime(ts time.Time) context.Context {
+	return context.WithValue(context.Background(), now.ContextKey, ts)
+}
@@ -2,7 +2,9 @@
 package process
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"sync"
 	"time"
 
@@ -13,12 +15,14 @@ import (
 	"go.skia.org/infra/go/query"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
 	"golang.org/x/oauth2/google"
 
 	"go.skia.org/infra/perf/go/builders"
 	"go.skia.org/infra/perf/go/config"
 	"go.skia.org/infra/perf/go/file"
 	"go.skia.org/infra/perf/go/git"
+	"go.skia.org/infra/perf/go/ingest/dedupe"
 	"go.skia.org/infra/perf/go/ingest/parser"
 	"go.skia.org/infra/perf/go/ingestevents"
 	"go.skia.org/infra/perf/go/tracestore"
@@ -33,25 +37,29 @@ const writeRetries = 10
 // involves the database. For more complex requests use config.QueryMaxRuntime.
 const defaultDatabaseTimeout = 60 * time.Minute
 
-// sendPubSubEvent sends the unencoded params and paramset found in a single
-// ingested file to the PubSub topic specified in the selected Perf instances
-// configuration data.
-func sendPubSubEvent(ctx context.Context, pubSubClient *pubsub.Client, topicName string, params []paramtools.Params, paramset paramtools.ReadOnlyParamSet, filename string) error {
+// sendPubSubEvent sends the unencoded params, values and paramset found in a
+// single ingested file to the PubSub topic specified in the selected Perf
+// instances configuration data.
+func sendPubSubEvent(ctx context.Context, pubSubClient *pubsub.Client, topicName string, commitNumber types.CommitNumber, params []paramtools.Params, values []float32, paramset paramtools.ReadOnlyParamSet, filename string) error {
 	if topicName == "" {
 		return nil
 	}
 	traceIDs := make([]string, 0, len(params))
-	for _, p := range params {
+	traceValues := make([]float32, 0, len(params))
+	for i, p := range params {
 		key, err := query.MakeKey(p)
 		if err != nil {
 			continue
 		}
 		traceIDs = append(traceIDs, key)
+		traceValues = append(traceValues, values[i])
 	}
 	ie := &ingestevents.IngestEvent{
-		TraceIDs: traceIDs,
-		ParamSet: paramset,
-		Filename: filename,
+		TraceIDs:     traceIDs,
+		ParamSet:     paramset,
+		Filename:     filename,
+		CommitNumber: commitNumber,
+		Values:       traceValues,
 	}
 	body, err := ingestevents.CreatePubSubBody(ie)
 	if err != nil {
@@ -81,6 +89,9 @@ type workerInfo struct {
 	g                    git.Git
 	pubSubClient         *pubsub.Client
 	instanceConfig       *config.InstanceConfig
+
+	// dedupeWindow is nil if deduplication of ingested files is disabled.
+	dedupeWindow *dedupe.Window
 }
 
 // newWorker returns a new *workerInfo.
@@ -98,6 +109,7 @@ func newWorker(
 	g git.Git,
 	pubSubClient *pubsub.Client,
 	instanceConfig *config.InstanceConfig,
+	dedupeWindow *dedupe.Window,
 ) *workerInfo {
 	return &workerInfo{
 		filesReceived:        filesReceived,
@@ -113,7 +125,22 @@ func newWorker(
 		g:                    g,
 		pubSubClient:         pubSubClient,
 		instanceConfig:       instanceConfig,
+		dedupeWindow:         dedupeWindow,
+	}
+}
+
+// isDuplicate returns true if the contents of the file exactly match a file
+// that was ingested within the dedupe window. It also returns the content hash
+// of the file, and a copy of the file that can still be read.
+func (w *workerInfo) isDuplicate(ctx context.Context, f file.File) (bool, string, file.File, error) {
+	defer util.Close(f.Contents)
+	b, err := io.ReadAll(f.Contents)
+	if err != nil {
+		return false, "", f, skerr.Wrapf(err, "reading %q", f.Name)
 	}
+	f.Contents = io.NopCloser(bytes.NewReader(b))
+	hash := dedupe.Hash(b)
+	return w.dedupeWindow.Contains(ctx, hash), hash, f, nil
 }
 
 // processSingleFile parses a single incoming file and write the data to the
@@ -127,6 +154,28 @@ func (w *workerInfo) processSingleFile(f file.File) error {
 	sklog.Infof("Ingest received: %v", f)
 	w.filesReceived.Inc(1)
 
+	var contentHash string
+	if w.dedupeWindow != nil {
+		var isDuplicate bool
+		var err error
+		isDuplicate, contentHash, f, err = w.isDuplicate(ctx, f)
+		if err != nil {
+			sklog.Errorf("Failed to read %v: %s", f, err)
+			w.failedToParse.Inc(1)
+			nackMessageIfNecessary(w.dlEnabled, f)
+			return nil
+		}
+		if isDuplicate {
+			uploader := dedupe.Uploader(f.Name)
+			sklog.Infof("Skipping %q from %q, identical contents were already ingested.", f.Name, uploader)
+			metrics2.GetCounter("perfserver_ingest_duplicate_files", map[string]string{"uploader": uploader}).Inc(1)
+			if f.PubSubMsg != nil {
+				f.PubSubMsg.Ack()
+			}
+			return nil
+		}
+	}
+
 	// Parse the file.
 	params, values, gitHash, err := w.p.Parse(ctx, f)
 	if err != nil {
@@ -234,11 +283,14 @@ func (w *workerInfo) processSingleFile(f file.File) error {
 			f.PubSubMsg.Ack()
 			sklog.Debugf("Message acked: %v", f.PubSubMsg)
 		}
+		if w.dedupeWindow != nil {
+			w.dedupeWindow.Add(ctx, contentHash)
+		}
 		w.successfulWrite.Inc(1)
 		w.successfulWriteCount.Inc(int64(len(params)))
 	}
 
-	if err := sendPubSubEvent(ctx, w.pubSubClient, w.instanceConfig.IngestionConfig.FileIngestionTopicName, params, ps.Freeze(), f.Name); err != nil {
+	if err := sendPubSubEvent(ctx, w.pubSubClient, w.instanceConfig.IngestionConfig.FileIngestionTopicName, commitNumber, params, values, ps.Freeze(), f.Name); err != nil {
 		sklog.Errorf("Failed to send pubsub event: %s", err)
 	} else {
 		sklog.Info("FileIngestionTopicName pubsub message sent.")
@@ -247,7 +299,7 @@ func (w *workerInfo) processSingleFile(f file.File) error {
 }
 
 // worker ingests files that arrive on the given 'ch' channel.
-func worker(ctx context.Context, wg *sync.WaitGroup, g git.Git, store tracestore.TraceStore, ch <-chan file.File, pubSubClient *pubsub.Client, instanceConfig *config.InstanceConfig) {
+func worker(ctx context.Context, wg *sync.WaitGroup, g git.Git, store tracestore.TraceStore, ch <-chan file.File, pubSubClient *pubsub.Client, instanceConfig *config.InstanceConfig, dedupeWindow *dedupe.Window) {
 	// Metrics.
 	filesReceived := metrics2.GetCounter("perfserver_ingest_files_received")
 	failedToParse := metrics2.GetCounter("perfserver_ingest_failed_to_parse")
@@ -266,7 +318,7 @@ func worker(ctx context.Context, wg *sync.WaitGroup, g git.Git, store tracestore
 		return
 	}
 
-	workerInfo := newWorker(filesReceived, failedToParse, skipped, badGitHash, failedToWrite, successfulWrite, successfulWriteCount, dlEnabled, p, store, g, pubSubClient, instanceConfig)
+	workerInfo := newWorker(filesReceived, failedToParse, skipped, badGitHash, failedToWrite, successfulWrite, successfulWriteCount, dlEnabled, p, store, g, pubSubClient, instanceConfig, dedupeWindow)
 
 	for f := range ch {
 		if err := ctx.Err(); err != nil {
@@ -328,13 +380,20 @@ func Start(ctx context.Context, local bool, numParallelIngesters int, instanceCo
 	// Polling isn't needed because we call update on the repo if we find a git hash we don't recognize.
 	// g.StartBackgroundPolling(ctx, gitRefreshDuration)
 
+	// The dedupe window is shared by all workers so that duplicates are
+	// detected regardless of which worker handles them.
+	var dedupeWindow *dedupe.Window
+	if instanceConfig.IngestionConfig.DedupeWindow > 0 {
+		dedupeWindow = dedupe.New(time.Duration(instanceConfig.IngestionConfig.DedupeWindow))
+	}
+
 	sklog.Info("Waiting on files to process.")
 
 	var wg sync.WaitGroup
 
 	for i := 0; i < numParallelIngesters; i++ {
 		wg.Add(1)
-		go worker(ctx, &wg, g, store, ch, pubSubClient, instanceConfig)
+		go worker(ctx, &wg, g, store, ch, pubSubClient, instanceConfig, dedupeWindow)
 	}
 	wg.Wait()
 
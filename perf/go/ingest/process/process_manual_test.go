@@ -22,6 +22,7 @@ import (
 	"go.skia.org/infra/perf/go/config"
 	"go.skia.org/infra/perf/go/ingestevents"
 	"go.skia.org/infra/perf/go/sql/sqltest"
+	"go.skia.org/infra/perf/go/types"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 )
@@ -134,13 +135,15 @@ func TestSendPubSubEvent_Success(t *testing.T) {
 			assert.Equal(t, "somefile.json", ev.Filename)
 			assert.Equal(t, ps, ev.ParamSet)
 			assert.Contains(t, ev.TraceIDs, ",arch=x86,config=8888,")
+			assert.Equal(t, types.CommitNumber(12), ev.CommitNumber)
+			assert.Equal(t, []float32{1.5, 2.5}, ev.Values)
 			wg.Done()
 		})
 		require.NoError(t, err)
 	}()
 
 	// Now we can finally send the message.
-	err = sendPubSubEvent(ctx, client, instanceConfig.IngestionConfig.FileIngestionTopicName, params, ps, "somefile.json")
+	err = sendPubSubEvent(ctx, client, instanceConfig.IngestionConfig.FileIngestionTopicName, types.CommitNumber(12), params, []float32{1.5, 2.5}, ps, "somefile.json")
 	require.NoError(t, err)
 
 	// Wait for one message to be delivered.
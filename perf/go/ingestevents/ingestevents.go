@@ -13,6 +13,7 @@ import (
 	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/perf/go/types"
 )
 
 // IngestEvent is the PubSub body that is sent from the ingesters each time
@@ -26,6 +27,13 @@ type IngestEvent struct {
 
 	// Filename of the file ingested.
 	Filename string
+
+	// CommitNumber is the commit the values in the file were written at.
+	CommitNumber types.CommitNumber
+
+	// Values are the values written for each of TraceIDs, in the same order.
+	// Empty for events sent by older ingesters.
+	Values []float32
 }
 
 // CreatePubSubBody takes an IngestEvent and returns a byte slice that is a
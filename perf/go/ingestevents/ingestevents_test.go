@@ -33,6 +33,15 @@ func TestCreatePubSubBody(t *testing.T) {
 				ParamSet: paramtools.NewReadOnlyParamSet(paramtools.Params{"foo": "bar", "baz": "quux"}),
 			},
 		},
+		{
+			name: "with values",
+			args: &IngestEvent{
+				TraceIDs:     []string{",foo=bar,baz=quux,"},
+				ParamSet:     paramtools.NewReadOnlyParamSet(paramtools.Params{"foo": "bar", "baz": "quux"}),
+				CommitNumber: 12,
+				Values:       []float32{1.5},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
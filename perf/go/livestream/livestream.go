@@ -0,0 +1,195 @@
+// Package livestream pushes newly ingested data points to web clients using
+// Server-Sent Events, so that dashboards can update as soon as ingestion
+// completes instead of polling for new data.
+//
+// The points come from the IngestEvents the ingesters send to the
+// FileIngestionTopicName PubSub topic. Every frontend replica needs to see
+// every event, so each one should use its own subscription to the topic.
+package livestream
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"cloud.google.com/go/pubsub"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/query"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/sse"
+	"go.skia.org/infra/perf/go/ingestevents"
+	"go.skia.org/infra/perf/go/types"
+)
+
+const (
+	// URLPath is the path the Server-Sent Events endpoint is served on.
+	URLPath = "/_/live/"
+
+	// QueryParameterName is the URL query parameter that holds the trace
+	// queries a client subscribes to. It may be repeated.
+	QueryParameterName = "q"
+
+	// maxQueriesPerClient limits the number of queries a single client can
+	// subscribe to.
+	maxQueriesPerClient = 50
+)
+
+// Point is a single newly ingested value.
+type Point struct {
+	TraceID string  `json:"trace_id"`
+	Value   float32 `json:"value"`
+}
+
+// Update is the data sent in each Server-Sent Event, the points from a single
+// ingested file that match any of the client's queries.
+type Update struct {
+	CommitNumber types.CommitNumber `json:"commit_number"`
+	Points       []Point            `json:"points"`
+}
+
+// subscriber is a single connected client.
+type subscriber struct {
+	queries []*query.Query
+	updates chan Update
+}
+
+// matches returns the Points in the event that match any of the subscriber's
+// queries.
+func (s *subscriber) matches(ie *ingestevents.IngestEvent) []Point {
+	ret := []Point{}
+	for i, traceID := range ie.TraceIDs {
+		for _, q := range s.queries {
+			if q.Matches(traceID) {
+				ret = append(ret, Point{TraceID: traceID, Value: ie.Values[i]})
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// Server keeps track of all the connected clients and pushes Updates to them.
+type Server struct {
+	mutex       sync.Mutex
+	subscribers map[*subscriber]bool
+
+	connected metrics2.Int64Metric
+	dropped   metrics2.Counter
+}
+
+// New returns a new *Server.
+func New() *Server {
+	return &Server{
+		subscribers: map[*subscriber]bool{},
+		connected:   metrics2.GetInt64Metric("perf_livestream_connected_clients"),
+		dropped:     metrics2.GetCounter("perf_livestream_dropped_updates"),
+	}
+}
+
+// Start receives IngestEvents from the subscription and publishes them to the
+// connected clients. This function returns immediately, the events are
+// received until the context is cancelled.
+func (s *Server) Start(ctx context.Context, sub *pubsub.Subscription) {
+	go func() {
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+			err := sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+				// There's nothing to retry for a live stream, so always Ack.
+				defer msg.Ack()
+				ie, err := ingestevents.DecodePubSubBody(msg.Data)
+				if err != nil {
+					sklog.Errorf("Failed to decode ingestion PubSub event: %s", err)
+					return
+				}
+				s.Publish(ie)
+			})
+			if err != nil {
+				sklog.Errorf("Failed receiving pubsub message: %s", err)
+			}
+		}
+	}()
+}
+
+// Publish sends the points in the IngestEvent to every client with a
+// matching query.
+func (s *Server) Publish(ie *ingestevents.IngestEvent) {
+	if len(ie.Values) != len(ie.TraceIDs) {
+		// Sent by an ingester that predates Values being added to IngestEvent.
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for sub := range s.subscribers {
+		points := sub.matches(ie)
+		if len(points) == 0 {
+			continue
+		}
+		select {
+		case sub.updates <- Update{CommitNumber: ie.CommitNumber, Points: points}:
+		default:
+			s.dropped.Inc(1)
+		}
+	}
+}
+
+// subscribe adds a subscriber for the given queries. The returned function
+// must be called to remove it.
+func (s *Server) subscribe(queries []*query.Query) (*subscriber, func()) {
+	sub := &subscriber{
+		queries: queries,
+		updates: make(chan Update, sse.BufferSize),
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.subscribers[sub] = true
+	s.connected.Update(int64(len(s.subscribers)))
+	return sub, func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		delete(s.subscribers, sub)
+		s.connected.Update(int64(len(s.subscribers)))
+	}
+}
+
+// parseQueries parses the queries a client wants to subscribe to.
+func parseQueries(r *http.Request) ([]*query.Query, error) {
+	values := r.URL.Query()[QueryParameterName]
+	if len(values) == 0 {
+		return nil, skerr.Fmt("At least one query is required.")
+	}
+	if len(values) > maxQueriesPerClient {
+		return nil, skerr.Fmt("At most %d queries are allowed, got %d.", maxQueriesPerClient, len(values))
+	}
+	ret := make([]*query.Query, 0, len(values))
+	for _, value := range values {
+		q, err := query.NewFromString(value)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Invalid query %q", value)
+		}
+		if q.Empty() {
+			return nil, skerr.Fmt("Empty queries are not allowed.")
+		}
+		ret = append(ret, q)
+	}
+	return ret, nil
+}
+
+// ServeHTTP implements http.Handler. It streams Updates for the queries in the
+// QueryParameterName query parameters to the client until it disconnects.
+//
+// The response must not be gzipped, as that would buffer the events.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	queries, err := parseQueries(r)
+	if err != nil {
+		httputils.ReportError(w, err, "Invalid queries.", http.StatusBadRequest)
+		return
+	}
+	sub, unsubscribe := s.subscribe(queries)
+	defer unsubscribe()
+
+	sse.Serve(w, r, sub.updates)
+}
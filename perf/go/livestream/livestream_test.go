@@ -0,0 +1,146 @@
+package livestream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/query"
+	"go.skia.org/infra/go/sse"
+	"go.skia.org/infra/perf/go/ingestevents"
+)
+
+func newIngestEventForTest() *ingestevents.IngestEvent {
+	return &ingestevents.IngestEvent{
+		TraceIDs:     []string{",arch=x86,config=8888,", ",arch=arm,config=565,"},
+		CommitNumber: 12,
+		Values:       []float32{1.5, 2.5},
+	}
+}
+
+func subscribeForTest(t *testing.T, s *Server, queries ...string) *subscriber {
+	parsed := []*query.Query{}
+	for _, q := range queries {
+		p, err := query.NewFromString(q)
+		require.NoError(t, err)
+		parsed = append(parsed, p)
+	}
+	sub, unsubscribe := s.subscribe(parsed)
+	t.Cleanup(unsubscribe)
+	return sub
+}
+
+func TestPublish_MatchingQuery_SendsOnlyMatchingPoints(t *testing.T) {
+	s := New()
+	sub := subscribeForTest(t, s, "arch=arm")
+
+	s.Publish(newIngestEventForTest())
+
+	require.Len(t, sub.updates, 1)
+	assert.Equal(t, Update{
+		CommitNumber: 12,
+		Points:       []Point{{TraceID: ",arch=arm,config=565,", Value: 2.5}},
+	}, <-sub.updates)
+}
+
+func TestPublish_PointMatchesMultipleQueries_IsSentOnce(t *testing.T) {
+	s := New()
+	sub := subscribeForTest(t, s, "arch=arm", "config=565")
+
+	s.Publish(newIngestEventForTest())
+
+	require.Len(t, sub.updates, 1)
+	assert.Len(t, (<-sub.updates).Points, 1)
+}
+
+func TestPublish_NoMatchingQuery_SendsNothing(t *testing.T) {
+	s := New()
+	sub := subscribeForTest(t, s, "arch=riscv")
+
+	s.Publish(newIngestEventForTest())
+
+	assert.Empty(t, sub.updates)
+}
+
+func TestPublish_EventWithoutValues_SendsNothing(t *testing.T) {
+	s := New()
+	sub := subscribeForTest(t, s, "arch=arm")
+	ie := newIngestEventForTest()
+	ie.Values = nil
+
+	s.Publish(ie)
+
+	assert.Empty(t, sub.updates)
+}
+
+func TestPublish_SlowClient_UpdatesAreDroppedWithoutBlocking(t *testing.T) {
+	s := New()
+	sub := subscribeForTest(t, s, "arch=arm")
+
+	for i := 0; i < sse.BufferSize+1; i++ {
+		s.Publish(newIngestEventForTest())
+	}
+
+	assert.Len(t, sub.updates, sse.BufferSize)
+}
+
+func TestServeHTTP_NoQuery_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", URLPath, nil)
+
+	New().ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServeHTTP_EmptyQuery_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", URLPath+"?q=", nil)
+
+	New().ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServeHTTP_MatchingEventPublished_ClientReceivesUpdate(t *testing.T) {
+	s := New()
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+URLPath+"?q="+url.QueryEscape("arch=x86"), nil)
+	require.NoError(t, err)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// Wait for the client to be subscribed before publishing.
+	require.Eventually(t, func() bool {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		return len(s.subscribers) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	s.Publish(newIngestEventForTest())
+
+	scanner := bufio.NewScanner(resp.Body)
+	require.True(t, scanner.Scan())
+	line := scanner.Text()
+	require.True(t, strings.HasPrefix(line, "data: "))
+	var update Update
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &update))
+	assert.Equal(t, Update{
+		CommitNumber: 12,
+		Points:       []Point{{TraceID: ",arch=x86,config=8888,", Value: 1.5}},
+	}, update)
+}
@@ -0,0 +1,311 @@
+// Package issuesync keeps the triage status of regressions in sync with the
+// state of the issues that were filed for them.
+//
+// Regressions with a linked issue are those whose ClusterSummary has a
+// NotificationID, which is the issue id when notifications are sent to the
+// issue tracker. A Syncer periodically compares each such regression with its
+// issue and updates whichever side is out of date:
+//
+//   - An open or fixed issue means the regression is a real one, so an
+//     untriaged regression is triaged as Negative.
+//   - An issue closed without a fix, e.g. as obsolete or intended behavior,
+//     means the bug is no longer being tracked, so a Negative regression goes
+//     back to Untriaged for someone to look at again.
+//   - A regression triaged as Positive closes its open issue as intended
+//     behavior, and a regression triaged as Negative re-opens its issue if it
+//     was closed without a fix.
+//
+// If both sides changed since the last sync then the issue wins. The time of
+// the last sync is only kept in memory, so the first sync after a restart
+// can't tell which side changed and only syncs untriaged regressions, where
+// the issue always wins.
+package issuesync
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.skia.org/infra/go/issuetracker/v1"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/perf/go/clustering2"
+	perfgit "go.skia.org/infra/perf/go/git"
+	"go.skia.org/infra/perf/go/regression"
+	"go.skia.org/infra/perf/go/types"
+)
+
+const (
+	// commitWindow is the number of most recent commits whose regressions are
+	// synced.
+	commitWindow = 5000
+
+	// syncTimeout is the maximum amount of time a single sync can take.
+	syncTimeout = 10 * time.Minute
+)
+
+// Issue statuses, as used by the issue tracker.
+const (
+	StatusNew              = "NEW"
+	StatusAssigned         = "ASSIGNED"
+	StatusAccepted         = "ACCEPTED"
+	StatusFixed            = "FIXED"
+	StatusVerified         = "VERIFIED"
+	StatusIntendedBehavior = "INTENDED_BEHAVIOR"
+)
+
+// Issue is the state of a single issue.
+type Issue struct {
+	// Status is the status of the issue, such as StatusNew.
+	Status string
+
+	// Modified is the last time the issue was modified.
+	Modified time.Time
+}
+
+// IssueTracker is the part of the issue tracker API needed to sync issues.
+type IssueTracker interface {
+	// GetIssue returns the state of the issue with the given id.
+	GetIssue(ctx context.Context, id string) (*Issue, error)
+
+	// SetStatus changes the status of the issue and adds the comment to it.
+	SetStatus(ctx context.Context, id, status, comment string) error
+}
+
+// issueKind classifies issue statuses.
+type issueKind int
+
+const (
+	issueOpen issueKind = iota
+	issueFixed
+	issueClosedNotFixed
+)
+
+func kindFromStatus(status string) issueKind {
+	switch status {
+	case StatusNew, StatusAssigned, StatusAccepted:
+		return issueOpen
+	case StatusFixed, StatusVerified:
+		return issueFixed
+	default:
+		return issueClosedNotFixed
+	}
+}
+
+// change is what needs to happen to bring a regression and its issue back in
+// sync. An empty field means that side doesn't need to change.
+type change struct {
+	triage      regression.Status
+	issueStatus string
+}
+
+// reconcile returns the change needed to sync a regression triaged as status
+// with the issue. issueChanged is true if the issue was modified since the
+// last sync.
+func reconcile(status regression.Status, issue *Issue, issueChanged bool) change {
+	kind := kindFromStatus(issue.Status)
+
+	// An untriaged regression says nothing about what Perf users think, so
+	// the issue is used in that case too.
+	if issueChanged || status == regression.Untriaged {
+		switch kind {
+		case issueOpen, issueFixed:
+			if status != regression.Negative {
+				return change{triage: regression.Negative}
+			}
+		case issueClosedNotFixed:
+			if status == regression.Negative {
+				return change{triage: regression.Untriaged}
+			}
+		}
+		return change{}
+	}
+
+	switch {
+	case status == regression.Positive && kind == issueOpen:
+		return change{issueStatus: StatusIntendedBehavior}
+	case status == regression.Negative && kind == issueClosedNotFixed:
+		return change{issueStatus: StatusNew}
+	}
+	return change{}
+}
+
+// Syncer syncs the triage status of regressions with their issues.
+type Syncer struct {
+	regStore regression.Store
+	git      perfgit.Git
+	tracker  IssueTracker
+
+	// lastSync is the time the last successful sync started.
+	lastSync time.Time
+
+	regressionsUpdated metrics2.Counter
+	issuesUpdated      metrics2.Counter
+	syncFailures       metrics2.Counter
+}
+
+// New returns a new *Syncer.
+func New(regStore regression.Store, git perfgit.Git, tracker IssueTracker) *Syncer {
+	return &Syncer{
+		regStore:           regStore,
+		git:                git,
+		tracker:            tracker,
+		regressionsUpdated: metrics2.GetCounter("perf_issuesync_regressions_updated"),
+		issuesUpdated:      metrics2.GetCounter("perf_issuesync_issues_updated"),
+		syncFailures:       metrics2.GetCounter("perf_issuesync_failures"),
+	}
+}
+
+// RunPeriodicSync runs a goroutine that syncs regressions with their issues
+// every period.
+func (s *Syncer) RunPeriodicSync(period time.Duration) {
+	go func() {
+		for range time.Tick(period) {
+			ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+			if err := s.SyncOnce(ctx); err != nil {
+				s.syncFailures.Inc(1)
+				sklog.Errorf("Failed to sync regressions with issues: %s", err)
+			}
+			cancel()
+		}
+	}()
+}
+
+// SyncOnce syncs the regressions found in the most recent commits with their
+// issues.
+//
+// Failures for a single regression are logged and don't stop the sync.
+func (s *Syncer) SyncOnce(ctx context.Context) error {
+	start := now.Now(ctx)
+	end, err := s.git.CommitNumberFromTime(ctx, time.Time{})
+	if err != nil {
+		return skerr.Wrapf(err, "finding the most recent commit")
+	}
+	begin := end - commitWindow
+	if begin < 0 {
+		begin = 0
+	}
+	regressions, err := s.regStore.Range(ctx, begin, end)
+	if err != nil {
+		return skerr.Wrapf(err, "loading regressions in [%d, %d]", begin, end)
+	}
+	for commitNumber, all := range regressions {
+		for alertID, reg := range all.ByAlertID {
+			if err := s.syncCluster(ctx, commitNumber, alertID, reg.High, reg.HighStatus, s.regStore.TriageHigh); err != nil {
+				s.syncFailures.Inc(1)
+				sklog.Errorf("Failed to sync high regression at commit %d for alert %s: %s", commitNumber, alertID, err)
+			}
+			if err := s.syncCluster(ctx, commitNumber, alertID, reg.Low, reg.LowStatus, s.regStore.TriageLow); err != nil {
+				s.syncFailures.Inc(1)
+				sklog.Errorf("Failed to sync low regression at commit %d for alert %s: %s", commitNumber, alertID, err)
+			}
+		}
+	}
+	s.lastSync = start
+	return nil
+}
+
+// triageFunc is the signature of regression.Store.TriageHigh and TriageLow.
+type triageFunc func(ctx context.Context, commitNumber types.CommitNumber, alertID string, tr regression.TriageStatus) error
+
+// syncCluster syncs a single direction of a regression with its issue.
+func (s *Syncer) syncCluster(ctx context.Context, commitNumber types.CommitNumber, alertID string, cl *clustering2.ClusterSummary, tr regression.TriageStatus, triage triageFunc) error {
+	if cl == nil || cl.NotificationID == "" {
+		return nil
+	}
+	// NotificationID is only an issue id when notifications go to the issue
+	// tracker, e.g. it is a thread id for email notifications.
+	if _, err := strconv.ParseInt(cl.NotificationID, 10, 64); err != nil {
+		return nil
+	}
+	if s.lastSync.IsZero() && tr.Status != regression.Untriaged {
+		return nil
+	}
+	issue, err := s.tracker.GetIssue(ctx, cl.NotificationID)
+	if err != nil {
+		return skerr.Wrapf(err, "loading issue %s", cl.NotificationID)
+	}
+	c := reconcile(tr.Status, issue, issue.Modified.After(s.lastSync))
+	if c.triage != "" {
+		err := triage(ctx, commitNumber, alertID, regression.TriageStatus{
+			Status:  c.triage,
+			Message: fmt.Sprintf("Synced from issue %s, which is %s.", cl.NotificationID, issue.Status),
+		})
+		if err != nil {
+			return skerr.Wrapf(err, "triaging regression for issue %s", cl.NotificationID)
+		}
+		s.regressionsUpdated.Inc(1)
+	}
+	if c.issueStatus != "" {
+		comment := fmt.Sprintf("The regression was triaged as %s in Perf.", tr.Status)
+		if tr.Message != "" {
+			comment += "\n\n" + tr.Message
+		}
+		if err := s.tracker.SetStatus(ctx, cl.NotificationID, c.issueStatus, comment); err != nil {
+			return skerr.Wrapf(err, "updating issue %s", cl.NotificationID)
+		}
+		s.issuesUpdated.Inc(1)
+	}
+	return nil
+}
+
+// issueTracker implements IssueTracker using the issue tracker API.
+type issueTracker struct {
+	client *issuetracker.Service
+}
+
+// NewIssueTracker returns an IssueTracker that uses the given client.
+func NewIssueTracker(client *issuetracker.Service) IssueTracker {
+	return issueTracker{client: client}
+}
+
+// GetIssue implements IssueTracker.
+func (t issueTracker) GetIssue(ctx context.Context, id string) (*Issue, error) {
+	issueID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "invalid issue id %q", id)
+	}
+	resp, err := t.client.Issues.Get(issueID).Context(ctx).Do()
+	if err != nil {
+		return nil, skerr.Wrapf(err, "getting issue %d", issueID)
+	}
+	ret := &Issue{}
+	if resp.IssueState != nil {
+		ret.Status = resp.IssueState.Status
+	}
+	if resp.ModifiedTime != "" {
+		ret.Modified, err = time.Parse(time.RFC3339, resp.ModifiedTime)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "parsing modified time of issue %d", issueID)
+		}
+	}
+	return ret, nil
+}
+
+// SetStatus implements IssueTracker.
+func (t issueTracker) SetStatus(ctx context.Context, id, status, comment string) error {
+	issueID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return skerr.Wrapf(err, "invalid issue id %q", id)
+	}
+	_, err = t.client.Issues.Modify(issueID, &issuetracker.ModifyIssueRequest{
+		Add: &issuetracker.IssueState{
+			Status: status,
+		},
+		IssueComment: &issuetracker.IssueComment{
+			Comment:        comment,
+			FormattingMode: "MARKDOWN",
+		},
+		AddMask: "status",
+	}).Context(ctx).Do()
+	if err != nil {
+		return skerr.Wrapf(err, "modifying issue %d", issueID)
+	}
+	return nil
+}
+
+// Confirm issueTracker implements IssueTracker.
+var _ IssueTracker = issueTracker{}
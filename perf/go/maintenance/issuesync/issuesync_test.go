@@ -0,0 +1,179 @@
+package issuesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/perf/go/clustering2"
+	gitmocks "go.skia.org/infra/perf/go/git/mocks"
+	"go.skia.org/infra/perf/go/regression"
+	regressionmocks "go.skia.org/infra/perf/go/regression/mocks"
+	"go.skia.org/infra/perf/go/types"
+)
+
+func TestReconcile(t *testing.T) {
+	test := func(name string, status regression.Status, issueStatus string, issueChanged bool, expected change) {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, reconcile(status, &Issue{Status: issueStatus}, issueChanged))
+		})
+	}
+	test("untriaged, open issue, triaged as negative", regression.Untriaged, StatusNew, false, change{triage: regression.Negative})
+	test("untriaged, fixed issue, triaged as negative", regression.Untriaged, StatusFixed, false, change{triage: regression.Negative})
+	test("untriaged, issue closed not fixed, no change", regression.Untriaged, "OBSOLETE", true, change{})
+	test("negative, issue closed not fixed, untriaged", regression.Negative, "OBSOLETE", true, change{triage: regression.Untriaged})
+	test("negative, open issue, no change", regression.Negative, StatusAssigned, true, change{})
+	test("positive, issue reopened, triaged as negative", regression.Positive, StatusNew, true, change{triage: regression.Negative})
+	test("positive, issue closed not fixed, no change", regression.Positive, StatusIntendedBehavior, true, change{})
+	test("positive, unchanged open issue, issue closed", regression.Positive, StatusAccepted, false, change{issueStatus: StatusIntendedBehavior})
+	test("positive, unchanged fixed issue, no change", regression.Positive, StatusVerified, false, change{})
+	test("negative, unchanged issue closed not fixed, issue reopened", regression.Negative, "OBSOLETE", false, change{issueStatus: StatusNew})
+	test("negative, unchanged open issue, no change", regression.Negative, StatusNew, false, change{})
+}
+
+// fakeIssueTracker implements IssueTracker.
+type fakeIssueTracker struct {
+	issues map[string]*Issue
+}
+
+func (f *fakeIssueTracker) GetIssue(ctx context.Context, id string) (*Issue, error) {
+	issue, ok := f.issues[id]
+	if !ok {
+		return nil, skerr.Fmt("issue %s not found", id)
+	}
+	return issue, nil
+}
+
+func (f *fakeIssueTracker) SetStatus(ctx context.Context, id, status, comment string) error {
+	issue, err := f.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	issue.Status = status
+	issue.Modified = now.Now(ctx)
+	return nil
+}
+
+const (
+	alertID      = "1"
+	commitNumber = types.CommitNumber(10)
+)
+
+var lastSyncTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func setUp(t *testing.T, reg *regression.Regression, tracker *fakeIssueTracker) (*Syncer, *regressionmocks.Store) {
+	g := gitmocks.NewGit(t)
+	g.On("CommitNumberFromTime", mock.Anything, time.Time{}).Return(commitNumber, nil)
+	store := regressionmocks.NewStore(t)
+	store.On("Range", mock.Anything, types.CommitNumber(0), commitNumber).Return(map[types.CommitNumber]*regression.AllRegressionsForCommit{
+		commitNumber: {ByAlertID: map[string]*regression.Regression{alertID: reg}},
+	}, nil)
+	s := New(store, g, tracker)
+	s.lastSync = lastSyncTime
+	return s, store
+}
+
+func TestSyncOnce_IssueClosedAsObsolete_NegativeRegressionIsUntriaged(t *testing.T) {
+	ctx := now.TimeTravelingContext(lastSyncTime.Add(time.Hour))
+	tracker := &fakeIssueTracker{issues: map[string]*Issue{
+		"123": {Status: "OBSOLETE", Modified: lastSyncTime.Add(time.Minute)},
+	}}
+	s, store := setUp(t, &regression.Regression{
+		High:       &clustering2.ClusterSummary{NotificationID: "123"},
+		HighStatus: regression.TriageStatus{Status: regression.Negative},
+	}, tracker)
+	store.On("TriageHigh", mock.Anything, commitNumber, alertID, regression.TriageStatus{
+		Status:  regression.Untriaged,
+		Message: "Synced from issue 123, which is OBSOLETE.",
+	}).Return(nil)
+
+	require.NoError(t, s.SyncOnce(ctx))
+	assert.Equal(t, lastSyncTime.Add(time.Hour), s.lastSync)
+}
+
+func TestSyncOnce_RegressionTriagedPositive_IssueIsClosed(t *testing.T) {
+	ctx := now.TimeTravelingContext(lastSyncTime.Add(time.Hour))
+	tracker := &fakeIssueTracker{issues: map[string]*Issue{
+		"123": {Status: StatusNew, Modified: lastSyncTime.Add(-time.Hour)},
+	}}
+	s, _ := setUp(t, &regression.Regression{
+		Low:       &clustering2.ClusterSummary{NotificationID: "123"},
+		LowStatus: regression.TriageStatus{Status: regression.Positive},
+	}, tracker)
+
+	require.NoError(t, s.SyncOnce(ctx))
+	assert.Equal(t, StatusIntendedBehavior, tracker.issues["123"].Status)
+}
+
+func TestSyncOnce_RegressionWithoutIssue_IsSkipped(t *testing.T) {
+	ctx := now.TimeTravelingContext(lastSyncTime.Add(time.Hour))
+	s, _ := setUp(t, &regression.Regression{
+		High:       &clustering2.ClusterSummary{},
+		HighStatus: regression.TriageStatus{Status: regression.Untriaged},
+	}, &fakeIssueTracker{})
+
+	require.NoError(t, s.SyncOnce(ctx))
+}
+
+func TestSyncOnce_IssueFailsToLoad_SyncStillSucceeds(t *testing.T) {
+	ctx := now.TimeTravelingContext(lastSyncTime.Add(time.Hour))
+	s, _ := setUp(t, &regression.Regression{
+		High:       &clustering2.ClusterSummary{NotificationID: "404"},
+		HighStatus: regression.TriageStatus{Status: regression.Untriaged},
+	}, &fakeIssueTracker{})
+
+	require.NoError(t, s.SyncOnce(ctx))
+}
+
+func TestSyncOnce_FirstSyncAfterRestart_PositiveRegressionWithOpenIssueIsUnchanged(t *testing.T) {
+	ctx := now.TimeTravelingContext(lastSyncTime.Add(time.Hour))
+	tracker := &fakeIssueTracker{issues: map[string]*Issue{
+		"123": {Status: StatusNew, Modified: lastSyncTime},
+	}}
+	s, _ := setUp(t, &regression.Regression{
+		High:       &clustering2.ClusterSummary{NotificationID: "123"},
+		HighStatus: regression.TriageStatus{Status: regression.Positive},
+	}, tracker)
+	s.lastSync = time.Time{}
+
+	// The store mock fails the test if the regression is triaged.
+	require.NoError(t, s.SyncOnce(ctx))
+	assert.Equal(t, StatusNew, tracker.issues["123"].Status)
+	assert.Equal(t, lastSyncTime.Add(time.Hour), s.lastSync)
+
+	// Once a sync has completed the issue didn't change since, so Perf wins.
+	require.NoError(t, s.SyncOnce(now.TimeTravelingContext(lastSyncTime.Add(2*time.Hour))))
+	assert.Equal(t, StatusIntendedBehavior, tracker.issues["123"].Status)
+}
+
+func TestSyncOnce_FirstSyncAfterRestart_UntriagedRegressionIsTriagedFromIssue(t *testing.T) {
+	ctx := now.TimeTravelingContext(lastSyncTime.Add(time.Hour))
+	tracker := &fakeIssueTracker{issues: map[string]*Issue{
+		"123": {Status: StatusAssigned, Modified: lastSyncTime},
+	}}
+	s, store := setUp(t, &regression.Regression{
+		Low:       &clustering2.ClusterSummary{NotificationID: "123"},
+		LowStatus: regression.TriageStatus{Status: regression.Untriaged},
+	}, tracker)
+	s.lastSync = time.Time{}
+	store.On("TriageLow", mock.Anything, commitNumber, alertID, regression.TriageStatus{
+		Status:  regression.Negative,
+		Message: "Synced from issue 123, which is ASSIGNED.",
+	}).Return(nil)
+
+	require.NoError(t, s.SyncOnce(ctx))
+}
+
+func TestSyncCluster_NotificationIDIsNotAnIssueID_IsSkipped(t *testing.T) {
+	ctx := now.TimeTravelingContext(lastSyncTime.Add(time.Hour))
+	s := New(regressionmocks.NewStore(t), gitmocks.NewGit(t), &fakeIssueTracker{})
+	s.lastSync = lastSyncTime
+
+	err := s.syncCluster(ctx, commitNumber, alertID, &clustering2.ClusterSummary{NotificationID: "<thread-id@example.com>"}, regression.TriageStatus{Status: regression.Untriaged}, nil)
+	require.NoError(t, err)
+}
@@ -8,10 +8,14 @@ import (
 	"go.skia.org/infra/go/luciconfig"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/perf/go/alerts"
 	"go.skia.org/infra/perf/go/builders"
 	"go.skia.org/infra/perf/go/config"
 	"go.skia.org/infra/perf/go/dfbuilder"
 	"go.skia.org/infra/perf/go/maintenance/deletion"
+	"go.skia.org/infra/perf/go/maintenance/issuesync"
+	"go.skia.org/infra/perf/go/notify"
+	"go.skia.org/infra/perf/go/notifytypes"
 	"go.skia.org/infra/perf/go/psrefresh"
 	"go.skia.org/infra/perf/go/regression/migration"
 	sheriffconfig "go.skia.org/infra/perf/go/sheriffconfig/service"
@@ -40,6 +44,9 @@ const (
 
 	// Size of the batch of shortcuts to delete.
 	deletionBatchSize = 1000
+
+	// How often to sync the triage status of regressions with their issues.
+	issueSyncPeriod = time.Minute * 15
 )
 
 // Start all the long running processes. This function does not return if all
@@ -136,5 +143,28 @@ func Start(ctx context.Context, flags config.MaintenanceFlags, instanceConfig *c
 		deleter.RunPeriodicDeletion(deletionPeriod, deletionBatchSize)
 	}
 
+	if flags.SyncIssueTriage {
+		if instanceConfig.NotifyConfig.Notifications != notifytypes.MarkdownIssueTracker {
+			return skerr.Fmt("Syncing issue triage requires notifications to be sent to the issue tracker, got %q.", instanceConfig.NotifyConfig.Notifications)
+		}
+		alertStore, err := builders.NewAlertStoreFromConfig(ctx, flags.Local, instanceConfig)
+		if err != nil {
+			return skerr.Wrapf(err, "Failed to build AlertStore.")
+		}
+		configProvider, err := alerts.NewConfigProvider(ctx, alertStore, 600)
+		if err != nil {
+			return skerr.Wrapf(err, "Failed to build alerts.ConfigProvider.")
+		}
+		regStore, err := builders.NewRegressionStoreFromConfig(ctx, flags.Local, instanceConfig, configProvider)
+		if err != nil {
+			return skerr.Wrapf(err, "Failed to build regression.Store.")
+		}
+		client, err := notify.NewIssueTrackerService(ctx, &instanceConfig.NotifyConfig)
+		if err != nil {
+			return skerr.Wrapf(err, "Failed to build issue tracker client.")
+		}
+		issuesync.New(regStore, g, issuesync.NewIssueTracker(client)).RunPeriodicSync(issueSyncPeriod)
+	}
+
 	select {}
 }
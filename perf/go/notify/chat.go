@@ -0,0 +1,109 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/perf/go/alerts"
+)
+
+// chatMessage is the body of a message posted to a Google Chat webhook.
+type chatMessage struct {
+	Text string `json:"text"`
+}
+
+// ChatTransport implements Transport by posting to Google Chat webhooks.
+//
+// The webhook is the Target of the first alerts.ChatSink in the Alert's
+// NotificationSinks. All the messages about a single regression are posted to
+// the same thread.
+type ChatTransport struct {
+	client *http.Client
+}
+
+// NewChatTransport returns a new ChatTransport.
+func NewChatTransport() ChatTransport {
+	return ChatTransport{
+		client: httputils.NewTimeoutClient(),
+	}
+}
+
+// webhook returns the URL of the chat webhook for the alert.
+func webhook(alert *alerts.Alert) (string, error) {
+	sinks, err := alert.Sinks()
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	for _, sink := range sinks {
+		if sink.Type == alerts.ChatSink {
+			return sink.Target, nil
+		}
+	}
+	return "", skerr.Fmt("No notification sent. No chat webhook set for alert #%s", alert.IDAsString)
+}
+
+// post sends the message to the alert's webhook in the given thread.
+func (c ChatTransport) post(ctx context.Context, alert *alerts.Alert, threadKey, subject, body string) error {
+	u, err := webhook(alert)
+	if err != nil {
+		return err
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return skerr.Wrapf(err, "parsing chat webhook for alert #%s", alert.IDAsString)
+	}
+	q := parsed.Query()
+	q.Set("threadKey", threadKey)
+	q.Set("messageReplyOption", "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD")
+	parsed.RawQuery = q.Encode()
+
+	b, err := json.Marshal(chatMessage{
+		Text: "*" + subject + "*\n\n" + body,
+	})
+	if err != nil {
+		return skerr.Wrapf(err, "encoding chat message")
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", parsed.String(), bytes.NewReader(b))
+	if err != nil {
+		return skerr.Wrapf(err, "creating chat request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return skerr.Wrapf(err, "posting chat message")
+	}
+	defer util.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return skerr.Fmt("posting chat message: got status %d %s", resp.StatusCode, resp.Status)
+	}
+	return nil
+}
+
+// SendNewRegression implements Transport.
+func (c ChatTransport) SendNewRegression(ctx context.Context, alert *alerts.Alert, body, subject string) (string, error) {
+	threadKey := uuid.New().String()
+	if err := c.post(ctx, alert, threadKey, subject, body); err != nil {
+		return "", skerr.Wrap(err)
+	}
+	return threadKey, nil
+}
+
+// SendRegressionMissing implements Transport.
+func (c ChatTransport) SendRegressionMissing(ctx context.Context, threadingReference string, alert *alerts.Alert, body, subject string) error {
+	if threadingReference == "" {
+		threadingReference = uuid.New().String()
+	}
+	return skerr.Wrap(c.post(ctx, alert, threadingReference, subject, body))
+}
+
+// UpdateRegressionNotification implements Transport.
+func (c ChatTransport) UpdateRegressionNotification(ctx context.Context, alert *alerts.Alert, body, notificationId string) error {
+	return nil
+}
@@ -24,8 +24,9 @@ type IssueTrackerTransport struct {
 	sendRegressionMissingFail metrics2.Counter
 }
 
-// NewIssueTrackerTransport returns a new IssueTrackerTransport.
-func NewIssueTrackerTransport(ctx context.Context, cfg *config.NotifyConfig) (*IssueTrackerTransport, error) {
+// NewIssueTrackerService returns a new issuetracker.Service authorized with
+// the API key given in the NotifyConfig.
+func NewIssueTrackerService(ctx context.Context, cfg *config.NotifyConfig) (*issuetracker.Service, error) {
 	secretClient, err := secret.NewClient(ctx)
 	if err != nil {
 		return nil, skerr.Wrapf(err, "creating secret client")
@@ -44,6 +45,15 @@ func NewIssueTrackerTransport(ctx context.Context, cfg *config.NotifyConfig) (*I
 		return nil, skerr.Wrapf(err, "creating issuetracker service")
 	}
 	c.BasePath = "https://issuetracker.googleapis.com"
+	return c, nil
+}
+
+// NewIssueTrackerTransport returns a new IssueTrackerTransport.
+func NewIssueTrackerTransport(ctx context.Context, cfg *config.NotifyConfig) (*IssueTrackerTransport, error) {
+	c, err := NewIssueTrackerService(ctx, cfg)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
 
 	return &IssueTrackerTransport{
 		client:                    c,
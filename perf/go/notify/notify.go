@@ -230,17 +230,32 @@ func New(ctx context.Context, cfg *config.NotifyConfig, URL, commitRangeURITempl
 		}
 	}
 
+	// Email and chat sinks are available on every instance, issue tracker
+	// sinks need the issue tracker to be configured.
+	markdownFormatter, err := NewMarkdownFormatter(commitRangeURITemplate, cfg)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	htmlFormatter := NewHTMLFormatter(commitRangeURITemplate)
+	sinkNotifiers := map[alerts.SinkType]Notifier{
+		alerts.EmailSink: newNotifier(newDefaultNotificationProvider(htmlFormatter), htmlFormatter, NewEmailTransport(), URL, traceStore, fs),
+		alerts.ChatSink:  newNotifier(newDefaultNotificationProvider(markdownFormatter), markdownFormatter, NewChatTransport(), URL, traceStore, fs),
+	}
+
 	switch cfg.Notifications {
 	case notifytypes.None:
+		// Notifications are turned off, so the sinks listed in Alerts are
+		// ignored too.
 		return newNotifier(notificationDataProvider, formatter, NewNoopTransport(), URL, traceStore, fs), nil
 	case notifytypes.HTMLEmail:
-		return newNotifier(notificationDataProvider, formatter, NewEmailTransport(), URL, traceStore, fs), nil
+		return newOwnerNotifier(newSinkNotifier(newNotifier(notificationDataProvider, formatter, NewEmailTransport(), URL, traceStore, fs), sinkNotifiers)), nil
 	case notifytypes.MarkdownIssueTracker:
 		tracker, err := NewIssueTrackerTransport(ctx, cfg)
 		if err != nil {
 			return nil, skerr.Wrap(err)
 		}
-		return newNotifier(notificationDataProvider, formatter, tracker, URL, traceStore, fs), nil
+		sinkNotifiers[alerts.IssueTrackerSink] = newNotifier(newDefaultNotificationProvider(markdownFormatter), markdownFormatter, tracker, URL, traceStore, fs)
+		return newOwnerNotifier(newSinkNotifier(newNotifier(notificationDataProvider, formatter, tracker, URL, traceStore, fs), sinkNotifiers)), nil
 	case notifytypes.ChromeperfAlerting:
 		return NewChromePerfNotifier(ctx, nil)
 	case notifytypes.AnomalyGrouper:
@@ -22,6 +22,7 @@ import (
 	"go.skia.org/infra/perf/go/git/provider"
 	"go.skia.org/infra/perf/go/notify/common"
 	"go.skia.org/infra/perf/go/notify/mocks"
+	"go.skia.org/infra/perf/go/notifytypes"
 	"go.skia.org/infra/perf/go/stepfit"
 	"go.skia.org/infra/perf/go/ui/frame"
 )
@@ -299,3 +300,15 @@ func TestMarkdownFormatter_ForLoopOnParamSet_Success(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "devices:  sailfish |  sargo |  wembley | ", subject)
 }
+
+func TestNew_NotificationsNone_IgnoresAlertSinks(t *testing.T) {
+	n, err := New(context.Background(), &config.NotifyConfig{Notifications: notifytypes.None}, "https://perf.skia.org", "", nil, nil)
+	require.NoError(t, err)
+	require.IsType(t, &defaultNotifier{}, n)
+
+	alert := alerts.NewConfig()
+	alert.NotificationSinks = "email:someone@example.com"
+	ref, err := n.RegressionFound(context.Background(), provider.Commit{}, provider.Commit{}, alert, &clustering2.ClusterSummary{}, &frame.FrameResponse{}, "")
+	require.NoError(t, err)
+	require.Empty(t, ref)
+}
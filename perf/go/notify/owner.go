@@ -0,0 +1,134 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/rotations"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/perf/go/alerts"
+	"go.skia.org/infra/perf/go/clustering2"
+	"go.skia.org/infra/perf/go/git/provider"
+	"go.skia.org/infra/perf/go/ui/frame"
+)
+
+// rotationCacheDuration is how long the owner loaded from a rotation URL is
+// used before it is loaded again.
+const rotationCacheDuration = 10 * time.Minute
+
+// rotationFetcher returns the current members of the rotation at the URL.
+type rotationFetcher func(ctx context.Context, url string) ([]string, error)
+
+// cachedOwner is the owner loaded from a single rotation URL.
+type cachedOwner struct {
+	owner  string
+	loaded time.Time
+}
+
+// ownerNotifier implements Notifier by resolving the owner of each Alert that
+// has an OwnerRotationURL before passing the notification on to the wrapped
+// Notifier.
+//
+// Owners are cached for rotationCacheDuration. If the rotation can't be
+// loaded then the last owner loaded from it is used, and if there is none then
+// the Alert's Owner is left unchanged.
+type ownerNotifier struct {
+	notifier Notifier
+	fetch    rotationFetcher
+
+	mutex sync.Mutex
+	cache map[string]cachedOwner
+
+	fetchFailures metrics2.Counter
+}
+
+// newOwnerNotifier returns a new ownerNotifier that loads rotations over HTTP.
+func newOwnerNotifier(notifier Notifier) *ownerNotifier {
+	client := httputils.NewTimeoutClient()
+	return newOwnerNotifierWithFetcher(notifier, func(ctx context.Context, url string) ([]string, error) {
+		return rotations.FromURL(client, url)
+	})
+}
+
+// newOwnerNotifierWithFetcher returns a new ownerNotifier that uses fetch to
+// load rotations.
+func newOwnerNotifierWithFetcher(notifier Notifier, fetch rotationFetcher) *ownerNotifier {
+	return &ownerNotifier{
+		notifier:      notifier,
+		fetch:         fetch,
+		cache:         map[string]cachedOwner{},
+		fetchFailures: metrics2.GetCounter("perf_notify_owner_rotation_failures"),
+	}
+}
+
+// resolveOwner returns the owner for the rotation at the given URL, or
+// fallback if it can't be determined.
+func (o *ownerNotifier) resolveOwner(ctx context.Context, url string, fallback string) string {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	cached, ok := o.cache[url]
+	if ok && now.Now(ctx).Sub(cached.loaded) < rotationCacheDuration {
+		return cached.owner
+	}
+	members, err := o.fetch(ctx, url)
+	if err == nil && len(members) == 0 {
+		err = skerr.Fmt("rotation has no members")
+	}
+	if err != nil {
+		o.fetchFailures.Inc(1)
+		if ok {
+			sklog.Warningf("Failed to load rotation %q, using the previous owner %q: %s", url, cached.owner, err)
+			return cached.owner
+		}
+		sklog.Warningf("Failed to load rotation %q, using the fallback owner %q: %s", url, fallback, err)
+		return fallback
+	}
+	// The members are sorted, so this is stable while the rotation doesn't
+	// change.
+	owner := members[0]
+	o.cache[url] = cachedOwner{
+		owner:  owner,
+		loaded: now.Now(ctx),
+	}
+	return owner
+}
+
+// alertWithOwner returns the alert, or a copy of it with the Owner resolved
+// from the OwnerRotationURL.
+func (o *ownerNotifier) alertWithOwner(ctx context.Context, alert *alerts.Alert) *alerts.Alert {
+	if alert.OwnerRotationURL == "" {
+		return alert
+	}
+	ret := *alert
+	ret.Owner = o.resolveOwner(ctx, alert.OwnerRotationURL, alert.Owner)
+	return &ret
+}
+
+// RegressionFound implements Notifier.
+func (o *ownerNotifier) RegressionFound(ctx context.Context, commit, previousCommit provider.Commit, alert *alerts.Alert, cl *clustering2.ClusterSummary, frame *frame.FrameResponse, regressionID string) (string, error) {
+	return o.notifier.RegressionFound(ctx, commit, previousCommit, o.alertWithOwner(ctx, alert), cl, frame, regressionID)
+}
+
+// RegressionMissing implements Notifier.
+func (o *ownerNotifier) RegressionMissing(ctx context.Context, commit, previousCommit provider.Commit, alert *alerts.Alert, cl *clustering2.ClusterSummary, frame *frame.FrameResponse, threadingReference string) error {
+	return o.notifier.RegressionMissing(ctx, commit, previousCommit, o.alertWithOwner(ctx, alert), cl, frame, threadingReference)
+}
+
+// ExampleSend implements Notifier.
+func (o *ownerNotifier) ExampleSend(ctx context.Context, alert *alerts.Alert) error {
+	return o.notifier.ExampleSend(ctx, o.alertWithOwner(ctx, alert))
+}
+
+// UpdateNotification implements Notifier.
+func (o *ownerNotifier) UpdateNotification(ctx context.Context, commit, previousCommit provider.Commit, alert *alerts.Alert, cl *clustering2.ClusterSummary, frame *frame.FrameResponse, notificationId string) error {
+	return o.notifier.UpdateNotification(ctx, commit, previousCommit, o.alertWithOwner(ctx, alert), cl, frame, notificationId)
+}
+
+// Confirm ownerNotifier implements Notifier.
+var _ Notifier = (*ownerNotifier)(nil)
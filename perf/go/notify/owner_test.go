@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/perf/go/alerts"
+	"go.skia.org/infra/perf/go/notify/mocks"
+)
+
+const rotationURLForTest = "https://rotations.example.org/current/sheriff"
+
+// fakeRotation is a rotationFetcher that counts how often it's called.
+type fakeRotation struct {
+	members []string
+	err     error
+	calls   int
+}
+
+func (f *fakeRotation) fetch(ctx context.Context, url string) ([]string, error) {
+	f.calls++
+	return f.members, f.err
+}
+
+func alertWithOwnerRotationForTest() *alerts.Alert {
+	return &alerts.Alert{
+		IDAsString:       "123",
+		Owner:            "fallback@example.org",
+		OwnerRotationURL: rotationURLForTest,
+	}
+}
+
+func exampleSendWithOwner(t *testing.T, owner string) *mocks.Notifier {
+	n := mocks.NewNotifier(t)
+	n.On("ExampleSend", mock.Anything, mock.MatchedBy(func(a *alerts.Alert) bool {
+		return a.Owner == owner
+	})).Return(nil)
+	return n
+}
+
+func TestOwnerNotifier_RotationLoaded_OwnerIsFirstMember(t *testing.T) {
+	rotation := &fakeRotation{members: []string{"a@example.org", "b@example.org"}}
+	o := newOwnerNotifierWithFetcher(exampleSendWithOwner(t, "a@example.org"), rotation.fetch)
+
+	alert := alertWithOwnerRotationForTest()
+	assert.NoError(t, o.ExampleSend(context.Background(), alert))
+	// The Alert passed in isn't modified.
+	assert.Equal(t, "fallback@example.org", alert.Owner)
+}
+
+func TestOwnerNotifier_NoRotationURL_AlertIsPassedThrough(t *testing.T) {
+	rotation := &fakeRotation{}
+	o := newOwnerNotifierWithFetcher(exampleSendWithOwner(t, "owner@example.org"), rotation.fetch)
+
+	assert.NoError(t, o.ExampleSend(context.Background(), &alerts.Alert{Owner: "owner@example.org"}))
+	assert.Equal(t, 0, rotation.calls)
+}
+
+func TestOwnerNotifier_RotationFailsWithNothingCached_FallsBackToOwner(t *testing.T) {
+	rotation := &fakeRotation{err: errors.New("rotation service is down")}
+	o := newOwnerNotifierWithFetcher(exampleSendWithOwner(t, "fallback@example.org"), rotation.fetch)
+
+	assert.NoError(t, o.ExampleSend(context.Background(), alertWithOwnerRotationForTest()))
+}
+
+func TestOwnerNotifier_RotationIsEmpty_FallsBackToOwner(t *testing.T) {
+	rotation := &fakeRotation{members: []string{}}
+	o := newOwnerNotifierWithFetcher(exampleSendWithOwner(t, "fallback@example.org"), rotation.fetch)
+
+	assert.NoError(t, o.ExampleSend(context.Background(), alertWithOwnerRotationForTest()))
+}
+
+func TestOwnerNotifier_OwnerIsCached_RotationIsOnlyLoadedOnceUntilItExpires(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ctx := now.TimeTravelingContext(start)
+	rotation := &fakeRotation{members: []string{"a@example.org"}}
+	o := newOwnerNotifierWithFetcher(exampleSendWithOwner(t, "a@example.org"), rotation.fetch)
+
+	assert.NoError(t, o.ExampleSend(ctx, alertWithOwnerRotationForTest()))
+	assert.NoError(t, o.ExampleSend(ctx, alertWithOwnerRotationForTest()))
+	assert.Equal(t, 1, rotation.calls)
+
+	ctx.SetTime(start.Add(rotationCacheDuration))
+	assert.NoError(t, o.ExampleSend(ctx, alertWithOwnerRotationForTest()))
+	assert.Equal(t, 2, rotation.calls)
+}
+
+func TestOwnerNotifier_RotationFailsAfterExpiry_UsesPreviousOwner(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ctx := now.TimeTravelingContext(start)
+	rotation := &fakeRotation{members: []string{"a@example.org"}}
+	o := newOwnerNotifierWithFetcher(exampleSendWithOwner(t, "a@example.org"), rotation.fetch)
+	assert.NoError(t, o.ExampleSend(ctx, alertWithOwnerRotationForTest()))
+
+	ctx.SetTime(start.Add(rotationCacheDuration))
+	rotation.err = errors.New("rotation service is down")
+	assert.NoError(t, o.ExampleSend(ctx, alertWithOwnerRotationForTest()))
+	assert.Equal(t, 2, rotation.calls)
+}
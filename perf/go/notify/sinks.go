@@ -0,0 +1,250 @@
+package notify
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/perf/go/alerts"
+	"go.skia.org/infra/perf/go/clustering2"
+	"go.skia.org/infra/perf/go/git/provider"
+	"go.skia.org/infra/perf/go/ui/frame"
+)
+
+const (
+	// sinkMaxAttempts is the number of times sending to a single sink is
+	// attempted before giving up.
+	sinkMaxAttempts = 3
+
+	// sinkInitialBackoff is how long to wait before the first retry. The wait
+	// doubles after each failed attempt.
+	sinkInitialBackoff = 2 * time.Second
+)
+
+// sinkMetrics are the delivery metrics for a single alerts.SinkType.
+type sinkMetrics struct {
+	sent     metrics2.Counter
+	retries  metrics2.Counter
+	failures metrics2.Counter
+}
+
+// sinkNotifier implements Notifier by sending each notification to every
+// alerts.NotificationSink listed in the Alert, retrying with backoff on
+// failure.
+//
+// Alerts that don't list any sinks are sent using the fallback Notifier, which
+// is the Notifier configured for the instance.
+type sinkNotifier struct {
+	fallback Notifier
+
+	// notifiers is the Notifier used for each type of sink. A sink type that
+	// isn't available on this instance is missing.
+	notifiers map[alerts.SinkType]Notifier
+
+	initialBackoff time.Duration
+	metrics        map[alerts.SinkType]sinkMetrics
+}
+
+// newSinkNotifier returns a new sinkNotifier.
+func newSinkNotifier(fallback Notifier, notifiers map[alerts.SinkType]Notifier) *sinkNotifier {
+	m := map[alerts.SinkType]sinkMetrics{}
+	for _, sinkType := range alerts.AllSinkTypes {
+		tags := map[string]string{"sink": string(sinkType)}
+		m[sinkType] = sinkMetrics{
+			sent:     metrics2.GetCounter("perf_notification_sink_sent", tags),
+			retries:  metrics2.GetCounter("perf_notification_sink_retries", tags),
+			failures: metrics2.GetCounter("perf_notification_sink_failures", tags),
+		}
+	}
+	return &sinkNotifier{
+		fallback:       fallback,
+		notifiers:      notifiers,
+		initialBackoff: sinkInitialBackoff,
+		metrics:        m,
+	}
+}
+
+// alertForSink returns a copy of the alert that only sends to the given sink.
+//
+// The Alert and IssueTrackerComponent fields are filled in from the sink so
+// that the existing Transports can be used unchanged.
+func alertForSink(alert *alerts.Alert, sink alerts.NotificationSink) *alerts.Alert {
+	ret := *alert
+	ret.NotificationSinks = sink.String()
+	ret.Alert = ""
+	ret.IssueTrackerComponent = alerts.InvalidIssueTrackerComponent
+	switch sink.Type {
+	case alerts.EmailSink:
+		ret.Alert = sink.Target
+	case alerts.IssueTrackerSink:
+		if component, err := strconv.ParseInt(sink.Target, 10, 64); err == nil {
+			ret.IssueTrackerComponent = alerts.SerializesToString(component)
+		}
+	}
+	return &ret
+}
+
+// sinkKey returns a key that identifies the sink within an Alert.
+//
+// The target is hashed since chat webhook URLs contain credentials, and the
+// keys are stored along with the regression.
+func sinkKey(sink alerts.NotificationSink) string {
+	return fmt.Sprintf("%s:%x", sink.Type, sha256.Sum256([]byte(sink.Target)))
+}
+
+// sinkNotificationID returns the ID of the notification sent to the sink for
+// the regression, or the empty string if there is none.
+func sinkNotificationID(cl *clustering2.ClusterSummary, sink alerts.NotificationSink) string {
+	if cl == nil {
+		return ""
+	}
+	return cl.SinkNotificationIDs[sinkKey(sink)]
+}
+
+// withRetry calls f with the Notifier for the sink and a copy of the alert
+// that only sends to the sink. It retries up to sinkMaxAttempts times,
+// waiting with exponential backoff between attempts.
+func (s *sinkNotifier) withRetry(ctx context.Context, alert *alerts.Alert, sink alerts.NotificationSink, f func(n Notifier, alert *alerts.Alert) error) error {
+	m := s.metrics[sink.Type]
+	n, ok := s.notifiers[sink.Type]
+	if !ok {
+		m.failures.Inc(1)
+		return skerr.Fmt("Notification sink type %q is not available on this instance.", sink.Type)
+	}
+	sinkAlert := alertForSink(alert, sink)
+	backoff := s.initialBackoff
+	var err error
+	for attempt := 1; attempt <= sinkMaxAttempts; attempt++ {
+		if err = f(n, sinkAlert); err == nil {
+			m.sent.Inc(1)
+			return nil
+		}
+		if attempt == sinkMaxAttempts {
+			break
+		}
+		m.retries.Inc(1)
+		sklog.Warningf("Sending %s notification for alert #%s failed, retrying in %s: %s", sink.Type, alert.IDAsString, backoff, err)
+		select {
+		case <-ctx.Done():
+			m.failures.Inc(1)
+			return skerr.Wrap(ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	m.failures.Inc(1)
+	return skerr.Wrapf(err, "sending %s notification for alert #%s after %d attempts", sink.Type, alert.IDAsString, sinkMaxAttempts)
+}
+
+// forEachSink calls withRetry for each of the sinks. Failures are logged, and
+// an error is only returned if every sink failed.
+func (s *sinkNotifier) forEachSink(ctx context.Context, alert *alerts.Alert, sinks []alerts.NotificationSink, f func(n Notifier, sink alerts.NotificationSink, alert *alerts.Alert) error) error {
+	var lastErr error
+	numFailed := 0
+	for _, sink := range sinks {
+		err := s.withRetry(ctx, alert, sink, func(n Notifier, sinkAlert *alerts.Alert) error {
+			return f(n, sink, sinkAlert)
+		})
+		if err != nil {
+			sklog.Errorf("Failed to send notification: %s", err)
+			lastErr = err
+			numFailed++
+		}
+	}
+	if numFailed == len(sinks) {
+		return skerr.Wrapf(lastErr, "all %d notification sinks failed", numFailed)
+	}
+	return nil
+}
+
+// RegressionFound implements Notifier.
+//
+// The threading reference returned by each sink is stored in
+// cl.SinkNotificationIDs, and the empty string is returned since there isn't a
+// single notification for the regression.
+func (s *sinkNotifier) RegressionFound(ctx context.Context, commit, previousCommit provider.Commit, alert *alerts.Alert, cl *clustering2.ClusterSummary, frame *frame.FrameResponse, regressionID string) (string, error) {
+	sinks, err := alert.Sinks()
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	if len(sinks) == 0 {
+		return s.fallback.RegressionFound(ctx, commit, previousCommit, alert, cl, frame, regressionID)
+	}
+	refs := map[string]string{}
+	err = s.forEachSink(ctx, alert, sinks, func(n Notifier, sink alerts.NotificationSink, sinkAlert *alerts.Alert) error {
+		ref, err := n.RegressionFound(ctx, commit, previousCommit, sinkAlert, cl, frame, regressionID)
+		if err != nil {
+			return err
+		}
+		refs[sinkKey(sink)] = ref
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if cl != nil {
+		cl.SinkNotificationIDs = refs
+	}
+	return "", nil
+}
+
+// RegressionMissing implements Notifier.
+//
+// Each sink is passed its own threading reference from cl.SinkNotificationIDs.
+func (s *sinkNotifier) RegressionMissing(ctx context.Context, commit, previousCommit provider.Commit, alert *alerts.Alert, cl *clustering2.ClusterSummary, frame *frame.FrameResponse, threadingReference string) error {
+	sinks, err := alert.Sinks()
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if len(sinks) == 0 {
+		return s.fallback.RegressionMissing(ctx, commit, previousCommit, alert, cl, frame, threadingReference)
+	}
+	return s.forEachSink(ctx, alert, sinks, func(n Notifier, sink alerts.NotificationSink, sinkAlert *alerts.Alert) error {
+		return n.RegressionMissing(ctx, commit, previousCommit, sinkAlert, cl, frame, sinkNotificationID(cl, sink))
+	})
+}
+
+// UpdateNotification implements Notifier.
+//
+// Each sink is passed its own notification ID from cl.SinkNotificationIDs.
+func (s *sinkNotifier) UpdateNotification(ctx context.Context, commit, previousCommit provider.Commit, alert *alerts.Alert, cl *clustering2.ClusterSummary, frame *frame.FrameResponse, notificationId string) error {
+	sinks, err := alert.Sinks()
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if len(sinks) == 0 {
+		return s.fallback.UpdateNotification(ctx, commit, previousCommit, alert, cl, frame, notificationId)
+	}
+	return s.forEachSink(ctx, alert, sinks, func(n Notifier, sink alerts.NotificationSink, sinkAlert *alerts.Alert) error {
+		return n.UpdateNotification(ctx, commit, previousCommit, sinkAlert, cl, frame, sinkNotificationID(cl, sink))
+	})
+}
+
+// ExampleSend implements Notifier.
+//
+// Unlike the other methods an example is sent to each sink only once, and the
+// first failure is returned, so that problems are reported to the user.
+func (s *sinkNotifier) ExampleSend(ctx context.Context, alert *alerts.Alert) error {
+	sinks, err := alert.Sinks()
+	if err != nil {
+		return skerr.Wrap(err)
+	}
+	if len(sinks) == 0 {
+		return s.fallback.ExampleSend(ctx, alert)
+	}
+	for _, sink := range sinks {
+		n, ok := s.notifiers[sink.Type]
+		if !ok {
+			return skerr.Fmt("Notification sink type %q is not available on this instance.", sink.Type)
+		}
+		if err := n.ExampleSend(ctx, alertForSink(alert, sink)); err != nil {
+			return skerr.Wrapf(err, "sending example %s notification", sink.Type)
+		}
+	}
+	return nil
+}
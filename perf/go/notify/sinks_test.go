@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/perf/go/alerts"
+	"go.skia.org/infra/perf/go/clustering2"
+	"go.skia.org/infra/perf/go/git/provider"
+	"go.skia.org/infra/perf/go/notify/mocks"
+)
+
+const (
+	emailSinkTarget = "someone@example.org"
+	chatSinkTarget  = "https://chat.googleapis.com/v1/spaces/AAAA/messages?key=secret"
+)
+
+var (
+	emailSinkForTest = alerts.NotificationSink{Type: alerts.EmailSink, Target: emailSinkTarget}
+	chatSinkForTest  = alerts.NotificationSink{Type: alerts.ChatSink, Target: chatSinkTarget}
+)
+
+func alertWithSinksForTest(sinks ...alerts.NotificationSink) *alerts.Alert {
+	lines := []string{}
+	for _, sink := range sinks {
+		lines = append(lines, sink.String())
+	}
+	return &alerts.Alert{
+		IDAsString:        "123",
+		Alert:             "instance-default@example.org",
+		DisplayName:       "MyAlert",
+		NotificationSinks: strings.Join(lines, "\n"),
+	}
+}
+
+func newSinkNotifierForTest(fallback Notifier, notifiers map[alerts.SinkType]Notifier) *sinkNotifier {
+	ret := newSinkNotifier(fallback, notifiers)
+	ret.initialBackoff = 0
+	return ret
+}
+
+func TestSinkNotifier_RegressionFound_AlertWithNoSinks_UsesFallback(t *testing.T) {
+	ctx := context.Background()
+	alert := alertWithSinksForTest()
+	fallback := mocks.NewNotifier(t)
+	fallback.On("RegressionFound", ctx, provider.Commit{}, provider.Commit{}, alert, mock.Anything, mock.Anything, "").Return(mockThreadingID, nil)
+	email := mocks.NewNotifier(t)
+
+	n := newSinkNotifierForTest(fallback, map[alerts.SinkType]Notifier{alerts.EmailSink: email})
+	ref, err := n.RegressionFound(ctx, provider.Commit{}, provider.Commit{}, alert, nil, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, mockThreadingID, ref)
+}
+
+func TestSinkNotifier_RegressionFound_ChatFailsOnceThenSucceeds_SendsToAllSinks(t *testing.T) {
+	ctx := context.Background()
+	alert := alertWithSinksForTest(emailSinkForTest, chatSinkForTest)
+	email := mocks.NewNotifier(t)
+	email.On("RegressionFound", ctx, provider.Commit{}, provider.Commit{}, mock.MatchedBy(func(a *alerts.Alert) bool {
+		return a.Alert == emailSinkTarget && a.NotificationSinks == emailSinkForTest.String()
+	}), mock.Anything, mock.Anything, "").Return("email-ref", nil).Once()
+	chat := mocks.NewNotifier(t)
+	chatAlert := mock.MatchedBy(func(a *alerts.Alert) bool {
+		return a.Alert == "" && a.NotificationSinks == chatSinkForTest.String()
+	})
+	chat.On("RegressionFound", ctx, provider.Commit{}, provider.Commit{}, chatAlert, mock.Anything, mock.Anything, "").Return("", errMock).Once()
+	chat.On("RegressionFound", ctx, provider.Commit{}, provider.Commit{}, chatAlert, mock.Anything, mock.Anything, "").Return("chat-ref", nil).Once()
+
+	n := newSinkNotifierForTest(mocks.NewNotifier(t), map[alerts.SinkType]Notifier{
+		alerts.EmailSink: email,
+		alerts.ChatSink:  chat,
+	})
+	cl := &clustering2.ClusterSummary{}
+	ref, err := n.RegressionFound(ctx, provider.Commit{}, provider.Commit{}, alert, cl, nil, "")
+	require.NoError(t, err)
+
+	// The sink references are kept apart from the notification ID.
+	assert.Empty(t, ref)
+	assert.Equal(t, map[string]string{
+		sinkKey(emailSinkForTest): "email-ref",
+		sinkKey(chatSinkForTest):  "chat-ref",
+	}, cl.SinkNotificationIDs)
+	for key := range cl.SinkNotificationIDs {
+		assert.NotContains(t, key, "secret")
+	}
+}
+
+func TestSinkNotifier_RegressionFound_SinkTypeNotAvailable_OtherSinksStillSent(t *testing.T) {
+	ctx := context.Background()
+	issueSink := alerts.NotificationSink{Type: alerts.IssueTrackerSink, Target: "1234"}
+	alert := alertWithSinksForTest(issueSink, emailSinkForTest)
+	email := mocks.NewNotifier(t)
+	email.On("RegressionFound", ctx, provider.Commit{}, provider.Commit{}, mock.Anything, mock.Anything, mock.Anything, "").Return("email-ref", nil)
+
+	n := newSinkNotifierForTest(mocks.NewNotifier(t), map[alerts.SinkType]Notifier{alerts.EmailSink: email})
+	_, err := n.RegressionFound(ctx, provider.Commit{}, provider.Commit{}, alert, nil, nil, "")
+	require.NoError(t, err)
+}
+
+func TestSinkNotifier_RegressionFound_AllSinksFail_ReturnsErrorAfterRetries(t *testing.T) {
+	ctx := context.Background()
+	alert := alertWithSinksForTest(emailSinkForTest)
+	email := mocks.NewNotifier(t)
+	email.On("RegressionFound", ctx, provider.Commit{}, provider.Commit{}, mock.Anything, mock.Anything, mock.Anything, "").Return("", errMock).Times(sinkMaxAttempts)
+
+	n := newSinkNotifierForTest(mocks.NewNotifier(t), map[alerts.SinkType]Notifier{alerts.EmailSink: email})
+	_, err := n.RegressionFound(ctx, provider.Commit{}, provider.Commit{}, alert, nil, nil, "")
+	require.ErrorIs(t, err, errMock)
+}
+
+func TestSinkNotifier_RegressionMissing_PassesEachSinkItsOwnThreadingReference(t *testing.T) {
+	ctx := context.Background()
+	alert := alertWithSinksForTest(emailSinkForTest, chatSinkForTest)
+	cl := &clustering2.ClusterSummary{
+		NotificationID: mockThreadingID,
+		SinkNotificationIDs: map[string]string{
+			sinkKey(emailSinkForTest): "email-ref",
+			sinkKey(chatSinkForTest):  "chat-ref",
+		},
+	}
+	email := mocks.NewNotifier(t)
+	email.On("RegressionMissing", ctx, provider.Commit{}, provider.Commit{}, mock.Anything, mock.Anything, mock.Anything, "email-ref").Return(nil)
+	chat := mocks.NewNotifier(t)
+	chat.On("RegressionMissing", ctx, provider.Commit{}, provider.Commit{}, mock.Anything, mock.Anything, mock.Anything, "chat-ref").Return(nil)
+
+	n := newSinkNotifierForTest(mocks.NewNotifier(t), map[alerts.SinkType]Notifier{
+		alerts.EmailSink: email,
+		alerts.ChatSink:  chat,
+	})
+	require.NoError(t, n.RegressionMissing(ctx, provider.Commit{}, provider.Commit{}, alert, cl, nil, cl.NotificationID))
+}
+
+func TestSinkNotifier_UpdateNotification_SinkWithoutNotificationID_PassesEmptyID(t *testing.T) {
+	ctx := context.Background()
+	alert := alertWithSinksForTest(emailSinkForTest)
+	email := mocks.NewNotifier(t)
+	email.On("UpdateNotification", ctx, provider.Commit{}, provider.Commit{}, mock.Anything, mock.Anything, mock.Anything, "").Return(nil)
+
+	n := newSinkNotifierForTest(mocks.NewNotifier(t), map[alerts.SinkType]Notifier{alerts.EmailSink: email})
+	require.NoError(t, n.UpdateNotification(ctx, provider.Commit{}, provider.Commit{}, alert, &clustering2.ClusterSummary{}, nil, mockThreadingID))
+}
+
+// roundTripperFunc implements http.RoundTripper.
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestChatTransport_SendRegressionMissing_PostsToWebhookInThread(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	c := ChatTransport{
+		client: &http.Client{
+			Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				req = r
+				var err error
+				body, err = io.ReadAll(r.Body)
+				require.NoError(t, err)
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+			}),
+		},
+	}
+
+	err := c.SendRegressionMissing(context.Background(), "my-thread", alertWithSinksForTest(chatSinkForTest), "the body", "the subject")
+	require.NoError(t, err)
+	assert.Equal(t, "chat.googleapis.com", req.URL.Host)
+	assert.Equal(t, "secret", req.URL.Query().Get("key"))
+	assert.Equal(t, "my-thread", req.URL.Query().Get("threadKey"))
+	assert.Equal(t, `{"text":"*the subject*\n\nthe body"}`, string(body))
+}
+
+func TestChatTransport_SendNewRegression_NoChatSink_ReturnsError(t *testing.T) {
+	_, err := NewChatTransport().SendNewRegression(context.Background(), alertWithSinksForTest(emailSinkForTest), "the body", "the subject")
+	require.Error(t, err)
+}
@@ -54,10 +54,14 @@
 //
 // Note that muliple Summary operations can be applied, and each one will
 // generate its own column in the resulting TraceSet.
+//
+// When only the summary table is needed, PivotTable returns the same result
+// in the more compact form of a Table, with one row per group.
 package pivot
 
 import (
 	"context"
+	"sort"
 
 	"go.skia.org/infra/go/calc"
 	"go.skia.org/infra/go/paramtools"
@@ -80,10 +84,13 @@ const (
 	Count Operation = "count"
 	Min   Operation = "min"
 	Max   Operation = "max"
+	P50   Operation = "p50"
+	P90   Operation = "p90"
+	P99   Operation = "p99"
 )
 
 // AllOperations for exporting to TypeScript.
-var AllOperations = []Operation{Sum, Avg, Geo, Std, Count, Min, Max}
+var AllOperations = []Operation{Sum, Avg, Geo, Std, Count, Min, Max, P50, P90, P99}
 
 // Request controls how a pivot is done.
 type Request struct {
@@ -117,6 +124,33 @@ func stdDev(a []float32) float32 {
 	return stddev
 }
 
+// percentile returns the functions for the p-th percentile operation.
+func percentile(p float32) operationFunctions {
+	summary := func(a []float32) float32 {
+		return vec32.Percentile(a, p)
+	}
+	return operationFunctions{
+		groupByOperation: func(traces types.TraceSet) types.Trace {
+			n := 0
+			for _, trace := range traces {
+				n = len(trace)
+				break
+			}
+			ret := vec32.New(n)
+			column := make([]float32, 0, len(traces))
+			for i := range ret {
+				column = column[:0]
+				for _, trace := range traces {
+					column = append(column, trace[i])
+				}
+				ret[i] = summary(column)
+			}
+			return ret
+		},
+		summaryOperation: summary,
+	}
+}
+
 // opMap contains all the known operation implementations for both GroupBy and
 // Summary operations. Keeping it in a table like this ensures that we always
 // have both groupBy and summary functions available.
@@ -149,6 +183,9 @@ var opMap map[Operation]operationFunctions = map[Operation]operationFunctions{
 		groupByOperation: calc.MaxFuncImpl,
 		summaryOperation: vec32.Max,
 	},
+	P50: percentile(50),
+	P90: percentile(90),
+	P99: percentile(99),
 }
 
 // Valid returns an error if the Request is not valid.
@@ -280,3 +317,68 @@ func Pivot(ctx context.Context, req Request, df *dataframe.DataFrame) (*datafram
 
 	return ret, nil
 }
+
+// TableRow is a single group in a Table.
+type TableRow struct {
+	// Group contains the value of each of the Table's GroupBy keys for this
+	// row, in the same order.
+	Group []string `json:"group"`
+
+	// Values contains one value for each of the Table's Columns.
+	Values []float32 `json:"values"`
+}
+
+// Table is the compact form of a pivot with Summary operations.
+type Table struct {
+	// GroupBy is the list of keys the traces were grouped by.
+	GroupBy []string `json:"group_by"`
+
+	// Columns is the Summary operation that produced each value in a row.
+	Columns []Operation `json:"columns"`
+
+	// Rows contains one row for each group that contained traces, sorted by
+	// Group.
+	Rows []TableRow `json:"rows"`
+}
+
+// PivotTable applies the pivot described in Request to the DataFrame and
+// returns the results as a Table. The Request must have at least one Summary
+// operation.
+func PivotTable(ctx context.Context, req Request, df *dataframe.DataFrame) (*Table, error) {
+	if len(req.Summary) == 0 {
+		return nil, skerr.Fmt("at least one Summary value must be supplied.")
+	}
+	pivoted, err := Pivot(ctx, req, df)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	ret := &Table{
+		GroupBy: req.GroupBy,
+		Columns: req.Summary,
+		Rows:    make([]TableRow, 0, len(pivoted.TraceSet)),
+	}
+	for groupID, values := range pivoted.TraceSet {
+		p, err := query.ParseKeyFast(groupID)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		group := make([]string, len(req.GroupBy))
+		for i, key := range req.GroupBy {
+			group[i] = p[key]
+		}
+		ret.Rows = append(ret.Rows, TableRow{
+			Group:  group,
+			Values: values,
+		})
+	}
+	sort.Slice(ret.Rows, func(i, j int) bool {
+		a, b := ret.Rows[i].Group, ret.Rows[j].Group
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+	return ret, nil
+}
@@ -186,3 +186,51 @@ func TestPivot_ContextIsCancelled_ReturnsError(t *testing.T) {
 	_, err := Pivot(ctx, req, df)
 	require.Contains(t, err.Error(), "canceled")
 }
+
+func TestPivot_PercentileOperationWithSummary_Success(t *testing.T) {
+
+	req := Request{
+		GroupBy:   []string{"arch"},
+		Operation: P50,
+		Summary:   []Operation{P90},
+	}
+	df := dataframeForTesting()
+	df, err := Pivot(context.Background(), req, df)
+	require.NoError(t, err)
+	require.Len(t, df.TraceSet, 2)
+	// The medians of the arm columns are all 0.
+	require.Equal(t, types.Trace{0}, df.TraceSet[",arch=arm,"])
+	// The medians of the intel columns are {5.5, 11, 16.5}.
+	require.InDelta(t, 15.4, df.TraceSet[",arch=intel,"][0], 0.0001)
+}
+
+func TestPivotTable_SumOperationWithSummary_ReturnsSortedRows(t *testing.T) {
+
+	req := Request{
+		GroupBy:   []string{"device", "arch"},
+		Operation: Sum,
+		Summary:   []Operation{Avg, Max},
+	}
+	table, err := PivotTable(context.Background(), req, dataframeForTesting())
+	require.NoError(t, err)
+	require.Equal(t, &Table{
+		GroupBy: []string{"device", "arch"},
+		Columns: []Operation{Avg, Max},
+		Rows: []TableRow{
+			{Group: []string{"Nexus5", "arm"}, Values: []float32{2, 3}},
+			{Group: []string{"Nexus5", "intel"}, Values: []float32{6, 9}},
+			{Group: []string{"Nexus7", "arm"}, Values: []float32{20, 30}},
+			{Group: []string{"Nexus7", "intel"}, Values: []float32{60, 90}},
+		},
+	}, table)
+}
+
+func TestPivotTable_NoSummary_ReturnsError(t *testing.T) {
+
+	req := Request{
+		GroupBy:   []string{"arch"},
+		Operation: Sum,
+	}
+	_, err := PivotTable(context.Background(), req, dataframeForTesting())
+	require.Error(t, err)
+}
@@ -0,0 +1,159 @@
+package querylimit
+
+import (
+	"context"
+	"time"
+
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/query"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/perf/go/dataframe"
+	"go.skia.org/infra/perf/go/progress"
+)
+
+// Priority of a DataFrame build.
+type Priority int
+
+const (
+	// Low is the priority of ad-hoc queries, such as those from the UI.
+	Low Priority = iota
+
+	// High is the priority of alert evaluation, which can also use the slots
+	// reserved for alerts.
+	High
+)
+
+// Gate limits the number of concurrent DataFrame builds.
+type Gate struct {
+	// shared slots are available to all priorities.
+	shared chan struct{}
+
+	// reserved slots are only available to High priority builds.
+	reserved chan struct{}
+}
+
+// NewGate returns a Gate that allows maxConcurrent builds at once, of which
+// reservedForAlerts are only available to High priority builds.
+func NewGate(maxConcurrent, reservedForAlerts int) *Gate {
+	return &Gate{
+		shared:   make(chan struct{}, maxConcurrent-reservedForAlerts),
+		reserved: make(chan struct{}, reservedForAlerts),
+	}
+}
+
+// Acquire blocks until a slot is available for the given priority, or the
+// context is cancelled. On success the returned func must be called to
+// release the slot.
+func (g *Gate) Acquire(ctx context.Context, p Priority) (func(), error) {
+	if p == High {
+		select {
+		case g.reserved <- struct{}{}:
+			return func() { <-g.reserved }, nil
+		case g.shared <- struct{}{}:
+			return func() { <-g.shared }, nil
+		case <-ctx.Done():
+			return nil, skerr.Wrapf(ctx.Err(), "waiting for a query slot")
+		}
+	}
+	select {
+	case g.shared <- struct{}{}:
+		return func() { <-g.shared }, nil
+	case <-ctx.Done():
+		return nil, skerr.Wrapf(ctx.Err(), "waiting for a query slot")
+	}
+}
+
+// gatedBuilder is a dataframe.DataFrameBuilder that only calls the wrapped
+// builder once it has acquired a slot from a Gate.
+type gatedBuilder struct {
+	builder  dataframe.DataFrameBuilder
+	gate     *Gate
+	priority Priority
+	waitTime metrics2.Float64SummaryMetric
+}
+
+// NewDataFrameBuilder returns a dataframe.DataFrameBuilder that limits the
+// number of concurrent calls to builder using gate, at the given priority.
+func NewDataFrameBuilder(builder dataframe.DataFrameBuilder, gate *Gate, priority Priority) dataframe.DataFrameBuilder {
+	tags := map[string]string{"priority": "low"}
+	if priority == High {
+		tags["priority"] = "high"
+	}
+	return &gatedBuilder{
+		builder:  builder,
+		gate:     gate,
+		priority: priority,
+		waitTime: metrics2.GetFloat64SummaryMetric("perf_query_limit_wait_s", tags),
+	}
+}
+
+func (g *gatedBuilder) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+	release, err := g.gate.Acquire(ctx, g.priority)
+	g.waitTime.Observe(time.Since(start).Seconds())
+	return release, err
+}
+
+// NewFromQueryAndRange implements dataframe.DataFrameBuilder.
+func (g *gatedBuilder) NewFromQueryAndRange(ctx context.Context, begin, end time.Time, q *query.Query, downsample bool, progress progress.Progress) (*dataframe.DataFrame, error) {
+	release, err := g.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return g.builder.NewFromQueryAndRange(ctx, begin, end, q, downsample, progress)
+}
+
+// NewFromKeysAndRange implements dataframe.DataFrameBuilder.
+func (g *gatedBuilder) NewFromKeysAndRange(ctx context.Context, keys []string, begin, end time.Time, downsample bool, progress progress.Progress) (*dataframe.DataFrame, error) {
+	release, err := g.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return g.builder.NewFromKeysAndRange(ctx, keys, begin, end, downsample, progress)
+}
+
+// NewNFromQuery implements dataframe.DataFrameBuilder.
+func (g *gatedBuilder) NewNFromQuery(ctx context.Context, end time.Time, q *query.Query, n int32, progress progress.Progress) (*dataframe.DataFrame, error) {
+	release, err := g.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return g.builder.NewNFromQuery(ctx, end, q, n, progress)
+}
+
+// NewNFromKeys implements dataframe.DataFrameBuilder.
+func (g *gatedBuilder) NewNFromKeys(ctx context.Context, end time.Time, keys []string, n int32, progress progress.Progress) (*dataframe.DataFrame, error) {
+	release, err := g.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return g.builder.NewNFromKeys(ctx, end, keys, n, progress)
+}
+
+// NumMatches implements dataframe.DataFrameBuilder.
+func (g *gatedBuilder) NumMatches(ctx context.Context, q *query.Query) (int64, error) {
+	release, err := g.acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	return g.builder.NumMatches(ctx, q)
+}
+
+// PreflightQuery implements dataframe.DataFrameBuilder.
+func (g *gatedBuilder) PreflightQuery(ctx context.Context, q *query.Query, referenceParamSet paramtools.ReadOnlyParamSet) (int64, paramtools.ParamSet, error) {
+	release, err := g.acquire(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer release()
+	return g.builder.PreflightQuery(ctx, q, referenceParamSet)
+}
+
+// Confirm gatedBuilder implements dataframe.DataFrameBuilder.
+var _ dataframe.DataFrameBuilder = (*gatedBuilder)(nil)
@@ -0,0 +1,82 @@
+package querylimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/query"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/perf/go/dataframe/mocks"
+)
+
+func timeoutContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestGateAcquire_SharedSlotsInUse_HighPriorityUsesReservedSlot(t *testing.T) {
+	g := NewGate(2, 1)
+	ctx := context.Background()
+
+	releaseLow, err := g.Acquire(ctx, Low)
+	require.NoError(t, err)
+
+	// The only shared slot is taken, so low priority requests must wait.
+	_, err = g.Acquire(timeoutContext(t), Low)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// High priority requests can still use the reserved slot.
+	releaseHigh, err := g.Acquire(ctx, High)
+	require.NoError(t, err)
+
+	// Now everything is in use.
+	_, err = g.Acquire(timeoutContext(t), High)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	releaseHigh()
+	releaseLow()
+	releaseLow, err = g.Acquire(ctx, Low)
+	require.NoError(t, err)
+	releaseLow()
+}
+
+func TestGateAcquire_NoReservedSlots_HighPriorityUsesSharedSlots(t *testing.T) {
+	g := NewGate(1, 0)
+
+	release, err := g.Acquire(context.Background(), High)
+	require.NoError(t, err)
+	_, err = g.Acquire(timeoutContext(t), High)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	release()
+}
+
+func TestGatedBuilder_NumMatches_CallsWrappedBuilderAndReleasesSlot(t *testing.T) {
+	q, err := query.NewFromString("arch=x86")
+	require.NoError(t, err)
+	inner := mocks.NewDataFrameBuilder(t)
+	inner.On("NumMatches", testutils.AnyContext, q).Return(int64(12), nil).Twice()
+	b := NewDataFrameBuilder(inner, NewGate(1, 0), Low)
+
+	// The gate only has one slot, so the second call only succeeds if the
+	// first one released it.
+	for i := 0; i < 2; i++ {
+		n, err := b.NumMatches(timeoutContext(t), q)
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), n)
+	}
+}
+
+func TestGatedBuilder_NoSlotAvailable_ReturnsErrorWithoutCallingWrappedBuilder(t *testing.T) {
+	g := NewGate(1, 0)
+	release, err := g.Acquire(context.Background(), Low)
+	require.NoError(t, err)
+	defer release()
+	b := NewDataFrameBuilder(mocks.NewDataFrameBuilder(t), g, Low)
+
+	_, err = b.NumMatches(timeoutContext(t), &query.Query{})
+	require.Error(t, err)
+}
@@ -0,0 +1,130 @@
+// Package querylimit limits expensive queries in the Perf frontend, so that a
+// single user, or a single runaway dashboard, can't monopolize reads from the
+// TraceStore.
+//
+// There are two mechanisms:
+//
+//   - RateLimiter is an HTTP middleware that applies a per-user (or per-IP
+//     for users that aren't logged in) token bucket to expensive endpoints.
+//   - Gate limits the number of DataFrames being built concurrently, with a
+//     number of slots reserved for alert evaluation so that regression
+//     detection never starves behind ad-hoc queries.
+package querylimit
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"golang.org/x/time/rate"
+)
+
+// maxTrackedClients is the number of clients we keep rate limiters for. The
+// least recently seen clients are dropped first, which only resets their
+// budget.
+const maxTrackedClients = 10000
+
+// RateLimiter limits the rate of requests to expensive endpoints per client.
+type RateLimiter struct {
+	loginProvider alogin.Login
+	qps           rate.Limit
+	burst         int
+
+	// mutex protects limiters.
+	mutex    sync.Mutex
+	limiters *lru.Cache
+
+	rejected metrics2.Counter
+}
+
+// NewRateLimiter returns a new RateLimiter that allows each client qps
+// requests per second on average, with bursts of up to burst requests.
+func NewRateLimiter(loginProvider alogin.Login, qps float64, burst int) (*RateLimiter, error) {
+	limiters, err := lru.New(maxTrackedClients)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return &RateLimiter{
+		loginProvider: loginProvider,
+		qps:           rate.Limit(qps),
+		burst:         burst,
+		limiters:      limiters,
+		rejected:      metrics2.GetCounter("perf_query_limit_rejected", nil),
+	}, nil
+}
+
+// clientKey returns the key used to track the budget of the client making
+// the request, which is the email address of logged in users, or the IP
+// address otherwise.
+//
+// Clients can put anything in X-Forwarded-For, so only the right-most entry,
+// which is appended by the load balancer, is used.
+func (l *RateLimiter) clientKey(r *http.Request) string {
+	if email := l.loginProvider.LoggedInAs(r); email != "" {
+		return "user:" + email.String()
+	}
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		hops := strings.Split(fwd, ",")
+		return "ip:" + strings.TrimSpace(hops[len(hops)-1])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// reserve takes a token from the bucket of the client with the given key. It
+// returns false and how many seconds to wait before retrying if the client is
+// over budget.
+func (l *RateLimiter) reserve(key string) (bool, int) {
+	l.mutex.Lock()
+	var limiter *rate.Limiter
+	if cached, ok := l.limiters.Get(key); ok {
+		limiter = cached.(*rate.Limiter)
+	} else {
+		limiter = rate.NewLimiter(l.qps, l.burst)
+		l.limiters.Add(key, limiter)
+	}
+	l.mutex.Unlock()
+
+	res := limiter.Reserve()
+	if delay := res.Delay(); delay > 0 {
+		// Don't consume the token since the request is rejected.
+		res.Cancel()
+		return false, int(delay.Seconds()) + 1
+	}
+	return true, 0
+}
+
+// Middleware returns a middleware that applies the rate limit to requests
+// for the given paths. All other requests are passed through unchanged.
+func (l *RateLimiter) Middleware(paths ...string) func(http.Handler) http.Handler {
+	limited := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		limited[p] = true
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !limited[r.URL.Path] {
+				h.ServeHTTP(w, r)
+				return
+			}
+			key := l.clientKey(r)
+			if ok, retryAfter := l.reserve(key); !ok {
+				l.rejected.Inc(1)
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				httputils.ReportError(w, skerr.Fmt("query budget exceeded for %q", key), "Too many queries, please try again later.", http.StatusTooManyRequests)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
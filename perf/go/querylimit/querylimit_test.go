@@ -0,0 +1,85 @@
+package querylimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/alogin/mocks"
+)
+
+func newHandlerForTest(t *testing.T, login alogin.Login) http.Handler {
+	l, err := NewRateLimiter(login, 0.001, 2)
+	require.NoError(t, err)
+	return l.Middleware("/_/frame/start")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func serve(h http.Handler, path, remoteAddr string) *httptest.ResponseRecorder {
+	return serveForwarded(h, path, remoteAddr, "")
+}
+
+func serveForwarded(h http.Handler, path, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", path, nil)
+	r.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		r.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestMiddleware_OverBudget_ReturnsTooManyRequests(t *testing.T) {
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", mock.Anything).Return(alogin.EMail(""))
+	h := newHandlerForTest(t, login)
+
+	assert.Equal(t, http.StatusOK, serve(h, "/_/frame/start", "10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusOK, serve(h, "/_/frame/start", "10.0.0.1:5678").Code)
+	w := serve(h, "/_/frame/start", "10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	// Other clients have their own budget.
+	assert.Equal(t, http.StatusOK, serve(h, "/_/frame/start", "10.0.0.2:1234").Code)
+}
+
+func TestMiddleware_PathNotLimited_PassesThrough(t *testing.T) {
+	h := newHandlerForTest(t, mocks.NewLogin(t))
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, serve(h, "/_/defaults/", "10.0.0.1:1234").Code)
+	}
+}
+
+func TestMiddleware_LoggedInUser_BudgetIsPerUser(t *testing.T) {
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", mock.Anything).Return(alogin.EMail("someone@example.com"))
+	h := newHandlerForTest(t, login)
+
+	// The same user from different addresses shares one budget.
+	assert.Equal(t, http.StatusOK, serve(h, "/_/frame/start", "10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusOK, serve(h, "/_/frame/start", "10.0.0.2:1234").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(h, "/_/frame/start", "10.0.0.3:1234").Code)
+}
+
+func TestMiddleware_SpoofedForwardedFor_DoesNotResetBudget(t *testing.T) {
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", mock.Anything).Return(alogin.EMail(""))
+	h := newHandlerForTest(t, login)
+
+	// The load balancer appends the client address to whatever the client
+	// sent, so changing the left-most entry doesn't give a new budget.
+	assert.Equal(t, http.StatusOK, serveForwarded(h, "/_/frame/start", "10.0.0.100:1234", "1.1.1.1, 203.0.113.7").Code)
+	assert.Equal(t, http.StatusOK, serveForwarded(h, "/_/frame/start", "10.0.0.100:1234", "2.2.2.2, 203.0.113.7").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveForwarded(h, "/_/frame/start", "10.0.0.100:1234", "3.3.3.3, 203.0.113.7").Code)
+
+	// A different client behind the same load balancer has its own budget.
+	assert.Equal(t, http.StatusOK, serveForwarded(h, "/_/frame/start", "10.0.0.100:1234", "203.0.113.8").Code)
+}
@@ -19,15 +19,20 @@ import (
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/perf/go/alerts"
+	"go.skia.org/infra/perf/go/annotation"
+	"go.skia.org/infra/perf/go/clustering2"
 	"go.skia.org/infra/perf/go/config"
 	"go.skia.org/infra/perf/go/dataframe"
 	perfgit "go.skia.org/infra/perf/go/git"
+	"go.skia.org/infra/perf/go/git/provider"
 	"go.skia.org/infra/perf/go/ingestevents"
 	"go.skia.org/infra/perf/go/notify"
 	"go.skia.org/infra/perf/go/regression"
+	"go.skia.org/infra/perf/go/regressiongroup"
 	"go.skia.org/infra/perf/go/shortcut"
 	"go.skia.org/infra/perf/go/stepfit"
 	"go.skia.org/infra/perf/go/types"
+	"go.skia.org/infra/perf/go/ui/frame"
 	"go.skia.org/infra/perf/go/urlprovider"
 )
 
@@ -52,17 +57,27 @@ const (
 // Continuous is used to run clustering on the last numCommits commits and
 // look for regressions.
 type Continuous struct {
-	perfGit        perfgit.Git
-	shortcutStore  shortcut.Store
-	store          regression.Store
-	provider       alerts.ConfigProvider
-	notifier       notify.Notifier
-	paramsProvider regression.ParamsetProvider
-	urlProvider    urlprovider.URLProvider
-	dfBuilder      dataframe.DataFrameBuilder
-	pollingDelay   time.Duration
-	instanceConfig *config.InstanceConfig
-	flags          *config.FrontendFlags
+	perfGit         perfgit.Git
+	shortcutStore   shortcut.Store
+	store           regression.Store
+	annotationStore annotation.Store
+	groupStore      regressiongroup.Store
+	provider        alerts.ConfigProvider
+	notifier        notify.Notifier
+	paramsProvider  regression.ParamsetProvider
+	urlProvider     urlprovider.URLProvider
+	dfBuilder       dataframe.DataFrameBuilder
+	pollingDelay    time.Duration
+	instanceConfig  *config.InstanceConfig
+	flags           *config.FrontendFlags
+
+	// suppressedCounter counts the regressions that were not reported
+	// because they fell within an annotation that suppresses alerts.
+	suppressedCounter metrics2.Counter
+
+	// groupedCounter counts the regressions that were added to an existing
+	// regression group, and so didn't send a notification of their own.
+	groupedCounter metrics2.Counter
 
 	mutex   sync.Mutex // Protects current.
 	current *alerts.Alert
@@ -71,6 +86,8 @@ type Continuous struct {
 // New creates a new *Continuous.
 //
 //	provider - Produces the slice of alerts.Config's that determine the clustering to perform.
+//	annotationStore - Regressions at commits covered by an annotation that suppresses alerts are not reported. May be nil.
+//	groupStore - Stores the regression groups that new regressions are added to. May be nil, in which case regressions are not grouped.
 //	numCommits - The number of commits to run the clustering over.
 //	radius - The number of commits on each side of a commit to include when clustering.
 func New(
@@ -78,6 +95,8 @@ func New(
 	shortcutStore shortcut.Store,
 	provider alerts.ConfigProvider,
 	store regression.Store,
+	annotationStore annotation.Store,
+	groupStore regressiongroup.Store,
 	notifier notify.Notifier,
 	paramsProvider regression.ParamsetProvider,
 	urlProvider urlprovider.URLProvider,
@@ -85,27 +104,86 @@ func New(
 	instanceConfig *config.InstanceConfig,
 	flags *config.FrontendFlags) *Continuous {
 	return &Continuous{
-		perfGit:        perfGit,
-		store:          store,
-		provider:       provider,
-		notifier:       notifier,
-		shortcutStore:  shortcutStore,
-		current:        &alerts.Alert{},
-		paramsProvider: paramsProvider,
-		urlProvider:    urlProvider,
-		dfBuilder:      dfBuilder,
-		pollingDelay:   pollingClusteringDelay,
-		instanceConfig: instanceConfig,
-		flags:          flags,
+		perfGit:           perfGit,
+		store:             store,
+		annotationStore:   annotationStore,
+		groupStore:        groupStore,
+		provider:          provider,
+		notifier:          notifier,
+		shortcutStore:     shortcutStore,
+		current:           &alerts.Alert{},
+		paramsProvider:    paramsProvider,
+		urlProvider:       urlProvider,
+		dfBuilder:         dfBuilder,
+		pollingDelay:      pollingClusteringDelay,
+		instanceConfig:    instanceConfig,
+		flags:             flags,
+		suppressedCounter: metrics2.GetCounter("perf_regressions_suppressed_by_annotation", nil),
+		groupedCounter:    metrics2.GetCounter("perf_regressions_grouped", nil),
 	}
 }
 
+// isSuppressedByAnnotation returns true if an annotation suppresses alerts at
+// the given commit. Failures to read annotations are logged and treated as no
+// suppression, so that regressions are never silently dropped.
+func (c *Continuous) isSuppressedByAnnotation(ctx context.Context, commitNumber types.CommitNumber) bool {
+	if c.annotationStore == nil {
+		return false
+	}
+	annotations, err := c.annotationStore.List(ctx, commitNumber, commitNumber)
+	if err != nil {
+		sklog.Errorf("Failed to load annotations for commit %d: %s", commitNumber, err)
+		return false
+	}
+	return annotation.SuppressesAlerts(annotations, commitNumber)
+}
+
+// notifyNewRegression sends the notification for a newly found regression and
+// returns the notification ID.
+//
+// If regressions are grouped then the regression is added to its regression
+// group, and only the first regression in a group sends a notification, the
+// rest share the notification ID of the group.
+func (c *Continuous) notifyNewRegression(ctx context.Context, commitNumber types.CommitNumber, commit, previousCommit provider.Commit, cfg *alerts.Alert, cl *clustering2.ClusterSummary, frame *frame.FrameResponse, regressionID string) (string, error) {
+	if c.groupStore == nil || len(c.instanceConfig.AnomalyConfig.GroupingKeys) == 0 {
+		return c.notifier.RegressionFound(ctx, commit, previousCommit, cfg, cl, frame, regressionID)
+	}
+	groupKey := regressiongroup.Key(cl.Keys, c.instanceConfig.AnomalyConfig.GroupingKeys)
+	group, isNewGroup, err := c.groupStore.AddRegression(ctx, commitNumber, groupKey, regressionID)
+	if err != nil {
+		// Fall back to notifying about the regression on its own, so it isn't
+		// silently dropped.
+		sklog.Errorf("Failed to add regression %s to a regression group: %s", regressionID, err)
+		return c.notifier.RegressionFound(ctx, commit, previousCommit, cfg, cl, frame, regressionID)
+	}
+	if !isNewGroup {
+		sklog.Infof("Regression %s added to regression group %s.", regressionID, group.ID)
+		c.groupedCounter.Inc(1)
+		return group.NotificationID, nil
+	}
+	notificationID, err := c.notifier.RegressionFound(ctx, commit, previousCommit, cfg, cl, frame, regressionID)
+	if err != nil {
+		return notificationID, err
+	}
+	if notificationID != "" {
+		if err := c.groupStore.SetNotificationID(ctx, group.ID, notificationID); err != nil {
+			sklog.Errorf("Failed to store the notification id of regression group %s: %s", group.ID, err)
+		}
+	}
+	return notificationID, nil
+}
+
 func (c *Continuous) reportRegressions(ctx context.Context, req *regression.RegressionDetectionRequest, resps []*regression.RegressionDetectionResponse, cfg *alerts.Alert) {
 	key := cfg.IDAsString
 	for _, resp := range resps {
 		headerLength := len(resp.Frame.DataFrame.Header)
 		midPoint := headerLength / 2
 		commitNumber := resp.Frame.DataFrame.Header[midPoint].Offset
+		if c.isSuppressedByAnnotation(ctx, commitNumber) {
+			sklog.Infof("Not reporting regressions at commit %d for alert %s: commit is in an annotated range that suppresses alerts.", commitNumber, key)
+			c.suppressedCounter.Inc(1)
+			continue
+		}
 		details, err := c.perfGit.CommitFromCommitNumber(ctx, commitNumber)
 		if err != nil {
 			sklog.Errorf("Failed to look up commit %d: %s", commitNumber, err)
@@ -153,13 +231,13 @@ func (c *Continuous) reportRegressions(ctx context.Context, req *regression.Regr
 					}
 					isNewRegression = isNew
 					if isNew {
-						notificationID, err := c.notifier.RegressionFound(ctx, details, previousCommitDetails, cfg, cl, resp.Frame, regressionID)
+						notificationID, err := c.notifyNewRegression(ctx, commitNumber, details, previousCommitDetails, cfg, cl, resp.Frame, regressionID)
 						if err != nil {
 							sklog.Errorf("Failed to send notification: %s", err)
 						}
 						cl.NotificationID = notificationID
 
-						if notificationID != "" {
+						if notificationID != "" || len(cl.SinkNotificationIDs) > 0 {
 							_, _, err := c.store.SetLow(ctx, commitNumber, key, resp.Frame, cl)
 							if err != nil {
 								sklog.Errorf("save cluster with notification: %s", err)
@@ -178,13 +256,13 @@ func (c *Continuous) reportRegressions(ctx context.Context, req *regression.Regr
 					}
 					isNewRegression = isNew
 					if isNew {
-						notificationID, err := c.notifier.RegressionFound(ctx, details, previousCommitDetails, cfg, cl, resp.Frame, regressionID)
+						notificationID, err := c.notifyNewRegression(ctx, commitNumber, details, previousCommitDetails, cfg, cl, resp.Frame, regressionID)
 						if err != nil {
 							sklog.Errorf("Failed to send notification: %s", err)
 						}
 						cl.NotificationID = notificationID
 
-						if notificationID != "" {
+						if notificationID != "" || len(cl.SinkNotificationIDs) > 0 {
 							_, _, err := c.store.SetHigh(ctx, commitNumber, key, resp.Frame, cl)
 							if err != nil {
 								sklog.Errorf("save cluster with notification: %s", err)
@@ -2,6 +2,7 @@ package continuous
 
 import (
 	"context"
+	"errors"
 	"net/url"
 	"testing"
 	"time"
@@ -9,10 +10,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/metrics2"
 	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/testutils"
 	"go.skia.org/infra/perf/go/alerts"
 	alertconfigmocks "go.skia.org/infra/perf/go/alerts/mock"
+	"go.skia.org/infra/perf/go/annotation"
+	annotationmocks "go.skia.org/infra/perf/go/annotation/mocks"
 	"go.skia.org/infra/perf/go/clustering2"
 	"go.skia.org/infra/perf/go/config"
 	"go.skia.org/infra/perf/go/dataframe"
@@ -23,6 +27,8 @@ import (
 	notifymocks "go.skia.org/infra/perf/go/notify/mocks"
 	"go.skia.org/infra/perf/go/regression"
 	regressionmocks "go.skia.org/infra/perf/go/regression/mocks"
+	"go.skia.org/infra/perf/go/regressiongroup"
+	regressiongroupmocks "go.skia.org/infra/perf/go/regressiongroup/mocks"
 	shortcutmocks "go.skia.org/infra/perf/go/shortcut/mocks"
 	"go.skia.org/infra/perf/go/stepfit"
 	"go.skia.org/infra/perf/go/types"
@@ -287,6 +293,103 @@ func TestReportRegressions_OneNewStepDownRegressionFound_OneRegressionStoredAndN
 	require.Equal(t, notificationID, resp[0].Summary.Clusters[0].NotificationID)
 }
 
+func TestNotifyNewRegression_FirstRegressionInGroup_NotifiesAndStoresNotificationIDOnGroup(t *testing.T) {
+	ctx := context.Background()
+	c, _, _, cfg, allMocks := createArgsForReportRegressions(t)
+	groupStore := regressiongroupmocks.NewStore(t)
+	c.groupStore = groupStore
+	c.instanceConfig.AnomalyConfig.GroupingKeys = []string{"benchmark"}
+
+	cl := &clustering2.ClusterSummary{Keys: []string{",benchmark=canvas,test=draw,"}}
+	fr := &frame.FrameResponse{}
+	groupStore.On("AddRegression", testutils.AnyContext, types.CommitNumber(2), "benchmark=canvas", "r1").Return(&regressiongroup.Group{ID: "g1"}, true, nil)
+	allMocks.notifier.On("RegressionFound", ctx, provider.Commit{}, provider.Commit{}, cfg, cl, fr, "r1").Return("n1", nil)
+	groupStore.On("SetNotificationID", testutils.AnyContext, "g1", "n1").Return(nil)
+
+	notificationID, err := c.notifyNewRegression(ctx, 2, provider.Commit{}, provider.Commit{}, cfg, cl, fr, "r1")
+	require.NoError(t, err)
+	assert.Equal(t, "n1", notificationID)
+}
+
+func TestNotifyNewRegression_RegressionAddedToExistingGroup_ReturnsNotificationIDOfGroupWithoutNotifying(t *testing.T) {
+	ctx := context.Background()
+	c, _, _, cfg, _ := createArgsForReportRegressions(t)
+	groupStore := regressiongroupmocks.NewStore(t)
+	c.groupStore = groupStore
+	c.groupedCounter = metrics2.GetCounter("test_perf_regressions_grouped", nil)
+	c.instanceConfig.AnomalyConfig.GroupingKeys = []string{"benchmark"}
+
+	cl := &clustering2.ClusterSummary{Keys: []string{",benchmark=canvas,test=fill,"}}
+	groupStore.On("AddRegression", testutils.AnyContext, types.CommitNumber(2), "benchmark=canvas", "r2").Return(&regressiongroup.Group{ID: "g1", NotificationID: "n1"}, false, nil)
+
+	// We know no notification was sent since we didn't supply an
+	// implementation for the notifier mock.
+	notificationID, err := c.notifyNewRegression(ctx, 2, provider.Commit{}, provider.Commit{}, cfg, cl, &frame.FrameResponse{}, "r2")
+	require.NoError(t, err)
+	assert.Equal(t, "n1", notificationID)
+	assert.Equal(t, int64(1), c.groupedCounter.Get())
+}
+
+func TestNotifyNewRegression_GroupStoreFails_NotifiesAboutRegressionOnItsOwn(t *testing.T) {
+	ctx := context.Background()
+	c, _, _, cfg, allMocks := createArgsForReportRegressions(t)
+	groupStore := regressiongroupmocks.NewStore(t)
+	c.groupStore = groupStore
+	c.instanceConfig.AnomalyConfig.GroupingKeys = []string{"benchmark"}
+
+	cl := &clustering2.ClusterSummary{Keys: []string{",benchmark=canvas,test=draw,"}}
+	fr := &frame.FrameResponse{}
+	groupStore.On("AddRegression", testutils.AnyContext, types.CommitNumber(2), "benchmark=canvas", "r1").Return(nil, false, errors.New("database is down"))
+	allMocks.notifier.On("RegressionFound", ctx, provider.Commit{}, provider.Commit{}, cfg, cl, fr, "r1").Return("n1", nil)
+
+	notificationID, err := c.notifyNewRegression(ctx, 2, provider.Commit{}, provider.Commit{}, cfg, cl, fr, "r1")
+	require.NoError(t, err)
+	assert.Equal(t, "n1", notificationID)
+}
+
+func TestReportRegressions_CommitInAnnotatedRangeThatSuppressesAlerts_NoRegressionsReported(t *testing.T) {
+	ctx := context.Background()
+	c, req, resp, cfg, _ := createArgsForReportRegressions(t)
+	annotationStore := annotationmocks.NewStore(t)
+	c.annotationStore = annotationStore
+	c.suppressedCounter = metrics2.GetCounter("test_perf_regressions_suppressed_by_annotation", nil)
+
+	const regressionCommitNumber = types.CommitNumber(2)
+	resp = append(resp, &regression.RegressionDetectionResponse{
+		Frame: &frame.FrameResponse{
+			DataFrame: &dataframe.DataFrame{
+				Header: []*dataframe.ColumnHeader{
+					{Offset: 1},
+					{Offset: regressionCommitNumber},
+				},
+			},
+		},
+		Summary: &clustering2.ClusterSummaries{},
+	})
+	annotationStore.On("List", testutils.AnyContext, regressionCommitNumber, regressionCommitNumber).Return([]*annotation.Annotation{
+		{Begin: 1, End: 5, Note: "lab power outage", SuppressAlerts: true},
+	}, nil)
+
+	// We know no regression was reported since we didn't need to supply any
+	// implementations for the perfGit, regression store, or notifier mocks.
+	c.reportRegressions(ctx, req, resp, cfg)
+	require.Equal(t, int64(1), c.suppressedCounter.Get())
+}
+
+func TestIsSuppressedByAnnotation_AnnotationDoesNotSuppressAlerts_ReturnsFalse(t *testing.T) {
+	annotationStore := annotationmocks.NewStore(t)
+	annotationStore.On("List", testutils.AnyContext, types.CommitNumber(3), types.CommitNumber(3)).Return([]*annotation.Annotation{
+		{Begin: 1, End: 5, Note: "toolchain roll"},
+	}, nil)
+	c := &Continuous{annotationStore: annotationStore}
+	require.False(t, c.isSuppressedByAnnotation(context.Background(), 3))
+}
+
+func TestIsSuppressedByAnnotation_NoAnnotationStore_ReturnsFalse(t *testing.T) {
+	c := &Continuous{}
+	require.False(t, c.isSuppressedByAnnotation(context.Background(), 3))
+}
+
 func TestTraceIdForIngestEvent_Matching(t *testing.T) {
 	c, _, _, _, allMocks := createArgsForReportRegressions(t)
 
@@ -344,6 +344,9 @@ func (p *regressionDetectionProcess) run(ctx context.Context) error {
 		if err != nil {
 			return p.reportError(err, "Invalid regression detection.")
 		}
+		for _, cl := range summary.Clusters {
+			cl.StepDetection = p.request.Alert.Step
+		}
 		if err := p.shortcutFromKeys(ctx, summary); err != nil {
 			return p.reportError(err, "Failed to write shortcut for keys.")
 		}
@@ -295,6 +295,24 @@ func (_m *Store) TriageLow(ctx context.Context, commitNumber types.CommitNumber,
 	return r0
 }
 
+// TriageByIDs provides a mock function with given fields: ctx, ids, tr
+func (_m *Store) TriageByIDs(ctx context.Context, ids []string, tr regression.TriageStatus) error {
+	ret := _m.Called(ctx, ids, tr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriageByIDs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, regression.TriageStatus) error); ok {
+		r0 = rf(ctx, ids, tr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Write provides a mock function with given fields: ctx, regressions
 func (_m *Store) Write(ctx context.Context, regressions map[types.CommitNumber]*regression.AllRegressionsForCommit) error {
 	ret := _m.Called(ctx, regressions)
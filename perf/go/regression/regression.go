@@ -14,6 +14,10 @@ import (
 
 var ErrNoClusterFound = errors.New("No Cluster.")
 
+// ErrNotFound is returned by Store.TriageByIDs if any of the regressions don't
+// exist.
+var ErrNotFound = errors.New("Regression not found.")
+
 // Status is used in TriageStatus.
 type Status string
 
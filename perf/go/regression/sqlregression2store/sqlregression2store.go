@@ -15,6 +15,7 @@ import (
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/sql/pool"
 	"go.skia.org/infra/go/sql/sqlutil"
+	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/go/vec32"
 	"go.skia.org/infra/perf/go/alerts"
 	"go.skia.org/infra/perf/go/clustering2"
@@ -47,6 +48,7 @@ const (
 	readByIDs
 	readBySubName
 	deleteByCommit
+	triageByIDs
 )
 
 // statementContext provides a struct to expand sql statement templates.
@@ -114,6 +116,14 @@ var statementFormats = map[statementFormat]string{
 		OFFSET
 			$3
 		`,
+	triageByIDs: `
+		UPDATE
+			Regressions2
+		SET
+			triage_status=$1, triage_message=$2
+		WHERE
+			id = ANY($3::UUID[])
+		`,
 	deleteByCommit: `
 		DELETE
 		FROM
@@ -247,6 +257,34 @@ func (s *SQLRegression2Store) TriageHigh(ctx context.Context, commitNumber types
 	return err
 }
 
+// TriageByIDs implements the regression.Store interface.
+func (s *SQLRegression2Store) TriageByIDs(ctx context.Context, ids []string, tr regression.TriageStatus) error {
+	uniqueIDs := util.NewStringSet(ids).Keys()
+	if len(uniqueIDs) == 0 {
+		return skerr.Fmt("At least one regression id must be supplied.")
+	}
+	for _, id := range uniqueIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return skerr.Wrapf(regression.ErrNotFound, "Invalid regression id %q: %s", id, err)
+		}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return skerr.Wrapf(err, "Can't start transaction")
+	}
+	tag, err := tx.Exec(ctx, s.statements[triageByIDs], tr.Status, tr.Message, uniqueIDs)
+	if err != nil {
+		rollbackTransaction(ctx, tx)
+		return skerr.Wrapf(err, "Failed to triage regressions.")
+	}
+	if int(tag.RowsAffected()) != len(uniqueIDs) {
+		rollbackTransaction(ctx, tx)
+		return skerr.Wrapf(regression.ErrNotFound, "Found %d of the %d regressions to triage", tag.RowsAffected(), len(uniqueIDs))
+	}
+	return skerr.Wrap(tx.Commit(ctx))
+}
+
 // No Op for SQLRegression2Store.
 func (s *SQLRegression2Store) GetNotificationId(ctx context.Context, commitNumber types.CommitNumber, alertID string) (string, error) {
 	return "", nil
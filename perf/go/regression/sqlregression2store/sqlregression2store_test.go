@@ -141,6 +141,57 @@ func TestGetByIDs_Success(t *testing.T) {
 	assert.Contains(t, regressionIDs, regressions[1].Id)
 }
 
+// TestTriageByIDs_Success triages multiple regressions at once and verifies
+// that all of them are updated.
+func TestTriageByIDs_Success(t *testing.T) {
+	alertsProvider := alerts_mock.NewConfigProvider(t)
+
+	store := setupStore(t, alertsProvider)
+	ctx := context.Background()
+	r := generateAndStoreNewRegression(ctx, t, store)
+	r2 := generateAndStoreNewRegression(ctx, t, store)
+
+	tr := regression.TriageStatus{Status: regression.Negative, Message: "Noisy roll."}
+	err := store.TriageByIDs(ctx, []string{r.Id, r2.Id}, tr)
+	assert.NoError(t, err)
+
+	regressions, err := store.GetByIDs(ctx, []string{r.Id, r2.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(regressions))
+	for _, reg := range regressions {
+		assert.Equal(t, tr, reg.HighStatus)
+	}
+}
+
+// TestTriageByIDs_MissingRegression_NothingIsTriaged verifies that if one of
+// the regressions doesn't exist then none of them are updated.
+func TestTriageByIDs_MissingRegression_NothingIsTriaged(t *testing.T) {
+	alertsProvider := alerts_mock.NewConfigProvider(t)
+
+	store := setupStore(t, alertsProvider)
+	ctx := context.Background()
+	r := generateAndStoreNewRegression(ctx, t, store)
+
+	tr := regression.TriageStatus{Status: regression.Positive, Message: "Expected."}
+	err := store.TriageByIDs(ctx, []string{r.Id, uuid.NewString()}, tr)
+	assert.ErrorIs(t, err, regression.ErrNotFound)
+
+	regressions, err := store.GetByIDs(ctx, []string{r.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(regressions))
+	assert.NotEqual(t, tr, regressions[0].HighStatus)
+}
+
+// TestTriageByIDs_InvalidID_ReturnsError verifies that ids which are not
+// UUIDs are rejected.
+func TestTriageByIDs_InvalidID_ReturnsError(t *testing.T) {
+	alertsProvider := alerts_mock.NewConfigProvider(t)
+
+	store := setupStore(t, alertsProvider)
+	err := store.TriageByIDs(context.Background(), []string{"not-a-uuid"}, regression.TriageStatus{Status: regression.Positive})
+	assert.ErrorIs(t, err, regression.ErrNotFound)
+}
+
 // TestHighRegression_KMeans_Triage sets a High regression into the database, triages it
 // and verifies that the data was updated correctly. The alert Algo is set to be KMeans.
 func TestHighRegression_KMeans_Triage(t *testing.T) {
@@ -374,6 +374,11 @@ func (s *SQLRegressionStore) GetByIDs(ctx context.Context, ids []string) ([]*reg
 	return nil, skerr.Fmt("GetByIDs are not implemented in old version of regression store.")
 }
 
+// TriageByIDs implements the regression.Store interface.
+func (s *SQLRegressionStore) TriageByIDs(ctx context.Context, ids []string, tr regression.TriageStatus) error {
+	return skerr.Fmt("TriageByIDs is not implemented in old version of regression store.")
+}
+
 // GetOldestCommit implements the regression.Store interface. Gets the oldest commit in the table.
 func (s *SQLRegressionStore) GetOldestCommit(ctx context.Context) (*types.CommitNumber, error) {
 	var num int
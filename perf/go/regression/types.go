@@ -28,6 +28,12 @@ type Store interface {
 	// TriageHigh sets the triage status for the high cluster at the given commit and alertID.
 	TriageHigh(ctx context.Context, commitNumber types.CommitNumber, alertID string, tr TriageStatus) error
 
+	// TriageByIDs sets the triage status for all the regressions with the
+	// given IDs. The update is atomic, if any of the regressions does not
+	// exist then none of them are updated and an error wrapping ErrNotFound
+	// is returned.
+	TriageByIDs(ctx context.Context, ids []string, tr TriageStatus) error
+
 	// Write the Regressions to the store. The provided 'regressions' maps from
 	// types.CommitNumber to all the regressions for that commit.
 	Write(ctx context.Context, regressions map[types.CommitNumber]*AllRegressionsForCommit) error
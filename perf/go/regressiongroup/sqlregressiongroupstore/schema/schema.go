@@ -0,0 +1,32 @@
+package schema
+
+// RegressionGroupSchema represents the SQL schema of the RegressionGroups
+// table.
+type RegressionGroupSchema struct {
+	// Unique identifier of the regression group.
+	ID string `sql:"id UUID PRIMARY KEY DEFAULT gen_random_uuid()"`
+
+	// The commit number all the regressions in the group were found at.
+	CommitNumber int `sql:"commit_number INT NOT NULL"`
+
+	// Identifies the traces of the regressions in the group, see
+	// regressiongroup.Key().
+	GroupKey string `sql:"group_key STRING NOT NULL"`
+
+	// The ids of the regressions in the group.
+	RegressionIDs []string `sql:"regression_ids STRING ARRAY"`
+
+	// The triage status and message of the group.
+	TriageStatus  string `sql:"triage_status STRING"`
+	TriageMessage string `sql:"triage_message STRING"`
+
+	// The id of the notification sent for the group.
+	NotificationID string `sql:"notification_id STRING"`
+
+	// Stored as a Unix timestamp.
+	CreationTime int `sql:"creation_time INT"`
+
+	// Index used to find the group for a regression, and to list the groups
+	// in a commit range.
+	byCommitGroupKeyIndex struct{} `sql:"UNIQUE INDEX by_commit_group_key (commit_number, group_key)"`
+}
@@ -0,0 +1,225 @@
+// Package sqlregressiongroupstore implements regressiongroup.Store using an
+// SQL database.
+package sqlregressiongroupstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/sql/pool"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/perf/go/regression"
+	"go.skia.org/infra/perf/go/regressiongroup"
+	"go.skia.org/infra/perf/go/types"
+)
+
+// statement is an SQL statement identifier.
+type statement int
+
+const (
+	// The identifiers for all the SQL statements used.
+	readGroupForUpdate statement = iota
+	insertGroup
+	appendRegressionID
+	getGroup
+	rangeGroups
+	setNotificationID
+	setTriage
+)
+
+// statements holds all the raw SQL statements.
+var statements = map[statement]string{
+	readGroupForUpdate: `
+		SELECT
+			id, commit_number, group_key, regression_ids, triage_status, triage_message, notification_id, creation_time
+		FROM
+			RegressionGroups
+		WHERE
+			commit_number=$1 AND group_key=$2
+		FOR UPDATE
+	`,
+	insertGroup: `
+		INSERT INTO
+			RegressionGroups (commit_number, group_key, regression_ids, triage_status, triage_message, notification_id, creation_time)
+		VALUES
+			($1, $2, $3, $4, '', '', $5)
+		RETURNING
+			id
+	`,
+	appendRegressionID: `
+		UPDATE
+			RegressionGroups
+		SET
+			regression_ids=array_append(regression_ids, $1)
+		WHERE
+			id=$2
+	`,
+	getGroup: `
+		SELECT
+			id, commit_number, group_key, regression_ids, triage_status, triage_message, notification_id, creation_time
+		FROM
+			RegressionGroups
+		WHERE
+			id=$1
+	`,
+	rangeGroups: `
+		SELECT
+			id, commit_number, group_key, regression_ids, triage_status, triage_message, notification_id, creation_time
+		FROM
+			RegressionGroups
+		WHERE
+			commit_number >= $1 AND commit_number <= $2
+		ORDER BY
+			commit_number, group_key
+	`,
+	setNotificationID: `
+		UPDATE
+			RegressionGroups
+		SET
+			notification_id=$1
+		WHERE
+			id=$2
+	`,
+	setTriage: `
+		UPDATE
+			RegressionGroups
+		SET
+			triage_status=$1, triage_message=$2
+		WHERE
+			id=$3
+	`,
+}
+
+// RegressionGroupStore implements the regressiongroup.Store interface using
+// an SQL database.
+type RegressionGroupStore struct {
+	db pool.Pool
+}
+
+// New returns a new *RegressionGroupStore.
+func New(db pool.Pool) *RegressionGroupStore {
+	return &RegressionGroupStore{
+		db: db,
+	}
+}
+
+// scanGroup reads a single group from the result of one of the SELECT
+// statements.
+func scanGroup(row pgx.Row) (*regressiongroup.Group, error) {
+	g := &regressiongroup.Group{}
+	var status string
+	if err := row.Scan(&g.ID, &g.CommitNumber, &g.GroupKey, &g.RegressionIDs, &status, &g.Triage.Message, &g.NotificationID, &g.CreationTime); err != nil {
+		return nil, err
+	}
+	g.Triage.Status = regression.Status(status)
+	return g, nil
+}
+
+// AddRegression implements the regressiongroup.Store interface.
+func (s *RegressionGroupStore) AddRegression(ctx context.Context, commitNumber types.CommitNumber, groupKey string, regressionID string) (*regressiongroup.Group, bool, error) {
+	if regressionID == "" {
+		return nil, false, skerr.Fmt("A regression id is required.")
+	}
+
+	// Do everything in a transaction so that concurrent additions to the same
+	// group aren't lost.
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, false, skerr.Wrapf(err, "Can't start transaction")
+	}
+
+	g, err := scanGroup(tx.QueryRow(ctx, statements[readGroupForUpdate], commitNumber, groupKey))
+	if err == pgx.ErrNoRows {
+		g = &regressiongroup.Group{
+			CommitNumber:  commitNumber,
+			GroupKey:      groupKey,
+			RegressionIDs: []string{regressionID},
+			Triage: regression.TriageStatus{
+				Status: regression.Untriaged,
+			},
+			CreationTime: time.Now().Unix(),
+		}
+		if err := tx.QueryRow(ctx, statements[insertGroup], commitNumber, groupKey, g.RegressionIDs, string(g.Triage.Status), g.CreationTime).Scan(&g.ID); err != nil {
+			rollbackTransaction(ctx, tx)
+			return nil, false, skerr.Wrapf(err, "Failed to create regression group for commit %d and key %q", commitNumber, groupKey)
+		}
+		return g, true, skerr.Wrap(tx.Commit(ctx))
+	} else if err != nil {
+		rollbackTransaction(ctx, tx)
+		return nil, false, skerr.Wrapf(err, "Failed to read regression group for commit %d and key %q", commitNumber, groupKey)
+	}
+
+	if util.In(regressionID, g.RegressionIDs) {
+		rollbackTransaction(ctx, tx)
+		return g, false, nil
+	}
+	if _, err := tx.Exec(ctx, statements[appendRegressionID], regressionID, g.ID); err != nil {
+		rollbackTransaction(ctx, tx)
+		return nil, false, skerr.Wrapf(err, "Failed to add regression to group %s", g.ID)
+	}
+	g.RegressionIDs = append(g.RegressionIDs, regressionID)
+	return g, false, skerr.Wrap(tx.Commit(ctx))
+}
+
+// Get implements the regressiongroup.Store interface.
+func (s *RegressionGroupStore) Get(ctx context.Context, id string) (*regressiongroup.Group, error) {
+	g, err := scanGroup(s.db.QueryRow(ctx, statements[getGroup], id))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to load regression group %s", id)
+	}
+	return g, nil
+}
+
+// Range implements the regressiongroup.Store interface.
+func (s *RegressionGroupStore) Range(ctx context.Context, begin, end types.CommitNumber) ([]*regressiongroup.Group, error) {
+	rows, err := s.db.Query(ctx, statements[rangeGroups], begin, end)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to query regression groups for [%d, %d]", begin, end)
+	}
+	defer rows.Close()
+
+	ret := []*regressiongroup.Group{}
+	for rows.Next() {
+		g, err := scanGroup(rows)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Failed to read regression group")
+		}
+		ret = append(ret, g)
+	}
+	return ret, nil
+}
+
+// SetNotificationID implements the regressiongroup.Store interface.
+func (s *RegressionGroupStore) SetNotificationID(ctx context.Context, id string, notificationID string) error {
+	return s.update(ctx, id, statements[setNotificationID], notificationID, id)
+}
+
+// SetTriage implements the regressiongroup.Store interface.
+func (s *RegressionGroupStore) SetTriage(ctx context.Context, id string, tr regression.TriageStatus) error {
+	return s.update(ctx, id, statements[setTriage], string(tr.Status), tr.Message, id)
+}
+
+// update runs an UPDATE statement that must change the group with the given
+// id.
+func (s *RegressionGroupStore) update(ctx context.Context, id string, sql string, args ...interface{}) error {
+	tag, err := s.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return skerr.Wrapf(err, "Failed to update regression group %s", id)
+	}
+	if tag.RowsAffected() != 1 {
+		return skerr.Fmt("No such regression group: %s", id)
+	}
+	return nil
+}
+
+func rollbackTransaction(ctx context.Context, tx pgx.Tx) {
+	if err := tx.Rollback(ctx); err != nil {
+		sklog.Errorf("Failed on rollback: %s", err)
+	}
+}
+
+// Confirm RegressionGroupStore implements regressiongroup.Store.
+var _ regressiongroup.Store = (*RegressionGroupStore)(nil)
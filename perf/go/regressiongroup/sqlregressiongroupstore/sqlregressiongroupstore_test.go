@@ -0,0 +1,101 @@
+package sqlregressiongroupstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/perf/go/regression"
+	"go.skia.org/infra/perf/go/regressiongroup"
+	"go.skia.org/infra/perf/go/sql/sqltest"
+)
+
+const (
+	regressionID1 = "11111111-1111-1111-1111-111111111111"
+	regressionID2 = "22222222-2222-2222-2222-222222222222"
+)
+
+func setUp(t *testing.T) regressiongroup.Store {
+	db := sqltest.NewCockroachDBForTests(t, "regressiongroupstore")
+	return New(db)
+}
+
+func TestAddRegression_NewGroup_GroupIsCreatedUntriaged(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+
+	g, isNew, err := store.AddRegression(ctx, 10, "benchmark=canvas", regressionID1)
+	require.NoError(t, err)
+	assert.True(t, isNew)
+	assert.NotEmpty(t, g.ID)
+	assert.Equal(t, []string{regressionID1}, g.RegressionIDs)
+	assert.Equal(t, regression.Untriaged, g.Triage.Status)
+
+	loaded, err := store.Get(ctx, g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, g, loaded)
+}
+
+func TestAddRegression_SameCommitAndKey_RegressionIsAddedToExistingGroup(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+
+	first, _, err := store.AddRegression(ctx, 10, "benchmark=canvas", regressionID1)
+	require.NoError(t, err)
+	second, isNew, err := store.AddRegression(ctx, 10, "benchmark=canvas", regressionID2)
+	require.NoError(t, err)
+	assert.False(t, isNew)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, []string{regressionID1, regressionID2}, second.RegressionIDs)
+
+	// Adding a regression a second time doesn't change the group.
+	third, isNew, err := store.AddRegression(ctx, 10, "benchmark=canvas", regressionID1)
+	require.NoError(t, err)
+	assert.False(t, isNew)
+	assert.Equal(t, []string{regressionID1, regressionID2}, third.RegressionIDs)
+}
+
+func TestAddRegression_DifferentCommitOrKey_NewGroupsAreCreated(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+
+	_, isNew, err := store.AddRegression(ctx, 10, "benchmark=canvas", regressionID1)
+	require.NoError(t, err)
+	assert.True(t, isNew)
+	_, isNew, err = store.AddRegression(ctx, 11, "benchmark=canvas", regressionID2)
+	require.NoError(t, err)
+	assert.True(t, isNew)
+	_, isNew, err = store.AddRegression(ctx, 10, "benchmark=skp", regressionID2)
+	require.NoError(t, err)
+	assert.True(t, isNew)
+
+	groups, err := store.Range(ctx, 10, 10)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "benchmark=canvas", groups[0].GroupKey)
+	assert.Equal(t, "benchmark=skp", groups[1].GroupKey)
+}
+
+func TestSetTriageAndNotificationID_ValuesAreStored(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	g, _, err := store.AddRegression(ctx, 10, "benchmark=canvas", regressionID1)
+	require.NoError(t, err)
+
+	tr := regression.TriageStatus{Status: regression.Negative, Message: "Bad roll."}
+	require.NoError(t, store.SetTriage(ctx, g.ID, tr))
+	require.NoError(t, store.SetNotificationID(ctx, g.ID, "issue-123"))
+
+	loaded, err := store.Get(ctx, g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, tr, loaded.Triage)
+	assert.Equal(t, "issue-123", loaded.NotificationID)
+}
+
+func TestSetTriage_UnknownGroup_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	err := store.SetTriage(ctx, regressionID1, regression.TriageStatus{Status: regression.Positive})
+	require.Error(t, err)
+}
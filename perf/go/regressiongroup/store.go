@@ -0,0 +1,95 @@
+// Package regressiongroup merges the regressions that a single commit causes
+// across many traces into one Group, so that sheriffs triage, and are
+// notified about, the commit once instead of once per regression.
+//
+// Regressions are grouped if they are found at the same commit and their
+// traces have the same values for the configured grouping keys, see
+// config.AnomalyConfig.GroupingKeys.
+package regressiongroup
+
+import (
+	"context"
+	"net/url"
+
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/query"
+	"go.skia.org/infra/perf/go/regression"
+	"go.skia.org/infra/perf/go/types"
+)
+
+// Group is a set of regressions found at the same commit in similar traces.
+type Group struct {
+	// ID uniquely identifies the group. It is assigned by the Store.
+	ID string `json:"id"`
+
+	// CommitNumber is the commit all the regressions in the group were found
+	// at.
+	CommitNumber types.CommitNumber `json:"commit_number"`
+
+	// GroupKey identifies the traces of the regressions in the group, see
+	// Key().
+	GroupKey string `json:"group_key"`
+
+	// RegressionIDs are the ids of the regressions in the group, in the order
+	// they were added.
+	RegressionIDs []string `json:"regression_ids"`
+
+	// Triage is the triage status of the group, which is applied to every
+	// regression in the group.
+	Triage regression.TriageStatus `json:"triage"`
+
+	// NotificationID is the id of the single notification sent for the
+	// group, which may be empty if no notification was sent.
+	NotificationID string `json:"notification_id"`
+
+	// CreationTime is the time the group was created, as a Unix timestamp.
+	CreationTime int64 `json:"creation_time"`
+}
+
+// Key returns the group key for a regression in the given traces. It is made
+// of the values the traces have for each of the groupingKeys, so regressions
+// in traces that only differ in other params get the same key.
+//
+// For example, with groupingKeys of ["benchmark"] the traces
+// ",benchmark=canvas,test=draw," and ",benchmark=canvas,test=fill," both
+// have a key of "benchmark=canvas".
+func Key(traceIDs []string, groupingKeys []string) string {
+	ps := paramtools.ParamSet{}
+	for _, traceID := range traceIDs {
+		params, err := query.ParseKey(traceID)
+		if err != nil {
+			continue
+		}
+		for _, key := range groupingKeys {
+			if value, ok := params[key]; ok {
+				ps.AddParams(paramtools.Params{key: value})
+			}
+		}
+	}
+	ps.Normalize()
+	return url.Values(ps).Encode()
+}
+
+// Store is the interface used to persist regression groups.
+type Store interface {
+	// AddRegression adds the regression to the group with the given commit
+	// number and group key, creating the group if it doesn't exist yet. It
+	// returns the group and true if the group was created. Adding a
+	// regression that is already in the group does nothing.
+	AddRegression(ctx context.Context, commitNumber types.CommitNumber, groupKey string, regressionID string) (*Group, bool, error)
+
+	// Get returns the group with the given ID.
+	Get(ctx context.Context, id string) (*Group, error)
+
+	// Range returns all the groups found at commits in [begin, end], sorted
+	// by commit number.
+	Range(ctx context.Context, begin, end types.CommitNumber) ([]*Group, error)
+
+	// SetNotificationID records the id of the notification sent for the
+	// group.
+	SetNotificationID(ctx context.Context, id string, notificationID string) error
+
+	// SetTriage sets the triage status of the group. It doesn't change the
+	// triage status of the regressions in the group.
+	SetTriage(ctx context.Context, id string, tr regression.TriageStatus) error
+}
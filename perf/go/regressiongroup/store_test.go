@@ -0,0 +1,32 @@
+package regressiongroup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey_TracesDifferOnlyInOtherKeys_ReturnsSameKey(t *testing.T) {
+	groupingKeys := []string{"benchmark", "bot"}
+	assert.Equal(t,
+		Key([]string{",benchmark=canvas,bot=linux,test=draw,"}, groupingKeys),
+		Key([]string{",benchmark=canvas,bot=linux,test=fill,"}, groupingKeys))
+}
+
+func TestKey_TracesDifferInGroupingKey_ReturnsDifferentKeys(t *testing.T) {
+	groupingKeys := []string{"benchmark", "bot"}
+	assert.NotEqual(t,
+		Key([]string{",benchmark=canvas,bot=linux,test=draw,"}, groupingKeys),
+		Key([]string{",benchmark=canvas,bot=mac,test=draw,"}, groupingKeys))
+}
+
+func TestKey_MultipleTraces_KeyIsIndependentOfTraceOrder(t *testing.T) {
+	groupingKeys := []string{"bot"}
+	key := Key([]string{",bot=mac,test=draw,", ",bot=linux,test=fill,"}, groupingKeys)
+	assert.Equal(t, "bot=linux&bot=mac", key)
+	assert.Equal(t, key, Key([]string{",bot=linux,test=fill,", ",bot=mac,test=draw,"}, groupingKeys))
+}
+
+func TestKey_InvalidTraceIDsAreIgnored(t *testing.T) {
+	assert.Equal(t, "bot=linux", Key([]string{"not-a-trace-id", ",bot=linux,"}, []string{"bot"}))
+}
@@ -0,0 +1,23 @@
+package schema
+
+// ReportSchema represents the SQL schema of the Reports table.
+type ReportSchema struct {
+	// Unique identifier of the report.
+	ID string `sql:"id UUID PRIMARY KEY DEFAULT gen_random_uuid()"`
+
+	// The title of the report.
+	Name string `sql:"name STRING NOT NULL"`
+
+	// Text displayed at the top of the report.
+	Description string `sql:"description STRING"`
+
+	// The graphs in the report, stored as a JSON encoded []report.Graph.
+	Graphs string `sql:"graphs TEXT"`
+
+	// The user who created the report, as their email as returned by
+	// uber-proxy auth.
+	Owner string `sql:"owner STRING NOT NULL"`
+
+	// Stored as a Unix timestamp.
+	LastModified int `sql:"last_modified INT"`
+}
@@ -0,0 +1,161 @@
+// Package sqlreportstore implements report.Store using an SQL database.
+package sqlreportstore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sql/pool"
+	"go.skia.org/infra/perf/go/report"
+)
+
+// statement is an SQL statement identifier.
+type statement int
+
+const (
+	// The identifiers for all the SQL statements used.
+	insertReport statement = iota
+	updateReport
+	getReport
+	listReports
+)
+
+// statements holds all the raw SQL statements.
+var statements = map[statement]string{
+	insertReport: `
+		INSERT INTO
+			Reports (name, description, graphs, owner, last_modified)
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING
+			id
+	`,
+	updateReport: `
+		UPDATE
+			Reports
+		SET
+			(name, description, graphs, last_modified) = ($1, $2, $3, $4)
+		WHERE
+			id=$5
+	`,
+	getReport: `
+		SELECT
+			id, name, description, graphs, owner, last_modified
+		FROM
+			Reports
+		WHERE
+			id=$1
+	`,
+	listReports: `
+		SELECT
+			id, name, description, graphs, owner, last_modified
+		FROM
+			Reports
+		ORDER BY
+			name
+	`,
+}
+
+// ReportStore implements the report.Store interface using an SQL database.
+type ReportStore struct {
+	db pool.Pool
+}
+
+// New returns a new *ReportStore.
+func New(db pool.Pool) *ReportStore {
+	return &ReportStore{
+		db: db,
+	}
+}
+
+// Create implements the report.Store interface.
+func (s *ReportStore) Create(ctx context.Context, r *report.Report) (string, error) {
+	if err := r.Validate(); err != nil {
+		return "", skerr.Wrap(err)
+	}
+	graphs, err := json.Marshal(r.Graphs)
+	if err != nil {
+		return "", skerr.Wrapf(err, "Failed to encode graphs")
+	}
+	var id string
+	if err := s.db.QueryRow(ctx, statements[insertReport], r.Name, r.Description, string(graphs), r.Owner, time.Now().Unix()).Scan(&id); err != nil {
+		return "", skerr.Wrapf(err, "Failed to insert report")
+	}
+	return id, nil
+}
+
+// Update implements the report.Store interface.
+func (s *ReportStore) Update(ctx context.Context, r *report.Report) error {
+	if err := r.Validate(); err != nil {
+		return skerr.Wrap(err)
+	}
+	graphs, err := json.Marshal(r.Graphs)
+	if err != nil {
+		return skerr.Wrapf(err, "Failed to encode graphs")
+	}
+	call, err := s.db.Exec(ctx, statements[updateReport], r.Name, r.Description, string(graphs), time.Now().Unix(), r.ID)
+	if err != nil {
+		return skerr.Wrapf(err, "Failed to update report with id=%s", r.ID)
+	}
+	if call.RowsAffected() != 1 {
+		return skerr.Fmt("No such report: %s", r.ID)
+	}
+	return nil
+}
+
+// Get implements the report.Store interface.
+func (s *ReportStore) Get(ctx context.Context, id string) (*report.Report, error) {
+	r, err := scanReport(s.db.QueryRow(ctx, statements[getReport], id))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to load report with id=%s", id)
+	}
+	return r, nil
+}
+
+// List implements the report.Store interface.
+func (s *ReportStore) List(ctx context.Context) ([]*report.Report, error) {
+	rows, err := s.db.Query(ctx, statements[listReports])
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to query reports")
+	}
+	defer rows.Close()
+
+	ret := []*report.Report{}
+	for rows.Next() {
+		r, err := scanReport(rows)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Failed to read report")
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+// scanReport reads a report from a row returned by the getReport or
+// listReports statements.
+func scanReport(row pgx.Row) (*report.Report, error) {
+	r := &report.Report{}
+	var description, graphs *string
+	var lastModified *int64
+	if err := row.Scan(&r.ID, &r.Name, &description, &graphs, &r.Owner, &lastModified); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	if description != nil {
+		r.Description = *description
+	}
+	if graphs != nil {
+		if err := json.Unmarshal([]byte(*graphs), &r.Graphs); err != nil {
+			return nil, skerr.Wrapf(err, "Failed to decode graphs of report with id=%s", r.ID)
+		}
+	}
+	if lastModified != nil {
+		r.LastModified = *lastModified
+	}
+	return r, nil
+}
+
+// Confirm ReportStore implements report.Store.
+var _ report.Store = (*ReportStore)(nil)
@@ -0,0 +1,95 @@
+package sqlreportstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/perf/go/report"
+	"go.skia.org/infra/perf/go/sql/sqltest"
+)
+
+func setUp(t *testing.T) report.Store {
+	db := sqltest.NewCockroachDBForTests(t, "reportstore")
+	return New(db)
+}
+
+func newReportForTest(name string) *report.Report {
+	return &report.Report{
+		Name:        name,
+		Description: "Regressions found this week.",
+		Graphs: []report.Graph{
+			{Queries: []string{"arch=x86&config=8888"}, Begin: 10, End: 20, Note: "Look at the jump at 15."},
+			{Formulas: []string{"norm(filter(\"arch=arm\"))"}},
+		},
+		Owner: "a@b.com",
+	}
+}
+
+func TestCreate_Get_ReturnsReport(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	expected := newReportForTest("Memory regressions")
+	id, err := store.Create(ctx, expected)
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+
+	r, err := store.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, id, r.ID)
+	assert.Equal(t, expected.Name, r.Name)
+	assert.Equal(t, expected.Description, r.Description)
+	assert.Equal(t, expected.Graphs, r.Graphs)
+	assert.Equal(t, "a@b.com", r.Owner)
+	assert.NotZero(t, r.LastModified)
+}
+
+func TestCreate_InvalidReport_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	_, err := store.Create(ctx, &report.Report{Name: "No graphs", Owner: "a@b.com"})
+	require.Error(t, err)
+}
+
+func TestUpdate_ExistingReport_ChangesEverythingButOwner(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	id, err := store.Create(ctx, newReportForTest("Memory regressions"))
+	require.NoError(t, err)
+
+	updated := newReportForTest("Renamed")
+	updated.ID = id
+	updated.Owner = "someone-else@b.com"
+	updated.Graphs = updated.Graphs[:1]
+	require.NoError(t, store.Update(ctx, updated))
+
+	r, err := store.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", r.Name)
+	assert.Len(t, r.Graphs, 1)
+	assert.Equal(t, "a@b.com", r.Owner)
+}
+
+func TestUpdate_NoSuchReport_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	r := newReportForTest("Memory regressions")
+	r.ID = "00000000-0000-0000-0000-000000000000"
+	require.Error(t, store.Update(ctx, r))
+}
+
+func TestList_ReturnsReportsSortedByName(t *testing.T) {
+	ctx := context.Background()
+	store := setUp(t)
+	_, err := store.Create(ctx, newReportForTest("b"))
+	require.NoError(t, err)
+	_, err = store.Create(ctx, newReportForTest("a"))
+	require.NoError(t, err)
+
+	reports, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, "a", reports[0].Name)
+	assert.Equal(t, "b", reports[1].Name)
+}
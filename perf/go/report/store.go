@@ -0,0 +1,118 @@
+// Package report stores reports, which are named collections of graphs that
+// are rendered together on a single page, so that sheriffs can share one link
+// instead of pasting many graph links into a doc.
+package report
+
+import (
+	"context"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/perf/go/types"
+)
+
+const (
+	// maxNameLength is the maximum length in bytes of Report.Name.
+	maxNameLength = 256
+
+	// maxNoteLength is the maximum length in bytes of Report.Description and
+	// Graph.Note.
+	maxNoteLength = 4096
+
+	// maxGraphs is the maximum number of graphs in a Report.
+	maxGraphs = 50
+)
+
+// Graph is a single graph in a Report.
+type Graph struct {
+	// Queries are the trace queries to plot, e.g. "arch=x86&config=8888".
+	Queries []string `json:"queries"`
+
+	// Formulas are the formulas to plot, e.g. "norm(filter(...))".
+	Formulas []string `json:"formulas"`
+
+	// Keys is the id of a shortcut for a set of trace ids to plot.
+	Keys string `json:"keys"`
+
+	// Begin is the first commit to display.
+	Begin types.CommitNumber `json:"begin"`
+
+	// End is the last commit to display, inclusive. If Begin and End are
+	// both zero the graph displays the most recent commits.
+	End types.CommitNumber `json:"end"`
+
+	// Note is text displayed alongside the graph, e.g. an explanation of
+	// what the graph shows.
+	Note string `json:"note"`
+}
+
+// Report is a named collection of graphs.
+type Report struct {
+	// ID uniquely identifies the report. It is assigned by the Store.
+	ID string `json:"id"`
+
+	// Name is the title of the report.
+	Name string `json:"name"`
+
+	// Description is text displayed at the top of the report.
+	Description string `json:"description"`
+
+	// Graphs are the graphs in the report, in display order.
+	Graphs []Graph `json:"graphs"`
+
+	// Owner is the email address of the user that created the report.
+	Owner string `json:"owner"`
+
+	// LastModified is the time the report was last changed, as a Unix
+	// timestamp.
+	LastModified int64 `json:"last_modified"`
+}
+
+// Validate returns an error if the Report is not valid.
+func (r *Report) Validate() error {
+	if r.Name == "" {
+		return skerr.Fmt("A name is required.")
+	}
+	if len(r.Name) > maxNameLength {
+		return skerr.Fmt("Name is too long, the maximum length is %d.", maxNameLength)
+	}
+	if len(r.Description) > maxNoteLength {
+		return skerr.Fmt("Description is too long, the maximum length is %d.", maxNoteLength)
+	}
+	if len(r.Graphs) == 0 {
+		return skerr.Fmt("A report must contain at least one graph.")
+	}
+	if len(r.Graphs) > maxGraphs {
+		return skerr.Fmt("Too many graphs, the maximum is %d.", maxGraphs)
+	}
+	for i, g := range r.Graphs {
+		if len(g.Queries) == 0 && len(g.Formulas) == 0 && g.Keys == "" {
+			return skerr.Fmt("Graph %d must have at least one query, formula, or keys.", i+1)
+		}
+		if g.Begin < 0 {
+			return skerr.Fmt("Graph %d: Begin must be a valid commit number, got %d.", i+1, g.Begin)
+		}
+		if g.End < g.Begin {
+			return skerr.Fmt("Graph %d: End (%d) must not come before Begin (%d).", i+1, g.End, g.Begin)
+		}
+		if len(g.Note) > maxNoteLength {
+			return skerr.Fmt("Graph %d: Note is too long, the maximum length is %d.", i+1, maxNoteLength)
+		}
+	}
+	return nil
+}
+
+// Store is the interface used to persist reports.
+type Store interface {
+	// Create stores a new report and returns its ID.
+	Create(ctx context.Context, r *Report) (string, error)
+
+	// Update replaces the name, description, and graphs of the report with
+	// the ID r.ID. The owner of the report is not changed.
+	Update(ctx context.Context, r *Report) error
+
+	// Get returns the report with the given ID.
+	Get(ctx context.Context, id string) (*Report, error)
+
+	// List returns all the reports, sorted by name.
+	List(ctx context.Context) ([]*Report, error)
+}
@@ -0,0 +1,60 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newReportForTest() *Report {
+	return &Report{
+		Name: "Memory regressions",
+		Graphs: []Graph{
+			{Queries: []string{"arch=x86&config=8888"}, Begin: 10, End: 20, Note: "Look at the jump at 15."},
+			{Formulas: []string{"norm(filter(\"arch=arm\"))"}},
+		},
+	}
+}
+
+func TestValidate_ValidReport_ReturnsNil(t *testing.T) {
+	require.NoError(t, newReportForTest().Validate())
+}
+
+func TestValidate_EmptyName_ReturnsError(t *testing.T) {
+	r := newReportForTest()
+	r.Name = ""
+	require.Error(t, r.Validate())
+}
+
+func TestValidate_NoGraphs_ReturnsError(t *testing.T) {
+	r := newReportForTest()
+	r.Graphs = nil
+	require.Error(t, r.Validate())
+}
+
+func TestValidate_TooManyGraphs_ReturnsError(t *testing.T) {
+	r := newReportForTest()
+	for len(r.Graphs) <= maxGraphs {
+		r.Graphs = append(r.Graphs, r.Graphs[0])
+	}
+	require.Error(t, r.Validate())
+}
+
+func TestValidate_EmptyGraph_ReturnsError(t *testing.T) {
+	r := newReportForTest()
+	r.Graphs = append(r.Graphs, Graph{Begin: 1, End: 2})
+	require.Error(t, r.Validate())
+}
+
+func TestValidate_EndBeforeBegin_ReturnsError(t *testing.T) {
+	r := newReportForTest()
+	r.Graphs[0].End = 9
+	require.Error(t, r.Validate())
+}
+
+func TestValidate_NoteTooLong_ReturnsError(t *testing.T) {
+	r := newReportForTest()
+	r.Graphs[1].Note = strings.Repeat("a", maxNoteLength+1)
+	require.Error(t, r.Validate())
+}
@@ -0,0 +1,117 @@
+// Package snapshot stores frozen copies of the data shown in a graph, so that
+// links to a snapshot keep showing the data as it was when the snapshot was
+// taken, even as new data arrives or old data is deleted.
+package snapshot
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"time"
+
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/perf/go/ui/frame"
+)
+
+// validID matches the IDs returned from Store.Put.
+var validID = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// Snapshot is the data and graph configuration captured for a permalink.
+type Snapshot struct {
+	// Request is the request that was used to build Response.
+	Request *frame.FrameRequest `json:"request"`
+
+	// Response holds the DataFrame as it was when the snapshot was taken.
+	Response *frame.FrameResponse `json:"response"`
+
+	// GraphConfig is the configuration of the graph as it was rendered by the
+	// client. It is opaque to the server.
+	GraphConfig map[string]interface{} `json:"graph_config,omitempty"`
+
+	// Created is when the snapshot was taken.
+	Created time.Time `json:"created"`
+
+	// User is the email of the user that took the snapshot, if they were
+	// logged in.
+	User string `json:"user,omitempty"`
+}
+
+// Store persists Snapshots.
+type Store interface {
+	// Put stores the snapshot and returns its ID. Snapshots are never
+	// modified once stored.
+	Put(ctx context.Context, s *Snapshot) (string, error)
+
+	// Get returns the snapshot with the given ID.
+	Get(ctx context.Context, id string) (*Snapshot, error)
+}
+
+// gcsStore implements Store by writing each Snapshot to a JSON file in Google
+// Cloud Storage, named by the hash of its contents.
+type gcsStore struct {
+	client gcs.GCSClient
+	dir    string
+}
+
+// NewGCSStore returns a Store that writes snapshots to files in dir in the
+// client's bucket.
+func NewGCSStore(client gcs.GCSClient, dir string) Store {
+	return &gcsStore{
+		client: client,
+		dir:    dir,
+	}
+}
+
+func (g *gcsStore) filename(id string) string {
+	return path.Join(g.dir, id+".json")
+}
+
+// Put implements Store.
+func (g *gcsStore) Put(ctx context.Context, s *Snapshot) (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", skerr.Wrapf(err, "encoding snapshot")
+	}
+	id := fmt.Sprintf("%x", md5.Sum(b))
+	filename := g.filename(id)
+
+	// The ID is a hash of the contents, so an existing file already has the
+	// same contents and there's no need to write it again.
+	exists, err := g.client.DoesFileExist(ctx, filename)
+	if err != nil {
+		return "", skerr.Wrapf(err, "checking for existing snapshot %q", filename)
+	}
+	if exists {
+		return id, nil
+	}
+	opts := gcs.FileWriteOptions{
+		ContentType: "application/json",
+	}
+	if err := g.client.SetFileContents(ctx, filename, opts, b); err != nil {
+		return "", skerr.Wrapf(err, "writing snapshot %q", filename)
+	}
+	return id, nil
+}
+
+// Get implements Store.
+func (g *gcsStore) Get(ctx context.Context, id string) (*Snapshot, error) {
+	if !validID.MatchString(id) {
+		return nil, skerr.Fmt("invalid snapshot id %q", id)
+	}
+	b, err := g.client.GetFileContents(ctx, g.filename(id))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "reading snapshot %q", id)
+	}
+	var ret Snapshot
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return nil, skerr.Wrapf(err, "decoding snapshot %q", id)
+	}
+	return &ret, nil
+}
+
+// Confirm gcsStore implements Store.
+var _ Store = (*gcsStore)(nil)
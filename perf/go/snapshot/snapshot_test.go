@@ -0,0 +1,82 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/gcs/mem_gcsclient"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/perf/go/dataframe"
+	"go.skia.org/infra/perf/go/types"
+	"go.skia.org/infra/perf/go/ui/frame"
+)
+
+func newSnapshotForTest() *Snapshot {
+	return &Snapshot{
+		Request: &frame.FrameRequest{
+			Begin:   100,
+			End:     200,
+			Queries: []string{"arch=x86"},
+		},
+		Response: &frame.FrameResponse{
+			DataFrame: &dataframe.DataFrame{
+				TraceSet: types.TraceSet{
+					",arch=x86,": types.Trace{1, 2, 3},
+				},
+				ParamSet: paramtools.NewReadOnlyParamSet(),
+			},
+		},
+		GraphConfig: map[string]interface{}{"zoom": []interface{}{1.0, 2.0}},
+		Created:     time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		User:        "someone@example.com",
+	}
+}
+
+func TestPutGet_RoundTrip_Success(t *testing.T) {
+	ctx := context.Background()
+	client := mem_gcsclient.New("bucket")
+	store := NewGCSStore(client, "snapshots")
+
+	s := newSnapshotForTest()
+	id, err := store.Put(ctx, s)
+	require.NoError(t, err)
+	assert.Regexp(t, validID, id)
+
+	exists, err := client.DoesFileExist(ctx, "snapshots/"+id+".json")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	got, err := store.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, s.Request, got.Request)
+	assert.Equal(t, s.Response.DataFrame.TraceSet, got.Response.DataFrame.TraceSet)
+	assert.Equal(t, s.GraphConfig, got.GraphConfig)
+	assert.Equal(t, s.Created, got.Created)
+	assert.Equal(t, s.User, got.User)
+}
+
+func TestPut_SameSnapshotTwice_ReturnsSameID(t *testing.T) {
+	ctx := context.Background()
+	store := NewGCSStore(mem_gcsclient.New("bucket"), "snapshots")
+
+	id1, err := store.Put(ctx, newSnapshotForTest())
+	require.NoError(t, err)
+	id2, err := store.Put(ctx, newSnapshotForTest())
+	require.NoError(t, err)
+	assert.Equal(t, id1, id2)
+}
+
+func TestGet_InvalidID_ReturnsError(t *testing.T) {
+	store := NewGCSStore(mem_gcsclient.New("bucket"), "snapshots")
+	_, err := store.Get(context.Background(), "../secrets")
+	require.Error(t, err)
+}
+
+func TestGet_UnknownID_ReturnsError(t *testing.T) {
+	store := NewGCSStore(mem_gcsclient.New("bucket"), "snapshots")
+	_, err := store.Get(context.Background(), "0123456789abcdef0123456789abcdef")
+	require.Error(t, err)
+}
@@ -30,6 +30,9 @@ import (
 //   - FromLiveToNext tells the SQL to execute to apply the change
 //   - FromNextToLive tells the SQL to revert the change
 //
+// Until a change is deployed, i.e. it is part of schema_prev.json, keep its
+// statements in both vars when adding another change.
+//
 // Also we need to update LiveSchema schema and DropTables in sql_test.go:
 //   - DropTables deletes all tables *including* the new one in the change.
 //   - LiveSchema creates all existing tables *without* the new one in the
@@ -38,6 +41,35 @@ import (
 // DO NOT DROP TABLES IN VAR BELOW.
 // FOR MODIFYING COLUMNS USE ADD/DROP COLUMN INSTEAD.
 var FromLiveToNext = `
+	CREATE TABLE IF NOT EXISTS Annotations (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		begin_commit INT NOT NULL,
+		end_commit INT NOT NULL,
+		note STRING NOT NULL,
+		suppress_alerts BOOL NOT NULL DEFAULT false,
+		author STRING NOT NULL,
+		last_modified INT,
+		INDEX by_commit_range (begin_commit, end_commit)
+	);
+	CREATE TABLE IF NOT EXISTS RegressionGroups (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		commit_number INT NOT NULL,
+		group_key STRING NOT NULL,
+		regression_ids STRING ARRAY,
+		triage_status STRING,
+		triage_message STRING,
+		notification_id STRING,
+		creation_time INT,
+		UNIQUE INDEX by_commit_group_key (commit_number, group_key)
+	);
+	CREATE TABLE IF NOT EXISTS Reports (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		name STRING NOT NULL,
+		description STRING,
+		graphs TEXT,
+		owner STRING NOT NULL,
+		last_modified INT
+	);
 	CREATE TABLE IF NOT EXISTS UserIssues (
 		user_id TEXT NOT NULL,
 		trace_key TEXT NOT NULL,
@@ -51,6 +83,9 @@ var FromLiveToNext = `
 // ONLY DROP TABLE IF YOU JUST CREATED A NEW TABLE.
 // FOR MODIFYING COLUMNS USE ADD/DROP COLUMN INSTEAD.
 var FromNextToLive = `
+	DROP TABLE IF EXISTS Annotations;
+	DROP TABLE IF EXISTS RegressionGroups;
+	DROP TABLE IF EXISTS Reports;
 	DROP TABLE IF EXISTS UserIssues;
 `
 
@@ -11,6 +11,16 @@ const Schema = `CREATE TABLE IF NOT EXISTS Alerts (
   sub_name STRING,
   sub_revision STRING
 );
+CREATE TABLE IF NOT EXISTS Annotations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  begin_commit INT NOT NULL,
+  end_commit INT NOT NULL,
+  note STRING NOT NULL,
+  suppress_alerts BOOL NOT NULL DEFAULT false,
+  author STRING NOT NULL,
+  last_modified INT,
+  INDEX by_commit_range (begin_commit, end_commit)
+);
 CREATE TABLE IF NOT EXISTS AnomalyGroups (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
   creation_time TIMESTAMPTZ DEFAULT now(),
@@ -97,6 +107,25 @@ CREATE TABLE IF NOT EXISTS Regressions2 (
   INDEX by_alert_id (alert_id),
   INDEX by_commit_alert (commit_number, alert_id)
 );
+CREATE TABLE IF NOT EXISTS RegressionGroups (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  commit_number INT NOT NULL,
+  group_key STRING NOT NULL,
+  regression_ids STRING ARRAY,
+  triage_status STRING,
+  triage_message STRING,
+  notification_id STRING,
+  creation_time INT,
+  UNIQUE INDEX by_commit_group_key (commit_number, group_key)
+);
+CREATE TABLE IF NOT EXISTS Reports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name STRING NOT NULL,
+  description STRING,
+  graphs TEXT,
+  owner STRING NOT NULL,
+  last_modified INT
+);
 CREATE TABLE IF NOT EXISTS Shortcuts (
   id TEXT UNIQUE NOT NULL PRIMARY KEY,
   trace_ids TEXT
@@ -145,6 +174,16 @@ var Alerts = []string{
 	"sub_revision",
 }
 
+var Annotations = []string{
+	"id",
+	"begin_commit",
+	"end_commit",
+	"note",
+	"suppress_alerts",
+	"author",
+	"last_modified",
+}
+
 var AnomalyGroups = []string{
 	"id",
 	"creation_time",
@@ -231,6 +270,27 @@ var Regressions2 = []string{
 	"triage_message",
 }
 
+var RegressionGroups = []string{
+	"id",
+	"commit_number",
+	"group_key",
+	"regression_ids",
+	"triage_status",
+	"triage_message",
+	"notification_id",
+	"creation_time",
+	"UNIQUE",
+}
+
+var Reports = []string{
+	"id",
+	"name",
+	"description",
+	"graphs",
+	"owner",
+	"last_modified",
+}
+
 var Shortcuts = []string{
 	"id",
 	"trace_ids",
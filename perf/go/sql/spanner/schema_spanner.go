@@ -15,6 +15,16 @@ CREATE TABLE IF NOT EXISTS Alerts (
   createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
   PRIMARY KEY (id)
 );
+CREATE TABLE IF NOT EXISTS Annotations (
+  id TEXT PRIMARY KEY DEFAULT spanner.generate_uuid(),
+  begin_commit INT NOT NULL,
+  end_commit INT NOT NULL,
+  note TEXT NOT NULL,
+  suppress_alerts BOOL NOT NULL DEFAULT false,
+  author TEXT NOT NULL,
+  last_modified INT,
+  createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
 CREATE TABLE IF NOT EXISTS AnomalyGroups (
   id TEXT PRIMARY KEY DEFAULT spanner.generate_uuid(),
   creation_time TIMESTAMPTZ DEFAULT now(),
@@ -103,6 +113,26 @@ CREATE TABLE IF NOT EXISTS Regressions2 (
   triage_message TEXT,
   createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
 ) TTL INTERVAL '1095 days' ON createdat;
+CREATE TABLE IF NOT EXISTS RegressionGroups (
+  id TEXT PRIMARY KEY DEFAULT spanner.generate_uuid(),
+  commit_number INT NOT NULL,
+  group_key TEXT NOT NULL,
+  regression_ids TEXT ARRAY,
+  triage_status TEXT,
+  triage_message TEXT,
+  notification_id TEXT,
+  creation_time INT,
+  createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+) TTL INTERVAL '1095 days' ON createdat;
+CREATE TABLE IF NOT EXISTS Reports (
+  id TEXT PRIMARY KEY DEFAULT spanner.generate_uuid(),
+  name TEXT NOT NULL,
+  description TEXT,
+  graphs TEXT,
+  owner TEXT NOT NULL,
+  last_modified INT,
+  createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
 CREATE TABLE IF NOT EXISTS Shortcuts (
   id TEXT  NOT NULL PRIMARY KEY,
   trace_ids TEXT,
@@ -144,6 +174,7 @@ CREATE TABLE IF NOT EXISTS UserIssues (
   PRIMARY KEY(trace_key, commit_position),
   createdat TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
 ) TTL INTERVAL '1095 days' ON createdat;
+CREATE INDEX IF NOT EXISTS by_commit_range on Annotations (begin_commit, end_commit);
 CREATE INDEX IF NOT EXISTS by_revision on Culprits (revision, host, project, ref);
 CREATE INDEX IF NOT EXISTS by_user_id on Favorites (user_id);
 CREATE INDEX IF NOT EXISTS by_tile_number on ParamSets (tile_number DESC);
@@ -151,6 +182,7 @@ CREATE INDEX IF NOT EXISTS by_trace_id on Postings (tile_number, trace_id, key_v
 CREATE INDEX IF NOT EXISTS by_key_value on Postings (tile_number, key_value);
 CREATE INDEX IF NOT EXISTS by_alert_id on Regressions2 (alert_id);
 CREATE INDEX IF NOT EXISTS by_commit_alert on Regressions2 (commit_number, alert_id);
+CREATE INDEX IF NOT EXISTS by_commit_group_key on RegressionGroups (commit_number, group_key);
 CREATE INDEX IF NOT EXISTS by_source_file on SourceFiles (source_file, source_file_id);
 CREATE INDEX IF NOT EXISTS by_source_file_id on TraceValues (source_file_id, trace_id);
 `
@@ -164,6 +196,16 @@ var Alerts = []string{
 	"sub_revision",
 }
 
+var Annotations = []string{
+	"id",
+	"begin_commit",
+	"end_commit",
+	"note",
+	"suppress_alerts",
+	"author",
+	"last_modified",
+}
+
 var AnomalyGroups = []string{
 	"id",
 	"creation_time",
@@ -250,6 +292,27 @@ var Regressions2 = []string{
 	"triage_message",
 }
 
+var RegressionGroups = []string{
+	"id",
+	"commit_number",
+	"group_key",
+	"regression_ids",
+	"triage_status",
+	"triage_message",
+	"notification_id",
+	"creation_time",
+	"UNIQUE",
+}
+
+var Reports = []string{
+	"id",
+	"name",
+	"description",
+	"graphs",
+	"owner",
+	"last_modified",
+}
+
 var Shortcuts = []string{
 	"id",
 	"trace_ids",
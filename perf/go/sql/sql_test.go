@@ -16,6 +16,7 @@ import (
 
 const DropTables = `
 	DROP TABLE IF EXISTS Alerts;
+	DROP TABLE IF EXISTS Annotations;
 	DROP TABLE IF EXISTS AnomalyGroups;
 	DROP TABLE IF EXISTS Commits;
 	DROP TABLE IF EXISTS Culprits;
@@ -25,6 +26,8 @@ const DropTables = `
 	DROP TABLE IF EXISTS Postings;
 	DROP TABLE IF EXISTS Regressions;
 	DROP TABLE IF EXISTS Regressions2;
+	DROP TABLE IF EXISTS RegressionGroups;
+	DROP TABLE IF EXISTS Reports;
 	DROP TABLE IF EXISTS Shortcuts;
 	DROP TABLE IF EXISTS SourceFiles;
 	DROP TABLE IF EXISTS Subscriptions;
@@ -5,6 +5,7 @@ package sql
 
 import (
 	alertschema "go.skia.org/infra/perf/go/alerts/sqlalertstore/schema"
+	annotationschema "go.skia.org/infra/perf/go/annotation/sqlannotationstore/schema"
 	anomalygroupschema "go.skia.org/infra/perf/go/anomalygroup/sqlanomalygroupstore/schema"
 	culpritschema "go.skia.org/infra/perf/go/culprit/sqlculpritstore/schema"
 	favoriteschema "go.skia.org/infra/perf/go/favorites/sqlfavoritestore/schema"
@@ -12,6 +13,8 @@ import (
 	graphsshortcutschema "go.skia.org/infra/perf/go/graphsshortcut/graphsshortcutstore/schema"
 	regression2schema "go.skia.org/infra/perf/go/regression/sqlregression2store/schema"
 	regressionschema "go.skia.org/infra/perf/go/regression/sqlregressionstore/schema"
+	regressiongroupschema "go.skia.org/infra/perf/go/regressiongroup/sqlregressiongroupstore/schema"
+	reportschema "go.skia.org/infra/perf/go/report/sqlreportstore/schema"
 	shortcutschema "go.skia.org/infra/perf/go/shortcut/sqlshortcutstore/schema"
 	subscriptionschema "go.skia.org/infra/perf/go/subscription/sqlsubscriptionstore/schema"
 	traceschema "go.skia.org/infra/perf/go/tracestore/sqltracestore/schema"
@@ -20,19 +23,22 @@ import (
 
 // Tables represents the full schema of the SQL database.
 type Tables struct {
-	Alerts          []alertschema.AlertSchema
-	AnomalyGroups   []anomalygroupschema.AnomalyGroupSchema
-	Commits         []gitschema.Commit
-	Culprits        []culpritschema.CulpritSchema
-	Favorites       []favoriteschema.FavoriteSchema
-	GraphsShortcuts []graphsshortcutschema.GraphsShortcutSchema
-	ParamSets       []traceschema.ParamSetsSchema
-	Postings        []traceschema.PostingsSchema
-	Regressions     []regressionschema.RegressionSchema
-	Regressions2    []regression2schema.Regression2Schema
-	Shortcuts       []shortcutschema.ShortcutSchema
-	SourceFiles     []traceschema.SourceFilesSchema
-	Subscriptions   []subscriptionschema.SubscriptionSchema
-	TraceValues     []traceschema.TraceValuesSchema
-	UserIssues      []userissuesschema.UserIssueSchema
+	Alerts           []alertschema.AlertSchema
+	Annotations      []annotationschema.AnnotationSchema
+	AnomalyGroups    []anomalygroupschema.AnomalyGroupSchema
+	Commits          []gitschema.Commit
+	Culprits         []culpritschema.CulpritSchema
+	Favorites        []favoriteschema.FavoriteSchema
+	GraphsShortcuts  []graphsshortcutschema.GraphsShortcutSchema
+	ParamSets        []traceschema.ParamSetsSchema
+	Postings         []traceschema.PostingsSchema
+	Regressions      []regressionschema.RegressionSchema
+	Regressions2     []regression2schema.Regression2Schema
+	RegressionGroups []regressiongroupschema.RegressionGroupSchema
+	Reports          []reportschema.ReportSchema
+	Shortcuts        []shortcutschema.ShortcutSchema
+	SourceFiles      []traceschema.SourceFilesSchema
+	Subscriptions    []subscriptionschema.SubscriptionSchema
+	TraceValues      []traceschema.TraceValuesSchema
+	UserIssues       []userissuesschema.UserIssueSchema
 }
@@ -39,7 +39,9 @@ func main() {
 
 	ttlExcludeTables := []string{
 		"Alerts",
+		"Annotations",
 		"Favorites",
+		"Reports",
 		"Subscriptions",
 	}
 	generatedText := exporter.GenerateSQL(sql.Tables{}, packageName, exporter.SchemaAndColumnNames, schemaTargetDB, ttlExcludeTables)
@@ -2,6 +2,7 @@ package stepfit
 
 import (
 	"math"
+	"sort"
 
 	"github.com/aclements/go-moremath/stats"
 	"go.skia.org/infra/go/vec32"
@@ -25,6 +26,11 @@ const (
 
 	// minTraceSize is the smallest trace length we can analyze.
 	minTraceSize = 3
+
+	// madToStdDev is the constant factor that makes the median absolute
+	// deviation a consistent estimator of the standard deviation for normally
+	// distributed data.
+	madToStdDev = 1.4826
 )
 
 // AllStepFitStatus is the list of all StepFitStatus values.
@@ -174,6 +180,43 @@ func GetStepFitAtMid(trace []float32, stddevThreshold float32, interesting float
 			}
 			regression = stepSize
 		}
+	} else if stepDetection == types.CUSUMStep {
+		// The cumulative sum of the deviations from the mean of the whole
+		// trace, taken up to the turning point, is i*(n-i)/n * (y0-y1). It is
+		// scaled by stddev*sqrt(n), so that for a trace which is just noise
+		// it is approximately normally distributed with a standard deviation
+		// of 1/2 at the midpoint, whatever the length and the units of the
+		// trace. The threshold therefore sets the false positive rate. Note
+		// that a real step is not independent of the trace length: a perfect
+		// step at the midpoint scores roughly sqrt(n)/2, so longer traces
+		// detect smaller steps.
+		n := float32(len(trace))
+		mean := vec32.Mean(trace)
+		cusum := float32(0)
+		for _, v := range trace[:i] {
+			cusum += v - mean
+		}
+		s := vec32.StdDev(trace, mean)
+		if math.IsNaN(float64(s)) || s < stddevThreshold {
+			s = stddevThreshold
+		}
+		stepSize = (y0 - y1)
+		regression = cusum / (s * float32(math.Sqrt(float64(n))))
+	} else if stepDetection == types.MADStep {
+		// Compare the medians of both halves, scaled by the median absolute
+		// deviation of the first half, i.e. a robust version of a z-score.
+		m0 := median(trace[:i])
+		m1 := median(trace[i:])
+		deviations := make([]float32, i)
+		for j, v := range trace[:i] {
+			deviations[j] = float32(math.Abs(float64(v - m0)))
+		}
+		s := madToStdDev * median(deviations)
+		if math.IsNaN(float64(s)) || s < stddevThreshold {
+			s = stddevThreshold
+		}
+		stepSize = (m0 - m1)
+		regression = stepSize / s
 	} else /* types.MannWhitneyU  */ {
 		s1 := vec32.ToFloat64(trace[:i])
 		s2 := vec32.ToFloat64(trace[i:])
@@ -215,3 +258,17 @@ func GetStepFitAtMid(trace []float32, stddevThreshold float32, interesting float
 	ret.Regression = regression
 	return ret
 }
+
+// median returns the median of the given values, which are not modified.
+func median(values []float32) float32 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := vec32.Dup(values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -180,3 +180,55 @@ func TestStepFit_MannWhitneyU_UninterestingBecauseNotEnoughData(t *testing.T) {
 		&StepFit{LeastSquares: 0, TurningPoint: 0, StepSize: 0, Regression: 0, Status: "Uninteresting"},
 		GetStepFitAtMid([]float32{2, 2, x}, minStdDev, 0.01, types.MannWhitneyU))
 }
+
+func TestStepFit_CUSUM_Step(t *testing.T) {
+	assert.Equal(t,
+		&StepFit{TurningPoint: 2, StepSize: -1, Status: HIGH, Regression: -0.86602545, LeastSquares: InvalidLeastSquaresError},
+		GetStepFitAtMid([]float32{1, 1, 2, 2, x}, minStdDev, 0.5, types.CUSUMStep))
+}
+
+func TestStepFit_CUSUM_StepDown(t *testing.T) {
+	assert.Equal(t,
+		&StepFit{TurningPoint: 2, StepSize: 1, Status: LOW, Regression: 0.86602545, LeastSquares: InvalidLeastSquaresError},
+		GetStepFitAtMid([]float32{2, 2, 1, 1, x}, minStdDev, 0.5, types.CUSUMStep))
+}
+
+func TestStepFit_CUSUM_PerfectStep_GrowsWithSquareRootOfLength(t *testing.T) {
+	short := GetStepFitAtMid([]float32{1, 1, 1, 1, 2, 2, 2, 2, x}, minStdDev, 0.5, types.CUSUMStep)
+	long := GetStepFitAtMid([]float32{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, x}, minStdDev, 0.5, types.CUSUMStep)
+	// Quadrupling the length of the trace doubles the statistic, apart from
+	// the difference between the sample and population standard deviations.
+	assert.InDelta(t, -math.Sqrt(7)/2, short.Regression, 0.0001)
+	assert.InDelta(t, -math.Sqrt(31)/2, long.Regression, 0.0001)
+}
+
+func TestStepFit_CUSUM_NoStep(t *testing.T) {
+	assert.Equal(t,
+		&StepFit{TurningPoint: 2, StepSize: 0, Status: UNINTERESTING, Regression: 0, LeastSquares: InvalidLeastSquaresError},
+		GetStepFitAtMid([]float32{1, 2, 2, 1, x}, minStdDev, 0.5, types.CUSUMStep))
+}
+
+func TestStepFit_MAD_Step(t *testing.T) {
+	sf := GetStepFitAtMid([]float32{1, 2, 1, 2, 10, 11, 10, 11, x}, minStdDev, 5.0, types.MADStep)
+	assert.Equal(t, HIGH, sf.Status)
+	assert.Equal(t, float32(-9), sf.StepSize)
+	assert.InDelta(t, -9/(0.5*madToStdDev), sf.Regression, 0.0001)
+}
+
+func TestStepFit_MAD_SingleOutlierIsUninteresting(t *testing.T) {
+	assert.Equal(t,
+		&StepFit{TurningPoint: 4, StepSize: 0, Status: UNINTERESTING, Regression: 0, LeastSquares: InvalidLeastSquaresError},
+		GetStepFitAtMid([]float32{1, 1, 1, 1, 1, 1, 1, 50, x}, minStdDev, 5.0, types.MADStep))
+}
+
+func TestStepFit_MAD_ZeroDeviationUsesStdDevThreshold(t *testing.T) {
+	assert.Equal(t,
+		&StepFit{TurningPoint: 2, StepSize: -1, Status: HIGH, Regression: -10, LeastSquares: InvalidLeastSquaresError},
+		GetStepFitAtMid([]float32{1, 1, 2, 2, x}, minStdDev, 5.0, types.MADStep))
+}
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, float32(0), median([]float32{}))
+	assert.Equal(t, float32(2), median([]float32{3, 1, 2}))
+	assert.Equal(t, float32(2.5), median([]float32{4, 1, 3, 2}))
+}
@@ -245,6 +245,24 @@ func (_m *TraceStore) QueryTraces(ctx context.Context, tileNumber types.TileNumb
 	return r0, r1, r2
 }
 
+// QueryTracesIncrementally provides a mock function with given fields: ctx, tileNumber, q, onTraces
+func (_m *TraceStore) QueryTracesIncrementally(ctx context.Context, tileNumber types.TileNumber, q *query.Query, onTraces tracestore.TracesCallback) error {
+	ret := _m.Called(ctx, tileNumber, q, onTraces)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryTracesIncrementally")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.TileNumber, *query.Query, tracestore.TracesCallback) error); ok {
+		r0 = rf(ctx, tileNumber, q, onTraces)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // QueryTracesIDOnly provides a mock function with given fields: ctx, tileNumber, q
 func (_m *TraceStore) QueryTracesIDOnly(ctx context.Context, tileNumber types.TileNumber, q *query.Query) (<-chan paramtools.Params, error) {
 	ret := _m.Called(ctx, tileNumber, q)
@@ -202,6 +202,15 @@ const (
 
 	queryTracesIDOnlyByIndexChannelSize = 10000
 
+	// readTracesNumShards is the number of shards that trace ids are split into
+	// by prefix when reading traces. See shardForTraceID.
+	readTracesNumShards = 16
+
+	// readTracesShardChunkSize is the number of trace ids a shard collects
+	// before they are read. Keeping this small means that the results of
+	// large queries start arriving before all the trace ids are known.
+	readTracesShardChunkSize = 1000
+
 	// defaultCacheSize is the size of the in-memory LRU caches.
 	defaultCacheSize = 40 * 1000 * 1000
 
@@ -1018,10 +1027,34 @@ func (s *SQLTraceStore) QueryTraces(ctx context.Context, tileNumber types.TileNu
 	ctx, span := trace.StartSpan(ctx, "sqltracestore.QueryTraces")
 	defer span.End()
 
+	ret := types.TraceSet{}
+	commits, err := s.queryTraces(ctx, tileNumber, q, func(traces types.TraceSet, _ []provider.Commit) {
+		for key, trace := range traces {
+			ret[key] = trace
+		}
+	})
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	return ret, commits, nil
+}
+
+// QueryTracesIncrementally implements the tracestore.TraceStore interface.
+func (s *SQLTraceStore) QueryTracesIncrementally(ctx context.Context, tileNumber types.TileNumber, q *query.Query, onTraces tracestore.TracesCallback) error {
+	ctx, span := trace.StartSpan(ctx, "sqltracestore.QueryTracesIncrementally")
+	defer span.End()
+
+	_, err := s.queryTraces(ctx, tileNumber, q, onTraces)
+	return err
+}
+
+// queryTraces calls onTraces with the traces that match the query as they are
+// read, and returns the commits of the tile.
+func (s *SQLTraceStore) queryTraces(ctx context.Context, tileNumber types.TileNumber, q *query.Query, onTraces tracestore.TracesCallback) ([]provider.Commit, error) {
 	traceNames := make(chan string, queryTracesIDOnlyByIndexChannelSize)
 	pChan, err := s.QueryTracesIDOnly(ctx, tileNumber, q)
 	if err != nil {
-		return nil, nil, skerr.Wrapf(err, "Failed to get list of traceIDs matching query.")
+		return nil, skerr.Wrapf(err, "Failed to get list of traceIDs matching query.")
 	}
 
 	// Start a Go routine that converts Params into a trace name and then feeds
@@ -1040,7 +1073,7 @@ func (s *SQLTraceStore) QueryTraces(ctx context.Context, tileNumber types.TileNu
 	}()
 
 	beginCommit, endCommit := types.TileCommitRangeForTileNumber(tileNumber, s.tileSize)
-	return s.readTracesByChannelForCommitRange(ctx, traceNames, beginCommit, endCommit)
+	return s.readTracesByChannelForCommitRange(ctx, traceNames, beginCommit, endCommit, onTraces)
 }
 
 // planCount is used in restrictByCounting to find how many traces match each
@@ -1523,88 +1556,116 @@ func (s *SQLTraceStore) ReadTracesForCommitRange(ctx context.Context, traceNames
 	}
 	close(traceNamesChannel)
 
-	return s.readTracesByChannelForCommitRange(ctx, traceNamesChannel, beginCommit, endCommit)
+	ret := types.TraceSet{}
+	commits, err := s.readTracesByChannelForCommitRange(ctx, traceNamesChannel, beginCommit, endCommit, func(traces types.TraceSet, _ []provider.Commit) {
+		for key, trace := range traces {
+			ret[key] = trace
+		}
+	})
+	if err != nil {
+		return nil, nil, skerr.Wrap(err)
+	}
+	return ret, commits, nil
+}
+
+// traceToRead is a single trace to be read by readTracesChunk.
+type traceToRead struct {
+	name string
+	id   traceIDForSQLInBytes
+}
+
+// shardForTraceID returns the shard that a trace is read in. Traces are
+// sharded by the prefix of their trace_id, so that the traces read in a single
+// chunk are close together in the TraceValues primary key, which makes for
+// faster range scans than reading trace_ids scattered across the whole table.
+func shardForTraceID(id traceIDForSQLInBytes) int {
+	return int(id[0]) * readTracesNumShards / 256
 }
 
 // readTracesByChannelForCommitRange reads the traceNames from a channel so we
 // don't have to wait for the full list of trace ids to be ready first.
 //
-// It works by reading in a number of traceNames into a chunk and then passing
-// that chunk of trace names to a worker pool that reads all the trace values
-// for the given trace names.
-func (s *SQLTraceStore) readTracesByChannelForCommitRange(ctx context.Context, traceNames <-chan string, beginCommit types.CommitNumber, endCommit types.CommitNumber) (types.TraceSet, []provider.Commit, error) {
+// It works by sharding the incoming traceNames by the prefix of their trace
+// ids, see shardForTraceID. Once a shard has collected a full chunk of trace
+// names that chunk is passed to a worker pool of poolSize workers that reads
+// all the trace values for the given trace names. onTraces is called with the
+// traces of each chunk as soon as the chunk has been read, so the caller can
+// merge the results incrementally.
+//
+// The commits for the given range are returned.
+func (s *SQLTraceStore) readTracesByChannelForCommitRange(ctx context.Context, traceNames <-chan string, beginCommit types.CommitNumber, endCommit types.CommitNumber, onTraces tracestore.TracesCallback) ([]provider.Commit, error) {
 	ctx, span := trace.StartSpan(ctx, "sqltracestore.readTracesByChannelForCommitRange")
 	defer span.End()
 
-	// The return value, protected by mutex.
-	ret := types.TraceSet{}
-
 	// Validate the begin and end commit numbers.
 	if beginCommit > endCommit {
 		// Empty the traceNames channel.
 		for range traceNames {
 		}
-		return nil, nil, skerr.Fmt("Invalid commit range, [%d, %d] should be [%d, %d]", beginCommit, endCommit, endCommit, beginCommit)
+		return nil, skerr.Fmt("Invalid commit range, [%d, %d] should be [%d, %d]", beginCommit, endCommit, endCommit, beginCommit)
 	}
 
 	commits, err := s.commitSliceFromCommitNumberRange(ctx, beginCommit, endCommit)
 	if err != nil {
-		return nil, nil, skerr.Fmt("Cannot count commit within the commit range, [%d, %d]", beginCommit, endCommit)
+		// Empty the traceNames channel.
+		for range traceNames {
+		}
+		return nil, skerr.Fmt("Cannot count commit within the commit range, [%d, %d]", beginCommit, endCommit)
 	}
 
-	// Map from the [md5.Size]byte representation of a trace id to the trace name.
-	//
-	// Protected by mutex.
-	traceNameMap := map[traceIDForSQLInBytes]string{}
-
-	// Protects traceNameMap and ret.
+	// Protects calls to onTraces, which must not be made concurrently.
 	var mutex sync.Mutex
 
 	// chunkChannel is used to distribute work to the workers.
-	chunkChannel := make(chan []traceIDForSQL, queryTracesIDOnlyByIndexChannelSize)
+	chunkChannel := make(chan []traceToRead, poolSize)
 
 	// Start the workers that do the actual querying when given chunks of trace ids.
-	g, ctx := errgroup.WithContext(ctx)
+	g, gCtx := errgroup.WithContext(ctx)
 	for i := 0; i < poolSize; i++ {
 		g.Go(func() error {
-			ctx, span := trace.StartSpan(ctx, "sqltracestore.ReadTraces.Worker")
+			ctx, span := trace.StartSpan(gCtx, "sqltracestore.ReadTraces.Worker")
 			defer span.End()
 
 			for chunk := range chunkChannel {
-				if err := s.readTracesChunk(ctx, beginCommit, endCommit, commits, chunk, &mutex, traceNameMap, &ret); err != nil {
+				traces, err := s.readTracesChunk(ctx, beginCommit, endCommit, commits, chunk)
+				if err != nil {
+					// Drain chunkChannel so the sender doesn't block.
+					for range chunkChannel {
+					}
 					return skerr.Wrap(err)
 				}
+				mutex.Lock()
+				onTraces(traces, commits)
+				mutex.Unlock()
 			}
 			return nil
 		})
 	}
 
-	// Now break up the incoming trace ids into chuck for the workers.
-	currentChunk := []traceIDForSQL{}
+	// Now break up the incoming trace ids into shards, and send each shard to
+	// the workers as it fills up a chunk.
+	shards := make([][]traceToRead, readTracesNumShards)
 	for key := range traceNames {
 		if !query.IsValid(key) {
 			sklog.Errorf("Invalid key: %q", key)
 			continue
 		}
-
-		mutex.Lock()
-		// Make space in ret for the values.
-		ret[key] = vec32.New(len(commits))
-
-		// Update the map from the full name of the trace and id in traceIDForSQLInBytes form.
-		traceNameMap[traceIDForSQLInBytesFromTraceName(key)] = key
-		mutex.Unlock()
-
-		trID := traceIDForSQLFromTraceName(key)
-		currentChunk = append(currentChunk, trID)
-		if len(currentChunk) > queryTraceIDsChunkSize {
-			chunkChannel <- currentChunk
-			currentChunk = []traceIDForSQL{}
+		t := traceToRead{
+			name: key,
+			id:   traceIDForSQLInBytesFromTraceName(key),
+		}
+		shard := shardForTraceID(t.id)
+		shards[shard] = append(shards[shard], t)
+		if len(shards[shard]) >= readTracesShardChunkSize {
+			chunkChannel <- shards[shard]
+			shards[shard] = nil
 		}
 	}
-	// Now handle any remaining values in the currentChunk.
-	if len(currentChunk) >= 0 {
-		chunkChannel <- currentChunk
+	// Now handle any remaining values in the shards.
+	for _, chunk := range shards {
+		if len(chunk) > 0 {
+			chunkChannel <- chunk
+		}
 	}
 	close(chunkChannel)
 
@@ -1613,31 +1674,39 @@ func (s *SQLTraceStore) readTracesByChannelForCommitRange(ctx context.Context, t
 			Code:    trace.StatusCodeInternal,
 			Message: err.Error(),
 		})
-		// Empty the traceNames channel.
-		for range traceNames {
-		}
-		return nil, nil, skerr.Wrap(err)
+		return nil, skerr.Wrap(err)
 	}
 
-	return ret, commits, nil
+	return commits, nil
 }
 
-// readTracesChunk updates the passed in TraceSet with all the values loaded for
-// the given slice of trace ids.
-//
-// The mutex protects 'ret' and 'traceNameMap'.
-func (s *SQLTraceStore) readTracesChunk(ctx context.Context, beginCommit types.CommitNumber, endCommit types.CommitNumber, commits []provider.Commit, chunk []traceIDForSQL, mutex *sync.Mutex, traceNameMap map[traceIDForSQLInBytes]string, ret *types.TraceSet) error {
+// readTracesChunk returns a TraceSet with all the values loaded for the given
+// slice of traces. Every trace in the chunk appears in the returned TraceSet,
+// even if no values were found for it.
+func (s *SQLTraceStore) readTracesChunk(ctx context.Context, beginCommit types.CommitNumber, endCommit types.CommitNumber, commits []provider.Commit, chunk []traceToRead) (types.TraceSet, error) {
+	ret := types.TraceSet{}
 	if len(chunk) == 0 {
-		return nil
+		return ret, nil
 	}
 	ctx, span := trace.StartSpan(ctx, "sqltracestore.ReadTraces.Chunk")
 	span.AddAttributes(trace.Int64Attribute("chunk_length", int64(len(chunk))))
 	defer span.End()
+
+	// Map from the [md5.Size]byte representation of a trace id to the trace name.
+	traceNameMap := make(map[traceIDForSQLInBytes]string, len(chunk))
+	traceIDs := make([]traceIDForSQL, 0, len(chunk))
+	for _, t := range chunk {
+		// Make space in ret for the values.
+		ret[t.name] = vec32.New(len(commits))
+		traceNameMap[t.id] = t.name
+		traceIDs = append(traceIDs, traceIDForSQLFromTraceIDAsBytes(t.id[:]))
+	}
+
 	// Populate the context for the SQL template.
 	readTracesContext := readTracesContext{
 		BeginCommitNumber: beginCommit,
 		EndCommitNumber:   endCommit,
-		TraceIDs:          chunk,
+		TraceIDs:          traceIDs,
 		AsOf:              "",
 	}
 	if s.enableFollowerReads {
@@ -1647,15 +1716,16 @@ func (s *SQLTraceStore) readTracesChunk(ctx context.Context, beginCommit types.C
 	// Expand the template for the SQL.
 	var b bytes.Buffer
 	if err := s.unpreparedStatements[readTraces].Execute(&b, readTracesContext); err != nil {
-		return skerr.Wrapf(err, "failed to expand readTraces template")
+		return nil, skerr.Wrapf(err, "failed to expand readTraces template")
 	}
 
 	sql := b.String()
 	// Execute the query.
 	rows, err := s.db.Query(ctx, sql)
 	if err != nil {
-		return skerr.Wrapf(err, "SQL: %q", sql)
+		return nil, skerr.Wrapf(err, "SQL: %q", sql)
 	}
+	defer rows.Close()
 
 	var traceIDArray traceIDForSQLInBytes
 	commitToIndexMap := map[types.CommitNumber]int{}
@@ -1668,26 +1738,20 @@ func (s *SQLTraceStore) readTracesChunk(ctx context.Context, beginCommit types.C
 		var commitNumber types.CommitNumber
 		var val float64
 		if err := rows.Scan(&traceIDInBytes, &commitNumber, &val); err != nil {
-			return skerr.Wrap(err)
+			return nil, skerr.Wrap(err)
 		}
 
-		if err != nil {
-			sklog.Warningf("Invalid trace name found in query response: %s", err)
-			continue
-		}
 		// pgx can't Scan into an array, but Go can't use a slice as a map key, so
 		// we Scan into a byte slice and then copy into a byte array to use
 		// as the index into the map.
 		copy(traceIDArray[:], traceIDInBytes)
-		mutex.Lock()
-		(*ret)[traceNameMap[traceIDArray]][commitToIndexMap[commitNumber]] = float32(val)
-		mutex.Unlock()
+		ret[traceNameMap[traceIDArray]][commitToIndexMap[commitNumber]] = float32(val)
 	}
 	if err := rows.Err(); err != nil {
-		return skerr.Wrap(err)
+		return nil, skerr.Wrap(err)
 	}
 
-	return nil
+	return ret, nil
 }
 
 // TileNumber implements the tracestore.TraceStore interface.
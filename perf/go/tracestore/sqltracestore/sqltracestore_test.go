@@ -403,6 +403,26 @@ func TestQueryTraces_MatchesTwoTraces(t *testing.T) {
 	})
 }
 
+func TestQueryTracesIncrementally_MatchesTwoTraces_EachTraceIsReturnedOnce(t *testing.T) {
+	ctx, s := commonTestSetupWithCommits(t, true)
+
+	q, err := query.NewFromString("arch=x86")
+	require.NoError(t, err)
+	ts := types.TraceSet{}
+	err = s.QueryTracesIncrementally(ctx, 0, q, func(traces types.TraceSet, commits []provider.Commit) {
+		assertCommitNumbersMatch(t, commits, []types.CommitNumber{0, 1, 2, 3, 4, 5, 6, 7})
+		for key, trace := range traces {
+			assert.NotContains(t, ts, key)
+			ts[key] = trace
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.TraceSet{
+		",arch=x86,config=565,":  {e, 2.3, e, 3.3, e, e, e, e},
+		",arch=x86,config=8888,": {e, 1.5, e, 2.5, e, e, e, e},
+	}, ts)
+}
+
 func TestQueryTraces_QueryHasUnknownParamReturnsNoError(t *testing.T) {
 	ctx, s := commonTestSetupWithCommits(t, true)
 
@@ -636,6 +656,13 @@ func populatedTestDB(t *testing.T, ctx context.Context, store *SQLTraceStore) {
 	require.NoError(t, err)
 }
 
+func TestShardForTraceID_CoversAllShardsInPrefixOrder(t *testing.T) {
+	assert.Equal(t, 0, shardForTraceID(traceIDForSQLInBytes{0x00}))
+	assert.Equal(t, 0, shardForTraceID(traceIDForSQLInBytes{0x0f}))
+	assert.Equal(t, 1, shardForTraceID(traceIDForSQLInBytes{0x10}))
+	assert.Equal(t, readTracesNumShards-1, shardForTraceID(traceIDForSQLInBytes{0xff}))
+}
+
 func Test_traceIDForSQLFromTraceName_Success(t *testing.T) {
 	/*
 	   $ python3
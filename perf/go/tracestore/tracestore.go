@@ -19,6 +19,12 @@ type Source struct {
 	CommitNumber types.CommitNumber
 }
 
+// TracesCallback is called by TraceStore.QueryTracesIncrementally with each
+// batch of traces read, along with the commits that the trace values
+// correspond to. Calls are never made concurrently, and each trace appears in
+// exactly one batch.
+type TracesCallback func(traces types.TraceSet, commits []provider.Commit)
+
 // TraceStore is the interface that all backends that store traces must
 // implement. It is used by dfbuilder to build DataFrames and by the perf-tool
 // to perform some common maintenance tasks.
@@ -52,6 +58,13 @@ type TraceStore interface {
 	// all traces that match the given query.
 	QueryTraces(ctx context.Context, tileNumber types.TileNumber, q *query.Query) (types.TraceSet, []provider.Commit, error)
 
+	// QueryTracesIncrementally finds all the traces that match the given
+	// query, like QueryTraces, but rather than returning them all at once it
+	// calls onTraces with each batch of traces as soon as it has been read.
+	// This allows callers to merge results and report progress while large
+	// queries are still running.
+	QueryTracesIncrementally(ctx context.Context, tileNumber types.TileNumber, q *query.Query, onTraces TracesCallback) error
+
 	// QueryTracesIDOnly returns a stream of ParamSets that match the
 	// given query.
 	// TODO(jcgregorio) Change to just return count and ParamSet.
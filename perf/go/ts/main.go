@@ -16,17 +16,20 @@ import (
 	"go.skia.org/infra/perf/go/chromeperf"
 	"go.skia.org/infra/perf/go/clustering2"
 	"go.skia.org/infra/perf/go/config"
+	"go.skia.org/infra/perf/go/dataframe"
 	"go.skia.org/infra/perf/go/dryrun"
 	"go.skia.org/infra/perf/go/frontend"
 	frontendApi "go.skia.org/infra/perf/go/frontend/api"
 	"go.skia.org/infra/perf/go/git/provider"
 	"go.skia.org/infra/perf/go/graphsshortcut"
 	"go.skia.org/infra/perf/go/ingest/format"
+	"go.skia.org/infra/perf/go/livestream"
 	"go.skia.org/infra/perf/go/notifytypes"
 	"go.skia.org/infra/perf/go/pinpoint"
 	"go.skia.org/infra/perf/go/pivot"
 	"go.skia.org/infra/perf/go/progress"
 	"go.skia.org/infra/perf/go/regression"
+	"go.skia.org/infra/perf/go/snapshot"
 	"go.skia.org/infra/perf/go/stepfit"
 	subProto "go.skia.org/infra/perf/go/subscription/proto/v1"
 	"go.skia.org/infra/perf/go/trybot/results"
@@ -58,6 +61,7 @@ func main() {
 
 	generator.AddUnionToNamespace(pivot.AllOperations, "pivot")
 	generator.AddToNamespace(pivot.Request{}, "pivot")
+	generator.AddToNamespace(pivot.Table{}, "pivot")
 
 	generator.AddMultiple(generator,
 		alerts.Alert{},
@@ -67,19 +71,35 @@ func main() {
 		clustering2.ValuePercent{},
 		config.Favorites{},
 		config.QueryConfig{},
+		dryrun.RangeRequest{},
 		dryrun.RegressionAtCommit{},
 		frame.FrameRequest{},
 		frame.FrameResponse{},
 		frontendApi.AlertUpdateResponse{},
+		frontendApi.BulkTriageRequest{},
+		frontendApi.BulkTriageResponse{},
 		frontendApi.CIDHandlerResponse{},
 		frontendApi.ClusterStartResponse{},
 		frontendApi.CommitDetailsRequest{},
+		frontendApi.CreateAnnotationRequest{},
+		frontendApi.CreateAnnotationResponse{},
+		frontendApi.CreateReportRequest{},
+		frontendApi.CreateReportResponse{},
+		frontendApi.CreateSnapshotRequest{},
+		frontendApi.CreateSnapshotResponse{},
 		frontendApi.CountHandlerRequest{},
 		frontendApi.CountHandlerResponse{},
+		frontendApi.DeleteAnnotationRequest{},
 		frontendApi.GetAnomaliesResponse{},
 		frontendApi.GetGroupReportResponse{},
 		frontendApi.GetGraphsShortcutRequest{},
+		frontendApi.GetReportRequest{},
 		frontendApi.GetSheriffListResponse{},
+		frontendApi.ListAnnotationsRequest{},
+		frontendApi.ListAnnotationsResponse{},
+		frontendApi.ListRegressionGroupsRequest{},
+		frontendApi.ListRegressionGroupsResponse{},
+		frontendApi.ListReportsResponse{},
 		frontendApi.NextParamListHandlerRequest{},
 		frontendApi.NextParamListHandlerResponse{},
 		frontendApi.RangeRequest{},
@@ -90,9 +110,13 @@ func main() {
 		frontend.SkPerfConfig{},
 		frontendApi.TriageRequest{},
 		frontendApi.TriageResponse{},
+		frontendApi.TriageRegressionGroupRequest{},
+		frontendApi.TriageRegressionGroupResponse{},
 		frontendApi.TryBugRequest{},
 		frontendApi.TryBugResponse{},
+		frontendApi.UpdateReportRequest{},
 		graphsshortcut.GraphsShortcut{},
+		livestream.Update{},
 		pinpoint.CreateBisectRequest{},
 		pinpoint.CreateBisectResponse{},
 		provider.Commit{},
@@ -103,6 +127,7 @@ func main() {
 		regression.TriageStatus{},
 		results.TryBotRequest{},
 		results.TryBotResponse{},
+		snapshot.Snapshot{},
 		subProto.Subscription{},
 	)
 
@@ -123,6 +148,7 @@ func main() {
 		{config.AllTraceFormats, "TraceFormat"},
 		{types.AllAlertActions, "AlertAction"},
 		{types.AllProjectIds, "ProjectId"},
+		{dataframe.AllMissingDataModes, "MissingDataMode"},
 	})
 
 	generator.AddUnionToNamespace(progress.AllStatus, "progress")
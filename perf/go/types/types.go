@@ -113,6 +113,17 @@ const (
 
 	// MannWhitneyU uses the Mann-Whitney U test to detect a change. https://en.wikipedia.org/wiki/Mann%E2%80%93Whitney_U_test
 	MannWhitneyU StepDetection = "mannwhitneyu"
+
+	// CUSUMStep uses the cumulative sum of deviations from the mean of the
+	// trace, evaluated at the midpoint and scaled by the standard deviation of
+	// the trace, to detect a change. https://en.wikipedia.org/wiki/CUSUM
+	CUSUMStep StepDetection = "cusum"
+
+	// MADStep is an outlier test that compares the medians of the two halves
+	// of the trace, scaled by the median absolute deviation of the first half.
+	// It is robust against noisy traces with occasional outliers.
+	// https://en.wikipedia.org/wiki/Median_absolute_deviation
+	MADStep StepDetection = "mad"
 )
 
 var (
@@ -130,6 +141,8 @@ var (
 		PercentStep,
 		CohenStep,
 		MannWhitneyU,
+		CUSUMStep,
+		MADStep,
 	}
 )
 
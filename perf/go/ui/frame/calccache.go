@@ -0,0 +1,118 @@
+package frame
+
+import (
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/perf/go/dataframe"
+	"go.skia.org/infra/perf/go/types"
+)
+
+const (
+	// calcCacheSize is the number of formula results kept in the cache.
+	calcCacheSize = 1000
+
+	// calcCacheItemTTL bounds how long a cached result is served for. The key
+	// captures new commits arriving, but not new data being ingested for
+	// commits that already exist, so results can't be cached forever.
+	calcCacheItemTTL = 10 * time.Minute
+)
+
+// calcCacheEntry is a single formula result stored in calcCache.
+type calcCacheEntry struct {
+	addTime time.Time // When this entry was added.
+	df      *dataframe.DataFrame
+}
+
+// calcCache caches the DataFrames produced by evaluating formulas, since
+// dashboards re-evaluate the same formulas over the same data constantly.
+//
+// Entries are keyed on the tile of data the formula is evaluated over and the
+// normalized formula, see calcCacheKey.
+type calcCache struct {
+	cache *lru.Cache
+
+	hits   metrics2.Counter
+	misses metrics2.Counter
+}
+
+// newCalcCache returns a new calcCache that holds up to size results.
+func newCalcCache(size int) (*calcCache, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to create formula cache.")
+	}
+	return &calcCache{
+		cache:  cache,
+		hits:   metrics2.GetCounter("perf_calc_cache_hits"),
+		misses: metrics2.GetCounter("perf_calc_cache_misses"),
+	}, nil
+}
+
+// defaultCalcCache is the calcCache used by ProcessFrameRequest.
+var defaultCalcCache *calcCache
+
+func init() {
+	var err error
+	defaultCalcCache, err = newCalcCache(calcCacheSize)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// calcCacheKey returns the key for the result of evaluating the normalized
+// formula over the tile of data identified by the request type, the time
+// range [begin, end), the number of commits requested, how missing data is
+// filled, and the most recent commit at or before end.
+func calcCacheKey(requestType RequestType, begin, end time.Time, numCommits int32, missingData dataframe.MissingDataMode, latest types.CommitNumber, normalizedFormula string) string {
+	return fmt.Sprintf("%d:%d:%d:%d:%s:%d:%s", requestType, begin.Unix(), end.Unix(), numCommits, missingData, latest, normalizedFormula)
+}
+
+// Get returns a copy of the DataFrame stored for the given key, or false if
+// there is no such DataFrame, or it is too old.
+func (c *calcCache) Get(key string, now time.Time) (*dataframe.DataFrame, bool) {
+	iEntry, ok := c.cache.Get(key)
+	if !ok {
+		c.misses.Inc(1)
+		return nil, false
+	}
+	entry := iEntry.(calcCacheEntry)
+	if entry.addTime.Before(now.Add(-calcCacheItemTTL)) {
+		c.cache.Remove(key)
+		c.misses.Inc(1)
+		return nil, false
+	}
+	c.hits.Inc(1)
+	return copyDataFrame(entry.df), true
+}
+
+// Add stores a copy of the given DataFrame under the given key.
+func (c *calcCache) Add(key string, df *dataframe.DataFrame, now time.Time) {
+	c.cache.Add(key, calcCacheEntry{
+		addTime: now,
+		df:      copyDataFrame(df),
+	})
+}
+
+// copyDataFrame returns a deep copy of df, so that callers are free to modify
+// the DataFrames they get from, or put into, the cache.
+func copyDataFrame(df *dataframe.DataFrame) *dataframe.DataFrame {
+	ret := &dataframe.DataFrame{
+		TraceSet: make(types.TraceSet, len(df.TraceSet)),
+		Header:   make([]*dataframe.ColumnHeader, 0, len(df.Header)),
+		// ReadOnlyParamSets are never modified, so they can be shared.
+		ParamSet: df.ParamSet,
+		Skip:     df.Skip,
+	}
+	for key, trace := range df.TraceSet {
+		ret.TraceSet[key] = append(types.Trace{}, trace...)
+	}
+	for _, h := range df.Header {
+		header := *h
+		ret.Header = append(ret.Header, &header)
+	}
+	return ret
+}
@@ -0,0 +1,75 @@
+package frame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/perf/go/dataframe"
+	"go.skia.org/infra/perf/go/types"
+)
+
+var calcCacheTestTime = time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+func newDataFrameForCalcCacheTest() *dataframe.DataFrame {
+	df := dataframe.NewEmpty()
+	df.TraceSet["sum(filter(\"arch=x86\"))"] = types.Trace{1, 2, 3}
+	df.Header = []*dataframe.ColumnHeader{{Offset: 1, Timestamp: 10}, {Offset: 2, Timestamp: 20}, {Offset: 3, Timestamp: 30}}
+	return df
+}
+
+func TestCalcCache_Get_MissingKey_ReturnsFalse(t *testing.T) {
+	c, err := newCalcCache(10)
+	require.NoError(t, err)
+
+	_, ok := c.Get("unknown", calcCacheTestTime)
+	assert.False(t, ok)
+}
+
+func TestCalcCache_AddThenGet_ReturnsIndependentCopy(t *testing.T) {
+	c, err := newCalcCache(10)
+	require.NoError(t, err)
+	df := newDataFrameForCalcCacheTest()
+
+	c.Add("key", df, calcCacheTestTime)
+	// Modifying the DataFrame after adding it doesn't change the cached value.
+	df.TraceSet["sum(filter(\"arch=x86\"))"][0] = 100
+	df.Header[0].Offset = 100
+
+	actual, ok := c.Get("key", calcCacheTestTime.Add(time.Minute))
+	require.True(t, ok)
+	expected := newDataFrameForCalcCacheTest()
+	assert.Equal(t, expected, actual)
+
+	// Modifying the returned DataFrame doesn't change the cached value either.
+	actual.TraceSet["sum(filter(\"arch=x86\"))"][1] = 100
+	actual, ok = c.Get("key", calcCacheTestTime.Add(time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, expected, actual)
+}
+
+func TestCalcCache_Get_EntryOlderThanTTL_ReturnsFalse(t *testing.T) {
+	c, err := newCalcCache(10)
+	require.NoError(t, err)
+	c.Add("key", newDataFrameForCalcCacheTest(), calcCacheTestTime)
+
+	_, ok := c.Get("key", calcCacheTestTime.Add(calcCacheItemTTL+time.Second))
+	assert.False(t, ok)
+}
+
+func TestCalcCacheKey_DifferentLatestCommit_ReturnsDifferentKeys(t *testing.T) {
+	begin := calcCacheTestTime
+	end := begin.Add(time.Hour)
+	assert.NotEqual(t,
+		calcCacheKey(REQUEST_TIME_RANGE, begin, end, 0, dataframe.MissingDataSkip, 10, `sum(filter("arch=x86"))`),
+		calcCacheKey(REQUEST_TIME_RANGE, begin, end, 0, dataframe.MissingDataSkip, 11, `sum(filter("arch=x86"))`))
+}
+
+func TestCalcCacheKey_DifferentMissingDataMode_ReturnsDifferentKeys(t *testing.T) {
+	begin := calcCacheTestTime
+	end := begin.Add(time.Hour)
+	assert.NotEqual(t,
+		calcCacheKey(REQUEST_TIME_RANGE, begin, end, 0, dataframe.MissingDataSkip, 10, `ave(filter("arch=x86"))`),
+		calcCacheKey(REQUEST_TIME_RANGE, begin, end, 0, dataframe.MissingDataLinear, 10, `ave(filter("arch=x86"))`))
+}
@@ -6,15 +6,18 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"slices"
 	"sort"
 	"time"
 
 	"go.opencensus.io/trace"
 	"go.skia.org/infra/go/calc"
+	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/paramtools"
 	"go.skia.org/infra/go/query"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/go/vec32"
 	"go.skia.org/infra/perf/go/anomalies"
 	"go.skia.org/infra/perf/go/chromeperf"
@@ -24,6 +27,7 @@ import (
 	"go.skia.org/infra/perf/go/pivot"
 	"go.skia.org/infra/perf/go/progress"
 	"go.skia.org/infra/perf/go/shortcut"
+	"go.skia.org/infra/perf/go/tracestore"
 	"go.skia.org/infra/perf/go/types"
 )
 
@@ -87,6 +91,10 @@ type FrameRequest struct {
 	RequestType             RequestType `json:"request_type,omitempty"`
 	DoNotFilterParentTraces bool        `json:"disable_filter_parent_traces,omitempty"`
 
+	// MissingData controls how gaps in traces are filled before they are
+	// returned or used in formulas. Defaults to dataframe.MissingDataSkip.
+	MissingData dataframe.MissingDataMode `json:"missing_data,omitempty"`
+
 	Pivot *pivot.Request `json:"pivot,omitempty"`
 
 	Progress progress.Progress `json:"-"`
@@ -121,6 +129,9 @@ type frameRequestProcess struct {
 
 	shortcutStore shortcut.Store
 
+	// calcCache caches the results of doCalc, may be nil.
+	calcCache *calcCache
+
 	search        int     // The current search (either Formula or Query) being processed.
 	totalSearches int     // The total number of Formulas and Queries in the FrameRequest.
 	percent       float32 // The percentage of the searches complete [0.0-1.0].
@@ -132,17 +143,7 @@ type frameRequestProcess struct {
 //
 // The finished results are stored in the FrameRequestProcess.Progress.Results.
 func ProcessFrameRequest(ctx context.Context, req *FrameRequest, perfGit perfgit.Git, dfBuilder dataframe.DataFrameBuilder, shortcutStore shortcut.Store, anomalyStore anomalies.Store, searchAnomaliesTimeBased bool) error {
-	numKeys := 0
-	if req.Keys != "" {
-		numKeys = 1
-	}
-	ret := &frameRequestProcess{
-		perfGit:       perfGit,
-		request:       req,
-		totalSearches: len(req.Formulas) + len(req.Queries) + numKeys,
-		dfBuilder:     dfBuilder,
-		shortcutStore: shortcutStore,
-	}
+	ret := newFrameRequestProcess(req, perfGit, dfBuilder, shortcutStore)
 	df, err := ret.run(ctx)
 	if err != nil {
 		return skerr.Wrap(err)
@@ -166,6 +167,117 @@ func ProcessFrameRequest(ctx context.Context, req *FrameRequest, perfGit perfgit
 
 }
 
+// DataFrameFromRequest runs the queries, formulas, and keys in the
+// FrameRequest and returns the resulting DataFrame.
+//
+// Unlike ProcessFrameRequest the DataFrame is never truncated and has no
+// anomalies attached, which makes it suitable for exporting.
+func DataFrameFromRequest(ctx context.Context, req *FrameRequest, perfGit perfgit.Git, dfBuilder dataframe.DataFrameBuilder, shortcutStore shortcut.Store) (*dataframe.DataFrame, error) {
+	df, err := newFrameRequestProcess(req, perfGit, dfBuilder, shortcutStore).run(ctx)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return df, nil
+}
+
+// CanLoadInChunks returns true if the traces requested by the FrameRequest can
+// be loaded a few at a time, by calling DataFrameFromTraceIDs with chunks of
+// the trace ids returned by TraceIDsFromRequest. Formulas and pivots combine
+// traces, and compact requests pick the commits for each query separately, so
+// those need DataFrameFromRequest.
+func (f *FrameRequest) CanLoadInChunks() bool {
+	return len(f.Formulas) == 0 && (f.Pivot == nil || len(f.Pivot.GroupBy) == 0) && f.RequestType == REQUEST_TIME_RANGE
+}
+
+// TraceIDsFromRequest returns the sorted ids of the traces that match the
+// queries and keys in the FrameRequest over its time range, without loading
+// their values. See CanLoadInChunks.
+func TraceIDsFromRequest(ctx context.Context, req *FrameRequest, perfGit perfgit.Git, traceStore tracestore.TraceStore, shortcutStore shortcut.Store) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "frame.TraceIDsFromRequest")
+	defer span.End()
+
+	if req.MissingData != "" && !slices.Contains(dataframe.AllMissingDataModes, req.MissingData) {
+		return nil, skerr.Fmt("Unknown missing data mode: %q", req.MissingData)
+	}
+	begin := time.Unix(int64(req.Begin), 0).UTC()
+	end := time.Unix(int64(req.End), 0).UTC()
+	commits, err := perfGit.CommitSliceFromTimeRange(ctx, begin, end)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "Failed to load commits for time range.")
+	}
+	tileNumbers := []types.TileNumber{}
+	for _, c := range commits {
+		tileNumber := traceStore.TileNumber(c.CommitNumber)
+		if !slices.Contains(tileNumbers, tileNumber) {
+			tileNumbers = append(tileNumbers, tileNumber)
+		}
+	}
+
+	traceIDs := util.StringSet{}
+	for _, queryStr := range req.Queries {
+		urlValues, err := url.ParseQuery(queryStr)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Failed to parse query")
+		}
+		q, err := query.New(urlValues)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Invalid query")
+		}
+		for _, tileNumber := range tileNumbers {
+			ch, err := traceStore.QueryTracesIDOnly(ctx, tileNumber, q)
+			if err != nil {
+				return nil, skerr.Wrapf(err, "Failed to query tile %d", tileNumber)
+			}
+			for params := range ch {
+				traceID, err := query.MakeKey(params)
+				if err != nil {
+					sklog.Warningf("Invalid trace params %v: %s", params, err)
+					continue
+				}
+				traceIDs[traceID] = true
+			}
+		}
+	}
+	if req.Keys != "" {
+		keys, err := shortcutStore.Get(ctx, req.Keys)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "Failed to find that set of keys %q", req.Keys)
+		}
+		traceIDs.AddLists(keys.Keys)
+	}
+	ret := traceIDs.Keys()
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// DataFrameFromTraceIDs returns a DataFrame of the given traces over the time
+// range of the FrameRequest, with missing data filled in as requested. See
+// CanLoadInChunks.
+func DataFrameFromTraceIDs(ctx context.Context, req *FrameRequest, dfBuilder dataframe.DataFrameBuilder, traceIDs []string) (*dataframe.DataFrame, error) {
+	begin := time.Unix(int64(req.Begin), 0).UTC()
+	end := time.Unix(int64(req.End), 0).UTC()
+	df, err := dfBuilder.NewFromKeysAndRange(ctx, traceIDs, begin, end, true, req.Progress)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return df, skerr.Wrap(df.FillMissing(req.MissingData))
+}
+
+func newFrameRequestProcess(req *FrameRequest, perfGit perfgit.Git, dfBuilder dataframe.DataFrameBuilder, shortcutStore shortcut.Store) *frameRequestProcess {
+	numKeys := 0
+	if req.Keys != "" {
+		numKeys = 1
+	}
+	return &frameRequestProcess{
+		perfGit:       perfGit,
+		request:       req,
+		totalSearches: len(req.Formulas) + len(req.Queries) + numKeys,
+		dfBuilder:     dfBuilder,
+		shortcutStore: shortcutStore,
+		calcCache:     defaultCalcCache,
+	}
+}
+
 // reportError records the reason a FrameRequestProcess failed.
 func (p *frameRequestProcess) reportError(err error, message string) error {
 	sklog.Errorf("FrameRequest failed: %#v %s: %s", *(p.request), message, err)
@@ -184,6 +296,10 @@ func (p *frameRequestProcess) run(ctx context.Context) (*dataframe.DataFrame, er
 	ctx, span := trace.StartSpan(ctx, "FrameRequestProcess.Run")
 	defer span.End()
 
+	if p.request.MissingData != "" && !slices.Contains(dataframe.AllMissingDataModes, p.request.MissingData) {
+		return nil, p.reportError(skerr.Fmt("Unknown missing data mode: %q", p.request.MissingData), "Invalid missing data mode.")
+	}
+
 	begin := time.Unix(int64(p.request.Begin), 0).UTC()
 	end := time.Unix(int64(p.request.End), 0).UTC()
 
@@ -399,11 +515,16 @@ func (p *frameRequestProcess) doSearch(ctx context.Context, queryStr string, beg
 		return nil, fmt.Errorf("Invalid Query: %s", err)
 	}
 	p.request.Progress.Message("Query", q.String())
+	var df *dataframe.DataFrame
 	if p.request.RequestType == REQUEST_TIME_RANGE {
-		return p.dfBuilder.NewFromQueryAndRange(ctx, begin, end, q, true, p.request.Progress)
+		df, err = p.dfBuilder.NewFromQueryAndRange(ctx, begin, end, q, true, p.request.Progress)
+	} else {
+		df, err = p.dfBuilder.NewNFromQuery(ctx, end, q, p.request.NumCommits, p.request.Progress)
 	}
-	return p.dfBuilder.NewNFromQuery(ctx, end, q, p.request.NumCommits, p.request.Progress)
-
+	if err != nil {
+		return nil, err
+	}
+	return df, df.FillMissing(p.request.MissingData)
 }
 
 // doKeys returns a DataFrame that matches the given set of keys given
@@ -413,16 +534,32 @@ func (p *frameRequestProcess) doKeys(ctx context.Context, keyID string, begin, e
 	if err != nil {
 		return nil, fmt.Errorf("Failed to find that set of keys %q: %s", keyID, err)
 	}
+	var df *dataframe.DataFrame
 	if p.request.RequestType == REQUEST_TIME_RANGE {
-		return p.dfBuilder.NewFromKeysAndRange(ctx, keys.Keys, begin, end, true, p.request.Progress)
+		df, err = p.dfBuilder.NewFromKeysAndRange(ctx, keys.Keys, begin, end, true, p.request.Progress)
+	} else {
+		df, err = p.dfBuilder.NewNFromKeys(ctx, end, keys.Keys, p.request.NumCommits, p.request.Progress)
 	}
-	return p.dfBuilder.NewNFromKeys(ctx, end, keys.Keys, p.request.NumCommits, p.request.Progress)
-
+	if err != nil {
+		return nil, err
+	}
+	return df, df.FillMissing(p.request.MissingData)
 }
 
 // doCalc applies the given formula and returns a dataframe that matches the
 // given time range [begin, end) in a DataFrame.
 func (p *frameRequestProcess) doCalc(ctx context.Context, formula string, begin, end time.Time) (*dataframe.DataFrame, error) {
+	cacheKey := ""
+	if p.calcCache != nil {
+		var err error
+		cacheKey, err = p.calcCacheKey(ctx, formula, begin, end)
+		if err != nil {
+			sklog.Warningf("Not caching the results of formula %q: %s", formula, err)
+		} else if df, ok := p.calcCache.Get(cacheKey, now.Now(ctx)); ok {
+			return df, nil
+		}
+	}
+
 	// During the calculation 'rowsFromQuery' will be called to load up data, we
 	// will capture the dataframe that's created at that time. We only really
 	// need df.Headers so it doesn't matter if the calculation has multiple calls
@@ -446,6 +583,9 @@ func (p *frameRequestProcess) doCalc(ctx context.Context, formula string, begin,
 		if err != nil {
 			return nil, err
 		}
+		if err := df.FillMissing(p.request.MissingData); err != nil {
+			return nil, err
+		}
 		// DataFrames are float32, but calc does its work in float64.
 		rows := types.TraceSet{}
 		for k, v := range df.TraceSet {
@@ -467,6 +607,9 @@ func (p *frameRequestProcess) doCalc(ctx context.Context, formula string, begin,
 		if err != nil {
 			return nil, err
 		}
+		if err := df.FillMissing(p.request.MissingData); err != nil {
+			return nil, err
+		}
 		// DataFrames are float32, but calc does its work in float64.
 		rows := types.TraceSet{}
 		for k, v := range df.TraceSet {
@@ -491,5 +634,23 @@ func (p *frameRequestProcess) doCalc(ctx context.Context, formula string, begin,
 	// Clear the paramset since we are returning calculated values.
 	df.ParamSet = paramtools.NewReadOnlyParamSet()
 
+	if cacheKey != "" {
+		p.calcCache.Add(cacheKey, df, now.Now(ctx))
+	}
+
 	return df, nil
 }
+
+// calcCacheKey returns the key used to cache the results of doCalc for the
+// given formula and time range.
+func (p *frameRequestProcess) calcCacheKey(ctx context.Context, formula string, begin, end time.Time) (string, error) {
+	normalized, err := calc.Normalize(formula)
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	latest, err := p.perfGit.CommitNumberFromTime(ctx, end)
+	if err != nil {
+		return "", skerr.Wrap(err)
+	}
+	return calcCacheKey(p.request.RequestType, begin, end, p.request.NumCommits, p.request.MissingData, latest, normalized), nil
+}
@@ -13,7 +13,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/paramtools"
+	"go.skia.org/infra/go/query"
 	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/go/vec32"
 	"go.skia.org/infra/perf/go/anomalies/cache"
 	"go.skia.org/infra/perf/go/chromeperf"
 	chromeperfMock "go.skia.org/infra/perf/go/chromeperf/mock"
@@ -22,10 +25,13 @@ import (
 	"go.skia.org/infra/perf/go/dataframe/mocks"
 	perfgit "go.skia.org/infra/perf/go/git"
 	"go.skia.org/infra/perf/go/git/gittest"
+	gitMocks "go.skia.org/infra/perf/go/git/mocks"
+	"go.skia.org/infra/perf/go/git/provider"
 	"go.skia.org/infra/perf/go/pivot"
 	"go.skia.org/infra/perf/go/progress"
 	"go.skia.org/infra/perf/go/shortcut"
 	shortcutStoreMock "go.skia.org/infra/perf/go/shortcut/mocks"
+	traceStoreMocks "go.skia.org/infra/perf/go/tracestore/mocks"
 	"go.skia.org/infra/perf/go/types"
 )
 
@@ -331,6 +337,62 @@ func TestDoCalc_ValidQueryTimeRange_ReturnsDataFrameWithCalculatedTraces(t *test
 	assert.Equal(t, actualDf.TraceSet[`sum(filter("arch=x86"))`], types.Trace{3, 6, 9})
 }
 
+func TestDoCalc_MissingDataLinear_FormulaSeesInterpolatedValues(t *testing.T) {
+
+	dfbMock, df, fr := frameRequestForTest(t)
+	df.TraceSet[",arch=x86,config=565,"] = types.Trace{2, vec32.MissingDataSentinel, 6}
+	fr.request.MissingData = dataframe.MissingDataLinear
+	dfbMock.On("NewNFromQuery", testutils.AnyContext, testTimeEnd, mock.Anything, fr.request.NumCommits, fr.request.Progress).Return(df, nil)
+
+	actualDf, err := fr.doCalc(context.Background(), `sum(filter("arch=x86"))`, testTimeBegin, testTimeEnd)
+	require.NoError(t, err)
+	assert.Equal(t, actualDf.TraceSet[`sum(filter("arch=x86"))`], types.Trace{3, 6, 9})
+}
+
+func TestDoSearch_MissingDataZero_GapsAreFilledWithZero(t *testing.T) {
+
+	dfbMock, df, fr := frameRequestForTest(t)
+	df.TraceSet[",arch=x86,config=565,"] = types.Trace{2, vec32.MissingDataSentinel, 6}
+	fr.request.MissingData = dataframe.MissingDataZero
+	dfbMock.On("NewNFromQuery", testutils.AnyContext, testTimeEnd, mock.Anything, fr.request.NumCommits, fr.request.Progress).Return(df, nil)
+
+	actualDf, err := fr.doSearch(context.Background(), "config=565", testTimeBegin, testTimeEnd)
+	require.NoError(t, err)
+	assert.Equal(t, types.Trace{2, 0, 6}, actualDf.TraceSet[",arch=x86,config=565,"])
+}
+
+func TestRun_UnknownMissingDataMode_ReturnsError(t *testing.T) {
+
+	_, _, fr := frameRequestForTest(t)
+	fr.request.MissingData = "cubic"
+
+	_, err := fr.run(context.Background())
+	require.Error(t, err)
+}
+
+func TestDoCalc_SameFormulaEvaluatedTwice_SecondResultComesFromCache(t *testing.T) {
+
+	dfbMock, df, fr := frameRequestForTest(t)
+	gitMock := gitMocks.NewGit(t)
+	gitMock.On("CommitNumberFromTime", testutils.AnyContext, testTimeEnd).Return(types.CommitNumber(3), nil)
+	fr.perfGit = gitMock
+	var err error
+	fr.calcCache, err = newCalcCache(10)
+	require.NoError(t, err)
+
+	// The dataframe should only be built once.
+	dfbMock.On("NewNFromQuery", testutils.AnyContext, testTimeEnd, mock.Anything, fr.request.NumCommits, fr.request.Progress).Return(df, nil).Once()
+
+	actualDf, err := fr.doCalc(context.Background(), `sum(filter("arch=x86"))`, testTimeBegin, testTimeEnd)
+	require.NoError(t, err)
+	assert.Equal(t, types.Trace{3, 6, 9}, actualDf.TraceSet[`sum(filter("arch=x86"))`])
+
+	// Formatting differences in the formula don't matter.
+	cachedDf, err := fr.doCalc(context.Background(), `sum( filter("arch=x86") )`, testTimeBegin, testTimeEnd)
+	require.NoError(t, err)
+	assert.Equal(t, actualDf, cachedDf)
+}
+
 func TestDoCalc_ValidFormulaInvalidShortcutCompact_ReturnsError(t *testing.T) {
 
 	_, _, fr := frameRequestForTest(t)
@@ -528,3 +590,66 @@ func buildResponse(t *testing.T) *FrameResponse {
 	require.NoError(t, err)
 	return resp
 }
+
+func TestCanLoadInChunks(t *testing.T) {
+	fr := NewFrameRequest()
+	fr.Queries = []string{"arch=x86"}
+	assert.True(t, fr.CanLoadInChunks())
+
+	fr.Formulas = []string{`sum(filter("arch=x86"))`}
+	assert.False(t, fr.CanLoadInChunks())
+
+	fr.Formulas = nil
+	fr.Pivot = &pivot.Request{GroupBy: []string{"arch"}}
+	assert.False(t, fr.CanLoadInChunks())
+
+	fr.Pivot = nil
+	fr.RequestType = REQUEST_COMPACT
+	assert.False(t, fr.CanLoadInChunks())
+}
+
+// traceIDsForTest returns a channel which yields the params of the given trace
+// ids, like TraceStore.QueryTracesIDOnly.
+func traceIDsForTest(t *testing.T, traceIDs ...string) <-chan paramtools.Params {
+	ch := make(chan paramtools.Params, len(traceIDs))
+	for _, traceID := range traceIDs {
+		params, err := query.ParseKey(traceID)
+		require.NoError(t, err)
+		ch <- params
+	}
+	close(ch)
+	return ch
+}
+
+func TestTraceIDsFromRequest_QueriesAndKeys_ReturnsSortedUniqueTraceIDsFromAllTiles(t *testing.T) {
+	ctx := context.Background()
+	fr := NewFrameRequest()
+	fr.Begin = int(testTimeBegin.Unix())
+	fr.End = int(testTimeEnd.Unix())
+	fr.Queries = []string{"arch=x86"}
+	fr.Keys = testShortcutKey
+
+	gitMock := gitMocks.NewGit(t)
+	gitMock.On("CommitSliceFromTimeRange", testutils.AnyContext, testTimeBegin, testTimeEnd).Return([]provider.Commit{
+		{CommitNumber: 1}, {CommitNumber: 2}, {CommitNumber: 3},
+	}, nil)
+	traceStoreMock := traceStoreMocks.NewTraceStore(t)
+	traceStoreMock.On("TileNumber", types.CommitNumber(1)).Return(types.TileNumber(0))
+	traceStoreMock.On("TileNumber", types.CommitNumber(2)).Return(types.TileNumber(0))
+	traceStoreMock.On("TileNumber", types.CommitNumber(3)).Return(types.TileNumber(1))
+	traceStoreMock.On("QueryTracesIDOnly", testutils.AnyContext, types.TileNumber(0), mock.Anything).Return(traceIDsForTest(t, ",arch=x86,config=8888,"), nil)
+	traceStoreMock.On("QueryTracesIDOnly", testutils.AnyContext, types.TileNumber(1), mock.Anything).Return(traceIDsForTest(t, ",arch=x86,config=8888,", ",arch=x86,config=565,"), nil)
+	ssMock := shortcutStoreMock.NewStore(t)
+	ssMock.On("Get", testutils.AnyContext, testShortcutKey).Return(&shortcut.Shortcut{Keys: []string{",arch=arm,config=8888,"}}, nil)
+
+	traceIDs, err := TraceIDsFromRequest(ctx, fr, gitMock, traceStoreMock, ssMock)
+	require.NoError(t, err)
+	assert.Equal(t, []string{",arch=arm,config=8888,", ",arch=x86,config=565,", ",arch=x86,config=8888,"}, traceIDs)
+}
+
+func TestTraceIDsFromRequest_InvalidMissingDataMode_ReturnsError(t *testing.T) {
+	fr := NewFrameRequest()
+	fr.MissingData = "guess"
+	_, err := TraceIDsFromRequest(context.Background(), fr, nil, nil, nil)
+	require.Error(t, err)
+}
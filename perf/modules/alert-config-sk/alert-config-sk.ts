@@ -100,6 +100,18 @@ const thresholdDescriptors: Record<StepDetection, ThresholdDescriptor> = {
     units: 'alpha (α)',
     label: 'Consider change significant if p < α. A typical value is 0.05.',
   },
+  cusum: {
+    units: 'R',
+    label: `Consider change significant if the cumulative sum of the deviations
+        from the mean, scaled by σ√n, is greater than R.
+        Values from 1.0 to 3.0 work well.`,
+  },
+  mad: {
+    units: 'robust standard deviations',
+    label: `Consider change significant if the median has changed by this many
+        median absolute deviations (scaled to be comparable to σ).
+        This is robust against outliers. Values from 3.0 to 5.0 work well.`,
+  },
 };
 
 export class AlertConfigSk extends ElementSk {
@@ -206,6 +218,8 @@ export class AlertConfigSk extends ElementSk {
       <div value="percent">Percent</div>
       <div value="cohen">Cohen's d</div>
       <div value="mannwhitneyu">Mann-Whitney U (Wilcoxon rank-sum)</div>
+      <div value="cusum">CUSUM</div>
+      <div value="mad">Median Absolute Deviation</div>
     </select-sk>
     <h4>Threshold</h4>
     <label for="threshold"> ${thresholdDescriptors[ele._config.step].label} </label>
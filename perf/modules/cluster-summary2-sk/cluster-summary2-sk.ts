@@ -146,6 +146,22 @@ const labelsForStepDetection: Record<StepDetection, LabelsAndFormatters> = {
     lse: 'U:',
     lseFormatter: decimalFormatter,
   },
+  cusum: {
+    regression: 'CUSUM:',
+    regressionFormatter: decimalFormatter,
+    stepSize: 'Step Size:',
+    stepSizeFormatter: decimalFormatter,
+    lse: '',
+    lseFormatter: emptyFormatter,
+  },
+  mad: {
+    regression: 'Robust Standard Deviations:',
+    regressionFormatter: decimalFormatter,
+    stepSize: 'Median Change:',
+    stepSizeFormatter: decimalFormatter,
+    lse: '',
+    lseFormatter: emptyFormatter,
+  },
 };
 
 export interface ClusterSummary2SkTriagedEventDetail {
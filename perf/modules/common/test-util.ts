@@ -415,4 +415,18 @@ export function setUpExploreDemoEnv() {
   fetchMock.post('/_/shortcut/update', {
     id: 'aaab78c9711cb79197d47f448ba51338',
   });
+
+  fetchMock.post('/_/annotations/list', {
+    annotations: [
+      {
+        id: '5f8a1c9e-1b2c-4d3e-9f40-123456789abc',
+        begin: 67127,
+        end: 67128,
+        note: 'Lab power outage',
+        suppress_alerts: true,
+        author: 'user@google.com',
+        last_modified: 1700000000,
+      },
+    ],
+  });
 }
@@ -84,9 +84,12 @@ import {
   Commit,
   Trace,
   ReadOnlyParamSet,
+  ListAnnotationsRequest,
+  ListAnnotationsResponse,
 } from '../json';
 import {
   AnomalyData,
+  PlotAnnotation,
   PlotSimpleSk,
   PlotSimpleSkTraceEventDetails,
 } from '../plot-simple-sk/plot-simple-sk';
@@ -2605,6 +2608,7 @@ export class ExploreSimpleSk extends ElementSk {
     const plot = this.plotSimple.value;
     if (plot) {
       plot.bands = bands;
+      this.loadAnnotations(mergedDataframe.header!);
     }
 
     // Populate the xbar if present.
@@ -2654,6 +2658,54 @@ export class ExploreSimpleSk extends ElementSk {
     }
   }
 
+  // Fetches the annotations for the commits in header and displays them on
+  // the plot, converting commit numbers to x offsets.
+  private loadAnnotations(header: (ColumnHeader | null)[]) {
+    if (header.length === 0) {
+      return;
+    }
+    const body: ListAnnotationsRequest = {
+      begin: header[0]!.offset,
+      end: header[header.length - 1]!.offset,
+    };
+    fetch('/_/annotations/list', {
+      method: 'POST',
+      body: JSON.stringify(body),
+      headers: {
+        'Content-Type': 'application/json',
+      },
+    })
+      .then(jsonOrThrow)
+      .then((json: ListAnnotationsResponse) => {
+        const plot = this.plotSimple.value;
+        if (!plot) {
+          return;
+        }
+        const annotations: PlotAnnotation[] = [];
+        (json.annotations || []).forEach((annotation) => {
+          if (!annotation) {
+            return;
+          }
+          const indices: number[] = [];
+          header.forEach((h, i) => {
+            if (h!.offset >= annotation.begin && h!.offset <= annotation.end) {
+              indices.push(i);
+            }
+          });
+          if (indices.length === 0) {
+            return;
+          }
+          annotations.push({
+            begin: indices[0],
+            end: indices[indices.length - 1],
+            note: annotation.note,
+          });
+        });
+        plot.annotations = annotations;
+      })
+      .catch(errorMessage);
+  }
+
   // Adds x and y coordinates to the user issue points needed to be displayed
   private addGraphCoordinatesToUserIssues(df: DataFrame, issues: UserIssueMap): UserIssueMap {
     const allPoints = df.header?.map((p) => p?.offset) || [];
@@ -8,6 +8,21 @@ export namespace pivot {
 	}
 }
 
+export namespace pivot {
+	export interface TableRow {
+		group: string[] | null;
+		values: number[] | null;
+	}
+}
+
+export namespace pivot {
+	export interface Table {
+		group_by: string[] | null;
+		columns: pivot.Operation[] | null;
+		rows: pivot.TableRow[] | null;
+	}
+}
+
 export interface Go2TS {
 	GenerateNominalTypes: boolean;
 }
@@ -35,6 +50,8 @@ export interface Alert {
 	action?: AlertAction;
 	sub_name?: string;
 	sub_revision?: string;
+	notification_sinks?: string;
+	owner_rotation_url?: string;
 }
 
 export interface AlertsStatus {
@@ -84,6 +101,8 @@ export interface ClusterSummary {
 	num: number;
 	ts: string;
 	notification_id?: string;
+	sink_notification_ids?: { [key: string]: string } | null;
+	step_detection?: StepDetection;
 }
 
 export interface FavoritesSectionLinkConfig {
@@ -111,6 +130,14 @@ export interface QueryCacheConfig {
 	enabled?: boolean;
 }
 
+export interface QueryLimitConfig {
+	enabled?: boolean;
+	qps?: number;
+	burst?: number;
+	max_concurrent?: number;
+	reserved_for_alerts?: number;
+}
+
 export interface RedisConfig {
 	project?: string;
 	zone?: string;
@@ -123,9 +150,16 @@ export interface QueryConfig {
 	default_param_selections?: { [key: string]: string[] | null } | null;
 	default_url_values?: { [key: string]: string } | null;
 	cache_config?: QueryCacheConfig;
+	limit_config?: QueryLimitConfig;
 	redis_config?: RedisConfig;
 }
 
+export interface RangeRequest {
+	alert: Alert | null;
+	begin: CommitNumber;
+	end: CommitNumber;
+}
+
 export interface Commit {
 	offset: CommitNumber;
 	hash: string;
@@ -215,6 +249,7 @@ export interface FrameRequest {
 	num_commits?: number;
 	request_type?: RequestType;
 	disable_filter_parent_traces?: boolean;
+	missing_data?: MissingDataMode;
 	pivot?: pivot.Request | null;
 }
 
@@ -222,6 +257,15 @@ export interface AlertUpdateResponse {
 	IDAsString: string;
 }
 
+export interface BulkTriageRequest {
+	regression_ids: string[] | null;
+	triage: TriageStatus;
+}
+
+export interface BulkTriageResponse {
+	triaged: number;
+}
+
 export interface CIDHandlerResponse {
 	commitSlice: Commit[] | null;
 	logEntry: string;
@@ -236,6 +280,45 @@ export interface CommitDetailsRequest {
 	traceid: string;
 }
 
+export interface CreateAnnotationRequest {
+	begin: CommitNumber;
+	end: CommitNumber;
+	note: string;
+	suppress_alerts: boolean;
+}
+
+export interface CreateAnnotationResponse {
+	id: string;
+}
+
+export interface Graph {
+	queries: string[] | null;
+	formulas: string[] | null;
+	keys: string;
+	begin: CommitNumber;
+	end: CommitNumber;
+	note: string;
+}
+
+export interface CreateReportRequest {
+	name: string;
+	description: string;
+	graphs: Graph[] | null;
+}
+
+export interface CreateReportResponse {
+	id: string;
+}
+
+export interface CreateSnapshotRequest {
+	request: FrameRequest | null;
+	graph_config?: { [key: string]: any } | null;
+}
+
+export interface CreateSnapshotResponse {
+	id: string;
+}
+
 export interface CountHandlerRequest {
 	q: string;
 	begin: number;
@@ -247,6 +330,10 @@ export interface CountHandlerResponse {
 	paramset: ReadOnlyParamSet;
 }
 
+export interface DeleteAnnotationRequest {
+	id: string;
+}
+
 export interface GetAnomaliesResponse {
 	anomaly_list: Anomaly[] | null;
 	anomaly_cursor: string;
@@ -270,11 +357,66 @@ export interface GetGraphsShortcutRequest {
 	id: string;
 }
 
+export interface GetReportRequest {
+	id: string;
+}
+
 export interface GetSheriffListResponse {
 	sheriff_list: string[] | null;
 	error: string;
 }
 
+export interface ListAnnotationsRequest {
+	begin: CommitNumber;
+	end: CommitNumber;
+}
+
+export interface Annotation {
+	id: string;
+	begin: CommitNumber;
+	end: CommitNumber;
+	note: string;
+	suppress_alerts: boolean;
+	author: string;
+	last_modified: number;
+}
+
+export interface ListAnnotationsResponse {
+	annotations: (Annotation | null)[] | null;
+}
+
+export interface ListRegressionGroupsRequest {
+	begin: CommitNumber;
+	end: CommitNumber;
+}
+
+export interface Group {
+	id: string;
+	commit_number: CommitNumber;
+	group_key: string;
+	regression_ids: string[] | null;
+	triage: TriageStatus;
+	notification_id: string;
+	creation_time: number;
+}
+
+export interface ListRegressionGroupsResponse {
+	groups: (Group | null)[] | null;
+}
+
+export interface Report {
+	id: string;
+	name: string;
+	description: string;
+	graphs: Graph[] | null;
+	owner: string;
+	last_modified: number;
+}
+
+export interface ListReportsResponse {
+	reports: (Report | null)[] | null;
+}
+
 export interface NextParamListHandlerRequest {
 	q: string;
 }
@@ -352,6 +494,15 @@ export interface TriageResponse {
 	bug: string;
 }
 
+export interface TriageRegressionGroupRequest {
+	id: string;
+	triage: TriageStatus;
+}
+
+export interface TriageRegressionGroupResponse {
+	triaged: number;
+}
+
 export interface TryBugRequest {
 	bug_uri_template: string;
 }
@@ -360,6 +511,13 @@ export interface TryBugResponse {
 	url: string;
 }
 
+export interface UpdateReportRequest {
+	id: string;
+	name: string;
+	description: string;
+	graphs: Graph[] | null;
+}
+
 export interface GraphConfig {
 	queries: string[] | null;
 	formulas: string[] | null;
@@ -370,6 +528,16 @@ export interface GraphsShortcut {
 	graphs: GraphConfig[] | null;
 }
 
+export interface Point {
+	trace_id: string;
+	value: number;
+}
+
+export interface Update {
+	commit_number: CommitNumber;
+	points: Point[] | null;
+}
+
 export interface CreateBisectRequest {
 	comparison_mode: string;
 	start_git_hash: string;
@@ -444,6 +612,14 @@ export interface TryBotResponse {
 	paramset: ReadOnlyParamSet;
 }
 
+export interface Snapshot {
+	request: FrameRequest | null;
+	response: FrameResponse | null;
+	graph_config?: { [key: string]: any } | null;
+	created: string;
+	user?: string;
+}
+
 export interface Subscription {
 	name?: string;
 	revision?: string;
@@ -570,7 +746,7 @@ export function TraceSet(v: { [key: string]: Trace }): TraceSet {
 	return v as TraceSet;
 };
 
-export namespace pivot { export type Operation = 'sum' | 'avg' | 'geo' | 'std' | 'count' | 'min' | 'max'; }
+export namespace pivot { export type Operation = 'sum' | 'avg' | 'geo' | 'std' | 'count' | 'min' | 'max' | 'p50' | 'p90' | 'p99'; }
 
 export type SerializesToString = string & {
 	/**
@@ -588,7 +764,7 @@ export function SerializesToString(v: string): SerializesToString {
 
 export type ClusterAlgo = 'kmeans' | 'stepfit';
 
-export type StepDetection = '' | 'absolute' | 'const' | 'percent' | 'cohen' | 'mannwhitneyu';
+export type StepDetection = '' | 'absolute' | 'const' | 'percent' | 'cohen' | 'mannwhitneyu' | 'cusum' | 'mad';
 
 export type ConfigState = 'ACTIVE' | 'DELETED';
 
@@ -650,6 +826,8 @@ export type Status = '' | 'positive' | 'negative' | 'untriaged';
 
 export type RequestType = 0 | 1;
 
+export type MissingDataMode = 'skip' | 'zero' | 'linear';
+
 export type Subset = 'all' | 'regressions' | 'untriaged';
 
 export type NotifierTypes = 'html_email' | 'markdown_issuetracker' | 'none';
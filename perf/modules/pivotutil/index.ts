@@ -9,6 +9,9 @@ export const operationDescriptions: { [key in pivot.Operation]: string } = {
   count: 'Count',
   min: 'Minimum',
   max: 'Maximum',
+  p50: 'Median',
+  p90: '90th Percentile',
+  p99: '99th Percentile',
 };
 
 /** Returns a non-empty string with the error message if the pivot.Request is
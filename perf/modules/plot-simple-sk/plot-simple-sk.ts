@@ -112,6 +112,8 @@ const HOVER_COLOR = '#8887'; // Note the alpha value.
 
 const ZOOM_RECT_COLOR = '#0007'; // Note the alpha value.
 
+const ANNOTATION_COLOR = '#fb03'; // Note the alpha value.
+
 const SUMMARY_LINE_WIDTH = 1; // px
 
 const DETAIL_LINE_WIDTH = 1; // px
@@ -440,6 +442,13 @@ export interface PlotSimpleSkTraceEventDetails {
   name: string;
 }
 
+/** A note that applies to all the points in [begin, end], in x source offsets. */
+export interface PlotAnnotation {
+  begin: number;
+  end: number;
+  note: string;
+}
+
 export interface PlotSimpleSkZoomEventDetails {
   xBegin: tick;
   xEnd: tick;
@@ -460,6 +469,9 @@ export class PlotSimpleSk extends ElementSk {
   /** The locations of the background bands. See bands property. */
   private _bands: number[] = [];
 
+  /** The shaded commit ranges. See annotations property. */
+  private _annotations: PlotAnnotation[] = [];
+
   private _anomalyDataMap: { [key: string]: AnomalyData[] } = {};
 
   private _userIssueMap: { [key: string]: { [key: number]: IssueDetail } } = {};
@@ -710,6 +722,7 @@ export class PlotSimpleSk extends ElementSk {
     this._upgradeProperty('width');
     this._upgradeProperty('height');
     this._upgradeProperty('bands');
+    this._upgradeProperty('annotations');
     this._upgradeProperty('xbar');
     this._upgradeProperty('hightlight');
     this._upgradeProperty('zoom');
@@ -1494,6 +1507,9 @@ export class PlotSimpleSk extends ElementSk {
         // Draw the bands.
         this.drawBands(ctx, this.summaryArea, this.SUMMARY_BAR_WIDTH);
 
+        // Draw the annotations, without labels since the summary is small.
+        this.drawAnnotations(ctx, this.summaryArea, false);
+
         // If detailsZoomRangeStacks is not empty then draw a box to indicate
         // the zoomed region.
         if (this.detailsZoomRangesStack.length > 0) {
@@ -1551,6 +1567,9 @@ export class PlotSimpleSk extends ElementSk {
       // Draw the bands.
       this.drawBands(ctx, this.detailArea, this.DETAIL_BAR_WIDTH);
 
+      // Draw the annotations.
+      this.drawAnnotations(ctx, this.detailArea, true);
+
       // Draw highlighted lines.
       this.lineData.forEach((highlightedLine) => {
         if (!(highlightedLine.name in this.highlighted)) {
@@ -1687,6 +1706,31 @@ export class PlotSimpleSk extends ElementSk {
     ctx.setLineDash([]);
   }
 
+  // Shade the annotated ranges in the given area, optionally labelling each
+  // range with its note.
+  private drawAnnotations(ctx: CanvasRenderingContext2D, area: Area, labels: boolean) {
+    this._annotations.forEach((annotation) => {
+      // Widen each range by half a point on either side so that an annotation
+      // on a single commit is still visible.
+      const x0 = area.range.x(annotation.begin - 0.5);
+      const x1 = area.range.x(annotation.end + 0.5);
+      ctx.fillStyle = ANNOTATION_COLOR;
+      ctx.fillRect(x0, area.rect.y, x1 - x0, area.rect.height);
+      if (labels) {
+        ctx.fillStyle = this.LABEL_COLOR;
+        ctx.font = this.LABEL_FONT;
+        ctx.textBaseline = 'top';
+        ctx.textAlign = 'left';
+        ctx.fillText(
+          annotation.note,
+          x0 + this.LABEL_MARGIN,
+          area.rect.y + this.LABEL_MARGIN,
+          Math.max(x1 - x0 - 2 * this.LABEL_MARGIN, 0)
+        );
+      }
+    });
+  }
+
   // Draw all anomalies in the given area.
   private drawAnomalies(ctx: CanvasRenderingContext2D, area: Area) {
     const keys = Object.keys(this._anomalyDataMap);
@@ -1948,6 +1992,19 @@ export class PlotSimpleSk extends ElementSk {
     this.drawOverlayCanvas();
   }
 
+  /**
+   * A list of ranges, in x source offsets, to shade and label with a note.
+   *   Can be set to [] to remove all annotations.
+   */
+  get annotations(): PlotAnnotation[] {
+    return this._annotations;
+  }
+
+  set annotations(annotations: PlotAnnotation[]) {
+    this._annotations = annotations || [];
+    this.drawOverlayCanvas();
+  }
+
   get anomalyDataMap(): { [key: string]: AnomalyData[] } {
     return this._anomalyDataMap;
   }
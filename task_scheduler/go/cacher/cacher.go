@@ -2,9 +2,11 @@ package cacher
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 
 	"go.skia.org/infra/go/cas"
+	"go.skia.org/infra/go/depot_tools/deps_parser"
 	"go.skia.org/infra/go/git"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/task_scheduler/go/specs"
@@ -79,6 +81,9 @@ func (c *CacherImpl) GetOrCacheRepoState(ctx context.Context, rs types.RepoState
 					casSpec.Digest = digest
 				}
 			}
+			if err := resolveExternalDependencies(co.Dir(), cfg); err != nil {
+				return skerr.Wrap(err)
+			}
 			tasksCfg = cfg
 			return nil
 		})
@@ -104,5 +109,35 @@ func (c *CacherImpl) GetOrCacheRepoState(ctx context.Context, rs types.RepoState
 	return cv.Cfg, nil
 }
 
+// resolveExternalDependencies sets the Revision of each of the JobSpecs'
+// ExternalDependencies which refer to an entry in the DEPS file in the given
+// checkout.
+func resolveExternalDependencies(dir string, cfg *specs.TasksCfg) error {
+	var deps deps_parser.DepsEntries
+	for name, jobSpec := range cfg.Jobs {
+		for _, d := range jobSpec.ExternalDependencies {
+			if d.Revision != "" || d.DepsEntry == "" {
+				continue
+			}
+			if deps == nil {
+				contents, err := os.ReadFile(filepath.Join(dir, "DEPS"))
+				if err != nil {
+					return skerr.Wrapf(err, "%s for job %q", specs.ErrExternalDependency, name)
+				}
+				deps, err = deps_parser.ParseDeps(string(contents))
+				if err != nil {
+					return skerr.Wrapf(err, "%s for job %q", specs.ErrExternalDependency, name)
+				}
+			}
+			entry := deps.Get(d.DepsEntry)
+			if entry == nil {
+				return skerr.Fmt("%s for job %q: no DEPS entry %q", specs.ErrExternalDependency, name, d.DepsEntry)
+			}
+			d.Revision = entry.Version
+		}
+	}
+	return nil
+}
+
 // Assert that CacherImpl implements Cacher.
 var _ Cacher = &CacherImpl{}
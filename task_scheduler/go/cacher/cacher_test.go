@@ -3,6 +3,7 @@ package cacher
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -120,3 +121,45 @@ func TestGetOrCacheRepoState_Unset_RBE(t *testing.T) {
 	require.NotNil(t, cached)
 	require.Equal(t, "fake-digest", cached.CasSpecs["my-cas"].Digest)
 }
+
+func TestResolveExternalDependencies(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &specs.TasksCfg{
+		Jobs: map[string]*specs.JobSpec{
+			"job": {
+				ExternalDependencies: []*specs.ExternalDependency{
+					{
+						Repo:      "https://skia.googlesource.com/skia.git",
+						Task:      "Build-Skia",
+						DepsEntry: "skia.googlesource.com/skia",
+					},
+					{
+						Repo:     "https://skia.googlesource.com/other.git",
+						Task:     "Build-Other",
+						Revision: "pinned",
+					},
+				},
+			},
+		},
+	}
+
+	// No DEPS file.
+	err := resolveExternalDependencies(dir, cfg.Copy())
+	require.ErrorContains(t, err, specs.ErrExternalDependency.Error())
+	require.True(t, specs.ErrorIsPermanent(err))
+
+	testutils.WriteFile(t, filepath.Join(dir, "DEPS"), `deps = {
+  "third_party/skia": "https://skia.googlesource.com/skia.git@abc123",
+}`)
+	require.NoError(t, resolveExternalDependencies(dir, cfg))
+	deps := cfg.Jobs["job"].ExternalDependencies
+	require.Equal(t, "abc123", deps[0].Revision)
+	require.Equal(t, "pinned", deps[1].Revision)
+
+	// Missing DEPS entry.
+	deps[0].Revision = ""
+	deps[0].DepsEntry = "skia.googlesource.com/missing"
+	err = resolveExternalDependencies(dir, cfg)
+	require.ErrorContains(t, err, `no DEPS entry "skia.googlesource.com/missing"`)
+	require.True(t, specs.ErrorIsPermanent(err))
+}
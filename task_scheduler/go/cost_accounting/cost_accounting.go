@@ -0,0 +1,176 @@
+// Package cost_accounting attributes the bot time used by tasks to their
+// TaskSpecs and Jobs, optionally converting it into a monetary cost using
+// per-dimension-set rates, so that teams can see what their CI load costs.
+package cost_accounting
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+// Rate assigns a cost per bot-hour to the tasks whose TaskSpecs have all of the
+// given Dimensions, eg. ["pool:Skia", "gpu:none"].
+type Rate struct {
+	Dimensions []string `json:"dimensions"`
+	USDPerHour float64  `json:"usd_per_hour"`
+}
+
+// Rates is a list of Rate. The first matching Rate wins, so more specific
+// Rates should be listed before less specific ones. Tasks which match no Rate
+// cost nothing, but their bot time is still accounted for.
+type Rates []*Rate
+
+// ParseRates parses and validates the given JSON-encoded Rates.
+func ParseRates(b []byte) (Rates, error) {
+	var rv Rates
+	if err := json.Unmarshal(b, &rv); err != nil {
+		return nil, skerr.Wrapf(err, "failed to decode cost rates")
+	}
+	for _, r := range rv {
+		if r.USDPerHour < 0 {
+			return nil, skerr.Fmt("cost rate for %v must not be negative; got %f", r.Dimensions, r.USDPerHour)
+		}
+		for _, d := range r.Dimensions {
+			if len(strings.SplitN(d, ":", 2)) != 2 {
+				return nil, skerr.Fmt("invalid dimension %q; expected \"key:value\"", d)
+			}
+		}
+	}
+	return rv, nil
+}
+
+// ReadRates reads Rates from the given JSON file.
+func ReadRates(path string) (Rates, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to read cost rates")
+	}
+	return ParseRates(b)
+}
+
+// usdPerHour returns the cost per bot-hour of a task with the given
+// dimensions.
+func (r Rates) usdPerHour(dims []string) float64 {
+	have := util.NewStringSet(dims)
+	for _, rate := range r {
+		if len(util.NewStringSet(rate.Dimensions).Complement(have)) == 0 {
+			return rate.USDPerHour
+		}
+	}
+	return 0
+}
+
+// TaskSpecCost is the bot time and cost attributed to a TaskSpec on one day.
+type TaskSpecCost struct {
+	Date       string  `json:"date"`
+	Repo       string  `json:"repo"`
+	Pool       string  `json:"pool"`
+	TaskSpec   string  `json:"task_spec"`
+	Tasks      int     `json:"tasks"`
+	BotSeconds float64 `json:"bot_seconds"`
+	CostUSD    float64 `json:"cost_usd"`
+}
+
+// JobCost is the bot time and cost attributed to a JobSpec on one day. The
+// bot time of a task which is shared by several Jobs is split evenly between
+// them.
+type JobCost struct {
+	Date       string  `json:"date"`
+	Repo       string  `json:"repo"`
+	Job        string  `json:"job"`
+	Tasks      int     `json:"tasks"`
+	BotSeconds float64 `json:"bot_seconds"`
+	CostUSD    float64 `json:"cost_usd"`
+}
+
+// poolFromDimensions returns the value of the pool dimension, if any.
+func poolFromDimensions(dims []string) string {
+	for _, d := range dims {
+		if strings.HasPrefix(d, "pool:") {
+			return strings.TrimPrefix(d, "pool:")
+		}
+	}
+	return ""
+}
+
+// attribute computes the TaskSpecCosts and JobCosts for the given tasks. The
+// dims function returns the dimensions of a task's TaskSpec, and jobNames maps
+// Job IDs to Job names. Tasks which did not run are ignored, as are Jobs whose
+// names are unknown.
+func attribute(date string, tasks []*types.Task, dims func(*types.Task) []string, jobNames map[string]string, rates Rates) ([]*TaskSpecCost, []*JobCost) {
+	type specKey struct{ repo, pool, name string }
+	type jobKey struct{ repo, name string }
+	bySpec := map[specKey]*TaskSpecCost{}
+	byJob := map[jobKey]*JobCost{}
+	for _, t := range tasks {
+		if util.TimeIsZero(t.Started) || util.TimeIsZero(t.Finished) || t.Finished.Before(t.Started) {
+			continue
+		}
+		d := dims(t)
+		seconds := t.Finished.Sub(t.Started).Seconds()
+		cost := seconds / 3600 * rates.usdPerHour(d)
+
+		sk := specKey{repo: t.Repo, pool: poolFromDimensions(d), name: t.Name}
+		sc, ok := bySpec[sk]
+		if !ok {
+			sc = &TaskSpecCost{Date: date, Repo: sk.repo, Pool: sk.pool, TaskSpec: sk.name}
+			bySpec[sk] = sc
+		}
+		sc.Tasks++
+		sc.BotSeconds += seconds
+		sc.CostUSD += cost
+
+		if len(t.Jobs) == 0 {
+			continue
+		}
+		share := float64(len(t.Jobs))
+		for _, id := range t.Jobs {
+			name, ok := jobNames[id]
+			if !ok {
+				continue
+			}
+			jk := jobKey{repo: t.Repo, name: name}
+			jc, ok := byJob[jk]
+			if !ok {
+				jc = &JobCost{Date: date, Repo: jk.repo, Job: jk.name}
+				byJob[jk] = jc
+			}
+			jc.Tasks++
+			jc.BotSeconds += seconds / share
+			jc.CostUSD += cost / share
+		}
+	}
+
+	specCosts := make([]*TaskSpecCost, 0, len(bySpec))
+	for _, c := range bySpec {
+		specCosts = append(specCosts, c)
+	}
+	sort.Slice(specCosts, func(i, j int) bool {
+		a, b := specCosts[i], specCosts[j]
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		if a.Pool != b.Pool {
+			return a.Pool < b.Pool
+		}
+		return a.TaskSpec < b.TaskSpec
+	})
+	jobCosts := make([]*JobCost, 0, len(byJob))
+	for _, c := range byJob {
+		jobCosts = append(jobCosts, c)
+	}
+	sort.Slice(jobCosts, func(i, j int) bool {
+		a, b := jobCosts[i], jobCosts[j]
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Job < b.Job
+	})
+	return specCosts, jobCosts
+}
@@ -0,0 +1,84 @@
+package cost_accounting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+var costTestTime = time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+func costTestTask(repo, name string, started time.Time, duration time.Duration, jobs ...string) *types.Task {
+	return &types.Task{
+		TaskKey: types.TaskKey{
+			RepoState: types.RepoState{Repo: repo, Revision: "abc123"},
+			Name:      name,
+		},
+		Created:  started,
+		Started:  started,
+		Finished: started.Add(duration),
+		Jobs:     jobs,
+	}
+}
+
+func TestParseRates(t *testing.T) {
+	rates, err := ParseRates([]byte(`[{"dimensions": ["pool:Skia", "gpu:none"], "usd_per_hour": 0.5}]`))
+	require.NoError(t, err)
+	require.Equal(t, Rates{{Dimensions: []string{"pool:Skia", "gpu:none"}, USDPerHour: 0.5}}, rates)
+
+	_, err = ParseRates([]byte(`[{"dimensions": ["pool:Skia"], "usd_per_hour": -1}]`))
+	require.Error(t, err)
+	_, err = ParseRates([]byte(`[{"dimensions": ["Skia"], "usd_per_hour": 1}]`))
+	require.Error(t, err)
+	_, err = ParseRates([]byte(`{`))
+	require.Error(t, err)
+}
+
+func TestRates_USDPerHour_FirstMatchWins(t *testing.T) {
+	rates := Rates{
+		{Dimensions: []string{"pool:Skia", "gpu:nvidia"}, USDPerHour: 2},
+		{Dimensions: []string{"pool:Skia"}, USDPerHour: 1},
+	}
+	require.Equal(t, 2.0, rates.usdPerHour([]string{"os:Linux", "gpu:nvidia", "pool:Skia"}))
+	require.Equal(t, 1.0, rates.usdPerHour([]string{"os:Linux", "pool:Skia"}))
+	require.Equal(t, 0.0, rates.usdPerHour([]string{"pool:SkiaCT"}))
+	require.Equal(t, 0.0, rates.usdPerHour(nil))
+}
+
+func TestAttribute(t *testing.T) {
+	dims := map[string][]string{
+		"Build": {"pool:Skia", "os:Linux"},
+		"Test":  {"pool:Skia", "gpu:nvidia"},
+	}
+	rates := Rates{{Dimensions: []string{"gpu:nvidia"}, USDPerHour: 2}}
+	jobNames := map[string]string{
+		"j1": "Test-Job",
+		"j2": "Other-Job",
+	}
+	notRun := costTestTask("skia.git", "Test", costTestTime, 0, "j1")
+	notRun.Started = time.Time{}
+	tasks := []*types.Task{
+		costTestTask("skia.git", "Build", costTestTime, time.Hour, "j1", "j2"),
+		costTestTask("skia.git", "Test", costTestTime, 30*time.Minute, "j1"),
+		costTestTask("skia.git", "Test", costTestTime, 30*time.Minute, "j1", "unknown"),
+		costTestTask("other.git", "Unknown", costTestTime, time.Minute),
+		notRun,
+	}
+	specCosts, jobCosts := attribute("2024-04-01", tasks, func(t *types.Task) []string {
+		return dims[t.Name]
+	}, jobNames, rates)
+
+	require.Equal(t, []*TaskSpecCost{
+		{Date: "2024-04-01", Repo: "other.git", Pool: "", TaskSpec: "Unknown", Tasks: 1, BotSeconds: 60},
+		{Date: "2024-04-01", Repo: "skia.git", Pool: "Skia", TaskSpec: "Build", Tasks: 1, BotSeconds: 3600},
+		{Date: "2024-04-01", Repo: "skia.git", Pool: "Skia", TaskSpec: "Test", Tasks: 2, BotSeconds: 3600, CostUSD: 2},
+	}, specCosts)
+	require.Equal(t, []*JobCost{
+		{Date: "2024-04-01", Repo: "skia.git", Job: "Other-Job", Tasks: 1, BotSeconds: 1800},
+		// Half of Build, all of the first Test, and half of the second
+		// Test, which is shared with an unknown Job.
+		{Date: "2024-04-01", Repo: "skia.git", Job: "Test-Job", Tasks: 3, BotSeconds: 1800 + 1800 + 900, CostUSD: 1.5},
+	}, jobCosts)
+}
@@ -0,0 +1,216 @@
+package cost_accounting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"time"
+
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/db"
+	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// exportDelay is how long after the end of a day we wait before
+	// exporting it, so that the tasks created that day have finished.
+	exportDelay = 24 * time.Hour
+
+	// exportInterval is how often we check for days to export.
+	exportInterval = time.Hour
+
+	// backfillDays is how many days back we look for days which have not
+	// been exported, eg. because the scheduler was down.
+	backfillDays = 7
+
+	// jobLookback is how long before a task was created we look for its
+	// Jobs. Jobs which are older than this are looked up individually.
+	jobLookback = 7 * 24 * time.Hour
+
+	// dateFormat is the format of the dates in the exported data.
+	dateFormat = "2006-01-02"
+
+	// taskSpecsFile and jobsFile are the names of the files written for each
+	// day. The jobs file is written last, so its existence indicates that
+	// the day has been exported.
+	taskSpecsFile = "task_specs.json"
+	jobsFile      = "jobs.json"
+)
+
+// Exporter writes the TaskSpecCosts and JobCosts for each day to Google Cloud
+// Storage as newline-delimited JSON, which can be loaded into BigQuery, eg.
+//
+//	bq load --source_format=NEWLINE_DELIMITED_JSON <table> gs://<bucket>/<dir>/2024/04/01/task_specs.json
+type Exporter struct {
+	d            db.DB
+	gcs          gcs.GCSClient
+	dir          string
+	rates        Rates
+	taskCfgCache task_cfg_cache.TaskCfgCache
+	liveness     metrics2.Liveness
+}
+
+// NewExporter returns an Exporter which writes to the given directory in the
+// GCS client's bucket.
+func NewExporter(d db.DB, taskCfgCache task_cfg_cache.TaskCfgCache, gcsClient gcs.GCSClient, dir string, rates Rates) *Exporter {
+	return &Exporter{
+		d:            d,
+		gcs:          gcsClient,
+		dir:          dir,
+		rates:        rates,
+		taskCfgCache: taskCfgCache,
+		liveness:     metrics2.NewLiveness("task_scheduler_cost_export"),
+	}
+}
+
+// Start periodically exports any days which have not yet been exported.
+func (e *Exporter) Start(ctx context.Context) {
+	go util.RepeatCtx(ctx, exportInterval, func(ctx context.Context) {
+		if err := e.exportPending(ctx, now.Now(ctx)); err != nil {
+			sklog.Errorf("Failed to export costs: %s", err)
+			return
+		}
+		e.liveness.Reset()
+	})
+}
+
+// dayDir returns the directory in which the files for the given day are
+// written.
+func (e *Exporter) dayDir(day time.Time) string {
+	return path.Join(e.dir, day.Format("2006/01/02"))
+}
+
+// exportPending exports each of the last backfillDays days which ended at
+// least exportDelay before currentTime and has not yet been exported.
+func (e *Exporter) exportPending(ctx context.Context, currentTime time.Time) error {
+	latest := currentTime.UTC().Add(-exportDelay).Truncate(24 * time.Hour).Add(-24 * time.Hour)
+	for i := backfillDays - 1; i >= 0; i-- {
+		day := latest.Add(-time.Duration(i) * 24 * time.Hour)
+		exists, err := e.gcs.DoesFileExist(ctx, path.Join(e.dayDir(day), jobsFile))
+		if err != nil {
+			return skerr.Wrapf(err, "failed to check for exported costs for %s", day.Format(dateFormat))
+		}
+		if exists {
+			continue
+		}
+		if err := e.Export(ctx, day); err != nil {
+			return skerr.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// Export computes the costs of the tasks created on the given day (UTC) and
+// writes them to GCS, overwriting any previous export of that day.
+func (e *Exporter) Export(ctx context.Context, day time.Time) error {
+	start := day.UTC().Truncate(24 * time.Hour)
+	end := start.Add(24 * time.Hour)
+	date := start.Format(dateFormat)
+	sklog.Infof("Exporting costs for %s", date)
+
+	tasks, err := e.d.GetTasksFromDateRange(ctx, start, end, "")
+	if err != nil {
+		return skerr.Wrapf(err, "failed to retrieve tasks for %s", date)
+	}
+	jobNames, err := e.getJobNames(ctx, tasks, start.Add(-jobLookback), end)
+	if err != nil {
+		return skerr.Wrapf(err, "failed to retrieve jobs for %s", date)
+	}
+	specCosts, jobCosts := attribute(date, tasks, e.taskDimensions(ctx), jobNames, e.rates)
+
+	specRows := make([]interface{}, 0, len(specCosts))
+	for _, c := range specCosts {
+		specRows = append(specRows, c)
+	}
+	jobRows := make([]interface{}, 0, len(jobCosts))
+	for _, c := range jobCosts {
+		jobRows = append(jobRows, c)
+	}
+	dir := e.dayDir(start)
+	if err := e.write(ctx, path.Join(dir, taskSpecsFile), specRows); err != nil {
+		return skerr.Wrap(err)
+	}
+	if err := e.write(ctx, path.Join(dir, jobsFile), jobRows); err != nil {
+		return skerr.Wrap(err)
+	}
+	sklog.Infof("Exported costs of %d TaskSpecs and %d Jobs for %s", len(specCosts), len(jobCosts), date)
+	return nil
+}
+
+// getJobNames returns a map of Job ID to Job name for all of the given tasks'
+// Jobs, loading the Jobs created in the given time range in bulk and any
+// others individually.
+func (e *Exporter) getJobNames(ctx context.Context, tasks []*types.Task, from, to time.Time) (map[string]string, error) {
+	jobs, err := e.d.GetJobsFromDateRange(ctx, from, to, "")
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	rv := make(map[string]string, len(jobs))
+	for _, j := range jobs {
+		rv[j.Id] = j.Name
+	}
+	for _, t := range tasks {
+		for _, id := range t.Jobs {
+			if _, ok := rv[id]; ok {
+				continue
+			}
+			j, err := e.d.GetJobById(ctx, id)
+			if err != nil {
+				return nil, skerr.Wrapf(err, "failed to retrieve job %s", id)
+			}
+			if j == nil {
+				sklog.Warningf("Unknown job %s for task %s", id, t.Id)
+				continue
+			}
+			rv[id] = j.Name
+		}
+	}
+	return rv, nil
+}
+
+// taskDimensions returns a function which returns the dimensions of a task's
+// TaskSpec. Tasks whose TaskSpecs can't be loaded have no dimensions.
+func (e *Exporter) taskDimensions(ctx context.Context) func(*types.Task) []string {
+	cfgs := map[types.RepoState]map[string][]string{}
+	return func(t *types.Task) []string {
+		byName, ok := cfgs[t.RepoState]
+		if !ok {
+			byName = map[string][]string{}
+			cfg, cachedErr, err := e.taskCfgCache.Get(ctx, t.RepoState)
+			if cachedErr != nil || err != nil {
+				sklog.Warningf("Failed to load TasksCfg for %s; costs will be missing pool and rate: %s %s", t.RepoState.RowKey(), cachedErr, err)
+			} else {
+				for name, spec := range cfg.Tasks {
+					byName[name] = spec.Dimensions
+				}
+			}
+			cfgs[t.RepoState] = byName
+		}
+		return byName[t.Name]
+	}
+}
+
+// write writes the given rows to the given file as newline-delimited JSON.
+func (e *Exporter) write(ctx context.Context, filename string, rows []interface{}) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return skerr.Wrapf(err, "failed to encode %s", filename)
+		}
+	}
+	opts := gcs.FileWriteOptions{
+		ContentType: "application/json",
+	}
+	if err := e.gcs.SetFileContents(ctx, filename, opts, buf.Bytes()); err != nil {
+		return skerr.Wrapf(err, "failed to write %s", filename)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package cost_accounting
+
+import (
+	"context"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/gcs/mem_gcsclient"
+	"go.skia.org/infra/task_scheduler/go/db/memory"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/task_cfg_cache/mocks"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+func TestExporter_ExportPending(t *testing.T) {
+	ctx := context.Background()
+	d := memory.NewInMemoryDB()
+	job := &types.Job{
+		Id:      "j1",
+		Name:    "Test-Job",
+		Created: costTestTime.Add(-time.Hour),
+	}
+	require.NoError(t, d.PutJob(ctx, job))
+	task := costTestTask("skia.git", "Test", costTestTime.Add(time.Hour), time.Hour, job.Id)
+	require.NoError(t, d.PutTask(ctx, task))
+
+	taskCfgCache := mocks.FixedTasksCfg(&specs.TasksCfg{
+		Tasks: map[string]*specs.TaskSpec{
+			"Test": {Dimensions: []string{"pool:Skia"}},
+		},
+	})
+	gcsClient := mem_gcsclient.New("fake-bucket")
+	e := NewExporter(d, taskCfgCache, gcsClient, "costs", Rates{{Dimensions: []string{"pool:Skia"}, USDPerHour: 3}})
+
+	// The day isn't exported until exportDelay after it ends.
+	require.NoError(t, e.exportPending(ctx, costTestTime.Add(24*time.Hour+exportDelay-time.Minute)))
+	exists, err := gcsClient.DoesFileExist(ctx, path.Join("costs", "2024/04/01", jobsFile))
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, e.exportPending(ctx, costTestTime.Add(24*time.Hour+exportDelay)))
+	b, err := gcsClient.GetFileContents(ctx, path.Join("costs", "2024/04/01", taskSpecsFile))
+	require.NoError(t, err)
+	require.Equal(t, `{"date":"2024-04-01","repo":"skia.git","pool":"Skia","task_spec":"Test","tasks":1,"bot_seconds":3600,"cost_usd":3}`, strings.TrimSpace(string(b)))
+	b, err = gcsClient.GetFileContents(ctx, path.Join("costs", "2024/04/01", jobsFile))
+	require.NoError(t, err)
+	require.Equal(t, `{"date":"2024-04-01","repo":"skia.git","job":"Test-Job","tasks":1,"bot_seconds":3600,"cost_usd":3}`, strings.TrimSpace(string(b)))
+
+	// Days which were already exported are not exported again.
+	require.NoError(t, gcsClient.SetFileContents(ctx, path.Join("costs", "2024/04/01", taskSpecsFile), gcs.FileWriteOptions{}, []byte("unchanged")))
+	require.NoError(t, e.exportPending(ctx, costTestTime.Add(48*time.Hour+exportDelay)))
+	b, err = gcsClient.GetFileContents(ctx, path.Join("costs", "2024/04/01", taskSpecsFile))
+	require.NoError(t, err)
+	require.Equal(t, "unchanged", string(b))
+	exists, err = gcsClient.DoesFileExist(ctx, path.Join("costs", "2024/04/02", jobsFile))
+	require.NoError(t, err)
+	require.True(t, exists)
+}
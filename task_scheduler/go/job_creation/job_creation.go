@@ -30,6 +30,12 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+const (
+	// SCHEDULED_JOBS_INTERVAL is how often we check for jobs with
+	// TRIGGER_SCHEDULED which have become due.
+	SCHEDULED_JOBS_INTERVAL = time.Minute
+)
+
 var (
 	// ignoreBranches indicates that we shouldn't schedule on these branches.
 	// WARNING: Any commit reachable from any of these branches will be
@@ -122,6 +128,14 @@ func (jc *JobCreator) Start(ctx context.Context, enableTryjobs bool) {
 	if enableTryjobs {
 		jc.tryjobs.Start(ctx)
 	}
+	lvScheduledJobs := metrics2.NewLiveness("last_successful_scheduled_jobs_trigger")
+	go util.RepeatCtx(ctx, SCHEDULED_JOBS_INTERVAL, func(ctx context.Context) {
+		if err := jc.MaybeTriggerScheduledJobs(ctx); err != nil {
+			sklog.Errorf("Failed to trigger scheduled jobs: %s", err)
+		} else {
+			lvScheduledJobs.Reset()
+		}
+	})
 }
 
 // putJobsInChunks is a wrapper around DB.PutJobsInChunks which adds the jobs
@@ -241,7 +255,7 @@ func (jc *JobCreator) gatherNewJobs(ctx context.Context, repoUrl string, repo *r
 				}
 				if !alreadyScheduled {
 					alreadyScheduledAllJobs = false
-					j, err := task_cfg_cache.MakeJob(ctx, jc.taskCfgCache, rs, name)
+					j, err := task_cfg_cache.MakeJob(ctx, jc.taskCfgCache, rs, name, nil)
 					if err != nil {
 						// We shouldn't get ErrNoSuchEntry due to the
 						// call to jc.cacher.GetOrCacheRepoState above,
@@ -381,6 +395,35 @@ func (jc *JobCreator) initCaches(ctx context.Context) error {
 	return g.Wait()
 }
 
+// forEachMainCfg calls fn with the TasksCfg at the head of the main branch of
+// each repo.
+func (jc *JobCreator) forEachMainCfg(ctx context.Context, fn func(types.RepoState, *specs.TasksCfg) error) error {
+	for repoUrl, repo := range jc.repos {
+		main := repo.Get(git.MasterBranch)
+		if main == nil {
+			main = repo.Get(git.MainBranch)
+		}
+		if main == nil {
+			return skerr.Fmt("failed to retrieve branch %q or %q for %s", git.MasterBranch, git.MainBranch, repoUrl)
+		}
+		rs := types.RepoState{
+			Repo:     repoUrl,
+			Revision: main.Hash,
+		}
+		cfg, cachedErr, err := jc.taskCfgCache.Get(ctx, rs)
+		if cachedErr != nil {
+			err = cachedErr
+		}
+		if err != nil {
+			return skerr.Wrapf(err, "failed to retrieve TaskCfg from %s", repoUrl)
+		}
+		if err := fn(rs, cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // MaybeTriggerPeriodicJobs triggers all periodic jobs with the given trigger
 // name, if those jobs haven't already been triggered.
 func (jc *JobCreator) MaybeTriggerPeriodicJobs(ctx context.Context, triggerName string) error {
@@ -407,28 +450,10 @@ func (jc *JobCreator) MaybeTriggerPeriodicJobs(ctx context.Context, triggerName
 
 	// Find the job specs matching the trigger and create Job instances.
 	jobs := []*types.Job{}
-	for repoUrl, repo := range jc.repos {
-		main := repo.Get(git.MasterBranch)
-		if main == nil {
-			main = repo.Get(git.MainBranch)
-		}
-		if main == nil {
-			return skerr.Fmt("failed to retrieve branch %q or %q for %s", git.MasterBranch, git.MainBranch, repoUrl)
-		}
-		rs := types.RepoState{
-			Repo:     repoUrl,
-			Revision: main.Hash,
-		}
-		cfg, cachedErr, err := jc.taskCfgCache.Get(ctx, rs)
-		if cachedErr != nil {
-			err = cachedErr
-		}
-		if err != nil {
-			return skerr.Wrapf(err, "failed to retrieve TaskCfg from %s", repoUrl)
-		}
+	if err := jc.forEachMainCfg(ctx, func(rs types.RepoState, cfg *specs.TasksCfg) error {
 		for name, js := range cfg.Jobs {
 			if js.Trigger == triggerName {
-				job, err := task_cfg_cache.MakeJob(ctx, jc.taskCfgCache, rs, name)
+				job, err := task_cfg_cache.MakeJob(ctx, jc.taskCfgCache, rs, name, nil)
 				if err != nil {
 					return skerr.Wrapf(err, "failed to create job")
 				}
@@ -436,6 +461,9 @@ func (jc *JobCreator) MaybeTriggerPeriodicJobs(ctx context.Context, triggerName
 				jobs = append(jobs, job)
 			}
 		}
+		return nil
+	}); err != nil {
+		return err
 	}
 	if len(jobs) == 0 {
 		return nil
@@ -479,3 +507,57 @@ func (jc *JobCreator) MaybeTriggerPeriodicJobs(ctx context.Context, triggerName
 	sklog.Infof("Created %d periodic jobs for trigger %q", len(jobs), triggerName)
 	return nil
 }
+
+// MaybeTriggerScheduledJobs triggers all jobs with TRIGGER_SCHEDULED which
+// have become due according to their JobSchedules, if those jobs haven't
+// already been triggered since they became due.
+func (jc *JobCreator) MaybeTriggerScheduledJobs(ctx context.Context) error {
+	currentTime := now.Now(ctx)
+	jobs := []*types.Job{}
+	if err := jc.forEachMainCfg(ctx, func(rs types.RepoState, cfg *specs.TasksCfg) error {
+		for name, js := range cfg.Jobs {
+			if js.Trigger != specs.TRIGGER_SCHEDULED || js.Schedule == nil {
+				continue
+			}
+			due, ok, err := js.Schedule.MostRecentDue(name, currentTime)
+			if err != nil {
+				return skerr.Wrapf(err, "failed to evaluate schedule for job %s in %s", name, rs.Repo)
+			}
+			if !ok {
+				continue
+			}
+			existing, err := jc.jCache.GetMatchingJobsFromDateRange([]string{name}, due, currentTime.Add(time.Second))
+			if err != nil {
+				return skerr.Wrap(err)
+			}
+			alreadyTriggered := false
+			for _, prev := range existing[name] {
+				if prev.Repo == rs.Repo && !prev.IsTryJob() && !prev.IsForce {
+					alreadyTriggered = true
+					break
+				}
+			}
+			if alreadyTriggered {
+				continue
+			}
+			job, err := task_cfg_cache.MakeJob(ctx, jc.taskCfgCache, rs, name, nil)
+			if err != nil {
+				return skerr.Wrapf(err, "failed to create job")
+			}
+			job.Requested = job.Created
+			sklog.Infof("Triggering scheduled job %s in %s, due at %s", name, rs.Repo, due)
+			jobs = append(jobs, job)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+	if err := jc.putJobsInChunks(ctx, jobs); err != nil {
+		return skerr.Wrapf(err, "failed to add scheduled jobs")
+	}
+	sklog.Infof("Created %d scheduled jobs", len(jobs))
+	return nil
+}
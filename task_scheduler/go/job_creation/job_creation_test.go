@@ -18,6 +18,7 @@ import (
 	"go.skia.org/infra/go/git/repograph"
 	git_testutils "go.skia.org/infra/go/git/testutils"
 	"go.skia.org/infra/go/mockhttpclient"
+	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/swarming"
 	"go.skia.org/infra/go/testutils"
@@ -269,6 +270,71 @@ func TestPeriodicJobs(t *testing.T) {
 	require.Equal(t, weeklyName, jobs[weeklyName][0].Name)
 }
 
+func TestScheduledJobs(t *testing.T) {
+	ctx, gb, _, jc, _, _, cleanup := setup(t)
+	defer cleanup()
+
+	// Rewrite tasks.json with a scheduled job.
+	jobName := "Scheduled-Job"
+	taskName := "Scheduled-Task"
+	cfg := &specs.TasksCfg{
+		Jobs: map[string]*specs.JobSpec{
+			jobName: {
+				Priority:  1.0,
+				TaskSpecs: []string{taskName},
+				Trigger:   specs.TRIGGER_SCHEDULED,
+				Schedule: &specs.JobSchedule{
+					Cron: "0 * * * *",
+				},
+			},
+		},
+		Tasks: map[string]*specs.TaskSpec{
+			taskName: {
+				CipdPackages: []*specs.CipdPackage{},
+				Dependencies: []string{},
+				Dimensions: []string{
+					"pool:Skia",
+					"os:Mac",
+					"gpu:my-gpu",
+				},
+				ExecutionTimeout: 40 * time.Minute,
+				Expiration:       2 * time.Hour,
+				IoTimeout:        3 * time.Minute,
+				CasSpec:          "compile",
+				Priority:         1.0,
+			},
+		},
+		CasSpecs: map[string]*specs.CasSpec{
+			"compile": {
+				Digest: "abc123/45",
+			},
+		},
+	}
+	gb.Add(ctx, specs.TASKS_CFG_FILE, testutils.MarshalJSON(t, &cfg))
+	gb.Commit(ctx)
+	updateRepos(t, ctx, jc)
+
+	hour := time.Now().UTC().Truncate(time.Hour)
+	trigger := func(ts time.Time) int {
+		require.NoError(t, jc.MaybeTriggerScheduledJobs(context.WithValue(ctx, now.ContextKey, ts)))
+		require.NoError(t, jc.jCache.Update(ctx))
+		jobs, err := jc.jCache.GetMatchingJobsFromDateRange([]string{jobName}, hour.Add(-time.Hour), hour.Add(3*time.Hour))
+		require.NoError(t, err)
+		return len(jobs[jobName])
+	}
+
+	// The job is due at the top of the hour.
+	require.Equal(t, 1, trigger(hour.Add(time.Minute)))
+	// Ensure that we don't trigger another.
+	require.Equal(t, 1, trigger(hour.Add(2*time.Minute)))
+	// Nothing is due.
+	require.Equal(t, 1, trigger(hour.Add(30*time.Minute)))
+	// The job is due again.
+	require.Equal(t, 2, trigger(hour.Add(time.Hour+time.Minute)))
+	// Missed times are skipped, since the schedule doesn't catch up.
+	require.Equal(t, 2, trigger(hour.Add(2*time.Hour+30*time.Minute)))
+}
+
 func TestTaskSchedulerIntegration(t *testing.T) {
 
 	ctx, _, d, jc, _, cas, cleanup := setup(t)
@@ -288,7 +354,7 @@ func TestTaskSchedulerIntegration(t *testing.T) {
 		types.TaskExecutor_UseDefault: swarmingTaskExec,
 		types.TaskExecutor_Swarming:   swarmingTaskExec,
 	}
-	ts, err := scheduling.NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, jc.repos, cas, "fake-rbe-instance", taskExecs, urlMock.Client(), 1.0, swarming.POOLS_PUBLIC, "", jc.taskCfgCache, nil, mem_gcsclient.New("fake"), "testing", scheduling.BusyBotsDebugLoggingOff)
+	ts, err := scheduling.NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, jc.repos, cas, "fake-rbe-instance", taskExecs, urlMock.Client(), 1.0, swarming.POOLS_PUBLIC, "", jc.taskCfgCache, nil, mem_gcsclient.New("fake"), "testing", scheduling.BusyBotsDebugLoggingOff, nil, scheduling.StarvationProtection{}, scheduling.BotAffinity{}, nil, scheduling.Quarantine{})
 	require.NoError(t, err)
 
 	jc.Start(ctx, false)
@@ -0,0 +1,183 @@
+// Package jobstream pushes Job and Task state transitions to web clients using
+// Server-Sent Events, so that the job pages can update live instead of
+// repeatedly loading the Job.
+//
+// Each Server watches the DB for modified Jobs and Tasks once and fans the
+// changes out to all of its connected clients.
+package jobstream
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/sse"
+	"go.skia.org/infra/task_scheduler/go/db"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// UpdateTypeJob indicates that an Update describes a Job.
+	UpdateTypeJob = "job"
+	// UpdateTypeTask indicates that an Update describes a Task.
+	UpdateTypeTask = "task"
+)
+
+// Update is the data sent in each Server-Sent Event. It describes a change in
+// the status of a Job or one of its Tasks.
+type Update struct {
+	Type   string `json:"type"`
+	JobID  string `json:"job_id"`
+	TaskID string `json:"task_id,omitempty"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// subscriber is a single connected client.
+type subscriber struct {
+	jobID   string
+	updates chan Update
+
+	// lastStatus is the last status sent to the client for each Job and
+	// Task, used to send only state transitions. Protected by Server.mutex.
+	lastStatus map[string]string
+}
+
+// Server keeps track of all the connected clients and pushes Updates to them.
+type Server struct {
+	mutex       sync.Mutex
+	subscribers map[string]map[*subscriber]bool
+
+	connected metrics2.Int64Metric
+	dropped   metrics2.Counter
+}
+
+// New returns a new *Server.
+func New() *Server {
+	return &Server{
+		subscribers: map[string]map[*subscriber]bool{},
+		connected:   metrics2.GetInt64Metric("task_scheduler_jobstream_connected_clients"),
+		dropped:     metrics2.GetCounter("task_scheduler_jobstream_dropped_updates"),
+	}
+}
+
+// Start watches the given DB for modified Jobs and Tasks and publishes them to
+// the connected clients. This function returns immediately; the DB is watched
+// until the context is cancelled.
+func (s *Server) Start(ctx context.Context, d db.DB) {
+	jobsCh := d.ModifiedJobsCh(ctx)
+	tasksCh := d.ModifiedTasksCh(ctx)
+	go func() {
+		for jobs := range jobsCh {
+			for _, job := range jobs {
+				s.PublishJob(job)
+			}
+		}
+	}()
+	go func() {
+		for tasks := range tasksCh {
+			for _, task := range tasks {
+				s.PublishTask(task)
+			}
+		}
+	}()
+}
+
+// PublishJob sends the status of the given Job to its subscribers.
+func (s *Server) PublishJob(job *types.Job) {
+	s.publish(job.Id, Update{
+		Type:   UpdateTypeJob,
+		JobID:  job.Id,
+		Name:   job.Name,
+		Status: string(job.Status),
+	}, job.Id)
+}
+
+// PublishTask sends the status of the given Task to the subscribers of each
+// of the Jobs which use it.
+func (s *Server) PublishTask(task *types.Task) {
+	for _, jobID := range task.Jobs {
+		s.publish(jobID, Update{
+			Type:   UpdateTypeTask,
+			JobID:  jobID,
+			TaskID: task.Id,
+			Name:   task.Name,
+			Status: string(task.Status),
+		}, task.Id)
+	}
+}
+
+// publish sends the Update to the subscribers of the given Job, unless they
+// have already been sent the same status for the given Job or Task ID.
+func (s *Server) publish(jobID string, update Update, id string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for sub := range s.subscribers[jobID] {
+		// Note that some statuses, eg. JOB_STATUS_IN_PROGRESS, are empty.
+		if prev, ok := sub.lastStatus[id]; ok && prev == update.Status {
+			continue
+		}
+		select {
+		case sub.updates <- update:
+			sub.lastStatus[id] = update.Status
+		default:
+			s.dropped.Inc(1)
+		}
+	}
+}
+
+// subscribe adds a subscriber for the given Job. The returned function must be
+// called to remove it.
+func (s *Server) subscribe(jobID string) (*subscriber, func()) {
+	sub := &subscriber{
+		jobID:      jobID,
+		updates:    make(chan Update, sse.BufferSize),
+		lastStatus: map[string]string{},
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.subscribers[jobID]; !ok {
+		s.subscribers[jobID] = map[*subscriber]bool{}
+	}
+	s.subscribers[jobID][sub] = true
+	s.updateConnectedMetric()
+	return sub, func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		delete(s.subscribers[jobID], sub)
+		if len(s.subscribers[jobID]) == 0 {
+			delete(s.subscribers, jobID)
+		}
+		s.updateConnectedMetric()
+	}
+}
+
+// updateConnectedMetric updates the metric for the number of connected
+// clients. Assumes that the caller holds s.mutex.
+func (s *Server) updateConnectedMetric() {
+	count := 0
+	for _, subs := range s.subscribers {
+		count += len(subs)
+	}
+	s.connected.Update(int64(count))
+}
+
+// Handler returns an http.HandlerFunc which streams Updates for the Job whose
+// ID is returned by getJobID to the client until it disconnects.
+//
+// The response must not be gzipped, as that would buffer the events.
+func (s *Server) Handler(getJobID func(*http.Request) string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID := getJobID(r)
+		if jobID == "" {
+			httputils.ReportError(w, nil, "Job ID is required.", http.StatusBadRequest)
+			return
+		}
+		sub, unsubscribe := s.subscribe(jobID)
+		defer unsubscribe()
+
+		sse.Serve(w, r, sub.updates)
+	}
+}
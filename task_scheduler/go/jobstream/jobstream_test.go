@@ -0,0 +1,133 @@
+package jobstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/sse"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const fakeJobID = "fake-job"
+
+func getJobID(r *http.Request) string {
+	return r.URL.Query().Get("id")
+}
+
+// connect subscribes to the given Job via an httptest.Server and returns a
+// Scanner for the response body once the subscription is registered.
+func connect(t *testing.T, s *Server, jobID string) *bufio.Scanner {
+	server := httptest.NewServer(s.Handler(getJobID))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?id="+jobID, nil)
+	require.NoError(t, err)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	require.Eventually(t, func() bool {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		return len(s.subscribers[jobID]) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	return bufio.NewScanner(resp.Body)
+}
+
+// nextUpdate reads the next Update from the stream.
+func nextUpdate(t *testing.T, scanner *bufio.Scanner) Update {
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		var update Update
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &update))
+		return update
+	}
+	require.FailNow(t, "stream ended unexpectedly", scanner.Err())
+	return Update{}
+}
+
+func TestHandler_NoJobID_ReturnsBadRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	New().Handler(getJobID)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_JobAndTaskTransitions_ClientReceivesUpdates(t *testing.T) {
+	s := New()
+	scanner := connect(t, s, fakeJobID)
+
+	// Updates for other Jobs are not sent.
+	s.PublishJob(&types.Job{Id: "other-job", Name: "other", Status: types.JOB_STATUS_SUCCESS})
+
+	s.PublishJob(&types.Job{Id: fakeJobID, Name: "my-job", Status: types.JOB_STATUS_IN_PROGRESS})
+	require.Equal(t, Update{
+		Type:   UpdateTypeJob,
+		JobID:  fakeJobID,
+		Name:   "my-job",
+		Status: string(types.JOB_STATUS_IN_PROGRESS),
+	}, nextUpdate(t, scanner))
+
+	task := &types.Task{
+		Id:   "fake-task",
+		Jobs: []string{"other-job", fakeJobID},
+		TaskKey: types.TaskKey{
+			Name: "my-task",
+		},
+		Status: types.TASK_STATUS_RUNNING,
+	}
+	s.PublishTask(task)
+	// Modifications which don't change the status are not sent.
+	s.PublishTask(task)
+	task.Status = types.TASK_STATUS_SUCCESS
+	s.PublishTask(task)
+	require.Equal(t, Update{
+		Type:   UpdateTypeTask,
+		JobID:  fakeJobID,
+		TaskID: "fake-task",
+		Name:   "my-task",
+		Status: string(types.TASK_STATUS_RUNNING),
+	}, nextUpdate(t, scanner))
+	require.Equal(t, Update{
+		Type:   UpdateTypeTask,
+		JobID:  fakeJobID,
+		TaskID: "fake-task",
+		Name:   "my-task",
+		Status: string(types.TASK_STATUS_SUCCESS),
+	}, nextUpdate(t, scanner))
+}
+
+func TestPublish_BufferFull_DropsUpdates(t *testing.T) {
+	s := New()
+	sub, unsubscribe := s.subscribe(fakeJobID)
+	defer unsubscribe()
+	for i := 0; i < sse.BufferSize+5; i++ {
+		s.PublishTask(&types.Task{
+			Id:     "task-" + string(rune('a'+i)),
+			Jobs:   []string{fakeJobID},
+			Status: types.TASK_STATUS_PENDING,
+		})
+	}
+	require.Len(t, sub.updates, sse.BufferSize)
+}
+
+func TestSubscribe_Unsubscribe_RemovesSubscriber(t *testing.T) {
+	s := New()
+	_, unsubscribe := s.subscribe(fakeJobID)
+	require.Len(t, s.subscribers[fakeJobID], 1)
+	unsubscribe()
+	require.Empty(t, s.subscribers)
+}
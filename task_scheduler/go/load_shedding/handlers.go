@@ -0,0 +1,58 @@
+package load_shedding
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/sklog"
+)
+
+// maxSetModeRequestBytes is the maximum size of a SetModeRequest.
+const maxSetModeRequestBytes = 1 << 16
+
+// SetModeRequest is the request body for SetModeHandler.
+type SetModeRequest struct {
+	Mode   Mode   `json:"mode"`
+	Reason string `json:"reason"`
+}
+
+// StateHandler returns an http.HandlerFunc which serves the current State.
+func StateHandler(d *DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeState(w, d.Get())
+	}
+}
+
+// SetModeHandler returns an http.HandlerFunc which sets the Mode given in the
+// SetModeRequest in the request body and responds with the updated State.
+// Callers are responsible for restricting access to the handler.
+func SetModeHandler(d *DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SetModeRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSetModeRequestBytes)).Decode(&req); err != nil {
+			httputils.ReportError(w, err, "Failed to decode request.", http.StatusBadRequest)
+			return
+		}
+		user := alogin.GetStatus(r.Context()).EMail.String()
+		if err := validateMode(req.Mode, user, req.Reason); err != nil {
+			httputils.ReportError(w, err, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := d.SetMode(r.Context(), req.Mode, user, req.Reason); err != nil {
+			httputils.ReportError(w, err, "Failed to set load shedding mode.", http.StatusInternalServerError)
+			return
+		}
+		sklog.Infof("Load shedding mode set to %q by %s: %s", req.Mode, user, req.Reason)
+		writeState(w, d.Get())
+	}
+}
+
+// writeState writes the given State as JSON.
+func writeState(w http.ResponseWriter, st *State) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(st); err != nil {
+		sklog.Errorf("Failed to write response: %s", err)
+	}
+}
@@ -0,0 +1,36 @@
+package load_shedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/alogin"
+)
+
+func TestStateHandler_DefaultState(t *testing.T) {
+	w := httptest.NewRecorder()
+	StateHandler(nil)(w, httptest.NewRequest(http.MethodGet, "/json/load_shedding", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var st State
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&st))
+	require.Equal(t, ModeAuto, st.Mode)
+	require.False(t, st.Active)
+}
+
+func TestSetModeHandler_InvalidRequest(t *testing.T) {
+	ctx := alogin.FakeStatus(context.Background(), &alogin.Status{EMail: "me@google.com"})
+	do := func(body string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/json/load_shedding", bytes.NewReader([]byte(body))).WithContext(ctx)
+		SetModeHandler(nil)(w, r)
+		return w.Code
+	}
+	require.Equal(t, http.StatusBadRequest, do(`not json`))
+	require.Equal(t, http.StatusBadRequest, do(`{"mode": "sometimes", "reason": "incident"}`))
+	require.Equal(t, http.StatusBadRequest, do(`{"mode": "on"}`))
+}
@@ -0,0 +1,385 @@
+// Package load_shedding provides a mode in which the Task Scheduler stops
+// scheduling tasks for configurable low-priority classes of Jobs, leaving bot
+// capacity for the commit queue during incidents. Load shedding may be turned
+// on or off via the API, or left to activate automatically when the backlog of
+// task candidates exceeds a threshold.
+package load_shedding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	fs "cloud.google.com/go/firestore"
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/firestore"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/types"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Collection name for the load shedding state.
+	collection = "load-shedding"
+
+	// ID of the document which holds the State.
+	stateDoc = "state"
+
+	// We'll perform this many attempts for a given request.
+	defaultAttempts = 3
+
+	// Timeouts for various requests.
+	timeoutGet = 60 * time.Second
+	timeoutPut = 10 * time.Second
+)
+
+// Mode indicates how load shedding is controlled.
+type Mode string
+
+const (
+	// ModeAuto activates load shedding when the backlog exceeds the
+	// configured threshold.
+	ModeAuto Mode = "auto"
+	// ModeOn forces load shedding on, regardless of the backlog.
+	ModeOn Mode = "on"
+	// ModeOff forces load shedding off, regardless of the backlog.
+	ModeOff Mode = "off"
+)
+
+// ValidModes lists all valid Modes.
+var ValidModes = []Mode{ModeAuto, ModeOn, ModeOff}
+
+// JobClass is a category of Jobs which may be suspended while load shedding is
+// active.
+type JobClass string
+
+const (
+	// JobClassTryjob includes all Jobs requested via Buildbucket, including
+	// those requested by the commit queue. These are never suspended.
+	JobClassTryjob JobClass = "tryjob"
+	// JobClassForced includes Jobs which were triggered manually.
+	JobClassForced JobClass = "forced"
+	// JobClassPeriodic includes Jobs which are triggered nightly, weekly, or
+	// on a schedule.
+	JobClassPeriodic JobClass = "periodic"
+	// JobClassCommit includes Jobs which are triggered by commits landing.
+	JobClassCommit JobClass = "commit"
+)
+
+// ValidJobClasses lists all JobClasses which may be suspended.
+var ValidJobClasses = []JobClass{JobClassForced, JobClassPeriodic, JobClassCommit}
+
+// Classify returns the JobClass of the given Job, whose JobSpec has the given
+// trigger.
+func Classify(job *types.Job, trigger string) JobClass {
+	if job.BuildbucketBuildId != 0 {
+		return JobClassTryjob
+	}
+	if job.IsForce {
+		return JobClassForced
+	}
+	if trigger == specs.TRIGGER_SCHEDULED || util.In(trigger, specs.PERIODIC_TRIGGERS) {
+		return JobClassPeriodic
+	}
+	return JobClassCommit
+}
+
+// State is the load shedding state shared between the Task Scheduler frontend,
+// through which the Mode is set, and the scheduler, which decides whether load
+// shedding is active and reports the outcome.
+type State struct {
+	// Mode, and who set it and why.
+	Mode        Mode      `json:"mode"`
+	ModeReason  string    `json:"mode_reason,omitempty"`
+	ModeSetBy   string    `json:"mode_set_by,omitempty"`
+	ModeUpdated time.Time `json:"mode_updated,omitempty"`
+
+	// Active indicates whether the scheduler is currently suspending Jobs,
+	// with a human-readable explanation in ActiveReason.
+	Active       bool   `json:"active"`
+	ActiveReason string `json:"active_reason,omitempty"`
+	// Backlog is the number of task candidates which were eligible to run
+	// in the most recent scheduling loop, before load shedding.
+	Backlog int `json:"backlog"`
+	// SuspendedClasses are the JobClasses suspended while active.
+	SuspendedClasses []JobClass `json:"suspended_classes"`
+	// SuspendedCandidates is the number of task candidates which were not
+	// scheduled due to load shedding in the most recent scheduling loop.
+	SuspendedCandidates int `json:"suspended_candidates"`
+	// Updated is the time at which the scheduler last reported its status.
+	Updated time.Time `json:"updated,omitempty"`
+}
+
+// Firestore field paths of the parts of the State set by the frontend and by
+// the scheduler, respectively, so that neither overwrites the other.
+var (
+	modeFields   = []fs.FieldPath{{"Mode"}, {"ModeReason"}, {"ModeSetBy"}, {"ModeUpdated"}}
+	statusFields = []fs.FieldPath{{"Active"}, {"ActiveReason"}, {"Backlog"}, {"SuspendedClasses"}, {"SuspendedCandidates"}, {"Updated"}}
+)
+
+// Copy returns a deep copy of the State.
+func (s *State) Copy() *State {
+	rv := *s
+	if s.SuspendedClasses != nil {
+		rv.SuspendedClasses = append([]JobClass{}, s.SuspendedClasses...)
+	}
+	return &rv
+}
+
+// DB is a struct which provides access to the load shedding State.
+type DB struct {
+	client *firestore.Client
+	doc    *fs.DocumentRef
+	mtx    sync.RWMutex
+	state  *State
+}
+
+// NewWithParams returns a DB instance backed by Firestore, using the given params.
+func NewWithParams(ctx context.Context, project, instance string, ts oauth2.TokenSource) (*DB, error) {
+	client, err := firestore.NewClient(ctx, project, firestore.APP_TASK_SCHEDULER, instance, ts)
+	if err != nil {
+		return nil, err
+	}
+	return New(ctx, client)
+}
+
+// New returns a DB instance backed by the given firestore.Client.
+func New(ctx context.Context, client *firestore.Client) (*DB, error) {
+	d := &DB{
+		client: client,
+		doc:    client.Collection(collection).Doc(stateDoc),
+	}
+	if err := d.Update(ctx); err != nil {
+		util.LogErr(d.Close())
+		return nil, err
+	}
+	return d, nil
+}
+
+// Close closes the database.
+func (d *DB) Close() error {
+	if d != nil {
+		return d.client.Close()
+	}
+	return nil
+}
+
+// decode returns the State contained in the given document.
+func decode(doc *fs.DocumentSnapshot) (*State, error) {
+	var st State
+	if err := doc.DataTo(&st); err != nil {
+		return nil, skerr.Wrapf(err, "failed to decode load shedding state")
+	}
+	if st.Mode == "" {
+		st.Mode = ModeAuto
+	}
+	return &st, nil
+}
+
+// Update updates the local view of the State to match the remote DB.
+func (d *DB) Update(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "loadshedding_Update")
+	defer span.End()
+	if d == nil {
+		return nil
+	}
+	st := &State{Mode: ModeAuto}
+	doc, err := d.client.Get(ctx, d.doc, defaultAttempts, timeoutGet)
+	if err == nil {
+		st, err = decode(doc)
+		if err != nil {
+			return err
+		}
+	} else if s, ok := status.FromError(err); !ok || s.Code() != codes.NotFound {
+		return skerr.Wrapf(err, "failed to retrieve load shedding state")
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.state = st
+	return nil
+}
+
+// AutoUpdate starts a goroutine which automatically updates the DB as changes
+// occur. Starts the goroutine and returns immediately. The goroutine exits when
+// the given context expires.
+func (d *DB) AutoUpdate(ctx context.Context) {
+	go func() {
+		for snap := range firestore.QuerySnapshotChannel(ctx, d.doc.Parent.Query) {
+			docs, err := snap.Documents.GetAll()
+			if err != nil {
+				sklog.Errorf("Failed to retrieve documents from query snapshot: %s", err)
+				continue
+			}
+			for _, doc := range docs {
+				if doc.Ref.ID != stateDoc {
+					continue
+				}
+				st, err := decode(doc)
+				if err != nil {
+					sklog.Errorf("Failed to decode document %s from query snapshot: %s", doc.Ref.ID, err)
+					continue
+				}
+				d.mtx.Lock()
+				d.state = st
+				d.mtx.Unlock()
+			}
+		}
+	}()
+}
+
+// Get returns a copy of the current State.
+func (d *DB) Get() *State {
+	if d == nil {
+		return &State{Mode: ModeAuto}
+	}
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	return d.state.Copy()
+}
+
+// SetMode sets the load shedding Mode.
+func (d *DB) SetMode(ctx context.Context, mode Mode, user, reason string) error {
+	if d == nil {
+		return skerr.Fmt("DB is nil; cannot set mode.")
+	}
+	if err := validateMode(mode, user, reason); err != nil {
+		return err
+	}
+	update := &State{
+		Mode:        mode,
+		ModeReason:  reason,
+		ModeSetBy:   user,
+		ModeUpdated: time.Now().UTC(),
+	}
+	if _, err := d.client.Set(ctx, d.doc, update, defaultAttempts, timeoutPut, fs.Merge(modeFields...)); err != nil {
+		return skerr.Wrapf(err, "failed to set load shedding mode")
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.state.Mode = update.Mode
+	d.state.ModeReason = update.ModeReason
+	d.state.ModeSetBy = update.ModeSetBy
+	d.state.ModeUpdated = update.ModeUpdated
+	return nil
+}
+
+// validateMode returns an error if the given Mode may not be set by the given
+// user with the given reason.
+func validateMode(mode Mode, user, reason string) error {
+	if !util.In(string(mode), modeStrings()) {
+		return fmt.Errorf("invalid load shedding mode %q", mode)
+	}
+	if user == "" {
+		return fmt.Errorf("a user is required to set the load shedding mode")
+	}
+	if mode != ModeAuto && reason == "" {
+		return fmt.Errorf("a reason is required to turn load shedding %s", mode)
+	}
+	return nil
+}
+
+// setStatus records the outcome of the scheduler's load shedding decision.
+func (d *DB) setStatus(ctx context.Context, update *State) error {
+	if d == nil {
+		return skerr.Fmt("DB is nil; cannot set status.")
+	}
+	if _, err := d.client.Set(ctx, d.doc, update, defaultAttempts, timeoutPut, fs.Merge(statusFields...)); err != nil {
+		return skerr.Wrapf(err, "failed to set load shedding status")
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.state.Active = update.Active
+	d.state.ActiveReason = update.ActiveReason
+	d.state.Backlog = update.Backlog
+	d.state.SuspendedClasses = update.SuspendedClasses
+	d.state.SuspendedCandidates = update.SuspendedCandidates
+	d.state.Updated = update.Updated
+	return nil
+}
+
+// modeStrings returns ValidModes as strings.
+func modeStrings() []string {
+	rv := make([]string, 0, len(ValidModes))
+	for _, m := range ValidModes {
+		rv = append(rv, string(m))
+	}
+	return rv
+}
+
+// Config configures the scheduler's load shedding behavior.
+type Config struct {
+	// SuspendedClasses are the JobClasses for which tasks are not scheduled
+	// while load shedding is active. Tasks shared with Jobs of other classes
+	// are still scheduled.
+	SuspendedClasses []JobClass
+	// ActivateBacklog is the number of eligible task candidates at or above
+	// which load shedding activates automatically in ModeAuto. If zero,
+	// load shedding never activates automatically.
+	ActivateBacklog int
+	// DeactivateBacklog is the number of eligible task candidates which are
+	// not suspended at or below which automatically-activated load shedding
+	// deactivates. Must be less than ActivateBacklog, to prevent load
+	// shedding from flapping.
+	DeactivateBacklog int
+}
+
+// Validate returns an error if the Config is not valid.
+func (c Config) Validate() error {
+	for _, class := range c.SuspendedClasses {
+		if class == JobClassTryjob {
+			return fmt.Errorf("tryjobs may not be suspended")
+		}
+		if !util.In(string(class), jobClassStrings()) {
+			return fmt.Errorf("invalid job class %q; valid classes are %v", class, ValidJobClasses)
+		}
+	}
+	if c.ActivateBacklog < 0 || c.DeactivateBacklog < 0 {
+		return fmt.Errorf("backlog thresholds must not be negative")
+	}
+	if c.ActivateBacklog > 0 && c.DeactivateBacklog >= c.ActivateBacklog {
+		return fmt.Errorf("deactivate backlog (%d) must be less than activate backlog (%d)", c.DeactivateBacklog, c.ActivateBacklog)
+	}
+	return nil
+}
+
+// jobClassStrings returns ValidJobClasses as strings.
+func jobClassStrings() []string {
+	rv := make([]string, 0, len(ValidJobClasses))
+	for _, c := range ValidJobClasses {
+		rv = append(rv, string(c))
+	}
+	return rv
+}
+
+// decide returns whether load shedding should be active and why, given the
+// State, whether load shedding was previously active, the current backlog and
+// the part of the backlog which is not suspended while load shedding is active.
+// Suspended candidates stay in the backlog, so once active, load shedding only
+// deactivates based on the candidates which are still being scheduled.
+func (c Config) decide(st *State, wasActive bool, backlog, unsuspendedBacklog int) (bool, string) {
+	switch st.Mode {
+	case ModeOn:
+		return true, fmt.Sprintf("Turned on by %s: %s", st.ModeSetBy, st.ModeReason)
+	case ModeOff:
+		return false, fmt.Sprintf("Turned off by %s: %s", st.ModeSetBy, st.ModeReason)
+	}
+	if c.ActivateBacklog <= 0 {
+		return false, ""
+	}
+	if wasActive {
+		if unsuspendedBacklog > c.DeactivateBacklog {
+			return true, fmt.Sprintf("Backlog of %d task candidates which are not suspended has not yet dropped to %d.", unsuspendedBacklog, c.DeactivateBacklog)
+		}
+		return false, ""
+	}
+	if backlog >= c.ActivateBacklog {
+		return true, fmt.Sprintf("Backlog of %d task candidates is at least %d.", backlog, c.ActivateBacklog)
+	}
+	return false, ""
+}
@@ -0,0 +1,123 @@
+package load_shedding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	ftestutils "go.skia.org/infra/go/firestore/testutils"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+func setup(t *testing.T) (*DB, func()) {
+	c, cleanup := ftestutils.NewClientForTesting(context.Background(), t)
+	d, err := New(context.Background(), c)
+	require.NoError(t, err)
+	return d, cleanup
+}
+
+func TestDB_ModeAndStatusDoNotOverwriteEachOther(t *testing.T) {
+	d, cleanup := setup(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Default state.
+	require.Equal(t, &State{Mode: ModeAuto}, d.Get())
+
+	require.NoError(t, d.SetMode(ctx, ModeOn, "me@google.com", "CQ is backed up"))
+	ts := time.Unix(1687000000, 0).UTC()
+	require.NoError(t, d.setStatus(ctx, &State{
+		Active:              true,
+		ActiveReason:        "Turned on",
+		Backlog:             500,
+		SuspendedClasses:    []JobClass{JobClassPeriodic},
+		SuspendedCandidates: 100,
+		Updated:             ts,
+	}))
+
+	// Load the state from the DB.
+	require.NoError(t, d.Update(ctx))
+	st := d.Get()
+	require.Equal(t, ModeOn, st.Mode)
+	require.Equal(t, "me@google.com", st.ModeSetBy)
+	require.Equal(t, "CQ is backed up", st.ModeReason)
+	require.True(t, st.Active)
+	require.Equal(t, 500, st.Backlog)
+	require.Equal(t, []JobClass{JobClassPeriodic}, st.SuspendedClasses)
+	require.Equal(t, 100, st.SuspendedCandidates)
+	require.True(t, ts.Equal(st.Updated))
+
+	// Setting the mode again doesn't clear the status.
+	require.NoError(t, d.SetMode(ctx, ModeAuto, "me@google.com", ""))
+	require.NoError(t, d.Update(ctx))
+	st = d.Get()
+	require.Equal(t, ModeAuto, st.Mode)
+	require.True(t, st.Active)
+	require.Equal(t, 500, st.Backlog)
+}
+
+func TestValidateMode(t *testing.T) {
+	require.NoError(t, validateMode(ModeAuto, "me@google.com", ""))
+	require.NoError(t, validateMode(ModeOn, "me@google.com", "incident"))
+	require.ErrorContains(t, validateMode("sometimes", "me@google.com", "incident"), "invalid load shedding mode")
+	require.ErrorContains(t, validateMode(ModeOff, "", "incident"), "a user is required")
+	require.ErrorContains(t, validateMode(ModeOff, "me@google.com", ""), "a reason is required")
+}
+
+func TestClassify(t *testing.T) {
+	require.Equal(t, JobClassTryjob, Classify(&types.Job{BuildbucketBuildId: 123, IsForce: true}, specs.TRIGGER_NIGHTLY))
+	require.Equal(t, JobClassForced, Classify(&types.Job{IsForce: true}, specs.TRIGGER_NIGHTLY))
+	require.Equal(t, JobClassPeriodic, Classify(&types.Job{}, specs.TRIGGER_NIGHTLY))
+	require.Equal(t, JobClassPeriodic, Classify(&types.Job{}, specs.TRIGGER_WEEKLY))
+	require.Equal(t, JobClassPeriodic, Classify(&types.Job{}, specs.TRIGGER_SCHEDULED))
+	require.Equal(t, JobClassCommit, Classify(&types.Job{}, specs.TRIGGER_ANY_BRANCH))
+	require.Equal(t, JobClassCommit, Classify(&types.Job{}, specs.TRIGGER_MAIN_ONLY))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	require.NoError(t, Config{}.Validate())
+	require.NoError(t, Config{
+		SuspendedClasses:  []JobClass{JobClassCommit, JobClassPeriodic, JobClassForced},
+		ActivateBacklog:   100,
+		DeactivateBacklog: 50,
+	}.Validate())
+	require.ErrorContains(t, Config{SuspendedClasses: []JobClass{JobClassTryjob}}.Validate(), "tryjobs may not be suspended")
+	require.ErrorContains(t, Config{SuspendedClasses: []JobClass{"bogus"}}.Validate(), "invalid job class")
+	require.ErrorContains(t, Config{ActivateBacklog: -1}.Validate(), "must not be negative")
+	require.ErrorContains(t, Config{ActivateBacklog: 100, DeactivateBacklog: 100}.Validate(), "must be less than")
+}
+
+func TestConfig_Decide(t *testing.T) {
+	cfg := Config{ActivateBacklog: 100, DeactivateBacklog: 50}
+	auto := &State{Mode: ModeAuto}
+
+	test := func(st *State, wasActive bool, backlog, unsuspendedBacklog int, expect bool) {
+		active, reason := cfg.decide(st, wasActive, backlog, unsuspendedBacklog)
+		require.Equal(t, expect, active, "backlog %d (%d not suspended), was active %v", backlog, unsuspendedBacklog, wasActive)
+		if active {
+			require.NotEmpty(t, reason)
+		}
+	}
+
+	// Automatic activation, with hysteresis.
+	test(auto, false, 99, 99, false)
+	test(auto, false, 100, 100, true)
+	test(auto, true, 75, 75, true)
+	test(auto, true, 50, 50, false)
+
+	// Once active, suspended candidates don't count towards deactivation.
+	test(auto, true, 1000, 75, true)
+	test(auto, true, 1000, 50, false)
+	test(auto, false, 1000, 50, true)
+
+	// The mode overrides the backlog.
+	test(&State{Mode: ModeOn, ModeSetBy: "me@google.com", ModeReason: "incident"}, false, 0, 0, true)
+	test(&State{Mode: ModeOff, ModeSetBy: "me@google.com", ModeReason: "all clear"}, true, 1000, 1000, false)
+
+	// Automatic activation is disabled without a threshold.
+	cfg = Config{}
+	test(auto, false, 1000, 1000, false)
+	test(&State{Mode: ModeOn}, false, 0, 0, true)
+}
@@ -0,0 +1,144 @@
+package load_shedding
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+)
+
+const (
+	// Measurement name indicating whether load shedding is active.
+	MEASUREMENT_LOAD_SHEDDING_ACTIVE = "task_scheduler_load_shedding_active"
+
+	// Measurement name for the number of task candidates which were not
+	// scheduled due to load shedding.
+	MEASUREMENT_LOAD_SHEDDING_SUSPENDED = "task_scheduler_load_shedding_suspended_candidates"
+
+	// statusRefreshInterval is how often the scheduler writes its status to
+	// the DB when nothing has changed, so that the frontend can display an
+	// up-to-date backlog.
+	statusRefreshInterval = 5 * time.Minute
+)
+
+// Shedder is used by the scheduler to decide whether load shedding is active
+// and which Jobs are suspended.
+type Shedder struct {
+	cfg Config
+	db  *DB
+
+	active       bool
+	activeReason string
+	backlog      int
+	lastReported *State
+	mtx          sync.Mutex
+
+	activeMetric    metrics2.Int64Metric
+	suspendedMetric metrics2.Int64Metric
+}
+
+// NewShedder returns a Shedder instance.
+func NewShedder(db *DB, cfg Config) (*Shedder, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	// Pick up where a previous instance of the scheduler left off, so that
+	// automatically-activated load shedding doesn't deactivate on restart.
+	st := db.Get()
+	return &Shedder{
+		cfg:             cfg,
+		db:              db,
+		active:          st.Active,
+		activeMetric:    metrics2.GetInt64Metric(MEASUREMENT_LOAD_SHEDDING_ACTIVE),
+		suspendedMetric: metrics2.GetInt64Metric(MEASUREMENT_LOAD_SHEDDING_SUSPENDED),
+	}, nil
+}
+
+// Update determines whether load shedding is active, given the number of task
+// candidates which are eligible to run and how many of those are not
+// suspendable, and returns the result. Safe to call on a nil Shedder, in which
+// case load shedding is never active.
+func (s *Shedder) Update(backlog, unsuspendedBacklog int) bool {
+	if s == nil {
+		return false
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.active, s.activeReason = s.cfg.decide(s.db.Get(), s.active, backlog, unsuspendedBacklog)
+	s.backlog = backlog
+	if s.active {
+		s.activeMetric.Update(1)
+	} else {
+		s.activeMetric.Update(0)
+	}
+	return s.active
+}
+
+// Suspended returns true if load shedding is active and the given JobClass is
+// suspended.
+func (s *Shedder) Suspended(class JobClass) bool {
+	if s == nil {
+		return false
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.active && s.suspendable(class)
+}
+
+// Suspendable returns true if the given JobClass is suspended while load
+// shedding is active, whether or not it currently is.
+func (s *Shedder) Suspendable(class JobClass) bool {
+	if s == nil {
+		return false
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.suspendable(class)
+}
+
+// suspendable implements Suspendable. The caller must hold s.mtx.
+func (s *Shedder) suspendable(class JobClass) bool {
+	if class == JobClassTryjob {
+		return false
+	}
+	for _, c := range s.cfg.SuspendedClasses {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// Report records the number of task candidates which were not scheduled due to
+// load shedding, and writes the status to the DB if it has changed or has not
+// been written recently.
+func (s *Shedder) Report(ctx context.Context, suspended int, currentTime time.Time) error {
+	if s == nil {
+		return nil
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.suspendedMetric.Update(int64(suspended))
+	update := &State{
+		Active:              s.active,
+		ActiveReason:        s.activeReason,
+		Backlog:             s.backlog,
+		SuspendedClasses:    s.cfg.SuspendedClasses,
+		SuspendedCandidates: suspended,
+		Updated:             currentTime.UTC(),
+	}
+	if prev := s.lastReported; prev != nil &&
+		prev.Active == update.Active &&
+		prev.ActiveReason == update.ActiveReason &&
+		prev.SuspendedCandidates == update.SuspendedCandidates &&
+		currentTime.Sub(prev.Updated) < statusRefreshInterval {
+		return nil
+	}
+	if err := s.db.setStatus(ctx, update); err != nil {
+		return err
+	}
+	s.lastReported = update
+	return nil
+}
@@ -0,0 +1,43 @@
+package load_shedding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShedder_Nil(t *testing.T) {
+	var s *Shedder
+	require.False(t, s.Update(1000, 1000))
+	require.False(t, s.Suspended(JobClassCommit))
+}
+
+func TestShedder_UpdateAndSuspended(t *testing.T) {
+	s, err := NewShedder(nil, Config{
+		SuspendedClasses:  []JobClass{JobClassPeriodic},
+		ActivateBacklog:   100,
+		DeactivateBacklog: 50,
+	})
+	require.NoError(t, err)
+
+	require.False(t, s.Update(10, 10))
+	require.False(t, s.Suspended(JobClassPeriodic))
+	require.True(t, s.Suspendable(JobClassPeriodic))
+	require.False(t, s.Suspendable(JobClassCommit))
+
+	require.True(t, s.Update(100, 100))
+	require.True(t, s.Suspended(JobClassPeriodic))
+	require.False(t, s.Suspended(JobClassCommit))
+	require.False(t, s.Suspended(JobClassTryjob))
+
+	// Remain active until the backlog which is not suspended drops to the
+	// deactivation threshold.
+	require.True(t, s.Update(200, 51))
+	require.False(t, s.Update(200, 50))
+	require.False(t, s.Suspended(JobClassPeriodic))
+}
+
+func TestNewShedder_InvalidConfig(t *testing.T) {
+	_, err := NewShedder(nil, Config{SuspendedClasses: []JobClass{JobClassTryjob}})
+	require.ErrorContains(t, err, "tryjobs may not be suspended")
+}
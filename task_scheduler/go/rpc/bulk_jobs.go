@@ -0,0 +1,330 @@
+package rpc
+
+import (
+	context "context"
+	"encoding/json"
+	"errors"
+	fmt "fmt"
+	http "net/http"
+	"regexp"
+	"sort"
+	"time"
+
+	"go.skia.org/infra/go/git/repograph"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/db"
+	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// maxBulkJobsRevisions is the maximum number of revisions which may be
+	// included in the revision range of a BulkJobsRequest.
+	maxBulkJobsRevisions = 100
+
+	// maxBulkJobsRequestBytes is the maximum size of a BulkJobsRequest.
+	maxBulkJobsRequestBytes = 1024 * 1024
+)
+
+// BulkJobsRequest selects the Jobs acted on by the bulk job cancellation and
+// re-trigger endpoints.
+type BulkJobsRequest struct {
+	// Repo is the URL of the repo containing the Jobs. Required.
+	Repo string `json:"repo"`
+	// NamePattern is an optional regular expression which must match the
+	// names of the Jobs.
+	NamePattern string `json:"name_pattern,omitempty"`
+	// RevisionStart is the first revision of an optional range of revisions,
+	// inclusive. If RevisionEnd is not set, only Jobs at RevisionStart match.
+	RevisionStart string `json:"revision_start,omitempty"`
+	// RevisionEnd is the last revision of the range of revisions, inclusive.
+	// RevisionStart must be an ancestor of RevisionEnd.
+	RevisionEnd string `json:"revision_end,omitempty"`
+	// Statuses optionally restricts the statuses of the matching Jobs. Only
+	// unfinished Jobs may be canceled. If not set, all unfinished Jobs are
+	// canceled, and failed, mishapped, or canceled Jobs are re-triggered.
+	Statuses []types.JobStatus `json:"statuses,omitempty"`
+	// TimeStart and TimeEnd optionally limit the creation times of the
+	// matching Jobs. The default is the last 24 hours.
+	TimeStart *time.Time `json:"time_start,omitempty"`
+	TimeEnd   *time.Time `json:"time_end,omitempty"`
+	// DryRun indicates that the matching Jobs should be returned without
+	// modifying them.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// BulkJobsResponse is the response from the bulk job cancellation and
+// re-trigger endpoints.
+type BulkJobsResponse struct {
+	// DryRun is true if no Jobs were modified.
+	DryRun bool `json:"dry_run,omitempty"`
+	// MatchedJobIds are the IDs of the Jobs which were, or would have been,
+	// canceled or re-triggered.
+	MatchedJobIds []string `json:"matched_job_ids"`
+	// NewJobIds are the IDs of the Jobs created by a re-trigger.
+	NewJobIds []string `json:"new_job_ids,omitempty"`
+	// Truncated is true if more Jobs matched the request than could be
+	// retrieved in a single search. The request may be repeated to act on the
+	// remaining Jobs.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+var (
+	// unfinishedJobStatuses are the statuses of Jobs which may be canceled.
+	unfinishedJobStatuses = []types.JobStatus{types.JOB_STATUS_REQUESTED, types.JOB_STATUS_IN_PROGRESS}
+
+	// defaultRetriggerJobStatuses are the statuses of Jobs which are
+	// re-triggered if BulkJobsRequest.Statuses is not set.
+	defaultRetriggerJobStatuses = []types.JobStatus{types.JOB_STATUS_FAILURE, types.JOB_STATUS_MISHAP, types.JOB_STATUS_CANCELED}
+
+	errBulkJobsInvalidRequest = errors.New("invalid request")
+)
+
+// CancelJobsHandler returns an http.HandlerFunc which cancels all unfinished
+// Jobs matching a BulkJobsRequest. The caller must be an editor.
+func CancelJobsHandler(ctx context.Context, db db.DB, repos repograph.Map, readOnly bool) http.HandlerFunc {
+	s := newTaskSchedulerServiceImpl(ctx, db, repos, nil, nil, nil, readOnly)
+	return s.bulkJobsHandler(s.cancelJobs)
+}
+
+// RetriggerJobsHandler returns an http.HandlerFunc which triggers a new Job for
+// each distinct Job name and RepoState matching a BulkJobsRequest. The caller
+// must be an editor.
+func RetriggerJobsHandler(ctx context.Context, db db.DB, repos repograph.Map, taskCfgCache task_cfg_cache.TaskCfgCache, readOnly bool) http.HandlerFunc {
+	s := newTaskSchedulerServiceImpl(ctx, db, repos, nil, taskCfgCache, nil, readOnly)
+	return s.bulkJobsHandler(s.retriggerJobs)
+}
+
+// bulkJobsHandler returns an http.HandlerFunc which decodes a BulkJobsRequest,
+// runs the given function, and encodes its result.
+func (s *taskSchedulerServiceImpl) bulkJobsHandler(fn func(context.Context, string, *BulkJobsRequest) (*BulkJobsResponse, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		email, err := s.getWriter(ctx)
+		if err != nil {
+			httputils.ReportError(w, err, err.Error(), http.StatusForbidden)
+			return
+		}
+		var req BulkJobsRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBulkJobsRequestBytes)).Decode(&req); err != nil {
+			httputils.ReportError(w, err, "Failed to decode request.", http.StatusBadRequest)
+			return
+		}
+		res, err := fn(ctx, email, &req)
+		if errors.Is(err, errBulkJobsInvalidRequest) {
+			httputils.ReportError(w, err, err.Error(), http.StatusBadRequest)
+			return
+		} else if err != nil {
+			httputils.ReportError(w, err, "Failed to process jobs.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			sklog.Errorf("Failed to write response: %s", err)
+		}
+	}
+}
+
+// cancelJobs cancels all unfinished Jobs matching the request.
+func (s *taskSchedulerServiceImpl) cancelJobs(ctx context.Context, email string, req *BulkJobsRequest) (*BulkJobsResponse, error) {
+	statuses := unfinishedJobStatuses
+	if len(req.Statuses) > 0 {
+		statuses = nil
+		for _, status := range req.Statuses {
+			if !util.In(string(status), []string{string(types.JOB_STATUS_REQUESTED), string(types.JOB_STATUS_IN_PROGRESS)}) {
+				return nil, invalidBulkJobsRequest("only unfinished jobs may be canceled; got status %q", status)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	jobs, truncated, err := s.findBulkJobs(ctx, req, statuses)
+	if err != nil {
+		return nil, err
+	}
+	rv := &BulkJobsResponse{
+		DryRun:        req.DryRun,
+		MatchedJobIds: jobIds(jobs),
+		Truncated:     truncated,
+	}
+	if req.DryRun || len(jobs) == 0 {
+		return rv, nil
+	}
+	finished := now.Now(ctx)
+	for _, job := range jobs {
+		job.Finished = finished
+		job.Status = types.JOB_STATUS_CANCELED
+		job.StatusDetails = fmt.Sprintf("Job was canceled in bulk by %s", email)
+	}
+	if err := s.db.PutJobsInChunks(ctx, jobs); err != nil {
+		return nil, skerr.Wrapf(err, "failed to cancel jobs")
+	}
+	sklog.Infof("%s canceled %d jobs matching %+v", email, len(jobs), req)
+	return rv, nil
+}
+
+// retriggerJobs triggers a new Job for each distinct Job name and RepoState
+// matching the request.
+func (s *taskSchedulerServiceImpl) retriggerJobs(ctx context.Context, email string, req *BulkJobsRequest) (*BulkJobsResponse, error) {
+	statuses := defaultRetriggerJobStatuses
+	if len(req.Statuses) > 0 {
+		statuses = req.Statuses
+	}
+	jobs, truncated, err := s.findBulkJobs(ctx, req, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only trigger one new Job for each name and RepoState, even if several
+	// attempts matched.
+	type jobKey struct {
+		types.RepoState
+		name string
+	}
+	seen := make(map[jobKey]bool, len(jobs))
+	toRetrigger := make([]*types.Job, 0, len(jobs))
+	for _, job := range jobs {
+		key := jobKey{RepoState: job.RepoState, name: job.Name}
+		if !seen[key] {
+			seen[key] = true
+			toRetrigger = append(toRetrigger, job)
+		}
+	}
+	rv := &BulkJobsResponse{
+		DryRun:        req.DryRun,
+		MatchedJobIds: jobIds(toRetrigger),
+		Truncated:     truncated,
+	}
+	if req.DryRun || len(toRetrigger) == 0 {
+		return rv, nil
+	}
+	newJobs := make([]*types.Job, 0, len(toRetrigger))
+	for _, job := range toRetrigger {
+		newJob, err := task_cfg_cache.MakeJob(ctx, s.taskCfgCache, job.RepoState, job.Name, job.Parameters)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "failed to create job to re-trigger %s", job.Id)
+		}
+		newJob.Requested = newJob.Created
+		newJob.IsForce = true
+		newJobs = append(newJobs, newJob)
+	}
+	if err := s.db.PutJobsInChunks(ctx, newJobs); err != nil {
+		return nil, skerr.Wrapf(err, "failed to insert jobs")
+	}
+	rv.NewJobIds = jobIds(newJobs)
+	sklog.Infof("%s re-triggered %d jobs matching %+v", email, len(newJobs), req)
+	return rv, nil
+}
+
+// findBulkJobs returns the Jobs with the given statuses which match the
+// request, sorted by creation time, and whether any of the searches reached
+// db.SearchResultLimit.
+func (s *taskSchedulerServiceImpl) findBulkJobs(ctx context.Context, req *BulkJobsRequest, statuses []types.JobStatus) ([]*types.Job, bool, error) {
+	if req.Repo == "" {
+		return nil, false, invalidBulkJobsRequest("repo is required")
+	}
+	if req.NamePattern == "" && req.RevisionStart == "" {
+		return nil, false, invalidBulkJobsRequest("at least one of name_pattern or revision_start is required")
+	}
+	var nameRegex *regexp.Regexp
+	if req.NamePattern != "" {
+		var err error
+		nameRegex, err = regexp.Compile(req.NamePattern)
+		if err != nil {
+			return nil, false, invalidBulkJobsRequest("invalid name_pattern: %s", err)
+		}
+	}
+	revisions, err := s.bulkJobsRevisions(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	truncated := false
+	var rv []*types.Job
+	search := func(revision *string, status types.JobStatus) error {
+		results, err := s.db.SearchJobs(ctx, &db.JobSearchParams{
+			Repo:      stringPtr(req.Repo),
+			Revision:  revision,
+			Status:    &status,
+			TimeStart: req.TimeStart,
+			TimeEnd:   req.TimeEnd,
+		})
+		if err != nil {
+			return skerr.Wrapf(err, "failed to search jobs")
+		}
+		if len(results) >= db.SearchResultLimit {
+			truncated = true
+		}
+		for _, job := range results {
+			if nameRegex == nil || nameRegex.MatchString(job.Name) {
+				rv = append(rv, job)
+			}
+		}
+		return nil
+	}
+	for _, status := range statuses {
+		if len(revisions) == 0 {
+			if err := search(nil, status); err != nil {
+				return nil, false, err
+			}
+		}
+		for _, revision := range revisions {
+			if err := search(stringPtr(revision), status); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	sort.Sort(types.JobSlice(rv))
+	return rv, truncated, nil
+}
+
+// bulkJobsRevisions returns the revisions in the range given by the request, or
+// nil if no range was given.
+func (s *taskSchedulerServiceImpl) bulkJobsRevisions(req *BulkJobsRequest) ([]string, error) {
+	if req.RevisionStart == "" {
+		if req.RevisionEnd != "" {
+			return nil, invalidBulkJobsRequest("revision_end requires revision_start")
+		}
+		return nil, nil
+	}
+	if req.RevisionEnd == "" || req.RevisionEnd == req.RevisionStart {
+		return []string{req.RevisionStart}, nil
+	}
+	repo, ok := s.repos[req.Repo]
+	if !ok {
+		return nil, invalidBulkJobsRequest("unknown repo %q", req.Repo)
+	}
+	isAncestor, err := repo.IsAncestor(req.RevisionStart, req.RevisionEnd)
+	if err != nil {
+		return nil, invalidBulkJobsRequest("invalid revision range: %s", err)
+	} else if !isAncestor {
+		return nil, invalidBulkJobsRequest("revision_start %s is not an ancestor of revision_end %s", req.RevisionStart, req.RevisionEnd)
+	}
+	revisions, err := repo.RevList(req.RevisionStart, req.RevisionEnd)
+	if err != nil {
+		return nil, invalidBulkJobsRequest("invalid revision range: %s", err)
+	}
+	revisions = append(revisions, req.RevisionStart)
+	if len(revisions) > maxBulkJobsRevisions {
+		return nil, invalidBulkJobsRequest("revision range includes %d revisions; the maximum is %d", len(revisions), maxBulkJobsRevisions)
+	}
+	return revisions, nil
+}
+
+// invalidBulkJobsRequest returns an error wrapping errBulkJobsInvalidRequest
+// with the given message.
+func invalidBulkJobsRequest(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", errBulkJobsInvalidRequest, fmt.Sprintf(format, args...))
+}
+
+// jobIds returns the IDs of the given Jobs.
+func jobIds(jobs []*types.Job) []string {
+	rv := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		rv = append(rv, job.Id)
+	}
+	return rv
+}
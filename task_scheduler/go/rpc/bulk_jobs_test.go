@@ -0,0 +1,235 @@
+package rpc
+
+import (
+	"bytes"
+	context "context"
+	"encoding/json"
+	http "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/git/repograph"
+	"go.skia.org/infra/go/git/testutils/mem_git"
+	"go.skia.org/infra/go/gitstore"
+	"go.skia.org/infra/go/gitstore/mem_gitstore"
+	"go.skia.org/infra/task_scheduler/go/db/memory"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	tcc_mocks "go.skia.org/infra/task_scheduler/go/task_cfg_cache/mocks"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+// setupBulkJobs creates a service backed by an in-memory DB and repo with five
+// commits, with Jobs "good" and "bad" at each commit with the given status.
+func setupBulkJobs(t *testing.T, status types.JobStatus) (context.Context, *taskSchedulerServiceImpl, []string) {
+	ctx := alogin.FakeStatus(context.Background(), &editorStatus)
+	d := memory.NewInMemoryDB()
+	gs := mem_gitstore.New()
+	gb := mem_git.New(t, gs)
+	hashes := gb.CommitN(5)
+	ri, err := gitstore.NewGitStoreRepoImpl(ctx, gs)
+	require.NoError(t, err)
+	repo, err := repograph.NewWithRepoImpl(ctx, ri)
+	require.NoError(t, err)
+	repos := repograph.Map{fakeRepo: repo}
+
+	tcc := tcc_mocks.FixedTasksCfg(&specs.TasksCfg{
+		Jobs: map[string]*specs.JobSpec{
+			"good": {TaskSpecs: []string{"task"}},
+			"bad":  {TaskSpecs: []string{"task"}},
+		},
+		Tasks: map[string]*specs.TaskSpec{
+			"task": {Dimensions: []string{"os:linux"}},
+		},
+	})
+
+	// CommitN returns hashes in reverse chronological order.
+	for _, hash := range hashes {
+		for _, name := range []string{"good", "bad"} {
+			require.NoError(t, d.PutJob(ctx, &types.Job{
+				Created: time.Now(),
+				Name:    name,
+				RepoState: types.RepoState{
+					Repo:     fakeRepo,
+					Revision: hash,
+				},
+				Status: status,
+			}))
+		}
+	}
+	return ctx, newTaskSchedulerServiceImpl(ctx, d, repos, nil, tcc, nil, false), hashes
+}
+
+func TestCancelJobs_NamePattern(t *testing.T) {
+	ctx, srv, _ := setupBulkJobs(t, types.JOB_STATUS_IN_PROGRESS)
+
+	// Dry run.
+	res, err := srv.cancelJobs(ctx, editor, &BulkJobsRequest{
+		Repo:        fakeRepo,
+		NamePattern: "^bad$",
+		DryRun:      true,
+	})
+	require.NoError(t, err)
+	require.True(t, res.DryRun)
+	require.Len(t, res.MatchedJobIds, 5)
+	for _, id := range res.MatchedJobIds {
+		job, err := srv.db.GetJobById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "bad", job.Name)
+		require.Equal(t, types.JOB_STATUS_IN_PROGRESS, job.Status)
+	}
+
+	// Actually cancel.
+	res, err = srv.cancelJobs(ctx, editor, &BulkJobsRequest{
+		Repo:        fakeRepo,
+		NamePattern: "^bad$",
+	})
+	require.NoError(t, err)
+	require.False(t, res.DryRun)
+	require.Len(t, res.MatchedJobIds, 5)
+	for _, id := range res.MatchedJobIds {
+		job, err := srv.db.GetJobById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, types.JOB_STATUS_CANCELED, job.Status)
+		require.Equal(t, "Job was canceled in bulk by editor@google.com", job.StatusDetails)
+	}
+
+	// The canceled jobs no longer match.
+	res, err = srv.cancelJobs(ctx, editor, &BulkJobsRequest{
+		Repo:        fakeRepo,
+		NamePattern: "^bad$",
+	})
+	require.NoError(t, err)
+	require.Empty(t, res.MatchedJobIds)
+}
+
+func TestCancelJobs_RevisionRange(t *testing.T) {
+	ctx, srv, hashes := setupBulkJobs(t, types.JOB_STATUS_REQUESTED)
+
+	// Single revision.
+	res, err := srv.cancelJobs(ctx, editor, &BulkJobsRequest{
+		Repo:          fakeRepo,
+		RevisionStart: hashes[4],
+		DryRun:        true,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.MatchedJobIds, 2)
+
+	// Range, combined with the name pattern.
+	res, err = srv.cancelJobs(ctx, editor, &BulkJobsRequest{
+		Repo:          fakeRepo,
+		NamePattern:   "good",
+		RevisionStart: hashes[3],
+		RevisionEnd:   hashes[1],
+	})
+	require.NoError(t, err)
+	require.Len(t, res.MatchedJobIds, 3)
+	for _, id := range res.MatchedJobIds {
+		job, err := srv.db.GetJobById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "good", job.Name)
+		require.Contains(t, hashes[1:4], job.Revision)
+		require.Equal(t, types.JOB_STATUS_CANCELED, job.Status)
+	}
+}
+
+func TestCancelJobs_InvalidRequest(t *testing.T) {
+	ctx, srv, hashes := setupBulkJobs(t, types.JOB_STATUS_REQUESTED)
+
+	test := func(req *BulkJobsRequest, expectErr string) {
+		_, err := srv.cancelJobs(ctx, editor, req)
+		require.ErrorIs(t, err, errBulkJobsInvalidRequest)
+		require.Contains(t, err.Error(), expectErr)
+	}
+	test(&BulkJobsRequest{NamePattern: "bad"}, "repo is required")
+	test(&BulkJobsRequest{Repo: fakeRepo}, "at least one of name_pattern or revision_start is required")
+	test(&BulkJobsRequest{Repo: fakeRepo, NamePattern: "("}, "invalid name_pattern")
+	test(&BulkJobsRequest{Repo: fakeRepo, RevisionStart: hashes[1], RevisionEnd: hashes[3]}, "is not an ancestor of revision_end")
+	test(&BulkJobsRequest{Repo: fakeRepo, NamePattern: "bad", RevisionEnd: hashes[3]}, "revision_end requires revision_start")
+	test(&BulkJobsRequest{Repo: fakeRepo, NamePattern: "bad", Statuses: []types.JobStatus{types.JOB_STATUS_SUCCESS}}, "only unfinished jobs may be canceled")
+}
+
+func TestRetriggerJobs(t *testing.T) {
+	ctx, srv, hashes := setupBulkJobs(t, types.JOB_STATUS_FAILURE)
+
+	// Add a second failed attempt at one revision; only one new job should
+	// be triggered for it.
+	require.NoError(t, srv.db.PutJob(ctx, &types.Job{
+		Created: time.Now(),
+		Name:    "bad",
+		RepoState: types.RepoState{
+			Repo:     fakeRepo,
+			Revision: hashes[0],
+		},
+		Status: types.JOB_STATUS_FAILURE,
+	}))
+
+	res, err := srv.retriggerJobs(ctx, editor, &BulkJobsRequest{
+		Repo:        fakeRepo,
+		NamePattern: "bad",
+		DryRun:      true,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.MatchedJobIds, 5)
+	require.Empty(t, res.NewJobIds)
+
+	res, err = srv.retriggerJobs(ctx, editor, &BulkJobsRequest{
+		Repo:        fakeRepo,
+		NamePattern: "bad",
+	})
+	require.NoError(t, err)
+	require.Len(t, res.MatchedJobIds, 5)
+	require.Len(t, res.NewJobIds, 5)
+	revisions := map[string]bool{}
+	for _, id := range res.NewJobIds {
+		job, err := srv.db.GetJobById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "bad", job.Name)
+		require.True(t, job.IsForce)
+		revisions[job.Revision] = true
+	}
+	require.Len(t, revisions, 5)
+
+	// Successful jobs are not re-triggered by default.
+	_, srv, _ = setupBulkJobs(t, types.JOB_STATUS_SUCCESS)
+	res, err = srv.retriggerJobs(ctx, editor, &BulkJobsRequest{
+		Repo:        fakeRepo,
+		NamePattern: "bad",
+	})
+	require.NoError(t, err)
+	require.Empty(t, res.MatchedJobIds)
+}
+
+func TestBulkJobsHandler(t *testing.T) {
+	ctx, srv, _ := setupBulkJobs(t, types.JOB_STATUS_IN_PROGRESS)
+	handler := srv.bulkJobsHandler(srv.cancelJobs)
+
+	do := func(ctx context.Context, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/json/jobs/cancel", bytes.NewReader([]byte(body))).WithContext(ctx)
+		handler(w, r)
+		return w
+	}
+
+	w := do(alogin.FakeStatus(ctx, &viewerStatus), `{"repo": "fake.git", "name_pattern": "bad"}`)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = do(ctx, `{"repo": "fake.git"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(ctx, `not json`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(ctx, `{"repo": "fake.git", "name_pattern": "bad", "dry_run": true}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var res BulkJobsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	require.True(t, res.DryRun)
+	require.Len(t, res.MatchedJobIds, 5)
+
+	srv.readOnly = true
+	w = do(ctx, `{"repo": "fake.git", "name_pattern": "bad"}`)
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
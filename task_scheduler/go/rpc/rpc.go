@@ -2,6 +2,7 @@ package rpc
 
 import (
 	context "context"
+	"errors"
 	fmt "fmt"
 	http "net/http"
 	"sort"
@@ -18,6 +19,7 @@ import (
 	"go.skia.org/infra/go/twirp_auth2"
 	"go.skia.org/infra/task_scheduler/go/db"
 	"go.skia.org/infra/task_scheduler/go/skip_tasks"
+	"go.skia.org/infra/task_scheduler/go/specs"
 	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
 	"go.skia.org/infra/task_scheduler/go/types"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -31,9 +33,10 @@ import (
 //go:generate bazelisk run --config=mayberemote //:goimports "--run_under=cd $PWD &&" -- -w rpc.twirp.go
 //go:generate bazelisk run --config=mayberemote //:protoc -- --twirp_typescript_out=../../modules/rpc ./rpc.proto
 
-// NewTaskSchedulerServer creates and returns a Twirp HTTP server.
-func NewTaskSchedulerServer(ctx context.Context, db db.DB, repos repograph.Map, skipTasks *skip_tasks.DB, taskCfgCache task_cfg_cache.TaskCfgCache, swarm swarmingv2.SwarmingV2Client, plogin alogin.Login) http.Handler {
-	impl := newTaskSchedulerServiceImpl(ctx, db, repos, skipTasks, taskCfgCache, swarm)
+// NewTaskSchedulerServer creates and returns a Twirp HTTP server. If readOnly
+// is true, all requests which would modify the DB are rejected.
+func NewTaskSchedulerServer(ctx context.Context, db db.DB, repos repograph.Map, skipTasks *skip_tasks.DB, taskCfgCache task_cfg_cache.TaskCfgCache, swarm swarmingv2.SwarmingV2Client, plogin alogin.Login, readOnly bool) http.Handler {
+	impl := newTaskSchedulerServiceImpl(ctx, db, repos, skipTasks, taskCfgCache, swarm, readOnly)
 	srv := NewTaskSchedulerServiceServer(impl, nil)
 	return alogin.StatusMiddleware(plogin)(srv)
 }
@@ -46,10 +49,11 @@ type taskSchedulerServiceImpl struct {
 	skipTasks    *skip_tasks.DB
 	taskCfgCache task_cfg_cache.TaskCfgCache
 	swarming     swarmingv2.SwarmingV2Client
+	readOnly     bool
 }
 
 // newTaskSchedulerServiceImpl returns a taskSchedulerServiceImpl instance.
-func newTaskSchedulerServiceImpl(ctx context.Context, db db.DB, repos repograph.Map, skipTasks *skip_tasks.DB, taskCfgCache task_cfg_cache.TaskCfgCache, swarm swarmingv2.SwarmingV2Client) *taskSchedulerServiceImpl {
+func newTaskSchedulerServiceImpl(ctx context.Context, db db.DB, repos repograph.Map, skipTasks *skip_tasks.DB, taskCfgCache task_cfg_cache.TaskCfgCache, swarm swarmingv2.SwarmingV2Client, readOnly bool) *taskSchedulerServiceImpl {
 	return &taskSchedulerServiceImpl{
 		AuthHelper:   twirp_auth2.New(),
 		db:           db,
@@ -57,12 +61,26 @@ func newTaskSchedulerServiceImpl(ctx context.Context, db db.DB, repos repograph.
 		skipTasks:    skipTasks,
 		taskCfgCache: taskCfgCache,
 		swarming:     swarm,
+		readOnly:     readOnly,
 	}
 }
 
+// getWriter returns the email address of the logged-in user if they are an
+// editor and the server is not in read-only mode, or an error otherwise.
+func (s *taskSchedulerServiceImpl) getWriter(ctx context.Context) (string, error) {
+	email, err := s.GetEditor(ctx)
+	if err != nil {
+		return "", err
+	}
+	if s.readOnly {
+		return "", twirp.NewError(twirp.FailedPrecondition, "Task Scheduler is running in read-only mode.")
+	}
+	return email, nil
+}
+
 // TriggerJobs triggers the given jobs.
 func (s *taskSchedulerServiceImpl) TriggerJobs(ctx context.Context, req *TriggerJobsRequest) (*TriggerJobsResponse, error) {
-	if _, err := s.GetEditor(ctx); err != nil {
+	if _, err := s.getWriter(ctx); err != nil {
 		return nil, err
 	}
 	jobs := make([]*types.Job, 0, len(req.Jobs))
@@ -75,8 +93,10 @@ func (s *taskSchedulerServiceImpl) TriggerJobs(ctx context.Context, req *Trigger
 		job, err := task_cfg_cache.MakeJob(ctx, s.taskCfgCache, types.RepoState{
 			Repo:     repoName,
 			Revision: j.CommitHash,
-		}, j.JobName)
-		if err != nil {
+		}, j.JobName, j.Parameters)
+		if errors.Is(err, specs.ErrInvalidJobParameters) {
+			return nil, twirp.NewError(twirp.InvalidArgument, err.Error())
+		} else if err != nil {
 			sklog.Error(err)
 			return nil, twirp.InternalError("Failed to create job.")
 		}
@@ -159,7 +179,7 @@ func (s *taskSchedulerServiceImpl) GetJob(ctx context.Context, req *GetJobReques
 
 // CancelJob cancels the given job.
 func (s *taskSchedulerServiceImpl) CancelJob(ctx context.Context, req *CancelJobRequest) (*CancelJobResponse, error) {
-	email, err := s.GetEditor(ctx)
+	email, err := s.getWriter(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -358,13 +378,19 @@ func (s *taskSchedulerServiceImpl) getSkipTaskRules() []*SkipTaskRule {
 	rules := s.skipTasks.GetRules()
 	rv := make([]*SkipTaskRule, 0, len(rules))
 	for _, rule := range rules {
-		rv = append(rv, &SkipTaskRule{
+		r := &SkipTaskRule{
 			AddedBy:          rule.AddedBy,
 			TaskSpecPatterns: rule.TaskSpecPatterns,
 			Commits:          rule.Commits,
 			Description:      rule.Description,
 			Name:             rule.Name,
-		})
+			Export:           rule.Export,
+			ImportedFrom:     rule.ImportedFrom,
+		}
+		if !rule.Expires.IsZero() {
+			r.Expires = timestamppb.New(rule.Expires)
+		}
+		rv = append(rv, r)
 	}
 	return rv
 }
@@ -378,7 +404,7 @@ func (s *taskSchedulerServiceImpl) GetSkipTaskRules(ctx context.Context, req *Ge
 
 // AddSkipTaskRule adds a rule for skipping tasks.
 func (s *taskSchedulerServiceImpl) AddSkipTaskRule(ctx context.Context, req *AddSkipTaskRuleRequest) (*AddSkipTaskRuleResponse, error) {
-	user, err := s.GetEditor(ctx)
+	user, err := s.getWriter(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -388,13 +414,18 @@ func (s *taskSchedulerServiceImpl) AddSkipTaskRule(ctx context.Context, req *Add
 		Commits:          req.Commits,
 		Description:      req.Description,
 		Name:             req.Name,
+		Export:           req.Export,
+	}
+	if req.Expires != nil {
+		rule.Expires = req.Expires.AsTime()
 	}
 	if len(rule.Commits) == 2 {
-		rangeRule, err := skip_tasks.NewCommitRangeRule(context.Background(), rule.Name, rule.AddedBy, rule.Description, rule.TaskSpecPatterns, rule.Commits[0], rule.Commits[1], s.repos)
+		rangeRule, err := skip_tasks.NewCommitRangeRule(ctx, rule.Name, rule.AddedBy, rule.Description, rule.TaskSpecPatterns, rule.Commits[0], rule.Commits[1], rule.Expires, s.repos)
 		if err != nil {
 			sklog.Error(err)
 			return nil, twirp.InvalidArgumentError("commits", "Failed to create commit range rule")
 		}
+		rangeRule.Export = rule.Export
 		rule = rangeRule
 	}
 	if err := s.skipTasks.AddRule(ctx, rule, s.repos); err != nil {
@@ -408,7 +439,7 @@ func (s *taskSchedulerServiceImpl) AddSkipTaskRule(ctx context.Context, req *Add
 
 // DeleteSkipTaskRule deletes the given rule for skipping tasks.
 func (s *taskSchedulerServiceImpl) DeleteSkipTaskRule(ctx context.Context, req *DeleteSkipTaskRuleRequest) (*DeleteSkipTaskRuleResponse, error) {
-	if _, err := s.GetEditor(ctx); err != nil {
+	if _, err := s.getWriter(ctx); err != nil {
 		return nil, err
 	}
 	if err := s.skipTasks.RemoveRule(ctx, req.Id); err != nil {
@@ -584,6 +615,7 @@ func convertJob(job *types.Job) (*Job, error) {
 		Id:                  job.Id,
 		IsForce:             job.IsForce,
 		Name:                job.Name,
+		Parameters:          job.Parameters,
 		Priority:            float32(job.Priority),
 		RepoState:           convertRepoState(job.RepoState),
 		RequestedAt:         timestamppb.New(job.Requested),
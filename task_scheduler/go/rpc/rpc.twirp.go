@@ -11,38 +11,24 @@ It is generated from these files:
 */
 package rpc
 
-import (
-	bytes "bytes"
-	strings "strings"
-
-	context "context"
-
-	fmt "fmt"
-
-	ioutil "io/ioutil"
-
-	http "net/http"
-
-	strconv "strconv"
-
-	jsonpb "github.com/golang/protobuf/jsonpb"
-
-	proto "github.com/golang/protobuf/proto"
-
-	twirp "github.com/twitchtv/twirp"
-
-	ctxsetters "github.com/twitchtv/twirp/ctxsetters"
-
-	// Imports only used by utility functions:
-
-	io "io"
-
-	json "encoding/json"
-
-	path "path"
-
-	url "net/url"
-)
+import bytes "bytes"
+import strings "strings"
+import context "context"
+import fmt "fmt"
+import ioutil "io/ioutil"
+import http "net/http"
+import strconv "strconv"
+
+import jsonpb "github.com/golang/protobuf/jsonpb"
+import proto "github.com/golang/protobuf/proto"
+import twirp "github.com/twitchtv/twirp"
+import ctxsetters "github.com/twitchtv/twirp/ctxsetters"
+
+// Imports only used by utility functions:
+import io "io"
+import json "encoding/json"
+import path "path"
+import url "net/url"
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the twirp package used in your project.
@@ -3263,136 +3249,142 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 2084 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcd, 0x72, 0x1b, 0xc7,
-	0x11, 0x36, 0xfe, 0x81, 0x06, 0xf1, 0xc3, 0xa1, 0x24, 0x42, 0x70, 0x49, 0xa2, 0x57, 0xb6, 0x44,
-	0x4b, 0x0a, 0x98, 0xa2, 0x4b, 0x72, 0x14, 0xc7, 0x49, 0x40, 0x12, 0x16, 0x49, 0x49, 0x24, 0xbd,
-	0x00, 0xab, 0x52, 0x4e, 0x95, 0xb7, 0x16, 0xd8, 0x21, 0xb1, 0x24, 0x80, 0xdd, 0xec, 0x0c, 0x68,
-	0xe3, 0x94, 0xaa, 0x9c, 0x72, 0xcd, 0x25, 0xf7, 0x9c, 0xf2, 0x12, 0x79, 0x92, 0x1c, 0x73, 0x48,
-	0xe5, 0x96, 0x57, 0x70, 0xcd, 0xdf, 0x72, 0x00, 0xec, 0x02, 0xa2, 0x74, 0xf0, 0x6d, 0xa7, 0xfb,
-	0xeb, 0x9e, 0x9e, 0x9e, 0xfe, 0x7a, 0x66, 0x07, 0x0a, 0x81, 0xdf, 0x6b, 0xf8, 0x81, 0x47, 0x3d,
-	0x84, 0xa8, 0x4d, 0x2e, 0x2d, 0xd2, 0xeb, 0x63, 0x67, 0x3c, 0xc0, 0x41, 0x23, 0xf0, 0x7b, 0xf5,
-	0x07, 0xe7, 0x9e, 0x77, 0x3e, 0xc0, 0x5b, 0x1c, 0xd1, 0x1d, 0x9f, 0x6d, 0x51, 0x77, 0x88, 0x09,
-	0xb5, 0x87, 0xbe, 0x30, 0x32, 0xf6, 0x01, 0x3a, 0x81, 0x7b, 0x7e, 0x8e, 0x83, 0x43, 0xaf, 0x8b,
-	0xee, 0x42, 0xfe, 0xc2, 0xeb, 0x5a, 0x23, 0x7b, 0x88, 0x6b, 0x89, 0x8d, 0xc4, 0x66, 0xc1, 0xcc,
-	0x5d, 0x78, 0xdd, 0x23, 0x7b, 0x88, 0xd1, 0x03, 0x28, 0xf6, 0xbc, 0xe1, 0xd0, 0xa5, 0x56, 0xdf,
-	0x26, 0xfd, 0x5a, 0x92, 0x6b, 0x41, 0x88, 0xf6, 0x6d, 0xd2, 0x37, 0xf6, 0x01, 0x5d, 0x7b, 0x22,
-	0x26, 0xfe, 0xd3, 0x18, 0x13, 0x8a, 0xb6, 0x21, 0x7d, 0xe1, 0x75, 0x49, 0x2d, 0xb1, 0x91, 0xda,
-	0x2c, 0x6e, 0xdf, 0x6f, 0xcc, 0xc7, 0xd8, 0xb8, 0xb6, 0x32, 0x39, 0xd6, 0x68, 0xc0, 0xda, 0x94,
-	0x27, 0xe2, 0x7b, 0x23, 0x82, 0xd1, 0x3a, 0xb0, 0x60, 0x2c, 0xd7, 0x11, 0xde, 0x0a, 0x66, 0xf6,
-	0xc2, 0xeb, 0x1e, 0x38, 0xc4, 0x78, 0x00, 0xa5, 0x57, 0x98, 0x32, 0x7b, 0x39, 0x69, 0x19, 0x92,
-	0xae, 0x23, 0x17, 0x90, 0x74, 0x1d, 0xe3, 0x2b, 0x28, 0x2b, 0x80, 0xf4, 0xf5, 0x39, 0xa4, 0x2e,
-	0xbc, 0x2e, 0x87, 0x14, 0xb7, 0xd7, 0xa3, 0xa2, 0x62, 0x68, 0x86, 0x31, 0x0c, 0xa8, 0xee, 0xda,
-	0xa3, 0x1e, 0x1e, 0x2c, 0x98, 0xe0, 0xb7, 0xb0, 0xaa, 0x61, 0x6e, 0x3e, 0xc7, 0xbf, 0x33, 0xb0,
-	0xda, 0xc6, 0x76, 0xd0, 0xeb, 0xeb, 0xb9, 0xfb, 0x25, 0xdc, 0xea, 0x8e, 0xdd, 0x81, 0xd3, 0x1d,
-	0xf7, 0x2e, 0x31, 0xb5, 0xf8, 0xb7, 0x15, 0xce, 0x8b, 0x34, 0xdd, 0x0e, 0xfb, 0x3c, 0x70, 0xd0,
-	0x97, 0x50, 0xeb, 0xdb, 0xc4, 0x8a, 0xb4, 0x62, 0x3b, 0x96, 0x37, 0x6f, 0xf7, 0x6d, 0xb2, 0x33,
-	0x6f, 0x78, 0x17, 0xf2, 0x2e, 0xb1, 0xce, 0xbc, 0xa0, 0x87, 0x6b, 0x29, 0x0e, 0xcc, 0xb9, 0xe4,
-	0x1b, 0x36, 0x44, 0x1b, 0xb0, 0xc2, 0x7c, 0x86, 0xea, 0x34, 0x57, 0x43, 0xdf, 0x26, 0x07, 0x12,
-	0x71, 0x0b, 0x32, 0x2e, 0x21, 0x63, 0x5c, 0xcb, 0xf0, 0xc0, 0xc4, 0x00, 0x7d, 0x0c, 0x05, 0x61,
-	0xc7, 0x34, 0x59, 0x6e, 0x94, 0xe7, 0x46, 0x4c, 0x89, 0x20, 0xcd, 0x8b, 0x2c, 0xc7, 0x2d, 0xf8,
-	0x37, 0x8b, 0x81, 0x19, 0x70, 0x79, 0x5e, 0xc4, 0xd0, 0xb7, 0x09, 0x2f, 0xbe, 0x3a, 0xe4, 0x7d,
-	0x9b, 0xf6, 0xfa, 0x04, 0xd3, 0x5a, 0x81, 0x9b, 0x84, 0x63, 0xf4, 0x89, 0x88, 0x2f, 0xd4, 0x03,
-	0x37, 0x2d, 0xf6, 0x6d, 0x72, 0xa2, 0x20, 0x08, 0xd2, 0x01, 0xf6, 0xbd, 0x5a, 0x51, 0xcc, 0xc6,
-	0xbe, 0xd5, 0x6c, 0x5c, 0xbe, 0x12, 0xce, 0x66, 0x32, 0x55, 0x1d, 0xf2, 0x01, 0xbe, 0x72, 0x89,
-	0xeb, 0x8d, 0x6a, 0x25, 0x31, 0x9b, 0x1a, 0xab, 0xd9, 0x42, 0x7d, 0x39, 0x9c, 0xcd, 0x54, 0x90,
-	0xe7, 0x90, 0x25, 0xd4, 0xa6, 0x63, 0x52, 0xab, 0x6c, 0x24, 0x36, 0xcb, 0xdb, 0xf7, 0x62, 0xb6,
-	0xbe, 0xcd, 0x41, 0xa6, 0x04, 0xa3, 0x7b, 0xc0, 0x72, 0x6a, 0x49, 0xd3, 0x2a, 0xf7, 0xcb, 0x32,
-	0x28, 0x60, 0xe8, 0x25, 0x00, 0xe3, 0x2e, 0xd3, 0x07, 0xb4, 0xb6, 0xca, 0x8b, 0xaa, 0xde, 0x10,
-	0xf4, 0x6e, 0x28, 0x7a, 0x37, 0x3a, 0x8a, 0xde, 0x66, 0x81, 0xa1, 0xdb, 0x0c, 0x8c, 0x3e, 0x85,
-	0x32, 0xf3, 0xac, 0x99, 0x23, 0xee, 0x9d, 0xad, 0xa4, 0x13, 0xa2, 0x9e, 0x43, 0x9e, 0x23, 0xf0,
-	0xc8, 0xa9, 0xad, 0x2d, 0x75, 0x9f, 0x63, 0xd8, 0xd6, 0xc8, 0x51, 0xe5, 0x11, 0x9a, 0xde, 0x0a,
-	0xcb, 0xa3, 0x23, 0x10, 0x46, 0x13, 0x90, 0x5e, 0xdb, 0x92, 0x1d, 0x4f, 0xa7, 0x1a, 0x43, 0x2c,
-	0x3d, 0x44, 0x47, 0x68, 0x71, 0x02, 0x77, 0x6c, 0x72, 0x19, 0xc3, 0x40, 0xf4, 0x10, 0x4a, 0xee,
-	0xa8, 0x37, 0x18, 0x3b, 0x7c, 0x89, 0x94, 0xc8, 0x72, 0x5f, 0x91, 0x42, 0x96, 0x44, 0x62, 0xfc,
-	0x0e, 0x2a, 0xa1, 0x1b, 0x19, 0xc6, 0x33, 0x48, 0xb3, 0x99, 0x25, 0x4b, 0x6b, 0x91, 0xfd, 0x89,
-	0xe1, 0x39, 0xca, 0xf8, 0x7f, 0x5a, 0xad, 0x85, 0x09, 0x43, 0xa2, 0xd6, 0x20, 0x67, 0x53, 0x8a,
-	0x87, 0x3e, 0xe5, 0x7e, 0x32, 0xa6, 0x1a, 0xb2, 0xae, 0xc9, 0xb2, 0xa3, 0xb4, 0xc9, 0x30, 0x39,
-	0x4d, 0x09, 0x08, 0xb9, 0x93, 0x8a, 0xe5, 0x4e, 0x3a, 0x86, 0x3b, 0x99, 0x18, 0xee, 0x64, 0xe3,
-	0xb9, 0x93, 0x5b, 0xc2, 0x9d, 0x7c, 0x3c, 0x77, 0x0a, 0x31, 0xdc, 0x81, 0x78, 0xee, 0x14, 0x97,
-	0x70, 0x67, 0x65, 0x9e, 0x3b, 0x2f, 0x42, 0xee, 0x94, 0x38, 0x77, 0xee, 0xc7, 0x6d, 0xc8, 0x42,
-	0xf2, 0x94, 0x17, 0x93, 0xa7, 0xf2, 0x61, 0xe4, 0xa9, 0x2e, 0x21, 0xcf, 0xea, 0xfb, 0x93, 0x07,
-	0xcd, 0x91, 0xa7, 0x05, 0x6b, 0x53, 0x05, 0x27, 0xcb, 0xb6, 0x01, 0x19, 0x96, 0x18, 0x45, 0x9f,
-	0xf8, 0xba, 0x15, 0x30, 0xe3, 0x2e, 0xac, 0xbf, 0xc2, 0xb4, 0x7d, 0xe9, 0xfa, 0x5c, 0x3a, 0x1e,
-	0x60, 0x55, 0xbc, 0xc6, 0x3f, 0x13, 0xb0, 0xa2, 0x2b, 0xd8, 0xee, 0xda, 0x8e, 0x83, 0x1d, 0xab,
-	0x3b, 0x51, 0x97, 0x00, 0x3e, 0xde, 0x99, 0xa0, 0x67, 0x20, 0x2f, 0x19, 0x3e, 0xee, 0xb1, 0xaa,
-	0xa1, 0x38, 0x18, 0x31, 0xaa, 0xb1, 0xd3, 0xb8, 0xca, 0x34, 0x6d, 0x1f, 0xf7, 0x4e, 0xa4, 0x9c,
-	0xd1, 0x42, 0xdc, 0x0f, 0x48, 0x2d, 0xc5, 0x21, 0x6a, 0x88, 0x36, 0xa0, 0xe8, 0x60, 0xd2, 0x0b,
-	0x5c, 0x9f, 0xb2, 0x42, 0x48, 0xf3, 0x59, 0x74, 0x51, 0x54, 0x91, 0x1b, 0x26, 0xd4, 0xe6, 0x17,
-	0x21, 0x13, 0xf2, 0x02, 0x32, 0x01, 0x13, 0xc8, 0x84, 0x6c, 0x44, 0x25, 0x44, 0xb7, 0x34, 0x05,
-	0xdc, 0xf8, 0x7b, 0x02, 0xee, 0x34, 0x1d, 0x67, 0x4a, 0x25, 0x59, 0xfd, 0xf3, 0x2e, 0xf6, 0x5b,
-	0x58, 0x9f, 0x8b, 0xeb, 0x03, 0xd7, 0xfa, 0x14, 0xee, 0xee, 0xe1, 0x01, 0xa6, 0x38, 0x6a, 0xb5,
-	0xb3, 0x57, 0x9a, 0x0e, 0xd4, 0xa3, 0xc0, 0x1f, 0x18, 0xc2, 0xff, 0x12, 0x50, 0x60, 0x7d, 0x82,
-	0xf1, 0x12, 0xa3, 0x97, 0x90, 0xe1, 0xad, 0x47, 0x76, 0xdf, 0x87, 0x51, 0x5e, 0x42, 0x74, 0x83,
-	0xb7, 0x24, 0x53, 0x58, 0x84, 0x6d, 0x29, 0xa9, 0xb5, 0x25, 0xbd, 0xf7, 0xa4, 0xa6, 0x7b, 0x4f,
-	0xdd, 0x87, 0x0c, 0xb7, 0xbf, 0x6e, 0xb8, 0x09, 0xbd, 0xe1, 0xde, 0x03, 0xe0, 0x7e, 0x2d, 0xcd,
-	0x69, 0x81, 0x4b, 0x54, 0x57, 0x0b, 0x7b, 0x64, 0x6a, 0xa6, 0x87, 0xde, 0x81, 0x2c, 0xc1, 0xc1,
-	0x15, 0x0e, 0xe4, 0xce, 0xca, 0x91, 0xf1, 0x67, 0xc8, 0xb1, 0xd5, 0xbf, 0xc6, 0x13, 0xf4, 0x1b,
-	0x00, 0xe6, 0x97, 0xb7, 0x27, 0x2c, 0x17, 0x7b, 0x6f, 0xe1, 0x62, 0xcd, 0x42, 0x10, 0x66, 0x49,
-	0x55, 0x47, 0x52, 0xeb, 0xf7, 0x06, 0x94, 0xf8, 0x6d, 0xcc, 0xb1, 0xc4, 0x95, 0x58, 0x46, 0x55,
-	0x14, 0xc2, 0x43, 0x76, 0x2f, 0x36, 0xfe, 0x93, 0x85, 0x34, 0x8b, 0x60, 0xc1, 0xf1, 0xa4, 0x15,
-	0x6d, 0x72, 0xba, 0x68, 0x5f, 0x02, 0xf4, 0x02, 0x6c, 0x53, 0xec, 0x58, 0xb6, 0x58, 0xf3, 0x92,
-	0x8e, 0x29, 0xd1, 0x4d, 0x8a, 0x7e, 0x0f, 0x65, 0xa7, 0x6b, 0x0d, 0x3d, 0xc7, 0x3d, 0x73, 0x85,
-	0x79, 0x7a, 0xa9, 0xf9, 0x8a, 0xd3, 0x7d, 0x2b, 0x0d, 0x9a, 0x14, 0x7d, 0x05, 0xc5, 0x33, 0x77,
-	0xe4, 0x92, 0xbe, 0x30, 0xcf, 0x2c, 0x35, 0x07, 0x05, 0x6f, 0xaa, 0x42, 0xce, 0x86, 0x37, 0x83,
-	0xc7, 0x50, 0x71, 0x89, 0x37, 0xe0, 0x4b, 0xf1, 0xc6, 0xd4, 0x1f, 0xab, 0x63, 0xb0, 0xac, 0xc4,
-	0xc7, 0x5c, 0xca, 0xf2, 0xcc, 0x6f, 0x24, 0x79, 0x9e, 0x09, 0xfe, 0xcd, 0x8e, 0xac, 0xa1, 0xfd,
-	0xa3, 0x3a, 0xbf, 0x09, 0x3f, 0x05, 0x33, 0x66, 0x71, 0x68, 0xff, 0x28, 0x0f, 0x70, 0x82, 0x1e,
-	0x41, 0xc5, 0xb7, 0x03, 0x3c, 0xa2, 0x16, 0xdf, 0x50, 0xf6, 0x7b, 0x02, 0xdc, 0x43, 0x49, 0x88,
-	0xd9, 0x16, 0x1c, 0x38, 0x04, 0xed, 0x03, 0xf8, 0x81, 0xe7, 0xe3, 0x80, 0xba, 0x98, 0xd4, 0x8a,
-	0x9c, 0x37, 0x9b, 0x71, 0x7d, 0xbb, 0x71, 0x12, 0x42, 0x5b, 0x23, 0x1a, 0x4c, 0x4c, 0xcd, 0x96,
-	0x35, 0xe8, 0x00, 0xd3, 0x60, 0x62, 0x79, 0x67, 0xfc, 0x0c, 0x2d, 0x98, 0x39, 0x3e, 0x3e, 0x3e,
-	0x63, 0xdb, 0xc6, 0x0f, 0x29, 0x91, 0xb8, 0xd2, 0xf2, 0x6d, 0x93, 0xe8, 0x26, 0xd5, 0x8e, 0xde,
-	0xf2, 0x8d, 0x8e, 0xde, 0x47, 0x50, 0x21, 0x3f, 0xd8, 0xc1, 0xd0, 0x1d, 0x9d, 0x5b, 0x5d, 0x8f,
-	0xb2, 0x62, 0xac, 0xf0, 0xa0, 0x4a, 0x4a, 0xbc, 0xe3, 0xd1, 0x03, 0x07, 0x6d, 0x42, 0x35, 0xc4,
-	0xc9, 0x4c, 0xf1, 0xa3, 0xb4, 0x60, 0x96, 0x95, 0x5c, 0xa4, 0x0a, 0xbd, 0x80, 0x3c, 0x07, 0x5c,
-	0xe2, 0x89, 0x3c, 0x4c, 0x3f, 0x8e, 0x8b, 0xe5, 0x35, 0x9e, 0x98, 0x39, 0x2a, 0x69, 0xf6, 0x05,
-	0x64, 0xc4, 0xdd, 0x0f, 0xc5, 0x33, 0x4c, 0x2d, 0x80, 0x98, 0x02, 0x5b, 0xff, 0x1a, 0x2a, 0x33,
-	0xb9, 0x46, 0x55, 0x48, 0xb1, 0xa9, 0x45, 0x83, 0x60, 0x9f, 0xac, 0x69, 0x5c, 0xd9, 0x83, 0xb1,
-	0xe2, 0xa0, 0x18, 0xfc, 0x3a, 0xf9, 0xab, 0x84, 0x71, 0x08, 0x55, 0xe6, 0x72, 0x0f, 0xfb, 0x78,
-	0xe4, 0xe0, 0x51, 0x8f, 0xed, 0x0f, 0xd2, 0xee, 0x94, 0x05, 0x71, 0x73, 0x44, 0x06, 0xac, 0x38,
-	0x1a, 0x46, 0xd2, 0x6d, 0x4a, 0x66, 0xfc, 0x2b, 0x01, 0x45, 0x1e, 0xdf, 0x78, 0x38, 0xb4, 0x83,
-	0xc9, 0xdc, 0x1d, 0x57, 0xe3, 0x71, 0x72, 0x9a, 0xc7, 0xb3, 0x65, 0x9a, 0x9a, 0x2f, 0xd3, 0xeb,
-	0xed, 0x4d, 0xdf, 0x68, 0x7b, 0xa3, 0xb6, 0x2d, 0x13, 0xb5, 0x6d, 0xc6, 0x77, 0x50, 0xba, 0x8e,
-	0x5e, 0xe6, 0x41, 0x7b, 0x49, 0x10, 0x8d, 0xeb, 0xb9, 0xba, 0xb8, 0x24, 0x39, 0x01, 0x1e, 0xc4,
-	0x46, 0x21, 0x72, 0xa0, 0xee, 0x2f, 0x6f, 0xa1, 0xcc, 0xd3, 0xec, 0x0e, 0xf1, 0x88, 0xf5, 0x73,
-	0xc2, 0xae, 0xc8, 0xdc, 0x54, 0x9b, 0x81, 0x57, 0x0d, 0xbf, 0xf3, 0xde, 0x07, 0x70, 0x42, 0xa8,
-	0xcc, 0xb5, 0x26, 0x31, 0xfe, 0x96, 0x80, 0x42, 0x58, 0x09, 0x6c, 0x89, 0xd4, 0xa3, 0xf6, 0xc0,
-	0xf2, 0xae, 0x70, 0xd0, 0xc7, 0xb6, 0x63, 0x11, 0xee, 0x31, 0x69, 0x96, 0xb9, 0xfc, 0x58, 0x8a,
-	0xdb, 0xa8, 0x01, 0x6b, 0x8e, 0xf7, 0xc3, 0x68, 0xe0, 0xd9, 0x8e, 0x0e, 0x4e, 0x72, 0xf0, 0xaa,
-	0x52, 0x5d, 0xe3, 0x9f, 0xc0, 0xea, 0xd8, 0x9f, 0x45, 0xa7, 0x38, 0xba, 0x22, 0x14, 0x21, 0xd6,
-	0xf8, 0x6f, 0x16, 0x52, 0x87, 0x5e, 0xf7, 0x3d, 0xfe, 0xfa, 0xb7, 0xe1, 0xb6, 0x6e, 0x31, 0xc0,
-	0x36, 0xc1, 0x9c, 0x3c, 0xa2, 0x5a, 0xd7, 0x34, 0xe5, 0x1b, 0xa6, 0x63, 0x5c, 0xf9, 0x59, 0xfb,
-	0xfb, 0xfe, 0x0c, 0x19, 0x32, 0xbc, 0x16, 0x3e, 0x8d, 0xab, 0x05, 0x9d, 0x5c, 0xd3, 0x94, 0x99,
-	0x3d, 0x29, 0xb2, 0xef, 0x71, 0x52, 0xe4, 0x42, 0x7e, 0xe9, 0x8f, 0x20, 0xf9, 0xe9, 0x47, 0x10,
-	0x55, 0xca, 0x05, 0xad, 0x94, 0xd9, 0xa5, 0x20, 0x70, 0xbd, 0xc0, 0xa5, 0x13, 0xfe, 0x17, 0x94,
-	0x34, 0xc3, 0xf1, 0xcc, 0x89, 0x5f, 0xbc, 0xe1, 0x89, 0xff, 0x35, 0xac, 0x04, 0xe2, 0x5a, 0x26,
-	0x96, 0xb5, 0xb2, 0x74, 0x59, 0xc5, 0x10, 0xdf, 0xa4, 0x33, 0x87, 0xc0, 0xea, 0x4d, 0x0e, 0x81,
-	0xe7, 0x33, 0xff, 0x5f, 0xef, 0xf8, 0x76, 0xf1, 0x19, 0x94, 0xc5, 0x97, 0xe5, 0x60, 0x6a, 0xbb,
-	0x03, 0x22, 0x3b, 0x7b, 0x49, 0x48, 0xf7, 0x84, 0x10, 0x7d, 0xa9, 0xc8, 0x5f, 0xe6, 0x1b, 0xfe,
-	0xc9, 0x62, 0xf2, 0xb3, 0xdd, 0x16, 0x78, 0xf4, 0x1a, 0x2a, 0x1c, 0xaa, 0x91, 0xba, 0xc2, 0x5d,
-	0x18, 0xb1, 0x35, 0x13, 0x22, 0xcd, 0x32, 0x9d, 0x1a, 0x3f, 0xf9, 0x6b, 0x02, 0xe0, 0xba, 0xd1,
-	0xa1, 0x75, 0x58, 0xeb, 0x34, 0xdb, 0xaf, 0xad, 0x76, 0xa7, 0xd9, 0x39, 0x6d, 0x5b, 0x27, 0xad,
-	0xa3, 0xbd, 0x83, 0xa3, 0x57, 0xd5, 0x8f, 0x66, 0x15, 0xe6, 0xe9, 0xd1, 0x11, 0x53, 0x24, 0x66,
-	0x15, 0xed, 0xd3, 0xdd, 0xdd, 0x56, 0xbb, 0x5d, 0x4d, 0xce, 0x2a, 0xbe, 0x69, 0x1e, 0xbc, 0x39,
-	0x35, 0x5b, 0xd5, 0x14, 0xba, 0x03, 0x48, 0x57, 0xbc, 0x3d, 0x68, 0xef, 0x37, 0x4f, 0xaa, 0xe9,
-	0x27, 0xff, 0x48, 0x40, 0x21, 0xcc, 0x26, 0xaa, 0xc3, 0x9d, 0xc3, 0xe3, 0x1d, 0x05, 0x3a, 0x38,
-	0xb2, 0x4e, 0xcc, 0xe3, 0x57, 0x26, 0x73, 0xfd, 0x11, 0xf3, 0xa0, 0xe9, 0xd4, 0x94, 0x89, 0x19,
-	0xb9, 0x9a, 0x31, 0x89, 0x6e, 0xc3, 0xaa, 0x26, 0x97, 0x13, 0xa6, 0x58, 0x84, 0x9a, 0x78, 0xb7,
-	0x79, 0xb4, 0xdb, 0x7a, 0xd3, 0xda, 0xab, 0xa6, 0x51, 0x0d, 0x6e, 0x69, 0x0a, 0xb3, 0xf5, 0xed,
-	0x69, 0xab, 0xdd, 0x69, 0xed, 0x55, 0x33, 0xdb, 0x7f, 0xc9, 0xc1, 0x2d, 0x9e, 0x2e, 0x95, 0xe3,
-	0x36, 0x0e, 0xae, 0xdc, 0x1e, 0x46, 0xdf, 0x43, 0x51, 0x7b, 0xa6, 0x45, 0x8f, 0x16, 0xbf, 0xed,
-	0xaa, 0xff, 0xcd, 0xfa, 0xe3, 0xa5, 0x38, 0xf9, 0x8f, 0x71, 0x0c, 0x59, 0xf1, 0x6a, 0x8b, 0x22,
-	0x0b, 0x65, 0xea, 0xc9, 0xb7, 0x6e, 0x2c, 0x82, 0x48, 0x87, 0x7f, 0x80, 0x42, 0xf8, 0x4a, 0x8b,
-	0x22, 0xbb, 0xcd, 0xec, 0x43, 0x6f, 0xfd, 0xb3, 0x25, 0x28, 0xe9, 0xf9, 0x8f, 0x00, 0xd7, 0x4f,
-	0x5c, 0x28, 0xd2, 0x68, 0xee, 0x79, 0xb7, 0xfe, 0x68, 0x19, 0x4c, 0x3a, 0x37, 0x21, 0x27, 0x5f,
-	0xad, 0x50, 0xdc, 0x2a, 0xb5, 0x97, 0xb1, 0xfa, 0xc3, 0x85, 0x18, 0xe9, 0xf3, 0x7b, 0x28, 0x6a,
-	0xcf, 0x0a, 0x68, 0x41, 0x28, 0xfa, 0x43, 0x57, 0xf4, 0xde, 0x45, 0xbd, 0x4f, 0x0c, 0xa1, 0x3a,
-	0xfb, 0xab, 0x8e, 0x9e, 0xc6, 0x04, 0x16, 0xf5, 0x2a, 0x51, 0x7f, 0xf6, 0x6e, 0x60, 0x39, 0xdd,
-	0x05, 0x54, 0x66, 0x7e, 0x96, 0xd1, 0x93, 0x28, 0x07, 0xd1, 0x7f, 0xfa, 0xf5, 0xa7, 0xef, 0x84,
-	0x95, 0x73, 0x11, 0x40, 0xf3, 0x3f, 0xc6, 0xe8, 0x17, 0x51, 0x2e, 0x62, 0xff, 0xb6, 0xeb, 0x8d,
-	0x77, 0x85, 0x8b, 0x49, 0x77, 0x3e, 0xff, 0xee, 0xf1, 0xb9, 0xd7, 0x20, 0x97, 0xae, 0xdd, 0xf0,
-	0x82, 0xf3, 0x2d, 0x77, 0x74, 0x16, 0xd8, 0x5b, 0xd3, 0x2e, 0xb6, 0xce, 0xbd, 0xad, 0xc0, 0xef,
-	0x75, 0xb3, 0xbc, 0xc3, 0x7f, 0xf1, 0x53, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc5, 0xab, 0x09, 0xca,
-	0x1a, 0x1a, 0x00, 0x00,
+	// 2183 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0x4b, 0x6f, 0x1b, 0xc9,
+	0x11, 0x5e, 0xbe, 0xc9, 0xe2, 0x53, 0x2d, 0x59, 0xa2, 0xb9, 0xb0, 0xad, 0x1d, 0xef, 0x5a, 0x5a,
+	0xdb, 0xa1, 0x02, 0x6d, 0xec, 0x8d, 0xb3, 0xd9, 0x24, 0x94, 0x44, 0x4b, 0xf2, 0x43, 0xd2, 0x0e,
+	0x29, 0x20, 0xd8, 0x00, 0x3b, 0x18, 0x72, 0x5a, 0xe2, 0x48, 0x24, 0x67, 0xd2, 0xdd, 0xd4, 0x5a,
+	0xa7, 0x00, 0x39, 0xe5, 0x9a, 0x6b, 0x6e, 0xf9, 0x1f, 0xf9, 0x1f, 0x01, 0x72, 0x0c, 0x72, 0xc8,
+	0x29, 0xf9, 0x0b, 0x41, 0x3f, 0x66, 0xd4, 0x7c, 0x0c, 0x69, 0xad, 0x11, 0x04, 0xc8, 0xad, 0xbb,
+	0xea, 0xab, 0xea, 0xee, 0xea, 0xaa, 0xea, 0xea, 0x82, 0x1c, 0xf1, 0xbb, 0x75, 0x9f, 0x78, 0xcc,
+	0x43, 0x88, 0xd9, 0xf4, 0xd2, 0xa2, 0xdd, 0x1e, 0x76, 0x46, 0x7d, 0x4c, 0xea, 0xc4, 0xef, 0xd6,
+	0x1e, 0x9c, 0x7b, 0xde, 0x79, 0x1f, 0x6f, 0x09, 0x44, 0x67, 0x74, 0xb6, 0xc5, 0xdc, 0x01, 0xa6,
+	0xcc, 0x1e, 0xf8, 0x52, 0xc8, 0xf8, 0x6b, 0x0c, 0xa0, 0x4d, 0xdc, 0xf3, 0x73, 0x4c, 0x5e, 0x79,
+	0x1d, 0x74, 0x17, 0xb2, 0x17, 0x5e, 0xc7, 0x1a, 0xda, 0x03, 0x5c, 0x8d, 0xad, 0xc7, 0x36, 0x73,
+	0x66, 0xe6, 0xc2, 0xeb, 0x1c, 0xd9, 0x03, 0x8c, 0x1e, 0x40, 0xbe, 0xeb, 0x0d, 0x06, 0x2e, 0xb3,
+	0x7a, 0x36, 0xed, 0x55, 0xe3, 0x82, 0x0b, 0x92, 0x74, 0x60, 0xd3, 0x1e, 0x3a, 0x02, 0xf0, 0x6d,
+	0x62, 0x0f, 0x30, 0xc3, 0x84, 0x56, 0x13, 0xeb, 0x89, 0xcd, 0xfc, 0x76, 0xbd, 0x3e, 0xbd, 0xa9,
+	0xfa, 0xcd, 0x7a, 0xf5, 0x93, 0x50, 0xa0, 0x39, 0x64, 0xe4, 0xda, 0xd4, 0x34, 0xd4, 0xbe, 0x86,
+	0xf2, 0x04, 0x1b, 0x55, 0x20, 0x71, 0x89, 0xaf, 0xd5, 0xce, 0xf8, 0x10, 0xad, 0x40, 0xea, 0xca,
+	0xee, 0x8f, 0xb0, 0xda, 0x8f, 0x9c, 0xfc, 0x2c, 0xfe, 0xd3, 0x98, 0x71, 0x00, 0xe8, 0x66, 0x21,
+	0x6a, 0xe2, 0xdf, 0x8e, 0x30, 0x65, 0x68, 0x1b, 0x92, 0x17, 0x5e, 0x87, 0x56, 0x63, 0x62, 0x7b,
+	0xf7, 0xe7, 0x6f, 0xcf, 0x14, 0x58, 0xa3, 0x0e, 0xcb, 0x63, 0x9a, 0xa8, 0xef, 0x0d, 0x29, 0x46,
+	0x6b, 0xc0, 0x6d, 0x63, 0xb9, 0x8e, 0xd4, 0x96, 0x33, 0xd3, 0x17, 0x5e, 0xe7, 0xd0, 0xa1, 0xc6,
+	0x03, 0x28, 0xee, 0x63, 0xc6, 0xe5, 0xd5, 0xa2, 0x25, 0x88, 0xbb, 0x8e, 0xda, 0x75, 0xdc, 0x75,
+	0x8c, 0xaf, 0xa0, 0x14, 0x00, 0x94, 0xae, 0xcf, 0x21, 0x71, 0xe1, 0x75, 0x04, 0x24, 0xbf, 0xbd,
+	0x36, 0x6b, 0x57, 0x1c, 0xcd, 0x31, 0x86, 0x01, 0x95, 0x5d, 0x7b, 0xd8, 0xc5, 0xfd, 0x39, 0x0b,
+	0xfc, 0x02, 0x96, 0x34, 0xcc, 0xed, 0xd7, 0xf8, 0x5b, 0x0a, 0x96, 0x5a, 0xd8, 0x26, 0xdd, 0x9e,
+	0x6e, 0xbb, 0x1f, 0xc3, 0x4a, 0x67, 0xe4, 0xf6, 0x9d, 0xce, 0xa8, 0x7b, 0x89, 0x99, 0x25, 0xc6,
+	0x56, 0xb8, 0x2e, 0xd2, 0x78, 0x3b, 0x7c, 0x78, 0xe8, 0xa0, 0x2f, 0xa1, 0xda, 0xb3, 0xa9, 0x35,
+	0x53, 0x8a, 0x5f, 0x58, 0xd6, 0xbc, 0xd3, 0xb3, 0xe9, 0xce, 0xb4, 0xe0, 0x5d, 0xc8, 0xba, 0xd4,
+	0x3a, 0xf3, 0x48, 0x17, 0x57, 0x13, 0x02, 0x98, 0x71, 0xe9, 0x4b, 0x3e, 0x45, 0xeb, 0x50, 0xe0,
+	0x3a, 0x43, 0x76, 0x52, 0xb0, 0xa1, 0x67, 0xd3, 0x43, 0x85, 0x58, 0x81, 0x94, 0x4b, 0xe9, 0x08,
+	0x57, 0x53, 0xd2, 0x27, 0xc4, 0x04, 0x7d, 0x0c, 0x39, 0x29, 0xc7, 0x39, 0x69, 0x21, 0x94, 0x15,
+	0x42, 0x9c, 0x89, 0x20, 0x29, 0x7c, 0x3e, 0x23, 0x24, 0xc4, 0x98, 0xef, 0x81, 0x0b, 0x08, 0x7a,
+	0x56, 0xee, 0xa1, 0x67, 0x53, 0x11, 0x0b, 0x35, 0xc8, 0xfa, 0x36, 0xeb, 0xf6, 0x28, 0x66, 0xd5,
+	0x9c, 0x10, 0x09, 0xe7, 0xe8, 0x13, 0xb9, 0xbf, 0x90, 0x0f, 0x42, 0x34, 0xdf, 0xb3, 0xe9, 0x49,
+	0x00, 0x41, 0x90, 0x24, 0xd8, 0xf7, 0xaa, 0x79, 0xb9, 0x1a, 0x1f, 0x07, 0xab, 0x09, 0x7a, 0x21,
+	0x5c, 0xcd, 0xe4, 0xac, 0x1a, 0x64, 0x09, 0xbe, 0x72, 0xa9, 0xeb, 0x0d, 0xab, 0x45, 0xb9, 0x5a,
+	0x30, 0x0f, 0x56, 0x0b, 0xf9, 0xa5, 0x70, 0x35, 0x33, 0x80, 0x3c, 0x83, 0x34, 0x65, 0x36, 0x1b,
+	0xd1, 0x6a, 0x79, 0x3d, 0xb6, 0x59, 0xda, 0xbe, 0x17, 0x71, 0xf5, 0x2d, 0x01, 0x32, 0x15, 0x18,
+	0xdd, 0x03, 0x6e, 0x53, 0x4b, 0x89, 0x56, 0x84, 0x5e, 0x6e, 0x41, 0x09, 0x43, 0x2f, 0x00, 0x78,
+	0x2e, 0xe1, 0x7c, 0xc2, 0xaa, 0x4b, 0xc2, 0xa9, 0x6a, 0x75, 0x99, 0x6e, 0xea, 0x41, 0xba, 0xa9,
+	0xb7, 0x83, 0x74, 0x63, 0xe6, 0x38, 0xba, 0xc5, 0xc1, 0xe8, 0x53, 0x28, 0x71, 0xcd, 0x9a, 0x38,
+	0x12, 0xda, 0xf9, 0x49, 0xda, 0x21, 0xea, 0x19, 0x64, 0x05, 0x02, 0x0f, 0x9d, 0xea, 0xf2, 0x42,
+	0xf5, 0x19, 0x8e, 0x6d, 0x0e, 0x9d, 0xc0, 0x3d, 0x42, 0xd1, 0x95, 0xd0, 0x3d, 0xda, 0x12, 0x61,
+	0x34, 0x00, 0xe9, 0xbe, 0xad, 0xa2, 0xe3, 0xc9, 0x58, 0x62, 0x88, 0x0c, 0x0f, 0x99, 0x11, 0x9a,
+	0x22, 0x80, 0xdb, 0x36, 0xbd, 0x8c, 0x88, 0x40, 0xf4, 0x10, 0x8a, 0xee, 0xb0, 0xdb, 0x1f, 0x39,
+	0xe2, 0x88, 0x8c, 0x2a, 0x77, 0x2f, 0x28, 0x22, 0x37, 0x22, 0x35, 0x7e, 0x09, 0xe5, 0x50, 0x8d,
+	0xda, 0xc6, 0x53, 0x48, 0xf2, 0x95, 0x55, 0x94, 0x56, 0x67, 0xe6, 0x27, 0x8e, 0x17, 0x28, 0xe3,
+	0xdf, 0xc9, 0xe0, 0x2c, 0x9c, 0x18, 0x06, 0x6a, 0x15, 0x32, 0x36, 0x63, 0x78, 0xe0, 0x33, 0xa1,
+	0x27, 0x65, 0x06, 0x53, 0x9e, 0xc4, 0xb9, 0x75, 0x02, 0x6e, 0x3c, 0x34, 0x4e, 0x43, 0x01, 0xc2,
+	0xd8, 0x49, 0x44, 0xc6, 0x4e, 0x32, 0x22, 0x76, 0x52, 0x11, 0xb1, 0x93, 0x8e, 0x8e, 0x9d, 0xcc,
+	0x82, 0xd8, 0xc9, 0x46, 0xc7, 0x4e, 0x2e, 0x22, 0x76, 0x20, 0x3a, 0x76, 0xf2, 0x0b, 0x62, 0xa7,
+	0x30, 0x1d, 0x3b, 0xcf, 0xc3, 0xd8, 0x29, 0x8a, 0xd8, 0xb9, 0x1f, 0x75, 0x21, 0x73, 0x83, 0xa7,
+	0x34, 0x3f, 0x78, 0xca, 0x1f, 0x16, 0x3c, 0x95, 0x05, 0xc1, 0xb3, 0xf4, 0xc3, 0x83, 0x07, 0x4d,
+	0x05, 0x4f, 0x13, 0x96, 0xc7, 0x1c, 0x4e, 0xb9, 0x6d, 0x1d, 0x52, 0xdc, 0x30, 0x41, 0xf8, 0x44,
+	0xfb, 0xad, 0x84, 0x19, 0x77, 0x61, 0x6d, 0x1f, 0xb3, 0xd6, 0xa5, 0xeb, 0x0b, 0xea, 0xa8, 0x8f,
+	0x03, 0xe7, 0x35, 0xfe, 0x14, 0x87, 0x82, 0xce, 0xe0, 0xb7, 0x6b, 0x3b, 0x0e, 0x76, 0xac, 0x4e,
+	0xf0, 0xf2, 0x67, 0xc4, 0x7c, 0xe7, 0x1a, 0x3d, 0x05, 0x55, 0xf4, 0xf8, 0xb8, 0xcb, 0xbd, 0x86,
+	0x61, 0x32, 0xe4, 0xa1, 0xc6, 0x5f, 0xe3, 0x0a, 0xe7, 0xb4, 0x7c, 0xdc, 0x3d, 0x51, 0x74, 0x1e,
+	0x16, 0xb2, 0x5c, 0x91, 0xd5, 0x49, 0xce, 0x0c, 0xa6, 0x68, 0x1d, 0xf2, 0x0e, 0xa6, 0x5d, 0xe2,
+	0xfa, 0x8c, 0x3b, 0x42, 0x52, 0xac, 0xa2, 0x93, 0x66, 0x3a, 0xf9, 0x4f, 0x20, 0x83, 0xdf, 0xf9,
+	0x2e, 0xc1, 0xb4, 0x9a, 0x5e, 0x6c, 0x63, 0x05, 0x45, 0xab, 0x90, 0xc6, 0xef, 0x7c, 0x8f, 0x48,
+	0xef, 0xcf, 0x9a, 0x6a, 0x26, 0x32, 0xc6, 0x80, 0x8f, 0xb0, 0x63, 0x9d, 0x11, 0x6f, 0x20, 0x9c,
+	0x3f, 0x67, 0x16, 0x02, 0xe2, 0x4b, 0xe2, 0x0d, 0x0c, 0x13, 0xaa, 0xd3, 0x76, 0x53, 0x77, 0xf0,
+	0x1c, 0x52, 0x84, 0x13, 0xd4, 0x1d, 0xac, 0xcf, 0xba, 0x03, 0x5d, 0xd2, 0x94, 0x70, 0xe3, 0x1f,
+	0x31, 0x58, 0x6d, 0x38, 0xce, 0x18, 0x4b, 0x25, 0x92, 0xff, 0x23, 0xfb, 0x1a, 0xdf, 0xc0, 0xda,
+	0xd4, 0x29, 0x3f, 0xd0, 0x72, 0x4f, 0xe0, 0xee, 0x1e, 0xee, 0x63, 0x86, 0x67, 0xd9, 0x6e, 0xb2,
+	0x26, 0x6b, 0x43, 0x6d, 0x16, 0xf8, 0x03, 0xb7, 0xf0, 0xcf, 0x18, 0xe4, 0x78, 0xa2, 0xe3, 0x89,
+	0x05, 0xa3, 0x17, 0x90, 0x12, 0xb9, 0x53, 0x3d, 0x1f, 0x0f, 0x67, 0x69, 0x09, 0xd1, 0x75, 0x91,
+	0x53, 0x4d, 0x29, 0x11, 0xe6, 0xd5, 0xb8, 0x96, 0x57, 0xf5, 0xe4, 0x99, 0x18, 0x4f, 0x9e, 0x35,
+	0x1f, 0x52, 0x42, 0xfe, 0xe6, 0xc5, 0x88, 0xe9, 0x2f, 0xc6, 0x3d, 0xfe, 0x19, 0x60, 0xdd, 0x9e,
+	0xa5, 0x29, 0xcd, 0x09, 0x4a, 0x90, 0x96, 0xc3, 0x24, 0x9f, 0x98, 0x78, 0x04, 0x56, 0x21, 0x4d,
+	0x31, 0xb9, 0xc2, 0x44, 0xf9, 0x89, 0x9a, 0x19, 0xbf, 0x83, 0x0c, 0x3f, 0xfd, 0x6b, 0x7c, 0x8d,
+	0x7e, 0x0e, 0xc0, 0xf5, 0x8a, 0xfc, 0x8a, 0xd5, 0x61, 0xef, 0xcd, 0x3d, 0xac, 0x99, 0x23, 0xc1,
+	0x30, 0xf4, 0xb5, 0xb8, 0xe6, 0x6b, 0x06, 0x14, 0x45, 0x39, 0xe9, 0x58, 0xb2, 0xa6, 0x57, 0xbb,
+	0xca, 0x4b, 0xe2, 0x2b, 0x5e, 0xd8, 0x1b, 0x7f, 0x4f, 0x43, 0x92, 0xef, 0x60, 0xce, 0xfb, 0xaa,
+	0x85, 0x40, 0x7c, 0x3c, 0x04, 0x5e, 0x00, 0x74, 0x09, 0xb6, 0x79, 0x74, 0xdb, 0xf2, 0xcc, 0x0b,
+	0x52, 0xbe, 0x42, 0x37, 0x18, 0xfa, 0x15, 0x94, 0x9c, 0x8e, 0x35, 0xf0, 0x1c, 0xf7, 0xcc, 0x95,
+	0xe2, 0xc9, 0x85, 0xe2, 0x05, 0xa7, 0xf3, 0x56, 0x09, 0x34, 0x18, 0xfa, 0x0a, 0xf2, 0x67, 0xee,
+	0xd0, 0xa5, 0x3d, 0x29, 0x9e, 0x5a, 0x28, 0x0e, 0x01, 0xbc, 0x11, 0x38, 0x72, 0x3a, 0x2c, 0x6d,
+	0x36, 0xa0, 0xec, 0x52, 0xaf, 0x2f, 0x8e, 0xe2, 0x8d, 0x98, 0x3f, 0x0a, 0xde, 0xf1, 0x52, 0x40,
+	0x3e, 0x16, 0x54, 0x6e, 0x67, 0x51, 0x52, 0x65, 0x85, 0x25, 0xc4, 0x98, 0xbf, 0xb9, 0x03, 0xfb,
+	0x5d, 0x50, 0x80, 0x50, 0xf1, 0x8c, 0xa7, 0xcc, 0xfc, 0xc0, 0x7e, 0xa7, 0x2a, 0x10, 0x8a, 0x1e,
+	0x41, 0xd9, 0xb7, 0x09, 0x1e, 0x32, 0x4b, 0x5c, 0x28, 0xff, 0x5f, 0x81, 0xd0, 0x50, 0x94, 0x64,
+	0x7e, 0x05, 0x87, 0x0e, 0x45, 0x07, 0x00, 0x3e, 0xf1, 0x7c, 0x4c, 0x98, 0x8b, 0x69, 0x35, 0x2f,
+	0xe2, 0x66, 0x33, 0xea, 0xe1, 0xa9, 0x9f, 0x84, 0xd0, 0xe0, 0xa7, 0x19, 0x12, 0xf8, 0x0b, 0x43,
+	0x30, 0x23, 0xd7, 0x96, 0x77, 0x26, 0x8a, 0x80, 0x9c, 0x99, 0x11, 0xf3, 0xe3, 0x33, 0x7e, 0x6d,
+	0xe2, 0x95, 0x95, 0x86, 0x2b, 0x2e, 0xbe, 0x36, 0x85, 0x6e, 0x30, 0xad, 0x76, 0x28, 0xdd, 0xaa,
+	0x76, 0x78, 0x04, 0x65, 0xfa, 0xbd, 0x4d, 0x06, 0xee, 0xf0, 0xdc, 0xea, 0x78, 0x8c, 0x3b, 0x63,
+	0x59, 0x6c, 0xaa, 0x18, 0x90, 0x77, 0x3c, 0x76, 0xe8, 0xa0, 0x4d, 0xa8, 0x84, 0x38, 0x65, 0x29,
+	0x51, 0x0b, 0xe4, 0xcc, 0x52, 0x40, 0x97, 0xa6, 0x42, 0xcf, 0x21, 0x2b, 0x00, 0xfc, 0xef, 0x2c,
+	0xab, 0x81, 0x8f, 0xa3, 0xf6, 0xf2, 0x1a, 0x5f, 0x9b, 0x19, 0x26, 0x07, 0xe8, 0x0b, 0x48, 0xc9,
+	0xe2, 0x15, 0x45, 0x47, 0x58, 0x70, 0x00, 0x6a, 0x4a, 0xac, 0xf8, 0xb6, 0x8f, 0xdb, 0xfa, 0x56,
+	0xdf, 0xf6, 0x57, 0x50, 0xe1, 0x2a, 0xf7, 0xb0, 0x8f, 0x87, 0x0e, 0x1e, 0x76, 0xf9, 0xfd, 0x20,
+	0xad, 0x28, 0xce, 0xc9, 0xd2, 0x17, 0x19, 0x50, 0x70, 0x34, 0x8c, 0x0a, 0xb7, 0x31, 0x9a, 0xf1,
+	0x97, 0x18, 0xe4, 0xc5, 0xfe, 0x46, 0x83, 0x81, 0x4d, 0xae, 0xa7, 0x8a, 0x74, 0x2d, 0x8e, 0xe3,
+	0xe3, 0x71, 0x3c, 0xe9, 0xa6, 0x89, 0x69, 0x37, 0xbd, 0xb9, 0xde, 0xe4, 0xad, 0xae, 0x77, 0xd6,
+	0xb5, 0xa5, 0x66, 0x5d, 0x9b, 0xf1, 0x2d, 0x14, 0x6f, 0x76, 0xaf, 0xec, 0xa0, 0x75, 0x66, 0xc4,
+	0x18, 0x3d, 0x0b, 0x2a, 0xaf, 0xb8, 0x08, 0x80, 0x07, 0x91, 0xbb, 0x90, 0x36, 0x08, 0x0a, 0xb0,
+	0xb7, 0x50, 0x12, 0x66, 0x76, 0x07, 0x78, 0xc8, 0xf3, 0x39, 0xe5, 0x35, 0xbe, 0x10, 0xd5, 0x56,
+	0x10, 0x5e, 0x23, 0x8a, 0xf6, 0xfb, 0x00, 0x4e, 0x08, 0x55, 0xb6, 0xd6, 0x28, 0xc6, 0x1f, 0x63,
+	0x90, 0x0b, 0x3d, 0x81, 0x1f, 0x91, 0x79, 0xcc, 0xee, 0x5b, 0xde, 0x15, 0x26, 0x3d, 0x6c, 0x3b,
+	0x16, 0x15, 0x1a, 0xe3, 0x66, 0x49, 0xd0, 0x8f, 0x15, 0xb9, 0x85, 0xea, 0xb0, 0xec, 0x78, 0xdf,
+	0x0f, 0xfb, 0x9e, 0xed, 0xe8, 0xe0, 0xb8, 0x00, 0x2f, 0x05, 0xac, 0x1b, 0xfc, 0x63, 0x58, 0x1a,
+	0xf9, 0x93, 0xe8, 0x84, 0x40, 0x97, 0x47, 0xfe, 0x18, 0xd6, 0xf8, 0x57, 0x06, 0x12, 0xbc, 0xa7,
+	0x75, 0xfb, 0xb6, 0xc5, 0x36, 0xdc, 0xd1, 0x25, 0xfa, 0xd8, 0xa6, 0x58, 0x04, 0x8f, 0xf4, 0xd6,
+	0x65, 0x8d, 0xf9, 0x86, 0xf3, 0x78, 0xac, 0xfc, 0x4f, 0xf3, 0xfb, 0xc1, 0x44, 0x30, 0xa4, 0x84,
+	0x2f, 0x7c, 0x1a, 0xe5, 0x0b, 0x7a, 0x70, 0x8d, 0x87, 0xcc, 0xe4, 0x4b, 0x91, 0xfe, 0x01, 0x2f,
+	0x45, 0x26, 0x8c, 0x2f, 0xbd, 0x8b, 0x93, 0x1d, 0xef, 0xe2, 0x04, 0xae, 0x9c, 0xd3, 0x5c, 0x99,
+	0x17, 0x05, 0xc4, 0xf5, 0x88, 0xcb, 0xae, 0xc5, 0x37, 0x2e, 0x6e, 0x86, 0xf3, 0x89, 0x17, 0x3f,
+	0x7f, 0xcb, 0x17, 0xff, 0x6b, 0x28, 0x10, 0x59, 0x96, 0xc9, 0x63, 0x15, 0x16, 0x1e, 0x2b, 0x1f,
+	0xe2, 0x1b, 0x6c, 0xe2, 0x11, 0x58, 0xba, 0xcd, 0x23, 0xf0, 0x6c, 0xe2, 0x03, 0xf9, 0x9e, 0xcd,
+	0x97, 0xcf, 0xa0, 0x24, 0x47, 0x96, 0x83, 0x99, 0xed, 0xf6, 0xa9, 0xca, 0xec, 0x45, 0x49, 0xdd,
+	0x93, 0x44, 0xf4, 0x65, 0x10, 0xfc, 0x25, 0x71, 0xe1, 0x9f, 0xcc, 0x0f, 0x7e, 0x7e, 0xdb, 0x12,
+	0x8f, 0x5e, 0x43, 0x59, 0x40, 0xb5, 0xa0, 0x2e, 0x0b, 0x15, 0x46, 0xa4, 0xcf, 0x84, 0x48, 0xb3,
+	0xc4, 0xc6, 0xe6, 0x68, 0x7f, 0xac, 0xf1, 0x8b, 0x84, 0x9e, 0x8d, 0x88, 0x73, 0xfe, 0x17, 0x3b,
+	0xbe, 0x8f, 0xff, 0xc0, 0x7b, 0xd9, 0x61, 0xc2, 0x45, 0x6b, 0xb0, 0xdc, 0x6e, 0xb4, 0x5e, 0x5b,
+	0xad, 0x76, 0xa3, 0x7d, 0xda, 0xb2, 0x4e, 0x9a, 0x47, 0x7b, 0x87, 0x47, 0xfb, 0x95, 0x8f, 0x26,
+	0x19, 0xe6, 0xe9, 0xd1, 0x11, 0x67, 0xc4, 0x26, 0x19, 0xad, 0xd3, 0xdd, 0xdd, 0x66, 0xab, 0x55,
+	0x89, 0x4f, 0x32, 0x5e, 0x36, 0x0e, 0xdf, 0x9c, 0x9a, 0xcd, 0x4a, 0x02, 0xad, 0x02, 0xd2, 0x19,
+	0x6f, 0x0f, 0x5b, 0x07, 0x8d, 0x93, 0x4a, 0xf2, 0xf1, 0x9f, 0x63, 0x90, 0x0b, 0x6f, 0x15, 0xd5,
+	0x60, 0xf5, 0xd5, 0xf1, 0x4e, 0x00, 0x3a, 0x3c, 0xb2, 0x4e, 0xcc, 0xe3, 0x7d, 0x93, 0xab, 0xfe,
+	0x88, 0x6b, 0xd0, 0x78, 0xc1, 0x92, 0xb1, 0x09, 0x7a, 0xb0, 0x62, 0x1c, 0xdd, 0x81, 0x25, 0x8d,
+	0xae, 0x16, 0x4c, 0xf0, 0x1d, 0x6a, 0xe4, 0xdd, 0xc6, 0xd1, 0x6e, 0xf3, 0x4d, 0x73, 0xaf, 0x92,
+	0x44, 0x55, 0x58, 0xd1, 0x18, 0x66, 0xf3, 0x9b, 0xd3, 0x66, 0xab, 0xdd, 0xdc, 0xab, 0xa4, 0xb6,
+	0x7f, 0x9f, 0x81, 0x15, 0x61, 0xae, 0xe0, 0x8e, 0x5a, 0x98, 0x5c, 0xb9, 0x5d, 0x8c, 0xbe, 0x83,
+	0xbc, 0xd6, 0xef, 0x46, 0x8f, 0xe6, 0x37, 0xc9, 0x83, 0x8f, 0x7b, 0x6d, 0x63, 0x21, 0x4e, 0xfd,
+	0x75, 0x8e, 0x21, 0x2d, 0xdb, 0xdf, 0x68, 0xa6, 0xc3, 0x8e, 0xf5, 0xce, 0x6b, 0xc6, 0x3c, 0x88,
+	0x52, 0xf8, 0x6b, 0xc8, 0x85, 0xed, 0x6e, 0x34, 0x33, 0xeb, 0x4d, 0x76, 0xcc, 0x6b, 0x9f, 0x2d,
+	0x40, 0x29, 0xcd, 0xbf, 0x01, 0xb8, 0xe9, 0x15, 0xa2, 0x99, 0x42, 0x53, 0x7d, 0xf2, 0xda, 0xa3,
+	0x45, 0x30, 0xa5, 0xdc, 0x84, 0x8c, 0x6a, 0xff, 0xa1, 0xa8, 0x53, 0x6a, 0x2d, 0xc6, 0xda, 0xc3,
+	0xb9, 0x18, 0xa5, 0xf3, 0x3b, 0xc8, 0x6b, 0xfd, 0x19, 0x34, 0x67, 0x2b, 0x7a, 0xc7, 0xb0, 0xb6,
+	0xb1, 0x10, 0xa7, 0xf4, 0x0f, 0xa0, 0x32, 0xd9, 0x80, 0x40, 0x4f, 0x22, 0x36, 0x36, 0xab, 0xbd,
+	0x53, 0x7b, 0xfa, 0x7e, 0x60, 0xb5, 0xdc, 0x05, 0x94, 0x27, 0x3e, 0xed, 0xe8, 0xf1, 0x2c, 0x05,
+	0xb3, 0xfb, 0x17, 0xb5, 0x27, 0xef, 0x85, 0x55, 0x6b, 0x51, 0x40, 0xd3, 0x1f, 0x74, 0xf4, 0xa3,
+	0x59, 0x2a, 0x22, 0x7f, 0xfd, 0xb5, 0xfa, 0xfb, 0xc2, 0xe5, 0xa2, 0x3b, 0x9f, 0x7f, 0xbb, 0x71,
+	0xee, 0xd5, 0xe9, 0xa5, 0x6b, 0xd7, 0x3d, 0x72, 0xbe, 0xe5, 0x0e, 0xcf, 0x88, 0xbd, 0x35, 0xae,
+	0x62, 0xeb, 0xdc, 0xdb, 0x22, 0x7e, 0xb7, 0x93, 0x16, 0x2f, 0xcd, 0x17, 0xff, 0x19, 0x00, 0x82,
+	0xb3, 0x4a, 0xf8, 0xf3, 0x1b, 0x00, 0x00,
 }
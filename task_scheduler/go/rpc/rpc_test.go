@@ -93,6 +93,12 @@ func setup(t *testing.T) (context.Context, *taskSchedulerServiceImpl, *types.Tas
 		cfg := &specs.TasksCfg{
 			Jobs: map[string]*specs.JobSpec{
 				"job": {
+					Parameters: []*specs.JobParameter{
+						{
+							Name:    "GN_ARGS",
+							Default: "is_debug=true",
+						},
+					},
 					TaskSpecs: []string{"task"},
 				},
 			},
@@ -137,7 +143,7 @@ func setup(t *testing.T) (context.Context, *taskSchedulerServiceImpl, *types.Tas
 	swarm := &mocks.SwarmingV2Client{}
 
 	// Create the service.
-	srv := newTaskSchedulerServiceImpl(ctx, d, repos, skipDB, tcc, swarm)
+	srv := newTaskSchedulerServiceImpl(ctx, d, repos, skipDB, tcc, swarm, false)
 	return ctx, srv, task, job, skipRule, swarm, func() {
 		btCleanup()
 		cleanupFS()
@@ -181,9 +187,48 @@ func TestTriggerJobs(t *testing.T) {
 	require.Equal(t, 2, len(res.JobIds))
 	for _, id := range res.JobIds {
 		require.NotEqual(t, "", id)
+		job, err := srv.db.GetJobById(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"GN_ARGS": "is_debug=true"}, job.Parameters)
 	}
 }
 
+func TestTriggerJobs_Parameters(t *testing.T) {
+
+	ctx, srv, _, _, _, _, cleanup := setup(t)
+	defer cleanup()
+	ctx = alogin.FakeStatus(ctx, &editorStatus)
+
+	commit := srv.repos[fakeRepo].Get(git.MainBranch).Hash
+	res, err := srv.TriggerJobs(ctx, &TriggerJobsRequest{
+		Jobs: []*TriggerJob{
+			{
+				JobName:    "job",
+				CommitHash: commit,
+				Parameters: map[string]string{"GN_ARGS": "is_debug=false"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.JobIds))
+	job, err := srv.db.GetJobById(ctx, res.JobIds[0])
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"GN_ARGS": "is_debug=false"}, job.Parameters)
+
+	// Unknown parameters are rejected.
+	res, err = srv.TriggerJobs(ctx, &TriggerJobsRequest{
+		Jobs: []*TriggerJob{
+			{
+				JobName:    "job",
+				CommitHash: commit,
+				Parameters: map[string]string{"BOGUS": "value"},
+			},
+		},
+	})
+	require.Nil(t, res)
+	require.EqualError(t, err, "twirp error invalid_argument: Invalid job parameters: unknown parameter \"BOGUS\"")
+}
+
 func TestGetJob(t *testing.T) {
 
 	ctx, srv, _, job, _, _, cleanup := setup(t)
@@ -433,6 +478,48 @@ func TestDeleteSkipTaskRule(t *testing.T) {
 	require.Equal(t, 0, len(res.Rules))
 }
 
+func TestReadOnly_WritesRejected_ReadsSucceed(t *testing.T) {
+
+	ctx, srv, _, job, skipRule, _, cleanup := setup(t)
+	defer cleanup()
+	srv.readOnly = true
+	ctx = alogin.FakeStatus(ctx, &editorStatus)
+
+	const expectedErr = "twirp error failed_precondition: Task Scheduler is running in read-only mode."
+	commit := srv.repos[fakeRepo].Get(git.MainBranch).Hash
+	triggerRes, err := srv.TriggerJobs(ctx, &TriggerJobsRequest{
+		Jobs: []*TriggerJob{
+			{
+				JobName:    "job",
+				CommitHash: commit,
+			},
+		},
+	})
+	require.Nil(t, triggerRes)
+	require.EqualError(t, err, expectedErr)
+
+	cancelRes, err := srv.CancelJob(ctx, &CancelJobRequest{Id: job.Id})
+	require.Nil(t, cancelRes)
+	require.EqualError(t, err, expectedErr)
+
+	addRes, err := srv.AddSkipTaskRule(ctx, &AddSkipTaskRuleRequest{
+		Name:             "new-rule",
+		TaskSpecPatterns: []string{"task"},
+	})
+	require.Nil(t, addRes)
+	require.EqualError(t, err, expectedErr)
+
+	deleteRes, err := srv.DeleteSkipTaskRule(ctx, &DeleteSkipTaskRuleRequest{Id: skipRule.Name})
+	require.Nil(t, deleteRes)
+	require.EqualError(t, err, expectedErr)
+	require.Len(t, srv.getSkipTaskRules(), 1)
+
+	// Queries still work, and the job was not modified.
+	getRes, err := srv.GetJob(ctx, &GetJobRequest{Id: job.Id})
+	require.NoError(t, err)
+	require.Equal(t, JobStatus_JOB_STATUS_IN_PROGRESS, getRes.Job.Status)
+}
+
 func TestConvertRepoState(t *testing.T) {
 
 	actual := convertRepoState(types.RepoState{
@@ -594,11 +681,12 @@ func TestConvertJob(t *testing.T) {
 			"taskC": {"taskB", "taskA"},
 			"taskD": {"taskC"},
 		},
-		Finished: time.Unix(1600183000, 0),
-		Id:       "fake-job-id",
-		IsForce:  true,
-		Name:     "My Job",
-		Priority: 0.8,
+		Finished:   time.Unix(1600183000, 0),
+		Id:         "fake-job-id",
+		IsForce:    true,
+		Name:       "My Job",
+		Parameters: map[string]string{"GN_ARGS": "is_debug=true"},
+		Priority:   0.8,
 		RepoState: types.RepoState{
 			Repo:     fakeRepo,
 			Revision: "abc123",
@@ -660,6 +748,7 @@ func TestConvertJob(t *testing.T) {
 		Id:         "fake-job-id",
 		IsForce:    true,
 		Name:       "My Job",
+		Parameters: map[string]string{"GN_ARGS": "is_debug=true"},
 		Priority:   0.8,
 		RepoState: &RepoState{
 			Repo:     fakeRepo,
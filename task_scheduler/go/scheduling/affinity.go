@@ -0,0 +1,212 @@
+package scheduling
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/db/cache"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// Measurement name for the number of tasks which started on a bot that
+	// ran the same TaskSpec within the affinity window, ie. a bot which is
+	// likely to have warm caches.
+	MEASUREMENT_BOT_AFFINITY_HITS = "task_scheduler_bot_affinity_hits"
+
+	// Measurement name for the number of tasks which started on a bot that
+	// did not run the same TaskSpec within the affinity window.
+	MEASUREMENT_BOT_AFFINITY_MISSES = "task_scheduler_bot_affinity_misses"
+)
+
+// BotAffinity configures a preference for task candidates which can run on a
+// free bot that recently ran the same TaskSpec, and is therefore likely to have
+// warm CAS and named caches.
+type BotAffinity struct {
+	// Weight is added to the score multiplier of a candidate for which at
+	// least one matching free bot ran the same TaskSpec within Window, eg. a
+	// Weight of 0.5 multiplies the candidate's score by 1.5. If zero, the
+	// scheduler does not prefer warm bots, but still records the affinity
+	// metrics for comparison.
+	Weight float64
+	// Window is how long after running a TaskSpec a bot is considered to be
+	// warm for that TaskSpec. If zero, bot affinity is disabled entirely.
+	Window time.Duration
+}
+
+// botAffinityTracker tracks which bots recently ran which TaskSpecs.
+type botAffinityTracker struct {
+	cfg BotAffinity
+
+	// lastRun maps TaskSpec name to bot ID to the most recent start time of
+	// a task for that TaskSpec on that bot.
+	lastRun    map[string]map[string]time.Time
+	lastUpdate time.Time
+	mtx        sync.RWMutex
+
+	hits   metrics2.Counter
+	misses metrics2.Counter
+}
+
+// newBotAffinityTracker returns a botAffinityTracker instance, or nil if bot
+// affinity is disabled. All methods are safe to call on a nil tracker.
+func newBotAffinityTracker(cfg BotAffinity) *botAffinityTracker {
+	if cfg.Window <= 0 {
+		return nil
+	}
+	return &botAffinityTracker{
+		cfg:     cfg,
+		lastRun: map[string]map[string]time.Time{},
+		hits:    metrics2.GetCounter(MEASUREMENT_BOT_AFFINITY_HITS),
+		misses:  metrics2.GetCounter(MEASUREMENT_BOT_AFFINITY_MISSES),
+	}
+}
+
+// update loads the tasks created within the affinity window from the cache and
+// records which bots ran them. Tasks which started since the last update are
+// counted as affinity hits or misses.
+func (t *botAffinityTracker) update(ctx context.Context, tCache cache.TaskCache, currentTime time.Time) error {
+	if t == nil {
+		return nil
+	}
+	_, span := trace.StartSpan(ctx, "botAffinityTracker_update")
+	defer span.End()
+	tasks, err := tCache.GetTasksFromDateRange(currentTime.Add(-t.cfg.Window), currentTime)
+	if err != nil {
+		return skerr.Wrapf(err, "failed to retrieve tasks for bot affinity")
+	}
+	t.updateFromTasks(tasks, currentTime)
+	return nil
+}
+
+// updateFromTasks is a helper function used by update.
+func (t *botAffinityTracker) updateFromTasks(tasks []*types.Task, currentTime time.Time) {
+	started := make([]*types.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.SwarmingBotId != "" && !util.TimeIsZero(task.Started) {
+			started = append(started, task)
+		}
+	}
+	sort.Slice(started, func(i, j int) bool {
+		return started[i].Started.Before(started[j].Started)
+	})
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	lastRun := map[string]map[string]time.Time{}
+	var hits, misses int64
+	for _, task := range started {
+		byBot, ok := lastRun[task.Name]
+		if !ok {
+			byBot = map[string]time.Time{}
+			lastRun[task.Name] = byBot
+		}
+		// Don't count the tasks loaded on startup, since we can't
+		// tell which of them are new.
+		if !t.lastUpdate.IsZero() && task.Started.After(t.lastUpdate) {
+			if prev, ok := byBot[task.SwarmingBotId]; ok && task.Started.Sub(prev) <= t.cfg.Window {
+				hits++
+			} else {
+				misses++
+			}
+		}
+		byBot[task.SwarmingBotId] = task.Started
+	}
+	t.hits.Inc(hits)
+	t.misses.Inc(misses)
+	t.lastRun = lastRun
+	t.lastUpdate = currentTime
+}
+
+// isWarm returns true if the given bot ran the given TaskSpec within the
+// affinity window.
+func (t *botAffinityTracker) isWarm(taskSpec, botId string, currentTime time.Time) bool {
+	if t == nil {
+		return false
+	}
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	last, ok := t.lastRun[taskSpec][botId]
+	return ok && currentTime.Sub(last) <= t.cfg.Window
+}
+
+// apply multiplies the scores of the candidates in the queue, which must be
+// sorted in decreasing order by score, for which at least one of the given
+// free bots is warm, and re-sorts the queue. Candidates which were placed in a
+// reserved position by StarvationProtection keep their positions.
+func (t *botAffinityTracker) apply(currentTime time.Time, bots []*types.Machine, queue []*TaskCandidate) []*TaskCandidate {
+	if t == nil || t.cfg.Weight <= 0 {
+		return queue
+	}
+	botsByDim := botsByDimension(bots)
+	boosted := false
+	for _, c := range queue {
+		for botId := range matchingBots(botsByDim, c.TaskSpec.Dimensions) {
+			if t.isWarm(c.Name, botId, currentTime) {
+				c.Score *= 1 + t.cfg.Weight
+				if diag := c.GetDiagnostics(); diag.Scoring != nil {
+					diag.Scoring.BotAffinity = 1 + t.cfg.Weight
+				}
+				boosted = true
+				break
+			}
+		}
+	}
+	if !boosted {
+		return queue
+	}
+
+	// Sort the candidates which are not in reserved positions, and put them
+	// back into the unreserved positions.
+	positions := make([]int, 0, len(queue))
+	unreserved := make([]*TaskCandidate, 0, len(queue))
+	for idx, c := range queue {
+		if c.Diagnostics != nil && c.Diagnostics.Scoring != nil && c.Diagnostics.Scoring.Starved {
+			continue
+		}
+		positions = append(positions, idx)
+		unreserved = append(unreserved, c)
+	}
+	sort.Stable(taskCandidateSlice(unreserved))
+	rv := make([]*TaskCandidate, len(queue))
+	copy(rv, queue)
+	for idx, pos := range positions {
+		rv[pos] = unreserved[idx]
+	}
+	return rv
+}
+
+// botsByDimension returns a mapping of Swarming dimensions to the IDs of the
+// given bots which have those dimensions.
+func botsByDimension(bots []*types.Machine) map[string]util.StringSet {
+	rv := map[string]util.StringSet{}
+	for _, b := range bots {
+		for _, dim := range b.Dimensions {
+			if _, ok := rv[dim]; !ok {
+				rv[dim] = util.StringSet{}
+			}
+			rv[dim][b.ID] = true
+		}
+	}
+	return rv
+}
+
+// matchingBots returns the IDs of the bots which have all of the given
+// dimensions.
+func matchingBots(botsByDim map[string]util.StringSet, dims []string) util.StringSet {
+	matches := util.StringSet{}
+	for i, d := range dims {
+		if i == 0 {
+			matches = matches.Union(botsByDim[d])
+		} else {
+			matches = matches.Intersect(botsByDim[d])
+		}
+	}
+	return matches
+}
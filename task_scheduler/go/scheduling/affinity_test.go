@@ -0,0 +1,120 @@
+package scheduling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+var affinityTestTime = time.Date(2024, time.April, 1, 12, 0, 0, 0, time.UTC)
+
+func affinityTestTask(name, bot string, started time.Time) *types.Task {
+	return &types.Task{
+		TaskKey:       types.TaskKey{Name: name},
+		SwarmingBotId: bot,
+		Started:       started,
+	}
+}
+
+func affinityTestCandidate(name string, score float64, starved bool) *TaskCandidate {
+	return &TaskCandidate{
+		Score: score,
+		TaskKey: types.TaskKey{
+			RepoState: types.RepoState{Repo: "fake.git", Revision: "abc123"},
+			Name:      name,
+		},
+		TaskSpec: &specs.TaskSpec{Dimensions: []string{"pool:Skia"}},
+		Diagnostics: &taskCandidateDiagnostics{
+			Scoring: &taskCandidateScoringDiagnostics{Starved: starved},
+		},
+	}
+}
+
+func TestNewBotAffinityTracker_Disabled(t *testing.T) {
+	tr := newBotAffinityTracker(BotAffinity{Weight: 1})
+	require.Nil(t, tr)
+	require.False(t, tr.isWarm("Build", "bot1", affinityTestTime))
+	queue := []*TaskCandidate{affinityTestCandidate("Build", 1, false)}
+	require.Equal(t, queue, tr.apply(affinityTestTime, nil, queue))
+}
+
+func TestBotAffinityTracker_UpdateFromTasks(t *testing.T) {
+	tr := newBotAffinityTracker(BotAffinity{Window: time.Hour})
+	hits := tr.hits.Get()
+	misses := tr.misses.Get()
+
+	// Tasks loaded on startup are not counted.
+	tr.updateFromTasks([]*types.Task{
+		affinityTestTask("Build", "bot1", affinityTestTime.Add(-30*time.Minute)),
+		affinityTestTask("Test", "bot2", affinityTestTime.Add(-2*time.Hour)),
+		affinityTestTask("Test", "", affinityTestTime.Add(-time.Minute)),
+		affinityTestTask("Perf", "bot3", time.Time{}),
+	}, affinityTestTime)
+	require.Equal(t, hits, tr.hits.Get())
+	require.Equal(t, misses, tr.misses.Get())
+	require.True(t, tr.isWarm("Build", "bot1", affinityTestTime))
+	require.False(t, tr.isWarm("Build", "bot2", affinityTestTime))
+	require.False(t, tr.isWarm("Test", "bot2", affinityTestTime))
+	require.False(t, tr.isWarm("Perf", "bot3", affinityTestTime))
+	require.False(t, tr.isWarm("Build", "bot1", affinityTestTime.Add(time.Hour)))
+
+	// New tasks are counted as hits if the bot ran the same TaskSpec within
+	// the window.
+	next := affinityTestTime.Add(time.Minute)
+	tr.updateFromTasks([]*types.Task{
+		affinityTestTask("Build", "bot1", affinityTestTime.Add(-30*time.Minute)),
+		affinityTestTask("Build", "bot1", affinityTestTime.Add(30*time.Second)),
+		affinityTestTask("Build", "bot2", affinityTestTime.Add(30*time.Second)),
+		affinityTestTask("Test", "bot2", affinityTestTime.Add(-2*time.Hour)),
+		affinityTestTask("Test", "bot2", affinityTestTime.Add(10*time.Second)),
+	}, next)
+	require.Equal(t, hits+1, tr.hits.Get())
+	require.Equal(t, misses+2, tr.misses.Get())
+	require.True(t, tr.isWarm("Build", "bot2", next))
+	require.True(t, tr.isWarm("Test", "bot2", next))
+}
+
+func TestBotAffinityTracker_Apply(t *testing.T) {
+	tr := newBotAffinityTracker(BotAffinity{Weight: 1, Window: time.Hour})
+	tr.updateFromTasks([]*types.Task{
+		affinityTestTask("Warm", "bot1", affinityTestTime.Add(-time.Minute)),
+	}, affinityTestTime)
+	bots := []*types.Machine{{ID: "bot1", Dimensions: []string{"pool:Skia"}}}
+
+	a := affinityTestCandidate("A", 3, false)
+	starved := affinityTestCandidate("Starved", 0.5, true)
+	b := affinityTestCandidate("B", 2.5, false)
+	warm := affinityTestCandidate("Warm", 2, false)
+	rv := tr.apply(affinityTestTime, bots, []*TaskCandidate{a, starved, b, warm})
+	require.Equal(t, []*TaskCandidate{warm, starved, a, b}, rv)
+	require.Equal(t, 4.0, warm.Score)
+	require.Equal(t, 2.0, warm.Diagnostics.Scoring.BotAffinity)
+	require.Zero(t, a.Diagnostics.Scoring.BotAffinity)
+
+	// No change if the warm bot is not free.
+	warm2 := affinityTestCandidate("Warm", 2, false)
+	queue := []*TaskCandidate{a, warm2}
+	require.Equal(t, queue, tr.apply(affinityTestTime, nil, queue))
+	require.Equal(t, 2.0, warm2.Score)
+}
+
+func TestGetCandidatesToSchedule_PrefersWarmBots(t *testing.T) {
+	tr := newBotAffinityTracker(BotAffinity{Window: time.Hour})
+	tr.updateFromTasks([]*types.Task{
+		affinityTestTask("Warm", "bot2", affinityTestTime.Add(-time.Minute)),
+	}, affinityTestTime)
+	bots := []*types.Machine{
+		{ID: "bot1", Dimensions: []string{"pool:Skia"}},
+		{ID: "bot2", Dimensions: []string{"pool:Skia"}},
+	}
+	warm := affinityTestCandidate("Warm", 2, false)
+	cold := affinityTestCandidate("Cold", 1, false)
+	rv := getCandidatesToSchedule(context.Background(), bots, []*TaskCandidate{warm, cold}, tr, affinityTestTime)
+	require.Equal(t, []*TaskCandidate{warm, cold}, rv)
+	require.True(t, warm.Diagnostics.Scheduling.WarmBot)
+	require.False(t, cold.Diagnostics.Scheduling.WarmBot)
+}
@@ -0,0 +1,281 @@
+package scheduling
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// CAPACITY_LOOKBACK is how far back we look at tasks to determine which
+	// bots exist for each dimension set and how long each TaskSpec takes.
+	CAPACITY_LOOKBACK = 24 * time.Hour
+
+	// CAPACITY_DEFAULT_TASK_DURATION is the duration assumed for TaskSpecs
+	// which have not finished any tasks within CAPACITY_LOOKBACK.
+	CAPACITY_DEFAULT_TASK_DURATION = 10 * time.Minute
+)
+
+// CapacityChange is a hypothetical change in the number of bots which have
+// a given set of dimensions.
+type CapacityChange struct {
+	// Dimensions are the dimensions of the bots, in the same "key:value"
+	// format as TaskSpec.Dimensions. These must match the dimensions of the
+	// affected TaskSpecs exactly.
+	Dimensions []string `json:"dimensions"`
+	// Delta is the number of bots to add, or remove if negative.
+	Delta int `json:"delta"`
+}
+
+// WaitEstimate describes the expected wait for the candidates of a TaskSpec
+// given a particular number of bots.
+type WaitEstimate struct {
+	NumBots  int           `json:"num_bots"`
+	MeanWait time.Duration `json:"mean_wait_ns"`
+	MaxWait  time.Duration `json:"max_wait_ns"`
+	// Unschedulable is true if there are no bots for the TaskSpec, in which
+	// case the candidates will never run.
+	Unschedulable bool `json:"unschedulable"`
+}
+
+// TaskSpecWaitEstimate compares the expected wait for the candidates of a
+// TaskSpec with the current bots against the expected wait after applying a
+// set of CapacityChanges.
+type TaskSpecWaitEstimate struct {
+	Name             string        `json:"name"`
+	Dimensions       []string      `json:"dimensions"`
+	NumCandidates    int           `json:"num_candidates"`
+	ExpectedDuration time.Duration `json:"expected_duration_ns"`
+	Current          *WaitEstimate `json:"current"`
+	WhatIf           *WaitEstimate `json:"what_if"`
+}
+
+// dimensionsKey returns a key which uniquely identifies the given set of
+// dimensions, regardless of order.
+func dimensionsKey(dims []string) string {
+	sorted := util.CopyStringSlice(dims)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// capacityModel is a simplified model of the bots available to run the
+// candidates in the queue. Each dimension set is treated as an independent
+// pool of bots; bots which could serve multiple dimension sets are counted
+// only for the dimension sets of the tasks they actually ran.
+type capacityModel struct {
+	// bots is the number of bots per dimension set.
+	bots map[string]int
+	// busyUntil is the expected time at which each busy bot finishes its
+	// current task, per dimension set.
+	busyUntil map[string][]time.Time
+	// durations is the expected duration of each TaskSpec.
+	durations map[string]time.Duration
+}
+
+// newCapacityModel derives a capacityModel from the given recent tasks. The
+// dimensions of each TaskSpec are taken from the given queue, since tasks do
+// not record their dimensions; tasks for TaskSpecs which are not in the queue
+// are ignored.
+func newCapacityModel(queue []*TaskCandidate, recentTasks []*types.Task) *capacityModel {
+	dimsByName := map[string]string{}
+	for _, c := range queue {
+		if c.TaskSpec != nil {
+			dimsByName[c.Name] = dimensionsKey(c.TaskSpec.Dimensions)
+		}
+	}
+	botsByDims := map[string]util.StringSet{}
+	totalDuration := map[string]time.Duration{}
+	numFinished := map[string]int{}
+	var running []*types.Task
+	for _, t := range recentTasks {
+		key, ok := dimsByName[t.Name]
+		if !ok || t.SwarmingBotId == "" || t.Started.IsZero() {
+			continue
+		}
+		if _, ok := botsByDims[key]; !ok {
+			botsByDims[key] = util.StringSet{}
+		}
+		botsByDims[key][t.SwarmingBotId] = true
+		if !t.Done() {
+			running = append(running, t)
+		} else if t.Finished.After(t.Started) {
+			totalDuration[t.Name] += t.Finished.Sub(t.Started)
+			numFinished[t.Name]++
+		}
+	}
+	m := &capacityModel{
+		bots:      make(map[string]int, len(botsByDims)),
+		busyUntil: map[string][]time.Time{},
+		durations: make(map[string]time.Duration, len(numFinished)),
+	}
+	for key, bots := range botsByDims {
+		m.bots[key] = len(bots)
+	}
+	for name, n := range numFinished {
+		m.durations[name] = totalDuration[name] / time.Duration(n)
+	}
+	for _, t := range running {
+		key := dimsByName[t.Name]
+		m.busyUntil[key] = append(m.busyUntil[key], t.Started.Add(m.duration(t.Name)))
+	}
+	return m
+}
+
+// duration returns the expected duration of the given TaskSpec.
+func (m *capacityModel) duration(name string) time.Duration {
+	if d, ok := m.durations[name]; ok {
+		return d
+	}
+	return CAPACITY_DEFAULT_TASK_DURATION
+}
+
+// withChanges returns a copy of the bot counts with the given changes applied.
+func (m *capacityModel) withChanges(changes []*CapacityChange) (map[string]int, error) {
+	rv := make(map[string]int, len(m.bots))
+	for key, n := range m.bots {
+		rv[key] = n
+	}
+	for _, c := range changes {
+		if len(c.Dimensions) == 0 {
+			return nil, skerr.Fmt("capacity change has no dimensions")
+		}
+		key := dimensionsKey(c.Dimensions)
+		rv[key] += c.Delta
+		if rv[key] < 0 {
+			rv[key] = 0
+		}
+	}
+	return rv, nil
+}
+
+// simulate runs the queue against the given bot counts, assigning each
+// candidate in order to the matching bot which becomes free the soonest, and
+// returns the resulting WaitEstimate for each TaskSpec. This ignores
+// candidates which will be added to the queue in the future and changes in
+// candidate scores over time, so the results are a lower bound on the actual
+// wait times.
+func (m *capacityModel) simulate(currentTime time.Time, queue []*TaskCandidate, bots map[string]int) map[string]*WaitEstimate {
+	// freeAt holds the time, relative to currentTime, at which each bot
+	// becomes free, per dimension set.
+	freeAt := map[string][]time.Duration{}
+	getFreeAt := func(key string) []time.Duration {
+		if f, ok := freeAt[key]; ok {
+			return f
+		}
+		f := make([]time.Duration, bots[key])
+		busy := make([]time.Duration, 0, len(m.busyUntil[key]))
+		for _, ts := range m.busyUntil[key] {
+			if d := ts.Sub(currentTime); d > 0 {
+				busy = append(busy, d)
+			}
+		}
+		// If we have fewer bots than busy bots, assume that the tasks which
+		// finish soonest are the ones running on the remaining bots.
+		sort.Slice(busy, func(i, j int) bool { return busy[i] < busy[j] })
+		copy(f, busy)
+		freeAt[key] = f
+		return f
+	}
+
+	rv := map[string]*WaitEstimate{}
+	totalWait := map[string]time.Duration{}
+	numCandidates := map[string]int{}
+	for _, c := range queue {
+		if c.TaskSpec == nil {
+			continue
+		}
+		key := dimensionsKey(c.TaskSpec.Dimensions)
+		est, ok := rv[c.Name]
+		if !ok {
+			est = &WaitEstimate{NumBots: bots[key]}
+			rv[c.Name] = est
+		}
+		f := getFreeAt(key)
+		if len(f) == 0 {
+			est.Unschedulable = true
+			continue
+		}
+		idx := 0
+		for i, d := range f {
+			if d < f[idx] {
+				idx = i
+			}
+		}
+		wait := f[idx]
+		f[idx] += m.duration(c.Name)
+		totalWait[c.Name] += wait
+		numCandidates[c.Name]++
+		if wait > est.MaxWait {
+			est.MaxWait = wait
+		}
+	}
+	for name, est := range rv {
+		if n := numCandidates[name]; n > 0 {
+			est.MeanWait = totalWait[name] / time.Duration(n)
+		}
+	}
+	return rv
+}
+
+// estimateWaitTimes simulates the given queue with the current bots and with
+// the given changes applied, and returns the expected wait times for each
+// TaskSpec in the queue, sorted by name.
+func estimateWaitTimes(currentTime time.Time, queue []*TaskCandidate, recentTasks []*types.Task, changes []*CapacityChange) ([]*TaskSpecWaitEstimate, error) {
+	m := newCapacityModel(queue, recentTasks)
+	whatIfBots, err := m.withChanges(changes)
+	if err != nil {
+		return nil, err
+	}
+	current := m.simulate(currentTime, queue, m.bots)
+	whatIf := m.simulate(currentTime, queue, whatIfBots)
+
+	byName := map[string]*TaskSpecWaitEstimate{}
+	for _, c := range queue {
+		if c.TaskSpec == nil {
+			continue
+		}
+		est, ok := byName[c.Name]
+		if !ok {
+			est = &TaskSpecWaitEstimate{
+				Name:             c.Name,
+				Dimensions:       util.CopyStringSlice(c.TaskSpec.Dimensions),
+				ExpectedDuration: m.duration(c.Name),
+				Current:          current[c.Name],
+				WhatIf:           whatIf[c.Name],
+			}
+			byName[c.Name] = est
+		}
+		est.NumCandidates++
+	}
+	rv := make([]*TaskSpecWaitEstimate, 0, len(byName))
+	for _, est := range byName {
+		rv = append(rv, est)
+	}
+	sort.Slice(rv, func(i, j int) bool { return rv[i].Name < rv[j].Name })
+	return rv, nil
+}
+
+// EstimateWaitTimes simulates the current queue against the scheduling
+// algorithm, both with the bots which have recently run tasks and with the
+// given hypothetical changes in the number of bots, and returns the expected
+// wait time for each TaskSpec in the queue. This is intended to help decide
+// where to add or remove capacity.
+func (s *TaskScheduler) EstimateWaitTimes(ctx context.Context, changes []*CapacityChange) ([]*TaskSpecWaitEstimate, error) {
+	ctx, span := trace.StartSpan(ctx, "EstimateWaitTimes")
+	defer span.End()
+
+	currentTime := now.Now(ctx)
+	queue := s.CloneQueue()
+	recentTasks, err := s.tCache.GetTasksFromDateRange(currentTime.Add(-CAPACITY_LOOKBACK), currentTime)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to retrieve recent tasks")
+	}
+	return estimateWaitTimes(currentTime, queue, recentTasks, changes)
+}
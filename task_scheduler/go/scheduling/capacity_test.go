@@ -0,0 +1,140 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+var capacityTestTime = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func capacityTestCandidate(name, revision string, dims ...string) *TaskCandidate {
+	return &TaskCandidate{
+		TaskKey: types.TaskKey{
+			RepoState: types.RepoState{Repo: "fake.git", Revision: revision},
+			Name:      name,
+		},
+		TaskSpec: &specs.TaskSpec{Dimensions: dims},
+	}
+}
+
+func capacityTestTask(name, bot string, started, finished time.Time) *types.Task {
+	status := types.TASK_STATUS_SUCCESS
+	if finished.IsZero() {
+		status = types.TASK_STATUS_RUNNING
+	}
+	return &types.Task{
+		TaskKey:       types.TaskKey{Name: name},
+		Started:       started,
+		Finished:      finished,
+		Status:        status,
+		SwarmingBotId: bot,
+	}
+}
+
+func TestEstimateWaitTimes(t *testing.T) {
+	linux := []string{"pool:Skia", "os:Linux"}
+	mac := []string{"os:Mac", "pool:Skia"}
+	queue := []*TaskCandidate{
+		capacityTestCandidate("Build-Linux", "c", linux...),
+		capacityTestCandidate("Build-Linux", "b", linux...),
+		capacityTestCandidate("Build-Linux", "a", linux...),
+		capacityTestCandidate("Build-Mac", "a", mac...),
+		capacityTestCandidate("Build-Win", "a", "os:Windows", "pool:Skia"),
+	}
+	ago := func(d time.Duration) time.Time {
+		return capacityTestTime.Add(-d)
+	}
+	recentTasks := []*types.Task{
+		// Two Linux bots; Build-Linux takes 20 minutes.
+		capacityTestTask("Build-Linux", "linux1", ago(3*time.Hour), ago(170*time.Minute)),
+		capacityTestTask("Build-Linux", "linux2", ago(2*time.Hour), ago(90*time.Minute)),
+		// One Mac bot, which is busy for another 15 minutes.
+		capacityTestTask("Build-Mac", "mac1", ago(5*time.Hour), ago(270*time.Minute)),
+		capacityTestTask("Build-Mac", "mac1", ago(15*time.Minute), time.Time{}),
+		// Tasks for TaskSpecs which aren't in the queue are ignored.
+		capacityTestTask("Build-Android", "android1", ago(time.Hour), ago(time.Minute)),
+	}
+	changes := []*CapacityChange{
+		{Dimensions: []string{"os:Linux", "pool:Skia"}, Delta: 1},
+		{Dimensions: []string{"os:Windows", "pool:Skia"}, Delta: 2},
+	}
+
+	est, err := estimateWaitTimes(capacityTestTime, queue, recentTasks, changes)
+	require.NoError(t, err)
+	require.Equal(t, []*TaskSpecWaitEstimate{
+		{
+			Name:             "Build-Linux",
+			Dimensions:       linux,
+			NumCandidates:    3,
+			ExpectedDuration: 20 * time.Minute,
+			// Two candidates start now, the third after 20 minutes.
+			Current: &WaitEstimate{
+				NumBots:  2,
+				MeanWait: 20 * time.Minute / 3,
+				MaxWait:  20 * time.Minute,
+			},
+			// All three candidates start now.
+			WhatIf: &WaitEstimate{
+				NumBots: 3,
+			},
+		},
+		{
+			Name:             "Build-Mac",
+			Dimensions:       mac,
+			NumCandidates:    1,
+			ExpectedDuration: 30 * time.Minute,
+			Current: &WaitEstimate{
+				NumBots:  1,
+				MeanWait: 15 * time.Minute,
+				MaxWait:  15 * time.Minute,
+			},
+			WhatIf: &WaitEstimate{
+				NumBots:  1,
+				MeanWait: 15 * time.Minute,
+				MaxWait:  15 * time.Minute,
+			},
+		},
+		// We have no finished Build-Win tasks, so we assume the default
+		// duration.
+		{
+			Name:             "Build-Win",
+			Dimensions:       []string{"os:Windows", "pool:Skia"},
+			NumCandidates:    1,
+			ExpectedDuration: CAPACITY_DEFAULT_TASK_DURATION,
+			Current: &WaitEstimate{
+				Unschedulable: true,
+			},
+			WhatIf: &WaitEstimate{
+				NumBots: 2,
+			},
+		},
+	}, est)
+}
+
+func TestEstimateWaitTimes_RemoveBots(t *testing.T) {
+	linux := []string{"os:Linux", "pool:Skia"}
+	queue := []*TaskCandidate{
+		capacityTestCandidate("Build-Linux", "b", linux...),
+		capacityTestCandidate("Build-Linux", "a", linux...),
+	}
+	recentTasks := []*types.Task{
+		capacityTestTask("Build-Linux", "linux1", capacityTestTime.Add(-time.Hour), capacityTestTime.Add(-30*time.Minute)),
+		capacityTestTask("Build-Linux", "linux2", capacityTestTime.Add(-time.Hour), capacityTestTime.Add(-30*time.Minute)),
+	}
+	est, err := estimateWaitTimes(capacityTestTime, queue, recentTasks, []*CapacityChange{
+		{Dimensions: linux, Delta: -5},
+	})
+	require.NoError(t, err)
+	require.Len(t, est, 1)
+	require.Equal(t, &WaitEstimate{NumBots: 2}, est[0].Current)
+	require.Equal(t, &WaitEstimate{Unschedulable: true}, est[0].WhatIf)
+}
+
+func TestEstimateWaitTimes_NoDimensions_ReturnsError(t *testing.T) {
+	_, err := estimateWaitTimes(capacityTestTime, nil, nil, []*CapacityChange{{Delta: 1}})
+	require.Error(t, err)
+}
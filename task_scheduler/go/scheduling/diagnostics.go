@@ -0,0 +1,355 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"go.skia.org/infra/go/gcs"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// MAIN_LOOP_DIAGNOSTICS_LOOKBACK is how far back from the requested time
+	// we search for main loop diagnostics.
+	MAIN_LOOP_DIAGNOSTICS_LOOKBACK = 10 * time.Minute
+
+	// Format of the names of main loop diagnostics files, minus the extension.
+	// See writeMainLoopDiagnosticsToGCS.
+	mainLoopDiagnosticsFilenameFormat = "20060102T150405.000000000Z"
+	// Prefix of mainLoopDiagnosticsFilenameFormat which matches all files
+	// written during a given minute.
+	mainLoopDiagnosticsMinuteFormat = "20060102T1504"
+
+	// Maximum number of candidates to follow in the chain of
+	// LastSimilarCandidate.
+	maxBeatenBy = 100
+)
+
+var (
+	// ErrNoDiagnostics is returned by ExplainCandidate when no main loop
+	// diagnostics exist for the requested time.
+	ErrNoDiagnostics = errors.New("No main loop diagnostics found")
+
+	// ErrCandidateNotFound is returned by ExplainCandidate when the requested
+	// candidate was not considered in the main loop.
+	ErrCandidateNotFound = errors.New("Candidate not found in main loop diagnostics")
+)
+
+// ScoreComponent is one of the factors which make up a candidate's score.
+type ScoreComponent struct {
+	Name        string  `json:"name"`
+	Value       float64 `json:"value"`
+	Description string  `json:"description"`
+}
+
+// CandidateSummary briefly describes a candidate which competed with the
+// candidate being explained.
+type CandidateSummary struct {
+	types.TaskKey
+	Score    float64 `json:"score"`
+	Selected bool    `json:"selected"`
+	Starved  bool    `json:"starved"`
+}
+
+// CandidateExplanation describes how a candidate was scored and why it was or
+// was not selected during one run of the scheduling loop.
+type CandidateExplanation struct {
+	// DiagnosticsPath is the GCS path of the main loop diagnostics.
+	DiagnosticsPath string `json:"diagnosticsPath"`
+	// StartTime and EndTime are the times at which the scheduling loop began
+	// and ended.
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	// Candidate is the candidate being explained, including its diagnostics.
+	Candidate *TaskCandidate `json:"candidate"`
+	// Rank is the position of the candidate among all scored candidates,
+	// starting at 1 for the highest score. Zero if the candidate was filtered
+	// out before scoring.
+	Rank int `json:"rank"`
+	// NumCandidates is the number of candidates scored during the loop.
+	NumCandidates int `json:"numCandidates"`
+	// ScoreComponents are the factors which were multiplied or added to
+	// obtain the candidate's score.
+	ScoreComponents []*ScoreComponent `json:"scoreComponents"`
+	// Outcome is a human-readable explanation of what happened to the
+	// candidate.
+	Outcome string `json:"outcome"`
+	// BeatenBy contains the higher-scoring candidates which could have used
+	// the bots this candidate needed, obtained by following the chain of
+	// LastSimilarCandidate. This may not be complete.
+	BeatenBy []*CandidateSummary `json:"beatenBy,omitempty"`
+}
+
+// FindMainLoopDiagnostics returns the GCS path of the most recent main loop
+// diagnostics which were written at or before the given time.
+func FindMainLoopDiagnostics(ctx context.Context, client gcs.GCSClient, diagInstance string, at time.Time) (string, error) {
+	at = at.UTC()
+	latest := path.Join(diagInstance, GCS_MAIN_LOOP_DIAGNOSTICS_DIR, at.Format(mainLoopDiagnosticsFilenameFormat)+".json")
+	for minute := at.Truncate(time.Minute); !minute.Before(at.Add(-MAIN_LOOP_DIAGNOSTICS_LOOKBACK)); minute = minute.Add(-time.Minute) {
+		prefix := path.Join(diagInstance, GCS_MAIN_LOOP_DIAGNOSTICS_DIR, minute.Format(mainLoopDiagnosticsMinuteFormat))
+		found := ""
+		if err := client.AllFilesInDirectory(ctx, prefix, func(item *storage.ObjectAttrs) error {
+			// File names sort chronologically.
+			if item.Name <= latest && item.Name > found {
+				found = item.Name
+			}
+			return nil
+		}); err != nil {
+			return "", skerr.Wrapf(err, "failed to list main loop diagnostics")
+		}
+		if found != "" {
+			return found, nil
+		}
+	}
+	return "", ErrNoDiagnostics
+}
+
+// readMainLoopDiagnostics reads the main loop diagnostics at the given GCS
+// path.
+func readMainLoopDiagnostics(ctx context.Context, client gcs.GCSClient, diagPath string) (*taskSchedulerMainLoopDiagnostics, error) {
+	r, err := client.FileReader(ctx, diagPath)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to read %s", diagPath)
+	}
+	defer func() {
+		if err := r.Close(); err != nil {
+			sklog.Errorf("Failed to close %s: %s", diagPath, err)
+		}
+	}()
+	var rv taskSchedulerMainLoopDiagnostics
+	if err := json.NewDecoder(r).Decode(&rv); err != nil {
+		return nil, skerr.Wrapf(err, "failed to decode %s", diagPath)
+	}
+	return &rv, nil
+}
+
+// ExplainCandidate reads the most recent main loop diagnostics written at or
+// before the given time and explains how the candidate with the given TaskKey
+// was scored and why it was or was not selected.
+func ExplainCandidate(ctx context.Context, client gcs.GCSClient, diagInstance string, at time.Time, key types.TaskKey) (*CandidateExplanation, error) {
+	diagPath, err := FindMainLoopDiagnostics(ctx, client, diagInstance, at)
+	if err != nil {
+		return nil, err
+	}
+	diag, err := readMainLoopDiagnostics(ctx, client, diagPath)
+	if err != nil {
+		return nil, err
+	}
+	rv, err := explainCandidate(diag, key)
+	if err != nil {
+		return nil, err
+	}
+	rv.DiagnosticsPath = diagPath
+	return rv, nil
+}
+
+// explainCandidate explains how the candidate with the given TaskKey was
+// scored and why it was or was not selected, using the given diagnostics.
+func explainCandidate(diag *taskSchedulerMainLoopDiagnostics, key types.TaskKey) (*CandidateExplanation, error) {
+	byKey := make(map[types.TaskKey]*TaskCandidate, len(diag.Candidates))
+	rv := &CandidateExplanation{
+		StartTime: diag.StartTime,
+		EndTime:   diag.EndTime,
+	}
+	// Candidates are written in order of decreasing score.
+	for _, c := range diag.Candidates {
+		byKey[c.TaskKey] = c
+		if c.Diagnostics != nil && c.Diagnostics.Scoring != nil {
+			rv.NumCandidates++
+			if c.TaskKey == key {
+				rv.Rank = rv.NumCandidates
+			}
+		}
+	}
+	c, ok := byKey[key]
+	if !ok {
+		return nil, ErrCandidateNotFound
+	}
+	rv.Candidate = c
+	rv.ScoreComponents = scoreComponents(c)
+	rv.Outcome = candidateOutcome(c)
+
+	// Follow the chain of similar candidates with higher scores.
+	seen := map[types.TaskKey]bool{key: true}
+	for next := c; len(rv.BeatenBy) < maxBeatenBy; {
+		if next.Diagnostics == nil || next.Diagnostics.Scheduling == nil || next.Diagnostics.Scheduling.LastSimilarCandidate == nil {
+			break
+		}
+		nextKey := *next.Diagnostics.Scheduling.LastSimilarCandidate
+		if seen[nextKey] {
+			break
+		}
+		seen[nextKey] = true
+		next, ok = byKey[nextKey]
+		if !ok {
+			break
+		}
+		summary := &CandidateSummary{
+			TaskKey: next.TaskKey,
+			Score:   next.Score,
+		}
+		if d := next.Diagnostics; d != nil {
+			summary.Starved = d.Scoring != nil && d.Scoring.Starved
+			summary.Selected = d.Scheduling != nil && d.Scheduling.Selected
+		}
+		rv.BeatenBy = append(rv.BeatenBy, summary)
+	}
+	return rv, nil
+}
+
+// scoreComponents returns the factors which make up the candidate's score. See
+// TaskScheduler.scoreCandidate.
+func scoreComponents(c *TaskCandidate) []*ScoreComponent {
+	if c.Diagnostics == nil || c.Diagnostics.Scoring == nil {
+		return nil
+	}
+	diag := c.Diagnostics.Scoring
+	var rv []*ScoreComponent
+	add := func(name string, value float64, description string) {
+		rv = append(rv, &ScoreComponent{
+			Name:        name,
+			Value:       value,
+			Description: description,
+		})
+	}
+	if c.IsTryJob() || c.IsForceRun() {
+		if c.IsTryJob() {
+			add("Try job base score", CANDIDATE_SCORE_TRY_JOB, "Try jobs are scored above regular jobs.")
+		} else {
+			add("Forced job base score", CANDIDATE_SCORE_FORCE_RUN, "Forced jobs are scored above all other jobs.")
+		}
+		add("Job age (hours)", diag.JobCreatedHours, "Added to the base score so that older jobs run first.")
+		if c.IsTryJob() {
+			add("Retry multiplier", math.Pow(CANDIDATE_SCORE_TRY_JOB_RETRY_MULTIPLIER, float64(c.Attempt)), fmt.Sprintf("Each retry is scored lower than the previous attempt; this is attempt %d.", c.Attempt))
+		}
+	} else {
+		add("Blamelist size", float64(len(c.Commits)), "Number of commits which would be tested by this task.")
+		add("Stolen commits", float64(diag.StoleFromCommits), "Number of those commits which were previously in the blamelist of another task, which would be split.")
+		add("Testedness increase", diag.TestednessIncrease, "Base score, derived from the blamelist size and the stolen commits.")
+		if diag.FailureOrMishapBonus != 0 {
+			add("Failure or mishap bonus", diag.FailureOrMishapBonus, "Added to the base score when retrying or bisecting a failed task.")
+		}
+		add("Time decay", diag.TimeDecay, "Multiplier which favors newer commits.")
+	}
+	add("Job priority", diag.Priority, "Multiplier derived from the priorities of all Jobs which need this task.")
+	add("Task spec priority", diag.TaskSpecPriority, "Multiplier from the TaskSpec's priority or a server-side override.")
+	if diag.BotAffinity != 0 {
+		add("Bot affinity", diag.BotAffinity, "Multiplier applied because a free bot recently ran the same TaskSpec and likely has warm caches.")
+	}
+	return rv
+}
+
+// candidateOutcome returns a human-readable explanation of what happened to the
+// candidate.
+func candidateOutcome(c *TaskCandidate) string {
+	diag := c.Diagnostics
+	if diag == nil {
+		return "No diagnostics were recorded for this candidate."
+	}
+	if f := diag.Filtering; f != nil {
+		switch {
+		case f.SkippedByRule != "":
+			return fmt.Sprintf("Not scored: skipped by rule %q.", f.SkippedByRule)
+		case f.RevisionTooOld:
+			return "Not scored: the revision is outside of the scheduling window."
+		case f.SupersededByTask != "":
+			return fmt.Sprintf("Not scored: superseded by task %s.", f.SupersededByTask)
+		case len(f.PreviousAttempts) > 0:
+			return fmt.Sprintf("Not scored: no more attempts are allowed after %s.", strings.Join(f.PreviousAttempts, ", "))
+		case f.RetryBackoffUntil != nil:
+			return fmt.Sprintf("Not scored: may not be retried until %s.", f.RetryBackoffUntil.UTC().Format(time.RFC3339))
+		case len(f.UnmetDependencies) > 0:
+			return fmt.Sprintf("Not scored: waiting for dependencies %s.", strings.Join(f.UnmetDependencies, ", "))
+		case f.ForbiddenPool != "":
+			return fmt.Sprintf("Not scored: not allowed to run in pool %q.", f.ForbiddenPool)
+		case f.SuspendedByLoadShedding:
+			return "Not scored: all of its jobs are suspended due to load shedding."
+		}
+		return "Not scored: filtered out."
+	}
+	s := diag.Scheduling
+	if s == nil {
+		return "Scored but not considered for scheduling."
+	}
+	starved := ""
+	if diag.Scoring != nil && diag.Scoring.Starved {
+		starved = " It waited longer than the starvation threshold and was moved ahead in the queue."
+	}
+	switch {
+	case s.OverSchedulingLimitPerTaskSpec:
+		return "Not selected: too many tasks with this name were already selected." + starved
+	case s.ScoreBelowThreshold:
+		return "Not selected: the score is below the minimum threshold." + starved
+	case s.Selected:
+		if t := diag.Triggering; t != nil && (t.CasError != "" || t.TriggerError != "") {
+			return fmt.Sprintf("Selected, but failed to trigger: %s", t.CasError+t.TriggerError)
+		} else if t != nil && t.TaskId != "" {
+			return fmt.Sprintf("Selected and triggered as task %s.", t.TaskId) + starved
+		}
+		return "Selected." + starved
+	case s.NoBotsAvailable:
+		return fmt.Sprintf("Not selected: no bots with matching dimensions were free. %d candidates with higher scores have the same dimensions.", s.NumHigherScoreSimilarCandidates) + starved
+	}
+	return fmt.Sprintf("Not selected: %d candidates with higher scores used all %d matching free bots.", s.NumHigherScoreSimilarCandidates, len(s.MatchingBots)) + starved
+}
+
+// DiagnosticsHandler returns an http.HandlerFunc which responds with the
+// CandidateExplanation for the candidate identified by the request's query
+// parameters, which are the JSON names of the fields of types.TaskKey, plus an
+// optional "time" in RFC3339 format which defaults to the current time.
+func DiagnosticsHandler(client gcs.GCSClient, diagInstance string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		key := types.TaskKey{
+			RepoState: types.RepoState{
+				Patch: types.Patch{
+					Issue:     q.Get("issue"),
+					PatchRepo: q.Get("patch_repo"),
+					Patchset:  q.Get("patchset"),
+					Server:    q.Get("server"),
+				},
+				Repo:     q.Get("repo"),
+				Revision: q.Get("revision"),
+			},
+			Name:        q.Get("name"),
+			ForcedJobId: q.Get("forcedJobId"),
+		}
+		if !key.Valid() {
+			httputils.ReportError(w, nil, "Repo, revision, and name are required.", http.StatusBadRequest)
+			return
+		}
+		at := now.Now(r.Context())
+		if ts := q.Get("time"); ts != "" {
+			var err error
+			at, err = time.Parse(time.RFC3339, ts)
+			if err != nil {
+				httputils.ReportError(w, err, "Invalid time.", http.StatusBadRequest)
+				return
+			}
+		}
+		rv, err := ExplainCandidate(r.Context(), client, diagInstance, at, key)
+		if err == ErrNoDiagnostics || err == ErrCandidateNotFound {
+			httputils.ReportError(w, err, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			httputils.ReportError(w, err, "Failed to read diagnostics.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(rv); err != nil {
+			sklog.Errorf("Failed to write response: %s", err)
+		}
+	}
+}
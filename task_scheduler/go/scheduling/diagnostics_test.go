@@ -0,0 +1,185 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/gcs/mem_gcsclient"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const diagTestInstance = "fake-instance"
+
+func diagTestCandidate(name string, score float64, diag *taskCandidateDiagnostics) *TaskCandidate {
+	return &TaskCandidate{
+		Commits: []string{"abc123", "def456"},
+		Score:   score,
+		TaskKey: types.TaskKey{
+			RepoState: types.RepoState{
+				Repo:     "fake.git",
+				Revision: "abc123",
+			},
+			Name: name,
+		},
+		Diagnostics: diag,
+	}
+}
+
+// setupDiagnostics writes main loop diagnostics at two different times and
+// returns the client and the start time of the second loop.
+func setupDiagnostics(t *testing.T) (context.Context, *mem_gcsclient.MemoryGCSClient, time.Time) {
+	ctx := context.Background()
+	client := mem_gcsclient.New("fake-bucket")
+	start := time.Date(2023, time.June, 1, 12, 0, 30, 0, time.UTC)
+
+	winner := diagTestCandidate("Winner", 3, &taskCandidateDiagnostics{
+		Scoring:    &taskCandidateScoringDiagnostics{Priority: 0.5, TestednessIncrease: 2, TimeDecay: 1, TaskSpecPriority: 1},
+		Scheduling: &taskCandidateSchedulingDiagnostics{MatchingBots: []string{"bot1"}, Selected: true},
+		Triggering: &taskCandidateTriggeringDiagnostics{TaskId: "fake-task"},
+	})
+	loser := diagTestCandidate("Loser", 1.5, &taskCandidateDiagnostics{
+		Scoring: &taskCandidateScoringDiagnostics{
+			Priority:             0.5,
+			StoleFromCommits:     3,
+			TestednessIncrease:   1.5,
+			FailureOrMishapBonus: CANDIDATE_SCORE_FAILURE_OR_MISHAP_BONUS,
+			TimeDecay:            0.8,
+			TaskSpecPriority:     1,
+		},
+		Scheduling: &taskCandidateSchedulingDiagnostics{
+			MatchingBots:                    []string{"bot1"},
+			NumHigherScoreSimilarCandidates: 1,
+			LastSimilarCandidate:            &winner.TaskKey,
+		},
+	})
+	skipped := diagTestCandidate("Skipped", 0, &taskCandidateDiagnostics{
+		Filtering: &taskCandidateFilteringDiagnostics{SkippedByRule: "fake-rule"},
+	})
+	candidates := map[types.TaskKey]*TaskCandidate{
+		winner.TaskKey:  winner,
+		loser.TaskKey:   loser,
+		skipped.TaskKey: skipped,
+	}
+	require.NoError(t, writeMainLoopDiagnosticsToGCS(ctx, start.Add(-2*time.Minute), start.Add(-time.Minute), client, diagTestInstance, map[types.TaskKey]*TaskCandidate{}, nil, nil))
+	require.NoError(t, writeMainLoopDiagnosticsToGCS(ctx, start, start.Add(time.Second), client, diagTestInstance, candidates, nil, nil))
+	return ctx, client, start
+}
+
+func TestFindMainLoopDiagnostics(t *testing.T) {
+	ctx, client, start := setupDiagnostics(t)
+
+	diagPath, err := FindMainLoopDiagnostics(ctx, client, diagTestInstance, start.Add(5*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, "fake-instance/MainLoop/20230601T120030.000000000Z.json", diagPath)
+
+	diagPath, err = FindMainLoopDiagnostics(ctx, client, diagTestInstance, start)
+	require.NoError(t, err)
+	require.Equal(t, "fake-instance/MainLoop/20230601T120030.000000000Z.json", diagPath)
+
+	diagPath, err = FindMainLoopDiagnostics(ctx, client, diagTestInstance, start.Add(-time.Second))
+	require.NoError(t, err)
+	require.Equal(t, "fake-instance/MainLoop/20230601T115830.000000000Z.json", diagPath)
+
+	_, err = FindMainLoopDiagnostics(ctx, client, diagTestInstance, start.Add(-3*time.Minute))
+	require.Equal(t, ErrNoDiagnostics, err)
+
+	_, err = FindMainLoopDiagnostics(ctx, client, diagTestInstance, start.Add(MAIN_LOOP_DIAGNOSTICS_LOOKBACK+time.Minute))
+	require.Equal(t, ErrNoDiagnostics, err)
+}
+
+func TestExplainCandidate_NotSelected(t *testing.T) {
+	ctx, client, start := setupDiagnostics(t)
+	key := diagTestCandidate("Loser", 0, nil).TaskKey
+
+	rv, err := ExplainCandidate(ctx, client, diagTestInstance, start, key)
+	require.NoError(t, err)
+	require.Equal(t, start, rv.StartTime)
+	require.Equal(t, key, rv.Candidate.TaskKey)
+	require.Equal(t, 2, rv.Rank)
+	require.Equal(t, 2, rv.NumCandidates)
+	require.Equal(t, "Not selected: 1 candidates with higher scores used all 1 matching free bots.", rv.Outcome)
+
+	names := make([]string, 0, len(rv.ScoreComponents))
+	for _, c := range rv.ScoreComponents {
+		names = append(names, c.Name)
+	}
+	require.Equal(t, []string{"Blamelist size", "Stolen commits", "Testedness increase", "Failure or mishap bonus", "Time decay", "Job priority", "Task spec priority"}, names)
+	require.Equal(t, 2.0, rv.ScoreComponents[0].Value)
+	require.Equal(t, 3.0, rv.ScoreComponents[1].Value)
+
+	require.Len(t, rv.BeatenBy, 1)
+	require.Equal(t, "Winner", rv.BeatenBy[0].Name)
+	require.Equal(t, 3.0, rv.BeatenBy[0].Score)
+	require.True(t, rv.BeatenBy[0].Selected)
+}
+
+func TestExplainCandidate_Selected(t *testing.T) {
+	ctx, client, start := setupDiagnostics(t)
+	rv, err := ExplainCandidate(ctx, client, diagTestInstance, start, diagTestCandidate("Winner", 0, nil).TaskKey)
+	require.NoError(t, err)
+	require.Equal(t, 1, rv.Rank)
+	require.Equal(t, "Selected and triggered as task fake-task.", rv.Outcome)
+	require.Empty(t, rv.BeatenBy)
+}
+
+func TestExplainCandidate_Filtered(t *testing.T) {
+	ctx, client, start := setupDiagnostics(t)
+	rv, err := ExplainCandidate(ctx, client, diagTestInstance, start, diagTestCandidate("Skipped", 0, nil).TaskKey)
+	require.NoError(t, err)
+	require.Equal(t, 0, rv.Rank)
+	require.Equal(t, `Not scored: skipped by rule "fake-rule".`, rv.Outcome)
+	require.Empty(t, rv.ScoreComponents)
+}
+
+func TestExplainCandidate_TryJob(t *testing.T) {
+	c := diagTestCandidate("Try", 0, &taskCandidateDiagnostics{
+		Scoring: &taskCandidateScoringDiagnostics{Priority: 0.5, JobCreatedHours: 2, TaskSpecPriority: 1},
+	})
+	c.Issue = "12345"
+	c.Patchset = "1"
+	c.Server = "https://fake-review.googlesource.com"
+	c.Attempt = 1
+	rv, err := explainCandidate(&taskSchedulerMainLoopDiagnostics{Candidates: []*TaskCandidate{c}}, c.TaskKey)
+	require.NoError(t, err)
+	require.Equal(t, "Try job base score", rv.ScoreComponents[0].Name)
+	require.Equal(t, CANDIDATE_SCORE_TRY_JOB, rv.ScoreComponents[0].Value)
+	require.Equal(t, 2.0, rv.ScoreComponents[1].Value)
+	require.Equal(t, CANDIDATE_SCORE_TRY_JOB_RETRY_MULTIPLIER, rv.ScoreComponents[2].Value)
+	require.Equal(t, "Scored but not considered for scheduling.", rv.Outcome)
+}
+
+func TestExplainCandidate_NotFound(t *testing.T) {
+	ctx, client, start := setupDiagnostics(t)
+	_, err := ExplainCandidate(ctx, client, diagTestInstance, start, diagTestCandidate("Missing", 0, nil).TaskKey)
+	require.Equal(t, ErrCandidateNotFound, err)
+}
+
+func TestDiagnosticsHandler(t *testing.T) {
+	_, client, start := setupDiagnostics(t)
+	handler := DiagnosticsHandler(client, diagTestInstance)
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/?repo=fake.git&revision=abc123&name=Loser&time="+start.Format(time.RFC3339), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var rv CandidateExplanation
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&rv))
+	require.Equal(t, "Loser", rv.Candidate.Name)
+	require.Len(t, rv.BeatenBy, 1)
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/?repo=fake.git&revision=abc123&name=Missing&time="+start.Format(time.RFC3339), nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/?repo=fake.git&name=Loser", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/?repo=fake.git&revision=abc123&name=Loser&time=yesterday", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,162 @@
+package scheduling
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.skia.org/infra/go/git/repograph"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/task_scheduler/go/db"
+	"go.skia.org/infra/task_scheduler/go/db/cache"
+	"go.skia.org/infra/task_scheduler/go/types"
+	"go.skia.org/infra/task_scheduler/go/window"
+)
+
+const (
+	// EXTERNAL_DEP_SEARCH_PERIOD is the minimum time between searches of the
+	// DB for a task upon which a Job depends, when the task's revision is
+	// outside of the scheduling window and therefore not in the TaskCache.
+	EXTERNAL_DEP_SEARCH_PERIOD = 5 * time.Minute
+)
+
+// externalDeps determines whether Jobs' ExternalDependencies are met. Tasks
+// which satisfy an ExternalDependency are cached, since a successful task
+// never changes.
+type externalDeps struct {
+	db      db.TaskReader
+	repos   repograph.Map
+	tCache  cache.TaskCache
+	waiting metrics2.Int64Metric
+	window  window.Window
+
+	// lastSearched records when we last searched the DB for each
+	// ExternalDependency which is outside of the scheduling window.
+	lastSearched map[types.TaskKey]time.Time
+	// succeeded contains the successful task for each ExternalDependency which
+	// has been met.
+	succeeded map[types.TaskKey]*types.Task
+	mtx       sync.Mutex
+}
+
+// newExternalDeps returns an externalDeps instance.
+func newExternalDeps(d db.TaskReader, repos repograph.Map, tCache cache.TaskCache, w window.Window) *externalDeps {
+	return &externalDeps{
+		db:           d,
+		repos:        repos,
+		tCache:       tCache,
+		waiting:      metrics2.GetInt64Metric("task_scheduler_jobs_waiting_for_external_deps"),
+		window:       w,
+		lastSearched: map[types.TaskKey]time.Time{},
+		succeeded:    map[types.TaskKey]*types.Task{},
+	}
+}
+
+// met returns true iff a task has succeeded for each of the Job's
+// ExternalDependencies.
+func (e *externalDeps) met(ctx context.Context, j *types.Job) (bool, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	for _, d := range j.ExternalDependencies {
+		key := d.TaskKey()
+		if _, ok := e.succeeded[key]; ok {
+			continue
+		}
+		task, err := e.find(ctx, key)
+		if err != nil {
+			return false, skerr.Wrapf(err, "failed to find external dependency %+v of job %s", key, j.Id)
+		}
+		if task == nil {
+			return false, nil
+		}
+		e.succeeded[key] = task
+		delete(e.lastSearched, key)
+	}
+	return true, nil
+}
+
+// find returns a successful task with the given TaskKey, or nil if there is
+// none. Assumes that the caller holds e.mtx.
+func (e *externalDeps) find(ctx context.Context, key types.TaskKey) (*types.Task, error) {
+	repo, ok := e.repos[key.Repo]
+	if !ok {
+		return nil, skerr.Fmt("unknown repo %s", key.Repo)
+	}
+	commit := repo.Get(key.Revision)
+	if commit == nil {
+		// We may not have synced the commit yet.
+		return nil, nil
+	}
+	if e.window.TestCommit(key.Repo, commit) {
+		tasks, err := e.tCache.GetTasksByKey(key)
+		if err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		return firstSuccess(tasks), nil
+	}
+
+	// The commit is outside of the scheduling window, so we have to search
+	// the DB. Don't do this on every scheduling loop.
+	currentTime := now.Now(ctx)
+	if last, ok := e.lastSearched[key]; ok && currentTime.Sub(last) < EXTERNAL_DEP_SEARCH_PERIOD {
+		return nil, nil
+	}
+	e.lastSearched[key] = currentTime
+	status := types.TASK_STATUS_SUCCESS
+	tasks, err := e.db.SearchTasks(ctx, &db.TaskSearchParams{
+		Name:      &key.Name,
+		Repo:      &key.Repo,
+		Revision:  &key.Revision,
+		Status:    &status,
+		TimeStart: &commit.Timestamp,
+		TimeEnd:   &currentTime,
+	})
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	for _, t := range tasks {
+		// SearchTasks matches try job tasks at the same revision.
+		if !t.IsTryJob() {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+// firstSuccess returns the first of the given tasks which has succeeded, or nil
+// if there is none.
+func firstSuccess(tasks []*types.Task) *types.Task {
+	for _, t := range tasks {
+		if t.Done() && t.Success() {
+			return t
+		}
+	}
+	return nil
+}
+
+// cleanup removes cached data for ExternalDependencies which are no longer
+// used by any of the given unfinished Jobs and updates the metric for the
+// number of Jobs which are waiting.
+func (e *externalDeps) cleanup(unfinishedJobs []*types.Job, waiting int) {
+	used := map[types.TaskKey]bool{}
+	for _, j := range unfinishedJobs {
+		for _, d := range j.ExternalDependencies {
+			used[d.TaskKey()] = true
+		}
+	}
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	for key := range e.succeeded {
+		if !used[key] {
+			delete(e.succeeded, key)
+		}
+	}
+	for key := range e.lastSearched {
+		if !used[key] {
+			delete(e.lastSearched, key)
+		}
+	}
+	e.waiting.Update(int64(waiting))
+}
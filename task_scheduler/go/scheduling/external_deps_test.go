@@ -0,0 +1,123 @@
+package scheduling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/git"
+	"go.skia.org/infra/go/git/repograph"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/vcsinfo"
+	"go.skia.org/infra/task_scheduler/go/db/cache/mocks"
+	"go.skia.org/infra/task_scheduler/go/db/memory"
+	"go.skia.org/infra/task_scheduler/go/types"
+	window_mocks "go.skia.org/infra/task_scheduler/go/window/mocks"
+)
+
+const (
+	externalRepo     = "https://skia.googlesource.com/skia.git"
+	externalRevision = "abc123"
+	externalTask     = "Build-Skia"
+)
+
+func setupExternalDeps(t *testing.T, inWindow bool) (context.Context, *externalDeps, *memory.InMemoryDB, *mocks.TaskCache, *types.Job) {
+	commitTime := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), now.ContextKey, commitTime.Add(time.Hour))
+	ri := repograph.NewMemCacheRepoImpl(map[string]*vcsinfo.LongCommit{
+		externalRevision: {
+			ShortCommit: &vcsinfo.ShortCommit{Hash: externalRevision},
+			Timestamp:   commitTime,
+		},
+	}, []*git.Branch{{Name: git.MainBranch, Head: externalRevision}})
+	repo, err := repograph.NewWithRepoImpl(ctx, ri)
+	require.NoError(t, err)
+
+	w := &window_mocks.Window{}
+	w.On("TestCommit", externalRepo, mock.Anything).Return(inWindow)
+	d := memory.NewInMemoryDB()
+	tCache := &mocks.TaskCache{}
+	t.Cleanup(func() {
+		tCache.AssertExpectations(t)
+	})
+
+	job := &types.Job{
+		Id: "fake-job",
+		ExternalDependencies: []*types.ExternalDependency{
+			{Repo: externalRepo, Task: externalTask, Revision: externalRevision},
+		},
+	}
+	return ctx, newExternalDeps(d, repograph.Map{externalRepo: repo}, tCache, w), d, tCache, job
+}
+
+func makeExternalTask(status types.TaskStatus) *types.Task {
+	return &types.Task{
+		Id: "fake-task",
+		TaskKey: types.TaskKey{
+			RepoState: types.RepoState{
+				Repo:     externalRepo,
+				Revision: externalRevision,
+			},
+			Name: externalTask,
+		},
+		Status: status,
+	}
+}
+
+func TestExternalDeps_InWindow_UsesTaskCache(t *testing.T) {
+	ctx, e, _, tCache, job := setupExternalDeps(t, true)
+	key := job.ExternalDependencies[0].TaskKey()
+
+	tCache.On("GetTasksByKey", key).Return([]*types.Task{makeExternalTask(types.TASK_STATUS_FAILURE)}, nil).Once()
+	ok, err := e.met(ctx, job)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	tCache.On("GetTasksByKey", key).Return([]*types.Task{makeExternalTask(types.TASK_STATUS_FAILURE), makeExternalTask(types.TASK_STATUS_SUCCESS)}, nil).Once()
+	ok, err = e.met(ctx, job)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The successful task is cached.
+	ok, err = e.met(ctx, job)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The cache is cleaned up once no jobs use the dependency.
+	e.cleanup([]*types.Job{job}, 0)
+	require.Len(t, e.succeeded, 1)
+	e.cleanup(nil, 0)
+	require.Empty(t, e.succeeded)
+}
+
+func TestExternalDeps_OutsideWindow_SearchesDB(t *testing.T) {
+	ctx, e, d, _, job := setupExternalDeps(t, false)
+
+	ok, err := e.met(ctx, job)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	task := makeExternalTask(types.TASK_STATUS_SUCCESS)
+	task.Created = now.Now(ctx).Add(-time.Minute)
+	require.NoError(t, d.PutTask(ctx, task))
+
+	// We don't search the DB again until EXTERNAL_DEP_SEARCH_PERIOD has
+	// elapsed.
+	ok, err = e.met(ctx, job)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ctx = context.WithValue(ctx, now.ContextKey, now.Now(ctx).Add(EXTERNAL_DEP_SEARCH_PERIOD))
+	ok, err = e.met(ctx, job)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestExternalDeps_UnknownRepo_ReturnsError(t *testing.T) {
+	ctx, e, _, _, job := setupExternalDeps(t, true)
+	job.ExternalDependencies[0].Repo = "https://fake.googlesource.com/fake.git"
+	_, err := e.met(ctx, job)
+	require.ErrorContains(t, err, "unknown repo")
+}
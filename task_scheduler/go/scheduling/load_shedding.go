@@ -0,0 +1,88 @@
+package scheduling
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/task_scheduler/go/load_shedding"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+// shedLoad determines whether load shedding is active, using the number of
+// filtered candidates as the backlog, and if so, removes the candidates whose
+// Jobs are all in suspended JobClasses. Candidates needed by at least one Job
+// which is not suspended, eg. a tryjob, are kept.
+func (s *TaskScheduler) shedLoad(ctx context.Context, candidates map[string]map[string][]*TaskCandidate) map[string]map[string][]*TaskCandidate {
+	ctx, span := trace.StartSpan(ctx, "shedLoad")
+	defer span.End()
+
+	if s.loadShedding == nil {
+		return candidates
+	}
+	backlog := 0
+	suspendable := map[*TaskCandidate]bool{}
+	triggers := map[types.RepoState]map[string]string{}
+	for _, byRepo := range candidates {
+		for _, bySpec := range byRepo {
+			backlog += len(bySpec)
+			for _, c := range bySpec {
+				if s.allJobsSuspendable(ctx, c, triggers) {
+					suspendable[c] = true
+				}
+			}
+		}
+	}
+	suspended := 0
+	if s.loadShedding.Update(backlog, backlog-len(suspendable)) {
+		for _, byRepo := range candidates {
+			for name, bySpec := range byRepo {
+				kept := make([]*TaskCandidate, 0, len(bySpec))
+				for _, c := range bySpec {
+					if suspendable[c] {
+						c.GetDiagnostics().Filtering = &taskCandidateFilteringDiagnostics{SuspendedByLoadShedding: true}
+						suspended++
+						continue
+					}
+					kept = append(kept, c)
+				}
+				if len(kept) == 0 {
+					delete(byRepo, name)
+				} else {
+					byRepo[name] = kept
+				}
+			}
+		}
+		sklog.Infof("Load shedding is active; suspended %d of %d candidates.", suspended, backlog)
+	}
+	if err := s.loadShedding.Report(ctx, suspended, now.Now(ctx)); err != nil {
+		sklog.Errorf("Failed to report load shedding status: %s", err)
+	}
+	return candidates
+}
+
+// allJobsSuspendable returns true if all of the candidate's Jobs are in
+// JobClasses which are suspended while load shedding is active. The triggers of the JobSpecs at each RepoState are cached in the
+// given map.
+func (s *TaskScheduler) allJobsSuspendable(ctx context.Context, c *TaskCandidate, triggers map[types.RepoState]map[string]string) bool {
+	for _, j := range c.Jobs {
+		byName, ok := triggers[j.RepoState]
+		if !ok {
+			byName = map[string]string{}
+			// If the TasksCfg can't be loaded, the Jobs are treated as
+			// having the default trigger.
+			taskCfg, cachedErr, err := s.taskCfgCache.Get(ctx, j.RepoState)
+			if cachedErr == nil && err == nil {
+				for name, jobSpec := range taskCfg.Jobs {
+					byName[name] = jobSpec.Trigger
+				}
+			}
+			triggers[j.RepoState] = byName
+		}
+		if !s.loadShedding.Suspendable(load_shedding.Classify(j, byName[j.Name])) {
+			return false
+		}
+	}
+	return true
+}
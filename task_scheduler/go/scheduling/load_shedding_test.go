@@ -0,0 +1,123 @@
+package scheduling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_scheduler/go/load_shedding"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	tcc_mocks "go.skia.org/infra/task_scheduler/go/task_cfg_cache/mocks"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+func loadSheddingTestCandidate(name string, jobs ...*types.Job) *TaskCandidate {
+	c := &TaskCandidate{
+		TaskKey: types.TaskKey{
+			RepoState: types.RepoState{Repo: "fake.git", Revision: "abc123"},
+			Name:      name,
+		},
+	}
+	for _, j := range jobs {
+		j.RepoState = c.RepoState
+		c.AddJob(j)
+	}
+	return c
+}
+
+func TestShedLoad(t *testing.T) {
+	shedder, err := load_shedding.NewShedder(nil, load_shedding.Config{
+		SuspendedClasses:  []load_shedding.JobClass{load_shedding.JobClassPeriodic, load_shedding.JobClassCommit},
+		ActivateBacklog:   4,
+		DeactivateBacklog: 1,
+	})
+	require.NoError(t, err)
+	s := &TaskScheduler{
+		loadShedding: shedder,
+		taskCfgCache: tcc_mocks.FixedTasksCfg(&specs.TasksCfg{
+			Jobs: map[string]*specs.JobSpec{
+				"Commit":  {},
+				"Nightly": {Trigger: specs.TRIGGER_NIGHTLY},
+			},
+		}),
+	}
+
+	commit := loadSheddingTestCandidate("Commit", &types.Job{Id: "commit", Name: "Commit"})
+	nightly := loadSheddingTestCandidate("Nightly", &types.Job{Id: "nightly", Name: "Nightly"})
+	forced := loadSheddingTestCandidate("Forced", &types.Job{Id: "forced", Name: "Commit", IsForce: true})
+	shared := loadSheddingTestCandidate("Shared",
+		&types.Job{Id: "shared-commit", Name: "Commit"},
+		&types.Job{Id: "shared-try", Name: "Commit", BuildbucketBuildId: 123},
+	)
+	candidates := func() map[string]map[string][]*TaskCandidate {
+		return map[string]map[string][]*TaskCandidate{
+			"fake.git": {
+				"Commit":  {commit},
+				"Nightly": {nightly},
+				"Forced":  {forced},
+				"Shared":  {shared},
+			},
+		}
+	}
+
+	// The backlog exceeds the threshold, so the commit and nightly
+	// candidates are suspended. The shared candidate is needed by a tryjob.
+	rv := s.shedLoad(context.Background(), candidates())
+	require.Equal(t, map[string]map[string][]*TaskCandidate{
+		"fake.git": {
+			"Forced": {forced},
+			"Shared": {shared},
+		},
+	}, rv)
+	require.True(t, commit.Diagnostics.Filtering.SuspendedByLoadShedding)
+	require.True(t, nightly.Diagnostics.Filtering.SuspendedByLoadShedding)
+	require.Nil(t, forced.Diagnostics)
+
+	// Below the activation threshold, nothing is suspended.
+	s.loadShedding.Update(0, 0)
+	small := map[string]map[string][]*TaskCandidate{
+		"fake.git": {"Commit": {loadSheddingTestCandidate("Commit", &types.Job{Id: "commit", Name: "Commit"})}},
+	}
+	require.Len(t, s.shedLoad(context.Background(), small)["fake.git"], 1)
+}
+
+func TestShedLoad_DeactivatesWhileSuspendedCandidatesRemain(t *testing.T) {
+	shedder, err := load_shedding.NewShedder(nil, load_shedding.Config{
+		SuspendedClasses:  []load_shedding.JobClass{load_shedding.JobClassCommit},
+		ActivateBacklog:   3,
+		DeactivateBacklog: 1,
+	})
+	require.NoError(t, err)
+	s := &TaskScheduler{
+		loadShedding: shedder,
+		taskCfgCache: tcc_mocks.FixedTasksCfg(&specs.TasksCfg{
+			Jobs: map[string]*specs.JobSpec{
+				"Commit": {},
+			},
+		}),
+	}
+
+	commit1 := loadSheddingTestCandidate("Commit1", &types.Job{Id: "commit1", Name: "Commit"})
+	commit2 := loadSheddingTestCandidate("Commit2", &types.Job{Id: "commit2", Name: "Commit"})
+	try1 := loadSheddingTestCandidate("Try1", &types.Job{Id: "try1", Name: "Commit", BuildbucketBuildId: 1})
+	try2 := loadSheddingTestCandidate("Try2", &types.Job{Id: "try2", Name: "Commit", BuildbucketBuildId: 2})
+
+	// The backlog exceeds the threshold, so load shedding activates.
+	rv := s.shedLoad(context.Background(), map[string]map[string][]*TaskCandidate{
+		"fake.git": {"Commit1": {commit1}, "Commit2": {commit2}, "Try1": {try1}, "Try2": {try2}},
+	})
+	require.Equal(t, map[string]map[string][]*TaskCandidate{
+		"fake.git": {"Try1": {try1}, "Try2": {try2}},
+	}, rv)
+
+	// The tryjobs ran but the suspended candidates are still in the
+	// backlog, which remains above the activation threshold. Load shedding
+	// still deactivates because only one candidate isn't suspended.
+	rv = s.shedLoad(context.Background(), map[string]map[string][]*TaskCandidate{
+		"fake.git": {"Commit1": {commit1}, "Commit2": {commit2}, "Try1": {try1}},
+	})
+	require.Equal(t, map[string]map[string][]*TaskCandidate{
+		"fake.git": {"Commit1": {commit1}, "Commit2": {commit2}, "Try1": {try1}},
+	}, rv)
+	require.False(t, s.loadShedding.Suspended(load_shedding.JobClassCommit))
+}
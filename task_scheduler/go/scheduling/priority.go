@@ -0,0 +1,72 @@
+package scheduling
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/task_scheduler/go/specs"
+)
+
+// TaskSpecPriorityOverride overrides the TaskSpec.Priority of all TaskSpecs
+// whose names match TaskSpecRegex.
+type TaskSpecPriorityOverride struct {
+	// TaskSpecRegex is matched against TaskSpec names.
+	TaskSpecRegex string `json:"task_spec_regex"`
+	// Priority is the weight to use instead of TaskSpec.Priority, with
+	// 0 < p <= 1.
+	Priority float64 `json:"priority"`
+
+	regex *regexp.Regexp
+}
+
+// TaskSpecPriorityOverrides is a server-side list of overrides for
+// TaskSpec.Priority, which allows the priority of TaskSpecs to be adjusted
+// without changing tasks.json in every affected repo. The first matching
+// override wins.
+type TaskSpecPriorityOverrides []*TaskSpecPriorityOverride
+
+// ParseTaskSpecPriorityOverrides parses and validates the given JSON-encoded
+// TaskSpecPriorityOverrides.
+func ParseTaskSpecPriorityOverrides(b []byte) (TaskSpecPriorityOverrides, error) {
+	var rv TaskSpecPriorityOverrides
+	if err := json.Unmarshal(b, &rv); err != nil {
+		return nil, skerr.Wrapf(err, "failed to decode priority overrides")
+	}
+	for _, o := range rv {
+		if o.Priority <= 0 || o.Priority > 1 {
+			return nil, skerr.Fmt("priority override for %q must satisfy 0 < p <= 1; got %f", o.TaskSpecRegex, o.Priority)
+		}
+		re, err := regexp.Compile(o.TaskSpecRegex)
+		if err != nil {
+			return nil, skerr.Wrapf(err, "invalid task_spec_regex %q", o.TaskSpecRegex)
+		}
+		o.regex = re
+	}
+	return rv, nil
+}
+
+// ReadTaskSpecPriorityOverrides reads TaskSpecPriorityOverrides from the given
+// JSON file.
+func ReadTaskSpecPriorityOverrides(path string) (TaskSpecPriorityOverrides, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to read priority overrides")
+	}
+	return ParseTaskSpecPriorityOverrides(b)
+}
+
+// taskSpecPriority returns the weight to apply to the scores of candidates for
+// the given TaskSpec, taking any override into account.
+func (o TaskSpecPriorityOverrides) taskSpecPriority(name string, taskSpec *specs.TaskSpec) float64 {
+	for _, override := range o {
+		if override.regex.MatchString(name) {
+			return override.Priority
+		}
+	}
+	if taskSpec != nil && taskSpec.Priority > 0 && taskSpec.Priority <= 1 {
+		return taskSpec.Priority
+	}
+	return specs.DEFAULT_TASK_SPEC_PRIORITY
+}
@@ -0,0 +1,43 @@
+package scheduling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_scheduler/go/specs"
+)
+
+func TestParseTaskSpecPriorityOverrides_Invalid_ReturnsError(t *testing.T) {
+	test := func(name, content, expectErr string) {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseTaskSpecPriorityOverrides([]byte(content))
+			require.ErrorContains(t, err, expectErr)
+		})
+	}
+
+	test("not json", "{", "failed to decode priority overrides")
+	test("bad regex", `[{"task_spec_regex": "(", "priority": 0.5}]`, "invalid task_spec_regex")
+	test("zero priority", `[{"task_spec_regex": "Perf", "priority": 0}]`, "must satisfy 0 < p <= 1")
+	test("priority too high", `[{"task_spec_regex": "Perf", "priority": 2}]`, "must satisfy 0 < p <= 1")
+}
+
+func TestTaskSpecPriority(t *testing.T) {
+	overrides, err := ParseTaskSpecPriorityOverrides([]byte(`[
+		{"task_spec_regex": "^Perf-.*-Release", "priority": 0.2},
+		{"task_spec_regex": "^Perf-", "priority": 0.4}
+	]`))
+	require.NoError(t, err)
+
+	test := func(name string, overrides TaskSpecPriorityOverrides, taskSpecName string, taskSpec *specs.TaskSpec, expect float64) {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, expect, overrides.taskSpecPriority(taskSpecName, taskSpec))
+		})
+	}
+
+	test("no overrides, no TaskSpec", nil, "Test-Foo", nil, specs.DEFAULT_TASK_SPEC_PRIORITY)
+	test("no overrides, unset", nil, "Test-Foo", &specs.TaskSpec{}, specs.DEFAULT_TASK_SPEC_PRIORITY)
+	test("no overrides, TaskSpec priority", nil, "Test-Foo", &specs.TaskSpec{Priority: 0.7}, 0.7)
+	test("no match", overrides, "Test-Foo", &specs.TaskSpec{Priority: 0.7}, 0.7)
+	test("first match wins", overrides, "Perf-Foo-Release", &specs.TaskSpec{Priority: 0.7}, 0.2)
+	test("second match", overrides, "Perf-Foo-Debug", &specs.TaskSpec{Priority: 0.7}, 0.4)
+}
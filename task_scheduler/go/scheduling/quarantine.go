@@ -0,0 +1,205 @@
+package scheduling
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/notifier"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/task_scheduler/go/skip_tasks"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// Measurement name for the number of TaskSpecs which are currently
+	// quarantined.
+	MEASUREMENT_QUARANTINED_TASK_SPECS = "task_scheduler_quarantined_task_specs"
+
+	// Message type used when notifying about quarantined TaskSpecs.
+	MSG_TYPE_TASK_SPEC_QUARANTINED = "task-spec-quarantined"
+
+	// QUARANTINE_RULE_ADDED_BY is the AddedBy field of the skip_tasks rules
+	// created to quarantine TaskSpecs.
+	QUARANTINE_RULE_ADDED_BY = "task-scheduler"
+
+	// quarantineRulePrefix is the prefix of the names of the skip_tasks rules
+	// created to quarantine TaskSpecs.
+	quarantineRulePrefix = "Quarantine "
+
+	// maxQuarantineRuleNameChars is the maximum length of a skip_tasks rule
+	// name.
+	maxQuarantineRuleNameChars = 50
+
+	// quarantineLookback is how far back we look for consecutive mishaps.
+	quarantineLookback = 24 * time.Hour
+)
+
+// Quarantine configures the automatic quarantining of TaskSpecs whose most
+// recent tasks all resulted in mishaps, eg. due to a broken recipe, so that
+// they don't waste bot time until somebody intervenes. A TaskSpec is
+// quarantined by adding a skip_tasks rule for it; deleting the rule lifts the
+// quarantine.
+type Quarantine struct {
+	// MaxConsecutiveMishaps is the number of consecutive mishaps after
+	// which a TaskSpec is quarantined. If zero, quarantining is disabled.
+	MaxConsecutiveMishaps int
+}
+
+// quarantineTracker tracks which TaskSpecs have been quarantined.
+type quarantineTracker struct {
+	cfg Quarantine
+
+	// since maps TaskSpec name to the time it was last quarantined. Tasks
+	// created before then are not counted, so that a TaskSpec isn't
+	// quarantined again immediately after the rule is removed.
+	since   map[string]time.Time
+	started time.Time
+	mtx     sync.Mutex
+
+	quarantined metrics2.Int64Metric
+}
+
+// newQuarantineTracker returns a quarantineTracker instance, or nil if
+// quarantining is disabled.
+func newQuarantineTracker(cfg Quarantine, currentTime time.Time) *quarantineTracker {
+	if cfg.MaxConsecutiveMishaps <= 0 {
+		return nil
+	}
+	return &quarantineTracker{
+		cfg:         cfg,
+		since:       map[string]time.Time{},
+		started:     currentTime,
+		quarantined: metrics2.GetInt64Metric(MEASUREMENT_QUARANTINED_TASK_SPECS),
+	}
+}
+
+// findMishaps returns the most recent consecutive mishaps of each TaskSpec
+// which has reached the configured limit, keyed by TaskSpec name. Tasks created
+// before the tracker was started or before the TaskSpec was last quarantined
+// are ignored.
+func (t *quarantineTracker) findMishaps(tasks []*types.Task) map[string][]*types.Task {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	byName := map[string][]*types.Task{}
+	for _, task := range tasks {
+		if !task.Done() || task.Fake() {
+			continue
+		}
+		since := t.started
+		if q, ok := t.since[task.Name]; ok && q.After(since) {
+			since = q
+		}
+		if task.Created.Before(since) {
+			continue
+		}
+		byName[task.Name] = append(byName[task.Name], task)
+	}
+	rv := map[string][]*types.Task{}
+	for name, tasks := range byName {
+		sort.Slice(tasks, func(i, j int) bool {
+			return tasks[i].Finished.Before(tasks[j].Finished)
+		})
+		var mishaps []*types.Task
+		for i := len(tasks) - 1; i >= 0 && tasks[i].Status == types.TASK_STATUS_MISHAP; i-- {
+			mishaps = append(mishaps, tasks[i])
+		}
+		if len(mishaps) >= t.cfg.MaxConsecutiveMishaps {
+			rv[name] = mishaps
+		}
+	}
+	return rv
+}
+
+// markQuarantined records that the given TaskSpec was quarantined.
+func (t *quarantineTracker) markQuarantined(name string, currentTime time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.since[name] = currentTime
+}
+
+// quarantineRuleName returns the name of the skip_tasks rule used to quarantine
+// the given TaskSpec. Rule names are used as document IDs and have a maximum
+// length, so long names are shortened and disambiguated with a hash.
+func quarantineRuleName(taskSpec string) string {
+	name := quarantineRulePrefix + strings.ReplaceAll(taskSpec, "/", "_")
+	if len(name) <= maxQuarantineRuleNameChars {
+		return name
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(taskSpec)))[:8]
+	return name[:maxQuarantineRuleNameChars-len(hash)-1] + "-" + hash
+}
+
+// quarantineTaskSpecs adds a skip_tasks rule for each TaskSpec which has
+// reached the configured number of consecutive mishaps, and sends a
+// notification for each of them.
+func (s *TaskScheduler) quarantineTaskSpecs(ctx context.Context) error {
+	if s.quarantine == nil {
+		return nil
+	}
+	ctx, span := trace.StartSpan(ctx, "quarantineTaskSpecs")
+	defer span.End()
+	currentTime := now.Now(ctx)
+
+	existing := map[string]bool{}
+	for _, rule := range s.skipTasks.GetRules() {
+		existing[rule.Name] = true
+	}
+
+	tasks, err := s.tCache.GetTasksFromDateRange(currentTime.Add(-quarantineLookback), currentTime)
+	if err != nil {
+		return skerr.Wrapf(err, "failed to retrieve tasks for quarantine")
+	}
+	for name, mishaps := range s.quarantine.findMishaps(tasks) {
+		ruleName := quarantineRuleName(name)
+		if existing[ruleName] {
+			continue
+		}
+		ids := make([]string, 0, len(mishaps))
+		for _, task := range mishaps {
+			ids = append(ids, task.Id)
+		}
+		rule := &skip_tasks.Rule{
+			AddedBy:          QUARANTINE_RULE_ADDED_BY,
+			TaskSpecPatterns: []string{"^" + regexp.QuoteMeta(name) + "$"},
+			Description:      fmt.Sprintf("Quarantined automatically after %d consecutive mishaps: %s. Delete this rule to resume scheduling.", len(mishaps), strings.Join(ids, ", ")),
+			Name:             ruleName,
+		}
+		if err := s.skipTasks.AddRule(ctx, rule, s.repos); err != nil {
+			return skerr.Wrapf(err, "failed to quarantine %s", name)
+		}
+		s.quarantine.markQuarantined(name, currentTime)
+		existing[ruleName] = true
+		sklog.Warningf("Quarantined %s after %d consecutive mishaps: %s", name, len(mishaps), strings.Join(ids, ", "))
+		if s.skipRuleNotifier == nil {
+			continue
+		}
+		msg := &notifier.Message{
+			Subject:  fmt.Sprintf("Task Scheduler quarantined %s", name),
+			Body:     fmt.Sprintf("%s has been quarantined after %d consecutive mishaps and will not be scheduled until the skip rule %q is deleted.\n\nTasks: %s", name, len(mishaps), ruleName, strings.Join(ids, ", ")),
+			Severity: notifier.SEVERITY_WARNING,
+			Type:     MSG_TYPE_TASK_SPEC_QUARANTINED,
+		}
+		if err := s.skipRuleNotifier.Send(ctx, msg); err != nil {
+			sklog.Errorf("Failed to send notification for quarantined %s: %s", name, err)
+		}
+	}
+
+	count := int64(0)
+	for _, rule := range s.skipTasks.GetRules() {
+		if rule.AddedBy == QUARANTINE_RULE_ADDED_BY && strings.HasPrefix(rule.Name, quarantineRulePrefix) {
+			count++
+		}
+	}
+	s.quarantine.quarantined.Update(count)
+	return nil
+}
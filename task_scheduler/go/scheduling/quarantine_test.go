@@ -0,0 +1,77 @@
+package scheduling
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+var quarantineTestTime = time.Date(2024, time.April, 1, 12, 0, 0, 0, time.UTC)
+
+func quarantineTestTask(name string, status types.TaskStatus, created time.Time) *types.Task {
+	return &types.Task{
+		Id:             name + "-" + created.Format(time.RFC3339),
+		TaskKey:        types.TaskKey{Name: name},
+		Created:        created,
+		Finished:       created.Add(10 * time.Minute),
+		Status:         status,
+		SwarmingTaskId: "swarming-id",
+	}
+}
+
+func TestNewQuarantineTracker_Disabled(t *testing.T) {
+	require.Nil(t, newQuarantineTracker(Quarantine{}, quarantineTestTime))
+}
+
+func TestQuarantineTracker_FindMishaps(t *testing.T) {
+	start := quarantineTestTime.Add(-time.Hour)
+	tr := newQuarantineTracker(Quarantine{MaxConsecutiveMishaps: 2}, start)
+	ts := func(d time.Duration) time.Time { return start.Add(d * time.Minute) }
+
+	tasks := []*types.Task{
+		// Two consecutive mishaps, the last of which is listed first.
+		quarantineTestTask("Broken", types.TASK_STATUS_SUCCESS, ts(1)),
+		quarantineTestTask("Broken", types.TASK_STATUS_MISHAP, ts(3)),
+		quarantineTestTask("Broken", types.TASK_STATUS_MISHAP, ts(2)),
+		// Pending tasks are ignored.
+		func() *types.Task {
+			t := quarantineTestTask("Broken", types.TASK_STATUS_PENDING, ts(4))
+			t.Finished = time.Time{}
+			return t
+		}(),
+		// Only one mishap since the last success.
+		quarantineTestTask("Flaky", types.TASK_STATUS_MISHAP, ts(1)),
+		quarantineTestTask("Flaky", types.TASK_STATUS_FAILURE, ts(2)),
+		quarantineTestTask("Flaky", types.TASK_STATUS_MISHAP, ts(3)),
+		// Tasks created before the tracker was started are ignored.
+		quarantineTestTask("Old", types.TASK_STATUS_MISHAP, ts(-2)),
+		quarantineTestTask("Old", types.TASK_STATUS_MISHAP, ts(1)),
+	}
+	mishaps := tr.findMishaps(tasks)
+	require.Len(t, mishaps, 1)
+	require.Equal(t, []*types.Task{tasks[1], tasks[2]}, mishaps["Broken"])
+
+	// After quarantining, earlier mishaps are no longer counted.
+	tr.markQuarantined("Broken", ts(5))
+	require.Empty(t, tr.findMishaps(tasks))
+	tasks = append(tasks,
+		quarantineTestTask("Broken", types.TASK_STATUS_MISHAP, ts(6)),
+		quarantineTestTask("Broken", types.TASK_STATUS_MISHAP, ts(7)),
+	)
+	require.Len(t, tr.findMishaps(tasks)["Broken"], 2)
+}
+
+func TestQuarantineRuleName(t *testing.T) {
+	require.Equal(t, "Quarantine Build-Debian10-Clang", quarantineRuleName("Build-Debian10-Clang"))
+	require.Equal(t, "Quarantine a_b", quarantineRuleName("a/b"))
+
+	long := "Test-Debian10-Clang-GCE-CPU-AVX2-x86_64-Debug-All-ASAN"
+	name := quarantineRuleName(long)
+	require.Len(t, name, maxQuarantineRuleNameChars)
+	require.True(t, strings.HasPrefix(name, quarantineRulePrefix))
+	require.Equal(t, name, quarantineRuleName(long))
+	require.NotEqual(t, name, quarantineRuleName(long+"-Shard_1"))
+}
@@ -0,0 +1,235 @@
+package scheduling
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/swarming"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+const (
+	// Measurement name for the age of the oldest candidate which was not
+	// scheduled, by pool, dimension set, and reason.
+	MEASUREMENT_STARVED_CANDIDATE_AGE = "task_candidate_starvation_age_s"
+
+	// Measurement name for the number of candidates which were not
+	// scheduled, by pool, dimension set, and reason.
+	MEASUREMENT_STARVED_CANDIDATE_COUNT = "task_candidate_starvation_count"
+
+	// Reasons that a candidate was not scheduled, used as the "reason" tag of
+	// the starvation metrics.
+
+	// STARVATION_REASON_NO_BOTS indicates that no free bots matched the
+	// candidate's dimensions, i.e. a capacity problem.
+	STARVATION_REASON_NO_BOTS = "no_bots"
+	// STARVATION_REASON_SKIP_RULE indicates that the candidate matched a
+	// skip_tasks rule.
+	STARVATION_REASON_SKIP_RULE = "skip_rule"
+	// STARVATION_REASON_SCORE indicates that matching bots were free but went
+	// to higher-scoring candidates, or that the candidate's score was below
+	// the scheduling threshold.
+	STARVATION_REASON_SCORE = "score"
+	// STARVATION_REASON_SCHEDULING_LIMIT indicates that too many candidates
+	// for the same TaskSpec were scheduled in this cycle.
+	STARVATION_REASON_SCHEDULING_LIMIT = "scheduling_limit"
+)
+
+// starvationReason returns the reason that the candidate was not scheduled,
+// based on its diagnostics, or the empty string if the candidate was
+// scheduled or was filtered out for a reason which does not indicate
+// starvation, eg. unmet dependencies.
+func starvationReason(c *TaskCandidate) string {
+	if c.Diagnostics == nil {
+		return ""
+	}
+	if f := c.Diagnostics.Filtering; f != nil {
+		if f.SkippedByRule != "" {
+			return STARVATION_REASON_SKIP_RULE
+		}
+		return ""
+	}
+	s := c.Diagnostics.Scheduling
+	if s == nil || s.Selected {
+		return ""
+	}
+	if s.OverSchedulingLimitPerTaskSpec {
+		return STARVATION_REASON_SCHEDULING_LIMIT
+	}
+	if s.NoBotsAvailable {
+		return STARVATION_REASON_NO_BOTS
+	}
+	return STARVATION_REASON_SCORE
+}
+
+// starvationMetrics tracks the metrics for candidates which were not
+// scheduled, so that metrics for pools and dimension sets which are no longer
+// starved can be reset.
+type starvationMetrics struct {
+	mtx    sync.Mutex
+	age    map[string]metrics2.Int64Metric
+	counts map[string]metrics2.Int64Metric
+}
+
+// newStarvationMetrics returns a starvationMetrics instance.
+func newStarvationMetrics() *starvationMetrics {
+	return &starvationMetrics{
+		age:    map[string]metrics2.Int64Metric{},
+		counts: map[string]metrics2.Int64Metric{},
+	}
+}
+
+// starvationStats are the aggregate stats for a single set of metric tags.
+type starvationStats struct {
+	tags   map[string]string
+	oldest time.Time
+	count  int64
+}
+
+// record updates the metrics for the given candidates, which should include
+// all candidates considered in the most recent scheduling cycle, including
+// those that were filtered out. Candidate diagnostics must already be
+// populated by scheduling.
+func (m *starvationMetrics) record(ctx context.Context, candidates map[types.TaskKey]*TaskCandidate) {
+	ctx, span := trace.StartSpan(ctx, "recordStarvationMetrics", trace.WithSampler(trace.ProbabilitySampler(0.01)))
+	defer span.End()
+
+	stats := map[string]*starvationStats{}
+	for _, c := range candidates {
+		reason := starvationReason(c)
+		if reason == "" || len(c.Jobs) == 0 || c.TaskSpec == nil {
+			continue
+		}
+		parsedDims, err := swarming.ParseDimensions(c.TaskSpec.Dimensions)
+		if err != nil {
+			sklog.Errorf("Failed to parse dimensions: %s", err)
+			continue
+		}
+		dims := make(map[string]string, len(parsedDims))
+		for k, v := range parsedDims {
+			// Just take the first value for each dimension.
+			dims[k] = v[0]
+		}
+		tags := flatten(dims)
+		tags["pool"] = dims["pool"]
+		tags["reason"] = reason
+		k, err := util.MD5Sum(tags)
+		if err != nil {
+			sklog.Errorf("Failed to create metrics key: %s", err)
+			continue
+		}
+		st, ok := stats[k]
+		if !ok {
+			st = &starvationStats{tags: tags, oldest: c.Jobs[0].Created}
+			stats[k] = st
+		}
+		// Jobs are sorted by creation time, so the first is the oldest.
+		if c.Jobs[0].Created.Before(st.oldest) {
+			st.oldest = c.Jobs[0].Created
+		}
+		st.count++
+	}
+
+	// Report the data.
+	currentTime := now.Now(ctx)
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for k, st := range stats {
+		age, ok := m.age[k]
+		if !ok {
+			age = metrics2.GetInt64Metric(MEASUREMENT_STARVED_CANDIDATE_AGE, st.tags)
+			m.age[k] = age
+		}
+		age.Update(int64(currentTime.Sub(st.oldest).Seconds()))
+		count, ok := m.counts[k]
+		if !ok {
+			count = metrics2.GetInt64Metric(MEASUREMENT_STARVED_CANDIDATE_COUNT, st.tags)
+			m.counts[k] = count
+		}
+		count.Update(st.count)
+	}
+	for k := range m.age {
+		if _, ok := stats[k]; !ok {
+			m.age[k].Update(0)
+			m.counts[k].Update(0)
+			delete(m.age, k)
+			delete(m.counts, k)
+		}
+	}
+}
+
+// StarvationProtection ensures that candidates which have waited for a long
+// time are eventually scheduled, even when they are continually outscored by
+// other candidates, eg. low-priority TaskSpecs behind a flood of try jobs.
+type StarvationProtection struct {
+	// Threshold is how long a candidate's oldest Job must have been waiting
+	// before the candidate is considered to be starved.
+	Threshold time.Duration
+	// MinShare is the minimum fraction of the queue, with 0 <= s <= 1, which
+	// is reserved for starved candidates. If zero, starvation protection is
+	// disabled.
+	MinShare float64
+}
+
+// apply reorders the given queue, which must be sorted in decreasing order by
+// score, so that at least one of every 1/MinShare candidates is a starved
+// candidate, if there are enough starved candidates. Since bots are matched
+// to candidates in queue order, this gives the starved candidates a chance to
+// take bots from higher-scoring candidates. Starved candidates fill their
+// reserved positions in order of waiting time, oldest first, and otherwise
+// keep their position in the queue.
+func (p StarvationProtection) apply(currentTime time.Time, queue []*TaskCandidate) []*TaskCandidate {
+	if p.MinShare <= 0 {
+		return queue
+	}
+	starved := make([]*TaskCandidate, 0, len(queue))
+	for _, c := range queue {
+		if len(c.Jobs) > 0 && currentTime.Sub(c.Jobs[0].Created) > p.Threshold {
+			starved = append(starved, c)
+		}
+	}
+	if len(starved) == 0 {
+		return queue
+	}
+	// Jobs are sorted by creation time, so the first is the oldest.
+	sort.SliceStable(starved, func(i, j int) bool {
+		return starved[i].Jobs[0].Created.Before(starved[j].Jobs[0].Created)
+	})
+	interval := int(math.Ceil(1 / math.Min(p.MinShare, 1)))
+
+	rv := make([]*TaskCandidate, 0, len(queue))
+	added := make(map[*TaskCandidate]bool, len(queue))
+	nextStarved := 0
+	nextQueued := 0
+	for len(rv) < len(queue) {
+		var c *TaskCandidate
+		if len(rv)%interval == interval-1 {
+			// This position is reserved for the oldest starved candidate
+			// which hasn't already been added.
+			for ; nextStarved < len(starved) && c == nil; nextStarved++ {
+				if !added[starved[nextStarved]] {
+					c = starved[nextStarved]
+					if diag := c.GetDiagnostics(); diag.Scoring != nil {
+						diag.Scoring.Starved = true
+					}
+				}
+			}
+		}
+		for ; nextQueued < len(queue) && c == nil; nextQueued++ {
+			if !added[queue[nextQueued]] {
+				c = queue[nextQueued]
+			}
+		}
+		rv = append(rv, c)
+		added[c] = true
+	}
+	return rv
+}
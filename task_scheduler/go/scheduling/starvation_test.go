@@ -0,0 +1,108 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metrics_testutils "go.skia.org/infra/go/metrics2/testutils"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+var starvationTestTime = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func starvationTestCandidate(name string, created time.Time, diag *taskCandidateDiagnostics, dims ...string) *TaskCandidate {
+	return &TaskCandidate{
+		Jobs: []*types.Job{{Created: created}},
+		TaskKey: types.TaskKey{
+			RepoState: types.RepoState{Repo: "fake.git", Revision: "abc123"},
+			Name:      name,
+		},
+		TaskSpec:    &specs.TaskSpec{Dimensions: dims},
+		Diagnostics: diag,
+	}
+}
+
+func TestStarvationReason(t *testing.T) {
+	test := func(diag *taskCandidateDiagnostics, expect string) {
+		require.Equal(t, expect, starvationReason(&TaskCandidate{Diagnostics: diag}))
+	}
+	test(nil, "")
+	test(&taskCandidateDiagnostics{Filtering: &taskCandidateFilteringDiagnostics{SkippedByRule: "flaky"}}, STARVATION_REASON_SKIP_RULE)
+	test(&taskCandidateDiagnostics{Filtering: &taskCandidateFilteringDiagnostics{UnmetDependencies: []string{"Build"}}}, "")
+	test(&taskCandidateDiagnostics{Scheduling: &taskCandidateSchedulingDiagnostics{Selected: true}}, "")
+	test(&taskCandidateDiagnostics{Scheduling: &taskCandidateSchedulingDiagnostics{NoBotsAvailable: true}}, STARVATION_REASON_NO_BOTS)
+	test(&taskCandidateDiagnostics{Scheduling: &taskCandidateSchedulingDiagnostics{ScoreBelowThreshold: true}}, STARVATION_REASON_SCORE)
+	test(&taskCandidateDiagnostics{Scheduling: &taskCandidateSchedulingDiagnostics{MatchingBots: []string{"bot1"}}}, STARVATION_REASON_SCORE)
+	test(&taskCandidateDiagnostics{Scheduling: &taskCandidateSchedulingDiagnostics{OverSchedulingLimitPerTaskSpec: true}}, STARVATION_REASON_SCHEDULING_LIMIT)
+}
+
+func TestStarvationMetrics_Record(t *testing.T) {
+	ctx := now.TimeTravelingContext(starvationTestTime)
+	noBots := &taskCandidateDiagnostics{Scheduling: &taskCandidateSchedulingDiagnostics{NoBotsAvailable: true}}
+	c1 := starvationTestCandidate("Test-GPU-1", starvationTestTime.Add(-time.Hour), noBots, "pool:SkiaStarvationTest", "gpu:none")
+	c2 := starvationTestCandidate("Test-GPU-2", starvationTestTime.Add(-10*time.Minute), noBots, "pool:SkiaStarvationTest", "gpu:none")
+	c3 := starvationTestCandidate("Test-GPU-3", starvationTestTime.Add(-time.Minute), &taskCandidateDiagnostics{
+		Scheduling: &taskCandidateSchedulingDiagnostics{Selected: true},
+	}, "pool:SkiaStarvationTest", "gpu:none")
+	candidates := map[types.TaskKey]*TaskCandidate{
+		c1.TaskKey: c1,
+		c2.TaskKey: c2,
+		c3.TaskKey: c3,
+	}
+
+	m := newStarvationMetrics()
+	m.record(ctx, candidates)
+	tags := map[string]string{
+		"dimensions": "gpu none pool SkiaStarvationTest",
+		"pool":       "SkiaStarvationTest",
+		"reason":     STARVATION_REASON_NO_BOTS,
+	}
+	require.Equal(t, "3600", metrics_testutils.GetRecordedMetric(t, MEASUREMENT_STARVED_CANDIDATE_AGE, tags))
+	require.Equal(t, "2", metrics_testutils.GetRecordedMetric(t, MEASUREMENT_STARVED_CANDIDATE_COUNT, tags))
+
+	// Once the candidates are no longer starved, the metrics are reset.
+	m.record(ctx, map[types.TaskKey]*TaskCandidate{c3.TaskKey: c3})
+	require.Equal(t, "0", metrics_testutils.GetRecordedMetric(t, MEASUREMENT_STARVED_CANDIDATE_AGE, tags))
+	require.Equal(t, "0", metrics_testutils.GetRecordedMetric(t, MEASUREMENT_STARVED_CANDIDATE_COUNT, tags))
+	require.Empty(t, m.age)
+}
+
+func TestStarvationProtection_Apply(t *testing.T) {
+	old := starvationTestTime.Add(-5 * time.Hour)
+	older := starvationTestTime.Add(-6 * time.Hour)
+	newCandidate := func(name string, created time.Time) *TaskCandidate {
+		return starvationTestCandidate(name, created, &taskCandidateDiagnostics{Scoring: &taskCandidateScoringDiagnostics{}})
+	}
+	try1 := newCandidate("try1", starvationTestTime)
+	try2 := newCandidate("try2", starvationTestTime)
+	try3 := newCandidate("try3", starvationTestTime)
+	try4 := newCandidate("try4", starvationTestTime)
+	starved1 := newCandidate("starved1", old)
+	starved2 := newCandidate("starved2", older)
+	queue := []*TaskCandidate{try1, try2, try3, try4, starved1, starved2}
+
+	test := func(name string, p StarvationProtection, expect ...*TaskCandidate) {
+		t.Run(name, func(t *testing.T) {
+			for _, c := range queue {
+				c.Diagnostics.Scoring.Starved = false
+			}
+			require.Equal(t, expect, p.apply(starvationTestTime, queue))
+		})
+	}
+
+	test("disabled", StarvationProtection{Threshold: time.Hour}, queue...)
+	test("nothing starved", StarvationProtection{Threshold: 24 * time.Hour, MinShare: 0.5}, queue...)
+	test("every third", StarvationProtection{Threshold: time.Hour, MinShare: 0.34},
+		try1, try2, starved2, try3, try4, starved1)
+	test("every other", StarvationProtection{Threshold: time.Hour, MinShare: 0.5},
+		try1, starved2, try2, starved1, try3, try4)
+	test("all", StarvationProtection{Threshold: time.Hour, MinShare: 1},
+		starved2, starved1, try1, try2, try3, try4)
+	test("only the oldest", StarvationProtection{Threshold: 330 * time.Minute, MinShare: 0.5},
+		try1, starved2, try2, try3, try4, starved1)
+	require.True(t, starved2.Diagnostics.Scoring.Starved)
+	require.False(t, starved1.Diagnostics.Scoring.Starved)
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.skia.org/infra/go/cas/rbe"
 	"go.skia.org/infra/go/util"
@@ -193,12 +194,42 @@ func replaceVars(c *TaskCandidate, s, taskId string) string {
 		specs.VARIABLE_TASK_ID:              taskId,
 		specs.VARIABLE_TASK_NAME:            c.Name,
 	}
+	for k, v := range c.getJobParameters() {
+		replacements[specs.VARIABLE_PARAMETER_PREFIX+k] = v
+	}
 	for k, v := range replacements {
 		s = strings.Replace(s, fmt.Sprintf(specs.VARIABLE_SYNTAX, k), v, -1)
 	}
 	return s
 }
 
+// getJobParameters returns the job parameter values to use for the task. If
+// the task is for a forced job, the parameters of that job are used. Otherwise
+// the task may be shared by several jobs, whose parameters have their default
+// values; the first job (in c.Jobs order) to define each parameter wins.
+func (c *TaskCandidate) getJobParameters() map[string]string {
+	if c.ForcedJobId != "" {
+		for _, j := range c.Jobs {
+			if j.Id == c.ForcedJobId {
+				return j.Parameters
+			}
+		}
+		return nil
+	}
+	var rv map[string]string
+	for _, j := range c.Jobs {
+		for k, v := range j.Parameters {
+			if rv == nil {
+				rv = map[string]string{}
+			}
+			if _, ok := rv[k]; !ok {
+				rv[k] = v
+			}
+		}
+	}
+	return rv
+}
+
 // MakeTaskRequest creates a SwarmingRpcsNewTaskRequest object from the taskCandidate.
 func (c *TaskCandidate) MakeTaskRequest(id, casInstance, pubSubTopic string) (*types.TaskRequest, error) {
 	var caches []*types.CacheRequest
@@ -257,9 +288,19 @@ func (c *TaskCandidate) MakeTaskRequest(id, casInstance, pubSubTopic string) (*t
 	return req, nil
 }
 
+// casOutputDigest returns the digest of the CAS outputs of the given Task, which
+// are merged into the inputs of any Tasks which depend on it. Tasks which
+// produced no outputs are treated as having produced an empty directory.
+func casOutputDigest(t *types.Task) string {
+	if t.IsolatedOutput == "" {
+		return rbe.EmptyDigest
+	}
+	return t.IsolatedOutput
+}
+
 // allDepsMet determines whether all dependencies for the given task candidate
 // have been satisfied, and if so, returns a map of whose keys are task IDs and
-// values are their isolated outputs.
+// values are the digests of their CAS outputs.
 func (c *TaskCandidate) allDepsMet(cache cache.TaskCache) (bool, map[string]string, error) {
 	rv := make(map[string]string, len(c.TaskSpec.Dependencies))
 	var missingDeps []string
@@ -273,10 +314,7 @@ func (c *TaskCandidate) allDepsMet(cache cache.TaskCache) (bool, map[string]stri
 		ok := false
 		for _, t := range byKey {
 			if t.Done() && t.Success() {
-				rv[t.Id] = t.IsolatedOutput
-				if t.IsolatedOutput == "" {
-					rv[t.Id] = rbe.EmptyDigest
-				}
+				rv[t.Id] = casOutputDigest(t)
 				ok = true
 				break
 			}
@@ -345,12 +383,18 @@ type taskCandidateFilteringDiagnostics struct {
 	RevisionTooOld bool `json:"revisionTooOld,omitempty"`
 	// TaskId of a pending, running, or completed task with the same TaskKey.
 	SupersededByTask string `json:"supersededByTask,omitempty"`
-	// TaskIds of previous attempts; set when max attempts have been reached.
+	// TaskIds of previous attempts; set when max attempts have been reached or the Jobs' retry
+	// policies don't allow the previous result to be retried.
 	PreviousAttempts []string `json:"previousAttempts,omitempty"`
+	// Time before which the retry of a previous attempt may not be triggered, due to the Jobs'
+	// retry policies.
+	RetryBackoffUntil *time.Time `json:"retryBackoffUntil,omitempty"`
 	// Names of TaskSpec dependencies that have not completed.
 	UnmetDependencies []string `json:"unmetDependencies,omitempty"`
 	// Name of the pool in which this candidate is not allowed to be triggered.
 	ForbiddenPool string `json:"forbiddenPool,omitempty"`
+	// True if all of this candidate's Jobs are suspended due to load shedding.
+	SuspendedByLoadShedding bool `json:"suspendedByLoadShedding,omitempty"`
 }
 
 // taskCandidateScoringDiagnostics contains intermediate results in the calculation of Score. For
@@ -368,8 +412,19 @@ type taskCandidateScoringDiagnostics struct {
 	// Base score. See doc for testednessIncrease in task_scheduler.go. Not set for forced or try
 	// jobs.
 	TestednessIncrease float64 `json:"testednessIncrease,omitempty"`
+	// Bonus added to TestednessIncrease when retrying or backfilling a failure or mishap. Not set
+	// for forced or try jobs.
+	FailureOrMishapBonus float64 `json:"failureOrMishapBonus,omitempty"`
 	// Multiplier to prioritize newer commits. Not set for forced or try jobs.
 	TimeDecay float64 `json:"timeDecay,omitempty"`
+	// Multiplier from the TaskSpec's priority, or from a server-side override.
+	TaskSpecPriority float64 `json:"taskSpecPriority,omitempty"`
+	// True if this candidate waited longer than the starvation threshold and
+	// was placed in a position in the queue reserved for starved candidates.
+	Starved bool `json:"starved,omitempty"`
+	// Multiplier applied because a matching free bot recently ran the same TaskSpec. Not set if no
+	// such bot was free or bot affinity is disabled.
+	BotAffinity float64 `json:"botAffinity,omitempty"`
 }
 
 // taskCandidateSchedulingDiagnostics contains information about matching tasks with bots.
@@ -401,13 +456,17 @@ type taskCandidateSchedulingDiagnostics struct {
 	LastSimilarCandidate *types.TaskKey `json:"lastSimilarCandidate,omitempty"`
 	// True if this candidate has been selected to run.
 	Selected bool `json:"selected,omitempty"`
+	// True if this candidate was matched to a bot which recently ran the same TaskSpec. Note that
+	// Swarming chooses the bot which actually runs the task.
+	WarmBot bool `json:"warmBot,omitempty"`
 }
 
 // taskCandidateTriggeringDiagnostics contains information about triggering a Swarming task for this
 // candidate.
 type taskCandidateTriggeringDiagnostics struct {
-	// Error message from isolating inputs.
-	IsolateError string `json:"isolateError,omitempty"`
+	// Error message from merging CAS inputs. The JSON name predates the move to RBE-CAS and is kept
+	// so that diagnostics already stored in GCS can still be read.
+	CasError string `json:"isolateError,omitempty"`
 	// Error message from triggering the task.
 	TriggerError string `json:"triggerError,omitempty"`
 	// Task Scheduler ID of the triggered task. If an error occurs after assigning an ID, the Task may
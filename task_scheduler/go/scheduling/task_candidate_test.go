@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/cas/rbe"
 	"go.skia.org/infra/go/deepequal/assertdeep"
 	"go.skia.org/infra/task_scheduler/go/specs"
 	"go.skia.org/infra/task_scheduler/go/types"
@@ -119,6 +120,27 @@ func TestReplaceVar(t *testing.T) {
 	require.Equal(t, "refs/changes/45/12345/3", replaceVars(c, "<(PATCH_REF)", dummyId))
 }
 
+func TestReplaceVar_JobParameters(t *testing.T) {
+	c := makeTaskCandidate("c", []string{"k:v"})
+	dummyId := "id123"
+	ts := time.Now()
+	j1 := &types.Job{Id: "j1", Created: ts, Parameters: map[string]string{"GN_ARGS": "is_debug=true"}}
+	j2 := &types.Job{Id: "j2", Created: ts.Add(time.Second), Parameters: map[string]string{"GN_ARGS": "is_debug=false", "CONFIG": "8888"}}
+	c.AddJob(j1)
+	c.AddJob(j2)
+
+	// Unforced tasks use the first value found for each parameter.
+	require.Equal(t, "is_debug=true 8888", replaceVars(c, "<(PARAM_GN_ARGS) <(PARAM_CONFIG)", dummyId))
+	// Unknown parameters are left alone.
+	require.Equal(t, "<(PARAM_BOGUS)", replaceVars(c, "<(PARAM_BOGUS)", dummyId))
+
+	// Forced tasks use the parameters of the forced job only.
+	c.ForcedJobId = j2.Id
+	require.Equal(t, "is_debug=false 8888", replaceVars(c, "<(PARAM_GN_ARGS) <(PARAM_CONFIG)", dummyId))
+	c.ForcedJobId = j1.Id
+	require.Equal(t, "is_debug=true <(PARAM_CONFIG)", replaceVars(c, "<(PARAM_GN_ARGS) <(PARAM_CONFIG)", dummyId))
+}
+
 func TestTaskCandidateJobs(t *testing.T) {
 
 	c := TaskCandidate{}
@@ -195,3 +217,15 @@ func TestTaskCandidateJobs(t *testing.T) {
 		last = j.Created
 	}
 }
+
+func TestCasOutputDigest(t *testing.T) {
+	require.Equal(t, rbe.EmptyDigest, casOutputDigest(&types.Task{}))
+	const digest = "aaaabbbbccccddddaaaabbbbccccddddaaaabbbbccccddddaaaabbbbccccdddd/32"
+	require.Equal(t, digest, casOutputDigest(&types.Task{IsolatedOutput: digest}))
+}
+
+func TestTaskCandidateTriggeringDiagnostics_StoredDiagnostics_CasErrorDecoded(t *testing.T) {
+	var diag taskCandidateTriggeringDiagnostics
+	require.NoError(t, json.Unmarshal([]byte(`{"isolateError":"failed to merge","taskId":"abc"}`), &diag))
+	require.Equal(t, taskCandidateTriggeringDiagnostics{CasError: "failed to merge", TaskId: "abc"}, diag)
+}
@@ -25,6 +25,7 @@ import (
 	"go.skia.org/infra/go/gcs"
 	"go.skia.org/infra/go/git/repograph"
 	"go.skia.org/infra/go/metrics2"
+	"go.skia.org/infra/go/notifier"
 	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/skerr"
 	"go.skia.org/infra/go/sklog"
@@ -32,6 +33,7 @@ import (
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/task_scheduler/go/db"
 	"go.skia.org/infra/task_scheduler/go/db/cache"
+	"go.skia.org/infra/task_scheduler/go/load_shedding"
 	"go.skia.org/infra/task_scheduler/go/skip_tasks"
 	"go.skia.org/infra/task_scheduler/go/specs"
 	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
@@ -68,6 +70,13 @@ const (
 	// Measurement name for task candidate counts by dimension set.
 	MEASUREMENT_TASK_CANDIDATE_COUNT = "task_candidate_count"
 
+	// Measurement name for the number of task candidates matched by each
+	// skip_tasks rule.
+	MEASUREMENT_SKIP_RULE_MATCH_COUNT = "task_scheduler_skip_rule_match_count"
+
+	// Message type used when notifying about expired skip_tasks rules.
+	MSG_TYPE_SKIP_RULE_EXPIRED = "skip-rule-expired"
+
 	NUM_TOP_CANDIDATES = 50
 
 	// To avoid errors resulting from DB transaction size limits, we
@@ -87,30 +96,42 @@ var (
 
 // TaskScheduler is a struct used for scheduling tasks on bots.
 type TaskScheduler struct {
+	botAffinity         *botAffinityTracker
 	busyBots            *busyBots
 	candidateMetrics    map[string]metrics2.Int64Metric
 	candidateMetricsMtx sync.Mutex
 	db                  db.DB
 	diagClient          gcs.GCSClient
 	diagInstance        string
+	externalDeps        *externalDeps
 	rbeCas              cas.CAS
 	rbeCasInstance      string
 	jCache              cache.JobCache
 	lastScheduled       time.Time // protected by queueMtx.
+	loadShedding        *load_shedding.Shedder
 
 	pendingInsert    map[string]bool
 	pendingInsertMtx sync.RWMutex
 
-	pools         []string
-	pubsubCount   metrics2.Counter
-	pubsubTopic   string
-	queue         []*TaskCandidate // protected by queueMtx.
-	queueMtx      sync.RWMutex
-	repos         repograph.Map
-	skipTasks     *skip_tasks.DB
-	taskExecutors map[string]types.TaskExecutor
-	taskCfgCache  task_cfg_cache.TaskCfgCache
-	tCache        cache.TaskCache
+	pools                []string
+	priorityOverrides    TaskSpecPriorityOverrides
+	pubsubCount          metrics2.Counter
+	pubsubTopic          string
+	quarantine           *quarantineTracker
+	queue                []*TaskCandidate // protected by queueMtx.
+	queueMtx             sync.RWMutex
+	repos                repograph.Map
+	retriesCount         metrics2.Counter
+	retriesInBackoff     metrics2.Int64Metric
+	skipTasks            *skip_tasks.DB
+	skipRuleMetrics      map[string]metrics2.Int64Metric
+	skipRuleMetricsMtx   sync.Mutex
+	skipRuleNotifier     *notifier.Router
+	starvation           *starvationMetrics
+	starvationProtection StarvationProtection
+	taskExecutors        map[string]types.TaskExecutor
+	taskCfgCache         task_cfg_cache.TaskCfgCache
+	tCache               cache.TaskCache
 	// testWaitGroup keeps track of any goroutines the TaskScheduler methods
 	// create so that tests can ensure all goroutines finish before asserting.
 	testWaitGroup         sync.WaitGroup
@@ -120,7 +141,7 @@ type TaskScheduler struct {
 	window                window.Window
 }
 
-func NewTaskScheduler(ctx context.Context, d db.DB, bl *skip_tasks.DB, period time.Duration, numCommits int, repos repograph.Map, rbeCas cas.CAS, rbeCasInstance string, taskExecutors map[string]types.TaskExecutor, c *http.Client, timeDecayAmt24Hr float64, pools []string, pubsubTopic string, taskCfgCache task_cfg_cache.TaskCfgCache, ts oauth2.TokenSource, diagClient gcs.GCSClient, diagInstance string, debugBusyBots BusyBotsDebugLog) (*TaskScheduler, error) {
+func NewTaskScheduler(ctx context.Context, d db.DB, bl *skip_tasks.DB, period time.Duration, numCommits int, repos repograph.Map, rbeCas cas.CAS, rbeCasInstance string, taskExecutors map[string]types.TaskExecutor, c *http.Client, timeDecayAmt24Hr float64, pools []string, pubsubTopic string, taskCfgCache task_cfg_cache.TaskCfgCache, ts oauth2.TokenSource, diagClient gcs.GCSClient, diagInstance string, debugBusyBots BusyBotsDebugLog, priorityOverrides TaskSpecPriorityOverrides, starvationProtection StarvationProtection, botAffinity BotAffinity, loadShedding *load_shedding.Shedder, quarantine Quarantine) (*TaskScheduler, error) {
 	// Repos must be updated before window is initialized; otherwise the repos may be uninitialized,
 	// resulting in the window being too short, causing the caches to be loaded with incomplete data.
 	for _, r := range repos {
@@ -146,21 +167,31 @@ func NewTaskScheduler(ctx context.Context, d db.DB, bl *skip_tasks.DB, period ti
 
 	s := &TaskScheduler{
 		skipTasks:             bl,
+		botAffinity:           newBotAffinityTracker(botAffinity),
 		busyBots:              newBusyBots(debugBusyBots),
 		candidateMetrics:      map[string]metrics2.Int64Metric{},
 		db:                    d,
 		diagClient:            diagClient,
 		diagInstance:          diagInstance,
+		externalDeps:          newExternalDeps(d, repos, tCache, w),
 		jCache:                jCache,
+		loadShedding:          loadShedding,
 		pendingInsert:         map[string]bool{},
 		pools:                 pools,
+		priorityOverrides:     priorityOverrides,
 		pubsubCount:           metrics2.GetCounter("task_scheduler_pubsub_handler"),
 		pubsubTopic:           pubsubTopic,
+		quarantine:            newQuarantineTracker(quarantine, now.Now(ctx)),
 		queue:                 []*TaskCandidate{},
 		queueMtx:              sync.RWMutex{},
 		rbeCas:                rbeCas,
 		rbeCasInstance:        rbeCasInstance,
 		repos:                 repos,
+		retriesCount:          metrics2.GetCounter("task_scheduler_retries_triggered_count"),
+		retriesInBackoff:      metrics2.GetInt64Metric("task_scheduler_retries_in_backoff"),
+		skipRuleMetrics:       map[string]metrics2.Int64Metric{},
+		starvation:            newStarvationMetrics(),
+		starvationProtection:  starvationProtection,
 		taskExecutors:         taskExecutors,
 		taskCfgCache:          taskCfgCache,
 		tCache:                tCache,
@@ -528,6 +559,7 @@ func (s *TaskScheduler) findTaskCandidatesForJobs(ctx context.Context, unfinishe
 
 	// Get the repo+commit+taskspecs for each job.
 	candidates := map[types.TaskKey]*TaskCandidate{}
+	waitingForExternalDeps := 0
 	for _, j := range unfinishedJobs {
 		if !s.window.TestTime(j.Repo, j.Created) {
 			continue
@@ -540,6 +572,16 @@ func (s *TaskScheduler) findTaskCandidatesForJobs(ctx context.Context, unfinishe
 			continue
 		}
 
+		// Don't trigger any tasks until the job's dependencies in other
+		// repos have succeeded.
+		if ok, err := s.externalDeps.met(ctx, j); err != nil {
+			sklog.Errorf("Failed to check external dependencies: %s", err)
+			continue
+		} else if !ok {
+			waitingForExternalDeps++
+			continue
+		}
+
 		// Add task candidates for this job.
 		for tsName := range j.Dependencies {
 			key := j.MakeTaskKey(tsName)
@@ -579,7 +621,8 @@ func (s *TaskScheduler) findTaskCandidatesForJobs(ctx context.Context, unfinishe
 			c.AddJob(j)
 		}
 	}
-	sklog.Infof("Found %d task candidates for %d unfinished jobs.", len(candidates), len(unfinishedJobs))
+	s.externalDeps.cleanup(unfinishedJobs, waitingForExternalDeps)
+	sklog.Infof("Found %d task candidates for %d unfinished jobs; %d jobs are waiting for external dependencies.", len(candidates), len(unfinishedJobs), waitingForExternalDeps)
 	return candidates, nil
 }
 
@@ -589,12 +632,14 @@ func (s *TaskScheduler) filterTaskCandidates(ctx context.Context, preFilterCandi
 	ctx, span := trace.StartSpan(ctx, "filterTaskCandidates")
 	defer span.End()
 
+	currentTime := now.Now(ctx)
 	candidatesBySpec := map[string]map[string][]*TaskCandidate{}
 	total := 0
+	inBackoff := 0
 	skipped := map[string]int{}
 	for _, c := range preFilterCandidates {
 		// Reject skipped tasks.
-		if rule := s.skipTasks.MatchRule(c.Name, c.Revision); rule != "" {
+		if rule := s.skipTasks.MatchRule(ctx, c.Name, c.Revision); rule != "" {
 			skipped[rule]++
 			c.GetDiagnostics().Filtering = &taskCandidateFilteringDiagnostics{SkippedByRule: rule}
 			continue
@@ -635,17 +680,8 @@ func (s *TaskScheduler) filterTaskCandidates(ctx context.Context, preFilterCandi
 			// TaskSpec. Fortunately, TaskCache.GetTasksByKey sorts
 			// by creation time, and we've selected the last of the
 			// results.
-			maxAttempts := c.TaskSpec.MaxAttempts
-			if maxAttempts == 0 {
-				maxAttempts = specs.DEFAULT_TASK_SPEC_MAX_ATTEMPTS
-			}
-			// Special case for tasks created before arbitrary
-			// numbers of attempts were possible.
-			previousAttempt := previous.Attempt
-			if previousAttempt == 0 && previous.RetryOf != "" {
-				previousAttempt = 1
-			}
-			if previousAttempt >= maxAttempts-1 {
+			attempt, retryAt, ok := getRetryAttempt(c, previous)
+			if !ok {
 				previousIds := make([]string, 0, len(prevTasks))
 				for _, t := range prevTasks {
 					previousIds = append(previousIds, t.Id)
@@ -653,7 +689,12 @@ func (s *TaskScheduler) filterTaskCandidates(ctx context.Context, preFilterCandi
 				c.GetDiagnostics().Filtering = &taskCandidateFilteringDiagnostics{PreviousAttempts: previousIds}
 				continue
 			}
-			c.Attempt = previousAttempt + 1
+			if currentTime.Before(retryAt) {
+				c.GetDiagnostics().Filtering = &taskCandidateFilteringDiagnostics{RetryBackoffUntil: &retryAt}
+				inBackoff++
+				continue
+			}
+			c.Attempt = attempt
 			c.RetryOf = previous.Id
 		}
 
@@ -690,10 +731,54 @@ func (s *TaskScheduler) filterTaskCandidates(ctx context.Context, preFilterCandi
 		diagLink := fmt.Sprintf("https://console.cloud.google.com/storage/browser/skia-task-scheduler-diagnostics/%s?project=google.com:skia-corp", path.Join(s.diagInstance, GCS_MAIN_LOOP_DIAGNOSTICS_DIR))
 		sklog.Infof("Skipped %d candidates due to skip_tasks rule %q. See details in diagnostics at %s.", numSkipped, rule, diagLink)
 	}
+	s.recordSkipRuleMetrics(skipped)
+	s.retriesInBackoff.Update(int64(inBackoff))
 	sklog.Infof("Filtered to %d candidates in %d spec categories.", total, len(candidatesBySpec))
 	return candidatesBySpec, nil
 }
 
+// getRetryAttempt determines whether the given candidate may retry the given
+// previous, unsuccessful attempt. It returns the number of the new attempt and
+// the earliest time at which it may be triggered, or false if no more attempts
+// may be made. The task is retried if any of the candidate's Jobs would retry
+// it, according to the Job's RetryPolicy or, if it has none, the TaskSpec.
+func getRetryAttempt(c *TaskCandidate, previous *types.Task) (int, time.Time, bool) {
+	maxAttempts := c.TaskSpec.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = specs.DEFAULT_TASK_SPEC_MAX_ATTEMPTS
+	}
+	// Special case for tasks created before arbitrary
+	// numbers of attempts were possible.
+	previousAttempt := previous.Attempt
+	if previousAttempt == 0 && previous.RetryOf != "" {
+		previousAttempt = 1
+	}
+	attempt := previousAttempt + 1
+
+	ok := false
+	var retryAt time.Time
+	allow := func(at time.Time) {
+		if !ok || at.Before(retryAt) {
+			retryAt = at
+		}
+		ok = true
+	}
+	if len(c.Jobs) == 0 && attempt < maxAttempts {
+		allow(time.Time{})
+	}
+	for _, j := range c.Jobs {
+		policy := j.RetryPolicy
+		if policy == nil {
+			if attempt < maxAttempts {
+				allow(time.Time{})
+			}
+		} else if attempt < policy.GetMaxAttempts(maxAttempts) && policy.ShouldRetry(previous.Status) {
+			allow(previous.Finished.Add(policy.GetBackoff(attempt)))
+		}
+	}
+	return attempt, retryAt, ok
+}
+
 // scoreCandidate sets the Score field on the given Task Candidate. Also records
 // diagnostic information on TaskCandidate.Diagnostics.Scoring.
 func (s *TaskScheduler) scoreCandidate(ctx context.Context, c *TaskCandidate, cycleStart, commitTime time.Time, stealingFrom *types.Task) {
@@ -723,6 +808,11 @@ func (s *TaskScheduler) scoreCandidate(ctx context.Context, c *TaskCandidate, cy
 	priority := 1 - inversePriorityProduct
 	diag.Priority = priority
 
+	// Weight the priority by that of the TaskSpec.
+	taskSpecPriority := s.priorityOverrides.taskSpecPriority(c.Name, c.TaskSpec)
+	diag.TaskSpecPriority = taskSpecPriority
+	priority *= taskSpecPriority
+
 	// Use the earliest Job's Created time, which will maximize priority for older forced/try jobs.
 	earliestJob := c.Jobs[0]
 	diag.JobCreatedHours = cycleStart.Sub(earliestJob.Created).Hours()
@@ -759,6 +849,7 @@ func (s *TaskScheduler) scoreCandidate(ctx context.Context, c *TaskCandidate, cy
 	// Add a bonus when retrying or backfilling failures and mishaps.
 	if stoleFromStatus == types.TASK_STATUS_FAILURE || stoleFromStatus == types.TASK_STATUS_MISHAP {
 		score += CANDIDATE_SCORE_FAILURE_OR_MISHAP_BONUS
+		diag.FailureOrMishapBonus = CANDIDATE_SCORE_FAILURE_OR_MISHAP_BONUS
 	}
 
 	// Scale the score by other factors, eg. time decay.
@@ -1023,32 +1114,32 @@ func (s *TaskScheduler) regenerateTaskQueue(ctx context.Context) ([]*TaskCandida
 	// Record the number of task candidates per dimension set.
 	s.recordCandidateMetrics(ctx, candidates)
 
+	// Suspend low-priority Jobs if we're shedding load.
+	candidates = s.shedLoad(ctx, candidates)
+
 	// Process the remaining task candidates.
 	queue, err := s.processTaskCandidates(ctx, candidates)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// Ensure that low-scoring candidates don't starve.
+	queue = s.starvationProtection.apply(now.Now(ctx), queue)
+
 	return queue, preFilterCandidates, nil
 }
 
 // getCandidatesToSchedule matches the list of free Swarming bots to task
 // candidates in the queue and returns the candidates which should be run.
-// Assumes that the tasks are sorted in decreasing order by score.
-func getCandidatesToSchedule(ctx context.Context, bots []*types.Machine, tasks []*TaskCandidate) []*TaskCandidate {
+// Assumes that the tasks are sorted in decreasing order by score. If affinity
+// is provided, candidates are matched to bots which recently ran the same
+// TaskSpec where possible.
+func getCandidatesToSchedule(ctx context.Context, bots []*types.Machine, tasks []*TaskCandidate, affinity *botAffinityTracker, currentTime time.Time) []*TaskCandidate {
 	ctx, span := trace.StartSpan(ctx, "getCandidatesToSchedule")
 	defer span.End()
 
 	// Create a bots-by-swarming-dimension mapping.
-	botsByDim := map[string]util.StringSet{}
-	for _, b := range bots {
-		for _, dim := range b.Dimensions {
-			if _, ok := botsByDim[dim]; !ok {
-				botsByDim[dim] = util.StringSet{}
-			}
-			botsByDim[dim][b.ID] = true
-		}
-	}
+	botsByDim := botsByDimension(bots)
 	// BotIds that have been used by previous candidates.
 	usedBots := util.StringSet{}
 	// Map BotId to the candidates that could have used that bot. In the
@@ -1081,14 +1172,7 @@ func getCandidatesToSchedule(ctx context.Context, bots []*types.Machine, tasks [
 		}
 
 		// For each dimension of the task, find the set of bots which matches.
-		matches := util.StringSet{}
-		for i, d := range c.TaskSpec.Dimensions {
-			if i == 0 {
-				matches = matches.Union(botsByDim[d])
-			} else {
-				matches = matches.Intersect(botsByDim[d])
-			}
-		}
+		matches := matchingBots(botsByDim, c.TaskSpec.Dimensions)
 
 		// Set of candidates that could have used the same bots.
 		similarCandidates := map[*TaskCandidate]struct{}{}
@@ -1107,14 +1191,21 @@ func getCandidatesToSchedule(ctx context.Context, bots []*types.Machine, tasks [
 			botToCandidates[key] = append(candidates, c)
 		}
 
-		// Choose a particular bot to mark as used. Sort by ID so that the choice is deterministic.
+		// Choose a particular bot to mark as used, preferring bots which
+		// recently ran the same TaskSpec. Sort by ID so that the choice is
+		// deterministic.
 		var chosenBot string
+		chosenWarm := false
 		if len(matches) > 0 {
 			diag.MatchingBots = matches.Keys()
 			sort.Strings(diag.MatchingBots)
-			for botId := range matches {
-				if (chosenBot == "" || botId < chosenBot) && !usedBots[botId] {
-					chosenBot = botId
+			for _, botId := range diag.MatchingBots {
+				if !usedBots[botId] {
+					warm := affinity.isWarm(c.Name, botId, currentTime)
+					if chosenBot == "" || (warm && !chosenWarm) {
+						chosenBot = botId
+						chosenWarm = warm
+					}
 				}
 				addCandidates(botId)
 			}
@@ -1134,6 +1225,7 @@ func getCandidatesToSchedule(ctx context.Context, bots []*types.Machine, tasks [
 		if chosenBot != "" {
 			// We're going to run this task.
 			diag.Selected = true
+			diag.WarmBot = chosenWarm
 			usedBots[chosenBot] = true
 
 			// Add the task to the scheduling list.
@@ -1167,7 +1259,7 @@ func (s *TaskScheduler) mergeCASInputs(ctx context.Context, candidates []*TaskCa
 			digest, err := s.rbeCas.Merge(eCtx, c.CasDigests)
 			if err != nil {
 				errStr := err.Error()
-				c.GetDiagnostics().Triggering = &taskCandidateTriggeringDiagnostics{IsolateError: errStr}
+				c.GetDiagnostics().Triggering = &taskCandidateTriggeringDiagnostics{CasError: errStr}
 				return skerr.Wrapf(err, "failed to merge CAS inputs for %s @ %s", c.Name, c.RepoState.String())
 			}
 			c.CasInput = digest
@@ -1272,8 +1364,12 @@ func (s *TaskScheduler) scheduleTasks(ctx context.Context, bots []*types.Machine
 	ctx, span := trace.StartSpan(ctx, "scheduleTasks")
 	defer span.End()
 
+	// Prefer candidates which can run on bots with warm caches.
+	currentTime := now.Now(ctx)
+	queue = s.botAffinity.apply(currentTime, bots, queue)
+
 	// Match free bots with tasks.
-	candidates := getCandidatesToSchedule(ctx, bots, queue)
+	candidates := getCandidatesToSchedule(ctx, bots, queue, s.botAffinity, currentTime)
 
 	// Merge CAS inputs for the tasks.
 	merged, mergeErr := s.mergeCASInputs(ctx, candidates)
@@ -1301,6 +1397,7 @@ func (s *TaskScheduler) scheduleTasks(ctx context.Context, bots []*types.Machine
 
 	// Collect the tasks we triggered.
 	numTriggered := 0
+	numRetries := 0
 	insert := map[string]map[string][]*types.Task{}
 	for t := range triggered {
 		byRepo, ok := insert[t.Repo]
@@ -1310,6 +1407,9 @@ func (s *TaskScheduler) scheduleTasks(ctx context.Context, bots []*types.Machine
 		}
 		byRepo[t.Name] = append(byRepo[t.Name], t)
 		numTriggered++
+		if t.RetryOf != "" {
+			numRetries++
+		}
 	}
 	close(errCh)
 	errWg.Wait()
@@ -1370,6 +1470,7 @@ func (s *TaskScheduler) scheduleTasks(ctx context.Context, bots []*types.Machine
 		sklog.Infof("Triggered no tasks (%d in queue, %d bots available)", len(queue), len(bots))
 	}
 	s.triggeredCount.Inc(int64(numTriggered))
+	s.retriesCount.Inc(int64(numRetries))
 	s.queueMtx.Lock()
 	defer s.queueMtx.Unlock()
 	s.queue = queue
@@ -1425,6 +1526,10 @@ func (s *TaskScheduler) MainLoop(ctx context.Context) error {
 		return skerr.Wrapf(err, "Failed to update job cache")
 	}
 
+	if err := s.botAffinity.update(ctx, s.tCache, now.Now(ctx)); err != nil {
+		return skerr.Wrapf(err, "Failed to update bot affinity")
+	}
+
 	if err := s.updateUnfinishedJobs(ctx); err != nil {
 		return skerr.Wrapf(err, "Failed to update unfinished jobs")
 	}
@@ -1433,6 +1538,15 @@ func (s *TaskScheduler) MainLoop(ctx context.Context) error {
 		return skerr.Wrapf(err, "Failed to update skip_tasks")
 	}
 
+	if err := s.removeExpiredSkipRules(ctx); err != nil {
+		return skerr.Wrapf(err, "Failed to remove expired skip_tasks rules")
+	}
+
+	// Failure to quarantine TaskSpecs shouldn't prevent us from scheduling.
+	if err := s.quarantineTaskSpecs(ctx); err != nil {
+		sklog.Errorf("Failed to quarantine TaskSpecs: %s", err)
+	}
+
 	// Regenerate the queue.
 	sklog.Infof("Task Scheduler regenerating the queue...")
 	queue, allCandidates, err := s.regenerateTaskQueue(ctx)
@@ -1447,6 +1561,10 @@ func (s *TaskScheduler) MainLoop(ctx context.Context) error {
 	sklog.Infof("Task Scheduler scheduling tasks...")
 	err = s.scheduleTasks(ctx, freeMachines, queue)
 
+	// Record metrics for the candidates which were not scheduled, so that we
+	// can distinguish a lack of capacity from scheduler problems.
+	s.starvation.record(ctx, allCandidates)
+
 	// An error from scheduleTasks can indicate a partial error; write diagnostics
 	// in either case.
 	if s.diagClient != nil {
@@ -1516,6 +1634,61 @@ func (s *TaskScheduler) GetSkipTasks() *skip_tasks.DB {
 	return s.skipTasks
 }
 
+// SetSkipRuleNotifier sets the Router used to announce skip_tasks rules which
+// have expired and been removed. If not set, expired rules are removed
+// silently.
+func (s *TaskScheduler) SetSkipRuleNotifier(n *notifier.Router) {
+	s.skipRuleNotifier = n
+}
+
+// removeExpiredSkipRules removes any skip_tasks rules which have expired and
+// sends a notification for each of them.
+func (s *TaskScheduler) removeExpiredSkipRules(ctx context.Context) error {
+	expired, err := s.skipTasks.RemoveExpiredRules(ctx, now.Now(ctx))
+	for _, rule := range expired {
+		sklog.Infof("Removed expired skip_tasks rule %q (expired %s).", rule.Name, rule.Expires)
+		if s.skipRuleNotifier == nil {
+			continue
+		}
+		msg := &notifier.Message{
+			Subject:  fmt.Sprintf("Task Scheduler skip rule %q expired", rule.Name),
+			Body:     fmt.Sprintf("The skip rule %q, added by %s, expired at %s and has been removed. Tasks which it matched will now be scheduled.\n\nDescription: %s", rule.Name, rule.AddedBy, rule.Expires.UTC().Format(time.RFC3339), rule.Description),
+			Severity: notifier.SEVERITY_INFO,
+			Type:     MSG_TYPE_SKIP_RULE_EXPIRED,
+		}
+		if err := s.skipRuleNotifier.Send(ctx, msg); err != nil {
+			sklog.Errorf("Failed to send notification for expired skip_tasks rule %q: %s", rule.Name, err)
+		}
+	}
+	return err
+}
+
+// recordSkipRuleMetrics reports the number of task candidates matched by each
+// skip_tasks rule. Rules which matched no candidates are reported as zero, so
+// that rules which are no longer useful can be found.
+func (s *TaskScheduler) recordSkipRuleMetrics(matches map[string]int) {
+	s.skipRuleMetricsMtx.Lock()
+	defer s.skipRuleMetricsMtx.Unlock()
+	active := map[string]bool{}
+	for _, rule := range s.skipTasks.GetRules() {
+		active[rule.Name] = true
+		metric, ok := s.skipRuleMetrics[rule.Name]
+		if !ok {
+			metric = metrics2.GetInt64Metric(MEASUREMENT_SKIP_RULE_MATCH_COUNT, map[string]string{"rule": rule.Name})
+			s.skipRuleMetrics[rule.Name] = metric
+		}
+		metric.Update(int64(matches[rule.Name]))
+	}
+	for name, metric := range s.skipRuleMetrics {
+		if !active[name] {
+			if err := metric.Delete(); err != nil {
+				sklog.Errorf("Failed to delete metric for skip_tasks rule %q: %s", name, err)
+			}
+			delete(s.skipRuleMetrics, name)
+		}
+	}
+}
+
 // testedness computes the total "testedness" of a set of commits covered by a
 // task whose blamelist included N commits. The "testedness" of a task spec at a
 // given commit is defined as follows:
@@ -1655,16 +1828,19 @@ func (s *TaskScheduler) updateUnfinishedTasks(ctx context.Context) error {
 
 	// Query for all unfinished tasks.
 	sklog.Infof("Querying states of %d unfinished tasks.", len(tasks))
-	unfinishedIDsByExecutor := map[string][]string{}
+	unfinishedByExecutor := map[string][]*types.Task{}
 	for _, t := range tasks {
 		executor := t.TaskExecutor
 		if executor == types.TaskExecutor_UseDefault {
 			executor = types.DefaultTaskExecutor
 		}
-		unfinishedIDsByExecutor[executor] = append(unfinishedIDsByExecutor[executor], t.SwarmingTaskId)
+		unfinishedByExecutor[executor] = append(unfinishedByExecutor[executor], t)
 	}
-	for executorName := range unfinishedIDsByExecutor {
-		ids := unfinishedIDsByExecutor[executorName]
+	for executorName, tasks := range unfinishedByExecutor {
+		ids := make([]string, 0, len(tasks))
+		for _, t := range tasks {
+			ids = append(ids, t.SwarmingTaskId)
+		}
 		taskExecutor, ok := s.taskExecutors[executorName]
 		if !ok {
 			return skerr.Fmt("Tasks use unknown task executor %q: %v", executorName, ids)
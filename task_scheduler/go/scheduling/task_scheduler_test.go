@@ -243,7 +243,7 @@ func insertJobs(t sktest.TestingT, ctx context.Context, s *TaskScheduler, rss ..
 		require.NoError(t, err)
 		require.NoError(t, cachedErr)
 		for name := range cfg.Jobs {
-			j, err := task_cfg_cache.MakeJob(ctx, s.taskCfgCache, rs, name)
+			j, err := task_cfg_cache.MakeJob(ctx, s.taskCfgCache, rs, name, nil)
 			require.NoError(t, err)
 			jobs = append(jobs, j)
 		}
@@ -290,7 +290,7 @@ func setup(t *testing.T) (context.Context, *mem_git.MemGit, *memory.InMemoryDB,
 		types.TaskExecutor_Swarming:   taskExec,
 		types.TaskExecutor_UseDefault: taskExec,
 	}
-	s, err := NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, repos, cas, "fake-cas-instance", taskExecs, urlMock.Client(), 1.0, swarming.POOLS_PUBLIC, "", taskCfgCache, nil, mem_gcsclient.New("diag_unit_tests"), btInstance, false)
+	s, err := NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, repos, cas, "fake-cas-instance", taskExecs, urlMock.Client(), 1.0, swarming.POOLS_PUBLIC, "", taskCfgCache, nil, mem_gcsclient.New("diag_unit_tests"), btInstance, false, nil, StarvationProtection{}, BotAffinity{}, nil, Quarantine{})
 	require.NoError(t, err)
 
 	// Insert jobs. This is normally done by the JobCreator.
@@ -1757,7 +1757,7 @@ func makeSwarmingBot(id string, dims []string) *types.Machine {
 func TestGetCandidatesToSchedule(t *testing.T) {
 	ctx := context.Background()
 	// Empty lists.
-	rv := getCandidatesToSchedule(ctx, []*types.Machine{}, []*TaskCandidate{})
+	rv := getCandidatesToSchedule(ctx, []*types.Machine{}, []*TaskCandidate{}, nil, time.Time{})
 	require.Empty(t, rv)
 
 	// checkDiags takes a list of bots with the same dimensions and a list of
@@ -1795,29 +1795,29 @@ func TestGetCandidatesToSchedule(t *testing.T) {
 	}
 
 	t1 := makeTaskCandidate("task1", []string{"k:v"})
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{}, []*TaskCandidate{t1})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{}, []*TaskCandidate{t1}, nil, time.Time{})
 	require.Empty(t, rv)
 	checkDiags([]*types.Machine{}, []*TaskCandidate{t1})
 
 	b1 := makeSwarmingBot("bot1", []string{"k:v"})
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{}, nil, time.Time{})
 	require.Empty(t, rv)
 
 	// Single match.
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t1})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t1}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t1}, rv)
 	checkDiags([]*types.Machine{b1}, []*TaskCandidate{t1})
 
 	// No match.
 	t1.TaskSpec.Dimensions[0] = "k:v2"
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t1})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t1}, nil, time.Time{})
 	require.Empty(t, rv)
 	checkDiags([]*types.Machine{}, []*TaskCandidate{t1})
 
 	// Add a task candidate to match b1.
 	t1 = makeTaskCandidate("task1", []string{"k:v2"})
 	t2 := makeTaskCandidate("task2", []string{"k:v"})
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t1, t2})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t1, t2}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t2}, rv)
 	checkDiags([]*types.Machine{}, []*TaskCandidate{t1})
 	checkDiags([]*types.Machine{b1}, []*TaskCandidate{t2})
@@ -1825,7 +1825,7 @@ func TestGetCandidatesToSchedule(t *testing.T) {
 	// Switch the task order.
 	t1 = makeTaskCandidate("task1", []string{"k:v2"})
 	t2 = makeTaskCandidate("task2", []string{"k:v"})
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t2, t1})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t2, t1}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t2}, rv)
 	checkDiags([]*types.Machine{}, []*TaskCandidate{t1})
 	checkDiags([]*types.Machine{b1}, []*TaskCandidate{t2})
@@ -1833,10 +1833,10 @@ func TestGetCandidatesToSchedule(t *testing.T) {
 	// Make both tasks match the bot, ensure that we pick the first one.
 	t1 = makeTaskCandidate("task1", []string{"k:v"})
 	t2 = makeTaskCandidate("task2", []string{"k:v"})
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t1, t2})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t1, t2}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t1}, rv)
 	checkDiags([]*types.Machine{b1}, []*TaskCandidate{t1, t2})
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t2, t1})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1}, []*TaskCandidate{t2, t1}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t2}, rv)
 	checkDiags([]*types.Machine{b1}, []*TaskCandidate{t2, t1})
 
@@ -1852,7 +1852,7 @@ func TestGetCandidatesToSchedule(t *testing.T) {
 	// is first in sorted order. The second task does not get scheduled
 	// because there is no bot available which can run it.
 	// TODO(borenet): Use a more optimal solution to avoid this case.
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1, b2}, []*TaskCandidate{t1, t2})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1, b2}, []*TaskCandidate{t1, t2}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t1}, rv)
 	// Can't use checkDiags for these cases.
 	require.Equal(t, []string{b1.ID, b2.ID}, t1.Diagnostics.Scheduling.MatchingBots)
@@ -1868,7 +1868,7 @@ func TestGetCandidatesToSchedule(t *testing.T) {
 
 	t1 = makeTaskCandidate("task1", []string{"k:v"})
 	t2 = makeTaskCandidate("task2", dims)
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b2, b1}, []*TaskCandidate{t1, t2})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b2, b1}, []*TaskCandidate{t1, t2}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t1}, rv)
 	require.Equal(t, []string{b1.ID, b2.ID}, t1.Diagnostics.Scheduling.MatchingBots)
 	require.Equal(t, 0, t1.Diagnostics.Scheduling.NumHigherScoreSimilarCandidates)
@@ -1885,7 +1885,7 @@ func TestGetCandidatesToSchedule(t *testing.T) {
 	// priority. Both tasks get scheduled.
 	t1 = makeTaskCandidate("task1", []string{"k:v"})
 	t2 = makeTaskCandidate("task2", dims)
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1, b2}, []*TaskCandidate{t2, t1})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1, b2}, []*TaskCandidate{t2, t1}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t2, t1}, rv)
 	require.Equal(t, []string{b1.ID, b2.ID}, t1.Diagnostics.Scheduling.MatchingBots)
 	require.Equal(t, 1, t1.Diagnostics.Scheduling.NumHigherScoreSimilarCandidates)
@@ -1900,7 +1900,7 @@ func TestGetCandidatesToSchedule(t *testing.T) {
 
 	t1 = makeTaskCandidate("task1", []string{"k:v"})
 	t2 = makeTaskCandidate("task2", dims)
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b2, b1}, []*TaskCandidate{t2, t1})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b2, b1}, []*TaskCandidate{t2, t1}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t2, t1}, rv)
 	require.Equal(t, []string{b1.ID, b2.ID}, t1.Diagnostics.Scheduling.MatchingBots)
 	require.Equal(t, 1, t1.Diagnostics.Scheduling.NumHigherScoreSimilarCandidates)
@@ -1919,7 +1919,7 @@ func TestGetCandidatesToSchedule(t *testing.T) {
 	t1 = makeTaskCandidate("task1", dims)
 	t2 = makeTaskCandidate("task2", dims)
 	t3 := makeTaskCandidate("task3", dims)
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1, b2, b3}, []*TaskCandidate{t1, t2})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1, b2, b3}, []*TaskCandidate{t1, t2}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t1, t2}, rv)
 	checkDiags([]*types.Machine{b1, b2, b3}, []*TaskCandidate{t1, t2})
 
@@ -1927,7 +1927,7 @@ func TestGetCandidatesToSchedule(t *testing.T) {
 	t1 = makeTaskCandidate("task1", dims)
 	t2 = makeTaskCandidate("task2", dims)
 	t3 = makeTaskCandidate("task3", dims)
-	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1, b2}, []*TaskCandidate{t1, t2, t3})
+	rv = getCandidatesToSchedule(ctx, []*types.Machine{b1, b2}, []*TaskCandidate{t1, t2, t3}, nil, time.Time{})
 	assertdeep.Equal(t, []*TaskCandidate{t1, t2}, rv)
 	checkDiags([]*types.Machine{b1, b2}, []*TaskCandidate{t1, t2, t3})
 }
@@ -2355,7 +2355,7 @@ func testMultipleCandidatesBackfillingEachOtherSetup(t *testing.T) (context.Cont
 		types.TaskExecutor_Swarming:   taskExec,
 		types.TaskExecutor_UseDefault: taskExec,
 	}
-	s, err := NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, repos, cas, "fake-cas-instance", taskExecs, mockhttpclient.NewURLMock().Client(), 1.0, swarming.POOLS_PUBLIC, "", taskCfgCache, nil, mem_gcsclient.New("diag_unit_tests"), btInstance, BusyBotsDebugLoggingOff)
+	s, err := NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, repos, cas, "fake-cas-instance", taskExecs, mockhttpclient.NewURLMock().Client(), 1.0, swarming.POOLS_PUBLIC, "", taskCfgCache, nil, mem_gcsclient.New("diag_unit_tests"), btInstance, BusyBotsDebugLoggingOff, nil, StarvationProtection{}, BotAffinity{}, nil, Quarantine{})
 	require.NoError(t, err)
 
 	for _, h := range hashes {
@@ -4036,6 +4036,74 @@ func TestScoreCandidate_ForcedJob_OldestJobOnlyUsedForWaitTime(t *testing.T) {
 	test("two jobs, only one waited 2 hours", 76.5, "2021-10-01T13:00:00Z", "2021-10-01T14:55:00Z")
 }
 
+func TestScoreCandidate_TryJob_TaskSpecPriorityImpactsScore(t *testing.T) {
+	ctx := context.Background()
+
+	overrides, err := ParseTaskSpecPriorityOverrides([]byte(`[{"task_spec_regex": "^Perf-", "priority": 0.1}]`))
+	require.NoError(t, err)
+	test := func(name, taskSpecName string, taskSpecPriority, expectedScore float64) {
+		t.Run(name, func(t *testing.T) {
+			s := TaskScheduler{priorityOverrides: overrides}
+			ts := rfc3339(t, "2021-10-01T15:00:00Z") // fixed time indicating no waiting
+			tc := asTryJob(TaskCandidate{
+				Jobs: []*types.Job{{
+					Created:  ts,
+					Priority: specs.DEFAULT_JOB_SPEC_PRIORITY,
+				}},
+				TaskKey:  types.TaskKey{Name: taskSpecName},
+				TaskSpec: &specs.TaskSpec{Priority: taskSpecPriority},
+			})
+			s.scoreCandidate(ctx, &tc, ts, timeDoesNotMatter, nil)
+			assert.InDelta(t, expectedScore, tc.Score, 0.0001)
+		})
+	}
+
+	test("unset priority results in default", "Test-Foo", 0, 5)
+	test("lower priority", "Test-Foo", 0.5, 2.5)
+	test("out of range priority results in default", "Test-Foo", 12, 5)
+	test("override", "Perf-Foo", 0.5, 0.5)
+}
+
+func TestGetRetryAttempt(t *testing.T) {
+	finished := rfc3339(t, "2021-10-01T15:00:00Z")
+	test := func(name string, previousAttempt int, status types.TaskStatus, policies []*types.RetryPolicy, expectOK bool, expectRetryAt time.Time) {
+		t.Run(name, func(t *testing.T) {
+			c := &TaskCandidate{
+				TaskSpec: &specs.TaskSpec{MaxAttempts: 2},
+			}
+			for _, p := range policies {
+				c.Jobs = append(c.Jobs, &types.Job{RetryPolicy: p})
+			}
+			previous := &types.Task{
+				Attempt:  previousAttempt,
+				Finished: finished,
+				Status:   status,
+			}
+			attempt, retryAt, ok := getRetryAttempt(c, previous)
+			require.Equal(t, expectOK, ok)
+			if ok {
+				assert.Equal(t, previousAttempt+1, attempt)
+				assert.Equal(t, expectRetryAt, retryAt)
+			}
+		})
+	}
+	noPolicy := []*types.RetryPolicy{nil}
+	backoff := &types.RetryPolicy{MaxAttempts: 4, Backoff: time.Minute}
+	mishapOnly := &types.RetryPolicy{RetryOn: types.RETRY_ON_MISHAP}
+
+	test("no jobs", 0, types.TASK_STATUS_FAILURE, nil, true, time.Time{})
+	test("no jobs, attempts exhausted", 1, types.TASK_STATUS_FAILURE, nil, false, time.Time{})
+	test("no policy", 0, types.TASK_STATUS_FAILURE, noPolicy, true, time.Time{})
+	test("no policy, attempts exhausted", 1, types.TASK_STATUS_FAILURE, noPolicy, false, time.Time{})
+	test("backoff, first retry", 0, types.TASK_STATUS_FAILURE, []*types.RetryPolicy{backoff}, true, finished.Add(time.Minute))
+	test("backoff, third retry", 2, types.TASK_STATUS_FAILURE, []*types.RetryPolicy{backoff}, true, finished.Add(4*time.Minute))
+	test("backoff, attempts exhausted", 3, types.TASK_STATUS_FAILURE, []*types.RetryPolicy{backoff}, false, time.Time{})
+	test("mishap only, failure", 0, types.TASK_STATUS_FAILURE, []*types.RetryPolicy{mishapOnly}, false, time.Time{})
+	test("mishap only, mishap", 0, types.TASK_STATUS_MISHAP, []*types.RetryPolicy{mishapOnly}, true, finished)
+	test("mishap only and no policy, failure", 0, types.TASK_STATUS_FAILURE, []*types.RetryPolicy{mishapOnly, nil}, true, time.Time{})
+	test("earliest retry wins", 0, types.TASK_STATUS_MISHAP, []*types.RetryPolicy{backoff, mishapOnly}, true, finished)
+}
+
 func TestComputeBlamelist_NoExistingTests(t *testing.T) {
 	ctx := context.Background()
 
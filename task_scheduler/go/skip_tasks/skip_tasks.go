@@ -13,6 +13,7 @@ import (
 	"go.opencensus.io/trace"
 	"go.skia.org/infra/go/firestore"
 	"go.skia.org/infra/go/git/repograph"
+	"go.skia.org/infra/go/now"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/util"
 	"golang.org/x/oauth2"
@@ -133,21 +134,22 @@ func (b *DB) AutoUpdate(ctx context.Context) {
 
 // Match determines whether the given taskSpec/commit pair matches one of the
 // Rules in the DB.
-func (b *DB) Match(taskSpec, commit string) bool {
-	return b.MatchRule(taskSpec, commit) != ""
+func (b *DB) Match(ctx context.Context, taskSpec, commit string) bool {
+	return b.MatchRule(ctx, taskSpec, commit) != ""
 }
 
 // MatchRule determines whether the given taskSpec/commit pair matches one of
 // the Rules in the DB. Returns the name of the matched Rule or the empty string
 // if no Rules match.
-func (b *DB) MatchRule(taskSpec, commit string) string {
+func (b *DB) MatchRule(ctx context.Context, taskSpec, commit string) string {
 	if b == nil {
 		return ""
 	}
 	b.mtx.RLock()
 	defer b.mtx.RUnlock()
+	currentTime := now.Now(ctx)
 	for _, rule := range b.rules {
-		if rule.Match(taskSpec, commit) {
+		if !rule.Expired(currentTime) && rule.Match(taskSpec, commit) {
 			return rule.Name
 		}
 	}
@@ -159,7 +161,7 @@ func (b *DB) AddRule(ctx context.Context, r *Rule, repos repograph.Map) error {
 	if b == nil {
 		return errors.New("DB is nil; cannot add rules.")
 	}
-	if err := ValidateRule(r, repos); err != nil {
+	if err := ValidateRule(ctx, r, repos); err != nil {
 		return err
 	}
 	return b.addRule(ctx, r)
@@ -178,7 +180,7 @@ func (b *DB) addRule(ctx context.Context, r *Rule) (rvErr error) {
 }
 
 // NewCommitRangeRule creates a new Rule which covers a range of commits.
-func NewCommitRangeRule(ctx context.Context, name, user, description string, taskSpecPatterns []string, startCommit, endCommit string, repos repograph.Map) (*Rule, error) {
+func NewCommitRangeRule(ctx context.Context, name, user, description string, taskSpecPatterns []string, startCommit, endCommit string, expires time.Time, repos repograph.Map) (*Rule, error) {
 	_, repoName, _, err := repos.FindCommit(startCommit)
 	if err != nil {
 		return nil, err
@@ -221,9 +223,10 @@ func NewCommitRangeRule(ctx context.Context, name, user, description string, tas
 		TaskSpecPatterns: taskSpecPatterns,
 		Commits:          commits,
 		Description:      description,
+		Expires:          expires,
 		Name:             name,
 	}
-	if err := ValidateRule(rule, repos); err != nil {
+	if err := ValidateRule(ctx, rule, repos); err != nil {
 		return nil, err
 	}
 	return rule, nil
@@ -244,6 +247,24 @@ func (b *DB) RemoveRule(ctx context.Context, id string) error {
 	return nil
 }
 
+// RemoveExpiredRules removes all Rules which have expired as of the given time
+// from the DB and returns them.
+func (b *DB) RemoveExpiredRules(ctx context.Context, currentTime time.Time) ([]*Rule, error) {
+	if b == nil {
+		return nil, nil
+	}
+	var expired []*Rule
+	for _, r := range b.GetRules() {
+		if r.Expired(currentTime) {
+			if err := b.RemoveRule(ctx, r.Name); err != nil {
+				return expired, err
+			}
+			expired = append(expired, r)
+		}
+	}
+	return expired, nil
+}
+
 // GetRules returns a slice containing all of the Rules in the DB.
 func (b *DB) GetRules() []*Rule {
 	if b == nil {
@@ -270,13 +291,23 @@ func (b *DB) GetRules() []*Rule {
 //
 // A Rule should specify TaskSpecPatterns or Commits or both.
 //
+// If Expires is set, the Rule no longer applies after that time and is
+// automatically removed by the Task Scheduler.
+//
+// If Export is set, the Rule is included in the signed export of this Task
+// Scheduler instance's rules, so that it can be synced to other instances.
+// ImportedFrom is set on Rules which were imported from another instance.
+//
 // TODO(borenet): Add an explicit ID field and a timestamp.
 type Rule struct {
-	AddedBy          string   `json:"added_by"`
-	TaskSpecPatterns []string `json:"task_spec_patterns"`
-	Commits          []string `json:"commits"`
-	Description      string   `json:"description"`
-	Name             string   `json:"name"`
+	AddedBy          string    `json:"added_by"`
+	TaskSpecPatterns []string  `json:"task_spec_patterns"`
+	Commits          []string  `json:"commits"`
+	Description      string    `json:"description"`
+	Expires          time.Time `json:"expires"`
+	Export           bool      `json:"export,omitempty"`
+	ImportedFrom     string    `json:"imported_from,omitempty"`
+	Name             string    `json:"name"`
 }
 
 type rules []*Rule
@@ -292,7 +323,7 @@ func (r rules) Swap(a, b int) {
 }
 
 // ValidateRule returns an error if the given Rule is not valid.
-func ValidateRule(r *Rule, repos repograph.Map) error {
+func ValidateRule(ctx context.Context, r *Rule, repos repograph.Map) error {
 	if r.Name == "" {
 		return errors.New("Rules must have a name.")
 	}
@@ -305,6 +336,9 @@ func ValidateRule(r *Rule, repos repograph.Map) error {
 	if len(r.TaskSpecPatterns) == 0 && len(r.Commits) == 0 {
 		return errors.New("Rules must include a taskSpec pattern and/or a commit/range.")
 	}
+	if !r.Expires.IsZero() && !r.Expires.After(now.Now(ctx)) {
+		return errors.New("Rules must expire in the future.")
+	}
 	for _, c := range r.Commits {
 		if _, _, _, err := repos.FindCommit(c); err != nil {
 			return err
@@ -349,6 +383,12 @@ func (r *Rule) matchCommit(commit string) bool {
 	return false
 }
 
+// Expired returns true iff the Rule has an expiration time which is not after
+// the given time.
+func (r *Rule) Expired(currentTime time.Time) bool {
+	return !r.Expires.IsZero() && !r.Expires.After(currentTime)
+}
+
 // Match returns true iff the Rule matches the given taskSpec and commit.
 func (r *Rule) Match(taskSpec, commit string) bool {
 	return r.matchTaskSpec(taskSpec) && r.matchCommit(commit)
@@ -361,6 +401,9 @@ func (r *Rule) Copy() *Rule {
 		TaskSpecPatterns: util.CopyStringSlice(r.TaskSpecPatterns),
 		Commits:          util.CopyStringSlice(r.Commits),
 		Description:      r.Description,
+		Expires:          r.Expires,
+		Export:           r.Export,
+		ImportedFrom:     r.ImportedFrom,
 		Name:             r.Name,
 	}
 }
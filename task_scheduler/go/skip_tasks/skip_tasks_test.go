@@ -14,6 +14,7 @@ import (
 	"go.skia.org/infra/go/git/testutils/mem_git"
 	"go.skia.org/infra/go/gitstore"
 	"go.skia.org/infra/go/gitstore/mem_gitstore"
+	"go.skia.org/infra/go/now"
 )
 
 func setup(t *testing.T) (*DB, func()) {
@@ -61,11 +62,59 @@ func TestRuleCopy(t *testing.T) {
 		TaskSpecPatterns: []string{"a", "b"},
 		Commits:          []string{"abc123", "def456"},
 		Description:      "this is a rule",
+		Expires:          time.Unix(1700000000, 0),
+		Export:           true,
+		ImportedFrom:     "other-instance",
 		Name:             "example",
 	}
 	assertdeep.Copy(t, r, r.Copy())
 }
 
+func TestRuleExpired(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	r := &Rule{}
+	require.False(t, r.Expired(ts))
+	r.Expires = ts.Add(time.Second)
+	require.False(t, r.Expired(ts))
+	r.Expires = ts
+	require.True(t, r.Expired(ts))
+}
+
+func TestRemoveExpiredRules(t *testing.T) {
+	b, cleanup := setup(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	ts := time.Now()
+	expired := &Rule{
+		AddedBy:          "test@google.com",
+		TaskSpecPatterns: []string{"Expired"},
+		Expires:          ts.Add(-time.Minute),
+		Name:             "Expired Rule",
+	}
+	active := &Rule{
+		AddedBy:          "test@google.com",
+		TaskSpecPatterns: []string{"Active"},
+		Expires:          ts.Add(time.Hour),
+		Name:             "Active Rule",
+	}
+	require.NoError(t, b.addRule(ctx, expired))
+	require.NoError(t, b.addRule(ctx, active))
+
+	// Expired rules don't match, even before they are removed.
+	require.False(t, b.Match(ctx, "Expired", "abc123"))
+	require.True(t, b.Match(ctx, "Active", "abc123"))
+	require.True(t, b.Match(now.TimeTravelingContext(ts.Add(-time.Hour)), "Expired", "abc123"))
+
+	removed, err := b.RemoveExpiredRules(ctx, ts)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	require.Equal(t, expired.Name, removed[0].Name)
+	rules := b.GetRules()
+	require.Len(t, rules, 1)
+	require.Equal(t, active.Name, rules[0].Name)
+}
+
 func TestRules(t *testing.T) {
 	type testCase struct {
 		taskSpec    string
@@ -255,6 +304,8 @@ func setupTestRepo(t *testing.T) (context.Context, repograph.Map, []string) {
 func TestValidation(t *testing.T) {
 	// Setup.
 	_, repos, commits := setupTestRepo(t)
+	ts := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	ctx := now.TimeTravelingContext(ts)
 
 	// Test.
 	tests := []struct {
@@ -370,9 +421,29 @@ func TestValidation(t *testing.T) {
 			expect: nil,
 			msg:    "Five commits",
 		},
+		{
+			rule: Rule{
+				AddedBy:          "test@google.com",
+				Name:             "My rule",
+				TaskSpecPatterns: []string{".*"},
+				Expires:          ts.Add(-time.Hour),
+			},
+			expect: fmt.Errorf("Rules must expire in the future."),
+			msg:    "Already expired",
+		},
+		{
+			rule: Rule{
+				AddedBy:          "test@google.com",
+				Name:             "My rule",
+				TaskSpecPatterns: []string{".*"},
+				Expires:          ts.Add(time.Hour),
+			},
+			expect: nil,
+			msg:    "Expires in the future",
+		},
 	}
 	for _, test := range tests {
-		require.Equal(t, test.expect, ValidateRule(&test.rule, repos), test.msg)
+		require.Equal(t, test.expect, ValidateRule(ctx, &test.rule, repos), test.msg)
 	}
 }
 
@@ -387,7 +458,7 @@ func TestCommitRange(t *testing.T) {
 	// Create a commit range rule.
 	startCommit := commits[0]
 	endCommit := commits[6]
-	rule, err := NewCommitRangeRule(ctx, "commit range", "test@google.com", "...", []string{}, startCommit, endCommit, repos)
+	rule, err := NewCommitRangeRule(ctx, "commit range", "test@google.com", "...", []string{}, startCommit, endCommit, time.Time{}, repos)
 	require.NoError(t, err)
 	err = b.AddRule(ctx, rule, repos)
 	require.NoError(t, err)
@@ -432,6 +503,6 @@ func TestCommitRange(t *testing.T) {
 		},
 	}
 	for _, c := range tc {
-		require.Equal(t, c.expect, b.Match("", c.commit))
+		require.Equal(t, c.expect, b.Match(ctx, "", c.commit))
 	}
 }
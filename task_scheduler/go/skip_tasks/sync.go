@@ -0,0 +1,312 @@
+package skip_tasks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	fs "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.skia.org/infra/go/git/repograph"
+	"go.skia.org/infra/go/httputils"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/sklog"
+	"go.skia.org/infra/go/util"
+)
+
+const (
+	// maxExportBytes is the maximum size of a signed export which we'll
+	// accept.
+	maxExportBytes = 1024 * 1024
+
+	// MaxExportAge is the maximum age of a signed export which we'll accept.
+	// Exports are generated on request, so anything older than this is likely
+	// to be a replay of a previously-captured export.
+	MaxExportAge = time.Hour
+
+	// importsCollection is the name of the Firestore collection which records
+	// the most recent export imported from each source.
+	importsCollection = "skip-tasks-imports"
+)
+
+// importRecord records the most recent export imported from a source.
+type importRecord struct {
+	Source   string    `json:"source"`
+	Exported time.Time `json:"exported"`
+}
+
+// RuleExport is a set of Rules exported from a Task Scheduler instance.
+type RuleExport struct {
+	// Source identifies the instance which exported the Rules. Imported
+	// Rules have their ImportedFrom field set to this value.
+	Source   string    `json:"source"`
+	Exported time.Time `json:"exported"`
+	Rules    []*Rule   `json:"rules"`
+}
+
+// signedRuleExport is the wire format of a RuleExport. Signature is the
+// HMAC-SHA256 of Payload, which is the JSON-encoded RuleExport.
+type signedRuleExport struct {
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+// sign returns the HMAC-SHA256 of the given payload using the given key.
+func sign(payload, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// SignRules encodes the given Rules as a RuleExport and signs it using the
+// given key.
+func SignRules(source string, rules []*Rule, key []byte, ts time.Time) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, skerr.Fmt("a signing key is required")
+	}
+	payload, err := json.Marshal(&RuleExport{
+		Source:   source,
+		Exported: ts.UTC(),
+		Rules:    rules,
+	})
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	rv, err := json.Marshal(&signedRuleExport{
+		Payload:   payload,
+		Signature: sign(payload, key),
+	})
+	return rv, skerr.Wrap(err)
+}
+
+// VerifyRules verifies the signature of the given signed export using the
+// given key and returns the decoded RuleExport. Exports which are older than
+// MaxExportAge as of the given time are rejected.
+func VerifyRules(b, key []byte, now time.Time) (*RuleExport, error) {
+	if len(key) == 0 {
+		return nil, skerr.Fmt("a signing key is required")
+	}
+	var signed signedRuleExport
+	if err := json.Unmarshal(b, &signed); err != nil {
+		return nil, skerr.Wrapf(err, "failed to decode signed export")
+	}
+	if !hmac.Equal(signed.Signature, sign(signed.Payload, key)) {
+		return nil, skerr.Fmt("invalid signature")
+	}
+	var rv RuleExport
+	if err := json.Unmarshal(signed.Payload, &rv); err != nil {
+		return nil, skerr.Wrapf(err, "failed to decode export")
+	}
+	if rv.Source == "" {
+		return nil, skerr.Fmt("export has no source")
+	}
+	if now.Sub(rv.Exported) > MaxExportAge {
+		return nil, skerr.Fmt("export from %s is too old; exported at %s", rv.Source, rv.Exported)
+	}
+	return &rv, nil
+}
+
+// ExportedRules returns the Rules which are designated for export. Rules which
+// were themselves imported are never exported, to prevent cycles.
+func (b *DB) ExportedRules() []*Rule {
+	rv := []*Rule{}
+	for _, r := range b.GetRules() {
+		if r.Export && r.ImportedFrom == "" {
+			rv = append(rv, r)
+		}
+	}
+	return rv
+}
+
+// ImportResult describes the changes made by ImportRules.
+type ImportResult struct {
+	Added   []string `json:"added"`
+	Updated []string `json:"updated"`
+	Removed []string `json:"removed"`
+	// Skipped contains the names of Rules which could not be imported, eg.
+	// because they conflict with a local Rule or refer to commits which are
+	// unknown to this instance.
+	Skipped []string `json:"skipped"`
+}
+
+// rulesEqual returns true iff the two Rules are equivalent.
+func rulesEqual(a, b *Rule) bool {
+	return a.AddedBy == b.AddedBy &&
+		util.SSliceEqual(a.TaskSpecPatterns, b.TaskSpecPatterns) &&
+		util.SSliceEqual(a.Commits, b.Commits) &&
+		a.Description == b.Description &&
+		a.Expires.Equal(b.Expires) &&
+		a.Export == b.Export &&
+		a.ImportedFrom == b.ImportedFrom &&
+		a.Name == b.Name
+}
+
+// recordImport records that the given export is being imported. Returns an
+// error if it is older than the last export imported from the same source, so
+// that a replayed export cannot undo more recent changes.
+func (b *DB) recordImport(ctx context.Context, exp *RuleExport) error {
+	ref := b.client.Collection(importsCollection).Doc(url.PathEscape(exp.Source))
+	return b.client.RunTransaction(ctx, "RecordSkipRulesImport", exp.Source, defaultAttempts, timeoutPut, func(ctx context.Context, tx *fs.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err == nil {
+			var prev importRecord
+			if err := doc.DataTo(&prev); err != nil {
+				return skerr.Wrap(err)
+			}
+			if exp.Exported.Before(prev.Exported) {
+				return skerr.Fmt("export from %s at %s is older than the last imported export at %s", exp.Source, exp.Exported, prev.Exported)
+			}
+		} else if st, ok := status.FromError(err); !ok || st.Code() != codes.NotFound {
+			return skerr.Wrapf(err, "failed to retrieve last import from %s", exp.Source)
+		}
+		return tx.Set(ref, &importRecord{
+			Source:   exp.Source,
+			Exported: exp.Exported,
+		})
+	})
+}
+
+// ImportRules makes the Rules previously imported from exp.Source match the
+// Rules in exp: new Rules are added, changed Rules are replaced, and Rules
+// which are no longer exported are removed. Rules which were added locally or
+// imported from a different source are never modified. Exports which are older
+// than the last one imported from the same source are rejected.
+func (b *DB) ImportRules(ctx context.Context, exp *RuleExport, repos repograph.Map) (*ImportResult, error) {
+	if b == nil {
+		return nil, errors.New("DB is nil; cannot import rules.")
+	}
+	if err := b.recordImport(ctx, exp); err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	existing := map[string]*Rule{}
+	for _, r := range b.GetRules() {
+		existing[r.Name] = r
+	}
+	rv := &ImportResult{}
+	imported := make(map[string]bool, len(exp.Rules))
+	for _, r := range exp.Rules {
+		cp := r.Copy()
+		cp.Export = false
+		cp.ImportedFrom = exp.Source
+		imported[cp.Name] = true
+		prev, ok := existing[cp.Name]
+		if ok && prev.ImportedFrom != exp.Source {
+			sklog.Warningf("Not importing skip rule %q from %s; it conflicts with an existing rule.", cp.Name, exp.Source)
+			rv.Skipped = append(rv.Skipped, cp.Name)
+			continue
+		}
+		if ok && rulesEqual(prev, cp) {
+			continue
+		}
+		if err := ValidateRule(ctx, cp, repos); err != nil {
+			sklog.Warningf("Not importing skip rule %q from %s: %s", cp.Name, exp.Source, err)
+			rv.Skipped = append(rv.Skipped, cp.Name)
+			continue
+		}
+		if ok {
+			if err := b.RemoveRule(ctx, prev.Name); err != nil {
+				return rv, skerr.Wrapf(err, "failed to remove outdated rule %q", prev.Name)
+			}
+		}
+		if err := b.addRule(ctx, cp); err != nil {
+			return rv, skerr.Wrapf(err, "failed to add rule %q", cp.Name)
+		}
+		if ok {
+			rv.Updated = append(rv.Updated, cp.Name)
+		} else {
+			rv.Added = append(rv.Added, cp.Name)
+		}
+	}
+	names := make([]string, 0, len(existing))
+	for name := range existing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if existing[name].ImportedFrom == exp.Source && !imported[name] {
+			if err := b.RemoveRule(ctx, name); err != nil {
+				return rv, skerr.Wrapf(err, "failed to remove rule %q", name)
+			}
+			rv.Removed = append(rv.Removed, name)
+		}
+	}
+	return rv, nil
+}
+
+// SyncFrom retrieves the signed export at the given URL, as served by
+// ExportHandler, and imports its Rules.
+func (b *DB) SyncFrom(ctx context.Context, c *http.Client, url string, key []byte, repos repograph.Map) (*ImportResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to retrieve rules from %s", url)
+	}
+	defer util.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, skerr.Fmt("failed to retrieve rules from %s: status %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxExportBytes))
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to read rules from %s", url)
+	}
+	exp, err := VerifyRules(body, key, time.Now())
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to verify rules from %s", url)
+	}
+	return b.ImportRules(ctx, exp, repos)
+}
+
+// ExportHandler returns an http.HandlerFunc which serves the signed export of
+// the Rules designated for export.
+func ExportHandler(b *DB, source string, key []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		enc, err := SignRules(source, b.ExportedRules(), key, time.Now())
+		if err != nil {
+			httputils.ReportError(w, err, "Failed to export rules.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(enc); err != nil {
+			sklog.Errorf("Failed to write response: %s", err)
+		}
+	}
+}
+
+// ImportHandler returns an http.HandlerFunc which imports Rules from a signed
+// export in the request body and responds with the ImportResult. Callers are
+// responsible for restricting access to the handler.
+func ImportHandler(b *DB, key []byte, repos repograph.Map) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxExportBytes))
+		if err != nil {
+			httputils.ReportError(w, err, "Failed to read request.", http.StatusBadRequest)
+			return
+		}
+		exp, err := VerifyRules(body, key, time.Now())
+		if err != nil {
+			httputils.ReportError(w, err, "Failed to verify rules.", http.StatusBadRequest)
+			return
+		}
+		res, err := b.ImportRules(r.Context(), exp, repos)
+		if err != nil {
+			httputils.ReportError(w, err, "Failed to import rules.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			sklog.Errorf("Failed to write response: %s", err)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package skip_tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	syncTestKey  = []byte("fake-signing-key")
+	syncTestTime = time.Unix(1700000000, 0).UTC()
+)
+
+func TestSignRules_VerifyRules_RoundTrip(t *testing.T) {
+	rules := []*Rule{
+		{
+			AddedBy:          "me@google.com",
+			TaskSpecPatterns: []string{"Build-.*"},
+			Description:      "broken",
+			Export:           true,
+			Name:             "rule",
+		},
+	}
+	b, err := SignRules("source-instance", rules, syncTestKey, syncTestTime)
+	require.NoError(t, err)
+	exp, err := VerifyRules(b, syncTestKey, syncTestTime)
+	require.NoError(t, err)
+	require.Equal(t, &RuleExport{
+		Source:   "source-instance",
+		Exported: syncTestTime,
+		Rules:    rules,
+	}, exp)
+}
+
+func TestVerifyRules_WrongKey_ReturnsError(t *testing.T) {
+	b, err := SignRules("source-instance", []*Rule{}, syncTestKey, syncTestTime)
+	require.NoError(t, err)
+	_, err = VerifyRules(b, []byte("some-other-key"), syncTestTime)
+	require.ErrorContains(t, err, "invalid signature")
+}
+
+func TestVerifyRules_TamperedPayload_ReturnsError(t *testing.T) {
+	b, err := SignRules("source-instance", []*Rule{{Name: "rule"}}, syncTestKey, syncTestTime)
+	require.NoError(t, err)
+	var signed signedRuleExport
+	require.NoError(t, json.Unmarshal(b, &signed))
+	signed.Payload, err = json.Marshal(&RuleExport{
+		Source: "source-instance",
+		Rules:  []*Rule{{Name: "evil-rule"}},
+	})
+	require.NoError(t, err)
+	b, err = json.Marshal(&signed)
+	require.NoError(t, err)
+	_, err = VerifyRules(b, syncTestKey, syncTestTime)
+	require.ErrorContains(t, err, "invalid signature")
+}
+
+func TestVerifyRules_TooOld_ReturnsError(t *testing.T) {
+	b, err := SignRules("source-instance", []*Rule{}, syncTestKey, syncTestTime)
+	require.NoError(t, err)
+	_, err = VerifyRules(b, syncTestKey, syncTestTime.Add(MaxExportAge))
+	require.NoError(t, err)
+	_, err = VerifyRules(b, syncTestKey, syncTestTime.Add(MaxExportAge+time.Second))
+	require.ErrorContains(t, err, "export from source-instance is too old")
+}
+
+func TestSignRules_NoKey_ReturnsError(t *testing.T) {
+	_, err := SignRules("source-instance", []*Rule{}, nil, syncTestTime)
+	require.ErrorContains(t, err, "a signing key is required")
+}
+
+func TestExportedRules(t *testing.T) {
+	b := &DB{
+		rules: map[string]*Rule{
+			"exported":     {Name: "exported", Export: true},
+			"local":        {Name: "local"},
+			"reimported":   {Name: "reimported", Export: true, ImportedFrom: "elsewhere"},
+			"exported-too": {Name: "exported-too", Export: true},
+		},
+	}
+	rules := b.ExportedRules()
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		names = append(names, r.Name)
+	}
+	sort.Strings(names)
+	require.Equal(t, []string{"exported", "exported-too"}, names)
+}
+
+func TestExportHandler(t *testing.T) {
+	b := &DB{
+		rules: map[string]*Rule{
+			"exported": {Name: "exported", AddedBy: "me@google.com", TaskSpecPatterns: []string{".*"}, Export: true},
+			"local":    {Name: "local", AddedBy: "me@google.com", TaskSpecPatterns: []string{".*"}},
+		},
+	}
+	w := httptest.NewRecorder()
+	ExportHandler(b, "source-instance", syncTestKey)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	exp, err := VerifyRules(w.Body.Bytes(), syncTestKey, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, "source-instance", exp.Source)
+	require.Len(t, exp.Rules, 1)
+	require.Equal(t, "exported", exp.Rules[0].Name)
+}
+
+func TestImportRules(t *testing.T) {
+	b, cleanup := setup(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	local := &Rule{
+		AddedBy:          "me@google.com",
+		TaskSpecPatterns: []string{"Local"},
+		Name:             "conflict",
+	}
+	require.NoError(t, b.addRule(ctx, local))
+
+	exp := &RuleExport{
+		Source: "source-instance",
+		Rules: []*Rule{
+			{AddedBy: "you@google.com", TaskSpecPatterns: []string{"A"}, Export: true, Name: "a"},
+			{AddedBy: "you@google.com", TaskSpecPatterns: []string{"B"}, Export: true, Name: "b"},
+			{AddedBy: "you@google.com", TaskSpecPatterns: []string{"Remote"}, Export: true, Name: "conflict"},
+			{AddedBy: "you@google.com", TaskSpecPatterns: []string{"Expired"}, Export: true, Expires: time.Now().Add(-time.Hour), Name: "expired"},
+		},
+	}
+	res, err := b.ImportRules(ctx, exp, nil)
+	require.NoError(t, err)
+	require.Equal(t, &ImportResult{
+		Added:   []string{"a", "b"},
+		Skipped: []string{"conflict", "expired"},
+	}, res)
+	require.True(t, b.Match(ctx, "A", "abc123"))
+	require.True(t, b.Match(ctx, "Local", "abc123"))
+	require.False(t, b.Match(ctx, "Remote", "abc123"))
+	for _, r := range b.GetRules() {
+		if r.Name == "a" {
+			require.Equal(t, "source-instance", r.ImportedFrom)
+			require.False(t, r.Export)
+		}
+	}
+
+	// Importing the same export again is a no-op.
+	exp.Rules = exp.Rules[:3]
+	res, err = b.ImportRules(ctx, exp, nil)
+	require.NoError(t, err)
+	require.Equal(t, &ImportResult{Skipped: []string{"conflict"}}, res)
+
+	// Changed rules are updated and missing rules are removed.
+	exp.Rules = []*Rule{
+		{AddedBy: "you@google.com", TaskSpecPatterns: []string{"A2"}, Export: true, Name: "a"},
+	}
+	res, err = b.ImportRules(ctx, exp, nil)
+	require.NoError(t, err)
+	require.Equal(t, &ImportResult{
+		Updated: []string{"a"},
+		Removed: []string{"b"},
+	}, res)
+	require.False(t, b.Match(ctx, "A", "abc123"))
+	require.True(t, b.Match(ctx, "A2", "abc123"))
+	require.False(t, b.Match(ctx, "B", "abc123"))
+	require.True(t, b.Match(ctx, "Local", "abc123"))
+}
+
+func TestImportRules_OlderThanLastImport_ReturnsError(t *testing.T) {
+	b, cleanup := setup(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newer := &RuleExport{
+		Source:   "source-instance",
+		Exported: syncTestTime,
+		Rules:    []*Rule{},
+	}
+	_, err := b.ImportRules(ctx, newer, nil)
+	require.NoError(t, err)
+
+	// A replay of an older export is rejected.
+	older := &RuleExport{
+		Source:   "source-instance",
+		Exported: syncTestTime.Add(-time.Minute),
+		Rules: []*Rule{
+			{AddedBy: "you@google.com", TaskSpecPatterns: []string{"A"}, Export: true, Name: "a"},
+		},
+	}
+	_, err = b.ImportRules(ctx, older, nil)
+	require.ErrorContains(t, err, "is older than the last imported export")
+	require.False(t, b.Match(ctx, "A", "abc123"))
+
+	// Exports from other sources are tracked separately.
+	older.Source = "other-instance"
+	_, err = b.ImportRules(ctx, older, nil)
+	require.NoError(t, err)
+	require.True(t, b.Match(ctx, "A", "abc123"))
+}
+
+func TestSyncFrom(t *testing.T) {
+	b, cleanup := setup(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	src := &DB{
+		rules: map[string]*Rule{
+			"exported": {Name: "exported", AddedBy: "me@google.com", TaskSpecPatterns: []string{"Exported"}, Export: true},
+		},
+	}
+	srv := httptest.NewServer(ExportHandler(src, "source-instance", syncTestKey))
+	defer srv.Close()
+
+	_, err := b.SyncFrom(ctx, srv.Client(), srv.URL, []byte("wrong-key"), nil)
+	require.ErrorContains(t, err, "invalid signature")
+
+	res, err := b.SyncFrom(ctx, srv.Client(), srv.URL, syncTestKey, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"exported"}, res.Added)
+	require.True(t, b.Match(ctx, "Exported", "abc123"))
+}
@@ -3,12 +3,16 @@ package specs
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"os"
 	"path"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/robfig/cron"
 	"go.skia.org/infra/go/cas/rbe"
 	"go.skia.org/infra/go/cipd"
 	"go.skia.org/infra/go/periodic"
@@ -23,6 +27,9 @@ const (
 	// The default JobSpec.Priority, when unspecified or invalid.
 	DEFAULT_JOB_SPEC_PRIORITY = 0.5
 
+	// The default TaskSpec.Priority, when unspecified.
+	DEFAULT_TASK_SPEC_PRIORITY = 1.0
+
 	TASKS_CFG_FILE = "infra/bots/tasks.json"
 
 	// Triggering configuration for jobs.
@@ -40,6 +47,19 @@ const (
 	TRIGGER_ON_DEMAND = "on demand"
 	// Trigger this job weekly.
 	TRIGGER_WEEKLY = periodic.TRIGGER_WEEKLY
+	// Trigger this job according to its Schedule.
+	TRIGGER_SCHEDULED = "scheduled"
+
+	// SCHEDULE_CATCH_UP_WINDOW is how far back we look for a missed
+	// scheduled time of a job whose JobSchedule has CatchUp set.
+	SCHEDULE_CATCH_UP_WINDOW = 24 * time.Hour
+	// SCHEDULE_GRACE_PERIOD is how long after its scheduled time a job whose
+	// JobSchedule does not have CatchUp set may still be triggered, to allow
+	// for lag in the Job Creator.
+	SCHEDULE_GRACE_PERIOD = 10 * time.Minute
+	// SCHEDULE_MIN_INTERVAL is the minimum time between the scheduled times
+	// of a job.
+	SCHEDULE_MIN_INTERVAL = time.Minute
 
 	VARIABLE_SYNTAX = "<(%s)"
 
@@ -57,6 +77,11 @@ const (
 	VARIABLE_REVISION             = "REVISION"
 	VARIABLE_TASK_ID              = "TASK_ID"
 	VARIABLE_TASK_NAME            = "TASK_NAME"
+
+	// VARIABLE_PARAMETER_PREFIX is prepended to the name of a JobSpec
+	// parameter to obtain the name of the variable which is replaced with the
+	// value of that parameter, eg. "<(PARAM_GN_ARGS)" for parameter "GN_ARGS".
+	VARIABLE_PARAMETER_PREFIX = "PARAM_"
 )
 
 var (
@@ -95,6 +120,14 @@ var (
 	PLACEHOLDER_ISOLATED_OUTDIR      = "${ISOLATED_OUTDIR}"
 
 	PERIODIC_TRIGGERS = []string{TRIGGER_NIGHTLY, TRIGGER_WEEKLY}
+
+	// ErrInvalidJobParameters is returned when the parameter values provided
+	// for a job do not match the parameters of its JobSpec.
+	ErrInvalidJobParameters = errors.New("Invalid job parameters")
+
+	// ErrExternalDependency is returned when the revision of a JobSpec's
+	// ExternalDependency cannot be resolved from the DEPS file.
+	ErrExternalDependency = errors.New("Failed to resolve external dependency")
 )
 
 // ErrorIsPermanent returns true if the given error cannot be recovered by
@@ -108,6 +141,7 @@ func ErrorIsPermanent(err error) bool {
 		strings.Contains(err.Error(), "Failed to apply patch") ||
 		strings.Contains(err.Error(), "Failed to read tasks cfg: could not parse file:") ||
 		strings.Contains(err.Error(), "Invalid TasksCfg") ||
+		strings.Contains(err.Error(), ErrExternalDependency.Error()) ||
 		strings.Contains(err.Error(), "The \"gclient_gn_args_from\" value must be in recursedeps") ||
 		// This repo was moved, so attempts to sync it will always fail.
 		strings.Contains(err.Error(), "https://skia.googlesource.com/third_party/libjpeg-turbo.git") ||
@@ -345,7 +379,10 @@ type TaskSpec struct {
 	// these is missing.
 	Outputs []string `json:"outputs,omitempty"`
 
-	// This field is ignored.
+	// Priority is a weight applied to the scores of all candidates for this
+	// TaskSpec, with 0 < p <= 1, so that less important TaskSpecs yield bots
+	// to more important ones. If unspecified, DEFAULT_TASK_SPEC_PRIORITY is
+	// used. May be overridden on the server side.
 	Priority float64 `json:"priority,omitempty"`
 
 	// ServiceAccount indicates the Swarming service account to use for the
@@ -353,6 +390,8 @@ type TaskSpec struct {
 	ServiceAccount string `json:"service_account,omitempty"`
 
 	// TaskExecutor specifies what type of task executor should handle the task.
+	// If "buildbucket", the task runs as a build of the builder with the same
+	// name as the TaskSpec.
 	TaskExecutor string `json:"task_executor,omitempty"`
 }
 
@@ -381,6 +420,10 @@ func (t *TaskSpec) Validate(cfg *TasksCfg) error {
 		return fmt.Errorf("Invalid task executor %q; must be one of: %v", t.TaskExecutor, types.ValidTaskExecutors)
 	}
 
+	if t.Priority < 0 || t.Priority > 1 {
+		return fmt.Errorf("Task priority must satisfy 0 < p <= 1; got %f", t.Priority)
+	}
+
 	return nil
 }
 
@@ -453,6 +496,14 @@ type Cache struct {
 // type here?
 type CipdPackage = cipd.Package
 
+// RetryPolicy determines how the tasks of a job are retried. It is stored with
+// each Job, so it is defined in the types package.
+type RetryPolicy = types.RetryPolicy
+
+// ExternalDependency is a dependency of a job on a task in another repo, at a
+// pinned revision. It is stored with each Job; see types.ExternalDependency.
+type ExternalDependency = types.ExternalDependency
+
 // JobSpec is a struct which describes a set of TaskSpecs to run as part of a
 // larger effort.
 type JobSpec struct {
@@ -473,6 +524,19 @@ type JobSpec struct {
 	TaskSpecs []string `json:"tasks"`
 	// One of the TRIGGER_* constants; see documentation above.
 	Trigger string `json:"trigger,omitempty"`
+	// Parameters which may be provided when the job is manually triggered.
+	// The value of each parameter replaces the ParameterPlaceholder for that
+	// parameter in the commands and extra tags of the job's tasks.
+	Parameters []*JobParameter `json:"parameters,omitempty"`
+	// RetryPolicy determines how the job's tasks are retried. If not
+	// specified, tasks are retried according to their TaskSpecs.
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+	// ExternalDependencies are tasks in other repos which must succeed
+	// before any of the job's tasks are triggered.
+	ExternalDependencies []*ExternalDependency `json:"external_dependencies,omitempty"`
+	// Schedule determines when the job is triggered. It is required for, and
+	// only allowed with, TRIGGER_SCHEDULED.
+	Schedule *JobSchedule `json:"schedule,omitempty"`
 }
 
 // Validate returns an error if the JobSpec is not valid.
@@ -482,14 +546,81 @@ func (j *JobSpec) Validate() error {
 
 	switch j.Trigger {
 	case TRIGGER_ANY_BRANCH, TRIGGER_MASTER_ONLY, TRIGGER_MAIN_ONLY,
-		TRIGGER_NIGHTLY, TRIGGER_ON_DEMAND, TRIGGER_WEEKLY:
+		TRIGGER_NIGHTLY, TRIGGER_ON_DEMAND, TRIGGER_SCHEDULED, TRIGGER_WEEKLY:
 		break
 	default:
 		return fmt.Errorf("Invalid job trigger %q", j.Trigger)
 	}
+	if j.Trigger == TRIGGER_SCHEDULED {
+		if j.Schedule == nil {
+			return fmt.Errorf("Job trigger %q requires a schedule", TRIGGER_SCHEDULED)
+		}
+		if err := j.Schedule.Validate(); err != nil {
+			return err
+		}
+	} else if j.Schedule != nil {
+		return fmt.Errorf("Job schedule requires trigger %q", TRIGGER_SCHEDULED)
+	}
+	names := make(map[string]bool, len(j.Parameters))
+	for _, p := range j.Parameters {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if names[p.Name] {
+			return fmt.Errorf("Duplicate job parameter %q", p.Name)
+		}
+		names[p.Name] = true
+		// Jobs which are triggered automatically have no way to obtain a
+		// value for a required parameter.
+		if p.Required && j.Trigger != TRIGGER_ON_DEMAND {
+			return fmt.Errorf("Job parameter %q is required, so the job must use trigger %q", p.Name, TRIGGER_ON_DEMAND)
+		}
+	}
+	if j.RetryPolicy != nil {
+		if err := j.RetryPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, d := range j.ExternalDependencies {
+		if err := d.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// ResolveParameters returns the values of all of the JobSpec's parameters,
+// given the values provided when triggering the job. Parameters which were not
+// provided take their default values. Returns an error wrapping
+// ErrInvalidJobParameters if a value is provided for an unknown parameter or no
+// value is provided for a required parameter.
+func (j *JobSpec) ResolveParameters(values map[string]string) (map[string]string, error) {
+	known := make(map[string]bool, len(j.Parameters))
+	for _, p := range j.Parameters {
+		known[p.Name] = true
+	}
+	for name := range values {
+		if !known[name] {
+			return nil, fmt.Errorf("%w: unknown parameter %q", ErrInvalidJobParameters, name)
+		}
+	}
+	if len(j.Parameters) == 0 {
+		return nil, nil
+	}
+	rv := make(map[string]string, len(j.Parameters))
+	for _, p := range j.Parameters {
+		value, ok := values[p.Name]
+		if !ok {
+			if p.Required {
+				return nil, fmt.Errorf("%w: missing value for required parameter %q", ErrInvalidJobParameters, p.Name)
+			}
+			value = p.Default
+		}
+		rv[p.Name] = value
+	}
+	return rv, nil
+}
+
 // Copy returns a copy of the JobSpec.
 func (j *JobSpec) Copy() *JobSpec {
 	var taskSpecs []string
@@ -497,13 +628,150 @@ func (j *JobSpec) Copy() *JobSpec {
 		taskSpecs = make([]string, len(j.TaskSpecs))
 		copy(taskSpecs, j.TaskSpecs)
 	}
+	var parameters []*JobParameter
+	if j.Parameters != nil {
+		parameters = make([]*JobParameter, 0, len(j.Parameters))
+		for _, p := range j.Parameters {
+			parameters = append(parameters, p.Copy())
+		}
+	}
+	var externalDeps []*ExternalDependency
+	if j.ExternalDependencies != nil {
+		externalDeps = make([]*ExternalDependency, 0, len(j.ExternalDependencies))
+		for _, d := range j.ExternalDependencies {
+			externalDeps = append(externalDeps, d.Copy())
+		}
+	}
 	return &JobSpec{
-		Priority:  j.Priority,
-		TaskSpecs: taskSpecs,
-		Trigger:   j.Trigger,
+		ExternalDependencies: externalDeps,
+		Parameters:           parameters,
+		Priority:             j.Priority,
+		RetryPolicy:          j.RetryPolicy.Copy(),
+		TaskSpecs:            taskSpecs,
+		Schedule:             j.Schedule.Copy(),
+		Trigger:              j.Trigger,
 	}
 }
 
+// JobSchedule determines when a job with TRIGGER_SCHEDULED is triggered.
+type JobSchedule struct {
+	// Cron is a standard five-field cron expression, eg. "30 2 * * 1-5", or a
+	// descriptor, eg. "@hourly". It is evaluated in UTC.
+	Cron string `json:"cron"`
+	// Jitter is the maximum delay after each scheduled time before the job is
+	// triggered. Each job is delayed by a fixed amount in [0, Jitter) derived
+	// from its name, which spreads out the load from jobs sharing a schedule.
+	Jitter time.Duration `json:"jitter_ns,omitempty"`
+	// CatchUp determines what happens when scheduled times are missed, eg.
+	// because the Task Scheduler was down. If true, a single job is triggered
+	// for the most recent time missed within SCHEDULE_CATCH_UP_WINDOW.
+	// Otherwise, missed times are skipped.
+	CatchUp bool `json:"catch_up,omitempty"`
+}
+
+// Validate returns an error if the JobSchedule is not valid.
+func (s *JobSchedule) Validate() error {
+	if s.Cron == "" {
+		return fmt.Errorf("Job schedule cron expression is required")
+	}
+	sched, err := cron.ParseStandard(s.Cron)
+	if err != nil {
+		return fmt.Errorf("Invalid job schedule cron expression %q: %s", s.Cron, err)
+	}
+	first := sched.Next(time.Time{})
+	if second := sched.Next(first); !second.IsZero() && second.Sub(first) < SCHEDULE_MIN_INTERVAL {
+		return fmt.Errorf("Job schedule %q must not trigger more than once every %s", s.Cron, SCHEDULE_MIN_INTERVAL)
+	}
+	if s.Jitter < 0 {
+		return fmt.Errorf("Job schedule jitter must not be negative")
+	}
+	return nil
+}
+
+// Copy returns a copy of the JobSchedule.
+func (s *JobSchedule) Copy() *JobSchedule {
+	if s == nil {
+		return nil
+	}
+	rv := *s
+	return &rv
+}
+
+// jitter returns the delay after each scheduled time before the job with the
+// given name is triggered.
+func (s *JobSchedule) jitter(jobName string) time.Duration {
+	if s.Jitter <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(jobName))
+	return time.Duration(h.Sum64() % uint64(s.Jitter))
+}
+
+// MostRecentDue returns the most recent time, no later than now, at which the
+// job with the given name was due to be triggered, including jitter. Returns
+// false if the job was not due within SCHEDULE_CATCH_UP_WINDOW, or within
+// SCHEDULE_GRACE_PERIOD if CatchUp is not set.
+func (s *JobSchedule) MostRecentDue(jobName string, now time.Time) (time.Time, bool, error) {
+	sched, err := cron.ParseStandard(s.Cron)
+	if err != nil {
+		return time.Time{}, false, skerr.Wrapf(err, "invalid job schedule cron expression %q", s.Cron)
+	}
+	window := SCHEDULE_GRACE_PERIOD
+	if s.CatchUp {
+		window = SCHEDULE_CATCH_UP_WINDOW
+	}
+	jitter := s.jitter(jobName)
+	latest := now.UTC().Add(-jitter)
+	var due time.Time
+	for t := sched.Next(latest.Add(-window)); !t.IsZero() && !t.After(latest); t = sched.Next(t) {
+		due = t.Add(jitter)
+	}
+	return due, !due.IsZero(), nil
+}
+
+// jobParameterNameRegex matches valid JobParameter names.
+var jobParameterNameRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// JobParameter describes a value which may be provided when manually
+// triggering a job.
+type JobParameter struct {
+	// Name of the parameter. Must consist of upper case letters, digits and
+	// underscores, starting with a letter.
+	Name string `json:"name"`
+	// Description of the parameter, for display to the user.
+	Description string `json:"description,omitempty"`
+	// Default value used when no value is provided.
+	Default string `json:"default,omitempty"`
+	// Required indicates that a value must be provided when triggering the
+	// job.
+	Required bool `json:"required,omitempty"`
+}
+
+// Validate returns an error if the JobParameter is not valid.
+func (p *JobParameter) Validate() error {
+	if !jobParameterNameRegex.MatchString(p.Name) {
+		return fmt.Errorf("Invalid job parameter name %q; must match %s", p.Name, jobParameterNameRegex)
+	}
+	return nil
+}
+
+// Copy returns a copy of the JobParameter.
+func (p *JobParameter) Copy() *JobParameter {
+	return &JobParameter{
+		Name:        p.Name,
+		Description: p.Description,
+		Default:     p.Default,
+		Required:    p.Required,
+	}
+}
+
+// ParameterPlaceholder returns the placeholder which is replaced with the value
+// of the given job parameter at task triggering time.
+func ParameterPlaceholder(name string) string {
+	return fmt.Sprintf(VARIABLE_SYNTAX, VARIABLE_PARAMETER_PREFIX+name)
+}
+
 // GetTaskSpecDAG returns a map describing all of the dependencies of the
 // JobSpec. Its keys are TaskSpec names and values are TaskSpec names upon
 // which the keys depend.
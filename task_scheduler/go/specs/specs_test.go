@@ -52,9 +52,34 @@ func fakeTaskSpec() *TaskSpec {
 
 func fakeJobSpec() *JobSpec {
 	return &JobSpec{
+		Parameters: []*JobParameter{
+			{
+				Name:        "GN_ARGS",
+				Description: "Extra GN args.",
+				Default:     "is_debug=true",
+				Required:    true,
+			},
+		},
 		TaskSpecs: []string{"Build", "Test"},
 		Trigger:   "trigger-name",
 		Priority:  753,
+		RetryPolicy: &RetryPolicy{
+			MaxAttempts: 4,
+			Backoff:     time.Minute,
+			RetryOn:     types.RETRY_ON_MISHAP,
+		},
+		ExternalDependencies: []*ExternalDependency{
+			{
+				Repo:      "https://skia.googlesource.com/skia.git",
+				Task:      "Build-Skia",
+				DepsEntry: "skia.googlesource.com/skia",
+			},
+		},
+		Schedule: &JobSchedule{
+			Cron:    "30 2 * * 1-5",
+			Jitter:  15 * time.Minute,
+			CatchUp: true,
+		},
 	}
 }
 
@@ -300,3 +325,204 @@ func TestGetTaskSpecDAG(t *testing.T) {
 		"g": {"d", "e", "f"},
 	}, []string{"a", "g"})
 }
+
+func TestJobSpecValidate_Parameters(t *testing.T) {
+	test := func(name, expectErr string, trigger string, params ...*JobParameter) {
+		t.Run(name, func(t *testing.T) {
+			j := &JobSpec{
+				Parameters: params,
+				TaskSpecs:  []string{"a"},
+				Trigger:    trigger,
+			}
+			err := j.Validate()
+			if expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, expectErr)
+			}
+		})
+	}
+
+	test("ok", "", TRIGGER_ANY_BRANCH, &JobParameter{Name: "GN_ARGS"}, &JobParameter{Name: "X2"})
+	test("required on demand", "", TRIGGER_ON_DEMAND, &JobParameter{Name: "GN_ARGS", Required: true})
+	test("required not on demand", "must use trigger", TRIGGER_NIGHTLY, &JobParameter{Name: "GN_ARGS", Required: true})
+	test("lower case name", "Invalid job parameter name", TRIGGER_ANY_BRANCH, &JobParameter{Name: "gn_args"})
+	test("empty name", "Invalid job parameter name", TRIGGER_ANY_BRANCH, &JobParameter{})
+	test("duplicate name", "Duplicate job parameter", TRIGGER_ANY_BRANCH, &JobParameter{Name: "A"}, &JobParameter{Name: "A"})
+}
+
+func TestJobSpecValidate_RetryPolicy(t *testing.T) {
+	j := &JobSpec{
+		TaskSpecs:   []string{"a"},
+		Trigger:     TRIGGER_ANY_BRANCH,
+		RetryPolicy: &RetryPolicy{MaxAttempts: 3, RetryOn: types.RETRY_ON_FAILURE},
+	}
+	require.NoError(t, j.Validate())
+
+	j.RetryPolicy.RetryOn = "sometimes"
+	require.ErrorContains(t, j.Validate(), "Invalid retry policy retry_on")
+}
+
+func TestJobSpecValidate_ExternalDependencies(t *testing.T) {
+	dep := &ExternalDependency{
+		Repo:     "https://skia.googlesource.com/skia.git",
+		Task:     "Build-Skia",
+		Revision: "abc123",
+	}
+	j := &JobSpec{
+		TaskSpecs:            []string{"a"},
+		Trigger:              TRIGGER_ANY_BRANCH,
+		ExternalDependencies: []*ExternalDependency{dep},
+	}
+	require.NoError(t, j.Validate())
+
+	dep.Revision = ""
+	require.ErrorContains(t, j.Validate(), "requires either revision or deps_entry")
+	dep.DepsEntry = "skia.googlesource.com/skia"
+	require.NoError(t, j.Validate())
+
+	dep.Task = ""
+	require.ErrorContains(t, j.Validate(), "External dependency task is required")
+	dep.Task = "Build-Skia"
+	dep.Repo = ""
+	require.ErrorContains(t, j.Validate(), "External dependency repo is required")
+}
+
+func TestJobSpecValidate_Schedule(t *testing.T) {
+	j := &JobSpec{
+		TaskSpecs: []string{"a"},
+		Trigger:   TRIGGER_SCHEDULED,
+	}
+	require.ErrorContains(t, j.Validate(), `Job trigger "scheduled" requires a schedule`)
+
+	j.Schedule = &JobSchedule{Cron: "0 */6 * * *", Jitter: time.Minute}
+	require.NoError(t, j.Validate())
+	j.Schedule.Cron = "@daily"
+	require.NoError(t, j.Validate())
+
+	j.Schedule.Cron = ""
+	require.ErrorContains(t, j.Validate(), "Job schedule cron expression is required")
+	j.Schedule.Cron = "not a cron expression"
+	require.ErrorContains(t, j.Validate(), "Invalid job schedule cron expression")
+	j.Schedule.Cron = "@every 30s"
+	require.ErrorContains(t, j.Validate(), "must not trigger more than once every 1m0s")
+	j.Schedule.Cron = "@hourly"
+	j.Schedule.Jitter = -time.Minute
+	require.ErrorContains(t, j.Validate(), "Job schedule jitter must not be negative")
+
+	j.Schedule.Jitter = 0
+	j.Trigger = TRIGGER_NIGHTLY
+	require.ErrorContains(t, j.Validate(), `Job schedule requires trigger "scheduled"`)
+}
+
+func TestJobScheduleMostRecentDue(t *testing.T) {
+	const jobName = "Scheduled-Job"
+	ts := func(day, hour, minute int) time.Time {
+		return time.Date(2023, time.May, day, hour, minute, 0, 0, time.UTC)
+	}
+	test := func(name string, s *JobSchedule, now time.Time, expectDue time.Time, expectOk bool) {
+		t.Run(name, func(t *testing.T) {
+			due, ok, err := s.MostRecentDue(jobName, now)
+			require.NoError(t, err)
+			require.Equal(t, expectOk, ok)
+			require.Equal(t, expectDue, due)
+		})
+	}
+
+	// May 1, 2023 is a Monday.
+	hourly := &JobSchedule{Cron: "0 * * * *"}
+	test("on time", hourly, ts(1, 10, 0), ts(1, 10, 0), true)
+	test("within grace period", hourly, ts(1, 10, 5), ts(1, 10, 0), true)
+	test("after grace period", hourly, ts(1, 10, 15), time.Time{}, false)
+
+	hourlyCatchUp := &JobSchedule{Cron: "0 * * * *", CatchUp: true}
+	test("catch up", hourlyCatchUp, ts(1, 10, 59), ts(1, 10, 0), true)
+	test("catch up most recent only", hourlyCatchUp, ts(2, 10, 30), ts(2, 10, 0), true)
+
+	weeklyCatchUp := &JobSchedule{Cron: "0 2 * * 1", CatchUp: true}
+	test("catch up within window", weeklyCatchUp, ts(2, 1, 0), ts(1, 2, 0), true)
+	test("catch up outside window", weeklyCatchUp, ts(3, 1, 0), time.Time{}, false)
+
+	invalid := &JobSchedule{Cron: "bogus"}
+	_, _, err := invalid.MostRecentDue(jobName, ts(1, 0, 0))
+	require.ErrorContains(t, err, "invalid job schedule cron expression")
+}
+
+func TestJobScheduleMostRecentDue_Jitter(t *testing.T) {
+	s := &JobSchedule{Cron: "0 * * * *", Jitter: 30 * time.Minute, CatchUp: true}
+	jitter := s.jitter("Scheduled-Job")
+	require.True(t, jitter >= 0 && jitter < s.Jitter)
+	// The jitter is derived from the job name, so it doesn't change.
+	require.Equal(t, jitter, s.jitter("Scheduled-Job"))
+
+	hour := time.Date(2023, time.May, 1, 10, 0, 0, 0, time.UTC)
+	due, ok, err := s.MostRecentDue("Scheduled-Job", hour.Add(jitter-time.Nanosecond))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, hour.Add(-time.Hour).Add(jitter), due)
+
+	due, ok, err = s.MostRecentDue("Scheduled-Job", hour.Add(jitter))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, hour.Add(jitter), due)
+}
+
+func TestTaskSpecValidate_Priority(t *testing.T) {
+	test := func(name string, priority float64, expectErr bool) {
+		t.Run(name, func(t *testing.T) {
+			ts := &TaskSpec{
+				Dimensions: []string{"os:whatever"},
+				Priority:   priority,
+			}
+			err := ts.Validate(nil)
+			if expectErr {
+				require.ErrorContains(t, err, "Task priority must satisfy")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	test("unset", 0, false)
+	test("valid", 0.3, false)
+	test("max", 1, false)
+	test("negative", -0.5, true)
+	test("too high", 1.5, true)
+}
+
+func TestJobSpecResolveParameters(t *testing.T) {
+	j := &JobSpec{
+		Parameters: []*JobParameter{
+			{Name: "GN_ARGS", Default: "is_debug=true"},
+			{Name: "CONFIG", Required: true},
+		},
+		Trigger: TRIGGER_ON_DEMAND,
+	}
+
+	// Defaults are filled in.
+	params, err := j.ResolveParameters(map[string]string{"CONFIG": "8888"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"GN_ARGS": "is_debug=true", "CONFIG": "8888"}, params)
+
+	// Provided values override defaults, even when empty.
+	params, err = j.ResolveParameters(map[string]string{"CONFIG": "8888", "GN_ARGS": ""})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"GN_ARGS": "", "CONFIG": "8888"}, params)
+
+	// Required parameters must be provided.
+	_, err = j.ResolveParameters(nil)
+	require.ErrorIs(t, err, ErrInvalidJobParameters)
+	require.ErrorContains(t, err, "missing value for required parameter \"CONFIG\"")
+
+	// Unknown parameters are rejected.
+	_, err = j.ResolveParameters(map[string]string{"CONFIG": "8888", "BOGUS": "x"})
+	require.ErrorIs(t, err, ErrInvalidJobParameters)
+	require.ErrorContains(t, err, "unknown parameter \"BOGUS\"")
+
+	// Jobs without parameters have no parameter values.
+	params, err = (&JobSpec{}).ResolveParameters(nil)
+	require.NoError(t, err)
+	require.Nil(t, params)
+	_, err = (&JobSpec{}).ResolveParameters(map[string]string{"BOGUS": "x"})
+	require.Error(t, err)
+}
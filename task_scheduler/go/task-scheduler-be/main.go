@@ -2,18 +2,28 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
+	"io"
+	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/bigtable"
 	"cloud.google.com/go/datastore"
 	"cloud.google.com/go/pubsub"
 	"cloud.google.com/go/storage"
+	"github.com/go-chi/chi/v5"
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+	"go.chromium.org/luci/grpc/prpc"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/option"
 
+	"go.skia.org/infra/email/go/emailclient"
 	"go.skia.org/infra/go/auth"
+	"go.skia.org/infra/go/buildbucket"
 	"go.skia.org/infra/go/cas/rbe"
 	"go.skia.org/infra/go/cleanup"
 	"go.skia.org/infra/go/common"
@@ -24,14 +34,18 @@ import (
 	gs_pubsub "go.skia.org/infra/go/gitstore/pubsub"
 	"go.skia.org/infra/go/httputils"
 	"go.skia.org/infra/go/human"
+	"go.skia.org/infra/go/notifier"
 	"go.skia.org/infra/go/sklog"
 	"go.skia.org/infra/go/swarming"
 	swarmingv2 "go.skia.org/infra/go/swarming/v2"
 	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/cost_accounting"
 	"go.skia.org/infra/task_scheduler/go/db/firestore"
+	"go.skia.org/infra/task_scheduler/go/load_shedding"
 	"go.skia.org/infra/task_scheduler/go/scheduling"
 	"go.skia.org/infra/task_scheduler/go/skip_tasks"
 	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
+	"go.skia.org/infra/task_scheduler/go/task_execution/buildbucketv2"
 	swarming_task_execution_v2 "go.skia.org/infra/task_scheduler/go/task_execution/swarmingv2"
 	"go.skia.org/infra/task_scheduler/go/types"
 )
@@ -49,25 +63,43 @@ const (
 
 var (
 	// Flags.
-	btInstance           = flag.String("bigtable_instance", "", "BigTable instance to use.")
-	btProject            = flag.String("bigtable_project", "", "GCE project to use for BigTable.")
-	debugBusyBots        = flag.Bool("debug-busy-bots", false, "If set, dump debug information in the busy-bots module.")
-	port                 = flag.String("port", ":8000", "HTTP service port for the web server (e.g., ':8000')")
-	firestoreInstance    = flag.String("firestore_instance", "", "Firestore instance to use, eg. \"production\"")
-	gitstoreTable        = flag.String("gitstore_bt_table", "git-repos2", "BigTable table used for GitStore.")
-	local                = flag.Bool("local", false, "Whether we're running on a dev machine vs in production.")
-	rbeInstance          = flag.String("rbe_instance", "projects/chromium-swarm/instances/default_instance", "CAS instance to use")
-	repoUrls             = common.NewMultiStringFlag("repo", nil, "Repositories for which to schedule tasks.")
-	scoreDecay24Hr       = flag.Float64("scoreDecay24Hr", 0.9, "Task candidate scores are penalized using linear time decay. This is the desired value after 24 hours. Setting it to 1.0 causes commits not to be prioritized according to commit time.")
-	swarmingPools        = common.NewMultiStringFlag("pool", nil, "Which Swarming pools to use.")
-	swarmingServer       = flag.String("swarming_server", swarming.SWARMING_SERVER, "Which Swarming server to use.")
-	timePeriod           = flag.String("timeWindow", "4d", "Time period to use.")
-	commitWindow         = flag.Int("commitWindow", 10, "Minimum number of recent commits to keep in the timeWindow.")
-	diagnosticsBucket    = flag.String("diagnostics_bucket", "skia-task-scheduler-diagnostics", "Name of Google Cloud Storage bucket to use for diagnostics data.")
-	promPort             = flag.String("prom_port", ":20000", "Metrics service address (e.g., ':10110')")
-	pubsubTopicName      = flag.String("pubsub_topic", swarming.PUBSUB_TOPIC_SWARMING_TASKS, "Pub/Sub topic to use for Swarming tasks.")
-	pubsubSubscriberName = flag.String("pubsub_subscriber", PUBSUB_SUBSCRIBER_TASK_SCHEDULER, "Pub/Sub subscriber name.")
-	swarmingAPIv2        = flag.Bool("swarming-api-v2", false, "If set, use Swarming API v2")
+	btInstance             = flag.String("bigtable_instance", "", "BigTable instance to use.")
+	bbProject              = flag.String("buildbucket_project", "", "Buildbucket project containing the builders for TaskSpecs which use the buildbucket task executor. If not set, the buildbucket task executor is disabled.")
+	bbBucket               = flag.String("buildbucket_bucket", "", "Buildbucket bucket containing the builders for TaskSpecs which use the buildbucket task executor.")
+	btProject              = flag.String("bigtable_project", "", "GCE project to use for BigTable.")
+	debugBusyBots          = flag.Bool("debug-busy-bots", false, "If set, dump debug information in the busy-bots module.")
+	port                   = flag.String("port", ":8000", "HTTP service port for the web server (e.g., ':8000')")
+	firestoreInstance      = flag.String("firestore_instance", "", "Firestore instance to use, eg. \"production\"")
+	gitstoreTable          = flag.String("gitstore_bt_table", "git-repos2", "BigTable table used for GitStore.")
+	local                  = flag.Bool("local", false, "Whether we're running on a dev machine vs in production.")
+	rbeInstance            = flag.String("rbe_instance", "projects/chromium-swarm/instances/default_instance", "CAS instance to use")
+	repoUrls               = common.NewMultiStringFlag("repo", nil, "Repositories for which to schedule tasks.")
+	scoreDecay24Hr         = flag.Float64("scoreDecay24Hr", 0.9, "Task candidate scores are penalized using linear time decay. This is the desired value after 24 hours. Setting it to 1.0 causes commits not to be prioritized according to commit time.")
+	swarmingPools          = common.NewMultiStringFlag("pool", nil, "Which Swarming pools to use.")
+	swarmingServer         = flag.String("swarming_server", swarming.SWARMING_SERVER, "Which Swarming server to use.")
+	timePeriod             = flag.String("timeWindow", "4d", "Time period to use.")
+	commitWindow           = flag.Int("commitWindow", 10, "Minimum number of recent commits to keep in the timeWindow.")
+	diagnosticsBucket      = flag.String("diagnostics_bucket", "skia-task-scheduler-diagnostics", "Name of Google Cloud Storage bucket to use for diagnostics data.")
+	promPort               = flag.String("prom_port", ":20000", "Metrics service address (e.g., ':10110')")
+	pubsubTopicName        = flag.String("pubsub_topic", swarming.PUBSUB_TOPIC_SWARMING_TASKS, "Pub/Sub topic to use for Swarming tasks.")
+	pubsubSubscriberName   = flag.String("pubsub_subscriber", PUBSUB_SUBSCRIBER_TASK_SCHEDULER, "Pub/Sub subscriber name.")
+	swarmingAPIv2          = flag.Bool("swarming-api-v2", false, "If set, use Swarming API v2")
+	priorityOverrides      = flag.String("priority_overrides", "", "Optional JSON file containing a list of {\"task_spec_regex\", \"priority\"} objects which override TaskSpec priorities.")
+	starvationMinShare     = flag.Float64("starvation_min_share", 0, "Minimum fraction of the task queue reserved for starved task candidates. Zero disables starvation protection.")
+	starvationThreshold    = flag.Duration("starvation_threshold", 4*time.Hour, "How long a task candidate must wait before it is considered starved.")
+	botAffinityWeight      = flag.Float64("bot_affinity_weight", 0, "Added to the score multiplier of task candidates for which a free bot recently ran the same TaskSpec and likely has warm caches. Zero disables the preference but still records affinity metrics.")
+	botAffinityWindow      = flag.Duration("bot_affinity_window", 6*time.Hour, "How long after running a TaskSpec a bot is considered to have warm caches for it. Zero disables bot affinity entirely.")
+	loadSheddingClasses    = common.NewMultiStringFlag("load_shedding_class", nil, "Class of jobs which are suspended while load shedding is active; one of \"commit\", \"periodic\", or \"forced\". May be repeated. Tryjobs are never suspended.")
+	loadSheddingActivate   = flag.Int("load_shedding_activate_backlog", 0, "Number of eligible task candidates at or above which load shedding activates automatically, unless turned off via the API. Zero disables automatic activation.")
+	loadSheddingDeactivate = flag.Int("load_shedding_deactivate_backlog", 0, "Number of eligible task candidates at or below which automatically-activated load shedding deactivates.")
+	quarantineAfter        = flag.Int("quarantine_after_mishaps", 0, "Number of consecutive mishaps after which a TaskSpec is quarantined by adding a skip rule for it. Zero disables quarantining.")
+	costExportBucket       = flag.String("cost_export_bucket", "", "Name of Google Cloud Storage bucket to which daily per-TaskSpec and per-Job bot time and costs are exported. If empty, costs are not exported.")
+	costExportDir          = flag.String("cost_export_dir", "cost_accounting", "Directory within --cost_export_bucket to which costs are exported.")
+	costRates              = flag.String("cost_rates", "", "Optional JSON file containing a list of {\"dimensions\", \"usd_per_hour\"} objects used to convert bot time into cost. The first rate whose dimensions are all present on a TaskSpec applies.")
+	skipRuleNotifiers      = flag.String("skip_rule_notifiers", "", "Optional JSON file containing a list of notifier configs used to announce expired skip rules and quarantined TaskSpecs. Chat notifiers are not supported.")
+	skipRulesSyncURL       = flag.String("skip_rules_sync_url", "", "Optional URL of another Task Scheduler's skip rules export, eg. \"https://task-scheduler.skia.org/json/skip_rules/export\". If set, the exported rules are periodically imported.")
+	skipRulesSyncPeriod    = flag.Duration("skip_rules_sync_period", 10*time.Minute, "How often to import skip rules from --skip_rules_sync_url.")
+	skipRulesKey           = flag.String("skip_rules_signing_key", "", "File containing the key used to verify the skip rules imported from --skip_rules_sync_url.")
 )
 
 func main() {
@@ -118,6 +150,13 @@ func main() {
 		sklog.Fatal(err)
 	}
 
+	// Load shedding state, which may be changed via the frontend.
+	loadSheddingDB, err := load_shedding.NewWithParams(ctx, firestore.FIRESTORE_PROJECT, *firestoreInstance, tokenSource)
+	if err != nil {
+		sklog.Fatal(err)
+	}
+	loadSheddingDB.AutoUpdate(ctx)
+
 	// Git repos.
 	if *repoUrls == nil {
 		sklog.Fatal("--repo is required.")
@@ -157,7 +196,7 @@ func main() {
 		sklog.Fatalf("Failed to create TaskCfgCache: %s", err)
 	}
 
-	// Create the task executor.
+	// Create the task executors.
 	prpcClient := swarmingv2.DefaultPRPCClient(httpClient, *swarmingServer)
 	swarmClient := swarmingv2.NewClient(prpcClient)
 	swarmingTaskExec := swarming_task_execution_v2.NewSwarmingV2TaskExecutor(swarmClient, *rbeInstance, *pubsubTopicName)
@@ -165,22 +204,116 @@ func main() {
 		types.TaskExecutor_UseDefault: swarmingTaskExec,
 		types.TaskExecutor_Swarming:   swarmingTaskExec,
 	}
+	if *bbProject != "" {
+		if *bbBucket == "" {
+			sklog.Fatal("--buildbucket_bucket is required if --buildbucket_project is set.")
+		}
+		bbClient := buildbucketpb.NewBuildsPRPCClient(&prpc.Client{
+			C:    httpClient,
+			Host: buildbucket.DEFAULT_HOST,
+		})
+		taskExecs[types.TaskExecutor_Buildbucket] = buildbucketv2.NewBuildbucketV2TaskExecutor(bbClient, *bbProject, *bbBucket, *rbeInstance)
+	}
+
+	// Load TaskSpec priority overrides.
+	var overrides scheduling.TaskSpecPriorityOverrides
+	if *priorityOverrides != "" {
+		overrides, err = scheduling.ReadTaskSpecPriorityOverrides(*priorityOverrides)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+	}
+	if *starvationMinShare < 0 || *starvationMinShare > 1 {
+		sklog.Fatalf("--starvation_min_share must be between 0 and 1; got %f", *starvationMinShare)
+	}
+	starvationProtection := scheduling.StarvationProtection{
+		Threshold: *starvationThreshold,
+		MinShare:  *starvationMinShare,
+	}
+	if *botAffinityWeight < 0 {
+		sklog.Fatalf("--bot_affinity_weight must not be negative; got %f", *botAffinityWeight)
+	}
+	botAffinity := scheduling.BotAffinity{
+		Weight: *botAffinityWeight,
+		Window: *botAffinityWindow,
+	}
+
+	loadSheddingCfg := load_shedding.Config{
+		ActivateBacklog:   *loadSheddingActivate,
+		DeactivateBacklog: *loadSheddingDeactivate,
+	}
+	for _, class := range *loadSheddingClasses {
+		loadSheddingCfg.SuspendedClasses = append(loadSheddingCfg.SuspendedClasses, load_shedding.JobClass(class))
+	}
+	shedder, err := load_shedding.NewShedder(loadSheddingDB, loadSheddingCfg)
+	if err != nil {
+		sklog.Fatalf("Invalid load shedding configuration: %s", err)
+	}
+
+	var rates cost_accounting.Rates
+	if *costRates != "" {
+		rates, err = cost_accounting.ReadRates(*costRates)
+		if err != nil {
+			sklog.Fatal(err)
+		}
+	}
+
+	if *quarantineAfter < 0 {
+		sklog.Fatalf("--quarantine_after_mishaps must not be negative; got %d", *quarantineAfter)
+	}
+	quarantine := scheduling.Quarantine{
+		MaxConsecutiveMishaps: *quarantineAfter,
+	}
 
 	// Create and start the task scheduler.
 	sklog.Infof("Creating task scheduler.")
-	ts, err := scheduling.NewTaskScheduler(ctx, tsDb, skipTasks, period, *commitWindow, repos, cas, *rbeInstance, taskExecs, httpClient, *scoreDecay24Hr, *swarmingPools, *pubsubTopicName, taskCfgCache, tokenSource, diagClient, diagInstance, scheduling.BusyBotsDebugLog(*debugBusyBots))
+	ts, err := scheduling.NewTaskScheduler(ctx, tsDb, skipTasks, period, *commitWindow, repos, cas, *rbeInstance, taskExecs, httpClient, *scoreDecay24Hr, *swarmingPools, *pubsubTopicName, taskCfgCache, tokenSource, diagClient, diagInstance, scheduling.BusyBotsDebugLog(*debugBusyBots), overrides, starvationProtection, botAffinity, shedder, quarantine)
 	if err != nil {
 		sklog.Fatal(err)
 	}
 	cleanup.AtExit(func() {
 		util.LogErr(ts.Close())
 	})
+	if *skipRuleNotifiers != "" {
+		var configs []*notifier.Config
+		if err := util.WithReadFile(*skipRuleNotifiers, func(f io.Reader) error {
+			return json.NewDecoder(f).Decode(&configs)
+		}); err != nil {
+			sklog.Fatalf("Failed to read --skip_rule_notifiers: %s", err)
+		}
+		router := notifier.NewRouter(httpClient, emailclient.New(), nil)
+		if err := router.AddFromConfigs(ctx, configs); err != nil {
+			sklog.Fatal(err)
+		}
+		ts.SetSkipRuleNotifier(router)
+	}
+	if *skipRulesSyncURL != "" {
+		if *skipRulesKey == "" {
+			sklog.Fatal("--skip_rules_signing_key is required if --skip_rules_sync_url is set.")
+		}
+		key, err := os.ReadFile(*skipRulesKey)
+		if err != nil {
+			sklog.Fatalf("Failed to read --skip_rules_signing_key: %s", err)
+		}
+		key = []byte(strings.TrimSpace(string(key)))
+		go util.RepeatCtx(ctx, *skipRulesSyncPeriod, func(ctx context.Context) {
+			res, err := skipTasks.SyncFrom(ctx, httpClient, *skipRulesSyncURL, key, repos)
+			if err != nil {
+				sklog.Errorf("Failed to sync skip rules: %s", err)
+				return
+			}
+			sklog.Infof("Synced skip rules from %s; added: %v, updated: %v, removed: %v, skipped: %v", *skipRulesSyncURL, res.Added, res.Updated, res.Removed, res.Skipped)
+		})
+	}
 	if err := swarming.InitPubSub(*pubsubTopicName, *pubsubSubscriberName, ts.HandleSwarmingPubSub); err != nil {
 		sklog.Fatal(err)
 	}
 
 	sklog.Infof("Created task scheduler. Starting loop.")
 	ts.Start(ctx)
+	if *costExportBucket != "" {
+		cost_accounting.NewExporter(tsDb, taskCfgCache, gcsclient.New(storageClient, *costExportBucket), *costExportDir, rates).Start(ctx)
+	}
 	if err := autoUpdateRepos.Start(ctx, GITSTORE_SUBSCRIBER_ID, tokenSource, 5*time.Minute, func(ctx context.Context, repo string, graph *repograph.Graph, ack, nack func()) error {
 		ack()
 		return nil
@@ -188,6 +321,40 @@ func main() {
 		sklog.Fatal(err)
 	}
 
-	// Run the health check server.
-	httputils.RunHealthCheckServer(*port)
+	runServer(ts)
+}
+
+// capacityHandler returns an http.HandlerFunc which estimates the wait times
+// for each TaskSpec in the queue, given the list of scheduling.CapacityChanges
+// in the request body.
+func capacityHandler(ts *scheduling.TaskScheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var changes []*scheduling.CapacityChange
+		if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+			httputils.ReportError(w, err, "Failed to decode request body.", http.StatusBadRequest)
+			return
+		}
+		estimates, err := ts.EstimateWaitTimes(r.Context(), changes)
+		if err != nil {
+			httputils.ReportError(w, err, "Failed to estimate wait times.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(estimates); err != nil {
+			httputils.ReportError(w, err, "Failed to encode response.", http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func runServer(ts *scheduling.TaskScheduler) {
+	r := chi.NewRouter()
+	r.Post("/json/capacity", capacityHandler(ts))
+	h := httputils.LoggingRequestResponse(r)
+	if !*local {
+		h = httputils.HealthzAndHTTPS(h)
+	}
+	http.Handle("/", h)
+	sklog.Infof("Ready to serve on %s", *port)
+	sklog.Fatal(http.ListenAndServe(*port, nil))
 }
@@ -9,14 +9,17 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/bigtable"
 	"cloud.google.com/go/datastore"
 	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/storage"
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/cors"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
 
 	"go.skia.org/infra/go/alogin"
 	"go.skia.org/infra/go/alogin/proxylogin"
@@ -24,6 +27,7 @@ import (
 	"go.skia.org/infra/go/buildbucket"
 	"go.skia.org/infra/go/cleanup"
 	"go.skia.org/infra/go/common"
+	"go.skia.org/infra/go/gcs/gcsclient"
 	"go.skia.org/infra/go/gerrit"
 	"go.skia.org/infra/go/git/repograph"
 	"go.skia.org/infra/go/gitstore/bt_gitstore"
@@ -40,7 +44,10 @@ import (
 	"go.skia.org/infra/go/util"
 	"go.skia.org/infra/task_scheduler/go/db/firestore"
 	"go.skia.org/infra/task_scheduler/go/job_creation/buildbucket_taskbackend"
+	"go.skia.org/infra/task_scheduler/go/jobstream"
+	"go.skia.org/infra/task_scheduler/go/load_shedding"
 	"go.skia.org/infra/task_scheduler/go/rpc"
+	"go.skia.org/infra/task_scheduler/go/scheduling"
 	"go.skia.org/infra/task_scheduler/go/skip_tasks"
 	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
 	"go.skia.org/infra/task_scheduler/go/types"
@@ -61,6 +68,7 @@ var (
 
 	// HTML templates.
 	skipTasksTemplate   *template.Template = nil
+	diagnosticsTemplate *template.Template = nil
 	jobTemplate         *template.Template = nil
 	jobSearchTemplate   *template.Template = nil
 	jobTimelineTemplate *template.Template = nil
@@ -74,6 +82,7 @@ var (
 	buildbucketTarget = flag.String("buildbucket_target", "", "Target name used by Buildbucket to address this Task Scheduler.")
 	commitWindow      = flag.Int("commitWindow", 10, "Minimum number of recent commits to keep in the timeWindow.")
 	debugPort         = flag.String("debug_port", "", "HTTP service port for debugging using pprof")
+	diagnosticsBucket = flag.String("diagnostics_bucket", "skia-task-scheduler-diagnostics", "Name of Google Cloud Storage bucket from which to read scheduling diagnostics.")
 	host              = flag.String("host", "localhost", "HTTP service host")
 	port              = flag.String("port", ":8000", "HTTP service port for the web server (e.g., ':8000')")
 	firestoreInstance = flag.String("firestore_instance", "", "Firestore instance to use, eg. \"production\"")
@@ -85,6 +94,8 @@ var (
 	timePeriod        = flag.String("timeWindow", "4d", "Time period to use for cache expiration.")
 	tracingProject    = flag.String("tracing_project", "", "GCP project where traces should be uploaded.")
 	promPort          = flag.String("prom_port", ":20000", "Metrics service address (e.g., ':10110')")
+	readOnly          = flag.Bool("read_only", false, "If true, serve the UI and query endpoints but never trigger jobs, ack pubsub messages, or write to the DB. Used for a standby instance.")
+	skipRulesKey      = flag.String("skip_rules_signing_key", "", "File containing the key used to sign exported skip rules and verify imported skip rules. If not set, skip rules cannot be exported or imported.")
 )
 
 func reloadTemplates() {
@@ -95,6 +106,9 @@ func reloadTemplates() {
 		}
 		*resourcesDir = filepath.Join(filepath.Dir(wd), "dist")
 	}
+	diagnosticsTemplate = template.Must(template.ParseFiles(
+		filepath.Join(*resourcesDir, "scheduling_diagnostics.html"),
+	))
 	jobTemplate = template.Must(template.ParseFiles(
 		filepath.Join(*resourcesDir, "job.html"),
 	))
@@ -173,6 +187,19 @@ func triggerHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func diagnosticsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html")
+
+	// Don't use cached templates in testing mode.
+	if *local {
+		reloadTemplates()
+	}
+	if err := diagnosticsTemplate.Execute(w, nil); err != nil {
+		httputils.ReportError(w, err, "Failed to execute template.", http.StatusInternalServerError)
+		return
+	}
+}
+
 func jobHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html")
 
@@ -292,14 +319,34 @@ func addCorsMiddleware(handler http.Handler) http.Handler {
 	return corsWrapper.Handler(handler)
 }
 
-func runServer(serverURL string, srv, bbHandler http.Handler, plogin alogin.Login) {
+// addJobEventsHandler adds the handler which streams the state transitions of a
+// Job and its Tasks to the router.
+func addJobEventsHandler(r chi.Router, jobEvents *jobstream.Server) {
+	r.Get("/json/job/{id}/events", jobEvents.Handler(func(r *http.Request) string {
+		return chi.URLParam(r, "id")
+	}))
+}
+
+// addMiddleware wraps the handler with the middleware used for all requests.
+// Note that gzip must not be added, since it would buffer the job event
+// stream.
+func addMiddleware(h http.Handler, plogin alogin.Login) http.Handler {
+	h = httputils.LoggingRequestResponse(h)
+	h = httputils.XFrameOptionsDeny(h)
+	return alogin.StatusMiddleware(plogin)(h)
+}
+
+func runServer(serverURL string, srv, bbHandler, skipRulesExportHandler, skipRulesImportHandler, diagJSONHandler, cancelJobsHandler, retriggerJobsHandler, loadSheddingHandler, setLoadSheddingHandler http.Handler, jobEvents *jobstream.Server, plogin alogin.Login) {
 	r := chi.NewRouter()
 	r.HandleFunc("/", mainHandler)
 	r.Handle("/dist/*", http.StripPrefix("/dist/", http.HandlerFunc(httputils.MakeResourceHandler(*resourcesDir))))
 	r.Handle(rpc.TaskSchedulerServicePathPrefix+"*", addCorsMiddleware(srv))
 	r.HandleFunc("/skip_tasks", skipTasksHandler)
+	r.HandleFunc("/diagnostics", diagnosticsHandler)
+	r.Get("/json/diagnostics/candidate", diagJSONHandler.ServeHTTP)
 	r.HandleFunc("/job/{id}", jobHandler)
 	r.HandleFunc("/job/{id}/timeline", jobTimelineHandler)
+	addJobEventsHandler(r, jobEvents)
 	r.HandleFunc("/jobs/search", jobSearchHandler)
 	r.HandleFunc("/task/{id}", taskHandler)
 	r.HandleFunc("/trigger", triggerHandler)
@@ -309,10 +356,20 @@ func runServer(serverURL string, srv, bbHandler http.Handler, plogin alogin.Logi
 	if bbHandler != nil {
 		r.Handle("/prpc/*", alogin.ForceRole(bbHandler, plogin, roles.Buildbucket))
 	}
+	if skipRulesExportHandler != nil {
+		r.Get("/json/skip_rules/export", skipRulesExportHandler.ServeHTTP)
+	}
+	if skipRulesImportHandler != nil {
+		r.Post("/json/skip_rules/import", alogin.ForceRole(skipRulesImportHandler, plogin, roles.Editor).ServeHTTP)
+	}
+	r.Post("/json/jobs/cancel", alogin.ForceRole(cancelJobsHandler, plogin, roles.Editor).ServeHTTP)
+	r.Post("/json/jobs/retrigger", alogin.ForceRole(retriggerJobsHandler, plogin, roles.Editor).ServeHTTP)
+	r.Get("/json/load_shedding", loadSheddingHandler.ServeHTTP)
+	if setLoadSheddingHandler != nil {
+		r.Post("/json/load_shedding", alogin.ForceRole(setLoadSheddingHandler, plogin, roles.Editor).ServeHTTP)
+	}
 
-	h := httputils.LoggingRequestResponse(r)
-	h = httputils.XFrameOptionsDeny(h)
-	h = alogin.StatusMiddleware(plogin)(h)
+	h := addMiddleware(r, plogin)
 	if !*local {
 		h = httputils.HealthzAndHTTPS(h)
 	}
@@ -342,7 +399,7 @@ func main() {
 	// Set up token source and authenticated API clients.
 	// TODO(borenet): Should we create a new service account with fewer
 	// permissions?
-	tokenSource, err := google.DefaultTokenSource(ctx, auth.ScopeUserinfoEmail, pubsub.ScopePubSub, datastore.ScopeDatastore, bigtable.Scope, swarming.AUTH_SCOPE)
+	tokenSource, err := google.DefaultTokenSource(ctx, auth.ScopeUserinfoEmail, pubsub.ScopePubSub, datastore.ScopeDatastore, bigtable.Scope, swarming.AUTH_SCOPE, storage.ScopeReadOnly)
 	if err != nil {
 		sklog.Fatalf("Failed to create token source: %s", err)
 	}
@@ -363,6 +420,13 @@ func main() {
 	}
 	skipTasks.AutoUpdate(ctx)
 
+	// Load shedding state.
+	loadShedding, err := load_shedding.NewWithParams(ctx, firestore.FIRESTORE_PROJECT, *firestoreInstance, tokenSource)
+	if err != nil {
+		sklog.Fatal(err)
+	}
+	loadShedding.AutoUpdate(ctx)
+
 	// Git repos.
 	if *repoUrls == nil {
 		sklog.Fatal("--repo is required.")
@@ -392,24 +456,35 @@ func main() {
 	if err != nil {
 		sklog.Fatal(err)
 	}
-	// Periodically clean up the taskCfgCache.
-	go util.RepeatCtx(ctx, 30*time.Minute, func(ctx context.Context) {
-		if err := w.Update(ctx); err != nil {
-			sklog.Errorf("Failed to update time window: %s", err)
-			return
-		}
-		if err := taskCfgCache.Cleanup(ctx, now.Now(ctx).Sub(w.EarliestStart())); err != nil {
-			sklog.Errorf("Failed to clean up task cfg cache: %s", err)
-		}
-	})
+	// Periodically clean up the taskCfgCache. This deletes entries, so it is
+	// left to the primary instance when we're in read-only mode.
+	if !*readOnly {
+		go util.RepeatCtx(ctx, 30*time.Minute, func(ctx context.Context) {
+			if err := w.Update(ctx); err != nil {
+				sklog.Errorf("Failed to update time window: %s", err)
+				return
+			}
+			if err := taskCfgCache.Cleanup(ctx, now.Now(ctx).Sub(w.EarliestStart())); err != nil {
+				sklog.Errorf("Failed to clean up task cfg cache: %s", err)
+			}
+		})
+	}
 
 	// Initialize Swarming client.
 	cfg := httputils.DefaultClientConfig().WithTokenSource(tokenSource).WithDialTimeout(time.Minute).With2xxOnly()
 	httpClient := cfg.Client()
 	swarm := swarmingv2.NewDefaultClient(httpClient, *swarmingServer)
 
-	// Auto-update the git repos.
-	if err := autoUpdateRepos.Start(ctx, GITSTORE_SUBSCRIBER_ID, tokenSource, 5*time.Minute, func(_ context.Context, _ string, _ *repograph.Graph, ack, _ func()) error {
+	// Auto-update the git repos. In read-only mode we must not consume the
+	// pubsub messages intended for the primary instance, so we poll instead.
+	if *readOnly {
+		sklog.Info("Running in read-only mode.")
+		go util.RepeatCtx(ctx, time.Minute, func(ctx context.Context) {
+			if err := repos.Update(ctx); err != nil {
+				sklog.Errorf("Failed to update repos: %s", err)
+			}
+		})
+	} else if err := autoUpdateRepos.Start(ctx, GITSTORE_SUBSCRIBER_ID, tokenSource, 5*time.Minute, func(_ context.Context, _ string, _ *repograph.Graph, ack, _ func()) error {
 		ack()
 		return nil
 	}); err != nil {
@@ -417,7 +492,7 @@ func main() {
 	}
 	plogin := proxylogin.NewWithDefaults()
 
-	srv := rpc.NewTaskSchedulerServer(ctx, tsDb, repos, skipTasks, taskCfgCache, swarm, plogin)
+	srv := rpc.NewTaskSchedulerServer(ctx, tsDb, repos, skipTasks, taskCfgCache, swarm, plogin, *readOnly)
 	if err != nil {
 		sklog.Fatal(err)
 	}
@@ -427,15 +502,56 @@ func main() {
 		serverURL = "http://" + *host + *port
 	}
 
-	// Initialize Buildbucket TaskBackend.
+	// Bulk job cancellation and re-trigger, for sheriffs clearing out the
+	// jobs of a bad revision. These reject requests in read-only mode.
+	cancelJobsHandler := rpc.CancelJobsHandler(ctx, tsDb, repos, *readOnly)
+	retriggerJobsHandler := rpc.RetriggerJobsHandler(ctx, tsDb, repos, taskCfgCache, *readOnly)
+
+	// Report and control load shedding. Changing the mode writes to the DB,
+	// so it is disabled in read-only mode.
+	loadSheddingHandler := load_shedding.StateHandler(loadShedding)
+	var setLoadSheddingHandler http.Handler
+	if !*readOnly {
+		setLoadSheddingHandler = load_shedding.SetModeHandler(loadShedding)
+	}
+
+	// Initialize Buildbucket TaskBackend. This creates jobs in the DB, so it is
+	// disabled in read-only mode.
 	var bbHandler http.Handler
-	if *buildbucketTarget != "" {
+	if *buildbucketTarget != "" && !*readOnly {
 		httpClient := httputils.DefaultClientConfig().WithTokenSource(tokenSource).Client()
 		bb2 := buildbucket.NewClient(httpClient)
 		bbHandler = buildbucket_taskbackend.Handler(*buildbucketTarget, serverURL, common.PROJECT_REPO_MAPPING, tsDb, bb2)
 	}
 
-	go runServer(serverURL, srv, bbHandler, plogin)
+	// Set up export and import of skip rules. Importing writes to the DB, so
+	// it is disabled in read-only mode.
+	var skipRulesExportHandler, skipRulesImportHandler http.Handler
+	if *skipRulesKey != "" {
+		key, err := os.ReadFile(*skipRulesKey)
+		if err != nil {
+			sklog.Fatalf("Failed to read --skip_rules_signing_key: %s", err)
+		}
+		key = []byte(strings.TrimSpace(string(key)))
+		skipRulesExportHandler = skip_tasks.ExportHandler(skipTasks, *host, key)
+		if !*readOnly {
+			skipRulesImportHandler = skip_tasks.ImportHandler(skipTasks, key, repos)
+		}
+	}
+
+	// Explain scheduling decisions using the diagnostics written by the
+	// scheduler.
+	storageClient, err := storage.NewClient(ctx, option.WithTokenSource(tokenSource))
+	if err != nil {
+		sklog.Fatalf("Failed to create storage client: %s", err)
+	}
+	diagJSONHandler := scheduling.DiagnosticsHandler(gcsclient.New(storageClient, *diagnosticsBucket), *firestoreInstance)
+
+	// Push Job and Task status changes to the job pages.
+	jobEvents := jobstream.New()
+	jobEvents.Start(ctx, tsDb)
+
+	go runServer(serverURL, srv, bbHandler, skipRulesExportHandler, skipRulesImportHandler, diagJSONHandler, cancelJobsHandler, retriggerJobsHandler, loadSheddingHandler, setLoadSheddingHandler, jobEvents, plogin)
 
 	if *debugPort != "" {
 		go httputils.ServePprof(*debugPort)
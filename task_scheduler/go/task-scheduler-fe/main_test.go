@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.skia.org/infra/go/alogin"
+	"go.skia.org/infra/go/alogin/mocks"
+	"go.skia.org/infra/go/roles"
+	"go.skia.org/infra/task_scheduler/go/jobstream"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+func TestJobEventsHandler_BehindMiddleware_EventsAreStreamed(t *testing.T) {
+	login := mocks.NewLogin(t)
+	login.On("LoggedInAs", mock.Anything).Return(alogin.EMail(""))
+	login.On("Roles", mock.Anything).Return(roles.Roles{})
+	jobEvents := jobstream.New()
+	r := chi.NewRouter()
+	addJobEventsHandler(r, jobEvents)
+	s := httptest.NewServer(addMiddleware(r, login))
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+"/json/job/my-job/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The client is subscribed once the headers have been flushed.
+	jobEvents.PublishJob(&types.Job{
+		Id:     "my-job",
+		Name:   "my-job-name",
+		Status: types.JOB_STATUS_SUCCESS,
+	})
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "data: {\"type\":\"job\",\"job_id\":\"my-job\",\"name\":\"my-job-name\",\"status\":\"SUCCESS\"}\n", line)
+}
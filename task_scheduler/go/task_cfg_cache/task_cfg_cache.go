@@ -268,8 +268,10 @@ func GetTaskSpec(ctx context.Context, c TaskCfgCache, rs types.RepoState, name s
 }
 
 // MakeJob is a helper function which retrieves the given JobSpec at the given
-// RepoState and uses it to create a Job instance.
-func MakeJob(ctx context.Context, c TaskCfgCache, rs types.RepoState, name string) (*types.Job, error) {
+// RepoState and uses it to create a Job instance. The given parameter values
+// are resolved against the JobSpec's parameters; see
+// specs.JobSpec.ResolveParameters.
+func MakeJob(ctx context.Context, c TaskCfgCache, rs types.RepoState, name string, params map[string]string) (*types.Job, error) {
 	cfg, cachedErr, err := c.Get(ctx, rs)
 	if err != nil {
 		return nil, err
@@ -285,14 +287,28 @@ func MakeJob(ctx context.Context, c TaskCfgCache, rs types.RepoState, name strin
 	if err != nil {
 		return nil, err
 	}
+	params, err = spec.ResolveParameters(params)
+	if err != nil {
+		return nil, err
+	}
+	var externalDeps []*types.ExternalDependency
+	for _, d := range spec.ExternalDependencies {
+		if d.Revision == "" {
+			return nil, fmt.Errorf("Unresolved external dependency on %s in %s for job %s", d.Task, d.Repo, name)
+		}
+		externalDeps = append(externalDeps, d.Copy())
+	}
 
 	return &types.Job{
-		Created:      now.Now(ctx),
-		Dependencies: deps,
-		Name:         name,
-		Priority:     spec.Priority,
-		RepoState:    rs,
-		Tasks:        map[string][]*types.TaskSummary{},
+		Created:              now.Now(ctx),
+		Dependencies:         deps,
+		ExternalDependencies: externalDeps,
+		Name:                 name,
+		Parameters:           params,
+		Priority:             spec.Priority,
+		RepoState:            rs,
+		RetryPolicy:          spec.RetryPolicy.Copy(),
+		Tasks:                map[string][]*types.TaskSummary{},
 	}, nil
 }
 
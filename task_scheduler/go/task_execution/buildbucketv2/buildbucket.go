@@ -0,0 +1,358 @@
+package buildbucketv2
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+	"go.opencensus.io/trace"
+	"go.skia.org/infra/go/cas/rbe"
+	"go.skia.org/infra/go/skerr"
+	"go.skia.org/infra/go/util"
+	"go.skia.org/infra/task_scheduler/go/types"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// PropertyTaskRequest is the name of the input property which contains
+	// the details of the TaskRequest. Builders which run Task Scheduler
+	// tasks should use it to determine what to run.
+	PropertyTaskRequest = "task_scheduler"
+
+	// PropertyCasOutput is the name of the output property in which
+	// builders may report the CAS digest of their outputs, for use by
+	// dependent tasks.
+	PropertyCasOutput = "cas_output"
+
+	// batchSize is the maximum number of requests we send to Buildbucket in
+	// a single batch.
+	batchSize = 200
+)
+
+var (
+	// buildFields indicates which fields we want returned when retrieving
+	// builds.
+	buildFields = &fieldmaskpb.FieldMask{
+		Paths: []string{
+			"create_time",
+			"end_time",
+			"id",
+			"infra.swarming.bot_dimensions",
+			"output.properties",
+			"start_time",
+			"status",
+			"tags",
+		},
+	}
+
+	// statusFields indicates which fields we want returned when retrieving
+	// only the status of builds.
+	statusFields = &fieldmaskpb.FieldMask{
+		Paths: []string{"id", "status"},
+	}
+)
+
+// BuildbucketV2TaskExecutor implements types.TaskExecutor by running each task
+// as a Buildbucket build. The TaskSpec name is used as the name of the builder,
+// which must exist in the configured project and bucket.
+//
+// Buildbucket builds run on Swarming bots, which are reported to the Task
+// Scheduler by the Swarming task executor, so this implementation does not
+// report any machines or pending tasks of its own. Builds are not tracked via
+// Pub/Sub; the Task Scheduler polls for their results.
+type BuildbucketV2TaskExecutor struct {
+	bucket      string
+	casInstance string
+	client      buildbucketpb.BuildsClient
+	project     string
+}
+
+// NewBuildbucketV2TaskExecutor returns a BuildbucketV2TaskExecutor instance
+// which triggers builds in the given Buildbucket project and bucket.
+func NewBuildbucketV2TaskExecutor(client buildbucketpb.BuildsClient, project, bucket, casInstance string) *BuildbucketV2TaskExecutor {
+	return &BuildbucketV2TaskExecutor{
+		bucket:      bucket,
+		casInstance: casInstance,
+		client:      client,
+		project:     project,
+	}
+}
+
+// GetFreeMachines implements types.TaskExecutor.
+func (b *BuildbucketV2TaskExecutor) GetFreeMachines(ctx context.Context, pool string) ([]*types.Machine, error) {
+	return []*types.Machine{}, nil
+}
+
+// GetPendingTasks implements types.TaskExecutor.
+func (b *BuildbucketV2TaskExecutor) GetPendingTasks(ctx context.Context, pool string) ([]*types.TaskResult, error) {
+	return []*types.TaskResult{}, nil
+}
+
+// GetTaskResult implements types.TaskExecutor.
+func (b *BuildbucketV2TaskExecutor) GetTaskResult(ctx context.Context, taskID string) (*types.TaskResult, error) {
+	ctx, span := trace.StartSpan(ctx, "buildbucket_GetTaskResult", trace.WithSampler(trace.ProbabilitySampler(0.01)))
+	defer span.End()
+	id, err := parseBuildID(taskID)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	build, err := b.client.GetBuild(ctx, &buildbucketpb.GetBuildRequest{
+		Id:     id,
+		Fields: buildFields,
+	})
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return convertTaskResult(build)
+}
+
+// GetTaskCompletionStatuses implements types.TaskExecutor.
+func (b *BuildbucketV2TaskExecutor) GetTaskCompletionStatuses(ctx context.Context, taskIDs []string) ([]bool, error) {
+	ctx, span := trace.StartSpan(ctx, "buildbucket_GetTaskCompletionStatuses")
+	span.AddAttributes(trace.Int64Attribute("num_tasks", int64(len(taskIDs))))
+	defer span.End()
+	rv := make([]bool, 0, len(taskIDs))
+	if err := util.ChunkIter(len(taskIDs), batchSize, func(startIdx, endIdx int) error {
+		reqs := make([]*buildbucketpb.BatchRequest_Request, 0, endIdx-startIdx)
+		for _, taskID := range taskIDs[startIdx:endIdx] {
+			id, err := parseBuildID(taskID)
+			if err != nil {
+				return skerr.Wrap(err)
+			}
+			reqs = append(reqs, &buildbucketpb.BatchRequest_Request{
+				Request: &buildbucketpb.BatchRequest_Request_GetBuild{
+					GetBuild: &buildbucketpb.GetBuildRequest{
+						Id:     id,
+						Fields: statusFields,
+					},
+				},
+			})
+		}
+		resp, err := b.client.Batch(ctx, &buildbucketpb.BatchRequest{
+			Requests: reqs,
+		})
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		if len(resp.Responses) != len(reqs) {
+			return skerr.Fmt("Buildbucket gave %d responses for %d builds", len(resp.Responses), len(reqs))
+		}
+		for idx, r := range resp.Responses {
+			if e := r.GetError(); e != nil {
+				return skerr.Fmt("failed to retrieve build %s: %s", taskIDs[startIdx+idx], e.GetMessage())
+			}
+			build := r.GetGetBuild()
+			if build == nil {
+				return skerr.Fmt("Buildbucket returned no build for %s", taskIDs[startIdx+idx])
+			}
+			rv = append(rv, build.Status&buildbucketpb.Status_ENDED_MASK != 0)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+// TriggerTask implements types.TaskExecutor.
+func (b *BuildbucketV2TaskExecutor) TriggerTask(ctx context.Context, req *types.TaskRequest) (*types.TaskResult, error) {
+	ctx, span := trace.StartSpan(ctx, "buildbucket_TriggerTask")
+	defer span.End()
+	bbReq, err := b.convertTaskRequest(req)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	build, err := b.client.ScheduleBuild(ctx, bbReq)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+	return convertTaskResult(build)
+}
+
+// convertTaskRequest converts a types.TaskRequest to a
+// buildbucketpb.ScheduleBuildRequest.
+func (b *BuildbucketV2TaskExecutor) convertTaskRequest(req *types.TaskRequest) (*buildbucketpb.ScheduleBuildRequest, error) {
+	var casInput string
+	if req.CasInput != "" {
+		// Validate the digest.
+		if _, _, err := rbe.StringToDigest(req.CasInput); err != nil {
+			return nil, skerr.Wrap(err)
+		}
+		casInput = req.CasInput
+	}
+	cipdPackages := make([]interface{}, 0, len(req.CipdPackages))
+	for _, p := range req.CipdPackages {
+		cipdPackages = append(cipdPackages, map[string]interface{}{
+			"name":    p.Name,
+			"path":    p.Path,
+			"version": p.Version,
+		})
+	}
+	env := make(map[string]interface{}, len(req.Env))
+	for k, v := range req.Env {
+		env[k] = v
+	}
+	envPrefixes := make(map[string]interface{}, len(req.EnvPrefixes))
+	for k, v := range req.EnvPrefixes {
+		envPrefixes[k] = stringsToInterfaces(v)
+	}
+	props, err := structpb.NewStruct(map[string]interface{}{
+		PropertyTaskRequest: map[string]interface{}{
+			"cas_input":     casInput,
+			"cas_instance":  b.casInstance,
+			"cipd_packages": cipdPackages,
+			"command":       stringsToInterfaces(req.Command),
+			"env":           env,
+			"env_prefixes":  envPrefixes,
+			"idempotent":    req.Idempotent,
+			"outputs":       stringsToInterfaces(req.Outputs),
+			"task_id":       req.TaskSchedulerTaskID,
+		},
+	})
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	dims := make([]*buildbucketpb.RequestedDimension, 0, len(req.Dimensions))
+	for _, d := range req.Dimensions {
+		split := strings.SplitN(d, ":", 2)
+		if len(split) != 2 {
+			return nil, skerr.Fmt("invalid dimension %q", d)
+		}
+		dims = append(dims, &buildbucketpb.RequestedDimension{
+			Key:   split[0],
+			Value: split[1],
+		})
+	}
+
+	tags := make([]*buildbucketpb.StringPair, 0, len(req.Tags))
+	for _, t := range req.Tags {
+		split := strings.SplitN(t, ":", 2)
+		if len(split) != 2 {
+			return nil, skerr.Fmt("invalid tag %q", t)
+		}
+		tags = append(tags, &buildbucketpb.StringPair{
+			Key:   split[0],
+			Value: split[1],
+		})
+	}
+
+	rv := &buildbucketpb.ScheduleBuildRequest{
+		// Use the task ID as the request ID, so that retried requests do
+		// not result in duplicate builds.
+		RequestId: req.TaskSchedulerTaskID,
+		Builder: &buildbucketpb.BuilderID{
+			Project: b.project,
+			Bucket:  b.bucket,
+			Builder: req.Name,
+		},
+		Properties: props,
+		Tags:       tags,
+		Dimensions: dims,
+		Fields:     buildFields,
+	}
+	if req.Expiration > 0 {
+		rv.SchedulingTimeout = durationpb.New(req.Expiration)
+	}
+	if req.ExecutionTimeout > 0 {
+		rv.ExecutionTimeout = durationpb.New(req.ExecutionTimeout)
+	}
+	return rv, nil
+}
+
+// convertTaskResult converts a buildbucketpb.Build to a types.TaskResult.
+func convertTaskResult(build *buildbucketpb.Build) (*types.TaskResult, error) {
+	status, err := convertTaskStatus(build.Status)
+	if err != nil {
+		return nil, skerr.Wrap(err)
+	}
+
+	var casOutput string
+	if props := build.GetOutput().GetProperties(); props != nil {
+		if v, ok := props.Fields[PropertyCasOutput]; ok {
+			casOutput = v.GetStringValue()
+		}
+	}
+
+	var machineID string
+	for _, d := range build.GetInfra().GetSwarming().GetBotDimensions() {
+		if d.Key == "id" {
+			machineID = d.Value
+			break
+		}
+	}
+
+	tags := make(map[string][]string, len(build.Tags))
+	for _, t := range build.Tags {
+		tags[t.Key] = append(tags[t.Key], t.Value)
+	}
+
+	// See the note in the swarmingv2 package about preserving the zero value
+	// of time.Time.
+	var created time.Time
+	if build.CreateTime != nil {
+		created = build.CreateTime.AsTime().UTC()
+	}
+	var started time.Time
+	if build.StartTime != nil {
+		started = build.StartTime.AsTime().UTC()
+	}
+	var finished time.Time
+	if build.EndTime != nil {
+		finished = build.EndTime.AsTime().UTC()
+	}
+
+	return &types.TaskResult{
+		CasOutput: casOutput,
+		Created:   created,
+		Finished:  finished,
+		ID:        strconv.FormatInt(build.Id, 10),
+		MachineID: machineID,
+		Started:   started,
+		Status:    status,
+		Tags:      tags,
+	}, nil
+}
+
+// convertTaskStatus converts a Buildbucket build status to a types.TaskStatus.
+func convertTaskStatus(status buildbucketpb.Status) (types.TaskStatus, error) {
+	switch status {
+	case buildbucketpb.Status_SCHEDULED:
+		return types.TASK_STATUS_PENDING, nil
+	case buildbucketpb.Status_STARTED:
+		return types.TASK_STATUS_RUNNING, nil
+	case buildbucketpb.Status_SUCCESS:
+		return types.TASK_STATUS_SUCCESS, nil
+	case buildbucketpb.Status_FAILURE:
+		return types.TASK_STATUS_FAILURE, nil
+	case buildbucketpb.Status_INFRA_FAILURE, buildbucketpb.Status_CANCELED:
+		return types.TASK_STATUS_MISHAP, nil
+	default:
+		return types.TASK_STATUS_MISHAP, skerr.Fmt("Unknown Buildbucket status %v", status)
+	}
+}
+
+// parseBuildID converts the given task ID to a Buildbucket build ID.
+func parseBuildID(taskID string) (int64, error) {
+	id, err := strconv.ParseInt(taskID, 10, 64)
+	if err != nil {
+		return 0, skerr.Wrapf(err, "invalid Buildbucket build ID %q", taskID)
+	}
+	return id, nil
+}
+
+// stringsToInterfaces converts the given slice of strings to a slice of
+// interface{}, as required by structpb.
+func stringsToInterfaces(s []string) []interface{} {
+	rv := make([]interface{}, 0, len(s))
+	for _, v := range s {
+		rv = append(rv, v)
+	}
+	return rv
+}
+
+var _ types.TaskExecutor = &BuildbucketV2TaskExecutor{}
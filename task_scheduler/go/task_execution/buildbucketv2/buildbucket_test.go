@@ -0,0 +1,204 @@
+package buildbucketv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	buildbucketpb "go.chromium.org/luci/buildbucket/proto"
+	"go.skia.org/infra/go/cipd"
+	"go.skia.org/infra/task_scheduler/go/types"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	fakeDigest = "abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234/987"
+)
+
+var fakeTime = time.Unix(1709294400, 0).UTC()
+
+func setup(t *testing.T) (*BuildbucketV2TaskExecutor, *buildbucketpb.MockBuildsClient) {
+	ctrl := gomock.NewController(t)
+	client := buildbucketpb.NewMockBuildsClient(ctrl)
+	return NewBuildbucketV2TaskExecutor(client, "fake-project", "fake-bucket", "fake-cas-instance"), client
+}
+
+func TestTriggerTask(t *testing.T) {
+	ctx := context.Background()
+	b, client := setup(t)
+
+	var actual *buildbucketpb.ScheduleBuildRequest
+	client.EXPECT().ScheduleBuild(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *buildbucketpb.ScheduleBuildRequest, _ ...grpc.CallOption) (*buildbucketpb.Build, error) {
+		actual = req
+		return &buildbucketpb.Build{
+			Id:         12345,
+			Status:     buildbucketpb.Status_SCHEDULED,
+			CreateTime: timestamppb.New(fakeTime),
+			Tags: []*buildbucketpb.StringPair{
+				{Key: types.SWARMING_TAG_ID, Value: "fake-task-id"},
+			},
+		}, nil
+	})
+
+	res, err := b.TriggerTask(ctx, &types.TaskRequest{
+		CasInput: fakeDigest,
+		CipdPackages: []*cipd.Package{
+			{Name: "package", Path: "path", Version: "version"},
+		},
+		Command:             []string{"run", "it"},
+		Dimensions:          []string{"os:Linux", "pool:Skia"},
+		Env:                 map[string]string{"K": "V"},
+		EnvPrefixes:         map[string][]string{"PATH": {"bin"}},
+		ExecutionTimeout:    time.Hour,
+		Expiration:          4 * time.Hour,
+		Name:                "Build-Linux",
+		Outputs:             []string{"out"},
+		Tags:                []string{"sk_id:fake-task-id", "sk_name:Build-Linux"},
+		TaskSchedulerTaskID: "fake-task-id",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &types.TaskResult{
+		Created: fakeTime,
+		ID:      "12345",
+		Status:  types.TASK_STATUS_PENDING,
+		Tags: map[string][]string{
+			types.SWARMING_TAG_ID: {"fake-task-id"},
+		},
+	}, res)
+
+	require.NotNil(t, actual)
+	require.Equal(t, "fake-task-id", actual.RequestId)
+	require.Equal(t, "fake-project", actual.Builder.Project)
+	require.Equal(t, "fake-bucket", actual.Builder.Bucket)
+	require.Equal(t, "Build-Linux", actual.Builder.Builder)
+	require.Len(t, actual.Dimensions, 2)
+	require.Equal(t, "os", actual.Dimensions[0].Key)
+	require.Equal(t, "Linux", actual.Dimensions[0].Value)
+	require.Len(t, actual.Tags, 2)
+	require.Equal(t, "sk_name", actual.Tags[1].Key)
+	require.Equal(t, "Build-Linux", actual.Tags[1].Value)
+	require.Equal(t, time.Hour, actual.ExecutionTimeout.AsDuration())
+	require.Equal(t, 4*time.Hour, actual.SchedulingTimeout.AsDuration())
+	require.Equal(t, map[string]interface{}{
+		"cas_input":    fakeDigest,
+		"cas_instance": "fake-cas-instance",
+		"cipd_packages": []interface{}{
+			map[string]interface{}{"name": "package", "path": "path", "version": "version"},
+		},
+		"command":      []interface{}{"run", "it"},
+		"env":          map[string]interface{}{"K": "V"},
+		"env_prefixes": map[string]interface{}{"PATH": []interface{}{"bin"}},
+		"idempotent":   false,
+		"outputs":      []interface{}{"out"},
+		"task_id":      "fake-task-id",
+	}, actual.Properties.AsMap()[PropertyTaskRequest])
+}
+
+func TestTriggerTask_InvalidDimension_ReturnsError(t *testing.T) {
+	b, _ := setup(t)
+	_, err := b.TriggerTask(context.Background(), &types.TaskRequest{
+		Dimensions: []string{"bogus"},
+		Name:       "Build-Linux",
+	})
+	require.ErrorContains(t, err, "invalid dimension")
+}
+
+func TestGetTaskResult(t *testing.T) {
+	ctx := context.Background()
+	b, client := setup(t)
+
+	outputProps, err := structpb.NewStruct(map[string]interface{}{
+		PropertyCasOutput: fakeDigest,
+	})
+	require.NoError(t, err)
+	client.EXPECT().GetBuild(gomock.Any(), &buildbucketpb.GetBuildRequest{
+		Id:     12345,
+		Fields: buildFields,
+	}).Return(&buildbucketpb.Build{
+		Id:         12345,
+		Status:     buildbucketpb.Status_FAILURE,
+		CreateTime: timestamppb.New(fakeTime),
+		StartTime:  timestamppb.New(fakeTime.Add(time.Minute)),
+		EndTime:    timestamppb.New(fakeTime.Add(time.Hour)),
+		Infra: &buildbucketpb.BuildInfra{
+			Swarming: &buildbucketpb.BuildInfra_Swarming{
+				BotDimensions: []*buildbucketpb.StringPair{
+					{Key: "os", Value: "Linux"},
+					{Key: "id", Value: "skia-bot-1"},
+				},
+			},
+		},
+		Output: &buildbucketpb.Build_Output{
+			Properties: outputProps,
+		},
+		Tags: []*buildbucketpb.StringPair{
+			{Key: types.SWARMING_TAG_ID, Value: "fake-task-id"},
+			{Key: "multi", Value: "a"},
+			{Key: "multi", Value: "b"},
+		},
+	}, nil)
+
+	res, err := b.GetTaskResult(ctx, "12345")
+	require.NoError(t, err)
+	require.Equal(t, &types.TaskResult{
+		CasOutput: fakeDigest,
+		Created:   fakeTime,
+		Finished:  fakeTime.Add(time.Hour),
+		ID:        "12345",
+		MachineID: "skia-bot-1",
+		Started:   fakeTime.Add(time.Minute),
+		Status:    types.TASK_STATUS_FAILURE,
+		Tags: map[string][]string{
+			types.SWARMING_TAG_ID: {"fake-task-id"},
+			"multi":               {"a", "b"},
+		},
+	}, res)
+}
+
+func TestGetTaskResult_InvalidID_ReturnsError(t *testing.T) {
+	b, _ := setup(t)
+	_, err := b.GetTaskResult(context.Background(), "not-a-build-id")
+	require.ErrorContains(t, err, "invalid Buildbucket build ID")
+}
+
+func TestGetTaskCompletionStatuses(t *testing.T) {
+	ctx := context.Background()
+	b, client := setup(t)
+
+	client.EXPECT().Batch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *buildbucketpb.BatchRequest, _ ...grpc.CallOption) (*buildbucketpb.BatchResponse, error) {
+		require.Len(t, req.Requests, 3)
+		require.Equal(t, int64(1), req.Requests[0].GetGetBuild().Id)
+		return &buildbucketpb.BatchResponse{
+			Responses: []*buildbucketpb.BatchResponse_Response{
+				{Response: &buildbucketpb.BatchResponse_Response_GetBuild{GetBuild: &buildbucketpb.Build{Id: 1, Status: buildbucketpb.Status_SCHEDULED}}},
+				{Response: &buildbucketpb.BatchResponse_Response_GetBuild{GetBuild: &buildbucketpb.Build{Id: 2, Status: buildbucketpb.Status_STARTED}}},
+				{Response: &buildbucketpb.BatchResponse_Response_GetBuild{GetBuild: &buildbucketpb.Build{Id: 3, Status: buildbucketpb.Status_INFRA_FAILURE}}},
+			},
+		}, nil
+	})
+
+	finished, err := b.GetTaskCompletionStatuses(ctx, []string{"1", "2", "3"})
+	require.NoError(t, err)
+	require.Equal(t, []bool{false, false, true}, finished)
+}
+
+func TestConvertTaskStatus(t *testing.T) {
+	test := func(status buildbucketpb.Status, expect types.TaskStatus) {
+		actual, err := convertTaskStatus(status)
+		require.NoError(t, err)
+		require.Equal(t, expect, actual)
+	}
+	test(buildbucketpb.Status_SCHEDULED, types.TASK_STATUS_PENDING)
+	test(buildbucketpb.Status_STARTED, types.TASK_STATUS_RUNNING)
+	test(buildbucketpb.Status_SUCCESS, types.TASK_STATUS_SUCCESS)
+	test(buildbucketpb.Status_FAILURE, types.TASK_STATUS_FAILURE)
+	test(buildbucketpb.Status_INFRA_FAILURE, types.TASK_STATUS_MISHAP)
+	test(buildbucketpb.Status_CANCELED, types.TASK_STATUS_MISHAP)
+
+	_, err := convertTaskStatus(buildbucketpb.Status_STATUS_UNSPECIFIED)
+	require.Error(t, err)
+}
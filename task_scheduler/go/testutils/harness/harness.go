@@ -0,0 +1,366 @@
+// Package harness provides a hermetic, fully-wired TaskScheduler for use in
+// end-to-end scheduling tests. All of the external dependencies (the database,
+// Swarming, git, RBE-CAS, Buildbucket, and the TaskCfgCache) are replaced with
+// in-memory or fake implementations, so no cloud credentials or emulators are
+// required.
+//
+// A typical test looks like this:
+//
+//	ctx, h := harness.New(t)
+//	rs := h.Commit(ctx, tcc_testutils.TasksCfg1)
+//	h.TriggerJobs(ctx, rs, tcc_testutils.BuildTaskName)
+//	h.MockBots(harness.MakeBot("bot1", map[string]string{"pool": "Skia", "os": "Ubuntu"}))
+//	h.RunMainLoop(ctx)
+//	tasks := h.Tasks(ctx)
+//	h.FinishTask(ctx, tasks[0], true, "")
+package harness
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apipb "go.chromium.org/luci/swarming/proto/api_v2"
+	bb_mocks "go.skia.org/infra/go/buildbucket/mocks"
+	cas_mocks "go.skia.org/infra/go/cas/mocks"
+	"go.skia.org/infra/go/cas/rbe"
+	"go.skia.org/infra/go/git/repograph"
+	"go.skia.org/infra/go/git/testutils/mem_git"
+	"go.skia.org/infra/go/gitstore"
+	"go.skia.org/infra/go/gitstore/mem_gitstore"
+	"go.skia.org/infra/go/now"
+	"go.skia.org/infra/go/sktest"
+	"go.skia.org/infra/go/swarming"
+	swarmingv2 "go.skia.org/infra/go/swarming/v2"
+	"go.skia.org/infra/go/testutils"
+	"go.skia.org/infra/task_scheduler/go/db/memory"
+	"go.skia.org/infra/task_scheduler/go/job_creation/buildbucket_taskbackend"
+	"go.skia.org/infra/task_scheduler/go/scheduling"
+	"go.skia.org/infra/task_scheduler/go/specs"
+	"go.skia.org/infra/task_scheduler/go/task_cfg_cache"
+	swarming_task_execution "go.skia.org/infra/task_scheduler/go/task_execution/swarmingv2"
+	swarming_testutils "go.skia.org/infra/task_scheduler/go/testutils"
+	"go.skia.org/infra/task_scheduler/go/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// RepoURL is the URL of the fake repo used by the Harness.
+	RepoURL = "fake.git"
+
+	// CASInstance is the RBE-CAS instance passed to the TaskScheduler.
+	CASInstance = "fake-cas-instance"
+
+	// BuildbucketTarget is the Buildbucket target used by the TaskBackend.
+	BuildbucketTarget = "skia://fake-task-scheduler"
+
+	// TaskSchedulerHost is the host used by the TaskBackend.
+	TaskSchedulerHost = "https://fake-task-scheduler"
+
+	// BuildbucketProject is the Buildbucket project which maps to RepoURL.
+	BuildbucketProject = "skia"
+)
+
+// Harness is a TaskScheduler wired up to in-memory and fake dependencies. The
+// exported fields may be used directly to mock additional behavior or to
+// inspect state.
+type Harness struct {
+	t sktest.TestingT
+
+	// Buildbucket is the mock Buildbucket client used by TaskBackend.
+	Buildbucket *bb_mocks.BuildBucketInterface
+	// CAS is the mock RBE-CAS client. Merge is mocked to return a
+	// deterministic digest for any set of inputs.
+	CAS *cas_mocks.CAS
+	// DB is the in-memory task and job database.
+	DB *memory.InMemoryDB
+	// Git is used to create commits in the repo at RepoURL.
+	Git *mem_git.MemGit
+	// Repos contains the single repo at RepoURL.
+	Repos repograph.Map
+	// Scheduler is the TaskScheduler under test.
+	Scheduler *scheduling.TaskScheduler
+	// Swarming is the fake Swarming server used by the task executor.
+	Swarming *swarming_testutils.TestClient
+	// TaskBackend implements the Buildbucket TaskBackend, backed by DB.
+	TaskBackend *buildbucket_taskbackend.TaskBackend
+	// TaskCfgCache is an in-memory TaskCfgCache.
+	TaskCfgCache task_cfg_cache.TaskCfgCache
+}
+
+// New returns a Harness with a single initial commit in the repo, and a
+// Context which should be used for all calls to the Harness. All resources are
+// cleaned up when the test finishes.
+func New(t sktest.TestingT) (context.Context, *Harness) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := memory.NewInMemoryDB()
+	swarmingClient := swarming_testutils.NewTestClient()
+
+	gs := mem_gitstore.New()
+	mg := mem_git.New(t, gs)
+	ri, err := gitstore.NewGitStoreRepoImpl(ctx, gs)
+	require.NoError(t, err)
+	repo, err := repograph.NewWithRepoImpl(ctx, ri)
+	require.NoError(t, err)
+	mg.AddUpdater(repo)
+	mg.Commit("Initial commit")
+	repos := repograph.Map{
+		RepoURL: repo,
+	}
+
+	cas := &cas_mocks.CAS{}
+	cas.On("Close").Return(nil)
+	cas.On("Merge", mock.Anything, mock.Anything).Return(mergeDigests, nil)
+
+	taskCfgCache := newMemTaskCfgCache()
+	taskExec := swarming_task_execution.NewSwarmingV2TaskExecutor(swarmingClient, CASInstance, "")
+	taskExecs := map[string]types.TaskExecutor{
+		types.TaskExecutor_Swarming:   taskExec,
+		types.TaskExecutor_UseDefault: taskExec,
+	}
+	s, err := scheduling.NewTaskScheduler(ctx, d, nil, time.Duration(math.MaxInt64), 0, repos, cas, CASInstance, taskExecs, nil, 1.0, swarming.POOLS_PUBLIC, "", taskCfgCache, nil, nil, "", scheduling.BusyBotsDebugLoggingOff, nil, scheduling.StarvationProtection{}, scheduling.BotAffinity{}, nil, scheduling.Quarantine{})
+	require.NoError(t, err)
+
+	bb := bb_mocks.NewBuildBucketInterface(t)
+	projectRepoMapping := map[string]string{
+		BuildbucketProject: RepoURL,
+	}
+	taskBackend := buildbucket_taskbackend.NewTaskBackend(BuildbucketTarget, TaskSchedulerHost, projectRepoMapping, d, bb)
+
+	t.Cleanup(func() {
+		testutils.AssertCloses(t, s)
+		cancel()
+	})
+	return ctx, &Harness{
+		t:            t,
+		Buildbucket:  bb,
+		CAS:          cas,
+		DB:           d,
+		Git:          mg,
+		Repos:        repos,
+		Scheduler:    s,
+		Swarming:     swarmingClient,
+		TaskBackend:  taskBackend,
+		TaskCfgCache: taskCfgCache,
+	}
+}
+
+// Commit creates a new commit in the repo whose TasksCfg is the given cfg, and
+// returns its RepoState.
+func (h *Harness) Commit(ctx context.Context, cfg *specs.TasksCfg) types.RepoState {
+	rs := types.RepoState{
+		Repo:     RepoURL,
+		Revision: h.Git.Commit("Commit"),
+	}
+	require.NoError(h.t, h.TaskCfgCache.Set(ctx, rs, cfg, nil))
+	return rs
+}
+
+// TriggerJobs inserts new Jobs with the given names at the given RepoState
+// into the DB, as the JobCreator would, and returns them.
+func (h *Harness) TriggerJobs(ctx context.Context, rs types.RepoState, names ...string) []*types.Job {
+	jobs := make([]*types.Job, 0, len(names))
+	for _, name := range names {
+		j, err := task_cfg_cache.MakeJob(ctx, h.TaskCfgCache, rs, name, nil)
+		require.NoError(h.t, err)
+		jobs = append(jobs, j)
+	}
+	require.NoError(h.t, h.DB.PutJobs(ctx, jobs))
+	return jobs
+}
+
+// MakeBot returns a Machine with the given ID and dimensions.
+func MakeBot(id string, dims map[string]string) *types.Machine {
+	dimensions := make([]string, 0, len(dims))
+	for k, v := range dims {
+		dimensions = append(dimensions, fmt.Sprintf("%s:%s", k, v))
+	}
+	sort.Strings(dimensions)
+	return &types.Machine{
+		ID:         id,
+		Dimensions: dimensions,
+	}
+}
+
+// MockBots sets the bots which are known to the fake Swarming server,
+// replacing any which were previously set. Bots are considered free unless
+// they have been assigned a task which has not yet finished.
+func (h *Harness) MockBots(bots ...*types.Machine) {
+	swarmBots := make([]*apipb.BotInfo, 0, len(bots))
+	for _, bot := range bots {
+		dims, err := swarming.ParseDimensions(bot.Dimensions)
+		require.NoError(h.t, err)
+		swarmBots = append(swarmBots, &apipb.BotInfo{
+			BotId:      bot.ID,
+			Dimensions: swarmingv2.StringMapToBotDimensions(dims),
+		})
+	}
+	h.Swarming.MockBots(swarmBots)
+}
+
+// RunMainLoop runs a single scheduling cycle and asserts that it succeeded.
+// Any pending modifications to the DB are delivered to the TaskScheduler's
+// caches first.
+func (h *Harness) RunMainLoop(ctx context.Context) {
+	h.syncCaches(ctx)
+	require.NoError(h.t, h.Scheduler.MainLoop(ctx))
+}
+
+// syncCaches ensures that the TaskScheduler's caches have processed all of the
+// modifications made to the DB. InMemoryDB.Wait only guarantees that the
+// modifications have been received by the caches' goroutines, not that they
+// have been applied, so we follow up with an empty modification; once that has
+// been received, the previous one must have been fully processed.
+func (h *Harness) syncCaches(ctx context.Context) {
+	h.DB.Wait()
+	require.NoError(h.t, h.DB.PutTasks(ctx, []*types.Task{}))
+	require.NoError(h.t, h.DB.PutJobs(ctx, []*types.Job{}))
+	h.DB.Wait()
+}
+
+// Tasks returns all of the Tasks in the DB, sorted by creation time.
+func (h *Harness) Tasks(ctx context.Context) []*types.Task {
+	tasks, err := h.DB.GetTasksFromDateRange(ctx, time.Time{}, now.Now(ctx).Add(time.Minute), "")
+	require.NoError(h.t, err)
+	sort.Sort(types.TaskSlice(tasks))
+	return tasks
+}
+
+// Job returns the current version of the given Job from the DB.
+func (h *Harness) Job(ctx context.Context, id string) *types.Job {
+	job, err := h.DB.GetJobById(ctx, id)
+	require.NoError(h.t, err)
+	require.NotNil(h.t, job)
+	return job
+}
+
+// FinishTask marks the given Task as finished in the fake Swarming server and
+// delivers the pub/sub notification to the TaskScheduler, which updates the
+// Task in the DB. If casOutput is empty, a digest derived from the task ID is
+// used.
+func (h *Harness) FinishTask(ctx context.Context, task *types.Task, success bool, casOutput string) {
+	if casOutput == "" {
+		casOutput = mergeDigests(ctx, []string{task.Id})
+	}
+	hash, size, err := rbe.StringToDigest(casOutput)
+	require.NoError(h.t, err)
+	ts := now.Now(ctx)
+	found := false
+	h.Swarming.DoMockTasks(func(t *apipb.TaskRequestMetadataResponse) {
+		if t.TaskId != task.SwarmingTaskId {
+			return
+		}
+		found = true
+		res := t.TaskResult
+		res.State = apipb.TaskState_COMPLETED
+		res.Failure = !success
+		res.BotId = task.SwarmingBotId
+		if res.StartedTs == nil {
+			res.StartedTs = timestamppb.New(ts)
+		}
+		res.CompletedTs = timestamppb.New(ts)
+		res.CasOutputRoot = &apipb.CASReference{
+			CasInstance: CASInstance,
+			Digest: &apipb.Digest{
+				Hash:      hash,
+				SizeBytes: size,
+			},
+		}
+	})
+	require.True(h.t, found, "Task %s was not triggered on Swarming", task.Id)
+	require.True(h.t, h.Scheduler.HandleSwarmingPubSub(&swarming.PubSubTaskMessage{
+		SwarmingTaskId: task.SwarmingTaskId,
+		UserData:       task.Id,
+	}))
+}
+
+// mergeDigests returns a deterministic, well-formed CAS digest for the given
+// inputs. A single input digest is returned unchanged.
+func mergeDigests(_ context.Context, digests []string) string {
+	if len(digests) == 1 && strings.Contains(digests[0], "/") {
+		return digests[0]
+	}
+	sorted := append([]string{}, digests...)
+	sort.Strings(sorted)
+	return fmt.Sprintf("%x/%d", sha256.Sum256([]byte(strings.Join(sorted, ","))), len(sorted))
+}
+
+// memTaskCfgCache is an in-memory implementation of
+// task_cfg_cache.TaskCfgCache.
+type memTaskCfgCache struct {
+	cache map[types.RepoState]*task_cfg_cache.CachedValue
+	mtx   sync.Mutex
+}
+
+// newMemTaskCfgCache returns an empty memTaskCfgCache.
+func newMemTaskCfgCache() *memTaskCfgCache {
+	return &memTaskCfgCache{
+		cache: map[types.RepoState]*task_cfg_cache.CachedValue{},
+	}
+}
+
+// Cleanup implements task_cfg_cache.TaskCfgCache. Entries are never removed.
+func (c *memTaskCfgCache) Cleanup(_ context.Context, _ time.Duration) error {
+	return nil
+}
+
+// Close implements task_cfg_cache.TaskCfgCache.
+func (c *memTaskCfgCache) Close() error {
+	return nil
+}
+
+// Get implements task_cfg_cache.TaskCfgCache.
+func (c *memTaskCfgCache) Get(_ context.Context, rs types.RepoState) (*specs.TasksCfg, error, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	cv, ok := c.cache[rs]
+	if !ok {
+		return nil, nil, task_cfg_cache.ErrNoSuchEntry
+	}
+	if cv.Err != "" {
+		return nil, fmt.Errorf("%s", cv.Err), nil
+	}
+	return cv.Cfg, nil, nil
+}
+
+// Set implements task_cfg_cache.TaskCfgCache.
+func (c *memTaskCfgCache) Set(_ context.Context, rs types.RepoState, cfg *specs.TasksCfg, storedErr error) error {
+	errString := ""
+	if storedErr != nil {
+		errString = storedErr.Error()
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.cache[rs] = &task_cfg_cache.CachedValue{
+		RepoState: rs,
+		Cfg:       cfg,
+		Err:       errString,
+	}
+	return nil
+}
+
+// SetIfUnset implements task_cfg_cache.TaskCfgCache.
+func (c *memTaskCfgCache) SetIfUnset(ctx context.Context, rs types.RepoState, fn func(context.Context) (*task_cfg_cache.CachedValue, error)) (*task_cfg_cache.CachedValue, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if cv, ok := c.cache[rs]; ok {
+		return cv, nil
+	}
+	cv, err := fn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.cache[rs] = cv
+	return cv, nil
+}
+
+// Assert that memTaskCfgCache implements task_cfg_cache.TaskCfgCache.
+var _ task_cfg_cache.TaskCfgCache = &memTaskCfgCache{}
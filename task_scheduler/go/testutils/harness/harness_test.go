@@ -0,0 +1,67 @@
+package harness
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tcc_testutils "go.skia.org/infra/task_scheduler/go/task_cfg_cache/testutils"
+	"go.skia.org/infra/task_scheduler/go/types"
+)
+
+func TestHarness_BuildThenTest_JobSucceeds(t *testing.T) {
+	ctx, h := New(t)
+	rs := h.Commit(ctx, tcc_testutils.TasksCfg1)
+	jobs := h.TriggerJobs(ctx, rs, tcc_testutils.TestTaskName)
+	require.Len(t, jobs, 1)
+
+	// No bots, so nothing is triggered.
+	h.RunMainLoop(ctx)
+	require.Empty(t, h.Tasks(ctx))
+
+	// A Linux bot picks up the build task.
+	linuxBot := MakeBot("linux-bot", map[string]string{"pool": "Skia", "os": "Ubuntu"})
+	androidBot := MakeBot("android-bot", map[string]string{"pool": "Skia", "os": "Android", "device_type": "grouper"})
+	h.MockBots(linuxBot, androidBot)
+	h.RunMainLoop(ctx)
+	tasks := h.Tasks(ctx)
+	require.Len(t, tasks, 1)
+	build := tasks[0]
+	require.Equal(t, tcc_testutils.BuildTaskName, build.Name)
+	require.Equal(t, rs.Revision, build.Revision)
+	require.Equal(t, types.TASK_STATUS_PENDING, build.Status)
+
+	// Once the build finishes, the test task is triggered.
+	h.FinishTask(ctx, build, true, "")
+	h.RunMainLoop(ctx)
+	tasks = h.Tasks(ctx)
+	require.Len(t, tasks, 2)
+	require.Equal(t, types.TASK_STATUS_SUCCESS, tasks[0].Status)
+	test := tasks[1]
+	require.Equal(t, tcc_testutils.TestTaskName, test.Name)
+	require.Equal(t, types.TASK_STATUS_PENDING, test.Status)
+	require.Equal(t, types.JOB_STATUS_IN_PROGRESS, h.Job(ctx, jobs[0].Id).Status)
+
+	// Once the test finishes, the job is complete.
+	h.FinishTask(ctx, test, true, "")
+	h.RunMainLoop(ctx)
+	require.Equal(t, types.JOB_STATUS_SUCCESS, h.Job(ctx, jobs[0].Id).Status)
+}
+
+func TestHarness_TaskFails_JobFails(t *testing.T) {
+	ctx, h := New(t)
+	rs := h.Commit(ctx, tcc_testutils.TasksCfg1)
+	jobs := h.TriggerJobs(ctx, rs, tcc_testutils.BuildTaskName)
+	h.MockBots(MakeBot("linux-bot", map[string]string{"pool": "Skia", "os": "Ubuntu"}))
+	h.RunMainLoop(ctx)
+	tasks := h.Tasks(ctx)
+	require.Len(t, tasks, 1)
+
+	h.FinishTask(ctx, tasks[0], false, "")
+	h.RunMainLoop(ctx)
+	require.Equal(t, types.TASK_STATUS_FAILURE, h.Tasks(ctx)[0].Status)
+
+	// The build task has MaxAttempts > 1, so it is retried.
+	h.RunMainLoop(ctx)
+	require.Len(t, h.Tasks(ctx), 2)
+	require.Equal(t, types.JOB_STATUS_IN_PROGRESS, h.Job(ctx, jobs[0].Id).Status)
+}
@@ -0,0 +1,95 @@
+package tryjobs
+
+import (
+	"regexp"
+	"strings"
+
+	"go.skia.org/infra/go/git"
+	"go.skia.org/infra/go/skerr"
+)
+
+const (
+	// IncludeJobsFooter is a CL description footer with a comma-separated list
+	// of regular expressions. If present, only try jobs whose names match at
+	// least one of the expressions are run; the others are canceled.
+	IncludeJobsFooter = "Scheduler-Include-Jobs"
+
+	// ExcludeJobsFooter is a CL description footer with a comma-separated list
+	// of regular expressions. Try jobs whose names match any of the expressions
+	// are canceled instead of being run. It takes precedence over
+	// IncludeJobsFooter.
+	ExcludeJobsFooter = "Scheduler-Exclude-Jobs"
+
+	// RerunTasksFooter is a boolean CL description footer. If true, try jobs
+	// do not reuse the results of tasks which already ran for the same
+	// patchset, eg. to retry flaky tasks.
+	RerunTasksFooter = "Scheduler-Rerun-Tasks"
+)
+
+// jobFooters are the try job controls parsed from the footers of a CL
+// description.
+type jobFooters struct {
+	include    []*regexp.Regexp
+	exclude    []*regexp.Regexp
+	rerunTasks bool
+}
+
+// parseJobFooters parses the try job controls out of the given CL
+// description. Returns an error if any of the footers are invalid.
+func parseJobFooters(commitMsg string, issue int64) (*jobFooters, error) {
+	footersMap := git.GetFootersMap(commitMsg)
+	include, err := parsePatterns(footersMap, IncludeJobsFooter)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := parsePatterns(footersMap, ExcludeJobsFooter)
+	if err != nil {
+		return nil, err
+	}
+	return &jobFooters{
+		include:    include,
+		exclude:    exclude,
+		rerunTasks: git.GetBoolFooterVal(footersMap, RerunTasksFooter, issue),
+	}, nil
+}
+
+// parsePatterns parses the comma-separated list of regular expressions in the
+// given footer. Each expression must match the whole job name.
+func parsePatterns(footersMap map[string]string, footer string) ([]*regexp.Regexp, error) {
+	var rv []*regexp.Regexp
+	for _, p := range strings.Split(git.GetStringFooterVal(footersMap, footer), ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		re, err := regexp.Compile("^(?:" + p + ")$")
+		if err != nil {
+			// Include the footer name in the message itself, since it ends up
+			// in the StatusDetails of the Job.
+			return nil, skerr.Fmt("invalid %s footer: %s", footer, err)
+		}
+		rv = append(rv, re)
+	}
+	return rv, nil
+}
+
+func matchesAny(patterns []*regexp.Regexp, name string) bool {
+	for _, re := range patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipReason returns the reason why the job with the given name should not
+// be run, or the empty string if it should be run.
+func (f *jobFooters) skipReason(jobName string) string {
+	if matchesAny(f.exclude, jobName) {
+		return "Job is excluded by the " + ExcludeJobsFooter + " footer."
+	}
+	if len(f.include) > 0 && !matchesAny(f.include, jobName) {
+		return "Job is not included by the " + IncludeJobsFooter + " footer."
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package tryjobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJobFooters_NoFooters_RunsEverything(t *testing.T) {
+	f, err := parseJobFooters("Some CL\n\nWith a description.", 123)
+	require.NoError(t, err)
+	require.False(t, f.rerunTasks)
+	require.Empty(t, f.skipReason("Build-Debian-Release"))
+}
+
+func TestParseJobFooters_IncludeAndExclude(t *testing.T) {
+	f, err := parseJobFooters("Some CL\n\nScheduler-Include-Jobs: Build-.*, Test-Debian-Release\nScheduler-Exclude-Jobs: Build-Win.*\nScheduler-Rerun-Tasks: true", 123)
+	require.NoError(t, err)
+	require.True(t, f.rerunTasks)
+	require.Empty(t, f.skipReason("Build-Debian-Release"))
+	require.Empty(t, f.skipReason("Test-Debian-Release"))
+	// Patterns must match the whole job name.
+	require.Contains(t, f.skipReason("Test-Debian-Release-ASAN"), IncludeJobsFooter)
+	require.Contains(t, f.skipReason("Perf-Debian-Release"), IncludeJobsFooter)
+	// Exclusion takes precedence over inclusion.
+	require.Contains(t, f.skipReason("Build-Win-Release"), ExcludeJobsFooter)
+}
+
+func TestParseJobFooters_InvalidPattern_ReturnsError(t *testing.T) {
+	_, err := parseJobFooters("Some CL\n\nScheduler-Include-Jobs: Build-(", 123)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), IncludeJobsFooter)
+}
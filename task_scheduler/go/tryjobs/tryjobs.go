@@ -309,6 +309,20 @@ func (t *TryJobIntegrator) getRevision(ctx context.Context, repo *repograph.Grap
 	return c.Hash, nil
 }
 
+// getJobFooters retrieves the description of the given patchset and parses the
+// try job controls out of its footers.
+func (t *TryJobIntegrator) getJobFooters(ctx context.Context, issue, patchset string) (*jobFooters, error) {
+	issueNum, err := strconv.ParseInt(issue, 10, 64)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to parse issue number")
+	}
+	commit, err := t.gerrit.GetCommit(ctx, issueNum, patchset)
+	if err != nil {
+		return nil, skerr.Wrapf(err, "failed to get commit info")
+	}
+	return parseJobFooters(commit.Message, issueNum)
+}
+
 func (t *TryJobIntegrator) localCancelJobs(ctx context.Context, jobs []*types.Job, reasons []string) error {
 	if len(jobs) != len(reasons) {
 		return skerr.Fmt("expected jobs and reasons to have the same length")
@@ -518,6 +532,9 @@ func (t *TryJobIntegrator) startJob(ctx context.Context, job *types.Job) error {
 	}
 
 	sklog.Infof("Starting job %s (build %d); lease key: %d, %+v", job.Id, job.BuildbucketBuildId, job.BuildbucketLeaseKey, job.RepoState)
+	// skipReason is set if the CL description footers indicate that the Job
+	// should not run.
+	skipReason := ""
 	startJobHelper := func() error {
 		sklog.Infof("Retrieving repo state information for job %s (build %d): %+v", job.Id, job.BuildbucketBuildId, job.RepoState)
 		repoGraph, err := t.getRepo(job.Repo)
@@ -579,6 +596,17 @@ func (t *TryJobIntegrator) startJob(ctx context.Context, job *types.Job) error {
 		if len(prevJobs) > 0 {
 			job.IsForce = true
 		}
+
+		// Apply any controls from the footers of the CL description.
+		sklog.Infof("Reading CL description footers for job %s (build %d): %+v", job.Id, job.BuildbucketBuildId, job.RepoState)
+		footers, err := t.getJobFooters(ctx, job.Issue, job.Patchset)
+		if err != nil {
+			return skerr.Wrap(err)
+		}
+		if footers.rerunTasks {
+			job.IsForce = true
+		}
+		skipReason = footers.skipReason(job.Name)
 		sklog.Infof("Ready to start job %s (build %d): %+v", job.Id, job.BuildbucketBuildId, job.RepoState)
 		return nil
 	}
@@ -609,6 +637,13 @@ func (t *TryJobIntegrator) startJob(ctx context.Context, job *types.Job) error {
 		job.Finished = now.Now(ctx)
 		job.Status = types.JOB_STATUS_MISHAP
 		job.StatusDetails = util.Truncate(fmt.Sprintf("Failed to start Job: %s", skerr.Unwrap(startJobErr)), 1024)
+	} else if skipReason != "" {
+		// The Job was started so that Buildbucket knows about it, but it
+		// should not actually run.
+		sklog.Infof("Skipping job %s (build %d): %s", job.Id, job.BuildbucketBuildId, skipReason)
+		job.Finished = now.Now(ctx)
+		job.Status = types.JOB_STATUS_CANCELED
+		job.StatusDetails = skipReason
 	} else {
 		job.Status = types.JOB_STATUS_IN_PROGRESS
 		job.Started = now.Now(ctx)
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"go.skia.org/infra/go/testutils"
 	"go.skia.org/infra/task_scheduler/go/db"
 	"go.skia.org/infra/task_scheduler/go/job_creation/buildbucket_taskbackend"
+	tcc_testutils "go.skia.org/infra/task_scheduler/go/task_cfg_cache/testutils"
 	"go.skia.org/infra/task_scheduler/go/types"
 	"google.golang.org/protobuf/proto"
 )
@@ -517,6 +519,7 @@ func TestStartJobV2_NormalJob_Succeeds(t *testing.T) {
 	require.NoError(t, trybots.db.PutJob(ctx, j1))
 	oldToken := j1.BuildbucketToken
 	mockGetChangeInfo(t, mock, gerritIssue, patchProject, git.MainBranch)
+	mockGetCommit(t, mock, "Normal CL")
 	mockBB.On("StartBuild", testutils.AnyContext, j1.BuildbucketBuildId, j1.Id, j1.BuildbucketToken).Return(bbFakeUpdateToken, nil)
 	require.NoError(t, trybots.startJob(ctx, j1))
 	j1, err := trybots.db.GetJobById(ctx, j1.Id)
@@ -539,6 +542,7 @@ func TestStartJobV2_RevisionAlreadySet_Succeeds(t *testing.T) {
 	require.NoError(t, trybots.db.PutJob(ctx, j1))
 	oldToken := j1.BuildbucketToken
 	mockGetChangeInfo(t, mock, gerritIssue, patchProject, git.MainBranch)
+	mockGetCommit(t, mock, "Normal CL")
 	mockBB.On("StartBuild", testutils.AnyContext, j1.BuildbucketBuildId, j1.Id, j1.BuildbucketToken).Return(bbFakeUpdateToken, nil)
 	require.NoError(t, trybots.startJob(ctx, j1))
 	j1, err := trybots.db.GetJobById(ctx, j1.Id)
@@ -609,6 +613,78 @@ func mockGetChangeInfo(t *testing.T, mock *mockhttpclient.URLMock, id int, proje
 	mock.Mock(fmt.Sprintf("%s/a%s", fakeGerritUrl, fmt.Sprintf(gerrit.URLTmplChange, ci.Id)), mockhttpclient.MockGetDialogue(issueBytes))
 }
 
+// mockGetCommit mocks the Gerrit request for the commit info of the patchset
+// used by the test try jobs, with the given commit message.
+func mockGetCommit(t *testing.T, mock *mockhttpclient.URLMock, message string) {
+	ci := &gerrit.CommitInfo{
+		Commit:  commit2.Hash,
+		Subject: strings.Split(message, "\n")[0],
+		Message: message,
+	}
+	commitBytes, err := json.Marshal(ci)
+	require.NoError(t, err)
+	commitBytes = append([]byte("XSS\n"), commitBytes...)
+	mock.Mock(fmt.Sprintf("%s/a/changes/%d/revisions/%d/commit", fakeGerritUrl, gerritIssue, gerritPatchset), mockhttpclient.MockGetDialogue(commitBytes))
+}
+
+// startJobWithFooters starts a try job for a CL with the given description
+// and returns the updated job.
+func startJobWithFooters(t *testing.T, message string) *types.Job {
+	ctx, trybots, mock, mockBB, _ := setup(t)
+
+	j1 := tryjobV2(ctx)
+	j1.Status = types.JOB_STATUS_REQUESTED
+	require.NoError(t, trybots.db.PutJob(ctx, j1))
+	mockGetChangeInfo(t, mock, gerritIssue, patchProject, git.MainBranch)
+	mockGetCommit(t, mock, message)
+	mockBB.On("StartBuild", testutils.AnyContext, j1.BuildbucketBuildId, j1.Id, j1.BuildbucketToken).Return(bbFakeUpdateToken, nil)
+	require.NoError(t, trybots.startJob(ctx, j1))
+	j1, err := trybots.db.GetJobById(ctx, j1.Id)
+	require.NoError(t, err)
+	return j1
+}
+
+func TestStartJobV2_ExcludedByFooter_JobIsCanceled(t *testing.T) {
+	j := startJobWithFooters(t, "Skip some jobs\n\nScheduler-Exclude-Jobs: Other-Job, "+tcc_testutils.BuildTaskName+".*")
+	require.Equal(t, types.JOB_STATUS_CANCELED, j.Status)
+	require.Contains(t, j.StatusDetails, ExcludeJobsFooter)
+	require.False(t, j.Finished.IsZero())
+}
+
+func TestStartJobV2_NotIncludedByFooter_JobIsCanceled(t *testing.T) {
+	j := startJobWithFooters(t, "Run other jobs\n\nScheduler-Include-Jobs: Other-Job")
+	require.Equal(t, types.JOB_STATUS_CANCELED, j.Status)
+	require.Contains(t, j.StatusDetails, IncludeJobsFooter)
+}
+
+func TestStartJobV2_IncludedByFooter_JobRuns(t *testing.T) {
+	j := startJobWithFooters(t, "Run some jobs\n\nScheduler-Include-Jobs: Other-Job,"+tcc_testutils.BuildTaskName+".*")
+	require.Equal(t, types.JOB_STATUS_IN_PROGRESS, j.Status)
+	require.False(t, j.IsForce)
+}
+
+func TestStartJobV2_RerunTasksFooter_JobIsForced(t *testing.T) {
+	j := startJobWithFooters(t, "Retry flakes\n\nScheduler-Rerun-Tasks: true")
+	require.Equal(t, types.JOB_STATUS_IN_PROGRESS, j.Status)
+	require.True(t, j.IsForce)
+}
+
+func TestStartJobV2_InvalidFooter_JobIsMishap(t *testing.T) {
+	ctx, trybots, mock, mockBB, _ := setup(t)
+
+	j1 := tryjobV2(ctx)
+	j1.Status = types.JOB_STATUS_REQUESTED
+	require.NoError(t, trybots.db.PutJob(ctx, j1))
+	mockGetChangeInfo(t, mock, gerritIssue, patchProject, git.MainBranch)
+	mockGetCommit(t, mock, "Bad footer\n\nScheduler-Exclude-Jobs: Build-(")
+	mockBB.On("StartBuild", testutils.AnyContext, j1.BuildbucketBuildId, j1.Id, j1.BuildbucketToken).Return(bbFakeUpdateToken, nil)
+	require.Error(t, trybots.startJob(ctx, j1))
+	j1, err := trybots.db.GetJobById(ctx, j1.Id)
+	require.NoError(t, err)
+	require.Equal(t, types.JOB_STATUS_MISHAP, j1.Status)
+	require.Contains(t, j1.StatusDetails, ExcludeJobsFooter)
+}
+
 func TestRetryV2(t *testing.T) {
 	ctx, trybots, mock, mockBB, _ := setup(t)
 
@@ -620,6 +696,7 @@ func TestRetryV2(t *testing.T) {
 	j1.Status = types.JOB_STATUS_REQUESTED
 	require.NoError(t, trybots.db.PutJob(ctx, j1))
 	mockGetChangeInfo(t, mock, gerritIssue, patchProject, git.MainBranch)
+	mockGetCommit(t, mock, "Normal CL")
 	mockBB.On("StartBuild", testutils.AnyContext, j1.BuildbucketBuildId, j1.Id, j1.BuildbucketToken).Return(bbFakeUpdateToken, nil)
 	require.NoError(t, trybots.startJob(ctx, j1))
 	mockBB.AssertExpectations(t)
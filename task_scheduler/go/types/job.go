@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"go.skia.org/infra/go/sklog"
@@ -39,6 +40,21 @@ const (
 	// DEFAULT_MAX_TASK_ATTEMPTS is the maximum number of attempts we'll
 	// make of each TaskSpec in a Job.
 	DEFAULT_MAX_TASK_ATTEMPTS = 2
+
+	// RETRY_ON_ANY indicates that tasks which failed or had a mishap may be
+	// retried.
+	RETRY_ON_ANY = "any"
+
+	// RETRY_ON_FAILURE indicates that only tasks which failed may be retried.
+	RETRY_ON_FAILURE = "failure"
+
+	// RETRY_ON_MISHAP indicates that only tasks which had a mishap may be
+	// retried.
+	RETRY_ON_MISHAP = "mishap"
+
+	// DEFAULT_RETRY_BACKOFF_MULTIPLIER is the default
+	// RetryPolicy.BackoffMultiplier.
+	DEFAULT_RETRY_BACKOFF_MULTIPLIER = 2.0
 )
 
 var (
@@ -125,6 +141,11 @@ type Job struct {
 	// for this Job, or zero if the job is new.
 	DbModified time.Time `json:"dbModified"`
 
+	// ExternalDependencies are tasks in other repos which must succeed before
+	// any of the Job's tasks are triggered. Their revisions are always
+	// resolved. This property should never change for a given Job instance.
+	ExternalDependencies []*ExternalDependency `json:"externalDependencies,omitempty"`
+
 	// Dependencies maps out the DAG of TaskSpec names upon which this Job
 	// depends. Keys are TaskSpec names and values are slices of TaskSpec
 	// names indicating which TaskSpecs that TaskSpec depends on. This
@@ -149,6 +170,11 @@ type Job struct {
 	// should never change for a given Job instance.
 	Name string `json:"name"`
 
+	// Parameters are the values of the JobSpec's parameters for this Job,
+	// keyed by parameter name. This property should never change for a given
+	// Job instance.
+	Parameters map[string]string `json:"parameters,omitempty"`
+
 	// Priority is an indicator of the relative priority of this Job.
 	Priority float64 `json:"priority"`
 
@@ -173,6 +199,10 @@ type Job struct {
 	// logs.
 	StatusDetails string `json:"statusDetails"`
 
+	// RetryPolicy determines how the Job's tasks are retried. If nil, the
+	// tasks are retried according to their TaskSpecs.
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
 	// Tasks are the Task instances which satisfied the dependencies of
 	// the Job. Keys are TaskSpec names and values are slices of TaskSummary
 	// instances describing the Tasks.
@@ -201,6 +231,20 @@ func (j *Job) Copy() *Job {
 			tasks[k] = cpy
 		}
 	}
+	var externalDeps []*ExternalDependency
+	if j.ExternalDependencies != nil {
+		externalDeps = make([]*ExternalDependency, 0, len(j.ExternalDependencies))
+		for _, d := range j.ExternalDependencies {
+			externalDeps = append(externalDeps, d.Copy())
+		}
+	}
+	var params map[string]string
+	if j.Parameters != nil {
+		params = make(map[string]string, len(j.Parameters))
+		for k, v := range j.Parameters {
+			params[k] = v
+		}
+	}
 	return &Job{
 		BuildbucketBuildId:     j.BuildbucketBuildId,
 		BuildbucketLeaseKey:    j.BuildbucketLeaseKey,
@@ -209,13 +253,16 @@ func (j *Job) Copy() *Job {
 		Created:                j.Created,
 		DbModified:             j.DbModified,
 		Dependencies:           deps,
+		ExternalDependencies:   externalDeps,
 		Finished:               j.Finished,
 		Id:                     j.Id,
 		IsForce:                j.IsForce,
 		Name:                   j.Name,
+		Parameters:             params,
 		Priority:               j.Priority,
 		RepoState:              j.RepoState.Copy(),
 		Requested:              j.Requested,
+		RetryPolicy:            j.RetryPolicy.Copy(),
 		Started:                j.Started,
 		Status:                 j.Status,
 		StatusDetails:          j.StatusDetails,
@@ -299,6 +346,10 @@ func (j *Job) DeriveStatus() JobStatus {
 			maxAttempts = DEFAULT_MAX_TASK_ATTEMPTS
 		}
 		canRetry := len(tasks) < maxAttempts
+		if j.RetryPolicy != nil {
+			maxAttempts = j.RetryPolicy.GetMaxAttempts(maxAttempts)
+			canRetry = len(tasks) < maxAttempts && j.RetryPolicy.ShouldRetry(tasks[len(tasks)-1].Status)
+		}
 		bestStatus := JOB_STATUS_MISHAP
 		for _, t := range tasks {
 			status := JobStatusFromTaskStatus(t.Status)
@@ -324,6 +375,161 @@ func (j *Job) DeriveStatus() JobStatus {
 	return worstStatus
 }
 
+// RetryPolicy determines how the tasks of a Job are retried after they fail
+// or have a mishap. It is specified in the JobSpec and copied to each Job.
+//
+// Since a task may be shared by several Jobs, it is retried if any of its
+// Jobs would retry it. Jobs without a RetryPolicy retry their tasks
+// immediately, according to TaskSpec.MaxAttempts.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of each of the Job's
+	// tasks, including the first. If zero, TaskSpec.MaxAttempts is used.
+	MaxAttempts int `json:"max_attempts,omitempty"`
+
+	// Backoff is how long to wait after the first attempt of a task finishes
+	// before retrying it. Each subsequent wait is BackoffMultiplier times
+	// longer than the previous one. If zero, tasks are retried immediately.
+	Backoff time.Duration `json:"backoff_ns,omitempty"`
+
+	// BackoffMultiplier is the factor by which the wait grows with each
+	// retry, with m >= 1. If zero, DEFAULT_RETRY_BACKOFF_MULTIPLIER is used.
+	BackoffMultiplier float64 `json:"backoff_multiplier,omitempty"`
+
+	// MaxBackoff is the maximum wait between attempts. If zero, the wait is
+	// not limited.
+	MaxBackoff time.Duration `json:"max_backoff_ns,omitempty"`
+
+	// RetryOn is one of the RETRY_ON_* constants, indicating which results
+	// may be retried. If empty, RETRY_ON_ANY is used.
+	RetryOn string `json:"retry_on,omitempty"`
+}
+
+// Validate returns an error if the RetryPolicy is not valid.
+func (p *RetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("Retry policy max_attempts must not be negative; got %d", p.MaxAttempts)
+	}
+	if p.Backoff < 0 || p.MaxBackoff < 0 {
+		return fmt.Errorf("Retry policy backoff must not be negative")
+	}
+	if p.BackoffMultiplier != 0 && p.BackoffMultiplier < 1 {
+		return fmt.Errorf("Retry policy backoff_multiplier must be at least 1; got %f", p.BackoffMultiplier)
+	}
+	switch p.RetryOn {
+	case "", RETRY_ON_ANY, RETRY_ON_FAILURE, RETRY_ON_MISHAP:
+	default:
+		return fmt.Errorf("Invalid retry policy retry_on %q", p.RetryOn)
+	}
+	return nil
+}
+
+// Copy returns a copy of the RetryPolicy.
+func (p *RetryPolicy) Copy() *RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	rv := *p
+	return &rv
+}
+
+// GetMaxAttempts returns the maximum number of attempts of each task, given
+// the maximum number of attempts from its TaskSpec.
+func (p *RetryPolicy) GetMaxAttempts(taskSpecMaxAttempts int) int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts
+	}
+	return taskSpecMaxAttempts
+}
+
+// ShouldRetry returns true iff a task with the given status may be retried.
+func (p *RetryPolicy) ShouldRetry(status TaskStatus) bool {
+	switch status {
+	case TASK_STATUS_FAILURE:
+		return p.RetryOn != RETRY_ON_MISHAP
+	case TASK_STATUS_MISHAP:
+		return p.RetryOn != RETRY_ON_FAILURE
+	}
+	return false
+}
+
+// GetBackoff returns how long to wait after the previous attempt of a task
+// finished before starting the given attempt, where attempt 1 is the first
+// retry.
+func (p *RetryPolicy) GetBackoff(attempt int) time.Duration {
+	if p.Backoff <= 0 || attempt < 1 {
+		return 0
+	}
+	multiplier := p.BackoffMultiplier
+	if multiplier == 0 {
+		multiplier = DEFAULT_RETRY_BACKOFF_MULTIPLIER
+	}
+	backoff := float64(p.Backoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	if backoff > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(backoff)
+}
+
+// ExternalDependency is a dependency of a Job on a task in another repo, at a
+// pinned revision, eg. a DEPS roll which must wait for a task at the revision
+// it rolls to. None of the Job's tasks are triggered until a task with the
+// given name has succeeded at that revision. It is specified in the JobSpec
+// and copied to each Job.
+type ExternalDependency struct {
+	// Repo is the URL of the other repo. It must be one of the repos known to
+	// the Task Scheduler.
+	Repo string `json:"repo"`
+
+	// Task is the name of the TaskSpec in the other repo.
+	Task string `json:"task"`
+
+	// Revision is the commit of the other repo at which the task must have
+	// succeeded. Either Revision or DepsEntry must be specified.
+	Revision string `json:"revision,omitempty"`
+
+	// DepsEntry is the ID of an entry in the DEPS file of the Job's repo, eg.
+	// "skia.googlesource.com/skia". If specified, Revision is set to the
+	// revision pinned by that entry when the TasksCfg is read from the repo.
+	DepsEntry string `json:"deps_entry,omitempty"`
+}
+
+// Validate returns an error if the ExternalDependency is not valid.
+func (d *ExternalDependency) Validate() error {
+	if d.Repo == "" {
+		return fmt.Errorf("External dependency repo is required")
+	}
+	if d.Task == "" {
+		return fmt.Errorf("External dependency task is required")
+	}
+	if d.Revision == "" && d.DepsEntry == "" {
+		return fmt.Errorf("External dependency on %s in %s requires either revision or deps_entry", d.Task, d.Repo)
+	}
+	return nil
+}
+
+// Copy returns a copy of the ExternalDependency.
+func (d *ExternalDependency) Copy() *ExternalDependency {
+	if d == nil {
+		return nil
+	}
+	rv := *d
+	return &rv
+}
+
+// TaskKey returns the TaskKey of the task upon which the Job depends.
+func (d *ExternalDependency) TaskKey() TaskKey {
+	return TaskKey{
+		RepoState: RepoState{
+			Repo:     d.Repo,
+			Revision: d.Revision,
+		},
+		Name: d.Task,
+	}
+}
+
 // JobSlice implements sort.Interface. To sort jobs []*Job, use
 // sort.Sort(JobSlice(jobs)).
 type JobSlice []*Job
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math"
 	"sort"
 	"testing"
 	"time"
@@ -102,3 +103,69 @@ func TestJobDeriveStatus(t *testing.T) {
 	t3.Status = TASK_STATUS_SUCCESS
 	require.Equal(t, j1.DeriveStatus(), JOB_STATUS_SUCCESS)
 }
+
+func TestJobDeriveStatus_RetryPolicy(t *testing.T) {
+	test := func(name string, policy *RetryPolicy, expect JobStatus, statuses ...TaskStatus) {
+		t.Run(name, func(t *testing.T) {
+			tasks := make([]*TaskSummary, 0, len(statuses))
+			for _, status := range statuses {
+				tasks = append(tasks, &TaskSummary{
+					MaxAttempts: 2,
+					Status:      status,
+				})
+			}
+			j := &Job{
+				Dependencies: map[string][]string{"build": {}},
+				RetryPolicy:  policy,
+				Tasks:        map[string][]*TaskSummary{"build": tasks},
+			}
+			require.Equal(t, expect, j.DeriveStatus())
+		})
+	}
+
+	test("no policy, retry left", nil, JOB_STATUS_IN_PROGRESS, TASK_STATUS_FAILURE)
+	test("no policy, no retries left", nil, JOB_STATUS_FAILURE, TASK_STATUS_FAILURE, TASK_STATUS_FAILURE)
+	test("more attempts", &RetryPolicy{MaxAttempts: 3}, JOB_STATUS_IN_PROGRESS, TASK_STATUS_FAILURE, TASK_STATUS_FAILURE)
+	test("more attempts exhausted", &RetryPolicy{MaxAttempts: 3}, JOB_STATUS_FAILURE, TASK_STATUS_FAILURE, TASK_STATUS_FAILURE, TASK_STATUS_MISHAP)
+	test("fewer attempts", &RetryPolicy{MaxAttempts: 1}, JOB_STATUS_FAILURE, TASK_STATUS_FAILURE)
+	test("mishap only, failure", &RetryPolicy{RetryOn: RETRY_ON_MISHAP}, JOB_STATUS_FAILURE, TASK_STATUS_FAILURE)
+	test("mishap only, mishap", &RetryPolicy{RetryOn: RETRY_ON_MISHAP}, JOB_STATUS_IN_PROGRESS, TASK_STATUS_MISHAP)
+	test("failure only, mishap", &RetryPolicy{RetryOn: RETRY_ON_FAILURE}, JOB_STATUS_MISHAP, TASK_STATUS_MISHAP)
+	test("failure only, failure", &RetryPolicy{RetryOn: RETRY_ON_FAILURE}, JOB_STATUS_IN_PROGRESS, TASK_STATUS_FAILURE)
+}
+
+func TestRetryPolicyValidate(t *testing.T) {
+	test := func(name string, p RetryPolicy, expectErr string) {
+		t.Run(name, func(t *testing.T) {
+			err := p.Validate()
+			if expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, expectErr)
+			}
+		})
+	}
+
+	test("empty", RetryPolicy{}, "")
+	test("full", RetryPolicy{MaxAttempts: 5, Backoff: time.Minute, BackoffMultiplier: 1.5, MaxBackoff: time.Hour, RetryOn: RETRY_ON_MISHAP}, "")
+	test("negative attempts", RetryPolicy{MaxAttempts: -1}, "max_attempts must not be negative")
+	test("negative backoff", RetryPolicy{Backoff: -time.Minute}, "backoff must not be negative")
+	test("small multiplier", RetryPolicy{BackoffMultiplier: 0.5}, "backoff_multiplier must be at least 1")
+	test("bad retry_on", RetryPolicy{RetryOn: "success"}, "Invalid retry policy retry_on")
+}
+
+func TestRetryPolicyGetBackoff(t *testing.T) {
+	test := func(name string, p RetryPolicy, attempt int, expect time.Duration) {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, expect, p.GetBackoff(attempt))
+		})
+	}
+
+	test("no backoff", RetryPolicy{}, 3, 0)
+	test("first attempt", RetryPolicy{Backoff: time.Minute}, 0, 0)
+	test("first retry", RetryPolicy{Backoff: time.Minute}, 1, time.Minute)
+	test("default multiplier", RetryPolicy{Backoff: time.Minute}, 3, 4*time.Minute)
+	test("custom multiplier", RetryPolicy{Backoff: time.Minute, BackoffMultiplier: 3}, 3, 9*time.Minute)
+	test("max backoff", RetryPolicy{Backoff: time.Minute, MaxBackoff: 5 * time.Minute}, 10, 5*time.Minute)
+	test("overflow", RetryPolicy{Backoff: time.Hour}, 100, time.Duration(math.MaxInt64))
+}
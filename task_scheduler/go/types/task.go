@@ -39,13 +39,14 @@ const (
 	MILO_HOST = "https://ci.chromium.org/raw/build/%s"
 
 	// Types of task executors.
-	TaskExecutor_UseDefault = ""
-	TaskExecutor_Swarming   = "swarming"
-	DefaultTaskExecutor     = TaskExecutor_Swarming
+	TaskExecutor_UseDefault  = ""
+	TaskExecutor_Swarming    = "swarming"
+	TaskExecutor_Buildbucket = "buildbucket"
+	DefaultTaskExecutor      = TaskExecutor_Swarming
 )
 
 var (
-	ValidTaskExecutors = []string{TaskExecutor_UseDefault, TaskExecutor_Swarming}
+	ValidTaskExecutors = []string{TaskExecutor_UseDefault, TaskExecutor_Swarming, TaskExecutor_Buildbucket}
 )
 
 type TaskStatus string
@@ -129,9 +130,11 @@ type Task struct {
 	// URL-safe.
 	Id string `json:"id"`
 
-	// IsolatedOutput is the isolated hash of any outputs produced by this Task.
-	// Filled in when the task is completed. This field will not be set if the
-	// Task does not correspond to a Swarming task.
+	// IsolatedOutput is the RBE-CAS digest of any outputs produced by this
+	// Task, which are provided as inputs to any Tasks which depend on it. Filled
+	// in when the task is completed. This field will not be set if the Task does
+	// not correspond to a Swarming task. The name predates the move from Isolate
+	// to RBE-CAS and is kept for compatibility with existing data.
 	IsolatedOutput string `json:"isolatedOutput"`
 
 	// Jobs are the IDs of all Jobs which utilized this Task.
@@ -273,7 +276,7 @@ func (orig *Task) UpdateFromTaskResult(res *TaskResult) (bool, error) {
 	// Status.
 	copy.Status = res.Status
 
-	// Isolated output.
+	// CAS output.
 	copy.IsolatedOutput = res.CasOutput
 
 	// Bot.
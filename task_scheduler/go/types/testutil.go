@@ -47,15 +47,31 @@ func MakeFullJob(now time.Time) *Job {
 		Created:                now.Add(time.Nanosecond),
 		DbModified:             now.Add(time.Millisecond),
 		Dependencies:           map[string][]string{"A": {"B"}, "B": {}},
-		Finished:               now.Add(time.Second),
-		Id:                     "abc123",
-		IsForce:                true,
-		Name:                   "C",
-		Priority:               1.2,
+		ExternalDependencies: []*ExternalDependency{
+			{
+				Repo:      common.REPO_SKIA,
+				Task:      "Build-Skia",
+				Revision:  "abc123",
+				DepsEntry: "skia.googlesource.com/skia",
+			},
+		},
+		Finished:   now.Add(time.Second),
+		Id:         "abc123",
+		IsForce:    true,
+		Name:       "C",
+		Parameters: map[string]string{"GN_ARGS": "is_debug=true"},
+		Priority:   1.2,
 		RepoState: RepoState{
 			Repo: DEFAULT_TEST_REPO,
 		},
-		Requested:     now,
+		Requested: now,
+		RetryPolicy: &RetryPolicy{
+			MaxAttempts:       3,
+			Backoff:           time.Minute,
+			BackoffMultiplier: 3,
+			MaxBackoff:        time.Hour,
+			RetryOn:           RETRY_ON_MISHAP,
+		},
 		Started:       now.Add(5 * time.Nanosecond),
 		Status:        JOB_STATUS_SUCCESS,
 		StatusDetails: "All tasks succeeded!",
@@ -203,6 +203,10 @@ export class JobSk extends ElementSk {
 
   private duration: string = '';
 
+  // Pushes status changes of the job and its tasks, so that we can reload
+  // only when something changes.
+  private events: EventSource | null = null;
+
   private isTryJob: boolean = false;
 
   private job: Job | null = null;
@@ -225,6 +229,7 @@ export class JobSk extends ElementSk {
 
   set jobID(jobID: string) {
     this.setAttribute('job-id', jobID);
+    this.subscribe();
     this.reload();
   }
 
@@ -248,9 +253,26 @@ export class JobSk extends ElementSk {
   connectedCallback() {
     super.connectedCallback();
     this.rpc = GetTaskSchedulerService(this);
+    this.subscribe();
     this.reload();
   }
 
+  disconnectedCallback() {
+    super.disconnectedCallback();
+    this.events?.close();
+    this.events = null;
+  }
+
+  private subscribe() {
+    this.events?.close();
+    this.events = null;
+    if (!this.jobID || !this._connected) {
+      return;
+    }
+    this.events = new EventSource(`/json/job/${encodeURIComponent(this.jobID)}/events`);
+    this.events.onmessage = () => this.reload();
+  }
+
   private updateFrom(job: Job) {
     this.job = job;
     const start = new Date(this.job.createdAt!);
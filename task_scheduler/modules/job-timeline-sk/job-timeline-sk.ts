@@ -25,7 +25,26 @@ function ts(tsStr: string) {
 }
 
 export class JobTimelineSk extends HTMLElement {
+  // Pushes status changes of the job and its tasks, so that we can redraw
+  // only when something changes.
+  private events: EventSource | null = null;
+
   connectedCallback() {
+    if (this.hasAttribute('job-id')) {
+      this.events = new EventSource(
+        `/json/job/${encodeURIComponent(this.getAttribute('job-id')!)}/events`
+      );
+      this.events.onmessage = () => this.reload();
+      this.reload();
+    }
+  }
+
+  disconnectedCallback() {
+    this.events?.close();
+    this.events = null;
+  }
+
+  private reload() {
     if (this.hasAttribute('job-id')) {
       const rpc = GetTaskSchedulerService(this);
       rpc
@@ -0,0 +1 @@
+import './load-shedding-sk';
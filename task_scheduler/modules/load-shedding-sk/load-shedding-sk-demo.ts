@@ -0,0 +1,29 @@
+import './index';
+import fetchMock from 'fetch-mock';
+import { LoadSheddingSk, LoadSheddingState } from './load-shedding-sk';
+import '../../../infra-sk/modules/theme-chooser-sk';
+
+const state: LoadSheddingState = {
+  mode: 'auto',
+  active: true,
+  active_reason: 'Backlog of 5230 task candidates is at least 5000.',
+  backlog: 5230,
+  suspended_classes: ['periodic', 'commit'],
+  suspended_candidates: 4120,
+  updated: '2023-06-01T12:00:31Z',
+};
+
+fetchMock.get('/json/load_shedding', state);
+fetchMock.post('/json/load_shedding', (_: string, opts: any) => {
+  const req = JSON.parse(opts.body);
+  return {
+    ...state,
+    mode: req.mode,
+    mode_reason: req.reason,
+    mode_set_by: 'user@google.com',
+    mode_updated: '2023-06-01T12:05:00Z',
+  };
+});
+
+// Add the element only after the fetch is mocked.
+document.body.appendChild(new LoadSheddingSk());
@@ -0,0 +1,123 @@
+/**
+ * @module modules/load-shedding-sk
+ * @description <h2><code>load-shedding-sk</code></h2>
+ *
+ * Displays whether the Task Scheduler is shedding load, ie. suspending
+ * low-priority classes of jobs to leave bot capacity for the commit queue, and
+ * allows the load shedding mode to be changed.
+ */
+import { html } from 'lit/html.js';
+import { define } from '../../../elements-sk/modules/define';
+import { errorMessage } from '../../../elements-sk/modules/errorMessage';
+import { ElementSk } from '../../../infra-sk/modules/ElementSk';
+import { jsonOrThrow } from '../../../infra-sk/modules/jsonOrThrow';
+
+export type LoadSheddingMode = 'auto' | 'on' | 'off';
+
+export interface LoadSheddingState {
+  mode: LoadSheddingMode;
+  mode_reason?: string;
+  mode_set_by?: string;
+  mode_updated?: string;
+  active: boolean;
+  active_reason?: string;
+  backlog: number;
+  suspended_classes: string[] | null;
+  suspended_candidates: number;
+  updated?: string;
+}
+
+const modes: [LoadSheddingMode, string][] = [
+  ['auto', 'Automatic, based on the backlog'],
+  ['on', 'On'],
+  ['off', 'Off'],
+];
+
+export class LoadSheddingSk extends ElementSk {
+  private static template = (ele: LoadSheddingSk) =>
+    ele.state
+      ? html`
+          <div class="status ${ele.state.active ? 'active' : 'inactive'}">
+            Load shedding is ${ele.state.active ? 'active' : 'inactive'}.
+            ${ele.state.active_reason || ''}
+          </div>
+          <div>
+            ${ele.state.active
+              ? html`Jobs of the following classes are suspended:
+                ${(ele.state.suspended_classes || []).join(', ') || 'none'}.
+                ${ele.state.suspended_candidates} task candidates were not scheduled.`
+              : html``}
+            Backlog: ${ele.state.backlog} eligible task candidates as of
+            ${ele.state.updated || 'never'}.
+          </div>
+          <div>
+            Mode: ${ele.state.mode}
+            ${ele.state.mode_set_by
+              ? html`(set by ${ele.state.mode_set_by}
+                at ${ele.state.mode_updated}${ele.state.mode_reason
+                  ? html`: ${ele.state.mode_reason}`
+                  : html``})`
+              : html``}
+          </div>
+          <div class="controls">
+            <select id="mode">
+              ${modes.map(
+                ([mode, label]) => html`
+                  <option value="${mode}" ?selected="${mode === ele.state!.mode}">${label}</option>
+                `
+              )}
+            </select>
+            <input id="reason" type="text" placeholder="Reason" />
+            <button @click="${() => ele.setMode()}">Set mode</button>
+          </div>
+        `
+      : html``;
+
+  private state: LoadSheddingState | null = null;
+
+  constructor() {
+    super(LoadSheddingSk.template);
+  }
+
+  connectedCallback() {
+    super.connectedCallback();
+    this._render();
+    this.load();
+  }
+
+  private load() {
+    this.dispatchEvent(new CustomEvent('begin-task', { bubbles: true }));
+    fetch('/json/load_shedding')
+      .then(jsonOrThrow)
+      .then((state: LoadSheddingState) => {
+        this.state = state;
+        this._render();
+      })
+      .catch(errorMessage)
+      .finally(() => {
+        this.dispatchEvent(new CustomEvent('end-task', { bubbles: true }));
+      });
+  }
+
+  private setMode() {
+    const mode = this.querySelector<HTMLSelectElement>('#mode')!.value;
+    const reason = this.querySelector<HTMLInputElement>('#reason')!.value;
+    this.dispatchEvent(new CustomEvent('begin-task', { bubbles: true }));
+    fetch('/json/load_shedding', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({ mode: mode, reason: reason }),
+    })
+      .then(jsonOrThrow)
+      .then((state: LoadSheddingState) => {
+        this.state = state;
+        this._render();
+      })
+      .catch(errorMessage)
+      .finally(() => {
+        this.dispatchEvent(new CustomEvent('end-task', { bubbles: true }));
+      });
+  }
+}
+
+define('load-shedding-sk', LoadSheddingSk);
@@ -393,6 +393,8 @@ export const skipRule1: SkipTaskRule = {
   commits: ['abc123'],
   description: 'Skip all test and perf tasks at abc123',
   name: 'No test/perf @ abc',
+  export: false,
+  importedFrom: '',
 };
 
 export const skipRule2: SkipTaskRule = {
@@ -400,6 +402,8 @@ export const skipRule2: SkipTaskRule = {
   commits: ['def456'],
   description: 'Skip everything at def456',
   name: 'def456 is bad',
+  export: true,
+  importedFrom: '',
 };
 
 export const skipRule3: SkipTaskRule = {
@@ -407,4 +411,6 @@ export const skipRule3: SkipTaskRule = {
   taskSpecPatterns: ['BadTask'],
   description: 'Skip all BadTasks at every commit',
   name: 'BadTask is bad!',
+  export: false,
+  importedFrom: 'task-scheduler-internal.skia.org',
 };
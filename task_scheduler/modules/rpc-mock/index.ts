@@ -151,6 +151,8 @@ export class FakeTaskSchedulerService implements TaskSchedulerService {
       commits: addSkipTaskRuleRequest.commits,
       description: addSkipTaskRuleRequest.description,
       name: addSkipTaskRuleRequest.name,
+      export: addSkipTaskRuleRequest.export,
+      importedFrom: '',
     });
     return Promise.resolve({ rules: this.skipRules.slice() });
   }
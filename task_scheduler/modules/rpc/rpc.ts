@@ -17,20 +17,31 @@ export enum JobStatus {
   JOB_STATUS_REQUESTED = "JOB_STATUS_REQUESTED",
 }
 
+export interface TriggerJob_ParametersEntry {
+  [key: string]: string;
+}
+
+interface TriggerJob_ParametersEntryJSON {
+  [key: string]: string;
+}
+
 export interface TriggerJob {
   jobName: string;
   commitHash: string;
+  parameters?: TriggerJob_ParametersEntry;
 }
 
 interface TriggerJobJSON {
   job_name?: string;
   commit_hash?: string;
+  parameters?: TriggerJob_ParametersEntryJSON;
 }
 
 const TriggerJobToJSON = (m: TriggerJob): TriggerJobJSON => {
   return {
     job_name: m.jobName,
     commit_hash: m.commitHash,
+    parameters: m.parameters,
   };
 };
 
@@ -330,6 +341,9 @@ export interface SkipTaskRule {
   commits?: string[];
   description: string;
   name: string;
+  expires?: string;
+  export: boolean;
+  importedFrom: string;
 }
 
 interface SkipTaskRuleJSON {
@@ -338,6 +352,9 @@ interface SkipTaskRuleJSON {
   commits?: string[];
   description?: string;
   name?: string;
+  expires?: string;
+  export?: boolean;
+  imported_from?: string;
 }
 
 const JSONToSkipTaskRule = (m: SkipTaskRuleJSON): SkipTaskRule => {
@@ -347,6 +364,9 @@ const JSONToSkipTaskRule = (m: SkipTaskRuleJSON): SkipTaskRule => {
     commits: m.commits,
     description: m.description || "",
     name: m.name || "",
+    expires: m.expires,
+    export: m.export || false,
+    importedFrom: m.imported_from || "",
   };
 };
 
@@ -369,6 +389,8 @@ export interface AddSkipTaskRuleRequest {
   commits?: string[];
   description: string;
   name: string;
+  expires?: string;
+  export: boolean;
 }
 
 interface AddSkipTaskRuleRequestJSON {
@@ -376,6 +398,8 @@ interface AddSkipTaskRuleRequestJSON {
   commits?: string[];
   description?: string;
   name?: string;
+  expires?: string;
+  export?: boolean;
 }
 
 const AddSkipTaskRuleRequestToJSON = (m: AddSkipTaskRuleRequest): AddSkipTaskRuleRequestJSON => {
@@ -384,6 +408,8 @@ const AddSkipTaskRuleRequestToJSON = (m: AddSkipTaskRuleRequest): AddSkipTaskRul
     commits: m.commits,
     description: m.description,
     name: m.name,
+    expires: m.expires,
+    export: m.export,
   };
 };
 
@@ -662,6 +688,14 @@ const JSONToTaskStats = (m: TaskStatsJSON): TaskStats => {
   };
 };
 
+export interface Job_ParametersEntry {
+  [key: string]: string;
+}
+
+interface Job_ParametersEntryJSON {
+  [key: string]: string;
+}
+
 export interface Job {
   buildbucketBuildId: string;
   buildbucketLeaseKey: string;
@@ -680,6 +714,7 @@ export interface Job {
   statusDetails: string;
   tasks?: TaskSummaries[];
   taskDimensions?: TaskDimensions[];
+  parameters?: Job_ParametersEntry;
 }
 
 interface JobJSON {
@@ -700,6 +735,7 @@ interface JobJSON {
   status_details?: string;
   tasks?: TaskSummariesJSON[];
   task_dimensions?: TaskDimensionsJSON[];
+  parameters?: Job_ParametersEntryJSON;
 }
 
 const JSONToJob = (m: JobJSON): Job => {
@@ -721,6 +757,7 @@ const JSONToJob = (m: JobJSON): Job => {
     statusDetails: m.status_details || "",
     tasks: m.tasks && m.tasks.map(JSONToTaskSummaries),
     taskDimensions: m.task_dimensions && m.task_dimensions.map(JSONToTaskDimensions),
+    parameters: m.parameters,
   };
 };
 
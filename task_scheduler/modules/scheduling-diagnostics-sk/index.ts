@@ -0,0 +1 @@
+import './scheduling-diagnostics-sk';
@@ -0,0 +1,60 @@
+import './index';
+import fetchMock from 'fetch-mock';
+import { CandidateExplanation, SchedulingDiagnosticsSk } from './scheduling-diagnostics-sk';
+import '../../../infra-sk/modules/theme-chooser-sk';
+
+const explanation: CandidateExplanation = {
+  diagnosticsPath: 'production/MainLoop/20230601T120030.000000000Z.json',
+  startTime: '2023-06-01T12:00:30Z',
+  endTime: '2023-06-01T12:00:31Z',
+  candidate: {
+    name: 'Test-Linux-Release',
+    repo: 'https://skia.googlesource.com/skia.git',
+    revision: 'abc123',
+    score: 1.2,
+    diagnostics: {
+      scoring: { priority: 0.5, testednessIncrease: 1.5, timeDecay: 0.8, taskSpecPriority: 1 },
+      scheduling: { matchingBots: ['skia-e-linux-001'], numHigherScoreSimilarCandidates: 1 },
+    },
+  },
+  rank: 2,
+  numCandidates: 2,
+  scoreComponents: [
+    {
+      name: 'Blamelist size',
+      value: 2,
+      description: 'Number of commits which would be tested by this task.',
+    },
+    {
+      name: 'Testedness increase',
+      value: 1.5,
+      description: 'Base score, derived from the blamelist size and the stolen commits.',
+    },
+    { name: 'Time decay', value: 0.8, description: 'Multiplier which favors newer commits.' },
+  ],
+  outcome: 'Not selected: 1 candidates with higher scores used all 1 matching free bots.',
+  beatenBy: [
+    {
+      repo: 'https://skia.googlesource.com/skia.git',
+      revision: 'def456',
+      name: 'Test-Linux-Debug',
+      issue: '',
+      patchset: '',
+      forcedJobId: '',
+      score: 3,
+      selected: true,
+      starved: false,
+    },
+  ],
+};
+
+fetchMock.get('glob:/json/diagnostics/candidate*', explanation);
+
+// Add the element only after the fetch is mocked and the URL identifies a
+// candidate.
+window.history.replaceState(
+  null,
+  '',
+  '?repo=https%3A%2F%2Fskia.googlesource.com%2Fskia.git&revision=abc123&name=Test-Linux-Release'
+);
+document.body.appendChild(new SchedulingDiagnosticsSk());
@@ -0,0 +1,185 @@
+/**
+ * @module modules/scheduling-diagnostics-sk
+ * @description <h2><code>scheduling-diagnostics-sk</code></h2>
+ *
+ * Explains how the Task Scheduler scored a task candidate and why it was or
+ * was not selected to run, using the diagnostics written by the scheduler. The
+ * candidate is identified by the query parameters of the page, which are
+ * reflected in the form.
+ */
+import { html } from 'lit/html.js';
+import { define } from '../../../elements-sk/modules/define';
+import { errorMessage } from '../../../elements-sk/modules/errorMessage';
+import { ElementSk } from '../../../infra-sk/modules/ElementSk';
+import { jsonOrThrow } from '../../../infra-sk/modules/jsonOrThrow';
+
+/** Query parameters which identify a candidate, with their labels. */
+const fields: [string, string][] = [
+  ['repo', 'Repo'],
+  ['revision', 'Revision'],
+  ['name', 'Task name'],
+  ['issue', 'Issue'],
+  ['patchset', 'Patchset'],
+  ['server', 'Gerrit server'],
+  ['patch_repo', 'Patch repo'],
+  ['forcedJobId', 'Forced job ID'],
+  ['time', 'Time (RFC3339, defaults to now)'],
+];
+
+export interface ScoreComponent {
+  name: string;
+  value: number;
+  description: string;
+}
+
+export interface CandidateSummary {
+  repo: string;
+  revision: string;
+  name: string;
+  issue: string;
+  patchset: string;
+  forcedJobId: string;
+  score: number;
+  selected: boolean;
+  starved: boolean;
+}
+
+export interface CandidateExplanation {
+  diagnosticsPath: string;
+  startTime: string;
+  endTime: string;
+  candidate: { [key: string]: any };
+  rank: number;
+  numCandidates: number;
+  scoreComponents: ScoreComponent[] | null;
+  outcome: string;
+  beatenBy?: CandidateSummary[];
+}
+
+export class SchedulingDiagnosticsSk extends ElementSk {
+  private static template = (ele: SchedulingDiagnosticsSk) => html`
+    <div class="grid">
+      ${fields.map(
+        ([key, label]) => html`
+          <label for="${key}">${label}</label>
+          <input
+            id="${key}"
+            type="text"
+            .value="${ele.params.get(key) || ''}"
+            @change="${(ev: Event) => {
+              ele.params.set(key, (ev.currentTarget as HTMLInputElement).value);
+            }}" />
+        `
+      )}
+    </div>
+    <button @click="${() => ele.submit()}">Explain</button>
+    ${ele.explanation ? SchedulingDiagnosticsSk.explanationTemplate(ele.explanation) : html``}
+  `;
+
+  private static explanationTemplate = (exp: CandidateExplanation) => html`
+    <h2>Outcome</h2>
+    <div class="outcome">${exp.outcome}</div>
+    <div>
+      Scheduling loop started at ${exp.startTime} and ended at ${exp.endTime}.
+      ${exp.rank
+        ? html`This candidate ranked ${exp.rank} of ${exp.numCandidates} scored candidates.`
+        : html``}
+    </div>
+    <div>Diagnostics: ${exp.diagnosticsPath}</div>
+
+    <h2>Score: ${exp.candidate.score}</h2>
+    <table class="score">
+      <tr>
+        <th>Component</th>
+        <th>Value</th>
+        <th>Meaning</th>
+      </tr>
+      ${(exp.scoreComponents || []).map(
+        (c: ScoreComponent) => html`
+          <tr>
+            <td>${c.name}</td>
+            <td>${c.value}</td>
+            <td class="description">${c.description}</td>
+          </tr>
+        `
+      )}
+    </table>
+
+    ${exp.beatenBy && exp.beatenBy.length > 0
+      ? html`
+          <h2>Higher-scoring candidates for the same bots</h2>
+          <table class="beaten-by">
+            <tr>
+              <th>Name</th>
+              <th>Revision</th>
+              <th>Issue</th>
+              <th>Score</th>
+              <th>Selected</th>
+            </tr>
+            ${exp.beatenBy.map(
+              (c: CandidateSummary) => html`
+                <tr>
+                  <td>${c.name}${c.starved ? ' (starved)' : ''}</td>
+                  <td>${c.revision}</td>
+                  <td>${c.issue ? `${c.issue}/${c.patchset}` : ''}</td>
+                  <td>${c.score}</td>
+                  <td>${c.selected ? 'yes' : 'no'}</td>
+                </tr>
+              `
+            )}
+          </table>
+        `
+      : html``}
+
+    <h2>Raw diagnostics</h2>
+    <pre>${JSON.stringify(exp.candidate.diagnostics, null, 2)}</pre>
+  `;
+
+  private params: URLSearchParams = new URLSearchParams(window.location.search);
+
+  private explanation: CandidateExplanation | null = null;
+
+  constructor() {
+    super(SchedulingDiagnosticsSk.template);
+  }
+
+  connectedCallback() {
+    super.connectedCallback();
+    this._render();
+    if (this.params.get('repo') && this.params.get('revision') && this.params.get('name')) {
+      this.load();
+    }
+  }
+
+  private submit() {
+    // Drop empty parameters so that the URL can be shared.
+    for (const [key] of fields) {
+      if (!this.params.get(key)) {
+        this.params.delete(key);
+      }
+    }
+    const url = `${window.location.origin + window.location.pathname}?${this.params.toString()}`;
+    window.history.pushState({ path: url }, '', url);
+    this.load();
+  }
+
+  private load() {
+    this.dispatchEvent(new CustomEvent('begin-task', { bubbles: true }));
+    fetch(`/json/diagnostics/candidate?${this.params.toString()}`)
+      .then(jsonOrThrow)
+      .then((exp: CandidateExplanation) => {
+        this.explanation = exp;
+        this._render();
+      })
+      .catch((err: any) => {
+        this.explanation = null;
+        this._render();
+        errorMessage(err);
+      })
+      .finally(() => {
+        this.dispatchEvent(new CustomEvent('end-task', { bubbles: true }));
+      });
+  }
+}
+
+define('scheduling-diagnostics-sk', SchedulingDiagnosticsSk);
@@ -33,6 +33,7 @@ export class SkipTasksSk extends ElementSk {
                 <th>TaskSpec Patterns</th>
                 <th>Commits</th>
                 <th>Description</th>
+                <th>Sync</th>
               </tr>
               ${ele.rules.map(
                 (rule) => html`
@@ -53,6 +54,13 @@ export class SkipTasksSk extends ElementSk {
                       ${rule.commits?.map((commit) => html` <div class="commit">${commit}</div> `)}
                     </td>
                     <td>${rule.description}</td>
+                    <td>
+                      ${rule.importedFrom
+                        ? `Imported from ${rule.importedFrom}`
+                        : rule.export
+                        ? 'Exported'
+                        : ''}
+                    </td>
                   </tr>
                 `
               )}
@@ -133,6 +141,14 @@ export class SkipTasksSk extends ElementSk {
             <textarea id="input-description" rows="5"></textarea>
           </td>
         </tr>
+        <tr>
+          <td>
+            <label for="export-checkbox">Sync to other Task Schedulers?</label>
+          </td>
+          <td>
+            <input type="checkbox" id="export-checkbox"></input>
+          </td>
+        </tr>
       </table>
       <button id="add-button" class="secondary-container-themes-sk" @click="${
         ele.addRule
@@ -175,6 +191,7 @@ export class SkipTasksSk extends ElementSk {
     const inputRangeEnd = $$<HTMLInputElement>('#input-range-end', this);
     const inputIsRange = $$<HTMLInputElement>('#range-checkbox', this)!;
     const inputTaskSpecs = $$<MultiInputSk>('#input-task-specs')!;
+    const inputExport = $$<HTMLInputElement>('#export-checkbox', this)!;
     const name = inputName.value;
     const description = inputDescription.value;
     const commitStart = inputRangeStart.value;
@@ -194,6 +211,7 @@ export class SkipTasksSk extends ElementSk {
       commits: commits,
       description: description,
       taskSpecPatterns: taskSpecs,
+      export: inputExport.checked,
     }).then((resp: AddSkipTaskRuleResponse) => {
       this.rules = resp.rules!;
       this._render();
@@ -204,6 +222,7 @@ export class SkipTasksSk extends ElementSk {
         inputRangeEnd.value = '';
       }
       inputIsRange.checked = false;
+      inputExport.checked = false;
       inputTaskSpecs.values = [];
     });
     $$<HTMLDialogElement>('dialog', this)!.close();
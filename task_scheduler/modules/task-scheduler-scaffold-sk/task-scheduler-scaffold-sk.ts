@@ -33,6 +33,7 @@ import '../../../elements-sk/modules/icons/help-icon-sk';
 import '../../../elements-sk/modules/icons/home-icon-sk';
 import '../../../elements-sk/modules/icons/search-icon-sk';
 import '../../../elements-sk/modules/icons/send-icon-sk';
+import '../../../elements-sk/modules/icons/timeline-icon-sk';
 import '../../../elements-sk/modules/spinner-sk';
 
 /**
@@ -67,6 +68,7 @@ export class TaskSchedulerScaffoldSk extends ElementSk {
           <a href="/trigger"> <send-icon-sk></send-icon-sk><span>Trigger Jobs</span> </a>
           <a href="/skip_tasks"> <block-icon-sk></block-icon-sk><span>Skip Tasks</span> </a>
           <a href="/jobs/search"> <search-icon-sk></search-icon-sk><span>Search Jobs</span> </a>
+          <a href="/diagnostics"> <timeline-icon-sk></timeline-icon-sk><span>Diagnostics</span> </a>
           <a href="https://skia.googlesource.com/buildbot/+/main/task_scheduler/README.md">
             <help-icon-sk></help-icon-sk><span>Docs</span>
           </a>
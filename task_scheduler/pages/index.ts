@@ -1 +1,2 @@
+import '../modules/load-shedding-sk';
 import '../modules/task-scheduler-scaffold-sk';
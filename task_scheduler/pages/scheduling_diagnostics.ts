@@ -0,0 +1,2 @@
+import '../modules/scheduling-diagnostics-sk';
+import '../modules/task-scheduler-scaffold-sk';